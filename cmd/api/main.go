@@ -2,24 +2,38 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/salesworks/s-works/api/internal/bootstrap"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/fabrics/handler"
+	"github.com/salesworks/s-works/api/internal/platform/blobstore"
 	"github.com/salesworks/s-works/api/internal/platform/database"
 	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/mail"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/otlplog"
+	"github.com/salesworks/s-works/api/internal/platform/readonly"
+	"github.com/salesworks/s-works/api/internal/platform/rebuild"
+	"github.com/salesworks/s-works/api/internal/platform/recording"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	userHandler "github.com/salesworks/s-works/api/internal/users/handler"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/metric"
+	"golang.org/x/text/language"
 )
 
 const version = "1.0.0"
@@ -33,25 +47,182 @@ type postgresConfig struct {
 
 type clerkConfig struct {
 	secretKey string
+	// signing secret of the webhook endpoint, the webhooks are refused without it
+	webhookSecret []byte
 }
 
 type natsConfig struct {
 	url string
+	// how long a consumed message may take to process
+	handlerTimeout time.Duration
+	// fraction of successfully handled messages that are logged
+	logSampleRate float64
+	// subjects whose events are published protobuf encoded instead of JSON
+	protobufSubjects []string
+	// event types published to the broker, the rest stay internal
+	publishAllowlist *messaging.EventAllowlist
+	// bounds on the consumption of ERP messages, off when zero
+	erpThrottle messaging.ThrottleConfig
+}
+
+// event types published to the broker unless NATS_PUBLISHED_EVENTS says otherwise. They are
+// listed one by one, so commercially sensitive ones such as price changes and event types
+// added later stay internal until listed.
+var defaultPublishedEvents = []string{
+	"app.fabric.created",
+	"app.fabric.updated",
+	"app.fabric.deleted",
+	"app.fabric.purged",
+	"app.fabric.restored",
+	"app.fabric.reactivated",
+	"app.fabric.merged",
+	"app.fabric.code_changed",
+	"app.fabric.activated",
+	"app.fabric.discontinued",
+	"app.fabric.archived",
+	"app.fabric.stock_adjusted",
+	"app.fabric.stock_reserved",
+	"app.fabric.stock_released",
+	"app.fabric.stock_reservation_expired",
+	"app.fabric.attachment_added",
+	"app.fabric.attachment_removed",
+	"app.fabric.certification_added",
+	"app.fabric.certification_expired",
+	"app.category.created",
+	"app.category.updated",
+	"app.category.deleted",
+	"app.category.fabric_assigned",
+	"app.category.fabric_unassigned",
+	"app.supplier.created",
+	"app.supplier.updated",
+	"app.supplier.deleted",
+	"app.supplier.fabric_linked",
+	"app.supplier.fabric_unlinked",
+	"app.customer.created",
+	"app.customer.updated",
+	"app.customer.deleted",
+	"app.customer.restored",
+	"app.order.created",
+	"app.order.lines_changed",
+	"app.order.confirmed",
+	"app.order.shipped",
+	"app.order.cancelled",
+	"app.product.created",
+	"app.product.updated",
+	"app.product.components_changed",
+	"app.product.deleted",
+	"app.product.restored",
+	"app.price_list.created",
+	"app.price_list.updated",
+	"app.price_list.prices_changed",
+	"app.price_list.published",
+	"app.warehouse.created",
+	"app.warehouse.updated",
+	"app.warehouse.deleted",
+	"app.warehouse.restored",
+	"app.color.created",
+	"app.color.updated",
+	"app.color.deleted",
+	"app.color.restored",
+	"app.catalog.snapshot_published",
+}
+
+type paginationConfig struct {
+	defaultPageSize int
+	maxPageSize     int
+	maxExportRows   int
+}
+
+// in-flight request limits per kind of endpoint, requests over a limit get 503
+type concurrencyConfig struct {
+	maxImports int
+	maxReads   int
+}
+
+// how long clients and shared caches may keep fabric listings, zero sends no caching headers
+type cacheConfig struct {
+	fabricListMaxAge time.Duration
+}
+
+// telemetry export; without a logs endpoint logs are only written to stdout
+type otelConfig struct {
+	serviceName string
+	// tells replicas apart, a random id unique to this process unless configured
+	instanceID   string
+	logsEndpoint string
+}
+
+// switches only accepted in development
+type devConfig struct {
+	authDisabled bool
+	userID       string
+	pprof        bool
+	erpSimulator bool
+}
+
+// digest email delivery; without an SMTP address mail is only logged
+type mailConfig struct {
+	smtp           mail.SMTPConfig
+	digestInterval time.Duration
+}
+
+// synthetic write path probe, disabled unless an interval is set; replicas running it
+// each need their own fabric code
+type probeConfig struct {
+	interval   time.Duration
+	timeout    time.Duration
+	fabricCode string
 }
 
 type config struct {
-	port     int
-	env      string
-	clerk    clerkConfig
-	postgres postgresConfig
-	nats     natsConfig
+	port        int
+	env         string
+	dev         devConfig
+	clerk       clerkConfig
+	postgres    postgresConfig
+	nats        natsConfig
+	pagination  paginationConfig
+	concurrency concurrencyConfig
+	cache       cacheConfig
+	erp         handler.ERPEventConfig
+	mail        mailConfig
+	otel        otelConfig
+	probe       probeConfig
+	// directory the files attached to fabrics are stored in, shared by all instances
+	attachmentDir string
+	// NBP table of the daily exchange rates fetched in the background, rates only come
+	// from ERP events when it is not set
+	exchangeRatesURL string
+	// start refusing commands, for an instance brought up while the database is restored
+	readOnly bool
+	// request/response pairs kept by the debug recorder once it is switched on
+	recordingBufferSize int
+	// bearer token of the admin routes, which are refused when it is not set
+	adminToken string
+	// scopes granted to the principals authenticated upstream, by user ID
+	scopeGrants httpx.ScopeGrants
+	// prefixed sequences the codes of articles created in the UI are allocated from
+	codeSequences domain.CodeSequences
+	// locale of the fabrics' own names and descriptions, served when no translation matches
+	baseLocale language.Tag
+	// how long a deleted fabric can still be restored, before an admin may purge it
+	purgeRetention time.Duration
+	// limits of the tenant served, whose data is all the database holds
+	tenantQuota domain.TenantQuota
 }
 
 type api struct {
-	config       config
-	logger       *slog.Logger
-	services     bootstrap.Services
-	repositories bootstrap.Repositories
+	config        config
+	logger        *slog.Logger
+	db            *sql.DB
+	nats          *nats.Conn
+	messageRouter *messaging.MessageRouter
+	consumers     handler.ConsumerStatuses
+	readOnly      *readonly.Mode
+	rebuilds      *rebuild.Tracker
+	recorder      *recording.Recorder
+	services      bootstrap.Services
+	repositories  bootstrap.Repositories
 }
 
 func main() {
@@ -65,8 +236,35 @@ func run() error {
 	setupOtelPropagator()
 	cfg := loadConfig()
 
-	logger := newLogger(cfg.env)
-	logger = logger.With("env", cfg.env, "component", "api")
+	telemetryResource := telemetry.NewResource(cfg.telemetryConfig())
+
+	// Spans carry the resource and give logs a trace to correlate with
+	tracerProvider := telemetry.SetupTracing(telemetryResource)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "shutdown error: %v\n", err)
+		}
+	}()
+
+	// Logs are exported next to metrics, the deferred shutdown ships the last lines
+	var logExporter *otlplog.Exporter
+	if cfg.otel.logsEndpoint != "" {
+		logExporter = otlplog.NewExporter(cfg.otel.logsEndpoint, telemetryResource)
+		logExporter.Start()
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := logExporter.Shutdown(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "shutdown error: %v\n", err)
+			}
+		}()
+	}
+
+	logger := newLogger(cfg.env, logExporter)
+	logger = logger.With("env", cfg.env, "instance", cfg.otel.instanceID, "component", "api")
+	slog.SetDefault(logger)
 
 	appCtx, stop := signal.NotifyContext(
 		context.Background(), syscall.SIGINT, syscall.SIGTERM,
@@ -95,27 +293,68 @@ func run() error {
 	}()
 	logger.Info("succesfully connected to postgres database")
 
-	natsConn, err := nats.Connect(cfg.nats.url)
+	// NATS is a soft dependency: while it is unreachable commands are still stored and their
+	// events wait in the outbox until the connection comes back
+	natsConn, err := nats.Connect(
+		cfg.nats.url,
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			logger.Warn("disconnected from NATS, running degraded", "error", err)
+		}),
+		nats.ReconnectHandler(func(*nats.Conn) {
+			logger.Info("reconnected to NATS server")
+		}),
+	)
 	if err != nil {
 		logger.Error("failed to connect to NATS", "error", err)
 		return fmt.Errorf("failed to connect to NATS: %w", err)
 	}
 	defer natsConn.Close()
-	logger.Info("successfully connected to NATS server")
+	if natsConn.IsConnected() {
+		logger.Info("successfully connected to NATS server")
+	} else {
+		logger.Warn("NATS server unreachable, starting degraded", "url", cfg.nats.url)
+	}
 
-	repositories := bootstrap.NewRepositories(postgres)
-	services := bootstrap.NewServices(repositories, natsConn, logger)
+	messagingConfig := cfg.messagingConfig()
+	container := bootstrap.NewContainer(
+		postgres, natsConn, messagingConfig, cfg.notificationConfig(logger),
+		bootstrap.CurrencyConfig{RatesURL: cfg.exchangeRatesURL}, cfg.tenantQuota,
+		blobstore.NewFileStore(cfg.attachmentDir), logger,
+	)
 
-	if _, err := setupMetrics(); err != nil {
+	if err := telemetry.SetupMetrics(telemetryResource); err != nil {
 		logger.Error("failed to setup metrics", "error", err)
 		return fmt.Errorf("failed to initialize metrics: %w", err)
 	}
 
+	readOnly := readonly.New(cfg.readOnly)
+	if cfg.readOnly {
+		logger.Warn("starting in read-only mode, commands are refused")
+	}
+
+	subscribers, err := NewSubscribers(
+		natsConn, container.Services, container.Repositories, cfg.erp, cfg.nats.handlerTimeout, messagingConfig.LogSampler,
+		cfg.nats.erpThrottle, cfg.probe, readOnly, logger,
+	)
+	if err != nil {
+		logger.Error("failed to set up NATS subscribers", "error", err)
+		return fmt.Errorf("failed to set up NATS subscribers: %w", err)
+	}
+
 	api := &api{
-		config:       cfg,
-		logger:       logger,
-		services:     services,
-		repositories: repositories,
+		config:        cfg,
+		logger:        logger,
+		db:            postgres.Pool,
+		nats:          natsConn,
+		messageRouter: subscribers.Router(),
+		consumers:     subscribers,
+		readOnly:      readOnly,
+		rebuilds:      rebuild.New(),
+		recorder:      recording.New(cfg.recordingBufferSize),
+		services:      container.Services,
+		repositories:  container.Repositories,
 	}
 
 	srv := &http.Server{
@@ -127,16 +366,15 @@ func run() error {
 		ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelError),
 	}
 
-	subscribers := NewSubscribers(natsConn, services, logger)
-	go subscribers.Start()
+	for _, hook := range subscribers.Hooks() {
+		container.Lifecycle.Append(hook)
+	}
+	container.Lifecycle.Append(httpServerHook(srv, logger, stop))
 
-	go func() {
-		logger.Info("starting server", "addr", srv.Addr)
-		if errSrv := srv.ListenAndServe(); errSrv != nil && errSrv != http.ErrServerClosed {
-			logger.Error("HTTP server ListenAndServe error", "error", errSrv)
-			stop()
-		}
-	}()
+	if err := container.Lifecycle.Start(startupCtx); err != nil {
+		logger.Error("failed to start components", "error", err)
+		return err
+	}
 
 	<-appCtx.Done()
 	logger.Info("shutdown initiated", "signal", "termination")
@@ -144,19 +382,31 @@ func run() error {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
 
-	var shutdownErr error
-
-	if err := srv.Shutdown(shutdownCtx); err != nil {
-		logger.Error("HTTP server shutdown error", "error", err)
-		shutdownErr = err
-	} else {
-		logger.Info("HTTP server gracefully stopped.")
-	}
+	shutdownErr := container.Lifecycle.Stop(shutdownCtx)
 
 	logger.Info("service exiting.")
 	return shutdownErr
 }
 
+// httpServerHook serves HTTP in the background. A server failing after start stops the
+// application through stop.
+func httpServerHook(srv *http.Server, logger *slog.Logger, stop context.CancelFunc) bootstrap.Hook {
+	return bootstrap.Hook{
+		Name: "HTTP server",
+		Start: func(context.Context) error {
+			go func() {
+				logger.Info("starting server", "addr", srv.Addr)
+				if errSrv := srv.ListenAndServe(); errSrv != nil && errSrv != http.ErrServerClosed {
+					logger.Error("HTTP server ListenAndServe error", "error", errSrv)
+					stop()
+				}
+			}()
+			return nil
+		},
+		Stop: srv.Shutdown,
+	}
+}
+
 func loadConfig() config {
 	var cfg config
 
@@ -185,6 +435,19 @@ func loadConfig() config {
 		cfg.env = "development"
 	}
 
+	cfg.dev.authDisabled = boolEnv("DEV_AUTH_DISABLED")
+	cfg.dev.pprof = boolEnv("DEV_PPROF")
+	cfg.dev.erpSimulator = boolEnv("DEV_ERP_SIMULATOR")
+	cfg.dev.userID = os.Getenv("DEV_USER_ID")
+	if cfg.dev.userID == "" {
+		cfg.dev.userID = "developer"
+	}
+	cfg.adminToken = os.Getenv("ADMIN_TOKEN")
+
+	if cfg.env != "development" && (cfg.dev.authDisabled || cfg.dev.pprof || cfg.dev.erpSimulator) {
+		panic("DEV_AUTH_DISABLED, DEV_PPROF and DEV_ERP_SIMULATOR are only allowed in development")
+	}
+
 	openConns := os.Getenv("POSTGRES_OPEN_CONNS")
 	if openConns == "" {
 		openConns = "25"
@@ -214,29 +477,299 @@ func loadConfig() config {
 		panic(fmt.Sprintf("invalid POSTGRES_IDLE_TIME env var: %v", err))
 	}
 	cfg.postgres.maxIdleTime = maxIdleTime
+
+	cfg.pagination.defaultPageSize = positiveIntEnv("PAGINATION_DEFAULT_PAGE_SIZE", 20)
+	cfg.pagination.maxPageSize = positiveIntEnv("PAGINATION_MAX_PAGE_SIZE", 100)
+	cfg.pagination.maxExportRows = positiveIntEnv("EXPORT_MAX_ROWS", 100000)
+	if cfg.pagination.defaultPageSize > cfg.pagination.maxPageSize {
+		panic("PAGINATION_DEFAULT_PAGE_SIZE must not exceed PAGINATION_MAX_PAGE_SIZE")
+	}
+
+	cfg.concurrency.maxImports = positiveIntEnv("CONCURRENCY_MAX_IMPORTS", 2)
+	cfg.concurrency.maxReads = positiveIntEnv("CONCURRENCY_MAX_READS", 100)
+
+	cfg.cache.fabricListMaxAge = durationEnv("CACHE_MAX_AGE_FABRIC_LIST")
+
+	conflictPolicy, err := handler.ParseConflictPolicy(os.Getenv("ERP_CONFLICT_POLICY"))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ERP_CONFLICT_POLICY env var: %v", err))
+	}
+	cfg.erp.ConflictPolicy = conflictPolicy
+
+	pendingTimeout := os.Getenv("ERP_PENDING_TIMEOUT")
+	if pendingTimeout == "" {
+		pendingTimeout = "15m"
+	}
+	cfg.erp.PendingTimeout, err = time.ParseDuration(pendingTimeout)
+	if err != nil {
+		panic(fmt.Sprintf("invalid ERP_PENDING_TIMEOUT env var: %v", err))
+	}
+
+	purgeRetention := os.Getenv("FABRIC_PURGE_RETENTION")
+	if purgeRetention == "" {
+		purgeRetention = "720h"
+	}
+	cfg.purgeRetention, err = time.ParseDuration(purgeRetention)
+	if err != nil || cfg.purgeRetention < 0 {
+		panic(fmt.Sprintf("invalid FABRIC_PURGE_RETENTION env var %q, expected a non-negative duration", purgeRetention))
+	}
+
+	cfg.tenantQuota.Tenant = os.Getenv("TENANT_ID")
+	if maxFabrics := os.Getenv("TENANT_MAX_FABRICS"); maxFabrics != "" {
+		cfg.tenantQuota.MaxFabrics, err = strconv.Atoi(maxFabrics)
+		if err != nil || cfg.tenantQuota.MaxFabrics < 0 {
+			panic("invalid TENANT_MAX_FABRICS env var: must be a non-negative integer")
+		}
+	}
+	if maxAttachmentBytes := os.Getenv("TENANT_MAX_ATTACHMENT_BYTES"); maxAttachmentBytes != "" {
+		cfg.tenantQuota.MaxAttachmentBytes, err = strconv.ParseInt(maxAttachmentBytes, 10, 64)
+		if err != nil || cfg.tenantQuota.MaxAttachmentBytes < 0 {
+			panic("invalid TENANT_MAX_ATTACHMENT_BYTES env var: must be a non-negative integer")
+		}
+	}
+
+	cfg.erp.DeadLetterSubject = os.Getenv("ERP_DEAD_LETTER_SUBJECT")
+	if cfg.erp.DeadLetterSubject == "" {
+		cfg.erp.DeadLetterSubject = "dlq.erp.fabric"
+	}
+
+	if minConfidence := os.Getenv("ERP_MIN_CONFIDENCE"); minConfidence != "" {
+		cfg.erp.MinConfidence, err = strconv.ParseFloat(minConfidence, 64)
+		if err != nil || cfg.erp.MinConfidence < 0 || cfg.erp.MinConfidence > 1 {
+			panic(fmt.Sprintf("invalid ERP_MIN_CONFIDENCE env var %q, expected a number between 0 and 1", minConfidence))
+		}
+	}
+
+	cfg.otel.serviceName = os.Getenv("OTEL_SERVICE_NAME")
+	if cfg.otel.serviceName == "" {
+		cfg.otel.serviceName = "s-works-api"
+	}
+	cfg.otel.instanceID = os.Getenv("OTEL_SERVICE_INSTANCE_ID")
+	if cfg.otel.instanceID == "" {
+		cfg.otel.instanceID = uuid.NewString()
+	}
+	// the signal specific endpoint is used as is, the generic one gets the logs path
+	// appended, as the OTLP exporter specification prescribes
+	cfg.otel.logsEndpoint = os.Getenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT")
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); cfg.otel.logsEndpoint == "" && endpoint != "" {
+		cfg.otel.logsEndpoint = strings.TrimSuffix(endpoint, "/") + "/v1/logs"
+	}
+
+	cfg.mail.smtp.Addr = os.Getenv("SMTP_ADDR")
+	cfg.mail.smtp.From = os.Getenv("SMTP_FROM")
+	cfg.mail.smtp.Username = os.Getenv("SMTP_USERNAME")
+	cfg.mail.smtp.Password = os.Getenv("SMTP_PASSWORD")
+	if cfg.mail.smtp.Addr != "" && cfg.mail.smtp.From == "" {
+		panic("SMTP_FROM environment variable must be set together with SMTP_ADDR")
+	}
+
+	cfg.clerk.webhookSecret, err = userHandler.ParseWebhookSecret(os.Getenv("CLERK_WEBHOOK_SECRET"))
+	if err != nil {
+		panic(fmt.Sprintf("invalid CLERK_WEBHOOK_SECRET env var: %v", err))
+	}
+
+	cfg.readOnly = boolEnv("READ_ONLY_MODE")
+	cfg.recordingBufferSize = positiveIntEnv("RECORDING_BUFFER_SIZE", 200)
+
+	cfg.codeSequences, err = domain.ParseCodeSequences(os.Getenv("FABRIC_CODE_SEQUENCES"))
+	if err != nil {
+		panic(fmt.Sprintf("invalid FABRIC_CODE_SEQUENCES env var: %v", err))
+	}
+
+	cfg.scopeGrants, err = httpx.ParseScopeGrants(os.Getenv("SCOPE_GRANTS"))
+	if err != nil {
+		panic(fmt.Sprintf("invalid SCOPE_GRANTS env var: %v", err))
+	}
+
+	cfg.baseLocale = language.English
+	if locale := os.Getenv("FABRIC_BASE_LOCALE"); locale != "" {
+		cfg.baseLocale, err = language.Parse(locale)
+		if err != nil {
+			panic(fmt.Sprintf("invalid FABRIC_BASE_LOCALE env var: %v", err))
+		}
+	}
+
+	cfg.attachmentDir = os.Getenv("ATTACHMENT_DIR")
+	if cfg.attachmentDir == "" {
+		cfg.attachmentDir = "./data/attachments"
+	}
+
+	cfg.exchangeRatesURL = os.Getenv("EXCHANGE_RATES_URL")
+	if cfg.exchangeRatesURL != "" {
+		ratesURL, err := url.Parse(cfg.exchangeRatesURL)
+		if err != nil || (ratesURL.Scheme != "http" && ratesURL.Scheme != "https") || ratesURL.Host == "" {
+			panic("invalid EXCHANGE_RATES_URL env var: must be an http(s) URL")
+		}
+	}
+
+	if probeInterval := os.Getenv("SYNTHETIC_PROBE_INTERVAL"); probeInterval != "" {
+		cfg.probe.interval, err = time.ParseDuration(probeInterval)
+		if err != nil || cfg.probe.interval <= 0 {
+			panic("invalid SYNTHETIC_PROBE_INTERVAL env var: must be a positive duration")
+		}
+	}
+	probeTimeout := os.Getenv("SYNTHETIC_PROBE_TIMEOUT")
+	if probeTimeout == "" {
+		probeTimeout = "10s"
+	}
+	cfg.probe.timeout, err = time.ParseDuration(probeTimeout)
+	if err != nil || cfg.probe.timeout <= 0 {
+		panic("invalid SYNTHETIC_PROBE_TIMEOUT env var: must be a positive duration")
+	}
+	cfg.probe.fabricCode = os.Getenv("SYNTHETIC_PROBE_FABRIC_CODE")
+	if cfg.probe.fabricCode == "" {
+		cfg.probe.fabricCode = domain.ProbeFabricCodePrefix
+	}
+
+	digestInterval := os.Getenv("NOTIFICATION_DIGEST_INTERVAL")
+	if digestInterval == "" {
+		digestInterval = "24h"
+	}
+	cfg.mail.digestInterval, err = time.ParseDuration(digestInterval)
+	if err != nil || cfg.mail.digestInterval <= 0 {
+		panic("invalid NOTIFICATION_DIGEST_INTERVAL env var: must be a positive duration")
+	}
+
+	handlerTimeout := os.Getenv("NATS_HANDLER_TIMEOUT")
+	if handlerTimeout == "" {
+		handlerTimeout = "30s"
+	}
+	cfg.nats.handlerTimeout, err = time.ParseDuration(handlerTimeout)
+	if err != nil || cfg.nats.handlerTimeout <= 0 {
+		panic("invalid NATS_HANDLER_TIMEOUT env var: must be a positive duration")
+	}
+
+	cfg.nats.logSampleRate = 1
+	if logSampleRate := os.Getenv("NATS_LOG_SAMPLE_RATE"); logSampleRate != "" {
+		cfg.nats.logSampleRate, err = strconv.ParseFloat(logSampleRate, 64)
+		if err != nil || cfg.nats.logSampleRate < 0 || cfg.nats.logSampleRate > 1 {
+			panic("invalid NATS_LOG_SAMPLE_RATE env var: must be between 0 and 1")
+		}
+	}
+
+	if maxPerSecond := os.Getenv("ERP_MAX_MESSAGES_PER_SECOND"); maxPerSecond != "" {
+		cfg.nats.erpThrottle.MaxPerSecond, err = strconv.ParseFloat(maxPerSecond, 64)
+		if err != nil || cfg.nats.erpThrottle.MaxPerSecond < 0 {
+			panic("invalid ERP_MAX_MESSAGES_PER_SECOND env var: must be a non-negative number")
+		}
+	}
+	if maxInFlight := os.Getenv("ERP_MAX_IN_FLIGHT"); maxInFlight != "" {
+		cfg.nats.erpThrottle.MaxInFlight, err = strconv.Atoi(maxInFlight)
+		if err != nil || cfg.nats.erpThrottle.MaxInFlight < 0 {
+			panic("invalid ERP_MAX_IN_FLIGHT env var: must be a non-negative integer")
+		}
+	}
+
+	for _, subject := range strings.Split(os.Getenv("NATS_PROTOBUF_SUBJECTS"), ",") {
+		if subject = strings.TrimSpace(subject); subject != "" {
+			cfg.nats.protobufSubjects = append(cfg.nats.protobufSubjects, subject)
+		}
+	}
+
+	allowlist := defaultPublishedEvents
+	if publishedEvents := os.Getenv("NATS_PUBLISHED_EVENTS"); publishedEvents != "" {
+		allowlist = nil
+		for _, eventType := range strings.Split(publishedEvents, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				allowlist = append(allowlist, eventType)
+			}
+		}
+	}
+	cfg.nats.publishAllowlist, err = messaging.NewEventAllowlist(allowlist...)
+	if err != nil {
+		panic("invalid NATS_PUBLISHED_EVENTS env var: " + err.Error())
+	}
 	return cfg
 }
 
-func newLogger(env string) *slog.Logger {
+func positiveIntEnv(key string, defaultValue int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 1 {
+		panic(fmt.Sprintf("invalid %s env var: must be a positive integer", key))
+	}
+	return value
+}
+
+// durationEnv reads a non-negative duration such as "30s", zero when not set
+func durationEnv(key string) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return 0
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil || value < 0 {
+		panic(fmt.Sprintf("invalid %s env var: must be a non-negative duration", key))
+	}
+	return value
+}
+
+func boolEnv(key string) bool {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return false
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s env var: must be a boolean", key))
+	}
+	return value
+}
+
+func (c config) paginationConfig() httpx.PaginationConfig {
+	return httpx.PaginationConfig{
+		DefaultPageSize: c.pagination.defaultPageSize,
+		MaxPageSize:     c.pagination.maxPageSize,
+		MaxExportRows:   c.pagination.maxExportRows,
+	}
+}
+
+func (c config) messagingConfig() bootstrap.MessagingConfig {
+	codecs := messaging.SubjectCodecs{}
+	for _, subject := range c.nats.protobufSubjects {
+		codecs[subject] = messaging.ProtobufCodec{}
+	}
+	return bootstrap.MessagingConfig{
+		Codecs:           codecs,
+		LogSampler:       messaging.NewLogSampler(c.nats.logSampleRate),
+		PublishAllowlist: c.nats.publishAllowlist,
+		Source:           messaging.Source{Service: c.otel.serviceName, Instance: c.otel.instanceID},
+	}
+}
+
+func (c config) notificationConfig(logger *slog.Logger) bootstrap.NotificationConfig {
+	var mailer mail.Mailer = mail.NewLogMailer(logger)
+	if c.mail.smtp.Addr != "" {
+		mailer = mail.NewSMTPMailer(c.mail.smtp)
+	}
+	return bootstrap.NotificationConfig{
+		Mailer:         mailer,
+		DigestInterval: c.mail.digestInterval,
+	}
+}
+
+// a nil exporter keeps logs local, they are still correlated with the active trace
+func newLogger(env string, exporter *otlplog.Exporter) *slog.Logger {
 	var handler slog.Handler
 	if env == "development" {
 		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
 	} else {
 		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})
 	}
-	return slog.New(handler)
+	return slog.New(otlplog.NewHandler(handler, exporter))
 }
 
-func setupMetrics() (*prometheus.Exporter, error) {
-	exporter, err := prometheus.New()
-	if err != nil {
-		return nil, fmt.Errorf("create prometheus exporter: %w", err)
+// identifies this process in every telemetry signal, so logs, metrics and traces group together
+func (c config) telemetryConfig() telemetry.Config {
+	return telemetry.Config{
+		ServiceName:    c.otel.serviceName,
+		ServiceVersion: version,
+		Environment:    c.env,
+		InstanceID:     c.otel.instanceID,
 	}
-
-	meterProvider := metric.NewMeterProvider(metric.WithReader(exporter))
-	otel.SetMeterProvider(meterProvider)
-
-	return exporter, nil
 }
 
 // global propagator for OpenTelemetry.
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig_PublishedEvents(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       string
+		published []string
+		withheld  []string
+	}{
+		{
+			name:      "default",
+			published: []string{"app.fabric.created", "app.fabric.deleted", "app.category.updated", "app.supplier.fabric_linked"},
+			withheld:  []string{"app.fabric.price_changed", "app.pricelist.published"},
+		},
+		{
+			name:      "configured",
+			env:       "app.fabric.*, app.category.created",
+			published: []string{"app.fabric.price_changed", "app.category.created"},
+			withheld:  []string{"app.category.deleted", "app.supplier.created"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			t.Setenv("NATS_URL", "nats://localhost:4222")
+			t.Setenv("POSTGRES_URI", "postgres://localhost:5432/sworks")
+			t.Setenv("NATS_PUBLISHED_EVENTS", tt.env)
+
+			// --- Act ---
+			cfg := loadConfig()
+
+			// --- Assert ---
+			for _, eventType := range tt.published {
+				assert.True(t, cfg.nats.publishAllowlist.Allows(eventType), eventType)
+			}
+			for _, eventType := range tt.withheld {
+				assert.False(t, cfg.nats.publishAllowlist.Allows(eventType), eventType)
+			}
+		})
+	}
+}
@@ -1,16 +1,47 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	categoryHandler "github.com/salesworks/s-works/api/internal/categories/handler"
+	colorHandler "github.com/salesworks/s-works/api/internal/colors/handler"
+	currencyHandler "github.com/salesworks/s-works/api/internal/currencies/handler"
+	customerHandler "github.com/salesworks/s-works/api/internal/customers/handler"
 	fabricHandler "github.com/salesworks/s-works/api/internal/fabrics/handler"
+	notificationHandler "github.com/salesworks/s-works/api/internal/notifications/handler"
+	orderHandler "github.com/salesworks/s-works/api/internal/orders/handler"
 	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/readonly"
+	"github.com/salesworks/s-works/api/internal/platform/rebuild"
+	priceListHandler "github.com/salesworks/s-works/api/internal/pricelists/handler"
+	productHandler "github.com/salesworks/s-works/api/internal/products/handler"
+	supplierDomain "github.com/salesworks/s-works/api/internal/suppliers/domain"
+	supplierHandler "github.com/salesworks/s-works/api/internal/suppliers/handler"
+	userHandler "github.com/salesworks/s-works/api/internal/users/handler"
+	warehouseHandler "github.com/salesworks/s-works/api/internal/warehouses/handler"
 )
 
+// how long a client shed by a concurrency limit is asked to wait before retrying
+const concurrencyRetryAfter = 2 * time.Second
+
 func (api *api) routes(metricsHandler http.Handler) http.Handler {
 	router := chi.NewRouter()
 
+	// Shared by every route of a kind, so a sync hitting several endpoints counts once
+	importLimiter := httpx.NewConcurrencyLimiter("imports", api.config.concurrency.maxImports, concurrencyRetryAfter)
+	readLimiter := httpx.NewConcurrencyLimiter("reads", api.config.concurrency.maxReads, concurrencyRetryAfter)
+
+	// Admin routes take the admin token, unless authentication is disabled in development
+	adminOnly := httpx.AdminMiddleware(api.config.adminToken)
+	if api.config.dev.authDisabled {
+		adminOnly = func(next http.Handler) http.Handler { return next }
+	}
+
 	// Apply panic recovery first to catch anything below it
 	router.Use(httpx.RecoverPanic(api.logger))
 
@@ -25,19 +56,520 @@ func (api *api) routes(metricsHandler http.Handler) http.Handler {
 		w.WriteHeader(http.StatusOK)
 	}))
 	router.Method(http.MethodGet, "/metrics", metricsHandler)
+	router.Method(http.MethodGet, "/healthz", api.healthHandler())
+	router.Method(http.MethodGet, "/readyz", httpx.NewReadinessHandler(api.rebuilds))
+
+	// --- Clerk Webhooks ---
+	// Clerk calls in directly, with no principal, so the webhooks carry their own signature
+	cwh := httpx.TraceHandler(userHandler.NewClerkWebhookHandler(
+		api.services.UserService, api.config.clerk.webhookSecret, api.services.Clock,
+	))
+	router.With(
+		httpx.ReadOnlyMiddleware(api.readOnly), httpx.TransactionMiddleware(api.db),
+	).Method(http.MethodPost, "/webhooks/clerk", cwh)
+
+	// --- Development Only ---
+	if api.config.dev.pprof {
+		router.Mount("/debug", middleware.Profiler())
+	}
+	if api.config.dev.erpSimulator {
+		esh := httpx.TraceHandler(fabricHandler.NewERPSimulatorHandler(
+			api.services.Publisher, api.services.Clock,
+		))
+		router.Method(http.MethodPost, "/dev/erp/fabrics", esh)
+	}
 
 	// --- V1 API Route Group (clerk middleware) ---
 	router.Route("/v1", func(r chi.Router) {
-		// --- Write Endpoint ---
-		fh := fabricHandler.NewFabricCommandHandler(api.services.FabricCommandService)
-		r.Method(http.MethodPost, "/fabrics", fh)
-		r.Method(http.MethodPut, "/fabrics/{code}", fh)
-		r.Method(http.MethodDelete, "/fabrics/{code}", fh)
-
-		// --- Read Endpoint ---
-		fqh := fabricHandler.NewFabricQueryHandler(api.repositories.FabricQueryRepository)
-		r.Method(http.MethodGet, "/fabrics/{code}", fqh)
+		// Inject the authenticated principal
+		if api.config.dev.authDisabled {
+			r.Use(httpx.DevPrincipalMiddleware(api.config.dev.userID, api.config.scopeGrants))
+		} else {
+			r.Use(httpx.PrincipalMiddleware(api.config.scopeGrants))
+		}
+
+		// Capture request/response pairs while the debug recorder is switched on
+		r.Use(httpx.RecordingMiddleware(api.recorder, api.services.Clock))
+
+		// Withhold the sensitive fields of fabrics from callers without the scope to see them,
+		// inside the recorder so captured responses are redacted too
+		r.Use(httpx.RedactionMiddleware(fabricHandler.FabricRedactionRules...))
+
+		// --- Read-Only Mode ---
+		// Outside the read-only group, so the mode can be switched off again
+		roh := httpx.TraceHandler(httpx.NewReadOnlyHandler(api.readOnly, api.services.Clock))
+		r.With(adminOnly).Method(http.MethodGet, "/admin/read-only", roh)
+		r.With(adminOnly).Method(http.MethodPut, "/admin/read-only", roh)
+
+		// --- Request Recording ---
+		// A debugging aid, it records on the instance it is switched on only
+		rech := httpx.TraceHandler(httpx.NewRecordingHandler(api.recorder, api.services.Clock))
+		r.With(adminOnly).Method(http.MethodGet, "/admin/recording", rech)
+		r.With(adminOnly).Method(http.MethodPut, "/admin/recording", rech)
+		r.With(adminOnly).Method(http.MethodGet, "/admin/recording/exchanges", rech)
+		r.With(adminOnly).Method(http.MethodDelete, "/admin/recording/exchanges", rech)
+
+		// --- Event Store Backup ---
+		// A logical backup of the whole event store, imported only into an empty store. An
+		// import is part of a restore, so it is accepted in read-only mode too
+		eah := httpx.TraceHandler(fabricHandler.NewEventArchiveHandler(
+			api.repositories.EventArchive, api.rebuilds, api.services.Clock,
+		))
+		r.With(adminOnly).Method(http.MethodGet, "/admin/events/export.ndjson", eah)
+		r.With(adminOnly).Method(http.MethodPost, "/admin/events/import", eah)
+
+		// --- Event Replay ---
+		// Re-delivers fabric events to the one subject a consumer recovers from, it only
+		// publishes and so is accepted in read-only mode too
+		erh := httpx.TraceHandler(fabricHandler.NewEventReplayHandler(
+			api.repositories.EventRange, api.services.Publisher, api.config.nats.publishAllowlist, api.services.Clock,
+		))
+		r.With(adminOnly).Method(http.MethodPost, "/admin/events/replay", erh)
+
+		// --- Pre-flight Validation ---
+		// Validation persists nothing, so a sync can pre-flight its batch in read-only mode too
+		fvh := httpx.TraceHandler(importLimiter.Limit(
+			fabricHandler.NewFabricValidateHandler(api.services.FabricValidationService),
+		))
+		r.Method(http.MethodPost, "/fabrics/validate", fvh)
+
+		// --- Supplier Portal ---
+		// Suppliers authenticate with the tokens issued to them, which replace the principal.
+		// Their proposals are queued for review, nothing is changed directly
+		r.Route("/portal", func(r chi.Router) {
+			r.Use(supplierHandler.SupplierTokenMiddleware(api.repositories.SupplierTokenRepository, api.services.Clock))
+
+			canRead := supplierHandler.RequireSupplierScope(supplierDomain.ScopeFabricsRead)
+			canPropose := supplierHandler.RequireSupplierScope(supplierDomain.ScopeFabricsPropose)
+
+			fpoh := httpx.TraceHandler(readLimiter.Limit(fabricHandler.NewFabricPortalHandler(
+				api.repositories.SupplierRepository,
+				api.repositories.FabricQueryRepository,
+				api.repositories.FabricProposalRepository,
+				api.services.Clock,
+				api.config.paginationConfig(),
+			)))
+			r.With(canRead).Method(http.MethodGet, "/fabrics", fpoh)
+			r.With(canRead).Method(http.MethodGet, "/fabrics/{code}", fpoh)
+			r.With(canPropose).Method(http.MethodGet, "/fabrics/{code}/proposals", fpoh)
+			r.With(
+				canPropose, httpx.ReadOnlyMiddleware(api.readOnly), httpx.TransactionMiddleware(api.db),
+			).Method(http.MethodPost, "/fabrics/{code}/proposals", fpoh)
+		})
+
+		r.Group(func(r chi.Router) {
+			// Refuse commands while the database is being restored, queries keep being served
+			r.Use(httpx.ReadOnlyMiddleware(api.readOnly))
+
+			// Imports commit record by record and report failures per record, so they stay
+			// outside the request transaction
+			fih := httpx.TraceHandler(importLimiter.Limit(
+				fabricHandler.NewFabricImportHandler(api.services.FabricCommandService),
+			))
+			r.Method(http.MethodPost, "/fabrics/import", fih)
+
+			// A season re-launch reactivates fabric by fabric and reports failures per item, so
+			// like imports it stays outside the request transaction
+			frah := httpx.TraceHandler(importLimiter.Limit(
+				fabricHandler.NewFabricReactivateHandler(api.services.FabricReactivateService),
+			))
+			r.Method(http.MethodPost, "/fabrics/reactivate-batch", frah)
+
+			// A scan reads the whole catalog, it queues what it finds as it goes
+			fdsh := httpx.TraceHandler(fabricHandler.NewFabricDuplicateScanHandler(api.services.DuplicateScanService))
+			r.With(adminOnly).Method(http.MethodPost, "/admin/fabrics/duplicates/scan", fdsh)
+
+			r.Group(func(r chi.Router) {
+				// Run each command in a request transaction
+				r.Use(httpx.TransactionMiddleware(api.db))
+
+				// --- Write Endpoint ---
+				fh := httpx.TraceHandler(fabricHandler.NewFabricCommandHandler(api.services.FabricCommandService))
+				r.Method(http.MethodPost, "/fabrics", fh)
+				r.Method(http.MethodPut, "/fabrics/{code}", fh)
+				r.Method(http.MethodDelete, "/fabrics/{code}", fh)
+
+				fmh := httpx.TraceHandler(fabricHandler.NewFabricMergeHandler(api.services.FabricMergeService))
+				r.Method(http.MethodPost, "/fabrics/{code}/merge", fmh)
+
+				frnh := httpx.TraceHandler(fabricHandler.NewFabricRenameHandler(api.services.FabricRenameService))
+				r.Method(http.MethodPost, "/fabrics/{code}/rename", frnh)
+
+				frh := httpx.TraceHandler(fabricHandler.NewFabricRestoreHandler(api.services.FabricRestoreService))
+				r.Method(http.MethodPost, "/fabrics/{code}/restore", frh)
+
+				flch := httpx.TraceHandler(fabricHandler.NewFabricLifecycleHandler(api.services.FabricLifecycleService))
+				r.Method(http.MethodPost, "/fabrics/{code}/lifecycle/{action}", flch)
+
+				fph := httpx.TraceHandler(fabricHandler.NewFabricPriceHandler(api.services.FabricPriceService))
+				r.Method(http.MethodPut, "/fabrics/{code}/price", fph)
+
+				fth := httpx.TraceHandler(fabricHandler.NewFabricTranslationHandler(api.services.FabricTranslationService))
+				r.Method(http.MethodPut, "/fabrics/{code}/translations/{locale}", fth)
+				r.Method(http.MethodDelete, "/fabrics/{code}/translations/{locale}", fth)
+
+				fcah := httpx.TraceHandler(fabricHandler.NewFabricCustomAttributeHandler(
+					api.repositories.FabricAttributeRepository, api.services.FabricAttributeService,
+				))
+				r.Method(http.MethodPut, "/fabrics/{code}/custom-attributes", fcah)
+
+				fadh := httpx.TraceHandler(fabricHandler.NewAttributeDefinitionHandler(
+					api.repositories.FabricAttributeRepository, api.services.Clock,
+				))
+				r.Method(http.MethodGet, "/fabric-attributes", fadh)
+
+				ffh := httpx.TraceHandler(fabricHandler.NewFibreHandler(api.repositories.FibreRepository, api.services.Clock))
+				r.Method(http.MethodGet, "/fibres", ffh)
+
+				fsh := httpx.TraceHandler(fabricHandler.NewFabricStockHandler(api.services.FabricStockService))
+				r.Method(http.MethodGet, "/fabrics/{code}/stock", fsh)
+				r.Method(http.MethodPost, "/fabrics/{code}/stock/{action}", fsh)
+
+				fath := httpx.TraceHandler(fabricHandler.NewFabricAttachmentHandler(api.services.FabricAttachmentService))
+				r.Method(http.MethodGet, "/fabrics/{code}/attachments", fath)
+				r.Method(http.MethodPost, "/fabrics/{code}/attachments", fath)
+				r.Method(http.MethodGet, "/fabrics/{code}/attachments/{id}", fath)
+				r.Method(http.MethodDelete, "/fabrics/{code}/attachments/{id}", fath)
+
+				fcerth := httpx.TraceHandler(fabricHandler.NewFabricCertificationHandler(api.services.CertificationService))
+				r.Method(http.MethodGet, "/fabrics/{code}/certifications", fcerth)
+				r.Method(http.MethodPost, "/fabrics/{code}/certifications", fcerth)
+
+				fah := httpx.TraceHandler(fabricHandler.NewFabricAliasHandler(
+					api.repositories.FabricAliasRepository, api.services.Clock,
+				))
+				r.Method(http.MethodGet, "/fabrics/{code}/aliases", fah)
+				r.Method(http.MethodPost, "/fabrics/{code}/aliases", fah)
+				r.Method(http.MethodDelete, "/fabrics/{code}/aliases/{alias}", fah)
+
+				fbh := httpx.TraceHandler(fabricHandler.NewFabricBarcodeHandler(
+					api.repositories.FabricBarcodeRepository, api.services.Clock,
+				))
+				r.Method(http.MethodGet, "/fabrics/{code}/barcodes", fbh)
+				r.Method(http.MethodPost, "/fabrics/{code}/barcodes", fbh)
+				r.Method(http.MethodDelete, "/fabrics/{code}/barcodes/{barcode}", fbh)
+
+				flkh := httpx.TraceHandler(fabricHandler.NewFabricLockHandler(
+					api.repositories.FabricLockRepository, api.services.Clock,
+				))
+				r.Method(http.MethodPost, "/fabrics/{code}/lock", flkh)
+				r.Method(http.MethodDelete, "/fabrics/{code}/lock", flkh)
+
+				fcdh := httpx.TraceHandler(fabricHandler.NewFabricCodeHandler(
+					api.repositories.FabricCodeRepository, api.config.codeSequences, api.services.Clock,
+				))
+				r.Method(http.MethodGet, "/fabric-codes", fcdh)
+				r.Method(http.MethodPost, "/fabric-codes", fcdh)
+
+				fdh := httpx.TraceHandler(fabricHandler.NewFabricDraftHandler(
+					api.repositories.FabricDraftRepository, api.services.FabricCommandService, api.services.Clock,
+				))
+				r.Method(http.MethodGet, "/fabrics/{code}/drafts", fdh)
+				r.Method(http.MethodPost, "/fabrics/{code}/drafts", fdh)
+				r.Method(http.MethodPost, "/fabrics/{code}/drafts/{id}/{action}", fdh)
+
+				// --- Read Endpoint ---
+				// a fabric carries its edit lock and the supplier terms the caller may see, so
+				// only the caller's own cache keeps it, revalidating it on every use
+				fabricQuery := readLimiter.Limit(httpx.Revalidate(
+					fabricHandler.NewFabricQueryHandler(
+						api.repositories.FabricQueryRepository,
+						api.repositories.FabricLockRepository,
+						api.repositories.FabricAttachmentRepository,
+						api.repositories.SupplierRepository,
+						api.services.Clock,
+						api.config.baseLocale,
+					),
+				))
+				fqh := httpx.TraceHandler(fabricQuery)
+				r.Method(http.MethodGet, "/fabrics/{code}", fqh)
+
+				// Warehouse scanners resolve a fabric by the barcode on its bolt
+				fblh := httpx.TraceHandler(fabricHandler.NewFabricBarcodeLookupHandler(
+					api.repositories.FabricBarcodeRepository, fabricQuery,
+				))
+				r.Method(http.MethodGet, "/fabrics/by-barcode/{ean}", fblh)
+
+				flh := httpx.TraceHandler(readLimiter.Limit(httpx.CacheControl(api.config.cache.fabricListMaxAge,
+					fabricHandler.NewFabricListHandler(
+						api.repositories.FabricListRepository, api.config.paginationConfig(), httpx.DefaultQueryCostLimits,
+					),
+				)))
+				r.Method(http.MethodGet, "/fabrics", flh)
+
+				fsrh := httpx.TraceHandler(readLimiter.Limit(fabricHandler.NewFabricSearchHandler(
+					api.repositories.FabricSearchRepository, api.config.paginationConfig(), httpx.DefaultQueryCostLimits,
+				)))
+				r.Method(http.MethodGet, "/fabrics/search", fsrh)
+
+				feh := httpx.TraceHandler(readLimiter.Limit(fabricHandler.NewFabricExportHandler(
+					api.repositories.FabricExportRepository, api.config.paginationConfig(),
+				)))
+				r.Method(http.MethodGet, "/fabrics/export", feh)
+				r.Method(http.MethodGet, "/fabrics/export.ndjson", feh)
+
+				// the change feed and the history read the event store, incomplete while an
+				// import restores it
+				fch := httpx.TraceHandler(readLimiter.Limit(fabricHandler.NewFabricChangesHandler(
+					api.repositories.FabricChangeFeed, api.config.paginationConfig(),
+				)))
+				r.With(httpx.RebuildMiddleware(api.rebuilds, "GET /v1/fabrics/changes", rebuild.EventStore)).
+					Method(http.MethodGet, "/fabrics/changes", fch)
+
+				fhh := httpx.TraceHandler(readLimiter.Limit(fabricHandler.NewFabricHistoryHandler(
+					api.repositories.FabricHistory,
+				)))
+				r.With(httpx.RebuildMiddleware(api.rebuilds, "GET /v1/fabrics/{code}/history", rebuild.EventStore)).
+					Method(http.MethodGet, "/fabrics/{code}/history", fhh)
+
+				// --- Categories ---
+				cth := httpx.TraceHandler(categoryHandler.NewCategoryCommandHandler(api.services.CategoryService))
+				r.Method(http.MethodPost, "/categories", cth)
+				r.Method(http.MethodPut, "/categories/{code}", cth)
+				r.Method(http.MethodDelete, "/categories/{code}", cth)
+				r.Method(http.MethodPut, "/categories/{code}/fabrics/{fabricCode}", cth)
+				r.Method(http.MethodDelete, "/categories/{code}/fabrics/{fabricCode}", cth)
+
+				cqh := httpx.TraceHandler(readLimiter.Limit(categoryHandler.NewCategoryQueryHandler(
+					api.repositories.CategoryRepository, api.config.paginationConfig(),
+				)))
+				r.Method(http.MethodGet, "/categories", cqh)
+				r.Method(http.MethodGet, "/categories/{code}", cqh)
+				r.Method(http.MethodGet, "/categories/{code}/fabrics", cqh)
+				r.Method(http.MethodGet, "/fabrics/{fabricCode}/categories", cqh)
+
+				// --- Suppliers ---
+				sch := httpx.TraceHandler(supplierHandler.NewSupplierCommandHandler(api.services.SupplierService))
+				r.Method(http.MethodPost, "/suppliers", sch)
+				r.Method(http.MethodPut, "/suppliers/{code}", sch)
+				r.Method(http.MethodDelete, "/suppliers/{code}", sch)
+				r.Method(http.MethodPut, "/suppliers/{code}/fabrics/{fabricCode}", sch)
+				r.Method(http.MethodDelete, "/suppliers/{code}/fabrics/{fabricCode}", sch)
+
+				sqh := httpx.TraceHandler(readLimiter.Limit(supplierHandler.NewSupplierQueryHandler(
+					api.repositories.SupplierRepository, api.config.paginationConfig(),
+				)))
+				r.Method(http.MethodGet, "/suppliers", sqh)
+				r.Method(http.MethodGet, "/suppliers/{code}", sqh)
+
+				// --- Customers ---
+				custch := httpx.TraceHandler(customerHandler.NewCustomerCommandHandler(api.services.CustomerService))
+				r.Method(http.MethodPost, "/customers", custch)
+				r.Method(http.MethodPut, "/customers/{code}", custch)
+				r.Method(http.MethodDelete, "/customers/{code}", custch)
+				r.Method(http.MethodPost, "/customers/{code}/restore", custch)
+
+				custqh := httpx.TraceHandler(readLimiter.Limit(customerHandler.NewCustomerQueryHandler(
+					api.repositories.CustomerRepository, api.config.paginationConfig(),
+				)))
+				r.Method(http.MethodGet, "/customers", custqh)
+				r.Method(http.MethodGet, "/customers/{code}", custqh)
+
+				// --- Orders ---
+				och := httpx.TraceHandler(orderHandler.NewOrderCommandHandler(api.services.OrderService))
+				r.Method(http.MethodPost, "/orders", och)
+				r.Method(http.MethodPut, "/orders/{id}/lines", och)
+				r.Method(http.MethodPost, "/orders/{id}/{action}", och)
+
+				oqh := httpx.TraceHandler(readLimiter.Limit(orderHandler.NewOrderQueryHandler(
+					api.repositories.OrderRepository, api.repositories.ShipmentRepository, api.config.paginationConfig(),
+				)))
+				r.Method(http.MethodGet, "/orders", oqh)
+				r.Method(http.MethodGet, "/orders/{id}", oqh)
+				r.Method(http.MethodGet, "/orders/{id}/shipments", oqh)
+
+				// --- Products ---
+				pch := httpx.TraceHandler(productHandler.NewProductCommandHandler(api.services.ProductService))
+				r.Method(http.MethodPost, "/products", pch)
+				r.Method(http.MethodPut, "/products/{code}", pch)
+				r.Method(http.MethodDelete, "/products/{code}", pch)
+				r.Method(http.MethodPut, "/products/{code}/components", pch)
+				r.Method(http.MethodPost, "/products/{code}/restore", pch)
+
+				pqh := httpx.TraceHandler(readLimiter.Limit(productHandler.NewProductQueryHandler(
+					api.repositories.ProductRepository, api.config.paginationConfig(),
+				)))
+				r.Method(http.MethodGet, "/products", pqh)
+				r.Method(http.MethodGet, "/products/{code}", pqh)
+
+				// --- Price lists ---
+				plch := httpx.TraceHandler(priceListHandler.NewPriceListCommandHandler(api.services.PriceListService))
+				r.Method(http.MethodPost, "/price-lists", plch)
+				r.Method(http.MethodPut, "/price-lists/{code}", plch)
+				r.Method(http.MethodPut, "/price-lists/{code}/prices", plch)
+				r.Method(http.MethodPost, "/price-lists/{code}/publish", plch)
+
+				plqh := httpx.TraceHandler(readLimiter.Limit(priceListHandler.NewPriceListQueryHandler(
+					api.repositories.PriceListRepository, api.config.paginationConfig(),
+				)))
+				r.Method(http.MethodGet, "/price-lists", plqh)
+				r.Method(http.MethodGet, "/price-lists/{code}", plqh)
+
+				plrh := httpx.TraceHandler(readLimiter.Limit(priceListHandler.NewPriceResolutionHandler(
+					api.repositories.PriceListRepository, api.services.ExchangeRateService, api.services.Clock,
+				)))
+				r.Method(http.MethodGet, "/price-lists/resolve", plrh)
+
+				// --- Exchange rates ---
+				erqh := httpx.TraceHandler(readLimiter.Limit(currencyHandler.NewExchangeRateQueryHandler(
+					api.services.ExchangeRateService, api.services.Clock,
+				)))
+				r.Method(http.MethodGet, "/exchange-rates", erqh)
+				r.Method(http.MethodGet, "/exchange-rates/convert", erqh)
+
+				// --- Warehouses ---
+				whch := httpx.TraceHandler(warehouseHandler.NewWarehouseCommandHandler(api.services.WarehouseService))
+				r.Method(http.MethodPost, "/warehouses", whch)
+				r.Method(http.MethodPut, "/warehouses/{code}", whch)
+				r.Method(http.MethodDelete, "/warehouses/{code}", whch)
+				r.Method(http.MethodPost, "/warehouses/{code}/restore", whch)
+
+				whqh := httpx.TraceHandler(readLimiter.Limit(warehouseHandler.NewWarehouseQueryHandler(
+					api.repositories.WarehouseRepository, api.config.paginationConfig(),
+				)))
+				r.Method(http.MethodGet, "/warehouses", whqh)
+				r.Method(http.MethodGet, "/warehouses/{code}", whqh)
+				r.Method(http.MethodGet, "/warehouses/{code}/stock", whqh)
+
+				// --- Colors ---
+				coch := httpx.TraceHandler(colorHandler.NewColorCommandHandler(api.services.ColorService))
+				r.Method(http.MethodPost, "/colors", coch)
+				r.Method(http.MethodPut, "/colors/{code}", coch)
+				r.Method(http.MethodDelete, "/colors/{code}", coch)
+				r.Method(http.MethodPost, "/colors/{code}/restore", coch)
+
+				coqh := httpx.TraceHandler(readLimiter.Limit(colorHandler.NewColorQueryHandler(
+					api.repositories.ColorRepository, api.config.paginationConfig(),
+				)))
+				r.Method(http.MethodGet, "/colors", coqh)
+				r.Method(http.MethodGet, "/colors/{code}", coqh)
+
+				// --- Users ---
+				uqh := httpx.TraceHandler(userHandler.NewUserQueryHandler(api.repositories.UserRepository))
+				r.Method(http.MethodGet, "/users/me", uqh)
+
+				// --- Tenant ---
+				tuh := httpx.TraceHandler(fabricHandler.NewTenantUsageHandler(api.services.TenantQuotaService))
+				r.Method(http.MethodGet, "/tenant/usage", tuh)
+
+				// --- ERP Conflict Review ---
+				fcrh := httpx.TraceHandler(fabricHandler.NewFabricConflictHandler(
+					api.repositories.FabricConflictRepository,
+					api.services.FabricCommandService,
+					api.services.Clock,
+					api.config.paginationConfig(),
+				))
+				r.Method(http.MethodGet, "/erp/conflicts", fcrh)
+				// resolving a conflict overwrites the fabric with either side, which only admins decide
+				r.With(adminOnly).Method(http.MethodPost, "/erp/conflicts/{id}/resolve", fcrh)
+
+				// --- Proposal Review ---
+				fprh := httpx.TraceHandler(fabricHandler.NewFabricProposalHandler(
+					api.repositories.FabricProposalRepository,
+					api.services.FabricCommandService,
+					api.services.Clock,
+					api.config.paginationConfig(),
+				))
+				r.Method(http.MethodGet, "/fabrics/proposals", fprh)
+				r.Method(http.MethodPost, "/fabrics/proposals/{id}/{action}", fprh)
+
+				// --- Duplicate Review ---
+				fduh := httpx.TraceHandler(fabricHandler.NewFabricDuplicateHandler(
+					api.repositories.FabricDuplicateRepository,
+					api.services.FabricMergeService,
+					api.services.Clock,
+					api.config.paginationConfig(),
+				))
+				r.Method(http.MethodGet, "/fabrics/duplicates", fduh)
+				// resolving a duplicate may merge two fabrics for good, which only admins decide
+				r.With(adminOnly).Method(http.MethodPost, "/fabrics/duplicates/{id}/resolve", fduh)
+			})
+
+			r.Group(func(r chi.Router) {
+				// Admin commands are authorized before their request transaction is opened
+				r.Use(adminOnly)
+				r.Use(httpx.TransactionMiddleware(api.db))
+
+				// --- Deleted Fabrics ---
+				fpuh := httpx.TraceHandler(fabricHandler.NewFabricPurgeHandler(
+					api.repositories.FabricListRepository,
+					api.services.FabricPurgeService,
+					api.config.purgeRetention,
+					api.config.paginationConfig(),
+				))
+				r.Method(http.MethodGet, "/admin/fabrics/deleted", fpuh)
+				r.Method(http.MethodPost, "/admin/fabrics/{code}/purge", fpuh)
+
+				// --- Outbox Administration ---
+				eoh := httpx.TraceHandler(fabricHandler.NewEventOutboxHandler(api.repositories.EventOutbox))
+				r.Method(http.MethodPost, "/admin/outbox/{eventID}/redispatch", eoh)
+
+				// --- Messaging Administration ---
+				mrh := httpx.TraceHandler(fabricHandler.NewMessageRouteHandler(api.messageRouter))
+				r.Method(http.MethodGet, "/admin/messaging/routes", mrh)
+
+				ch := httpx.TraceHandler(fabricHandler.NewConsumerHandler(api.consumers))
+				r.Method(http.MethodGet, "/admin/consumers", ch)
+
+				// --- Notification Administration ---
+				nsh := httpx.TraceHandler(notificationHandler.NewSubscriptionHandler(
+					api.repositories.SubscriptionRepository, api.services.Clock,
+				))
+				r.Method(http.MethodGet, "/admin/notifications/subscriptions", nsh)
+				r.Method(http.MethodPut, "/admin/notifications/subscriptions", nsh)
+				r.Method(http.MethodDelete, "/admin/notifications/subscriptions/{id}", nsh)
+
+				nwh := httpx.TraceHandler(notificationHandler.NewWebhookHandler(
+					api.repositories.WebhookRepository, api.services.Clock,
+				))
+				r.Method(http.MethodGet, "/admin/notifications/webhooks", nwh)
+				r.Method(http.MethodPut, "/admin/notifications/webhooks", nwh)
+				r.Method(http.MethodDelete, "/admin/notifications/webhooks/{id}", nwh)
+
+				// --- Supplier Portal Tokens ---
+				sth := httpx.TraceHandler(supplierHandler.NewSupplierTokenHandler(
+					api.repositories.SupplierTokenRepository, api.repositories.SupplierRepository, api.services.Clock,
+				))
+				r.Method(http.MethodGet, "/admin/suppliers/{code}/tokens", sth)
+				r.Method(http.MethodPost, "/admin/suppliers/{code}/tokens", sth)
+				r.Method(http.MethodDelete, "/admin/suppliers/{code}/tokens/{id}", sth)
+
+				// --- Custom Attribute Registry ---
+				fadh := httpx.TraceHandler(fabricHandler.NewAttributeDefinitionHandler(
+					api.repositories.FabricAttributeRepository, api.services.Clock,
+				))
+				r.Method(http.MethodPut, "/admin/fabric-attributes/{key}", fadh)
+				r.Method(http.MethodDelete, "/admin/fabric-attributes/{key}", fadh)
+
+				// --- Fibre Reference List ---
+				ffh := httpx.TraceHandler(fabricHandler.NewFibreHandler(api.repositories.FibreRepository, api.services.Clock))
+				r.Method(http.MethodPut, "/admin/fibres/{code}", ffh)
+				r.Method(http.MethodDelete, "/admin/fibres/{code}", ffh)
+			})
+		})
 	})
 
 	return router
 }
+
+// the database is required to serve anything, the broker only to publish events; read-only
+// mode degrades the service as commands are refused
+func (api *api) healthHandler() http.Handler {
+	return httpx.NewHealthHandler(
+		httpx.HealthCheck{Name: "postgres", Critical: true, Probe: func(ctx context.Context) error {
+			return api.db.PingContext(ctx)
+		}},
+		httpx.HealthCheck{Name: "nats", Probe: func(context.Context) error {
+			if api.nats == nil || !api.nats.IsConnected() {
+				return errors.New("not connected")
+			}
+			return nil
+		}},
+		httpx.HealthCheck{Name: "writes", Probe: func(context.Context) error {
+			if api.readOnly != nil && api.readOnly.Enabled() {
+				return readonly.ErrReadOnly
+			}
+			return nil
+		}},
+	)
+}
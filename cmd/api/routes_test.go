@@ -0,0 +1,159 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/bootstrap"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/readonly"
+	"github.com/salesworks/s-works/api/internal/platform/rebuild"
+	"github.com/salesworks/s-works/api/internal/platform/recording"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveRoute(t *testing.T, cfg config, method, path string) int {
+	t.Helper()
+	return serveRequest(t, cfg, httptest.NewRequest(method, path, nil))
+}
+
+func serveRequest(t *testing.T, cfg config, req *http.Request) int {
+	t.Helper()
+
+	api := &api{
+		config:   cfg,
+		logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		readOnly: readonly.New(cfg.readOnly),
+		rebuilds: rebuild.New(),
+		recorder: recording.New(1),
+		services: bootstrap.Services{Clock: clock.New()},
+	}
+	router := api.routes(http.NotFoundHandler())
+
+	responseRecorder := httptest.NewRecorder()
+	router.ServeHTTP(responseRecorder, req)
+	return responseRecorder.Code
+}
+
+func TestRoutes_DevelopmentToggles(t *testing.T) {
+	tests := []struct {
+		name           string
+		dev            devConfig
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "pprof off", method: http.MethodGet, path: "/debug/pprof/", expectedStatus: http.StatusNotFound},
+		{
+			name: "pprof on", dev: devConfig{pprof: true},
+			method: http.MethodGet, path: "/debug/pprof/", expectedStatus: http.StatusOK,
+		},
+		{name: "simulator off", method: http.MethodPost, path: "/dev/erp/fabrics", expectedStatus: http.StatusNotFound},
+		{
+			name: "simulator on", dev: devConfig{erpSimulator: true},
+			method: http.MethodPost, path: "/dev/erp/fabrics", expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			cfg := config{env: "development", dev: tt.dev}
+
+			// --- Act ---
+			status := serveRoute(t, cfg, tt.method, tt.path)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, status)
+		})
+	}
+}
+
+func TestRoutes_ReadOnlyMode(t *testing.T) {
+	// --- Arrange ---
+	cfg := config{env: "development", dev: devConfig{authDisabled: true, userID: "developer"}, readOnly: true}
+
+	// --- Act ---
+	commandStatus := serveRoute(t, cfg, http.MethodPost, "/v1/fabrics")
+	importStatus := serveRoute(t, cfg, http.MethodPost, "/v1/fabrics/import")
+	toggleStatus := serveRoute(t, cfg, http.MethodGet, "/v1/admin/read-only")
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusServiceUnavailable, commandStatus)
+	assert.Equal(t, http.StatusServiceUnavailable, importStatus)
+	assert.Equal(t, http.StatusOK, toggleStatus, "the mode must stay reachable to be switched off")
+}
+
+func TestRoutes_AdminAuthorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            config
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "no admin token configured", cfg: config{env: "production"}, expectedStatus: http.StatusForbidden},
+		{
+			name: "principal without admin token", cfg: config{env: "production", adminToken: "s3cret"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "admin token", cfg: config{env: "production", adminToken: "s3cret"},
+			authorization: "Bearer s3cret", expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "authentication disabled in development",
+			cfg:            config{env: "development", dev: devConfig{authDisabled: true, userID: "developer"}},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/read-only", nil)
+			req.Header.Set("X-User-ID", "user_123")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			// --- Act ---
+			status := serveRequest(t, tt.cfg, req)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, status)
+		})
+	}
+}
+
+func TestRoutes_InstanceAdministrationTakesAdminToken(t *testing.T) {
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{method: http.MethodGet, path: "/v1/admin/events/export.ndjson"},
+		{method: http.MethodPost, path: "/v1/admin/events/import"},
+		{method: http.MethodPost, path: "/v1/admin/outbox/0190b0a0-0000-7000-8000-000000000001/redispatch"},
+		{method: http.MethodPut, path: "/v1/admin/recording"},
+		{method: http.MethodGet, path: "/v1/admin/recording/exchanges"},
+		{method: http.MethodGet, path: "/v1/admin/consumers"},
+	}
+
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			// --- Arrange ---
+			cfg := config{env: "production", adminToken: "s3cret"}
+			req := httptest.NewRequest(route.method, route.path, nil)
+			req.Header.Set("X-User-ID", "user_123")
+			req.Header.Set("Authorization", "Bearer guess")
+
+			// --- Act ---
+			status := serveRequest(t, cfg, req)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusForbidden, status)
+		})
+	}
+}
@@ -1,49 +1,317 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/salesworks/s-works/api/internal/bootstrap"
+	currencyHandler "github.com/salesworks/s-works/api/internal/currencies/handler"
+	customerHandler "github.com/salesworks/s-works/api/internal/customers/handler"
+	fabricApp "github.com/salesworks/s-works/api/internal/fabrics/application"
 	"github.com/salesworks/s-works/api/internal/fabrics/handler"
+	notificationHandler "github.com/salesworks/s-works/api/internal/notifications/handler"
+	orderHandler "github.com/salesworks/s-works/api/internal/orders/handler"
 	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/readonly"
+	supplierHandler "github.com/salesworks/s-works/api/internal/suppliers/handler"
+)
+
+const (
+	// how often parked out-of-order events are retried and checked for expiry
+	pendingSweepInterval = 5 * time.Second
+
+	// how often dead-lettered ERP events are reported to the alert webhooks
+	deadLetterAlertInterval = 5 * time.Minute
+
+	// how many ERP messages are held in memory while read-only, and how soon they are
+	// replayed once writes are accepted again
+	readOnlyParkCapacity       = 10000
+	readOnlyParkReplayInterval = time.Second
+
+	// the JetStream stream and durable consumer holding ERP messages beyond the in-flight
+	// bound, parked under overflow.erp.*
+	erpOverflowStream   = "ERP_OVERFLOW"
+	erpOverflowPrefix   = "overflow"
+	erpOverflowConsumer = "erp-overflow"
 )
 
 // Subscribers holds the dependencies required for message processing.
 type Subscribers struct {
-	natsConn *nats.Conn
-	services bootstrap.Services
-	logger   *slog.Logger
+	router             *messaging.MessageRouter
+	natsSubscriber     *messaging.NatsSubscriber
+	erpOverflow        bool
+	alertSubscribers   []*messaging.NatsSubscriber
+	fabricEventHandler *handler.FabricEventHandler
+	alertEventHandler  *notificationHandler.AlertEventHandler
+	readOnly           *readonly.Mode
+	readOnlyGuard      *messaging.ReadOnlyGuard
+	probe              *fabricApp.SyntheticProbe
+	probeSubscriber    *messaging.NatsSubscriber
+	probeInterval      time.Duration
+	logger             *slog.Logger
 }
 
 // NewSubscribers creates a new instance of our subscriber manager.
-func NewSubscribers(natsConn *nats.Conn, services bootstrap.Services, logger *slog.Logger) *Subscribers {
-	return &Subscribers{
-		natsConn: natsConn,
-		services: services,
-		logger:   logger,
-	}
-}
-
-// Start begins listening for messages on all configured subjects.
-// It should be run as a goroutine.
-func (s *Subscribers) Start() {
+func NewSubscribers(
+	natsConn *nats.Conn,
+	services bootstrap.Services,
+	repositories bootstrap.Repositories,
+	erpConfig handler.ERPEventConfig,
+	handlerTimeout time.Duration,
+	logSampler *messaging.LogSampler,
+	erpThrottle messaging.ThrottleConfig,
+	probeConfig probeConfig,
+	readOnly *readonly.Mode,
+	logger *slog.Logger,
+) (*Subscribers, error) {
 	// Create the message router
-	router := messaging.NewMessageRouter(s.logger)
+	router := messaging.NewMessageRouter(logger)
 
 	// Register handlers with the router
-	fabricEventHandler := handler.NewFabricEventHandler(s.services.FabricCommandService, s.logger)
-	router.RegisterHandler("erp.fabric", fabricEventHandler)
+	fabricEventHandler := handler.NewFabricEventHandler(
+		services.FabricCommandService,
+		repositories.FabricConflictRepository,
+		repositories.FabricProposalRepository,
+		repositories.FabricPendingEventRepository,
+		services.Publisher,
+		erpConfig,
+		services.Clock,
+		logger,
+	)
+	if err := router.RegisterHandler("erp.fabric", fabricEventHandler); err != nil {
+		return nil, err
+	}
+	customerEventHandler := customerHandler.NewCustomerEventHandler(services.CustomerService, logger)
+	if err := router.RegisterHandler("erp.customer", customerEventHandler); err != nil {
+		return nil, err
+	}
+	supplierEventHandler := supplierHandler.NewSupplierEventHandler(services.SupplierService, logger)
+	if err := router.RegisterHandler("erp.supplier", supplierEventHandler); err != nil {
+		return nil, err
+	}
+	shipmentEventHandler := orderHandler.NewShipmentEventHandler(repositories.ShipmentRepository, logger)
+	if err := router.RegisterHandler("erp.shipment", shipmentEventHandler); err != nil {
+		return nil, err
+	}
+	exchangeRateEventHandler := currencyHandler.NewExchangeRateEventHandler(services.ExchangeRateService, logger)
+	if err := router.RegisterHandler("erp.exchange_rate", exchangeRateEventHandler); err != nil {
+		return nil, err
+	}
+
+	// ERP messages change the catalog, they are parked while the service is read-only
+	readOnlyGuard := messaging.NewReadOnlyGuard(router, readOnly, readOnlyParkCapacity, logger)
 
 	// Create a single subscriber that uses the router
 	natsSubscriber := messaging.NewNatsSubscriber(
-		s.natsConn,
-		router,
+		natsConn,
+		readOnlyGuard,
 		"erp.*",             // Wildcard to catch all ERP events
 		"erp-service-group", // TODO: Get from config
-		s.logger,
+		handlerTimeout,
+		logSampler,
+		logger,
+	)
+
+	// A full ERP re-sync is consumed at a bounded pace, so it leaves database connections
+	// to interactive traffic. Messages beyond the in-flight bound wait in JetStream, or in
+	// the client when JetStream is unavailable, as while starting degraded.
+	var erpOverflow messaging.Overflow
+	if erpThrottle.MaxInFlight > 0 {
+		overflow, err := messaging.NewJetStreamOverflow(
+			natsConn, erpOverflowStream, erpOverflowPrefix, "erp.*", erpOverflowConsumer,
+		)
+		if err != nil {
+			logger.Warn("ERP overflow unavailable, messages beyond the in-flight bound wait in the client", "error", err)
+		} else {
+			erpOverflow = overflow
+		}
+	}
+	if erpThrottle.MaxPerSecond > 0 || erpThrottle.MaxInFlight > 0 {
+		natsSubscriber.SetThrottle(messaging.NewThrottle(erpThrottle), erpOverflow)
+	}
+
+	// Alert on fabric events published by the outbox and on ERP dead letters
+	alertEventHandler := notificationHandler.NewAlertEventHandler(
+		services.WebhookNotifier, erpConfig.DeadLetterSubject,
 	)
+	alertSubscribers := []*messaging.NatsSubscriber{
+		messaging.NewNatsSubscriber(
+			natsConn, alertEventHandler, "app.fabric", "notification-group", handlerTimeout, logSampler, logger,
+		),
+		messaging.NewNatsSubscriber(
+			natsConn, alertEventHandler, erpConfig.DeadLetterSubject, "notification-group", handlerTimeout, logSampler, logger,
+		),
+	}
+
+	subscribers := &Subscribers{
+		router:             router,
+		natsSubscriber:     natsSubscriber,
+		erpOverflow:        erpOverflow != nil,
+		alertSubscribers:   alertSubscribers,
+		fabricEventHandler: fabricEventHandler,
+		alertEventHandler:  alertEventHandler,
+		readOnly:           readOnly,
+		readOnlyGuard:      readOnlyGuard,
+		logger:             logger,
+	}
+
+	// The probe waits for its own events, every instance subscribes outside a queue group
+	if probeConfig.interval > 0 {
+		probe, err := fabricApp.NewSyntheticProbe(
+			services.FabricCommandService, probeConfig.fabricCode, probeConfig.timeout, services.Clock, logger,
+		)
+		if err != nil {
+			return nil, err
+		}
+		subscribers.probe = probe
+		subscribers.probeInterval = probeConfig.interval
+		subscribers.probeSubscriber = messaging.NewNatsSubscriber(
+			natsConn, probe, "app.fabric", "", handlerTimeout, logSampler, logger,
+		)
+	}
+
+	return subscribers, nil
+}
+
+// Router returns the router dispatching ERP messages to their handlers.
+func (s *Subscribers) Router() *messaging.MessageRouter {
+	return s.router
+}
+
+// Consumers reports the state of every subscription, the probe's included when enabled.
+func (s *Subscribers) Consumers() []messaging.ConsumerStatus {
+	statuses := []messaging.ConsumerStatus{s.natsSubscriber.Status()}
+	for _, subscriber := range s.alertSubscribers {
+		statuses = append(statuses, subscriber.Status())
+	}
+	if s.probeSubscriber != nil {
+		statuses = append(statuses, s.probeSubscriber.Status())
+	}
+	return statuses
+}
+
+// Hooks returns the lifecycle hooks listening for messages, sweeping parked events, replaying
+// messages held while read-only, reporting dead letters and, when enabled, replaying ERP
+// overflow and probing the write path.
+func (s *Subscribers) Hooks() []bootstrap.Hook {
+	hooks := []bootstrap.Hook{
+		{
+			Name: "NATS subscribers",
+			Start: func(context.Context) error {
+				s.logger.Info("starting NATS subscribers with router")
+				return s.natsSubscriber.StartListening()
+			},
+			Stop: func(ctx context.Context) error {
+				return s.natsSubscriber.StopListening(ctx)
+			},
+		},
+		{
+			Name: "NATS alert subscribers",
+			Start: func(context.Context) error {
+				for i, subscriber := range s.alertSubscribers {
+					if err := subscriber.StartListening(); err != nil {
+						for _, started := range s.alertSubscribers[:i] {
+							_ = started.StopListening(context.Background())
+						}
+						return err
+					}
+				}
+				return nil
+			},
+			Stop: func(ctx context.Context) error {
+				var errs []error
+				for _, subscriber := range s.alertSubscribers {
+					errs = append(errs, subscriber.StopListening(ctx))
+				}
+				return errors.Join(errs...)
+			},
+		},
+		bootstrap.Background("pending ERP event sweeper", s.sweepPendingEvents),
+		bootstrap.Background("read-only message replay", func(ctx context.Context) {
+			s.readOnlyGuard.Run(ctx, readOnlyParkReplayInterval)
+		}),
+		bootstrap.Background("dead letter alerts", s.reportDeadLetters),
+	}
+	if s.erpOverflow {
+		hooks = append(hooks, bootstrap.Background("ERP overflow replay", s.natsSubscriber.ReplayOverflow))
+	}
+	if s.probe != nil {
+		hooks = append(hooks,
+			bootstrap.Hook{
+				Name: "NATS synthetic probe subscriber",
+				Start: func(context.Context) error {
+					return s.probeSubscriber.StartListening()
+				},
+				Stop: func(ctx context.Context) error {
+					return s.probeSubscriber.StopListening(ctx)
+				},
+			},
+			bootstrap.Background("synthetic probe", s.runProbe),
+		)
+	}
+	return hooks
+}
+
+// sweepPendingEvents periodically retries parked ERP events and dead-letters those that
+// waited too long. Sweeping pauses while the service is read-only.
+func (s *Subscribers) sweepPendingEvents(ctx context.Context) {
+	ticker := time.NewTicker(pendingSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if s.readOnly.Enabled() {
+				continue
+			}
+			if err := s.fabricEventHandler.RetryPending(ctx); err != nil {
+				s.logger.Error("failed to retry pending ERP events", "error", err)
+			}
+			if err := s.fabricEventHandler.ExpirePending(ctx); err != nil {
+				s.logger.Error("failed to expire pending ERP events", "error", err)
+			}
+		}
+	}
+}
 
-	s.logger.Info("starting NATS subscribers with router")
-	natsSubscriber.StartListening()
+// runProbe periodically runs a synthetic probe cycle. Probing pauses while the service is
+// read-only, as its commands would only be refused.
+func (s *Subscribers) runProbe(ctx context.Context) {
+	ticker := time.NewTicker(s.probeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if s.readOnly.Enabled() {
+				continue
+			}
+			// failures are logged and counted by the probe
+			_ = s.probe.Probe(ctx)
+		}
+	}
+}
+
+// reportDeadLetters periodically alerts on the ERP events dead-lettered since the last report.
+func (s *Subscribers) reportDeadLetters(ctx context.Context) {
+	ticker := time.NewTicker(deadLetterAlertInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.alertEventHandler.ReportDeadLetters(ctx, deadLetterAlertInterval); err != nil {
+				s.logger.Error("failed to report dead letters", "error", err)
+			}
+		}
+	}
 }
@@ -0,0 +1,128 @@
+// Command scaffold generates the domain and application layers of a new module around an
+// aggregate, built on the mechanics of internal/platform/aggregate:
+//
+//	go run ./cmd/scaffold -module customers -aggregate Customer
+//
+// writes internal/customers/domain/customer.go with its test and
+// internal/customers/application/customer_command_service.go. The handler and persistence
+// layers follow the module closest to the new one, suppliers for most.
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// the generated files, keyed by their template and relative to the module directory
+var outputs = map[string]string{
+	"domain.go.tmpl":      "domain/{{.Snake}}.go",
+	"domain_test.go.tmpl": "domain/{{.Snake}}_test.go",
+	"service.go.tmpl":     "application/{{.Snake}}_command_service.go",
+}
+
+var (
+	modulePattern    = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	aggregatePattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// names are the spellings of the module and aggregate the templates use.
+type names struct {
+	// ModulePath is the import path of the module, e.g. .../internal/customers.
+	ModulePath string
+	// Aggregate is the type of the aggregate, e.g. SalesOrder.
+	Aggregate string
+	// Var is the aggregate as a variable, salesOrder, and Receiver its receiver, o.
+	Var      string
+	Receiver string
+	// Snake names files and events, sales_order.
+	Snake string
+	// Label is the aggregate in messages, sales order.
+	Label string
+}
+
+func main() {
+	module := flag.String("module", "", "name of the module, e.g. customers")
+	aggregate := flag.String("aggregate", "", "type of the aggregate, e.g. Customer")
+	dir := flag.String("dir", "internal", "directory the modules are in")
+	flag.Parse()
+
+	if err := run(*module, *aggregate, *dir); err != nil {
+		fmt.Fprintln(os.Stderr, "scaffold:", err)
+		os.Exit(1)
+	}
+}
+
+// run renders every template of the module into dir, refusing to overwrite existing files.
+func run(module, aggregate, dir string) error {
+	if !modulePattern.MatchString(module) {
+		return fmt.Errorf("module must be lowercase letters and digits, got %q", module)
+	}
+	if !aggregatePattern.MatchString(aggregate) {
+		return fmt.Errorf("aggregate must be an exported Go type name, got %q", aggregate)
+	}
+	n := newNames(module, aggregate)
+
+	tmpl, err := template.ParseFS(templates, "templates/*.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to parse templates: %w", err)
+	}
+
+	for name, output := range outputs {
+		path := filepath.Join(dir, module, strings.ReplaceAll(output, "{{.Snake}}", n.Snake))
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, name, n); err != nil {
+			return fmt.Errorf("failed to render %s: %w", name, err)
+		}
+		source, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %w", path, err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, source, 0o644); err != nil {
+			return err
+		}
+		fmt.Println("wrote", path)
+	}
+	return nil
+}
+
+func newNames(module, aggregate string) names {
+	var snake strings.Builder
+	for i, r := range aggregate {
+		if unicode.IsUpper(r) && i > 0 {
+			snake.WriteByte('_')
+		}
+		snake.WriteRune(unicode.ToLower(r))
+	}
+
+	return names{
+		ModulePath: "github.com/salesworks/s-works/api/internal/" + module,
+		Aggregate:  aggregate,
+		Var:        strings.ToLower(aggregate[:1]) + aggregate[1:],
+		Receiver:   strings.ToLower(aggregate[:1]),
+		Snake:      snake.String(),
+		Label:      strings.ReplaceAll(snake.String(), "_", " "),
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNames(t *testing.T) {
+	n := newNames("orders", "SalesOrder")
+
+	assert.Equal(t, "github.com/salesworks/s-works/api/internal/orders", n.ModulePath)
+	assert.Equal(t, "salesOrder", n.Var)
+	assert.Equal(t, "s", n.Receiver)
+	assert.Equal(t, "sales_order", n.Snake)
+	assert.Equal(t, "sales order", n.Label)
+}
+
+func TestRun(t *testing.T) {
+	t.Run("writes the domain and application layers", func(t *testing.T) {
+		// --- Arrange ---
+		dir := t.TempDir()
+
+		// --- Act ---
+		err := run("customers", "Customer", dir)
+
+		// --- Assert ---
+		require.NoError(t, err)
+		for _, path := range []string{
+			"customers/domain/customer.go",
+			"customers/domain/customer_test.go",
+			"customers/application/customer_command_service.go",
+		} {
+			assert.FileExists(t, filepath.Join(dir, path))
+		}
+		source, err := os.ReadFile(filepath.Join(dir, "customers/domain/customer.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(source), "func (c *Customer) Delete(version int, stamp Stamp) error {")
+	})
+
+	t.Run("leaves existing files alone", func(t *testing.T) {
+		// --- Arrange ---
+		dir := t.TempDir()
+		path := filepath.Join(dir, "customers/domain/customer.go")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("package domain\n"), 0o644))
+
+		// --- Act ---
+		err := run("customers", "Customer", dir)
+
+		// --- Assert ---
+		require.Error(t, err)
+		source, _ := os.ReadFile(path)
+		assert.Equal(t, "package domain\n", string(source))
+	})
+
+	t.Run("rejects names that are not Go identifiers", func(t *testing.T) {
+		assert.Error(t, run("Customers", "Customer", t.TempDir()))
+		assert.Error(t, run("customers", "customer", t.TempDir()))
+		assert.Error(t, run("customers", "Sales-Order", t.TempDir()))
+	})
+}
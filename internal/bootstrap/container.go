@@ -0,0 +1,114 @@
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	fabricDomain "github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/blobstore"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/salesworks/s-works/api/internal/platform/mail"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+)
+
+const (
+	// how often queued events are published from the outbox
+	outboxRelayInterval = time.Second
+
+	// how often the catalog is scanned for duplicate fabrics
+	duplicateScanInterval = 24 * time.Hour
+
+	// how often a snapshot of the catalog is published to blob storage
+	catalogSnapshotInterval = time.Hour
+
+	// how often the certifications of fabrics are checked for expiry
+	certificationExpiryInterval = time.Hour
+
+	// how often expired stock reservations are given back to the available stock
+	reservationExpiryInterval = time.Minute
+
+	// how often the exchange rates are fetched from the provider
+	exchangeRateFetchInterval = 6 * time.Hour
+)
+
+// MessagingConfig sets how events are encoded on the wire, which of them are published
+// at all and how much of the routine per-message logging is kept. Source names this
+// instance as the producer of the events it records.
+type MessagingConfig struct {
+	Codecs           messaging.SubjectCodecs
+	LogSampler       *messaging.LogSampler
+	PublishAllowlist *messaging.EventAllowlist
+	Source           messaging.Source
+}
+
+// NotificationConfig sets how digest emails are delivered and how often they are sent.
+type NotificationConfig struct {
+	Mailer         mail.Mailer
+	DigestInterval time.Duration
+}
+
+// CurrencyConfig sets where the daily exchange rates are fetched from. Without a URL the
+// rates are only taken from ERP events.
+type CurrencyConfig struct {
+	RatesURL string
+}
+
+// Container holds the application's components together with the lifecycle running the
+// ones that work in the background.
+type Container struct {
+	Repositories Repositories
+	Services     Services
+	Lifecycle    *Lifecycle
+}
+
+// NewContainer wires the repositories and services on top of the open connections and
+// registers the background components owned by the services. Entry points append their
+// own components, such as servers and subscribers, before starting the lifecycle.
+func NewContainer(
+	postgres *database.PostgresDB,
+	natsConn *nats.Conn,
+	messagingConfig MessagingConfig,
+	notifications NotificationConfig,
+	currencies CurrencyConfig,
+	quota fabricDomain.TenantQuota,
+	blobs blobstore.Store,
+	logger *slog.Logger,
+) *Container {
+	repositories := NewRepositories(postgres, logger)
+	services := NewServices(
+		repositories, natsConn, messagingConfig, notifications.Mailer, blobs, currencies.RatesURL, quota, logger,
+	)
+
+	lifecycle := NewLifecycle(logger)
+	lifecycle.Append(Background("outbox relay", func(ctx context.Context) {
+		services.OutboxRelay.Run(ctx, outboxRelayInterval)
+	}))
+	lifecycle.Append(Background("notification digests", func(ctx context.Context) {
+		services.DigestService.Run(ctx, notifications.DigestInterval)
+	}))
+	lifecycle.Append(Background("duplicate fabric scan", func(ctx context.Context) {
+		services.DuplicateScanService.Run(ctx, duplicateScanInterval)
+	}))
+	lifecycle.Append(Background("catalog snapshots", func(ctx context.Context) {
+		services.CatalogSnapshotService.Run(ctx, catalogSnapshotInterval)
+	}))
+	lifecycle.Append(Background("certification expiry", func(ctx context.Context) {
+		services.CertificationService.Run(ctx, certificationExpiryInterval)
+	}))
+	lifecycle.Append(Background("stock reservation expiry", func(ctx context.Context) {
+		services.FabricStockService.Run(ctx, reservationExpiryInterval)
+	}))
+	if currencies.RatesURL != "" {
+		lifecycle.Append(Background("exchange rate fetch", func(ctx context.Context) {
+			services.ExchangeRateService.Run(ctx, exchangeRateFetchInterval)
+		}))
+	}
+
+	return &Container{
+		Repositories: repositories,
+		Services:     services,
+		Lifecycle:    lifecycle,
+	}
+}
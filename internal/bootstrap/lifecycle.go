@@ -0,0 +1,98 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Hook starts and stops one component of the application. Either function may be nil.
+type Hook struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+// Lifecycle starts the registered components in registration order and stops them in
+// reverse, so a component can rely on everything registered before it for as long as it
+// runs.
+type Lifecycle struct {
+	hooks   []Hook
+	started int
+	logger  *slog.Logger
+}
+
+func NewLifecycle(logger *slog.Logger) *Lifecycle {
+	return &Lifecycle{logger: logger.With("component", "lifecycle")}
+}
+
+// Append registers a component to be started after all components registered so far.
+func (l *Lifecycle) Append(hook Hook) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// Start runs the start hooks in order. When one fails, the components already started are
+// stopped again and the error is returned.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	for _, hook := range l.hooks[l.started:] {
+		if hook.Start != nil {
+			if err := hook.Start(ctx); err != nil {
+				startErr := fmt.Errorf("failed to start %s: %w", hook.Name, err)
+				return errors.Join(startErr, l.Stop(ctx))
+			}
+		}
+		l.started++
+		l.logger.Info("component started", "name", hook.Name)
+	}
+	return nil
+}
+
+// Stop runs the stop hooks of the started components in reverse order. Every component is
+// stopped even when an earlier one fails; the failures are returned joined.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	var errs []error
+	for ; l.started > 0; l.started-- {
+		hook := l.hooks[l.started-1]
+		if hook.Stop == nil {
+			continue
+		}
+		if err := hook.Stop(ctx); err != nil {
+			l.logger.Error("component failed to stop", "name", hook.Name, "error", err)
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", hook.Name, err))
+			continue
+		}
+		l.logger.Info("component stopped", "name", hook.Name)
+	}
+	return errors.Join(errs...)
+}
+
+// Background returns a hook running fn in its own goroutine. Stopping it cancels the
+// context passed to fn and waits for fn to return, or for the stop context to expire.
+func Background(name string, fn func(ctx context.Context)) Hook {
+	var (
+		cancel context.CancelFunc
+		done   = make(chan struct{})
+	)
+	return Hook{
+		Name: name,
+		Start: func(context.Context) error {
+			var runCtx context.Context
+			runCtx, cancel = context.WithCancel(context.Background())
+			go func() {
+				defer close(done)
+				fn(runCtx)
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
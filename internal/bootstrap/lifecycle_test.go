@@ -0,0 +1,99 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recordingHook(name string, calls *[]string, startErr error) Hook {
+	return Hook{
+		Name: name,
+		Start: func(context.Context) error {
+			*calls = append(*calls, "start "+name)
+			return startErr
+		},
+		Stop: func(context.Context) error {
+			*calls = append(*calls, "stop "+name)
+			return nil
+		},
+	}
+}
+
+func newTestLifecycle() *Lifecycle {
+	return NewLifecycle(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestLifecycle_StartsInOrderAndStopsInReverse(t *testing.T) {
+	// --- Arrange ---
+	var calls []string
+	lifecycle := newTestLifecycle()
+	lifecycle.Append(recordingHook("relay", &calls, nil))
+	lifecycle.Append(Hook{Name: "no hooks"})
+	lifecycle.Append(recordingHook("server", &calls, nil))
+
+	// --- Act ---
+	startErr := lifecycle.Start(context.Background())
+	stopErr := lifecycle.Stop(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, startErr)
+	require.NoError(t, stopErr)
+	assert.Equal(t, []string{"start relay", "start server", "stop server", "stop relay"}, calls)
+}
+
+func TestLifecycle_StartFailureStopsStartedComponents(t *testing.T) {
+	// --- Arrange ---
+	var calls []string
+	failure := errors.New("port in use")
+	lifecycle := newTestLifecycle()
+	lifecycle.Append(recordingHook("relay", &calls, nil))
+	lifecycle.Append(recordingHook("server", &calls, failure))
+	lifecycle.Append(recordingHook("never", &calls, nil))
+
+	// --- Act ---
+	err := lifecycle.Start(context.Background())
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, []string{"start relay", "start server", "stop relay"}, calls)
+}
+
+func TestLifecycle_StopContinuesAfterFailure(t *testing.T) {
+	// --- Arrange ---
+	var calls []string
+	failure := errors.New("drain timed out")
+	lifecycle := newTestLifecycle()
+	lifecycle.Append(recordingHook("relay", &calls, nil))
+	lifecycle.Append(Hook{Name: "subscribers", Stop: func(context.Context) error { return failure }})
+	require.NoError(t, lifecycle.Start(context.Background()))
+
+	// --- Act ---
+	err := lifecycle.Stop(context.Background())
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, []string{"start relay", "stop relay"}, calls)
+}
+
+func TestBackground_StopCancelsAndWaits(t *testing.T) {
+	// --- Arrange ---
+	exited := false
+	hook := Background("sweeper", func(ctx context.Context) {
+		<-ctx.Done()
+		exited = true
+	})
+
+	// --- Act ---
+	require.NoError(t, hook.Start(context.Background()))
+	err := hook.Stop(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.True(t, exited, "stop should wait for the goroutine to return")
+}
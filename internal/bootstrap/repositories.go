@@ -1,23 +1,214 @@
 package bootstrap
 
 import (
+	"log/slog"
+
+	categoryDomain "github.com/salesworks/s-works/api/internal/categories/domain"
+	categoryPersistence "github.com/salesworks/s-works/api/internal/categories/infrastructure/persistence"
+	colorDomain "github.com/salesworks/s-works/api/internal/colors/domain"
+	colorPersistence "github.com/salesworks/s-works/api/internal/colors/infrastructure/persistence"
+	currencyDomain "github.com/salesworks/s-works/api/internal/currencies/domain"
+	currencyPersistence "github.com/salesworks/s-works/api/internal/currencies/infrastructure/persistence"
+	customerDomain "github.com/salesworks/s-works/api/internal/customers/domain"
+	customerPersistence "github.com/salesworks/s-works/api/internal/customers/infrastructure/persistence"
 	"github.com/salesworks/s-works/api/internal/fabrics/domain"
 	"github.com/salesworks/s-works/api/internal/fabrics/handler"
 	"github.com/salesworks/s-works/api/internal/fabrics/infrastructure/persistence"
+	notificationDomain "github.com/salesworks/s-works/api/internal/notifications/domain"
+	notificationPersistence "github.com/salesworks/s-works/api/internal/notifications/infrastructure/persistence"
+	orderDomain "github.com/salesworks/s-works/api/internal/orders/domain"
+	orderPersistence "github.com/salesworks/s-works/api/internal/orders/infrastructure/persistence"
 	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+	priceListDomain "github.com/salesworks/s-works/api/internal/pricelists/domain"
+	priceListPersistence "github.com/salesworks/s-works/api/internal/pricelists/infrastructure/persistence"
+	productDomain "github.com/salesworks/s-works/api/internal/products/domain"
+	productPersistence "github.com/salesworks/s-works/api/internal/products/infrastructure/persistence"
+	supplierDomain "github.com/salesworks/s-works/api/internal/suppliers/domain"
+	supplierPersistence "github.com/salesworks/s-works/api/internal/suppliers/infrastructure/persistence"
+	userDomain "github.com/salesworks/s-works/api/internal/users/domain"
+	userPersistence "github.com/salesworks/s-works/api/internal/users/infrastructure/persistence"
+	warehouseDomain "github.com/salesworks/s-works/api/internal/warehouses/domain"
+	warehousePersistence "github.com/salesworks/s-works/api/internal/warehouses/infrastructure/persistence"
 )
 
 type Repositories struct {
-	postgres                *database.PostgresDB
-	FabricCommandRepository domain.FabricCommandRepository
-	FabricQueryRepository   handler.FabricQueryRepository
+	postgres                     *database.PostgresDB
+	FabricCommandRepository      domain.FabricCommandRepository
+	FabricQueryRepository        handler.FabricQueryRepository
+	FabricListRepository         handler.FabricListRepository
+	FabricSearchRepository       handler.FabricSearchRepository
+	FabricExportRepository       handler.FabricExportRepository
+	FabricChangeFeed             handler.FabricChangeFeed
+	FabricHistory                handler.FabricHistoryReader
+	FabricAliasRepository        domain.FabricAliasRepository
+	FabricBarcodeRepository      domain.FabricBarcodeRepository
+	FabricLockRepository         domain.FabricLockRepository
+	FabricCodeRepository         domain.FabricCodeRepository
+	FabricDraftRepository        domain.FabricDraftRepository
+	FabricAttributeRepository    domain.AttributeDefinitionRepository
+	FibreRepository              domain.FibreRepository
+	FabricProposalRepository     domain.FabricProposalRepository
+	FabricConflictRepository     domain.FabricConflictRepository
+	FabricPendingEventRepository domain.FabricPendingEventRepository
+	FabricDuplicateRepository    domain.FabricDuplicateRepository
+	FabricStockRepository        domain.FabricStockRepository
+	FabricAttachmentRepository   domain.FabricAttachmentRepository
+	CertificationRepository      domain.FabricCertificationRepository
+	TenantUsageRepository        domain.TenantUsageRepository
+	CategoryRepository           categoryDomain.CategoryRepository
+	SupplierRepository           supplierDomain.SupplierRepository
+	SupplierTokenRepository      supplierDomain.SupplierTokenRepository
+	CustomerRepository           customerDomain.CustomerRepository
+	OrderRepository              orderDomain.OrderRepository
+	ShipmentRepository           orderDomain.ShipmentRepository
+	ProductRepository            productDomain.ProductRepository
+	PriceListRepository          priceListDomain.PriceListRepository
+	WarehouseRepository          warehouseDomain.WarehouseRepository
+	ColorRepository              colorDomain.ColorRepository
+	ExchangeRateRepository       currencyDomain.ExchangeRateRepository
+	UserRepository               userDomain.UserRepository
+	EventOutbox                  handler.EventOutbox
+	EventArchive                 handler.EventArchive
+	EventRange                   handler.EventRange
+	SubscriptionRepository       notificationDomain.SubscriptionRepository
+	WebhookRepository            notificationDomain.WebhookRepository
 }
 
-func NewRepositories(postgres *database.PostgresDB) Repositories {
-	postgresRepo := persistence.NewFabricPostgresRepository(postgres)
+func NewRepositories(postgres *database.PostgresDB, logger *slog.Logger) Repositories {
+	eventStore := eventstore.NewPostgresStore(postgres.Pool)
+	fabricRepo := persistence.NewInstrumentedFabricRepository(
+		persistence.NewFabricPostgresRepository(postgres),
+		instrument.NewRecorder("fabric.repository", logger),
+	)
 	return Repositories{
 		postgres:                postgres,
-		FabricCommandRepository: postgresRepo,
-		FabricQueryRepository:   postgresRepo,
+		FabricCommandRepository: fabricRepo,
+		FabricQueryRepository:   fabricRepo,
+		FabricListRepository:    fabricRepo,
+		FabricSearchRepository:  fabricRepo,
+		FabricExportRepository:  fabricRepo,
+		FabricChangeFeed:        eventStore,
+		FabricHistory:           eventStore,
+		EventOutbox:             eventStore,
+		EventArchive:            eventStore,
+		EventRange:              eventStore,
+		FabricAliasRepository: persistence.NewInstrumentedFabricAliasRepository(
+			persistence.NewFabricAliasPostgresRepository(postgres),
+			instrument.NewRecorder("fabric.alias_repository", logger),
+		),
+		FabricBarcodeRepository: persistence.NewInstrumentedFabricBarcodeRepository(
+			persistence.NewFabricBarcodePostgresRepository(postgres),
+			instrument.NewRecorder("fabric.barcode_repository", logger),
+		),
+		FabricLockRepository: persistence.NewInstrumentedFabricLockRepository(
+			persistence.NewFabricLockPostgresRepository(postgres),
+			instrument.NewRecorder("fabric.lock_repository", logger),
+		),
+		FabricCodeRepository: persistence.NewInstrumentedFabricCodeRepository(
+			persistence.NewFabricCodePostgresRepository(postgres),
+			instrument.NewRecorder("fabric.code_repository", logger),
+		),
+		FabricAttributeRepository: persistence.NewInstrumentedAttributeDefinitionRepository(
+			persistence.NewAttributeDefinitionPostgresRepository(postgres),
+			instrument.NewRecorder("fabric.attribute_definition_repository", logger),
+		),
+		FibreRepository: persistence.NewInstrumentedFibreRepository(
+			persistence.NewFibrePostgresRepository(postgres),
+			instrument.NewRecorder("fabric.fibre_repository", logger),
+		),
+		FabricDraftRepository: persistence.NewInstrumentedFabricDraftRepository(
+			persistence.NewFabricDraftPostgresRepository(postgres),
+			instrument.NewRecorder("fabric.draft_repository", logger),
+		),
+		FabricProposalRepository: persistence.NewInstrumentedFabricProposalRepository(
+			persistence.NewFabricProposalPostgresRepository(postgres),
+			instrument.NewRecorder("fabric.proposal_repository", logger),
+		),
+		FabricConflictRepository: persistence.NewInstrumentedFabricConflictRepository(
+			persistence.NewFabricConflictPostgresRepository(postgres),
+			instrument.NewRecorder("fabric.conflict_repository", logger),
+		),
+		FabricPendingEventRepository: persistence.NewInstrumentedFabricPendingEventRepository(
+			persistence.NewFabricPendingEventPostgresRepository(postgres),
+			instrument.NewRecorder("fabric.pending_event_repository", logger),
+		),
+		FabricDuplicateRepository: persistence.NewInstrumentedFabricDuplicateRepository(
+			persistence.NewFabricDuplicatePostgresRepository(postgres),
+			instrument.NewRecorder("fabric.duplicate_repository", logger),
+		),
+		FabricStockRepository: persistence.NewInstrumentedFabricStockRepository(
+			persistence.NewFabricStockPostgresRepository(postgres),
+			instrument.NewRecorder("fabric.stock_repository", logger),
+		),
+		FabricAttachmentRepository: persistence.NewInstrumentedFabricAttachmentRepository(
+			persistence.NewFabricAttachmentPostgresRepository(postgres),
+			instrument.NewRecorder("fabric.attachment_repository", logger),
+		),
+		CertificationRepository: persistence.NewInstrumentedFabricCertificationRepository(
+			persistence.NewFabricCertificationPostgresRepository(postgres),
+			instrument.NewRecorder("fabric.certification_repository", logger),
+		),
+		TenantUsageRepository: persistence.NewInstrumentedTenantUsageRepository(
+			persistence.NewTenantUsagePostgresRepository(postgres),
+			instrument.NewRecorder("fabric.tenant_usage_repository", logger),
+		),
+		CategoryRepository: categoryPersistence.NewInstrumentedCategoryRepository(
+			categoryPersistence.NewCategoryPostgresRepository(postgres),
+			instrument.NewRecorder("category.repository", logger),
+		),
+		SupplierRepository: supplierPersistence.NewInstrumentedSupplierRepository(
+			supplierPersistence.NewSupplierPostgresRepository(postgres),
+			instrument.NewRecorder("supplier.repository", logger),
+		),
+		SupplierTokenRepository: supplierPersistence.NewInstrumentedSupplierTokenRepository(
+			supplierPersistence.NewSupplierTokenPostgresRepository(postgres),
+			instrument.NewRecorder("supplier.token_repository", logger),
+		),
+		CustomerRepository: customerPersistence.NewInstrumentedCustomerRepository(
+			customerPersistence.NewCustomerPostgresRepository(postgres),
+			instrument.NewRecorder("customer.repository", logger),
+		),
+		OrderRepository: orderPersistence.NewInstrumentedOrderRepository(
+			orderPersistence.NewOrderPostgresRepository(postgres),
+			instrument.NewRecorder("order.repository", logger),
+		),
+		ShipmentRepository: orderPersistence.NewInstrumentedShipmentRepository(
+			orderPersistence.NewShipmentPostgresRepository(postgres),
+			instrument.NewRecorder("shipment.repository", logger),
+		),
+		ProductRepository: productPersistence.NewInstrumentedProductRepository(
+			productPersistence.NewProductPostgresRepository(postgres),
+			instrument.NewRecorder("product.repository", logger),
+		),
+		PriceListRepository: priceListPersistence.NewInstrumentedPriceListRepository(
+			priceListPersistence.NewPriceListPostgresRepository(postgres),
+			instrument.NewRecorder("price_list.repository", logger),
+		),
+		WarehouseRepository: warehousePersistence.NewInstrumentedWarehouseRepository(
+			warehousePersistence.NewWarehousePostgresRepository(postgres),
+			instrument.NewRecorder("warehouse.repository", logger),
+		),
+		ColorRepository: colorPersistence.NewInstrumentedColorRepository(
+			colorPersistence.NewColorPostgresRepository(postgres),
+			instrument.NewRecorder("color.repository", logger),
+		),
+		ExchangeRateRepository: currencyPersistence.NewInstrumentedExchangeRateRepository(
+			currencyPersistence.NewExchangeRatePostgresRepository(postgres),
+			instrument.NewRecorder("exchange_rate.repository", logger),
+		),
+		UserRepository: userPersistence.NewInstrumentedUserRepository(
+			userPersistence.NewUserPostgresRepository(postgres),
+			instrument.NewRecorder("user.repository", logger),
+		),
+		SubscriptionRepository: notificationPersistence.NewInstrumentedSubscriptionRepository(
+			notificationPersistence.NewSubscriptionPostgresRepository(postgres),
+			instrument.NewRecorder("notification.subscription_repository", logger),
+		),
+		WebhookRepository: notificationPersistence.NewInstrumentedWebhookRepository(
+			notificationPersistence.NewWebhookPostgresRepository(postgres),
+			instrument.NewRecorder("notification.webhook_repository", logger),
+		),
 	}
 }
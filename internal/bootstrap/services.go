@@ -2,30 +2,175 @@ package bootstrap
 
 import (
 	"log/slog"
+	"net/http"
+	"time"
 
 	"github.com/nats-io/nats.go"
+	categoryApp "github.com/salesworks/s-works/api/internal/categories/application"
+	categoryHandler "github.com/salesworks/s-works/api/internal/categories/handler"
+	colorApp "github.com/salesworks/s-works/api/internal/colors/application"
+	colorHandler "github.com/salesworks/s-works/api/internal/colors/handler"
+	currencyApp "github.com/salesworks/s-works/api/internal/currencies/application"
+	customerApp "github.com/salesworks/s-works/api/internal/customers/application"
 	fabricApp "github.com/salesworks/s-works/api/internal/fabrics/application"
+	fabricDomain "github.com/salesworks/s-works/api/internal/fabrics/domain"
 	"github.com/salesworks/s-works/api/internal/fabrics/handler"
+	notificationApp "github.com/salesworks/s-works/api/internal/notifications/application"
+	orderApp "github.com/salesworks/s-works/api/internal/orders/application"
+	orderHandler "github.com/salesworks/s-works/api/internal/orders/handler"
+	"github.com/salesworks/s-works/api/internal/platform/blobstore"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
 	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/mail"
 	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	priceListApp "github.com/salesworks/s-works/api/internal/pricelists/application"
+	priceListHandler "github.com/salesworks/s-works/api/internal/pricelists/handler"
+	productApp "github.com/salesworks/s-works/api/internal/products/application"
+	productHandler "github.com/salesworks/s-works/api/internal/products/handler"
+	supplierApp "github.com/salesworks/s-works/api/internal/suppliers/application"
+	userApp "github.com/salesworks/s-works/api/internal/users/application"
+	userHandler "github.com/salesworks/s-works/api/internal/users/handler"
+	warehouseApp "github.com/salesworks/s-works/api/internal/warehouses/application"
+	warehouseHandler "github.com/salesworks/s-works/api/internal/warehouses/handler"
+)
+
+const (
+	// how long a chat webhook may take to accept an alert
+	webhookTimeout = 10 * time.Second
+
+	// how long the exchange rate provider may take to answer
+	exchangeRateTimeout = 30 * time.Second
 )
 
 type Services struct {
-	FabricCommandService handler.FabricCommandService
+	FabricCommandService     handler.FabricCommandService
+	FabricMergeService       handler.FabricMergeService
+	FabricRenameService      handler.FabricRenameService
+	FabricRestoreService     handler.FabricRestoreService
+	FabricPurgeService       handler.FabricPurgeService
+	FabricLifecycleService   handler.FabricLifecycleService
+	FabricReactivateService  handler.FabricReactivationService
+	FabricValidationService  handler.FabricValidationService
+	FabricPriceService       handler.FabricPriceService
+	FabricTranslationService handler.FabricTranslationService
+	FabricAttributeService   handler.FabricCustomAttributeService
+	FabricStockService       *fabricApp.FabricStockService
+	FabricAttachmentService  handler.FabricAttachmentService
+	CertificationService     *fabricApp.FabricCertificationService
+	TenantQuotaService       *fabricApp.TenantQuotaService
+	CategoryService          categoryHandler.CategoryCommandService
+	SupplierService          *supplierApp.SupplierService
+	CustomerService          *customerApp.CustomerService
+	OrderService             orderHandler.OrderCommandService
+	ProductService           productHandler.ProductCommandService
+	PriceListService         priceListHandler.PriceListCommandService
+	WarehouseService         warehouseHandler.WarehouseCommandService
+	ColorService             colorHandler.ColorCommandService
+	ExchangeRateService      *currencyApp.ExchangeRateService
+	UserService              userHandler.UserSyncService
+	DuplicateScanService     *fabricApp.DuplicateScanService
+	CatalogSnapshotService   *fabricApp.CatalogSnapshotService
+	Publisher                messaging.Publisher
+	OutboxRelay              *eventstore.OutboxRelay
+	DigestService            *notificationApp.DigestService
+	WebhookNotifier          *notificationApp.WebhookNotifier
+	Clock                    clock.Clock
 }
 
 func NewServices(
-	repositories Repositories, natsConn *nats.Conn, logger *slog.Logger,
+	repositories Repositories,
+	natsConn *nats.Conn,
+	messagingConfig MessagingConfig,
+	mailer mail.Mailer,
+	blobs blobstore.Store,
+	ratesURL string,
+	quota fabricDomain.TenantQuota,
+	logger *slog.Logger,
 ) Services {
-	appEventPublisher := messaging.NewNatsPublisher(natsConn, logger)
+	appEventPublisher := messaging.NewNatsPublisher(
+		natsConn, messagingConfig.Codecs, messagingConfig.LogSampler, logger,
+	)
 	eventStore := eventstore.NewPostgresStore(repositories.postgres.Pool)
+	systemClock := clock.New()
+	tenantQuotaService := fabricApp.NewTenantQuotaService(repositories.TenantUsageRepository, quota)
 	fabricCommandService := fabricApp.NewFabricCommandService(
 		repositories.FabricCommandRepository,
-		appEventPublisher,
+		repositories.FibreRepository,
+		tenantQuotaService,
 		eventStore,
+		systemClock,
+		messagingConfig.Source,
 	)
 
 	return Services{
-		FabricCommandService: fabricCommandService,
+		FabricCommandService:     fabricCommandService,
+		FabricMergeService:       fabricCommandService,
+		FabricRenameService:      fabricCommandService,
+		FabricRestoreService:     fabricCommandService,
+		FabricPurgeService:       fabricCommandService,
+		FabricLifecycleService:   fabricCommandService,
+		FabricReactivateService:  fabricCommandService,
+		FabricValidationService:  fabricCommandService,
+		FabricPriceService:       fabricCommandService,
+		FabricTranslationService: fabricCommandService,
+		FabricAttributeService:   fabricCommandService,
+		FabricStockService: fabricApp.NewFabricStockService(
+			repositories.FabricStockRepository, eventStore, systemClock, messagingConfig.Source, logger,
+		),
+		FabricAttachmentService: fabricApp.NewFabricAttachmentService(
+			repositories.FabricAttachmentRepository, blobs, tenantQuotaService, eventStore, systemClock,
+			messagingConfig.Source,
+		),
+		CertificationService: fabricApp.NewFabricCertificationService(
+			repositories.CertificationRepository, eventStore, systemClock, messagingConfig.Source, logger,
+		),
+		TenantQuotaService: tenantQuotaService,
+		CategoryService: categoryApp.NewCategoryCommandService(
+			repositories.CategoryRepository, eventStore, systemClock, messagingConfig.Source,
+		),
+		SupplierService: supplierApp.NewSupplierCommandService(
+			repositories.SupplierRepository, eventStore, systemClock, messagingConfig.Source,
+		),
+		CustomerService: customerApp.NewCustomerCommandService(
+			repositories.CustomerRepository, eventStore, systemClock, messagingConfig.Source,
+		),
+		OrderService: orderApp.NewOrderCommandService(
+			repositories.OrderRepository, eventStore, systemClock, messagingConfig.Source,
+		),
+		ProductService: productApp.NewProductCommandService(
+			repositories.ProductRepository, eventStore, systemClock, messagingConfig.Source,
+		),
+		PriceListService: priceListApp.NewPriceListCommandService(
+			repositories.PriceListRepository, eventStore, systemClock, messagingConfig.Source,
+		),
+		WarehouseService: warehouseApp.NewWarehouseCommandService(
+			repositories.WarehouseRepository, eventStore, systemClock, messagingConfig.Source,
+		),
+		ColorService: colorApp.NewColorCommandService(
+			repositories.ColorRepository, eventStore, systemClock, messagingConfig.Source,
+		),
+		ExchangeRateService: currencyApp.NewExchangeRateService(
+			repositories.ExchangeRateRepository, &http.Client{Timeout: exchangeRateTimeout}, ratesURL, systemClock, logger,
+		),
+		UserService: userApp.NewUserCommandService(
+			repositories.UserRepository, eventStore, systemClock, messagingConfig.Source,
+		),
+		DuplicateScanService: fabricApp.NewDuplicateScanService(
+			repositories.FabricExportRepository, repositories.FabricDuplicateRepository, systemClock, logger,
+		),
+		CatalogSnapshotService: fabricApp.NewCatalogSnapshotService(
+			repositories.FabricExportRepository, blobs, eventStore, systemClock, messagingConfig.Source, logger,
+		),
+		Publisher: appEventPublisher,
+		OutboxRelay: eventstore.NewOutboxRelay(
+			eventStore, appEventPublisher, messagingConfig.PublishAllowlist, logger,
+		),
+		DigestService: notificationApp.NewDigestService(
+			repositories.SubscriptionRepository, eventStore, mailer, systemClock, logger,
+		),
+		WebhookNotifier: notificationApp.NewWebhookNotifier(
+			repositories.WebhookRepository, &http.Client{Timeout: webhookTimeout}, logger,
+		),
+		Clock: systemClock,
 	}
 }
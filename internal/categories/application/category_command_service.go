@@ -0,0 +1,246 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/salesworks/s-works/api/internal/categories/domain"
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CategoryService maintains the fabric taxonomy and the assignment of fabrics to it.
+type CategoryService struct {
+	repo         domain.CategoryRepository
+	eventStore   eventstore.Store
+	clock        clock.Clock
+	eventChannel string
+	source       messaging.Source
+}
+
+func NewCategoryCommandService(
+	repo domain.CategoryRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+) *CategoryService {
+	return &CategoryService{
+		repo:         repo,
+		eventStore:   eventStore,
+		clock:        clock,
+		eventChannel: "app.category",
+		source:       source,
+	}
+}
+
+// CreateCategory creates a category under the parent, or a root category when parentCode
+// is empty.
+func (s *CategoryService) CreateCategory(
+	ctx context.Context, code, name, parentCode string,
+) (*domain.Category, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "category.service.create")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "category.service")
+
+	parent, err := s.getParent(ctx, parentCode)
+	if err != nil {
+		return nil, err
+	}
+
+	category := domain.NewCategory(code, name, parent, aggregate.StampNow(ctx, s.clock))
+	if err := s.repo.SaveCategory(ctx, category); err != nil {
+		return nil, s.failed(span, logger, "saving category failed", err)
+	}
+
+	if err := s.publish(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory renames the category and moves it under the parent, or to the root when
+// parentCode is empty.
+func (s *CategoryService) UpdateCategory(
+	ctx context.Context, code, name, parentCode string, version int,
+) (*domain.Category, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "category.service.update")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "category.service")
+
+	category, err := s.repo.GetCategory(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := s.getParent(ctx, parentCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := category.Update(name, parent, version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateCategory(ctx, category); err != nil {
+		return nil, s.failed(span, logger, "updating category failed", err)
+	}
+
+	if err := s.publish(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *CategoryService) DeleteCategory(ctx context.Context, code string, version int) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "category.service.delete")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "category.service")
+
+	category, err := s.repo.GetCategory(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if err := category.Delete(version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteCategory(ctx, category); err != nil {
+		return s.failed(span, logger, "deleting category failed", err)
+	}
+
+	return s.publish(ctx, category)
+}
+
+// AssignFabric assigns the fabric, given by its code or one of its aliases, to the category.
+func (s *CategoryService) AssignFabric(ctx context.Context, code, fabricCode string) (*domain.Category, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "category.service.assign_fabric")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "category.service")
+
+	category, fabricCode, err := s.getAssignment(ctx, code, fabricCode)
+	if err != nil {
+		return nil, err
+	}
+
+	category.AssignFabric(fabricCode, aggregate.StampNow(ctx, s.clock))
+	if err := s.repo.AssignFabric(ctx, category, fabricCode); err != nil {
+		return nil, s.failed(span, logger, "assigning fabric to category failed", err)
+	}
+
+	if err := s.publish(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *CategoryService) UnassignFabric(ctx context.Context, code, fabricCode string) (*domain.Category, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "category.service.unassign_fabric")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "category.service")
+
+	category, fabricCode, err := s.getAssignment(ctx, code, fabricCode)
+	if err != nil {
+		return nil, err
+	}
+
+	category.UnassignFabric(fabricCode, aggregate.StampNow(ctx, s.clock))
+	if err := s.repo.UnassignFabric(ctx, category, fabricCode); err != nil {
+		return nil, s.failed(span, logger, "unassigning fabric from category failed", err)
+	}
+
+	if err := s.publish(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// getParent loads the parent category, which is nil for a root category.
+func (s *CategoryService) getParent(ctx context.Context, parentCode string) (*domain.Category, error) {
+	if parentCode == "" {
+		return nil, nil
+	}
+	parent, err := s.repo.GetCategory(ctx, parentCode)
+	if errors.Is(err, domain.ErrCategoryNotFound) {
+		return nil, domain.ErrParentNotFound
+	}
+	return parent, err
+}
+
+// getAssignment loads the category and resolves the fabric to its canonical code.
+func (s *CategoryService) getAssignment(
+	ctx context.Context, code, fabricCode string,
+) (*domain.Category, string, error) {
+	category, err := s.repo.GetCategory(ctx, code)
+	if err != nil {
+		return nil, "", err
+	}
+	fabricCode, err = s.repo.ResolveFabricCode(ctx, fabricCode)
+	if err != nil {
+		return nil, "", err
+	}
+	return category, fabricCode, nil
+}
+
+// failed reports a repository write that did not succeed. Category errors are passed
+// through as they are, anything else is wrapped and recorded as a database error.
+func (s *CategoryService) failed(span trace.Span, logger *slog.Logger, msg string, err error) error {
+	var categoryErr *domain.CategoryError
+	if errors.As(err, &categoryErr) {
+		return err
+	}
+	wrappedErr := fmt.Errorf("failed to write category in repo: %w", err)
+	logger.Error(msg, "error", wrappedErr)
+	span.RecordError(wrappedErr)
+	span.SetStatus(codes.Error, "database write error")
+	return wrappedErr
+}
+
+func (s *CategoryService) publish(ctx context.Context, category *domain.Category) error {
+	logger := httpx.GetLogger(ctx).With("component", "category.service")
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range category.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.CategoryCreated:
+			eventType = "app.category.created"
+		case domain.CategoryUpdated:
+			eventType = "app.category.updated"
+		case domain.CategoryDeleted:
+			eventType = "app.category.deleted"
+		case domain.CategoryFabricAssigned:
+			eventType = "app.category.fabric_assigned"
+		case domain.CategoryFabricUnassigned:
+			eventType = "app.category.fabric_unassigned"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			category.Code,
+			"Category",
+			category.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopesToPublish...); err != nil {
+			wrappedErr := fmt.Errorf("failed to save category event to event store: %w", err)
+			logger.Error("saving category event failed", "error", wrappedErr)
+			return wrappedErr
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/categories/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = domain.Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testSource = messaging.Source{Service: "s-works-api", Instance: "test-instance"}
+
+type mockCategoryRepository struct {
+	categories  map[string]*domain.Category
+	aliases     map[string]string
+	saved       *domain.Category
+	assigned    string
+	errToReturn error
+}
+
+func (m *mockCategoryRepository) SaveCategory(ctx context.Context, category *domain.Category) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.saved = category
+	return nil
+}
+
+func (m *mockCategoryRepository) GetCategory(ctx context.Context, code string) (*domain.Category, error) {
+	category, ok := m.categories[code]
+	if !ok {
+		return nil, domain.ErrCategoryNotFound
+	}
+	categoryCopy := *category
+	return &categoryCopy, nil
+}
+
+func (m *mockCategoryRepository) ListCategories(ctx context.Context) ([]*domain.Category, error) {
+	return nil, nil
+}
+
+func (m *mockCategoryRepository) ListChildren(ctx context.Context, code string) ([]*domain.Category, error) {
+	return nil, nil
+}
+
+func (m *mockCategoryRepository) UpdateCategory(ctx context.Context, category *domain.Category) error {
+	return m.SaveCategory(ctx, category)
+}
+
+func (m *mockCategoryRepository) DeleteCategory(ctx context.Context, category *domain.Category) error {
+	return m.SaveCategory(ctx, category)
+}
+
+func (m *mockCategoryRepository) AssignFabric(ctx context.Context, category *domain.Category, fabricCode string) error {
+	m.assigned = fabricCode
+	return m.SaveCategory(ctx, category)
+}
+
+func (m *mockCategoryRepository) UnassignFabric(ctx context.Context, category *domain.Category, fabricCode string) error {
+	return m.SaveCategory(ctx, category)
+}
+
+func (m *mockCategoryRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	if canonical, ok := m.aliases[code]; ok {
+		return canonical, nil
+	}
+	return "", domain.ErrFabricNotFound
+}
+
+func (m *mockCategoryRepository) ListCategoryFabrics(
+	ctx context.Context, code string, limit, offset int,
+) ([]*domain.CategoryFabric, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockCategoryRepository) ListFabricCategories(ctx context.Context, fabricCode string) ([]*domain.Category, error) {
+	return nil, nil
+}
+
+type mockEventStore struct {
+	SavedCalled      bool
+	EnqueuedSubject  string
+	EnqueuedEnvelope *messaging.EventEnvelope
+}
+
+func (m *mockEventStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
+	m.SavedCalled = true
+	return nil
+}
+
+func (m *mockEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.SavedCalled = true
+	m.EnqueuedSubject = subject
+	m.EnqueuedEnvelope = envelopes[len(envelopes)-1]
+	return nil
+}
+
+func newTestRepository() *mockCategoryRepository {
+	root := &domain.Category{Code: "UPHOLSTERY", Name: "Upholstery", Path: []string{}, Version: 1}
+	return &mockCategoryRepository{
+		categories: map[string]*domain.Category{root.Code: root},
+		aliases:    map[string]string{"VELVET01": "VELVET01", "OLDVELVET": "VELVET01"},
+	}
+}
+
+func TestCategoryService_CreateCategory_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewCategoryCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	category, err := service.CreateCategory(context.Background(), "VELVETS", "Velvets", "UPHOLSTERY")
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, repo.saved, "expected SaveCategory() to be called on the repository")
+	assert.Equal(t, "UPHOLSTERY", category.ParentCode)
+	assert.Equal(t, []string{"UPHOLSTERY"}, category.Path)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.category", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.category.created", publishedEnvelope.EventType)
+	assert.Equal(t, "Category", publishedEnvelope.AggregateType)
+	assert.Equal(t, "VELVETS", publishedEnvelope.AggregateID)
+	assert.Equal(t, 1, publishedEnvelope.AggregateVersion)
+}
+
+func TestCategoryService_CreateCategory_UnknownParent(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewCategoryCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	_, err := service.CreateCategory(context.Background(), "VELVETS", "Velvets", "CURTAINS")
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, domain.ErrParentNotFound)
+	assert.Nil(t, repo.saved)
+	assert.False(t, eventStore.SavedCalled)
+}
+
+func TestCategoryService_AssignFabric_ResolvesAlias(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewCategoryCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	category, err := service.AssignFabric(context.Background(), "UPHOLSTERY", "OLDVELVET")
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, "VELVET01", repo.assigned)
+	assert.Equal(t, 2, category.Version)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.category.fabric_assigned", publishedEnvelope.EventType)
+	payload, ok := publishedEnvelope.Payload.(domain.CategoryFabricAssigned)
+	require.True(t, ok, "payload should be of type domain.CategoryFabricAssigned")
+	assert.Equal(t, "VELVET01", payload.FabricCode)
+}
+
+func TestCategoryService_RejectedIsNotPublished(t *testing.T) {
+	testCases := []struct {
+		name        string
+		errToReturn error
+		run         func(s *CategoryService) error
+		expectedErr error
+	}{
+		{
+			name: "Stale version",
+			run: func(s *CategoryService) error {
+				_, err := s.UpdateCategory(context.Background(), "UPHOLSTERY", "Upholstery", "", 2)
+				return err
+			},
+			expectedErr: domain.ErrConcurrencyConflict,
+		},
+		{
+			name: "Unknown fabric",
+			run: func(s *CategoryService) error {
+				_, err := s.AssignFabric(context.Background(), "UPHOLSTERY", "NOSUCHFABRIC")
+				return err
+			},
+			expectedErr: domain.ErrFabricNotFound,
+		},
+		{
+			name:        "Subcategories left",
+			errToReturn: domain.ErrCategoryHasChildren,
+			run: func(s *CategoryService) error {
+				return s.DeleteCategory(context.Background(), "UPHOLSTERY", 1)
+			},
+			expectedErr: domain.ErrCategoryHasChildren,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			repo.errToReturn = tc.errToReturn
+			eventStore := &mockEventStore{}
+			service := NewCategoryCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err := tc.run(service)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, repo.saved)
+			assert.False(t, eventStore.SavedCalled, "a rejected command must not be stored")
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"context"
+	"slices"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+var (
+	ErrCategoryNotFound      = notFoundError("category not found")
+	ErrFabricNotFound        = notFoundError("fabric not found")
+	ErrParentNotFound        = &CategoryError{Kind: "invalid", Message: "parent category does not exist"}
+	ErrDuplicateCategoryCode = conflictError("a category with this code already exists")
+	ErrConcurrencyConflict   = conflictError("the category has been modified by another process, please refresh and try again")
+	ErrCategoryCycle         = conflictError("a category cannot be placed under itself or one of its subcategories")
+	ErrCategoryHasChildren   = conflictError("a category with subcategories cannot be deleted")
+	ErrFabricAlreadyAssigned = conflictError("the fabric is already assigned to this category")
+	ErrFabricNotAssigned     = conflictError("the fabric is not assigned to this category")
+)
+
+// CategoryError is a rule violation reported by the category domain. Its kind tells the
+// handler which status to answer with and instrumentation how to class it.
+type CategoryError struct {
+	Kind    string
+	Message string
+}
+
+func (e *CategoryError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *CategoryError) ErrorClass() string {
+	return e.Kind
+}
+
+func notFoundError(message string) *CategoryError {
+	return &CategoryError{Kind: "not_found", Message: message}
+}
+
+func conflictError(message string) *CategoryError {
+	return &CategoryError{Kind: "conflict", Message: message}
+}
+
+type Event = aggregate.Event
+
+// Stamp identifies who performed a change on a category and when it happened.
+type Stamp = aggregate.Stamp
+
+// Category is a node of the fabric taxonomy. A category without a parent is a root, and a
+// fabric can be assigned to any number of categories at any level.
+type Category struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	ParentCode string `json:"parent_code,omitempty"`
+	// Path holds the codes of the ancestors from the root down to the parent. It is derived
+	// from the parent links when the category is loaded and never stored.
+	Path    []string `json:"path"`
+	Version int      `json:"version"`
+	aggregate.Audit
+	aggregate.Root
+}
+
+// CategoryFabric is a fabric assigned to a category or to one of its subcategories.
+type CategoryFabric struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	CategoryCode string `json:"category_code"`
+}
+
+type CategoryCreated struct {
+	Code       string
+	Name       string
+	ParentCode string
+	Version    int
+}
+
+// CategoryUpdated is recorded when a category is renamed or moved under another parent.
+type CategoryUpdated struct {
+	Code       string
+	Name       string
+	ParentCode string
+	Version    int
+}
+
+type CategoryDeleted struct {
+	Code    string
+	Version int
+}
+
+type CategoryFabricAssigned struct {
+	Code       string
+	FabricCode string
+	Version    int
+}
+
+type CategoryFabricUnassigned struct {
+	Code       string
+	FabricCode string
+	Version    int
+}
+
+// NewCategory creates a category under the parent, or a root category when parent is nil.
+func NewCategory(code, name string, parent *Category, stamp Stamp) *Category {
+	category := &Category{
+		Code:    code,
+		Name:    name,
+		Path:    []string{},
+		Version: 1,
+		Audit:   aggregate.NewAudit(stamp),
+	}
+	category.placeUnder(parent)
+
+	event := CategoryCreated{
+		Code:       category.Code,
+		Name:       category.Name,
+		ParentCode: category.ParentCode,
+		Version:    category.Version,
+	}
+	category.Record(event)
+	return category
+}
+
+// Update renames the category and places it under the parent, or at the root when parent
+// is nil. Its subcategories move along with it.
+func (c *Category) Update(name string, parent *Category, version int, stamp Stamp) error {
+	if err := aggregate.CheckVersion(c.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+	if parent != nil && (parent.Code == c.Code || slices.Contains(parent.Path, c.Code)) {
+		return ErrCategoryCycle
+	}
+
+	c.Name = name
+	c.placeUnder(parent)
+	c.Version++
+	c.Touch(stamp)
+
+	event := CategoryUpdated{
+		Code:       c.Code,
+		Name:       c.Name,
+		ParentCode: c.ParentCode,
+		Version:    c.Version,
+	}
+	c.Record(event)
+	return nil
+}
+
+// Delete removes the category together with its fabric assignments. Only a category
+// without subcategories can be deleted, which the repository enforces.
+func (c *Category) Delete(version int, stamp Stamp) error {
+	if err := aggregate.CheckVersion(c.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	c.Version++
+	c.Touch(stamp)
+
+	event := CategoryDeleted{
+		Code:    c.Code,
+		Version: c.Version,
+	}
+	c.Record(event)
+	return nil
+}
+
+// AssignFabric records that the fabric, given by its canonical code, belongs to the category.
+func (c *Category) AssignFabric(fabricCode string, stamp Stamp) {
+	c.Version++
+	c.Touch(stamp)
+
+	event := CategoryFabricAssigned{
+		Code:       c.Code,
+		FabricCode: fabricCode,
+		Version:    c.Version,
+	}
+	c.Record(event)
+}
+
+func (c *Category) UnassignFabric(fabricCode string, stamp Stamp) {
+	c.Version++
+	c.Touch(stamp)
+
+	event := CategoryFabricUnassigned{
+		Code:       c.Code,
+		FabricCode: fabricCode,
+		Version:    c.Version,
+	}
+	c.Record(event)
+}
+
+func (c *Category) placeUnder(parent *Category) {
+	if parent == nil {
+		c.ParentCode = ""
+		c.Path = []string{}
+		return
+	}
+	c.ParentCode = parent.Code
+	c.Path = append(slices.Clone(parent.Path), parent.Code)
+}
+
+type CategoryRepository interface {
+	// SaveCategory stores a new category, failing with ErrDuplicateCategoryCode when the
+	// code is taken.
+	SaveCategory(ctx context.Context, category *Category) error
+	GetCategory(ctx context.Context, code string) (*Category, error)
+	// ListCategories returns the whole taxonomy, every category right after its parent.
+	ListCategories(ctx context.Context) ([]*Category, error)
+	ListChildren(ctx context.Context, code string) ([]*Category, error)
+	// UpdateCategory, DeleteCategory, AssignFabric and UnassignFabric store a change of a
+	// category still at the version it was loaded with, or fail with ErrConcurrencyConflict.
+	UpdateCategory(ctx context.Context, category *Category) error
+	DeleteCategory(ctx context.Context, category *Category) error
+	AssignFabric(ctx context.Context, category *Category, fabricCode string) error
+	UnassignFabric(ctx context.Context, category *Category, fabricCode string) error
+	// ResolveFabricCode returns the canonical code of an active fabric given by its code or
+	// one of its aliases.
+	ResolveFabricCode(ctx context.Context, code string) (string, error)
+	// ListCategoryFabrics returns a page of the fabrics assigned to the category or to any
+	// of its subcategories, together with their total number.
+	ListCategoryFabrics(ctx context.Context, code string, limit, offset int) ([]*CategoryFabric, int, error)
+	ListFabricCategories(ctx context.Context, fabricCode string) ([]*Category, error)
+}
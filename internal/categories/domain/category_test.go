@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func TestNewCategory_PlacedUnderParent(t *testing.T) {
+	// --- Arrange ---
+	root := NewCategory("UPHOLSTERY", "Upholstery", nil, testStamp)
+
+	// --- Act ---
+	category := NewCategory("VELVETS", "Velvets", root, testStamp)
+
+	// --- Assert ---
+	assert.Equal(t, "UPHOLSTERY", category.ParentCode)
+	assert.Equal(t, []string{"UPHOLSTERY"}, category.Path)
+	assert.Equal(t, 1, category.Version)
+	require.Len(t, category.Events(), 1)
+	created, ok := category.Events()[0].(CategoryCreated)
+	require.True(t, ok, "expected a CategoryCreated event")
+	assert.Equal(t, "UPHOLSTERY", created.ParentCode)
+}
+
+func TestCategory_Update_MovesToRoot(t *testing.T) {
+	// --- Arrange ---
+	root := NewCategory("UPHOLSTERY", "Upholstery", nil, testStamp)
+	category := NewCategory("VELVETS", "Velvets", root, testStamp)
+
+	// --- Act ---
+	err := category.Update("All Velvets", nil, 1, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Empty(t, category.ParentCode)
+	assert.Empty(t, category.Path)
+	assert.Equal(t, 2, category.Version)
+	updated, ok := category.Events()[1].(CategoryUpdated)
+	require.True(t, ok, "expected a CategoryUpdated event")
+	assert.Equal(t, "All Velvets", updated.Name)
+}
+
+func TestCategory_Update_Rejected(t *testing.T) {
+	root := NewCategory("UPHOLSTERY", "Upholstery", nil, testStamp)
+	velvets := NewCategory("VELVETS", "Velvets", root, testStamp)
+	crushed := NewCategory("CRUSHED", "Crushed Velvets", velvets, testStamp)
+
+	testCases := []struct {
+		name        string
+		parent      *Category
+		version     int
+		expectedErr error
+	}{
+		{name: "Stale version", parent: nil, version: 2, expectedErr: ErrConcurrencyConflict},
+		{name: "Under itself", parent: root, version: 1, expectedErr: ErrCategoryCycle},
+		{name: "Under own subcategory", parent: crushed, version: 1, expectedErr: ErrCategoryCycle},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			err := root.Update("Upholstery", tc.parent, tc.version, testStamp)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, 1, root.Version, "a rejected update must not change the category")
+			assert.Empty(t, root.ParentCode)
+		})
+	}
+}
+
+func TestCategory_AssignFabric(t *testing.T) {
+	// --- Arrange ---
+	category := NewCategory("VELVETS", "Velvets", nil, testStamp)
+
+	// --- Act ---
+	category.AssignFabric("VELVET01", testStamp)
+	category.UnassignFabric("VELVET01", testStamp)
+
+	// --- Assert ---
+	assert.Equal(t, 3, category.Version)
+	require.Len(t, category.Events(), 3)
+	unassigned, ok := category.Events()[2].(CategoryFabricUnassigned)
+	require.True(t, ok, "expected a CategoryFabricUnassigned event")
+	assert.Equal(t, "VELVET01", unassigned.FabricCode)
+	assert.Equal(t, 3, unassigned.Version)
+}
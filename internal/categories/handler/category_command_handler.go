@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+
+	"github.com/salesworks/s-works/api/internal/categories/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+var categoryCodeRX = regexp.MustCompile("^[A-Z0-9-]+$")
+
+// CategoryCommandService maintains the taxonomy and the fabrics assigned to it.
+type CategoryCommandService interface {
+	CreateCategory(ctx context.Context, code, name, parentCode string) (*domain.Category, error)
+	UpdateCategory(ctx context.Context, code, name, parentCode string, version int) (*domain.Category, error)
+	DeleteCategory(ctx context.Context, code string, version int) error
+	AssignFabric(ctx context.Context, code, fabricCode string) (*domain.Category, error)
+	UnassignFabric(ctx context.Context, code, fabricCode string) (*domain.Category, error)
+}
+
+// CategoryCommandHandler creates, updates and deletes categories and assigns fabrics to them.
+type CategoryCommandHandler struct {
+	service CategoryCommandService
+}
+
+type createCategoryRequest struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	ParentCode string `json:"parent_code"`
+}
+
+// an empty parent code moves the category to the root
+type updateCategoryRequest struct {
+	Name       string `json:"name"`
+	ParentCode string `json:"parent_code"`
+	Version    int    `json:"version"`
+}
+
+type deleteCategoryRequest struct {
+	Version int `json:"version"`
+}
+
+func NewCategoryCommandHandler(service CategoryCommandService) *CategoryCommandHandler {
+	return &CategoryCommandHandler{service: service}
+}
+
+func (h *CategoryCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+	r = r.WithContext(ctx)
+
+	if httpx.URLParam(r, "fabricCode") != "" {
+		switch r.Method {
+		case http.MethodPut:
+			h.assignFabric(w, r)
+		case http.MethodDelete:
+			h.unassignFabric(w, r)
+		default:
+			httpx.MethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		h.createCategory(w, r)
+	case http.MethodPut:
+		h.updateCategory(w, r)
+	case http.MethodDelete:
+		h.deleteCategory(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *CategoryCommandHandler) createCategory(w http.ResponseWriter, r *http.Request) {
+	var req createCategoryRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Code = validator.NormalizeCode(req.Code)
+	req.Name = validator.NormalizeText(req.Name)
+	req.ParentCode = validator.NormalizeCode(req.ParentCode)
+	v := validator.New()
+	v.Check(req.Code != "", "code", "code must be provided")
+	v.Check(len(req.Code) >= 2 && len(req.Code) <= 30, "code", "code must be between 2 and 30 characters long")
+	v.Check(validator.Matches(req.Code, categoryCodeRX), "code", "code must only contain uppercase letters, numbers and dashes")
+	validateCategoryName(v, req.Name)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	category, err := h.service.CreateCategory(r.Context(), req.Code, req.Name, req.ParentCode)
+	if err != nil {
+		writeCategoryError(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", "/v1/categories/"+category.Code)
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"category": category}, headers); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *CategoryCommandHandler) updateCategory(w http.ResponseWriter, r *http.Request) {
+	var req updateCategoryRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Name = validator.NormalizeText(req.Name)
+	req.ParentCode = validator.NormalizeCode(req.ParentCode)
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	validateCategoryName(v, req.Name)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	category, err := h.service.UpdateCategory(
+		r.Context(), httpx.URLParam(r, "code"), req.Name, req.ParentCode, req.Version,
+	)
+	if err != nil {
+		writeCategoryError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"category": category}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *CategoryCommandHandler) deleteCategory(w http.ResponseWriter, r *http.Request) {
+	var req deleteCategoryRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	if err := h.service.DeleteCategory(r.Context(), httpx.URLParam(r, "code"), req.Version); err != nil {
+		writeCategoryError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CategoryCommandHandler) assignFabric(w http.ResponseWriter, r *http.Request) {
+	category, err := h.service.AssignFabric(
+		r.Context(), httpx.URLParam(r, "code"), validator.NormalizeCode(httpx.URLParam(r, "fabricCode")),
+	)
+	if err != nil {
+		writeCategoryError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"category": category}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *CategoryCommandHandler) unassignFabric(w http.ResponseWriter, r *http.Request) {
+	category, err := h.service.UnassignFabric(
+		r.Context(), httpx.URLParam(r, "code"), validator.NormalizeCode(httpx.URLParam(r, "fabricCode")),
+	)
+	if err != nil {
+		writeCategoryError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"category": category}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func validateCategoryName(v *validator.Validator, name string) {
+	v.Check(name != "", "name", "name must be provided")
+	v.Check(len(name) <= 250, "name", "name must not be more than 250 characters long")
+}
+
+// writeCategoryError answers a failed command with the status matching the kind of
+// category error, anything that is not a category error is answered as an internal error.
+func writeCategoryError(w http.ResponseWriter, r *http.Request, err error) {
+	var categoryErr *domain.CategoryError
+	if !errors.As(err, &categoryErr) {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch categoryErr.Kind {
+	case "invalid":
+		httpx.ValidationError(w, r, map[string]string{"parent_code": categoryErr.Message})
+	case "not_found":
+		httpx.NotFound(w, r)
+	default:
+		httpx.ErrorJSON(w, http.StatusConflict, categoryErr.Message)
+	}
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/categories/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCategoryCommandService struct {
+	called      string
+	code        string
+	parentCode  string
+	fabricCode  string
+	version     int
+	errToReturn error
+}
+
+func (m *mockCategoryCommandService) CreateCategory(
+	ctx context.Context, code, name, parentCode string,
+) (*domain.Category, error) {
+	m.called, m.code, m.parentCode = "create", code, parentCode
+	return m.result(code, 1)
+}
+
+func (m *mockCategoryCommandService) UpdateCategory(
+	ctx context.Context, code, name, parentCode string, version int,
+) (*domain.Category, error) {
+	m.called, m.code, m.parentCode, m.version = "update", code, parentCode, version
+	return m.result(code, version+1)
+}
+
+func (m *mockCategoryCommandService) DeleteCategory(ctx context.Context, code string, version int) error {
+	m.called, m.code, m.version = "delete", code, version
+	return m.errToReturn
+}
+
+func (m *mockCategoryCommandService) AssignFabric(ctx context.Context, code, fabricCode string) (*domain.Category, error) {
+	m.called, m.code, m.fabricCode = "assign", code, fabricCode
+	return m.result(code, 2)
+}
+
+func (m *mockCategoryCommandService) UnassignFabric(ctx context.Context, code, fabricCode string) (*domain.Category, error) {
+	m.called, m.code, m.fabricCode = "unassign", code, fabricCode
+	return m.result(code, 2)
+}
+
+func (m *mockCategoryCommandService) result(code string, version int) (*domain.Category, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Category{Code: code, Path: []string{}, Version: version}, nil
+}
+
+func serveCategory(
+	t *testing.T, handler http.Handler, method, target, body string, params map[string]string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestCategoryCommandHandler_CreateCategory(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockCategoryCommandService{}
+	handler := NewCategoryCommandHandler(svc)
+	body := `{"code": " velvets ", "name": "Velvets", "parent_code": "upholstery"}`
+
+	// --- Act ---
+	responseRecorder := serveCategory(t, handler, http.MethodPost, "/v1/categories", body, nil)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, "/v1/categories/VELVETS", responseRecorder.Header().Get("Location"))
+	assert.Equal(t, "VELVETS", svc.code)
+	assert.Equal(t, "UPHOLSTERY", svc.parentCode)
+}
+
+func TestCategoryCommandHandler_AssignFabric(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockCategoryCommandService{}
+	handler := NewCategoryCommandHandler(svc)
+	params := map[string]string{"code": "VELVETS", "fabricCode": "velvet01"}
+
+	// --- Act ---
+	responseRecorder := serveCategory(
+		t, handler, http.MethodPut, "/v1/categories/VELVETS/fabrics/velvet01", "", params,
+	)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "assign", svc.called)
+	assert.Equal(t, "VELVET01", svc.fabricCode)
+}
+
+func TestCategoryCommandHandler_Rejected(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		params         map[string]string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{
+			name: "invalid code", method: http.MethodPost, body: `{"code": "velvets!", "name": "Velvets"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing version", method: http.MethodPut, body: `{"name": "Velvets"}`,
+			params: map[string]string{"code": "VELVETS"}, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "unknown parent", method: http.MethodPost, body: `{"code": "VELVETS", "name": "Velvets", "parent_code": "NONE"}`,
+			errToReturn: domain.ErrParentNotFound, expectedStatus: http.StatusUnprocessableEntity, expectedCall: true,
+		},
+		{
+			name: "duplicate code", method: http.MethodPost, body: `{"code": "VELVETS", "name": "Velvets"}`,
+			errToReturn: domain.ErrDuplicateCategoryCode, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "moved under own subcategory", method: http.MethodPut, body: `{"name": "Velvets", "parent_code": "CRUSHED", "version": 1}`,
+			params:      map[string]string{"code": "VELVETS"},
+			errToReturn: domain.ErrCategoryCycle, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "unknown category", method: http.MethodDelete, body: `{"version": 1}`,
+			params:      map[string]string{"code": "NONE"},
+			errToReturn: domain.ErrCategoryNotFound, expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+		{
+			name: "subcategories left", method: http.MethodDelete, body: `{"version": 1}`,
+			params:      map[string]string{"code": "UPHOLSTERY"},
+			errToReturn: domain.ErrCategoryHasChildren, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockCategoryCommandService{errToReturn: tc.errToReturn}
+			handler := NewCategoryCommandHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveCategory(t, handler, tc.method, "/v1/categories", tc.body, tc.params)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called != "")
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/categories/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// CategoryQueryRepository reads the taxonomy and the fabrics assigned to it.
+type CategoryQueryRepository interface {
+	GetCategory(ctx context.Context, code string) (*domain.Category, error)
+	ListCategories(ctx context.Context) ([]*domain.Category, error)
+	ListChildren(ctx context.Context, code string) ([]*domain.Category, error)
+	ResolveFabricCode(ctx context.Context, code string) (string, error)
+	ListCategoryFabrics(ctx context.Context, code string, limit, offset int) ([]*domain.CategoryFabric, int, error)
+	ListFabricCategories(ctx context.Context, fabricCode string) ([]*domain.Category, error)
+}
+
+// CategoryQueryHandler serves the taxonomy, the fabrics of a category and the categories
+// of a fabric.
+type CategoryQueryHandler struct {
+	categories CategoryQueryRepository
+	pagination httpx.PaginationConfig
+}
+
+func NewCategoryQueryHandler(categories CategoryQueryRepository, pagination httpx.PaginationConfig) *CategoryQueryHandler {
+	return &CategoryQueryHandler{
+		categories: categories,
+		pagination: pagination,
+	}
+}
+
+func (h *CategoryQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	switch {
+	case httpx.URLParam(r, "fabricCode") != "":
+		h.listFabricCategories(w, r)
+	case httpx.URLParam(r, "code") == "":
+		h.listCategories(w, r)
+	case strings.HasSuffix(r.URL.Path, "/fabrics"):
+		h.listCategoryFabrics(w, r)
+	default:
+		h.getCategory(w, r)
+	}
+}
+
+// listCategories returns the whole taxonomy, every category right after its parent.
+func (h *CategoryQueryHandler) listCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.categories.ListCategories(r.Context())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"categories": categories}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *CategoryQueryHandler) getCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	category, err := h.categories.GetCategory(ctx, httpx.URLParam(r, "code"))
+	if err != nil {
+		writeCategoryError(w, r, err)
+		return
+	}
+	children, err := h.categories.ListChildren(ctx, category.Code)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"category": category, "children": children}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// listCategoryFabrics pages through the fabrics of the category and of all its subcategories.
+func (h *CategoryQueryHandler) listCategoryFabrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	category, err := h.categories.GetCategory(ctx, httpx.URLParam(r, "code"))
+	if err != nil {
+		writeCategoryError(w, r, err)
+		return
+	}
+
+	fabrics, totalRecords, err := h.categories.ListCategoryFabrics(ctx, category.Code, page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabrics": fabrics, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// listFabricCategories returns the categories the fabric, given by its code or one of its
+// aliases, is directly assigned to.
+func (h *CategoryQueryHandler) listFabricCategories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	fabricCode, err := h.categories.ResolveFabricCode(ctx, validator.NormalizeCode(httpx.URLParam(r, "fabricCode")))
+	if err != nil {
+		if errors.Is(err, domain.ErrFabricNotFound) {
+			httpx.NotFound(w, r)
+			return
+		}
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	categories, err := h.categories.ListFabricCategories(ctx, fabricCode)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"fabric_code": fabricCode, "categories": categories}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/categories/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCategoryQueryRepository struct {
+	listedCode   string
+	listedLimit  int
+	listedOffset int
+}
+
+func (m *mockCategoryQueryRepository) GetCategory(ctx context.Context, code string) (*domain.Category, error) {
+	if code != "UPHOLSTERY" {
+		return nil, domain.ErrCategoryNotFound
+	}
+	return &domain.Category{Code: code, Name: "Upholstery", Path: []string{}, Version: 1}, nil
+}
+
+func (m *mockCategoryQueryRepository) ListCategories(ctx context.Context) ([]*domain.Category, error) {
+	return []*domain.Category{
+		{Code: "UPHOLSTERY", Path: []string{}},
+		{Code: "VELVETS", ParentCode: "UPHOLSTERY", Path: []string{"UPHOLSTERY"}},
+	}, nil
+}
+
+func (m *mockCategoryQueryRepository) ListChildren(ctx context.Context, code string) ([]*domain.Category, error) {
+	return []*domain.Category{{Code: "VELVETS", ParentCode: code, Path: []string{code}}}, nil
+}
+
+func (m *mockCategoryQueryRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	if code == "OLDVELVET" {
+		return "VELVET01", nil
+	}
+	return "", domain.ErrFabricNotFound
+}
+
+func (m *mockCategoryQueryRepository) ListCategoryFabrics(
+	ctx context.Context, code string, limit, offset int,
+) ([]*domain.CategoryFabric, int, error) {
+	m.listedCode, m.listedLimit, m.listedOffset = code, limit, offset
+	return []*domain.CategoryFabric{{Code: "VELVET01", Name: "Velvet", CategoryCode: "VELVETS"}}, 21, nil
+}
+
+func (m *mockCategoryQueryRepository) ListFabricCategories(ctx context.Context, fabricCode string) ([]*domain.Category, error) {
+	return []*domain.Category{{Code: "VELVETS", ParentCode: "UPHOLSTERY", Path: []string{"UPHOLSTERY"}}}, nil
+}
+
+func TestCategoryQueryHandler_GetCategory(t *testing.T) {
+	// --- Arrange ---
+	handler := NewCategoryQueryHandler(&mockCategoryQueryRepository{}, httpx.PaginationConfig{})
+
+	// --- Act ---
+	responseRecorder := serveCategory(
+		t, handler, http.MethodGet, "/v1/categories/UPHOLSTERY", "", map[string]string{"code": "UPHOLSTERY"},
+	)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	var body struct {
+		Category domain.Category   `json:"category"`
+		Children []domain.Category `json:"children"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	assert.Equal(t, "UPHOLSTERY", body.Category.Code)
+	require.Len(t, body.Children, 1)
+	assert.Equal(t, "VELVETS", body.Children[0].Code)
+}
+
+func TestCategoryQueryHandler_ListCategoryFabrics(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockCategoryQueryRepository{}
+	pagination := httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+	handler := NewCategoryQueryHandler(repo, pagination)
+
+	// --- Act ---
+	responseRecorder := serveCategory(
+		t, handler, http.MethodGet, "/v1/categories/UPHOLSTERY/fabrics?page=2", "",
+		map[string]string{"code": "UPHOLSTERY"},
+	)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "UPHOLSTERY", repo.listedCode)
+	assert.Equal(t, 20, repo.listedLimit)
+	assert.Equal(t, 20, repo.listedOffset)
+}
+
+func TestCategoryQueryHandler_NotFound(t *testing.T) {
+	testCases := []struct {
+		name   string
+		target string
+		params map[string]string
+	}{
+		{name: "unknown category", target: "/v1/categories/NONE", params: map[string]string{"code": "NONE"}},
+		{name: "fabrics of unknown category", target: "/v1/categories/NONE/fabrics", params: map[string]string{"code": "NONE"}},
+		{name: "categories of unknown fabric", target: "/v1/fabrics/NONE/categories", params: map[string]string{"fabricCode": "NONE"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			pagination := httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+			handler := NewCategoryQueryHandler(&mockCategoryQueryRepository{}, pagination)
+
+			// --- Act ---
+			responseRecorder := serveCategory(t, handler, http.MethodGet, tc.target, "", tc.params)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/categories/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+// categoryTreeSQL derives the path of every category from the parent links: the codes of
+// its ancestors joined with "/", and the same codes as an array to order the tree by, so
+// every category comes right after its parent.
+const categoryTreeSQL = `
+	WITH RECURSIVE tree AS (
+		SELECT code, ''::TEXT AS path, ARRAY[code]::TEXT[] AS sort_key
+		FROM categories WHERE parent_code IS NULL
+		UNION ALL
+		SELECT c.code, CASE WHEN t.path = '' THEN t.code ELSE t.path || '/' || t.code END, t.sort_key || c.code::TEXT
+		FROM categories c JOIN tree t ON c.parent_code = t.code
+	)
+`
+
+const categoryColumns = `c.code, c.name, COALESCE(c.parent_code, ''), t.path, c.version,
+	c.created_at, c.created_by, c.updated_at, c.updated_by`
+
+// resolves a fabric code, or one of its aliases, to the canonical code
+const canonicalFabricCodeSQL = `COALESCE((SELECT canonical_code FROM fabric_aliases WHERE alias_code = $1), $1)`
+
+type CategoryPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewCategoryPostgresRepository(db *database.PostgresDB) *CategoryPostgresRepository {
+	return &CategoryPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *CategoryPostgresRepository) SaveCategory(ctx context.Context, category *domain.Category) error {
+	query := `
+		INSERT INTO categories (code, name, parent_code, version, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		category.Code, category.Name, category.ParentCode, category.Version,
+		category.CreatedAt, category.CreatedBy, category.UpdatedAt, category.UpdatedBy,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return domain.ErrDuplicateCategoryCode
+			case "23503":
+				// the parent was deleted since it was loaded
+				return domain.ErrCategoryNotFound
+			}
+		}
+		return fmt.Errorf("failed to insert category: %w", err)
+	}
+	return nil
+}
+
+func (r *CategoryPostgresRepository) GetCategory(ctx context.Context, code string) (*domain.Category, error) {
+	query := categoryTreeSQL + `
+		SELECT ` + categoryColumns + `
+		FROM categories c JOIN tree t ON t.code = c.code
+		WHERE c.code = $1
+	`
+	category, err := scanCategory(r.db.Conn(ctx).QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return category, nil
+}
+
+func (r *CategoryPostgresRepository) ListCategories(ctx context.Context) ([]*domain.Category, error) {
+	query := categoryTreeSQL + `
+		SELECT ` + categoryColumns + `
+		FROM categories c JOIN tree t ON t.code = c.code
+		ORDER BY t.sort_key
+	`
+	return r.queryCategories(ctx, query)
+}
+
+func (r *CategoryPostgresRepository) ListChildren(ctx context.Context, code string) ([]*domain.Category, error) {
+	query := categoryTreeSQL + `
+		SELECT ` + categoryColumns + `
+		FROM categories c JOIN tree t ON t.code = c.code
+		WHERE c.parent_code = $1
+		ORDER BY c.code
+	`
+	return r.queryCategories(ctx, query, code)
+}
+
+// UpdateCategory renames and moves the category. A move locks the taxonomy against other
+// writes and checks the new parent again, so two concurrent moves cannot form a cycle.
+func (r *CategoryPostgresRepository) UpdateCategory(ctx context.Context, category *domain.Category) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if category.ParentCode != "" {
+		if _, err := tx.ExecContext(ctx, `LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return fmt.Errorf("failed to lock categories: %w", err)
+		}
+
+		var cycle bool
+		err := tx.QueryRowContext(ctx, `
+			WITH RECURSIVE ancestors AS (
+				SELECT code, parent_code FROM categories WHERE code = $1
+				UNION ALL
+				SELECT c.code, c.parent_code FROM categories c JOIN ancestors a ON c.code = a.parent_code
+			)
+			SELECT EXISTS (SELECT 1 FROM ancestors WHERE code = $2)
+		`, category.ParentCode, category.Code).Scan(&cycle)
+		if err != nil {
+			return fmt.Errorf("failed to check category ancestors: %w", err)
+		}
+		if cycle {
+			return domain.ErrCategoryCycle
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE categories
+		SET name = $1, parent_code = NULLIF($2, ''), version = $3, updated_at = $4, updated_by = $5
+		WHERE code = $6 AND version = $7
+	`, category.Name, category.ParentCode, category.Version, category.UpdatedAt, category.UpdatedBy,
+		category.Code, category.Version-1)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.ErrCategoryNotFound
+		}
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+	if err := expectOneRow(result); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteCategory removes a category without subcategories along with its fabric assignments.
+func (r *CategoryPostgresRepository) DeleteCategory(ctx context.Context, category *domain.Category) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx,
+		`DELETE FROM categories WHERE code = $1 AND version = $2`, category.Code, category.Version-1,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.ErrCategoryHasChildren
+		}
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	return expectOneRow(result)
+}
+
+func (r *CategoryPostgresRepository) AssignFabric(
+	ctx context.Context, category *domain.Category, fabricCode string,
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO category_fabrics (category_code, fabric_code, assigned_at, assigned_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (category_code, fabric_code) DO NOTHING
+	`, category.Code, fabricCode, category.UpdatedAt, category.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to assign fabric to category: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrFabricAlreadyAssigned
+	}
+
+	if err := bumpVersion(ctx, tx, category); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *CategoryPostgresRepository) UnassignFabric(
+	ctx context.Context, category *domain.Category, fabricCode string,
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM category_fabrics WHERE category_code = $1 AND fabric_code = $2`,
+		category.Code, fabricCode,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unassign fabric from category: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrFabricNotAssigned
+	}
+
+	if err := bumpVersion(ctx, tx, category); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *CategoryPostgresRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	var canonicalCode string
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		`SELECT code FROM fabrics WHERE code = `+canonicalFabricCodeSQL+` AND status = 'ACTIVE'`, code,
+	).Scan(&canonicalCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrFabricNotFound
+		}
+		return "", fmt.Errorf("failed to resolve fabric code: %w", err)
+	}
+	return canonicalCode, nil
+}
+
+// ListCategoryFabrics returns a page of the active fabrics assigned to the category or to
+// its subcategories, by fabric code. A fabric assigned at several levels is listed under
+// the first category in code order.
+func (r *CategoryPostgresRepository) ListCategoryFabrics(
+	ctx context.Context, code string, limit, offset int,
+) ([]*domain.CategoryFabric, int, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT code FROM categories WHERE code = $1
+			UNION ALL
+			SELECT c.code FROM categories c JOIN subtree s ON c.parent_code = s.code
+		)
+		SELECT count(*) OVER(), f.code, f.name, MIN(cf.category_code)
+		FROM category_fabrics cf
+		JOIN subtree s ON s.code = cf.category_code
+		JOIN fabrics f ON f.code = cf.fabric_code AND f.status = 'ACTIVE'
+		GROUP BY f.code, f.name
+		ORDER BY f.code
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, code, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list category fabrics: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	fabrics := []*domain.CategoryFabric{}
+	for rows.Next() {
+		fabric := &domain.CategoryFabric{}
+		if err := rows.Scan(&totalRecords, &fabric.Code, &fabric.Name, &fabric.CategoryCode); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan category fabric: %w", err)
+		}
+		fabrics = append(fabrics, fabric)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate category fabrics: %w", err)
+	}
+
+	return fabrics, totalRecords, nil
+}
+
+// ListFabricCategories returns the categories the fabric, given by its code or an alias, is
+// assigned to.
+func (r *CategoryPostgresRepository) ListFabricCategories(
+	ctx context.Context, fabricCode string,
+) ([]*domain.Category, error) {
+	query := categoryTreeSQL + `
+		SELECT ` + categoryColumns + `
+		FROM categories c
+		JOIN tree t ON t.code = c.code
+		JOIN category_fabrics cf ON cf.category_code = c.code
+		WHERE cf.fabric_code = ` + canonicalFabricCodeSQL + `
+		ORDER BY t.sort_key
+	`
+	return r.queryCategories(ctx, query, fabricCode)
+}
+
+func (r *CategoryPostgresRepository) queryCategories(
+	ctx context.Context, query string, args ...any,
+) ([]*domain.Category, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []*domain.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate categories: %w", err)
+	}
+	return categories, nil
+}
+
+// bumpVersion moves the category to its new version, provided nobody else did first.
+func bumpVersion(ctx context.Context, tx *database.Tx, category *domain.Category) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE categories SET version = $1, updated_at = $2, updated_by = $3
+		WHERE code = $4 AND version = $5
+	`, category.Version, category.UpdatedAt, category.UpdatedBy, category.Code, category.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update category version: %w", err)
+	}
+	return expectOneRow(result)
+}
+
+func expectOneRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCategory(row rowScanner) (*domain.Category, error) {
+	category := &domain.Category{}
+	var path string
+	err := row.Scan(
+		&category.Code, &category.Name, &category.ParentCode, &path, &category.Version,
+		&category.CreatedAt, &category.CreatedBy, &category.UpdatedAt, &category.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	category.Path = []string{}
+	if path != "" {
+		category.Path = strings.Split(path, "/")
+	}
+	return category, nil
+}
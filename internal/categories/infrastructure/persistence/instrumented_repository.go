@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/categories/domain"
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+)
+
+// InstrumentedCategoryRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedCategoryRepository struct {
+	next domain.CategoryRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedCategoryRepository(
+	next domain.CategoryRepository, rec *instrument.Recorder,
+) *InstrumentedCategoryRepository {
+	return &InstrumentedCategoryRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedCategoryRepository) SaveCategory(ctx context.Context, category *domain.Category) error {
+	return instrument.Exec(ctx, r.rec, "SaveCategory", func(ctx context.Context) error {
+		return r.next.SaveCategory(ctx, category)
+	})
+}
+
+func (r *InstrumentedCategoryRepository) GetCategory(ctx context.Context, code string) (*domain.Category, error) {
+	return instrument.Call(ctx, r.rec, "GetCategory", func(ctx context.Context) (*domain.Category, error) {
+		return r.next.GetCategory(ctx, code)
+	})
+}
+
+func (r *InstrumentedCategoryRepository) ListCategories(ctx context.Context) ([]*domain.Category, error) {
+	return instrument.Call(ctx, r.rec, "ListCategories", func(ctx context.Context) ([]*domain.Category, error) {
+		return r.next.ListCategories(ctx)
+	})
+}
+
+func (r *InstrumentedCategoryRepository) ListChildren(ctx context.Context, code string) ([]*domain.Category, error) {
+	return instrument.Call(ctx, r.rec, "ListChildren", func(ctx context.Context) ([]*domain.Category, error) {
+		return r.next.ListChildren(ctx, code)
+	})
+}
+
+func (r *InstrumentedCategoryRepository) UpdateCategory(ctx context.Context, category *domain.Category) error {
+	return instrument.Exec(ctx, r.rec, "UpdateCategory", func(ctx context.Context) error {
+		return r.next.UpdateCategory(ctx, category)
+	})
+}
+
+func (r *InstrumentedCategoryRepository) DeleteCategory(ctx context.Context, category *domain.Category) error {
+	return instrument.Exec(ctx, r.rec, "DeleteCategory", func(ctx context.Context) error {
+		return r.next.DeleteCategory(ctx, category)
+	})
+}
+
+func (r *InstrumentedCategoryRepository) AssignFabric(
+	ctx context.Context, category *domain.Category, fabricCode string,
+) error {
+	return instrument.Exec(ctx, r.rec, "AssignFabric", func(ctx context.Context) error {
+		return r.next.AssignFabric(ctx, category, fabricCode)
+	})
+}
+
+func (r *InstrumentedCategoryRepository) UnassignFabric(
+	ctx context.Context, category *domain.Category, fabricCode string,
+) error {
+	return instrument.Exec(ctx, r.rec, "UnassignFabric", func(ctx context.Context) error {
+		return r.next.UnassignFabric(ctx, category, fabricCode)
+	})
+}
+
+func (r *InstrumentedCategoryRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	return instrument.Call(ctx, r.rec, "ResolveFabricCode", func(ctx context.Context) (string, error) {
+		return r.next.ResolveFabricCode(ctx, code)
+	})
+}
+
+func (r *InstrumentedCategoryRepository) ListCategoryFabrics(
+	ctx context.Context, code string, limit, offset int,
+) ([]*domain.CategoryFabric, int, error) {
+	var total int
+	fabrics, err := instrument.Call(ctx, r.rec, "ListCategoryFabrics",
+		func(ctx context.Context) ([]*domain.CategoryFabric, error) {
+			fabrics, count, err := r.next.ListCategoryFabrics(ctx, code, limit, offset)
+			total = count
+			return fabrics, err
+		})
+	return fabrics, total, err
+}
+
+func (r *InstrumentedCategoryRepository) ListFabricCategories(
+	ctx context.Context, fabricCode string,
+) ([]*domain.Category, error) {
+	return instrument.Call(ctx, r.rec, "ListFabricCategories", func(ctx context.Context) ([]*domain.Category, error) {
+		return r.next.ListFabricCategories(ctx, fabricCode)
+	})
+}
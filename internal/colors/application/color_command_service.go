@@ -0,0 +1,173 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/salesworks/s-works/api/internal/colors/domain"
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ColorService maintains the colors and publishes their events.
+type ColorService struct {
+	repo         domain.ColorRepository
+	eventStore   eventstore.Store
+	clock        clock.Clock
+	eventChannel string
+	source       messaging.Source
+}
+
+func NewColorCommandService(
+	repo domain.ColorRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+) *ColorService {
+	return &ColorService{
+		repo:         repo,
+		eventStore:   eventStore,
+		clock:        clock,
+		eventChannel: "app.color",
+		source:       source,
+	}
+}
+
+func (s *ColorService) CreateColor(
+	ctx context.Context, code string, details domain.ColorDetails,
+) (*domain.Color, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "color.service.create")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "color.service")
+
+	color := domain.NewColor(code, details, aggregate.StampNow(ctx, s.clock))
+	if err := s.repo.SaveColor(ctx, color); err != nil {
+		return nil, s.failed(span, logger, "saving color failed", err)
+	}
+
+	if err := s.publish(ctx, color); err != nil {
+		return nil, err
+	}
+	return color, nil
+}
+
+func (s *ColorService) UpdateColor(
+	ctx context.Context, code string, details domain.ColorDetails, version int,
+) (*domain.Color, error) {
+	return s.change(ctx, code, "color.service.update", func(color *domain.Color, stamp domain.Stamp) error {
+		return color.Update(details, version, stamp)
+	})
+}
+
+// DeleteColor deletes a color no fabric is made in any more, so no fabric is left
+// referencing a color that is gone.
+func (s *ColorService) DeleteColor(ctx context.Context, code string, version int) (*domain.Color, error) {
+	inUse, err := s.repo.InUse(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if inUse {
+		return nil, domain.ErrColorInUse
+	}
+	return s.change(ctx, code, "color.service.delete", func(color *domain.Color, stamp domain.Stamp) error {
+		return color.Delete(version, stamp)
+	})
+}
+
+func (s *ColorService) RestoreColor(ctx context.Context, code string, version int) (*domain.Color, error) {
+	return s.change(ctx, code, "color.service.restore", func(color *domain.Color, stamp domain.Stamp) error {
+		return color.Restore(version, stamp)
+	})
+}
+
+// change loads the color, applies a command to it, stores it and publishes its events.
+func (s *ColorService) change(
+	ctx context.Context, code, spanName string, apply func(color *domain.Color, stamp domain.Stamp) error,
+) (*domain.Color, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, spanName)
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "color.service")
+
+	color, err := s.repo.GetColor(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(color, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateColor(ctx, color); err != nil {
+		return nil, s.failed(span, logger, "updating color failed", err)
+	}
+
+	if err := s.publish(ctx, color); err != nil {
+		return nil, err
+	}
+	return color, nil
+}
+
+// failed reports a repository write that did not succeed. Color errors are passed
+// through as they are, anything else is wrapped and recorded as a database error.
+func (s *ColorService) failed(span trace.Span, logger *slog.Logger, msg string, err error) error {
+	var colorErr *domain.ColorError
+	if errors.As(err, &colorErr) {
+		return err
+	}
+	wrappedErr := fmt.Errorf("failed to write color in repo: %w", err)
+	logger.Error(msg, "error", wrappedErr)
+	span.RecordError(wrappedErr)
+	span.SetStatus(codes.Error, "database write error")
+	return wrappedErr
+}
+
+func (s *ColorService) publish(ctx context.Context, color *domain.Color) error {
+	logger := httpx.GetLogger(ctx).With("component", "color.service")
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range color.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.ColorCreated:
+			eventType = "app.color.created"
+		case domain.ColorUpdated:
+			eventType = "app.color.updated"
+		case domain.ColorDeleted:
+			eventType = "app.color.deleted"
+		case domain.ColorRestored:
+			eventType = "app.color.restored"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			color.Code,
+			"Color",
+			color.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelope.UserID = command.Actor(ctx)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopesToPublish...); err != nil {
+			wrappedErr := fmt.Errorf("failed to save color event to event store: %w", err)
+			logger.Error("saving color event failed", "error", wrappedErr)
+			return wrappedErr
+		}
+	}
+
+	return nil
+}
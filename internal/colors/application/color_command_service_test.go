@@ -0,0 +1,196 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/colors/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = domain.Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testSource = messaging.Source{Service: "s-works-api", Instance: "test-instance"}
+
+var testDetails = domain.ColorDetails{Name: "Navy blue", Hex: "#1F2A44"}
+
+type mockColorRepository struct {
+	colors      map[string]*domain.Color
+	used        map[string]bool
+	saved       *domain.Color
+	errToReturn error
+}
+
+func (m *mockColorRepository) SaveColor(ctx context.Context, color *domain.Color) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.saved = color
+	return nil
+}
+
+func (m *mockColorRepository) GetColor(ctx context.Context, code string) (*domain.Color, error) {
+	color, ok := m.colors[code]
+	if !ok {
+		return nil, domain.ErrColorNotFound
+	}
+	colorCopy := *color
+	return &colorCopy, nil
+}
+
+func (m *mockColorRepository) ListColors(ctx context.Context, limit, offset int) ([]*domain.Color, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockColorRepository) UpdateColor(ctx context.Context, color *domain.Color) error {
+	return m.SaveColor(ctx, color)
+}
+
+func (m *mockColorRepository) InUse(ctx context.Context, code string) (bool, error) {
+	return m.used[code], nil
+}
+
+type mockEventStore struct {
+	SavedCalled      bool
+	EnqueuedSubject  string
+	EnqueuedEnvelope *messaging.EventEnvelope
+}
+
+func (m *mockEventStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
+	m.SavedCalled = true
+	return nil
+}
+
+func (m *mockEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.SavedCalled = true
+	m.EnqueuedSubject = subject
+	m.EnqueuedEnvelope = envelopes[len(envelopes)-1]
+	return nil
+}
+
+func newTestRepository() *mockColorRepository {
+	color := &domain.Color{Code: "NAVY", Name: "Navy blue", Hex: "#1F2A44", Version: 1}
+	used := &domain.Color{Code: "ECRU", Name: "Ecru", Hex: "#C2B280", Version: 1}
+	return &mockColorRepository{
+		colors: map[string]*domain.Color{color.Code: color, used.Code: used},
+		used:   map[string]bool{used.Code: true},
+	}
+}
+
+func TestColorService_CreateColor_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewColorCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+	ctx := command.WithUserID(context.Background(), "user_test")
+
+	// --- Act ---
+	color, err := service.CreateColor(ctx, "OLIVE", testDetails)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, repo.saved, "expected SaveColor() to be called on the repository")
+	assert.Equal(t, testDetails.Hex, color.Hex)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.color", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.color.created", publishedEnvelope.EventType)
+	assert.Equal(t, "Color", publishedEnvelope.AggregateType)
+	assert.Equal(t, "OLIVE", publishedEnvelope.AggregateID)
+	assert.Equal(t, "user_test", publishedEnvelope.UserID)
+}
+
+func TestColorService_DeleteColor(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewColorCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	color, err := service.DeleteColor(context.Background(), "NAVY", 1)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.True(t, color.Deleted())
+	assert.Equal(t, "app.color.deleted", eventStore.EnqueuedEnvelope.EventType)
+}
+
+func TestColorService_RejectedIsNotPublished(t *testing.T) {
+	testCases := []struct {
+		name        string
+		errToReturn error
+		run         func(s *ColorService) error
+		expectedErr error
+	}{
+		{
+			name: "Stale version",
+			run: func(s *ColorService) error {
+				_, err := s.UpdateColor(context.Background(), "NAVY", testDetails, 2)
+				return err
+			},
+			expectedErr: domain.ErrConcurrencyConflict,
+		},
+		{
+			name: "Code taken",
+			run: func(s *ColorService) error {
+				_, err := s.CreateColor(context.Background(), "NAVY", testDetails)
+				return err
+			},
+			errToReturn: domain.ErrDuplicateColorCode,
+			expectedErr: domain.ErrDuplicateColorCode,
+		},
+		{
+			name: "Color fabrics are made in",
+			run: func(s *ColorService) error {
+				_, err := s.DeleteColor(context.Background(), "ECRU", 1)
+				return err
+			},
+			expectedErr: domain.ErrColorInUse,
+		},
+		{
+			name: "Restore of a live color",
+			run: func(s *ColorService) error {
+				_, err := s.RestoreColor(context.Background(), "NAVY", 1)
+				return err
+			},
+			expectedErr: domain.ErrColorNotDeleted,
+		},
+		{
+			name: "Unknown color",
+			run: func(s *ColorService) error {
+				_, err := s.DeleteColor(context.Background(), "NOSUCH", 1)
+				return err
+			},
+			expectedErr: domain.ErrColorNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			repo.errToReturn = tc.errToReturn
+			eventStore := &mockEventStore{}
+			service := NewColorCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err := tc.run(service)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, repo.saved)
+			assert.False(t, eventStore.SavedCalled, "a rejected command must not be stored")
+		})
+	}
+}
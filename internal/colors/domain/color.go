@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+var (
+	ErrColorNotFound       = notFoundError("color not found")
+	ErrDuplicateColorCode  = conflictError("a color with this code already exists")
+	ErrConcurrencyConflict = conflictError("the color has been modified by another process, please refresh and try again")
+	ErrColorDeleted        = conflictError("cannot perform on a deleted color")
+	ErrColorNotDeleted     = conflictError("the color is not deleted")
+	ErrColorInUse          = conflictError("a color fabrics are made in cannot be deleted, change their color first")
+)
+
+// ColorError is a rule violation reported by the color domain. Its kind tells the
+// handler which status to answer with and instrumentation how to class it.
+type ColorError struct {
+	Kind    string
+	Message string
+}
+
+func (e *ColorError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *ColorError) ErrorClass() string {
+	return e.Kind
+}
+
+func notFoundError(message string) *ColorError {
+	return &ColorError{Kind: "not_found", Message: message}
+}
+
+func conflictError(message string) *ColorError {
+	return &ColorError{Kind: "conflict", Message: message}
+}
+
+type Event = aggregate.Event
+
+type Stamp = aggregate.Stamp
+
+// Color is an entry of the palette fabrics are made in, fabrics reference it by its code.
+// A deleted color is kept, so it can be restored and its code is not given to another one.
+type Color struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// Hex is the RGB value the color is shown with, as #RRGGBB. It is empty for the
+	// colors taken over from the free-text colors of fabrics until someone sets it.
+	Hex     string `json:"hex"`
+	Version int    `json:"version"`
+	aggregate.Audit
+	aggregate.SoftDelete
+	aggregate.Root
+}
+
+// ColorDetails is the master data of a color, everything but its code.
+type ColorDetails struct {
+	Name string
+	Hex  string
+}
+
+type ColorCreated struct {
+	Code    string
+	Name    string
+	Hex     string
+	Version int
+}
+
+type ColorUpdated struct {
+	Code    string
+	Name    string
+	Hex     string
+	Version int
+}
+
+type ColorDeleted struct {
+	Code    string
+	Version int
+}
+
+type ColorRestored struct {
+	Code    string
+	Version int
+}
+
+func NewColor(code string, details ColorDetails, stamp Stamp) *Color {
+	color := &Color{
+		Code:    code,
+		Version: 1,
+		Audit:   aggregate.NewAudit(stamp),
+	}
+	color.setDetails(details)
+
+	event := ColorCreated{
+		Code:    color.Code,
+		Name:    color.Name,
+		Hex:     color.Hex,
+		Version: color.Version,
+	}
+	color.Record(event)
+	return color
+}
+
+// Update replaces the master data of the color.
+func (c *Color) Update(details ColorDetails, version int, stamp Stamp) error {
+	if c.Deleted() {
+		return ErrColorDeleted
+	}
+	if err := aggregate.CheckVersion(c.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	c.setDetails(details)
+	c.Version++
+	c.Touch(stamp)
+
+	event := ColorUpdated{
+		Code:    c.Code,
+		Name:    c.Name,
+		Hex:     c.Hex,
+		Version: c.Version,
+	}
+	c.Record(event)
+	return nil
+}
+
+// Delete marks the color deleted, it keeps its code until it is restored. Whether fabrics
+// are still made in it is up to the caller to check, fabrics are not part of the aggregate.
+func (c *Color) Delete(version int, stamp Stamp) error {
+	if c.Deleted() {
+		return ErrColorDeleted
+	}
+	if err := aggregate.CheckVersion(c.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	c.MarkDeleted(stamp.At)
+	c.Version++
+	c.Touch(stamp)
+
+	event := ColorDeleted{
+		Code:    c.Code,
+		Version: c.Version,
+	}
+	c.Record(event)
+	return nil
+}
+
+// Restore brings a deleted color back with the master data it had.
+func (c *Color) Restore(version int, stamp Stamp) error {
+	if !c.Deleted() {
+		return ErrColorNotDeleted
+	}
+	if err := aggregate.CheckVersion(c.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	c.ClearDeleted()
+	c.Version++
+	c.Touch(stamp)
+
+	event := ColorRestored{
+		Code:    c.Code,
+		Version: c.Version,
+	}
+	c.Record(event)
+	return nil
+}
+
+func (c *Color) setDetails(details ColorDetails) {
+	c.Name = details.Name
+	c.Hex = details.Hex
+}
+
+type ColorRepository interface {
+	// SaveColor stores a new color, failing with ErrDuplicateColorCode when the
+	// code is taken, deleted colors included.
+	SaveColor(ctx context.Context, color *Color) error
+	// GetColor loads a color, deleted ones included, or fails with ErrColorNotFound.
+	GetColor(ctx context.Context, code string) (*Color, error)
+	// ListColors returns a page of the colors that are not deleted, by code, together
+	// with their total number.
+	ListColors(ctx context.Context, limit, offset int) ([]*Color, int, error)
+	// UpdateColor stores a change of a color still at the version it was loaded
+	// with, or fails with ErrConcurrencyConflict.
+	UpdateColor(ctx context.Context, color *Color) error
+	// InUse reports whether any fabric is made in the color.
+	InUse(ctx context.Context, code string) (bool, error)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testDetails = ColorDetails{Name: "Navy blue", Hex: "#1F2A44"}
+
+func TestColor_Update(t *testing.T) {
+	testCases := []struct {
+		name        string
+		version     int
+		expectedErr error
+	}{
+		{name: "Current version", version: 1},
+		{name: "Stale version", version: 2, expectedErr: ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			color := NewColor("NAVY", testDetails, testStamp)
+
+			// --- Act ---
+			err := color.Update(ColorDetails{Name: "Midnight blue", Hex: "#191970"}, tc.version, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Len(t, color.Events(), 1, "a rejected update must not record an event")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 2, color.Version)
+			assert.Equal(t, ColorUpdated{Code: "NAVY", Name: "Midnight blue", Hex: "#191970", Version: 2}, color.Events()[1])
+		})
+	}
+}
+
+func TestColor_DeleteAndRestore(t *testing.T) {
+	// --- Arrange ---
+	color := NewColor("NAVY", testDetails, testStamp)
+
+	// --- Act ---
+	deleteErr := color.Delete(1, testStamp)
+	updateErr := color.Update(ColorDetails{Name: "Midnight blue"}, 2, testStamp)
+	restoreErr := color.Restore(2, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, deleteErr)
+	assert.ErrorIs(t, updateErr, ErrColorDeleted)
+	require.NoError(t, restoreErr)
+	assert.False(t, color.Deleted())
+	assert.Equal(t, 3, color.Version)
+	assert.Equal(t, testDetails.Hex, color.Hex)
+	require.Len(t, color.Events(), 3)
+	assert.IsType(t, ColorRestored{}, color.Events()[2])
+}
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+
+	"github.com/salesworks/s-works/api/internal/colors/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+var (
+	colorCodeRX = regexp.MustCompile("^[A-Z0-9-]+$")
+	hexRX       = regexp.MustCompile("^#[0-9A-F]{6}$")
+)
+
+// ColorCommandService maintains the colors.
+type ColorCommandService interface {
+	CreateColor(ctx context.Context, code string, details domain.ColorDetails) (*domain.Color, error)
+	UpdateColor(
+		ctx context.Context, code string, details domain.ColorDetails, version int,
+	) (*domain.Color, error)
+	DeleteColor(ctx context.Context, code string, version int) (*domain.Color, error)
+	RestoreColor(ctx context.Context, code string, version int) (*domain.Color, error)
+}
+
+// ColorCommandHandler creates, updates, deletes and restores colors.
+type ColorCommandHandler struct {
+	service ColorCommandService
+}
+
+type createColorRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Hex  string `json:"hex"`
+}
+
+type updateColorRequest struct {
+	Name    string `json:"name"`
+	Hex     string `json:"hex"`
+	Version int    `json:"version"`
+}
+
+// versionRequest carries the version a delete or restore is made against.
+type versionRequest struct {
+	Version int `json:"version"`
+}
+
+func NewColorCommandHandler(service ColorCommandService) *ColorCommandHandler {
+	return &ColorCommandHandler{service: service}
+}
+
+func (h *ColorCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+	r = r.WithContext(ctx)
+
+	switch r.Method {
+	case http.MethodPost:
+		// a color is created on the collection and restored on its own resource
+		if httpx.URLParam(r, "code") != "" {
+			h.restoreColor(w, r)
+			return
+		}
+		h.createColor(w, r)
+	case http.MethodPut:
+		h.updateColor(w, r)
+	case http.MethodDelete:
+		h.deleteColor(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *ColorCommandHandler) createColor(w http.ResponseWriter, r *http.Request) {
+	var req createColorRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Code = validator.NormalizeCode(req.Code)
+	details := normalizeDetails(req.Name, req.Hex)
+	v := validator.New()
+	v.Check(req.Code != "", "code", "code must be provided")
+	v.Check(len(req.Code) >= 2 && len(req.Code) <= 30, "code", "code must be between 2 and 30 characters long")
+	v.Check(validator.Matches(req.Code, colorCodeRX), "code", "code must only contain uppercase letters, numbers and dashes")
+	validateColor(v, details)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	color, err := h.service.CreateColor(r.Context(), req.Code, details)
+	if err != nil {
+		writeColorError(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", "/v1/colors/"+color.Code)
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"color": color}, headers); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *ColorCommandHandler) updateColor(w http.ResponseWriter, r *http.Request) {
+	var req updateColorRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	details := normalizeDetails(req.Name, req.Hex)
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	validateColor(v, details)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	color, err := h.service.UpdateColor(r.Context(), httpx.URLParam(r, "code"), details, req.Version)
+	if err != nil {
+		writeColorError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"color": color}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *ColorCommandHandler) deleteColor(w http.ResponseWriter, r *http.Request) {
+	version, ok := readVersion(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.service.DeleteColor(r.Context(), httpx.URLParam(r, "code"), version); err != nil {
+		writeColorError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ColorCommandHandler) restoreColor(w http.ResponseWriter, r *http.Request) {
+	version, ok := readVersion(w, r)
+	if !ok {
+		return
+	}
+
+	color, err := h.service.RestoreColor(r.Context(), httpx.URLParam(r, "code"), version)
+	if err != nil {
+		writeColorError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"color": color}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// readVersion reads the version of a delete or restore, answering the request itself when
+// it is missing or invalid.
+func readVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	var req versionRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return 0, false
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return 0, false
+	}
+	return req.Version, true
+}
+
+func normalizeDetails(name, hex string) domain.ColorDetails {
+	return domain.ColorDetails{
+		Name: validator.NormalizeText(name),
+		Hex:  validator.NormalizeCode(hex),
+	}
+}
+
+func validateColor(v *validator.Validator, details domain.ColorDetails) {
+	v.Check(details.Name != "", "name", "name must be provided")
+	v.Check(len(details.Name) <= 100, "name", "name must not be more than 100 characters long")
+	v.Check(validator.Matches(details.Hex, hexRX), "hex", "hex must be an RGB value in the form #RRGGBB")
+}
+
+// writeColorError answers a failed command with the status matching the kind of
+// color error, anything that is not a color error is answered as an internal error.
+func writeColorError(w http.ResponseWriter, r *http.Request, err error) {
+	var colorErr *domain.ColorError
+	if !errors.As(err, &colorErr) {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch colorErr.Kind {
+	case "not_found":
+		httpx.NotFound(w, r)
+	default:
+		httpx.ErrorJSON(w, http.StatusConflict, colorErr.Message)
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/colors/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockColorCommandService struct {
+	called      string
+	code        string
+	details     domain.ColorDetails
+	version     int
+	errToReturn error
+}
+
+func (m *mockColorCommandService) CreateColor(
+	ctx context.Context, code string, details domain.ColorDetails,
+) (*domain.Color, error) {
+	m.called, m.code, m.details = "create", code, details
+	return m.result(code, 1)
+}
+
+func (m *mockColorCommandService) UpdateColor(
+	ctx context.Context, code string, details domain.ColorDetails, version int,
+) (*domain.Color, error) {
+	m.called, m.code, m.details, m.version = "update", code, details, version
+	return m.result(code, version+1)
+}
+
+func (m *mockColorCommandService) DeleteColor(ctx context.Context, code string, version int) (*domain.Color, error) {
+	m.called, m.code, m.version = "delete", code, version
+	return m.result(code, version+1)
+}
+
+func (m *mockColorCommandService) RestoreColor(ctx context.Context, code string, version int) (*domain.Color, error) {
+	m.called, m.code, m.version = "restore", code, version
+	return m.result(code, version+1)
+}
+
+func (m *mockColorCommandService) result(code string, version int) (*domain.Color, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Color{Code: code, Version: version}, nil
+}
+
+func serveColor(
+	t *testing.T, handler http.Handler, method, target, body string, params map[string]string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestColorCommandHandler_CreateColor(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockColorCommandService{}
+	handler := NewColorCommandHandler(svc)
+	body := `{"code": " navy ", "name": " Navy  blue ", "hex": "#1f2a44"}`
+
+	// --- Act ---
+	responseRecorder := serveColor(t, handler, http.MethodPost, "/v1/colors", body, nil)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, "/v1/colors/NAVY", responseRecorder.Header().Get("Location"))
+	assert.Equal(t, "NAVY", svc.code)
+	assert.Equal(t, domain.ColorDetails{Name: "Navy blue", Hex: "#1F2A44"}, svc.details)
+}
+
+func TestColorCommandHandler_RestoreColor(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockColorCommandService{}
+	handler := NewColorCommandHandler(svc)
+
+	// --- Act ---
+	responseRecorder := serveColor(
+		t, handler, http.MethodPost, "/v1/colors/NAVY/restore", `{"version": 2}`, map[string]string{"code": "NAVY"},
+	)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "restore", svc.called)
+	assert.Equal(t, 2, svc.version)
+}
+
+func TestColorCommandHandler_Rejected(t *testing.T) {
+	color := map[string]string{"code": "NAVY"}
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		params         map[string]string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{
+			name: "invalid code", method: http.MethodPost, body: `{"code": "navy blue", "name": "Navy blue", "hex": "#1F2A44"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "invalid hex", method: http.MethodPost, body: `{"code": "NAVY", "name": "Navy blue", "hex": "1F2A44"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing hex", method: http.MethodPut, body: `{"name": "Navy blue", "version": 1}`,
+			params: color, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing version", method: http.MethodPut, body: `{"name": "Navy blue", "hex": "#1F2A44"}`,
+			params: color, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "duplicate code", method: http.MethodPost, body: `{"code": "NAVY", "name": "Navy blue", "hex": "#1F2A44"}`,
+			errToReturn: domain.ErrDuplicateColorCode, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "color fabrics are made in", method: http.MethodDelete, body: `{"version": 1}`,
+			params:      color,
+			errToReturn: domain.ErrColorInUse, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "unknown color", method: http.MethodPut, body: `{"name": "Navy blue", "hex": "#1F2A44", "version": 1}`,
+			params:      color,
+			errToReturn: domain.ErrColorNotFound, expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockColorCommandService{errToReturn: tc.errToReturn}
+			handler := NewColorCommandHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveColor(t, handler, tc.method, "/v1/colors", tc.body, tc.params)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called != "")
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/colors/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// ColorQueryRepository reads the colors.
+type ColorQueryRepository interface {
+	GetColor(ctx context.Context, code string) (*domain.Color, error)
+	ListColors(ctx context.Context, limit, offset int) ([]*domain.Color, int, error)
+}
+
+// ColorQueryHandler serves the palette of colors. Deleted colors are left out of the list
+// but can still be read by code, so they can be restored.
+type ColorQueryHandler struct {
+	colors     ColorQueryRepository
+	pagination httpx.PaginationConfig
+}
+
+func NewColorQueryHandler(colors ColorQueryRepository, pagination httpx.PaginationConfig) *ColorQueryHandler {
+	return &ColorQueryHandler{
+		colors:     colors,
+		pagination: pagination,
+	}
+}
+
+func (h *ColorQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	if httpx.URLParam(r, "code") == "" {
+		h.listColors(w, r)
+		return
+	}
+	h.getColor(w, r)
+}
+
+func (h *ColorQueryHandler) listColors(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	colors, totalRecords, err := h.colors.ListColors(r.Context(), page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"colors": colors, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *ColorQueryHandler) getColor(w http.ResponseWriter, r *http.Request) {
+	color, err := h.colors.GetColor(r.Context(), validator.NormalizeCode(httpx.URLParam(r, "code")))
+	if err != nil {
+		writeColorError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"color": color}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
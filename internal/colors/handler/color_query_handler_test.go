@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/colors/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockColorQueryRepository struct {
+	listedLimit  int
+	listedOffset int
+}
+
+func (m *mockColorQueryRepository) GetColor(ctx context.Context, code string) (*domain.Color, error) {
+	if code != "NAVY" {
+		return nil, domain.ErrColorNotFound
+	}
+	return &domain.Color{Code: code, Name: "Navy blue", Hex: "#1F2A44", Version: 1}, nil
+}
+
+func (m *mockColorQueryRepository) ListColors(ctx context.Context, limit, offset int) ([]*domain.Color, int, error) {
+	m.listedLimit, m.listedOffset = limit, offset
+	return []*domain.Color{{Code: "NAVY", Name: "Navy blue", Hex: "#1F2A44", Version: 1}}, 21, nil
+}
+
+func TestColorQueryHandler_GetColor(t *testing.T) {
+	testCases := []struct {
+		name           string
+		code           string
+		expectedStatus int
+	}{
+		{name: "known color", code: "navy", expectedStatus: http.StatusOK},
+		{name: "unknown color", code: "NOSUCH", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewColorQueryHandler(&mockColorQueryRepository{}, httpx.PaginationConfig{})
+
+			// --- Act ---
+			responseRecorder := serveColor(
+				t, handler, http.MethodGet, "/v1/colors/"+tc.code, "", map[string]string{"code": tc.code},
+			)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Color domain.Color `json:"color"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+			assert.Equal(t, "NAVY", body.Color.Code)
+			assert.Equal(t, "#1F2A44", body.Color.Hex)
+		})
+	}
+}
+
+func TestColorQueryHandler_ListColors(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockColorQueryRepository{}
+	pagination := httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+	handler := NewColorQueryHandler(repo, pagination)
+
+	// --- Act ---
+	responseRecorder := serveColor(t, handler, http.MethodGet, "/v1/colors?page=2", "", nil)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, 20, repo.listedLimit)
+	assert.Equal(t, 20, repo.listedOffset)
+}
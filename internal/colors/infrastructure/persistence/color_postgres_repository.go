@@ -0,0 +1,145 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/colors/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+const colorColumns = `code, name, hex, version,
+	created_at, created_by, updated_at, updated_by, deleted_at`
+
+type ColorPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewColorPostgresRepository(db *database.PostgresDB) *ColorPostgresRepository {
+	return &ColorPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *ColorPostgresRepository) SaveColor(ctx context.Context, color *domain.Color) error {
+	query := `
+		INSERT INTO colors (` + colorColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		color.Code, color.Name, color.Hex, color.Version,
+		color.CreatedAt, color.CreatedBy, color.UpdatedAt, color.UpdatedBy, color.DeletedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrDuplicateColorCode
+		}
+		return fmt.Errorf("failed to insert color: %w", err)
+	}
+	return nil
+}
+
+func (r *ColorPostgresRepository) GetColor(ctx context.Context, code string) (*domain.Color, error) {
+	query := `SELECT ` + colorColumns + ` FROM colors WHERE code = $1`
+	color, err := scanColor(r.db.Conn(ctx).QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrColorNotFound
+		}
+		return nil, fmt.Errorf("failed to get color: %w", err)
+	}
+	return color, nil
+}
+
+func (r *ColorPostgresRepository) ListColors(
+	ctx context.Context, limit, offset int,
+) ([]*domain.Color, int, error) {
+	query := `
+		SELECT count(*) OVER(), ` + colorColumns + `
+		FROM colors
+		WHERE deleted_at IS NULL
+		ORDER BY code
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list colors: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	colors := []*domain.Color{}
+	for rows.Next() {
+		color := &domain.Color{}
+		err := rows.Scan(append([]any{&totalRecords}, colorFields(color)...)...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan color: %w", err)
+		}
+		colors = append(colors, color)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate colors: %w", err)
+	}
+
+	return colors, totalRecords, nil
+}
+
+// UpdateColor stores the master data and deletion of the color, provided nobody
+// changed it since it was loaded.
+func (r *ColorPostgresRepository) UpdateColor(ctx context.Context, color *domain.Color) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE colors
+		SET name = $1, hex = $2,
+			version = $3, updated_at = $4, updated_by = $5, deleted_at = $6
+		WHERE code = $7 AND version = $8
+	`, color.Name, color.Hex,
+		color.Version, color.UpdatedAt, color.UpdatedBy, color.DeletedAt,
+		color.Code, color.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update color: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+	return nil
+}
+
+// InUse looks at every fabric, archived ones included.
+func (r *ColorPostgresRepository) InUse(ctx context.Context, code string) (bool, error) {
+	var inUse bool
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM fabrics WHERE color = $1)
+	`, code).Scan(&inUse)
+	if err != nil {
+		return false, fmt.Errorf("failed to check fabrics of color: %w", err)
+	}
+	return inUse, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanColor(row rowScanner) (*domain.Color, error) {
+	color := &domain.Color{}
+	if err := row.Scan(colorFields(color)...); err != nil {
+		return nil, err
+	}
+	return color, nil
+}
+
+// colorFields lists the destinations of colorColumns, in the same order.
+func colorFields(color *domain.Color) []any {
+	return []any{
+		&color.Code, &color.Name, &color.Hex, &color.Version,
+		&color.CreatedAt, &color.CreatedBy, &color.UpdatedAt, &color.UpdatedBy, &color.DeletedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/colors/domain"
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+)
+
+// InstrumentedColorRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedColorRepository struct {
+	next domain.ColorRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedColorRepository(
+	next domain.ColorRepository, rec *instrument.Recorder,
+) *InstrumentedColorRepository {
+	return &InstrumentedColorRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedColorRepository) SaveColor(ctx context.Context, color *domain.Color) error {
+	return instrument.Exec(ctx, r.rec, "SaveColor", func(ctx context.Context) error {
+		return r.next.SaveColor(ctx, color)
+	})
+}
+
+func (r *InstrumentedColorRepository) GetColor(ctx context.Context, code string) (*domain.Color, error) {
+	return instrument.Call(ctx, r.rec, "GetColor", func(ctx context.Context) (*domain.Color, error) {
+		return r.next.GetColor(ctx, code)
+	})
+}
+
+func (r *InstrumentedColorRepository) ListColors(
+	ctx context.Context, limit, offset int,
+) ([]*domain.Color, int, error) {
+	var total int
+	colors, err := instrument.Call(ctx, r.rec, "ListColors",
+		func(ctx context.Context) ([]*domain.Color, error) {
+			colors, count, err := r.next.ListColors(ctx, limit, offset)
+			total = count
+			return colors, err
+		})
+	return colors, total, err
+}
+
+func (r *InstrumentedColorRepository) UpdateColor(ctx context.Context, color *domain.Color) error {
+	return instrument.Exec(ctx, r.rec, "UpdateColor", func(ctx context.Context) error {
+		return r.next.UpdateColor(ctx, color)
+	})
+}
+
+func (r *InstrumentedColorRepository) InUse(ctx context.Context, code string) (bool, error) {
+	return instrument.Call(ctx, r.rec, "InUse", func(ctx context.Context) (bool, error) {
+		return r.next.InUse(ctx, code)
+	})
+}
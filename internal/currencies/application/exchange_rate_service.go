@@ -0,0 +1,184 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/currencies/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+)
+
+// ExchangeRateService keeps the daily exchange rates, published by the ERP or fetched from
+// the NBP, and converts amounts between currencies at them.
+type ExchangeRateService struct {
+	repo     domain.ExchangeRateRepository
+	client   *http.Client
+	ratesURL string
+	clock    clock.Clock
+	logger   *slog.Logger
+}
+
+// nbpTable is a table of average exchange rates as the NBP API serves it, e.g. from
+// https://api.nbp.pl/api/exchangerates/tables/A/?format=json
+type nbpTable struct {
+	EffectiveDate string `json:"effectiveDate"`
+	Rates         []struct {
+		Code string      `json:"code"`
+		Mid  domain.Rate `json:"mid"`
+	} `json:"rates"`
+}
+
+// NewExchangeRateService builds the service. The rates URL serves the NBP table Fetch
+// reads; without one the service is not run and the rates only come from the ERP.
+func NewExchangeRateService(
+	repo domain.ExchangeRateRepository, client *http.Client, ratesURL string, clock clock.Clock, logger *slog.Logger,
+) *ExchangeRateService {
+	return &ExchangeRateService{
+		repo:     repo,
+		client:   client,
+		ratesURL: ratesURL,
+		clock:    clock,
+		logger:   logger.With("component", "currency.exchange_rates"),
+	}
+}
+
+// IngestRates stores the rates of the currencies on a day. Either all of them are stored
+// or, when one is invalid, none.
+func (s *ExchangeRateService) IngestRates(
+	ctx context.Context, day time.Time, rates map[string]domain.Rate, source string,
+) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "currency.service.ingest_rates")
+	defer span.End()
+
+	now := s.clock.Now()
+	exchangeRates := make([]domain.ExchangeRate, 0, len(rates))
+	for currency, rate := range rates {
+		exchangeRate, err := domain.NewExchangeRate(currency, day, rate, source, now)
+		if err != nil {
+			return fmt.Errorf("rate of %s: %w", currency, err)
+		}
+		exchangeRates = append(exchangeRates, exchangeRate)
+	}
+	sort.Slice(exchangeRates, func(i, j int) bool { return exchangeRates[i].Currency < exchangeRates[j].Currency })
+
+	if err := s.repo.SaveRates(ctx, exchangeRates); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// ListRates returns the rate in force on the day of each currency that has one.
+func (s *ExchangeRateService) ListRates(ctx context.Context, at time.Time) ([]domain.ExchangeRate, error) {
+	rates, err := s.repo.ListRates(ctx, domain.Day(at))
+	if err != nil {
+		return nil, err
+	}
+	inForce := make([]domain.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		if rate.InForce(at) {
+			inForce = append(inForce, rate)
+		}
+	}
+	return inForce, nil
+}
+
+// Convert converts an amount in the minor unit of a currency into another at the rates in
+// force at the time. It fails with ErrRateNotFound when either currency has none.
+func (s *ExchangeRateService) Convert(
+	ctx context.Context, amount int64, from, to string, at time.Time,
+) (*domain.Conversion, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "currency.service.convert")
+	defer span.End()
+
+	fromRate, err := s.rateInForce(ctx, from, at)
+	if err != nil {
+		return nil, err
+	}
+	toRate, err := s.rateInForce(ctx, to, at)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := domain.Convert(amount, from, fromRate, to, toRate)
+	return &conversion, nil
+}
+
+// rateInForce looks up the rate of a currency at a time, the base currency has none.
+func (s *ExchangeRateService) rateInForce(ctx context.Context, currency string, at time.Time) (*domain.ExchangeRate, error) {
+	if currency == domain.BaseCurrency {
+		return nil, nil
+	}
+	rate, err := s.repo.FindRate(ctx, currency, domain.Day(at))
+	if err != nil {
+		return nil, err
+	}
+	if !rate.InForce(at) {
+		return nil, domain.ErrRateNotFound
+	}
+	return rate, nil
+}
+
+// Run fetches the rates right away and then every interval until ctx is done, so a new
+// instance does not wait a whole interval for them.
+func (s *ExchangeRateService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Fetch(ctx); err != nil {
+			s.logger.Error("failed to fetch exchange rates", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Fetch reads the current table of average rates from the NBP and stores it. Fetching a
+// table already stored only refreshes it.
+func (s *ExchangeRateService) Fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.ratesURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("exchange rate provider answered %d", res.StatusCode)
+	}
+	var tables []nbpTable
+	if err := json.NewDecoder(res.Body).Decode(&tables); err != nil {
+		return fmt.Errorf("failed to decode exchange rates: %w", err)
+	}
+
+	for _, table := range tables {
+		day, err := time.Parse(time.DateOnly, table.EffectiveDate)
+		if err != nil {
+			return fmt.Errorf("invalid effective date of exchange rates: %w", err)
+		}
+		rates := make(map[string]domain.Rate, len(table.Rates))
+		for _, rate := range table.Rates {
+			rates[rate.Code] = rate.Mid
+		}
+		if err := s.IngestRates(ctx, day, rates, domain.SourceNBP); err != nil {
+			return err
+		}
+		s.logger.Info("Exchange rates fetched", "day", table.EffectiveDate, "currencies", len(rates))
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/currencies/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 3, 4, 14, 0, 0, 0, time.UTC)
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+type mockExchangeRateRepository struct {
+	rates []domain.ExchangeRate
+	saved []domain.ExchangeRate
+}
+
+func (m *mockExchangeRateRepository) SaveRates(ctx context.Context, rates []domain.ExchangeRate) error {
+	m.saved = rates
+	return nil
+}
+
+func (m *mockExchangeRateRepository) FindRate(
+	ctx context.Context, currency string, day time.Time,
+) (*domain.ExchangeRate, error) {
+	var found *domain.ExchangeRate
+	for i, rate := range m.rates {
+		if rate.Currency == currency && !rate.Day.After(day) && (found == nil || rate.Day.After(found.Day)) {
+			found = &m.rates[i]
+		}
+	}
+	if found == nil {
+		return nil, domain.ErrRateNotFound
+	}
+	return found, nil
+}
+
+func (m *mockExchangeRateRepository) ListRates(ctx context.Context, day time.Time) ([]domain.ExchangeRate, error) {
+	return m.rates, nil
+}
+
+func newTestRepository() *mockExchangeRateRepository {
+	return &mockExchangeRateRepository{rates: []domain.ExchangeRate{
+		{Currency: "EUR", Day: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), Rate: 427350000},
+		{Currency: "EUR", Day: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), Rate: 418000000},
+		{Currency: "USD", Day: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), Rate: 410120000},
+	}}
+}
+
+func TestExchangeRateService_Convert(t *testing.T) {
+	testCases := []struct {
+		name              string
+		from              string
+		to                string
+		at                time.Time
+		expectedConverted int64
+		expectedErr       error
+	}{
+		{name: "rate of the day", from: "EUR", to: "PLN", at: testNow, expectedConverted: 4180},
+		{name: "rate of an earlier day", from: "EUR", to: "PLN", at: time.Date(2025, 3, 3, 23, 0, 0, 0, time.UTC), expectedConverted: 4274},
+		{name: "rate out of date", from: "USD", to: "PLN", at: testNow, expectedErr: domain.ErrRateNotFound},
+		{name: "unknown currency", from: "PLN", to: "CHF", at: testNow, expectedErr: domain.ErrRateNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			service := NewExchangeRateService(newTestRepository(), http.DefaultClient, "", clock.NewFixed(testNow), testLogger)
+
+			// --- Act ---
+			conversion, err := service.Convert(context.Background(), 1000, tc.from, tc.to, tc.at)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConverted, conversion.Converted)
+		})
+	}
+}
+
+func TestExchangeRateService_IngestRates_InvalidStoresNothing(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	service := NewExchangeRateService(repo, http.DefaultClient, "", clock.NewFixed(testNow), testLogger)
+	rates := map[string]domain.Rate{"EUR": 427350000, "PLN": 100000000}
+
+	// --- Act ---
+	err := service.IngestRates(context.Background(), testNow, rates, domain.SourceERP)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, domain.ErrBaseCurrencyRate)
+	assert.Nil(t, repo.saved)
+}
+
+func TestExchangeRateService_Fetch(t *testing.T) {
+	// --- Arrange ---
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[{
+			"table": "A", "no": "043/A/NBP/2025", "effectiveDate": "2025-03-04",
+			"rates": [
+				{"currency": "euro", "code": "EUR", "mid": 4.18},
+				{"currency": "dolar amerykański", "code": "USD", "mid": 3.9874},
+				{"currency": "forint (Węgry)", "code": "HUF", "mid": 0.010402}
+			]
+		}]`)
+	}))
+	defer provider.Close()
+	repo := newTestRepository()
+	service := NewExchangeRateService(repo, provider.Client(), provider.URL, clock.NewFixed(testNow), testLogger)
+
+	// --- Act ---
+	err := service.Fetch(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []domain.ExchangeRate{
+		{Currency: "EUR", Day: day, Rate: 418000000, Source: domain.SourceNBP, UpdatedAt: testNow},
+		{Currency: "HUF", Day: day, Rate: 1040200, Source: domain.SourceNBP, UpdatedAt: testNow},
+		{Currency: "USD", Day: day, Rate: 398740000, Source: domain.SourceNBP, UpdatedAt: testNow},
+	}, repo.saved)
+}
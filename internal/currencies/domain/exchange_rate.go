@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BaseCurrency is the currency exchange rates are quoted in, as the ERP and the National
+// Bank of Poland publish them.
+const BaseCurrency = "PLN"
+
+// Sources of exchange rates.
+const (
+	SourceERP = "erp"
+	SourceNBP = "nbp"
+)
+
+// rates are kept in hundred-millionths of the base currency
+const rateDecimals = 8
+
+// MaxRateAge is how long a rate is used for after its day. Rates are not published on
+// weekends and holidays, the rate of the last business day stands in for them; an older
+// one is taken as missing rather than converting at a rate that is out of date.
+const MaxRateAge = 7 * 24 * time.Hour
+
+var (
+	ErrRateNotFound     = notFoundError("no exchange rate of the currency is known at this time")
+	ErrInvalidRate      = invalidError("an exchange rate must be a positive decimal with at most 8 decimals")
+	ErrBaseCurrencyRate = invalidError("the base currency has no exchange rate")
+)
+
+// CurrencyError is a rule violation reported by the currency domain. Its kind tells the
+// handler which status to answer with and instrumentation how to class it.
+type CurrencyError struct {
+	Kind    string
+	Message string
+}
+
+func (e *CurrencyError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *CurrencyError) ErrorClass() string {
+	return e.Kind
+}
+
+func notFoundError(message string) *CurrencyError {
+	return &CurrencyError{Kind: "not_found", Message: message}
+}
+
+func invalidError(message string) *CurrencyError {
+	return &CurrencyError{Kind: "invalid", Message: message}
+}
+
+// Rate is the price of one unit of a currency in the base currency, in hundred-millionths.
+type Rate int64
+
+// ParseRate reads a positive decimal rate such as "4.2735".
+func ParseRate(raw string) (Rate, error) {
+	whole, fraction, _ := strings.Cut(raw, ".")
+	if whole == "" || len(fraction) > rateDecimals || strings.ContainsAny(whole, "+-") {
+		return 0, ErrInvalidRate
+	}
+	fraction += strings.Repeat("0", rateDecimals-len(fraction))
+	units, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || units <= 0 {
+		return 0, ErrInvalidRate
+	}
+	return Rate(units), nil
+}
+
+// String formats the rate without trailing zeros, e.g. "4.2735".
+func (r Rate) String() string {
+	digits := strconv.FormatInt(int64(r), 10)
+	if len(digits) <= rateDecimals {
+		digits = strings.Repeat("0", rateDecimals-len(digits)+1) + digits
+	}
+	whole, fraction := digits[:len(digits)-rateDecimals], strings.TrimRight(digits[len(digits)-rateDecimals:], "0")
+	if fraction == "" {
+		return whole
+	}
+	return whole + "." + fraction
+}
+
+// MarshalJSON writes the rate as a decimal string, so no precision is lost on the way.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(r.String())), nil
+}
+
+// UnmarshalJSON reads a rate given as a decimal string or as a plain JSON number, the way
+// the ERP and the NBP publish them.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	raw := string(data)
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		raw = unquoted
+	}
+	parsed, err := ParseRate(raw)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// ExchangeRate is the price of one unit of a currency in the base currency on a day. The
+// rate received last for a currency and day replaces the earlier one.
+type ExchangeRate struct {
+	Currency string    `json:"currency"`
+	Day      time.Time `json:"day"`
+	Rate     Rate      `json:"rate"`
+	// Source tells where the rate came from, the ERP or the NBP.
+	Source    string    `json:"source"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewExchangeRate checks a rate received for a currency and day.
+func NewExchangeRate(currency string, day time.Time, rate Rate, source string, at time.Time) (ExchangeRate, error) {
+	if currency == BaseCurrency {
+		return ExchangeRate{}, ErrBaseCurrencyRate
+	}
+	if rate <= 0 {
+		return ExchangeRate{}, ErrInvalidRate
+	}
+	return ExchangeRate{Currency: currency, Day: Day(day), Rate: rate, Source: source, UpdatedAt: at}, nil
+}
+
+// InForce reports whether the rate may still be used on the day, see MaxRateAge.
+func (e ExchangeRate) InForce(day time.Time) bool {
+	return Day(day).Sub(e.Day) <= MaxRateAge
+}
+
+// Day is the day of a time in UTC, the day exchange rates are looked up for.
+func Day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// Conversion is an amount of one currency converted into another through the base
+// currency, at the rates of both currencies in force on the day.
+type Conversion struct {
+	// Amount and Converted are expressed in the minor unit of their currency, e.g. grosze
+	// for PLN.
+	Amount    int64  `json:"amount"`
+	From      string `json:"from"`
+	Converted int64  `json:"converted"`
+	To        string `json:"to"`
+	// FromRate and ToRate are the rates the amount was converted at, the base currency
+	// has none.
+	FromRate *ExchangeRate `json:"from_rate,omitempty"`
+	ToRate   *ExchangeRate `json:"to_rate,omitempty"`
+}
+
+// Convert converts an amount in the minor unit of a currency into the minor unit of
+// another, rounding half away from zero. A nil rate is the rate of the base currency.
+func Convert(amount int64, from string, fromRate *ExchangeRate, to string, toRate *ExchangeRate) Conversion {
+	numerator := big.NewInt(amount)
+	numerator.Mul(numerator, rateOf(fromRate))
+	numerator.Mul(numerator, pow10(MinorUnits(to)))
+	denominator := new(big.Int).Mul(rateOf(toRate), pow10(MinorUnits(from)))
+
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	twiceRemainder := new(big.Int).Lsh(new(big.Int).Abs(remainder), 1)
+	if twiceRemainder.Cmp(denominator) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(numerator.Sign())))
+	}
+
+	return Conversion{
+		Amount: amount, From: from, Converted: quotient.Int64(), To: to, FromRate: fromRate, ToRate: toRate,
+	}
+}
+
+func rateOf(rate *ExchangeRate) *big.Int {
+	if rate == nil {
+		return pow10(rateDecimals)
+	}
+	return big.NewInt(int64(rate.Rate))
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+// minorUnits lists the currencies whose minor unit is not a hundredth, by ISO 4217.
+var minorUnits = map[string]int{
+	"BHD": 3, "CLP": 0, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0, "KWD": 3, "OMR": 3, "TND": 3, "VND": 0,
+}
+
+// MinorUnits is the number of decimals of the minor unit of a currency, two for most.
+func MinorUnits(currency string) int {
+	if units, ok := minorUnits[currency]; ok {
+		return units
+	}
+	return 2
+}
+
+type ExchangeRateRepository interface {
+	// SaveRates stores the rates, replacing those of the same currency and day.
+	SaveRates(ctx context.Context, rates []ExchangeRate) error
+	// FindRate returns the rate of the currency on the latest day not after the given one,
+	// or fails with ErrRateNotFound.
+	FindRate(ctx context.Context, currency string, day time.Time) (*ExchangeRate, error)
+	// ListRates returns the rate of each currency on the latest day not after the given
+	// one, by currency.
+	ListRates(ctx context.Context, day time.Time) ([]ExchangeRate, error)
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDay = time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+func TestParseRate(t *testing.T) {
+	testCases := []struct {
+		raw         string
+		expected    Rate
+		expectedErr error
+	}{
+		{raw: "4.2735", expected: 427350000},
+		{raw: "0.010402", expected: 1040200},
+		{raw: "1", expected: 100000000},
+		{raw: "0.000000001", expectedErr: ErrInvalidRate},
+		{raw: "-4.27", expectedErr: ErrInvalidRate},
+		{raw: "0", expectedErr: ErrInvalidRate},
+		{raw: "4,27", expectedErr: ErrInvalidRate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.raw, func(t *testing.T) {
+			// --- Act ---
+			rate, err := ParseRate(tc.raw)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, rate)
+		})
+	}
+}
+
+func TestRate_JSON(t *testing.T) {
+	// --- Act ---
+	var rates map[string]Rate
+	err := json.Unmarshal([]byte(`{"EUR": "4.2735", "USD": 4.1012}`), &rates)
+	encoded, encodeErr := json.Marshal(rates["EUR"])
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Rate{"EUR": 427350000, "USD": 410120000}, rates)
+	require.NoError(t, encodeErr)
+	assert.Equal(t, `"4.2735"`, string(encoded))
+}
+
+func TestConvert(t *testing.T) {
+	eur := &ExchangeRate{Currency: "EUR", Day: testDay, Rate: 427350000}
+	jpy := &ExchangeRate{Currency: "JPY", Day: testDay, Rate: 2750000}
+
+	testCases := []struct {
+		name      string
+		amount    int64
+		from      string
+		fromRate  *ExchangeRate
+		to        string
+		toRate    *ExchangeRate
+		converted int64
+	}{
+		{name: "into the base currency", amount: 1000, from: "EUR", fromRate: eur, to: "PLN", converted: 4274},
+		{name: "from the base currency", amount: 2490, from: "PLN", to: "EUR", toRate: eur, converted: 583},
+		{name: "between foreign currencies", amount: 1000, from: "EUR", fromRate: eur, to: "JPY", toRate: jpy, converted: 1554},
+		{name: "negative amount", amount: -1000, from: "EUR", fromRate: eur, to: "PLN", converted: -4274},
+		{name: "same currency", amount: 2490, from: "PLN", to: "PLN", converted: 2490},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			conversion := Convert(tc.amount, tc.from, tc.fromRate, tc.to, tc.toRate)
+
+			// --- Assert ---
+			assert.Equal(t, tc.converted, conversion.Converted)
+			assert.Equal(t, tc.amount, conversion.Amount)
+		})
+	}
+}
+
+func TestNewExchangeRate(t *testing.T) {
+	// --- Act ---
+	rate, err := NewExchangeRate("EUR", testDay.Add(13*time.Hour), 427350000, SourceERP, testDay)
+	_, baseErr := NewExchangeRate(BaseCurrency, testDay, 100000000, SourceERP, testDay)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, testDay, rate.Day)
+	assert.True(t, rate.InForce(testDay.AddDate(0, 0, 7)))
+	assert.False(t, rate.InForce(testDay.AddDate(0, 0, 8)))
+	assert.ErrorIs(t, baseErr, ErrBaseCurrencyRate)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/currencies/domain"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const erpExchangeRatesPublished = "erp.exchange_rate.published"
+
+// ExchangeRateIngester stores the exchange rates of a day.
+type ExchangeRateIngester interface {
+	IngestRates(ctx context.Context, day time.Time, rates map[string]domain.Rate, source string) error
+}
+
+// ExchangeRateEventHandler stores the daily exchange rates the ERP publishes, the rates
+// of a day in a single event. It implements the subscriber.MessageHandler interface.
+type ExchangeRateEventHandler struct {
+	service ExchangeRateIngester
+	logger  *slog.Logger
+}
+
+type erpExchangeRatesEvent struct {
+	Day   string                 `json:"day"`
+	Rates map[string]domain.Rate `json:"rates"`
+}
+
+func NewExchangeRateEventHandler(service ExchangeRateIngester, logger *slog.Logger) *ExchangeRateEventHandler {
+	return &ExchangeRateEventHandler{
+		service: service,
+		logger:  logger.With("component", "erpExchangeRateEventHandler"),
+	}
+}
+
+// HandleMessage is the entry point called by the NatsSubscriber. Malformed and invalid
+// events are logged and dropped, infrastructure errors are returned to be retried.
+func (h *ExchangeRateEventHandler) HandleMessage(ctx context.Context, subject string, payload []byte) error {
+	var envelope messaging.EventEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		h.logger.Error("Failed to unmarshal event envelope", "error", err, "subject", subject)
+		return nil
+	}
+	if err := envelope.Validate(); err != nil {
+		h.logger.Error("Invalid event envelope", "error", err, "subject", subject)
+		return nil
+	}
+	if envelope.EventType != erpExchangeRatesPublished {
+		h.logger.Warn("Received unknown ERP event, discarding", "type", envelope.EventType)
+		return nil
+	}
+
+	event, err := decodeERPExchangeRatesEvent(envelope)
+	if err != nil {
+		h.logger.Error("Failed to decode ERP event payload", "error", err, "event_id", envelope.EventID)
+		return nil
+	}
+
+	day, err := time.Parse(time.DateOnly, event.Day)
+	v := validator.New()
+	v.Check(err == nil, "day", "day must be a date such as 2025-03-01")
+	v.Check(len(event.Rates) > 0, "rates", "rates must be provided")
+	for currency := range event.Rates {
+		v.Check(validator.Matches(currency, currencyRX), "rates", "rates must be keyed by three-letter ISO 4217 codes")
+	}
+	if !v.Valid() {
+		h.logger.Error("Invalid exchange rates from ERP event", "errors", v.Errors, "event_id", envelope.EventID)
+		return nil // Don't retry validation errors
+	}
+
+	if err := h.service.IngestRates(ctx, day, event.Rates, domain.SourceERP); err != nil {
+		var currencyErr *domain.CurrencyError
+		if errors.As(err, &currencyErr) {
+			h.logger.Error("ERP exchange rates rejected", "error", err, "day", event.Day, "event_id", envelope.EventID)
+			return nil
+		}
+		h.logger.Error("Failed to store exchange rates", "error", err, "day", event.Day, "event_id", envelope.EventID)
+		return err // Retry infrastructure errors
+	}
+
+	h.logger.Info("Exchange rates stored from event", "day", event.Day, "currencies", len(event.Rates), "event_id", envelope.EventID)
+	return nil
+}
+
+// extracts the ERP exchange rates payload from an envelope
+func decodeERPExchangeRatesEvent(envelope messaging.EventEnvelope) (erpExchangeRatesEvent, error) {
+	var event erpExchangeRatesEvent
+
+	payloadBytes, err := json.Marshal(envelope.Payload)
+	if err != nil {
+		return event, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	if err := json.Unmarshal(payloadBytes, &event); err != nil {
+		return event, fmt.Errorf("failed to unmarshal payload to erpExchangeRatesEvent: %w", err)
+	}
+	rates := make(map[string]domain.Rate, len(event.Rates))
+	for currency, rate := range event.Rates {
+		rates[validator.NormalizeCode(currency)] = rate
+	}
+	event.Day = strings.TrimSpace(event.Day)
+	event.Rates = rates
+	return event, nil
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/currencies/domain"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func erpExchangeRatesMessage(t *testing.T, eventType string, data map[string]any) []byte {
+	t.Helper()
+
+	envelope := messaging.NewEventEnvelope(eventType, "2025-03-04", "ExchangeRates", 1, data)
+	payload, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestExchangeRateEventHandler_HandleMessage(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+	valid := map[string]any{"day": "2025-03-04", "rates": map[string]any{" eur ": "4.18", "USD": 3.9874}}
+
+	testCases := []struct {
+		name         string
+		eventType    string
+		data         map[string]any
+		errToReturn  error
+		expectedCall bool
+		expectedErr  error
+	}{
+		{name: "published", eventType: erpExchangeRatesPublished, data: valid, expectedCall: true},
+		{name: "unknown type is dropped", eventType: "erp.exchange_rate.revised", data: valid},
+		{name: "invalid day is dropped", eventType: erpExchangeRatesPublished, data: map[string]any{"day": "04.03.2025", "rates": valid["rates"]}},
+		{name: "malformed rate is dropped", eventType: erpExchangeRatesPublished, data: map[string]any{"day": "2025-03-04", "rates": map[string]any{"EUR": "4,18"}}},
+		{
+			name: "rejected rate is dropped", eventType: erpExchangeRatesPublished, data: valid,
+			errToReturn: domain.ErrBaseCurrencyRate, expectedCall: true,
+		},
+		{
+			name: "infrastructure error is retried", eventType: erpExchangeRatesPublished, data: valid,
+			errToReturn: errDatabase, expectedCall: true, expectedErr: errDatabase,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockExchangeRateService{errToReturn: tc.errToReturn}
+			handler := NewExchangeRateEventHandler(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			// --- Act ---
+			err := handler.HandleMessage(context.Background(), "erp.exchange_rate", erpExchangeRatesMessage(t, tc.eventType, tc.data))
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedCall, svc.ingested != nil)
+			if tc.expectedCall {
+				assert.Equal(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), svc.day)
+				assert.Equal(t, map[string]domain.Rate{"EUR": 418000000, "USD": 398740000}, svc.ingested)
+			}
+		})
+	}
+}
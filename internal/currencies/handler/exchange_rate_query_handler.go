@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/currencies/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+var currencyRX = regexp.MustCompile("^[A-Z]{3}$")
+
+// ExchangeRateQueryService reads the exchange rates and converts amounts at them.
+type ExchangeRateQueryService interface {
+	ListRates(ctx context.Context, at time.Time) ([]domain.ExchangeRate, error)
+	Convert(ctx context.Context, amount int64, from, to string, at time.Time) (*domain.Conversion, error)
+}
+
+// ExchangeRateQueryHandler serves the exchange rates in force on a date and converts
+// amounts at them, now when no date is given:
+//
+//	GET /v1/exchange-rates?at=2025-03-01
+//	GET /v1/exchange-rates/convert?amount=2490&from=PLN&to=EUR&at=2025-03-01
+//
+// Amounts are in the minor unit of their currency. The date is either a day, which is
+// resolved at its start in UTC, or an RFC 3339 time.
+type ExchangeRateQueryHandler struct {
+	service ExchangeRateQueryService
+	clock   clock.Clock
+}
+
+func NewExchangeRateQueryHandler(service ExchangeRateQueryService, clock clock.Clock) *ExchangeRateQueryHandler {
+	return &ExchangeRateQueryHandler{
+		service: service,
+		clock:   clock,
+	}
+}
+
+func (h *ExchangeRateQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	if strings.HasSuffix(r.URL.Path, "/convert") {
+		h.convert(w, r)
+		return
+	}
+	h.listRates(w, r)
+}
+
+func (h *ExchangeRateQueryHandler) listRates(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	at := h.readAt(r, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	rates, err := h.service.ListRates(r.Context(), at)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"base": domain.BaseCurrency, "rates": rates}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *ExchangeRateQueryHandler) convert(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from := validator.NormalizeCode(query.Get("from"))
+	to := validator.NormalizeCode(query.Get("to"))
+
+	v := validator.New()
+	amount, err := strconv.ParseInt(query.Get("amount"), 10, 64)
+	v.Check(err == nil, "amount", "amount must be a whole number of the minor unit of the currency")
+	v.Check(validator.Matches(from, currencyRX), "from", "from must be a three-letter ISO 4217 code")
+	v.Check(validator.Matches(to, currencyRX), "to", "to must be a three-letter ISO 4217 code")
+	at := h.readAt(r, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	conversion, err := h.service.Convert(r.Context(), amount, from, to, at)
+	if err != nil {
+		writeCurrencyError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"conversion": conversion}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// readAt reads the date of the query, now when none is given.
+func (h *ExchangeRateQueryHandler) readAt(r *http.Request, v *validator.Validator) time.Time {
+	raw := r.URL.Query().Get("at")
+	if raw == "" {
+		return h.clock.Now()
+	}
+	at, err := parseAt(raw)
+	v.Check(err == nil, "at", "at must be a date such as 2025-03-01 or an RFC 3339 time")
+	return at
+}
+
+// parseAt reads a day, taken at its start in UTC, or an RFC 3339 time.
+func parseAt(raw string) (time.Time, error) {
+	if day, err := time.Parse(time.DateOnly, raw); err == nil {
+		return day, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// writeCurrencyError answers a failed query with the status matching the kind of currency
+// error, anything that is not a currency error is answered as an internal error. A missing
+// rate is answered with its message, so it is not taken for an unknown route.
+func writeCurrencyError(w http.ResponseWriter, r *http.Request, err error) {
+	var currencyErr *domain.CurrencyError
+	if !errors.As(err, &currencyErr) {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch currencyErr.Kind {
+	case "not_found":
+		httpx.ErrorJSON(w, http.StatusNotFound, currencyErr.Message)
+	default:
+		httpx.ErrorJSON(w, http.StatusUnprocessableEntity, currencyErr.Message)
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/currencies/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 3, 4, 14, 0, 0, 0, time.UTC)
+
+var testEUR = domain.ExchangeRate{Currency: "EUR", Day: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), Rate: 418000000}
+
+type mockExchangeRateService struct {
+	at          time.Time
+	converted   bool
+	ingested    map[string]domain.Rate
+	day         time.Time
+	errToReturn error
+}
+
+func (m *mockExchangeRateService) ListRates(ctx context.Context, at time.Time) ([]domain.ExchangeRate, error) {
+	m.at = at
+	return []domain.ExchangeRate{testEUR}, nil
+}
+
+func (m *mockExchangeRateService) Convert(
+	ctx context.Context, amount int64, from, to string, at time.Time,
+) (*domain.Conversion, error) {
+	m.at, m.converted = at, true
+	if to != "EUR" {
+		return nil, domain.ErrRateNotFound
+	}
+	conversion := domain.Convert(amount, from, nil, to, &testEUR)
+	return &conversion, nil
+}
+
+func (m *mockExchangeRateService) IngestRates(
+	ctx context.Context, day time.Time, rates map[string]domain.Rate, source string,
+) error {
+	m.day, m.ingested = day, rates
+	return m.errToReturn
+}
+
+func serveExchangeRates(t *testing.T, handler http.Handler, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestExchangeRateQueryHandler_ListRates(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockExchangeRateService{}
+	handler := NewExchangeRateQueryHandler(svc, clock.NewFixed(testNow))
+
+	// --- Act ---
+	responseRecorder := serveExchangeRates(t, handler, "/v1/exchange-rates?at=2025-03-01")
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), svc.at)
+	var body struct {
+		Base  string `json:"base"`
+		Rates []struct {
+			Currency string `json:"currency"`
+			Rate     string `json:"rate"`
+		} `json:"rates"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	assert.Equal(t, "PLN", body.Base)
+	require.Len(t, body.Rates, 1)
+	assert.Equal(t, "4.18", body.Rates[0].Rate)
+}
+
+func TestExchangeRateQueryHandler_Convert(t *testing.T) {
+	testCases := []struct {
+		name              string
+		target            string
+		expectedStatus    int
+		expectedConverted int64
+		expectedCall      bool
+	}{
+		{
+			name: "converted", target: "/v1/exchange-rates/convert?amount=2490&from=pln&to=eur",
+			expectedStatus: http.StatusOK, expectedConverted: 596, expectedCall: true,
+		},
+		{
+			name: "no rate", target: "/v1/exchange-rates/convert?amount=2490&from=PLN&to=CHF",
+			expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+		{name: "missing amount", target: "/v1/exchange-rates/convert?from=PLN&to=EUR", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fractional amount", target: "/v1/exchange-rates/convert?amount=24.90&from=PLN&to=EUR", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "invalid currency", target: "/v1/exchange-rates/convert?amount=2490&from=PLN&to=EURO", expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockExchangeRateService{}
+			handler := NewExchangeRateQueryHandler(svc, clock.NewFixed(testNow))
+
+			// --- Act ---
+			responseRecorder := serveExchangeRates(t, handler, tc.target)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.converted)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, testNow, svc.at)
+			var body struct {
+				Conversion domain.Conversion `json:"conversion"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedConverted, body.Conversion.Converted)
+			assert.Equal(t, "EUR", body.Conversion.To)
+		})
+	}
+}
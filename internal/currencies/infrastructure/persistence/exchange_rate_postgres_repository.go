@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/currencies/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type ExchangeRatePostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewExchangeRatePostgresRepository(db *database.PostgresDB) *ExchangeRatePostgresRepository {
+	return &ExchangeRatePostgresRepository{
+		db: db,
+	}
+}
+
+func (r *ExchangeRatePostgresRepository) SaveRates(ctx context.Context, rates []domain.ExchangeRate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, rate := range rates {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO exchange_rates (currency, day, rate, source, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (currency, day) DO UPDATE SET
+				rate = EXCLUDED.rate, source = EXCLUDED.source, updated_at = EXCLUDED.updated_at
+		`, rate.Currency, rate.Day, rate.Rate, rate.Source, rate.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save exchange rate: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit exchange rates: %w", err)
+	}
+	return nil
+}
+
+func (r *ExchangeRatePostgresRepository) FindRate(
+	ctx context.Context, currency string, day time.Time,
+) (*domain.ExchangeRate, error) {
+	rate := &domain.ExchangeRate{}
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT currency, day, rate, source, updated_at
+		FROM exchange_rates
+		WHERE currency = $1 AND day <= $2
+		ORDER BY day DESC
+		LIMIT 1
+	`, currency, day).Scan(&rate.Currency, &rate.Day, &rate.Rate, &rate.Source, &rate.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRateNotFound
+		}
+		return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+	return rate, nil
+}
+
+func (r *ExchangeRatePostgresRepository) ListRates(ctx context.Context, day time.Time) ([]domain.ExchangeRate, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT DISTINCT ON (currency) currency, day, rate, source, updated_at
+		FROM exchange_rates
+		WHERE day <= $1
+		ORDER BY currency, day DESC
+	`, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := []domain.ExchangeRate{}
+	for rows.Next() {
+		var rate domain.ExchangeRate
+		if err := rows.Scan(&rate.Currency, &rate.Day, &rate.Rate, &rate.Source, &rate.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exchange rates: %w", err)
+	}
+	return rates, nil
+}
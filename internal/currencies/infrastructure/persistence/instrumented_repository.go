@@ -0,0 +1,41 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/currencies/domain"
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+)
+
+// InstrumentedExchangeRateRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedExchangeRateRepository struct {
+	next domain.ExchangeRateRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedExchangeRateRepository(
+	next domain.ExchangeRateRepository, rec *instrument.Recorder,
+) *InstrumentedExchangeRateRepository {
+	return &InstrumentedExchangeRateRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedExchangeRateRepository) SaveRates(ctx context.Context, rates []domain.ExchangeRate) error {
+	return instrument.Exec(ctx, r.rec, "SaveRates", func(ctx context.Context) error {
+		return r.next.SaveRates(ctx, rates)
+	})
+}
+
+func (r *InstrumentedExchangeRateRepository) FindRate(
+	ctx context.Context, currency string, day time.Time,
+) (*domain.ExchangeRate, error) {
+	return instrument.Call(ctx, r.rec, "FindRate", func(ctx context.Context) (*domain.ExchangeRate, error) {
+		return r.next.FindRate(ctx, currency, day)
+	})
+}
+
+func (r *InstrumentedExchangeRateRepository) ListRates(ctx context.Context, day time.Time) ([]domain.ExchangeRate, error) {
+	return instrument.Call(ctx, r.rec, "ListRates", func(ctx context.Context) ([]domain.ExchangeRate, error) {
+		return r.next.ListRates(ctx, day)
+	})
+}
@@ -0,0 +1,216 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/salesworks/s-works/api/internal/customers/domain"
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CustomerService maintains the customers and publishes their events.
+type CustomerService struct {
+	repo         domain.CustomerRepository
+	eventStore   eventstore.Store
+	clock        clock.Clock
+	eventChannel string
+	source       messaging.Source
+}
+
+func NewCustomerCommandService(
+	repo domain.CustomerRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+) *CustomerService {
+	return &CustomerService{
+		repo:         repo,
+		eventStore:   eventStore,
+		clock:        clock,
+		eventChannel: "app.customer",
+		source:       source,
+	}
+}
+
+func (s *CustomerService) CreateCustomer(
+	ctx context.Context, code string, details domain.CustomerDetails,
+) (*domain.Customer, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "customer.service.create")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "customer.service")
+
+	customer := domain.NewCustomer(code, details, aggregate.StampNow(ctx, s.clock))
+	if err := s.repo.SaveCustomer(ctx, customer); err != nil {
+		return nil, s.failed(span, logger, "saving customer failed", err)
+	}
+
+	if err := s.publish(ctx, customer); err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+func (s *CustomerService) UpdateCustomer(
+	ctx context.Context, code string, details domain.CustomerDetails, version int,
+) (*domain.Customer, error) {
+	return s.change(ctx, code, "customer.service.update", func(customer *domain.Customer, stamp domain.Stamp) error {
+		return customer.Update(details, version, stamp)
+	})
+}
+
+func (s *CustomerService) DeleteCustomer(ctx context.Context, code string, version int) (*domain.Customer, error) {
+	return s.change(ctx, code, "customer.service.delete", func(customer *domain.Customer, stamp domain.Stamp) error {
+		return customer.Delete(version, stamp)
+	})
+}
+
+func (s *CustomerService) RestoreCustomer(ctx context.Context, code string, version int) (*domain.Customer, error) {
+	return s.change(ctx, code, "customer.service.restore", func(customer *domain.Customer, stamp domain.Stamp) error {
+		return customer.Restore(version, stamp)
+	})
+}
+
+// SyncCustomer brings the customer to the given master data at whatever version it is,
+// creating it when the ERP sends a customer not known yet. A customer already holding the
+// data is left untouched and reported unchanged, so re-sent ERP events are harmless.
+func (s *CustomerService) SyncCustomer(
+	ctx context.Context, code string, details domain.CustomerDetails,
+) (*domain.Customer, bool, error) {
+	current, err := s.repo.GetCustomer(ctx, code)
+	if errors.Is(err, domain.ErrCustomerNotFound) {
+		customer, err := s.CreateCustomer(ctx, code, details)
+		return customer, err == nil, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if !current.Deleted() && current.Details() == details {
+		return current, false, nil
+	}
+
+	customer, err := s.UpdateCustomer(ctx, code, details, current.Version)
+	return customer, err == nil, err
+}
+
+// SyncCustomerDeletion deletes the customer at whatever version it is, for deletions sent
+// by the ERP. A customer that is unknown or already deleted is reported unchanged.
+func (s *CustomerService) SyncCustomerDeletion(ctx context.Context, code string) (bool, error) {
+	current, err := s.repo.GetCustomer(ctx, code)
+	if errors.Is(err, domain.ErrCustomerNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if current.Deleted() {
+		return false, nil
+	}
+
+	_, err = s.DeleteCustomer(ctx, code, current.Version)
+	return err == nil, err
+}
+
+// change loads the customer, applies a command to it, stores it and publishes its events.
+func (s *CustomerService) change(
+	ctx context.Context, code, spanName string, apply func(customer *domain.Customer, stamp domain.Stamp) error,
+) (*domain.Customer, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, spanName)
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "customer.service")
+
+	customer, err := s.repo.GetCustomer(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(customer, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateCustomer(ctx, customer); err != nil {
+		return nil, s.failed(span, logger, "updating customer failed", err)
+	}
+
+	if err := s.publish(ctx, customer); err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// failed reports a repository write that did not succeed. Customer errors are passed
+// through as they are, anything else is wrapped and recorded as a database error.
+func (s *CustomerService) failed(span trace.Span, logger *slog.Logger, msg string, err error) error {
+	var customerErr *domain.CustomerError
+	if errors.As(err, &customerErr) {
+		return err
+	}
+	wrappedErr := fmt.Errorf("failed to write customer in repo: %w", err)
+	logger.Error(msg, "error", wrappedErr)
+	span.RecordError(wrappedErr)
+	span.SetStatus(codes.Error, "database write error")
+	return wrappedErr
+}
+
+func (s *CustomerService) publish(ctx context.Context, customer *domain.Customer) error {
+	logger := httpx.GetLogger(ctx).With("component", "customer.service")
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range customer.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.CustomerCreated:
+			eventType = "app.customer.created"
+		case domain.CustomerUpdated:
+			eventType = "app.customer.updated"
+		case domain.CustomerDeleted:
+			eventType = "app.customer.deleted"
+		case domain.CustomerRestored:
+			eventType = "app.customer.restored"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			customer.Code,
+			"Customer",
+			customer.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
+			wrappedErr := fmt.Errorf("failed to save customer event to event store: %w", err)
+			logger.Error("saving customer event failed", "error", wrappedErr)
+			return wrappedErr
+		}
+	}
+
+	return nil
+}
+
+// saveEvents appends the envelopes to the event store. Events of commands issued through
+// the REST API are also queued in the outbox to be published; events of customers mirrored
+// from the ERP are not published back.
+func (s *CustomerService) saveEvents(ctx context.Context, envelopes []*messaging.EventEnvelope) error {
+	for _, envelope := range envelopes {
+		envelope.UserID = command.Actor(ctx)
+	}
+	if command.IsFromREST(ctx) {
+		return s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopes...)
+	}
+	return s.eventStore.Save(ctx, envelopes...)
+}
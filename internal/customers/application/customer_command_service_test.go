@@ -0,0 +1,237 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/customers/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = domain.Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testSource = messaging.Source{Service: "s-works-api", Instance: "test-instance"}
+
+var testDetails = domain.CustomerDetails{Name: "Atelier Nord", Email: "orders@atelier-nord.example", Country: "PL"}
+
+type mockCustomerRepository struct {
+	customers   map[string]*domain.Customer
+	saved       *domain.Customer
+	errToReturn error
+}
+
+func (m *mockCustomerRepository) SaveCustomer(ctx context.Context, customer *domain.Customer) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.saved = customer
+	return nil
+}
+
+func (m *mockCustomerRepository) GetCustomer(ctx context.Context, code string) (*domain.Customer, error) {
+	customer, ok := m.customers[code]
+	if !ok {
+		return nil, domain.ErrCustomerNotFound
+	}
+	customerCopy := *customer
+	return &customerCopy, nil
+}
+
+func (m *mockCustomerRepository) ListCustomers(ctx context.Context, limit, offset int) ([]*domain.Customer, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockCustomerRepository) UpdateCustomer(ctx context.Context, customer *domain.Customer) error {
+	return m.SaveCustomer(ctx, customer)
+}
+
+type mockEventStore struct {
+	SavedCalled      bool
+	EnqueuedSubject  string
+	EnqueuedEnvelope *messaging.EventEnvelope
+}
+
+func (m *mockEventStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
+	m.SavedCalled = true
+	return nil
+}
+
+func (m *mockEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.SavedCalled = true
+	m.EnqueuedSubject = subject
+	m.EnqueuedEnvelope = envelopes[len(envelopes)-1]
+	return nil
+}
+
+func newTestRepository() *mockCustomerRepository {
+	customer := &domain.Customer{Code: "CUST01", Name: "Atelier Nord", Email: "orders@atelier-nord.example", Country: "PL", Version: 1}
+	return &mockCustomerRepository{customers: map[string]*domain.Customer{customer.Code: customer}}
+}
+
+func restContext() context.Context {
+	return command.WithCommandSource(context.Background(), command.CommandSourceREST)
+}
+
+func TestCustomerService_CreateCustomer_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewCustomerCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	customer, err := service.CreateCustomer(restContext(), "CUST02", testDetails)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, repo.saved, "expected SaveCustomer() to be called on the repository")
+	assert.Equal(t, "PL", customer.Country)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.customer", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.customer.created", publishedEnvelope.EventType)
+	assert.Equal(t, "Customer", publishedEnvelope.AggregateType)
+	assert.Equal(t, "CUST02", publishedEnvelope.AggregateID)
+	assert.Equal(t, 1, publishedEnvelope.AggregateVersion)
+}
+
+func TestCustomerService_SyncCustomer(t *testing.T) {
+	changedDetails := testDetails
+	changedDetails.Phone = "+48 22 123 45 67"
+
+	testCases := []struct {
+		name            string
+		code            string
+		details         domain.CustomerDetails
+		expectedChanged bool
+		expectedVersion int
+	}{
+		{name: "Unknown customer is created", code: "CUST02", details: testDetails, expectedChanged: true, expectedVersion: 1},
+		{name: "Changed data is applied", code: "CUST01", details: changedDetails, expectedChanged: true, expectedVersion: 2},
+		{name: "Same data is left untouched", code: "CUST01", details: testDetails, expectedVersion: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			eventStore := &mockEventStore{}
+			service := NewCustomerCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+			ctx := command.WithCommandSource(context.Background(), command.CommandSourceEvent)
+
+			// --- Act ---
+			customer, changed, err := service.SyncCustomer(ctx, tc.code, tc.details)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChanged, changed)
+			assert.Equal(t, tc.expectedVersion, customer.Version)
+			assert.Equal(t, tc.expectedChanged, eventStore.SavedCalled)
+			assert.Nil(t, eventStore.EnqueuedEnvelope, "customers mirrored from the ERP are not published back")
+		})
+	}
+}
+
+func TestCustomerService_RejectedIsNotPublished(t *testing.T) {
+	testCases := []struct {
+		name        string
+		errToReturn error
+		run         func(s *CustomerService) error
+		expectedErr error
+	}{
+		{
+			name: "Stale version",
+			run: func(s *CustomerService) error {
+				_, err := s.UpdateCustomer(restContext(), "CUST01", testDetails, 2)
+				return err
+			},
+			expectedErr: domain.ErrConcurrencyConflict,
+		},
+		{
+			name: "Code taken",
+			run: func(s *CustomerService) error {
+				_, err := s.CreateCustomer(restContext(), "CUST01", testDetails)
+				return err
+			},
+			errToReturn: domain.ErrDuplicateCustomerCode,
+			expectedErr: domain.ErrDuplicateCustomerCode,
+		},
+		{
+			name: "Restore of a live customer",
+			run: func(s *CustomerService) error {
+				_, err := s.RestoreCustomer(restContext(), "CUST01", 1)
+				return err
+			},
+			expectedErr: domain.ErrCustomerNotDeleted,
+		},
+		{
+			name: "Unknown customer",
+			run: func(s *CustomerService) error {
+				_, err := s.DeleteCustomer(restContext(), "NOSUCH", 1)
+				return err
+			},
+			expectedErr: domain.ErrCustomerNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			repo.errToReturn = tc.errToReturn
+			eventStore := &mockEventStore{}
+			service := NewCustomerCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err := tc.run(service)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, repo.saved)
+			assert.False(t, eventStore.SavedCalled, "a rejected command must not be stored")
+		})
+	}
+}
+
+func TestCustomerService_SyncCustomerDeletion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		code            string
+		expectedChanged bool
+	}{
+		{name: "Live customer is deleted", code: "CUST01", expectedChanged: true},
+		{name: "Unknown customer is ignored", code: "NOSUCH"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			eventStore := &mockEventStore{}
+			service := NewCustomerCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+			ctx := command.WithCommandSource(context.Background(), command.CommandSourceEvent)
+
+			// --- Act ---
+			changed, err := service.SyncCustomerDeletion(ctx, tc.code)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChanged, changed)
+			if tc.expectedChanged {
+				require.NotNil(t, repo.saved)
+				assert.True(t, repo.saved.Deleted())
+				assert.Equal(t, 2, repo.saved.Version)
+			}
+		})
+	}
+}
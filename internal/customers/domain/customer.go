@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+var (
+	ErrCustomerNotFound      = notFoundError("customer not found")
+	ErrDuplicateCustomerCode = conflictError("a customer with this code already exists")
+	ErrConcurrencyConflict   = conflictError("the customer has been modified by another process, please refresh and try again")
+	ErrCustomerDeleted       = conflictError("cannot perform on a deleted customer")
+	ErrCustomerNotDeleted    = conflictError("the customer is not deleted")
+)
+
+// CustomerError is a rule violation reported by the customer domain. Its kind tells the
+// handler which status to answer with and instrumentation how to class it.
+type CustomerError struct {
+	Kind    string
+	Message string
+}
+
+func (e *CustomerError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *CustomerError) ErrorClass() string {
+	return e.Kind
+}
+
+func notFoundError(message string) *CustomerError {
+	return &CustomerError{Kind: "not_found", Message: message}
+}
+
+func conflictError(message string) *CustomerError {
+	return &CustomerError{Kind: "conflict", Message: message}
+}
+
+type Event = aggregate.Event
+
+// Stamp identifies who performed a change on a customer and when it happened.
+type Stamp = aggregate.Stamp
+
+// Customer buys fabrics, identified by the code the ERP gave it. A deleted customer is
+// kept, so it can be restored and its code is not given to another one.
+type Customer struct {
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+	// TaxID is the VAT or other tax number the customer is invoiced under.
+	TaxID string `json:"tax_id,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 code of the country the customer is based in.
+	Country string `json:"country,omitempty"`
+	Version int    `json:"version"`
+	aggregate.Audit
+	aggregate.SoftDelete
+	aggregate.Root
+}
+
+// CustomerDetails is the master data of a customer, everything but its code.
+type CustomerDetails struct {
+	Name    string
+	Email   string
+	Phone   string
+	TaxID   string
+	Country string
+}
+
+type CustomerCreated struct {
+	Code    string
+	Name    string
+	Email   string
+	Phone   string
+	TaxID   string
+	Country string
+	Version int
+}
+
+type CustomerUpdated struct {
+	Code    string
+	Name    string
+	Email   string
+	Phone   string
+	TaxID   string
+	Country string
+	Version int
+}
+
+type CustomerDeleted struct {
+	Code    string
+	Version int
+}
+
+type CustomerRestored struct {
+	Code    string
+	Version int
+}
+
+func NewCustomer(code string, details CustomerDetails, stamp Stamp) *Customer {
+	customer := &Customer{
+		Code:    code,
+		Version: 1,
+		Audit:   aggregate.NewAudit(stamp),
+	}
+	customer.setDetails(details)
+
+	event := CustomerCreated{
+		Code:    customer.Code,
+		Name:    customer.Name,
+		Email:   customer.Email,
+		Phone:   customer.Phone,
+		TaxID:   customer.TaxID,
+		Country: customer.Country,
+		Version: customer.Version,
+	}
+	customer.Record(event)
+	return customer
+}
+
+// Details returns the master data of the customer.
+func (c *Customer) Details() CustomerDetails {
+	return CustomerDetails{Name: c.Name, Email: c.Email, Phone: c.Phone, TaxID: c.TaxID, Country: c.Country}
+}
+
+// Update replaces the master data of the customer.
+func (c *Customer) Update(details CustomerDetails, version int, stamp Stamp) error {
+	if c.Deleted() {
+		return ErrCustomerDeleted
+	}
+	if err := aggregate.CheckVersion(c.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	c.setDetails(details)
+	c.Version++
+	c.Touch(stamp)
+
+	event := CustomerUpdated{
+		Code:    c.Code,
+		Name:    c.Name,
+		Email:   c.Email,
+		Phone:   c.Phone,
+		TaxID:   c.TaxID,
+		Country: c.Country,
+		Version: c.Version,
+	}
+	c.Record(event)
+	return nil
+}
+
+// Delete marks the customer deleted, it keeps its code until it is restored.
+func (c *Customer) Delete(version int, stamp Stamp) error {
+	if c.Deleted() {
+		return ErrCustomerDeleted
+	}
+	if err := aggregate.CheckVersion(c.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	c.MarkDeleted(stamp.At)
+	c.Version++
+	c.Touch(stamp)
+
+	event := CustomerDeleted{
+		Code:    c.Code,
+		Version: c.Version,
+	}
+	c.Record(event)
+	return nil
+}
+
+// Restore brings a deleted customer back with the master data it had.
+func (c *Customer) Restore(version int, stamp Stamp) error {
+	if !c.Deleted() {
+		return ErrCustomerNotDeleted
+	}
+	if err := aggregate.CheckVersion(c.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	c.ClearDeleted()
+	c.Version++
+	c.Touch(stamp)
+
+	event := CustomerRestored{
+		Code:    c.Code,
+		Version: c.Version,
+	}
+	c.Record(event)
+	return nil
+}
+
+func (c *Customer) setDetails(details CustomerDetails) {
+	c.Name = details.Name
+	c.Email = details.Email
+	c.Phone = details.Phone
+	c.TaxID = details.TaxID
+	c.Country = details.Country
+}
+
+type CustomerRepository interface {
+	// SaveCustomer stores a new customer, failing with ErrDuplicateCustomerCode when the
+	// code is taken, deleted customers included.
+	SaveCustomer(ctx context.Context, customer *Customer) error
+	// GetCustomer loads a customer, deleted ones included, or fails with ErrCustomerNotFound.
+	GetCustomer(ctx context.Context, code string) (*Customer, error)
+	// ListCustomers returns a page of the customers that are not deleted, by code, together
+	// with their total number.
+	ListCustomers(ctx context.Context, limit, offset int) ([]*Customer, int, error)
+	// UpdateCustomer stores a change of a customer still at the version it was loaded
+	// with, or fails with ErrConcurrencyConflict.
+	UpdateCustomer(ctx context.Context, customer *Customer) error
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testDetails = CustomerDetails{
+	Name: "Atelier Nord", Email: "orders@atelier-nord.example", Phone: "+48 22 123 45 67", TaxID: "PL5260001246", Country: "PL",
+}
+
+func TestNewCustomer(t *testing.T) {
+	// --- Act ---
+	customer := NewCustomer("CUST01", testDetails, testStamp)
+
+	// --- Assert ---
+	assert.Equal(t, testDetails, customer.Details())
+	assert.Equal(t, 1, customer.Version)
+	assert.False(t, customer.Deleted())
+	require.Len(t, customer.Events(), 1)
+	assert.Equal(t, CustomerCreated{
+		Code: "CUST01", Name: "Atelier Nord", Email: "orders@atelier-nord.example", Phone: "+48 22 123 45 67",
+		TaxID: "PL5260001246", Country: "PL", Version: 1,
+	}, customer.Events()[0])
+}
+
+func TestCustomer_Update(t *testing.T) {
+	testCases := []struct {
+		name        string
+		version     int
+		expectedErr error
+	}{
+		{name: "Current version", version: 1},
+		{name: "Stale version", version: 2, expectedErr: ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			customer := NewCustomer("CUST01", testDetails, testStamp)
+			details := testDetails
+			details.Name = "Atelier Nord Sp. z o.o."
+
+			// --- Act ---
+			err := customer.Update(details, tc.version, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Len(t, customer.Events(), 1, "a rejected update must not record an event")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 2, customer.Version)
+			assert.Equal(t, "Atelier Nord Sp. z o.o.", customer.Name)
+			updated, ok := customer.Events()[1].(CustomerUpdated)
+			require.True(t, ok, "expected a CustomerUpdated event")
+			assert.Equal(t, "Atelier Nord Sp. z o.o.", updated.Name)
+			assert.Equal(t, "PL", updated.Country)
+		})
+	}
+}
+
+func TestCustomer_DeleteAndRestore(t *testing.T) {
+	// --- Arrange ---
+	customer := NewCustomer("CUST01", testDetails, testStamp)
+
+	// --- Act ---
+	deleteErr := customer.Delete(1, testStamp)
+	updateErr := customer.Update(testDetails, 2, testStamp)
+	restoreErr := customer.Restore(2, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, deleteErr)
+	assert.ErrorIs(t, updateErr, ErrCustomerDeleted)
+	require.NoError(t, restoreErr)
+	assert.False(t, customer.Deleted())
+	assert.Equal(t, 3, customer.Version)
+	require.Len(t, customer.Events(), 3)
+	assert.IsType(t, CustomerRestored{}, customer.Events()[2])
+}
+
+func TestCustomer_Restore_NotDeleted(t *testing.T) {
+	// --- Arrange ---
+	customer := NewCustomer("CUST01", testDetails, testStamp)
+
+	// --- Act ---
+	err := customer.Restore(1, testStamp)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, ErrCustomerNotDeleted)
+	assert.Len(t, customer.Events(), 1)
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/customers/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+var (
+	customerCodeRX = regexp.MustCompile("^[A-Z0-9-]+$")
+	countryRX      = regexp.MustCompile("^[A-Z]{2}$")
+)
+
+// CustomerCommandService maintains the customers.
+type CustomerCommandService interface {
+	CreateCustomer(ctx context.Context, code string, details domain.CustomerDetails) (*domain.Customer, error)
+	UpdateCustomer(
+		ctx context.Context, code string, details domain.CustomerDetails, version int,
+	) (*domain.Customer, error)
+	DeleteCustomer(ctx context.Context, code string, version int) (*domain.Customer, error)
+	RestoreCustomer(ctx context.Context, code string, version int) (*domain.Customer, error)
+}
+
+// CustomerCommandHandler creates, updates, deletes and restores customers.
+type CustomerCommandHandler struct {
+	service CustomerCommandService
+}
+
+type createCustomerRequest struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Phone   string `json:"phone"`
+	TaxID   string `json:"tax_id"`
+	Country string `json:"country"`
+}
+
+type updateCustomerRequest struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Phone   string `json:"phone"`
+	TaxID   string `json:"tax_id"`
+	Country string `json:"country"`
+	Version int    `json:"version"`
+}
+
+// versionRequest carries the version a delete or restore is made against.
+type versionRequest struct {
+	Version int `json:"version"`
+}
+
+func NewCustomerCommandHandler(service CustomerCommandService) *CustomerCommandHandler {
+	return &CustomerCommandHandler{service: service}
+}
+
+func (h *CustomerCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+	r = r.WithContext(ctx)
+
+	switch r.Method {
+	case http.MethodPost:
+		// a customer is created on the collection and restored on its own resource
+		if httpx.URLParam(r, "code") != "" {
+			h.restoreCustomer(w, r)
+			return
+		}
+		h.createCustomer(w, r)
+	case http.MethodPut:
+		h.updateCustomer(w, r)
+	case http.MethodDelete:
+		h.deleteCustomer(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *CustomerCommandHandler) createCustomer(w http.ResponseWriter, r *http.Request) {
+	var req createCustomerRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Code = validator.NormalizeCode(req.Code)
+	details := normalizeDetails(req.Name, req.Email, req.Phone, req.TaxID, req.Country)
+	v := validator.New()
+	v.Check(req.Code != "", "code", "code must be provided")
+	v.Check(len(req.Code) >= 2 && len(req.Code) <= 30, "code", "code must be between 2 and 30 characters long")
+	v.Check(validator.Matches(req.Code, customerCodeRX), "code", "code must only contain uppercase letters, numbers and dashes")
+	validateCustomer(v, details)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	customer, err := h.service.CreateCustomer(r.Context(), req.Code, details)
+	if err != nil {
+		writeCustomerError(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", "/v1/customers/"+customer.Code)
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"customer": customer}, headers); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *CustomerCommandHandler) updateCustomer(w http.ResponseWriter, r *http.Request) {
+	var req updateCustomerRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	details := normalizeDetails(req.Name, req.Email, req.Phone, req.TaxID, req.Country)
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	validateCustomer(v, details)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	customer, err := h.service.UpdateCustomer(r.Context(), httpx.URLParam(r, "code"), details, req.Version)
+	if err != nil {
+		writeCustomerError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"customer": customer}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *CustomerCommandHandler) deleteCustomer(w http.ResponseWriter, r *http.Request) {
+	version, ok := readVersion(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.service.DeleteCustomer(r.Context(), httpx.URLParam(r, "code"), version); err != nil {
+		writeCustomerError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CustomerCommandHandler) restoreCustomer(w http.ResponseWriter, r *http.Request) {
+	version, ok := readVersion(w, r)
+	if !ok {
+		return
+	}
+
+	customer, err := h.service.RestoreCustomer(r.Context(), httpx.URLParam(r, "code"), version)
+	if err != nil {
+		writeCustomerError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"customer": customer}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// readVersion reads the version of a delete or restore, answering the request itself when
+// it is missing or invalid.
+func readVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	var req versionRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return 0, false
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return 0, false
+	}
+	return req.Version, true
+}
+
+// normalizeDetails cleans up customer input the same way for the API and the ERP.
+func normalizeDetails(name, email, phone, taxID, country string) domain.CustomerDetails {
+	return domain.CustomerDetails{
+		Name:    validator.NormalizeText(name),
+		Email:   strings.TrimSpace(email),
+		Phone:   strings.TrimSpace(phone),
+		TaxID:   validator.NormalizeCode(taxID),
+		Country: validator.NormalizeCode(country),
+	}
+}
+
+// validateCustomer checks the master data of a customer, whether it comes from the API or
+// the ERP.
+func validateCustomer(v *validator.Validator, details domain.CustomerDetails) {
+	v.Check(details.Name != "", "name", "name must be provided")
+	v.Check(len(details.Name) <= 250, "name", "name must not be more than 250 characters long")
+	if details.Email != "" {
+		v.Check(validator.Matches(details.Email, validator.EmailRX), "email", "email must be a valid email address")
+	}
+	v.Check(len(details.Phone) <= 50, "phone", "phone must not be more than 50 characters long")
+	v.Check(len(details.TaxID) <= 50, "tax_id", "tax_id must not be more than 50 characters long")
+	if details.Country != "" {
+		v.Check(validator.Matches(details.Country, countryRX), "country", "country must be a two-letter ISO 3166-1 code")
+	}
+}
+
+// writeCustomerError answers a failed command with the status matching the kind of
+// customer error, anything that is not a customer error is answered as an internal error.
+func writeCustomerError(w http.ResponseWriter, r *http.Request, err error) {
+	var customerErr *domain.CustomerError
+	if !errors.As(err, &customerErr) {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch customerErr.Kind {
+	case "not_found":
+		httpx.NotFound(w, r)
+	default:
+		httpx.ErrorJSON(w, http.StatusConflict, customerErr.Message)
+	}
+}
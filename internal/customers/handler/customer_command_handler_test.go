@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/customers/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCustomerCommandService struct {
+	called      string
+	code        string
+	details     domain.CustomerDetails
+	version     int
+	errToReturn error
+}
+
+func (m *mockCustomerCommandService) CreateCustomer(
+	ctx context.Context, code string, details domain.CustomerDetails,
+) (*domain.Customer, error) {
+	m.called, m.code, m.details = "create", code, details
+	return m.result(code, 1)
+}
+
+func (m *mockCustomerCommandService) UpdateCustomer(
+	ctx context.Context, code string, details domain.CustomerDetails, version int,
+) (*domain.Customer, error) {
+	m.called, m.code, m.details, m.version = "update", code, details, version
+	return m.result(code, version+1)
+}
+
+func (m *mockCustomerCommandService) DeleteCustomer(ctx context.Context, code string, version int) (*domain.Customer, error) {
+	m.called, m.code, m.version = "delete", code, version
+	return m.result(code, version+1)
+}
+
+func (m *mockCustomerCommandService) RestoreCustomer(ctx context.Context, code string, version int) (*domain.Customer, error) {
+	m.called, m.code, m.version = "restore", code, version
+	return m.result(code, version+1)
+}
+
+func (m *mockCustomerCommandService) result(code string, version int) (*domain.Customer, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Customer{Code: code, Version: version}, nil
+}
+
+func serveCustomer(
+	t *testing.T, handler http.Handler, method, target, body string, params map[string]string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestCustomerCommandHandler_CreateCustomer(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockCustomerCommandService{}
+	handler := NewCustomerCommandHandler(svc)
+	body := `{"code": " cust01 ", "name": "Atelier Nord", "email": " orders@atelier-nord.example ", "tax_id": "pl5260001246", "country": "pl"}`
+
+	// --- Act ---
+	responseRecorder := serveCustomer(t, handler, http.MethodPost, "/v1/customers", body, nil)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, "/v1/customers/CUST01", responseRecorder.Header().Get("Location"))
+	assert.Equal(t, "CUST01", svc.code)
+	assert.Equal(t, domain.CustomerDetails{
+		Name: "Atelier Nord", Email: "orders@atelier-nord.example", TaxID: "PL5260001246", Country: "PL",
+	}, svc.details)
+}
+
+func TestCustomerCommandHandler_RestoreCustomer(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockCustomerCommandService{}
+	handler := NewCustomerCommandHandler(svc)
+
+	// --- Act ---
+	responseRecorder := serveCustomer(
+		t, handler, http.MethodPost, "/v1/customers/CUST01/restore", `{"version": 2}`, map[string]string{"code": "CUST01"},
+	)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "restore", svc.called)
+	assert.Equal(t, 2, svc.version)
+}
+
+func TestCustomerCommandHandler_Rejected(t *testing.T) {
+	customer := map[string]string{"code": "CUST01"}
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		params         map[string]string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{
+			name: "invalid code", method: http.MethodPost, body: `{"code": "cust01!", "name": "Atelier Nord"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "invalid email", method: http.MethodPost, body: `{"code": "CUST01", "name": "Atelier Nord", "email": "orders"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "invalid country", method: http.MethodPost, body: `{"code": "CUST01", "name": "Atelier Nord", "country": "POL"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing version", method: http.MethodPut, body: `{"name": "Atelier Nord"}`,
+			params: customer, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "duplicate code", method: http.MethodPost, body: `{"code": "CUST01", "name": "Atelier Nord"}`,
+			errToReturn: domain.ErrDuplicateCustomerCode, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "stale version", method: http.MethodDelete, body: `{"version": 1}`,
+			params:      customer,
+			errToReturn: domain.ErrConcurrencyConflict, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "unknown customer", method: http.MethodPut, body: `{"name": "Atelier Nord", "version": 1}`,
+			params:      customer,
+			errToReturn: domain.ErrCustomerNotFound, expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockCustomerCommandService{errToReturn: tc.errToReturn}
+			handler := NewCustomerCommandHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveCustomer(t, handler, tc.method, "/v1/customers", tc.body, tc.params)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called != "")
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/salesworks/s-works/api/internal/customers/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	erpCustomerCreated = "erp.customer.created"
+	erpCustomerUpdated = "erp.customer.updated"
+	erpCustomerDeleted = "erp.customer.deleted"
+)
+
+// CustomerSyncService mirrors the customers of the ERP.
+type CustomerSyncService interface {
+	SyncCustomer(ctx context.Context, code string, details domain.CustomerDetails) (*domain.Customer, bool, error)
+	SyncCustomerDeletion(ctx context.Context, code string) (bool, error)
+}
+
+// CustomerEventHandler mirrors the customer master data the ERP publishes. The ERP owns
+// the customers, so its events are applied at whatever version the customer is at.
+// It implements the subscriber.MessageHandler interface.
+type CustomerEventHandler struct {
+	service CustomerSyncService
+	logger  *slog.Logger
+}
+
+type erpCustomerEvent struct {
+	Code    string `json:"customer_code"`
+	Name    string `json:"customer_name"`
+	Email   string `json:"email,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+	TaxID   string `json:"tax_id,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+func NewCustomerEventHandler(service CustomerSyncService, logger *slog.Logger) *CustomerEventHandler {
+	return &CustomerEventHandler{
+		service: service,
+		logger:  logger.With("component", "erpCustomerEventHandler"),
+	}
+}
+
+// HandleMessage is the entry point called by the NatsSubscriber. Malformed and invalid
+// events are logged and dropped, infrastructure errors are returned to be retried.
+func (h *CustomerEventHandler) HandleMessage(ctx context.Context, subject string, payload []byte) error {
+	var envelope messaging.EventEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		h.logger.Error("Failed to unmarshal event envelope", "error", err, "subject", subject)
+		return nil
+	}
+	if err := envelope.Validate(); err != nil {
+		h.logger.Error("Invalid event envelope", "error", err, "subject", subject)
+		return nil
+	}
+
+	event, err := decodeERPCustomerEvent(envelope)
+	if err != nil {
+		h.logger.Error("Failed to decode ERP event payload", "error", err, "event_id", envelope.EventID)
+		return nil
+	}
+
+	ctx = command.WithCommandSource(ctx, command.CommandSourceEvent)
+	switch envelope.EventType {
+	case erpCustomerCreated, erpCustomerUpdated:
+		return h.handleSync(ctx, event, envelope.EventID)
+	case erpCustomerDeleted:
+		return h.handleDelete(ctx, event, envelope.EventID)
+	default:
+		h.logger.Warn("Received unknown ERP event, discarding", "type", envelope.EventType)
+		return nil
+	}
+}
+
+// extracts the ERP customer payload from an envelope
+func decodeERPCustomerEvent(envelope messaging.EventEnvelope) (erpCustomerEvent, error) {
+	var event erpCustomerEvent
+
+	payloadBytes, err := json.Marshal(envelope.Payload)
+	if err != nil {
+		return event, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	if err := json.Unmarshal(payloadBytes, &event); err != nil {
+		return event, fmt.Errorf("failed to unmarshal payload to erpCustomerEvent: %w", err)
+	}
+	event.Code = validator.NormalizeCode(event.Code)
+	return event, nil
+}
+
+func (h *CustomerEventHandler) handleSync(ctx context.Context, event erpCustomerEvent, eventID string) error {
+	details := normalizeDetails(event.Name, event.Email, event.Phone, event.TaxID, event.Country)
+	v := validator.New()
+	v.Check(event.Code != "", "customer_code", "customer_code must be provided")
+	validateCustomer(v, details)
+	if !v.Valid() {
+		h.logger.Error("Invalid customer data from ERP event", "errors", v.Errors, "code", event.Code, "event_id", eventID)
+		return nil // Don't retry validation errors
+	}
+
+	_, changed, err := h.service.SyncCustomer(ctx, event.Code, details)
+	if err != nil {
+		if isRejection(err) {
+			h.logger.Error("ERP customer change rejected", "error", err, "code", event.Code, "event_id", eventID)
+			return nil
+		}
+		h.logger.Error("Failed to sync customer", "error", err, "code", event.Code, "event_id", eventID)
+		return err // Retry infrastructure errors and lost races
+	}
+
+	h.logger.Info("Customer synced from event", "code", event.Code, "changed", changed, "event_id", eventID)
+	return nil
+}
+
+func (h *CustomerEventHandler) handleDelete(ctx context.Context, event erpCustomerEvent, eventID string) error {
+	if event.Code == "" {
+		h.logger.Error("ERP customer deletion without a code", "event_id", eventID)
+		return nil
+	}
+
+	changed, err := h.service.SyncCustomerDeletion(ctx, event.Code)
+	if err != nil {
+		if isRejection(err) {
+			h.logger.Error("ERP customer deletion rejected", "error", err, "code", event.Code, "event_id", eventID)
+			return nil
+		}
+		h.logger.Error("Failed to delete customer", "error", err, "code", event.Code, "event_id", eventID)
+		return err // Retry infrastructure errors and lost races
+	}
+
+	h.logger.Info("Customer deleted from event", "code", event.Code, "changed", changed, "event_id", eventID)
+	return nil
+}
+
+// isRejection reports whether the customer refused the change for good. A concurrency
+// conflict is not, the event is retried against the version that won the race.
+func isRejection(err error) bool {
+	var customerErr *domain.CustomerError
+	return errors.As(err, &customerErr) && !errors.Is(err, domain.ErrConcurrencyConflict)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/customers/domain"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCustomerSyncService struct {
+	called      string
+	code        string
+	details     domain.CustomerDetails
+	errToReturn error
+}
+
+func (m *mockCustomerSyncService) SyncCustomer(
+	ctx context.Context, code string, details domain.CustomerDetails,
+) (*domain.Customer, bool, error) {
+	m.called, m.code, m.details = "sync", code, details
+	if m.errToReturn != nil {
+		return nil, false, m.errToReturn
+	}
+	return &domain.Customer{Code: code, Version: 1}, true, nil
+}
+
+func (m *mockCustomerSyncService) SyncCustomerDeletion(ctx context.Context, code string) (bool, error) {
+	m.called, m.code = "delete", code
+	return m.errToReturn == nil, m.errToReturn
+}
+
+func erpCustomerMessage(t *testing.T, eventType string, data map[string]string) []byte {
+	t.Helper()
+
+	envelope := messaging.NewEventEnvelope(eventType, "CUST01", "Customer", 1, data)
+	payload, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestCustomerEventHandler_HandleMessage(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+	valid := map[string]string{"customer_code": " cust01 ", "customer_name": "Atelier Nord", "country": "pl"}
+
+	testCases := []struct {
+		name         string
+		eventType    string
+		data         map[string]string
+		errToReturn  error
+		expectedCall string
+		expectedErr  error
+	}{
+		{name: "created", eventType: erpCustomerCreated, data: valid, expectedCall: "sync"},
+		{name: "updated", eventType: erpCustomerUpdated, data: valid, expectedCall: "sync"},
+		{name: "deleted", eventType: erpCustomerDeleted, data: map[string]string{"customer_code": "CUST01"}, expectedCall: "delete"},
+		{name: "invalid data is dropped", eventType: erpCustomerCreated, data: map[string]string{"customer_code": "CUST01"}},
+		{name: "unknown type is dropped", eventType: "erp.customer.merged", data: valid},
+		{
+			name: "rejected change is dropped", eventType: erpCustomerUpdated, data: valid,
+			errToReturn: domain.ErrCustomerDeleted, expectedCall: "sync",
+		},
+		{
+			name: "lost race is retried", eventType: erpCustomerUpdated, data: valid,
+			errToReturn: domain.ErrConcurrencyConflict, expectedCall: "sync", expectedErr: domain.ErrConcurrencyConflict,
+		},
+		{
+			name: "infrastructure error is retried", eventType: erpCustomerDeleted, data: valid,
+			errToReturn: errDatabase, expectedCall: "delete", expectedErr: errDatabase,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockCustomerSyncService{errToReturn: tc.errToReturn}
+			handler := NewCustomerEventHandler(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			// --- Act ---
+			err := handler.HandleMessage(context.Background(), "erp.customer", erpCustomerMessage(t, tc.eventType, tc.data))
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedCall, svc.called)
+			if tc.expectedCall != "" {
+				assert.Equal(t, "CUST01", svc.code)
+			}
+			if tc.expectedCall == "sync" {
+				assert.Equal(t, "PL", svc.details.Country)
+			}
+		})
+	}
+}
+
+func TestCustomerEventHandler_MalformedMessageIsDropped(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockCustomerSyncService{}
+	handler := NewCustomerEventHandler(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// --- Act ---
+	err := handler.HandleMessage(context.Background(), "erp.customer", []byte("{not json"))
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Empty(t, svc.called)
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/customers/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// CustomerQueryRepository reads the customers.
+type CustomerQueryRepository interface {
+	GetCustomer(ctx context.Context, code string) (*domain.Customer, error)
+	ListCustomers(ctx context.Context, limit, offset int) ([]*domain.Customer, int, error)
+}
+
+// CustomerQueryHandler serves the customers. Deleted customers are left out of the list but
+// can still be read by code, so they can be restored.
+type CustomerQueryHandler struct {
+	customers  CustomerQueryRepository
+	pagination httpx.PaginationConfig
+}
+
+func NewCustomerQueryHandler(customers CustomerQueryRepository, pagination httpx.PaginationConfig) *CustomerQueryHandler {
+	return &CustomerQueryHandler{
+		customers:  customers,
+		pagination: pagination,
+	}
+}
+
+func (h *CustomerQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	if httpx.URLParam(r, "code") == "" {
+		h.listCustomers(w, r)
+		return
+	}
+	h.getCustomer(w, r)
+}
+
+func (h *CustomerQueryHandler) listCustomers(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	customers, totalRecords, err := h.customers.ListCustomers(r.Context(), page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"customers": customers, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *CustomerQueryHandler) getCustomer(w http.ResponseWriter, r *http.Request) {
+	customer, err := h.customers.GetCustomer(r.Context(), validator.NormalizeCode(httpx.URLParam(r, "code")))
+	if err != nil {
+		writeCustomerError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"customer": customer}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/customers/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCustomerQueryRepository struct {
+	listedLimit  int
+	listedOffset int
+}
+
+func (m *mockCustomerQueryRepository) GetCustomer(ctx context.Context, code string) (*domain.Customer, error) {
+	if code != "CUST01" {
+		return nil, domain.ErrCustomerNotFound
+	}
+	return &domain.Customer{Code: code, Name: "Atelier Nord", Country: "PL", Version: 1}, nil
+}
+
+func (m *mockCustomerQueryRepository) ListCustomers(ctx context.Context, limit, offset int) ([]*domain.Customer, int, error) {
+	m.listedLimit, m.listedOffset = limit, offset
+	return []*domain.Customer{{Code: "CUST01", Name: "Atelier Nord", Version: 1}}, 21, nil
+}
+
+func TestCustomerQueryHandler_GetCustomer(t *testing.T) {
+	testCases := []struct {
+		name           string
+		code           string
+		expectedStatus int
+	}{
+		{name: "known customer", code: "cust01", expectedStatus: http.StatusOK},
+		{name: "unknown customer", code: "NOSUCH", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewCustomerQueryHandler(&mockCustomerQueryRepository{}, httpx.PaginationConfig{})
+
+			// --- Act ---
+			responseRecorder := serveCustomer(
+				t, handler, http.MethodGet, "/v1/customers/"+tc.code, "", map[string]string{"code": tc.code},
+			)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Customer domain.Customer `json:"customer"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+			assert.Equal(t, "CUST01", body.Customer.Code)
+			assert.Equal(t, "PL", body.Customer.Country)
+		})
+	}
+}
+
+func TestCustomerQueryHandler_ListCustomers(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockCustomerQueryRepository{}
+	pagination := httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+	handler := NewCustomerQueryHandler(repo, pagination)
+
+	// --- Act ---
+	responseRecorder := serveCustomer(t, handler, http.MethodGet, "/v1/customers?page=2", "", nil)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, 20, repo.listedLimit)
+	assert.Equal(t, 20, repo.listedOffset)
+}
@@ -0,0 +1,135 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/customers/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+const customerColumns = `code, name, email, phone, tax_id, country, version,
+	created_at, created_by, updated_at, updated_by, deleted_at`
+
+type CustomerPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewCustomerPostgresRepository(db *database.PostgresDB) *CustomerPostgresRepository {
+	return &CustomerPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *CustomerPostgresRepository) SaveCustomer(ctx context.Context, customer *domain.Customer) error {
+	query := `
+		INSERT INTO customers (` + customerColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		customer.Code, customer.Name, customer.Email, customer.Phone, customer.TaxID, customer.Country,
+		customer.Version, customer.CreatedAt, customer.CreatedBy, customer.UpdatedAt, customer.UpdatedBy,
+		customer.DeletedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrDuplicateCustomerCode
+		}
+		return fmt.Errorf("failed to insert customer: %w", err)
+	}
+	return nil
+}
+
+func (r *CustomerPostgresRepository) GetCustomer(ctx context.Context, code string) (*domain.Customer, error) {
+	query := `SELECT ` + customerColumns + ` FROM customers WHERE code = $1`
+	customer, err := scanCustomer(r.db.Conn(ctx).QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+	return customer, nil
+}
+
+func (r *CustomerPostgresRepository) ListCustomers(
+	ctx context.Context, limit, offset int,
+) ([]*domain.Customer, int, error) {
+	query := `
+		SELECT count(*) OVER(), ` + customerColumns + `
+		FROM customers
+		WHERE deleted_at IS NULL
+		ORDER BY code
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list customers: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	customers := []*domain.Customer{}
+	for rows.Next() {
+		customer := &domain.Customer{}
+		err := rows.Scan(append([]any{&totalRecords}, customerFields(customer)...)...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan customer: %w", err)
+		}
+		customers = append(customers, customer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate customers: %w", err)
+	}
+
+	return customers, totalRecords, nil
+}
+
+// UpdateCustomer stores the master data and deletion of the customer, provided nobody
+// changed it since it was loaded.
+func (r *CustomerPostgresRepository) UpdateCustomer(ctx context.Context, customer *domain.Customer) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE customers
+		SET name = $1, email = $2, phone = $3, tax_id = $4, country = $5,
+			version = $6, updated_at = $7, updated_by = $8, deleted_at = $9
+		WHERE code = $10 AND version = $11
+	`, customer.Name, customer.Email, customer.Phone, customer.TaxID, customer.Country,
+		customer.Version, customer.UpdatedAt, customer.UpdatedBy, customer.DeletedAt,
+		customer.Code, customer.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update customer: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCustomer(row rowScanner) (*domain.Customer, error) {
+	customer := &domain.Customer{}
+	if err := row.Scan(customerFields(customer)...); err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// customerFields lists the destinations of customerColumns, in the same order.
+func customerFields(customer *domain.Customer) []any {
+	return []any{
+		&customer.Code, &customer.Name, &customer.Email, &customer.Phone, &customer.TaxID, &customer.Country,
+		&customer.Version, &customer.CreatedAt, &customer.CreatedBy, &customer.UpdatedAt, &customer.UpdatedBy,
+		&customer.DeletedAt,
+	}
+}
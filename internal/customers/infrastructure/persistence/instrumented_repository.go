@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/customers/domain"
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+)
+
+// InstrumentedCustomerRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedCustomerRepository struct {
+	next domain.CustomerRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedCustomerRepository(
+	next domain.CustomerRepository, rec *instrument.Recorder,
+) *InstrumentedCustomerRepository {
+	return &InstrumentedCustomerRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedCustomerRepository) SaveCustomer(ctx context.Context, customer *domain.Customer) error {
+	return instrument.Exec(ctx, r.rec, "SaveCustomer", func(ctx context.Context) error {
+		return r.next.SaveCustomer(ctx, customer)
+	})
+}
+
+func (r *InstrumentedCustomerRepository) GetCustomer(ctx context.Context, code string) (*domain.Customer, error) {
+	return instrument.Call(ctx, r.rec, "GetCustomer", func(ctx context.Context) (*domain.Customer, error) {
+		return r.next.GetCustomer(ctx, code)
+	})
+}
+
+func (r *InstrumentedCustomerRepository) ListCustomers(
+	ctx context.Context, limit, offset int,
+) ([]*domain.Customer, int, error) {
+	var total int
+	customers, err := instrument.Call(ctx, r.rec, "ListCustomers",
+		func(ctx context.Context) ([]*domain.Customer, error) {
+			customers, count, err := r.next.ListCustomers(ctx, limit, offset)
+			total = count
+			return customers, err
+		})
+	return customers, total, err
+}
+
+func (r *InstrumentedCustomerRepository) UpdateCustomer(ctx context.Context, customer *domain.Customer) error {
+	return instrument.Exec(ctx, r.rec, "UpdateCustomer", func(ctx context.Context) error {
+		return r.next.UpdateCustomer(ctx, customer)
+	})
+}
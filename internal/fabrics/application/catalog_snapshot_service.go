@@ -0,0 +1,185 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/blobstore"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+)
+
+// upper bound for the fabrics of a snapshot, a catalog outgrowing it fails the snapshot
+// rather than publishing part of it
+const maxCatalogSnapshotFabrics = 100_000
+
+var catalogCSVHeader = []string{
+	"code", "name", "measure_unit", "offer_status", "composition", "width_cm", "weight_gsm", "color",
+	"min_order_quantity", "lead_time_days", "description", "updated_at",
+}
+
+// catalogDocument is the JSON file of a snapshot
+type catalogDocument struct {
+	Version     string           `json:"version"`
+	PublishedAt time.Time        `json:"published_at"`
+	Fabrics     []*domain.Fabric `json:"fabrics"`
+}
+
+// CatalogSnapshotService publishes snapshots of the active catalog to blob storage and
+// announces each one with an app.catalog.snapshot_published event. The internal notes and
+// the prices of fabrics are left out of snapshots, which are read without any scope.
+type CatalogSnapshotService struct {
+	fabrics      FabricSource
+	blobs        blobstore.Store
+	eventStore   eventstore.Store
+	clock        clock.Clock
+	eventChannel string
+	source       messaging.Source
+	logger       *slog.Logger
+}
+
+func NewCatalogSnapshotService(
+	fabrics FabricSource,
+	blobs blobstore.Store,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+	logger *slog.Logger,
+) *CatalogSnapshotService {
+	return &CatalogSnapshotService{
+		fabrics:      fabrics,
+		blobs:        blobs,
+		eventStore:   eventStore,
+		clock:        clock,
+		eventChannel: "app.catalog",
+		source:       source,
+		logger:       logger.With("component", "fabric.catalog_snapshot"),
+	}
+}
+
+// Run publishes a snapshot every interval until ctx is done.
+func (s *CatalogSnapshotService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Publish(ctx); err != nil {
+				s.logger.Error("failed to publish catalog snapshot", "error", err)
+			}
+		}
+	}
+}
+
+// Publish renders the active catalog, stores it as JSON and CSV under a new version and
+// records the event announcing it. The event is only recorded once both files are stored.
+func (s *CatalogSnapshotService) Publish(ctx context.Context) (*domain.CatalogSnapshot, error) {
+	var fabrics []*domain.Fabric
+	err := s.fabrics.ExportFabrics(ctx, maxCatalogSnapshotFabrics+1, func(fabric *domain.Fabric) error {
+		public := *fabric
+		public.Notes = ""
+		public.Price = nil
+		fabrics = append(fabrics, &public)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fabrics for catalog snapshot: %w", err)
+	}
+	if len(fabrics) > maxCatalogSnapshotFabrics {
+		return nil, fmt.Errorf("the catalog holds more than %d active fabrics", maxCatalogSnapshotFabrics)
+	}
+
+	snapshot := domain.NewCatalogSnapshot(len(fabrics), s.clock.Now())
+
+	document, err := json.Marshal(catalogDocument{
+		Version: snapshot.Version, PublishedAt: snapshot.PublishedAt, Fabrics: fabrics,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render catalog snapshot: %w", err)
+	}
+	table, err := renderCatalogCSV(fabrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render catalog snapshot: %w", err)
+	}
+
+	if _, err := s.blobs.Put(ctx, snapshot.JSONKey, bytes.NewReader(document)); err != nil {
+		return nil, fmt.Errorf("failed to store catalog snapshot: %w", err)
+	}
+	if _, err := s.blobs.Put(ctx, snapshot.CSVKey, bytes.NewReader(table)); err != nil {
+		s.discard(ctx, snapshot.JSONKey)
+		return nil, fmt.Errorf("failed to store catalog snapshot: %w", err)
+	}
+
+	envelope := messaging.NewEventEnvelope(
+		"app.catalog.snapshot_published",
+		snapshot.Version,
+		"CatalogSnapshot",
+		1,
+		snapshot.Published(),
+		messaging.WithClock(s.clock),
+		messaging.WithSource(s.source.Service, s.source.Instance),
+	)
+	if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelope); err != nil {
+		s.discard(ctx, snapshot.JSONKey, snapshot.CSVKey)
+		return nil, fmt.Errorf("failed to save catalog snapshot event to event store: %w", err)
+	}
+
+	s.logger.Info("catalog snapshot published", "version", snapshot.Version, "fabrics", snapshot.Fabrics)
+	return snapshot, nil
+}
+
+// discard removes the files of a snapshot that was not announced
+func (s *CatalogSnapshotService) discard(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			s.logger.Warn("failed to delete unpublished catalog snapshot file", "key", key, "error", err)
+		}
+	}
+}
+
+// renderCatalogCSV writes one row per fabric, the composition as fibre:percent pairs
+// separated by semicolons.
+func renderCatalogCSV(fabrics []*domain.Fabric) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(catalogCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, fabric := range fabrics {
+		parts := make([]string, 0, len(fabric.Specification.Composition))
+		for _, part := range fabric.Specification.Composition {
+			parts = append(parts, part.Fibre+":"+strconv.Itoa(part.Percent))
+		}
+		err := w.Write([]string{
+			fabric.Code,
+			fabric.Name,
+			string(fabric.MeasureUnit),
+			string(fabric.OfferStatus),
+			strings.Join(parts, ";"),
+			strconv.Itoa(fabric.Specification.WidthCM),
+			strconv.Itoa(fabric.Specification.WeightGSM),
+			fabric.Specification.Color,
+			strconv.Itoa(fabric.Specification.MinOrderQuantity),
+			strconv.Itoa(fabric.Specification.LeadTimeDays),
+			fabric.Description,
+			fabric.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
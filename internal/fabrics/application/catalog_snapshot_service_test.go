@@ -0,0 +1,79 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/blobstore"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readBlob(t *testing.T, blobs blobstore.Store, key string) []byte {
+	t.Helper()
+
+	file, err := blobs.Open(context.Background(), key)
+	require.NoError(t, err)
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	return content
+}
+
+func TestCatalogSnapshotService_Publish(t *testing.T) {
+	// --- Arrange ---
+	price := domain.Price{Amount: 2490, Currency: "PLN"}
+	source := &stubFabricSource{fabrics: []*domain.Fabric{
+		{
+			Code: "LINEN01", Name: "Linen", MeasureUnit: domain.MeasureUnitMetre, OfferStatus: domain.OfferStatusAvailable,
+			Specification: domain.Specification{
+				Composition: []domain.CompositionPart{{Fibre: "linen", Percent: 70}, {Fibre: "cotton", Percent: 30}},
+				WidthCM:     140,
+			},
+			Notes: "supplier is late again",
+			Price: &price,
+		},
+		{Code: "VELVET01", Name: "Velvet, crushed", MeasureUnit: domain.MeasureUnitMetre},
+	}}
+	blobs := blobstore.NewFileStore(t.TempDir())
+	eventStore := &mockEventStore{}
+	service := NewCatalogSnapshotService(
+		source, blobs, eventStore, clock.NewFixed(testStamp.At), testSource, slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	// --- Act ---
+	snapshot, err := service.Publish(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, "20250102T030405Z", snapshot.Version)
+	assert.Equal(t, 2, snapshot.Fabrics)
+	assert.Equal(t, "catalog/snapshots/20250102T030405Z/catalog.json", snapshot.JSONKey)
+
+	var document catalogDocument
+	require.NoError(t, json.Unmarshal(readBlob(t, blobs, snapshot.JSONKey), &document))
+	require.Len(t, document.Fabrics, 2)
+	assert.Equal(t, "LINEN01", document.Fabrics[0].Code)
+	assert.Empty(t, document.Fabrics[0].Notes, "internal notes are left out")
+	assert.Nil(t, document.Fabrics[0].Price, "prices are left out")
+	assert.NotNil(t, source.fabrics[0].Price, "the exported fabric is not changed")
+
+	rows, err := csv.NewReader(bytes.NewReader(readBlob(t, blobs, snapshot.CSVKey))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, catalogCSVHeader, rows[0])
+	assert.Equal(t, "linen:70;cotton:30", rows[1][4])
+	assert.Equal(t, "Velvet, crushed", rows[2][1])
+
+	require.NotNil(t, eventStore.EnqueuedEnvelope)
+	assert.Equal(t, "app.catalog", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.catalog.snapshot_published", eventStore.EnqueuedEnvelope.EventType)
+	assert.Equal(t, snapshot.Version, eventStore.EnqueuedEnvelope.AggregateID)
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+)
+
+// upper bound for the fabrics compared by a scan, keeps the pairwise comparison bounded
+const maxDuplicateScanFabrics = 20_000
+
+// FabricSource streams the active fabrics of the catalog.
+type FabricSource interface {
+	ExportFabrics(ctx context.Context, limit int, fn func(*domain.Fabric) error) error
+}
+
+// DuplicateScanService looks for probable duplicates across the catalog and queues them
+// for review.
+type DuplicateScanService struct {
+	fabrics    FabricSource
+	duplicates domain.FabricDuplicateRepository
+	clock      clock.Clock
+	logger     *slog.Logger
+}
+
+func NewDuplicateScanService(
+	fabrics FabricSource,
+	duplicates domain.FabricDuplicateRepository,
+	clock clock.Clock,
+	logger *slog.Logger,
+) *DuplicateScanService {
+	return &DuplicateScanService{
+		fabrics:    fabrics,
+		duplicates: duplicates,
+		clock:      clock,
+		logger:     logger.With("component", "fabric.duplicate_scan"),
+	}
+}
+
+// Run scans the catalog every interval until ctx is done.
+func (s *DuplicateScanService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Scan(ctx); err != nil {
+				s.logger.Error("failed to scan for duplicate fabrics", "error", err)
+			}
+		}
+	}
+}
+
+// Scan compares the active fabrics and queues the probable duplicates not flagged before,
+// returning how many were queued.
+func (s *DuplicateScanService) Scan(ctx context.Context) (int, error) {
+	var fabrics []*domain.Fabric
+	err := s.fabrics.ExportFabrics(ctx, maxDuplicateScanFabrics, func(fabric *domain.Fabric) error {
+		fabrics = append(fabrics, fabric)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read fabrics for duplicate scan: %w", err)
+	}
+	if len(fabrics) == maxDuplicateScanFabrics {
+		s.logger.Warn("duplicate scan limited to part of the catalog", "fabrics", len(fabrics))
+	}
+
+	flagged := 0
+	for _, duplicate := range domain.FindDuplicates(fabrics, s.clock.Now()) {
+		if err := s.duplicates.SaveDuplicate(ctx, duplicate); err != nil {
+			return flagged, err
+		}
+		if duplicate.ID != 0 {
+			flagged++
+		}
+	}
+
+	s.logger.Info("duplicate scan finished", "fabrics", len(fabrics), "flagged", flagged)
+	return flagged, nil
+}
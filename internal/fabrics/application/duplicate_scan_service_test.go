@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubFabricSource struct {
+	fabrics []*domain.Fabric
+}
+
+func (s *stubFabricSource) ExportFabrics(ctx context.Context, limit int, fn func(*domain.Fabric) error) error {
+	for _, fabric := range s.fabrics {
+		if err := fn(fabric); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mockFabricDuplicateRepository queues each pair once, like the unique pair constraint
+type mockFabricDuplicateRepository struct {
+	saved []*domain.FabricDuplicate
+	pairs map[[2]string]bool
+}
+
+func (m *mockFabricDuplicateRepository) SaveDuplicate(ctx context.Context, duplicate *domain.FabricDuplicate) error {
+	if m.pairs == nil {
+		m.pairs = map[[2]string]bool{}
+	}
+	pair := [2]string{duplicate.Code, duplicate.CanonicalCode}
+	if m.pairs[pair] {
+		return nil
+	}
+	m.pairs[pair] = true
+	m.saved = append(m.saved, duplicate)
+	duplicate.ID = int64(len(m.saved))
+	return nil
+}
+
+func (m *mockFabricDuplicateRepository) GetDuplicate(ctx context.Context, id int64) (*domain.FabricDuplicate, error) {
+	return nil, domain.ErrRecordNotFound
+}
+
+func (m *mockFabricDuplicateRepository) ListPendingDuplicates(
+	ctx context.Context, limit, offset int,
+) ([]*domain.FabricDuplicate, int, error) {
+	return m.saved, len(m.saved), nil
+}
+
+func (m *mockFabricDuplicateRepository) ResolveDuplicate(ctx context.Context, duplicate *domain.FabricDuplicate) error {
+	return nil
+}
+
+func TestDuplicateScanService_Scan(t *testing.T) {
+	// --- Arrange ---
+	source := &stubFabricSource{fabrics: []*domain.Fabric{
+		{Code: "ZOYA1", Name: "Zoya Velvet", CreatedAt: testStamp.At},
+		{Code: "ZOYA2", Name: "Zoya Velvet ", CreatedAt: testStamp.At.Add(1)},
+		{Code: "LINEN1", Name: "Linen Natural", CreatedAt: testStamp.At},
+	}}
+	duplicates := &mockFabricDuplicateRepository{}
+	service := NewDuplicateScanService(
+		source, duplicates, clock.NewFixed(testStamp.At), slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	// --- Act ---
+	first, err := service.Scan(context.Background())
+	require.NoError(t, err)
+	second, err := service.Scan(context.Background())
+	require.NoError(t, err)
+
+	// --- Assert ---
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 0, second, "a pair flagged before should not be queued again")
+	require.Len(t, duplicates.saved, 1)
+	assert.Equal(t, "ZOYA2", duplicates.saved[0].Code)
+	assert.Equal(t, "ZOYA1", duplicates.saved[0].CanonicalCode)
+	assert.Equal(t, testStamp.At, duplicates.saved[0].DetectedAt)
+}
@@ -0,0 +1,229 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/blobstore"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// bytes read to detect the type of an uploaded file, as many as http.DetectContentType uses
+const sniffLen = 512
+
+// FabricAttachmentService attaches images and spec sheets to fabrics. Files go to blob
+// storage, their description to the repository, and each attachment is an aggregate of
+// its own published on a subject of its own.
+type FabricAttachmentService struct {
+	attachmentRepo domain.FabricAttachmentRepository
+	blobs          blobstore.Store
+	quota          *TenantQuotaService
+	eventStore     eventstore.Store
+	clock          clock.Clock
+	eventChannel   string
+	source         messaging.Source
+}
+
+func NewFabricAttachmentService(
+	attachmentRepo domain.FabricAttachmentRepository,
+	blobs blobstore.Store,
+	quota *TenantQuotaService,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+) *FabricAttachmentService {
+	return &FabricAttachmentService{
+		attachmentRepo: attachmentRepo,
+		blobs:          blobs,
+		quota:          quota,
+		eventStore:     eventStore,
+		clock:          clock,
+		eventChannel:   "app.fabric.attachment",
+		source:         source,
+	}
+}
+
+func (s *FabricAttachmentService) ListAttachments(ctx context.Context, code string) ([]*domain.FabricAttachment, error) {
+	fabricCode, err := s.attachmentRepo.GetFabricCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.ListAttachments(ctx, fabricCode)
+}
+
+// AddAttachment stores the file and attaches it to the fabric. The type of the file is
+// detected from its content, a file of a type the kind does not accept is not stored.
+func (s *FabricAttachmentService) AddAttachment(
+	ctx context.Context, code, kind, fileName string, content io.Reader,
+) (*domain.FabricAttachment, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.attachment.service.add")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.attachment.service")
+
+	fabricCode, err := s.attachmentRepo.GetFabricCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	head = head[:n]
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if err := domain.ValidateAttachment(kind, fileName, contentType); err != nil {
+		return nil, err
+	}
+
+	// one byte past the limit is read, so an oversized file is told apart from one at the limit
+	id := uuid.NewString()
+	key := domain.AttachmentStorageKey(fabricCode, id)
+	body := io.MultiReader(bytes.NewReader(head), io.LimitReader(content, domain.MaxAttachmentSize+1-int64(n)))
+	size, err := s.blobs.Put(ctx, key, body)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to store attachment file: %w", err)
+		logger.Error("storing attachment file failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "blob write error")
+		return nil, wrappedErr
+	}
+
+	attachment, err := s.attach(ctx, fabricCode, id, kind, fileName, contentType, size)
+	if err != nil {
+		// the description was not stored, so nothing refers to the file
+		if deleteErr := s.blobs.Delete(ctx, key); deleteErr != nil {
+			logger.Warn("failed to delete orphaned attachment file", "key", key, "error", deleteErr)
+		}
+		if _, ok := domain.AsDomainError(err); !ok {
+			logger.Error("saving attachment failed", "error", err)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "database write error")
+		}
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// OpenAttachment returns the attachment together with its file, which the caller closes.
+func (s *FabricAttachmentService) OpenAttachment(
+	ctx context.Context, code, id string,
+) (*domain.FabricAttachment, io.ReadCloser, error) {
+	attachment, err := s.attachmentRepo.GetAttachment(ctx, code, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := s.blobs.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open attachment file: %w", err)
+	}
+	return attachment, file, nil
+}
+
+// RemoveAttachment takes the attachment off the fabric and deletes its file. The file is
+// deleted last, a failure to do so leaves an orphaned file but no broken attachment.
+func (s *FabricAttachmentService) RemoveAttachment(ctx context.Context, code, id string) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.attachment.service.remove")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.attachment.service")
+
+	attachment, err := s.attachmentRepo.GetAttachment(ctx, code, id)
+	if err != nil {
+		return err
+	}
+
+	attachment.Remove()
+	if err := s.attachmentRepo.DeleteAttachment(ctx, attachment); err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			return err
+		}
+		wrappedErr := fmt.Errorf("failed to delete attachment in repo: %w", err)
+		logger.Error("deleting attachment failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return wrappedErr
+	}
+	if err := s.publish(ctx, attachment); err != nil {
+		return err
+	}
+
+	if err := s.blobs.Delete(ctx, attachment.StorageKey); err != nil {
+		logger.Warn("failed to delete attachment file", "key", attachment.StorageKey, "error", err)
+	}
+	return nil
+}
+
+// attach describes the stored file and saves the attachment together with its events.
+func (s *FabricAttachmentService) attach(
+	ctx context.Context, fabricCode, id, kind, fileName, contentType string, size int64,
+) (*domain.FabricAttachment, error) {
+	stamp := domain.Stamp{By: command.Actor(ctx), At: s.clock.Now()}
+	attachment, err := domain.NewFabricAttachment(id, fabricCode, kind, fileName, contentType, size, stamp)
+	if err != nil {
+		return nil, err
+	}
+	// the size is only known once the file is stored, so a file over the quota is
+	// stored and then deleted again
+	if err := s.quota.checkAttachment(ctx, size); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachmentRepo.SaveAttachment(ctx, attachment); err != nil {
+		return nil, fmt.Errorf("failed to save attachment in repo: %w", err)
+	}
+	if err := s.publish(ctx, attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (s *FabricAttachmentService) publish(ctx context.Context, attachment *domain.FabricAttachment) error {
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range attachment.Events() {
+		var (
+			eventType string
+			version   int
+		)
+		switch event.(type) {
+		case domain.FabricAttachmentAdded:
+			eventType, version = "app.fabric.attachment_added", 1
+		case domain.FabricAttachmentRemoved:
+			eventType, version = "app.fabric.attachment_removed", 2
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			attachment.ID,
+			"FabricAttachment",
+			version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopesToPublish...); err != nil {
+			return fmt.Errorf("failed to save attachment event to event store: %w", err)
+		}
+	}
+	return nil
+}
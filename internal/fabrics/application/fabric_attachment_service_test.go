@@ -0,0 +1,156 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/blobstore"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the signature of a PNG file, enough for its type to be detected
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+type mockFabricAttachmentRepository struct {
+	attachments map[string]*domain.FabricAttachment
+	deleted     *domain.FabricAttachment
+}
+
+func (m *mockFabricAttachmentRepository) GetFabricCode(ctx context.Context, code string) (string, error) {
+	if code != "VELVET01" {
+		return "", domain.ErrRecordNotFound
+	}
+	return code, nil
+}
+
+func (m *mockFabricAttachmentRepository) SaveAttachment(ctx context.Context, attachment *domain.FabricAttachment) error {
+	m.attachments[attachment.ID] = attachment
+	return nil
+}
+
+func (m *mockFabricAttachmentRepository) GetAttachment(
+	ctx context.Context, fabricCode, id string,
+) (*domain.FabricAttachment, error) {
+	attachment, ok := m.attachments[id]
+	if !ok || attachment.FabricCode != fabricCode {
+		return nil, domain.ErrRecordNotFound
+	}
+	// loaded as stored, without the events recorded when it was added
+	return &domain.FabricAttachment{
+		ID:          attachment.ID,
+		FabricCode:  attachment.FabricCode,
+		Kind:        attachment.Kind,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		StorageKey:  attachment.StorageKey,
+	}, nil
+}
+
+func (m *mockFabricAttachmentRepository) ListAttachments(
+	ctx context.Context, fabricCode string,
+) ([]*domain.FabricAttachment, error) {
+	return nil, nil
+}
+
+func (m *mockFabricAttachmentRepository) DeleteAttachment(ctx context.Context, attachment *domain.FabricAttachment) error {
+	m.deleted = attachment
+	delete(m.attachments, attachment.ID)
+	return nil
+}
+
+func TestFabricAttachmentService_AddAttachment_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockFabricAttachmentRepository{attachments: map[string]*domain.FabricAttachment{}}
+	blobs := blobstore.NewFileStore(t.TempDir())
+	eventStore := &mockEventStore{}
+	service := NewFabricAttachmentService(repo, blobs, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+	content := append(bytes.Clone(pngHeader), []byte("image data")...)
+
+	// --- Act ---
+	attachment, err := service.AddAttachment(
+		context.Background(), "VELVET01", domain.AttachmentImage, "swatch.png", bytes.NewReader(content),
+	)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", attachment.ContentType)
+	assert.Equal(t, int64(len(content)), attachment.Size)
+	assert.Contains(t, repo.attachments, attachment.ID)
+
+	file, err := blobs.Open(context.Background(), attachment.StorageKey)
+	require.NoError(t, err, "the file must be stored under the attachment's key")
+	require.NoError(t, file.Close())
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.fabric.attachment", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.fabric.attachment_added", publishedEnvelope.EventType)
+	assert.Equal(t, "FabricAttachment", publishedEnvelope.AggregateType)
+	assert.Equal(t, attachment.ID, publishedEnvelope.AggregateID)
+}
+
+func TestFabricAttachmentService_AddAttachment_Rejected(t *testing.T) {
+	testCases := []struct {
+		name        string
+		kind        string
+		content     []byte
+		expectedErr error
+	}{
+		{name: "Text is not an image", kind: domain.AttachmentImage, content: []byte("just text"), expectedErr: domain.ErrUnsupportedAttachmentType},
+		{name: "Unknown kind", kind: "video", content: pngHeader, expectedErr: domain.ErrInvalidAttachmentKind},
+		{
+			name: "Too large", kind: domain.AttachmentImage, expectedErr: domain.ErrAttachmentTooLarge,
+			content: append(bytes.Clone(pngHeader), bytes.Repeat([]byte{0}, domain.MaxAttachmentSize)...),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := &mockFabricAttachmentRepository{attachments: map[string]*domain.FabricAttachment{}}
+			eventStore := &mockEventStore{}
+			service := NewFabricAttachmentService(
+				repo, blobstore.NewFileStore(t.TempDir()), noQuota, eventStore, clock.NewFixed(testStamp.At), testSource,
+			)
+
+			// --- Act ---
+			_, err := service.AddAttachment(
+				context.Background(), "VELVET01", tc.kind, "upload.bin", bytes.NewReader(tc.content),
+			)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Empty(t, repo.attachments)
+			assert.False(t, eventStore.SavedCalled, "a rejected upload must not be stored")
+		})
+	}
+}
+
+func TestFabricAttachmentService_RemoveAttachment(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockFabricAttachmentRepository{attachments: map[string]*domain.FabricAttachment{}}
+	blobs := blobstore.NewFileStore(t.TempDir())
+	eventStore := &mockEventStore{}
+	service := NewFabricAttachmentService(repo, blobs, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+	attachment, err := service.AddAttachment(
+		context.Background(), "VELVET01", domain.AttachmentSpecSheet, "spec.pdf", strings.NewReader("%PDF-1.7 spec"),
+	)
+	require.NoError(t, err)
+
+	// --- Act ---
+	err = service.RemoveAttachment(context.Background(), "VELVET01", attachment.ID)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, repo.deleted)
+	assert.Equal(t, "app.fabric.attachment_removed", eventStore.EnqueuedEnvelope.EventType)
+	assert.Equal(t, 2, eventStore.EnqueuedEnvelope.AggregateVersion)
+	_, err = blobs.Open(context.Background(), attachment.StorageKey)
+	assert.ErrorIs(t, err, blobstore.ErrBlobNotFound)
+}
@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+)
+
+// maximum number of certifications expired per round trip of a sweep
+const certificationExpiryBatchSize = 100
+
+// FabricCertificationService records the certifications of fabrics and detects their
+// expiry. Each certification is an aggregate of its own, published on a subject of its own.
+type FabricCertificationService struct {
+	certificationRepo domain.FabricCertificationRepository
+	eventStore        eventstore.Store
+	clock             clock.Clock
+	eventChannel      string
+	source            messaging.Source
+	logger            *slog.Logger
+}
+
+func NewFabricCertificationService(
+	certificationRepo domain.FabricCertificationRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+	logger *slog.Logger,
+) *FabricCertificationService {
+	return &FabricCertificationService{
+		certificationRepo: certificationRepo,
+		eventStore:        eventStore,
+		clock:             clock,
+		eventChannel:      "app.fabric.certification",
+		source:            source,
+		logger:            logger.With("component", "fabric.certification.service"),
+	}
+}
+
+func (s *FabricCertificationService) ListCertifications(
+	ctx context.Context, code string,
+) ([]*domain.FabricCertification, error) {
+	fabricCode, err := s.certificationRepo.GetFabricCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.certificationRepo.ListCertifications(ctx, fabricCode)
+}
+
+// AddCertification records a certificate the fabric holds. A certification whose validity
+// has already ended is recorded together with its expiry.
+func (s *FabricCertificationService) AddCertification(
+	ctx context.Context, code, scheme, certificateNumber string, validFrom, validUntil time.Time,
+) (*domain.FabricCertification, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.certification.service.add")
+	defer span.End()
+
+	fabricCode, err := s.certificationRepo.GetFabricCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	certification, err := domain.NewFabricCertification(
+		uuid.NewString(), fabricCode, scheme, certificateNumber, validFrom, validUntil,
+		domain.Stamp{By: command.Actor(ctx), At: now},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !certification.ValidUntil.After(now) {
+		if err := certification.Expire(now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.certificationRepo.SaveCertification(ctx, certification); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to save certification in repo: %w", err)
+	}
+	if err := s.publish(ctx, certification); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return certification, nil
+}
+
+// Run records the expiry of certifications every interval until ctx is done.
+func (s *FabricCertificationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ExpireCertifications(ctx); err != nil {
+				s.logger.Error("failed to expire fabric certifications", "error", err)
+			}
+		}
+	}
+}
+
+// ExpireCertifications records the expiry of every certification whose validity has
+// ended, returning how many expired. A certification another instance expired first is
+// skipped, so each expiry is announced once.
+func (s *FabricCertificationService) ExpireCertifications(ctx context.Context) (int, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.certification.service.expire")
+	defer span.End()
+
+	now := s.clock.Now()
+	expired := 0
+	for {
+		due, err := s.certificationRepo.DueForExpiry(ctx, now, certificationExpiryBatchSize)
+		if err != nil {
+			span.RecordError(err)
+			return expired, fmt.Errorf("failed to find certifications due for expiry: %w", err)
+		}
+
+		for _, certification := range due {
+			if err := certification.Expire(now); err != nil {
+				return expired, err
+			}
+			if err := s.certificationRepo.MarkExpired(ctx, certification); err != nil {
+				if errors.Is(err, domain.ErrCertificationAlreadyExpired) {
+					continue
+				}
+				span.RecordError(err)
+				return expired, fmt.Errorf("failed to mark certification expired: %w", err)
+			}
+			if err := s.publish(ctx, certification); err != nil {
+				span.RecordError(err)
+				return expired, err
+			}
+			expired++
+			s.logger.Info(
+				"fabric certification expired",
+				"id", certification.ID, "code", certification.FabricCode, "scheme", certification.Scheme,
+			)
+		}
+
+		if len(due) < certificationExpiryBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+func (s *FabricCertificationService) publish(ctx context.Context, certification *domain.FabricCertification) error {
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range certification.Events() {
+		var (
+			eventType string
+			version   int
+		)
+		switch event.(type) {
+		case domain.FabricCertificationAdded:
+			eventType, version = "app.fabric.certification_added", 1
+		case domain.FabricCertificationExpired:
+			eventType, version = "app.fabric.certification_expired", 2
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			certification.ID,
+			"FabricCertification",
+			version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopesToPublish...); err != nil {
+			return fmt.Errorf("failed to save certification event to event store: %w", err)
+		}
+	}
+	return nil
+}
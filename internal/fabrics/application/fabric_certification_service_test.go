@@ -0,0 +1,209 @@
+package application
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFabricCertificationRepository keeps the certifications in memory, the IDs in
+// expiredElsewhere stand for certifications another instance expired first
+type mockFabricCertificationRepository struct {
+	certifications   []*domain.FabricCertification
+	expiredElsewhere map[string]bool
+}
+
+func (m *mockFabricCertificationRepository) GetFabricCode(ctx context.Context, code string) (string, error) {
+	if code != "VELVET01" {
+		return "", domain.ErrRecordNotFound
+	}
+	return code, nil
+}
+
+func (m *mockFabricCertificationRepository) SaveCertification(
+	ctx context.Context, certification *domain.FabricCertification,
+) error {
+	m.certifications = append(m.certifications, certification)
+	return nil
+}
+
+func (m *mockFabricCertificationRepository) ListCertifications(
+	ctx context.Context, fabricCode string,
+) ([]*domain.FabricCertification, error) {
+	return m.certifications, nil
+}
+
+func (m *mockFabricCertificationRepository) DueForExpiry(
+	ctx context.Context, at time.Time, limit int,
+) ([]*domain.FabricCertification, error) {
+	due := []*domain.FabricCertification{}
+	for _, certification := range m.certifications {
+		if certification.ExpiredAt == nil && !certification.ValidUntil.After(at) && len(due) < limit {
+			// loaded as stored, without the events recorded when it was added
+			due = append(due, &domain.FabricCertification{
+				ID:         certification.ID,
+				FabricCode: certification.FabricCode,
+				Scheme:     certification.Scheme,
+				ValidUntil: certification.ValidUntil,
+			})
+		}
+	}
+	return due, nil
+}
+
+func (m *mockFabricCertificationRepository) MarkExpired(
+	ctx context.Context, certification *domain.FabricCertification,
+) error {
+	for _, stored := range m.certifications {
+		if stored.ID != certification.ID {
+			continue
+		}
+		if m.expiredElsewhere[stored.ID] {
+			stored.ExpiredAt = certification.ExpiredAt
+			return domain.ErrCertificationAlreadyExpired
+		}
+		stored.ExpiredAt = certification.ExpiredAt
+	}
+	return nil
+}
+
+// recordingEventStore keeps every envelope enqueued
+type recordingEventStore struct {
+	mockEventStore
+	enqueued []*messaging.EventEnvelope
+}
+
+func (m *recordingEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.enqueued = append(m.enqueued, envelopes...)
+	return m.mockEventStore.SaveAndEnqueue(ctx, subject, envelopes...)
+}
+
+func newTestCertificationService(
+	repo domain.FabricCertificationRepository, eventStore *recordingEventStore,
+) *FabricCertificationService {
+	return NewFabricCertificationService(
+		repo, eventStore, clock.NewFixed(testStamp.At), testSource, slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+}
+
+func TestFabricCertificationService_AddCertification(t *testing.T) {
+	testCases := []struct {
+		name               string
+		validUntil         time.Time
+		expectedEventTypes []string
+	}{
+		{
+			name:               "Valid certification",
+			validUntil:         testStamp.At.AddDate(1, 0, 0),
+			expectedEventTypes: []string{"app.fabric.certification_added"},
+		},
+		{
+			name:               "Certification that already ended",
+			validUntil:         testStamp.At.AddDate(0, 0, -1),
+			expectedEventTypes: []string{"app.fabric.certification_added", "app.fabric.certification_expired"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := &mockFabricCertificationRepository{}
+			eventStore := &recordingEventStore{}
+			service := newTestCertificationService(repo, eventStore)
+
+			// --- Act ---
+			certification, err := service.AddCertification(
+				context.Background(), "VELVET01", domain.CertificationGOTS, "CU 123456",
+				testStamp.At.AddDate(-1, 0, 0), tc.validUntil,
+			)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			require.Len(t, repo.certifications, 1)
+			assert.Equal(t, "VELVET01", certification.FabricCode)
+			assert.Equal(t, "app.fabric.certification", eventStore.EnqueuedSubject)
+			eventTypes := []string{}
+			for _, envelope := range eventStore.enqueued {
+				eventTypes = append(eventTypes, envelope.EventType)
+				assert.Equal(t, certification.ID, envelope.AggregateID)
+				assert.Equal(t, "FabricCertification", envelope.AggregateType)
+			}
+			assert.Equal(t, tc.expectedEventTypes, eventTypes)
+		})
+	}
+}
+
+func TestFabricCertificationService_AddCertification_Rejected(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        string
+		scheme      string
+		expectedErr error
+	}{
+		{name: "Unknown fabric", code: "MISSING", scheme: domain.CertificationGOTS, expectedErr: domain.ErrRecordNotFound},
+		{name: "Unknown scheme", code: "VELVET01", scheme: "fairtrade", expectedErr: domain.ErrInvalidCertificationScheme},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := &mockFabricCertificationRepository{}
+			eventStore := &recordingEventStore{}
+			service := newTestCertificationService(repo, eventStore)
+
+			// --- Act ---
+			_, err := service.AddCertification(
+				context.Background(), tc.code, tc.scheme, "CU 123456", testStamp.At, testStamp.At.AddDate(1, 0, 0),
+			)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Empty(t, repo.certifications)
+			assert.Empty(t, eventStore.enqueued)
+		})
+	}
+}
+
+func TestFabricCertificationService_ExpireCertifications(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockFabricCertificationRepository{
+		certifications: []*domain.FabricCertification{
+			{ID: "ended", FabricCode: "VELVET01", Scheme: domain.CertificationGOTS, ValidUntil: testStamp.At.AddDate(0, 0, -1)},
+			{ID: "ending-now", FabricCode: "VELVET01", Scheme: domain.CertificationGRS, ValidUntil: testStamp.At},
+			{ID: "raced", FabricCode: "SILK01", Scheme: domain.CertificationGOTS, ValidUntil: testStamp.At.AddDate(0, 0, -2)},
+			{ID: "valid", FabricCode: "SILK01", Scheme: domain.CertificationOekoTex, ValidUntil: testStamp.At.AddDate(0, 6, 0)},
+		},
+		expiredElsewhere: map[string]bool{"raced": true},
+	}
+	eventStore := &recordingEventStore{}
+	service := newTestCertificationService(repo, eventStore)
+
+	// --- Act ---
+	expired, err := service.ExpireCertifications(context.Background())
+	again, againErr := service.ExpireCertifications(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, 2, expired)
+	require.NoError(t, againErr)
+	assert.Zero(t, again, "an expiry is recorded once")
+
+	expiredIDs := []string{}
+	for _, envelope := range eventStore.enqueued {
+		assert.Equal(t, "app.fabric.certification_expired", envelope.EventType)
+		assert.Equal(t, 2, envelope.AggregateVersion)
+		expiredIDs = append(expiredIDs, envelope.AggregateID)
+	}
+	assert.Equal(t, []string{"ended", "ending-now"}, expiredIDs, "the expiry another instance recorded is not announced again")
+	assert.Nil(t, repo.certifications[3].ExpiredAt)
+}
@@ -3,44 +3,80 @@ package application
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
 	command "github.com/salesworks/s-works/api/internal/platform/context"
 	"github.com/salesworks/s-works/api/internal/platform/eventstore"
 	"github.com/salesworks/s-works/api/internal/platform/httpx"
 	"github.com/salesworks/s-works/api/internal/platform/messaging"
-	"go.opentelemetry.io/otel"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
 	"go.opentelemetry.io/otel/codes"
 )
 
 type FabricService struct {
 	commandRepo  domain.FabricCommandRepository
-	publisher    messaging.Publisher
+	fibres       domain.FibreRepository
+	quota        *TenantQuotaService
 	eventStore   eventstore.Store
+	clock        clock.Clock
 	eventChannel string
+	source       messaging.Source
 }
 
 func NewFabricCommandService(
 	commandRepo domain.FabricCommandRepository,
-	publisher messaging.Publisher,
+	fibres domain.FibreRepository,
+	quota *TenantQuotaService,
 	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
 ) *FabricService {
 	return &FabricService{
 		commandRepo:  commandRepo,
-		publisher:    publisher,
+		fibres:       fibres,
+		quota:        quota,
 		eventStore:   eventStore,
+		clock:        clock,
 		eventChannel: "app.fabric",
+		source:       source,
 	}
 }
 
 func (s *FabricService) CreateFabric(
-	ctx context.Context, code, name, measureUnit, offerStatus string,
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification, texts domain.FabricTexts,
+) (*domain.Fabric, error) {
+	return s.create(ctx, domain.NewFabric, code, name, measureUnit, offerStatus, spec, texts)
+}
+
+// CreateDraftFabric creates a fabric that stays off offer until it is activated.
+func (s *FabricService) CreateDraftFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification, texts domain.FabricTexts,
+) (*domain.Fabric, error) {
+	return s.create(ctx, domain.NewDraftFabric, code, name, measureUnit, offerStatus, spec, texts)
+}
+
+// newFabricFunc builds a fabric in the status it is created in
+type newFabricFunc func(
+	code, name, measureUnit, offerStatus string, spec domain.Specification, texts domain.FabricTexts, stamp domain.Stamp,
+) (*domain.Fabric, error)
+
+func (s *FabricService) create(
+	ctx context.Context, newFabric newFabricFunc, code, name, measureUnit, offerStatus string, spec domain.Specification,
+	texts domain.FabricTexts,
 ) (*domain.Fabric, error) {
-	ctx, span := otel.Tracer("s-works/api").Start(ctx, "fabric.service.create")
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.create")
 	defer span.End()
 	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
 
-	fabric, err := domain.NewFabric(code, name, measureUnit, offerStatus)
+	if err := checkNotReserved(ctx, code); err != nil {
+		return nil, err
+	}
+
+	fabric, err := newFabric(code, name, measureUnit, offerStatus, spec, texts, aggregate.StampNow(ctx, s.clock))
 	if err != nil {
 		wrappedErr := fmt.Errorf("application service failed to create fabric: %w", err)
 		logger.Error("fabric creation failed due to a domain error", "error", wrappedErr)
@@ -48,6 +84,12 @@ func (s *FabricService) CreateFabric(
 		span.SetStatus(codes.Error, "domain rule violation")
 		return nil, wrappedErr
 	}
+	if err := s.checkSpecification(ctx, spec); err != nil {
+		return nil, err
+	}
+	if err := s.quota.checkFabric(ctx); err != nil {
+		return nil, err
+	}
 
 	persistedFabric, err := s.commandRepo.Save(ctx, fabric)
 	if err != nil {
@@ -73,55 +115,55 @@ func (s *FabricService) CreateFabric(
 		envelope := messaging.NewEventEnvelope(
 			eventType,
 			persistedFabric.Code,
-			"fabric",
+			domain.AggregateType,
 			persistedFabric.Version,
 			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
 		)
 		envelopesToPublish = append(envelopesToPublish, envelope)
 	}
 
 	if len(envelopesToPublish) > 0 {
-		if err := s.eventStore.Save(ctx, envelopesToPublish...); err != nil {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
 			wrappedErr := fmt.Errorf("failed to save to event store: %w", err)
 			logger.Error("saving to event store failed", "error", wrappedErr)
 			span.RecordError(wrappedErr)
 			span.SetStatus(codes.Error, "event store write error")
 			return nil, wrappedErr
 		}
-
-		// the contextet may be from REST API or from NATS subscription
-		if command.IsFromREST(ctx) {
-			for _, envelope := range envelopesToPublish {
-				if err := s.publisher.Publish(ctx, s.eventChannel, envelope); err != nil {
-					wrappedErr := fmt.Errorf("failed to publish fabric event envelope: %w", err)
-					logger.Error(
-						"publishing event envelope failed",
-						"error", wrappedErr, "eventID", envelope.EventID,
-					)
-					span.RecordError(wrappedErr)
-				}
-			}
-		}
 	}
 
 	return persistedFabric, nil
 }
 
+// UpdateFabric replaces the data of the fabric, a nil spec keeps its current specification
+// and texts left out keep theirs.
 func (s *FabricService) UpdateFabric(
-	ctx context.Context, code, name, measureUnit, offerStatus string, version int,
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification,
+	texts domain.FabricTexts, version int,
 ) (*domain.Fabric, error) {
-	ctx, span := otel.Tracer("s-works/api").Start(ctx, "fabric.service.update")
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.update")
 	defer span.End()
 	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
 
+	if err := checkNotReserved(ctx, code); err != nil {
+		return nil, err
+	}
+
 	fabric, err := s.commandRepo.GetByCode(ctx, code)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := fabric.UpdateFabric(name, measureUnit, offerStatus, version); err != nil {
+	if err := fabric.UpdateFabric(name, measureUnit, offerStatus, spec, texts, version, aggregate.StampNow(ctx, s.clock)); err != nil {
 		return nil, err
 	}
+	if spec != nil {
+		if err := s.checkSpecification(ctx, *spec); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.commandRepo.Update(ctx, fabric); err != nil {
 		wrappedErr := fmt.Errorf("failed to update fabric in repo: %w", err)
@@ -137,48 +179,44 @@ func (s *FabricService) UpdateFabric(
 			envelope := messaging.NewEventEnvelope(
 				"app.fabric.updated",
 				fabric.Code,
-				"Fabric",
+				domain.AggregateType,
 				fabric.Version,
 				event,
+				messaging.WithClock(s.clock),
+				messaging.WithSource(s.source.Service, s.source.Instance),
 			)
 			envelopesToPublish = append(envelopesToPublish, envelope)
 		}
 	}
 
 	if len(envelopesToPublish) > 0 {
-		if err := s.eventStore.Save(ctx, envelopesToPublish...); err != nil {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
 			wrappedErr := fmt.Errorf("failed to save update event to event store: %w", err)
 			logger.Error("saving update event to event store failed", "error", wrappedErr)
 			span.RecordError(wrappedErr)
 			span.SetStatus(codes.Error, "event store write error")
 			return nil, wrappedErr
 		}
-
-		if command.IsFromREST(ctx) {
-			for _, envelope := range envelopesToPublish {
-				if err := s.publisher.Publish(ctx, s.eventChannel, envelope); err != nil {
-					wrappedErr := fmt.Errorf("failed to publish fabric updated event: %w", err)
-					logger.Error("publishing fabric updated event failed", "error", wrappedErr, "eventID", envelope.EventID)
-					span.RecordError(wrappedErr)
-				}
-			}
-		}
 	}
 
 	return fabric, nil
 }
 
 func (s *FabricService) DeleteFabric(ctx context.Context, code string, version int) error {
-	ctx, span := otel.Tracer("s-works/api").Start(ctx, "fabric.service.delete")
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.delete")
 	defer span.End()
 	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
 
+	if err := checkNotReserved(ctx, code); err != nil {
+		return err
+	}
+
 	fabric, err := s.commandRepo.GetByCode(ctx, code)
 	if err != nil {
 		return err
 	}
 
-	if err := fabric.Delete(version); err != nil {
+	if err := fabric.Delete(version, aggregate.StampNow(ctx, s.clock)); err != nil {
 		return err
 	}
 
@@ -195,36 +233,677 @@ func (s *FabricService) DeleteFabric(ctx context.Context, code string, version i
 			envelope := messaging.NewEventEnvelope(
 				"app.fabric.deleted",
 				fabric.Code,
-				"Fabric",
+				domain.AggregateType,
 				fabric.Version,
 				event,
+				messaging.WithClock(s.clock),
+				messaging.WithSource(s.source.Service, s.source.Instance),
 			)
 			envelopesToPublish = append(envelopesToPublish, envelope)
 		}
 	}
 
 	if len(envelopesToPublish) > 0 {
-		if err := s.eventStore.Save(ctx, envelopesToPublish...); err != nil {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
 			wrappedErr := fmt.Errorf("failed to save delete event to event store: %w", err)
 			logger.Error("saving delete event failed", "error", wrappedErr)
 			span.RecordError(wrappedErr)
 			return wrappedErr
 		}
-		// the contextet may be from REST API or from NATS subscription
-		if command.IsFromREST(ctx) {
-			for _, envelope := range envelopesToPublish {
-				if err := s.publisher.Publish(ctx, s.eventChannel, envelope); err != nil {
-					wrappedErr := fmt.Errorf("failed to publish fabric deleted event: %w", err)
-					logger.Error("publishing fabric deleted event failed", "error", wrappedErr, "eventID", envelope.EventID)
-					span.RecordError(wrappedErr)
-				}
-			}
+	}
+
+	return nil
+}
+
+// PurgeFabric removes a fabric deleted for at least the retention for good, and with
+// purgeEvents the history it recorded. The purge itself is published and kept in the
+// event store, so consumers replaying the changes drop the fabric too.
+func (s *FabricService) PurgeFabric(
+	ctx context.Context, code string, version int, retention time.Duration, purgeEvents bool,
+) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.purge")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
+
+	if err := checkNotReserved(ctx, code); err != nil {
+		return err
+	}
+
+	fabric, err := s.commandRepo.GetByCodeIncludingDeleted(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if err := fabric.Purge(version, retention, purgeEvents, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return err
+	}
+
+	if err := s.commandRepo.Purge(ctx, fabric, purgeEvents); err != nil {
+		wrappedErr := fmt.Errorf("failed to purge fabric in repo: %w", err)
+		logger.Error("purging fabric failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return wrappedErr
+	}
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range fabric.Events() {
+		if _, ok := event.(domain.FabricPurged); ok {
+			envelope := messaging.NewEventEnvelope(
+				"app.fabric.purged",
+				fabric.Code,
+				domain.AggregateType,
+				fabric.Version,
+				event,
+				messaging.WithClock(s.clock),
+				messaging.WithSource(s.source.Service, s.source.Instance),
+			)
+			envelopesToPublish = append(envelopesToPublish, envelope)
+		}
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
+			wrappedErr := fmt.Errorf("failed to save purge event to event store: %w", err)
+			logger.Error("saving purge event failed", "error", wrappedErr)
+			span.RecordError(wrappedErr)
+			return wrappedErr
+		}
+	}
+
+	return nil
+}
+
+// ChangePrice sets the list price of the fabric from a decimal amount such as "24.90". A
+// price given without the moment it is valid from takes effect immediately.
+func (s *FabricService) ChangePrice(
+	ctx context.Context, code, amount, currency string, validFrom time.Time, version int,
+) (*domain.Fabric, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.change_price")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
+
+	if err := checkNotReserved(ctx, code); err != nil {
+		return nil, err
+	}
+
+	if validFrom.IsZero() {
+		validFrom = s.clock.Now()
+	}
+	price, err := domain.ParsePrice(amount, currency, validFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	fabric, err := s.commandRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fabric.ChangePrice(price, version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+
+	if err := s.commandRepo.Update(ctx, fabric); err != nil {
+		wrappedErr := fmt.Errorf("failed to update fabric price in repo: %w", err)
+		logger.Error("updating fabric price failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return nil, wrappedErr
+	}
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range fabric.Events() {
+		if _, ok := event.(domain.FabricPriceChanged); ok {
+			envelope := messaging.NewEventEnvelope(
+				"app.fabric.price_changed",
+				fabric.Code,
+				domain.AggregateType,
+				fabric.Version,
+				event,
+				messaging.WithClock(s.clock),
+				messaging.WithSource(s.source.Service, s.source.Instance),
+			)
+			envelopesToPublish = append(envelopesToPublish, envelope)
+		}
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
+			wrappedErr := fmt.Errorf("failed to save price event to event store: %w", err)
+			logger.Error("saving price event failed", "error", wrappedErr)
+			span.RecordError(wrappedErr)
+			return nil, wrappedErr
+		}
+	}
+
+	return fabric, nil
+}
+
+// SetTranslation adds or replaces the name and description of the fabric in a locale.
+func (s *FabricService) SetTranslation(
+	ctx context.Context, code, locale string, translation domain.Translation, version int,
+) (*domain.Fabric, error) {
+	return s.translate(ctx, code, "fabric.service.set_translation", func(fabric *domain.Fabric, stamp domain.Stamp) error {
+		return fabric.SetTranslation(locale, translation, version, stamp)
+	})
+}
+
+// RemoveTranslation drops the translation of the fabric in a locale.
+func (s *FabricService) RemoveTranslation(
+	ctx context.Context, code, locale string, version int,
+) (*domain.Fabric, error) {
+	return s.translate(ctx, code, "fabric.service.remove_translation", func(fabric *domain.Fabric, stamp domain.Stamp) error {
+		return fabric.RemoveTranslation(locale, version, stamp)
+	})
+}
+
+// translate applies a change of the translations to the fabric and records its event.
+func (s *FabricService) translate(
+	ctx context.Context, code, spanName string, change func(fabric *domain.Fabric, stamp domain.Stamp) error,
+) (*domain.Fabric, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, spanName)
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
+
+	if err := checkNotReserved(ctx, code); err != nil {
+		return nil, err
+	}
+
+	fabric, err := s.commandRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := change(fabric, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+
+	if err := s.commandRepo.Update(ctx, fabric); err != nil {
+		wrappedErr := fmt.Errorf("failed to update fabric translations in repo: %w", err)
+		logger.Error("updating fabric translations failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return nil, wrappedErr
+	}
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range fabric.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.FabricTranslationSet:
+			eventType = "app.fabric.translation_set"
+		case domain.FabricTranslationRemoved:
+			eventType = "app.fabric.translation_removed"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			fabric.Code,
+			domain.AggregateType,
+			fabric.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
+			wrappedErr := fmt.Errorf("failed to save translation event to event store: %w", err)
+			logger.Error("saving translation event failed", "error", wrappedErr)
+			span.RecordError(wrappedErr)
+			return nil, wrappedErr
+		}
+	}
+
+	return fabric, nil
+}
+
+// SetCustomAttributes replaces the custom attributes of the fabric, validated against the
+// registered attribute definitions.
+func (s *FabricService) SetCustomAttributes(
+	ctx context.Context, code string, values map[string]any, definitions []*domain.AttributeDefinition, version int,
+) (*domain.Fabric, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.set_custom_attributes")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
+
+	if err := checkNotReserved(ctx, code); err != nil {
+		return nil, err
+	}
+
+	fabric, err := s.commandRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fabric.SetCustomAttributes(values, definitions, version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+
+	if err := s.commandRepo.Update(ctx, fabric); err != nil {
+		wrappedErr := fmt.Errorf("failed to update fabric custom attributes in repo: %w", err)
+		logger.Error("updating fabric custom attributes failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return nil, wrappedErr
+	}
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range fabric.Events() {
+		if _, ok := event.(domain.FabricCustomAttributesChanged); !ok {
+			continue
+		}
+		envelope := messaging.NewEventEnvelope(
+			"app.fabric.custom_attributes_changed",
+			fabric.Code,
+			domain.AggregateType,
+			fabric.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
+			wrappedErr := fmt.Errorf("failed to save custom attributes event to event store: %w", err)
+			logger.Error("saving custom attributes event failed", "error", wrappedErr)
+			span.RecordError(wrappedErr)
+			return nil, wrappedErr
+		}
+	}
+
+	return fabric, nil
+}
+
+// ActivateFabric puts a draft or discontinued fabric on offer.
+func (s *FabricService) ActivateFabric(ctx context.Context, code string, version int) (*domain.Fabric, error) {
+	return s.changeStatus(ctx, code, version, "fabric.service.activate", (*domain.Fabric).Activate)
+}
+
+// DiscontinueFabric takes an active fabric off offer.
+func (s *FabricService) DiscontinueFabric(ctx context.Context, code string, version int) (*domain.Fabric, error) {
+	return s.changeStatus(ctx, code, version, "fabric.service.discontinue", (*domain.Fabric).Discontinue)
+}
+
+// ArchiveFabric retires a discontinued fabric, leaving it read-only.
+func (s *FabricService) ArchiveFabric(ctx context.Context, code string, version int) (*domain.Fabric, error) {
+	return s.changeStatus(ctx, code, version, "fabric.service.archive", (*domain.Fabric).Archive)
+}
+
+// changeStatus applies a lifecycle transition to the fabric and records its event.
+func (s *FabricService) changeStatus(
+	ctx context.Context, code string, version int, spanName string,
+	transition func(fabric *domain.Fabric, version int, stamp domain.Stamp) error,
+) (*domain.Fabric, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, spanName)
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
+
+	if err := checkNotReserved(ctx, code); err != nil {
+		return nil, err
+	}
+
+	fabric, err := s.commandRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transition(fabric, version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+
+	if err := s.commandRepo.ChangeStatus(ctx, fabric); err != nil {
+		wrappedErr := fmt.Errorf("failed to change fabric status in repo: %w", err)
+		logger.Error("changing fabric status failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return nil, wrappedErr
+	}
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range fabric.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.FabricActivated:
+			eventType = "app.fabric.activated"
+		case domain.FabricDiscontinued:
+			eventType = "app.fabric.discontinued"
+		case domain.FabricArchived:
+			eventType = "app.fabric.archived"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			fabric.Code,
+			domain.AggregateType,
+			fabric.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
+			wrappedErr := fmt.Errorf("failed to save status event to event store: %w", err)
+			logger.Error("saving status event failed", "error", wrappedErr)
+			span.RecordError(wrappedErr)
+			return nil, wrappedErr
+		}
+	}
+
+	return fabric, nil
+}
+
+// RestoreFabric brings a deleted fabric back with the data it had before it was deleted,
+// so the caller does not have to resubmit it as a reactivating create does.
+func (s *FabricService) RestoreFabric(ctx context.Context, code string, version int) (*domain.Fabric, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.restore")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
+
+	if err := checkNotReserved(ctx, code); err != nil {
+		return nil, err
+	}
+
+	fabric, err := s.commandRepo.GetByCodeIncludingDeleted(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fabric.Restore(version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+	// a restored fabric counts against the quota again
+	if err := s.quota.checkFabric(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.commandRepo.Restore(ctx, fabric); err != nil {
+		wrappedErr := fmt.Errorf("failed to restore fabric in repo: %w", err)
+		logger.Error("restoring fabric failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return nil, wrappedErr
+	}
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range fabric.Events() {
+		if _, ok := event.(domain.FabricRestored); ok {
+			envelope := messaging.NewEventEnvelope(
+				"app.fabric.restored",
+				fabric.Code,
+				domain.AggregateType,
+				fabric.Version,
+				event,
+				messaging.WithClock(s.clock),
+				messaging.WithSource(s.source.Service, s.source.Instance),
+			)
+			envelopesToPublish = append(envelopesToPublish, envelope)
+		}
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
+			wrappedErr := fmt.Errorf("failed to save restore event to event store: %w", err)
+			logger.Error("saving restore event failed", "error", wrappedErr)
+			span.RecordError(wrappedErr)
+			return nil, wrappedErr
+		}
+	}
+
+	return fabric, nil
+}
+
+// ReactivateFabric puts a discontinued or deleted fabric back on offer, for articles that
+// return in a new season. Empty attributes and a nil spec keep the data the fabric had.
+func (s *FabricService) ReactivateFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification, version int,
+) (*domain.Fabric, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.reactivate")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
+
+	if err := checkNotReserved(ctx, code); err != nil {
+		return nil, err
+	}
+
+	fabric, err := s.commandRepo.GetByCodeIncludingDeleted(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	// an active or draft fabric has nothing to come back from, reactivating it would
+	// silently update or activate it instead
+	if fabric.Status != domain.StatusDiscontinued && fabric.Status != domain.StatusDeleted {
+		return nil, domain.ErrInvalidStatusTransition.
+			WithParam("from", fabric.Status).WithParam("to", domain.StatusActive)
+	}
+
+	if name == "" {
+		name = fabric.Name
+	}
+	if measureUnit == "" {
+		measureUnit = string(fabric.MeasureUnit)
+	}
+	if offerStatus == "" {
+		offerStatus = string(fabric.OfferStatus)
+	}
+	// the specification the fabric already had is not checked again, the reference list of
+	// fibres and the palette may have changed since
+	checkSpecification := spec != nil
+	if spec == nil {
+		spec = &fabric.Specification
+	}
+
+	err = fabric.Reactivate(
+		domain.StatusActive, name, measureUnit, offerStatus, *spec, domain.FabricTexts{}, version, aggregate.StampNow(ctx, s.clock),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if checkSpecification {
+		if err := s.checkSpecification(ctx, *spec); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.commandRepo.Reactivate(ctx, fabric); err != nil {
+		wrappedErr := fmt.Errorf("failed to reactivate fabric in repo: %w", err)
+		logger.Error("reactivating fabric failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return nil, wrappedErr
+	}
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range fabric.Events() {
+		if _, ok := event.(domain.FabricReactivated); ok {
+			envelope := messaging.NewEventEnvelope(
+				"app.fabric.reactivated",
+				fabric.Code,
+				domain.AggregateType,
+				fabric.Version,
+				event,
+				messaging.WithClock(s.clock),
+				messaging.WithSource(s.source.Service, s.source.Instance),
+			)
+			envelopesToPublish = append(envelopesToPublish, envelope)
+		}
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
+			wrappedErr := fmt.Errorf("failed to save reactivation event to event store: %w", err)
+			logger.Error("saving reactivation event failed", "error", wrappedErr)
+			span.RecordError(wrappedErr)
+			return nil, wrappedErr
+		}
+	}
+
+	return fabric, nil
+}
+
+// MergeFabric folds the duplicate fabric into the canonical one. The duplicate code keeps
+// resolving, as an alias of the canonical fabric, and its history stays in the event store.
+func (s *FabricService) MergeFabric(ctx context.Context, code, into string, version int) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.merge")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
+
+	if err := checkNotReserved(ctx, code, into); err != nil {
+		return err
+	}
+
+	duplicate, err := s.commandRepo.GetByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	canonical, err := s.commandRepo.GetByCode(ctx, into)
+	if err != nil {
+		return err
+	}
+
+	if err := duplicate.MergeInto(canonical, version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return err
+	}
+
+	if err := s.commandRepo.Merge(ctx, duplicate, canonical.Code); err != nil {
+		wrappedErr := fmt.Errorf("failed to merge fabric in repo: %w", err)
+		logger.Error("merging fabric failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return wrappedErr
+	}
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range duplicate.Events() {
+		if _, ok := event.(domain.FabricMerged); ok {
+			envelope := messaging.NewEventEnvelope(
+				"app.fabric.merged",
+				duplicate.Code,
+				domain.AggregateType,
+				duplicate.Version,
+				event,
+				messaging.WithClock(s.clock),
+				messaging.WithSource(s.source.Service, s.source.Instance),
+			)
+			envelopesToPublish = append(envelopesToPublish, envelope)
+		}
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
+			wrappedErr := fmt.Errorf("failed to save merge event to event store: %w", err)
+			logger.Error("saving merge event failed", "error", wrappedErr)
+			span.RecordError(wrappedErr)
+			return wrappedErr
 		}
 	}
 
 	return nil
 }
 
+// RenameFabric changes the code of a fabric, given by its code or an alias. The previous
+// code keeps resolving to the fabric as an alias, and the change is published so
+// downstream systems can remap their references.
+func (s *FabricService) RenameFabric(ctx context.Context, code, newCode string, version int) (*domain.Fabric, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.rename")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.service")
+
+	if err := checkNotReserved(ctx, code, newCode); err != nil {
+		return nil, err
+	}
+
+	fabric, err := s.commandRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	previousCode := fabric.Code
+
+	if err := fabric.ChangeCode(newCode, version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+
+	if err := s.commandRepo.ChangeCode(ctx, fabric, previousCode); err != nil {
+		wrappedErr := fmt.Errorf("failed to change fabric code in repo: %w", err)
+		logger.Error("renaming fabric failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return nil, wrappedErr
+	}
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range fabric.Events() {
+		if _, ok := event.(domain.FabricCodeChanged); ok {
+			envelope := messaging.NewEventEnvelope(
+				"app.fabric.code_changed",
+				fabric.Code,
+				domain.AggregateType,
+				fabric.Version,
+				event,
+				messaging.WithClock(s.clock),
+				messaging.WithSource(s.source.Service, s.source.Instance),
+			)
+			envelopesToPublish = append(envelopesToPublish, envelope)
+		}
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.saveEvents(ctx, envelopesToPublish); err != nil {
+			wrappedErr := fmt.Errorf("failed to save code change event to event store: %w", err)
+			logger.Error("saving code change event failed", "error", wrappedErr)
+			span.RecordError(wrappedErr)
+			return nil, wrappedErr
+		}
+	}
+
+	return fabric, nil
+}
+
+// SyncFabric brings the fabric to the given data at whatever version it is, for idempotent
+// commands sourced from events such as a re-sent ERP create. A fabric already holding the
+// data is left untouched and reported unchanged. When a concurrent change wins the race,
+// the fabric is reloaded and the data reapplied, a bounded number of times.
+func (s *FabricService) SyncFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string,
+) (*domain.Fabric, bool, error) {
+	var (
+		fabric  *domain.Fabric
+		changed bool
+	)
+	err := retryOnConflict(ctx, func() error {
+		current, err := s.commandRepo.GetByCode(ctx, code)
+		if err != nil {
+			return err
+		}
+		if current.Name == name &&
+			strings.EqualFold(string(current.MeasureUnit), measureUnit) &&
+			strings.EqualFold(string(current.OfferStatus), offerStatus) {
+			fabric, changed = current, false
+			return nil
+		}
+
+		fabric, err = s.UpdateFabric(ctx, code, name, measureUnit, offerStatus, nil, domain.FabricTexts{}, current.Version)
+		changed = err == nil
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return fabric, changed, nil
+}
+
 func (s *FabricService) GetByCode(ctx context.Context, code string) (*domain.Fabric, error) {
 	return s.commandRepo.GetByCode(ctx, code)
 }
@@ -232,3 +911,56 @@ func (s *FabricService) GetByCode(ctx context.Context, code string) (*domain.Fab
 func (s *FabricService) GetByCodeIncludingDeleted(ctx context.Context, code string) (*domain.Fabric, error) {
 	return s.commandRepo.GetByCodeIncludingDeleted(ctx, code)
 }
+
+// saveEvents appends the envelopes to the event store. Events of commands issued through
+// the REST API are also queued in the outbox, from where the relay publishes them; events
+// mirrored from the ERP are not published back.
+func (s *FabricService) saveEvents(ctx context.Context, envelopes []*messaging.EventEnvelope) error {
+	// the actor tells the history who made a change and whether it came from the ERP, it
+	// also flags events of the synthetic probe so consumers can tell them from real changes
+	for _, envelope := range envelopes {
+		envelope.UserID = command.Actor(ctx)
+	}
+	if command.IsFromREST(ctx) {
+		return s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopes...)
+	}
+	return s.eventStore.Save(ctx, envelopes...)
+}
+
+// checkSpecification holds the composition to the reference list of fibres and the color
+// to the palette, each only looked up when it is given.
+func (s *FabricService) checkSpecification(ctx context.Context, spec domain.Specification) error {
+	if spec.Color != "" {
+		if err := s.commandRepo.CheckColor(ctx, spec.Color); err != nil {
+			return err
+		}
+	}
+	return s.checkComposition(ctx, spec.Composition)
+}
+
+// checkComposition holds the composition to the reference list of fibres, which is only
+// loaded when there is a composition to check.
+func (s *FabricService) checkComposition(ctx context.Context, composition []domain.CompositionPart) error {
+	if len(composition) == 0 {
+		return nil
+	}
+	fibres, err := s.fibres.ListFibres(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load fibres: %w", err)
+	}
+	return domain.NewCompositionValidator(fibres).Validate(composition)
+}
+
+// checkNotReserved refuses commands on the codes reserved for the synthetic probe, unless
+// the probe issues them itself.
+func checkNotReserved(ctx context.Context, codes ...string) error {
+	if command.IsSyntheticProbe(ctx) {
+		return nil
+	}
+	for _, code := range codes {
+		if domain.IsProbeFabricCode(code) {
+			return domain.ErrReservedFabricCode
+		}
+	}
+	return nil
+}
@@ -3,19 +3,33 @@ package application
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
 	"github.com/salesworks/s-works/api/internal/platform/messaging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+var testStamp = domain.Stamp{By: "tester", At: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+var testSource = messaging.Source{Service: "s-works-api", Instance: "test-instance"}
+
 type mockFabricCommandRepository struct {
-	SavedCalled  bool
-	UpdateCalled bool
-	DeleteCalled bool
-	fabric       *domain.Fabric
-	errToReturn  error
+	SavedCalled   bool
+	UpdateCalled  bool
+	DeleteCalled  bool
+	RestoreCalled bool
+	Reactivated   bool
+	StatusChanged bool
+	MergedInto    string
+	RenamedFrom   string
+	PurgedEvents  *bool
+	fabric        *domain.Fabric
+	others        []*domain.Fabric
+	errToReturn   error
 }
 
 func (m *mockFabricCommandRepository) Save(ctx context.Context, fabric *domain.Fabric) (*domain.Fabric, error) {
@@ -31,13 +45,24 @@ func (m *mockFabricCommandRepository) GetByCode(ctx context.Context, code string
 	if m.errToReturn != nil {
 		return nil, m.errToReturn
 	}
-	if m.fabric != nil && m.fabric.Code == code && m.fabric.Status == domain.StatusActive {
+	if m.fabric != nil && m.fabric.Code == code && inCatalogue(m.fabric) {
 		fabricCopy := *m.fabric
 		return &fabricCopy, nil
 	}
+	for _, other := range m.others {
+		if other.Code == code && inCatalogue(other) {
+			fabricCopy := *other
+			return &fabricCopy, nil
+		}
+	}
 	return nil, domain.ErrRecordNotFound
 }
 
+// inCatalogue mirrors the repository, which loads fabrics neither deleted nor merged
+func inCatalogue(fabric *domain.Fabric) bool {
+	return fabric.Status != domain.StatusDeleted && fabric.Status != domain.StatusMerged
+}
+
 func (m *mockFabricCommandRepository) GetByCodeIncludingDeleted(ctx context.Context, code string) (*domain.Fabric, error) {
 	if m.errToReturn != nil {
 		return nil, m.errToReturn
@@ -67,37 +92,109 @@ func (m *mockFabricCommandRepository) Delete(ctx context.Context, fabric *domain
 	return nil
 }
 
-type mockEventPublisher struct {
-	PublishedCalled   bool
-	PublishedEnvelope *messaging.EventEnvelope
+func (m *mockFabricCommandRepository) ChangeStatus(ctx context.Context, fabric *domain.Fabric) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.StatusChanged = true
+	m.fabric = fabric
+	return nil
+}
+
+func (m *mockFabricCommandRepository) Restore(ctx context.Context, fabric *domain.Fabric) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.RestoreCalled = true
+	m.fabric = fabric
+	return nil
+}
+
+func (m *mockFabricCommandRepository) Reactivate(ctx context.Context, fabric *domain.Fabric) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.Reactivated = true
+	m.fabric = fabric
+	return nil
+}
+
+func (m *mockFabricCommandRepository) Merge(ctx context.Context, duplicate *domain.Fabric, canonicalCode string) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.MergedInto = canonicalCode
+	m.fabric.Status = duplicate.Status
+	m.fabric.Version = duplicate.Version
+	return nil
+}
+
+func (m *mockFabricCommandRepository) ChangeCode(ctx context.Context, fabric *domain.Fabric, previousCode string) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	for _, other := range m.others {
+		if other.Code == fabric.Code {
+			return domain.ErrDuplicateFabricCode
+		}
+	}
+	m.RenamedFrom = previousCode
+	m.fabric.Code = fabric.Code
+	m.fabric.Version = fabric.Version
+	return nil
+}
+
+// mockFibreRepository holds the reference list of fibres, cotton and elastane unless set
+type mockFibreRepository struct {
+	fibres []*domain.Fibre
 }
 
-func (m *mockEventPublisher) Publish(ctx context.Context, subject string, envelope *messaging.EventEnvelope) error {
-	m.PublishedCalled = true
-	m.PublishedEnvelope = envelope
+func (m *mockFibreRepository) SaveFibre(ctx context.Context, fibre *domain.Fibre) error {
+	m.fibres = append(m.fibres, fibre)
 	return nil
 }
 
-func (m *mockEventPublisher) Close() error {
-	// No-op for mock
+func (m *mockFibreRepository) ListFibres(ctx context.Context) ([]*domain.Fibre, error) {
+	if m.fibres == nil {
+		return []*domain.Fibre{{Code: "cotton", Name: "Cotton"}, {Code: "elastane", Name: "Elastane"}}, nil
+	}
+	return m.fibres, nil
+}
+
+func (m *mockFibreRepository) DeleteFibre(ctx context.Context, code string) error {
 	return nil
 }
 
 type mockEventStore struct {
-	SavedCalled bool
+	SavedCalled      bool
+	EnqueuedCalled   bool
+	EnqueuedSubject  string
+	EnqueuedEnvelope *messaging.EventEnvelope
+	SavedEnvelope    *messaging.EventEnvelope
 }
 
 func (m *mockEventStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
 	m.SavedCalled = true
+	m.SavedEnvelope = envelopes[len(envelopes)-1]
+	return nil
+}
+
+func (m *mockEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.SavedCalled = true
+	m.EnqueuedCalled = true
+	m.EnqueuedSubject = subject
+	m.EnqueuedEnvelope = envelopes[len(envelopes)-1]
+	m.SavedEnvelope = m.EnqueuedEnvelope
 	return nil
 }
 
 func TestFabricService_CreateFabric_HappyPath(t *testing.T) {
 	// --- Arrange ---
 	commandRepo := &mockFabricCommandRepository{}
-	publisher := &mockEventPublisher{}
 	eventStore := &mockEventStore{}
-	service := NewFabricCommandService(commandRepo, publisher, eventStore)
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
 
 	ctx := context.Background()
 	code := "TESTCODE"
@@ -106,21 +203,26 @@ func TestFabricService_CreateFabric_HappyPath(t *testing.T) {
 	offerStatus := "available"
 
 	// --- Act ---
-	createdFabric, err := service.CreateFabric(ctx, code, name, measureUnit, offerStatus)
+	createdFabric, err := service.CreateFabric(ctx, code, name, measureUnit, offerStatus, domain.Specification{}, domain.FabricTexts{})
 
 	// --- Assert ---
 	assert.NoError(t, err)
 	assert.NotNil(t, createdFabric)
 	assert.True(t, commandRepo.SavedCalled, "expected Save() to be called on the write repository")
-	assert.True(t, publisher.PublishedCalled, "expected Publish() to be called on the event publisher")
+	assert.True(t, eventStore.EnqueuedCalled, "expected the event to be queued for publishing")
+	assert.Equal(t, "app.fabric", eventStore.EnqueuedSubject)
 	assert.True(t, eventStore.SavedCalled, "expected Save() to be called on the event store")
 
-	publishedEnvelope := publisher.PublishedEnvelope
+	publishedEnvelope := eventStore.EnqueuedEnvelope
 	require.NotNil(t, publishedEnvelope, "published envelope should not be nil")
 	assert.Equal(t, "app.fabric.created", publishedEnvelope.EventType)
 	assert.Equal(t, "Fabric", publishedEnvelope.AggregateType)
 	assert.Equal(t, code, publishedEnvelope.AggregateID)
 	assert.Equal(t, 1, publishedEnvelope.AggregateVersion)
+	assert.Equal(t, testStamp.At, publishedEnvelope.Timestamp, "envelope timestamp should come from the injected clock")
+	assert.Equal(t, testStamp.At, createdFabric.CreatedAt, "audit time should come from the injected clock")
+	assert.Equal(t, &testSource, publishedEnvelope.Source, "the envelope should name the producing instance")
+	assert.NoError(t, publishedEnvelope.Validate())
 
 	payload, ok := publishedEnvelope.Payload.(domain.FabricCreated)
 	require.True(t, ok, "payload should be of type domain.FabricCreated")
@@ -128,18 +230,115 @@ func TestFabricService_CreateFabric_HappyPath(t *testing.T) {
 	assert.Equal(t, name, payload.Name)
 }
 
+func TestFabricService_CreateFabric_FromEventIsNotQueued(t *testing.T) {
+	// --- Arrange ---
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(&mockFabricCommandRepository{}, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+	ctx := command.WithCommandSource(context.Background(), command.CommandSourceEvent)
+
+	// --- Act ---
+	_, err := service.CreateFabric(ctx, "TESTCODE", "Test Fabric", "mb", "available", domain.Specification{}, domain.FabricTexts{})
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.True(t, eventStore.SavedCalled, "expected the event to be stored")
+	assert.False(t, eventStore.EnqueuedCalled, "events mirrored from the ERP are not published back")
+}
+
+func TestFabricService_ChecksCompositionAgainstFibres(t *testing.T) {
+	tests := []struct {
+		name        string
+		composition []domain.CompositionPart
+		expectedErr error
+	}{
+		{
+			name:        "fibres on the list regardless of case",
+			composition: []domain.CompositionPart{{Fibre: "Cotton", Percent: 95}, {Fibre: "elastane", Percent: 5}},
+		},
+		{
+			name:        "fibre off the list",
+			composition: []domain.CompositionPart{{Fibre: "cotton", Percent: 80}, {Fibre: "kevlar", Percent: 20}},
+			expectedErr: domain.ErrUnknownFibre,
+		},
+		{
+			name:        "shares not adding up",
+			composition: []domain.CompositionPart{{Fibre: "cotton", Percent: 80}, {Fibre: "elastane", Percent: 5}},
+			expectedErr: domain.ErrInvalidCompositionTotal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			commandRepo := &mockFabricCommandRepository{}
+			service := NewFabricCommandService(
+				commandRepo, &mockFibreRepository{}, noQuota, &mockEventStore{}, clock.NewFixed(testStamp.At), testSource,
+			)
+			spec := domain.Specification{Composition: tt.composition}
+
+			// --- Act ---
+			_, err := service.CreateFabric(context.Background(), "FAB01", "Poplin", "mb", "available", spec, domain.FabricTexts{})
+
+			// --- Assert ---
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.True(t, domain.IsValidation(err))
+				assert.False(t, commandRepo.SavedCalled)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, commandRepo.SavedCalled)
+		})
+	}
+}
+
+func TestFabricService_ChecksColorAgainstPalette(t *testing.T) {
+	tests := []struct {
+		name        string
+		color       string
+		expectedErr error
+	}{
+		{name: "color in the palette", color: "NAVY"},
+		{name: "no color", color: ""},
+		{name: "color off the palette", color: "GRAPHITE", expectedErr: domain.ErrUnknownColor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			commandRepo := &mockFabricCommandRepository{}
+			service := NewFabricCommandService(
+				commandRepo, &mockFibreRepository{}, noQuota, &mockEventStore{}, clock.NewFixed(testStamp.At), testSource,
+			)
+			spec := domain.Specification{Color: tt.color}
+
+			// --- Act ---
+			_, err := service.CreateFabric(context.Background(), "FAB01", "Poplin", "mb", "available", spec, domain.FabricTexts{})
+
+			// --- Assert ---
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.True(t, domain.IsValidation(err))
+				assert.False(t, commandRepo.SavedCalled)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, commandRepo.SavedCalled)
+		})
+	}
+}
+
 func TestFabricService_UpdateFabric_HappyPath(t *testing.T) {
 	// --- Arrange ---
 	commandRepo := &mockFabricCommandRepository{}
-	publisher := &mockEventPublisher{}
 	eventStore := &mockEventStore{}
-	service := NewFabricCommandService(commandRepo, publisher, eventStore)
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
 
 	ctx := context.Background()
 	code := "TESTCODE"
 	initialName := "Initial Fabric"
 
-	existingFabric, err := domain.NewFabric(code, initialName, "m", "available")
+	existingFabric, err := domain.NewFabric(code, initialName, "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 	commandRepo.fabric = existingFabric
 	initialVersion := existingFabric.Version
@@ -149,16 +348,17 @@ func TestFabricService_UpdateFabric_HappyPath(t *testing.T) {
 	updatedOfferStatus := "out_of_stock"
 
 	// --- Act ---
-	updatedFabric, err := service.UpdateFabric(ctx, code, updatedName, updatedMeasureUnit, updatedOfferStatus, initialVersion)
+	updatedFabric, err := service.UpdateFabric(ctx, code, updatedName, updatedMeasureUnit, updatedOfferStatus, nil, domain.FabricTexts{}, initialVersion)
 
 	// --- Assert ---
 	require.NoError(t, err)
 	assert.NotNil(t, updatedFabric)
 	assert.True(t, commandRepo.UpdateCalled, "expected Update() to be called on the repository")
 	assert.True(t, eventStore.SavedCalled, "expected Save() to be called on the event store")
-	assert.True(t, publisher.PublishedCalled, "expected Publish() to be called on the event publisher")
+	assert.True(t, eventStore.EnqueuedCalled, "expected the event to be queued for publishing")
+	assert.Equal(t, "app.fabric", eventStore.EnqueuedSubject)
 
-	publishedEnvelope := publisher.PublishedEnvelope
+	publishedEnvelope := eventStore.EnqueuedEnvelope
 	require.NotNil(t, publishedEnvelope, "published envelope should not be nil")
 	assert.Equal(t, "app.fabric.updated", publishedEnvelope.EventType)
 	assert.Equal(t, code, publishedEnvelope.AggregateID)
@@ -168,40 +368,38 @@ func TestFabricService_UpdateFabric_HappyPath(t *testing.T) {
 func TestFabricService_UpdateFabric_ConcurrencyError(t *testing.T) {
 	// --- Arrange ---
 	commandRepo := &mockFabricCommandRepository{}
-	publisher := &mockEventPublisher{}
 	eventStore := &mockEventStore{}
-	service := NewFabricCommandService(commandRepo, publisher, eventStore)
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
 
 	ctx := context.Background()
 	code := "TESTCODE"
-	existingFabric, err := domain.NewFabric(code, "Initial Name", "m", "available")
+	existingFabric, err := domain.NewFabric(code, "Initial Name", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 	commandRepo.fabric = existingFabric
 
 	staleVersion := existingFabric.Version - 1
 
 	// --- Act ---
-	_, err = service.UpdateFabric(ctx, code, "New Name", "cm", "new", staleVersion)
+	_, err = service.UpdateFabric(ctx, code, "New Name", "cm", "new", nil, domain.FabricTexts{}, staleVersion)
 
 	// --- Assert ---
 	require.Error(t, err)
 	assert.ErrorIs(t, err, domain.ErrConcurrencyConflict)
 	assert.False(t, commandRepo.UpdateCalled, "Update() should not be called on the repo if domain validation fails")
 	assert.False(t, eventStore.SavedCalled, "Save() should not be called on the event store if domain validation fails")
-	assert.False(t, publisher.PublishedCalled, "Publish() should not be called if domain validation fails")
+	assert.False(t, eventStore.EnqueuedCalled, "no event should be queued if domain validation fails")
 }
 
 func TestFabricService_UpdateFabric_NotFound(t *testing.T) {
 	// --- Arrange ---
 	commandRepo := &mockFabricCommandRepository{errToReturn: domain.ErrRecordNotFound}
-	publisher := &mockEventPublisher{}
 	eventStore := &mockEventStore{}
-	service := NewFabricCommandService(commandRepo, publisher, eventStore)
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
 
 	ctx := context.Background()
 
 	// --- Act ---
-	_, err := service.UpdateFabric(ctx, "NONEXISTENT", "New Name", "cm", "new", 1)
+	_, err := service.UpdateFabric(ctx, "NONEXISTENT", "New Name", "cm", "new", nil, domain.FabricTexts{}, 1)
 
 	// --- Assert ---
 	require.Error(t, err)
@@ -211,13 +409,12 @@ func TestFabricService_UpdateFabric_NotFound(t *testing.T) {
 func TestFabricService_GetByCode(t *testing.T) {
 	// --- Arrange ---
 	commandRepo := &mockFabricCommandRepository{}
-	publisher := &mockEventPublisher{}
 	eventStore := &mockEventStore{}
-	service := NewFabricCommandService(commandRepo, publisher, eventStore)
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
 
 	ctx := context.Background()
 	code := "GETBYCODE"
-	expectedFabric, _ := domain.NewFabric(code, "Test Fabric", "m", "available")
+	expectedFabric, _ := domain.NewFabric(code, "Test Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 
 	commandRepo.fabric = expectedFabric
 
@@ -231,16 +428,32 @@ func TestFabricService_GetByCode(t *testing.T) {
 	assert.Equal(t, expectedFabric.Name, retrievedFabric.Name)
 }
 
+func (m *mockFabricCommandRepository) Purge(ctx context.Context, fabric *domain.Fabric, purgeEvents bool) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.PurgedEvents = &purgeEvents
+	m.fabric = nil
+	return nil
+}
+
+// CheckColor knows a palette of the single color NAVY.
+func (m *mockFabricCommandRepository) CheckColor(ctx context.Context, code string) error {
+	if code != "NAVY" {
+		return domain.ErrUnknownColor
+	}
+	return nil
+}
+
 func TestFabricService_DeleteFabric_HappyPath(t *testing.T) {
 	// --- Arrange ---
 	commandRepo := &mockFabricCommandRepository{}
-	publisher := &mockEventPublisher{}
 	eventStore := &mockEventStore{}
-	service := NewFabricCommandService(commandRepo, publisher, eventStore)
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
 
 	ctx := context.Background()
 	code := "DELETEME"
-	existingFabric, err := domain.NewFabric(code, "To Be Deleted", "m", "available")
+	existingFabric, err := domain.NewFabric(code, "To Be Deleted", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 	commandRepo.fabric = existingFabric
 
@@ -251,9 +464,10 @@ func TestFabricService_DeleteFabric_HappyPath(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, commandRepo.DeleteCalled, "expected Delete() to be called on the repository")
 	assert.True(t, eventStore.SavedCalled, "expected Save() to be called on the event store")
-	assert.True(t, publisher.PublishedCalled, "expected Publish() to be called on the event publisher")
+	assert.True(t, eventStore.EnqueuedCalled, "expected the event to be queued for publishing")
+	assert.Equal(t, "app.fabric", eventStore.EnqueuedSubject)
 
-	publishedEnvelope := publisher.PublishedEnvelope
+	publishedEnvelope := eventStore.EnqueuedEnvelope
 	require.NotNil(t, publishedEnvelope)
 	assert.Equal(t, "app.fabric.deleted", publishedEnvelope.EventType)
 	assert.Equal(t, code, publishedEnvelope.AggregateID)
@@ -262,3 +476,673 @@ func TestFabricService_DeleteFabric_HappyPath(t *testing.T) {
 	_, ok := publishedEnvelope.Payload.(domain.FabricDeleted)
 	require.True(t, ok, "payload should be of type domain.FabricDeleted")
 }
+
+func TestFabricService_RestoreFabric_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	commandRepo := &mockFabricCommandRepository{}
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	ctx := context.Background()
+	code := "RESTOREME"
+	deletedFabric, err := domain.NewFabric(code, "Deleted Name", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	require.NoError(t, deletedFabric.Delete(1, testStamp))
+	commandRepo.fabric = deletedFabric
+
+	// --- Act ---
+	restored, err := service.RestoreFabric(ctx, code, 2)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.True(t, commandRepo.RestoreCalled, "expected Restore() to be called on the repository")
+	assert.Equal(t, domain.StatusActive, restored.Status)
+	assert.Equal(t, "Deleted Name", restored.Name)
+	assert.Equal(t, 3, restored.Version)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.fabric.restored", publishedEnvelope.EventType)
+	assert.Equal(t, 3, publishedEnvelope.AggregateVersion)
+	_, ok := publishedEnvelope.Payload.(domain.FabricRestored)
+	require.True(t, ok, "payload should be of type domain.FabricRestored")
+}
+
+func TestFabricService_RestoreFabric_ActiveFabric(t *testing.T) {
+	// --- Arrange ---
+	commandRepo := &mockFabricCommandRepository{}
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	activeFabric, err := domain.NewFabric("ACTIVE01", "Active", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	commandRepo.fabric = activeFabric
+
+	// --- Act ---
+	_, err = service.RestoreFabric(context.Background(), "ACTIVE01", 1)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, domain.ErrFabricNotDeleted)
+	assert.False(t, commandRepo.RestoreCalled)
+	assert.False(t, eventStore.SavedCalled)
+}
+
+func TestFabricService_PurgeFabric(t *testing.T) {
+	const retention = 30 * 24 * time.Hour
+
+	testCases := []struct {
+		name        string
+		deleted     bool
+		purgedAt    time.Time
+		expectedErr error
+	}{
+		{name: "Deleted past the retention", deleted: true, purgedAt: testStamp.At.Add(retention)},
+		{
+			name: "Deleted within the retention", deleted: true,
+			purgedAt: testStamp.At.Add(retention - time.Hour), expectedErr: domain.ErrPurgeRetention,
+		},
+		{name: "Active fabric", purgedAt: testStamp.At.Add(retention), expectedErr: domain.ErrFabricNotPurgeable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			commandRepo := &mockFabricCommandRepository{}
+			eventStore := &mockEventStore{}
+			service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(tc.purgedAt), testSource)
+
+			fabric, err := domain.NewFabric("PURGEME", "Deleted Name", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			version := 1
+			if tc.deleted {
+				require.NoError(t, fabric.Delete(1, testStamp))
+				version = 2
+			}
+			commandRepo.fabric = fabric
+			ctx := command.WithCommandSource(context.Background(), command.CommandSourceREST)
+
+			// --- Act ---
+			err = service.PurgeFabric(ctx, "PURGEME", version, retention, true)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, commandRepo.PurgedEvents, "the fabric must not be purged")
+				assert.False(t, eventStore.SavedCalled)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, commandRepo.PurgedEvents)
+			assert.True(t, *commandRepo.PurgedEvents)
+
+			publishedEnvelope := eventStore.EnqueuedEnvelope
+			require.NotNil(t, publishedEnvelope)
+			assert.Equal(t, "app.fabric.purged", publishedEnvelope.EventType)
+			assert.Equal(t, "PURGEME", publishedEnvelope.AggregateID)
+			assert.Equal(t, 3, publishedEnvelope.AggregateVersion)
+			assert.Equal(t, domain.FabricPurged{Code: "PURGEME", Version: 3, EventsPurged: true}, publishedEnvelope.Payload)
+		})
+	}
+}
+
+func TestFabricService_ReactivateFabric(t *testing.T) {
+	testCases := []struct {
+		name         string
+		status       string
+		newName      string
+		expectedName string
+	}{
+		{name: "Discontinued keeps its data", status: domain.StatusDiscontinued, expectedName: "Old Name"},
+		{name: "Discontinued with a new name", status: domain.StatusDiscontinued, newName: "New Season", expectedName: "New Season"},
+		{name: "Deleted", status: domain.StatusDeleted, expectedName: "Old Name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			stored := &domain.Fabric{
+				Code: "SEASON01", Name: "Old Name", MeasureUnit: domain.MeasureUnitRunningMetre,
+				OfferStatus: domain.OfferStatusActive, Status: tc.status, Version: 3,
+			}
+			commandRepo := &mockFabricCommandRepository{fabric: stored}
+			eventStore := &mockEventStore{}
+			service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+			ctx := command.WithCommandSource(context.Background(), command.CommandSourceREST)
+
+			// --- Act ---
+			fabric, err := service.ReactivateFabric(ctx, "SEASON01", tc.newName, "", "", nil, 3)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.True(t, commandRepo.Reactivated)
+			assert.Equal(t, domain.StatusActive, fabric.Status)
+			assert.Equal(t, tc.expectedName, fabric.Name)
+			assert.Equal(t, domain.MeasureUnitRunningMetre, fabric.MeasureUnit)
+			assert.Equal(t, 4, fabric.Version)
+			require.NotNil(t, eventStore.EnqueuedEnvelope)
+			assert.Equal(t, "app.fabric.reactivated", eventStore.EnqueuedEnvelope.EventType)
+			_, ok := eventStore.EnqueuedEnvelope.Payload.(domain.FabricReactivated)
+			assert.True(t, ok, "payload should be of type domain.FabricReactivated")
+		})
+	}
+}
+
+func TestFabricService_ReactivateFabric_Errors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      string
+		version     int
+		expectedErr error
+	}{
+		{name: "Active", status: domain.StatusActive, version: 3, expectedErr: domain.ErrInvalidStatusTransition},
+		{name: "Draft", status: domain.StatusDraft, version: 3, expectedErr: domain.ErrInvalidStatusTransition},
+		{name: "Archived", status: domain.StatusArchived, version: 3, expectedErr: domain.ErrInvalidStatusTransition},
+		{name: "Stale version", status: domain.StatusDiscontinued, version: 2, expectedErr: domain.ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			stored := &domain.Fabric{
+				Code: "SEASON01", Name: "Old Name", MeasureUnit: domain.MeasureUnitRunningMetre,
+				OfferStatus: domain.OfferStatusActive, Status: tc.status, Version: 3,
+			}
+			commandRepo := &mockFabricCommandRepository{fabric: stored}
+			eventStore := &mockEventStore{}
+			service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			_, err := service.ReactivateFabric(context.Background(), "SEASON01", "", "", "", nil, tc.version)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.False(t, commandRepo.Reactivated)
+			assert.False(t, eventStore.SavedCalled)
+		})
+	}
+}
+
+func TestFabricService_RecordsActorOnCommands(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ctx           context.Context
+		expectedActor string
+	}{
+		{
+			name:          "Authenticated REST user",
+			ctx:           command.WithUserID(context.Background(), "user_123"),
+			expectedActor: "user_123",
+		},
+		{
+			name:          "ERP event",
+			ctx:           command.WithCommandSource(context.Background(), command.CommandSourceEvent),
+			expectedActor: command.ActorERP,
+		},
+		{
+			name:          "No principal",
+			ctx:           context.Background(),
+			expectedActor: command.ActorAnonymous,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			commandRepo := &mockFabricCommandRepository{}
+			eventStore := &mockEventStore{}
+			service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			created, err := service.CreateFabric(tc.ctx, "AUDIT01", "Audited Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{})
+			require.NoError(t, err)
+			updated, err := service.UpdateFabric(tc.ctx, "AUDIT01", "Audited Again", "m", "available", nil, domain.FabricTexts{}, created.Version)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedActor, updated.CreatedBy)
+			assert.Equal(t, tc.expectedActor, updated.UpdatedBy)
+			assert.Equal(t, testStamp.At, updated.CreatedAt)
+			assert.Equal(t, testStamp.At, updated.UpdatedAt)
+			require.NotNil(t, eventStore.SavedEnvelope)
+			assert.Equal(t, tc.expectedActor, eventStore.SavedEnvelope.UserID, "the actor is recorded on the event")
+		})
+	}
+}
+
+func TestFabricService_MergeFabric_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	duplicate, err := domain.NewFabric("DUPE01", "Duplicate", "mb", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	canonical, err := domain.NewFabric("CANON01", "Canonical", "mb", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	commandRepo := &mockFabricCommandRepository{fabric: duplicate, others: []*domain.Fabric{canonical}}
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+	ctx := command.WithCommandSource(context.Background(), command.CommandSourceREST)
+
+	// --- Act ---
+	err = service.MergeFabric(ctx, "DUPE01", "CANON01", 1)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, "CANON01", commandRepo.MergedInto)
+	assert.Equal(t, domain.StatusMerged, commandRepo.fabric.Status)
+	assert.True(t, eventStore.SavedCalled)
+	require.NotNil(t, eventStore.EnqueuedEnvelope)
+	assert.Equal(t, "app.fabric.merged", eventStore.EnqueuedEnvelope.EventType)
+	assert.Equal(t, "DUPE01", eventStore.EnqueuedEnvelope.AggregateID)
+	assert.Equal(t, 2, eventStore.EnqueuedEnvelope.AggregateVersion)
+}
+
+func TestFabricService_MergeFabric_Errors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        string
+		into        string
+		version     int
+		expectedErr error
+	}{
+		{name: "Unknown duplicate", code: "NOPE01", into: "CANON01", version: 1, expectedErr: domain.ErrRecordNotFound},
+		{name: "Unknown canonical", code: "DUPE01", into: "NOPE01", version: 1, expectedErr: domain.ErrRecordNotFound},
+		{name: "Merge into itself", code: "DUPE01", into: "DUPE01", version: 1, expectedErr: domain.ErrInvalidMergeTarget},
+		{name: "Stale version", code: "DUPE01", into: "CANON01", version: 3, expectedErr: domain.ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			duplicate, err := domain.NewFabric("DUPE01", "Duplicate", "mb", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			canonical, err := domain.NewFabric("CANON01", "Canonical", "mb", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			commandRepo := &mockFabricCommandRepository{fabric: duplicate, others: []*domain.Fabric{canonical}}
+			eventStore := &mockEventStore{}
+			service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err = service.MergeFabric(context.Background(), tc.code, tc.into, tc.version)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Empty(t, commandRepo.MergedInto)
+			assert.False(t, eventStore.SavedCalled)
+		})
+	}
+}
+
+func TestFabricService_RenameFabric_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := domain.NewFabric("OLD01", "Linen", "mb", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	commandRepo := &mockFabricCommandRepository{fabric: fabric}
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+	ctx := command.WithCommandSource(context.Background(), command.CommandSourceREST)
+
+	// --- Act ---
+	renamed, err := service.RenameFabric(ctx, "OLD01", "NEW01", 1)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, "NEW01", renamed.Code)
+	assert.Equal(t, 2, renamed.Version)
+	assert.Equal(t, "OLD01", commandRepo.RenamedFrom)
+	require.NotNil(t, eventStore.EnqueuedEnvelope)
+	assert.Equal(t, "app.fabric.code_changed", eventStore.EnqueuedEnvelope.EventType)
+	assert.Equal(t, "NEW01", eventStore.EnqueuedEnvelope.AggregateID)
+	event, ok := eventStore.EnqueuedEnvelope.Payload.(domain.FabricCodeChanged)
+	require.True(t, ok)
+	assert.Equal(t, "OLD01", event.PreviousCode)
+}
+
+func TestFabricService_RenameFabric_Errors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        string
+		newCode     string
+		version     int
+		expectedErr error
+	}{
+		{name: "Unknown fabric", code: "NOPE01", newCode: "NEW01", version: 1, expectedErr: domain.ErrRecordNotFound},
+		{name: "Same code", code: "OLD01", newCode: "OLD01", version: 1, expectedErr: domain.ErrUnchangedFabricCode},
+		{name: "Invalid code", code: "OLD01", newCode: "new-01", version: 1, expectedErr: domain.ErrInvalidFabricCodePattern},
+		{name: "Code taken", code: "OLD01", newCode: "TAKEN01", version: 1, expectedErr: domain.ErrDuplicateFabricCode},
+		{name: "Stale version", code: "OLD01", newCode: "NEW01", version: 3, expectedErr: domain.ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fabric, err := domain.NewFabric("OLD01", "Linen", "mb", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			taken, err := domain.NewFabric("TAKEN01", "Wool", "mb", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			commandRepo := &mockFabricCommandRepository{fabric: fabric, others: []*domain.Fabric{taken}}
+			eventStore := &mockEventStore{}
+			service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			_, err = service.RenameFabric(context.Background(), tc.code, tc.newCode, tc.version)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Empty(t, commandRepo.RenamedFrom)
+			assert.False(t, eventStore.SavedCalled)
+		})
+	}
+}
+
+// racingFabricRepository loses the given number of update races to a concurrent writer
+type racingFabricRepository struct {
+	*mockFabricCommandRepository
+	races   int
+	updates int
+}
+
+func (m *racingFabricRepository) Update(ctx context.Context, fabric *domain.Fabric) error {
+	m.updates++
+	if m.races > 0 {
+		m.races--
+		m.fabric.Version++
+		return domain.ErrConcurrencyConflict
+	}
+	return m.mockFabricCommandRepository.Update(ctx, fabric)
+}
+
+func TestFabricService_SyncFabric(t *testing.T) {
+	testCases := []struct {
+		name            string
+		source          command.CommandSource
+		storedName      string
+		races           int
+		expectedErr     error
+		expectedChanged bool
+		expectedUpdates int
+		expectedVersion int
+	}{
+		{
+			name: "same data is left untouched", source: command.CommandSourceEvent, storedName: "Linen",
+			expectedUpdates: 0, expectedVersion: 1,
+		},
+		{
+			name: "changed data is applied", source: command.CommandSourceEvent, storedName: "Old Linen",
+			expectedChanged: true, expectedUpdates: 1, expectedVersion: 2,
+		},
+		{
+			name: "lost races are reloaded and reapplied", source: command.CommandSourceEvent, storedName: "Old Linen",
+			races: 2, expectedChanged: true, expectedUpdates: 3, expectedVersion: 4,
+		},
+		{
+			name: "attempts are bounded", source: command.CommandSourceEvent, storedName: "Old Linen",
+			races: 3, expectedErr: domain.ErrConcurrencyConflict, expectedUpdates: 3,
+		},
+		{
+			name: "REST commands are not retried", source: command.CommandSourceREST, storedName: "Old Linen",
+			races: 1, expectedErr: domain.ErrConcurrencyConflict, expectedUpdates: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			stored, err := domain.NewFabric("FAB01", tc.storedName, "MB", "ACTIVE", domain.Specification{}, domain.FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			repo := &racingFabricRepository{
+				mockFabricCommandRepository: &mockFabricCommandRepository{fabric: stored},
+				races:                       tc.races,
+			}
+			service := NewFabricCommandService(repo, &mockFibreRepository{}, noQuota, &mockEventStore{}, clock.NewFixed(testStamp.At), testSource)
+			ctx := command.WithCommandSource(context.Background(), tc.source)
+
+			// --- Act ---
+			fabric, changed, err := service.SyncFabric(ctx, "FAB01", "Linen", "mb", "active")
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedUpdates, repo.updates)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChanged, changed)
+			assert.Equal(t, tc.expectedVersion, fabric.Version)
+			assert.Equal(t, "Linen", fabric.Name)
+		})
+	}
+}
+
+func TestFabricService_ChangePrice_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	commandRepo := &mockFabricCommandRepository{}
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	fabric, err := domain.NewFabric("PRICED01", "Priced", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	commandRepo.fabric = fabric
+
+	// --- Act ---
+	priced, err := service.ChangePrice(context.Background(), "PRICED01", "24.90", "PLN", time.Time{}, 1)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.True(t, commandRepo.UpdateCalled, "expected Update() to be called on the repository")
+	require.NotNil(t, priced.Price)
+	assert.Equal(t, int64(2490), priced.Price.Amount)
+	assert.Equal(t, testStamp.At, priced.Price.ValidFrom, "a missing valid_from should default to now")
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.fabric.price_changed", publishedEnvelope.EventType)
+	_, ok := publishedEnvelope.Payload.(domain.FabricPriceChanged)
+	require.True(t, ok, "payload should be of type domain.FabricPriceChanged")
+}
+
+func TestFabricService_SetTranslation(t *testing.T) {
+	// --- Arrange ---
+	commandRepo := &mockFabricCommandRepository{}
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	fabric, err := domain.NewFabric("VELVET01", "Velvet", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	commandRepo.fabric = fabric
+
+	// --- Act ---
+	translated, err := service.SetTranslation(
+		context.Background(), "VELVET01", "pt-br", domain.Translation{Name: "Veludo"}, 1,
+	)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.True(t, commandRepo.UpdateCalled, "expected Update() to be called on the repository")
+	assert.Equal(t, domain.Translation{Name: "Veludo"}, translated.Translations["pt-BR"])
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.fabric.translation_set", publishedEnvelope.EventType)
+	event, ok := publishedEnvelope.Payload.(domain.FabricTranslationSet)
+	require.True(t, ok, "payload should be of type domain.FabricTranslationSet")
+	assert.Equal(t, "pt-BR", event.Locale)
+}
+
+func TestFabricService_SetCustomAttributes(t *testing.T) {
+	// --- Arrange ---
+	commandRepo := &mockFabricCommandRepository{}
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	fabric, err := domain.NewFabric("VELVET01", "Velvet", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	commandRepo.fabric = fabric
+	definitions := []*domain.AttributeDefinition{{Key: "gots_certified", Type: domain.AttributeTypeBoolean}}
+
+	// --- Act ---
+	updated, err := service.SetCustomAttributes(
+		context.Background(), "VELVET01", map[string]any{"gots_certified": true}, definitions, 1,
+	)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.True(t, commandRepo.UpdateCalled, "expected Update() to be called on the repository")
+	assert.Equal(t, map[string]any{"gots_certified": true}, updated.CustomAttributes)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.fabric.custom_attributes_changed", publishedEnvelope.EventType)
+	_, ok := publishedEnvelope.Payload.(domain.FabricCustomAttributesChanged)
+	require.True(t, ok, "payload should be of type domain.FabricCustomAttributesChanged")
+}
+
+func TestFabricService_CreateDraftFabric(t *testing.T) {
+	// --- Arrange ---
+	commandRepo := &mockFabricCommandRepository{}
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+	ctx := command.WithCommandSource(context.Background(), command.CommandSourceREST)
+
+	// --- Act ---
+	fabric, err := service.CreateDraftFabric(ctx, "TESTCODE", "Test Fabric", "mb", "new", domain.Specification{}, domain.FabricTexts{})
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusDraft, fabric.Status)
+	require.NotNil(t, eventStore.EnqueuedEnvelope)
+	assert.Equal(t, "app.fabric.created", eventStore.EnqueuedEnvelope.EventType)
+	payload, ok := eventStore.EnqueuedEnvelope.Payload.(domain.FabricCreated)
+	require.True(t, ok, "payload should be of type domain.FabricCreated")
+	assert.Equal(t, domain.StatusDraft, payload.Status)
+}
+
+func TestFabricService_Lifecycle(t *testing.T) {
+	testCases := []struct {
+		name              string
+		status            string
+		transition        func(s *FabricService, ctx context.Context, code string, version int) (*domain.Fabric, error)
+		expectedStatus    string
+		expectedEventType string
+	}{
+		{
+			name: "Activate a draft", status: domain.StatusDraft, transition: (*FabricService).ActivateFabric,
+			expectedStatus: domain.StatusActive, expectedEventType: "app.fabric.activated",
+		},
+		{
+			name: "Discontinue", status: domain.StatusActive, transition: (*FabricService).DiscontinueFabric,
+			expectedStatus: domain.StatusDiscontinued, expectedEventType: "app.fabric.discontinued",
+		},
+		{
+			name: "Archive", status: domain.StatusDiscontinued, transition: (*FabricService).ArchiveFabric,
+			expectedStatus: domain.StatusArchived, expectedEventType: "app.fabric.archived",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			stored := &domain.Fabric{Code: "TESTCODE", Name: "Test Fabric", Status: tc.status, Version: 4}
+			commandRepo := &mockFabricCommandRepository{fabric: stored}
+			eventStore := &mockEventStore{}
+			service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+			ctx := command.WithCommandSource(context.Background(), command.CommandSourceREST)
+
+			// --- Act ---
+			fabric, err := tc.transition(service, ctx, "TESTCODE", 4)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.True(t, commandRepo.StatusChanged)
+			assert.Equal(t, tc.expectedStatus, fabric.Status)
+			assert.Equal(t, 5, fabric.Version)
+			require.NotNil(t, eventStore.EnqueuedEnvelope)
+			assert.Equal(t, tc.expectedEventType, eventStore.EnqueuedEnvelope.EventType)
+			assert.Equal(t, 5, eventStore.EnqueuedEnvelope.AggregateVersion)
+		})
+	}
+}
+
+func TestFabricService_Lifecycle_InvalidTransition(t *testing.T) {
+	// --- Arrange ---
+	stored := &domain.Fabric{Code: "TESTCODE", Status: domain.StatusDraft, Version: 1}
+	commandRepo := &mockFabricCommandRepository{fabric: stored}
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	_, err := service.ArchiveFabric(context.Background(), "TESTCODE", 1)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	assert.False(t, commandRepo.StatusChanged)
+	assert.False(t, eventStore.SavedCalled)
+}
+
+func TestFabricService_RefusesReservedProbeCode(t *testing.T) {
+	commands := []struct {
+		name string
+		run  func(s *FabricService, ctx context.Context) error
+	}{
+		{name: "Update", run: func(s *FabricService, ctx context.Context) error {
+			_, err := s.UpdateFabric(ctx, "ZZPROBE01", "Renamed", "mb", "available", nil, domain.FabricTexts{}, 1)
+			return err
+		}},
+		{name: "Delete", run: func(s *FabricService, ctx context.Context) error {
+			return s.DeleteFabric(ctx, "ZZPROBE01", 1)
+		}},
+		{name: "Discontinue", run: func(s *FabricService, ctx context.Context) error {
+			_, err := s.DiscontinueFabric(ctx, "ZZPROBE01", 1)
+			return err
+		}},
+		{name: "Change price", run: func(s *FabricService, ctx context.Context) error {
+			_, err := s.ChangePrice(ctx, "ZZPROBE01", "12.50", "EUR", testStamp.At, 1)
+			return err
+		}},
+		{name: "Merge the probe fabric", run: func(s *FabricService, ctx context.Context) error {
+			return s.MergeFabric(ctx, "ZZPROBE01", "CANON01", 1)
+		}},
+		{name: "Merge into the probe fabric", run: func(s *FabricService, ctx context.Context) error {
+			return s.MergeFabric(ctx, "CANON01", "ZZPROBE01", 1)
+		}},
+	}
+
+	for _, tc := range commands {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			probe, err := domain.NewFabric("ZZPROBE01", "Synthetic probe", "pcs", "prototyp", domain.Specification{}, domain.FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			canonical, err := domain.NewFabric("CANON01", "Canonical", "mb", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			commandRepo := &mockFabricCommandRepository{fabric: probe, others: []*domain.Fabric{canonical}}
+			eventStore := &mockEventStore{}
+			service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+			// a principal claiming the probe actor is still a client of the API
+			ctx := command.WithUserID(context.Background(), command.ActorSyntheticProbe)
+
+			// --- Act ---
+			err = tc.run(service, ctx)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, domain.ErrReservedFabricCode)
+			assert.False(t, commandRepo.UpdateCalled || commandRepo.DeleteCalled || commandRepo.StatusChanged)
+			assert.Empty(t, commandRepo.MergedInto)
+			assert.False(t, eventStore.SavedCalled)
+		})
+	}
+}
+
+func TestFabricService_ProbeCommandsOnReservedCode(t *testing.T) {
+	// --- Arrange ---
+	commandRepo := &mockFabricCommandRepository{}
+	eventStore := &mockEventStore{}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+	ctx := command.WithSyntheticProbe(context.Background())
+
+	// --- Act ---
+	created, createErr := service.CreateFabric(ctx, "ZZPROBE01", "Synthetic probe", "pcs", "prototyp", domain.Specification{}, domain.FabricTexts{})
+	deleteErr := service.DeleteFabric(ctx, "ZZPROBE01", 1)
+
+	// --- Assert ---
+	require.NoError(t, createErr)
+	assert.Equal(t, command.ActorSyntheticProbe, created.CreatedBy)
+	require.NoError(t, deleteErr)
+	assert.True(t, commandRepo.DeleteCalled)
+}
@@ -0,0 +1,248 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// maximum number of fabrics whose reservations are expired per round trip of a sweep
+const reservationExpiryBatchSize = 100
+
+// FabricStockService moves the stock of fabrics. Stock is its own aggregate, published on
+// a subject of its own, so consumers of catalog changes are not flooded with movements.
+type FabricStockService struct {
+	stockRepo    domain.FabricStockRepository
+	eventStore   eventstore.Store
+	clock        clock.Clock
+	eventChannel string
+	source       messaging.Source
+	logger       *slog.Logger
+}
+
+func NewFabricStockService(
+	stockRepo domain.FabricStockRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+	logger *slog.Logger,
+) *FabricStockService {
+	return &FabricStockService{
+		stockRepo:    stockRepo,
+		eventStore:   eventStore,
+		clock:        clock,
+		eventChannel: "app.fabric.stock",
+		source:       source,
+		logger:       logger.With("component", "fabric.stock.service"),
+	}
+}
+
+func (s *FabricStockService) GetStock(ctx context.Context, code string) (*domain.FabricStock, error) {
+	return s.stockRepo.GetStock(ctx, code)
+}
+
+// AdjustStock changes the quantity on hand by a signed decimal quantity such as "-2.5",
+// attributed to the warehouse unless it is empty.
+func (s *FabricStockService) AdjustStock(
+	ctx context.Context, code, quantity, reason, warehouse string, version int,
+) (*domain.FabricStock, error) {
+	if warehouse != "" {
+		if err := s.stockRepo.CheckWarehouse(ctx, warehouse); err != nil {
+			return nil, err
+		}
+	}
+	return s.move(ctx, "fabric.stock.service.adjust", code, quantity,
+		func(stock *domain.FabricStock, qty domain.Quantity) error {
+			return stock.Adjust(qty, reason, warehouse, version, aggregate.StampNow(ctx, s.clock))
+		})
+}
+
+// ReserveStock sets a decimal quantity of the available stock aside for the reference,
+// until expiresAt unless it is nil.
+func (s *FabricStockService) ReserveStock(
+	ctx context.Context, code, quantity, reference string, expiresAt *time.Time, version int,
+) (*domain.FabricStock, error) {
+	return s.move(ctx, "fabric.stock.service.reserve", code, quantity,
+		func(stock *domain.FabricStock, qty domain.Quantity) error {
+			return stock.Reserve(qty, reference, expiresAt, version, aggregate.StampNow(ctx, s.clock))
+		})
+}
+
+// ReleaseStock gives a decimal quantity reserved for the reference back to the available stock.
+func (s *FabricStockService) ReleaseStock(
+	ctx context.Context, code, quantity, reference string, version int,
+) (*domain.FabricStock, error) {
+	return s.move(ctx, "fabric.stock.service.release", code, quantity,
+		func(stock *domain.FabricStock, qty domain.Quantity) error {
+			return stock.Release(qty, reference, version, aggregate.StampNow(ctx, s.clock))
+		})
+}
+
+// move loads the stock, applies the movement to it and stores it together with the
+// events it recorded.
+func (s *FabricStockService) move(
+	ctx context.Context, spanName, code, quantity string,
+	apply func(stock *domain.FabricStock, qty domain.Quantity) error,
+) (*domain.FabricStock, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, spanName)
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "fabric.stock.service")
+
+	qty, err := domain.ParseQuantity(quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := s.stockRepo.GetStock(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(stock, qty); err != nil {
+		return nil, err
+	}
+
+	if err := s.stockRepo.SaveStock(ctx, stock); err != nil {
+		wrappedErr := fmt.Errorf("failed to save fabric stock in repo: %w", err)
+		logger.Error("saving fabric stock failed", "error", wrappedErr)
+		span.RecordError(wrappedErr)
+		span.SetStatus(codes.Error, "database write error")
+		return nil, wrappedErr
+	}
+
+	if err := s.publish(ctx, stock); err != nil {
+		logger.Error("saving stock event failed", "error", err)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+// Run gives expired reservations back to the available stock every interval until ctx
+// is done.
+func (s *FabricStockService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx = command.WithUserID(ctx, command.ActorScheduler)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ExpireReservations(ctx); err != nil {
+				s.logger.Error("failed to expire fabric stock reservations", "error", err)
+			}
+		}
+	}
+}
+
+// ExpireReservations gives every reservation whose expiry has passed back to the
+// available stock, returning how many expired. A stock another instance or a request
+// changed in the meantime is skipped, its reservations expire on the next sweep.
+func (s *FabricStockService) ExpireReservations(ctx context.Context) (int, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.stock.service.expire")
+	defer span.End()
+
+	now := s.clock.Now()
+	expired := 0
+	for {
+		due, err := s.stockRepo.DueForReservationExpiry(ctx, now, reservationExpiryBatchSize)
+		if err != nil {
+			span.RecordError(err)
+			return expired, fmt.Errorf("failed to find fabric stock reservations due for expiry: %w", err)
+		}
+
+		progressed := false
+		for _, code := range due {
+			stock, err := s.stockRepo.GetStock(ctx, code)
+			if err != nil {
+				if errors.Is(err, domain.ErrRecordNotFound) {
+					continue
+				}
+				span.RecordError(err)
+				return expired, err
+			}
+			if !stock.ExpireReservations(now, aggregate.StampNow(ctx, s.clock)) {
+				continue
+			}
+			if err := s.stockRepo.SaveStock(ctx, stock); err != nil {
+				if errors.Is(err, domain.ErrConcurrencyConflict) {
+					continue
+				}
+				span.RecordError(err)
+				return expired, fmt.Errorf("failed to save fabric stock in repo: %w", err)
+			}
+			if err := s.publish(ctx, stock); err != nil {
+				span.RecordError(err)
+				return expired, err
+			}
+			progressed = true
+			for _, event := range stock.Events() {
+				if expiry, ok := event.(domain.FabricStockReservationExpired); ok {
+					expired++
+					s.logger.Info(
+						"fabric stock reservation expired",
+						"code", expiry.Code, "reference", expiry.Reference, "quantity", expiry.Quantity.String(),
+					)
+				}
+			}
+		}
+
+		// skipped stocks are still due, they must not keep the sweep going
+		if len(due) < reservationExpiryBatchSize || !progressed {
+			return expired, nil
+		}
+	}
+}
+
+// publish stores the events the stock recorded, to be published from the outbox.
+func (s *FabricStockService) publish(ctx context.Context, stock *domain.FabricStock) error {
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range stock.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.FabricStockAdjusted:
+			eventType = "app.fabric.stock_adjusted"
+		case domain.FabricStockReserved:
+			eventType = "app.fabric.stock_reserved"
+		case domain.FabricStockReleased:
+			eventType = "app.fabric.stock_released"
+		case domain.FabricStockReservationExpired:
+			eventType = "app.fabric.stock_reservation_expired"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			stock.Code,
+			"FabricStock",
+			stock.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopesToPublish...); err != nil {
+			return fmt.Errorf("failed to save stock event to event store: %w", err)
+		}
+	}
+	return nil
+}
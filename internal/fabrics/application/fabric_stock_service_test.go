@@ -0,0 +1,191 @@
+package application
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricStockRepository struct {
+	stock       *domain.FabricStock
+	saved       *domain.FabricStock
+	warehouses  map[string]bool
+	due         []string
+	errToReturn error
+}
+
+func (m *mockFabricStockRepository) GetStock(ctx context.Context, code string) (*domain.FabricStock, error) {
+	if m.stock == nil || m.stock.Code != code {
+		return nil, domain.ErrRecordNotFound
+	}
+	stockCopy := *m.stock
+	return &stockCopy, nil
+}
+
+func (m *mockFabricStockRepository) SaveStock(ctx context.Context, stock *domain.FabricStock) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.saved = stock
+	return nil
+}
+
+func (m *mockFabricStockRepository) CheckWarehouse(ctx context.Context, code string) error {
+	if !m.warehouses[code] {
+		return domain.ErrUnknownWarehouse
+	}
+	return nil
+}
+
+func (m *mockFabricStockRepository) DueForReservationExpiry(
+	ctx context.Context, at time.Time, limit int,
+) ([]string, error) {
+	due := m.due
+	m.due = nil
+	return due, nil
+}
+
+func newTestStockService(stockRepo *mockFabricStockRepository, eventStore eventstore.Store) *FabricStockService {
+	return NewFabricStockService(
+		stockRepo, eventStore, clock.NewFixed(testStamp.At), testSource, slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+}
+
+func TestFabricStockService_ReserveStock_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	stockRepo := &mockFabricStockRepository{
+		stock: &domain.FabricStock{Code: "STOCK01", OnHand: 10000, Version: 1},
+	}
+	eventStore := &mockEventStore{}
+	service := newTestStockService(stockRepo, eventStore)
+
+	// --- Act ---
+	stock, err := service.ReserveStock(context.Background(), "STOCK01", "2.5", "ORDER-1", nil, 1)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, stockRepo.saved, "expected SaveStock() to be called on the repository")
+	assert.Equal(t, domain.Quantity(2500), stock.Reserved)
+	assert.Equal(t, 2, stock.Version)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.fabric.stock", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.fabric.stock_reserved", publishedEnvelope.EventType)
+	assert.Equal(t, "FabricStock", publishedEnvelope.AggregateType)
+	assert.Equal(t, 2, publishedEnvelope.AggregateVersion)
+	_, ok := publishedEnvelope.Payload.(domain.FabricStockReserved)
+	require.True(t, ok, "payload should be of type domain.FabricStockReserved")
+}
+
+func TestFabricStockService_AdjustStock_RejectedIsNotPublished(t *testing.T) {
+	// --- Arrange ---
+	stockRepo := &mockFabricStockRepository{stock: domain.NewFabricStock("STOCK01")}
+	eventStore := &mockEventStore{}
+	service := newTestStockService(stockRepo, eventStore)
+
+	// --- Act ---
+	_, err := service.AdjustStock(context.Background(), "STOCK01", "-1", "write-off", "", 0)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	assert.Nil(t, stockRepo.saved)
+	assert.False(t, eventStore.SavedCalled, "a rejected movement must not be stored")
+}
+
+func TestFabricStockService_AdjustStock_AtWarehouse(t *testing.T) {
+	testCases := []struct {
+		name        string
+		warehouse   string
+		expectedErr error
+	}{
+		{name: "Known warehouse", warehouse: "WH-LDZ"},
+		{name: "Unknown warehouse", warehouse: "WH-NOSUCH", expectedErr: domain.ErrUnknownWarehouse},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			stockRepo := &mockFabricStockRepository{
+				stock:      domain.NewFabricStock("STOCK01"),
+				warehouses: map[string]bool{"WH-LDZ": true},
+			}
+			eventStore := &mockEventStore{}
+			service := newTestStockService(stockRepo, eventStore)
+
+			// --- Act ---
+			stock, err := service.AdjustStock(context.Background(), "STOCK01", "12.5", "goods receipt", tc.warehouse, 0)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, stockRepo.saved)
+				assert.False(t, eventStore.SavedCalled, "a rejected movement must not be stored")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]domain.Quantity{"WH-LDZ": 12500}, stock.Locations)
+			adjusted, ok := eventStore.EnqueuedEnvelope.Payload.(domain.FabricStockAdjusted)
+			require.True(t, ok, "payload should be of type domain.FabricStockAdjusted")
+			assert.Equal(t, "WH-LDZ", adjusted.Warehouse)
+		})
+	}
+}
+
+func TestFabricStockService_ExpireReservations(t *testing.T) {
+	passed, later := testStamp.At.Add(-time.Minute), testStamp.At.Add(time.Hour)
+	newStock := func() *domain.FabricStock {
+		return &domain.FabricStock{
+			Code: "STOCK01", OnHand: 10000, Reserved: 3000, Version: 4,
+			Reservations: map[string]domain.StockReservation{
+				"QUOTE-1": {Reference: "QUOTE-1", Quantity: 1000, ExpiresAt: &passed},
+				"QUOTE-2": {Reference: "QUOTE-2", Quantity: 2000, ExpiresAt: &later},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		errToReturn     error
+		expectedExpired int
+	}{
+		{name: "Expired", expectedExpired: 1},
+		{name: "Changed in the meantime", errToReturn: domain.ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			stockRepo := &mockFabricStockRepository{stock: newStock(), due: []string{"STOCK01"}, errToReturn: tc.errToReturn}
+			eventStore := &recordingEventStore{}
+			service := newTestStockService(stockRepo, eventStore)
+
+			// --- Act ---
+			expired, err := service.ExpireReservations(context.Background())
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedExpired, expired)
+			if tc.expectedExpired == 0 {
+				assert.Empty(t, eventStore.enqueued, "an expiry that was not stored must not be announced")
+				return
+			}
+			require.NotNil(t, stockRepo.saved)
+			assert.Equal(t, domain.Quantity(2000), stockRepo.saved.Reserved)
+			assert.Equal(t, 5, stockRepo.saved.Version)
+			require.Len(t, eventStore.enqueued, 1)
+			assert.Equal(t, "app.fabric.stock_reservation_expired", eventStore.enqueued[0].EventType)
+			expiry, ok := eventStore.enqueued[0].Payload.(domain.FabricStockReservationExpired)
+			require.True(t, ok, "payload should be of type domain.FabricStockReservationExpired")
+			assert.Equal(t, "QUOTE-1", expiry.Reference)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+)
+
+// ValidateCreate runs every check of CreateFabric without persisting anything: the domain
+// rules and that the code is not taken by another fabric or an alias. A deleted code is
+// free, creating it again reactivates the fabric.
+func (s *FabricService) ValidateCreate(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification,
+) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.validate_create")
+	defer span.End()
+
+	if _, err := domain.NewFabric(code, name, measureUnit, offerStatus, spec, domain.FabricTexts{}, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return err
+	}
+	if err := s.checkSpecification(ctx, spec); err != nil {
+		return err
+	}
+
+	_, err := s.commandRepo.GetByCode(ctx, code)
+	switch {
+	case err == nil:
+		return domain.ErrDuplicateFabricCode
+	case errors.Is(err, domain.ErrRecordNotFound):
+		return nil
+	default:
+		span.RecordError(err)
+		return err
+	}
+}
+
+// ValidateUpdate runs every check of UpdateFabric on the stored fabric without persisting
+// the change.
+func (s *FabricService) ValidateUpdate(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification, version int,
+) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.validate_update")
+	defer span.End()
+
+	fabric, err := s.commandRepo.GetByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if err := fabric.UpdateFabric(name, measureUnit, offerStatus, spec, domain.FabricTexts{}, version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return err
+	}
+	if spec == nil {
+		return nil
+	}
+	return s.checkSpecification(ctx, *spec)
+}
+
+// ValidateDelete runs every check of DeleteFabric on the stored fabric without deleting it.
+func (s *FabricService) ValidateDelete(ctx context.Context, code string, version int) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "fabric.service.validate_delete")
+	defer span.End()
+
+	fabric, err := s.commandRepo.GetByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	return fabric.Delete(version, aggregate.StampNow(ctx, s.clock))
+}
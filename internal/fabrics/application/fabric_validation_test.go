@@ -0,0 +1,114 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabricService_ValidateCreate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		stored      *domain.Fabric
+		repoErr     error
+		code        string
+		fabricName  string
+		expectedErr error
+	}{
+		{name: "New code", code: "NEWCODE", fabricName: "Linen"},
+		{
+			name:   "Code taken",
+			stored: &domain.Fabric{Code: "TAKEN", Status: domain.StatusActive, Version: 1},
+			code:   "TAKEN", fabricName: "Linen", expectedErr: domain.ErrDuplicateFabricCode,
+		},
+		{
+			name:   "Deleted code is free",
+			stored: &domain.Fabric{Code: "GONE", Status: domain.StatusDeleted, Version: 2},
+			code:   "GONE", fabricName: "Linen",
+		},
+		{name: "Domain rule", code: "NEWCODE", fabricName: "", expectedErr: domain.ErrInvalidFabricNameLength},
+		{name: "Repository failure", repoErr: errors.New("connection reset"), code: "NEWCODE", fabricName: "Linen"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			commandRepo := &mockFabricCommandRepository{fabric: tc.stored, errToReturn: tc.repoErr}
+			eventStore := &mockEventStore{}
+			service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err := service.ValidateCreate(context.Background(), tc.code, tc.fabricName, "mb", "new", domain.Specification{})
+
+			// --- Assert ---
+			switch {
+			case tc.repoErr != nil:
+				assert.ErrorIs(t, err, tc.repoErr)
+			case tc.expectedErr != nil:
+				assert.ErrorIs(t, err, tc.expectedErr)
+			default:
+				assert.NoError(t, err)
+			}
+			assert.False(t, commandRepo.SavedCalled, "validation persists nothing")
+			assert.False(t, eventStore.SavedCalled, "validation records no events")
+		})
+	}
+}
+
+func TestFabricService_ValidateUpdate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        string
+		status      string
+		version     int
+		expectedErr error
+	}{
+		{name: "Valid update", code: "TESTCODE", status: domain.StatusActive, version: 3},
+		{name: "Stale version", code: "TESTCODE", status: domain.StatusActive, version: 2, expectedErr: domain.ErrConcurrencyConflict},
+		{name: "Archived fabric", code: "TESTCODE", status: domain.StatusArchived, version: 3, expectedErr: domain.ErrFabricArchived},
+		{name: "Unknown fabric", code: "OTHER", status: domain.StatusActive, version: 3, expectedErr: domain.ErrRecordNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			stored := &domain.Fabric{Code: "TESTCODE", Name: "Linen", Status: tc.status, Version: 3}
+			commandRepo := &mockFabricCommandRepository{fabric: stored}
+			service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, &mockEventStore{}, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err := service.ValidateUpdate(context.Background(), tc.code, "Washed Linen", "mb", "new", nil, tc.version)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.False(t, commandRepo.UpdateCalled, "validation persists nothing")
+			assert.Equal(t, "Linen", stored.Name, "the stored fabric is left untouched")
+			assert.Equal(t, 3, stored.Version)
+		})
+	}
+}
+
+func TestFabricService_ValidateDelete(t *testing.T) {
+	// --- Arrange ---
+	stored := &domain.Fabric{Code: "TESTCODE", Status: domain.StatusActive, Version: 3}
+	commandRepo := &mockFabricCommandRepository{fabric: stored}
+	service := NewFabricCommandService(commandRepo, &mockFibreRepository{}, noQuota, &mockEventStore{}, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	validErr := service.ValidateDelete(context.Background(), "TESTCODE", 3)
+	staleErr := service.ValidateDelete(context.Background(), "TESTCODE", 1)
+
+	// --- Assert ---
+	assert.NoError(t, validErr)
+	assert.ErrorIs(t, staleErr, domain.ErrConcurrencyConflict)
+	assert.False(t, commandRepo.DeleteCalled, "validation persists nothing")
+	assert.Equal(t, domain.StatusActive, stored.Status)
+}
@@ -0,0 +1,42 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+)
+
+const (
+	// attempts made by an event-sourced command that keeps losing optimistic concurrency races
+	conflictRetryAttempts = 3
+
+	// pause before the first retry, doubled before every following one
+	conflictRetryBackoff = 20 * time.Millisecond
+)
+
+// retryOnConflict runs fn and, when it fails with a concurrency conflict, runs it again up
+// to conflictRetryAttempts times in total. fn must reload the aggregate and reapply the
+// command on every call, so it is only suitable for idempotent commands. Only commands
+// sourced from events are retried; REST clients get the conflict and decide themselves.
+func retryOnConflict(ctx context.Context, fn func() error) error {
+	backoff := conflictRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if !errors.Is(err, domain.ErrConcurrencyConflict) || !command.IsFromEvent(ctx) || attempt == conflictRetryAttempts {
+			return err
+		}
+
+		httpx.GetLogger(ctx).Warn("concurrency conflict, reloading and retrying",
+			"attempt", attempt, "maxAttempts", conflictRetryAttempts)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,214 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// the steps of a probe cycle, reported on its metrics
+const (
+	probeStepCreate   = "create"
+	probeStepUpdate   = "update"
+	probeStepDelete   = "delete"
+	probeStepDelivery = "delivery"
+	probeStepTotal    = "total"
+)
+
+var ErrProbeDeliveryTimeout = errors.New("the deleted event of the probe fabric was not delivered in time")
+
+// ProbeTarget is the write path exercised by the synthetic probe.
+type ProbeTarget interface {
+	CreateFabric(
+		ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification, texts domain.FabricTexts,
+	) (*domain.Fabric, error)
+	UpdateFabric(
+		ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification,
+		texts domain.FabricTexts, version int,
+	) (*domain.Fabric, error)
+	DeleteFabric(ctx context.Context, code string, version int) error
+	GetByCode(ctx context.Context, code string) (*domain.Fabric, error)
+}
+
+// SyntheticProbe measures the write path end to end. Every cycle creates, updates and
+// deletes a fabric under a code reserved for the probe, then waits for the deleted event
+// to come back over NATS, so the outbox relay and the broker are covered too. The
+// commands run as the synthetic probe actor, which flags their events.
+//
+// The probe receives fabric events through HandleMessage and must be subscribed to the
+// fabric event subject outside any queue group, so every instance sees its own events.
+type SyntheticProbe struct {
+	target  ProbeTarget
+	code    string
+	timeout time.Duration
+	clock   clock.Clock
+	logger  *slog.Logger
+
+	mu sync.Mutex
+	// version of the deleted event the running cycle waits for, closed on delivery
+	awaitedVersion int
+	delivered      chan struct{}
+}
+
+func NewSyntheticProbe(
+	target ProbeTarget,
+	code string,
+	timeout time.Duration,
+	clock clock.Clock,
+	logger *slog.Logger,
+) (*SyntheticProbe, error) {
+	if !domain.IsProbeFabricCode(code) {
+		return nil, fmt.Errorf("probe fabric code %q must start with %s", code, domain.ProbeFabricCodePrefix)
+	}
+	return &SyntheticProbe{
+		target:  target,
+		code:    code,
+		timeout: timeout,
+		clock:   clock,
+		logger:  logger.With("component", "fabric.synthetic_probe", "code", code),
+	}, nil
+}
+
+// Probe runs one create, update and delete cycle and waits for its deleted event. The
+// duration of every step and the outcome of the cycle are recorded as metrics; the error
+// tells which step failed.
+func (p *SyntheticProbe) Probe(ctx context.Context) error {
+	ctx = command.WithSyntheticProbe(ctx)
+	start := p.clock.Now()
+
+	step, err := p.cycle(ctx)
+
+	attrs := []attribute.KeyValue{attribute.String("result", "pass")}
+	result := "pass"
+	if err != nil {
+		result = "fail"
+		attrs = []attribute.KeyValue{attribute.String("result", result), attribute.String("step", step)}
+		p.logger.Warn("synthetic probe failed", "step", step, "error", err)
+	}
+	p.record(ctx, probeStepTotal, result, start)
+	httpx.ProbeRunCounter.Add(ctx, 1, metric.WithAttributes(attrs...))
+	return err
+}
+
+// cycle runs the steps of a probe, returning the last step it got to.
+func (p *SyntheticProbe) cycle(ctx context.Context) (string, error) {
+	stepStart := p.clock.Now()
+	fabric, err := p.target.CreateFabric(
+		ctx, p.code, "Synthetic probe", string(domain.MeasureUnitPiece), string(domain.OfferStatusPrototype),
+		domain.Specification{}, domain.FabricTexts{},
+	)
+	if errors.Is(err, domain.ErrDuplicateFabricCode) {
+		// a previous cycle stopped half way, the fabric is removed for the next one
+		p.cleanUp(ctx)
+	}
+	if err != nil {
+		p.record(ctx, probeStepCreate, "fail", stepStart)
+		return probeStepCreate, err
+	}
+	p.record(ctx, probeStepCreate, "pass", stepStart)
+
+	stepStart = p.clock.Now()
+	fabric, err = p.target.UpdateFabric(
+		ctx, p.code, "Synthetic probe", string(domain.MeasureUnitPiece), string(domain.OfferStatusUnavailable),
+		nil, domain.FabricTexts{}, fabric.Version,
+	)
+	if err != nil {
+		p.record(ctx, probeStepUpdate, "fail", stepStart)
+		p.cleanUp(ctx)
+		return probeStepUpdate, err
+	}
+	p.record(ctx, probeStepUpdate, "pass", stepStart)
+
+	// the delivery is awaited before deleting, the event may arrive before DeleteFabric returns
+	delivered := p.await(fabric.Version + 1)
+	defer p.await(0)
+
+	stepStart = p.clock.Now()
+	if err := p.target.DeleteFabric(ctx, p.code, fabric.Version); err != nil {
+		p.record(ctx, probeStepDelete, "fail", stepStart)
+		p.cleanUp(ctx)
+		return probeStepDelete, err
+	}
+	p.record(ctx, probeStepDelete, "pass", stepStart)
+
+	stepStart = p.clock.Now()
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+	select {
+	case <-delivered:
+		p.record(ctx, probeStepDelivery, "pass", stepStart)
+		return probeStepDelivery, nil
+	case <-timer.C:
+		p.record(ctx, probeStepDelivery, "fail", stepStart)
+		return probeStepDelivery, ErrProbeDeliveryTimeout
+	case <-ctx.Done():
+		return probeStepDelivery, ctx.Err()
+	}
+}
+
+// HandleMessage signals the running cycle once the deleted event it waits for arrives.
+// Events of other fabrics and of earlier cycles are ignored.
+func (p *SyntheticProbe) HandleMessage(ctx context.Context, subject string, payload []byte) error {
+	var envelope messaging.EventEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return fmt.Errorf("failed to unmarshal event envelope: %w", err)
+	}
+	if envelope.AggregateID != p.code || envelope.EventType != "app.fabric.deleted" {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.delivered != nil && envelope.AggregateVersion == p.awaitedVersion {
+		close(p.delivered)
+		p.delivered = nil
+	}
+	return nil
+}
+
+// await starts waiting for the deleted event at the given version, zero stops waiting.
+func (p *SyntheticProbe) await(version int) <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.awaitedVersion = version
+	p.delivered = nil
+	if version == 0 {
+		return nil
+	}
+	p.delivered = make(chan struct{})
+	return p.delivered
+}
+
+// cleanUp deletes the probe fabric left active by a failed cycle. Failing to do so is
+// only logged, the next cycle tries again.
+func (p *SyntheticProbe) cleanUp(ctx context.Context) {
+	fabric, err := p.target.GetByCode(ctx, p.code)
+	if err != nil {
+		if !errors.Is(err, domain.ErrRecordNotFound) {
+			p.logger.Warn("failed to look up the probe fabric for clean up", "error", err)
+		}
+		return
+	}
+	if err := p.target.DeleteFabric(ctx, p.code, fabric.Version); err != nil {
+		p.logger.Warn("failed to clean up the probe fabric", "error", err)
+	}
+}
+
+func (p *SyntheticProbe) record(ctx context.Context, step, result string, start time.Time) {
+	httpx.ProbeDuration.Record(ctx, p.clock.Now().Sub(start).Seconds(), metric.WithAttributes(
+		attribute.String("step", step),
+		attribute.String("result", result),
+	))
+}
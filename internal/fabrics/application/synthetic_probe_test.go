@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProbeCode = domain.ProbeFabricCodePrefix + "1"
+
+// fakeProbeTarget keeps the probe fabric in memory and hands its deleted events to the
+// probe, as the outbox relay and NATS would, unless delivery is switched off.
+type fakeProbeTarget struct {
+	probe     *SyntheticProbe
+	fabric    *domain.Fabric
+	deliver   bool
+	actors    []string
+	createErr error
+	deleted   int
+}
+
+func (f *fakeProbeTarget) CreateFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification, texts domain.FabricTexts,
+) (*domain.Fabric, error) {
+	f.actors = append(f.actors, command.Actor(ctx))
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.fabric = &domain.Fabric{Code: code, Version: 1}
+	return f.fabric, nil
+}
+
+func (f *fakeProbeTarget) UpdateFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification,
+	texts domain.FabricTexts, version int,
+) (*domain.Fabric, error) {
+	f.actors = append(f.actors, command.Actor(ctx))
+	f.fabric.Version++
+	return f.fabric, nil
+}
+
+func (f *fakeProbeTarget) DeleteFabric(ctx context.Context, code string, version int) error {
+	f.actors = append(f.actors, command.Actor(ctx))
+	f.deleted++
+	if !f.deliver {
+		return nil
+	}
+	envelope := messaging.NewEventEnvelope("app.fabric.deleted", code, "Fabric", version+1, domain.FabricDeleted{})
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	return f.probe.HandleMessage(ctx, "app.fabric", payload)
+}
+
+func (f *fakeProbeTarget) GetByCode(ctx context.Context, code string) (*domain.Fabric, error) {
+	return &domain.Fabric{Code: code, Version: 4}, nil
+}
+
+func newTestProbe(t *testing.T, target *fakeProbeTarget, timeout time.Duration) *SyntheticProbe {
+	t.Helper()
+
+	probe, err := NewSyntheticProbe(
+		target, testProbeCode, timeout, clock.NewFixed(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	require.NoError(t, err)
+	target.probe = probe
+	return probe
+}
+
+func TestSyntheticProbe_Probe_Delivered(t *testing.T) {
+	// --- Arrange ---
+	target := &fakeProbeTarget{deliver: true}
+	probe := newTestProbe(t, target, time.Second)
+
+	// --- Act ---
+	err := probe.Probe(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		command.ActorSyntheticProbe, command.ActorSyntheticProbe, command.ActorSyntheticProbe,
+	}, target.actors, "every command must run as the synthetic probe")
+}
+
+func TestSyntheticProbe_Probe_DeliveryTimeout(t *testing.T) {
+	// --- Arrange ---
+	target := &fakeProbeTarget{deliver: false}
+	probe := newTestProbe(t, target, 10*time.Millisecond)
+
+	// --- Act ---
+	err := probe.Probe(context.Background())
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, ErrProbeDeliveryTimeout)
+}
+
+func TestSyntheticProbe_Probe_CleansUpLeftover(t *testing.T) {
+	// --- Arrange ---
+	target := &fakeProbeTarget{createErr: domain.ErrDuplicateFabricCode}
+	probe := newTestProbe(t, target, time.Second)
+
+	// --- Act ---
+	err := probe.Probe(context.Background())
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, domain.ErrDuplicateFabricCode)
+	assert.Equal(t, 1, target.deleted, "the fabric left by a broken cycle must be deleted")
+}
+
+func TestSyntheticProbe_HandleMessage_IgnoresOtherEvents(t *testing.T) {
+	// --- Arrange ---
+	probe := newTestProbe(t, &fakeProbeTarget{}, time.Second)
+	delivered := probe.await(3)
+	events := []*messaging.EventEnvelope{
+		messaging.NewEventEnvelope("app.fabric.deleted", "VELVET01", "Fabric", 3, domain.FabricDeleted{}),
+		messaging.NewEventEnvelope("app.fabric.updated", testProbeCode, "Fabric", 3, domain.FabricUpdated{}),
+		messaging.NewEventEnvelope("app.fabric.deleted", testProbeCode, "Fabric", 2, domain.FabricDeleted{}),
+	}
+
+	for _, envelope := range events {
+		payload, err := json.Marshal(envelope)
+		require.NoError(t, err)
+
+		// --- Act ---
+		require.NoError(t, probe.HandleMessage(context.Background(), "app.fabric", payload))
+	}
+
+	// --- Assert ---
+	select {
+	case <-delivered:
+		t.Fatal("only the awaited deleted event of the probe fabric may end the wait")
+	default:
+	}
+}
+
+func TestNewSyntheticProbe_RejectsUnreservedCode(t *testing.T) {
+	// --- Act ---
+	_, err := NewSyntheticProbe(
+		&fakeProbeTarget{}, "VELVET01", time.Second, clock.New(), slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	// --- Assert ---
+	assert.Error(t, err)
+}
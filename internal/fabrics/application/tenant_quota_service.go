@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+)
+
+// TenantQuotaService holds the tenant to its quota. Only commands of its users are
+// refused: the fabrics the ERP sends are counted but always taken, as refusing them would
+// leave the catalogue out of step with the ERP. The limits are soft, commands running at
+// the same time may each find room for one more.
+type TenantQuotaService struct {
+	usage domain.TenantUsageRepository
+	quota domain.TenantQuota
+}
+
+func NewTenantQuotaService(usage domain.TenantUsageRepository, quota domain.TenantQuota) *TenantQuotaService {
+	return &TenantQuotaService{usage: usage, quota: quota}
+}
+
+// Usage returns the quota of the tenant together with what it holds of it.
+func (s *TenantQuotaService) Usage(ctx context.Context) (domain.TenantQuota, domain.TenantUsage, error) {
+	usage, err := s.usage.GetTenantUsage(ctx)
+	if err != nil {
+		return s.quota, domain.TenantUsage{}, fmt.Errorf("failed to get tenant usage: %w", err)
+	}
+	return s.quota, usage, nil
+}
+
+// checkFabric fails with ErrFabricQuotaExceeded when the command would take the tenant
+// past the fabrics it may hold.
+func (s *TenantQuotaService) checkFabric(ctx context.Context) error {
+	if s.quota.MaxFabrics == 0 || !command.IsFromREST(ctx) {
+		return nil
+	}
+	usage, err := s.usage.GetTenantUsage(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant usage: %w", err)
+	}
+	return s.quota.CheckFabric(usage)
+}
+
+// checkAttachment fails when a file of the size would not fit in the attachment storage
+// left to the tenant.
+func (s *TenantQuotaService) checkAttachment(ctx context.Context, size int64) error {
+	if s.quota.MaxAttachmentBytes == 0 || !command.IsFromREST(ctx) {
+		return nil
+	}
+	usage, err := s.usage.GetTenantUsage(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant usage: %w", err)
+	}
+	return s.quota.CheckAttachment(usage, size)
+}
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/blobstore"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noQuota leaves the tenant unlimited, its usage is never read
+var noQuota = NewTenantQuotaService(nil, domain.TenantQuota{})
+
+type mockTenantUsageRepository struct {
+	usage domain.TenantUsage
+}
+
+func (m *mockTenantUsageRepository) GetTenantUsage(ctx context.Context) (domain.TenantUsage, error) {
+	return m.usage, nil
+}
+
+func TestTenantQuotaService_Usage(t *testing.T) {
+	// --- Arrange ---
+	quota := domain.TenantQuota{Tenant: "acme", MaxFabrics: 100}
+	usage := domain.TenantUsage{Fabrics: 42, AttachmentBytes: 2048}
+	service := NewTenantQuotaService(&mockTenantUsageRepository{usage: usage}, quota)
+
+	// --- Act ---
+	gotQuota, gotUsage, err := service.Usage(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, quota, gotQuota)
+	assert.Equal(t, usage, gotUsage)
+}
+
+func TestFabricService_CreateFabric_Quota(t *testing.T) {
+	testCases := []struct {
+		name        string
+		source      command.CommandSource
+		expectedErr error
+	}{
+		{name: "Refused to a user", source: command.CommandSourceREST, expectedErr: domain.ErrFabricQuotaExceeded},
+		{name: "Taken from the ERP", source: command.CommandSourceEvent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			commandRepo := &mockFabricCommandRepository{}
+			quota := NewTenantQuotaService(
+				&mockTenantUsageRepository{usage: domain.TenantUsage{Fabrics: 10}}, domain.TenantQuota{MaxFabrics: 10},
+			)
+			service := NewFabricCommandService(
+				commandRepo, &mockFibreRepository{}, quota, &mockEventStore{}, clock.NewFixed(testStamp.At), testSource,
+			)
+			ctx := command.WithCommandSource(context.Background(), tc.source)
+
+			// --- Act ---
+			_, err := service.CreateFabric(ctx, "VELVET01", "Velvet", "mb", "available", domain.Specification{}, domain.FabricTexts{})
+
+			// --- Assert ---
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+				assert.True(t, commandRepo.SavedCalled)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.False(t, commandRepo.SavedCalled, "a fabric over the quota must not be saved")
+		})
+	}
+}
+
+func TestFabricAttachmentService_AddAttachment_Quota(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockFabricAttachmentRepository{attachments: map[string]*domain.FabricAttachment{}}
+	dir := t.TempDir()
+	eventStore := &mockEventStore{}
+	quota := NewTenantQuotaService(
+		&mockTenantUsageRepository{usage: domain.TenantUsage{AttachmentBytes: 1000}}, domain.TenantQuota{MaxAttachmentBytes: 1024},
+	)
+	service := NewFabricAttachmentService(
+		repo, blobstore.NewFileStore(dir), quota, eventStore, clock.NewFixed(testStamp.At), testSource,
+	)
+	content := append(bytes.Clone(pngHeader), bytes.Repeat([]byte{0}, 100)...)
+
+	// --- Act ---
+	_, err := service.AddAttachment(
+		context.Background(), "VELVET01", domain.AttachmentImage, "swatch.png", bytes.NewReader(content),
+	)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, domain.ErrAttachmentQuotaExceeded)
+	assert.Empty(t, repo.attachments)
+	assert.False(t, eventStore.SavedCalled)
+
+	var files []string
+	require.NoError(t, filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	}))
+	assert.Empty(t, files, "the file stored before the quota was checked must be deleted")
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"path"
+	"time"
+)
+
+// catalogSnapshotPrefix is the blob storage key below which snapshots are kept, one
+// directory per version
+const catalogSnapshotPrefix = "catalog/snapshots"
+
+// CatalogSnapshot is a rendering of the active catalog published to blob storage as JSON
+// and CSV, so the e-commerce frontend reads the whole catalog at once instead of crawling
+// the API. A snapshot is never overwritten; its version is the time it was taken.
+type CatalogSnapshot struct {
+	Version     string    `json:"version"`
+	Fabrics     int       `json:"fabrics"`
+	JSONKey     string    `json:"json_key"`
+	CSVKey      string    `json:"csv_key"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// CatalogSnapshotPublished is recorded once both files of a snapshot are stored.
+type CatalogSnapshotPublished struct {
+	Version     string
+	Fabrics     int
+	JSONKey     string
+	CSVKey      string
+	PublishedAt time.Time
+}
+
+// NewCatalogSnapshot names the snapshot of the given number of fabrics taken at the time.
+func NewCatalogSnapshot(fabrics int, takenAt time.Time) *CatalogSnapshot {
+	version := takenAt.UTC().Format("20060102T150405Z")
+	return &CatalogSnapshot{
+		Version:     version,
+		Fabrics:     fabrics,
+		JSONKey:     path.Join(catalogSnapshotPrefix, version, "catalog.json"),
+		CSVKey:      path.Join(catalogSnapshotPrefix, version, "catalog.csv"),
+		PublishedAt: takenAt.UTC(),
+	}
+}
+
+// Published returns the event announcing the snapshot.
+func (s *CatalogSnapshot) Published() CatalogSnapshotPublished {
+	return CatalogSnapshotPublished{
+		Version:     s.Version,
+		Fabrics:     s.Fabrics,
+		JSONKey:     s.JSONKey,
+		CSVKey:      s.CSVKey,
+		PublishedAt: s.PublishedAt,
+	}
+}
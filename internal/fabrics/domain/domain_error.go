@@ -0,0 +1,83 @@
+package domain
+
+import "errors"
+
+// ErrorKind tells what went wrong in a domain operation, independently of the transport.
+type ErrorKind string
+
+const (
+	KindValidation ErrorKind = "validation"
+	KindNotFound   ErrorKind = "not_found"
+	KindConflict   ErrorKind = "conflict"
+	KindQuota      ErrorKind = "quota"
+)
+
+// DomainError is a rule violation reported by the domain. Code identifies the rule, Field
+// the offending attribute if any, and Params the values needed to explain it.
+type DomainError struct {
+	Kind    ErrorKind      `json:"-"`
+	Code    string         `json:"code"`
+	Field   string         `json:"field,omitempty"`
+	Message string         `json:"message"`
+	Params  map[string]any `json:"params,omitempty"`
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *DomainError) ErrorClass() string {
+	return string(e.Kind)
+}
+
+// Is matches domain errors by code, so a copy carrying extra params still satisfies
+// errors.Is against the exported error it was derived from.
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Code == e.Code
+}
+
+// WithParam returns a copy of the error carrying an additional parameter.
+func (e *DomainError) WithParam(key string, value any) *DomainError {
+	params := make(map[string]any, len(e.Params)+1)
+	for k, v := range e.Params {
+		params[k] = v
+	}
+	params[key] = value
+
+	copied := *e
+	copied.Params = params
+	return &copied
+}
+
+// AsDomainError extracts the domain error wrapped in err, if any.
+func AsDomainError(err error) (*DomainError, bool) {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+// IsValidation reports whether err is a domain rule violation caused by invalid input.
+func IsValidation(err error) bool {
+	domainErr, ok := AsDomainError(err)
+	return ok && domainErr.Kind == KindValidation
+}
+
+func validationError(code, field, message string, params map[string]any) *DomainError {
+	return &DomainError{Kind: KindValidation, Code: code, Field: field, Message: message, Params: params}
+}
+
+func notFoundError(code, message string) *DomainError {
+	return &DomainError{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func conflictError(code, message string) *DomainError {
+	return &DomainError{Kind: KindConflict, Code: code, Message: message}
+}
+
+func quotaError(code, message string) *DomainError {
+	return &DomainError{Kind: KindQuota, Code: code, Message: message}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainError_WithParamKeepsIdentity(t *testing.T) {
+	// --- Arrange ---
+	_, err := ParseMeasureUnit("yard")
+
+	// --- Act ---
+	wrapped := fmt.Errorf("application service failed to create fabric: %w", err)
+	domainErr, ok := AsDomainError(wrapped)
+
+	// --- Assert ---
+	assert.ErrorIs(t, wrapped, ErrInvalidMeasureUnit)
+	require.True(t, ok)
+	assert.Equal(t, "invalid_measure_unit", domainErr.Code)
+	assert.Equal(t, "measure_unit", domainErr.Field)
+	assert.Equal(t, "yard", domainErr.Params["value"])
+	assert.NotContains(t, ErrInvalidMeasureUnit.Params, "value", "the exported error must not be modified")
+}
+
+func TestDomainError_Kinds(t *testing.T) {
+	testCases := []struct {
+		name       string
+		err        error
+		validation bool
+	}{
+		{name: "Invalid name", err: ErrInvalidFabricNameLength, validation: true},
+		{name: "Invalid merge target", err: ErrInvalidMergeTarget, validation: true},
+		{name: "Not found", err: ErrRecordNotFound},
+		{name: "Concurrency conflict", err: ErrConcurrencyConflict},
+		{name: "Not a domain error", err: fmt.Errorf("connection reset")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			validation := IsValidation(fmt.Errorf("wrapped: %w", tc.err))
+
+			// --- Assert ---
+			assert.Equal(t, tc.validation, validation)
+		})
+	}
+}
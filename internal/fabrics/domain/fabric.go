@@ -1,177 +1,505 @@
 package domain
 
 import (
-	"errors"
 	"regexp"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
 )
 
 var (
-	ErrInvalidFabricCodeLength  = errors.New("the fabric code length must be 2-30")
-	ErrInvalidFabricCodePattern = errors.New("the fabric code can contain A-Z and 0-9 characters")
-	ErrInvalidFabricNameLength  = errors.New("the fabric name length must be 1-250")
-	ErrRecordNotFound           = errors.New("record not found")
-	ErrDuplicateFabricCode      = errors.New("a fabric with this code already exsists")
-	ErrConcurrencyConflict      = errors.New("a concurrency conflict occurred")
-	ErrFabricDeleted            = errors.New("cannot perform on a deleted fabric")
+	ErrInvalidFabricCodeLength = validationError(
+		"invalid_code_length", "code", "the fabric code length must be 2-30", map[string]any{"min": 2, "max": 30},
+	)
+	ErrInvalidFabricCodePattern = validationError(
+		"invalid_code_pattern", "code", "the fabric code can contain A-Z and 0-9 characters", map[string]any{"pattern": "^[A-Z0-9]+$"},
+	)
+	ErrInvalidFabricNameLength = validationError(
+		"invalid_name_length", "name", "the fabric name length must be 1-250", map[string]any{"min": 1, "max": 250},
+	)
+	ErrInvalidMergeTarget = validationError(
+		"invalid_merge_target", "into", "a fabric can only be merged into another active fabric", nil,
+	)
+	ErrUnchangedFabricCode = validationError(
+		"unchanged_code", "code", "the new code must differ from the current one", nil,
+	)
+	ErrRecordNotFound      = notFoundError("not_found", "record not found")
+	ErrDuplicateFabricCode = conflictError("duplicate_code", "a fabric with this code already exists")
+	ErrConcurrencyConflict = conflictError(
+		"concurrency_conflict", "the resource has been modified by another process, please refresh and try again",
+	)
+	ErrFabricDeleted    = conflictError("fabric_deleted", "cannot perform on a deleted fabric")
+	ErrFabricMerged     = conflictError("fabric_merged", "cannot perform on a fabric merged into another")
+	ErrFabricNotDeleted = conflictError("fabric_not_deleted", "only a deleted fabric can be restored")
+	ErrFabricArchived   = conflictError("fabric_archived", "cannot perform on an archived fabric")
 )
 
+// The lifecycle of a fabric, see fabric_lifecycle.go for the transitions between them.
 const (
-	StatusActive  = "ACTIVE"
-	StatusDeleted = "DELETED"
+	StatusDraft        = "DRAFT"
+	StatusActive       = "ACTIVE"
+	StatusDiscontinued = "DISCONTINUED"
+	StatusArchived     = "ARCHIVED"
+	StatusDeleted      = "DELETED"
+	StatusMerged       = "MERGED"
 )
 
-type Event any
+// AggregateType names the fabric aggregate in the event store. Every event of a fabric is
+// stored under it, the history and the change feed read it back by the same name.
+const AggregateType = "Fabric"
+
+type Event = aggregate.Event
+
+// Stamp identifies who performed a change on an aggregate and when it happened.
+type Stamp = aggregate.Stamp
 
 type Fabric struct {
-	Code        string
-	Name        string
-	MeasureUnit string
-	OfferStatus string
-	Status      string
-	Version     int
-	events      []Event
+	Code          string
+	Name          string
+	MeasureUnit   MeasureUnit
+	OfferStatus   OfferStatus
+	Specification Specification
+	// Description is markdown shown to customers, Notes are internal remarks for staff.
+	Description string
+	Notes       string
+	// Translations holds the name and description in other locales, keyed by language tag.
+	Translations map[string]Translation
+	// CustomAttributes holds the values of registered custom attributes, keyed by attribute.
+	CustomAttributes map[string]any
+	// Price is nil until a list price is set.
+	Price  *Price
+	Status string
+	// StatusBeforeDeletion is the status a deleted fabric is restored to, empty otherwise.
+	StatusBeforeDeletion string
+	Version              int
+	CreatedAt            time.Time
+	CreatedBy            string
+	UpdatedAt            time.Time
+	UpdatedBy            string
+	aggregate.Root
 }
 
 type FabricCreated struct {
-	Code        string
-	Name        string
-	MeasureUnit string
-	OfferStatus string
-	Version     int
+	Code          string
+	Name          string
+	MeasureUnit   MeasureUnit
+	OfferStatus   OfferStatus
+	Specification Specification
+	Description   string
+	Notes         string
+	Status        string
+	Version       int
 }
 
 type FabricUpdated struct {
-	Code        string
-	Name        string
-	MeasureUnit string
-	OfferStatus string
-	Version     int
+	Code          string
+	Name          string
+	MeasureUnit   MeasureUnit
+	OfferStatus   OfferStatus
+	Specification Specification
+	Description   string
+	Notes         string
+	Version       int
 }
 
 type FabricDeleted struct {
+	Code           string
+	PreviousStatus string
+	Version        int
+}
+
+// FabricMerged is recorded on a duplicate fabric when it is folded into its canonical fabric.
+type FabricMerged struct {
+	Code          string
+	CanonicalCode string
+	Version       int
+}
+
+// FabricCodeChanged is recorded when a fabric is renamed, the previous code resolving to it
+// as an alias from then on.
+type FabricCodeChanged struct {
+	Code         string
+	PreviousCode string
+	Version      int
+}
+
+type FabricReactivated struct {
+	Code          string
+	Name          string
+	MeasureUnit   MeasureUnit
+	OfferStatus   OfferStatus
+	Specification Specification
+	Description   string
+	Notes         string
+	Status        string
+	Version       int
+}
+
+// FabricPriceChanged is recorded when the list price of a fabric is set.
+type FabricPriceChanged struct {
 	Code    string
+	Price   Price
 	Version int
 }
 
-type FabricReactivated struct {
-	Code        string
-	Name        string
-	MeasureUnit string
-	OfferStatus string
-	Version     int
+// FabricRestored is recorded when a deleted fabric is brought back with its prior data.
+type FabricRestored struct {
+	Code          string
+	Name          string
+	MeasureUnit   MeasureUnit
+	OfferStatus   OfferStatus
+	Specification Specification
+	Description   string
+	Notes         string
+	Status        string
+	Version       int
 }
 
-func NewFabric(code, name, measureUnit, offerStatus string) (*Fabric, error) {
+func NewFabric(
+	code, name, measureUnit, offerStatus string, spec Specification, texts FabricTexts, stamp Stamp,
+) (*Fabric, error) {
+	return newFabric(StatusActive, code, name, measureUnit, offerStatus, spec, texts, stamp)
+}
+
+// NewDraftFabric creates a fabric that is prepared but not yet offered, it goes on offer
+// once activated.
+func NewDraftFabric(
+	code, name, measureUnit, offerStatus string, spec Specification, texts FabricTexts, stamp Stamp,
+) (*Fabric, error) {
+	return newFabric(StatusDraft, code, name, measureUnit, offerStatus, spec, texts, stamp)
+}
+
+func newFabric(
+	lifecycleStatus, code, name, measureUnit, offerStatus string, spec Specification, texts FabricTexts, stamp Stamp,
+) (*Fabric, error) {
 	if err := validateCode(code); err != nil {
 		return nil, err
 	}
 	if err := validateName(name); err != nil {
 		return nil, err
 	}
+	unit, status, err := parseAttributes(measureUnit, offerStatus)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	if err := texts.Validate(); err != nil {
+		return nil, err
+	}
 
 	fabric := &Fabric{
-		Code:        code,
-		Name:        name,
-		MeasureUnit: measureUnit,
-		OfferStatus: offerStatus,
-		Status:      StatusActive,
-		Version:     1,
+		Code:          code,
+		Name:          name,
+		MeasureUnit:   unit,
+		OfferStatus:   status,
+		Specification: spec,
+		Status:        lifecycleStatus,
+		Version:       1,
+		CreatedAt:     stamp.At,
+		CreatedBy:     stamp.By,
+		UpdatedAt:     stamp.At,
+		UpdatedBy:     stamp.By,
 	}
+	texts.applyTo(fabric)
 
 	event := FabricCreated{
-		Code:        fabric.Code,
-		Name:        fabric.Name,
-		MeasureUnit: fabric.MeasureUnit,
-		OfferStatus: fabric.OfferStatus,
-		Version:     fabric.Version,
+		Code:          fabric.Code,
+		Name:          fabric.Name,
+		MeasureUnit:   fabric.MeasureUnit,
+		OfferStatus:   fabric.OfferStatus,
+		Specification: fabric.Specification,
+		Description:   fabric.Description,
+		Notes:         fabric.Notes,
+		Status:        fabric.Status,
+		Version:       fabric.Version,
 	}
 
-	fabric.events = append(fabric.events, event)
+	fabric.Record(event)
 	return fabric, nil
 }
 
-func (f *Fabric) UpdateFabric(name, measureUnit, offerStatus string, version int) error {
-	// Soft delete check
-	if f.Status == StatusDeleted {
-		return ErrFabricDeleted
+// UpdateFabric replaces the data of a draft, active or discontinued fabric. A nil spec
+// keeps the current specification and texts left out keep theirs, for sources such as
+// the ERP that do not manage them.
+func (f *Fabric) UpdateFabric(
+	name, measureUnit, offerStatus string, spec *Specification, texts FabricTexts, version int, stamp Stamp,
+) error {
+	if err := f.checkEditable(); err != nil {
+		return err
 	}
 	// Optimistic concurrency check
 	if f.Version != version {
-		return ErrConcurrencyConflict
+		return versionConflict(f.Version, f.updateConflicts(name, measureUnit, offerStatus, spec, texts))
 	}
 	if err := validateName(name); err != nil {
 		return err
 	}
+	unit, status, err := parseAttributes(measureUnit, offerStatus)
+	if err != nil {
+		return err
+	}
+	if !CanChangeOfferStatus(f.OfferStatus, status) {
+		return ErrInvalidOfferStatusTransition.WithParam("from", string(f.OfferStatus)).WithParam("to", string(status))
+	}
+	if spec != nil {
+		if err := spec.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := texts.Validate(); err != nil {
+		return err
+	}
 
+	if spec != nil {
+		f.Specification = *spec
+	}
+	texts.applyTo(f)
 	f.Name = name
-	f.MeasureUnit = measureUnit
-	f.OfferStatus = offerStatus
+	f.MeasureUnit = unit
+	f.OfferStatus = status
 	f.Version++ // Increment version on successful update
+	f.touch(stamp)
 
 	event := FabricUpdated{
-		Code:        f.Code,
-		Name:        f.Name,
-		MeasureUnit: f.MeasureUnit,
-		OfferStatus: f.OfferStatus,
-		Version:     f.Version,
+		Code:          f.Code,
+		Name:          f.Name,
+		MeasureUnit:   f.MeasureUnit,
+		OfferStatus:   f.OfferStatus,
+		Specification: f.Specification,
+		Description:   f.Description,
+		Notes:         f.Notes,
+		Version:       f.Version,
 	}
 
-	f.events = append(f.events, event)
+	f.Record(event)
 	return nil
 }
 
-func (f *Fabric) Delete(version int) error {
-	if f.Status == StatusDeleted {
+func (f *Fabric) Delete(version int, stamp Stamp) error {
+	switch f.Status {
+	case StatusDeleted:
 		return ErrFabricDeleted
+	case StatusMerged:
+		return ErrFabricMerged
+	}
+	if err := f.checkTransition(StatusDeleted); err != nil {
+		return err
 	}
 	if f.Version != version {
-		return ErrConcurrencyConflict
+		return versionConflict(f.Version, nil)
 	}
 
+	f.StatusBeforeDeletion = f.Status
 	f.Status = StatusDeleted
 	f.Version++
+	f.touch(stamp)
 
 	event := FabricDeleted{
-		Code:    f.Code,
-		Version: f.Version,
+		Code:           f.Code,
+		PreviousStatus: f.StatusBeforeDeletion,
+		Version:        f.Version,
 	}
-	f.events = append(f.events, event)
+	f.Record(event)
 
 	return nil
 }
 
-func (f *Fabric) Reactivate(name, measureUnit, offerStatus string, version int) error {
+// Reactivate brings a deleted fabric back with new data, when a fabric is created again
+// under its code. lifecycleStatus is the status it is created in, ACTIVE or DRAFT. The
+// fabric is offered afresh, so its offer status may take any value. Texts left out keep
+// the ones it had.
+func (f *Fabric) Reactivate(
+	lifecycleStatus, name, measureUnit, offerStatus string, spec Specification, texts FabricTexts, version int,
+	stamp Stamp,
+) error {
 	if f.Status == StatusActive {
 		// if it's already active, this shold be treated as a regular update
-		return f.UpdateFabric(name, measureUnit, offerStatus, version)
+		return f.UpdateFabric(name, measureUnit, offerStatus, &spec, texts, version, stamp)
+	}
+	if err := f.checkTransition(lifecycleStatus); err != nil {
+		return err
 	}
 	if f.Version != version {
-		return ErrConcurrencyConflict
+		return versionConflict(f.Version, f.updateConflicts(name, measureUnit, offerStatus, &spec, texts))
 	}
 	if err := validateName(name); err != nil {
 		return err
 	}
+	unit, status, err := parseAttributes(measureUnit, offerStatus)
+	if err != nil {
+		return err
+	}
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	if err := texts.Validate(); err != nil {
+		return err
+	}
 
-	f.Status = StatusActive
+	f.Status = lifecycleStatus
+	f.StatusBeforeDeletion = ""
 	f.Name = name
-	f.MeasureUnit = measureUnit
-	f.OfferStatus = offerStatus
+	f.MeasureUnit = unit
+	f.OfferStatus = status
+	f.Specification = spec
+	texts.applyTo(f)
 	f.Version++
+	f.touch(stamp)
 
 	event := FabricReactivated{
-		Code:        f.Code,
-		Name:        f.Name,
-		MeasureUnit: f.MeasureUnit,
-		OfferStatus: f.OfferStatus,
-		Version:     f.Version,
+		Code:          f.Code,
+		Name:          f.Name,
+		MeasureUnit:   f.MeasureUnit,
+		OfferStatus:   f.OfferStatus,
+		Specification: f.Specification,
+		Description:   f.Description,
+		Notes:         f.Notes,
+		Status:        f.Status,
+		Version:       f.Version,
 	}
-	f.events = append(f.events, event)
+	f.Record(event)
 
 	return nil
 }
 
-func (f *Fabric) Events() []Event {
-	return f.events
+// ChangePrice sets the list price of a draft, active or discontinued fabric.
+func (f *Fabric) ChangePrice(price Price, version int, stamp Stamp) error {
+	if err := f.checkEditable(); err != nil {
+		return err
+	}
+	if f.Version != version {
+		return versionConflict(f.Version, f.priceConflicts(price))
+	}
+	if price.Amount < 0 {
+		return ErrInvalidPriceAmount.WithParam("value", price.Amount)
+	}
+	if _, ok := currencyDecimals[price.Currency]; !ok {
+		return ErrInvalidCurrency.WithParam("value", price.Currency)
+	}
+
+	f.Price = &price
+	f.Version++
+	f.touch(stamp)
+
+	event := FabricPriceChanged{
+		Code:    f.Code,
+		Price:   price,
+		Version: f.Version,
+	}
+	f.Record(event)
+
+	return nil
+}
+
+// ChangeCode renames a draft, active or discontinued fabric. The previous code is kept as an
+// alias, so it keeps resolving to the fabric.
+func (f *Fabric) ChangeCode(code string, version int, stamp Stamp) error {
+	if err := f.checkEditable(); err != nil {
+		return err
+	}
+	if f.Version != version {
+		return versionConflict(f.Version, nil)
+	}
+	if err := validateCode(code); err != nil {
+		return err
+	}
+	if code == f.Code {
+		return ErrUnchangedFabricCode
+	}
+
+	previous := f.Code
+	f.Code = code
+	f.Version++
+	f.touch(stamp)
+
+	event := FabricCodeChanged{
+		Code:         f.Code,
+		PreviousCode: previous,
+		Version:      f.Version,
+	}
+	f.Record(event)
+
+	return nil
+}
+
+// Restore brings a deleted fabric back as it was when it was deleted, in the lifecycle status
+// it had then, unlike Reactivate, which replaces its data.
+func (f *Fabric) Restore(version int, stamp Stamp) error {
+	switch f.Status {
+	case StatusDeleted:
+	case StatusMerged:
+		return ErrFabricMerged
+	default:
+		return ErrFabricNotDeleted
+	}
+	if f.Version != version {
+		return versionConflict(f.Version, nil)
+	}
+
+	f.Status = f.StatusBeforeDeletion
+	if f.Status == "" {
+		// deleted before the status it had was kept
+		f.Status = StatusActive
+	}
+	f.StatusBeforeDeletion = ""
+	f.Version++
+	f.touch(stamp)
+
+	event := FabricRestored{
+		Code:          f.Code,
+		Name:          f.Name,
+		MeasureUnit:   f.MeasureUnit,
+		OfferStatus:   f.OfferStatus,
+		Specification: f.Specification,
+		Description:   f.Description,
+		Notes:         f.Notes,
+		Status:        f.Status,
+		Version:       f.Version,
+	}
+	f.Record(event)
+
+	return nil
+}
+
+// MergeInto retires a duplicate fabric in favour of the canonical one, after which the
+// duplicate code is only an alias of the canonical code.
+func (f *Fabric) MergeInto(canonical *Fabric, version int, stamp Stamp) error {
+	switch f.Status {
+	case StatusDeleted:
+		return ErrFabricDeleted
+	case StatusMerged:
+		return ErrFabricMerged
+	}
+	if canonical.Code == f.Code || canonical.Status != StatusActive {
+		return ErrInvalidMergeTarget
+	}
+	if err := f.checkTransition(StatusMerged); err != nil {
+		return err
+	}
+	if f.Version != version {
+		return versionConflict(f.Version, nil)
+	}
+
+	f.Status = StatusMerged
+	f.Version++
+	f.touch(stamp)
+
+	event := FabricMerged{
+		Code:          f.Code,
+		CanonicalCode: canonical.Code,
+		Version:       f.Version,
+	}
+	f.Record(event)
+
+	return nil
+}
+
+// touch records the author and time of the latest change.
+func (f *Fabric) touch(stamp Stamp) {
+	f.UpdatedAt = stamp.At
+	f.UpdatedBy = stamp.By
 }
 
 func validateCode(code string) error {
@@ -191,3 +519,15 @@ func validateName(name string) error {
 	}
 	return nil
 }
+
+func parseAttributes(measureUnit, offerStatus string) (MeasureUnit, OfferStatus, error) {
+	unit, err := ParseMeasureUnit(measureUnit)
+	if err != nil {
+		return "", "", err
+	}
+	status, err := ParseOfferStatus(offerStatus)
+	if err != nil {
+		return "", "", err
+	}
+	return unit, status, nil
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// FabricAlias is an alternate, usually legacy, code that resolves to a canonical fabric.
+type FabricAlias struct {
+	AliasCode     string    `json:"alias_code"`
+	CanonicalCode string    `json:"canonical_code"`
+	CreatedAt     time.Time `json:"created_at"`
+	CreatedBy     string    `json:"created_by"`
+}
+
+func NewFabricAlias(aliasCode, canonicalCode string, stamp Stamp) (*FabricAlias, error) {
+	if err := validateCode(aliasCode); err != nil {
+		return nil, err
+	}
+	if aliasCode == canonicalCode {
+		return nil, ErrDuplicateFabricCode
+	}
+
+	return &FabricAlias{
+		AliasCode:     aliasCode,
+		CanonicalCode: canonicalCode,
+		CreatedAt:     stamp.At,
+		CreatedBy:     stamp.By,
+	}, nil
+}
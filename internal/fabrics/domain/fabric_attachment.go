@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+const (
+	AttachmentImage     = "image"
+	AttachmentSpecSheet = "spec_sheet"
+
+	// MaxAttachmentSize is the largest file accepted as an attachment, in bytes.
+	MaxAttachmentSize = 10 << 20
+)
+
+var (
+	// content types accepted per kind of attachment, checked against the uploaded bytes
+	imageContentTypes     = []string{"image/jpeg", "image/png", "image/webp"}
+	specSheetContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+)
+
+var (
+	ErrInvalidAttachmentKind = validationError(
+		"invalid_attachment_kind", "kind", "the attachment kind must be image or spec_sheet",
+		map[string]any{"permitted": []string{AttachmentImage, AttachmentSpecSheet}},
+	)
+	ErrInvalidAttachmentName = validationError(
+		"invalid_attachment_name", "file", "the file name length must be 1-255", map[string]any{"min": 1, "max": 255},
+	)
+	ErrUnsupportedAttachmentType = validationError(
+		"unsupported_attachment_type", "file", "the file type is not accepted for this kind of attachment", nil,
+	)
+	ErrAttachmentTooLarge = validationError(
+		"attachment_too_large", "file", "the file must not be larger than 10 MB", map[string]any{"max_bytes": MaxAttachmentSize},
+	)
+	ErrEmptyAttachment = validationError(
+		"empty_attachment", "file", "the file must not be empty", nil,
+	)
+)
+
+// FabricAttachment is an image or a spec sheet attached to a fabric. The file itself is
+// kept in blob storage under StorageKey, the attachment holds what describes it.
+type FabricAttachment struct {
+	ID          string    `json:"id"`
+	FabricCode  string    `json:"fabric_code"`
+	Kind        string    `json:"kind"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-"`
+	URL         string    `json:"url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   string    `json:"created_by"`
+	aggregate.Root
+}
+
+// FabricAttachmentAdded is recorded when a file is attached to a fabric.
+type FabricAttachmentAdded struct {
+	ID          string
+	FabricCode  string
+	Kind        string
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+type FabricAttachmentRemoved struct {
+	ID         string
+	FabricCode string
+}
+
+// NewFabricAttachment describes a stored file attached to the fabric. The content type is
+// the one detected from the file, not the one declared by the client.
+func NewFabricAttachment(
+	id, fabricCode, kind, fileName, contentType string, size int64, stamp Stamp,
+) (*FabricAttachment, error) {
+	if err := ValidateAttachment(kind, fileName, contentType); err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, ErrEmptyAttachment
+	}
+	if size > MaxAttachmentSize {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	attachment := &FabricAttachment{
+		ID:          id,
+		FabricCode:  fabricCode,
+		Kind:        kind,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  AttachmentStorageKey(fabricCode, id),
+		CreatedAt:   stamp.At,
+		CreatedBy:   stamp.By,
+	}
+
+	event := FabricAttachmentAdded{
+		ID:          attachment.ID,
+		FabricCode:  attachment.FabricCode,
+		Kind:        attachment.Kind,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+	}
+	attachment.Record(event)
+	return attachment, nil
+}
+
+// AttachmentStorageKey is the blob storage key the file of an attachment is kept under.
+func AttachmentStorageKey(fabricCode, id string) string {
+	return "fabrics/" + fabricCode + "/" + id
+}
+
+// ValidateAttachment checks an upload before its content is stored.
+func ValidateAttachment(kind, fileName, contentType string) error {
+	var permitted []string
+	switch kind {
+	case AttachmentImage:
+		permitted = imageContentTypes
+	case AttachmentSpecSheet:
+		permitted = specSheetContentTypes
+	default:
+		return ErrInvalidAttachmentKind
+	}
+	if fileName == "" || len(fileName) > 255 {
+		return ErrInvalidAttachmentName
+	}
+	if !slices.Contains(permitted, contentType) {
+		return ErrUnsupportedAttachmentType.WithParam("permitted", permitted).WithParam("content_type", contentType)
+	}
+	return nil
+}
+
+// Remove records that the attachment was taken off the fabric.
+func (a *FabricAttachment) Remove() {
+	event := FabricAttachmentRemoved{
+		ID:         a.ID,
+		FabricCode: a.FabricCode,
+	}
+	a.Record(event)
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFabricAttachment(t *testing.T) {
+	testCases := []struct {
+		name        string
+		kind        string
+		fileName    string
+		contentType string
+		size        int64
+		expectedErr error
+	}{
+		{name: "Image", kind: AttachmentImage, fileName: "swatch.webp", contentType: "image/webp", size: 1024},
+		{name: "PDF spec sheet", kind: AttachmentSpecSheet, fileName: "spec.pdf", contentType: "application/pdf", size: 1024},
+		{name: "Scanned spec sheet", kind: AttachmentSpecSheet, fileName: "spec.jpg", contentType: "image/jpeg", size: 1024},
+		{name: "PDF as image", kind: AttachmentImage, fileName: "spec.pdf", contentType: "application/pdf", size: 1024, expectedErr: ErrUnsupportedAttachmentType},
+		{name: "Unknown kind", kind: "video", fileName: "clip.mp4", contentType: "video/mp4", size: 1024, expectedErr: ErrInvalidAttachmentKind},
+		{name: "Missing file name", kind: AttachmentImage, contentType: "image/png", size: 1024, expectedErr: ErrInvalidAttachmentName},
+		{name: "Long file name", kind: AttachmentImage, fileName: strings.Repeat("a", 256), contentType: "image/png", size: 1024, expectedErr: ErrInvalidAttachmentName},
+		{name: "Empty file", kind: AttachmentImage, fileName: "swatch.png", contentType: "image/png", expectedErr: ErrEmptyAttachment},
+		{name: "Too large", kind: AttachmentImage, fileName: "swatch.png", contentType: "image/png", size: MaxAttachmentSize + 1, expectedErr: ErrAttachmentTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			stamp := Stamp{By: "tester", At: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+			// --- Act ---
+			attachment, err := NewFabricAttachment("id-1", "VELVET01", tc.kind, tc.fileName, tc.contentType, tc.size, stamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "fabrics/VELVET01/id-1", attachment.StorageKey)
+			require.Len(t, attachment.Events(), 1)
+			assert.IsType(t, FabricAttachmentAdded{}, attachment.Events()[0])
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/platform/quantity"
+)
+
+var (
+	ErrInvalidMeasureUnit = validationError(
+		"invalid_measure_unit", "measure_unit", "the measure unit must be one of MB, M, CM, M2, KG, PCS",
+		map[string]any{"allowed": measureUnits},
+	)
+	ErrInvalidOfferStatus = validationError(
+		"invalid_offer_status", "offer_status",
+		"the offer status must be one of ACTIVE, NEW, AVAILABLE, UNAVAILABLE, OUT_OF_STOCK, PROTOTYP, DISCONTINUED",
+		map[string]any{"allowed": offerStatuses},
+	)
+	ErrInvalidOfferStatusTransition = validationError(
+		"invalid_offer_status_transition", "offer_status",
+		"the offer status cannot change from its current value to the requested one", nil,
+	)
+	ErrIncompatibleMeasureUnits = validationError(
+		"incompatible_measure_units", "measure_unit", "the quantity cannot be converted between these measure units", nil,
+	)
+	ErrInexactConversion = validationError(
+		"inexact_conversion", "quantity",
+		"the converted quantity would need more than 3 decimals", map[string]any{"decimals": quantity.Decimals},
+	)
+)
+
+// MeasureUnit is the unit a fabric is sold in.
+type MeasureUnit string
+
+const (
+	MeasureUnitRunningMetre MeasureUnit = "MB"
+	MeasureUnitMetre        MeasureUnit = "M"
+	MeasureUnitCentimetre   MeasureUnit = "CM"
+	MeasureUnitSquareMetre  MeasureUnit = "M2"
+	MeasureUnitKilogram     MeasureUnit = "KG"
+	MeasureUnitPiece        MeasureUnit = "PCS"
+)
+
+var measureUnits = []MeasureUnit{
+	MeasureUnitRunningMetre, MeasureUnitMetre, MeasureUnitCentimetre,
+	MeasureUnitSquareMetre, MeasureUnitKilogram, MeasureUnitPiece,
+}
+
+// ParseMeasureUnit accepts a known unit in any letter case and returns its canonical form.
+func ParseMeasureUnit(raw string) (MeasureUnit, error) {
+	unit := MeasureUnit(strings.ToUpper(raw))
+	if !slices.Contains(measureUnits, unit) {
+		return "", ErrInvalidMeasureUnit.WithParam("value", raw)
+	}
+	return unit, nil
+}
+
+// unitScales relates each unit to the base unit of what it measures, lengths in centimetres.
+// Units of different dimensions do not convert into each other.
+var unitScales = map[MeasureUnit]struct {
+	dimension string
+	scale     int64
+}{
+	MeasureUnitRunningMetre: {dimension: "length", scale: 100},
+	MeasureUnitMetre:        {dimension: "length", scale: 100},
+	MeasureUnitCentimetre:   {dimension: "length", scale: 1},
+	MeasureUnitSquareMetre:  {dimension: "area", scale: 1},
+	MeasureUnitKilogram:     {dimension: "mass", scale: 1},
+	MeasureUnitPiece:        {dimension: "count", scale: 1},
+}
+
+// ConvertQuantity expresses a quantity given in one unit in another one of the same
+// dimension, e.g. 2.5 M as 250 CM. A conversion that would lose a thousandth is refused.
+func ConvertQuantity(quantity Quantity, from, to MeasureUnit) (Quantity, error) {
+	source, ok := unitScales[from]
+	if !ok {
+		return 0, ErrInvalidMeasureUnit.WithParam("value", string(from))
+	}
+	target, ok := unitScales[to]
+	if !ok {
+		return 0, ErrInvalidMeasureUnit.WithParam("value", string(to))
+	}
+	if source.dimension != target.dimension {
+		return 0, ErrIncompatibleMeasureUnits.WithParam("from", string(from)).WithParam("to", string(to))
+	}
+
+	scaled := int64(quantity) * source.scale
+	if scaled%target.scale != 0 {
+		return 0, ErrInexactConversion.WithParam("value", quantity.String())
+	}
+	return Quantity(scaled / target.scale), nil
+}
+
+// OfferStatus is the commercial availability of a fabric.
+type OfferStatus string
+
+const (
+	OfferStatusActive       OfferStatus = "ACTIVE"
+	OfferStatusNew          OfferStatus = "NEW"
+	OfferStatusAvailable    OfferStatus = "AVAILABLE"
+	OfferStatusUnavailable  OfferStatus = "UNAVAILABLE"
+	OfferStatusOutOfStock   OfferStatus = "OUT_OF_STOCK"
+	OfferStatusPrototype    OfferStatus = "PROTOTYP"
+	OfferStatusDiscontinued OfferStatus = "DISCONTINUED"
+)
+
+var offerStatuses = []OfferStatus{
+	OfferStatusActive, OfferStatusNew, OfferStatusAvailable, OfferStatusUnavailable,
+	OfferStatusOutOfStock, OfferStatusPrototype, OfferStatusDiscontinued,
+}
+
+// offerStatusTransitions lists the offer statuses a fabric can move to from each one. A
+// prototype goes on offer as new or straight away, availability then changes freely, and
+// a discontinued offer stays so until the fabric is reactivated. Nothing returns to being
+// a prototype or new.
+var offerStatusTransitions = map[OfferStatus][]OfferStatus{
+	OfferStatusPrototype: {
+		OfferStatusNew, OfferStatusActive, OfferStatusAvailable, OfferStatusUnavailable, OfferStatusDiscontinued,
+	},
+	OfferStatusNew: {
+		OfferStatusActive, OfferStatusAvailable, OfferStatusUnavailable, OfferStatusOutOfStock, OfferStatusDiscontinued,
+	},
+	OfferStatusActive:      {OfferStatusAvailable, OfferStatusUnavailable, OfferStatusOutOfStock, OfferStatusDiscontinued},
+	OfferStatusAvailable:   {OfferStatusActive, OfferStatusUnavailable, OfferStatusOutOfStock, OfferStatusDiscontinued},
+	OfferStatusUnavailable: {OfferStatusActive, OfferStatusAvailable, OfferStatusOutOfStock, OfferStatusDiscontinued},
+	OfferStatusOutOfStock:  {OfferStatusActive, OfferStatusAvailable, OfferStatusUnavailable, OfferStatusDiscontinued},
+}
+
+// CanChangeOfferStatus reports whether the offer status of a fabric may change from from to
+// to. Keeping the status is always allowed, as is setting one on a fabric without any.
+func CanChangeOfferStatus(from, to OfferStatus) bool {
+	return from == "" || from == to || slices.Contains(offerStatusTransitions[from], to)
+}
+
+// ParseOfferStatus accepts a known status in any letter case and returns its canonical form.
+func ParseOfferStatus(raw string) (OfferStatus, error) {
+	status := OfferStatus(strings.ToUpper(raw))
+	if !slices.Contains(offerStatuses, status) {
+		return "", ErrInvalidOfferStatus.WithParam("value", raw)
+	}
+	return status, nil
+}
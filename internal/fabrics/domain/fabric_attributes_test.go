@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertQuantity(t *testing.T) {
+	testCases := []struct {
+		name        string
+		quantity    Quantity
+		from, to    MeasureUnit
+		expected    Quantity
+		expectedErr error
+	}{
+		{name: "Metres to centimetres", quantity: 2500, from: MeasureUnitMetre, to: MeasureUnitCentimetre, expected: 250000},
+		{name: "Centimetres to running metres", quantity: 150000, from: MeasureUnitCentimetre, to: MeasureUnitRunningMetre, expected: 1500},
+		{name: "Running metres are metres", quantity: 1, from: MeasureUnitRunningMetre, to: MeasureUnitMetre, expected: 1},
+		{name: "Same unit", quantity: 7250, from: MeasureUnitKilogram, to: MeasureUnitKilogram, expected: 7250},
+		{name: "Negative", quantity: -300, from: MeasureUnitMetre, to: MeasureUnitCentimetre, expected: -30000},
+		{
+			name: "Thousandth of a centimetre", quantity: 1, from: MeasureUnitCentimetre, to: MeasureUnitMetre,
+			expectedErr: ErrInexactConversion,
+		},
+		{
+			name: "Length to mass", quantity: 1000, from: MeasureUnitMetre, to: MeasureUnitKilogram,
+			expectedErr: ErrIncompatibleMeasureUnits,
+		},
+		{
+			name: "Length to area", quantity: 1000, from: MeasureUnitMetre, to: MeasureUnitSquareMetre,
+			expectedErr: ErrIncompatibleMeasureUnits,
+		},
+		{name: "Unknown unit", quantity: 1000, from: "YD", to: MeasureUnitMetre, expectedErr: ErrInvalidMeasureUnit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			converted, err := ConvertQuantity(tc.quantity, tc.from, tc.to)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, converted)
+		})
+	}
+}
+
+func TestFabric_UpdateFabric_OfferStatusTransitions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		from, to    string
+		expectedErr error
+	}{
+		{name: "Prototype goes on offer as new", from: "prototyp", to: "new"},
+		{name: "New becomes available", from: "new", to: "available"},
+		{name: "Available runs out of stock", from: "available", to: "out_of_stock"},
+		{name: "Out of stock is restocked", from: "out_of_stock", to: "available"},
+		{name: "Available is discontinued", from: "available", to: "discontinued"},
+		{name: "Status is kept", from: "discontinued", to: "discontinued"},
+		{name: "Discontinued is not offered again", from: "discontinued", to: "available", expectedErr: ErrInvalidOfferStatusTransition},
+		{name: "Available is not new again", from: "available", to: "new", expectedErr: ErrInvalidOfferStatusTransition},
+		{name: "New is not a prototype again", from: "new", to: "prototyp", expectedErr: ErrInvalidOfferStatusTransition},
+		{name: "Unknown status", from: "available", to: "withdrawn", expectedErr: ErrInvalidOfferStatus},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fabric, err := NewFabric("OFFER01", "Offer Fabric", "m", tc.from, Specification{}, FabricTexts{}, testStamp)
+			require.NoError(t, err)
+
+			// --- Act ---
+			err = fabric.UpdateFabric("Offer Fabric", "m", tc.to, nil, FabricTexts{}, fabric.Version, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Equal(t, 1, fabric.Version, "a refused change should leave the fabric untouched")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, OfferStatus(strings.ToUpper(tc.to)), fabric.OfferStatus)
+		})
+	}
+}
+
+func TestFabric_Reactivate_OffersAfresh(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric("OFFER02", "Offer Fabric", "m", "discontinued", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	require.NoError(t, fabric.Delete(fabric.Version, testStamp))
+
+	// --- Act ---
+	err = fabric.Reactivate(StatusActive, "Offer Fabric", "m", "new", Specification{}, FabricTexts{}, fabric.Version, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, OfferStatusNew, fabric.OfferStatus)
+}
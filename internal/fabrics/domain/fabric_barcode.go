@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidBarcode = validationError(
+		"invalid_barcode", "barcode", "the barcode must be an EAN-8, UPC-A, EAN-13 or GTIN-14 with a valid check digit", nil,
+	)
+	ErrBarcodeTaken = conflictError("barcode_taken", "the barcode is already assigned to a fabric")
+)
+
+// FabricBarcode is a barcode printed on the bolts of a fabric, so warehouse scanners can
+// resolve the fabric without knowing its code. A barcode belongs to a single fabric.
+type FabricBarcode struct {
+	Barcode    string    `json:"barcode"`
+	FabricCode string    `json:"fabric_code"`
+	CreatedAt  time.Time `json:"created_at"`
+	CreatedBy  string    `json:"created_by"`
+}
+
+func NewFabricBarcode(barcode, fabricCode string, stamp Stamp) (*FabricBarcode, error) {
+	barcode, err := ParseBarcode(barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FabricBarcode{
+		Barcode:    barcode,
+		FabricCode: fabricCode,
+		CreatedAt:  stamp.At,
+		CreatedBy:  stamp.By,
+	}, nil
+}
+
+// ParseBarcode reads an EAN-8, UPC-A, EAN-13 or GTIN-14 and checks its check digit. Spaces
+// are dropped and a UPC-A is read as the EAN-13 it is part of, "0" followed by its digits,
+// so a code scanned either way resolves to the same barcode.
+func ParseBarcode(raw string) (string, error) {
+	barcode := strings.ReplaceAll(strings.TrimSpace(raw), " ", "")
+	switch len(barcode) {
+	case 8, 13, 14:
+	case 12:
+		barcode = "0" + barcode
+	default:
+		return "", ErrInvalidBarcode.WithParam("value", raw)
+	}
+
+	// the check digit makes the weighted sum of all digits a multiple of 10, the weights
+	// alternating 3 and 1 from the right
+	sum := 0
+	for i := len(barcode) - 1; i >= 0; i-- {
+		if barcode[i] < '0' || barcode[i] > '9' {
+			return "", ErrInvalidBarcode.WithParam("value", raw)
+		}
+		digit := int(barcode[i] - '0')
+		if (len(barcode)-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	if sum%10 != 0 {
+		return "", ErrInvalidBarcode.WithParam("value", raw)
+	}
+	return barcode, nil
+}
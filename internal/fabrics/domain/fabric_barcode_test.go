@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBarcode(t *testing.T) {
+	testCases := []struct {
+		name        string
+		raw         string
+		expected    string
+		expectedErr error
+	}{
+		{name: "EAN-13", raw: "4006381333931", expected: "4006381333931"},
+		{name: "EAN-8", raw: "96385074", expected: "96385074"},
+		{name: "UPC-A read as EAN-13", raw: "036000291452", expected: "0036000291452"},
+		{name: "GTIN-14", raw: "10012345678902", expected: "10012345678902"},
+		{name: "Spaces dropped", raw: " 4006381 333931 ", expected: "4006381333931"},
+		{name: "Wrong check digit", raw: "4006381333932", expectedErr: ErrInvalidBarcode},
+		{name: "Not digits", raw: "40063813339A1", expectedErr: ErrInvalidBarcode},
+		{name: "Wrong length", raw: "400638133", expectedErr: ErrInvalidBarcode},
+		{name: "Empty", raw: "", expectedErr: ErrInvalidBarcode},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			barcode, err := ParseBarcode(tc.raw)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, barcode)
+		})
+	}
+}
+
+func TestNewFabricBarcode(t *testing.T) {
+	// --- Act ---
+	barcode, err := NewFabricBarcode("036000291452", "FAB01", testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, "0036000291452", barcode.Barcode)
+	assert.Equal(t, "FAB01", barcode.FabricCode)
+	assert.Equal(t, testStamp.At, barcode.CreatedAt)
+	assert.Equal(t, testStamp.By, barcode.CreatedBy)
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+// Certification schemes a fabric can be certified under.
+const (
+	CertificationOekoTex  = "oeko_tex"
+	CertificationGOTS     = "gots"
+	CertificationGRS      = "grs"
+	CertificationBluesign = "bluesign"
+)
+
+var CertificationSchemes = []string{
+	CertificationOekoTex, CertificationGOTS, CertificationGRS, CertificationBluesign,
+}
+
+var (
+	ErrInvalidCertificationScheme = validationError(
+		"invalid_certification_scheme", "scheme", "the certification scheme must be oeko_tex, gots, grs or bluesign",
+		map[string]any{"permitted": CertificationSchemes},
+	)
+	ErrInvalidCertificateNumber = validationError(
+		"invalid_certificate_number", "certificate_number", "the certificate number length must be 1-100",
+		map[string]any{"min": 1, "max": 100},
+	)
+	ErrInvalidCertificationValidity = validationError(
+		"invalid_certification_validity", "valid_until", "the certification must be valid until after it is valid from",
+		nil,
+	)
+	ErrCertificationAlreadyExpired = conflictError(
+		"certification_already_expired", "the expiry of the certification has already been recorded",
+	)
+	ErrCertificationStillValid = conflictError(
+		"certification_still_valid", "the certification is still valid",
+	)
+)
+
+// FabricCertification is a certificate a fabric holds under a certification scheme, valid
+// from ValidFrom until, but not including, ValidUntil. ExpiredAt is set once the expiry of
+// the certification has been recorded.
+type FabricCertification struct {
+	ID                string     `json:"id"`
+	FabricCode        string     `json:"fabric_code"`
+	Scheme            string     `json:"scheme"`
+	CertificateNumber string     `json:"certificate_number"`
+	ValidFrom         time.Time  `json:"valid_from"`
+	ValidUntil        time.Time  `json:"valid_until"`
+	ExpiredAt         *time.Time `json:"expired_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	CreatedBy         string     `json:"created_by"`
+	aggregate.Root
+}
+
+// FabricCertificationAdded is recorded when a certification is added to a fabric.
+type FabricCertificationAdded struct {
+	ID                string
+	FabricCode        string
+	Scheme            string
+	CertificateNumber string
+	ValidFrom         time.Time
+	ValidUntil        time.Time
+}
+
+// FabricCertificationExpired is recorded once the validity of a certification has ended.
+type FabricCertificationExpired struct {
+	ID         string
+	FabricCode string
+	Scheme     string
+	ValidUntil time.Time
+}
+
+// NewFabricCertification records the certificate the fabric holds under the scheme.
+func NewFabricCertification(
+	id, fabricCode, scheme, certificateNumber string, validFrom, validUntil time.Time, stamp Stamp,
+) (*FabricCertification, error) {
+	if !slices.Contains(CertificationSchemes, scheme) {
+		return nil, ErrInvalidCertificationScheme.WithParam("value", scheme)
+	}
+	if certificateNumber == "" || len(certificateNumber) > 100 {
+		return nil, ErrInvalidCertificateNumber
+	}
+	if !validUntil.After(validFrom) {
+		return nil, ErrInvalidCertificationValidity
+	}
+
+	certification := &FabricCertification{
+		ID:                id,
+		FabricCode:        fabricCode,
+		Scheme:            scheme,
+		CertificateNumber: certificateNumber,
+		ValidFrom:         validFrom.UTC(),
+		ValidUntil:        validUntil.UTC(),
+		CreatedAt:         stamp.At,
+		CreatedBy:         stamp.By,
+	}
+
+	event := FabricCertificationAdded{
+		ID:                certification.ID,
+		FabricCode:        certification.FabricCode,
+		Scheme:            certification.Scheme,
+		CertificateNumber: certification.CertificateNumber,
+		ValidFrom:         certification.ValidFrom,
+		ValidUntil:        certification.ValidUntil,
+	}
+	certification.Record(event)
+	return certification, nil
+}
+
+// IsActive reports whether the certification is valid at the time.
+func (c *FabricCertification) IsActive(at time.Time) bool {
+	return !at.Before(c.ValidFrom) && at.Before(c.ValidUntil)
+}
+
+// Expire records that the validity of the certification has ended by the time.
+func (c *FabricCertification) Expire(at time.Time) error {
+	if c.ExpiredAt != nil {
+		return ErrCertificationAlreadyExpired
+	}
+	if at.Before(c.ValidUntil) {
+		return ErrCertificationStillValid.WithParam("valid_until", c.ValidUntil)
+	}
+
+	expiredAt := at.UTC()
+	c.ExpiredAt = &expiredAt
+	event := FabricCertificationExpired{
+		ID:         c.ID,
+		FabricCode: c.FabricCode,
+		Scheme:     c.Scheme,
+		ValidUntil: c.ValidUntil,
+	}
+	c.Record(event)
+	return nil
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFabricCertification(t *testing.T) {
+	validFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := validFrom.AddDate(1, 0, 0)
+
+	testCases := []struct {
+		name        string
+		scheme      string
+		number      string
+		validUntil  time.Time
+		expectedErr error
+	}{
+		{name: "Valid", scheme: CertificationGOTS, number: "CU 123456", validUntil: validUntil},
+		{name: "Unknown scheme", scheme: "fairtrade", number: "FT-1", validUntil: validUntil, expectedErr: ErrInvalidCertificationScheme},
+		{name: "No certificate number", scheme: CertificationOekoTex, validUntil: validUntil, expectedErr: ErrInvalidCertificateNumber},
+		{
+			name: "Valid until before valid from", scheme: CertificationOekoTex, number: "21.HUS.12345",
+			validUntil: validFrom.AddDate(0, 0, -1), expectedErr: ErrInvalidCertificationValidity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			certification, err := NewFabricCertification("id-1", "FAB01", tc.scheme, tc.number, validFrom, tc.validUntil, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.scheme, certification.Scheme)
+			assert.Equal(t, testStamp.By, certification.CreatedBy)
+			require.Len(t, certification.Events(), 1)
+			assert.IsType(t, FabricCertificationAdded{}, certification.Events()[0])
+		})
+	}
+}
+
+func TestFabricCertification_IsActive(t *testing.T) {
+	validFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	certification := &FabricCertification{ValidFrom: validFrom, ValidUntil: validFrom.AddDate(1, 0, 0)}
+
+	assert.False(t, certification.IsActive(validFrom.Add(-time.Second)), "not yet valid")
+	assert.True(t, certification.IsActive(validFrom))
+	assert.False(t, certification.IsActive(validFrom.AddDate(1, 0, 0)), "valid until is not included")
+}
+
+func TestFabricCertification_Expire(t *testing.T) {
+	validUntil := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		expiredAt   *time.Time
+		at          time.Time
+		expectedErr error
+	}{
+		{name: "Validity ended", at: validUntil},
+		{name: "Still valid", at: validUntil.Add(-time.Second), expectedErr: ErrCertificationStillValid},
+		{name: "Expiry already recorded", expiredAt: &validUntil, at: validUntil, expectedErr: ErrCertificationAlreadyExpired},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			certification := &FabricCertification{ID: "id-1", ValidUntil: validUntil, ExpiredAt: tc.expiredAt}
+
+			// --- Act ---
+			err := certification.Expire(tc.at)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, certification.Events())
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, certification.ExpiredAt)
+			assert.Equal(t, tc.at, *certification.ExpiredAt)
+			require.Len(t, certification.Events(), 1)
+			assert.Equal(t, FabricCertificationExpired{ID: "id-1", ValidUntil: validUntil}, certification.Events()[0])
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bounds of the zero-padded number following the prefix of a generated code
+const (
+	minCodeDigits = 3
+	maxCodeDigits = 12
+)
+
+var (
+	ErrUnknownCodePrefix     = validationError("unknown_code_prefix", "prefix", "no code sequence is configured for this prefix", nil)
+	ErrCodeSequenceExhausted = conflictError("code_sequence_exhausted", "every code of the sequence has been allocated")
+)
+
+// CodeSequence generates fabric codes made of a prefix followed by a zero-padded number,
+// ZY0001 for the prefix ZY with 4 digits. The prefix keeps codes created in the UI apart
+// from the ones the ERP assigns.
+type CodeSequence struct {
+	Prefix string `json:"prefix"`
+	Digits int    `json:"digits"`
+}
+
+// Format returns the code holding the given number of the sequence.
+func (s CodeSequence) Format(number int64) (string, error) {
+	digits := strconv.FormatInt(number, 10)
+	if number < 1 || len(digits) > s.Digits {
+		return "", ErrCodeSequenceExhausted
+	}
+	return s.Prefix + strings.Repeat("0", s.Digits-len(digits)) + digits, nil
+}
+
+func (s CodeSequence) validate() error {
+	if s.Digits < minCodeDigits || s.Digits > maxCodeDigits {
+		return fmt.Errorf("sequence %q must have between %d and %d digits", s.Prefix, minCodeDigits, maxCodeDigits)
+	}
+	// the first code of the sequence must itself be a valid fabric code
+	if err := validateCode(s.Prefix + strings.Repeat("0", s.Digits)); err != nil || s.Prefix == "" {
+		return fmt.Errorf("sequence prefix %q does not make valid fabric codes", s.Prefix)
+	}
+	return nil
+}
+
+// CodeSequences are the sequences codes are allocated from, by prefix.
+type CodeSequences map[string]CodeSequence
+
+// ParseCodeSequences reads a comma-separated list of PREFIX:DIGITS pairs such as
+// "ZY:4,UI:6". An empty list configures no sequence.
+func ParseCodeSequences(raw string) (CodeSequences, error) {
+	sequences := CodeSequences{}
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		prefix, digits, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("sequence %q must be given as PREFIX:DIGITS", item)
+		}
+		sequence := CodeSequence{Prefix: strings.ToUpper(strings.TrimSpace(prefix))}
+		var err error
+		if sequence.Digits, err = strconv.Atoi(strings.TrimSpace(digits)); err != nil {
+			return nil, fmt.Errorf("sequence %q must be given as PREFIX:DIGITS", item)
+		}
+		if err := sequence.validate(); err != nil {
+			return nil, err
+		}
+		if _, dup := sequences[sequence.Prefix]; dup {
+			return nil, fmt.Errorf("sequence prefix %q is configured twice", sequence.Prefix)
+		}
+		sequences[sequence.Prefix] = sequence
+	}
+	return sequences, nil
+}
+
+// Lookup returns the sequence of the prefix, ErrUnknownCodePrefix listing the configured
+// prefixes when there is none.
+func (s CodeSequences) Lookup(prefix string) (CodeSequence, error) {
+	sequence, ok := s[prefix]
+	if !ok {
+		return CodeSequence{}, ErrUnknownCodePrefix.WithParam("allowed", s.Prefixes())
+	}
+	return sequence, nil
+}
+
+// Prefixes returns the configured prefixes in alphabetical order.
+func (s CodeSequences) Prefixes() []string {
+	prefixes := make([]string, 0, len(s))
+	for prefix := range s {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// CodeReservation records a code handed out by a sequence. A reserved code is never
+// handed out again, whether or not a fabric gets created with it.
+type CodeReservation struct {
+	Code       string    `json:"code"`
+	Prefix     string    `json:"prefix"`
+	ReservedBy string    `json:"reserved_by"`
+	ReservedAt time.Time `json:"reserved_at"`
+}
+
+func NewCodeReservation(sequence CodeSequence, stamp Stamp) *CodeReservation {
+	return &CodeReservation{
+		Prefix:     sequence.Prefix,
+		ReservedBy: stamp.By,
+		ReservedAt: stamp.At,
+	}
+}
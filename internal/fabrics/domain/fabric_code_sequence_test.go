@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCodeSequences(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		expected  CodeSequences
+		expectErr bool
+	}{
+		{name: "empty", raw: "", expected: CodeSequences{}},
+		{
+			name:     "several",
+			raw:      " zy:4 , UI:6,",
+			expected: CodeSequences{"ZY": {Prefix: "ZY", Digits: 4}, "UI": {Prefix: "UI", Digits: 6}},
+		},
+		{name: "missing digits", raw: "ZY", expectErr: true},
+		{name: "digits not a number", raw: "ZY:four", expectErr: true},
+		{name: "too few digits", raw: "ZY:2", expectErr: true},
+		{name: "prefix with a hyphen", raw: "ZY-:4", expectErr: true},
+		{name: "codes too long", raw: "ABCDEFGHIJKLMNOPQRSTUVWXYZ:6", expectErr: true},
+		{name: "prefix twice", raw: "ZY:4,zy:5", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Act ---
+			sequences, err := ParseCodeSequences(tt.raw)
+
+			// --- Assert ---
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sequences)
+		})
+	}
+}
+
+func TestCodeSequence_Format(t *testing.T) {
+	// --- Arrange ---
+	sequence := CodeSequence{Prefix: "ZY", Digits: 4}
+
+	// --- Act ---
+	first, firstErr := sequence.Format(1)
+	last, lastErr := sequence.Format(9999)
+	_, exhaustedErr := sequence.Format(10000)
+
+	// --- Assert ---
+	require.NoError(t, firstErr)
+	require.NoError(t, lastErr)
+	assert.Equal(t, "ZY0001", first)
+	assert.Equal(t, "ZY9999", last)
+	assert.NoError(t, validateCode(first), "generated codes are valid fabric codes")
+	assert.ErrorIs(t, exhaustedErr, ErrCodeSequenceExhausted)
+}
+
+func TestCodeSequences_Lookup(t *testing.T) {
+	// --- Arrange ---
+	sequences := CodeSequences{"ZY": {Prefix: "ZY", Digits: 4}, "UI": {Prefix: "UI", Digits: 6}}
+
+	// --- Act ---
+	sequence, err := sequences.Lookup("ZY")
+	_, unknownErr := sequences.Lookup("XX")
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, 4, sequence.Digits)
+	assert.ErrorIs(t, unknownErr, ErrUnknownCodePrefix)
+	domainErr, ok := AsDomainError(unknownErr)
+	require.True(t, ok)
+	assert.Equal(t, []string{"UI", "ZY"}, domainErr.Params["allowed"])
+}
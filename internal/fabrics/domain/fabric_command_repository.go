@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type FabricCommandRepository interface {
 	Save(ctx context.Context, fabric *Fabric) (*Fabric, error)
@@ -8,4 +11,156 @@ type FabricCommandRepository interface {
 	GetByCodeIncludingDeleted(ctx context.Context, code string) (*Fabric, error)
 	Update(ctx context.Context, fabric *Fabric) error
 	Delete(ctx context.Context, fabric *Fabric) error
+	ChangeStatus(ctx context.Context, fabric *Fabric) error
+	Restore(ctx context.Context, fabric *Fabric) error
+	Reactivate(ctx context.Context, fabric *Fabric) error
+	Merge(ctx context.Context, duplicate *Fabric, canonicalCode string) error
+	ChangeCode(ctx context.Context, fabric *Fabric, previousCode string) error
+	// Purge removes the deleted fabric and the rows of it, and retires its code and aliases.
+	// With purgeEvents the events it recorded are removed as well.
+	Purge(ctx context.Context, fabric *Fabric, purgeEvents bool) error
+	// CheckColor fails with ErrUnknownColor unless the color is in the palette and is not
+	// deleted.
+	CheckColor(ctx context.Context, code string) error
+}
+
+type FabricAliasRepository interface {
+	AddAlias(ctx context.Context, alias *FabricAlias) error
+	RemoveAlias(ctx context.Context, canonicalCode, aliasCode string) error
+	ListAliases(ctx context.Context, canonicalCode string) ([]*FabricAlias, error)
+}
+
+type FabricBarcodeRepository interface {
+	// AddBarcode assigns the barcode to a fabric that is neither deleted nor merged, failing
+	// with ErrBarcodeTaken when any fabric holds it already.
+	AddBarcode(ctx context.Context, barcode *FabricBarcode) error
+	RemoveBarcode(ctx context.Context, fabricCode, barcode string) error
+	ListBarcodes(ctx context.Context, fabricCode string) ([]*FabricBarcode, error)
+	GetBarcode(ctx context.Context, barcode string) (*FabricBarcode, error)
+}
+
+type FabricLockRepository interface {
+	// AcquireLock takes or renews the lock on the fabric and returns the lock in force: the
+	// acquired one, or the lock of another holder together with ErrFabricLocked.
+	AcquireLock(ctx context.Context, lock *FabricLock) (*FabricLock, error)
+	ReleaseLock(ctx context.Context, code, holder string) error
+	GetLock(ctx context.Context, code string, now time.Time) (*FabricLock, error)
+}
+
+type FabricCodeRepository interface {
+	// ReserveCode sets the reservation to the next code of the sequence that no fabric,
+	// alias, earlier reservation or purged fabric uses and records it, advancing the
+	// sequence past it.
+	ReserveCode(ctx context.Context, sequence CodeSequence, reservation *CodeReservation) error
+}
+
+type AttributeDefinitionRepository interface {
+	// SaveDefinition registers an attribute or updates its registration. Changing the type
+	// of an attribute fabrics hold values of fails with ErrAttributeDefinitionInUse.
+	SaveDefinition(ctx context.Context, definition *AttributeDefinition) error
+	GetDefinition(ctx context.Context, key string) (*AttributeDefinition, error)
+	// ListDefinitions returns every registered attribute, ordered by key.
+	ListDefinitions(ctx context.Context) ([]*AttributeDefinition, error)
+	// DeleteDefinition removes the registration of an attribute no fabric holds a value of.
+	DeleteDefinition(ctx context.Context, key string) error
+}
+
+type FibreRepository interface {
+	// SaveFibre adds a fibre to the reference list or renames it.
+	SaveFibre(ctx context.Context, fibre *Fibre) error
+	// ListFibres returns the reference list of fibres, ordered by code.
+	ListFibres(ctx context.Context) ([]*Fibre, error)
+	// DeleteFibre removes a fibre no fabric is made of from the reference list.
+	DeleteFibre(ctx context.Context, code string) error
+}
+
+type FabricDraftRepository interface {
+	SaveDraft(ctx context.Context, draft *FabricDraft) error
+	GetDraft(ctx context.Context, id int64) (*FabricDraft, error)
+	ListPendingDrafts(ctx context.Context, code string) ([]*FabricDraft, error)
+	ResolveDraft(ctx context.Context, draft *FabricDraft) error
+}
+
+type FabricProposalRepository interface {
+	// SaveProposal queues a proposal for review. A redelivered ERP event is queued only once,
+	// in which case the ID of the proposal is left zero.
+	SaveProposal(ctx context.Context, proposal *FabricProposal) error
+	GetProposal(ctx context.Context, id int64) (*FabricProposal, error)
+	// ListPendingProposals returns a page of proposals awaiting review, oldest first,
+	// together with their total number.
+	ListPendingProposals(ctx context.Context, filter ProposalFilter, limit, offset int) ([]*FabricProposal, int, error)
+	ResolveProposal(ctx context.Context, proposal *FabricProposal) error
+}
+
+// ProposalFilter narrows pending proposals to a fabric and to the one who submitted them.
+// Empty fields do not filter.
+type ProposalFilter struct {
+	Code      string
+	CreatedBy string
+}
+
+type FabricConflictRepository interface {
+	SaveConflict(ctx context.Context, conflict *FabricConflict) error
+	GetConflict(ctx context.Context, id int64) (*FabricConflict, error)
+	ListPendingConflicts(ctx context.Context, limit, offset int) ([]*FabricConflict, int, error)
+	ResolveConflict(ctx context.Context, conflict *FabricConflict) error
+}
+
+type FabricPendingEventRepository interface {
+	ParkEvent(ctx context.Context, event *PendingFabricEvent) error
+	NextPendingEvent(ctx context.Context, code string, version int) (*PendingFabricEvent, error)
+	DueForRetry(ctx context.Context, now time.Time, limit int) ([]*PendingFabricEvent, error)
+	ScheduleRetry(ctx context.Context, event *PendingFabricEvent) error
+	MarkResolved(ctx context.Context, id int64, status string) error
+	ExpirePendingEvents(ctx context.Context, receivedBefore time.Time) ([]*PendingFabricEvent, error)
+}
+
+type FabricDuplicateRepository interface {
+	// SaveDuplicate queues a duplicate for review, unless the pair has been flagged before,
+	// in which case the ID of the duplicate is left zero.
+	SaveDuplicate(ctx context.Context, duplicate *FabricDuplicate) error
+	GetDuplicate(ctx context.Context, id int64) (*FabricDuplicate, error)
+	ListPendingDuplicates(ctx context.Context, limit, offset int) ([]*FabricDuplicate, int, error)
+	ResolveDuplicate(ctx context.Context, duplicate *FabricDuplicate) error
+}
+
+type FabricStockRepository interface {
+	// GetStock returns the stock of a fabric that is neither deleted nor merged, looked up
+	// by its code or an alias. A fabric that never had stock gets an empty one at version 0.
+	GetStock(ctx context.Context, code string) (*FabricStock, error)
+	// SaveStock stores the stock at its new version with its locations and reservations,
+	// or fails with ErrConcurrencyConflict when it was changed since it was loaded.
+	SaveStock(ctx context.Context, stock *FabricStock) error
+	// CheckWarehouse fails with ErrUnknownWarehouse unless the warehouse exists and is not
+	// deleted.
+	CheckWarehouse(ctx context.Context, code string) error
+	// DueForReservationExpiry returns the codes of up to limit fabrics, neither deleted nor
+	// merged, holding a reservation expired at the time, the earliest expired first.
+	DueForReservationExpiry(ctx context.Context, at time.Time, limit int) ([]string, error)
+}
+
+type FabricAttachmentRepository interface {
+	// GetFabricCode returns the canonical code of a fabric that is neither deleted nor
+	// merged, given by its code or one of its aliases.
+	GetFabricCode(ctx context.Context, code string) (string, error)
+	SaveAttachment(ctx context.Context, attachment *FabricAttachment) error
+	GetAttachment(ctx context.Context, fabricCode, id string) (*FabricAttachment, error)
+	// ListAttachments returns the attachments of the fabric, oldest first.
+	ListAttachments(ctx context.Context, fabricCode string) ([]*FabricAttachment, error)
+	DeleteAttachment(ctx context.Context, attachment *FabricAttachment) error
+}
+
+type FabricCertificationRepository interface {
+	// GetFabricCode returns the canonical code of a fabric that is neither deleted nor
+	// merged, given by its code or one of its aliases.
+	GetFabricCode(ctx context.Context, code string) (string, error)
+	SaveCertification(ctx context.Context, certification *FabricCertification) error
+	// ListCertifications returns the certifications of the fabric, the longest valid first.
+	ListCertifications(ctx context.Context, fabricCode string) ([]*FabricCertification, error)
+	// DueForExpiry returns up to limit certifications no longer valid at the time whose
+	// expiry has not been recorded yet, the earliest ended first.
+	DueForExpiry(ctx context.Context, at time.Time, limit int) ([]*FabricCertification, error)
+	// MarkExpired records the expiry of the certification. It fails with
+	// ErrCertificationAlreadyExpired when another sweep recorded it first.
+	MarkExpired(ctx context.Context, certification *FabricCertification) error
 }
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+)
+
+var (
+	ErrConflictAlreadyResolved = conflictError("conflict_already_resolved", "the conflict has already been resolved")
+)
+
+const (
+	ConflictStatusPending   = "PENDING"
+	ConflictStatusApplied   = "APPLIED"
+	ConflictStatusDiscarded = "DISCARDED"
+)
+
+// FabricConflict is an ERP change that could not be applied because its version did not
+// match the stored fabric, parked until someone decides to apply or discard it.
+type FabricConflict struct {
+	ID              int64
+	EventID         string
+	EventType       string
+	Code            string
+	Name            string
+	MeasureUnit     string
+	OfferStatus     string
+	ExpectedVersion int
+	CurrentVersion  int
+	Status          string
+	CreatedAt       time.Time
+	ResolvedAt      *time.Time
+	ResolvedBy      string
+}
+
+// Resolve marks a pending conflict as applied or discarded.
+func (c *FabricConflict) Resolve(status string, stamp Stamp) error {
+	if c.Status != ConflictStatusPending {
+		return ErrConflictAlreadyResolved
+	}
+	c.Status = status
+	at := stamp.At
+	c.ResolvedAt = &at
+	c.ResolvedBy = stamp.By
+	return nil
+}
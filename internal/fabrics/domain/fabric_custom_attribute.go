@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidAttributeKey = validationError(
+		"invalid_attribute_key", "key",
+		"the attribute key must be 2-50 lowercase letters, digits or underscores, starting with a letter",
+		map[string]any{"pattern": "^[a-z][a-z0-9_]{1,49}$"},
+	)
+	ErrInvalidAttributeType = validationError(
+		"invalid_attribute_type", "type", "the attribute type must be string, number or boolean", nil,
+	)
+	ErrUnknownAttribute = validationError(
+		"unknown_attribute", "custom_attributes", "the attribute is not registered", nil,
+	)
+	ErrAttributeTypeMismatch = validationError(
+		"attribute_type_mismatch", "custom_attributes", "the value does not match the registered type of the attribute", nil,
+	)
+	ErrRequiredAttributeMissing = validationError(
+		"required_attribute_missing", "custom_attributes", "a required attribute is missing", nil,
+	)
+	ErrAttributeDefinitionNotFound = notFoundError("attribute_definition_not_found", "the attribute is not registered")
+	ErrAttributeDefinitionInUse    = conflictError(
+		"attribute_definition_in_use", "fabrics hold values of the attribute, its type cannot change nor can it be removed",
+	)
+)
+
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// AttributeType is the type of the values of a custom attribute, named after JSON types.
+type AttributeType string
+
+const (
+	AttributeTypeString  AttributeType = "string"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+)
+
+// AttributeDefinition registers a custom attribute integrators may attach to fabrics,
+// with the type its values must have and whether every fabric must carry it.
+type AttributeDefinition struct {
+	Key         string        `json:"key"`
+	Type        AttributeType `json:"type"`
+	Required    bool          `json:"required"`
+	Description string        `json:"description,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	CreatedBy   string        `json:"created_by"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	UpdatedBy   string        `json:"updated_by"`
+}
+
+// FabricCustomAttributesChanged is recorded when the custom attributes of a fabric are set,
+// it carries all of them.
+type FabricCustomAttributesChanged struct {
+	Code             string
+	CustomAttributes map[string]any
+	Version          int
+}
+
+// NewAttributeDefinition registers the custom attribute under the key.
+func NewAttributeDefinition(
+	key string, attributeType AttributeType, required bool, description string, stamp Stamp,
+) (*AttributeDefinition, error) {
+	if !attributeKeyPattern.MatchString(key) {
+		return nil, ErrInvalidAttributeKey.WithParam("value", key)
+	}
+	switch attributeType {
+	case AttributeTypeString, AttributeTypeNumber, AttributeTypeBoolean:
+	default:
+		return nil, ErrInvalidAttributeType.WithParam("value", string(attributeType))
+	}
+
+	return &AttributeDefinition{
+		Key:         key,
+		Type:        attributeType,
+		Required:    required,
+		Description: description,
+		CreatedAt:   stamp.At,
+		CreatedBy:   stamp.By,
+		UpdatedAt:   stamp.At,
+		UpdatedBy:   stamp.By,
+	}, nil
+}
+
+// accepts reports whether a decoded JSON value has the type of the attribute
+func (d *AttributeDefinition) accepts(value any) bool {
+	switch value.(type) {
+	case string:
+		return d.Type == AttributeTypeString
+	case float64, int:
+		return d.Type == AttributeTypeNumber
+	case bool:
+		return d.Type == AttributeTypeBoolean
+	}
+	return false
+}
+
+// ValidateCustomAttributes checks values decoded from JSON against the registered
+// attributes: every key must be registered, every value must have its type and every
+// required attribute must be present.
+func ValidateCustomAttributes(values map[string]any, definitions []*AttributeDefinition) error {
+	byKey := make(map[string]*AttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		definition, ok := byKey[key]
+		if !ok {
+			return ErrUnknownAttribute.WithParam("key", key)
+		}
+		if !definition.accepts(values[key]) {
+			return ErrAttributeTypeMismatch.WithParam("key", key).WithParam("type", string(definition.Type))
+		}
+	}
+	for _, definition := range definitions {
+		if _, ok := values[definition.Key]; definition.Required && !ok {
+			return ErrRequiredAttributeMissing.WithParam("key", definition.Key)
+		}
+	}
+	return nil
+}
+
+// SetCustomAttributes replaces the custom attributes of a draft, active or discontinued
+// fabric. Required attributes are enforced from the moment the attributes are set, fabrics
+// that never had any are not affected by registering a required attribute.
+func (f *Fabric) SetCustomAttributes(
+	values map[string]any, definitions []*AttributeDefinition, version int, stamp Stamp,
+) error {
+	if err := f.checkEditable(); err != nil {
+		return err
+	}
+	if f.Version != version {
+		return versionConflict(f.Version, f.attributeConflicts(values))
+	}
+	if err := ValidateCustomAttributes(values, definitions); err != nil {
+		return err
+	}
+
+	f.CustomAttributes = nil
+	if len(values) > 0 {
+		f.CustomAttributes = maps.Clone(values)
+	}
+	f.Version++
+	f.touch(stamp)
+
+	event := FabricCustomAttributesChanged{
+		Code:             f.Code,
+		CustomAttributes: maps.Clone(values),
+		Version:          f.Version,
+	}
+	f.Record(event)
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAttributeDefinition(t *testing.T) {
+	testCases := []struct {
+		name          string
+		key           string
+		attributeType AttributeType
+		expectedErr   error
+	}{
+		{name: "Valid", key: "mill_batch", attributeType: AttributeTypeString},
+		{name: "Uppercase key", key: "MillBatch", attributeType: AttributeTypeString, expectedErr: ErrInvalidAttributeKey},
+		{name: "Key starting with a digit", key: "2nd_mill", attributeType: AttributeTypeString, expectedErr: ErrInvalidAttributeKey},
+		{name: "Unknown type", key: "woven_on", attributeType: "date", expectedErr: ErrInvalidAttributeType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			definition, err := NewAttributeDefinition(tc.key, tc.attributeType, true, "", testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.key, definition.Key)
+			assert.True(t, definition.Required)
+			assert.Equal(t, testStamp.By, definition.CreatedBy)
+		})
+	}
+}
+
+func TestValidateCustomAttributes(t *testing.T) {
+	definitions := []*AttributeDefinition{
+		{Key: "gots_certified", Type: AttributeTypeBoolean, Required: true},
+		{Key: "mill_batch", Type: AttributeTypeString},
+		{Key: "shrinkage_pct", Type: AttributeTypeNumber},
+	}
+
+	testCases := []struct {
+		name        string
+		values      map[string]any
+		expectedErr error
+	}{
+		{name: "Valid", values: map[string]any{"gots_certified": true, "mill_batch": "B-17", "shrinkage_pct": 2.5}},
+		{name: "Only the required ones", values: map[string]any{"gots_certified": false}},
+		{name: "Unknown attribute", values: map[string]any{"gots_certified": true, "weave": "twill"}, expectedErr: ErrUnknownAttribute},
+		{name: "Wrong type", values: map[string]any{"gots_certified": true, "shrinkage_pct": "2.5"}, expectedErr: ErrAttributeTypeMismatch},
+		{name: "Null value", values: map[string]any{"gots_certified": nil}, expectedErr: ErrAttributeTypeMismatch},
+		{name: "Required attribute missing", values: map[string]any{"mill_batch": "B-17"}, expectedErr: ErrRequiredAttributeMissing},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			err := ValidateCustomAttributes(tc.values, definitions)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFabric_SetCustomAttributes(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric("FAB01", "Linen", "m", "active", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	definitions := []*AttributeDefinition{{Key: "mill_batch", Type: AttributeTypeString}}
+	values := map[string]any{"mill_batch": "B-17"}
+
+	// --- Act ---
+	err = fabric.SetCustomAttributes(values, definitions, 1, testStamp)
+	values["mill_batch"] = "changed by the caller"
+	staleErr := fabric.SetCustomAttributes(map[string]any{}, definitions, 1, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"mill_batch": "B-17"}, fabric.CustomAttributes, "the fabric keeps its own copy")
+	assert.Equal(t, 2, fabric.Version)
+	assert.ErrorIs(t, staleErr, ErrConcurrencyConflict)
+	events := fabric.Events()
+	require.IsType(t, FabricCustomAttributesChanged{}, events[len(events)-1])
+	assert.Equal(t, 2, events[len(events)-1].(FabricCustomAttributesChanged).Version)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+var (
+	ErrDraftAlreadyResolved = conflictError("draft_already_resolved", "the draft has already been applied or discarded")
+)
+
+const (
+	DraftStatusPending   = "PENDING"
+	DraftStatusApplied   = "APPLIED"
+	DraftStatusDiscarded = "DISCARDED"
+)
+
+// FabricDraft is a prepared change of a fabric's attributes. It records no events until it
+// is applied, and applying it fails when the fabric moved past the version it was based on.
+type FabricDraft struct {
+	ID          int64       `json:"id"`
+	Code        string      `json:"code"`
+	Name        string      `json:"name"`
+	MeasureUnit MeasureUnit `json:"measure_unit"`
+	OfferStatus OfferStatus `json:"offer_status"`
+	BaseVersion int         `json:"base_version"`
+	Status      string      `json:"status"`
+	CreatedAt   time.Time   `json:"created_at"`
+	CreatedBy   string      `json:"created_by"`
+	ResolvedAt  *time.Time  `json:"resolved_at,omitempty"`
+	ResolvedBy  string      `json:"resolved_by,omitempty"`
+}
+
+// NewFabricDraft prepares a change of the given fabric, validated like an update would be.
+func NewFabricDraft(fabric *Fabric, name, measureUnit, offerStatus string, stamp Stamp) (*FabricDraft, error) {
+	if err := fabric.checkEditable(); err != nil {
+		return nil, err
+	}
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	unit, status, err := parseAttributes(measureUnit, offerStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FabricDraft{
+		Code:        fabric.Code,
+		Name:        name,
+		MeasureUnit: unit,
+		OfferStatus: status,
+		BaseVersion: fabric.Version,
+		Status:      DraftStatusPending,
+		CreatedAt:   stamp.At,
+		CreatedBy:   stamp.By,
+	}, nil
+}
+
+// Resolve marks a pending draft as applied or discarded.
+func (d *FabricDraft) Resolve(status string, stamp Stamp) error {
+	if d.Status != DraftStatusPending {
+		return ErrDraftAlreadyResolved
+	}
+	d.Status = status
+	at := stamp.At
+	d.ResolvedAt = &at
+	d.ResolvedBy = stamp.By
+	return nil
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFabricDraft(t *testing.T) {
+	// --- Arrange ---
+	stamp := Stamp{By: "user_42", At: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	fabric, err := NewFabric("FAB01", "Linen", "mb", "active", Specification{}, FabricTexts{}, stamp)
+	require.NoError(t, err)
+	deleted, err := NewFabric("FAB02", "Wool", "mb", "active", Specification{}, FabricTexts{}, stamp)
+	require.NoError(t, err)
+	require.NoError(t, deleted.Delete(1, stamp))
+
+	// --- Act ---
+	draft, err := NewFabricDraft(fabric, "Reworked Linen", "m", "new", stamp)
+	_, invalidErr := NewFabricDraft(fabric, "", "m", "new", stamp)
+	_, deletedErr := NewFabricDraft(deleted, "Wool", "m", "new", stamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, "FAB01", draft.Code)
+	assert.Equal(t, MeasureUnitMetre, draft.MeasureUnit)
+	assert.Equal(t, OfferStatusNew, draft.OfferStatus)
+	assert.Equal(t, 1, draft.BaseVersion)
+	assert.Equal(t, DraftStatusPending, draft.Status)
+	assert.Empty(t, fabric.Events()[1:], "drafting records no events on the fabric")
+	assert.ErrorIs(t, invalidErr, ErrInvalidFabricNameLength)
+	assert.ErrorIs(t, deletedErr, ErrFabricDeleted)
+}
+
+func TestFabricDraft_Resolve(t *testing.T) {
+	// --- Arrange ---
+	stamp := Stamp{By: "reviewer", At: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	draft := &FabricDraft{Status: DraftStatusPending}
+
+	// --- Act ---
+	err := draft.Resolve(DraftStatusApplied, stamp)
+	againErr := draft.Resolve(DraftStatusDiscarded, stamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, DraftStatusApplied, draft.Status)
+	assert.Equal(t, "reviewer", draft.ResolvedBy)
+	assert.ErrorIs(t, againErr, ErrDraftAlreadyResolved)
+}
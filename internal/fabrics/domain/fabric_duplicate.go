@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDuplicateAlreadyResolved = conflictError(
+		"duplicate_already_resolved", "the duplicate has already been merged or dismissed",
+	)
+)
+
+const (
+	DuplicateStatusPending   = "PENDING"
+	DuplicateStatusMerged    = "MERGED"
+	DuplicateStatusDismissed = "DISMISSED"
+
+	// DuplicateReasonSimilarName flags fabrics whose names barely differ.
+	DuplicateReasonSimilarName = "SIMILAR_NAME"
+	// DuplicateReasonSameSpecification flags fabrics made of the same composition, in the
+	// same width, weight and color.
+	DuplicateReasonSameSpecification = "SAME_SPECIFICATION"
+
+	// DuplicateNameThreshold is the name similarity from which two fabrics are flagged.
+	DuplicateNameThreshold = 0.8
+)
+
+// FabricDuplicate is a pair of fabrics that probably describe the same product, queued
+// until someone merges the newer fabric into the older one or dismisses the finding.
+type FabricDuplicate struct {
+	ID            int64      `json:"id"`
+	Code          string     `json:"code"`
+	CanonicalCode string     `json:"canonical_code"`
+	Reason        string     `json:"reason"`
+	NameScore     float64    `json:"name_score"`
+	Status        string     `json:"status"`
+	DetectedAt    time.Time  `json:"detected_at"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+	ResolvedBy    string     `json:"resolved_by,omitempty"`
+}
+
+// Resolve marks a pending duplicate as merged or dismissed.
+func (d *FabricDuplicate) Resolve(status string, stamp Stamp) error {
+	if d.Status != DuplicateStatusPending {
+		return ErrDuplicateAlreadyResolved
+	}
+	d.Status = status
+	at := stamp.At
+	d.ResolvedAt = &at
+	d.ResolvedBy = stamp.By
+	return nil
+}
+
+// FindDuplicates compares every pair of the given fabrics and returns the probable
+// duplicates, each pointing from the newer fabric to the older one. The comparison is
+// quadratic, it is meant for a periodic scan of the catalog rather than per request.
+func FindDuplicates(fabrics []*Fabric, detectedAt time.Time) []*FabricDuplicate {
+	names := make([]map[string]int, len(fabrics))
+	for i, fabric := range fabrics {
+		names[i] = bigrams(fabric.Name)
+	}
+
+	var duplicates []*FabricDuplicate
+	for i := range fabrics {
+		for j := i + 1; j < len(fabrics); j++ {
+			score := diceCoefficient(names[i], names[j])
+			reason := ""
+			switch {
+			case sameSpecification(fabrics[i].Specification, fabrics[j].Specification):
+				reason = DuplicateReasonSameSpecification
+			case score >= DuplicateNameThreshold:
+				reason = DuplicateReasonSimilarName
+			default:
+				continue
+			}
+
+			newer, older := fabrics[i], fabrics[j]
+			if older.CreatedAt.After(newer.CreatedAt) ||
+				(older.CreatedAt.Equal(newer.CreatedAt) && older.Code > newer.Code) {
+				newer, older = older, newer
+			}
+			duplicates = append(duplicates, &FabricDuplicate{
+				Code:          newer.Code,
+				CanonicalCode: older.Code,
+				Reason:        reason,
+				NameScore:     score,
+				Status:        DuplicateStatusPending,
+				DetectedAt:    detectedAt,
+			})
+		}
+	}
+	return duplicates
+}
+
+// sameSpecification reports whether both fabrics have a known composition and agree on
+// every attribute of their specification, regardless of the order of the fibres.
+func sameSpecification(a, b Specification) bool {
+	if len(a.Composition) == 0 || len(a.Composition) != len(b.Composition) {
+		return false
+	}
+	if a.WidthCM != b.WidthCM || a.WeightGSM != b.WeightGSM || !strings.EqualFold(a.Color, b.Color) {
+		return false
+	}
+	sorted := func(parts []CompositionPart) []CompositionPart {
+		parts = slices.Clone(parts)
+		slices.SortFunc(parts, func(x, y CompositionPart) int {
+			return strings.Compare(strings.ToLower(x.Fibre), strings.ToLower(y.Fibre))
+		})
+		return parts
+	}
+	return slices.EqualFunc(sorted(a.Composition), sorted(b.Composition), func(x, y CompositionPart) bool {
+		return strings.EqualFold(x.Fibre, y.Fibre) && x.Percent == y.Percent
+	})
+}
+
+// bigrams counts the letter pairs of a name, ignoring case and runs of whitespace.
+func bigrams(name string) map[string]int {
+	runes := []rune(strings.ToLower(strings.Join(strings.Fields(name), " ")))
+	counts := make(map[string]int, len(runes))
+	for i := 0; i+1 < len(runes); i++ {
+		counts[string(runes[i:i+2])]++
+	}
+	return counts
+}
+
+// diceCoefficient scores the similarity of two bigram sets from 0, nothing in common, to 1.
+func diceCoefficient(a, b map[string]int) float64 {
+	total := 0
+	for _, n := range a {
+		total += n
+	}
+	for _, n := range b {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+
+	shared := 0
+	for pair, n := range a {
+		shared += min(n, b[pair])
+	}
+	return 2 * float64(shared) / float64(total)
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func duplicateTestFabric(code, name string, createdAt time.Time, spec Specification) *Fabric {
+	return &Fabric{Code: code, Name: name, Specification: spec, Status: StatusActive, CreatedAt: createdAt}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	earlier := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	cotton := Specification{
+		Composition: []CompositionPart{{Fibre: "cotton", Percent: 95}, {Fibre: "elastane", Percent: 5}},
+		WidthCM:     145,
+	}
+
+	testCases := []struct {
+		name           string
+		fabrics        []*Fabric
+		expectedReason string
+		expectedCode   string
+		expectedCanon  string
+	}{
+		{
+			name: "Similar names",
+			fabrics: []*Fabric{
+				duplicateTestFabric("ZOYA2", "Zoya  Velvet Blue", later, Specification{}),
+				duplicateTestFabric("ZOYA1", "zoya velvet blue", earlier, Specification{}),
+			},
+			expectedReason: DuplicateReasonSimilarName,
+			expectedCode:   "ZOYA2",
+			expectedCanon:  "ZOYA1",
+		},
+		{
+			name: "Same specification in another fibre order",
+			fabrics: []*Fabric{
+				duplicateTestFabric("DENIM1", "Denim Classic", earlier, cotton),
+				duplicateTestFabric("JEANS7", "Jeans Stretch", later, Specification{
+					Composition: []CompositionPart{{Fibre: "Elastane", Percent: 5}, {Fibre: "Cotton", Percent: 95}},
+					WidthCM:     145,
+				}),
+			},
+			expectedReason: DuplicateReasonSameSpecification,
+			expectedCode:   "JEANS7",
+			expectedCanon:  "DENIM1",
+		},
+		{
+			name: "Same creation time orders by code",
+			fabrics: []*Fabric{
+				duplicateTestFabric("LIN2", "Linen Natural", earlier, Specification{}),
+				duplicateTestFabric("LIN1", "Linen Natural", earlier, Specification{}),
+			},
+			expectedReason: DuplicateReasonSimilarName,
+			expectedCode:   "LIN2",
+			expectedCanon:  "LIN1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			duplicates := FindDuplicates(tc.fabrics, later)
+
+			// --- Assert ---
+			require.Len(t, duplicates, 1)
+			assert.Equal(t, tc.expectedReason, duplicates[0].Reason)
+			assert.Equal(t, tc.expectedCode, duplicates[0].Code)
+			assert.Equal(t, tc.expectedCanon, duplicates[0].CanonicalCode)
+			assert.Equal(t, DuplicateStatusPending, duplicates[0].Status)
+		})
+	}
+}
+
+func TestFindDuplicates_DistinctFabrics(t *testing.T) {
+	// --- Arrange ---
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fabrics := []*Fabric{
+		duplicateTestFabric("VELVET1", "Velvet Royal", at, Specification{}),
+		duplicateTestFabric("LINEN1", "Linen Natural", at, Specification{}),
+		// an unknown composition is not evidence of a duplicate
+		duplicateTestFabric("WOOL1", "Wool Melange", at, Specification{WidthCM: 150}),
+		duplicateTestFabric("SILK1", "Silk Charmeuse", at, Specification{WidthCM: 150}),
+	}
+
+	// --- Act ---
+	duplicates := FindDuplicates(fabrics, at)
+
+	// --- Assert ---
+	assert.Empty(t, duplicates)
+}
+
+func TestFabricDuplicate_Resolve(t *testing.T) {
+	// --- Arrange ---
+	duplicate := &FabricDuplicate{Code: "ZOYA2", CanonicalCode: "ZOYA1", Status: DuplicateStatusPending}
+
+	// --- Act ---
+	err := duplicate.Resolve(DuplicateStatusDismissed, testStamp)
+	again := duplicate.Resolve(DuplicateStatusMerged, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, DuplicateStatusDismissed, duplicate.Status)
+	assert.Equal(t, testStamp.By, duplicate.ResolvedBy)
+	assert.ErrorIs(t, again, ErrDuplicateAlreadyResolved)
+}
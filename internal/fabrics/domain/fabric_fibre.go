@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidFibreCode = validationError(
+		"invalid_fibre_code", "code",
+		"the fibre code must be 2-50 lowercase letters, digits or underscores, starting with a letter",
+		map[string]any{"pattern": "^[a-z][a-z0-9_]{1,49}$"},
+	)
+	ErrInvalidFibreName = validationError(
+		"invalid_fibre_name", "name", "the fibre name length must be 1-100", map[string]any{"min": 1, "max": 100},
+	)
+	ErrUnknownFibre = validationError(
+		"unknown_fibre", "composition", "the fibre is not on the reference list of fibres", nil,
+	)
+	ErrFibreNotFound = notFoundError("fibre_not_found", "the fibre is not on the reference list")
+	ErrFibreInUse    = conflictError("fibre_in_use", "fabrics are made of the fibre, it cannot be removed")
+)
+
+var fibreCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// Fibre is an entry of the managed reference list of fibres the composition of a fabric
+// may name, compared regardless of case.
+type Fibre struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
+
+// NewFibre adds the fibre under the code to the reference list.
+func NewFibre(code, name string, stamp Stamp) (*Fibre, error) {
+	if !fibreCodePattern.MatchString(code) {
+		return nil, ErrInvalidFibreCode.WithParam("value", code)
+	}
+	if name == "" || len(name) > 100 {
+		return nil, ErrInvalidFibreName
+	}
+
+	return &Fibre{
+		Code:      code,
+		Name:      name,
+		CreatedAt: stamp.At,
+		CreatedBy: stamp.By,
+	}, nil
+}
+
+// CompositionValidator is the domain service checking a composition against the reference
+// list of fibres on top of its own rules, so REST commands and ERP events are held to the
+// same list.
+type CompositionValidator struct {
+	fibres map[string]bool
+}
+
+func NewCompositionValidator(fibres []*Fibre) *CompositionValidator {
+	known := make(map[string]bool, len(fibres))
+	for _, fibre := range fibres {
+		known[strings.ToLower(fibre.Code)] = true
+	}
+	return &CompositionValidator{fibres: known}
+}
+
+// Validate checks that the composition names each fibre once, that its shares add up to
+// 100 percent and that every fibre is on the reference list.
+func (v *CompositionValidator) Validate(composition []CompositionPart) error {
+	if err := validateComposition(composition); err != nil {
+		return err
+	}
+	for _, part := range composition {
+		if !v.fibres[strings.ToLower(part.Fibre)] {
+			return ErrUnknownFibre.WithParam("fibre", part.Fibre)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFibre(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        string
+		fibreName   string
+		expectedErr error
+	}{
+		{name: "Valid", code: "recycled_polyester", fibreName: "Recycled polyester"},
+		{name: "Uppercase code", code: "Cotton", fibreName: "Cotton", expectedErr: ErrInvalidFibreCode},
+		{name: "Single letter code", code: "c", fibreName: "Cotton", expectedErr: ErrInvalidFibreCode},
+		{name: "Unnamed", code: "cotton", expectedErr: ErrInvalidFibreName},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			fibre, err := NewFibre(tc.code, tc.fibreName, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.code, fibre.Code)
+			assert.Equal(t, testStamp.By, fibre.CreatedBy)
+		})
+	}
+}
+
+func TestCompositionValidator_Validate(t *testing.T) {
+	validator := NewCompositionValidator([]*Fibre{{Code: "cotton"}, {Code: "elastane"}, {Code: "wool"}})
+
+	testCases := []struct {
+		name        string
+		composition []CompositionPart
+		expectedErr error
+	}{
+		{name: "Unspecified"},
+		{
+			name:        "Fibres on the list",
+			composition: []CompositionPart{{Fibre: "cotton", Percent: 95}, {Fibre: "Elastane", Percent: 5}},
+		},
+		{
+			name:        "Fibre off the list",
+			composition: []CompositionPart{{Fibre: "wool", Percent: 60}, {Fibre: "cashmere", Percent: 40}},
+			expectedErr: ErrUnknownFibre,
+		},
+		{
+			name:        "Shares not adding up to 100 percent",
+			composition: []CompositionPart{{Fibre: "wool", Percent: 60}},
+			expectedErr: ErrInvalidCompositionTotal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			err := validator.Validate(tc.composition)
+
+			// --- Assert ---
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedErr)
+			domainErr, ok := AsDomainError(err)
+			require.True(t, ok)
+			assert.Equal(t, "composition", domainErr.Field, "violations name the offending field")
+		})
+	}
+}
+
+func TestCompositionValidator_NamesUnknownFibre(t *testing.T) {
+	// --- Act ---
+	err := NewCompositionValidator(nil).Validate([]CompositionPart{{Fibre: "Kevlar", Percent: 100}})
+
+	// --- Assert ---
+	domainErr, ok := AsDomainError(err)
+	require.True(t, ok)
+	assert.Equal(t, "unknown_fibre", domainErr.Code)
+	assert.Equal(t, "Kevlar", domainErr.Params["fibre"])
+}
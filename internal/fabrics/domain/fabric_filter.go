@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// FabricFilter narrows and orders a listing of fabrics. Every criterion is optional and
+// the set ones are combined, fabrics are limited to active ones unless Status says otherwise.
+// CodePrefix selects the fabrics whose code starts with it, such as the codes of a
+// collection. Certification selects the fabrics holding a certification of the scheme
+// valid right now. Sort lists the columns to order by, see SortKeys.
+type FabricFilter struct {
+	Status        string
+	OfferStatus   OfferStatus
+	MeasureUnit   MeasureUnit
+	CodePrefix    string
+	Search        string
+	UpdatedSince  *time.Time
+	Certification string
+	Sort          string
+	Limit         int
+	Offset        int
+}
+
+// returns the fabric status to list, active fabrics by default
+func (f FabricFilter) StatusOrDefault() string {
+	if f.Status == "" {
+		return StatusActive
+	}
+	return f.Status
+}
+
+// ErrUnsortableColumn is returned by a listing asked to sort by a column it does not offer.
+var ErrUnsortableColumn = validationError(
+	"unsortable_column", "sort", "the fabrics cannot be sorted by this column", nil,
+)
+
+// SortKey is one of the columns a listing is sorted by, in order of precedence.
+type SortKey struct {
+	Column     string
+	Descending bool
+}
+
+// SortKeys reads Sort, a comma-separated list of columns each descending when prefixed
+// with "-", such as "name,-version".
+func (f FabricFilter) SortKeys() []SortKey {
+	if f.Sort == "" {
+		return nil
+	}
+	var keys []SortKey
+	for _, raw := range strings.Split(f.Sort, ",") {
+		column, descending := strings.CutPrefix(raw, "-")
+		keys = append(keys, SortKey{Column: column, Descending: descending})
+	}
+	return keys
+}
+
+// ChangedSince selects the fabrics changed after an event store position or, when Time is
+// set, after a point in time. Fabrics of every status are selected, so a downstream copy
+// also learns of deletions.
+type ChangedSince struct {
+	Position int64
+	Time     *time.Time
+}
@@ -0,0 +1,134 @@
+package domain
+
+var ErrInvalidStatusTransition = conflictError(
+	"invalid_status_transition", "the fabric cannot move from its current status to the requested one",
+)
+
+// statusTransitions lists the statuses a fabric can move to from each status. A draft is
+// activated once ready; an active fabric is discontinued when it goes off offer and can
+// be activated again until it is archived, which keeps it only for the record. Deleted
+// fabrics can be restored, merged ones are final.
+var statusTransitions = map[string][]string{
+	StatusDraft:        {StatusActive, StatusDeleted},
+	StatusActive:       {StatusDiscontinued, StatusDeleted, StatusMerged},
+	StatusDiscontinued: {StatusActive, StatusArchived, StatusDeleted, StatusMerged},
+	StatusArchived:     {StatusDeleted},
+	StatusDeleted:      {StatusActive, StatusDraft},
+}
+
+// Statuses lists every status of the fabric lifecycle.
+var Statuses = []string{
+	StatusDraft, StatusActive, StatusDiscontinued, StatusArchived, StatusDeleted, StatusMerged,
+}
+
+// FabricActivated is recorded when a draft goes on offer or a discontinued fabric returns to it.
+type FabricActivated struct {
+	Code           string
+	Name           string
+	PreviousStatus string
+	Version        int
+}
+
+// FabricDiscontinued is recorded when an active fabric goes off offer.
+type FabricDiscontinued struct {
+	Code           string
+	Name           string
+	PreviousStatus string
+	Version        int
+}
+
+// FabricArchived is recorded when a discontinued fabric is retired for good.
+type FabricArchived struct {
+	Code           string
+	Name           string
+	PreviousStatus string
+	Version        int
+}
+
+// CanTransition reports whether a fabric in status from may move to status to.
+func CanTransition(from, to string) bool {
+	for _, allowed := range statusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Activate puts a draft or discontinued fabric on offer.
+func (f *Fabric) Activate(version int, stamp Stamp) error {
+	previous := f.Status
+	if err := f.transition(StatusActive, version, stamp); err != nil {
+		return err
+	}
+	f.Record(FabricActivated{
+		Code: f.Code, Name: f.Name, PreviousStatus: previous, Version: f.Version,
+	})
+	return nil
+}
+
+// Discontinue takes an active fabric off offer. It can still be edited and activated again.
+func (f *Fabric) Discontinue(version int, stamp Stamp) error {
+	previous := f.Status
+	if err := f.transition(StatusDiscontinued, version, stamp); err != nil {
+		return err
+	}
+	f.Record(FabricDiscontinued{
+		Code: f.Code, Name: f.Name, PreviousStatus: previous, Version: f.Version,
+	})
+	return nil
+}
+
+// Archive retires a discontinued fabric, after which it is kept read-only.
+func (f *Fabric) Archive(version int, stamp Stamp) error {
+	previous := f.Status
+	if err := f.transition(StatusArchived, version, stamp); err != nil {
+		return err
+	}
+	f.Record(FabricArchived{
+		Code: f.Code, Name: f.Name, PreviousStatus: previous, Version: f.Version,
+	})
+	return nil
+}
+
+// transition moves the fabric to the status, provided the lifecycle allows it.
+func (f *Fabric) transition(to string, version int, stamp Stamp) error {
+	switch f.Status {
+	case StatusDeleted:
+		return ErrFabricDeleted
+	case StatusMerged:
+		return ErrFabricMerged
+	}
+	if err := f.checkTransition(to); err != nil {
+		return err
+	}
+	if f.Version != version {
+		return versionConflict(f.Version, nil)
+	}
+
+	f.Status = to
+	f.Version++
+	f.touch(stamp)
+	return nil
+}
+
+func (f *Fabric) checkTransition(to string) error {
+	if !CanTransition(f.Status, to) {
+		return ErrInvalidStatusTransition.WithParam("from", f.Status).WithParam("to", to)
+	}
+	return nil
+}
+
+// checkEditable fails unless the data of the fabric may change, that is unless it is a
+// draft, active or discontinued.
+func (f *Fabric) checkEditable() error {
+	switch f.Status {
+	case StatusDeleted:
+		return ErrFabricDeleted
+	case StatusMerged:
+		return ErrFabricMerged
+	case StatusArchived:
+		return ErrFabricArchived
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDraftFabric(t *testing.T) {
+	// --- Act ---
+	fabric, err := NewDraftFabric("ZOYA", "Zoya", "mb", "new", Specification{}, FabricTexts{}, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, StatusDraft, fabric.Status)
+	require.Len(t, fabric.Events(), 1)
+	created, ok := fabric.Events()[0].(FabricCreated)
+	require.True(t, ok, "a draft is created like any other fabric")
+	assert.Equal(t, StatusDraft, created.Status)
+}
+
+func TestFabric_Lifecycle_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewDraftFabric("ZOYA", "Zoya", "mb", "new", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	require.NoError(t, fabric.Activate(1, testStamp))
+	require.NoError(t, fabric.Discontinue(2, testStamp))
+	require.NoError(t, fabric.Activate(3, testStamp))
+	require.NoError(t, fabric.Discontinue(4, testStamp))
+	require.NoError(t, fabric.Archive(5, testStamp))
+
+	// --- Assert ---
+	assert.Equal(t, StatusArchived, fabric.Status)
+	assert.Equal(t, 6, fabric.Version)
+	assert.Equal(t, []Event{
+		FabricActivated{Code: "ZOYA", Name: "Zoya", PreviousStatus: StatusDraft, Version: 2},
+		FabricDiscontinued{Code: "ZOYA", Name: "Zoya", PreviousStatus: StatusActive, Version: 3},
+		FabricActivated{Code: "ZOYA", Name: "Zoya", PreviousStatus: StatusDiscontinued, Version: 4},
+		FabricDiscontinued{Code: "ZOYA", Name: "Zoya", PreviousStatus: StatusActive, Version: 5},
+		FabricArchived{Code: "ZOYA", Name: "Zoya", PreviousStatus: StatusDiscontinued, Version: 6},
+	}, fabric.Events()[1:])
+}
+
+func TestFabric_Lifecycle_Rejected(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      string
+		version     int
+		command     func(f *Fabric, version int) error
+		expectedErr error
+	}{
+		{name: "Activate an active fabric", status: StatusActive, version: 1, command: activate, expectedErr: ErrInvalidStatusTransition},
+		{name: "Activate an archived fabric", status: StatusArchived, version: 1, command: activate, expectedErr: ErrInvalidStatusTransition},
+		{name: "Discontinue a draft", status: StatusDraft, version: 1, command: discontinue, expectedErr: ErrInvalidStatusTransition},
+		{name: "Archive an active fabric", status: StatusActive, version: 1, command: archive, expectedErr: ErrInvalidStatusTransition},
+		{name: "Archive a deleted fabric", status: StatusDeleted, version: 1, command: archive, expectedErr: ErrFabricDeleted},
+		{name: "Activate a merged fabric", status: StatusMerged, version: 1, command: activate, expectedErr: ErrFabricMerged},
+		{name: "Stale version", status: StatusDraft, version: 3, command: activate, expectedErr: ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fabric, err := NewFabric("ZOYA", "Zoya", "mb", "new", Specification{}, FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			fabric.Status = tc.status
+
+			// --- Act ---
+			err = tc.command(fabric, tc.version)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.status, fabric.Status)
+			assert.Len(t, fabric.Events(), 1, "No new event should be added on a rejected transition")
+		})
+	}
+}
+
+func TestFabric_Archived_IsReadOnly(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric("ZOYA", "Zoya", "mb", "new", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	fabric.Status = StatusArchived
+
+	// --- Act ---
+	updateErr := fabric.UpdateFabric("Zoya II", "mb", "new", nil, FabricTexts{}, 1, testStamp)
+	priceErr := fabric.ChangePrice(Price{Amount: 100, Currency: "EUR"}, 1, testStamp)
+	deleteErr := fabric.Delete(1, testStamp)
+
+	// --- Assert ---
+	assert.ErrorIs(t, updateErr, ErrFabricArchived)
+	assert.ErrorIs(t, priceErr, ErrFabricArchived)
+	assert.NoError(t, deleteErr, "an archived fabric can still be deleted")
+}
+
+func activate(f *Fabric, version int) error    { return f.Activate(version, testStamp) }
+func discontinue(f *Fabric, version int) error { return f.Discontinue(version, testStamp) }
+func archive(f *Fabric, version int) error     { return f.Archive(version, testStamp) }
+
+func TestFabric_Restore_ReturnsToStatusBeforeDeletion(t *testing.T) {
+	testCases := []struct {
+		name   string
+		reach  func(f *Fabric) error
+		status string
+	}{
+		{name: "Draft", reach: func(*Fabric) error { return nil }, status: StatusDraft},
+		{name: "Active", reach: func(f *Fabric) error { return f.Activate(f.Version, testStamp) }, status: StatusActive},
+		{
+			name: "Discontinued", status: StatusDiscontinued,
+			reach: func(f *Fabric) error {
+				if err := f.Activate(f.Version, testStamp); err != nil {
+					return err
+				}
+				return f.Discontinue(f.Version, testStamp)
+			},
+		},
+		{
+			name: "Archived", status: StatusArchived,
+			reach: func(f *Fabric) error {
+				if err := f.Activate(f.Version, testStamp); err != nil {
+					return err
+				}
+				if err := f.Discontinue(f.Version, testStamp); err != nil {
+					return err
+				}
+				return f.Archive(f.Version, testStamp)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fabric, err := NewDraftFabric("ZOYA", "Zoya", "mb", "new", Specification{}, FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			require.NoError(t, tc.reach(fabric))
+			require.NoError(t, fabric.Delete(fabric.Version, testStamp))
+
+			// --- Act ---
+			err = fabric.Restore(fabric.Version, testStamp)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tc.status, fabric.Status)
+			assert.Empty(t, fabric.StatusBeforeDeletion)
+
+			events := fabric.Events()
+			deleted, ok := events[len(events)-2].(FabricDeleted)
+			require.True(t, ok, "the deletion records the status the fabric had")
+			assert.Equal(t, tc.status, deleted.PreviousStatus)
+			restored, ok := events[len(events)-1].(FabricRestored)
+			require.True(t, ok)
+			assert.Equal(t, tc.status, restored.Status)
+		})
+	}
+}
+
+func TestFabric_Restore_DeletedBeforeStatusWasKept(t *testing.T) {
+	// --- Arrange ---
+	fabric := &Fabric{Code: "ZOYA", Name: "Zoya", Status: StatusDeleted, Version: 4}
+
+	// --- Act ---
+	err := fabric.Restore(4, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, fabric.Status)
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+const (
+	DefaultLockTTL = 5 * time.Minute
+	MaxLockTTL     = 30 * time.Minute
+)
+
+var (
+	ErrInvalidLockTTL = validationError(
+		"invalid_lock_ttl", "ttl_seconds", "the lock TTL must be between 1 second and 30 minutes",
+		map[string]any{"min": 1, "max": int(MaxLockTTL.Seconds())},
+	)
+	ErrFabricLocked = conflictError("fabric_locked", "the fabric is being edited by another user")
+)
+
+// FabricLock is an advisory edit lock telling other users that a fabric is being edited.
+// It does not block writes and lapses on its own once it expires.
+type FabricLock struct {
+	Code       string    `json:"code"`
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func NewFabricLock(code string, ttl time.Duration, stamp Stamp) (*FabricLock, error) {
+	if ttl < time.Second || ttl > MaxLockTTL {
+		return nil, ErrInvalidLockTTL
+	}
+
+	return &FabricLock{
+		Code:       code,
+		Holder:     stamp.By,
+		AcquiredAt: stamp.At,
+		ExpiresAt:  stamp.At.Add(ttl),
+	}, nil
+}
+
+// ActiveAt reports whether the lock is still in force at the given time.
+func (l *FabricLock) ActiveAt(now time.Time) bool {
+	return now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFabricLock(t *testing.T) {
+	// --- Arrange ---
+	stamp := Stamp{By: "user_42", At: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	// --- Act ---
+	lock, err := NewFabricLock("FAB01", time.Minute, stamp)
+	_, tooLongErr := NewFabricLock("FAB01", MaxLockTTL+time.Second, stamp)
+	_, tooShortErr := NewFabricLock("FAB01", 0, stamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, "user_42", lock.Holder)
+	assert.Equal(t, stamp.At.Add(time.Minute), lock.ExpiresAt)
+	assert.True(t, lock.ActiveAt(stamp.At.Add(59*time.Second)))
+	assert.False(t, lock.ActiveAt(stamp.At.Add(time.Minute)))
+	assert.ErrorIs(t, tooLongErr, ErrInvalidLockTTL)
+	assert.ErrorIs(t, tooShortErr, ErrInvalidLockTTL)
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+const (
+	PendingStatusWaiting = "WAITING"
+	PendingStatusApplied = "APPLIED"
+	PendingStatusExpired = "EXPIRED"
+	// PendingStatusConflicted marks an event that turned out to be stale and was handed
+	// to the conflict strategy instead of being applied
+	PendingStatusConflicted = "CONFLICTED"
+)
+
+const (
+	pendingRetryBaseDelay = 5 * time.Second
+	pendingRetryMaxDelay  = 5 * time.Minute
+)
+
+// PendingFabricEvent is an ERP event that arrived ahead of a version it depends on,
+// parked until the missing versions have been applied.
+type PendingFabricEvent struct {
+	ID         int64
+	EventID    string
+	EventType  string
+	Code       string
+	Version    int
+	Envelope   []byte
+	Status     string
+	ReceivedAt time.Time
+	// Attempts counts the retries made so far, NextAttemptAt is when the next one is due
+	Attempts      int
+	NextAttemptAt time.Time
+}
+
+// ScheduleRetry records a failed attempt and schedules the next one with exponential
+// backoff, capped at pendingRetryMaxDelay.
+func (e *PendingFabricEvent) ScheduleRetry(now time.Time) {
+	e.Attempts++
+
+	delay := pendingRetryBaseDelay
+	for i := 1; i < e.Attempts && delay < pendingRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	e.NextAttemptAt = now.Add(min(delay, pendingRetryMaxDelay))
+}
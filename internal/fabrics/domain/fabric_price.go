@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidPriceAmount = validationError(
+		"invalid_price_amount", "list_price",
+		"the list price must be a non-negative amount with no more decimals than the currency allows", nil,
+	)
+	ErrInvalidCurrency = validationError(
+		"invalid_currency", "currency", "the currency must be a supported ISO 4217 code",
+		map[string]any{"allowed": supportedCurrencies()},
+	)
+)
+
+// currencyDecimals maps the supported currencies onto the number of decimals of their
+// minor unit.
+var currencyDecimals = map[string]int{
+	"PLN": 2, "EUR": 2, "USD": 2, "GBP": 2, "CHF": 2, "CZK": 2, "SEK": 2, "NOK": 2, "DKK": 2, "JPY": 0,
+}
+
+func supportedCurrencies() []string {
+	currencies := make([]string, 0, len(currencyDecimals))
+	for currency := range currencyDecimals {
+		currencies = append(currencies, currency)
+	}
+	slices.Sort(currencies)
+	return currencies
+}
+
+// Price is the list price of a fabric per its measure unit, effective from ValidFrom.
+type Price struct {
+	// Amount is expressed in the minor unit of the currency, e.g. grosze for PLN.
+	Amount    int64
+	Currency  string
+	ValidFrom time.Time
+}
+
+// ParsePrice reads a decimal amount such as "24.90" in the given currency.
+func ParsePrice(amount, currency string, validFrom time.Time) (Price, error) {
+	currency = strings.ToUpper(currency)
+	decimals, ok := currencyDecimals[currency]
+	if !ok {
+		return Price{}, ErrInvalidCurrency.WithParam("value", currency)
+	}
+
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if whole == "" || len(fraction) > decimals || strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") {
+		return Price{}, ErrInvalidPriceAmount.WithParam("value", amount)
+	}
+	fraction += strings.Repeat("0", decimals-len(fraction))
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Price{}, ErrInvalidPriceAmount.WithParam("value", amount)
+	}
+
+	return Price{Amount: minor, Currency: currency, ValidFrom: validFrom}, nil
+}
+
+// String formats the amount with the decimals of its currency, e.g. "24.90 PLN".
+func (p Price) String() string {
+	return p.Decimal() + " " + p.Currency
+}
+
+// Decimal formats the amount with the decimals of its currency, e.g. "24.90".
+func (p Price) Decimal() string {
+	decimals := currencyDecimals[p.Currency]
+	digits := strconv.FormatInt(p.Amount, 10)
+	if decimals == 0 {
+		return digits
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+}
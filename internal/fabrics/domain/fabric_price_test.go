@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrice(t *testing.T) {
+	validFrom := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		amount         string
+		currency       string
+		expectedAmount int64
+		expectedErr    error
+	}{
+		{name: "Two decimals", amount: "24.90", currency: "PLN", expectedAmount: 2490},
+		{name: "One decimal", amount: "24.9", currency: "eur", expectedAmount: 2490},
+		{name: "Whole amount", amount: "24", currency: "USD", expectedAmount: 2400},
+		{name: "Currency without minor unit", amount: "1500", currency: "JPY", expectedAmount: 1500},
+		{name: "Free", amount: "0", currency: "PLN", expectedAmount: 0},
+		{name: "Too many decimals", amount: "24.905", currency: "PLN", expectedErr: ErrInvalidPriceAmount},
+		{name: "Decimals for JPY", amount: "15.5", currency: "JPY", expectedErr: ErrInvalidPriceAmount},
+		{name: "Negative", amount: "-1.00", currency: "PLN", expectedErr: ErrInvalidPriceAmount},
+		{name: "Not a number", amount: "cheap", currency: "PLN", expectedErr: ErrInvalidPriceAmount},
+		{name: "Missing whole part", amount: ".50", currency: "PLN", expectedErr: ErrInvalidPriceAmount},
+		{name: "Unsupported currency", amount: "10", currency: "XYZ", expectedErr: ErrInvalidCurrency},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			price, err := ParsePrice(tc.amount, tc.currency, validFrom)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAmount, price.Amount)
+			assert.Equal(t, validFrom, price.ValidFrom)
+		})
+	}
+}
+
+func TestPrice_String(t *testing.T) {
+	assert.Equal(t, "24.90 PLN", Price{Amount: 2490, Currency: "PLN"}.String())
+	assert.Equal(t, "0.05 EUR", Price{Amount: 5, Currency: "EUR"}.String())
+	assert.Equal(t, "1500 JPY", Price{Amount: 1500, Currency: "JPY"}.String())
+}
+
+func TestFabric_ChangePrice_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	price := Price{Amount: 2490, Currency: "PLN", ValidFrom: testStamp.At}
+
+	// --- Act ---
+	err = fabric.ChangePrice(price, fabric.Version, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, fabric.Price)
+	assert.Equal(t, price, *fabric.Price)
+	assert.Equal(t, 2, fabric.Version)
+	require.Len(t, fabric.Events(), 2)
+	event, ok := fabric.Events()[1].(FabricPriceChanged)
+	require.True(t, ok, "the second event must be a FabricPriceChanged event")
+	assert.Equal(t, price, event.Price)
+	assert.Equal(t, 2, event.Version)
+}
+
+func TestFabric_ChangePrice_Rejected(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      string
+		version     int
+		price       Price
+		expectedErr error
+	}{
+		{name: "Deleted fabric", status: StatusDeleted, version: 1, price: Price{Currency: "PLN"}, expectedErr: ErrFabricDeleted},
+		{name: "Merged fabric", status: StatusMerged, version: 1, price: Price{Currency: "PLN"}, expectedErr: ErrFabricMerged},
+		{name: "Stale version", status: StatusActive, version: 0, price: Price{Currency: "PLN"}, expectedErr: ErrConcurrencyConflict},
+		{name: "Unknown currency", status: StatusActive, version: 1, price: Price{Currency: "XYZ"}, expectedErr: ErrInvalidCurrency},
+		{
+			name: "Negative amount", status: StatusActive, version: 1, price: Price{Amount: -1, Currency: "PLN"},
+			expectedErr: ErrInvalidPriceAmount,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fabric := &Fabric{Code: "TESTCODE", Status: tc.status, Version: 1}
+
+			// --- Act ---
+			err := fabric.ChangePrice(tc.price, tc.version, testStamp)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, fabric.Price)
+			assert.Empty(t, fabric.Events())
+		})
+	}
+}
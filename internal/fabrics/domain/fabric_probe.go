@@ -0,0 +1,17 @@
+package domain
+
+import "strings"
+
+// ProbeFabricCodePrefix reserves fabric codes for the synthetic probe, which creates,
+// updates and deletes such a fabric to measure the write path end to end. The catalog
+// refuses every other command on them.
+const ProbeFabricCodePrefix = "ZZPROBE"
+
+var ErrReservedFabricCode = validationError(
+	"reserved_code", "code", "the code is reserved for the synthetic probe", map[string]any{"prefix": ProbeFabricCodePrefix},
+)
+
+// IsProbeFabricCode reports whether the code is reserved for the synthetic probe.
+func IsProbeFabricCode(code string) bool {
+	return strings.HasPrefix(code, ProbeFabricCodePrefix)
+}
@@ -0,0 +1,119 @@
+package domain
+
+import "time"
+
+var (
+	ErrProposalAlreadyResolved = conflictError(
+		"proposal_already_resolved", "the proposal has already been approved or rejected",
+	)
+	ErrProposalWithoutChanges = validationError(
+		"proposal_without_changes", "name", "the proposal does not change the fabric", nil,
+	)
+)
+
+const (
+	ProposalStatusPending  = "PENDING"
+	ProposalStatusApproved = "APPROVED"
+	ProposalStatusRejected = "REJECTED"
+)
+
+const (
+	// ProposalSourceSupplier marks proposals submitted through the supplier portal
+	ProposalSourceSupplier = "supplier"
+	// ProposalSourceERP marks ERP changes held back for their low confidence
+	ProposalSourceERP = "erp"
+)
+
+// FieldChange is one attribute a proposal changes, from its value at the base version.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// FabricProposal is a change of a fabric's attributes submitted by someone who may not
+// change the fabric directly. It waits for review and, once approved, is applied at the
+// version it was based on, so changes made in the meantime are not overwritten.
+type FabricProposal struct {
+	ID          int64         `json:"id"`
+	Code        string        `json:"code"`
+	Source      string        `json:"source"`
+	EventID     string        `json:"event_id,omitempty"`
+	Name        string        `json:"name"`
+	MeasureUnit MeasureUnit   `json:"measure_unit"`
+	OfferStatus OfferStatus   `json:"offer_status"`
+	Changes     []FieldChange `json:"changes"`
+	BaseVersion int           `json:"base_version"`
+	Status      string        `json:"status"`
+	Reason      string        `json:"reason,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	CreatedBy   string        `json:"created_by"`
+	ResolvedAt  *time.Time    `json:"resolved_at,omitempty"`
+	ResolvedBy  string        `json:"resolved_by,omitempty"`
+}
+
+// NewFabricProposal prepares a proposed change of the given fabric, validated like an
+// update would be, together with the attributes it changes.
+func NewFabricProposal(
+	fabric *Fabric, source, name, measureUnit, offerStatus string, stamp Stamp,
+) (*FabricProposal, error) {
+	if err := fabric.checkEditable(); err != nil {
+		return nil, err
+	}
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	unit, status, err := parseAttributes(measureUnit, offerStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []FieldChange{}
+	for _, change := range []FieldChange{
+		{Field: "name", From: fabric.Name, To: name},
+		{Field: "measure_unit", From: string(fabric.MeasureUnit), To: string(unit)},
+		{Field: "offer_status", From: string(fabric.OfferStatus), To: string(status)},
+	} {
+		if change.From != change.To {
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 {
+		return nil, ErrProposalWithoutChanges
+	}
+
+	return &FabricProposal{
+		Code:        fabric.Code,
+		Source:      source,
+		Name:        name,
+		MeasureUnit: unit,
+		OfferStatus: status,
+		Changes:     changes,
+		BaseVersion: fabric.Version,
+		Status:      ProposalStatusPending,
+		CreatedAt:   stamp.At,
+		CreatedBy:   stamp.By,
+	}, nil
+}
+
+// Approve marks a pending proposal as approved.
+func (p *FabricProposal) Approve(stamp Stamp) error {
+	return p.resolve(ProposalStatusApproved, "", stamp)
+}
+
+// Reject marks a pending proposal as rejected, for the reason given to its submitter.
+func (p *FabricProposal) Reject(reason string, stamp Stamp) error {
+	return p.resolve(ProposalStatusRejected, reason, stamp)
+}
+
+func (p *FabricProposal) resolve(status, reason string, stamp Stamp) error {
+	if p.Status != ProposalStatusPending {
+		return ErrProposalAlreadyResolved
+	}
+	p.Status = status
+	p.Reason = reason
+	at := stamp.At
+	p.ResolvedAt = &at
+	p.ResolvedBy = stamp.By
+	return nil
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFabricProposal(t *testing.T) {
+	// --- Arrange ---
+	stamp := Stamp{By: "supplier:TEXTILIA", At: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	fabric, err := NewFabric("FAB01", "Linen", "m", "active", Specification{}, FabricTexts{}, stamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	proposal, err := NewFabricProposal(fabric, ProposalSourceSupplier, "Reworked Linen", "m", "new", stamp)
+	_, unchangedErr := NewFabricProposal(fabric, ProposalSourceSupplier, "Linen", "m", "active", stamp)
+	_, invalidErr := NewFabricProposal(fabric, ProposalSourceSupplier, "", "m", "new", stamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, "FAB01", proposal.Code)
+	assert.Equal(t, ProposalSourceSupplier, proposal.Source)
+	assert.Equal(t, 1, proposal.BaseVersion)
+	assert.Equal(t, ProposalStatusPending, proposal.Status)
+	assert.Equal(t, "supplier:TEXTILIA", proposal.CreatedBy)
+	assert.Equal(t, []FieldChange{
+		{Field: "name", From: "Linen", To: "Reworked Linen"},
+		{Field: "offer_status", From: string(OfferStatusActive), To: string(OfferStatusNew)},
+	}, proposal.Changes, "only the attributes that change are part of the diff")
+	assert.ErrorIs(t, unchangedErr, ErrProposalWithoutChanges)
+	assert.ErrorIs(t, invalidErr, ErrInvalidFabricNameLength)
+}
+
+func TestFabricProposal_Resolve(t *testing.T) {
+	// --- Arrange ---
+	stamp := Stamp{By: "reviewer", At: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	approved := &FabricProposal{Status: ProposalStatusPending}
+	rejected := &FabricProposal{Status: ProposalStatusPending}
+
+	// --- Act ---
+	approveErr := approved.Approve(stamp)
+	rejectErr := rejected.Reject("the name is already taken", stamp)
+	againErr := approved.Reject("changed my mind", stamp)
+
+	// --- Assert ---
+	require.NoError(t, approveErr)
+	require.NoError(t, rejectErr)
+	assert.Equal(t, ProposalStatusApproved, approved.Status)
+	assert.Equal(t, "reviewer", approved.ResolvedBy)
+	assert.Equal(t, ProposalStatusRejected, rejected.Status)
+	assert.Equal(t, "the name is already taken", rejected.Reason)
+	assert.ErrorIs(t, againErr, ErrProposalAlreadyResolved)
+	assert.Empty(t, approved.Reason)
+}
@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+var (
+	ErrFabricNotPurgeable = conflictError("fabric_not_purgeable", "only a deleted fabric can be purged")
+	ErrPurgeRetention     = conflictError(
+		"purge_retention", "the fabric has not been deleted long enough to be purged",
+	)
+	ErrFabricCodePurged = conflictError(
+		"code_purged", "the code belonged to a purged fabric and cannot be used again",
+	)
+	ErrFabricReferenced = conflictError(
+		"fabric_referenced", "a fabric on orders, in bills of materials or on price lists cannot be purged",
+	)
+)
+
+// FabricPurged is recorded when a deleted fabric is removed for good. EventsPurged tells
+// whether its earlier events were removed with it, this one being the only one left then.
+type FabricPurged struct {
+	Code         string
+	Version      int
+	EventsPurged bool
+}
+
+// PurgeableAt returns the moment a deleted fabric may be purged, once it has been deleted
+// for the retention. Nothing changes a deleted fabric, so it was deleted at UpdatedAt.
+func (f *Fabric) PurgeableAt(retention time.Duration) time.Time {
+	return f.UpdatedAt.Add(retention)
+}
+
+// Purge removes a fabric deleted for at least the retention, until when the deletion can
+// still be undone. Its code is retired with it: downstream systems dropped the fabric on
+// the purge and must not see the code come back as another fabric.
+func (f *Fabric) Purge(version int, retention time.Duration, purgeEvents bool, stamp Stamp) error {
+	switch f.Status {
+	case StatusDeleted:
+	case StatusMerged:
+		return ErrFabricMerged
+	default:
+		return ErrFabricNotPurgeable
+	}
+	if f.Version != version {
+		return versionConflict(f.Version, nil)
+	}
+	purgeableAt := f.PurgeableAt(retention)
+	if stamp.At.Before(purgeableAt) {
+		return ErrPurgeRetention.WithParam("purgeable_at", purgeableAt)
+	}
+
+	f.Version++
+	f.touch(stamp)
+
+	event := FabricPurged{
+		Code:         f.Code,
+		Version:      f.Version,
+		EventsPurged: purgeEvents,
+	}
+	f.Record(event)
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabric_Purge(t *testing.T) {
+	const retention = 30 * 24 * time.Hour
+	deletedAt := testStamp.At.Add(time.Hour)
+
+	testCases := []struct {
+		name        string
+		status      string
+		version     int
+		purgedAt    time.Time
+		expectedErr error
+	}{
+		{name: "Deleted past the retention", status: StatusDeleted, version: 2, purgedAt: deletedAt.Add(retention)},
+		{
+			name: "Deleted within the retention", status: StatusDeleted, version: 2,
+			purgedAt: deletedAt.Add(retention - time.Minute), expectedErr: ErrPurgeRetention,
+		},
+		{
+			name: "Active fabric", status: StatusActive, version: 2,
+			purgedAt: deletedAt.Add(retention), expectedErr: ErrFabricNotPurgeable,
+		},
+		{
+			name: "Merged fabric", status: StatusMerged, version: 2,
+			purgedAt: deletedAt.Add(retention), expectedErr: ErrFabricMerged,
+		},
+		{
+			name: "Stale version", status: StatusDeleted, version: 1,
+			purgedAt: deletedAt.Add(retention), expectedErr: ErrConcurrencyConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			require.NoError(t, fabric.Delete(1, Stamp{By: "editor", At: deletedAt}))
+			fabric.Status = tc.status
+
+			// --- Act ---
+			err = fabric.Purge(tc.version, retention, true, Stamp{By: "admin", At: tc.purgedAt})
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Equal(t, 2, fabric.Version)
+				assert.Len(t, fabric.Events(), 2, "No new event should be added on failed purge")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 3, fabric.Version)
+			assert.Equal(t, "admin", fabric.UpdatedBy)
+			require.Len(t, fabric.Events(), 3)
+			assert.Equal(t, FabricPurged{Code: "TESTCODE", Version: 3, EventsPurged: true}, fabric.Events()[2])
+		})
+	}
+}
+
+func TestFabric_Purge_ReportsWhenPurgeable(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	require.NoError(t, fabric.Delete(1, testStamp))
+
+	// --- Act ---
+	err = fabric.Purge(2, time.Hour, false, testStamp)
+
+	// --- Assert ---
+	domainErr, ok := AsDomainError(err)
+	require.True(t, ok)
+	assert.Equal(t, testStamp.At.Add(time.Hour), domainErr.Params["purgeable_at"])
+}
@@ -0,0 +1,28 @@
+package domain
+
+// FabricSearch finds active fabrics by the words of their code, name and description.
+// Each word of Query matches the words starting with it, and a name close enough to
+// Query is found even when misspelled.
+type FabricSearch struct {
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// FabricSearchHit is a fabric found by a search, the best matches ranked first. The
+// highlights repeat the matched texts with the matching words wrapped in <mark> tags; the
+// description is cut to the fragments around them and empty when none of its words match.
+type FabricSearchHit struct {
+	Code        string           `json:"code"`
+	Name        string           `json:"name"`
+	MeasureUnit MeasureUnit      `json:"measure_unit"`
+	OfferStatus OfferStatus      `json:"offer_status"`
+	Rank        float64          `json:"rank"`
+	Highlights  SearchHighlights `json:"highlights"`
+}
+
+// SearchHighlights are the texts of a fabric with the words matching a search marked.
+type SearchHighlights struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,121 @@
+package domain
+
+import "strings"
+
+const (
+	maxFabricWidthCM    = 500
+	maxFabricWeightGSM  = 2000
+	maxColorLength      = 50
+	maxMinOrderQuantity = 100000
+	maxLeadTimeDays     = 365
+)
+
+var (
+	ErrInvalidCompositionShare = validationError(
+		"invalid_composition_share", "composition", "each fibre of the composition must have a share of 1-100 percent",
+		map[string]any{"min": 1, "max": 100},
+	)
+	ErrInvalidCompositionFibre = validationError(
+		"invalid_composition_fibre", "composition", "each fibre of the composition must be named once",
+		nil,
+	)
+	ErrInvalidCompositionTotal = validationError(
+		"invalid_composition_total", "composition", "the composition shares must add up to 100 percent",
+		map[string]any{"total": 100},
+	)
+	ErrInvalidFabricWidth = validationError(
+		"invalid_width", "width_cm", "the fabric width must be 1-500 cm",
+		map[string]any{"min": 1, "max": maxFabricWidthCM},
+	)
+	ErrInvalidFabricWeight = validationError(
+		"invalid_weight", "weight_gsm", "the fabric weight must be 1-2000 g/m²",
+		map[string]any{"min": 1, "max": maxFabricWeightGSM},
+	)
+	ErrInvalidColorLength = validationError(
+		"invalid_color_length", "color", "the fabric color length must be at most 50",
+		map[string]any{"max": maxColorLength},
+	)
+	ErrUnknownColor = validationError(
+		"unknown_color", "color", "the color is not in the palette or is deleted", nil,
+	)
+	ErrInvalidMinOrderQuantity = validationError(
+		"invalid_min_order_quantity", "min_order_quantity", "the minimum order quantity must be 0-100000",
+		map[string]any{"min": 0, "max": maxMinOrderQuantity},
+	)
+	ErrInvalidLeadTime = validationError(
+		"invalid_lead_time", "lead_time_days", "the lead time must be 0-365 days",
+		map[string]any{"min": 0, "max": maxLeadTimeDays},
+	)
+)
+
+// CompositionPart is the share of a single fibre in a fabric, in whole percent.
+type CompositionPart struct {
+	Fibre   string
+	Percent int
+}
+
+// Specification describes what a fabric is made of and the terms it is ordered on. Every
+// attribute is optional: an empty composition or color and a zero width or weight mean the
+// attribute is not known, a zero minimum order quantity or lead time that there is none.
+type Specification struct {
+	Composition []CompositionPart
+	// WidthCM is the usable width of the fabric roll in centimetres.
+	WidthCM int
+	// WeightGSM is the grammage of the fabric in grams per square metre.
+	WeightGSM int
+	// Color is the code of the color of the palette the fabric is made in.
+	Color string
+	// MinOrderQuantity is the smallest quantity taken in one order, in whole measure units.
+	MinOrderQuantity int
+	// LeadTimeDays is the number of days between ordering the fabric and its dispatch.
+	LeadTimeDays int
+}
+
+// Validate checks the specification. A composition, when given, must name each fibre
+// once and account for the whole fabric.
+func (s Specification) Validate() error {
+	if err := validateComposition(s.Composition); err != nil {
+		return err
+	}
+	if s.WidthCM < 0 || s.WidthCM > maxFabricWidthCM {
+		return ErrInvalidFabricWidth.WithParam("value", s.WidthCM)
+	}
+	if s.WeightGSM < 0 || s.WeightGSM > maxFabricWeightGSM {
+		return ErrInvalidFabricWeight.WithParam("value", s.WeightGSM)
+	}
+	if len(s.Color) > maxColorLength {
+		return ErrInvalidColorLength
+	}
+	if s.MinOrderQuantity < 0 || s.MinOrderQuantity > maxMinOrderQuantity {
+		return ErrInvalidMinOrderQuantity.WithParam("value", s.MinOrderQuantity)
+	}
+	if s.LeadTimeDays < 0 || s.LeadTimeDays > maxLeadTimeDays {
+		return ErrInvalidLeadTime.WithParam("value", s.LeadTimeDays)
+	}
+	return nil
+}
+
+// validateComposition checks that a composition, when given, names each fibre once and
+// accounts for the whole fabric.
+func validateComposition(composition []CompositionPart) error {
+	if len(composition) == 0 {
+		return nil
+	}
+	total := 0
+	seen := make(map[string]bool, len(composition))
+	for _, part := range composition {
+		fibre := strings.ToLower(part.Fibre)
+		if fibre == "" || seen[fibre] {
+			return ErrInvalidCompositionFibre.WithParam("fibre", part.Fibre)
+		}
+		seen[fibre] = true
+		if part.Percent < 1 || part.Percent > 100 {
+			return ErrInvalidCompositionShare.WithParam("fibre", part.Fibre)
+		}
+		total += part.Percent
+	}
+	if total != 100 {
+		return ErrInvalidCompositionTotal.WithParam("value", total)
+	}
+	return nil
+}
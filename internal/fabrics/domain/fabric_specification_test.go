@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecification_Validate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		spec        Specification
+		expectedErr error
+	}{
+		{name: "Unspecified", spec: Specification{}},
+		{
+			name: "Complete",
+			spec: Specification{
+				Composition: []CompositionPart{{Fibre: "cotton", Percent: 80}, {Fibre: "elastane", Percent: 20}},
+				WidthCM:     150, WeightGSM: 320, Color: "navy", MinOrderQuantity: 50, LeadTimeDays: 21,
+			},
+		},
+		{
+			name:        "Composition short of 100 percent",
+			spec:        Specification{Composition: []CompositionPart{{Fibre: "cotton", Percent: 80}}},
+			expectedErr: ErrInvalidCompositionTotal,
+		},
+		{
+			name:        "Zero share",
+			spec:        Specification{Composition: []CompositionPart{{Fibre: "cotton", Percent: 100}, {Fibre: "silk", Percent: 0}}},
+			expectedErr: ErrInvalidCompositionShare,
+		},
+		{
+			name:        "Fibre named twice",
+			spec:        Specification{Composition: []CompositionPart{{Fibre: "wool", Percent: 50}, {Fibre: "Wool", Percent: 50}}},
+			expectedErr: ErrInvalidCompositionFibre,
+		},
+		{
+			name:        "Unnamed fibre",
+			spec:        Specification{Composition: []CompositionPart{{Percent: 100}}},
+			expectedErr: ErrInvalidCompositionFibre,
+		},
+		{name: "Negative width", spec: Specification{WidthCM: -1}, expectedErr: ErrInvalidFabricWidth},
+		{name: "Width too large", spec: Specification{WidthCM: 501}, expectedErr: ErrInvalidFabricWidth},
+		{name: "Weight too large", spec: Specification{WeightGSM: 2001}, expectedErr: ErrInvalidFabricWeight},
+		{name: "Negative minimum order quantity", spec: Specification{MinOrderQuantity: -1}, expectedErr: ErrInvalidMinOrderQuantity},
+		{name: "Minimum order quantity too large", spec: Specification{MinOrderQuantity: 100001}, expectedErr: ErrInvalidMinOrderQuantity},
+		{name: "Negative lead time", spec: Specification{LeadTimeDays: -1}, expectedErr: ErrInvalidLeadTime},
+		{name: "Lead time too long", spec: Specification{LeadTimeDays: 366}, expectedErr: ErrInvalidLeadTime},
+		{
+			name:        "Color too long",
+			spec:        Specification{Color: "a very long color name that nobody would ever really use"},
+			expectedErr: ErrInvalidColorLength,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			err := tc.spec.Validate()
+
+			// --- Assert ---
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestFabric_Specification_CarriedByEvents(t *testing.T) {
+	// --- Arrange ---
+	spec := Specification{
+		Composition: []CompositionPart{{Fibre: "linen", Percent: 100}},
+		WidthCM:     140, WeightGSM: 210, Color: "ecru",
+	}
+
+	// --- Act ---
+	fabric, err := NewFabric("LINEN01", "Linen", "mb", "active", spec, FabricTexts{}, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, spec, fabric.Specification)
+	created, ok := fabric.Events()[0].(FabricCreated)
+	require.True(t, ok)
+	assert.Equal(t, spec, created.Specification)
+}
+
+func TestFabric_UpdateFabric_Specification(t *testing.T) {
+	spec := Specification{WidthCM: 140, Color: "ecru"}
+
+	t.Run("Nil keeps the current specification", func(t *testing.T) {
+		// --- Arrange ---
+		fabric, err := NewFabric("LINEN01", "Linen", "mb", "active", spec, FabricTexts{}, testStamp)
+		require.NoError(t, err)
+
+		// --- Act ---
+		err = fabric.UpdateFabric("Linen Washed", "mb", "active", nil, FabricTexts{}, fabric.Version, testStamp)
+
+		// --- Assert ---
+		require.NoError(t, err)
+		assert.Equal(t, spec, fabric.Specification)
+		updated, ok := fabric.Events()[1].(FabricUpdated)
+		require.True(t, ok)
+		assert.Equal(t, spec, updated.Specification)
+	})
+
+	t.Run("Given specification replaces it", func(t *testing.T) {
+		// --- Arrange ---
+		fabric, err := NewFabric("LINEN01", "Linen", "mb", "active", spec, FabricTexts{}, testStamp)
+		require.NoError(t, err)
+		replacement := Specification{WeightGSM: 180}
+
+		// --- Act ---
+		err = fabric.UpdateFabric("Linen", "mb", "active", &replacement, FabricTexts{}, fabric.Version, testStamp)
+
+		// --- Assert ---
+		require.NoError(t, err)
+		assert.Equal(t, replacement, fabric.Specification)
+	})
+
+	t.Run("Invalid specification leaves the fabric unchanged", func(t *testing.T) {
+		// --- Arrange ---
+		fabric, err := NewFabric("LINEN01", "Linen", "mb", "active", spec, FabricTexts{}, testStamp)
+		require.NoError(t, err)
+		invalid := Specification{WidthCM: 1000}
+
+		// --- Act ---
+		err = fabric.UpdateFabric("Linen Washed", "mb", "active", &invalid, FabricTexts{}, fabric.Version, testStamp)
+
+		// --- Assert ---
+		assert.ErrorIs(t, err, ErrInvalidFabricWidth)
+		assert.Equal(t, "Linen", fabric.Name)
+		assert.Equal(t, spec, fabric.Specification)
+		assert.Equal(t, 1, fabric.Version)
+	})
+}
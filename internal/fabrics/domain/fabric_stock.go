@@ -0,0 +1,344 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/quantity"
+)
+
+var (
+	ErrInvalidQuantity = validationError(
+		"invalid_quantity", "quantity",
+		"the quantity must be a decimal number with at most 3 decimals", map[string]any{"decimals": quantity.Decimals},
+	)
+	ErrNonPositiveQuantity = validationError(
+		"non_positive_quantity", "quantity", "the quantity must be greater than 0", nil,
+	)
+	ErrZeroStockAdjustment = validationError(
+		"zero_stock_adjustment", "quantity", "a stock adjustment must change the quantity on hand", nil,
+	)
+	ErrInvalidStockReference = validationError(
+		"invalid_stock_reference", "reference", "the reference length must be 1-100", map[string]any{"min": 1, "max": 100},
+	)
+	ErrInsufficientStock = conflictError(
+		"insufficient_stock", "there is not enough stock available for this change",
+	)
+	ErrReservationExceeded = conflictError(
+		"reservation_exceeded", "cannot release more than is currently reserved",
+	)
+	ErrReservationExpiryPassed = validationError(
+		"reservation_expiry_passed", "expires_at", "a reservation must expire in the future", nil,
+	)
+	ErrUnknownWarehouse = validationError(
+		"unknown_warehouse", "warehouse", "the warehouse does not exist or is deleted", nil,
+	)
+	ErrInsufficientLocationStock = conflictError(
+		"insufficient_location_stock", "there is not enough stock on hand at the warehouse for this change",
+	)
+)
+
+// Quantity is an amount of a fabric in its measure unit, in thousandths of the unit.
+type Quantity = quantity.Quantity
+
+// ParseQuantity reads a decimal quantity such as "12.5" or, for adjustments, "-3".
+func ParseQuantity(raw string) (Quantity, error) {
+	parsed, err := quantity.Parse(raw)
+	if err != nil {
+		return 0, ErrInvalidQuantity.WithParam("value", raw)
+	}
+	return parsed, nil
+}
+
+// FabricStock is the stock on hand of a fabric and the part of it reserved for orders. It
+// is versioned on its own, so stock movements do not conflict with edits of the fabric.
+// Locations holds the part of the stock on hand attributed to warehouses, by warehouse
+// code; the rest was adjusted without naming one. Reservations holds what is reserved for
+// each reference, by reference; quantities reserved before reservations were tracked are
+// only part of Reserved. Reservations are not tied to a site.
+type FabricStock struct {
+	Code         string                      `json:"code"`
+	OnHand       Quantity                    `json:"on_hand"`
+	Reserved     Quantity                    `json:"reserved"`
+	Locations    map[string]Quantity         `json:"locations,omitempty"`
+	Reservations map[string]StockReservation `json:"reservations,omitempty"`
+	Version      int                         `json:"version"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+	UpdatedBy    string                      `json:"updated_by"`
+	aggregate.Root
+}
+
+// StockReservation is the quantity of a fabric set aside for a quote or an order. A
+// reservation with an expiry is given back to the available stock once it passes, unless
+// it was released before.
+type StockReservation struct {
+	Reference  string     `json:"reference"`
+	Quantity   Quantity   `json:"quantity"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	ReservedAt time.Time  `json:"reserved_at"`
+	ReservedBy string     `json:"reserved_by"`
+}
+
+// FabricStockAdjusted is recorded when the quantity on hand is corrected, by a goods
+// receipt, a stocktake or a write-off. Warehouse is empty for an adjustment not
+// attributed to a site.
+type FabricStockAdjusted struct {
+	Code      string
+	Delta     Quantity
+	Reason    string
+	Warehouse string
+	OnHand    Quantity
+	Reserved  Quantity
+	Version   int
+}
+
+// FabricStockReserved is recorded when part of the available stock is set aside. ExpiresAt
+// is the expiry of the whole reservation of the reference, nil when it does not expire.
+type FabricStockReserved struct {
+	Code      string
+	Quantity  Quantity
+	Reference string
+	ExpiresAt *time.Time
+	OnHand    Quantity
+	Reserved  Quantity
+	Version   int
+}
+
+// FabricStockReleased is recorded when a reservation is given back to the available stock.
+type FabricStockReleased struct {
+	Code      string
+	Quantity  Quantity
+	Reference string
+	OnHand    Quantity
+	Reserved  Quantity
+	Version   int
+}
+
+// FabricStockReservationExpired is recorded when a reservation is given back to the
+// available stock because its expiry passed.
+type FabricStockReservationExpired struct {
+	Code      string
+	Quantity  Quantity
+	Reference string
+	ExpiredAt time.Time
+	OnHand    Quantity
+	Reserved  Quantity
+	Version   int
+}
+
+// NewFabricStock returns the empty stock of a fabric that has never had any, at version 0.
+func NewFabricStock(code string) *FabricStock {
+	return &FabricStock{Code: code}
+}
+
+// Available is the quantity on hand that is not reserved.
+func (s *FabricStock) Available() Quantity {
+	return s.OnHand - s.Reserved
+}
+
+// Adjust changes the quantity on hand by delta, at the warehouse when one is given. Stock
+// already reserved cannot be adjusted away, the reservations have to be released first,
+// and a warehouse cannot give away more than is on hand there.
+func (s *FabricStock) Adjust(delta Quantity, reason, warehouse string, version int, stamp Stamp) error {
+	if s.Version != version {
+		return versionConflict(s.Version, nil)
+	}
+	if delta == 0 {
+		return ErrZeroStockAdjustment
+	}
+	if s.OnHand+delta < s.Reserved {
+		return ErrInsufficientStock.WithParam("available", s.Available().String())
+	}
+	if warehouse != "" && s.Locations[warehouse]+delta < 0 {
+		return ErrInsufficientLocationStock.WithParam("on_hand", s.Locations[warehouse].String())
+	}
+
+	s.OnHand += delta
+	if warehouse != "" {
+		s.moveLocation(warehouse, delta)
+	}
+	s.Version++
+	s.touch(stamp)
+
+	event := FabricStockAdjusted{
+		Code:      s.Code,
+		Delta:     delta,
+		Reason:    reason,
+		Warehouse: warehouse,
+		OnHand:    s.OnHand,
+		Reserved:  s.Reserved,
+		Version:   s.Version,
+	}
+	s.Record(event)
+
+	return nil
+}
+
+// Reserve sets the quantity aside for the reference, typically a quote or an order number,
+// until expiresAt unless it is nil. Reserving more for a reference already holding a
+// reservation adds to it, and the expiry given last applies to all of it.
+func (s *FabricStock) Reserve(
+	quantity Quantity, reference string, expiresAt *time.Time, version int, stamp Stamp,
+) error {
+	if s.Version != version {
+		return versionConflict(s.Version, nil)
+	}
+	if err := validateStockChange(quantity, reference); err != nil {
+		return err
+	}
+	if expiresAt != nil && !expiresAt.After(stamp.At) {
+		return ErrReservationExpiryPassed
+	}
+	if quantity > s.Available() {
+		return ErrInsufficientStock.WithParam("available", s.Available().String())
+	}
+
+	reservation, ok := s.Reservations[reference]
+	if !ok {
+		reservation = StockReservation{Reference: reference, ReservedAt: stamp.At, ReservedBy: stamp.By}
+	}
+	reservation.Quantity += quantity
+	reservation.ExpiresAt = expiresAt
+	if s.Reservations == nil {
+		s.Reservations = make(map[string]StockReservation)
+	}
+	s.Reservations[reference] = reservation
+
+	s.Reserved += quantity
+	s.Version++
+	s.touch(stamp)
+
+	event := FabricStockReserved{
+		Code:      s.Code,
+		Quantity:  quantity,
+		Reference: reference,
+		ExpiresAt: expiresAt,
+		OnHand:    s.OnHand,
+		Reserved:  s.Reserved,
+		Version:   s.Version,
+	}
+	s.Record(event)
+
+	return nil
+}
+
+// Release gives a quantity reserved for the reference back to the available stock. A
+// reference without a reservation releases from the quantities reserved before
+// reservations were tracked.
+func (s *FabricStock) Release(quantity Quantity, reference string, version int, stamp Stamp) error {
+	if s.Version != version {
+		return versionConflict(s.Version, nil)
+	}
+	if err := validateStockChange(quantity, reference); err != nil {
+		return err
+	}
+	reservation, ok := s.Reservations[reference]
+	if !ok {
+		if untracked := s.untrackedReserved(); quantity > untracked {
+			return ErrReservationExceeded.WithParam("reserved", untracked.String())
+		}
+	} else if quantity > reservation.Quantity {
+		return ErrReservationExceeded.WithParam("reserved", reservation.Quantity.String())
+	}
+
+	if ok {
+		reservation.Quantity -= quantity
+		if reservation.Quantity == 0 {
+			delete(s.Reservations, reference)
+		} else {
+			s.Reservations[reference] = reservation
+		}
+	}
+	s.Reserved -= quantity
+	s.Version++
+	s.touch(stamp)
+
+	event := FabricStockReleased{
+		Code:      s.Code,
+		Quantity:  quantity,
+		Reference: reference,
+		OnHand:    s.OnHand,
+		Reserved:  s.Reserved,
+		Version:   s.Version,
+	}
+	s.Record(event)
+
+	return nil
+}
+
+// ExpireReservations gives every reservation whose expiry has passed at now back to the
+// available stock, in order of reference, and reports whether any did. The stock moves
+// to its next version once for all of them.
+func (s *FabricStock) ExpireReservations(now time.Time, stamp Stamp) bool {
+	var expired []StockReservation
+	for _, reservation := range s.Reservations {
+		if reservation.ExpiresAt != nil && !reservation.ExpiresAt.After(now) {
+			expired = append(expired, reservation)
+		}
+	}
+	if len(expired) == 0 {
+		return false
+	}
+	slices.SortFunc(expired, func(a, b StockReservation) int { return strings.Compare(a.Reference, b.Reference) })
+
+	s.Version++
+	s.touch(stamp)
+	for _, reservation := range expired {
+		delete(s.Reservations, reservation.Reference)
+		s.Reserved -= reservation.Quantity
+
+		event := FabricStockReservationExpired{
+			Code:      s.Code,
+			Quantity:  reservation.Quantity,
+			Reference: reservation.Reference,
+			ExpiredAt: *reservation.ExpiresAt,
+			OnHand:    s.OnHand,
+			Reserved:  s.Reserved,
+			Version:   s.Version,
+		}
+		s.Record(event)
+	}
+
+	return true
+}
+
+// untrackedReserved is the part of the reserved quantity that is not held by any of the
+// reservations, reserved before they were tracked.
+func (s *FabricStock) untrackedReserved() Quantity {
+	untracked := s.Reserved
+	for _, reservation := range s.Reservations {
+		untracked -= reservation.Quantity
+	}
+	return untracked
+}
+
+// moveLocation changes the quantity on hand at the warehouse, a warehouse left with nothing
+// is dropped from the locations.
+func (s *FabricStock) moveLocation(warehouse string, delta Quantity) {
+	onHand := s.Locations[warehouse] + delta
+	if onHand == 0 {
+		delete(s.Locations, warehouse)
+		return
+	}
+	if s.Locations == nil {
+		s.Locations = make(map[string]Quantity)
+	}
+	s.Locations[warehouse] = onHand
+}
+
+func (s *FabricStock) touch(stamp Stamp) {
+	s.UpdatedAt = stamp.At
+	s.UpdatedBy = stamp.By
+}
+
+func validateStockChange(quantity Quantity, reference string) error {
+	if quantity <= 0 {
+		return ErrNonPositiveQuantity
+	}
+	if len(reference) < 1 || len(reference) > 100 {
+		return ErrInvalidStockReference
+	}
+	return nil
+}
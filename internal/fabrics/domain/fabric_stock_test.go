@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuantity(t *testing.T) {
+	testCases := []struct {
+		name        string
+		raw         string
+		expected    Quantity
+		expectedErr error
+	}{
+		{name: "Whole", raw: "12", expected: 12000},
+		{name: "Decimal", raw: "12.5", expected: 12500},
+		{name: "Thousandth", raw: "0.001", expected: 1},
+		{name: "Negative", raw: "-2.25", expected: -2250},
+		{name: "Too many decimals", raw: "1.2345", expectedErr: ErrInvalidQuantity},
+		{name: "Missing whole part", raw: ".5", expectedErr: ErrInvalidQuantity},
+		{name: "Explicit plus", raw: "+1", expectedErr: ErrInvalidQuantity},
+		{name: "Not a number", raw: "plenty", expectedErr: ErrInvalidQuantity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			quantity, err := ParseQuantity(tc.raw)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, quantity)
+		})
+	}
+}
+
+func TestQuantity_String(t *testing.T) {
+	assert.Equal(t, "12.5", Quantity(12500).String())
+	assert.Equal(t, "0.001", Quantity(1).String())
+	assert.Equal(t, "-3", Quantity(-3000).String())
+	assert.Equal(t, "0", Quantity(0).String())
+}
+
+func TestFabricStock_AdjustReserveRelease(t *testing.T) {
+	// --- Arrange ---
+	stock := NewFabricStock("TESTCODE")
+
+	// --- Act ---
+	require.NoError(t, stock.Adjust(10000, "goods receipt", "", 0, testStamp))
+	require.NoError(t, stock.Reserve(4000, "ORDER-1", nil, 1, testStamp))
+	require.NoError(t, stock.Release(1500, "ORDER-1", 2, testStamp))
+
+	// --- Assert ---
+	assert.Equal(t, Quantity(10000), stock.OnHand)
+	assert.Equal(t, Quantity(2500), stock.Reserved)
+	assert.Equal(t, Quantity(7500), stock.Available())
+	assert.Equal(t, 3, stock.Version)
+	assert.Equal(t, testStamp.By, stock.UpdatedBy)
+
+	require.Len(t, stock.Events(), 3)
+	released, ok := stock.Events()[2].(FabricStockReleased)
+	require.True(t, ok, "expected a FabricStockReleased event")
+	assert.Equal(t, Quantity(2500), released.Reserved)
+	assert.Equal(t, 3, released.Version)
+}
+
+func TestFabricStock_AdjustAtWarehouse(t *testing.T) {
+	// --- Arrange ---
+	stock := NewFabricStock("TESTCODE")
+	require.NoError(t, stock.Adjust(2000, "opening balance", "", 0, testStamp))
+
+	// --- Act ---
+	require.NoError(t, stock.Adjust(10000, "goods receipt", "WH-LDZ", 1, testStamp))
+	require.NoError(t, stock.Adjust(3000, "goods receipt", "WH-WAW", 2, testStamp))
+	require.NoError(t, stock.Adjust(-3000, "transfer out", "WH-WAW", 3, testStamp))
+
+	// --- Assert ---
+	assert.Equal(t, Quantity(12000), stock.OnHand)
+	assert.Equal(t, map[string]Quantity{"WH-LDZ": 10000}, stock.Locations, "an emptied warehouse is dropped")
+	adjusted, ok := stock.Events()[3].(FabricStockAdjusted)
+	require.True(t, ok, "expected a FabricStockAdjusted event")
+	assert.Equal(t, "WH-WAW", adjusted.Warehouse)
+	assert.Equal(t, Quantity(-3000), adjusted.Delta)
+}
+
+func TestFabricStock_Rejected(t *testing.T) {
+	testCases := []struct {
+		name        string
+		move        func(stock *FabricStock) error
+		expectedErr error
+	}{
+		{
+			name:        "Stale version",
+			move:        func(s *FabricStock) error { return s.Adjust(1000, "stocktake", "", 0, testStamp) },
+			expectedErr: ErrConcurrencyConflict,
+		},
+		{
+			name:        "Zero adjustment",
+			move:        func(s *FabricStock) error { return s.Adjust(0, "stocktake", "", 2, testStamp) },
+			expectedErr: ErrZeroStockAdjustment,
+		},
+		{
+			name:        "Adjusting reserved stock away",
+			move:        func(s *FabricStock) error { return s.Adjust(-7000, "write-off", "", 2, testStamp) },
+			expectedErr: ErrInsufficientStock,
+		},
+		{
+			name:        "Adjusting away more than on hand at the warehouse",
+			move:        func(s *FabricStock) error { return s.Adjust(-1000, "write-off", "WH-LDZ", 2, testStamp) },
+			expectedErr: ErrInsufficientLocationStock,
+		},
+		{
+			name:        "Reserving more than available",
+			move:        func(s *FabricStock) error { return s.Reserve(6001, "ORDER-2", nil, 2, testStamp) },
+			expectedErr: ErrInsufficientStock,
+		},
+		{
+			name:        "Reserving nothing",
+			move:        func(s *FabricStock) error { return s.Reserve(-1000, "ORDER-2", nil, 2, testStamp) },
+			expectedErr: ErrNonPositiveQuantity,
+		},
+		{
+			name:        "Reserving without reference",
+			move:        func(s *FabricStock) error { return s.Reserve(1000, "", nil, 2, testStamp) },
+			expectedErr: ErrInvalidStockReference,
+		},
+		{
+			name: "Reserving until a time passed",
+			move: func(s *FabricStock) error {
+				return s.Reserve(1000, "QUOTE-1", &testStamp.At, 2, testStamp)
+			},
+			expectedErr: ErrReservationExpiryPassed,
+		},
+		{
+			name:        "Releasing more than reserved",
+			move:        func(s *FabricStock) error { return s.Release(4001, "ORDER-1", 2, testStamp) },
+			expectedErr: ErrReservationExceeded,
+		},
+		{
+			name:        "Releasing for a reference without a reservation",
+			move:        func(s *FabricStock) error { return s.Release(1000, "ORDER-2", 2, testStamp) },
+			expectedErr: ErrReservationExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			stock := NewFabricStock("TESTCODE")
+			require.NoError(t, stock.Adjust(10000, "goods receipt", "", 0, testStamp))
+			require.NoError(t, stock.Reserve(4000, "ORDER-1", nil, 1, testStamp))
+
+			// --- Act ---
+			err := tc.move(stock)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, 2, stock.Version, "a rejected movement must not change the stock")
+			assert.Len(t, stock.Events(), 2)
+		})
+	}
+}
+
+func TestFabricStock_ExpireReservations(t *testing.T) {
+	// --- Arrange ---
+	stock := NewFabricStock("TESTCODE")
+	require.NoError(t, stock.Adjust(10000, "goods receipt", "", 0, testStamp))
+	soon, later := testStamp.At.Add(time.Hour), testStamp.At.Add(48*time.Hour)
+	require.NoError(t, stock.Reserve(2000, "QUOTE-2", &soon, 1, testStamp))
+	require.NoError(t, stock.Reserve(1000, "QUOTE-1", &soon, 2, testStamp))
+	require.NoError(t, stock.Reserve(500, "QUOTE-3", &later, 3, testStamp))
+	require.NoError(t, stock.Reserve(1500, "ORDER-1", nil, 4, testStamp))
+
+	// --- Act ---
+	expired := stock.ExpireReservations(soon, testStamp)
+
+	// --- Assert ---
+	require.True(t, expired)
+	assert.Equal(t, Quantity(2000), stock.Reserved)
+	assert.Equal(t, 6, stock.Version, "all expiries move the stock to a single new version")
+	assert.ElementsMatch(t, []string{"QUOTE-3", "ORDER-1"}, slices.Collect(maps.Keys(stock.Reservations)))
+
+	require.Len(t, stock.Events(), 7)
+	first, ok := stock.Events()[5].(FabricStockReservationExpired)
+	require.True(t, ok, "expected a FabricStockReservationExpired event")
+	assert.Equal(t, "QUOTE-1", first.Reference)
+	assert.Equal(t, Quantity(1000), first.Quantity)
+	assert.Equal(t, soon, first.ExpiredAt)
+	last, ok := stock.Events()[6].(FabricStockReservationExpired)
+	require.True(t, ok, "expected a FabricStockReservationExpired event")
+	assert.Equal(t, "QUOTE-2", last.Reference)
+	assert.Equal(t, Quantity(2000), last.Reserved)
+	assert.Equal(t, 6, last.Version)
+
+	assert.False(t, stock.ExpireReservations(soon, testStamp), "nothing more expires at the same time")
+	assert.Equal(t, 6, stock.Version)
+}
+
+func TestFabricStock_ReleaseUntrackedReservation(t *testing.T) {
+	// --- Arrange ---
+	stock := &FabricStock{Code: "TESTCODE", OnHand: 10000, Reserved: 3000, Version: 5}
+	require.NoError(t, stock.Reserve(1000, "ORDER-2", nil, 5, testStamp))
+
+	// --- Act ---
+	err := stock.Release(3000, "ORDER-1", 6, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, Quantity(1000), stock.Reserved)
+	assert.Equal(t, Quantity(1000), stock.Reservations["ORDER-2"].Quantity)
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var testStamp = Stamp{By: "tester", At: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+
 func TestFabric_UpdateFabric_HappyPath(t *testing.T) {
 	// --- Arrange ---
-	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available")
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
 	require.NoError(t, err, "Test setup should not fail")
 	initialVersion := fabric.Version
 
@@ -21,18 +23,18 @@ func TestFabric_UpdateFabric_HappyPath(t *testing.T) {
 	updatedOfferStatus := "unavailable"
 
 	// --- Act ---
-	err = fabric.UpdateFabric(updatedName, updatedMeasureUnit, updatedOfferStatus, initialVersion)
+	err = fabric.UpdateFabric(updatedName, updatedMeasureUnit, updatedOfferStatus, nil, FabricTexts{}, initialVersion, testStamp)
 
 	// --- Assert ---
 	assert.NoError(t, err)
 	assert.Equal(t, updatedName, fabric.Name)
-	assert.Equal(t, updatedMeasureUnit, fabric.MeasureUnit)
-	assert.Equal(t, updatedOfferStatus, fabric.OfferStatus)
+	assert.Equal(t, MeasureUnitCentimetre, fabric.MeasureUnit)
+	assert.Equal(t, OfferStatusUnavailable, fabric.OfferStatus)
 	assert.Equal(t, initialVersion+1, fabric.Version, "Version should be incremented by 1")
 
 	// Check for the FabricUpdated event
-	require.Len(t, fabric.events, 2, "There should be two events: Created and Updated")
-	updateEvent, ok := fabric.events[1].(FabricUpdated)
+	require.Len(t, fabric.Events(), 2, "There should be two events: Created and Updated")
+	updateEvent, ok := fabric.Events()[1].(FabricUpdated)
 	require.True(t, ok, "The second event must be a FabricUpdated event")
 
 	assert.Equal(t, fabric.Code, updateEvent.Code)
@@ -42,7 +44,7 @@ func TestFabric_UpdateFabric_HappyPath(t *testing.T) {
 
 func TestFabric_UpdateFabric_ConcurrencyConflict(t *testing.T) {
 	// --- Arrange ---
-	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available")
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
 	require.NoError(t, err, "Test setup should not fail")
 
 	staleVersion := fabric.Version - 1 // Simulate a stale version number
@@ -50,30 +52,30 @@ func TestFabric_UpdateFabric_ConcurrencyConflict(t *testing.T) {
 
 	// --- Act ---
 	// Attempt to update with a stale version
-	err = fabric.UpdateFabric("New Name", "cm", "new_status", staleVersion)
+	err = fabric.UpdateFabric("New Name", "cm", "new_status", nil, FabricTexts{}, staleVersion, testStamp)
 
 	// --- Assert ---
 	assert.Error(t, err, "An error should be returned for a version mismatch")
 	assert.ErrorIs(t, err, ErrConcurrencyConflict, "The error should be a concurrency conflict error")
 	assert.Equal(t, correctVersion, fabric.Version, "Version should not change on a failed update")
-	assert.Len(t, fabric.events, 1, "No new event should be added on a failed update")
+	assert.Len(t, fabric.Events(), 1, "No new event should be added on a failed update")
 }
 
 func TestFabric_UpdateFabric_InvalidName(t *testing.T) {
 	// --- Arrange ---
-	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available")
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
 	require.NoError(t, err)
 	correctVersion := fabric.Version
 
 	// --- Act ---
 	// Attempt to update with an invalid name
-	err = fabric.UpdateFabric("", "cm", "new_status", correctVersion)
+	err = fabric.UpdateFabric("", "cm", "new_status", nil, FabricTexts{}, correctVersion, testStamp)
 
 	// --- Assert ---
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, ErrInvalidFabricNameLength)
 	assert.Equal(t, correctVersion, fabric.Version, "Version should not change on a failed update")
-	assert.Len(t, fabric.events, 1, "No new event should be added on a failed update")
+	assert.Len(t, fabric.Events(), 1, "No new event should be added on a failed update")
 }
 
 func TestNewFabric_ValidInput_ShouldSucced(t *testing.T) {
@@ -84,14 +86,15 @@ func TestNewFabric_ValidInput_ShouldSucced(t *testing.T) {
 	offerStatus := "prototyp"
 
 	// --- Act ---
-	fabric, err := NewFabric(code, name, measureUnit, offerStatus)
+	fabric, err := NewFabric(code, name, measureUnit, offerStatus, Specification{}, FabricTexts{}, testStamp)
 
 	// --- Assert ---
 	assert.NoError(t, err)
 	assert.NotNil(t, fabric)
 	assert.Equal(t, fabric.Code, code)
 	assert.Equal(t, fabric.Name, name)
-	assert.Equal(t, fabric.MeasureUnit, measureUnit)
+	assert.Equal(t, MeasureUnitRunningMetre, fabric.MeasureUnit)
+	assert.Equal(t, OfferStatusPrototype, fabric.OfferStatus)
 	assert.Equal(t, fabric.Version, 1)
 }
 
@@ -109,7 +112,7 @@ func TestNewFabric_InvalidCodeInput_ShouldFail(t *testing.T) {
 	for _, code := range invalidCodes {
 		t.Run("InvalidCode_"+code, func(t *testing.T) {
 			// --- Act ---
-			fabric, err := NewFabric(code, name, measureUnit, offerStatus)
+			fabric, err := NewFabric(code, name, measureUnit, offerStatus, Specification{}, FabricTexts{}, testStamp)
 
 			// --- Assert ---
 			assert.Error(t, err, "NewFabric should fail for invalid code")
@@ -131,7 +134,7 @@ func TestNewFabric_InvalidNameInput_ShouldFail(t *testing.T) {
 	for _, name := range invalidNames {
 		t.Run("InvalidName_"+name, func(t *testing.T) {
 			// --- Act ---
-			fabric, err := NewFabric(code, name, measureUnit, offerStatus)
+			fabric, err := NewFabric(code, name, measureUnit, offerStatus, Specification{}, FabricTexts{}, testStamp)
 
 			// --- Assert ---
 			assert.Error(t, err, "NewFabric should fail for invalid name")
@@ -148,7 +151,7 @@ func TestNewFabric_ShouldGenerateFabricCreatedEvent(t *testing.T) {
 	offerStatus := "prototyp"
 
 	// --- Act ---
-	fabric, err := NewFabric(code, name, measureUnit, offerStatus)
+	fabric, err := NewFabric(code, name, measureUnit, offerStatus, Specification{}, FabricTexts{}, testStamp)
 	assert.NoError(t, err)
 
 	events := fabric.Events()
@@ -164,8 +167,9 @@ func TestNewFabric_ShouldGenerateFabricCreatedEvent(t *testing.T) {
 		FabricCreated{
 			Code:        code,
 			Name:        name,
-			MeasureUnit: measureUnit,
-			OfferStatus: offerStatus,
+			MeasureUnit: MeasureUnitRunningMetre,
+			OfferStatus: OfferStatusPrototype,
+			Status:      StatusActive,
 			Version:     1,
 		},
 		event,
@@ -185,7 +189,7 @@ func generateRandomString(length int) string {
 
 func TestFabric_Update_FailsOnDeletedFabric(t *testing.T) {
 	// --- Arrange ---
-	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available")
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
 	require.NoError(t, err)
 
 	// Manually set the fabric to a deleted state for the test
@@ -193,7 +197,7 @@ func TestFabric_Update_FailsOnDeletedFabric(t *testing.T) {
 	fabric.Version++ // Simulate a version increment from the delete operation
 
 	// --- Act ---
-	err = fabric.UpdateFabric("Attempted Update", "cm", "new", fabric.Version)
+	err = fabric.UpdateFabric("Attempted Update", "cm", "new", nil, FabricTexts{}, fabric.Version, testStamp)
 
 	// --- Assert ---
 	assert.Error(t, err, "Should not be able to update a deleted fabric")
@@ -202,20 +206,20 @@ func TestFabric_Update_FailsOnDeletedFabric(t *testing.T) {
 
 func TestFabric_Delete_HappyPath(t *testing.T) {
 	// --- Arrange ---
-	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available")
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
 	require.NoError(t, err)
 	initialVersion := fabric.Version
 
 	// --- Act ---
-	err = fabric.Delete(initialVersion)
+	err = fabric.Delete(initialVersion, testStamp)
 
 	// --- Assert ---
 	assert.NoError(t, err)
 	assert.Equal(t, StatusDeleted, fabric.Status)
 	assert.Equal(t, initialVersion+1, fabric.Version)
 
-	require.Len(t, fabric.events, 2, "Should have Created and Deleted events")
-	deleteEvent, ok := fabric.events[1].(FabricDeleted)
+	require.Len(t, fabric.Events(), 2, "Should have Created and Deleted events")
+	deleteEvent, ok := fabric.Events()[1].(FabricDeleted)
 	require.True(t, ok, "The second event must be a FabricDeleted event")
 	assert.Equal(t, fabric.Code, deleteEvent.Code)
 	assert.Equal(t, fabric.Version, deleteEvent.Version)
@@ -223,23 +227,23 @@ func TestFabric_Delete_HappyPath(t *testing.T) {
 
 func TestFabric_Delete_ConcurrencyConflict(t *testing.T) {
 	// --- Arrange ---
-	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available")
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
 	require.NoError(t, err)
 	staleVersion := fabric.Version - 1
 
 	// --- Act ---
-	err = fabric.Delete(staleVersion)
+	err = fabric.Delete(staleVersion, testStamp)
 
 	// --- Assert ---
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, ErrConcurrencyConflict)
 	assert.Equal(t, StatusActive, fabric.Status, "Status should not change on failed delete")
-	assert.Len(t, fabric.events, 1, "No new event should be added on failed delete")
+	assert.Len(t, fabric.Events(), 1, "No new event should be added on failed delete")
 }
 
 func TestFabric_Reactivate_HappyPath(t *testing.T) {
 	// --- Arrange ---
-	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available")
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
 	require.NoError(t, err)
 	// Manually set it to a deleted state for the test
 	fabric.Status = StatusDeleted
@@ -248,7 +252,7 @@ func TestFabric_Reactivate_HappyPath(t *testing.T) {
 	reactivatedName := "Reactivated Name"
 
 	// --- Act ---
-	err = fabric.Reactivate(reactivatedName, "m", "available", 2)
+	err = fabric.Reactivate(StatusActive, reactivatedName, "m", "available", Specification{}, FabricTexts{}, 2, testStamp)
 
 	// --- Assert ---
 	assert.NoError(t, err)
@@ -256,9 +260,260 @@ func TestFabric_Reactivate_HappyPath(t *testing.T) {
 	assert.Equal(t, reactivatedName, fabric.Name)
 	assert.Equal(t, 3, fabric.Version)
 
-	require.Len(t, fabric.events, 2, "Should have Created and Reactivated events")
-	reactivateEvent, ok := fabric.events[1].(FabricReactivated)
+	require.Len(t, fabric.Events(), 2, "Should have Created and Reactivated events")
+	reactivateEvent, ok := fabric.Events()[1].(FabricReactivated)
 	require.True(t, ok, "The second event must be a FabricReactivated event")
 	assert.Equal(t, fabric.Code, reactivateEvent.Code)
 	assert.Equal(t, fabric.Version, reactivateEvent.Version)
 }
+
+func TestFabric_Restore_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	require.NoError(t, fabric.Delete(1, testStamp))
+
+	// --- Act ---
+	err = fabric.Restore(2, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, fabric.Status)
+	assert.Equal(t, "Original Name", fabric.Name, "prior data is kept")
+	assert.Equal(t, 3, fabric.Version)
+
+	require.Len(t, fabric.Events(), 3, "Should have Created, Deleted and Restored events")
+	restoreEvent, ok := fabric.Events()[2].(FabricRestored)
+	require.True(t, ok, "The third event must be a FabricRestored event")
+	assert.Equal(t, "Original Name", restoreEvent.Name)
+	assert.Equal(t, MeasureUnitMetre, restoreEvent.MeasureUnit)
+	assert.Equal(t, 3, restoreEvent.Version)
+}
+
+func TestFabric_Restore_Rejected(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      string
+		version     int
+		expectedErr error
+	}{
+		{name: "Active fabric", status: StatusActive, version: 1, expectedErr: ErrFabricNotDeleted},
+		{name: "Merged fabric", status: StatusMerged, version: 1, expectedErr: ErrFabricMerged},
+		{name: "Stale version", status: StatusDeleted, version: 3, expectedErr: ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			fabric.Status = tc.status
+
+			// --- Act ---
+			err = fabric.Restore(tc.version, testStamp)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.status, fabric.Status)
+			assert.Len(t, fabric.Events(), 1, "No new event should be added on failed restore")
+		})
+	}
+}
+
+func TestFabric_AuditFields(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+
+	updateStamp := Stamp{By: "editor", At: testStamp.At.Add(time.Hour)}
+
+	// --- Act ---
+	err = fabric.UpdateFabric("Updated Name", "m", "available", nil, FabricTexts{}, fabric.Version, updateStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, testStamp.By, fabric.CreatedBy, "creator should not change on update")
+	assert.Equal(t, testStamp.At, fabric.CreatedAt, "creation time should not change on update")
+	assert.Equal(t, updateStamp.By, fabric.UpdatedBy)
+	assert.Equal(t, updateStamp.At, fabric.UpdatedAt)
+}
+
+func TestFabricConflict_Resolve(t *testing.T) {
+	// --- Arrange ---
+	conflict := &FabricConflict{ID: 1, Status: ConflictStatusPending}
+
+	// --- Act ---
+	err := conflict.Resolve(ConflictStatusDiscarded, testStamp)
+	againErr := conflict.Resolve(ConflictStatusApplied, testStamp)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, ConflictStatusDiscarded, conflict.Status)
+	assert.Equal(t, testStamp.By, conflict.ResolvedBy)
+	require.NotNil(t, conflict.ResolvedAt)
+	assert.True(t, testStamp.At.Equal(*conflict.ResolvedAt))
+	assert.ErrorIs(t, againErr, ErrConflictAlreadyResolved)
+}
+
+func TestPendingFabricEvent_ScheduleRetry_BacksOffExponentially(t *testing.T) {
+	// --- Arrange ---
+	event := &PendingFabricEvent{}
+	now := testStamp.At
+
+	// --- Act & Assert ---
+	event.ScheduleRetry(now)
+	assert.Equal(t, 1, event.Attempts)
+	assert.Equal(t, now.Add(5*time.Second), event.NextAttemptAt)
+
+	event.ScheduleRetry(now)
+	assert.Equal(t, now.Add(10*time.Second), event.NextAttemptAt)
+
+	for range 10 {
+		event.ScheduleRetry(now)
+	}
+	assert.Equal(t, now.Add(5*time.Minute), event.NextAttemptAt, "backoff should be capped")
+}
+
+func TestFabric_MergeInto_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	duplicate, err := NewFabric("DUPE01", "Duplicate", "m", "available", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	canonical, err := NewFabric("CANON01", "Canonical", "m", "available", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	err = duplicate.MergeInto(canonical, 1, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, StatusMerged, duplicate.Status)
+	assert.Equal(t, 2, duplicate.Version)
+	assert.Equal(t, StatusActive, canonical.Status, "the canonical fabric is left untouched")
+
+	require.Len(t, duplicate.Events(), 2, "Should have Created and Merged events")
+	mergeEvent, ok := duplicate.Events()[1].(FabricMerged)
+	require.True(t, ok, "The second event must be a FabricMerged event")
+	assert.Equal(t, "DUPE01", mergeEvent.Code)
+	assert.Equal(t, "CANON01", mergeEvent.CanonicalCode)
+	assert.Equal(t, 2, mergeEvent.Version)
+}
+
+func TestFabric_MergeInto_Rejected(t *testing.T) {
+	testCases := []struct {
+		name            string
+		duplicateStatus string
+		canonicalCode   string
+		canonicalStatus string
+		version         int
+		expectedErr     error
+	}{
+		{name: "Deleted duplicate", duplicateStatus: StatusDeleted, canonicalCode: "CANON01", canonicalStatus: StatusActive, version: 1, expectedErr: ErrFabricDeleted},
+		{name: "Already merged", duplicateStatus: StatusMerged, canonicalCode: "CANON01", canonicalStatus: StatusActive, version: 1, expectedErr: ErrFabricMerged},
+		{name: "Into itself", duplicateStatus: StatusActive, canonicalCode: "DUPE01", canonicalStatus: StatusActive, version: 1, expectedErr: ErrInvalidMergeTarget},
+		{name: "Into a deleted fabric", duplicateStatus: StatusActive, canonicalCode: "CANON01", canonicalStatus: StatusDeleted, version: 1, expectedErr: ErrInvalidMergeTarget},
+		{name: "Stale version", duplicateStatus: StatusActive, canonicalCode: "CANON01", canonicalStatus: StatusActive, version: 3, expectedErr: ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			duplicate, err := NewFabric("DUPE01", "Duplicate", "m", "available", Specification{}, FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			duplicate.Status = tc.duplicateStatus
+			canonical, err := NewFabric(tc.canonicalCode, "Canonical", "m", "available", Specification{}, FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			canonical.Status = tc.canonicalStatus
+
+			// --- Act ---
+			err = duplicate.MergeInto(canonical, tc.version, testStamp)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.duplicateStatus, duplicate.Status)
+			assert.Len(t, duplicate.Events(), 1, "No new event should be added on failed merge")
+		})
+	}
+}
+
+func TestNewFabric_InvalidAttributes_ShouldFail(t *testing.T) {
+	testCases := []struct {
+		name        string
+		measureUnit string
+		offerStatus string
+		expectedErr error
+	}{
+		{name: "Missing measure unit", measureUnit: "", offerStatus: "available", expectedErr: ErrInvalidMeasureUnit},
+		{name: "Unknown measure unit", measureUnit: "yard", offerStatus: "available", expectedErr: ErrInvalidMeasureUnit},
+		{name: "Missing offer status", measureUnit: "mb", offerStatus: "", expectedErr: ErrInvalidOfferStatus},
+		{name: "Unknown offer status", measureUnit: "mb", offerStatus: "someday", expectedErr: ErrInvalidOfferStatus},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			fabric, err := NewFabric("ZOYA", "Zoya", tc.measureUnit, tc.offerStatus, Specification{}, FabricTexts{}, testStamp)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, fabric)
+		})
+	}
+}
+
+func TestFabric_UpdateFabric_InvalidAttributes(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	err = fabric.UpdateFabric("Original Name", "yard", "available", nil, FabricTexts{}, fabric.Version, testStamp)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, ErrInvalidMeasureUnit)
+	assert.Equal(t, MeasureUnitMetre, fabric.MeasureUnit, "the fabric should not change on a failed update")
+	assert.Equal(t, 1, fabric.Version)
+	assert.Len(t, fabric.Events(), 1)
+}
+
+func TestFabric_ChangeCode(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      string
+		code        string
+		version     int
+		expectedErr error
+	}{
+		{name: "Active fabric", status: StatusActive, code: "NEWCODE", version: 1},
+		{name: "Draft fabric", status: StatusDraft, code: "NEWCODE", version: 1},
+		{name: "Same code", status: StatusActive, code: "TESTCODE", version: 1, expectedErr: ErrUnchangedFabricCode},
+		{name: "Invalid code", status: StatusActive, code: "new-code", version: 1, expectedErr: ErrInvalidFabricCodePattern},
+		{name: "Stale version", status: StatusActive, code: "NEWCODE", version: 2, expectedErr: ErrConcurrencyConflict},
+		{name: "Archived fabric", status: StatusArchived, code: "NEWCODE", version: 1, expectedErr: ErrFabricArchived},
+		{name: "Deleted fabric", status: StatusDeleted, code: "NEWCODE", version: 1, expectedErr: ErrFabricDeleted},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fabric, err := NewFabric("TESTCODE", "Original Name", "m", "available", Specification{}, FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			fabric.Status = tc.status
+
+			// --- Act ---
+			err = fabric.ChangeCode(tc.code, tc.version, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Equal(t, "TESTCODE", fabric.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "NEWCODE", fabric.Code)
+			assert.Equal(t, 2, fabric.Version)
+			event, ok := fabric.Events()[len(fabric.Events())-1].(FabricCodeChanged)
+			require.True(t, ok)
+			assert.Equal(t, FabricCodeChanged{Code: "NEWCODE", PreviousCode: "TESTCODE", Version: 2}, event)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxDescriptionLength = 5000
+	maxNotesLength       = 2000
+)
+
+var (
+	ErrInvalidDescriptionLength = validationError(
+		"invalid_description_length", "description", "the fabric description length must be at most 5000",
+		map[string]any{"max": maxDescriptionLength},
+	)
+	ErrInvalidDescriptionMarkup = validationError(
+		"invalid_description_markup", "description", "the fabric description must be markdown without HTML tags or control characters",
+		nil,
+	)
+	ErrInvalidNotesLength = validationError(
+		"invalid_notes_length", "notes", "the fabric notes length must be at most 2000",
+		map[string]any{"max": maxNotesLength},
+	)
+	ErrInvalidNotesCharacters = validationError(
+		"invalid_notes_characters", "notes", "the fabric notes must not contain control characters", nil,
+	)
+)
+
+// an opening or closing HTML tag, a comment or a declaration, which markdown would pass
+// through to the rendered page as is
+var htmlTagPattern = regexp.MustCompile(`<(!--|[!/?]?[A-Za-z])`)
+
+// FabricTexts carries the long-form texts given with a command on a fabric. The description
+// is markdown shown to customers, the notes are internal remarks for staff. A text left nil
+// is kept as it is on update and left empty on creation.
+type FabricTexts struct {
+	Description *string
+	Notes       *string
+}
+
+// Validate checks the texts that are given. Lengths are counted in characters.
+func (t FabricTexts) Validate() error {
+	if t.Description != nil {
+		if utf8.RuneCountInString(*t.Description) > maxDescriptionLength {
+			return ErrInvalidDescriptionLength
+		}
+		if htmlTagPattern.MatchString(*t.Description) || hasControlCharacters(*t.Description) {
+			return ErrInvalidDescriptionMarkup
+		}
+	}
+	if t.Notes != nil {
+		if utf8.RuneCountInString(*t.Notes) > maxNotesLength {
+			return ErrInvalidNotesLength
+		}
+		if hasControlCharacters(*t.Notes) {
+			return ErrInvalidNotesCharacters
+		}
+	}
+	return nil
+}
+
+// applyTo sets the texts that are given on the fabric.
+func (t FabricTexts) applyTo(f *Fabric) {
+	if t.Description != nil {
+		f.Description = *t.Description
+	}
+	if t.Notes != nil {
+		f.Notes = *t.Notes
+	}
+}
+
+// hasControlCharacters reports control characters other than the line breaks and tabs
+// of multi-line text.
+func hasControlCharacters(text string) bool {
+	for _, r := range text {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func text(s string) *string {
+	return &s
+}
+
+func TestFabricTexts_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		texts       FabricTexts
+		expectedErr error
+	}{
+		{name: "nothing given", texts: FabricTexts{}},
+		{name: "markdown", texts: FabricTexts{Description: text("# Linen\n\n* soft\n* width < 150 cm\n\n[care](https://example.com)")}},
+		{name: "longest description", texts: FabricTexts{Description: text(strings.Repeat("ż", maxDescriptionLength))}},
+		{name: "description too long", texts: FabricTexts{Description: text(strings.Repeat("a", maxDescriptionLength+1))}, expectedErr: ErrInvalidDescriptionLength},
+		{name: "html tag", texts: FabricTexts{Description: text("Soft <script>alert(1)</script>")}, expectedErr: ErrInvalidDescriptionMarkup},
+		{name: "html comment", texts: FabricTexts{Description: text("Soft <!-- hidden -->")}, expectedErr: ErrInvalidDescriptionMarkup},
+		{name: "control character", texts: FabricTexts{Description: text("Soft\x00linen")}, expectedErr: ErrInvalidDescriptionMarkup},
+		{name: "notes", texts: FabricTexts{Notes: text("call the mill\tbefore <reorder>")}},
+		{name: "notes too long", texts: FabricTexts{Notes: text(strings.Repeat("a", maxNotesLength+1))}, expectedErr: ErrInvalidNotesLength},
+		{name: "notes with control character", texts: FabricTexts{Notes: text("call\x07")}, expectedErr: ErrInvalidNotesCharacters},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Act ---
+			err := tt.texts.Validate()
+
+			// --- Assert ---
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestFabric_Texts(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric(
+		"FAB01", "Linen", "m", "available", Specification{},
+		FabricTexts{Description: text("Soft *linen*"), Notes: text("reorder in May")}, testStamp,
+	)
+	require.NoError(t, err)
+
+	// --- Act ---
+	keepErr := fabric.UpdateFabric("Linen", "m", "available", nil, FabricTexts{}, 1, testStamp)
+	clearErr := fabric.UpdateFabric("Linen", "m", "available", nil, FabricTexts{Notes: text("")}, 2, testStamp)
+	invalidErr := fabric.UpdateFabric("Linen", "m", "available", nil, FabricTexts{Description: text("<b>Soft</b>")}, 3, testStamp)
+
+	// --- Assert ---
+	created, ok := fabric.Events()[0].(FabricCreated)
+	require.True(t, ok)
+	assert.Equal(t, "Soft *linen*", created.Description)
+	assert.Equal(t, "reorder in May", created.Notes)
+
+	require.NoError(t, keepErr)
+	kept, ok := fabric.Events()[1].(FabricUpdated)
+	require.True(t, ok)
+	assert.Equal(t, "reorder in May", kept.Notes, "texts left out are kept")
+
+	require.NoError(t, clearErr)
+	cleared, ok := fabric.Events()[2].(FabricUpdated)
+	require.True(t, ok)
+	assert.Equal(t, "", cleared.Notes)
+	assert.Equal(t, "Soft *linen*", cleared.Description)
+
+	assert.ErrorIs(t, invalidErr, ErrInvalidDescriptionMarkup)
+	assert.Equal(t, 3, fabric.Version, "an invalid text changes nothing")
+	assert.Equal(t, "Soft *linen*", fabric.Description)
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"maps"
+	"slices"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	ErrInvalidLocale = validationError(
+		"invalid_locale", "locale", "the locale must be a language tag such as de or pt-BR", nil,
+	)
+	ErrTranslationNotFound = notFoundError("translation_not_found", "the fabric has no translation in this locale")
+)
+
+// Translation is the name and description of a fabric in one locale. A translation without
+// a description falls back to the description of the fabric.
+type Translation struct {
+	Name        string
+	Description string
+}
+
+// FabricTranslationSet is recorded when the translation of a fabric in a locale is added or
+// replaced.
+type FabricTranslationSet struct {
+	Code        string
+	Locale      string
+	Name        string
+	Description string
+	Version     int
+}
+
+type FabricTranslationRemoved struct {
+	Code    string
+	Locale  string
+	Version int
+}
+
+// ParseLocale reads a language tag such as "de" or "pt-br" into its canonical form, "pt-BR".
+func ParseLocale(raw string) (string, error) {
+	tag, err := language.Parse(raw)
+	if err != nil || tag == language.Und {
+		return "", ErrInvalidLocale.WithParam("value", raw)
+	}
+	return tag.String(), nil
+}
+
+// SetTranslation adds or replaces the name and description of a draft, active or
+// discontinued fabric in the locale.
+func (f *Fabric) SetTranslation(locale string, translation Translation, version int, stamp Stamp) error {
+	if err := f.checkEditable(); err != nil {
+		return err
+	}
+	if f.Version != version {
+		return versionConflict(f.Version, f.translationConflicts(locale, translation))
+	}
+	locale, err := ParseLocale(locale)
+	if err != nil {
+		return err
+	}
+	if err := validateName(translation.Name); err != nil {
+		return err
+	}
+	if err := (FabricTexts{Description: &translation.Description}).Validate(); err != nil {
+		return err
+	}
+
+	if f.Translations == nil {
+		f.Translations = map[string]Translation{}
+	}
+	f.Translations[locale] = translation
+	f.Version++
+	f.touch(stamp)
+
+	event := FabricTranslationSet{
+		Code:        f.Code,
+		Locale:      locale,
+		Name:        translation.Name,
+		Description: translation.Description,
+		Version:     f.Version,
+	}
+	f.Record(event)
+
+	return nil
+}
+
+// RemoveTranslation drops the translation of a draft, active or discontinued fabric in the
+// locale, the fabric falls back to another locale for it from then on.
+func (f *Fabric) RemoveTranslation(locale string, version int, stamp Stamp) error {
+	if err := f.checkEditable(); err != nil {
+		return err
+	}
+	if f.Version != version {
+		return versionConflict(f.Version, nil)
+	}
+	locale, err := ParseLocale(locale)
+	if err != nil {
+		return err
+	}
+	if _, ok := f.Translations[locale]; !ok {
+		return ErrTranslationNotFound.WithParam("locale", locale)
+	}
+
+	delete(f.Translations, locale)
+	f.Version++
+	f.touch(stamp)
+
+	event := FabricTranslationRemoved{
+		Code:    f.Code,
+		Locale:  locale,
+		Version: f.Version,
+	}
+	f.Record(event)
+
+	return nil
+}
+
+// Localize returns a copy of the fabric with the name and description of the translation
+// best matching the preferred languages of an Accept-Language header, together with the
+// locale served. The fabric's own texts, in the base locale, are served when no
+// translation matches.
+func (f *Fabric) Localize(acceptLanguage string, base language.Tag) (*Fabric, string) {
+	localized := *f
+	preferred, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(preferred) == 0 || len(f.Translations) == 0 {
+		return &localized, base.String()
+	}
+
+	// the base locale comes first, so it is the fallback of the matcher
+	locales := slices.Sorted(maps.Keys(f.Translations))
+	supported := []language.Tag{base}
+	for _, locale := range locales {
+		supported = append(supported, language.Make(locale))
+	}
+	_, index, confidence := language.NewMatcher(supported).Match(preferred...)
+	if index == 0 || confidence == language.No {
+		return &localized, base.String()
+	}
+
+	translation := f.Translations[locales[index-1]]
+	localized.Name = translation.Name
+	if translation.Description != "" {
+		localized.Description = translation.Description
+	}
+	return &localized, locales[index-1]
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		raw       string
+		expected  string
+		expectErr bool
+	}{
+		{raw: "de", expected: "de"},
+		{raw: "pt-br", expected: "pt-BR"},
+		{raw: "PL", expected: "pl"},
+		{raw: "", expectErr: true},
+		{raw: "und", expectErr: true},
+		{raw: "not a locale", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			// --- Act ---
+			locale, err := ParseLocale(tt.raw)
+
+			// --- Assert ---
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidLocale)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, locale)
+		})
+	}
+}
+
+func TestFabric_Translations(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric("VELVET01", "Velvet", "m", "available", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	setErr := fabric.SetTranslation("DE", Translation{Name: "Samt"}, 1, testStamp)
+	invalidErr := fabric.SetTranslation("de", Translation{Name: "Samt", Description: "<i>weich</i>"}, 2, testStamp)
+	staleErr := fabric.SetTranslation("pl", Translation{Name: "Aksamit"}, 1, testStamp)
+	missingErr := fabric.RemoveTranslation("pl", 2, testStamp)
+	removeErr := fabric.RemoveTranslation("de", 2, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, setErr)
+	set, ok := fabric.Events()[1].(FabricTranslationSet)
+	require.True(t, ok)
+	assert.Equal(t, "de", set.Locale)
+	assert.Equal(t, 2, set.Version)
+
+	assert.ErrorIs(t, invalidErr, ErrInvalidDescriptionMarkup)
+	assert.ErrorIs(t, staleErr, ErrConcurrencyConflict)
+	assert.ErrorIs(t, missingErr, ErrTranslationNotFound)
+
+	require.NoError(t, removeErr)
+	assert.Empty(t, fabric.Translations)
+	assert.Equal(t, 3, fabric.Version)
+	_, ok = fabric.Events()[2].(FabricTranslationRemoved)
+	assert.True(t, ok)
+}
+
+func TestFabric_Localize(t *testing.T) {
+	// --- Arrange ---
+	fabric := &Fabric{
+		Name: "Velvet", Description: "Soft velvet",
+		Translations: map[string]Translation{"de": {Name: "Samt"}, "pt-BR": {Name: "Veludo", Description: "Veludo macio"}},
+	}
+
+	// --- Act ---
+	german, germanLocale := fabric.Localize("de-AT", language.English)
+	portuguese, portugueseLocale := fabric.Localize("pt-BR,pt;q=0.9", language.English)
+	fallback, fallbackLocale := fabric.Localize("ja", language.English)
+	_, invalidLocale := fabric.Localize(";;;", language.English)
+
+	// --- Assert ---
+	assert.Equal(t, "de", germanLocale)
+	assert.Equal(t, "Samt", german.Name)
+	assert.Equal(t, "Soft velvet", german.Description, "a missing description falls back to the fabric's")
+	assert.Equal(t, "pt-BR", portugueseLocale)
+	assert.Equal(t, "Veludo macio", portuguese.Description)
+	assert.Equal(t, "en", fallbackLocale)
+	assert.Equal(t, "Velvet", fallback.Name)
+	assert.Equal(t, "en", invalidLocale)
+	assert.Equal(t, "Velvet", fabric.Name, "localizing leaves the fabric as it is")
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// FieldConflict is a field a command made against a stale version would set to a value
+// other than the one it holds at the current version. Field is the path of the field in the
+// request, e.g. specification.width_cm.
+type FieldConflict struct {
+	Field     string `json:"field"`
+	Current   any    `json:"current"`
+	Requested any    `json:"requested"`
+}
+
+// versionConflict reports a command made against a stale version together with the current
+// version, so clients can merge their change and retry instead of refreshing blindly.
+// Commands that set fields also pass the fields they disagree on, an empty list meaning
+// the command can be retried as is at the current version.
+func versionConflict(current int, conflicts []FieldConflict) *DomainError {
+	err := ErrConcurrencyConflict.WithParam("current_version", current)
+	if conflicts != nil {
+		err = err.WithParam("conflicts", conflicts)
+	}
+	return err
+}
+
+// fieldConflicts collects the fields whose requested value differs from the current one,
+// in the order they are compared.
+type fieldConflicts []FieldConflict
+
+func (c *fieldConflicts) compare(field string, current, requested any) {
+	if !reflect.DeepEqual(current, requested) {
+		*c = append(*c, FieldConflict{Field: field, Current: current, Requested: requested})
+	}
+}
+
+// updateConflicts lists the fields of an update that disagree with the fabric, comparing
+// the measure unit and offer status in their canonical form. A nil spec and texts left out
+// are not part of the update, so they cannot conflict.
+func (f *Fabric) updateConflicts(name, measureUnit, offerStatus string, spec *Specification, texts FabricTexts) []FieldConflict {
+	if unit, status, err := parseAttributes(measureUnit, offerStatus); err == nil {
+		measureUnit, offerStatus = string(unit), string(status)
+	}
+	conflicts := fieldConflicts{}
+	conflicts.compare("name", f.Name, name)
+	conflicts.compare("measure_unit", string(f.MeasureUnit), measureUnit)
+	conflicts.compare("offer_status", string(f.OfferStatus), offerStatus)
+	if spec != nil {
+		if !slices.Equal(f.Specification.Composition, spec.Composition) {
+			conflicts = append(conflicts, FieldConflict{
+				Field:     "specification.composition",
+				Current:   compositionValue(f.Specification.Composition),
+				Requested: compositionValue(spec.Composition),
+			})
+		}
+		conflicts.compare("specification.width_cm", f.Specification.WidthCM, spec.WidthCM)
+		conflicts.compare("specification.weight_gsm", f.Specification.WeightGSM, spec.WeightGSM)
+		conflicts.compare("specification.color", f.Specification.Color, spec.Color)
+		conflicts.compare("specification.min_order_quantity", f.Specification.MinOrderQuantity, spec.MinOrderQuantity)
+		conflicts.compare("specification.lead_time_days", f.Specification.LeadTimeDays, spec.LeadTimeDays)
+	}
+	if texts.Description != nil {
+		conflicts.compare("description", f.Description, *texts.Description)
+	}
+	if texts.Notes != nil {
+		conflicts.compare("notes", f.Notes, *texts.Notes)
+	}
+	return conflicts
+}
+
+// priceConflicts lists the parts of a new price that disagree with the price of the fabric,
+// against no price at all when none is set. The moment a price is valid from defaults to
+// the time of the command, so it is not compared.
+func (f *Fabric) priceConflicts(price Price) []FieldConflict {
+	var listPrice, currency any
+	if f.Price != nil {
+		listPrice, currency = f.Price.Decimal(), f.Price.Currency
+	}
+	conflicts := fieldConflicts{}
+	conflicts.compare("list_price", listPrice, price.Decimal())
+	conflicts.compare("currency", currency, price.Currency)
+	return conflicts
+}
+
+// translationConflicts lists the parts of a translation that disagree with the one the
+// fabric holds in the locale, against none at all when it has no translation in it.
+func (f *Fabric) translationConflicts(locale string, translation Translation) []FieldConflict {
+	var name, description any
+	if parsed, err := ParseLocale(locale); err == nil {
+		locale = parsed
+	}
+	if current, ok := f.Translations[locale]; ok {
+		name, description = current.Name, current.Description
+	}
+	conflicts := fieldConflicts{}
+	conflicts.compare("name", name, translation.Name)
+	conflicts.compare("description", description, translation.Description)
+	return conflicts
+}
+
+// attributeConflicts lists the custom attributes whose requested value disagrees with the
+// current one, by key. An attribute missing on either side is compared as null.
+func (f *Fabric) attributeConflicts(values map[string]any) []FieldConflict {
+	keys := slices.Collect(maps.Keys(f.CustomAttributes))
+	for key := range values {
+		if _, ok := f.CustomAttributes[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	conflicts := fieldConflicts{}
+	for _, key := range keys {
+		conflicts.compare("custom_attributes."+key, f.CustomAttributes[key], values[key])
+	}
+	return conflicts
+}
+
+// compositionValue renders a composition the way requests give it
+func compositionValue(composition []CompositionPart) []map[string]any {
+	parts := make([]map[string]any, 0, len(composition))
+	for _, part := range composition {
+		parts = append(parts, map[string]any{"fibre": part.Fibre, "percent": part.Percent})
+	}
+	return parts
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func conflictParams(t *testing.T, err error) (int, []FieldConflict) {
+	t.Helper()
+
+	require.ErrorIs(t, err, ErrConcurrencyConflict)
+	domainErr, ok := AsDomainError(err)
+	require.True(t, ok)
+	conflicts, _ := domainErr.Params["conflicts"].([]FieldConflict)
+	return domainErr.Params["current_version"].(int), conflicts
+}
+
+func TestFabric_UpdateFabric_VersionConflict(t *testing.T) {
+	description := "Soft velvet"
+	spec := Specification{Composition: []CompositionPart{{Fibre: "cotton", Percent: 100}}, WidthCM: 140, Color: "red"}
+
+	testCases := []struct {
+		name              string
+		updateName        string
+		spec              *Specification
+		texts             FabricTexts
+		expectedConflicts []FieldConflict
+	}{
+		{
+			name:              "Same values",
+			updateName:        "Velvet Red",
+			expectedConflicts: []FieldConflict{},
+		},
+		{
+			name:       "Changed name and width",
+			updateName: "Velvet Crimson",
+			spec: &Specification{
+				Composition: []CompositionPart{{Fibre: "cotton", Percent: 100}}, WidthCM: 150, Color: "red",
+			},
+			expectedConflicts: []FieldConflict{
+				{Field: "name", Current: "Velvet Red", Requested: "Velvet Crimson"},
+				{Field: "specification.width_cm", Current: 140, Requested: 150},
+			},
+		},
+		{
+			name:       "Changed composition and description",
+			updateName: "Velvet Red",
+			spec: &Specification{
+				Composition: []CompositionPart{{Fibre: "cotton", Percent: 95}, {Fibre: "elastane", Percent: 5}},
+				WidthCM:     140, Color: "red",
+			},
+			texts: FabricTexts{Description: &description},
+			expectedConflicts: []FieldConflict{
+				{
+					Field:   "specification.composition",
+					Current: []map[string]any{{"fibre": "cotton", "percent": 100}},
+					Requested: []map[string]any{
+						{"fibre": "cotton", "percent": 95}, {"fibre": "elastane", "percent": 5},
+					},
+				},
+				{Field: "description", Current: "", Requested: "Soft velvet"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fabric, err := NewFabric("VELVET01", "Velvet", "m", "available", spec, FabricTexts{}, testStamp)
+			require.NoError(t, err)
+			require.NoError(t, fabric.UpdateFabric("Velvet Red", "m", "available", nil, FabricTexts{}, 1, testStamp))
+
+			// --- Act ---
+			err = fabric.UpdateFabric(tc.updateName, "m", "available", tc.spec, tc.texts, 1, testStamp)
+
+			// --- Assert ---
+			currentVersion, conflicts := conflictParams(t, err)
+			assert.Equal(t, 2, currentVersion)
+			assert.Equal(t, tc.expectedConflicts, conflicts)
+		})
+	}
+}
+
+func TestFabric_VersionConflict_OtherCommands(t *testing.T) {
+	// --- Arrange ---
+	fabric, err := NewFabric("VELVET01", "Velvet", "m", "available", Specification{}, FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	require.NoError(t, fabric.SetTranslation("de", Translation{Name: "Samt"}, 1, testStamp))
+	definitions := []*AttributeDefinition{{Key: "finish", Type: AttributeTypeString}, {Key: "pile", Type: AttributeTypeString}}
+	require.NoError(t, fabric.SetCustomAttributes(map[string]any{"finish": "matte"}, definitions, 2, testStamp))
+	price, err := ParsePrice("24.90", "PLN", testStamp.At)
+	require.NoError(t, err)
+	require.NoError(t, fabric.ChangePrice(price, 3, testStamp))
+	newPrice, err := ParsePrice("26.50", "PLN", testStamp.At)
+	require.NoError(t, err)
+
+	// --- Act ---
+	translationErr := fabric.SetTranslation("DE", Translation{Name: "Samt", Description: "weich"}, 1, testStamp)
+	attributesErr := fabric.SetCustomAttributes(map[string]any{"finish": "gloss", "pile": "short"}, definitions, 2, testStamp)
+	priceErr := fabric.ChangePrice(newPrice, 3, testStamp)
+	deleteErr := fabric.Delete(3, testStamp)
+
+	// --- Assert ---
+	version, conflicts := conflictParams(t, translationErr)
+	assert.Equal(t, 4, version)
+	assert.Equal(t, []FieldConflict{{Field: "description", Current: "", Requested: "weich"}}, conflicts)
+
+	_, conflicts = conflictParams(t, attributesErr)
+	assert.Equal(t, []FieldConflict{
+		{Field: "custom_attributes.finish", Current: "matte", Requested: "gloss"},
+		{Field: "custom_attributes.pile", Current: nil, Requested: "short"},
+	}, conflicts)
+
+	_, conflicts = conflictParams(t, priceErr)
+	assert.Equal(t, []FieldConflict{{Field: "list_price", Current: "24.90", Requested: "26.50"}}, conflicts)
+
+	version, conflicts = conflictParams(t, deleteErr)
+	assert.Equal(t, 4, version)
+	assert.Nil(t, conflicts, "a command setting no fields has no conflicting fields")
+}
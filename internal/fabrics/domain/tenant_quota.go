@@ -0,0 +1,62 @@
+package domain
+
+import "context"
+
+var (
+	ErrFabricQuotaExceeded = quotaError(
+		"fabric_quota_exceeded", "the tenant has reached the number of fabrics it may hold",
+	)
+	ErrAttachmentQuotaExceeded = quotaError(
+		"attachment_quota_exceeded", "the file would take the attachments of the tenant over their storage limit",
+	)
+	ErrAttachmentOverQuota = validationError(
+		"attachment_over_quota", "file", "the file is larger than the whole attachment storage of the tenant", nil,
+	)
+)
+
+// TenantQuota limits what the tenant served by the instance may hold. Tenants are kept
+// apart by deployment, a database each, so the quota of the tenant is configured on the
+// instance. A limit of zero leaves the resource unlimited.
+type TenantQuota struct {
+	Tenant             string
+	MaxFabrics         int
+	MaxAttachmentBytes int64
+}
+
+// TenantUsage is what the tenant holds of the resources its quota limits: the fabrics
+// neither deleted nor merged, and the bytes of every file stored as an attachment.
+type TenantUsage struct {
+	Fabrics         int
+	AttachmentBytes int64
+}
+
+type TenantUsageRepository interface {
+	GetTenantUsage(ctx context.Context) (TenantUsage, error)
+}
+
+// CheckFabric fails with ErrFabricQuotaExceeded when the tenant may not hold one more
+// fabric.
+func (q TenantQuota) CheckFabric(usage TenantUsage) error {
+	if q.MaxFabrics > 0 && usage.Fabrics >= q.MaxFabrics {
+		return ErrFabricQuotaExceeded.WithParam("max_fabrics", q.MaxFabrics).WithParam("fabrics", usage.Fabrics)
+	}
+	return nil
+}
+
+// CheckAttachment fails when a file of the size would not fit in the attachment storage
+// of the tenant: with ErrAttachmentOverQuota when it would not even fit in an empty one,
+// with ErrAttachmentQuotaExceeded when the files already attached leave too little room.
+func (q TenantQuota) CheckAttachment(usage TenantUsage, size int64) error {
+	if q.MaxAttachmentBytes == 0 {
+		return nil
+	}
+	if size > q.MaxAttachmentBytes {
+		return ErrAttachmentOverQuota.WithParam("max_bytes", q.MaxAttachmentBytes)
+	}
+	if usage.AttachmentBytes+size > q.MaxAttachmentBytes {
+		return ErrAttachmentQuotaExceeded.
+			WithParam("max_bytes", q.MaxAttachmentBytes).
+			WithParam("used_bytes", usage.AttachmentBytes)
+	}
+	return nil
+}
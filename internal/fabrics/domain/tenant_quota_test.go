@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantQuota_CheckFabric(t *testing.T) {
+	testCases := []struct {
+		name        string
+		quota       TenantQuota
+		fabrics     int
+		expectedErr error
+	}{
+		{name: "Unlimited", quota: TenantQuota{}, fabrics: 5000},
+		{name: "Below the limit", quota: TenantQuota{MaxFabrics: 100}, fabrics: 99},
+		{name: "At the limit", quota: TenantQuota{MaxFabrics: 100}, fabrics: 100, expectedErr: ErrFabricQuotaExceeded},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			err := tc.quota.CheckFabric(TenantUsage{Fabrics: tc.fabrics})
+
+			// --- Assert ---
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expectedErr)
+			domainErr, _ := AsDomainError(err)
+			assert.Equal(t, KindQuota, domainErr.Kind)
+			assert.Equal(t, tc.quota.MaxFabrics, domainErr.Params["max_fabrics"])
+		})
+	}
+}
+
+func TestTenantQuota_CheckAttachment(t *testing.T) {
+	testCases := []struct {
+		name        string
+		quota       TenantQuota
+		used        int64
+		size        int64
+		expectedErr error
+	}{
+		{name: "Unlimited", quota: TenantQuota{}, used: 1 << 40, size: 1024},
+		{name: "Fits", quota: TenantQuota{MaxAttachmentBytes: 4096}, used: 3072, size: 1024},
+		{name: "No room left", quota: TenantQuota{MaxAttachmentBytes: 4096}, used: 3072, size: 1025, expectedErr: ErrAttachmentQuotaExceeded},
+		{name: "Larger than the quota", quota: TenantQuota{MaxAttachmentBytes: 4096}, size: 4097, expectedErr: ErrAttachmentOverQuota},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			err := tc.quota.CheckAttachment(TenantUsage{AttachmentBytes: tc.used}, tc.size)
+
+			// --- Assert ---
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
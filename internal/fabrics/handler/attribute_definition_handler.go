@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// AttributeDefinitionHandler lists the registered custom attributes of fabrics and lets
+// administrators register, change and remove them, e.g. /admin/fabric-attributes/mill_batch.
+type AttributeDefinitionHandler struct {
+	definitions domain.AttributeDefinitionRepository
+	clock       clock.Clock
+}
+
+type saveAttributeDefinitionRequest struct {
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+func NewAttributeDefinitionHandler(
+	definitions domain.AttributeDefinitionRepository, clock clock.Clock,
+) *AttributeDefinitionHandler {
+	return &AttributeDefinitionHandler{
+		definitions: definitions,
+		clock:       clock,
+	}
+}
+
+func (h *AttributeDefinitionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listDefinitions(w, r)
+	case http.MethodPut:
+		h.saveDefinition(w, r)
+	case http.MethodDelete:
+		h.deleteDefinition(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *AttributeDefinitionHandler) listDefinitions(w http.ResponseWriter, r *http.Request) {
+	definitions, err := h.definitions.ListDefinitions(r.Context())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"attributes": definitions}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *AttributeDefinitionHandler) saveDefinition(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req saveAttributeDefinitionRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Description = validator.NormalizeText(req.Description)
+	v := validator.New()
+	v.Check(req.Type != "", "type", "type must be provided")
+	v.Check(len(req.Description) <= 500, "description", "description must not be more than 500 characters long")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	definition, err := domain.NewAttributeDefinition(
+		httpx.URLParam(r, "key"), domain.AttributeType(req.Type), req.Required, req.Description,
+		domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()},
+	)
+	if err == nil {
+		err = h.definitions.SaveDefinition(ctx, definition)
+	}
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"attribute": definition}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *AttributeDefinitionHandler) deleteDefinition(w http.ResponseWriter, r *http.Request) {
+	if err := h.definitions.DeleteDefinition(r.Context(), httpx.URLParam(r, "key")); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
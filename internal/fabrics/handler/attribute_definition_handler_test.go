@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAttributeDefinitionRepository keeps the definitions in memory, the keys in inUse
+// stand for attributes fabrics hold values of
+type mockAttributeDefinitionRepository struct {
+	definitions []*domain.AttributeDefinition
+	inUse       map[string]bool
+}
+
+func (m *mockAttributeDefinitionRepository) SaveDefinition(
+	ctx context.Context, definition *domain.AttributeDefinition,
+) error {
+	for i, existing := range m.definitions {
+		if existing.Key != definition.Key {
+			continue
+		}
+		if existing.Type != definition.Type && m.inUse[definition.Key] {
+			return domain.ErrAttributeDefinitionInUse
+		}
+		m.definitions[i] = definition
+		return nil
+	}
+	m.definitions = append(m.definitions, definition)
+	return nil
+}
+
+func (m *mockAttributeDefinitionRepository) GetDefinition(
+	ctx context.Context, key string,
+) (*domain.AttributeDefinition, error) {
+	for _, definition := range m.definitions {
+		if definition.Key == key {
+			return definition, nil
+		}
+	}
+	return nil, domain.ErrAttributeDefinitionNotFound
+}
+
+func (m *mockAttributeDefinitionRepository) ListDefinitions(ctx context.Context) ([]*domain.AttributeDefinition, error) {
+	return m.definitions, nil
+}
+
+func (m *mockAttributeDefinitionRepository) DeleteDefinition(ctx context.Context, key string) error {
+	if _, err := m.GetDefinition(ctx, key); err != nil {
+		return err
+	}
+	if m.inUse[key] {
+		return domain.ErrAttributeDefinitionInUse
+	}
+	m.definitions = slices.DeleteFunc(m.definitions, func(d *domain.AttributeDefinition) bool { return d.Key == key })
+	return nil
+}
+
+func serveAttributeDefinition(
+	t *testing.T, handler *AttributeDefinitionHandler, method, key, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "/v1/admin/fabric-attributes/"+key, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("key", key)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestAttributeDefinitionHandler_SaveDefinition(t *testing.T) {
+	testCases := []struct {
+		name           string
+		key            string
+		body           string
+		expectedStatus int
+		expectedType   domain.AttributeType
+	}{
+		{
+			name: "Register", key: "gots_certified", body: `{"type": "boolean", "required": true}`,
+			expectedStatus: http.StatusOK, expectedType: domain.AttributeTypeBoolean,
+		},
+		{
+			name: "Change the type of an unused attribute", key: "mill_batch", body: `{"type": "number"}`,
+			expectedStatus: http.StatusOK, expectedType: domain.AttributeTypeNumber,
+		},
+		{
+			name: "Change the type of an attribute in use", key: "mill_code", body: `{"type": "number"}`,
+			expectedStatus: http.StatusConflict, expectedType: domain.AttributeTypeString,
+		},
+		{name: "Invalid key", key: "Mill-Batch", body: `{"type": "string"}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Unknown type", key: "woven_on", body: `{"type": "date"}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "No type", key: "woven_on", body: `{}`, expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			definitions := &mockAttributeDefinitionRepository{
+				definitions: []*domain.AttributeDefinition{
+					{Key: "mill_batch", Type: domain.AttributeTypeString},
+					{Key: "mill_code", Type: domain.AttributeTypeString},
+				},
+				inUse: map[string]bool{"mill_code": true},
+			}
+			handler := NewAttributeDefinitionHandler(definitions, testClock)
+
+			// --- Act ---
+			responseRecorder := serveAttributeDefinition(t, handler, http.MethodPut, tc.key, tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			saved, err := definitions.GetDefinition(context.Background(), tc.key)
+			if tc.expectedType == "" {
+				assert.ErrorIs(t, err, domain.ErrAttributeDefinitionNotFound)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedType, saved.Type)
+		})
+	}
+}
+
+func TestAttributeDefinitionHandler_DeleteDefinition(t *testing.T) {
+	testCases := []struct {
+		name           string
+		key            string
+		expectedStatus int
+	}{
+		{name: "Unused attribute", key: "mill_batch", expectedStatus: http.StatusNoContent},
+		{name: "Attribute in use", key: "mill_code", expectedStatus: http.StatusConflict},
+		{name: "Unknown attribute", key: "woven_on", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			definitions := &mockAttributeDefinitionRepository{
+				definitions: []*domain.AttributeDefinition{
+					{Key: "mill_batch", Type: domain.AttributeTypeString},
+					{Key: "mill_code", Type: domain.AttributeTypeString},
+				},
+				inUse: map[string]bool{"mill_code": true},
+			}
+			handler := NewAttributeDefinitionHandler(definitions, testClock)
+
+			// --- Act ---
+			responseRecorder := serveAttributeDefinition(t, handler, http.MethodDelete, tc.key, "")
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
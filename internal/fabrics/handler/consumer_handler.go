@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+)
+
+// ConsumerStatuses reports the state of every subscription the service consumes messages from.
+type ConsumerStatuses interface {
+	Consumers() []messaging.ConsumerStatus
+}
+
+// ConsumerHandler lets an operator diagnose an ingestion stall without access to NATS.
+type ConsumerHandler struct {
+	consumers ConsumerStatuses
+}
+
+func NewConsumerHandler(consumers ConsumerStatuses) *ConsumerHandler {
+	return &ConsumerHandler{consumers: consumers}
+}
+
+func (h *ConsumerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"consumers": h.consumers.Consumers()}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
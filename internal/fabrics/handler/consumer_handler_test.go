@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockConsumerStatuses struct {
+	statuses []messaging.ConsumerStatus
+}
+
+func (m *mockConsumerStatuses) Consumers() []messaging.ConsumerStatus {
+	return m.statuses
+}
+
+func TestConsumerHandler(t *testing.T) {
+	t.Run("lists the subscriptions", func(t *testing.T) {
+		// --- Arrange ---
+		handler := NewConsumerHandler(&mockConsumerStatuses{statuses: []messaging.ConsumerStatus{
+			{Subject: "erp.*", QueueGroup: "erp-service-group", Listening: true, PendingMessages: 12, Failed: 3},
+		}})
+		responseRecorder := httptest.NewRecorder()
+
+		// --- Act ---
+		handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/v1/admin/consumers", nil))
+
+		// --- Assert ---
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		var body struct {
+			Consumers []messaging.ConsumerStatus `json:"consumers"`
+		}
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+		require.Len(t, body.Consumers, 1)
+		assert.Equal(t, "erp.*", body.Consumers[0].Subject)
+		assert.Equal(t, 12, body.Consumers[0].PendingMessages)
+		assert.Equal(t, int64(3), body.Consumers[0].Failed)
+	})
+
+	t.Run("only reads", func(t *testing.T) {
+		// --- Arrange ---
+		handler := NewConsumerHandler(&mockConsumerStatuses{})
+		responseRecorder := httptest.NewRecorder()
+
+		// --- Act ---
+		handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodPost, "/v1/admin/consumers", nil))
+
+		// --- Assert ---
+		assert.Equal(t, http.StatusMethodNotAllowed, responseRecorder.Code)
+	})
+}
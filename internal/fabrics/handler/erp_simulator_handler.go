@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// subject the ERP publishes fabric events on
+const erpFabricSubject = "erp.fabric"
+
+// ERPSimulatorHandler publishes fabric events the way the ERP does, so the event handling
+// can be exercised without an ERP. It is only mounted in development.
+type ERPSimulatorHandler struct {
+	publisher messaging.Publisher
+	clock     clock.Clock
+}
+
+type simulateERPEventRequest struct {
+	Type    string         `json:"type"`
+	Version int            `json:"version"`
+	Fabric  erpFabricEvent `json:"fabric"`
+}
+
+var erpEventTypes = map[string]string{
+	"created": erpFabricCreated,
+	"updated": erpFabricUpdated,
+	"deleted": erpFabricDeleted,
+}
+
+func NewERPSimulatorHandler(publisher messaging.Publisher, clock clock.Clock) *ERPSimulatorHandler {
+	return &ERPSimulatorHandler{publisher: publisher, clock: clock}
+}
+
+func (h *ERPSimulatorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	var req simulateERPEventRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	eventType, known := erpEventTypes[req.Type]
+
+	v := validator.New()
+	v.Check(known, "type", "type must be one of created, updated or deleted")
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	v.Check(req.Fabric.Code != "", "fabric_code", "fabric_code must be provided")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	envelope := messaging.NewEventEnvelope(
+		eventType,
+		req.Fabric.Code,
+		domain.AggregateType,
+		req.Version,
+		req.Fabric,
+		messaging.WithClock(h.clock),
+	)
+	if err := h.publisher.Publish(r.Context(), erpFabricSubject, envelope); err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusAccepted, httpx.Envelope{"event": envelope}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveSimulateERPEvent(t *testing.T, handler *ERPSimulatorHandler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/dev/erp/fabrics", strings.NewReader(body))
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestERPSimulatorHandler_PublishesERPEvent(t *testing.T) {
+	// --- Arrange ---
+	publisher := &mockPublisher{}
+	handler := NewERPSimulatorHandler(publisher, testClock)
+	body := `{"type": "updated", "version": 3, "fabric": {"fabric_code": "FAB01", "fabric_name": "Linen"}}`
+
+	// --- Act ---
+	responseRecorder := serveSimulateERPEvent(t, handler, body)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusAccepted, responseRecorder.Code)
+	require.Len(t, publisher.envelopes, 1)
+	assert.Equal(t, []string{"erp.fabric"}, publisher.subjects)
+
+	envelope := publisher.envelopes[0]
+	assert.Equal(t, erpFabricUpdated, envelope.EventType)
+	assert.Equal(t, "FAB01", envelope.AggregateID)
+	assert.Equal(t, 3, envelope.AggregateVersion)
+	assert.Equal(t, testClock.Now(), envelope.Timestamp)
+
+	event, err := decodeERPEvent(*envelope)
+	require.NoError(t, err)
+	assert.Equal(t, "Linen", event.Name)
+}
+
+func TestERPSimulatorHandler_ValidationErrors(t *testing.T) {
+	// --- Arrange ---
+	publisher := &mockPublisher{}
+	handler := NewERPSimulatorHandler(publisher, testClock)
+
+	// --- Act ---
+	responseRecorder := serveSimulateERPEvent(t, handler, `{"type": "renamed", "fabric": {}}`)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+	assert.Contains(t, responseRecorder.Body.String(), "type")
+	assert.Contains(t, responseRecorder.Body.String(), "version")
+	assert.Contains(t, responseRecorder.Body.String(), "fabric_code")
+	assert.Empty(t, publisher.envelopes)
+}
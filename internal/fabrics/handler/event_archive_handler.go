@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/rebuild"
+)
+
+// EventArchive exports the whole event store and imports it into an empty one.
+type EventArchive interface {
+	ExportEvents(ctx context.Context, w io.Writer, exportedAt time.Time) (int64, error)
+	ImportEvents(ctx context.Context, r io.Reader) (int64, error)
+}
+
+// EventArchiveHandler lets an operator take a logical backup of the event store and load
+// it into a fresh environment, for cloning environments and disaster recovery drills. An
+// import is tracked as a rebuild of the event store, degrading the endpoints reading it.
+type EventArchiveHandler struct {
+	archive  EventArchive
+	rebuilds *rebuild.Tracker
+	clock    clock.Clock
+}
+
+func NewEventArchiveHandler(archive EventArchive, rebuilds *rebuild.Tracker, clock clock.Clock) *EventArchiveHandler {
+	return &EventArchiveHandler{archive: archive, rebuilds: rebuilds, clock: clock}
+}
+
+func (h *EventArchiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.exportEvents(w, r)
+	case http.MethodPost:
+		h.importEvents(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+// exportEvents streams the archive. Once streaming has started the status code can no
+// longer change, so the outcome is reported in a trailer; the archive's own footer tells
+// a complete file from a truncated one after the fact.
+func (h *EventArchiveHandler) exportEvents(w http.ResponseWriter, r *http.Request) {
+	logger := httpx.GetLogger(r.Context())
+	rc := http.NewResponseController(w)
+
+	// the export may outlive the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Warn("failed to clear write deadline for event export", "error", err)
+	}
+
+	w.Header().Set("Content-Type", contentTypeNDJSON)
+	w.Header().Set("Content-Disposition", `attachment; filename="events.ndjson"`)
+	w.Header().Set("Trailer", exportStatusTrailer)
+	w.WriteHeader(http.StatusOK)
+
+	written, err := h.archive.ExportEvents(r.Context(), w, h.clock.Now())
+	if err != nil {
+		logger.Error("event export failed", "written", written, "error", err)
+		w.Header().Set(exportStatusTrailer, "failed")
+		return
+	}
+	logger.Info("event store exported", "events", written)
+	w.Header().Set(exportStatusTrailer, "complete")
+}
+
+func (h *EventArchiveHandler) importEvents(w http.ResponseWriter, r *http.Request) {
+	logger := httpx.GetLogger(r.Context())
+	rc := http.NewResponseController(w)
+
+	// an archive of the whole store takes longer to upload than the server's read timeout
+	if err := rc.SetReadDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Warn("failed to clear read deadline for event import", "error", err)
+	}
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Warn("failed to clear write deadline for event import", "error", err)
+	}
+
+	finish := h.rebuilds.Start("event-import", []string{rebuild.EventStore}, command.Actor(r.Context()), h.clock.Now())
+	imported, err := h.archive.ImportEvents(r.Context(), r.Body)
+	finish()
+	switch {
+	case err == nil:
+	case errors.Is(err, eventstore.ErrStoreNotEmpty):
+		httpx.ErrorJSON(w, http.StatusConflict, "events can only be imported into an empty event store")
+		return
+	case errors.Is(err, eventstore.ErrInvalidArchive):
+		httpx.ErrorJSON(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	default:
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	logger.Info("event store imported", "events", imported)
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"imported": imported}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
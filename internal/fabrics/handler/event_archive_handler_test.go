@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/rebuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEventArchive struct {
+	exportedAt  time.Time
+	imported    string
+	errToReturn error
+	// rebuilds, when set, is looked at during an import
+	rebuilds   *rebuild.Tracker
+	rebuilding []string
+}
+
+func (m *mockEventArchive) ExportEvents(ctx context.Context, w io.Writer, exportedAt time.Time) (int64, error) {
+	m.exportedAt = exportedAt
+	if m.errToReturn != nil {
+		return 0, m.errToReturn
+	}
+	_, err := io.WriteString(w, "{\"header\":{}}\n{\"footer\":{\"events\":0}}\n")
+	return 0, err
+}
+
+func (m *mockEventArchive) ImportEvents(ctx context.Context, r io.Reader) (int64, error) {
+	raw, _ := io.ReadAll(r)
+	m.imported = string(raw)
+	if m.rebuilds != nil {
+		m.rebuilding = m.rebuilds.Rebuilding(rebuild.EventStore)
+	}
+	if m.errToReturn != nil {
+		return 0, m.errToReturn
+	}
+	return 2, nil
+}
+
+func TestEventArchiveHandler_ExportEvents(t *testing.T) {
+	// --- Arrange ---
+	archive := &mockEventArchive{}
+	handler := NewEventArchiveHandler(archive, rebuild.New(), testClock)
+	req, err := http.NewRequest(http.MethodGet, "/v1/admin/events/export.ndjson", nil)
+	require.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, contentTypeNDJSON, responseRecorder.Header().Get("Content-Type"))
+	assert.Equal(t, "complete", responseRecorder.Header().Get(exportStatusTrailer))
+	assert.Equal(t, testClock.Now(), archive.exportedAt)
+	assert.Contains(t, responseRecorder.Body.String(), `"footer"`)
+}
+
+func TestEventArchiveHandler_ImportEvents(t *testing.T) {
+	tests := []struct {
+		name           string
+		errToReturn    error
+		expectedStatus int
+	}{
+		{name: "imported", expectedStatus: http.StatusOK},
+		{name: "store not empty", errToReturn: eventstore.ErrStoreNotEmpty, expectedStatus: http.StatusConflict},
+		{
+			name:           "invalid archive",
+			errToReturn:    fmt.Errorf("%w: the archive ends without a footer", eventstore.ErrInvalidArchive),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			rebuilds := rebuild.New()
+			archive := &mockEventArchive{errToReturn: tt.errToReturn, rebuilds: rebuilds}
+			handler := NewEventArchiveHandler(archive, rebuilds, testClock)
+			body := "{\"header\":{}}\n"
+			req, err := http.NewRequest(http.MethodPost, "/v1/admin/events/import", strings.NewReader(body))
+			require.NoError(t, err)
+
+			// --- Act ---
+			responseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, body, archive.imported, "the request body must be handed to the store as is")
+			assert.Equal(t, []string{"event-import"}, archive.rebuilding, "the import rebuilds the event store")
+			assert.Empty(t, rebuilds.Running(), "the rebuild ends with the import")
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+)
+
+// EventOutbox queues stored events for publishing again.
+type EventOutbox interface {
+	Redispatch(ctx context.Context, eventID string) error
+}
+
+// EventOutboxHandler lets an operator re-dispatch an event the outbox relay gave up on, or
+// one a consumer lost after it was published.
+type EventOutboxHandler struct {
+	outbox EventOutbox
+}
+
+func NewEventOutboxHandler(outbox EventOutbox) *EventOutboxHandler {
+	return &EventOutboxHandler{outbox: outbox}
+}
+
+func (h *EventOutboxHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	eventID, err := uuid.Parse(httpx.URLParam(r, "eventID"))
+	if err != nil {
+		httpx.ValidationError(w, r, map[string]string{"event_id": "must be a valid event ID"})
+		return
+	}
+
+	if err := h.outbox.Redispatch(r.Context(), eventID.String()); err != nil {
+		if errors.Is(err, eventstore.ErrEventNotFound) {
+			httpx.NotFound(w, r)
+			return
+		}
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusAccepted, httpx.Envelope{"event_id": eventID.String()}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
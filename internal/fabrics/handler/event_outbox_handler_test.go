@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEventOutbox struct {
+	eventID     string
+	errToReturn error
+}
+
+func (m *mockEventOutbox) Redispatch(ctx context.Context, eventID string) error {
+	m.eventID = eventID
+	return m.errToReturn
+}
+
+func serveRedispatch(t *testing.T, handler *EventOutboxHandler, eventID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/admin/outbox/"+eventID+"/redispatch", nil)
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("eventID", eventID)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestEventOutboxHandler_Redispatch(t *testing.T) {
+	const eventID = "0190a8c4-5b1e-7c3a-9f2d-1e4b6c8d0a2f"
+
+	tests := []struct {
+		name           string
+		eventID        string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   string
+	}{
+		{name: "queued again", eventID: eventID, expectedStatus: http.StatusAccepted, expectedCall: eventID},
+		{name: "invalid id", eventID: "not-a-uuid", expectedStatus: http.StatusUnprocessableEntity},
+		{
+			name: "unknown event", eventID: eventID, errToReturn: eventstore.ErrEventNotFound,
+			expectedStatus: http.StatusNotFound, expectedCall: eventID,
+		},
+		{
+			name: "outbox failure", eventID: eventID, errToReturn: errors.New("database is down"),
+			expectedStatus: http.StatusInternalServerError, expectedCall: eventID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			outbox := &mockEventOutbox{errToReturn: tt.errToReturn}
+			handler := NewEventOutboxHandler(outbox)
+
+			// --- Act ---
+			responseRecorder := serveRedispatch(t, handler, tt.eventID)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tt.expectedCall, outbox.eventID)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	// type prefix of the events a replay re-delivers
+	replayEventPrefix = "app.fabric."
+
+	// number of stored events read and published per batch of a replay
+	replayBatchSize = 500
+)
+
+// replaySubjectRX matches a NATS subject without wildcards, so a replay reaches one subject
+var replaySubjectRX = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// EventRange reads the stored events recorded within a time range.
+type EventRange interface {
+	ReadBetween(
+		ctx context.Context, typePrefix string, from, to time.Time, after int64, limit int,
+	) ([]eventstore.RecordedEvent, error)
+}
+
+// EventReplayHandler re-delivers the fabric events recorded within a time range to a single
+// subject, so a consumer that lost them can recover without the events reaching every other
+// consumer again. The outbox is left alone and, as in the outbox relay, only the event types
+// on the allowlist are published.
+type EventReplayHandler struct {
+	events    EventRange
+	publisher messaging.Publisher
+	allowlist *messaging.EventAllowlist
+	clock     clock.Clock
+}
+
+type replayEventsRequest struct {
+	Subject string    `json:"subject"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+}
+
+func NewEventReplayHandler(
+	events EventRange, publisher messaging.Publisher, allowlist *messaging.EventAllowlist, clock clock.Clock,
+) *EventReplayHandler {
+	return &EventReplayHandler{
+		events:    events,
+		publisher: publisher,
+		allowlist: allowlist,
+		clock:     clock,
+	}
+}
+
+func (h *EventReplayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	var req replayEventsRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Subject != "", "subject", "subject must be provided")
+	v.Check(req.Subject == "" || validator.Matches(req.Subject, replaySubjectRX),
+		"subject", "subject must be a NATS subject without wildcards")
+	v.Check(len(req.Subject) <= 255, "subject", "subject must not be more than 255 characters long")
+	v.Check(!req.From.IsZero(), "from", "from must be provided")
+	v.Check(!req.To.IsZero(), "to", "to must be provided")
+	v.Check(req.To.IsZero() || req.From.Before(req.To), "to", "to must be after from")
+	v.Check(!req.From.After(h.clock.Now()), "from", "from must not be in the future")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	replayed, skipped, err := h.replay(r.Context(), req)
+	if err != nil {
+		httpx.InternalError(w, r, fmt.Errorf("replay stopped after %d events: %w", replayed, err))
+		return
+	}
+
+	env := httpx.Envelope{"subject": req.Subject, "replayed": replayed, "skipped": skipped}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// replay publishes the events of the range in position order, reporting how many were
+// published and how many were left out as internal to the service.
+func (h *EventReplayHandler) replay(ctx context.Context, req replayEventsRequest) (int, int, error) {
+	replayed, skipped := 0, 0
+	var after int64
+	for {
+		events, err := h.events.ReadBetween(ctx, replayEventPrefix, req.From, req.To, after, replayBatchSize)
+		if err != nil {
+			return replayed, skipped, err
+		}
+
+		for _, event := range events {
+			if !h.allowlist.Allows(event.Envelope.EventType) {
+				skipped++
+				continue
+			}
+			if err := h.publisher.Publish(ctx, req.Subject, event.Envelope); err != nil {
+				return replayed, skipped, fmt.Errorf("could not publish event %s: %w", event.Envelope.EventID, err)
+			}
+			replayed++
+		}
+
+		if len(events) < replayBatchSize {
+			return replayed, skipped, nil
+		}
+		after = events[len(events)-1].Position
+	}
+}
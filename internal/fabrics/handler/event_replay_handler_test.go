@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEventRange struct {
+	events []eventstore.RecordedEvent
+	reads  int
+}
+
+func (m *mockEventRange) ReadBetween(
+	ctx context.Context, typePrefix string, from, to time.Time, after int64, limit int,
+) ([]eventstore.RecordedEvent, error) {
+	m.reads++
+	events := []eventstore.RecordedEvent{}
+	for _, event := range m.events {
+		recordedAt := event.Envelope.Timestamp
+		if event.Position > after && strings.HasPrefix(event.Envelope.EventType, typePrefix) &&
+			!recordedAt.Before(from) && recordedAt.Before(to) && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func recordedFabricEvents(eventTypes ...string) []eventstore.RecordedEvent {
+	events := make([]eventstore.RecordedEvent, 0, len(eventTypes))
+	for i, eventType := range eventTypes {
+		events = append(events, eventstore.RecordedEvent{
+			Position: int64(i + 1),
+			Envelope: &messaging.EventEnvelope{
+				EventID:   fmt.Sprintf("event-%d", i+1),
+				EventType: eventType,
+				Timestamp: time.Date(2025, 1, 1, 12, i, 0, 0, time.UTC),
+			},
+		})
+	}
+	return events
+}
+
+func TestEventReplayHandler_Replay(t *testing.T) {
+	allowlist, err := messaging.NewEventAllowlist("app.fabric.created", "app.fabric.updated")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		body             string
+		events           []eventstore.RecordedEvent
+		expectedStatus   int
+		expectedReplayed []string
+	}{
+		{
+			name: "allowlisted events of the range",
+			body: `{"subject": "consumer.pricing.fabric", "from": "2025-01-01T12:01:00Z", "to": "2025-01-01T12:04:00Z"}`,
+			events: recordedFabricEvents(
+				"app.fabric.created", "app.fabric.updated", "app.fabric.stock_reserved",
+				"app.fabric.updated", "app.fabric.updated",
+			),
+			expectedStatus:   http.StatusOK,
+			expectedReplayed: []string{"event-2", "event-4"},
+		},
+		{
+			name:           "wildcard subject",
+			body:           `{"subject": "consumer.>", "from": "2025-01-01T00:00:00Z", "to": "2025-01-02T00:00:00Z"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "range ending before it starts",
+			body:           `{"subject": "consumer.pricing", "from": "2025-01-02T00:00:00Z", "to": "2025-01-01T00:00:00Z"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "range starting in the future",
+			body:           `{"subject": "consumer.pricing", "from": "2025-02-01T00:00:00Z", "to": "2025-02-02T00:00:00Z"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "missing subject",
+			body:           `{"from": "2025-01-01T00:00:00Z", "to": "2025-01-02T00:00:00Z"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			events := &mockEventRange{events: tt.events}
+			publisher := &mockPublisher{}
+			handler := NewEventReplayHandler(events, publisher, allowlist, testClock)
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/admin/events/replay", strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			// --- Act ---
+			responseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, responseRecorder.Code)
+			var replayed []string
+			for i, envelope := range publisher.envelopes {
+				assert.Equal(t, "consumer.pricing.fabric", publisher.subjects[i])
+				replayed = append(replayed, envelope.EventID)
+			}
+			assert.Equal(t, tt.expectedReplayed, replayed)
+		})
+	}
+}
+
+func TestEventReplayHandler_Replay_ReadsInBatches(t *testing.T) {
+	// --- Arrange ---
+	allowlist, err := messaging.NewEventAllowlist("app.fabric.*")
+	require.NoError(t, err)
+
+	eventTypes := make([]string, replayBatchSize+1)
+	for i := range eventTypes {
+		eventTypes[i] = "app.fabric.updated"
+	}
+	events := &mockEventRange{events: recordedFabricEvents(eventTypes...)}
+	publisher := &mockPublisher{}
+	handler := NewEventReplayHandler(events, publisher, allowlist, testClock)
+
+	body := `{"subject": "consumer.pricing", "from": "2025-01-01T00:00:00Z", "to": "2025-01-02T00:00:00Z"}`
+	req, err := http.NewRequest(http.MethodPost, "/v1/admin/events/replay", strings.NewReader(body))
+	require.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Len(t, publisher.envelopes, replayBatchSize+1)
+	assert.Equal(t, 2, events.reads, "a full batch is followed by another read")
+	assert.JSONEq(t, `{"subject": "consumer.pricing", "replayed": 501, "skipped": 0}`, responseRecorder.Body.String())
+}
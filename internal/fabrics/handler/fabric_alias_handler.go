@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricAliasHandler manages the alternate codes that resolve to a fabric.
+type FabricAliasHandler struct {
+	aliases domain.FabricAliasRepository
+	clock   clock.Clock
+}
+
+type addFabricAliasRequest struct {
+	Alias string `json:"alias"`
+}
+
+func NewFabricAliasHandler(aliases domain.FabricAliasRepository, clock clock.Clock) *FabricAliasHandler {
+	return &FabricAliasHandler{
+		aliases: aliases,
+		clock:   clock,
+	}
+}
+
+func (h *FabricAliasHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listAliases(w, r)
+	case http.MethodPost:
+		h.addAlias(w, r)
+	case http.MethodDelete:
+		h.removeAlias(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricAliasHandler) listAliases(w http.ResponseWriter, r *http.Request) {
+	aliases, err := h.aliases.ListAliases(r.Context(), httpx.URLParam(r, "code"))
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"aliases": aliases}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricAliasHandler) addAlias(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	code := httpx.URLParam(r, "code")
+
+	var req addFabricAliasRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Alias = validator.NormalizeCode(req.Alias)
+	v := validator.New()
+	v.Check(req.Alias != "", "alias", "alias must be provided")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	alias, err := domain.NewFabricAlias(req.Alias, code, domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()})
+	if err == nil {
+		err = h.aliases.AddAlias(ctx, alias)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrDuplicateFabricCode) {
+			httpx.ErrorJSON(w, http.StatusConflict, "the alias is already used as a fabric code or another alias")
+			return
+		}
+		writeDomainError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"alias": alias}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricAliasHandler) removeAlias(w http.ResponseWriter, r *http.Request) {
+	err := h.aliases.RemoveAlias(r.Context(), httpx.URLParam(r, "code"), httpx.URLParam(r, "alias"))
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricAliasRepository struct {
+	added       []*domain.FabricAlias
+	removed     []string
+	errToReturn error
+}
+
+func (m *mockFabricAliasRepository) AddAlias(ctx context.Context, alias *domain.FabricAlias) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.added = append(m.added, alias)
+	return nil
+}
+
+func (m *mockFabricAliasRepository) RemoveAlias(ctx context.Context, canonicalCode, aliasCode string) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.removed = append(m.removed, canonicalCode+"/"+aliasCode)
+	return nil
+}
+
+func (m *mockFabricAliasRepository) ListAliases(ctx context.Context, canonicalCode string) ([]*domain.FabricAlias, error) {
+	return m.added, m.errToReturn
+}
+
+func serveFabricAlias(t *testing.T, handler *FabricAliasHandler, method, code, alias, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "/v1/fabrics/"+code+"/aliases", strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", code)
+	if alias != "" {
+		rctx.URLParams.Add("alias", alias)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricAliasHandler_AddAlias_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	aliases := &mockFabricAliasRepository{}
+	handler := NewFabricAliasHandler(aliases, testClock)
+
+	// --- Act ---
+	responseRecorder := serveFabricAlias(t, handler, http.MethodPost, "CANON01", "", `{"alias": "LEGACY01"}`)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	require.Len(t, aliases.added, 1)
+	assert.Equal(t, "LEGACY01", aliases.added[0].AliasCode)
+	assert.Equal(t, "CANON01", aliases.added[0].CanonicalCode)
+	assert.Equal(t, "anonymous", aliases.added[0].CreatedBy)
+	assert.Equal(t, testClock.Now(), aliases.added[0].CreatedAt)
+}
+
+func TestFabricAliasHandler_AddAlias_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		repoErr        error
+		expectedStatus int
+	}{
+		{name: "Missing alias", body: `{}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Invalid alias", body: `{"alias": "legacy-01"}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Alias of itself", body: `{"alias": "CANON01"}`, expectedStatus: http.StatusConflict},
+		{name: "Alias taken", body: `{"alias": "LEGACY01"}`, repoErr: domain.ErrDuplicateFabricCode, expectedStatus: http.StatusConflict},
+		{name: "Unknown fabric", body: `{"alias": "LEGACY01"}`, repoErr: domain.ErrRecordNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			aliases := &mockFabricAliasRepository{errToReturn: tc.repoErr}
+			handler := NewFabricAliasHandler(aliases, testClock)
+
+			// --- Act ---
+			responseRecorder := serveFabricAlias(t, handler, http.MethodPost, "CANON01", "", tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Empty(t, aliases.added)
+		})
+	}
+}
+
+func TestFabricAliasHandler_RemoveAlias(t *testing.T) {
+	// --- Arrange ---
+	aliases := &mockFabricAliasRepository{}
+	handler := NewFabricAliasHandler(aliases, testClock)
+
+	// --- Act ---
+	responseRecorder := serveFabricAlias(t, handler, http.MethodDelete, "CANON01", "LEGACY01", "")
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusNoContent, responseRecorder.Code)
+	assert.Equal(t, []string{"CANON01/LEGACY01"}, aliases.removed)
+}
+
+func TestFabricAliasHandler_RemoveAlias_NotFound(t *testing.T) {
+	// --- Arrange ---
+	handler := NewFabricAliasHandler(&mockFabricAliasRepository{errToReturn: domain.ErrRecordNotFound}, testClock)
+
+	// --- Act ---
+	responseRecorder := serveFabricAlias(t, handler, http.MethodDelete, "CANON01", "LEGACY01", "")
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	// room for the form fields and multipart framing around the file
+	maxAttachmentRequestSize = domain.MaxAttachmentSize + 1<<20
+	// part of an upload kept in memory, the rest is buffered in a temporary file
+	attachmentMemoryLimit = 1 << 20
+)
+
+// FabricAttachmentService attaches images and spec sheets to fabrics.
+type FabricAttachmentService interface {
+	ListAttachments(ctx context.Context, code string) ([]*domain.FabricAttachment, error)
+	AddAttachment(ctx context.Context, code, kind, fileName string, content io.Reader) (*domain.FabricAttachment, error)
+	OpenAttachment(ctx context.Context, code, id string) (*domain.FabricAttachment, io.ReadCloser, error)
+	RemoveAttachment(ctx context.Context, code, id string) error
+}
+
+// FabricAttachmentHandler uploads, lists, serves and removes the attachments of a fabric.
+// Uploads are multipart forms with a kind field and a file part.
+type FabricAttachmentHandler struct {
+	service FabricAttachmentService
+}
+
+func NewFabricAttachmentHandler(service FabricAttachmentService) *FabricAttachmentHandler {
+	return &FabricAttachmentHandler{service: service}
+}
+
+func (h *FabricAttachmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if httpx.URLParam(r, "id") == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.listAttachments(w, r)
+		case http.MethodPost:
+			h.addAttachment(w, r)
+		default:
+			httpx.MethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.serveAttachment(w, r)
+	case http.MethodDelete:
+		h.removeAttachment(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricAttachmentHandler) listAttachments(w http.ResponseWriter, r *http.Request) {
+	attachments, err := h.service.ListAttachments(r.Context(), httpx.URLParam(r, "code"))
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+	withAttachmentURLs(attachments)
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"attachments": attachments}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricAttachmentHandler) addAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentRequestSize)
+	if err := r.ParseMultipartForm(attachmentMemoryLimit); err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			httpx.ErrorJSON(w, http.StatusRequestEntityTooLarge, domain.ErrAttachmentTooLarge.Message)
+			return
+		}
+		httpx.BadRequest(w, r, fmt.Errorf("body must be a multipart form: %w", err))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	kind := validator.NormalizeText(r.FormValue("kind"))
+	file, header, err := r.FormFile("file")
+	v := validator.New()
+	v.Check(kind != "", "kind", "kind must be provided")
+	v.Check(err == nil, "file", "file must be provided")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.service.AddAttachment(ctx, httpx.URLParam(r, "code"), kind, header.Filename, file)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+	withAttachmentURLs([]*domain.FabricAttachment{attachment})
+
+	headers := make(http.Header)
+	headers.Set("Location", attachment.URL)
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"attachment": attachment}, headers); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// serveAttachment streams the file of the attachment with the type detected on upload.
+func (h *FabricAttachmentHandler) serveAttachment(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.ReadIDParam(r)
+	if err != nil {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	attachment, file, err := h.service.OpenAttachment(r.Context(), httpx.URLParam(r, "code"), id.String())
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, file); err != nil {
+		httpx.GetLogger(r.Context()).Warn("failed to serve attachment", "id", attachment.ID, "error", err)
+	}
+}
+
+func (h *FabricAttachmentHandler) removeAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	id, err := httpx.ReadIDParam(r)
+	if err != nil {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	if err := h.service.RemoveAttachment(ctx, httpx.URLParam(r, "code"), id.String()); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// withAttachmentURLs sets the URL each attachment's file is served at.
+func withAttachmentURLs(attachments []*domain.FabricAttachment) {
+	for _, attachment := range attachments {
+		attachment.URL = "/v1/fabrics/" + url.PathEscape(attachment.FabricCode) + "/attachments/" + attachment.ID
+	}
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAttachmentID = "0b9a3c1e-5d2f-4b7a-9e61-2f8d4c3b1a70"
+
+type mockFabricAttachmentService struct {
+	attachments []*domain.FabricAttachment
+	kind        string
+	fileName    string
+	content     string
+	removedID   string
+	errToReturn error
+}
+
+func (m *mockFabricAttachmentService) ListAttachments(ctx context.Context, code string) ([]*domain.FabricAttachment, error) {
+	return m.attachments, m.errToReturn
+}
+
+func (m *mockFabricAttachmentService) AddAttachment(
+	ctx context.Context, code, kind, fileName string, content io.Reader,
+) (*domain.FabricAttachment, error) {
+	raw, _ := io.ReadAll(content)
+	m.kind, m.fileName, m.content = kind, fileName, string(raw)
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.FabricAttachment{ID: testAttachmentID, FabricCode: code, Kind: kind, FileName: fileName}, nil
+}
+
+func (m *mockFabricAttachmentService) OpenAttachment(
+	ctx context.Context, code, id string,
+) (*domain.FabricAttachment, io.ReadCloser, error) {
+	if m.errToReturn != nil {
+		return nil, nil, m.errToReturn
+	}
+	attachment := &domain.FabricAttachment{
+		ID: id, FabricCode: code, FileName: "spec sheet.pdf", ContentType: "application/pdf", Size: 8,
+	}
+	return attachment, io.NopCloser(strings.NewReader("%PDF-1.7")), nil
+}
+
+func (m *mockFabricAttachmentService) RemoveAttachment(ctx context.Context, code, id string) error {
+	m.removedID = id
+	return m.errToReturn
+}
+
+func serveAttachment(t *testing.T, handler *FabricAttachmentHandler, req *http.Request, id string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", "FAB01")
+	rctx.URLParams.Add("id", id)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func newUploadRequest(t *testing.T, kind, fileName, content string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if kind != "" {
+		require.NoError(t, form.WriteField("kind", kind))
+	}
+	if fileName != "" {
+		part, err := form.CreateFormFile("file", fileName)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, form.Close())
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/FAB01/attachments", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+func TestFabricAttachmentHandler_AddAttachment(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricAttachmentService{}
+	handler := NewFabricAttachmentHandler(svc)
+	req := newUploadRequest(t, "spec_sheet", "spec.pdf", "%PDF-1.7")
+
+	// --- Act ---
+	responseRecorder := serveAttachment(t, handler, req, "")
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, "/v1/fabrics/FAB01/attachments/"+testAttachmentID, responseRecorder.Header().Get("Location"))
+	assert.Equal(t, "spec_sheet", svc.kind)
+	assert.Equal(t, "spec.pdf", svc.fileName)
+	assert.Equal(t, "%PDF-1.7", svc.content)
+}
+
+func TestFabricAttachmentHandler_AddAttachment_Rejected(t *testing.T) {
+	testCases := []struct {
+		name           string
+		req            func(t *testing.T) *http.Request
+		errToReturn    error
+		expectedStatus int
+	}{
+		{
+			name:           "missing file",
+			req:            func(t *testing.T) *http.Request { return newUploadRequest(t, "image", "", "") },
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "not a multipart form",
+			req: func(t *testing.T) *http.Request {
+				req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/FAB01/attachments", strings.NewReader(`{}`))
+				require.NoError(t, err)
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unsupported type",
+			req:            func(t *testing.T) *http.Request { return newUploadRequest(t, "image", "notes.txt", "text") },
+			errToReturn:    domain.ErrUnsupportedAttachmentType,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "unknown fabric",
+			req:            func(t *testing.T) *http.Request { return newUploadRequest(t, "image", "swatch.png", "png") },
+			errToReturn:    domain.ErrRecordNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewFabricAttachmentHandler(&mockFabricAttachmentService{errToReturn: tc.errToReturn})
+
+			// --- Act ---
+			responseRecorder := serveAttachment(t, handler, tc.req(t), "")
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
+
+func TestFabricAttachmentHandler_ServeAttachment(t *testing.T) {
+	// --- Arrange ---
+	handler := NewFabricAttachmentHandler(&mockFabricAttachmentService{})
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/FAB01/attachments/"+testAttachmentID, nil)
+	require.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := serveAttachment(t, handler, req, testAttachmentID)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "application/pdf", responseRecorder.Header().Get("Content-Type"))
+	assert.Equal(t, `inline; filename="spec sheet.pdf"`, responseRecorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, "%PDF-1.7", responseRecorder.Body.String())
+}
+
+func TestFabricAttachmentHandler_RemoveAttachment(t *testing.T) {
+	testCases := []struct {
+		name           string
+		id             string
+		errToReturn    error
+		expectedStatus int
+	}{
+		{name: "removed", id: testAttachmentID, expectedStatus: http.StatusNoContent},
+		{name: "invalid id", id: "not-a-uuid", expectedStatus: http.StatusNotFound},
+		{name: "unknown attachment", id: testAttachmentID, errToReturn: domain.ErrRecordNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewFabricAttachmentHandler(&mockFabricAttachmentService{errToReturn: tc.errToReturn})
+			req, err := http.NewRequest(http.MethodDelete, "/v1/fabrics/FAB01/attachments/"+tc.id, nil)
+			require.NoError(t, err)
+
+			// --- Act ---
+			responseRecorder := serveAttachment(t, handler, req, tc.id)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
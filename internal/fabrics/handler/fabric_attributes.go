@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	defaultMeasureUnit = domain.MeasureUnitRunningMetre
+	defaultOfferStatus = domain.OfferStatusActive
+)
+
+// normalizeFabricAttributes fills in missing attributes and replaces an unknown offer
+// status with the default, reporting every correction as a warning. An unknown measure
+// unit cannot be guessed, it is left for the domain to reject.
+func normalizeFabricAttributes(v *validator.Validator, measureUnit, offerStatus *string) {
+	if *measureUnit == "" {
+		*measureUnit = string(defaultMeasureUnit)
+		v.AddWarning("measure_unit", fmt.Sprintf("measure_unit not provided, defaulted to %s", defaultMeasureUnit))
+	}
+
+	switch _, err := domain.ParseOfferStatus(*offerStatus); {
+	case *offerStatus == "":
+		*offerStatus = string(defaultOfferStatus)
+		v.AddWarning("offer_status", fmt.Sprintf("offer_status not provided, defaulted to %s", defaultOfferStatus))
+	case err != nil:
+		v.AddWarning("offer_status", fmt.Sprintf("offer_status %q unknown, defaulted to %s", *offerStatus, defaultOfferStatus))
+		*offerStatus = string(defaultOfferStatus)
+	}
+}
+
+// writeAccepted answers a successful command, listing the warnings raised while validating it
+func writeAccepted(w http.ResponseWriter, r *http.Request, status int, v *validator.Validator) {
+	if !v.HasWarnings() {
+		w.WriteHeader(status)
+		return
+	}
+	if err := httpx.WriteJSON(w, status, httpx.Envelope{"warnings": v.Warnings}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
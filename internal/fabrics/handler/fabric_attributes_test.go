@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeFabricAttributes(t *testing.T) {
+	testCases := []struct {
+		name                string
+		measureUnit         string
+		offerStatus         string
+		expectedMeasureUnit string
+		expectedOfferStatus string
+		expectedWarnings    []string
+	}{
+		{name: "Known values", measureUnit: "mb", offerStatus: "available", expectedMeasureUnit: "mb", expectedOfferStatus: "available"},
+		{name: "Missing values", expectedMeasureUnit: "MB", expectedOfferStatus: "ACTIVE", expectedWarnings: []string{"measure_unit", "offer_status"}},
+		{name: "Unknown offer status", measureUnit: "m", offerStatus: "someday", expectedMeasureUnit: "m", expectedOfferStatus: "ACTIVE", expectedWarnings: []string{"offer_status"}},
+		{name: "Unknown measure unit", measureUnit: "yard", offerStatus: "new", expectedMeasureUnit: "yard", expectedOfferStatus: "new"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			v := validator.New()
+			measureUnit, offerStatus := tc.measureUnit, tc.offerStatus
+
+			// --- Act ---
+			normalizeFabricAttributes(v, &measureUnit, &offerStatus)
+
+			// --- Assert ---
+			assert.True(t, v.Valid(), "warnings must never make the validator invalid")
+			assert.Equal(t, tc.expectedMeasureUnit, measureUnit)
+			assert.Equal(t, tc.expectedOfferStatus, offerStatus)
+			assert.Len(t, v.Warnings, len(tc.expectedWarnings))
+			for _, key := range tc.expectedWarnings {
+				assert.Contains(t, v.Warnings, key)
+			}
+		})
+	}
+}
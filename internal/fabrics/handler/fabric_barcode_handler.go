@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricBarcodeHandler manages the barcodes printed on the bolts of a fabric.
+type FabricBarcodeHandler struct {
+	barcodes domain.FabricBarcodeRepository
+	clock    clock.Clock
+}
+
+type addFabricBarcodeRequest struct {
+	Barcode string `json:"barcode"`
+}
+
+func NewFabricBarcodeHandler(barcodes domain.FabricBarcodeRepository, clock clock.Clock) *FabricBarcodeHandler {
+	return &FabricBarcodeHandler{
+		barcodes: barcodes,
+		clock:    clock,
+	}
+}
+
+func (h *FabricBarcodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listBarcodes(w, r)
+	case http.MethodPost:
+		h.addBarcode(w, r)
+	case http.MethodDelete:
+		h.removeBarcode(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricBarcodeHandler) listBarcodes(w http.ResponseWriter, r *http.Request) {
+	barcodes, err := h.barcodes.ListBarcodes(r.Context(), httpx.URLParam(r, "code"))
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"barcodes": barcodes}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricBarcodeHandler) addBarcode(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	code := httpx.URLParam(r, "code")
+
+	var req addFabricBarcodeRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Barcode != "", "barcode", "barcode must be provided")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	barcode, err := domain.NewFabricBarcode(req.Barcode, code, domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()})
+	if err == nil {
+		err = h.barcodes.AddBarcode(ctx, barcode)
+	}
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"barcode": barcode}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricBarcodeHandler) removeBarcode(w http.ResponseWriter, r *http.Request) {
+	// a barcode not in its canonical form cannot be assigned, so it is not found either
+	barcode, err := domain.ParseBarcode(httpx.URLParam(r, "barcode"))
+	if err != nil {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	err = h.barcodes.RemoveBarcode(r.Context(), httpx.URLParam(r, "code"), barcode)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// FabricBarcodeReader resolves a barcode to the fabric holding it.
+type FabricBarcodeReader interface {
+	GetBarcode(ctx context.Context, barcode string) (*domain.FabricBarcode, error)
+}
+
+// FabricBarcodeLookupHandler serves the fabric holding a scanned barcode, e.g.
+// /fabrics/by-barcode/4006381333931, handing the request over to the handler serving the
+// fabric under its code.
+type FabricBarcodeLookupHandler struct {
+	barcodes FabricBarcodeReader
+	fabrics  http.Handler
+}
+
+func NewFabricBarcodeLookupHandler(barcodes FabricBarcodeReader, fabrics http.Handler) *FabricBarcodeLookupHandler {
+	return &FabricBarcodeLookupHandler{
+		barcodes: barcodes,
+		fabrics:  fabrics,
+	}
+}
+
+func (h *FabricBarcodeLookupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	raw, err := domain.ParseBarcode(httpx.URLParam(r, "ean"))
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	barcode, err := h.barcodes.GetBarcode(r.Context(), raw)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRecordNotFound):
+			httpx.NotFound(w, r)
+		default:
+			httpx.InternalError(w, r, err)
+		}
+		return
+	}
+
+	h.fabrics.ServeHTTP(w, httpx.WithURLParam(r, "code", barcode.FabricCode))
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricBarcodeRepository struct {
+	added       []*domain.FabricBarcode
+	removed     []string
+	errToReturn error
+}
+
+func (m *mockFabricBarcodeRepository) AddBarcode(ctx context.Context, barcode *domain.FabricBarcode) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.added = append(m.added, barcode)
+	return nil
+}
+
+func (m *mockFabricBarcodeRepository) RemoveBarcode(ctx context.Context, fabricCode, barcode string) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.removed = append(m.removed, fabricCode+"/"+barcode)
+	return nil
+}
+
+func (m *mockFabricBarcodeRepository) ListBarcodes(ctx context.Context, fabricCode string) ([]*domain.FabricBarcode, error) {
+	return m.added, m.errToReturn
+}
+
+func (m *mockFabricBarcodeRepository) GetBarcode(ctx context.Context, barcode string) (*domain.FabricBarcode, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	for _, added := range m.added {
+		if added.Barcode == barcode {
+			return added, nil
+		}
+	}
+	return nil, domain.ErrRecordNotFound
+}
+
+func serveFabricBarcode(
+	t *testing.T, handler http.Handler, method, target string, params map[string]string, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricBarcodeHandler_AddBarcode_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	barcodes := &mockFabricBarcodeRepository{}
+	handler := NewFabricBarcodeHandler(barcodes, testClock)
+
+	// --- Act ---
+	responseRecorder := serveFabricBarcode(t, handler, http.MethodPost, "/v1/fabrics/FAB01/barcodes",
+		map[string]string{"code": "FAB01"}, `{"barcode": "036000291452"}`)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	require.Len(t, barcodes.added, 1)
+	assert.Equal(t, "0036000291452", barcodes.added[0].Barcode, "a UPC-A is stored as its EAN-13")
+	assert.Equal(t, "FAB01", barcodes.added[0].FabricCode)
+	assert.Equal(t, "anonymous", barcodes.added[0].CreatedBy)
+	assert.Equal(t, testClock.Now(), barcodes.added[0].CreatedAt)
+}
+
+func TestFabricBarcodeHandler_AddBarcode_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		repoErr        error
+		expectedStatus int
+	}{
+		{name: "Missing barcode", body: `{}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Wrong check digit", body: `{"barcode": "4006381333932"}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Barcode taken", body: `{"barcode": "4006381333931"}`, repoErr: domain.ErrBarcodeTaken, expectedStatus: http.StatusConflict},
+		{name: "Unknown fabric", body: `{"barcode": "4006381333931"}`, repoErr: domain.ErrRecordNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			barcodes := &mockFabricBarcodeRepository{errToReturn: tc.repoErr}
+			handler := NewFabricBarcodeHandler(barcodes, testClock)
+
+			// --- Act ---
+			responseRecorder := serveFabricBarcode(t, handler, http.MethodPost, "/v1/fabrics/FAB01/barcodes",
+				map[string]string{"code": "FAB01"}, tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Empty(t, barcodes.added)
+		})
+	}
+}
+
+func TestFabricBarcodeHandler_RemoveBarcode(t *testing.T) {
+	// --- Arrange ---
+	barcodes := &mockFabricBarcodeRepository{}
+	handler := NewFabricBarcodeHandler(barcodes, testClock)
+
+	// --- Act ---
+	responseRecorder := serveFabricBarcode(t, handler, http.MethodDelete, "/v1/fabrics/FAB01/barcodes/036000291452",
+		map[string]string{"code": "FAB01", "barcode": "036000291452"}, "")
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusNoContent, responseRecorder.Code)
+	assert.Equal(t, []string{"FAB01/0036000291452"}, barcodes.removed)
+}
+
+func TestFabricBarcodeLookupHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		ean            string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "Known barcode", ean: "4006381333931", expectedStatus: http.StatusOK, expectedCode: "FAB01"},
+		{name: "Known barcode scanned as UPC-A", ean: "036000291452", expectedStatus: http.StatusOK, expectedCode: "FAB02"},
+		{name: "Unknown barcode", ean: "96385074", expectedStatus: http.StatusNotFound},
+		{name: "Invalid barcode", ean: "12345", expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			barcodes := &mockFabricBarcodeRepository{added: []*domain.FabricBarcode{
+				{Barcode: "4006381333931", FabricCode: "FAB01"},
+				{Barcode: "0036000291452", FabricCode: "FAB02"},
+			}}
+			var servedCode string
+			fabrics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				servedCode = httpx.URLParam(r, "code")
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := NewFabricBarcodeLookupHandler(barcodes, fabrics)
+
+			// --- Act ---
+			responseRecorder := serveFabricBarcode(t, handler, http.MethodGet, "/v1/fabrics/by-barcode/"+tc.ean,
+				map[string]string{"ean": tc.ean}, "")
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCode, servedCode)
+		})
+	}
+}
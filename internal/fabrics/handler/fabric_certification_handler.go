@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricCertificationService records the certifications of fabrics.
+type FabricCertificationService interface {
+	ListCertifications(ctx context.Context, code string) ([]*domain.FabricCertification, error)
+	AddCertification(
+		ctx context.Context, code, scheme, certificateNumber string, validFrom, validUntil time.Time,
+	) (*domain.FabricCertification, error)
+}
+
+// FabricCertificationHandler lists the certifications of a fabric and adds new ones, e.g.
+// a GOTS certificate valid for a year.
+type FabricCertificationHandler struct {
+	service FabricCertificationService
+}
+
+// the validity bounds are RFC 3339 timestamps
+type addFabricCertificationRequest struct {
+	Scheme            string     `json:"scheme"`
+	CertificateNumber string     `json:"certificate_number"`
+	ValidFrom         *time.Time `json:"valid_from"`
+	ValidUntil        *time.Time `json:"valid_until"`
+}
+
+func NewFabricCertificationHandler(service FabricCertificationService) *FabricCertificationHandler {
+	return &FabricCertificationHandler{service: service}
+}
+
+func (h *FabricCertificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listCertifications(w, r)
+	case http.MethodPost:
+		h.addCertification(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricCertificationHandler) listCertifications(w http.ResponseWriter, r *http.Request) {
+	certifications, err := h.service.ListCertifications(r.Context(), httpx.URLParam(r, "code"))
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"certifications": certifications}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricCertificationHandler) addCertification(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req addFabricCertificationRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Scheme = strings.ToLower(validator.NormalizeText(req.Scheme))
+	req.CertificateNumber = validator.NormalizeText(req.CertificateNumber)
+	v := validator.New()
+	v.Check(req.Scheme != "", "scheme", "scheme must be provided")
+	v.Check(req.CertificateNumber != "", "certificate_number", "certificate_number must be provided")
+	v.Check(req.ValidFrom != nil, "valid_from", "valid_from must be provided")
+	v.Check(req.ValidUntil != nil, "valid_until", "valid_until must be provided")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	certification, err := h.service.AddCertification(
+		ctx, httpx.URLParam(r, "code"), req.Scheme, req.CertificateNumber, *req.ValidFrom, *req.ValidUntil,
+	)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"certification": certification}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricCertificationService struct {
+	certifications    []*domain.FabricCertification
+	scheme            string
+	certificateNumber string
+	validFrom         time.Time
+	validUntil        time.Time
+	errToReturn       error
+}
+
+func (m *mockFabricCertificationService) ListCertifications(
+	ctx context.Context, code string,
+) ([]*domain.FabricCertification, error) {
+	return m.certifications, m.errToReturn
+}
+
+func (m *mockFabricCertificationService) AddCertification(
+	ctx context.Context, code, scheme, certificateNumber string, validFrom, validUntil time.Time,
+) (*domain.FabricCertification, error) {
+	m.scheme, m.certificateNumber, m.validFrom, m.validUntil = scheme, certificateNumber, validFrom, validUntil
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.FabricCertification{
+		ID: "cert-1", FabricCode: code, Scheme: scheme, CertificateNumber: certificateNumber,
+		ValidFrom: validFrom, ValidUntil: validUntil,
+	}, nil
+}
+
+func serveCertification(t *testing.T, handler *FabricCertificationHandler, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", "FAB01")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricCertificationHandler_ListCertifications(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricCertificationService{certifications: []*domain.FabricCertification{
+		{ID: "cert-1", FabricCode: "FAB01", Scheme: domain.CertificationGOTS, CertificateNumber: "GOTS-1"},
+	}}
+	handler := NewFabricCertificationHandler(svc)
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/FAB01/certifications", nil)
+	require.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := serveCertification(t, handler, req)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	var body struct {
+		Certifications []domain.FabricCertification `json:"certifications"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	require.Len(t, body.Certifications, 1)
+	assert.Equal(t, "gots", body.Certifications[0].Scheme)
+	assert.Equal(t, "GOTS-1", body.Certifications[0].CertificateNumber)
+}
+
+func TestFabricCertificationHandler_AddCertification(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricCertificationService{}
+	handler := NewFabricCertificationHandler(svc)
+	body := `{"scheme":" GOTS ","certificate_number":"CU-123","valid_from":"2025-01-01T00:00:00Z","valid_until":"2026-01-01T00:00:00+01:00"}`
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/FAB01/certifications", strings.NewReader(body))
+	require.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := serveCertification(t, handler, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, "gots", svc.scheme)
+	assert.Equal(t, "CU-123", svc.certificateNumber)
+	assert.True(t, svc.validFrom.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, svc.validUntil.Equal(time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC)))
+	assert.Contains(t, responseRecorder.Body.String(), `"certification"`)
+}
+
+func TestFabricCertificationHandler_AddCertification_Rejected(t *testing.T) {
+	const validBody = `{"scheme":"gots","certificate_number":"CU-123","valid_from":"2025-01-01T00:00:00Z","valid_until":"2026-01-01T00:00:00Z"}`
+
+	testCases := []struct {
+		name           string
+		body           string
+		errToReturn    error
+		expectedStatus int
+		expectedField  string
+	}{
+		{
+			name:           "missing validity",
+			body:           `{"scheme":"gots","certificate_number":"CU-123"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedField:  "valid_from",
+		},
+		{
+			name:           "timestamp without zone",
+			body:           `{"scheme":"gots","certificate_number":"CU-123","valid_from":"2025-01-01","valid_until":"2026-01-01"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown scheme",
+			body:           validBody,
+			errToReturn:    domain.ErrInvalidCertificationScheme,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedField:  "scheme",
+		},
+		{
+			name:           "unknown fabric",
+			body:           validBody,
+			errToReturn:    domain.ErrRecordNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewFabricCertificationHandler(&mockFabricCertificationService{errToReturn: tc.errToReturn})
+			req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/FAB01/certifications", strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			// --- Act ---
+			responseRecorder := serveCertification(t, handler, req)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedField != "" {
+				assert.Contains(t, responseRecorder.Body.String(), `"`+tc.expectedField+`"`)
+			}
+		})
+	}
+}
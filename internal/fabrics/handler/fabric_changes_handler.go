@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	// position-only tokens, still accepted from clients that synced before tombstones
+	changeTokenPrefixV1 = "p:"
+	// position and event ID tokens, the event ID keeps the token valid across replays
+	changeTokenPrefixV2 = "v2:"
+)
+
+var errInvalidChangeToken = errors.New("invalid change token")
+
+// events after which a fabric no longer exists for clients and must be removed locally
+var fabricTombstoneEvents = []string{"app.fabric.deleted", "app.fabric.purged"}
+
+type FabricChangeFeed interface {
+	ReadAfter(ctx context.Context, aggregateType string, after int64, limit int) ([]eventstore.RecordedEvent, error)
+	PositionOf(ctx context.Context, eventID string) (int64, error)
+}
+
+// changeToken is the decoded form of the opaque since/next token
+type changeToken struct {
+	Position int64
+	EventID  string
+}
+
+// fabricChange is a single entry of the change feed
+type fabricChange struct {
+	Position   int64     `json:"position"`
+	Type       string    `json:"type"`
+	Code       string    `json:"code"`
+	Version    int       `json:"version"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Tombstone  bool      `json:"tombstone"`
+	Data       any       `json:"data"`
+}
+
+type FabricChangesHandler struct {
+	feed       FabricChangeFeed
+	pagination httpx.PaginationConfig
+}
+
+func NewFabricChangesHandler(feed FabricChangeFeed, pagination httpx.PaginationConfig) *FabricChangesHandler {
+	return &FabricChangesHandler{
+		feed:       feed,
+		pagination: pagination,
+	}
+}
+
+// ServeHTTP returns the fabric changes recorded after the since token, in event store
+// order, with the token to pass on the next call. An empty since starts from the beginning.
+// Deleted fabrics are reported as tombstones; a token whose event no longer exists in the
+// store answers 410 Gone, telling the client to discard local state and resync.
+func (h *FabricChangesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+
+	v := validator.New()
+	since, err := decodeChangeToken(qs.Get("since"))
+	if err != nil {
+		v.AddError("since", "must be a token returned by a previous call")
+	}
+	limit := h.pagination.DefaultPageSize
+	if raw := qs.Get("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil {
+			v.AddError("limit", "must be an integer value")
+		}
+	}
+	v.Check(limit > 0, "limit", "must be greater than zero")
+	v.Check(limit <= h.pagination.MaxPageSize, "limit", "must be a maximum of "+strconv.Itoa(h.pagination.MaxPageSize))
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	after := since.Position
+	if since.EventID != "" {
+		after, err = h.feed.PositionOf(r.Context(), since.EventID)
+		if err != nil {
+			switch {
+			case errors.Is(err, eventstore.ErrEventNotFound):
+				httpx.ErrorJSON(w, http.StatusGone, "the change token is no longer valid, perform a full resync")
+			default:
+				httpx.InternalError(w, r, err)
+			}
+			return
+		}
+	}
+
+	// one extra record tells whether the client should keep paging
+	events, err := h.feed.ReadAfter(r.Context(), domain.AggregateType, after, limit+1)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+
+	changes := make([]fabricChange, 0, len(events))
+	next := since
+	for _, event := range events {
+		changes = append(changes, fabricChange{
+			Position:   event.Position,
+			Type:       event.Envelope.EventType,
+			Code:       event.Envelope.AggregateID,
+			Version:    event.Envelope.AggregateVersion,
+			OccurredAt: event.Envelope.Timestamp,
+			Tombstone:  validator.PermittedValue(event.Envelope.EventType, fabricTombstoneEvents...),
+			Data:       event.Envelope.Payload,
+		})
+		next = changeToken{Position: event.Position, EventID: event.Envelope.EventID}
+	}
+
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{
+		"changes":  changes,
+		"next":     encodeChangeToken(next),
+		"has_more": hasMore,
+	}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// tokens are opaque to clients, so the encoding can change without breaking them
+func encodeChangeToken(token changeToken) string {
+	raw := changeTokenPrefixV1 + strconv.FormatInt(token.Position, 10)
+	if token.EventID != "" {
+		raw = changeTokenPrefixV2 + strconv.FormatInt(token.Position, 10) + ":" + token.EventID
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeChangeToken(token string) (changeToken, error) {
+	if token == "" {
+		return changeToken{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return changeToken{}, errInvalidChangeToken
+	}
+
+	var decoded changeToken
+	position := string(raw)
+	if value, ok := strings.CutPrefix(position, changeTokenPrefixV2); ok {
+		var eventID string
+		position, eventID, ok = strings.Cut(value, ":")
+		if !ok {
+			return changeToken{}, errInvalidChangeToken
+		}
+		if _, err := uuid.Parse(eventID); err != nil {
+			return changeToken{}, errInvalidChangeToken
+		}
+		decoded.EventID = eventID
+	} else if value, ok := strings.CutPrefix(position, changeTokenPrefixV1); ok {
+		position = value
+	} else {
+		return changeToken{}, errInvalidChangeToken
+	}
+
+	decoded.Position, err = strconv.ParseInt(position, 10, 64)
+	if err != nil || decoded.Position < 0 {
+		return changeToken{}, errInvalidChangeToken
+	}
+
+	return decoded, nil
+}
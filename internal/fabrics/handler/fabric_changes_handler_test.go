@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricChangeFeed struct {
+	events []eventstore.RecordedEvent
+	after  int64
+	limit  int
+}
+
+func (m *mockFabricChangeFeed) ReadAfter(
+	ctx context.Context, aggregateType string, after int64, limit int,
+) ([]eventstore.RecordedEvent, error) {
+	m.after = after
+	m.limit = limit
+	result := []eventstore.RecordedEvent{}
+	for _, event := range m.events {
+		if event.Position > after && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockFabricChangeFeed) PositionOf(ctx context.Context, eventID string) (int64, error) {
+	for _, event := range m.events {
+		if event.Envelope.EventID == eventID {
+			return event.Position, nil
+		}
+	}
+	return 0, eventstore.ErrEventNotFound
+}
+
+type changesResponse struct {
+	Changes []fabricChange `json:"changes"`
+	Next    string         `json:"next"`
+	HasMore bool           `json:"has_more"`
+}
+
+func recordedFabricEvent(position int64, eventType, code string, version int) eventstore.RecordedEvent {
+	return eventstore.RecordedEvent{
+		Position: position,
+		Envelope: &messaging.EventEnvelope{
+			EventID:          uuid.NewString(),
+			EventType:        eventType,
+			AggregateID:      code,
+			AggregateVersion: version,
+		},
+	}
+}
+
+func serveChanges(t *testing.T, feed FabricChangeFeed, query string) (*httptest.ResponseRecorder, changesResponse) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/changes?"+query, nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	NewFabricChangesHandler(feed, testPaginationConfig).ServeHTTP(responseRecorder, req)
+
+	var response changesResponse
+	if responseRecorder.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	}
+	return responseRecorder, response
+}
+
+func TestFabricChangesHandler_IncrementalSync(t *testing.T) {
+	// --- Arrange ---
+	feed := &mockFabricChangeFeed{events: []eventstore.RecordedEvent{
+		recordedFabricEvent(3, "app.fabric.created", "CHG01", 1),
+		recordedFabricEvent(7, "app.fabric.updated", "CHG01", 2),
+		recordedFabricEvent(9, "app.fabric.created", "CHG02", 1),
+	}}
+
+	// --- Act ---
+	firstRecorder, first := serveChanges(t, feed, "limit=2")
+	_, second := serveChanges(t, feed, "limit=2&since="+first.Next)
+	_, third := serveChanges(t, feed, "limit=2&since="+second.Next)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, firstRecorder.Code)
+	require.Len(t, first.Changes, 2)
+	assert.Equal(t, int64(3), first.Changes[0].Position)
+	assert.Equal(t, "CHG01", first.Changes[0].Code)
+	assert.True(t, first.HasMore)
+
+	require.Len(t, second.Changes, 1)
+	assert.Equal(t, int64(9), second.Changes[0].Position)
+	assert.False(t, second.HasMore)
+
+	assert.Empty(t, third.Changes)
+	assert.Equal(t, second.Next, third.Next, "next token should not move when there are no new changes")
+}
+
+func TestFabricChangesHandler_InvalidToken(t *testing.T) {
+	// --- Arrange ---
+	feed := &mockFabricChangeFeed{}
+
+	// --- Act ---
+	responseRecorder, _ := serveChanges(t, feed, "since=not-a-token")
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+	assert.Contains(t, responseRecorder.Body.String(), "since")
+}
+
+func TestFabricChangesHandler_Tombstones(t *testing.T) {
+	// --- Arrange ---
+	feed := &mockFabricChangeFeed{events: []eventstore.RecordedEvent{
+		recordedFabricEvent(1, "app.fabric.created", "CHG01", 1),
+		recordedFabricEvent(2, "app.fabric.deleted", "CHG01", 2),
+	}}
+
+	// --- Act ---
+	_, response := serveChanges(t, feed, "")
+
+	// --- Assert ---
+	require.Len(t, response.Changes, 2)
+	assert.False(t, response.Changes[0].Tombstone)
+	assert.True(t, response.Changes[1].Tombstone, "a deletion should be reported as a tombstone")
+}
+
+func TestFabricChangesHandler_TokenSurvivesReplay(t *testing.T) {
+	// --- Arrange ---
+	feed := &mockFabricChangeFeed{events: []eventstore.RecordedEvent{
+		recordedFabricEvent(10, "app.fabric.created", "CHG01", 1),
+		recordedFabricEvent(11, "app.fabric.created", "CHG02", 1),
+	}}
+	_, first := serveChanges(t, feed, "limit=1")
+
+	// the store is replayed and every event gets a new, lower position
+	feed.events[0].Position, feed.events[1].Position = 1, 2
+
+	// --- Act ---
+	_, second := serveChanges(t, feed, "since="+first.Next)
+
+	// --- Assert ---
+	assert.Equal(t, int64(1), feed.after, "the checkpoint should be resolved from the event ID")
+	require.Len(t, second.Changes, 1)
+	assert.Equal(t, "CHG02", second.Changes[0].Code)
+}
+
+func TestFabricChangesHandler_UnknownEventTokenIsGone(t *testing.T) {
+	// --- Arrange ---
+	feed := &mockFabricChangeFeed{}
+	token := encodeChangeToken(changeToken{Position: 5, EventID: uuid.NewString()})
+
+	// --- Act ---
+	responseRecorder, _ := serveChanges(t, feed, "since="+token)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusGone, responseRecorder.Code)
+}
+
+func TestChangeToken_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		name  string
+		token changeToken
+	}{
+		{name: "Position only", token: changeToken{Position: 42}},
+		{name: "Position and event ID", token: changeToken{Position: 42, EventID: uuid.NewString()}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			decoded, err := decodeChangeToken(encodeChangeToken(tc.token))
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tc.token, decoded)
+		})
+	}
+}
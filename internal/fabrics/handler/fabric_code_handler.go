@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricCodeHandler hands out codes for articles created in the UI. The codes come from
+// the configured prefixed sequences, so they do not collide with the ones the ERP assigns.
+type FabricCodeHandler struct {
+	codes     domain.FabricCodeRepository
+	sequences domain.CodeSequences
+	clock     clock.Clock
+}
+
+type reserveFabricCodeRequest struct {
+	Prefix string `json:"prefix"`
+}
+
+func NewFabricCodeHandler(
+	codes domain.FabricCodeRepository, sequences domain.CodeSequences, clock clock.Clock,
+) *FabricCodeHandler {
+	return &FabricCodeHandler{
+		codes:     codes,
+		sequences: sequences,
+		clock:     clock,
+	}
+}
+
+func (h *FabricCodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listSequences(w, r)
+	case http.MethodPost:
+		h.reserveCode(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricCodeHandler) listSequences(w http.ResponseWriter, r *http.Request) {
+	sequences := make([]domain.CodeSequence, 0, len(h.sequences))
+	for _, prefix := range h.sequences.Prefixes() {
+		sequences = append(sequences, h.sequences[prefix])
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"sequences": sequences}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricCodeHandler) reserveCode(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req reserveFabricCodeRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Prefix = validator.NormalizeCode(req.Prefix)
+	v := validator.New()
+	v.Check(req.Prefix != "", "prefix", "prefix must be provided")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	sequence, err := h.sequences.Lookup(req.Prefix)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+	reservation := domain.NewCodeReservation(sequence, domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()})
+	if err := h.codes.ReserveCode(ctx, sequence, reservation); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"reservation": reservation}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFabricCodeRepository hands out consecutive numbers of whichever sequence is asked for
+type mockFabricCodeRepository struct {
+	next     int64
+	reserved []*domain.CodeReservation
+}
+
+func (m *mockFabricCodeRepository) ReserveCode(
+	ctx context.Context, sequence domain.CodeSequence, reservation *domain.CodeReservation,
+) error {
+	m.next++
+	code, err := sequence.Format(m.next)
+	if err != nil {
+		return err
+	}
+	reservation.Code = code
+	m.reserved = append(m.reserved, reservation)
+	return nil
+}
+
+func serveFabricCodes(t *testing.T, handler *FabricCodeHandler, method, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "/v1/fabric-codes", strings.NewReader(body))
+	require.NoError(t, err)
+	req = req.WithContext(command.WithUserID(req.Context(), "user_42"))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricCodeHandler_Reserve(t *testing.T) {
+	// --- Arrange ---
+	sequences, err := domain.ParseCodeSequences("ZY:4")
+	require.NoError(t, err)
+	codes := &mockFabricCodeRepository{}
+	handler := NewFabricCodeHandler(codes, sequences, testClock)
+
+	// --- Act ---
+	first := serveFabricCodes(t, handler, http.MethodPost, `{"prefix": " zy "}`)
+	second := serveFabricCodes(t, handler, http.MethodPost, `{"prefix": "ZY"}`)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusCreated, first.Code)
+	require.Equal(t, http.StatusCreated, second.Code)
+
+	var body struct {
+		Reservation domain.CodeReservation `json:"reservation"`
+	}
+	require.NoError(t, json.Unmarshal(second.Body.Bytes(), &body))
+	assert.Equal(t, "ZY0002", body.Reservation.Code)
+	assert.Equal(t, "ZY", body.Reservation.Prefix)
+	assert.Equal(t, "user_42", body.Reservation.ReservedBy)
+	assert.Equal(t, testClock.Now(), body.Reservation.ReservedAt)
+	assert.Equal(t, "ZY0001", codes.reserved[0].Code)
+}
+
+func TestFabricCodeHandler_RejectsRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+	}{
+		{name: "unknown prefix", method: http.MethodPost, body: `{"prefix": "XX"}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "no prefix", method: http.MethodPost, body: `{}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "bad JSON", method: http.MethodPost, body: `{"prefix": `, expectedStatus: http.StatusBadRequest},
+		{name: "wrong method", method: http.MethodPut, body: ``, expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			sequences, err := domain.ParseCodeSequences("ZY:4")
+			require.NoError(t, err)
+			codes := &mockFabricCodeRepository{}
+			handler := NewFabricCodeHandler(codes, sequences, testClock)
+
+			// --- Act ---
+			responseRecorder := serveFabricCodes(t, handler, tt.method, tt.body)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, responseRecorder.Code)
+			assert.Empty(t, codes.reserved, "no code should be reserved for a rejected request")
+		})
+	}
+}
+
+func TestFabricCodeHandler_ListSequences(t *testing.T) {
+	// --- Arrange ---
+	sequences, err := domain.ParseCodeSequences("ZY:4,UI:6")
+	require.NoError(t, err)
+	handler := NewFabricCodeHandler(&mockFabricCodeRepository{}, sequences, testClock)
+
+	// --- Act ---
+	responseRecorder := serveFabricCodes(t, handler, http.MethodGet, "")
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	var body struct {
+		Sequences []domain.CodeSequence `json:"sequences"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	assert.Equal(t, []domain.CodeSequence{{Prefix: "UI", Digits: 6}, {Prefix: "ZY", Digits: 4}}, body.Sequences)
+}
@@ -2,9 +2,9 @@ package handler
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/salesworks/s-works/api/internal/fabrics/domain"
 	command "github.com/salesworks/s-works/api/internal/platform/context"
@@ -14,32 +14,63 @@ import (
 
 type FabricCommandService interface {
 	CreateFabric(
-		ctx context.Context, code, name, measureUnit, offerStatus string,
+		ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification, texts domain.FabricTexts,
+	) (*domain.Fabric, error)
+	CreateDraftFabric(
+		ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification, texts domain.FabricTexts,
 	) (*domain.Fabric, error)
 	UpdateFabric(
-		ctx context.Context, code, name, measureUnit, offerStatus string, version int,
+		ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification,
+		texts domain.FabricTexts, version int,
 	) (*domain.Fabric, error)
 	DeleteFabric(ctx context.Context, code string, version int) error
 	GetByCode(ctx context.Context, code string) (*domain.Fabric, error)
+	// SyncFabric applies the data at the current version of the fabric, reporting whether
+	// it changed anything. Conflicts of event-sourced calls are retried.
+	SyncFabric(
+		ctx context.Context, code, name, measureUnit, offerStatus string,
+	) (*domain.Fabric, bool, error)
 }
 
 type FabricCommandHandler struct {
 	service FabricCommandService
 }
 
-// data contract for API endpoint
+// data contract for API endpoint, a draft is created off offer until it is activated
 type createFabricRequest struct {
-	Code        string `json:"code"`
-	Name        string `json:"name"`
-	MeasureUnit string `json:"measure_unit"`
-	OfferStatus string `json:"offer_status"`
+	Code          string                      `json:"code"`
+	Name          string                      `json:"name"`
+	MeasureUnit   string                      `json:"measure_unit"`
+	OfferStatus   string                      `json:"offer_status"`
+	Specification *fabricSpecificationRequest `json:"specification"`
+	Description   *string                     `json:"description"`
+	Notes         *string                     `json:"notes"`
+	Draft         bool                        `json:"draft"`
 }
 
+// an update without a specification, description or notes leaves the stored one as it is
 type updateFabricRequest struct {
-	Name        string `json:"name"`
-	MeasureUnit string `json:"measure_unit"`
-	OfferStatus string `json:"offer_status"`
-	Version     int    `json:"version"`
+	Name          string                      `json:"name"`
+	MeasureUnit   string                      `json:"measure_unit"`
+	OfferStatus   string                      `json:"offer_status"`
+	Specification *fabricSpecificationRequest `json:"specification"`
+	Description   *string                     `json:"description"`
+	Notes         *string                     `json:"notes"`
+	Version       int                         `json:"version"`
+}
+
+type fabricSpecificationRequest struct {
+	Composition      []compositionPartRequest `json:"composition"`
+	WidthCM          int                      `json:"width_cm"`
+	WeightGSM        int                      `json:"weight_gsm"`
+	Color            string                   `json:"color"`
+	MinOrderQuantity int                      `json:"min_order_quantity"`
+	LeadTimeDays     int                      `json:"lead_time_days"`
+}
+
+type compositionPartRequest struct {
+	Fibre   string `json:"fibre"`
+	Percent int    `json:"percent"`
 }
 
 type deleteFabricRequest struct {
@@ -74,35 +105,34 @@ func (h *FabricCommandHandler) createFabric(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	req.normalize()
 	v := validator.New()
 	validateCreateFabricRequest(v, &req)
 	if !v.Valid() {
 		httpx.ValidationError(w, r, v.Errors)
 		return
 	}
+	normalizeFabricAttributes(v, &req.MeasureUnit, &req.OfferStatus)
 
-	_, err := h.service.CreateFabric(
+	create := h.service.CreateFabric
+	if req.Draft {
+		create = h.service.CreateDraftFabric
+	}
+	_, err := create(
 		ctx,
 		req.Code,
 		req.Name,
 		req.MeasureUnit,
 		req.OfferStatus,
+		req.Specification.toDomain(),
+		domain.FabricTexts{Description: req.Description, Notes: req.Notes},
 	)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrDuplicateFabricCode):
-			httpx.ErrorJSON(w, http.StatusConflict, "a fabric with this code already exists")
-		case errors.Is(err, domain.ErrInvalidFabricCodeLength) ||
-			errors.Is(err, domain.ErrInvalidFabricCodePattern) ||
-			errors.Is(err, domain.ErrInvalidFabricNameLength):
-			httpx.ValidationError(w, r, map[string]string{"error": err.Error()})
-		default:
-			httpx.InternalError(w, r, err)
-		}
+		writeDomainError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusAccepted)
+	writeAccepted(w, r, http.StatusAccepted, v)
 }
 
 func (h *FabricCommandHandler) updateFabric(w http.ResponseWriter, r *http.Request) {
@@ -116,12 +146,14 @@ func (h *FabricCommandHandler) updateFabric(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	req.normalize()
 	v := validator.New()
 	validateUpdateFabricRequest(v, &req)
 	if !v.Valid() {
 		httpx.ValidationError(w, r, v.Errors)
 		return
 	}
+	normalizeFabricAttributes(v, &req.MeasureUnit, &req.OfferStatus)
 
 	_, err := h.service.UpdateFabric(
 		ctx,
@@ -129,23 +161,16 @@ func (h *FabricCommandHandler) updateFabric(w http.ResponseWriter, r *http.Reque
 		req.Name,
 		req.MeasureUnit,
 		req.OfferStatus,
+		req.Specification.toDomainOrNil(),
+		domain.FabricTexts{Description: req.Description, Notes: req.Notes},
 		req.Version,
 	)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrRecordNotFound):
-			httpx.NotFound(w, r)
-		case errors.Is(err, domain.ErrConcurrencyConflict):
-			httpx.ErrorJSON(w, http.StatusConflict, "the resource has been modified by another process, please refresh and try again")
-		case errors.Is(err, domain.ErrInvalidFabricNameLength):
-			httpx.ValidationError(w, r, map[string]string{"error": err.Error()})
-		default:
-			httpx.InternalError(w, r, err)
-		}
+		writeDomainError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	writeAccepted(w, r, http.StatusOK, v)
 }
 
 func (h *FabricCommandHandler) deleteFabric(w http.ResponseWriter, r *http.Request) {
@@ -168,26 +193,75 @@ func (h *FabricCommandHandler) deleteFabric(w http.ResponseWriter, r *http.Reque
 
 	err := h.service.DeleteFabric(ctx, code, req.Version)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrRecordNotFound):
-			httpx.NotFound(w, r)
-		case errors.Is(err, domain.ErrConcurrencyConflict):
-			httpx.ErrorJSON(w, http.StatusConflict, "the resource has been modified by another process, please refresh and try again")
-		default:
-			httpx.InternalError(w, r, err)
-		}
+		writeDomainError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// normalize cleans up the request before validation, so near-duplicate input such as
+// "Zoya " and "Zoya" is stored the same
+func (req *createFabricRequest) normalize() {
+	req.Code = validator.NormalizeCode(req.Code)
+	req.Name = validator.NormalizeText(req.Name)
+	req.MeasureUnit = validator.NormalizeCode(req.MeasureUnit)
+	req.OfferStatus = validator.NormalizeCode(req.OfferStatus)
+	req.Specification.normalize()
+}
+
+func (req *updateFabricRequest) normalize() {
+	req.Name = validator.NormalizeText(req.Name)
+	req.MeasureUnit = validator.NormalizeCode(req.MeasureUnit)
+	req.OfferStatus = validator.NormalizeCode(req.OfferStatus)
+	req.Specification.normalize()
+}
+
+// fibres are stored in lower case, so "Cotton" and "cotton " name the same fibre
+func (req *fabricSpecificationRequest) normalize() {
+	if req == nil {
+		return
+	}
+	for i := range req.Composition {
+		req.Composition[i].Fibre = strings.ToLower(validator.NormalizeText(req.Composition[i].Fibre))
+	}
+	req.Color = validator.NormalizeCode(req.Color)
+}
+
+// toDomain maps the specification onto the domain, an absent one is left unspecified
+func (req *fabricSpecificationRequest) toDomain() domain.Specification {
+	if req == nil {
+		return domain.Specification{}
+	}
+	spec := domain.Specification{
+		WidthCM:          req.WidthCM,
+		WeightGSM:        req.WeightGSM,
+		Color:            req.Color,
+		MinOrderQuantity: req.MinOrderQuantity,
+		LeadTimeDays:     req.LeadTimeDays,
+	}
+	for _, part := range req.Composition {
+		spec.Composition = append(spec.Composition, domain.CompositionPart{Fibre: part.Fibre, Percent: part.Percent})
+	}
+	return spec
+}
+
+// toDomainOrNil maps the specification onto the domain, an absent one keeps the stored one
+func (req *fabricSpecificationRequest) toDomainOrNil() *domain.Specification {
+	if req == nil {
+		return nil
+	}
+	spec := req.toDomain()
+	return &spec
+}
+
 func validateCreateFabricRequest(v *validator.Validator, req *createFabricRequest) {
 	// --- Fabric Code Validation ---
 	v.Check(req.Code != "", "code", "code must be provided")
 	v.Check(len(req.Code) >= 2, "code", "code must be between 2 and 30 characters long")
 	v.Check(len(req.Code) <= 30, "code", "code must be between 2 and 30 characters long")
 	v.Check(validator.Matches(req.Code, regexp.MustCompile("^[A-Z0-9]+$")), "code", "code must only contain uppercase letters and numbers")
+	v.Check(!domain.IsProbeFabricCode(req.Code), "code", "code is reserved for the synthetic probe")
 
 	// --- Fabric Name Validation ---
 	v.Check(req.Name != "", "name", "name must be provided")
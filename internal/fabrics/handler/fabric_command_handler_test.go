@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/salesworks/s-works/api/internal/fabrics/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockFabricCommandService struct {
@@ -17,20 +19,38 @@ type mockFabricCommandService struct {
 	UpdateFabricCalled bool
 	DeleteFabricCalled bool
 	GetByCodeCalled    bool
+	SyncFabricCalled   bool
+	createdCode        string
+	createdName        string
+	createdSpec        domain.Specification
+	createdTexts       domain.FabricTexts
+	createdDraft       bool
+	updatedSpec        *domain.Specification
+	updatedTexts       domain.FabricTexts
 	errToReturn        error
 }
 
 func (m *mockFabricCommandService) CreateFabric(
-	ctx context.Context, code, name, measureUnit, offerStatus string,
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification, texts domain.FabricTexts,
 ) (*domain.Fabric, error) {
 	m.CreateFabricCalled = true
+	m.createdCode, m.createdName, m.createdSpec, m.createdTexts = code, name, spec, texts
 	return &domain.Fabric{Code: code}, m.errToReturn
 }
 
+func (m *mockFabricCommandService) CreateDraftFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification, texts domain.FabricTexts,
+) (*domain.Fabric, error) {
+	m.createdDraft = true
+	return m.CreateFabric(ctx, code, name, measureUnit, offerStatus, spec, texts)
+}
+
 func (m *mockFabricCommandService) UpdateFabric(
-	ctx context.Context, code, name, measureUnit, offerStatus string, version int,
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification,
+	texts domain.FabricTexts, version int,
 ) (*domain.Fabric, error) {
 	m.UpdateFabricCalled = true
+	m.updatedSpec, m.updatedTexts = spec, texts
 	if m.errToReturn != nil {
 		return nil, m.errToReturn
 	}
@@ -50,6 +70,16 @@ func (m *mockFabricCommandService) GetByCode(ctx context.Context, code string) (
 	return &domain.Fabric{Code: code}, nil
 }
 
+func (m *mockFabricCommandService) SyncFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string,
+) (*domain.Fabric, bool, error) {
+	m.SyncFabricCalled = true
+	if m.errToReturn != nil {
+		return nil, false, m.errToReturn
+	}
+	return &domain.Fabric{Code: code, Name: name}, true, nil
+}
+
 func TestFabricCommandHandler_CreateFabric_HappyPath(t *testing.T) {
 	// --- Arrange ---
 	mockSvc := &mockFabricCommandService{}
@@ -68,6 +98,119 @@ func TestFabricCommandHandler_CreateFabric_HappyPath(t *testing.T) {
 	assert.Equal(t, http.StatusAccepted, responseRecorder.Code, "expected HTTP status 202 Accepted")
 }
 
+func TestFabricCommandHandler_CreateFabric_Draft(t *testing.T) {
+	// --- Arrange ---
+	mockSvc := &mockFabricCommandService{}
+	handler := NewFabricCommandHandler(mockSvc)
+
+	requestBody := `{"code": "TEST01", "name": "Test Name", "measure_unit": "mb", "offer_status": "new", "draft": true}`
+	request, err := http.NewRequest(http.MethodPost, "/v1/fabrics", strings.NewReader(requestBody))
+	assert.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, request)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+	assert.True(t, mockSvc.createdDraft, "expected CreateDraftFabric to be called on the service")
+}
+
+func TestFabricCommandHandler_CreateFabric_NormalizesInput(t *testing.T) {
+	// --- Arrange ---
+	mockSvc := &mockFabricCommandService{}
+	handler := NewFabricCommandHandler(mockSvc)
+
+	requestBody := `{"code": " test01 ", "name": "  Zoya   Velvet ", "measure_unit": "mb", "offer_status": "new"}`
+	request, err := http.NewRequest(http.MethodPost, "/v1/fabrics", strings.NewReader(requestBody))
+	assert.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, request)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+	assert.Equal(t, "TEST01", mockSvc.createdCode)
+	assert.Equal(t, "Zoya Velvet", mockSvc.createdName)
+}
+
+func TestFabricCommandHandler_CreateFabric_Specification(t *testing.T) {
+	// --- Arrange ---
+	mockSvc := &mockFabricCommandService{}
+	handler := NewFabricCommandHandler(mockSvc)
+
+	requestBody := `{"code": "TEST01", "name": "Test Name", "measure_unit": "mb", "offer_status": "new",
+		"specification": {"composition": [{"fibre": " Cotton ", "percent": 95}, {"fibre": "elastane", "percent": 5}],
+		"width_cm": 145, "weight_gsm": 280, "color": " navy ", "min_order_quantity": 50, "lead_time_days": 14}}`
+	request, err := http.NewRequest(http.MethodPost, "/v1/fabrics", strings.NewReader(requestBody))
+	assert.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, request)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+	expected := domain.Specification{
+		Composition: []domain.CompositionPart{{Fibre: "cotton", Percent: 95}, {Fibre: "elastane", Percent: 5}},
+		WidthCM:     145, WeightGSM: 280, Color: "NAVY", MinOrderQuantity: 50, LeadTimeDays: 14,
+	}
+	assert.Equal(t, expected, mockSvc.createdSpec)
+}
+
+func TestFabricCommandHandler_UpdateFabric_WithoutSpecification(t *testing.T) {
+	// --- Arrange ---
+	mockSvc := &mockFabricCommandService{}
+	handler := NewFabricCommandHandler(mockSvc)
+
+	requestBody := `{"name": "Test Name", "measure_unit": "mb", "offer_status": "new", "version": 1}`
+	request, err := http.NewRequest(http.MethodPut, "/v1/fabrics/TEST01", strings.NewReader(requestBody))
+	assert.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, request)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.True(t, mockSvc.UpdateFabricCalled)
+	assert.Nil(t, mockSvc.updatedSpec, "an update without a specification should keep the stored one")
+	assert.Equal(t, domain.FabricTexts{}, mockSvc.updatedTexts, "an update without texts should keep the stored ones")
+}
+
+func TestFabricCommandHandler_Texts(t *testing.T) {
+	// --- Arrange ---
+	mockSvc := &mockFabricCommandService{}
+	handler := NewFabricCommandHandler(mockSvc)
+
+	createBody := `{"code": "TEST01", "name": "Test Name", "measure_unit": "mb", "offer_status": "new",
+		"description": "Soft **linen**\n\nfor summer", "notes": "ask the mill about dye lots"}`
+	create, err := http.NewRequest(http.MethodPost, "/v1/fabrics", strings.NewReader(createBody))
+	require.NoError(t, err)
+	updateBody := `{"name": "Test Name", "measure_unit": "mb", "offer_status": "new", "version": 1, "notes": ""}`
+	update, err := http.NewRequest(http.MethodPut, "/v1/fabrics/TEST01", strings.NewReader(updateBody))
+	require.NoError(t, err)
+
+	// --- Act ---
+	createRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(createRecorder, create)
+	updateRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(updateRecorder, update)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusAccepted, createRecorder.Code)
+	require.NotNil(t, mockSvc.createdTexts.Description)
+	assert.Equal(t, "Soft **linen**\n\nfor summer", *mockSvc.createdTexts.Description, "markdown is passed on as written")
+	require.NotNil(t, mockSvc.createdTexts.Notes)
+	assert.Equal(t, "ask the mill about dye lots", *mockSvc.createdTexts.Notes)
+
+	require.Equal(t, http.StatusOK, updateRecorder.Code)
+	assert.Nil(t, mockSvc.updatedTexts.Description, "a description left out is kept")
+	require.NotNil(t, mockSvc.updatedTexts.Notes)
+	assert.Equal(t, "", *mockSvc.updatedTexts.Notes, "empty notes clear the stored ones")
+}
+
 func TestFabricCommandHandler_CreateFabric_ValidationErrors(t *testing.T) {
 	testCases := []struct {
 		name                 string
@@ -111,12 +254,24 @@ func TestFabricCommandHandler_CreateFabric_ValidationErrors(t *testing.T) {
 			expectedStatusCode:   http.StatusUnprocessableEntity, // 422
 			expectedErrorSnippet: "code must be between 2 and 30 characters long",
 		},
+		{
+			name:                 "Code is reserved for the probe",
+			body:                 `{"code": "ZZPROBE", "name": "Test Fabric"}`,
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedErrorSnippet: "code is reserved for the synthetic probe",
+		},
 		{
 			name:                 "Name is empty",
 			body:                 `{"code": "TEST01", "name": ""}`,
 			expectedStatusCode:   http.StatusUnprocessableEntity,
 			expectedErrorSnippet: "name must be provided",
 		},
+		{
+			name:                 "Name is only whitespace",
+			body:                 `{"code": "TEST01", "name": "   "}`,
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedErrorSnippet: "name must be provided",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -336,3 +491,62 @@ func TestFabricCommandHandler_DeleteFabric_ConcurrencyConflict(t *testing.T) {
 	assert.True(t, mockSvc.DeleteFabricCalled, "expected DeleteFabric to be called")
 	assert.Equal(t, http.StatusConflict, responseRecorder.Code, "expected HTTP status 409 Conflict")
 }
+
+func TestFabricCommandHandler_CreateFabric_ReturnsWarnings(t *testing.T) {
+	// --- Arrange ---
+	mockSvc := &mockFabricCommandService{}
+	handler := NewFabricCommandHandler(mockSvc)
+
+	requestBody := `{"code": "TEST01", "name": "Test Name", "measure_unit": "mb", "offer_status": "someday"}`
+	request, err := http.NewRequest(http.MethodPost, "/v1/fabrics", strings.NewReader(requestBody))
+	assert.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, request)
+
+	// --- Assert ---
+	assert.True(t, mockSvc.CreateFabricCalled, "warnings must not block the command")
+	assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+
+	var response struct {
+		Warnings map[string]string `json:"warnings"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Contains(t, response.Warnings, "offer_status")
+}
+
+func TestFabricCommandHandler_CreateFabric_InvalidAttributes(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		expectedField string
+	}{
+		{name: "Unknown measure unit", err: domain.ErrInvalidMeasureUnit, expectedField: "measure_unit"},
+		{name: "Unknown offer status", err: domain.ErrInvalidOfferStatus, expectedField: "offer_status"},
+		{name: "Unknown fibre", err: domain.ErrUnknownFibre.WithParam("fibre", "kevlar"), expectedField: "composition"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewFabricCommandHandler(&mockFabricCommandService{errToReturn: tc.err})
+
+			requestBody := `{"code": "TEST01", "name": "Test Name", "measure_unit": "yard", "offer_status": "new"}`
+			request, err := http.NewRequest(http.MethodPost, "/v1/fabrics", strings.NewReader(requestBody))
+			require.NoError(t, err)
+
+			// --- Act ---
+			responseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(responseRecorder, request)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+			var response struct {
+				Error map[string]string `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+			assert.Contains(t, response.Error, tc.expectedField)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	conflictActionApply   = "apply"
+	conflictActionDiscard = "discard"
+)
+
+// FabricConflictHandler exposes ERP conflicts queued for review and lets a user apply
+// or discard them.
+type FabricConflictHandler struct {
+	conflicts  domain.FabricConflictRepository
+	service    FabricCommandService
+	clock      clock.Clock
+	pagination httpx.PaginationConfig
+}
+
+type resolveConflictRequest struct {
+	Action string `json:"action"`
+}
+
+func NewFabricConflictHandler(
+	conflicts domain.FabricConflictRepository,
+	service FabricCommandService,
+	clock clock.Clock,
+	pagination httpx.PaginationConfig,
+) *FabricConflictHandler {
+	return &FabricConflictHandler{
+		conflicts:  conflicts,
+		service:    service,
+		clock:      clock,
+		pagination: pagination,
+	}
+}
+
+func (h *FabricConflictHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listConflicts(w, r)
+	case http.MethodPost:
+		h.resolveConflict(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricConflictHandler) listConflicts(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	conflicts, totalRecords, err := h.conflicts.ListPendingConflicts(r.Context(), page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"conflicts": conflicts, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricConflictHandler) resolveConflict(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	id, err := strconv.ParseInt(httpx.URLParam(r, "id"), 10, 64)
+	if err != nil || id < 1 {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	var req resolveConflictRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(
+		validator.PermittedValue(req.Action, conflictActionApply, conflictActionDiscard),
+		"action", "action must be apply or discard",
+	)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	conflict, err := h.conflicts.GetConflict(ctx, id)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	status := domain.ConflictStatusDiscarded
+	if req.Action == conflictActionApply {
+		status = domain.ConflictStatusApplied
+	}
+	if err := conflict.Resolve(status, domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()}); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if req.Action == conflictActionApply {
+		if err := forceApplyERPChange(ctx, h.service, conflict.EventType, conflictEvent(conflict)); err != nil {
+			switch {
+			case errors.Is(err, domain.ErrRecordNotFound):
+				httpx.ErrorJSON(w, http.StatusConflict, "the fabric no longer exists, discard the conflict instead")
+			default:
+				writeDomainError(w, r, err)
+			}
+			return
+		}
+	}
+
+	if err := h.conflicts.ResolveConflict(ctx, conflict); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"conflict": conflict}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
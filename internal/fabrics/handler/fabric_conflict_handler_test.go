@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveResolveConflict(t *testing.T, handler *FabricConflictHandler, id, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/erp/conflicts/"+id+"/resolve", strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func pendingConflict() *domain.FabricConflict {
+	return &domain.FabricConflict{
+		ID:              7,
+		EventType:       erpFabricUpdated,
+		Code:            "ERP01",
+		Name:            "ERP Fabric",
+		ExpectedVersion: 2,
+		CurrentVersion:  5,
+		Status:          domain.ConflictStatusPending,
+	}
+}
+
+func TestFabricConflictHandler_Apply(t *testing.T) {
+	// --- Arrange ---
+	svc := &conflictingFabricService{storedVersion: 5}
+	conflicts := &mockFabricConflictRepository{toReturn: pendingConflict()}
+	handler := NewFabricConflictHandler(conflicts, svc, testClock, testPaginationConfig)
+
+	// --- Act ---
+	responseRecorder := serveResolveConflict(t, handler, "7", `{"action": "apply"}`)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, []int{5}, svc.updateVersions, "the queued change should be applied over the stored version")
+	require.Len(t, conflicts.resolved, 1)
+	assert.Equal(t, domain.ConflictStatusApplied, conflicts.resolved[0].Status)
+	assert.Equal(t, "anonymous", conflicts.resolved[0].ResolvedBy)
+}
+
+func TestFabricConflictHandler_Discard(t *testing.T) {
+	// --- Arrange ---
+	svc := &conflictingFabricService{storedVersion: 5}
+	conflicts := &mockFabricConflictRepository{toReturn: pendingConflict()}
+	handler := NewFabricConflictHandler(conflicts, svc, testClock, testPaginationConfig)
+
+	// --- Act ---
+	responseRecorder := serveResolveConflict(t, handler, "7", `{"action": "discard"}`)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Empty(t, svc.updateVersions)
+	require.Len(t, conflicts.resolved, 1)
+	assert.Equal(t, domain.ConflictStatusDiscarded, conflicts.resolved[0].Status)
+}
+
+func TestFabricConflictHandler_ResolveErrors(t *testing.T) {
+	resolved := pendingConflict()
+	resolved.Status = domain.ConflictStatusApplied
+
+	testCases := []struct {
+		name               string
+		conflict           *domain.FabricConflict
+		id                 string
+		body               string
+		expectedStatusCode int
+	}{
+		{name: "Unknown action", conflict: pendingConflict(), id: "7", body: `{"action": "merge"}`, expectedStatusCode: http.StatusUnprocessableEntity},
+		{name: "Unknown conflict", conflict: pendingConflict(), id: "8", body: `{"action": "apply"}`, expectedStatusCode: http.StatusNotFound},
+		{name: "Invalid id", conflict: pendingConflict(), id: "abc", body: `{"action": "apply"}`, expectedStatusCode: http.StatusNotFound},
+		{name: "Already resolved", conflict: resolved, id: "7", body: `{"action": "apply"}`, expectedStatusCode: http.StatusConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &conflictingFabricService{storedVersion: 5}
+			conflicts := &mockFabricConflictRepository{toReturn: tc.conflict}
+			handler := NewFabricConflictHandler(conflicts, svc, testClock, testPaginationConfig)
+
+			// --- Act ---
+			responseRecorder := serveResolveConflict(t, handler, tc.id, tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+			assert.Empty(t, svc.updateVersions)
+			assert.Empty(t, conflicts.resolved)
+		})
+	}
+}
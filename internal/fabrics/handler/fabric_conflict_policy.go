@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+)
+
+const (
+	erpFabricCreated = "erp.fabric.created"
+	erpFabricUpdated = "erp.fabric.updated"
+	erpFabricDeleted = "erp.fabric.deleted"
+)
+
+// ConflictStrategy decides what happens to an ERP change whose version does not match
+// the stored fabric.
+type ConflictStrategy string
+
+const (
+	// ConflictReject drops the change, the behaviour before strategies were configurable
+	ConflictReject ConflictStrategy = "reject"
+	// ConflictLastWriteWins applies the change on top of the stored version
+	ConflictLastWriteWins ConflictStrategy = "last_write_wins"
+	// ConflictQueueForReview parks the change until it is applied or discarded via the API
+	ConflictQueueForReview ConflictStrategy = "queue_for_review"
+)
+
+// ConflictPolicy maps ERP event types to the strategy used on a version conflict.
+// Event types without an entry are rejected.
+type ConflictPolicy map[string]ConflictStrategy
+
+func (p ConflictPolicy) For(eventType string) ConflictStrategy {
+	if strategy, ok := p[eventType]; ok {
+		return strategy
+	}
+	return ConflictReject
+}
+
+// ParseConflictPolicy reads a policy in the form
+// "erp.fabric.updated=last_write_wins,erp.fabric.deleted=queue_for_review".
+func ParseConflictPolicy(raw string) (ConflictPolicy, error) {
+	policy := ConflictPolicy{}
+	if strings.TrimSpace(raw) == "" {
+		return policy, nil
+	}
+
+	for _, entry := range strings.Split(raw, ",") {
+		eventType, strategy, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid conflict policy entry %q, expected <event type>=<strategy>", entry)
+		}
+		switch eventType {
+		case erpFabricUpdated, erpFabricDeleted:
+		default:
+			return nil, fmt.Errorf("conflict policy is not supported for event type %q", eventType)
+		}
+		switch s := ConflictStrategy(strategy); s {
+		case ConflictReject, ConflictLastWriteWins, ConflictQueueForReview:
+			policy[eventType] = s
+		default:
+			return nil, fmt.Errorf("unknown conflict strategy %q for %s", strategy, eventType)
+		}
+	}
+
+	return policy, nil
+}
+
+// applies an ERP change on top of whatever version of the fabric is currently stored
+func forceApplyERPChange(
+	ctx context.Context, service FabricCommandService, eventType string, event erpFabricEvent,
+) error {
+	current, err := service.GetByCode(ctx, event.Code)
+	if err != nil {
+		return err
+	}
+
+	switch eventType {
+	case erpFabricUpdated:
+		_, err = service.UpdateFabric(
+			ctx, event.Code, event.Name, event.MeasureUnit, event.OfferStatus, nil, domain.FabricTexts{}, current.Version,
+		)
+	case erpFabricDeleted:
+		err = service.DeleteFabric(ctx, event.Code, current.Version)
+	default:
+		err = fmt.Errorf("cannot apply event type %q", eventType)
+	}
+	return err
+}
+
+// rebuilds the ERP change recorded by a queued conflict
+func conflictEvent(conflict *domain.FabricConflict) erpFabricEvent {
+	return erpFabricEvent{
+		Code:        conflict.Code,
+		Name:        conflict.Name,
+		MeasureUnit: conflict.MeasureUnit,
+		OfferStatus: conflict.OfferStatus,
+	}
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricCustomAttributeService sets the values of the custom attributes of fabrics.
+type FabricCustomAttributeService interface {
+	SetCustomAttributes(
+		ctx context.Context, code string, values map[string]any, definitions []*domain.AttributeDefinition,
+		version int,
+	) (*domain.Fabric, error)
+}
+
+// FabricCustomAttributeHandler replaces the custom attributes of a fabric, checked against
+// the attributes registered at the time of the request.
+type FabricCustomAttributeHandler struct {
+	definitions domain.AttributeDefinitionRepository
+	service     FabricCustomAttributeService
+}
+
+type setCustomAttributesRequest struct {
+	CustomAttributes map[string]any `json:"custom_attributes"`
+	Version          int            `json:"version"`
+}
+
+func NewFabricCustomAttributeHandler(
+	definitions domain.AttributeDefinitionRepository, service FabricCustomAttributeService,
+) *FabricCustomAttributeHandler {
+	return &FabricCustomAttributeHandler{
+		definitions: definitions,
+		service:     service,
+	}
+}
+
+func (h *FabricCustomAttributeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req setCustomAttributesRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	definitions, err := h.definitions.ListDefinitions(ctx)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	fabric, err := h.service.SetCustomAttributes(
+		ctx, httpx.URLParam(r, "code"), req.CustomAttributes, definitions, req.Version,
+	)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabric": fabric}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
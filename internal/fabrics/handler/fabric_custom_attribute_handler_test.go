@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFabricCustomAttributeService sets the custom attributes of a single stored fabric
+type mockFabricCustomAttributeService struct {
+	fabric *domain.Fabric
+}
+
+func (m *mockFabricCustomAttributeService) SetCustomAttributes(
+	ctx context.Context, code string, values map[string]any, definitions []*domain.AttributeDefinition, version int,
+) (*domain.Fabric, error) {
+	if err := m.fabric.SetCustomAttributes(values, definitions, version, domain.Stamp{At: testClock.Now()}); err != nil {
+		return nil, err
+	}
+	return m.fabric, nil
+}
+
+func TestFabricCustomAttributeHandler_SetCustomAttributes(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedValues map[string]any
+	}{
+		{
+			name:           "Registered attributes",
+			body:           `{"custom_attributes": {"gots_certified": true, "mill_batch": "B-17", "shrinkage_pct": 2.5}, "version": 1}`,
+			expectedStatus: http.StatusOK,
+			expectedValues: map[string]any{"gots_certified": true, "mill_batch": "B-17", "shrinkage_pct": 2.5},
+		},
+		{
+			name:           "Unknown attribute",
+			body:           `{"custom_attributes": {"gots_certified": true, "colour_fastness": 4}, "version": 1}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Value of the wrong type",
+			body:           `{"custom_attributes": {"gots_certified": "yes"}, "version": 1}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Required attribute missing",
+			body:           `{"custom_attributes": {"mill_batch": "B-17"}, "version": 1}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Stale version",
+			body:           `{"custom_attributes": {"gots_certified": false}, "version": 3}`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "No version",
+			body:           `{"custom_attributes": {"gots_certified": false}}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			definitions := &mockAttributeDefinitionRepository{definitions: []*domain.AttributeDefinition{
+				{Key: "gots_certified", Type: domain.AttributeTypeBoolean, Required: true},
+				{Key: "mill_batch", Type: domain.AttributeTypeString},
+				{Key: "shrinkage_pct", Type: domain.AttributeTypeNumber},
+			}}
+			service := &mockFabricCustomAttributeService{
+				fabric: &domain.Fabric{Code: "FAB01", Status: domain.StatusActive, Version: 1},
+			}
+			handler := NewFabricCustomAttributeHandler(definitions, service)
+
+			req, err := http.NewRequest(
+				http.MethodPut, "/v1/fabrics/FAB01/custom-attributes", strings.NewReader(tc.body),
+			)
+			require.NoError(t, err)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("code", "FAB01")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			// --- Act ---
+			responseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedValues, service.fabric.CustomAttributes)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	draftActionApply   = "apply"
+	draftActionDiscard = "discard"
+)
+
+// FabricDraftHandler keeps prepared fabric changes aside for review and applies or
+// discards them. Nothing is published before a draft is applied.
+type FabricDraftHandler struct {
+	drafts  domain.FabricDraftRepository
+	service FabricCommandService
+	clock   clock.Clock
+}
+
+type createFabricDraftRequest struct {
+	Name        string `json:"name"`
+	MeasureUnit string `json:"measure_unit"`
+	OfferStatus string `json:"offer_status"`
+}
+
+func NewFabricDraftHandler(
+	drafts domain.FabricDraftRepository, service FabricCommandService, clock clock.Clock,
+) *FabricDraftHandler {
+	return &FabricDraftHandler{
+		drafts:  drafts,
+		service: service,
+		clock:   clock,
+	}
+}
+
+func (h *FabricDraftHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet:
+		h.listDrafts(w, r)
+	case r.Method == http.MethodPost && httpx.URLParam(r, "action") == "":
+		h.createDraft(w, r)
+	case r.Method == http.MethodPost:
+		h.resolveDraft(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricDraftHandler) listDrafts(w http.ResponseWriter, r *http.Request) {
+	drafts, err := h.drafts.ListPendingDrafts(r.Context(), httpx.URLParam(r, "code"))
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"drafts": drafts}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricDraftHandler) createDraft(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req createFabricDraftRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Name = validator.NormalizeText(req.Name)
+	req.MeasureUnit = validator.NormalizeCode(req.MeasureUnit)
+	req.OfferStatus = validator.NormalizeCode(req.OfferStatus)
+	v := validator.New()
+	normalizeFabricAttributes(v, &req.MeasureUnit, &req.OfferStatus)
+
+	fabric, err := h.service.GetByCode(ctx, httpx.URLParam(r, "code"))
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	draft, err := domain.NewFabricDraft(
+		fabric, req.Name, req.MeasureUnit, req.OfferStatus, domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()},
+	)
+	if err == nil {
+		err = h.drafts.SaveDraft(ctx, draft)
+	}
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"draft": draft}
+	if v.HasWarnings() {
+		env["warnings"] = v.Warnings
+	}
+	if err := httpx.WriteJSON(w, http.StatusCreated, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricDraftHandler) resolveDraft(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	action := httpx.URLParam(r, "action")
+	id, err := strconv.ParseInt(httpx.URLParam(r, "id"), 10, 64)
+	if err != nil || id < 1 || !validator.PermittedValue(action, draftActionApply, draftActionDiscard) {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	draft, err := h.drafts.GetDraft(ctx, id)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+	if draft.Code != httpx.URLParam(r, "code") {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	status := domain.DraftStatusDiscarded
+	if action == draftActionApply {
+		status = domain.DraftStatusApplied
+	}
+	if err := draft.Resolve(status, domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()}); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"draft": draft}
+	if action == draftActionApply {
+		// applied against the version the draft was prepared on, so changes made to the
+		// fabric in the meantime are not silently overwritten
+		fabric, err := h.service.UpdateFabric(
+			ctx, draft.Code, draft.Name, string(draft.MeasureUnit), string(draft.OfferStatus), nil, domain.FabricTexts{},
+			draft.BaseVersion,
+		)
+		if err != nil {
+			writeDomainError(w, r, err)
+			return
+		}
+		env["fabric"] = fabric
+	}
+
+	if err := h.drafts.ResolveDraft(ctx, draft); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricDraftRepository struct {
+	saved    []*domain.FabricDraft
+	resolved []*domain.FabricDraft
+	toReturn *domain.FabricDraft
+}
+
+func (m *mockFabricDraftRepository) SaveDraft(ctx context.Context, draft *domain.FabricDraft) error {
+	draft.ID = int64(len(m.saved) + 1)
+	m.saved = append(m.saved, draft)
+	return nil
+}
+
+func (m *mockFabricDraftRepository) GetDraft(ctx context.Context, id int64) (*domain.FabricDraft, error) {
+	if m.toReturn == nil || m.toReturn.ID != id {
+		return nil, domain.ErrRecordNotFound
+	}
+	return m.toReturn, nil
+}
+
+func (m *mockFabricDraftRepository) ListPendingDrafts(ctx context.Context, code string) ([]*domain.FabricDraft, error) {
+	return m.saved, nil
+}
+
+func (m *mockFabricDraftRepository) ResolveDraft(ctx context.Context, draft *domain.FabricDraft) error {
+	m.resolved = append(m.resolved, draft)
+	return nil
+}
+
+func serveFabricDraft(
+	t *testing.T, handler *FabricDraftHandler, params map[string]string, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/"+params["code"]+"/drafts", strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func pendingDraft() *domain.FabricDraft {
+	return &domain.FabricDraft{
+		ID:          3,
+		Code:        "FAB01",
+		Name:        "Reworked Linen",
+		MeasureUnit: domain.MeasureUnitRunningMetre,
+		OfferStatus: domain.OfferStatusActive,
+		BaseVersion: 4,
+		Status:      domain.DraftStatusPending,
+	}
+}
+
+func TestFabricDraftHandler_CreateDraft(t *testing.T) {
+	// --- Arrange ---
+	svc := &conflictingFabricService{stored: domain.Fabric{Status: domain.StatusActive}, storedVersion: 4}
+	drafts := &mockFabricDraftRepository{}
+	handler := NewFabricDraftHandler(drafts, svc, testClock)
+
+	// --- Act ---
+	responseRecorder := serveFabricDraft(t, handler, map[string]string{"code": "FAB01"}, `{"name": "Reworked Linen"}`)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusCreated, responseRecorder.Code)
+	require.Len(t, drafts.saved, 1)
+	assert.Equal(t, 4, drafts.saved[0].BaseVersion)
+	assert.Equal(t, domain.DraftStatusPending, drafts.saved[0].Status)
+	assert.Empty(t, svc.updateVersions, "a draft does not change the fabric")
+
+	var body struct {
+		Warnings map[string]string `json:"warnings"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	assert.Contains(t, body.Warnings, "measure_unit")
+}
+
+func TestFabricDraftHandler_ResolveDraft(t *testing.T) {
+	testCases := []struct {
+		name             string
+		action           string
+		storedVersion    int
+		expectedStatus   int
+		expectedUpdates  []int
+		expectedResolved string
+	}{
+		{
+			name: "apply", action: "apply", storedVersion: 4,
+			expectedStatus: http.StatusOK, expectedUpdates: []int{4}, expectedResolved: domain.DraftStatusApplied,
+		},
+		{
+			name: "discard", action: "discard", storedVersion: 4,
+			expectedStatus: http.StatusOK, expectedResolved: domain.DraftStatusDiscarded,
+		},
+		{
+			name: "apply over a newer version", action: "apply", storedVersion: 6,
+			expectedStatus: http.StatusConflict, expectedUpdates: []int{4},
+		},
+		{name: "unknown action", action: "publish", storedVersion: 4, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &conflictingFabricService{storedVersion: tc.storedVersion}
+			drafts := &mockFabricDraftRepository{toReturn: pendingDraft()}
+			handler := NewFabricDraftHandler(drafts, svc, testClock)
+			params := map[string]string{"code": "FAB01", "id": "3", "action": tc.action}
+
+			// --- Act ---
+			responseRecorder := serveFabricDraft(t, handler, params, "")
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedUpdates, svc.updateVersions)
+			if tc.expectedResolved == "" {
+				assert.Empty(t, drafts.resolved, "the draft stays pending")
+				return
+			}
+			require.Len(t, drafts.resolved, 1)
+			assert.Equal(t, tc.expectedResolved, drafts.resolved[0].Status)
+		})
+	}
+}
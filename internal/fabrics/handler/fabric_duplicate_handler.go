@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	duplicateActionMerge   = "merge"
+	duplicateActionDismiss = "dismiss"
+)
+
+// DuplicateScanner flags probable duplicate fabrics for review.
+type DuplicateScanner interface {
+	Scan(ctx context.Context) (int, error)
+}
+
+// FabricDuplicateHandler exposes the fabrics flagged as probable duplicates and lets a
+// user merge the duplicate into its canonical fabric or dismiss the finding.
+type FabricDuplicateHandler struct {
+	duplicates domain.FabricDuplicateRepository
+	merges     FabricMergeService
+	clock      clock.Clock
+	pagination httpx.PaginationConfig
+}
+
+type resolveDuplicateRequest struct {
+	Action string `json:"action"`
+	// Version of the duplicate fabric, required to merge it
+	Version int `json:"version"`
+}
+
+func NewFabricDuplicateHandler(
+	duplicates domain.FabricDuplicateRepository,
+	merges FabricMergeService,
+	clock clock.Clock,
+	pagination httpx.PaginationConfig,
+) *FabricDuplicateHandler {
+	return &FabricDuplicateHandler{
+		duplicates: duplicates,
+		merges:     merges,
+		clock:      clock,
+		pagination: pagination,
+	}
+}
+
+func (h *FabricDuplicateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listDuplicates(w, r)
+	case http.MethodPost:
+		h.resolveDuplicate(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricDuplicateHandler) listDuplicates(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	duplicates, totalRecords, err := h.duplicates.ListPendingDuplicates(r.Context(), page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"duplicates": duplicates, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricDuplicateHandler) resolveDuplicate(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	id, err := strconv.ParseInt(httpx.URLParam(r, "id"), 10, 64)
+	if err != nil || id < 1 {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	var req resolveDuplicateRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(
+		validator.PermittedValue(req.Action, duplicateActionMerge, duplicateActionDismiss),
+		"action", "action must be merge or dismiss",
+	)
+	if req.Action == duplicateActionMerge {
+		v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	}
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	duplicate, err := h.duplicates.GetDuplicate(ctx, id)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	status := domain.DuplicateStatusDismissed
+	if req.Action == duplicateActionMerge {
+		status = domain.DuplicateStatusMerged
+	}
+	if err := duplicate.Resolve(status, domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()}); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if req.Action == duplicateActionMerge {
+		if err := h.merges.MergeFabric(ctx, duplicate.Code, duplicate.CanonicalCode, req.Version); err != nil {
+			writeDomainError(w, r, err)
+			return
+		}
+	}
+
+	if err := h.duplicates.ResolveDuplicate(ctx, duplicate); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"duplicate": duplicate}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// FabricDuplicateScanHandler runs a duplicate scan on demand, on top of the periodic one.
+type FabricDuplicateScanHandler struct {
+	scanner DuplicateScanner
+}
+
+func NewFabricDuplicateScanHandler(scanner DuplicateScanner) *FabricDuplicateScanHandler {
+	return &FabricDuplicateScanHandler{scanner: scanner}
+}
+
+func (h *FabricDuplicateScanHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	flagged, err := h.scanner.Scan(r.Context())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"flagged": flagged}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricDuplicateRepository struct {
+	toReturn *domain.FabricDuplicate
+	resolved []*domain.FabricDuplicate
+}
+
+func (m *mockFabricDuplicateRepository) SaveDuplicate(ctx context.Context, duplicate *domain.FabricDuplicate) error {
+	return nil
+}
+
+func (m *mockFabricDuplicateRepository) GetDuplicate(ctx context.Context, id int64) (*domain.FabricDuplicate, error) {
+	if m.toReturn == nil || m.toReturn.ID != id {
+		return nil, domain.ErrRecordNotFound
+	}
+	return m.toReturn, nil
+}
+
+func (m *mockFabricDuplicateRepository) ListPendingDuplicates(
+	ctx context.Context, limit, offset int,
+) ([]*domain.FabricDuplicate, int, error) {
+	return []*domain.FabricDuplicate{m.toReturn}, 1, nil
+}
+
+func (m *mockFabricDuplicateRepository) ResolveDuplicate(ctx context.Context, duplicate *domain.FabricDuplicate) error {
+	m.resolved = append(m.resolved, duplicate)
+	return nil
+}
+
+func pendingDuplicate() *domain.FabricDuplicate {
+	return &domain.FabricDuplicate{
+		ID:            3,
+		Code:          "ZOYA2",
+		CanonicalCode: "ZOYA1",
+		Reason:        domain.DuplicateReasonSimilarName,
+		NameScore:     0.93,
+		Status:        domain.DuplicateStatusPending,
+	}
+}
+
+func serveResolveDuplicate(t *testing.T, handler *FabricDuplicateHandler, id, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/duplicates/"+id+"/resolve", strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricDuplicateHandler_Merge(t *testing.T) {
+	// --- Arrange ---
+	merges := &mockFabricMergeService{}
+	duplicates := &mockFabricDuplicateRepository{toReturn: pendingDuplicate()}
+	handler := NewFabricDuplicateHandler(duplicates, merges, testClock, testPaginationConfig)
+
+	// --- Act ---
+	responseRecorder := serveResolveDuplicate(t, handler, "3", `{"action": "merge", "version": 4}`)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "ZOYA2", merges.code)
+	assert.Equal(t, "ZOYA1", merges.into)
+	assert.Equal(t, 4, merges.version)
+	require.Len(t, duplicates.resolved, 1)
+	assert.Equal(t, domain.DuplicateStatusMerged, duplicates.resolved[0].Status)
+}
+
+func TestFabricDuplicateHandler_Dismiss(t *testing.T) {
+	// --- Arrange ---
+	merges := &mockFabricMergeService{}
+	duplicates := &mockFabricDuplicateRepository{toReturn: pendingDuplicate()}
+	handler := NewFabricDuplicateHandler(duplicates, merges, testClock, testPaginationConfig)
+
+	// --- Act ---
+	responseRecorder := serveResolveDuplicate(t, handler, "3", `{"action": "dismiss"}`)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Empty(t, merges.code, "dismissing should not merge anything")
+	require.Len(t, duplicates.resolved, 1)
+	assert.Equal(t, domain.DuplicateStatusDismissed, duplicates.resolved[0].Status)
+}
+
+func TestFabricDuplicateHandler_Rejected(t *testing.T) {
+	resolved := pendingDuplicate()
+	resolved.Status = domain.DuplicateStatusDismissed
+
+	testCases := []struct {
+		name           string
+		id             string
+		body           string
+		duplicate      *domain.FabricDuplicate
+		mergeErr       error
+		expectedStatus int
+	}{
+		{
+			name: "unknown action", id: "3", body: `{"action": "accept"}`, duplicate: pendingDuplicate(),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "merge without version", id: "3", body: `{"action": "merge"}`, duplicate: pendingDuplicate(),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "unknown duplicate", id: "9", body: `{"action": "dismiss"}`, duplicate: pendingDuplicate(),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "already resolved", id: "3", body: `{"action": "dismiss"}`, duplicate: resolved,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "stale version", id: "3", body: `{"action": "merge", "version": 1}`, duplicate: pendingDuplicate(),
+			mergeErr: domain.ErrConcurrencyConflict, expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			duplicates := &mockFabricDuplicateRepository{toReturn: tc.duplicate}
+			handler := NewFabricDuplicateHandler(
+				duplicates, &mockFabricMergeService{errToReturn: tc.mergeErr}, testClock, testPaginationConfig,
+			)
+
+			// --- Act ---
+			responseRecorder := serveResolveDuplicate(t, handler, tc.id, tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Empty(t, duplicates.resolved)
+		})
+	}
+}
+
+type stubDuplicateScanner struct {
+	flagged int
+}
+
+func (s *stubDuplicateScanner) Scan(ctx context.Context) (int, error) {
+	return s.flagged, nil
+}
+
+func TestFabricDuplicateScanHandler(t *testing.T) {
+	// --- Arrange ---
+	handler := NewFabricDuplicateScanHandler(&stubDuplicateScanner{flagged: 2})
+	req, err := http.NewRequest(http.MethodPost, "/v1/admin/fabrics/duplicates/scan", nil)
+	require.NoError(t, err)
+
+	// --- Act ---
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `{"flagged": 2}`, responseRecorder.Body.String())
+}
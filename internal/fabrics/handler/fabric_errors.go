@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+)
+
+// writeDomainError answers a failed command with the status matching the kind of domain
+// error. Validation errors are reported per field together with the broken rule, conflicts
+// together with their params, such as the current version for a stale command. Anything
+// that is not a domain error is answered as an internal error.
+func writeDomainError(w http.ResponseWriter, r *http.Request, err error) {
+	domainErr, ok := domain.AsDomainError(err)
+	if !ok {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch domainErr.Kind {
+	case domain.KindValidation:
+		env := httpx.Envelope{"error": fieldErrors(domainErr), "details": domainErr}
+		if err := httpx.WriteJSON(w, http.StatusUnprocessableEntity, env, nil); err != nil {
+			httpx.InternalError(w, r, err)
+		}
+	case domain.KindNotFound:
+		httpx.NotFound(w, r)
+	case domain.KindConflict:
+		env := httpx.Envelope{"error": domainErr.Message, "details": domainErr}
+		if err := httpx.WriteJSON(w, http.StatusConflict, env, nil); err != nil {
+			httpx.InternalError(w, r, err)
+		}
+	case domain.KindQuota:
+		env := httpx.Envelope{"error": domainErr.Message, "details": domainErr}
+		if err := httpx.WriteJSON(w, http.StatusForbidden, env, nil); err != nil {
+			httpx.InternalError(w, r, err)
+		}
+	default:
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// fieldErrors reports a validation error in the field keyed shape of validator errors
+func fieldErrors(domainErr *domain.DomainError) map[string]string {
+	field := domainErr.Field
+	if field == "" {
+		field = "error"
+	}
+	return map[string]string{field: domainErr.Message}
+}
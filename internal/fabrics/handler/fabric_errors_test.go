@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDomainError_Validation(t *testing.T) {
+	// --- Arrange ---
+	_, parseErr := domain.ParseOfferStatus("someday")
+	err := fmt.Errorf("application service failed to create fabric: %w", parseErr)
+	req := httptest.NewRequest(http.MethodPost, "/v1/fabrics", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	writeDomainError(responseRecorder, req, err)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+	var response struct {
+		Error   map[string]string  `json:"error"`
+		Details domain.DomainError `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Equal(t, domain.ErrInvalidOfferStatus.Message, response.Error["offer_status"])
+	assert.Equal(t, "invalid_offer_status", response.Details.Code)
+	assert.Equal(t, "offer_status", response.Details.Field)
+	assert.Equal(t, "someday", response.Details.Params["value"])
+}
+
+func TestWriteDomainError_Status(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "Not found", err: domain.ErrRecordNotFound, expectedStatus: http.StatusNotFound},
+		{name: "Duplicate code", err: domain.ErrDuplicateFabricCode, expectedStatus: http.StatusConflict},
+		{name: "Concurrency conflict", err: domain.ErrConcurrencyConflict, expectedStatus: http.StatusConflict},
+		{name: "Deleted fabric", err: domain.ErrFabricDeleted, expectedStatus: http.StatusConflict},
+		{name: "Fabric quota", err: domain.ErrFabricQuotaExceeded, expectedStatus: http.StatusForbidden},
+		{name: "File over the whole quota", err: domain.ErrAttachmentOverQuota, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Infrastructure error", err: errors.New("connection reset"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			req := httptest.NewRequest(http.MethodPost, "/v1/fabrics", nil)
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			writeDomainError(responseRecorder, req, fmt.Errorf("wrapped: %w", tc.err))
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
+
+func TestWriteDomainError_Conflict(t *testing.T) {
+	// --- Arrange ---
+	stamp := domain.Stamp{At: testClock.Now()}
+	fabric, err := domain.NewFabric("VELVET01", "Velvet", "m", "available", domain.Specification{}, domain.FabricTexts{}, stamp)
+	require.NoError(t, err)
+	require.NoError(t, fabric.UpdateFabric("Velvet Red", "m", "available", nil, domain.FabricTexts{}, 1, stamp))
+	staleErr := fabric.UpdateFabric("Velvet Blue", "m", "available", nil, domain.FabricTexts{}, 1, stamp)
+	req := httptest.NewRequest(http.MethodPut, "/v1/fabrics/VELVET01", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	writeDomainError(responseRecorder, req, fmt.Errorf("wrapped: %w", staleErr))
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+	var response struct {
+		Error   string `json:"error"`
+		Details struct {
+			Code   string `json:"code"`
+			Params struct {
+				CurrentVersion int `json:"current_version"`
+				Conflicts      []struct {
+					Field     string `json:"field"`
+					Current   any    `json:"current"`
+					Requested any    `json:"requested"`
+				} `json:"conflicts"`
+			} `json:"params"`
+		} `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Equal(t, domain.ErrConcurrencyConflict.Message, response.Error)
+	assert.Equal(t, "concurrency_conflict", response.Details.Code)
+	assert.Equal(t, 2, response.Details.Params.CurrentVersion)
+	require.Len(t, response.Details.Params.Conflicts, 1)
+	assert.Equal(t, "name", response.Details.Params.Conflicts[0].Field)
+	assert.Equal(t, "Velvet Red", response.Details.Params.Conflicts[0].Current)
+	assert.Equal(t, "Velvet Blue", response.Details.Params.Conflicts[0].Requested)
+}
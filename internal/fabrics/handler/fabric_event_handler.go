@@ -4,20 +4,48 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"regexp"
+	"time"
 
 	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
 	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
 	"github.com/salesworks/s-works/api/internal/platform/messaging"
 	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // FabricEventHandler contains the business logic for processing ERP events for fabrics.
 // It implements the subscriber.MessageHandler interface.
 type FabricEventHandler struct {
-	service FabricCommandService
-	logger  *slog.Logger
+	service     FabricCommandService
+	conflicts   domain.FabricConflictRepository
+	proposals   domain.FabricProposalRepository
+	pending     domain.FabricPendingEventRepository
+	deadLetters messaging.Publisher
+	config      ERPEventConfig
+	clock       clock.Clock
+	logger      *slog.Logger
+}
+
+// maximum number of parked events retried per sweep
+const pendingRetryBatchSize = 100
+
+// ERPEventConfig holds the configurable behaviour of the ERP event handler.
+type ERPEventConfig struct {
+	// ConflictPolicy decides what happens to changes that conflict with the stored version
+	ConflictPolicy ConflictPolicy
+	// PendingTimeout is how long an out-of-order event waits for the versions before it
+	PendingTimeout time.Duration
+	// DeadLetterSubject receives events that timed out waiting
+	DeadLetterSubject string
+	// MinConfidence is the confidence below which an ERP update is queued as a proposal for
+	// review instead of being applied, zero applies every update
+	MinConfidence float64
 }
 
 type erpFabricEvent struct {
@@ -25,12 +53,30 @@ type erpFabricEvent struct {
 	Name        string `json:"fabric_name"`
 	MeasureUnit string `json:"measure_unit,omitempty"`
 	OfferStatus string `json:"offer_status,omitempty"`
+	// Confidence the ERP has in data it matched or extracted itself, between 0 and 1.
+	// Data entered in the ERP carries none.
+	Confidence *float64 `json:"confidence,omitempty"`
 }
 
-func NewFabricEventHandler(service FabricCommandService, logger *slog.Logger) *FabricEventHandler {
+func NewFabricEventHandler(
+	service FabricCommandService,
+	conflicts domain.FabricConflictRepository,
+	proposals domain.FabricProposalRepository,
+	pending domain.FabricPendingEventRepository,
+	deadLetters messaging.Publisher,
+	config ERPEventConfig,
+	clock clock.Clock,
+	logger *slog.Logger,
+) *FabricEventHandler {
 	return &FabricEventHandler{
-		service: service,
-		logger:  logger.With("component", "erpEventHandler"),
+		service:     service,
+		conflicts:   conflicts,
+		proposals:   proposals,
+		pending:     pending,
+		deadLetters: deadLetters,
+		config:      config,
+		clock:       clock,
+		logger:      logger.With("component", "erpEventHandler"),
 	}
 }
 
@@ -53,26 +99,56 @@ func (h *FabricEventHandler) HandleMessage(ctx context.Context, subject string,
 	return h.adaptEventToCommand(ctx, envelope)
 }
 
-func (h *FabricEventHandler) adaptEventToCommand(ctx context.Context, envelope messaging.EventEnvelope) error {
-	// Extract payload from envelope
+// extracts the ERP fabric payload from an envelope
+func decodeERPEvent(envelope messaging.EventEnvelope) (erpFabricEvent, error) {
+	var erpEvent erpFabricEvent
+
 	payloadBytes, err := json.Marshal(envelope.Payload)
 	if err != nil {
-		h.logger.Error("Failed to marshal payload", "error", err, "event_id", envelope.EventID)
-		return nil
+		return erpEvent, fmt.Errorf("failed to marshal payload: %w", err)
 	}
-
-	var erpEvent erpFabricEvent
 	if err := json.Unmarshal(payloadBytes, &erpEvent); err != nil {
-		h.logger.Error("Failed to unmarshal payload to erpFabricEvent", "error", err, "event_id", envelope.EventID)
+		return erpEvent, fmt.Errorf("failed to unmarshal payload to erpFabricEvent: %w", err)
+	}
+	erpEvent.normalize()
+	return erpEvent, nil
+}
+
+// violation logs a domain rule violation as the structured error REST clients receive,
+// so rejected ERP events can be queried by rule and field
+func violation(err error) slog.Attr {
+	domainErr, ok := domain.AsDomainError(err)
+	if !ok {
+		return slog.Any("error", err)
+	}
+	return slog.Group("violation",
+		"code", domainErr.Code, "field", domainErr.Field, "message", domainErr.Message, "params", domainErr.Params,
+	)
+}
+
+// normalize cleans up ERP data before validation the same way as API input, so both
+// sources agree on codes and names
+func (e *erpFabricEvent) normalize() {
+	e.Code = validator.NormalizeCode(e.Code)
+	e.Name = validator.NormalizeText(e.Name)
+	e.MeasureUnit = validator.NormalizeCode(e.MeasureUnit)
+	e.OfferStatus = validator.NormalizeCode(e.OfferStatus)
+}
+
+func (h *FabricEventHandler) adaptEventToCommand(ctx context.Context, envelope messaging.EventEnvelope) error {
+	erpEvent, err := decodeERPEvent(envelope)
+	if err != nil {
+		h.logger.Error("Failed to decode ERP event payload", "error", err, "event_id", envelope.EventID)
 		return nil
 	}
+	erpEvent.Code = h.canonicalCode(ctx, erpEvent.Code)
 
 	switch envelope.EventType {
-	case "erp.fabric.created":
+	case erpFabricCreated:
 		return h.handleCreateEvent(ctx, erpEvent, envelope.EventID)
-	case "erp.fabric.updated":
-		return h.handleUpdateEvent(ctx, erpEvent, envelope.EventID, envelope.AggregateVersion)
-	case "erp.fabric.deleted":
+	case erpFabricUpdated:
+		return h.handleUpdateEvent(ctx, erpEvent, envelope)
+	case erpFabricDeleted:
 		return h.handleDeleteEvent(ctx, erpEvent, envelope.EventID, envelope.AggregateVersion)
 	default:
 		h.logger.Warn("Received unknown ERP event, discarding", "type", envelope.EventType)
@@ -85,9 +161,8 @@ func (h *FabricEventHandler) handleCreateEvent(
 ) error {
 
 	ctx = command.WithCommandSource(ctx, command.CommandSourceEvent)
-	event = h.withDefaults(event)
-
 	v := validator.New()
+	event = withDefaults(v, event)
 	validateCreateFabricEvent(v, event)
 	if !v.Valid() {
 		h.logger.Error(
@@ -96,25 +171,25 @@ func (h *FabricEventHandler) handleCreateEvent(
 		)
 		return nil // Don't retry validation errors
 	}
+	h.logWarnings(v, event.Code, eventID)
 
-	_, err := h.service.CreateFabric(
+	fabric, err := h.service.CreateFabric(
 		ctx,
-		event.Code,        // code
-		event.Name,        // name
-		event.MeasureUnit, // measureUnit (default if not provided)
-		event.OfferStatus, // offerStatus (default if not provided)
+		event.Code,             // code
+		event.Name,             // name
+		event.MeasureUnit,      // measureUnit (default if not provided)
+		event.OfferStatus,      // offerStatus (default if not provided)
+		domain.Specification{}, // the ERP does not manage the specification
+		domain.FabricTexts{},   // nor the texts
 	)
 
 	if err != nil {
 		// Handle domain errors the same way as REST handler
 		switch {
 		case errors.Is(err, domain.ErrDuplicateFabricCode):
-			h.logger.Info("Fabric already exists, skipping", "code", event.Code, "event_id", eventID)
-			return nil // Idempotent - don't error on duplicates from events
-		case errors.Is(err, domain.ErrInvalidFabricCodeLength) ||
-			errors.Is(err, domain.ErrInvalidFabricCodePattern) ||
-			errors.Is(err, domain.ErrInvalidFabricNameLength):
-			h.logger.Error("Invalid fabric data from ERP", "error", err, "code", event.Code, "event_id", eventID)
+			return h.handleDuplicateCreate(ctx, event, eventID)
+		case domain.IsValidation(err):
+			h.logger.Error("Invalid fabric data from ERP", violation(err), "code", event.Code, "event_id", eventID)
 			return nil // Don't retry validation errors
 		default:
 			h.logger.Error("Failed to create fabric", "error", err, "code", event.Code, "event_id", eventID)
@@ -123,17 +198,48 @@ func (h *FabricEventHandler) handleCreateEvent(
 	}
 
 	h.logger.Info("Fabric created from event", "code", event.Code, "event_id", eventID)
+	h.applyPending(ctx, fabric.Code, fabric.Version)
+	return nil
+}
+
+// handleDuplicateCreate keeps creates idempotent: a re-sent create with the same data is
+// skipped, one carrying corrected data is applied as an update of the existing fabric.
+func (h *FabricEventHandler) handleDuplicateCreate(ctx context.Context, event erpFabricEvent, eventID string) error {
+	fabric, changed, err := h.service.SyncFabric(ctx, event.Code, event.Name, event.MeasureUnit, event.OfferStatus)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrConcurrencyConflict):
+			h.logger.Warn("Fabric kept changing while applying duplicate create", "code", event.Code, "event_id", eventID)
+			return err
+		case domain.IsValidation(err):
+			h.logger.Error("Invalid fabric data from ERP", violation(err), "code", event.Code, "event_id", eventID)
+			return nil
+		default:
+			h.logger.Error("Failed to update fabric from duplicate create", "error", err, "code", event.Code, "event_id", eventID)
+			return err
+		}
+	}
+
+	if !changed {
+		h.logger.Info("Fabric already exists, skipping", "code", event.Code, "event_id", eventID)
+		return nil // Idempotent - don't error on duplicates from events
+	}
+
+	h.logger.Info(
+		"Fabric already existed with different data, updated from create event",
+		"code", event.Code, "version", fabric.Version, "event_id", eventID,
+	)
 	return nil
 }
 
 func (h *FabricEventHandler) handleUpdateEvent(
-	ctx context.Context, event erpFabricEvent, eventID string, version int,
+	ctx context.Context, event erpFabricEvent, envelope messaging.EventEnvelope,
 ) error {
 	ctx = command.WithCommandSource(ctx, command.CommandSourceEvent)
-
-	event = h.withDefaults(event)
+	eventID, version := envelope.EventID, envelope.AggregateVersion
 
 	v := validator.New()
+	event = withDefaults(v, event)
 	validateUpdateFabricEvent(v, version, event)
 	if !v.Valid() {
 		h.logger.Error(
@@ -142,14 +248,26 @@ func (h *FabricEventHandler) handleUpdateEvent(
 		)
 		return nil // Don't retry validation errors
 	}
+	h.logWarnings(v, event.Code, eventID)
+
+	if h.isLowConfidence(event) {
+		// only an update that follows the stored version is held back, the others are
+		// parked or handled as conflicts below and come back here once they are due
+		current, err := h.service.GetByCode(ctx, event.Code)
+		if err == nil && current.Version == version-1 {
+			return h.propose(ctx, current, event, eventID)
+		}
+	}
 
-	_, err := h.service.UpdateFabric(
+	fabric, err := h.service.UpdateFabric(
 		ctx,
-		event.Code,        // code
-		event.Name,        // name
-		event.MeasureUnit, // measureUnit
-		event.OfferStatus, // offerStatus
-		version-1,         // version sent by the erp system is the next version,
+		event.Code,           // code
+		event.Name,           // name
+		event.MeasureUnit,    // measureUnit
+		event.OfferStatus,    // offerStatus
+		nil,                  // specification, kept as it is
+		domain.FabricTexts{}, // texts, kept as they are
+		version-1,            // version sent by the erp system is the next version,
 		// to keep it consistent with the REST API we need to subtract 1
 	)
 
@@ -157,20 +275,26 @@ func (h *FabricEventHandler) handleUpdateEvent(
 		switch {
 		case errors.Is(err, domain.ErrRecordNotFound):
 			h.logger.Warn(
-				"Fabric not found for update, might need to create first",
+				"Fabric not found for update, parking until it is created",
 				"code", event.Code, "event_id", eventID,
 			)
-			return nil
+			return h.park(ctx, envelope, event.Code)
+		case errors.Is(err, domain.ErrConcurrencyConflict) && h.isAhead(ctx, event.Code, version):
+			h.logger.Warn(
+				"Version gap, parking event until the missing versions arrive",
+				"code", event.Code, "version", version, "event_id", eventID,
+			)
+			return h.park(ctx, envelope, event.Code)
 		case errors.Is(err, domain.ErrConcurrencyConflict):
 			h.logger.Warn(
 				"Version conflict, event might be out of order",
 				"code", event.Code, "version", version, "event_id", eventID,
 			)
-			return nil // Don't retry version conflicts from events
-		case errors.Is(err, domain.ErrInvalidFabricNameLength):
+			return h.handleConflict(ctx, erpFabricUpdated, event, eventID, version-1)
+		case domain.IsValidation(err):
 			h.logger.Error(
 				"Invalid fabric data from ERP",
-				"error", err, "code", event.Code, "event_id", eventID,
+				violation(err), "code", event.Code, "event_id", eventID,
 			)
 			return nil
 		default:
@@ -186,6 +310,7 @@ func (h *FabricEventHandler) handleUpdateEvent(
 		"Fabric updated from event",
 		"code", event.Code, "version", version, "event_id", eventID,
 	)
+	h.applyPending(ctx, fabric.Code, fabric.Version)
 	return nil
 }
 
@@ -209,7 +334,7 @@ func (h *FabricEventHandler) handleDeleteEvent(
 				"Version conflict on delete",
 				"code", event.Code, "version", version, "event_id", eventID,
 			)
-			return nil
+			return h.handleConflict(ctx, erpFabricDeleted, event, eventID, version)
 		default:
 			h.logger.Error(
 				"Failed to delete fabric",
@@ -226,16 +351,290 @@ func (h *FabricEventHandler) handleDeleteEvent(
 	return nil
 }
 
-func (h *FabricEventHandler) withDefaults(event erpFabricEvent) erpFabricEvent {
-	if event.MeasureUnit == "" {
-		event.MeasureUnit = "MB" // or whatever your default is
+// handleConflict resolves a version conflict with the strategy configured for the event type
+func (h *FabricEventHandler) handleConflict(
+	ctx context.Context, eventType string, event erpFabricEvent, eventID string, expectedVersion int,
+) error {
+	strategy := h.config.ConflictPolicy.For(eventType)
+	httpx.ERPConflictCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("event_type", eventType),
+		attribute.String("strategy", string(strategy)),
+	))
+
+	switch strategy {
+	case ConflictLastWriteWins:
+		if err := forceApplyERPChange(ctx, h.service, eventType, event); err != nil {
+			if errors.Is(err, domain.ErrRecordNotFound) {
+				h.logger.Warn("Fabric disappeared before forced apply", "code", event.Code, "event_id", eventID)
+				return nil
+			}
+			h.logger.Error("Failed to force apply ERP change", "error", err, "code", event.Code, "event_id", eventID)
+			return err
+		}
+		h.logger.Info("ERP change applied over conflicting version", "code", event.Code, "event_id", eventID)
+		return nil
+	case ConflictQueueForReview:
+		currentVersion := 0
+		if current, err := h.service.GetByCode(ctx, event.Code); err == nil {
+			currentVersion = current.Version
+		}
+		conflict := &domain.FabricConflict{
+			EventID:         eventID,
+			EventType:       eventType,
+			Code:            event.Code,
+			Name:            event.Name,
+			MeasureUnit:     event.MeasureUnit,
+			OfferStatus:     event.OfferStatus,
+			ExpectedVersion: expectedVersion,
+			CurrentVersion:  currentVersion,
+			Status:          domain.ConflictStatusPending,
+			CreatedAt:       h.clock.Now(),
+		}
+		if err := h.conflicts.SaveConflict(ctx, conflict); err != nil {
+			h.logger.Error("Failed to queue ERP conflict", "error", err, "code", event.Code, "event_id", eventID)
+			return err
+		}
+		h.logger.Info("ERP conflict queued for review", "code", event.Code, "event_id", eventID)
+		return nil
+	default:
+		return nil // Don't retry rejected version conflicts from events
+	}
+}
+
+// isLowConfidence reports whether the ERP is less confident in the data of an event than
+// the configured minimum
+func (h *FabricEventHandler) isLowConfidence(event erpFabricEvent) bool {
+	return event.Confidence != nil && *event.Confidence < h.config.MinConfidence
+}
+
+// propose queues a low-confidence ERP update for review instead of applying it. Later
+// updates of the fabric are parked until it is approved.
+func (h *FabricEventHandler) propose(
+	ctx context.Context, current *domain.Fabric, event erpFabricEvent, eventID string,
+) error {
+	proposal, err := domain.NewFabricProposal(
+		current, domain.ProposalSourceERP, event.Name, event.MeasureUnit, event.OfferStatus,
+		domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()},
+	)
+	if err != nil {
+		if errors.Is(err, domain.ErrProposalWithoutChanges) {
+			h.logger.Info("Low-confidence ERP update changes nothing, skipping", "code", event.Code, "event_id", eventID)
+			return nil
+		}
+		h.logger.Error("Invalid fabric data from ERP", "error", err, "code", event.Code, "event_id", eventID)
+		return nil
+	}
+	proposal.EventID = eventID
+
+	if err := h.proposals.SaveProposal(ctx, proposal); err != nil {
+		h.logger.Error("Failed to queue ERP proposal", "error", err, "code", event.Code, "event_id", eventID)
+		return err
+	}
+	h.logger.Info(
+		"Low-confidence ERP update queued for review",
+		"code", event.Code, "confidence", *event.Confidence, "event_id", eventID,
+	)
+	return nil
+}
+
+// reports whether an ERP version is beyond the one that directly follows the stored fabric
+func (h *FabricEventHandler) isAhead(ctx context.Context, code string, version int) bool {
+	current, err := h.service.GetByCode(ctx, code)
+	if err != nil {
+		return false
+	}
+	return version > current.Version+1
+}
+
+// canonicalCode resolves an alias to the code of the fabric it points to, so that parked
+// events and conflicts are keyed by the canonical fabric. Unknown codes are kept as sent.
+func (h *FabricEventHandler) canonicalCode(ctx context.Context, code string) string {
+	fabric, err := h.service.GetByCode(ctx, code)
+	if err != nil {
+		return code
+	}
+	return fabric.Code
+}
+
+// park stores an out-of-order event until the versions before it have been applied
+func (h *FabricEventHandler) park(ctx context.Context, envelope messaging.EventEnvelope, code string) error {
+	raw, err := json.Marshal(envelope)
+	if err != nil {
+		h.logger.Error("Failed to marshal event for parking", "error", err, "event_id", envelope.EventID)
+		return nil
+	}
+
+	now := h.clock.Now()
+	parked := &domain.PendingFabricEvent{
+		EventID:    envelope.EventID,
+		EventType:  envelope.EventType,
+		Code:       code,
+		Version:    envelope.AggregateVersion,
+		Envelope:   raw,
+		Status:     domain.PendingStatusWaiting,
+		ReceivedAt: now,
+	}
+	// the delivery that got the event parked counts as the first attempt
+	parked.ScheduleRetry(now)
+
+	if err := h.pending.ParkEvent(ctx, parked); err != nil {
+		h.logger.Error("Failed to park out-of-order event", "error", err, "code", code, "event_id", envelope.EventID)
+		return err
+	}
+	return nil
+}
+
+// applyPending applies parked updates that directly follow the stored version, stopping
+// at the next gap. Failures leave the event parked for the retry sweep.
+func (h *FabricEventHandler) applyPending(ctx context.Context, code string, storedVersion int) {
+	for {
+		parked, err := h.pending.NextPendingEvent(ctx, code, storedVersion+1)
+		if err != nil {
+			if !errors.Is(err, domain.ErrRecordNotFound) {
+				h.logger.Error("Failed to look up parked events", "error", err, "code", code)
+			}
+			return
+		}
+
+		event, err := h.decodeParked(parked)
+		if err != nil {
+			h.logger.Error("Failed to decode parked event", "error", err, "event_id", parked.EventID)
+			return
+		}
+
+		fabric, err := h.service.UpdateFabric(
+			ctx, code, event.Name, event.MeasureUnit, event.OfferStatus, nil, domain.FabricTexts{}, parked.Version-1,
+		)
+		if err != nil {
+			h.logger.Error("Failed to apply parked event", "error", err, "code", code, "event_id", parked.EventID)
+			return
+		}
+		if err := h.pending.MarkResolved(ctx, parked.ID, domain.PendingStatusApplied); err != nil {
+			h.logger.Error("Failed to mark parked event applied", "error", err, "event_id", parked.EventID)
+			return
+		}
+
+		h.logger.Info("Parked event applied", "code", code, "version", parked.Version, "event_id", parked.EventID)
+		storedVersion = fabric.Version
 	}
-	if event.OfferStatus == "" {
-		event.OfferStatus = "ACTIVE" // or whatever your default is
+}
+
+// RetryPending re-attempts parked events whose backoff has elapsed. An event that still
+// cannot be applied is rescheduled, one that has become stale goes to the conflict strategy.
+func (h *FabricEventHandler) RetryPending(ctx context.Context) error {
+	ctx = command.WithCommandSource(ctx, command.CommandSourceEvent)
+
+	due, err := h.pending.DueForRetry(ctx, h.clock.Now(), pendingRetryBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list parked events due for retry: %w", err)
+	}
+
+	for _, parked := range due {
+		h.retryParked(ctx, parked)
 	}
+	return nil
+}
+
+func (h *FabricEventHandler) retryParked(ctx context.Context, parked *domain.PendingFabricEvent) {
+	event, err := h.decodeParked(parked)
+	if err != nil {
+		// cannot succeed on a later attempt, leave it for the expiry sweep to dead-letter
+		h.logger.Error("Failed to decode parked event", "error", err, "event_id", parked.EventID)
+		return
+	}
+
+	fabric, err := h.service.UpdateFabric(
+		ctx, parked.Code, event.Name, event.MeasureUnit, event.OfferStatus, nil, domain.FabricTexts{}, parked.Version-1,
+	)
+	switch {
+	case err == nil:
+		if err := h.pending.MarkResolved(ctx, parked.ID, domain.PendingStatusApplied); err != nil {
+			h.logger.Error("Failed to mark parked event applied", "error", err, "event_id", parked.EventID)
+			return
+		}
+		h.logger.Info("Parked event applied on retry", "code", parked.Code, "version", parked.Version, "event_id", parked.EventID)
+		h.applyPending(ctx, fabric.Code, fabric.Version)
+	case errors.Is(err, domain.ErrConcurrencyConflict) && !h.isAhead(ctx, parked.Code, parked.Version):
+		if err := h.handleConflict(ctx, erpFabricUpdated, event, parked.EventID, parked.Version-1); err != nil {
+			h.reschedule(ctx, parked)
+			return
+		}
+		if err := h.pending.MarkResolved(ctx, parked.ID, domain.PendingStatusConflicted); err != nil {
+			h.logger.Error("Failed to mark parked event conflicted", "error", err, "event_id", parked.EventID)
+		}
+	default:
+		h.logger.Info(
+			"Parked event still cannot be applied, rescheduling",
+			"error", err, "code", parked.Code, "attempts", parked.Attempts, "event_id", parked.EventID,
+		)
+		h.reschedule(ctx, parked)
+	}
+}
+
+func (h *FabricEventHandler) reschedule(ctx context.Context, parked *domain.PendingFabricEvent) {
+	parked.ScheduleRetry(h.clock.Now())
+	if err := h.pending.ScheduleRetry(ctx, parked); err != nil {
+		h.logger.Error("Failed to reschedule parked event", "error", err, "event_id", parked.EventID)
+	}
+}
+
+// extracts the ERP change from a parked event, with defaults applied
+func (h *FabricEventHandler) decodeParked(parked *domain.PendingFabricEvent) (erpFabricEvent, error) {
+	var envelope messaging.EventEnvelope
+	if err := json.Unmarshal(parked.Envelope, &envelope); err != nil {
+		return erpFabricEvent{}, fmt.Errorf("failed to unmarshal parked envelope: %w", err)
+	}
+	event, err := decodeERPEvent(envelope)
+	if err != nil {
+		return erpFabricEvent{}, err
+	}
+	// warnings were already logged when the event was first received
+	return withDefaults(validator.New(), event), nil
+}
+
+// ExpirePending dead-letters parked events that waited longer than the configured timeout
+// for the versions before them.
+func (h *FabricEventHandler) ExpirePending(ctx context.Context) error {
+	expired, err := h.pending.ExpirePendingEvents(ctx, h.clock.Now().Add(-h.config.PendingTimeout))
+	if err != nil {
+		return fmt.Errorf("failed to expire parked events: %w", err)
+	}
+
+	for _, parked := range expired {
+		var envelope messaging.EventEnvelope
+		if err := json.Unmarshal(parked.Envelope, &envelope); err != nil {
+			h.logger.Error("Failed to unmarshal expired event", "error", err, "event_id", parked.EventID)
+			continue
+		}
+		if err := h.deadLetters.Publish(ctx, h.config.DeadLetterSubject, &envelope); err != nil {
+			h.logger.Error("Failed to dead-letter expired event", "error", err, "event_id", parked.EventID)
+			continue
+		}
+		httpx.ERPDeadLetterCounter.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("event_type", parked.EventType),
+		))
+		h.logger.Warn(
+			"Parked event expired and was dead-lettered",
+			"code", parked.Code, "version", parked.Version, "event_id", parked.EventID,
+		)
+	}
+	return nil
+}
+
+// withDefaults fills in and corrects the optional attributes of an ERP event, recording
+// every correction as a warning in v
+func withDefaults(v *validator.Validator, event erpFabricEvent) erpFabricEvent {
+	normalizeFabricAttributes(v, &event.MeasureUnit, &event.OfferStatus)
 	return event
 }
 
+// logWarnings reports data accepted from the ERP that needs cleaning at the source
+func (h *FabricEventHandler) logWarnings(v *validator.Validator, code, eventID string) {
+	if v.HasWarnings() {
+		h.logger.Warn("ERP event accepted with warnings", "warnings", v.Warnings, "code", code, "event_id", eventID)
+	}
+}
+
 func validateCreateFabricEvent(v *validator.Validator, event erpFabricEvent) {
 	// --- Fabric Code Validation ---
 	v.Check(event.Code != "", "code", "code must be provided")
@@ -255,4 +654,8 @@ func validateUpdateFabricEvent(v *validator.Validator, version int, event erpFab
 	v.Check(version > 0, "version", "version must be provided and greater than 0")
 	v.Check(event.Name != "", "name", "name must be provided")
 	v.Check(len(event.Name) <= 250, "name", "name must not be more than 250 characters long")
+	v.Check(
+		event.Confidence == nil || (*event.Confidence >= 0 && *event.Confidence <= 1),
+		"confidence", "confidence must be between 0 and 1",
+	)
 }
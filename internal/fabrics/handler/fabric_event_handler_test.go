@@ -0,0 +1,519 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conflictingFabricService reports a version conflict unless an update or delete targets
+// the stored version, and records the version used by every call
+type conflictingFabricService struct {
+	mockFabricCommandService
+	missing        bool
+	aliases        map[string]string
+	stored         domain.Fabric
+	storedVersion  int
+	updateVersions []int
+	updateCodes    []string
+	deleteVersions []int
+}
+
+func (m *conflictingFabricService) UpdateFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification,
+	texts domain.FabricTexts, version int,
+) (*domain.Fabric, error) {
+	m.updateVersions = append(m.updateVersions, version)
+	m.updateCodes = append(m.updateCodes, code)
+	if m.missing {
+		return nil, domain.ErrRecordNotFound
+	}
+	if version != m.storedVersion {
+		return nil, domain.ErrConcurrencyConflict
+	}
+	m.storedVersion++
+	return &domain.Fabric{Code: code, Version: m.storedVersion}, nil
+}
+
+func (m *conflictingFabricService) DeleteFabric(ctx context.Context, code string, version int) error {
+	m.deleteVersions = append(m.deleteVersions, version)
+	if version != m.storedVersion {
+		return domain.ErrConcurrencyConflict
+	}
+	return nil
+}
+
+// SyncFabric skips data the stored fabric already holds and updates it at the stored
+// version otherwise
+func (m *conflictingFabricService) SyncFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string,
+) (*domain.Fabric, bool, error) {
+	current, err := m.GetByCode(ctx, code)
+	if err != nil {
+		return nil, false, err
+	}
+	if current.Name == name &&
+		strings.EqualFold(string(current.MeasureUnit), measureUnit) &&
+		strings.EqualFold(string(current.OfferStatus), offerStatus) {
+		return current, false, nil
+	}
+	fabric, err := m.UpdateFabric(ctx, code, name, measureUnit, offerStatus, nil, domain.FabricTexts{}, current.Version)
+	return fabric, err == nil, err
+}
+
+func (m *conflictingFabricService) GetByCode(ctx context.Context, code string) (*domain.Fabric, error) {
+	if m.missing {
+		return nil, domain.ErrRecordNotFound
+	}
+	if canonical, ok := m.aliases[code]; ok {
+		code = canonical
+	}
+	stored := m.stored
+	stored.Code, stored.Version = code, m.storedVersion
+	return &stored, nil
+}
+
+type mockFabricConflictRepository struct {
+	saved    []*domain.FabricConflict
+	resolved []*domain.FabricConflict
+	toReturn *domain.FabricConflict
+}
+
+func (m *mockFabricConflictRepository) SaveConflict(ctx context.Context, conflict *domain.FabricConflict) error {
+	m.saved = append(m.saved, conflict)
+	return nil
+}
+
+func (m *mockFabricConflictRepository) GetConflict(ctx context.Context, id int64) (*domain.FabricConflict, error) {
+	if m.toReturn == nil || m.toReturn.ID != id {
+		return nil, domain.ErrRecordNotFound
+	}
+	return m.toReturn, nil
+}
+
+func (m *mockFabricConflictRepository) ListPendingConflicts(
+	ctx context.Context, limit, offset int,
+) ([]*domain.FabricConflict, int, error) {
+	return m.saved, len(m.saved), nil
+}
+
+func (m *mockFabricConflictRepository) ResolveConflict(ctx context.Context, conflict *domain.FabricConflict) error {
+	m.resolved = append(m.resolved, conflict)
+	return nil
+}
+
+// mockFabricPendingEventRepository keeps parked events in memory
+type mockFabricPendingEventRepository struct {
+	events []*domain.PendingFabricEvent
+}
+
+func (m *mockFabricPendingEventRepository) ParkEvent(ctx context.Context, event *domain.PendingFabricEvent) error {
+	event.ID = int64(len(m.events) + 1)
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockFabricPendingEventRepository) NextPendingEvent(
+	ctx context.Context, code string, version int,
+) (*domain.PendingFabricEvent, error) {
+	for _, event := range m.events {
+		if event.Code == code && event.Version == version && event.Status == domain.PendingStatusWaiting {
+			return event, nil
+		}
+	}
+	return nil, domain.ErrRecordNotFound
+}
+
+func (m *mockFabricPendingEventRepository) DueForRetry(
+	ctx context.Context, now time.Time, limit int,
+) ([]*domain.PendingFabricEvent, error) {
+	due := []*domain.PendingFabricEvent{}
+	for _, event := range m.events {
+		if event.Status == domain.PendingStatusWaiting && !event.NextAttemptAt.After(now) && len(due) < limit {
+			due = append(due, event)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockFabricPendingEventRepository) ScheduleRetry(ctx context.Context, event *domain.PendingFabricEvent) error {
+	return nil
+}
+
+func (m *mockFabricPendingEventRepository) MarkResolved(ctx context.Context, id int64, status string) error {
+	m.events[id-1].Status = status
+	return nil
+}
+
+func (m *mockFabricPendingEventRepository) ExpirePendingEvents(
+	ctx context.Context, receivedBefore time.Time,
+) ([]*domain.PendingFabricEvent, error) {
+	expired := []*domain.PendingFabricEvent{}
+	for _, event := range m.events {
+		if event.Status == domain.PendingStatusWaiting && event.ReceivedAt.Before(receivedBefore) {
+			event.Status = domain.PendingStatusExpired
+			expired = append(expired, event)
+		}
+	}
+	return expired, nil
+}
+
+type mockPublisher struct {
+	subjects  []string
+	envelopes []*messaging.EventEnvelope
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, subject string, envelope *messaging.EventEnvelope) error {
+	m.subjects = append(m.subjects, subject)
+	m.envelopes = append(m.envelopes, envelope)
+	return nil
+}
+
+func (m *mockPublisher) Close() error {
+	return nil
+}
+
+var testClock = clock.NewFixed(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+func erpMessage(t *testing.T, eventType string, version int) []byte {
+	t.Helper()
+
+	envelope := messaging.NewEventEnvelope(eventType, "ERP01", "Fabric", version, map[string]string{
+		"fabric_code": "ERP01",
+		"fabric_name": "ERP Fabric",
+	})
+	payload, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	return payload
+}
+
+func newTestEventHandler(
+	svc FabricCommandService, conflicts domain.FabricConflictRepository, policy ConflictPolicy,
+) *FabricEventHandler {
+	return newTestEventHandlerWithPending(svc, conflicts, &mockFabricPendingEventRepository{}, &mockPublisher{}, policy)
+}
+
+func newTestEventHandlerWithPending(
+	svc FabricCommandService,
+	conflicts domain.FabricConflictRepository,
+	pending domain.FabricPendingEventRepository,
+	deadLetters messaging.Publisher,
+	policy ConflictPolicy,
+) *FabricEventHandler {
+	config := ERPEventConfig{
+		ConflictPolicy:    policy,
+		PendingTimeout:    15 * time.Minute,
+		DeadLetterSubject: "dlq.erp.fabric",
+	}
+	return NewFabricEventHandler(
+		svc, conflicts, &mockFabricProposalRepository{}, pending, deadLetters, config, testClock,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+}
+
+func TestFabricEventHandler_UpdateConflict_RejectByDefault(t *testing.T) {
+	// --- Arrange ---
+	svc := &conflictingFabricService{storedVersion: 5}
+	conflicts := &mockFabricConflictRepository{}
+	handler := newTestEventHandler(svc, conflicts, ConflictPolicy{})
+
+	// --- Act ---
+	err := handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricUpdated, 3))
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, svc.updateVersions, "a rejected conflict should not be retried")
+	assert.Empty(t, conflicts.saved)
+}
+
+func TestFabricEventHandler_Update_LowConfidenceQueuedForReview(t *testing.T) {
+	testCases := []struct {
+		name             string
+		confidence       any
+		expectedProposal bool
+	}{
+		{name: "Below the minimum", confidence: 0.4, expectedProposal: true},
+		{name: "At the minimum", confidence: 0.8},
+		{name: "Without confidence"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &conflictingFabricService{
+				stored:        domain.Fabric{Name: "Old Name", Status: domain.StatusActive},
+				storedVersion: 2,
+			}
+			proposals := &mockFabricProposalRepository{}
+			handler := NewFabricEventHandler(
+				svc, &mockFabricConflictRepository{}, proposals, &mockFabricPendingEventRepository{}, &mockPublisher{},
+				ERPEventConfig{MinConfidence: 0.8}, testClock, slog.New(slog.NewTextHandler(io.Discard, nil)),
+			)
+			data := map[string]any{"fabric_code": "ERP01", "fabric_name": "ERP Fabric"}
+			if tc.confidence != nil {
+				data["confidence"] = tc.confidence
+			}
+			envelope := messaging.NewEventEnvelope(erpFabricUpdated, "ERP01", "Fabric", 3, data)
+			payload, err := json.Marshal(envelope)
+			require.NoError(t, err)
+
+			// --- Act ---
+			err = handler.HandleMessage(context.Background(), "erp.fabric", payload)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			if !tc.expectedProposal {
+				assert.Empty(t, proposals.saved)
+				assert.Equal(t, []int{2}, svc.updateVersions)
+				return
+			}
+			assert.Empty(t, svc.updateVersions, "a low-confidence update must not be applied")
+			require.Len(t, proposals.saved, 1)
+			assert.Equal(t, domain.ProposalSourceERP, proposals.saved[0].Source)
+			assert.Equal(t, envelope.EventID, proposals.saved[0].EventID)
+			assert.Equal(t, 2, proposals.saved[0].BaseVersion)
+			assert.Equal(t, "ERP Fabric", proposals.saved[0].Name)
+		})
+	}
+}
+
+func TestFabricEventHandler_UpdateConflict_LastWriteWins(t *testing.T) {
+	// --- Arrange ---
+	svc := &conflictingFabricService{storedVersion: 5}
+	policy := ConflictPolicy{erpFabricUpdated: ConflictLastWriteWins}
+	handler := newTestEventHandler(svc, &mockFabricConflictRepository{}, policy)
+
+	// --- Act ---
+	err := handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricUpdated, 3))
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 5}, svc.updateVersions, "the update should be forced over the stored version")
+}
+
+func TestFabricEventHandler_DeleteConflict_QueueForReview(t *testing.T) {
+	// --- Arrange ---
+	svc := &conflictingFabricService{storedVersion: 5}
+	conflicts := &mockFabricConflictRepository{}
+	policy := ConflictPolicy{erpFabricDeleted: ConflictQueueForReview}
+	handler := newTestEventHandler(svc, conflicts, policy)
+
+	// --- Act ---
+	err := handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricDeleted, 3))
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3}, svc.deleteVersions)
+	require.Len(t, conflicts.saved, 1)
+	queued := conflicts.saved[0]
+	assert.Equal(t, erpFabricDeleted, queued.EventType)
+	assert.Equal(t, "ERP01", queued.Code)
+	assert.Equal(t, 3, queued.ExpectedVersion)
+	assert.Equal(t, 5, queued.CurrentVersion)
+	assert.Equal(t, domain.ConflictStatusPending, queued.Status)
+	assert.Equal(t, testClock.Now(), queued.CreatedAt)
+}
+
+func TestFabricEventHandler_UpdateGap_ParksUntilMissingVersionArrives(t *testing.T) {
+	// --- Arrange ---
+	svc := &conflictingFabricService{storedVersion: 1}
+	pending := &mockFabricPendingEventRepository{}
+	handler := newTestEventHandlerWithPending(svc, &mockFabricConflictRepository{}, pending, &mockPublisher{}, ConflictPolicy{})
+
+	// --- Act ---
+	errAhead := handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricUpdated, 3))
+	parkedBeforeGapCloses := len(pending.events)
+	errMissing := handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricUpdated, 2))
+
+	// --- Assert ---
+	assert.NoError(t, errAhead)
+	assert.NoError(t, errMissing)
+	assert.Equal(t, 1, parkedBeforeGapCloses, "the event ahead of the gap should be parked")
+	assert.Equal(t, []int{2, 1, 2}, svc.updateVersions)
+	assert.Equal(t, 3, svc.storedVersion, "the parked event should be applied once the gap closes")
+	assert.Equal(t, domain.PendingStatusApplied, pending.events[0].Status)
+}
+
+func TestFabricEventHandler_UpdateGap_ParksAliasUnderCanonicalCode(t *testing.T) {
+	// --- Arrange ---
+	svc := &conflictingFabricService{storedVersion: 1, aliases: map[string]string{"ERP01": "CANON01"}}
+	pending := &mockFabricPendingEventRepository{}
+	handler := newTestEventHandlerWithPending(svc, &mockFabricConflictRepository{}, pending, &mockPublisher{}, ConflictPolicy{})
+
+	// --- Act ---
+	errAhead := handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricUpdated, 3))
+	errMissing := handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricUpdated, 2))
+
+	// --- Assert ---
+	assert.NoError(t, errAhead)
+	assert.NoError(t, errMissing)
+	require.Len(t, pending.events, 1)
+	assert.Equal(t, "CANON01", pending.events[0].Code, "events sent under an alias should wait on the canonical fabric")
+	assert.Equal(t, []string{"CANON01", "CANON01", "CANON01"}, svc.updateCodes)
+	assert.Equal(t, domain.PendingStatusApplied, pending.events[0].Status)
+}
+
+func TestFabricEventHandler_RetryPending_AppliesUpdateOnceFabricExists(t *testing.T) {
+	// --- Arrange ---
+	svc := &conflictingFabricService{missing: true}
+	pending := &mockFabricPendingEventRepository{}
+	clk := clock.NewFixed(testClock.Now())
+	handler := NewFabricEventHandler(
+		svc, &mockFabricConflictRepository{}, &mockFabricProposalRepository{}, pending, &mockPublisher{},
+		ERPEventConfig{PendingTimeout: time.Hour}, clk, slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	require.NoError(t, handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricUpdated, 2)))
+	require.Len(t, pending.events, 1)
+
+	// --- Act ---
+	require.NoError(t, handler.RetryPending(context.Background()))
+	retriedBeforeBackoff := len(svc.updateVersions)
+
+	clk.Advance(time.Minute)
+	require.NoError(t, handler.RetryPending(context.Background()))
+	attemptsWhileMissing := pending.events[0].Attempts
+
+	// the fabric is created outside the ERP flow, e.g. through the REST API
+	svc.missing, svc.storedVersion = false, 1
+	clk.Advance(time.Minute)
+	require.NoError(t, handler.RetryPending(context.Background()))
+
+	// --- Assert ---
+	assert.Equal(t, 1, retriedBeforeBackoff, "the event should not be retried before its backoff elapses")
+	assert.Equal(t, 2, attemptsWhileMissing, "a failed retry should be rescheduled")
+	assert.Equal(t, domain.PendingStatusApplied, pending.events[0].Status)
+	assert.Equal(t, 2, svc.storedVersion)
+}
+
+func TestFabricEventHandler_ExpirePending_DeadLetters(t *testing.T) {
+	// --- Arrange ---
+	svc := &conflictingFabricService{storedVersion: 1}
+	pending := &mockFabricPendingEventRepository{}
+	deadLetters := &mockPublisher{}
+	handler := newTestEventHandlerWithPending(svc, &mockFabricConflictRepository{}, pending, deadLetters, ConflictPolicy{})
+	require.NoError(t, handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricUpdated, 3)))
+	require.Len(t, pending.events, 1)
+	pending.events[0].ReceivedAt = testClock.Now().Add(-time.Hour)
+
+	// --- Act ---
+	err := handler.ExpirePending(context.Background())
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PendingStatusExpired, pending.events[0].Status)
+	assert.Equal(t, []string{"dlq.erp.fabric"}, deadLetters.subjects)
+	require.Len(t, deadLetters.envelopes, 1)
+	assert.Equal(t, pending.events[0].EventID, deadLetters.envelopes[0].EventID)
+}
+
+func TestFabricEventHandler_DuplicateCreate(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		stored                 domain.Fabric
+		expectedUpdateVersions []int
+	}{
+		{
+			name:                   "Same data is skipped",
+			stored:                 domain.Fabric{Name: "ERP Fabric", MeasureUnit: "MB", OfferStatus: "ACTIVE"},
+			expectedUpdateVersions: nil,
+		},
+		{
+			name:                   "Corrected data updates the fabric",
+			stored:                 domain.Fabric{Name: "ERP Fabirc", MeasureUnit: "MB", OfferStatus: "ACTIVE"},
+			expectedUpdateVersions: []int{4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &conflictingFabricService{stored: tc.stored, storedVersion: 4}
+			svc.errToReturn = domain.ErrDuplicateFabricCode
+			handler := newTestEventHandler(svc, &mockFabricConflictRepository{}, ConflictPolicy{})
+
+			// --- Act ---
+			err := handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricCreated, 1))
+
+			// --- Assert ---
+			assert.NoError(t, err)
+			assert.True(t, svc.CreateFabricCalled)
+			assert.Equal(t, tc.expectedUpdateVersions, svc.updateVersions)
+		})
+	}
+}
+
+func TestFabricEventHandler_LogsViolationAsStructuredData(t *testing.T) {
+	// --- Arrange ---
+	var logs bytes.Buffer
+	svc := &mockFabricCommandService{errToReturn: domain.ErrUnknownFibre.WithParam("fibre", "kevlar")}
+	handler := NewFabricEventHandler(
+		svc, &mockFabricConflictRepository{}, &mockFabricProposalRepository{}, &mockFabricPendingEventRepository{},
+		&mockPublisher{}, ERPEventConfig{}, testClock, slog.New(slog.NewJSONHandler(&logs, nil)),
+	)
+
+	// --- Act ---
+	err := handler.HandleMessage(context.Background(), "erp.fabric", erpMessage(t, erpFabricCreated, 1))
+
+	// --- Assert ---
+	assert.NoError(t, err, "violations are not retried")
+	var entry struct {
+		Violation struct {
+			Code   string         `json:"code"`
+			Field  string         `json:"field"`
+			Params map[string]any `json:"params"`
+		} `json:"violation"`
+	}
+	lines := bytes.Split(bytes.TrimSpace(logs.Bytes()), []byte("\n"))
+	require.NoError(t, json.Unmarshal(lines[len(lines)-1], &entry), "the rejection is logged last")
+	assert.Equal(t, "unknown_fibre", entry.Violation.Code)
+	assert.Equal(t, "composition", entry.Violation.Field)
+	assert.Equal(t, "kevlar", entry.Violation.Params["fibre"])
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		raw         string
+		expected    ConflictPolicy
+		expectError bool
+	}{
+		{name: "Empty", raw: "", expected: ConflictPolicy{}},
+		{
+			name: "Per event type",
+			raw:  "erp.fabric.updated=last_write_wins, erp.fabric.deleted=queue_for_review",
+			expected: ConflictPolicy{
+				erpFabricUpdated: ConflictLastWriteWins,
+				erpFabricDeleted: ConflictQueueForReview,
+			},
+		},
+		{name: "Unknown strategy", raw: "erp.fabric.updated=merge", expectError: true},
+		{name: "Unsupported event type", raw: "erp.fabric.created=reject", expectError: true},
+		{name: "Malformed entry", raw: "erp.fabric.updated", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			policy, err := ParseConflictPolicy(tc.raw)
+
+			// --- Assert ---
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, policy)
+		})
+	}
+}
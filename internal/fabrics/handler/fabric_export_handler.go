@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	// trailer reporting whether the export stream is complete, truncated or failed
+	exportStatusTrailer = "X-Export-Status"
+
+	// header with the event position to pass as changed_since on the next export
+	exportPositionHeader = "X-Export-Position"
+
+	// number of records written between flushes to the client
+	exportFlushEvery = 500
+)
+
+var errExportLimitReached = errors.New("export row limit reached")
+
+type FabricExportRepository interface {
+	ExportFabrics(ctx context.Context, limit int, fn func(*domain.Fabric) error) error
+	ExportChangedFabrics(ctx context.Context, since domain.ChangedSince, limit int, fn func(*domain.Fabric) error) error
+	LastEventPosition(ctx context.Context) (int64, error)
+}
+
+type FabricExportHandler struct {
+	repo    FabricExportRepository
+	maxRows int
+}
+
+func NewFabricExportHandler(repo FabricExportRepository, pagination httpx.PaginationConfig) *FabricExportHandler {
+	return &FabricExportHandler{
+		repo:    repo,
+		maxRows: pagination.MaxExportRows,
+	}
+}
+
+// ServeHTTP streams every active fabric as one JSON object per line. With changed_since,
+// an event position or an RFC 3339 timestamp, only the fabrics changed after it are
+// streamed, whatever their status. The position to pass on the next export is sent in a
+// header. Once streaming has started the status code can no longer change, so the outcome
+// is reported in a trailer.
+func (h *FabricExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := httpx.GetLogger(r.Context())
+	rc := http.NewResponseController(w)
+
+	since, changedOnly, err := parseChangedSince(r.URL.Query().Get("changed_since"))
+	if err != nil {
+		v := validator.New()
+		v.AddError("changed_since", "must be an event position or an RFC 3339 timestamp")
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	// read before the export, a change recorded meanwhile is exported again next time
+	position, err := h.repo.LastEventPosition(r.Context())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+	export := func(limit int, fn func(*domain.Fabric) error) error {
+		if changedOnly {
+			return h.repo.ExportChangedFabrics(r.Context(), since, limit, fn)
+		}
+		return h.repo.ExportFabrics(r.Context(), limit, fn)
+	}
+
+	// the export may outlive the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Warn("failed to clear write deadline for export", "error", err)
+	}
+
+	w.Header().Set("Content-Type", contentTypeNDJSON)
+	w.Header().Set("Trailer", exportStatusTrailer)
+	w.Header().Set(exportPositionHeader, strconv.FormatInt(position, 10))
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	written := 0
+	err = export(h.maxRows+1, func(fabric *domain.Fabric) error {
+		if written == h.maxRows {
+			return errExportLimitReached
+		}
+		if err := enc.Encode(fabric); err != nil {
+			return err
+		}
+		written++
+		if written%exportFlushEvery == 0 {
+			return rc.Flush()
+		}
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		w.Header().Set(exportStatusTrailer, "complete")
+	case errors.Is(err, errExportLimitReached):
+		logger.Warn("fabric export truncated", "max_rows", h.maxRows)
+		w.Header().Set(exportStatusTrailer, "truncated")
+	default:
+		logger.Error("fabric export failed", "written", written, "error", err)
+		w.Header().Set(exportStatusTrailer, "failed")
+	}
+}
+
+// parseChangedSince reads an event position or a timestamp, an empty value exports everything
+func parseChangedSince(raw string) (domain.ChangedSince, bool, error) {
+	if raw == "" {
+		return domain.ChangedSince{}, false, nil
+	}
+	if position, err := strconv.ParseInt(raw, 10, 64); err == nil && position >= 0 {
+		return domain.ChangedSince{Position: position}, true, nil
+	}
+	at, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return domain.ChangedSince{}, false, err
+	}
+	return domain.ChangedSince{Time: &at}, true, nil
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricExportRepository struct {
+	fabrics       []*domain.Fabric
+	errorToReturn error
+	limit         int
+	position      int64
+	changedSince  *domain.ChangedSince
+}
+
+func (m *mockFabricExportRepository) ExportFabrics(ctx context.Context, limit int, fn func(*domain.Fabric) error) error {
+	m.limit = limit
+	for i, fabric := range m.fabrics {
+		if i == limit {
+			break
+		}
+		if err := fn(fabric); err != nil {
+			return err
+		}
+	}
+	return m.errorToReturn
+}
+
+func (m *mockFabricExportRepository) ExportChangedFabrics(
+	ctx context.Context, since domain.ChangedSince, limit int, fn func(*domain.Fabric) error,
+) error {
+	m.changedSince = &since
+	return m.ExportFabrics(ctx, limit, fn)
+}
+
+func (m *mockFabricExportRepository) LastEventPosition(context.Context) (int64, error) {
+	return m.position, nil
+}
+
+func serveExport(t *testing.T, repo FabricExportRepository, maxRows int) (*httptest.ResponseRecorder, []string) {
+	t.Helper()
+	return serveExportURL(t, repo, maxRows, "/v1/fabrics/export.ndjson")
+}
+
+func serveExportURL(
+	t *testing.T, repo FabricExportRepository, maxRows int, target string,
+) (*httptest.ResponseRecorder, []string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	handler := NewFabricExportHandler(repo, httpx.PaginationConfig{MaxExportRows: maxRows})
+	handler.ServeHTTP(responseRecorder, req)
+
+	var codes []string
+	scanner := bufio.NewScanner(responseRecorder.Body)
+	for scanner.Scan() {
+		var fabric domain.Fabric
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &fabric))
+		codes = append(codes, fabric.Code)
+	}
+	return responseRecorder, codes
+}
+
+func TestFabricExportHandler_StreamsOnePerLine(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockFabricExportRepository{fabrics: []*domain.Fabric{{Code: "EXP01"}, {Code: "EXP02"}}}
+
+	// --- Act ---
+	responseRecorder, codes := serveExport(t, repo, 10)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "application/x-ndjson", responseRecorder.Header().Get("Content-Type"))
+	assert.Equal(t, []string{"EXP01", "EXP02"}, codes)
+	assert.Equal(t, "complete", responseRecorder.Result().Trailer.Get("X-Export-Status"))
+}
+
+func TestFabricExportHandler_TruncatesAtMaxRows(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockFabricExportRepository{fabrics: []*domain.Fabric{{Code: "EXP01"}, {Code: "EXP02"}, {Code: "EXP03"}}}
+
+	// --- Act ---
+	responseRecorder, codes := serveExport(t, repo, 2)
+
+	// --- Assert ---
+	assert.Equal(t, 3, repo.limit, "one extra row should be requested to detect truncation")
+	assert.Equal(t, []string{"EXP01", "EXP02"}, codes)
+	assert.Equal(t, "truncated", responseRecorder.Result().Trailer.Get("X-Export-Status"))
+}
+
+func TestFabricExportHandler_ReportsFailureInTrailer(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockFabricExportRepository{
+		fabrics:       []*domain.Fabric{{Code: "EXP01"}},
+		errorToReturn: errors.New("connection reset"),
+	}
+
+	// --- Act ---
+	responseRecorder, codes := serveExport(t, repo, 10)
+
+	// --- Assert ---
+	assert.Equal(t, []string{"EXP01"}, codes)
+	assert.Equal(t, "failed", responseRecorder.Result().Trailer.Get("X-Export-Status"))
+}
+
+func TestFabricExportHandler_ChangedSince(t *testing.T) {
+	changedAt := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		changedSince  string
+		expectedSince *domain.ChangedSince
+	}{
+		{name: "full export", changedSince: ""},
+		{name: "event position", changedSince: "1200", expectedSince: &domain.ChangedSince{Position: 1200}},
+		{name: "timestamp", changedSince: "2025-03-01T02:00:00Z", expectedSince: &domain.ChangedSince{Time: &changedAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := &mockFabricExportRepository{fabrics: []*domain.Fabric{{Code: "EXP01"}}, position: 1450}
+
+			// --- Act ---
+			responseRecorder, codes := serveExportURL(
+				t, repo, 10, "/v1/fabrics/export?changed_since="+url.QueryEscape(tt.changedSince),
+			)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Equal(t, []string{"EXP01"}, codes)
+			assert.Equal(t, "1450", responseRecorder.Header().Get("X-Export-Position"))
+			assert.Equal(t, tt.expectedSince, repo.changedSince)
+		})
+	}
+}
+
+func TestFabricExportHandler_RejectsInvalidChangedSince(t *testing.T) {
+	for _, changedSince := range []string{"-1", "yesterday", "2025-03-01"} {
+		t.Run(changedSince, func(t *testing.T) {
+			// --- Arrange ---
+			repo := &mockFabricExportRepository{fabrics: []*domain.Fabric{{Code: "EXP01"}}}
+			req := httptest.NewRequest(http.MethodGet, "/v1/fabrics/export?changed_since="+changedSince, nil)
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			NewFabricExportHandler(repo, httpx.PaginationConfig{MaxExportRows: 10}).ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+			assert.Zero(t, repo.limit, "nothing should be exported")
+		})
+	}
+}
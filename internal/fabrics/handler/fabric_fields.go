@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// the top-level fields of a fabric in a response, keyed by their normalized name
+var fabricFieldNames = func() map[string]bool {
+	names := map[string]bool{}
+	fabricType := reflect.TypeOf(domain.Fabric{})
+	for i := 0; i < fabricType.NumField(); i++ {
+		// embedded structs such as aggregate.Root add no field of their own
+		if field := fabricType.Field(i); field.IsExported() && !field.Anonymous {
+			names[normalizeFieldName(field.Name)] = true
+		}
+	}
+	return names
+}()
+
+// fabricFields limits the fabrics of a response to the fields selected with the fields
+// query parameter, fields=code,name,description for a public page that must not show the
+// internal notes. Fields prefixed with a minus, fields=-notes, are left out instead.
+type fabricFields struct {
+	names   map[string]bool
+	exclude bool
+}
+
+// readFabricFields reads the field selection from the query string, nil when every field
+// is wanted. Names are matched regardless of case and underscores, so measure_unit
+// selects MeasureUnit.
+func readFabricFields(r *http.Request, v *validator.Validator) *fabricFields {
+	raw := strings.TrimSpace(r.URL.Query().Get("fields"))
+	if raw == "" {
+		return nil
+	}
+
+	fields := &fabricFields{names: map[string]bool{}, exclude: strings.HasPrefix(raw, "-")}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		excluded := strings.HasPrefix(name, "-")
+		if excluded != fields.exclude {
+			v.AddError("fields", "fields must either all be selected or all be left out with a minus")
+			return nil
+		}
+		normalized := normalizeFieldName(strings.TrimPrefix(name, "-"))
+		if !fabricFieldNames[normalized] {
+			v.AddError("fields", "fields must only name fields of a fabric")
+			return nil
+		}
+		fields.names[normalized] = true
+	}
+	return fields
+}
+
+// apply returns the fabric reduced to the selected fields, the fabric itself when there
+// is no selection.
+func (f *fabricFields) apply(fabric *domain.Fabric) (any, error) {
+	if f == nil {
+		return fabric, nil
+	}
+
+	data, err := json.Marshal(fabric)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	for name := range values {
+		if f.names[normalizeFieldName(name)] == f.exclude {
+			delete(values, name)
+		}
+	}
+	return values, nil
+}
+
+// applyAll reduces every fabric of a listing to the selected fields.
+func (f *fabricFields) applyAll(fabrics []*domain.Fabric) (any, error) {
+	if f == nil {
+		return fabrics, nil
+	}
+
+	reduced := make([]any, 0, len(fabrics))
+	for _, fabric := range fabrics {
+		values, err := f.apply(fabric)
+		if err != nil {
+			return nil, err
+		}
+		reduced = append(reduced, values)
+	}
+	return reduced, nil
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
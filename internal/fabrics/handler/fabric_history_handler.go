@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+)
+
+const (
+	revisionSourceREST = "rest"
+	revisionSourceERP  = "erp_event"
+	// events recorded before the actor was stored on them
+	revisionSourceUnknown = "unknown"
+)
+
+// payload fields identifying the event rather than describing the fabric
+var revisionIgnoredFields = map[string]bool{"Code": true, "Version": true, "PreviousStatus": true}
+
+// the status a fabric is left in by events that do not carry it in their payload
+var revisionEventStatus = map[string]string{
+	"app.fabric.created":      domain.StatusActive,
+	"app.fabric.reactivated":  domain.StatusActive,
+	"app.fabric.restored":     domain.StatusActive,
+	"app.fabric.activated":    domain.StatusActive,
+	"app.fabric.discontinued": domain.StatusDiscontinued,
+	"app.fabric.archived":     domain.StatusArchived,
+	"app.fabric.deleted":      domain.StatusDeleted,
+	"app.fabric.merged":       domain.StatusMerged,
+}
+
+type FabricHistoryReader interface {
+	ReadAggregate(ctx context.Context, aggregateType, aggregateID string) ([]eventstore.RecordedEvent, error)
+}
+
+// fabricRevision is a single version of a fabric with the fields its event changed
+type fabricRevision struct {
+	Version    int                    `json:"version"`
+	Type       string                 `json:"type"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Source     string                 `json:"source"`
+	Actor      string                 `json:"actor,omitempty"`
+	Changes    map[string]fieldChange `json:"changes"`
+}
+
+type fieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type FabricHistoryHandler struct {
+	history FabricHistoryReader
+}
+
+func NewFabricHistoryHandler(history FabricHistoryReader) *FabricHistoryHandler {
+	return &FabricHistoryHandler{history: history}
+}
+
+// ServeHTTP returns the revisions of the fabric from the event store, oldest first, each
+// with the fields it changed and whether it came through the REST API or from the ERP.
+func (h *FabricHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	code := httpx.URLParam(r, "code")
+	events, err := h.history.ReadAggregate(r.Context(), domain.AggregateType, code)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+	if len(events) == 0 {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	revisions, err := fabricRevisions(events)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"code": code, "history": revisions}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// fabricRevisions replays the events, diffing the fields of each against the state the
+// events before it left the fabric in
+func fabricRevisions(events []eventstore.RecordedEvent) ([]fabricRevision, error) {
+	state := map[string]any{}
+	revisions := make([]fabricRevision, 0, len(events))
+	for _, event := range events {
+		envelope := event.Envelope
+		fields, err := revisionFields(envelope.EventType, envelope.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+		changes := map[string]fieldChange{}
+		for field, value := range fields {
+			if revisionIgnoredFields[field] {
+				continue
+			}
+			previous, known := state[field]
+			if known && reflect.DeepEqual(previous, value) {
+				continue
+			}
+			changes[field] = fieldChange{From: previous, To: value}
+			state[field] = value
+		}
+
+		revisions = append(revisions, fabricRevision{
+			Version:    envelope.AggregateVersion,
+			Type:       envelope.EventType,
+			OccurredAt: envelope.Timestamp,
+			Source:     revisionSource(envelope.UserID),
+			Actor:      envelope.UserID,
+			Changes:    changes,
+		})
+	}
+	return revisions, nil
+}
+
+// revisionFields decodes the payload, adding the status the event leaves the fabric in
+func revisionFields(eventType string, payload any) (map[string]any, error) {
+	raw, ok := payload.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	if status, ok := revisionEventStatus[eventType]; ok {
+		if _, carried := fields["Status"]; !carried {
+			fields["Status"] = status
+		}
+	}
+	return fields, nil
+}
+
+func revisionSource(actor string) string {
+	switch actor {
+	case "":
+		return revisionSourceUnknown
+	case command.ActorERP:
+		return revisionSourceERP
+	default:
+		return revisionSourceREST
+	}
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricHistoryReader struct {
+	events []eventstore.RecordedEvent
+	err    error
+}
+
+func (m *mockFabricHistoryReader) ReadAggregate(
+	ctx context.Context, aggregateType, aggregateID string,
+) ([]eventstore.RecordedEvent, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	result := []eventstore.RecordedEvent{}
+	for _, event := range m.events {
+		if event.Envelope.AggregateType == aggregateType && event.Envelope.AggregateID == aggregateID {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+var historyTime = time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+
+func historyEvent(version int, eventType, actor, payload string) eventstore.RecordedEvent {
+	return eventstore.RecordedEvent{Position: int64(version), Envelope: &messaging.EventEnvelope{
+		EventType:        eventType,
+		AggregateID:      "FAB01",
+		AggregateType:    "Fabric",
+		AggregateVersion: version,
+		Timestamp:        historyTime.Add(time.Duration(version) * time.Hour),
+		UserID:           actor,
+		Payload:          json.RawMessage(payload),
+	}}
+}
+
+func serveFabricHistory(t *testing.T, reader FabricHistoryReader, code string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/"+code+"/history", nil)
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", code)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	NewFabricHistoryHandler(reader).ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricHistoryHandler_ReturnsDiffs(t *testing.T) {
+	// --- Arrange ---
+	reader := &mockFabricHistoryReader{events: []eventstore.RecordedEvent{
+		historyEvent(1, "app.fabric.created", "",
+			`{"Code": "FAB01", "Name": "Linen", "MeasureUnit": "M", "OfferStatus": "NEW", "Version": 1}`),
+		historyEvent(2, "app.fabric.updated", command.ActorERP,
+			`{"Code": "FAB01", "Name": "Washed Linen", "MeasureUnit": "M", "OfferStatus": "NEW", "Version": 2}`),
+		historyEvent(3, "app.fabric.discontinued", "user_123",
+			`{"Code": "FAB01", "Name": "Washed Linen", "PreviousStatus": "ACTIVE", "Version": 3}`),
+	}}
+
+	// --- Act ---
+	rr := serveFabricHistory(t, reader, "FAB01")
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Code    string           `json:"code"`
+		History []fabricRevision `json:"history"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "FAB01", response.Code)
+	require.Len(t, response.History, 3)
+
+	created := response.History[0]
+	assert.Equal(t, 1, created.Version)
+	assert.Equal(t, revisionSourceUnknown, created.Source, "events without an actor predate its recording")
+	assert.Equal(t, fieldChange{From: nil, To: "Linen"}, created.Changes["Name"])
+	assert.Equal(t, fieldChange{From: nil, To: "ACTIVE"}, created.Changes["Status"])
+	assert.NotContains(t, created.Changes, "Code")
+
+	updated := response.History[1]
+	assert.Equal(t, revisionSourceERP, updated.Source)
+	assert.Equal(t, command.ActorERP, updated.Actor)
+	assert.Equal(t, historyTime.Add(2*time.Hour), updated.OccurredAt)
+	assert.Equal(t, map[string]fieldChange{"Name": {From: "Linen", To: "Washed Linen"}}, updated.Changes,
+		"only the fields that changed are reported")
+
+	discontinued := response.History[2]
+	assert.Equal(t, revisionSourceREST, discontinued.Source)
+	assert.Equal(t, "user_123", discontinued.Actor)
+	assert.Equal(t, map[string]fieldChange{"Status": {From: "ACTIVE", To: "DISCONTINUED"}}, discontinued.Changes)
+}
+
+func TestFabricHistoryHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		reader         *mockFabricHistoryReader
+		code           string
+		expectedStatus int
+	}{
+		{
+			name:           "unknown fabric",
+			reader:         &mockFabricHistoryReader{},
+			code:           "NOPE01",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "event store failure",
+			reader:         &mockFabricHistoryReader{err: errors.New("connection reset")},
+			code:           "FAB01",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "corrupt payload",
+			reader: &mockFabricHistoryReader{events: []eventstore.RecordedEvent{
+				historyEvent(1, "app.fabric.created", "", `not json`),
+			}},
+			code:           "FAB01",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Act ---
+			rr := serveFabricHistory(t, tt.reader, tt.code)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
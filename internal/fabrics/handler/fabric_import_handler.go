@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	contentTypeJSON   = "application/json"
+	contentTypeNDJSON = "application/x-ndjson"
+
+	// upper bound for a single NDJSON line, mirrors the single request limit of ReadJSON
+	maxImportLineBytes = 1_048_576
+	// upper bound for reported failures, keeps the response bounded for huge imports
+	maxImportErrors = 100
+)
+
+// importError reports a failed record, by its line for NDJSON or its position for a JSON array
+type importError struct {
+	Line   int    `json:"line,omitempty"`
+	Record int    `json:"record,omitempty"`
+	Code   string `json:"code,omitempty"`
+	Error  any    `json:"error"`
+}
+
+// importWarning reports corrections made to a record that was imported anyway
+type importWarning struct {
+	Line     int               `json:"line,omitempty"`
+	Record   int               `json:"record,omitempty"`
+	Code     string            `json:"code,omitempty"`
+	Warnings map[string]string `json:"warnings"`
+}
+
+type importResult struct {
+	Imported          int             `json:"imported"`
+	Failed            int             `json:"failed"`
+	Errors            []importError   `json:"errors"`
+	Truncated         bool            `json:"errors_truncated,omitempty"`
+	Warnings          []importWarning `json:"warnings,omitempty"`
+	WarningsTruncated bool            `json:"warnings_truncated,omitempty"`
+}
+
+func (res *importResult) fail(e importError) {
+	res.Failed++
+	if len(res.Errors) < maxImportErrors {
+		res.Errors = append(res.Errors, e)
+		return
+	}
+	res.Truncated = true
+}
+
+func (res *importResult) succeed(w importWarning) {
+	res.Imported++
+	if len(w.Warnings) == 0 {
+		return
+	}
+	if len(res.Warnings) < maxImportErrors {
+		res.Warnings = append(res.Warnings, w)
+		return
+	}
+	res.WarningsTruncated = true
+}
+
+type FabricImportHandler struct {
+	service FabricCommandService
+}
+
+func NewFabricImportHandler(service FabricCommandService) *FabricImportHandler {
+	return &FabricImportHandler{
+		service: service,
+	}
+}
+
+// ServeHTTP creates fabrics in bulk from either a JSON array or an NDJSON stream. Records
+// are processed one at a time as they are read, so memory use does not grow with the body.
+func (h *FabricImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	mediaType := contentTypeJSON
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		parsed, _, err := mime.ParseMediaType(ct)
+		if err != nil {
+			httpx.BadRequest(w, r, fmt.Errorf("invalid Content-Type header: %w", err))
+			return
+		}
+		mediaType = parsed
+	}
+
+	result := &importResult{Errors: []importError{}}
+	var err error
+	switch mediaType {
+	case contentTypeNDJSON:
+		err = h.importNDJSON(ctx, r.Body, result)
+	case contentTypeJSON:
+		err = h.importJSONArray(ctx, r.Body, result)
+	default:
+		httpx.ErrorJSON(w, http.StatusUnsupportedMediaType, fmt.Sprintf(
+			"Content-Type must be %s or %s", contentTypeJSON, contentTypeNDJSON))
+		return
+	}
+	if err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"import": result}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricImportHandler) importNDJSON(ctx context.Context, body io.Reader, result *importResult) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var req createFabricRequest
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			result.fail(importError{Line: line, Error: "line contains badly-formed JSON"})
+			continue
+		}
+
+		warnings, failure := h.importRecord(ctx, &req)
+		if failure != nil {
+			failure.Line = line
+			result.fail(*failure)
+			continue
+		}
+		result.succeed(importWarning{Line: line, Code: req.Code, Warnings: warnings})
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("line %d must not be larger than %d bytes", line+1, maxImportLineBytes)
+		}
+		return err
+	}
+	return nil
+}
+
+func (h *FabricImportHandler) importJSONArray(ctx context.Context, body io.Reader, result *importResult) error {
+	dec := json.NewDecoder(body)
+	dec.DisallowUnknownFields()
+
+	token, err := dec.Token()
+	if err != nil {
+		return errors.New("body must be a JSON array of fabrics")
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return errors.New("body must be a JSON array of fabrics")
+	}
+
+	record := 0
+	for dec.More() {
+		record++
+		var req createFabricRequest
+		if err := dec.Decode(&req); err != nil {
+			// the decoder cannot resynchronise inside an array, so stop at the first bad record
+			return fmt.Errorf("record %d contains badly-formed JSON", record)
+		}
+
+		warnings, failure := h.importRecord(ctx, &req)
+		if failure != nil {
+			failure.Record = record
+			result.fail(*failure)
+			continue
+		}
+		result.succeed(importWarning{Record: record, Code: req.Code, Warnings: warnings})
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return errors.New("body contains badly-formed JSON")
+	}
+	return nil
+}
+
+// validates and creates a single fabric, returning the warnings raised or the failure to report
+func (h *FabricImportHandler) importRecord(
+	ctx context.Context, req *createFabricRequest,
+) (map[string]string, *importError) {
+	req.normalize()
+	v := validator.New()
+	validateCreateFabricRequest(v, req)
+	if !v.Valid() {
+		return nil, &importError{Code: req.Code, Error: v.Errors}
+	}
+	normalizeFabricAttributes(v, &req.MeasureUnit, &req.OfferStatus)
+
+	_, err := h.service.CreateFabric(
+		ctx, req.Code, req.Name, req.MeasureUnit, req.OfferStatus, req.Specification.toDomain(), domain.FabricTexts{},
+	)
+	if err != nil {
+		domainErr, ok := domain.AsDomainError(err)
+		switch {
+		case ok && domainErr.Kind == domain.KindValidation:
+			return nil, &importError{Code: req.Code, Error: fieldErrors(domainErr)}
+		case ok:
+			return nil, &importError{Code: req.Code, Error: domainErr.Message}
+		default:
+			httpx.GetLogger(ctx).Error("failed to import fabric", "code", req.Code, "error", err)
+			return nil, &importError{Code: req.Code, Error: "the server could not import this record"}
+		}
+	}
+	return v.Warnings, nil
+}
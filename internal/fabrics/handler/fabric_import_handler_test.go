@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockImportService fails CreateFabric for the configured codes
+type mockImportService struct {
+	mockFabricCommandService
+	failures map[string]error
+	created  []string
+}
+
+func (m *mockImportService) CreateFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification, texts domain.FabricTexts,
+) (*domain.Fabric, error) {
+	if err, ok := m.failures[code]; ok {
+		return nil, err
+	}
+	m.created = append(m.created, code)
+	return &domain.Fabric{Code: code}, nil
+}
+
+func serveImport(t *testing.T, svc FabricCommandService, contentType, body string) (*httptest.ResponseRecorder, importResult) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/import", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	responseRecorder := httptest.NewRecorder()
+
+	NewFabricImportHandler(svc).ServeHTTP(responseRecorder, req)
+
+	var response struct {
+		Import importResult `json:"import"`
+	}
+	if responseRecorder.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	}
+	return responseRecorder, response.Import
+}
+
+func TestFabricImportHandler_NDJSON_ReportsLineNumbers(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockImportService{failures: map[string]error{"DUP01": domain.ErrDuplicateFabricCode}}
+	body := strings.Join([]string{
+		`{"code": "IMP01", "name": "First", "measure_unit": "m", "offer_status": "new"}`,
+		`{"code": "DUP01", "name": "Duplicate", "measure_unit": "m", "offer_status": "new"}`,
+		``,
+		`{"code": "bad-1", "name": "Hyphenated", "measure_unit": "m", "offer_status": "new"}`,
+		`{not json`,
+		`{"code": "IMP02", "name": "Second", "measure_unit": "m", "offer_status": "new"}`,
+	}, "\n")
+
+	// --- Act ---
+	responseRecorder, result := serveImport(t, svc, "application/x-ndjson", body)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, []string{"IMP01", "IMP02"}, svc.created)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 3, result.Failed)
+	require.Len(t, result.Errors, 3)
+	assert.Equal(t, 2, result.Errors[0].Line)
+	assert.Equal(t, "DUP01", result.Errors[0].Code)
+	assert.Equal(t, 4, result.Errors[1].Line)
+	assert.Equal(t, 5, result.Errors[2].Line)
+}
+
+func TestFabricImportHandler_NDJSON_LineTooLong(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockImportService{}
+	body := `{"code": "IMP01", "name": "First"}` + "\n" + `{"name": "` + strings.Repeat("x", maxImportLineBytes) + `"}`
+
+	// --- Act ---
+	responseRecorder, _ := serveImport(t, svc, "application/x-ndjson", body)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(t, responseRecorder.Body.String(), "line 2 must not be larger than")
+}
+
+func TestFabricImportHandler_JSONArray(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockImportService{failures: map[string]error{"DUP01": domain.ErrDuplicateFabricCode}}
+	body := `[
+		{"code": "IMP01", "name": "First", "measure_unit": "m", "offer_status": "new"},
+		{"code": "DUP01", "name": "Duplicate", "measure_unit": "m", "offer_status": "new"}
+	]`
+
+	// --- Act ---
+	responseRecorder, result := serveImport(t, svc, "application/json", body)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, 1, result.Imported)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 2, result.Errors[0].Record)
+}
+
+func TestFabricImportHandler_ReportsWarnings(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockImportService{}
+	body := `[
+		{"code": "IMP01", "name": "First", "measure_unit": "m", "offer_status": "new"},
+		{"code": "IMP02", "name": "Second", "measure_unit": "m", "offer_status": "someday"}
+	]`
+
+	// --- Act ---
+	responseRecorder, result := serveImport(t, svc, "application/json", body)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, 2, result.Imported, "warnings must not fail the record")
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, 2, result.Warnings[0].Record)
+	assert.Equal(t, "IMP02", result.Warnings[0].Code)
+	assert.Contains(t, result.Warnings[0].Warnings, "offer_status")
+}
+
+func TestFabricImportHandler_UnsupportedMediaType(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockImportService{}
+
+	// --- Act ---
+	responseRecorder, _ := serveImport(t, svc, "text/csv", "code,name\n")
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusUnsupportedMediaType, responseRecorder.Code)
+	assert.Empty(t, svc.created)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	lifecycleActionActivate    = "activate"
+	lifecycleActionDiscontinue = "discontinue"
+	lifecycleActionArchive     = "archive"
+)
+
+// FabricLifecycleService moves fabrics through their lifecycle.
+type FabricLifecycleService interface {
+	ActivateFabric(ctx context.Context, code string, version int) (*domain.Fabric, error)
+	DiscontinueFabric(ctx context.Context, code string, version int) (*domain.Fabric, error)
+	ArchiveFabric(ctx context.Context, code string, version int) (*domain.Fabric, error)
+}
+
+// FabricLifecycleHandler activates, discontinues and archives fabrics. A transition the
+// lifecycle does not allow from the current status is answered with a conflict.
+type FabricLifecycleHandler struct {
+	service FabricLifecycleService
+}
+
+type changeFabricStatusRequest struct {
+	Version int `json:"version"`
+}
+
+func NewFabricLifecycleHandler(service FabricLifecycleService) *FabricLifecycleHandler {
+	return &FabricLifecycleHandler{service: service}
+}
+
+func (h *FabricLifecycleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	action := httpx.URLParam(r, "action")
+	if !validator.PermittedValue(action, lifecycleActionActivate, lifecycleActionDiscontinue, lifecycleActionArchive) {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	var req changeFabricStatusRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	code := httpx.URLParam(r, "code")
+	var (
+		fabric *domain.Fabric
+		err    error
+	)
+	switch action {
+	case lifecycleActionActivate:
+		fabric, err = h.service.ActivateFabric(ctx, code, req.Version)
+	case lifecycleActionDiscontinue:
+		fabric, err = h.service.DiscontinueFabric(ctx, code, req.Version)
+	case lifecycleActionArchive:
+		fabric, err = h.service.ArchiveFabric(ctx, code, req.Version)
+	}
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabric": fabric}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
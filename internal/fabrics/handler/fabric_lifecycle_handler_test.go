@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricLifecycleService struct {
+	called      string
+	version     int
+	errToReturn error
+}
+
+func (m *mockFabricLifecycleService) ActivateFabric(ctx context.Context, code string, version int) (*domain.Fabric, error) {
+	return m.transition("activate", code, domain.StatusActive, version)
+}
+
+func (m *mockFabricLifecycleService) DiscontinueFabric(ctx context.Context, code string, version int) (*domain.Fabric, error) {
+	return m.transition("discontinue", code, domain.StatusDiscontinued, version)
+}
+
+func (m *mockFabricLifecycleService) ArchiveFabric(ctx context.Context, code string, version int) (*domain.Fabric, error) {
+	return m.transition("archive", code, domain.StatusArchived, version)
+}
+
+func (m *mockFabricLifecycleService) transition(action, code, status string, version int) (*domain.Fabric, error) {
+	m.called = action
+	m.version = version
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Fabric{Code: code, Status: status, Version: version + 1}, nil
+}
+
+func serveFabricLifecycle(
+	t *testing.T, handler *FabricLifecycleHandler, code, action, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/"+code+"/lifecycle/"+action, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", code)
+	rctx.URLParams.Add("action", action)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricLifecycleHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		action         string
+		body           string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   string
+	}{
+		{name: "activated", action: "activate", body: `{"version": 2}`, expectedStatus: http.StatusOK, expectedCall: "activate"},
+		{name: "discontinued", action: "discontinue", body: `{"version": 2}`, expectedStatus: http.StatusOK, expectedCall: "discontinue"},
+		{name: "archived", action: "archive", body: `{"version": 2}`, expectedStatus: http.StatusOK, expectedCall: "archive"},
+		{name: "unknown action", action: "publish", body: `{"version": 2}`, expectedStatus: http.StatusNotFound},
+		{name: "missing version", action: "activate", body: `{}`, expectedStatus: http.StatusUnprocessableEntity},
+		{
+			name: "transition not allowed", action: "archive", body: `{"version": 2}`,
+			errToReturn: domain.ErrInvalidStatusTransition, expectedStatus: http.StatusConflict, expectedCall: "archive",
+		},
+		{
+			name: "stale version", action: "activate", body: `{"version": 1}`,
+			errToReturn: domain.ErrConcurrencyConflict, expectedStatus: http.StatusConflict, expectedCall: "activate",
+		},
+		{
+			name: "unknown fabric", action: "discontinue", body: `{"version": 2}`,
+			errToReturn: domain.ErrRecordNotFound, expectedStatus: http.StatusNotFound, expectedCall: "discontinue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricLifecycleService{errToReturn: tt.errToReturn}
+			handler := NewFabricLifecycleHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveFabricLifecycle(t, handler, "FAB01", tt.action, tt.body)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tt.expectedCall, svc.called)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// sort keys accepted by the list endpoint, combined as in sort=name,-version; only a
+// listing sorted by code first is backed by an index
+var (
+	fabricSortSafelist = []string{
+		"code", "-code", "name", "-name", "version", "-version", "offer_status", "-offer_status",
+		"created_at", "-created_at", "updated_at", "-updated_at",
+	}
+	fabricIndexedSorts = []string{"code"}
+)
+
+var fabricCodePrefixRX = regexp.MustCompile("^[A-Z0-9]{1,30}$")
+
+type FabricListRepository interface {
+	ListFabrics(ctx context.Context, filter domain.FabricFilter) ([]*domain.Fabric, int, error)
+}
+
+type FabricListHandler struct {
+	repo       FabricListRepository
+	pagination httpx.PaginationConfig
+	queryCost  httpx.QueryCostLimits
+}
+
+func NewFabricListHandler(
+	repo FabricListRepository, pagination httpx.PaginationConfig, queryCost httpx.QueryCostLimits,
+) *FabricListHandler {
+	return &FabricListHandler{
+		repo:       repo,
+		pagination: pagination,
+		queryCost:  queryCost,
+	}
+}
+
+func (h *FabricListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	filter := readFabricFilter(r, v)
+	filter.Sort = httpx.ReadSort(r, "code", fabricSortSafelist, v)
+	fields := readFabricFields(r, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+	filter.Limit, filter.Offset = page.Limit(), page.Offset()
+
+	costErr := httpx.CheckQueryCost(h.queryCost, httpx.QueryShape{
+		Search:      filter.Search,
+		SortIndexed: validator.PermittedValue(filter.SortKeys()[0].Column, fabricIndexedSorts...),
+		PageSize:    page.PageSize,
+	})
+	if costErr != nil {
+		httpx.QueryTooExpensive(w, r, costErr)
+		return
+	}
+
+	fabrics, totalRecords, err := h.repo.ListFabrics(r.Context(), filter)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	// the most recent change among the listed fabrics
+	var lastModified time.Time
+	for _, fabric := range fabrics {
+		if fabric.UpdatedAt.After(lastModified) {
+			lastModified = fabric.UpdatedAt
+		}
+	}
+	headers := make(http.Header)
+	httpx.SetLastModified(headers, lastModified)
+
+	selected, err := fields.applyAll(fabrics)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabrics": selected, "metadata": metadata}, headers)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// readFabricFilter reads the optional criteria of a fabric listing from the query string
+func readFabricFilter(r *http.Request, v *validator.Validator) domain.FabricFilter {
+	qs := r.URL.Query()
+	filter := domain.FabricFilter{
+		Status: strings.ToUpper(qs.Get("status")),
+		Search: strings.TrimSpace(qs.Get("q")),
+	}
+
+	v.Check(
+		filter.Status == "" || validator.PermittedValue(filter.Status, domain.Statuses...),
+		"status", "status must be draft, active, discontinued, archived, deleted or merged",
+	)
+
+	if raw := qs.Get("offer_status"); raw != "" {
+		offerStatus, err := domain.ParseOfferStatus(raw)
+		if err != nil {
+			v.AddError("offer_status", err.Error())
+		}
+		filter.OfferStatus = offerStatus
+	}
+
+	if raw := qs.Get("measure_unit"); raw != "" {
+		measureUnit, err := domain.ParseMeasureUnit(strings.TrimSpace(raw))
+		if err != nil {
+			v.AddError("measure_unit", err.Error())
+		}
+		filter.MeasureUnit = measureUnit
+	}
+
+	if raw := qs.Get("code_prefix"); raw != "" {
+		filter.CodePrefix = validator.NormalizeCode(raw)
+		v.Check(
+			validator.Matches(filter.CodePrefix, fabricCodePrefixRX),
+			"code_prefix", "code_prefix must be 1-30 letters and numbers",
+		)
+	}
+
+	if raw := qs.Get("certification"); raw != "" {
+		filter.Certification = strings.ToLower(strings.TrimSpace(raw))
+		v.Check(
+			validator.PermittedValue(filter.Certification, domain.CertificationSchemes...),
+			"certification", "certification must be oeko_tex, gots, grs or bluesign",
+		)
+	}
+
+	if raw := qs.Get("updated_since"); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			v.AddError("updated_since", "updated_since must be an RFC 3339 timestamp")
+		}
+		filter.UpdatedSince = &since
+	}
+
+	return filter
+}
@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPaginationConfig = httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100, MaxExportRows: 1000}
+
+type mockFabricListRepository struct {
+	fabricsToReturn []*domain.Fabric
+	totalToReturn   int
+	errorToReturn   error
+	filter          domain.FabricFilter
+	called          bool
+}
+
+func (m *mockFabricListRepository) ListFabrics(ctx context.Context, filter domain.FabricFilter) ([]*domain.Fabric, int, error) {
+	m.called = true
+	m.filter = filter
+	return m.fabricsToReturn, m.totalToReturn, m.errorToReturn
+}
+
+func TestFabricListHandler_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricListRepository{
+		fabricsToReturn: []*domain.Fabric{
+			{Code: "ZOYA", Name: "Zoya", UpdatedAt: time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)},
+			{Code: "ZOYB", Name: "Zoyb", UpdatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		totalToReturn: 21,
+	}
+	handler := NewFabricListHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics?page=2&page_size=10", nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, 10, mockRepo.filter.Limit)
+	assert.Equal(t, 10, mockRepo.filter.Offset)
+	assert.Equal(t, "code", mockRepo.filter.Sort)
+
+	var response struct {
+		Fabrics  []domain.Fabric    `json:"fabrics"`
+		Metadata httpx.PageMetadata `json:"metadata"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	require.Len(t, response.Fabrics, 2)
+	assert.Equal(t, "ZOYA", response.Fabrics[0].Code)
+	assert.Equal(t, 3, response.Metadata.LastPage)
+	assert.Equal(t, 21, response.Metadata.TotalRecords)
+	assert.Equal(t, "Tue, 04 Mar 2025 05:06:07 GMT", responseRecorder.Header().Get("Last-Modified"),
+		"the listing was last modified with its most recently changed fabric")
+}
+
+func TestFabricListHandler_RejectsOversizedPage(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricListRepository{}
+	handler := NewFabricListHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics?page_size=1000000", nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+	assert.Contains(t, responseRecorder.Body.String(), "must be a maximum of 100")
+	assert.False(t, mockRepo.called, "repository should not be queried for an invalid page")
+}
+
+func TestFabricListHandler_SearchAndSort(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricListRepository{}
+	handler := NewFabricListHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics?q=velvet&sort=-name&page_size=10", nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "velvet", mockRepo.filter.Search)
+	assert.Equal(t, "-name", mockRepo.filter.Sort)
+}
+
+func TestFabricListHandler_Filters(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricListRepository{}
+	handler := NewFabricListHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+	req, err := http.NewRequest(
+		http.MethodGet, "/v1/fabrics?status=deleted&offer_status=available&updated_since=2025-01-02T03:04:05Z&certification=GOTS"+
+			"&measure_unit=kg&code_prefix=vel",
+		nil,
+	)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, domain.StatusDeleted, mockRepo.filter.Status)
+	assert.Equal(t, domain.OfferStatusAvailable, mockRepo.filter.OfferStatus)
+	require.NotNil(t, mockRepo.filter.UpdatedSince)
+	assert.True(t, mockRepo.filter.UpdatedSince.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+	assert.Equal(t, domain.CertificationGOTS, mockRepo.filter.Certification)
+	assert.Equal(t, domain.MeasureUnitKilogram, mockRepo.filter.MeasureUnit)
+	assert.Equal(t, "VEL", mockRepo.filter.CodePrefix)
+}
+
+func TestFabricListHandler_RejectsInvalidFilters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		expectedField string
+	}{
+		{name: "Unknown status", query: "status=retired", expectedField: "status"},
+		{name: "Unknown offer status", query: "offer_status=someday", expectedField: "offer_status"},
+		{name: "Malformed timestamp", query: "updated_since=yesterday", expectedField: "updated_since"},
+		{name: "Unknown certification", query: "certification=fsc", expectedField: "certification"},
+		{name: "Unknown measure unit", query: "measure_unit=yard", expectedField: "measure_unit"},
+		{name: "Code prefix with a wildcard", query: "code_prefix=VEL%25", expectedField: "code_prefix"},
+		{name: "Unknown field", query: "fields=code,colour", expectedField: "fields"},
+		{name: "Mixed field selection", query: "fields=code,-notes", expectedField: "fields"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			mockRepo := &mockFabricListRepository{}
+			handler := NewFabricListHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/fabrics?"+tc.query, nil)
+			require.NoError(t, err)
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+			assert.Contains(t, responseRecorder.Body.String(), tc.expectedField)
+			assert.False(t, mockRepo.called)
+		})
+	}
+}
+
+func TestFabricListHandler_SelectsFields(t *testing.T) {
+	testCases := []struct {
+		name         string
+		query        string
+		expectedKeys []string
+	}{
+		{name: "Selected fields", query: "fields=code,name,description", expectedKeys: []string{"Code", "Description", "Name"}},
+		{name: "Snake case names", query: "fields=code,measure_unit", expectedKeys: []string{"Code", "MeasureUnit"}},
+		{name: "Left out fields", query: "fields=-notes", expectedKeys: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			mockRepo := &mockFabricListRepository{
+				fabricsToReturn: []*domain.Fabric{
+					{Code: "ZOYA", Name: "Zoya", Description: "Soft *linen*", Notes: "supplier price rises in May"},
+				},
+				totalToReturn: 1,
+			}
+			handler := NewFabricListHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/fabrics?"+tc.query, nil)
+			require.NoError(t, err)
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			require.Equal(t, http.StatusOK, responseRecorder.Code)
+			var response struct {
+				Fabrics []map[string]any `json:"fabrics"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+			require.Len(t, response.Fabrics, 1)
+			assert.NotContains(t, response.Fabrics[0], "Notes")
+			if tc.expectedKeys == nil {
+				assert.Equal(t, "Soft *linen*", response.Fabrics[0]["Description"])
+				assert.Contains(t, response.Fabrics[0], "Version")
+				return
+			}
+			keys := make([]string, 0, len(response.Fabrics[0]))
+			for key := range response.Fabrics[0] {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, tc.expectedKeys, keys)
+		})
+	}
+}
+
+func TestFabricListHandler_SortsBySeveralColumns(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricListRepository{}
+	handler := NewFabricListHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics?sort=name,-version&page_size=10", nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, []domain.SortKey{{Column: "name"}, {Column: "version", Descending: true}}, mockRepo.filter.SortKeys())
+}
+
+func TestFabricListHandler_RejectsUnsortableColumnFromRepository(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricListRepository{errorToReturn: domain.ErrUnsortableColumn.WithParam("column", "name")}
+	handler := NewFabricListHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics?sort=name", nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+	assert.Contains(t, responseRecorder.Body.String(), "sort")
+}
+
+func TestFabricListHandler_RejectsUnknownSort(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricListRepository{}
+	handler := NewFabricListHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics?sort=measure_unit", nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+	assert.False(t, mockRepo.called)
+}
+
+func TestFabricListHandler_RejectsExpensiveQuery(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricListRepository{}
+	handler := NewFabricListHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics?q=velvet&sort=name&page_size=100", nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(t, responseRecorder.Body.String(), "advice")
+	assert.False(t, mockRepo.called, "repository should not be queried for a rejected query")
+}
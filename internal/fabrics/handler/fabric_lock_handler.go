@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+)
+
+// FabricLockHandler lets a user announce they are editing a fabric, so others know to wait
+// instead of running into version conflicts.
+type FabricLockHandler struct {
+	locks domain.FabricLockRepository
+	clock clock.Clock
+}
+
+type acquireFabricLockRequest struct {
+	TTLSeconds *int `json:"ttl_seconds"`
+}
+
+func NewFabricLockHandler(locks domain.FabricLockRepository, clock clock.Clock) *FabricLockHandler {
+	return &FabricLockHandler{
+		locks: locks,
+		clock: clock,
+	}
+}
+
+func (h *FabricLockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.acquireLock(w, r)
+	case http.MethodDelete:
+		h.releaseLock(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricLockHandler) acquireLock(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	// the body is optional, a lock without one lasts for the default TTL
+	var req acquireFabricLockRequest
+	if r.ContentLength != 0 {
+		if err := httpx.ReadJSON(w, r, &req); err != nil {
+			httpx.BadRequest(w, r, err)
+			return
+		}
+	}
+	ttl := domain.DefaultLockTTL
+	if req.TTLSeconds != nil {
+		ttl = time.Duration(*req.TTLSeconds) * time.Second
+	}
+
+	lock, err := domain.NewFabricLock(
+		httpx.URLParam(r, "code"), ttl, domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()},
+	)
+	if err == nil {
+		lock, err = h.locks.AcquireLock(ctx, lock)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrFabricLocked) {
+			env := httpx.Envelope{"error": domain.ErrFabricLocked.Message, "lock": lock}
+			if err := httpx.WriteJSON(w, http.StatusConflict, env, nil); err != nil {
+				httpx.InternalError(w, r, err)
+			}
+			return
+		}
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"lock": lock}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricLockHandler) releaseLock(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	if err := h.locks.ReleaseLock(ctx, httpx.URLParam(r, "code"), command.Actor(ctx)); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
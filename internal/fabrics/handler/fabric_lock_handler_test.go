@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricLockRepository struct {
+	lockToReturn   *domain.FabricLock
+	errToReturn    error
+	acquired       *domain.FabricLock
+	releasedCode   string
+	releasedHolder string
+}
+
+func (m *mockFabricLockRepository) AcquireLock(ctx context.Context, lock *domain.FabricLock) (*domain.FabricLock, error) {
+	m.acquired = lock
+	if m.errToReturn != nil {
+		return m.lockToReturn, m.errToReturn
+	}
+	return lock, nil
+}
+
+func (m *mockFabricLockRepository) ReleaseLock(ctx context.Context, code, holder string) error {
+	m.releasedCode = code
+	m.releasedHolder = holder
+	return m.errToReturn
+}
+
+func (m *mockFabricLockRepository) GetLock(ctx context.Context, code string, now time.Time) (*domain.FabricLock, error) {
+	if m.lockToReturn == nil {
+		return nil, domain.ErrRecordNotFound
+	}
+	return m.lockToReturn, nil
+}
+
+func serveFabricLock(
+	t *testing.T, handler *FabricLockHandler, method, code, userID, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "/v1/fabrics/"+code+"/lock", strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", code)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	req = req.WithContext(command.WithUserID(ctx, userID))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricLockHandler_Acquire(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		expectedStatus  int
+		expectedExpires time.Time
+	}{
+		{name: "default ttl", body: "", expectedStatus: http.StatusOK, expectedExpires: testClock.Now().Add(domain.DefaultLockTTL)},
+		{name: "custom ttl", body: `{"ttl_seconds": 60}`, expectedStatus: http.StatusOK, expectedExpires: testClock.Now().Add(time.Minute)},
+		{name: "ttl too long", body: `{"ttl_seconds": 7200}`, expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			locks := &mockFabricLockRepository{}
+			handler := NewFabricLockHandler(locks, testClock)
+
+			// --- Act ---
+			responseRecorder := serveFabricLock(t, handler, http.MethodPost, "FAB01", "user_42", tt.body)
+
+			// --- Assert ---
+			require.Equal(t, tt.expectedStatus, responseRecorder.Code)
+			if tt.expectedStatus != http.StatusOK {
+				assert.Nil(t, locks.acquired)
+				return
+			}
+			require.NotNil(t, locks.acquired)
+			assert.Equal(t, "FAB01", locks.acquired.Code)
+			assert.Equal(t, "user_42", locks.acquired.Holder)
+			assert.Equal(t, tt.expectedExpires, locks.acquired.ExpiresAt)
+		})
+	}
+}
+
+func TestFabricLockHandler_Acquire_HeldByAnotherUser(t *testing.T) {
+	// --- Arrange ---
+	held := &domain.FabricLock{
+		Code: "FAB01", Holder: "user_7", AcquiredAt: testClock.Now(), ExpiresAt: testClock.Now().Add(time.Minute),
+	}
+	locks := &mockFabricLockRepository{lockToReturn: held, errToReturn: domain.ErrFabricLocked}
+	handler := NewFabricLockHandler(locks, testClock)
+
+	// --- Act ---
+	responseRecorder := serveFabricLock(t, handler, http.MethodPost, "FAB01", "user_42", "")
+
+	// --- Assert ---
+	require.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+	var body struct {
+		Lock domain.FabricLock `json:"lock"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	assert.Equal(t, "user_7", body.Lock.Holder)
+}
+
+func TestFabricLockHandler_Release(t *testing.T) {
+	// --- Arrange ---
+	locks := &mockFabricLockRepository{}
+	handler := NewFabricLockHandler(locks, testClock)
+
+	// --- Act ---
+	responseRecorder := serveFabricLock(t, handler, http.MethodDelete, "FAB01", "user_42", "")
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusNoContent, responseRecorder.Code)
+	assert.Equal(t, "FAB01", locks.releasedCode)
+	assert.Equal(t, "user_42", locks.releasedHolder)
+}
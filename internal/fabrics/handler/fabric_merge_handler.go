@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricMergeService folds a duplicate fabric into its canonical counterpart.
+type FabricMergeService interface {
+	MergeFabric(ctx context.Context, code, into string, version int) error
+}
+
+// FabricMergeHandler folds duplicate fabrics into a canonical one. The duplicate code
+// stays resolvable as an alias, so nothing referencing it breaks.
+type FabricMergeHandler struct {
+	service FabricMergeService
+}
+
+type mergeFabricRequest struct {
+	Into    string `json:"into"`
+	Version int    `json:"version"`
+}
+
+type mergeFabricResponse struct {
+	Code          string `json:"code"`
+	CanonicalCode string `json:"canonical_code"`
+}
+
+func NewFabricMergeHandler(service FabricMergeService) *FabricMergeHandler {
+	return &FabricMergeHandler{service: service}
+}
+
+func (h *FabricMergeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	code := httpx.URLParam(r, "code")
+
+	var req mergeFabricRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Into = validator.NormalizeCode(req.Into)
+	v := validator.New()
+	v.Check(req.Into != "", "into", "into must be provided")
+	v.Check(req.Into != code, "into", "a fabric cannot be merged into itself")
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	err := h.service.MergeFabric(ctx, code, req.Into, req.Version)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	resp := mergeFabricResponse{Code: code, CanonicalCode: req.Into}
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"merge": resp}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricMergeService struct {
+	code        string
+	into        string
+	version     int
+	errToReturn error
+}
+
+func (m *mockFabricMergeService) MergeFabric(ctx context.Context, code, into string, version int) error {
+	m.code = code
+	m.into = into
+	m.version = version
+	return m.errToReturn
+}
+
+func serveMergeFabric(t *testing.T, handler *FabricMergeHandler, code, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/"+code+"/merge", strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", code)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricMergeHandler_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricMergeService{}
+	handler := NewFabricMergeHandler(svc)
+
+	// --- Act ---
+	responseRecorder := serveMergeFabric(t, handler, "DUPE01", `{"into": "CANON01", "version": 2}`)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "DUPE01", svc.code)
+	assert.Equal(t, "CANON01", svc.into)
+	assert.Equal(t, 2, svc.version)
+
+	var body struct {
+		Merge mergeFabricResponse `json:"merge"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	assert.Equal(t, mergeFabricResponse{Code: "DUPE01", CanonicalCode: "CANON01"}, body.Merge)
+}
+
+func TestFabricMergeHandler_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{name: "Missing target", body: `{"version": 1}`},
+		{name: "Missing version", body: `{"into": "CANON01"}`},
+		{name: "Merge into itself", body: `{"into": "DUPE01", "version": 1}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricMergeService{}
+			handler := NewFabricMergeHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveMergeFabric(t, handler, "DUPE01", tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+			assert.Empty(t, svc.code, "the service must not be called")
+		})
+	}
+}
+
+func TestFabricMergeHandler_ServiceErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "Unknown fabric", err: domain.ErrRecordNotFound, expectedStatus: http.StatusNotFound},
+		{name: "Stale version", err: domain.ErrConcurrencyConflict, expectedStatus: http.StatusConflict},
+		{name: "Deleted duplicate", err: domain.ErrFabricDeleted, expectedStatus: http.StatusConflict},
+		{name: "Already merged", err: domain.ErrFabricMerged, expectedStatus: http.StatusConflict},
+		{name: "Inactive target", err: domain.ErrInvalidMergeTarget, expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewFabricMergeHandler(&mockFabricMergeService{errToReturn: tc.err})
+
+			// --- Act ---
+			responseRecorder := serveMergeFabric(t, handler, "DUPE01", `{"into": "CANON01", "version": 1}`)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
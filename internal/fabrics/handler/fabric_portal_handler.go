@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	supplierDomain "github.com/salesworks/s-works/api/internal/suppliers/domain"
+)
+
+// the internal notes are for staff, suppliers never see them
+var portalFabricFields = &fabricFields{names: map[string]bool{normalizeFieldName("Notes"): true}, exclude: true}
+
+// FabricPortalSupplierReader looks up the fabrics linked to a supplier.
+type FabricPortalSupplierReader interface {
+	ResolveFabricCode(ctx context.Context, code string) (string, error)
+	ListSupplierFabrics(ctx context.Context, code string) ([]*supplierDomain.SupplierFabric, error)
+}
+
+// FabricPortalHandler serves the supplier portal: a supplier reads the fabrics linked to it
+// and proposes changes to them. A proposal is queued for staff to review, it never changes
+// the fabric directly. Fabrics not linked to the supplier are answered as not found.
+type FabricPortalHandler struct {
+	suppliers  FabricPortalSupplierReader
+	fabrics    FabricQueryRepository
+	proposals  domain.FabricProposalRepository
+	clock      clock.Clock
+	pagination httpx.PaginationConfig
+}
+
+func NewFabricPortalHandler(
+	suppliers FabricPortalSupplierReader,
+	fabrics FabricQueryRepository,
+	proposals domain.FabricProposalRepository,
+	clock clock.Clock,
+	pagination httpx.PaginationConfig,
+) *FabricPortalHandler {
+	return &FabricPortalHandler{
+		suppliers:  suppliers,
+		fabrics:    fabrics,
+		proposals:  proposals,
+		clock:      clock,
+		pagination: pagination,
+	}
+}
+
+func (h *FabricPortalHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	supplier, ok := command.GetSupplier(r.Context())
+	if !ok {
+		httpx.ErrorJSON(w, http.StatusUnauthorized, "a supplier token must be provided")
+		return
+	}
+
+	code := httpx.URLParam(r, "code")
+	switch {
+	case code == "" && r.Method == http.MethodGet:
+		h.listFabrics(w, r, supplier.Code)
+	case code != "" && strings.HasSuffix(r.URL.Path, "/proposals") && r.Method == http.MethodGet:
+		h.listProposals(w, r, supplier.Code, code)
+	case code != "" && strings.HasSuffix(r.URL.Path, "/proposals") && r.Method == http.MethodPost:
+		h.propose(w, r, supplier.Code, code)
+	case code != "" && r.Method == http.MethodGet:
+		h.getFabric(w, r, supplier.Code, code)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricPortalHandler) listFabrics(w http.ResponseWriter, r *http.Request, supplierCode string) {
+	fabrics, err := h.suppliers.ListSupplierFabrics(r.Context(), supplierCode)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabrics": fabrics}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricPortalHandler) getFabric(w http.ResponseWriter, r *http.Request, supplierCode, code string) {
+	fabric, ok := h.linkedFabric(w, r, supplierCode, code)
+	if !ok {
+		return
+	}
+
+	body, err := portalFabricFields.apply(fabric)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabric": body}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// listProposals lists the proposals of the supplier still awaiting review.
+func (h *FabricPortalHandler) listProposals(w http.ResponseWriter, r *http.Request, supplierCode, code string) {
+	fabric, ok := h.linkedFabric(w, r, supplierCode, code)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	filter := domain.ProposalFilter{Code: fabric.Code, CreatedBy: command.Actor(r.Context())}
+	proposals, totalRecords, err := h.proposals.ListPendingProposals(r.Context(), filter, page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"proposals": proposals, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricPortalHandler) propose(w http.ResponseWriter, r *http.Request, supplierCode, code string) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req createFabricDraftRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Name = validator.NormalizeText(req.Name)
+	req.MeasureUnit = validator.NormalizeCode(req.MeasureUnit)
+	req.OfferStatus = validator.NormalizeCode(req.OfferStatus)
+	v := validator.New()
+	normalizeFabricAttributes(v, &req.MeasureUnit, &req.OfferStatus)
+
+	fabric, ok := h.linkedFabric(w, r, supplierCode, code)
+	if !ok {
+		return
+	}
+
+	proposal, err := domain.NewFabricProposal(
+		fabric, domain.ProposalSourceSupplier, req.Name, req.MeasureUnit, req.OfferStatus,
+		domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()},
+	)
+	if err == nil {
+		err = h.proposals.SaveProposal(ctx, proposal)
+	}
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"proposal": proposal}
+	if v.HasWarnings() {
+		env["warnings"] = v.Warnings
+	}
+	if err := httpx.WriteJSON(w, http.StatusAccepted, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// linkedFabric loads the fabric, given by its code or an alias, provided it is linked to
+// the supplier. Otherwise it answers the request and reports false.
+func (h *FabricPortalHandler) linkedFabric(
+	w http.ResponseWriter, r *http.Request, supplierCode, code string,
+) (*domain.Fabric, bool) {
+	canonicalCode, err := h.suppliers.ResolveFabricCode(r.Context(), validator.NormalizeCode(code))
+	if err != nil {
+		if errors.Is(err, supplierDomain.ErrFabricNotFound) {
+			httpx.NotFound(w, r)
+		} else {
+			httpx.InternalError(w, r, err)
+		}
+		return nil, false
+	}
+
+	linked, err := h.suppliers.ListSupplierFabrics(r.Context(), supplierCode)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return nil, false
+	}
+	for _, fabric := range linked {
+		if fabric.Code != canonicalCode {
+			continue
+		}
+
+		found, err := h.fabrics.GetByCode(r.Context(), canonicalCode)
+		if err != nil {
+			writeDomainError(w, r, err)
+			return nil, false
+		}
+		return found, true
+	}
+
+	httpx.NotFound(w, r)
+	return nil, false
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	supplierDomain "github.com/salesworks/s-works/api/internal/suppliers/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFabricPortalSupplierReader links VELVET01, also known as VLV01, to TEXTILIA only
+type mockFabricPortalSupplierReader struct{}
+
+func (m *mockFabricPortalSupplierReader) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	switch code {
+	case "VELVET01", "VLV01":
+		return "VELVET01", nil
+	case "LINEN01":
+		return code, nil
+	}
+	return "", supplierDomain.ErrFabricNotFound
+}
+
+func (m *mockFabricPortalSupplierReader) ListSupplierFabrics(
+	ctx context.Context, code string,
+) ([]*supplierDomain.SupplierFabric, error) {
+	if code != "TEXTILIA" {
+		return []*supplierDomain.SupplierFabric{}, nil
+	}
+	return []*supplierDomain.SupplierFabric{{Code: "VELVET01", Name: "Velvet", LeadTimeDays: 21}}, nil
+}
+
+func servePortal(
+	t *testing.T, handler *FabricPortalHandler, supplier, method, target, code, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	if code != "" {
+		rctx.URLParams.Add("code", code)
+	}
+	ctx := command.WithSupplier(req.Context(), command.SupplierPrincipal{Code: supplier})
+	req = req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func newTestPortalHandler(proposals *mockFabricProposalRepository) *FabricPortalHandler {
+	fabric := &domain.Fabric{
+		Code: "VELVET01", Name: "Velvet", MeasureUnit: domain.MeasureUnitMetre, OfferStatus: domain.OfferStatusAvailable,
+		Status: domain.StatusActive, Version: 3, Description: "Soft velvet", Notes: "margin is thin",
+	}
+	return NewFabricPortalHandler(
+		&mockFabricPortalSupplierReader{}, &mockFabricQueryRepository{fabricToReturn: fabric}, proposals, testClock,
+		testPaginationConfig,
+	)
+}
+
+func TestFabricPortalHandler_GetFabric(t *testing.T) {
+	// --- Arrange ---
+	handler := newTestPortalHandler(&mockFabricProposalRepository{})
+
+	// --- Act ---
+	responseRecorder := servePortal(t, handler, "TEXTILIA", http.MethodGet, "/v1/portal/fabrics/VLV01", "VLV01", "")
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	var body struct {
+		Fabric map[string]any `json:"fabric"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	assert.Equal(t, "VELVET01", body.Fabric["Code"])
+	assert.Equal(t, "Soft velvet", body.Fabric["Description"])
+	assert.NotContains(t, body.Fabric, "Notes", "the internal notes must not be shown to suppliers")
+}
+
+func TestFabricPortalHandler_Propose(t *testing.T) {
+	// --- Arrange ---
+	proposals := &mockFabricProposalRepository{}
+	handler := newTestPortalHandler(proposals)
+	body := `{"name": "Velvet Royal", "measure_unit": "m", "offer_status": "available"}`
+
+	// --- Act ---
+	proposed := servePortal(
+		t, handler, "TEXTILIA", http.MethodPost, "/v1/portal/fabrics/VELVET01/proposals", "VELVET01", body,
+	)
+	listed := servePortal(
+		t, handler, "TEXTILIA", http.MethodGet, "/v1/portal/fabrics/VELVET01/proposals", "VELVET01", "",
+	)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusAccepted, proposed.Code)
+	require.Len(t, proposals.saved, 1, "the proposal should be queued for review")
+	assert.Equal(t, "Velvet Royal", proposals.saved[0].Name)
+	assert.Equal(t, domain.ProposalSourceSupplier, proposals.saved[0].Source)
+	assert.Equal(t, domain.ProposalStatusPending, proposals.saved[0].Status)
+	assert.Equal(t, 3, proposals.saved[0].BaseVersion)
+	assert.Equal(t, "supplier:TEXTILIA", proposals.saved[0].CreatedBy)
+	assert.Equal(t, []domain.FieldChange{{Field: "name", From: "Velvet", To: "Velvet Royal"}}, proposals.saved[0].Changes)
+
+	require.Equal(t, http.StatusOK, listed.Code)
+	var response struct {
+		Proposals []domain.FabricProposal `json:"proposals"`
+	}
+	require.NoError(t, json.Unmarshal(listed.Body.Bytes(), &response))
+	assert.Len(t, response.Proposals, 1)
+}
+
+func TestFabricPortalHandler_Rejected(t *testing.T) {
+	testCases := []struct {
+		name           string
+		supplier       string
+		method         string
+		target         string
+		code           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name: "Fabric of another supplier", supplier: "TEXTILIA", method: http.MethodGet,
+			target: "/v1/portal/fabrics/LINEN01", code: "LINEN01", expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Proposal for another supplier's fabric", supplier: "WEAVERS", method: http.MethodPost,
+			target: "/v1/portal/fabrics/VELVET01/proposals", code: "VELVET01",
+			body: `{"name": "Velvet", "measure_unit": "m", "offer_status": "available"}`, expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Unknown fabric", supplier: "TEXTILIA", method: http.MethodGet,
+			target: "/v1/portal/fabrics/NOPE", code: "NOPE", expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Invalid proposal", supplier: "TEXTILIA", method: http.MethodPost,
+			target: "/v1/portal/fabrics/VELVET01/proposals", code: "VELVET01",
+			body: `{"name": "", "measure_unit": "m", "offer_status": "available"}`, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Direct update", supplier: "TEXTILIA", method: http.MethodPut,
+			target: "/v1/portal/fabrics/VELVET01", code: "VELVET01", body: `{}`, expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			proposals := &mockFabricProposalRepository{}
+			handler := newTestPortalHandler(proposals)
+
+			// --- Act ---
+			responseRecorder := servePortal(t, handler, tc.supplier, tc.method, tc.target, tc.code, tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Empty(t, proposals.saved, "no proposal should be queued for a rejected request")
+		})
+	}
+}
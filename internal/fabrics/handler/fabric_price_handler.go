@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricPriceService sets the list price of fabrics.
+type FabricPriceService interface {
+	ChangePrice(
+		ctx context.Context, code, amount, currency string, validFrom time.Time, version int,
+	) (*domain.Fabric, error)
+}
+
+// FabricPriceHandler sets the list price of a fabric.
+type FabricPriceHandler struct {
+	service FabricPriceService
+}
+
+// the list price is a decimal string such as "24.90", so no precision is lost on the way
+type changePriceRequest struct {
+	ListPrice string     `json:"list_price"`
+	Currency  string     `json:"currency"`
+	ValidFrom *time.Time `json:"valid_from"`
+	Version   int        `json:"version"`
+}
+
+func NewFabricPriceHandler(service FabricPriceService) *FabricPriceHandler {
+	return &FabricPriceHandler{service: service}
+}
+
+func (h *FabricPriceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req changePriceRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.ListPrice = validator.NormalizeText(req.ListPrice)
+	req.Currency = validator.NormalizeCode(req.Currency)
+	v := validator.New()
+	v.Check(req.ListPrice != "", "list_price", "list_price must be provided")
+	v.Check(req.Currency != "", "currency", "currency must be provided")
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	var validFrom time.Time
+	if req.ValidFrom != nil {
+		validFrom = *req.ValidFrom
+	}
+	fabric, err := h.service.ChangePrice(
+		ctx, httpx.URLParam(r, "code"), req.ListPrice, req.Currency, validFrom, req.Version,
+	)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabric": fabric}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricPriceService struct {
+	called      bool
+	amount      string
+	currency    string
+	validFrom   time.Time
+	errToReturn error
+}
+
+func (m *mockFabricPriceService) ChangePrice(
+	ctx context.Context, code, amount, currency string, validFrom time.Time, version int,
+) (*domain.Fabric, error) {
+	m.called = true
+	m.amount, m.currency, m.validFrom = amount, currency, validFrom
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Fabric{Code: code, Version: version + 1}, nil
+}
+
+func serveChangePrice(t *testing.T, handler *FabricPriceHandler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPut, "/v1/fabrics/FAB01/price", strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", "FAB01")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricPriceHandler_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricPriceService{}
+	handler := NewFabricPriceHandler(svc)
+
+	// --- Act ---
+	responseRecorder := serveChangePrice(t, handler,
+		`{"list_price": "24.90", "currency": "pln", "valid_from": "2026-01-01T00:00:00Z", "version": 2}`)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "24.90", svc.amount)
+	assert.Equal(t, "PLN", svc.currency)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), svc.validFrom)
+}
+
+func TestFabricPriceHandler_Rejected(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{name: "missing price", body: `{"currency": "PLN", "version": 1}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "missing version", body: `{"list_price": "10", "currency": "PLN"}`, expectedStatus: http.StatusUnprocessableEntity},
+		{
+			name: "unsupported currency", body: `{"list_price": "10", "currency": "XYZ", "version": 1}`,
+			errToReturn: domain.ErrInvalidCurrency, expectedStatus: http.StatusUnprocessableEntity, expectedCall: true,
+		},
+		{
+			name: "stale version", body: `{"list_price": "10", "currency": "PLN", "version": 1}`,
+			errToReturn: domain.ErrConcurrencyConflict, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricPriceService{errToReturn: tc.errToReturn}
+			handler := NewFabricPriceHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveChangePrice(t, handler, tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	proposalActionApprove = "approve"
+	proposalActionReject  = "reject"
+)
+
+// FabricProposalHandler exposes the changes proposed by suppliers and held back from the
+// ERP, and lets a reviewer approve or reject them. Approving a proposal updates the fabric
+// at the version the proposal was based on.
+type FabricProposalHandler struct {
+	proposals  domain.FabricProposalRepository
+	service    FabricCommandService
+	clock      clock.Clock
+	pagination httpx.PaginationConfig
+}
+
+type rejectProposalRequest struct {
+	Reason string `json:"reason"`
+}
+
+func NewFabricProposalHandler(
+	proposals domain.FabricProposalRepository,
+	service FabricCommandService,
+	clock clock.Clock,
+	pagination httpx.PaginationConfig,
+) *FabricProposalHandler {
+	return &FabricProposalHandler{
+		proposals:  proposals,
+		service:    service,
+		clock:      clock,
+		pagination: pagination,
+	}
+}
+
+func (h *FabricProposalHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listProposals(w, r)
+	case http.MethodPost:
+		h.resolveProposal(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+// listProposals lists the pending proposals, of one fabric when ?code= is given.
+func (h *FabricProposalHandler) listProposals(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	filter := domain.ProposalFilter{Code: validator.NormalizeCode(r.URL.Query().Get("code"))}
+	proposals, totalRecords, err := h.proposals.ListPendingProposals(r.Context(), filter, page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"proposals": proposals, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricProposalHandler) resolveProposal(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	action := httpx.URLParam(r, "action")
+	id, err := strconv.ParseInt(httpx.URLParam(r, "id"), 10, 64)
+	if err != nil || id < 1 || !validator.PermittedValue(action, proposalActionApprove, proposalActionReject) {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	var req rejectProposalRequest
+	if action == proposalActionReject {
+		if err := httpx.ReadJSON(w, r, &req); err != nil {
+			httpx.BadRequest(w, r, err)
+			return
+		}
+		req.Reason = validator.NormalizeText(req.Reason)
+		v := validator.New()
+		v.Check(req.Reason != "", "reason", "reason must be provided")
+		v.Check(len(req.Reason) <= 500, "reason", "reason must not be more than 500 characters long")
+		if !v.Valid() {
+			httpx.ValidationError(w, r, v.Errors)
+			return
+		}
+	}
+
+	proposal, err := h.proposals.GetProposal(ctx, id)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	stamp := domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()}
+	if action == proposalActionReject {
+		err = proposal.Reject(req.Reason, stamp)
+	} else {
+		err = proposal.Approve(stamp)
+	}
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"proposal": proposal}
+	if action == proposalActionApprove {
+		// applied against the version the proposal was based on, a fabric changed in the
+		// meantime has to be proposed again
+		fabric, err := h.service.UpdateFabric(
+			ctx, proposal.Code, proposal.Name, string(proposal.MeasureUnit), string(proposal.OfferStatus), nil,
+			domain.FabricTexts{}, proposal.BaseVersion,
+		)
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrConcurrencyConflict):
+				httpx.ErrorJSON(w, http.StatusConflict, "the fabric changed since the proposal, reject it instead")
+			default:
+				writeDomainError(w, r, err)
+			}
+			return
+		}
+		env["fabric"] = fabric
+	}
+
+	if err := h.proposals.ResolveProposal(ctx, proposal); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
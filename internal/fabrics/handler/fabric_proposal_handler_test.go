@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricProposalRepository struct {
+	saved    []*domain.FabricProposal
+	resolved []*domain.FabricProposal
+	toReturn *domain.FabricProposal
+}
+
+func (m *mockFabricProposalRepository) SaveProposal(ctx context.Context, proposal *domain.FabricProposal) error {
+	proposal.ID = int64(len(m.saved) + 1)
+	m.saved = append(m.saved, proposal)
+	return nil
+}
+
+func (m *mockFabricProposalRepository) GetProposal(ctx context.Context, id int64) (*domain.FabricProposal, error) {
+	if m.toReturn == nil || m.toReturn.ID != id {
+		return nil, domain.ErrRecordNotFound
+	}
+	return m.toReturn, nil
+}
+
+func (m *mockFabricProposalRepository) ListPendingProposals(
+	ctx context.Context, filter domain.ProposalFilter, limit, offset int,
+) ([]*domain.FabricProposal, int, error) {
+	proposals := []*domain.FabricProposal{}
+	for _, proposal := range m.saved {
+		if (filter.Code == "" || proposal.Code == filter.Code) &&
+			(filter.CreatedBy == "" || proposal.CreatedBy == filter.CreatedBy) {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals, len(proposals), nil
+}
+
+func (m *mockFabricProposalRepository) ResolveProposal(ctx context.Context, proposal *domain.FabricProposal) error {
+	m.resolved = append(m.resolved, proposal)
+	return nil
+}
+
+func pendingProposal() *domain.FabricProposal {
+	return &domain.FabricProposal{
+		ID:          7,
+		Code:        "FAB01",
+		Source:      domain.ProposalSourceSupplier,
+		Name:        "Reworked Linen",
+		MeasureUnit: domain.MeasureUnitRunningMetre,
+		OfferStatus: domain.OfferStatusActive,
+		Changes:     []domain.FieldChange{{Field: "name", From: "Linen", To: "Reworked Linen"}},
+		BaseVersion: 4,
+		Status:      domain.ProposalStatusPending,
+		CreatedBy:   "supplier:TEXTILIA",
+	}
+}
+
+func TestFabricProposalHandler_ResolveProposal(t *testing.T) {
+	testCases := []struct {
+		name             string
+		action           string
+		body             string
+		storedVersion    int
+		expectedStatus   int
+		expectedUpdates  []int
+		expectedResolved string
+	}{
+		{
+			name: "Approve", action: "approve", storedVersion: 4,
+			expectedStatus: http.StatusOK, expectedUpdates: []int{4}, expectedResolved: domain.ProposalStatusApproved,
+		},
+		{
+			name: "Reject", action: "reject", body: `{"reason": "the name is reserved"}`, storedVersion: 4,
+			expectedStatus: http.StatusOK, expectedResolved: domain.ProposalStatusRejected,
+		},
+		{
+			name: "Approve over a newer version", action: "approve", storedVersion: 6,
+			expectedStatus: http.StatusConflict, expectedUpdates: []int{4},
+		},
+		{
+			name: "Reject without a reason", action: "reject", body: `{"reason": " "}`, storedVersion: 4,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{name: "Unknown action", action: "apply", storedVersion: 4, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &conflictingFabricService{storedVersion: tc.storedVersion}
+			proposals := &mockFabricProposalRepository{toReturn: pendingProposal()}
+			handler := NewFabricProposalHandler(proposals, svc, testClock, testPaginationConfig)
+
+			req, err := http.NewRequest(
+				http.MethodPost, "/v1/fabrics/proposals/7/"+tc.action, strings.NewReader(tc.body),
+			)
+			require.NoError(t, err)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "7")
+			rctx.URLParams.Add("action", tc.action)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			// --- Act ---
+			responseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedUpdates, svc.updateVersions)
+			if tc.expectedResolved == "" {
+				assert.Empty(t, proposals.resolved, "the proposal stays pending")
+				return
+			}
+			require.Len(t, proposals.resolved, 1)
+			assert.Equal(t, tc.expectedResolved, proposals.resolved[0].Status)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricPurgeService removes deleted fabrics for good.
+type FabricPurgeService interface {
+	PurgeFabric(ctx context.Context, code string, version int, retention time.Duration, purgeEvents bool) error
+}
+
+// FabricPurgeHandler lets admins review deleted fabrics and purge those deleted for longer
+// than the retention, within which a deletion can still be restored.
+type FabricPurgeHandler struct {
+	fabrics    FabricListRepository
+	service    FabricPurgeService
+	retention  time.Duration
+	pagination httpx.PaginationConfig
+}
+
+type purgeFabricRequest struct {
+	Version     int  `json:"version"`
+	PurgeEvents bool `json:"purge_events"`
+}
+
+// deletedFabric is a deleted fabric together with the moment it may be purged
+type deletedFabric struct {
+	Fabric      *domain.Fabric `json:"fabric"`
+	PurgeableAt time.Time      `json:"purgeable_at"`
+}
+
+func NewFabricPurgeHandler(
+	fabrics FabricListRepository, service FabricPurgeService, retention time.Duration, pagination httpx.PaginationConfig,
+) *FabricPurgeHandler {
+	return &FabricPurgeHandler{
+		fabrics:    fabrics,
+		service:    service,
+		retention:  retention,
+		pagination: pagination,
+	}
+}
+
+func (h *FabricPurgeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listDeleted(w, r)
+	case http.MethodPost:
+		h.purge(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+// listDeleted pages through the deleted fabrics, the longest deleted first.
+func (h *FabricPurgeHandler) listDeleted(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	fabrics, totalRecords, err := h.fabrics.ListFabrics(r.Context(), domain.FabricFilter{
+		Status: domain.StatusDeleted,
+		Sort:   "updated_at",
+		Limit:  page.Limit(),
+		Offset: page.Offset(),
+	})
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	deleted := make([]deletedFabric, 0, len(fabrics))
+	for _, fabric := range fabrics {
+		deleted = append(deleted, deletedFabric{Fabric: fabric, PurgeableAt: fabric.PurgeableAt(h.retention)})
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabrics": deleted, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricPurgeHandler) purge(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req purgeFabricRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	err := h.service.PurgeFabric(ctx, httpx.URLParam(r, "code"), req.Version, h.retention, req.PurgeEvents)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPurgeRetention = 30 * 24 * time.Hour
+
+type mockFabricPurgeService struct {
+	code        string
+	version     int
+	retention   time.Duration
+	purgeEvents bool
+	called      bool
+	errToReturn error
+}
+
+func (m *mockFabricPurgeService) PurgeFabric(
+	ctx context.Context, code string, version int, retention time.Duration, purgeEvents bool,
+) error {
+	m.called = true
+	m.code, m.version, m.retention, m.purgeEvents = code, version, retention, purgeEvents
+	return m.errToReturn
+}
+
+func TestFabricPurgeHandler_ListDeleted(t *testing.T) {
+	// --- Arrange ---
+	deletedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := &mockFabricListRepository{
+		fabricsToReturn: []*domain.Fabric{{Code: "FAB01", Status: domain.StatusDeleted, Version: 2, UpdatedAt: deletedAt}},
+		totalToReturn:   1,
+	}
+	handler := NewFabricPurgeHandler(repo, &mockFabricPurgeService{}, testPurgeRetention, testPaginationConfig)
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/fabrics/deleted?page=2&page_size=10", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, domain.StatusDeleted, repo.filter.Status)
+	assert.Equal(t, "updated_at", repo.filter.Sort, "the longest deleted come first")
+	assert.Equal(t, 10, repo.filter.Limit)
+	assert.Equal(t, 10, repo.filter.Offset)
+
+	var body struct {
+		Fabrics []struct {
+			Fabric      domain.Fabric `json:"fabric"`
+			PurgeableAt time.Time     `json:"purgeable_at"`
+		} `json:"fabrics"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	require.Len(t, body.Fabrics, 1)
+	assert.Equal(t, "FAB01", body.Fabrics[0].Fabric.Code)
+	assert.Equal(t, deletedAt.Add(testPurgeRetention), body.Fabrics[0].PurgeableAt)
+}
+
+func TestFabricPurgeHandler_Purge(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{
+			name: "purged with events", body: `{"version": 2, "purge_events": true}`,
+			expectedStatus: http.StatusNoContent, expectedCall: true,
+		},
+		{name: "missing version", body: `{}`, expectedStatus: http.StatusUnprocessableEntity},
+		{
+			name: "within the retention", body: `{"version": 2}`, errToReturn: domain.ErrPurgeRetention,
+			expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "not deleted", body: `{"version": 2}`, errToReturn: domain.ErrFabricNotPurgeable,
+			expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "unknown fabric", body: `{"version": 2}`, errToReturn: domain.ErrRecordNotFound,
+			expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricPurgeService{errToReturn: tt.errToReturn}
+			handler := NewFabricPurgeHandler(&mockFabricListRepository{}, svc, testPurgeRetention, testPaginationConfig)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/admin/fabrics/FAB01/purge", strings.NewReader(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("code", "FAB01")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tt.expectedCall, svc.called)
+			if tt.expectedCall {
+				assert.Equal(t, "FAB01", svc.code)
+				assert.Equal(t, 2, svc.version)
+				assert.Equal(t, testPurgeRetention, svc.retention, "the retention is configured, not requested")
+			}
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.True(t, svc.purgeEvents)
+			}
+		})
+	}
+}
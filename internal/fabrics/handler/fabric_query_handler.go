@@ -4,28 +4,74 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
 	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	supplierDomain "github.com/salesworks/s-works/api/internal/suppliers/domain"
+	"golang.org/x/text/language"
 )
 
 type FabricQueryRepository interface {
 	GetByCode(ctx context.Context, code string) (*domain.Fabric, error)
 }
 
+// FabricLockReader looks up the edit lock in force on a fabric.
+type FabricLockReader interface {
+	GetLock(ctx context.Context, code string, now time.Time) (*domain.FabricLock, error)
+}
+
+// FabricAttachmentReader lists the images and spec sheets attached to a fabric.
+type FabricAttachmentReader interface {
+	ListAttachments(ctx context.Context, fabricCode string) ([]*domain.FabricAttachment, error)
+}
+
+// FabricSupplierReader lists the suppliers delivering a fabric.
+type FabricSupplierReader interface {
+	ListFabricSuppliers(ctx context.Context, fabricCode string) ([]*supplierDomain.FabricSupplier, error)
+}
+
 type FabricQueryHandler struct {
-	repo FabricQueryRepository
+	repo        FabricQueryRepository
+	locks       FabricLockReader
+	attachments FabricAttachmentReader
+	suppliers   FabricSupplierReader
+	clock       clock.Clock
+	// baseLocale is the locale of the fabrics' own names and descriptions
+	baseLocale language.Tag
 }
 
-func NewFabricQueryHandler(repo FabricQueryRepository) *FabricQueryHandler {
+func NewFabricQueryHandler(
+	repo FabricQueryRepository,
+	locks FabricLockReader,
+	attachments FabricAttachmentReader,
+	suppliers FabricSupplierReader,
+	clock clock.Clock,
+	baseLocale language.Tag,
+) *FabricQueryHandler {
 	return &FabricQueryHandler{
-		repo: repo,
+		repo:        repo,
+		locks:       locks,
+		attachments: attachments,
+		suppliers:   suppliers,
+		clock:       clock,
+		baseLocale:  baseLocale,
 	}
 }
 
 func (h *FabricQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	code := httpx.URLParam(r, "code")
 
+	v := validator.New()
+	fields := readFabricFields(r, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
 	fabric, err := h.repo.GetByCode(r.Context(), code)
 	if err != nil {
 		switch {
@@ -37,7 +83,68 @@ func (h *FabricQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabric": fabric}, nil)
+	// the name and description are served in the locale best matching Accept-Language,
+	// which is why a cached response must not be served to a client asking for another
+	localized, locale := fabric.Localize(r.Header.Get("Accept-Language"), h.baseLocale)
+	selected, err := fields.apply(localized)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"fabric": selected}
+	headers := make(http.Header)
+	headers.Set("Content-Language", locale)
+	headers.Add("Vary", "Accept-Language")
+	// a fabric looked up by an alias is served under its canonical code
+	if fabric.Code != code {
+		env["canonical_code"] = fabric.Code
+		headers.Set("Content-Location", "/v1/fabrics/"+url.PathEscape(fabric.Code))
+	}
+
+	// the fabric counts as modified when its data changed, it was locked, a file was
+	// attached to it or one of its suppliers changed; a lock released or a supplier
+	// unlinked leaves no date behind, which the ETag of the response accounts for
+	lastModified := fabric.UpdatedAt
+	modifiedAt := func(at time.Time) {
+		if at.After(lastModified) {
+			lastModified = at
+		}
+	}
+
+	// the edit lock is advisory, a failed lookup does not fail the read
+	lock, err := h.locks.GetLock(r.Context(), fabric.Code, h.clock.Now())
+	switch {
+	case err == nil:
+		env["lock"] = lock
+		modifiedAt(lock.AcquiredAt)
+	case !errors.Is(err, domain.ErrRecordNotFound):
+		httpx.GetLogger(r.Context()).Warn("failed to look up fabric lock", "code", fabric.Code, "error", err)
+	}
+
+	attachments, err := h.attachments.ListAttachments(r.Context(), fabric.Code)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+	withAttachmentURLs(attachments)
+	env["attachments"] = attachments
+	for _, attachment := range attachments {
+		modifiedAt(attachment.CreatedAt)
+	}
+
+	suppliers, err := h.suppliers.ListFabricSuppliers(r.Context(), fabric.Code)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+	env["suppliers"] = suppliers
+	for _, supplier := range suppliers {
+		modifiedAt(supplier.ModifiedAt)
+	}
+	httpx.SetLastModified(headers, lastModified)
+
+	err = httpx.WriteCacheableJSON(w, r, env, headers)
 	if err != nil {
 		httpx.InternalError(w, r, err)
 	}
@@ -6,12 +6,17 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	supplierDomain "github.com/salesworks/s-works/api/internal/suppliers/domain"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
 )
 
+var testBaseLocale = language.English
+
 type mockFabricQueryRepository struct {
 	fabricToReturn *domain.Fabric
 	errorToReturn  error
@@ -21,6 +26,16 @@ func (m *mockFabricQueryRepository) GetByCode(ctx context.Context, code string)
 	return m.fabricToReturn, m.errorToReturn
 }
 
+type mockFabricSupplierReader struct {
+	suppliers []*supplierDomain.FabricSupplier
+}
+
+func (m *mockFabricSupplierReader) ListFabricSuppliers(
+	ctx context.Context, fabricCode string,
+) ([]*supplierDomain.FabricSupplier, error) {
+	return m.suppliers, nil
+}
+
 func TestFabricQueryHandler_GetByCode_HappyPath(t *testing.T) {
 	// --- Arrange ---
 	expectedFabric := &domain.Fabric{
@@ -28,6 +43,8 @@ func TestFabricQueryHandler_GetByCode_HappyPath(t *testing.T) {
 		Name:        "An Existing Fabric",
 		MeasureUnit: "m",
 		OfferStatus: "available",
+		CreatedBy:   "user_123",
+		UpdatedBy:   "erp",
 	}
 
 	mockRepo := &mockFabricQueryRepository{
@@ -35,7 +52,10 @@ func TestFabricQueryHandler_GetByCode_HappyPath(t *testing.T) {
 		errorToReturn:  nil,
 	}
 
-	handler := NewFabricQueryHandler(mockRepo)
+	handler := NewFabricQueryHandler(
+		mockRepo, &mockFabricLockRepository{}, &mockFabricAttachmentService{}, &mockFabricSupplierReader{},
+		testClock, testBaseLocale,
+	)
 	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/EXISTING", nil)
 	assert.NoError(t, err)
 
@@ -60,4 +80,338 @@ func TestFabricQueryHandler_GetByCode_HappyPath(t *testing.T) {
 	actualFabric := responseEnvelope.Fabric
 	assert.Equal(t, expectedFabric.Code, actualFabric.Code)
 	assert.Equal(t, expectedFabric.Name, actualFabric.Name)
+	assert.Equal(t, expectedFabric.CreatedBy, actualFabric.CreatedBy, "who created the fabric is returned")
+	assert.Equal(t, expectedFabric.UpdatedBy, actualFabric.UpdatedBy, "who last changed the fabric is returned")
+}
+
+func TestFabricQueryHandler_GetByCode_SelectsFields(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectNotes    bool
+	}{
+		{name: "All fields", query: "", expectedStatus: http.StatusOK, expectNotes: true},
+		{name: "Notes left out", query: "?fields=-notes", expectedStatus: http.StatusOK},
+		{name: "Public fields", query: "?fields=code,name,description", expectedStatus: http.StatusOK},
+		{name: "Unknown field", query: "?fields=secret", expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			mockRepo := &mockFabricQueryRepository{
+				fabricToReturn: &domain.Fabric{Code: "EXISTING", Name: "Linen", Description: "Soft", Notes: "Reorder soon"},
+			}
+			handler := NewFabricQueryHandler(
+				mockRepo, &mockFabricLockRepository{}, &mockFabricAttachmentService{}, &mockFabricSupplierReader{},
+				testClock, testBaseLocale,
+			)
+			req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/EXISTING"+tc.query, nil)
+			assert.NoError(t, err)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("code", "EXISTING")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var responseEnvelope struct {
+				Fabric map[string]any `json:"fabric"`
+			}
+			assert.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &responseEnvelope))
+			assert.Equal(t, "Soft", responseEnvelope.Fabric["Description"])
+			_, hasNotes := responseEnvelope.Fabric["Notes"]
+			assert.Equal(t, tc.expectNotes, hasNotes)
+		})
+	}
+}
+
+func TestFabricQueryHandler_GetByCode_ResolvesAlias(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricQueryRepository{
+		fabricToReturn: &domain.Fabric{Code: "CANON01", Name: "Canonical Fabric"},
+	}
+
+	handler := NewFabricQueryHandler(
+		mockRepo, &mockFabricLockRepository{}, &mockFabricAttachmentService{}, &mockFabricSupplierReader{},
+		testClock, testBaseLocale,
+	)
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/LEGACY01", nil)
+	assert.NoError(t, err)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", "LEGACY01")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "/v1/fabrics/CANON01", responseRecorder.Header().Get("Content-Location"))
+
+	var responseEnvelope struct {
+		Fabric        domain.Fabric `json:"fabric"`
+		CanonicalCode string        `json:"canonical_code"`
+	}
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &responseEnvelope)
+	assert.NoError(t, err)
+	assert.Equal(t, "CANON01", responseEnvelope.CanonicalCode)
+	assert.Equal(t, "CANON01", responseEnvelope.Fabric.Code)
+}
+
+func TestFabricQueryHandler_GetByCode_SurfacesEditLock(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricQueryRepository{
+		fabricToReturn: &domain.Fabric{Code: "EXISTING", Name: "An Existing Fabric"},
+	}
+	lock := &domain.FabricLock{
+		Code:       "EXISTING",
+		Holder:     "user_42",
+		AcquiredAt: testClock.Now(),
+		ExpiresAt:  testClock.Now().Add(domain.DefaultLockTTL),
+	}
+
+	handler := NewFabricQueryHandler(
+		mockRepo, &mockFabricLockRepository{lockToReturn: lock}, &mockFabricAttachmentService{}, &mockFabricSupplierReader{},
+		testClock, testBaseLocale,
+	)
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/EXISTING", nil)
+	assert.NoError(t, err)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", "EXISTING")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var responseEnvelope struct {
+		Lock *domain.FabricLock `json:"lock"`
+	}
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &responseEnvelope)
+	assert.NoError(t, err)
+	if assert.NotNil(t, responseEnvelope.Lock) {
+		assert.Equal(t, "user_42", responseEnvelope.Lock.Holder)
+		assert.True(t, lock.ExpiresAt.Equal(responseEnvelope.Lock.ExpiresAt))
+	}
+}
+
+func TestFabricQueryHandler_GetByCode_ListsAttachments(t *testing.T) {
+	// --- Arrange ---
+	updatedAt := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	mockRepo := &mockFabricQueryRepository{
+		fabricToReturn: &domain.Fabric{Code: "EXISTING", Name: "An Existing Fabric", UpdatedAt: updatedAt},
+	}
+	attachments := &mockFabricAttachmentService{attachments: []*domain.FabricAttachment{
+		{
+			ID: testAttachmentID, FabricCode: "EXISTING", Kind: domain.AttachmentImage, FileName: "swatch.png",
+			CreatedAt: updatedAt.Add(time.Hour),
+		},
+	}}
+
+	handler := NewFabricQueryHandler(
+		mockRepo, &mockFabricLockRepository{}, attachments, &mockFabricSupplierReader{},
+		testClock, testBaseLocale,
+	)
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/EXISTING", nil)
+	assert.NoError(t, err)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", "EXISTING")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var responseEnvelope struct {
+		Attachments []domain.FabricAttachment `json:"attachments"`
+	}
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &responseEnvelope)
+	assert.NoError(t, err)
+	if assert.Len(t, responseEnvelope.Attachments, 1) {
+		assert.Equal(t, "/v1/fabrics/EXISTING/attachments/"+testAttachmentID, responseEnvelope.Attachments[0].URL)
+	}
+	assert.Equal(t, "Tue, 04 Mar 2025 06:06:07 GMT", responseRecorder.Header().Get("Last-Modified"),
+		"attaching a file modifies the fabric")
+}
+
+func TestFabricQueryHandler_GetByCode_EmbedsSuppliers(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricQueryRepository{
+		fabricToReturn: &domain.Fabric{Code: "EXISTING", Name: "An Existing Fabric"},
+	}
+	suppliers := &mockFabricSupplierReader{suppliers: []*supplierDomain.FabricSupplier{
+		{Code: "TEXTILIA", Name: "Textilia", LeadTimeDays: 21, ArticleNumber: "TX-4411"},
+	}}
+
+	handler := NewFabricQueryHandler(
+		mockRepo, &mockFabricLockRepository{}, &mockFabricAttachmentService{}, suppliers,
+		testClock, testBaseLocale,
+	)
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/EXISTING", nil)
+	assert.NoError(t, err)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", "EXISTING")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var responseEnvelope struct {
+		Suppliers []supplierDomain.FabricSupplier `json:"suppliers"`
+	}
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &responseEnvelope)
+	assert.NoError(t, err)
+	if assert.Len(t, responseEnvelope.Suppliers, 1) {
+		assert.Equal(t, "TEXTILIA", responseEnvelope.Suppliers[0].Code)
+		assert.Equal(t, 21, responseEnvelope.Suppliers[0].LeadTimeDays)
+	}
+}
+
+func TestFabricQueryHandler_GetByCode_Localized(t *testing.T) {
+	tests := []struct {
+		name                string
+		acceptLanguage      string
+		expectedName        string
+		expectedDescription string
+		expectedLocale      string
+	}{
+		{name: "no preference", expectedName: "Velvet", expectedDescription: "Soft velvet", expectedLocale: "en"},
+		{
+			name: "translated locale", acceptLanguage: "de-DE,de;q=0.9,en;q=0.5",
+			expectedName: "Samt", expectedDescription: "Weicher Samt", expectedLocale: "de",
+		},
+		{
+			name: "translation without description", acceptLanguage: "pl",
+			expectedName: "Aksamit", expectedDescription: "Soft velvet", expectedLocale: "pl",
+		},
+		{
+			name: "untranslated locale falls back", acceptLanguage: "fr-CH, fr;q=0.9",
+			expectedName: "Velvet", expectedDescription: "Soft velvet", expectedLocale: "en",
+		},
+		{
+			name: "preferred translation first", acceptLanguage: "fr, pl;q=0.8, de;q=0.7",
+			expectedName: "Aksamit", expectedDescription: "Soft velvet", expectedLocale: "pl",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			mockRepo := &mockFabricQueryRepository{
+				fabricToReturn: &domain.Fabric{
+					Code: "VELVET01", Name: "Velvet", Description: "Soft velvet",
+					Translations: map[string]domain.Translation{
+						"de": {Name: "Samt", Description: "Weicher Samt"},
+						"pl": {Name: "Aksamit"},
+					},
+				},
+			}
+			handler := NewFabricQueryHandler(
+				mockRepo, &mockFabricLockRepository{}, &mockFabricAttachmentService{}, &mockFabricSupplierReader{},
+				testClock, testBaseLocale,
+			)
+			req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/VELVET01", nil)
+			assert.NoError(t, err)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("code", "VELVET01")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Equal(t, tt.expectedLocale, responseRecorder.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", responseRecorder.Header().Get("Vary"))
+
+			var responseEnvelope struct {
+				Fabric domain.Fabric `json:"fabric"`
+			}
+			assert.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &responseEnvelope))
+			assert.Equal(t, tt.expectedName, responseEnvelope.Fabric.Name)
+			assert.Equal(t, tt.expectedDescription, responseEnvelope.Fabric.Description)
+			assert.Len(t, responseEnvelope.Fabric.Translations, 2, "the translations are served as they are")
+		})
+	}
+}
+
+func TestFabricQueryHandler_GetByCode_ConditionalRequests(t *testing.T) {
+	// --- Arrange ---
+	updatedAt := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	mockRepo := &mockFabricQueryRepository{
+		fabricToReturn: &domain.Fabric{Code: "EXISTING", Name: "An Existing Fabric", UpdatedAt: updatedAt},
+	}
+	locks := &mockFabricLockRepository{lockToReturn: &domain.FabricLock{
+		Code: "EXISTING", Holder: "user_42", AcquiredAt: updatedAt.Add(time.Hour), ExpiresAt: updatedAt.Add(2 * time.Hour),
+	}}
+	suppliers := &mockFabricSupplierReader{suppliers: []*supplierDomain.FabricSupplier{
+		{Code: "TEXTILIA", Name: "Textilia", LeadTimeDays: 21, ModifiedAt: updatedAt.Add(2 * time.Hour)},
+	}}
+	handler := NewFabricQueryHandler(
+		mockRepo, locks, &mockFabricAttachmentService{}, suppliers, testClock, testBaseLocale,
+	)
+	serve := func(conditions map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/fabrics/EXISTING", nil)
+		for name, value := range conditions {
+			req.Header.Set(name, value)
+		}
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("code", "EXISTING")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		responseRecorder := httptest.NewRecorder()
+		handler.ServeHTTP(responseRecorder, req)
+		return responseRecorder
+	}
+
+	// --- Act ---
+	first := serve(nil)
+	etag := first.Header().Get("ETag")
+	sameDate := serve(map[string]string{"If-Modified-Since": "Tue, 04 Mar 2025 07:06:07 GMT"})
+	olderDate := serve(map[string]string{"If-Modified-Since": "Tue, 04 Mar 2025 06:06:07 GMT"})
+	sameTag := serve(map[string]string{"If-None-Match": etag})
+	locks.lockToReturn = nil
+	afterRelease := serve(map[string]string{"If-None-Match": etag, "If-Modified-Since": "Tue, 04 Mar 2025 07:06:07 GMT"})
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "Tue, 04 Mar 2025 07:06:07 GMT", first.Header().Get("Last-Modified"),
+		"locking the fabric and changing its suppliers modify it")
+	assert.Equal(t, http.StatusNotModified, sameDate.Code)
+	assert.Empty(t, sameDate.Body.Bytes())
+	assert.Equal(t, http.StatusOK, olderDate.Code)
+	assert.Equal(t, http.StatusNotModified, sameTag.Code)
+	assert.Equal(t, http.StatusOK, afterRelease.Code, "releasing the lock changes the representation")
+	assert.NotEqual(t, etag, afterRelease.Header().Get("ETag"))
 }
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// upper bound for the items of a single batch, a season re-launch is split into several
+const maxReactivateItems = 1000
+
+// FabricReactivationService puts discontinued and deleted fabrics back on offer.
+type FabricReactivationService interface {
+	ReactivateFabric(
+		ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification, version int,
+	) (*domain.Fabric, error)
+}
+
+// FabricReactivateHandler brings a batch of discontinued or deleted fabrics back on offer,
+// as when a season re-launches hundreds of articles. Each item is reactivated on its own,
+// so a failing item is reported without holding back the rest of the batch.
+type FabricReactivateHandler struct {
+	service FabricReactivationService
+}
+
+type reactivateFabricsRequest struct {
+	Items []reactivateFabricItemRequest `json:"items"`
+}
+
+// a fabric to reactivate at the version the caller saw, attributes left out keep the values
+// the fabric had
+type reactivateFabricItemRequest struct {
+	Code          string                      `json:"code"`
+	Name          string                      `json:"name"`
+	MeasureUnit   string                      `json:"measure_unit"`
+	OfferStatus   string                      `json:"offer_status"`
+	Specification *fabricSpecificationRequest `json:"specification"`
+	Version       int                         `json:"version"`
+}
+
+// reactivateItemResult reports the outcome of the item at the index of the batch
+type reactivateItemResult struct {
+	Index       int    `json:"index"`
+	Code        string `json:"code,omitempty"`
+	Reactivated bool   `json:"reactivated"`
+	Version     int    `json:"version,omitempty"`
+	Errors      any    `json:"errors,omitempty"`
+}
+
+type reactivateResult struct {
+	Reactivated int                    `json:"reactivated"`
+	Failed      int                    `json:"failed"`
+	Results     []reactivateItemResult `json:"results"`
+}
+
+func NewFabricReactivateHandler(service FabricReactivationService) *FabricReactivateHandler {
+	return &FabricReactivateHandler{service: service}
+}
+
+func (h *FabricReactivateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req reactivateFabricsRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(len(req.Items) > 0, "items", "items must be provided")
+	v.Check(len(req.Items) <= maxReactivateItems, "items", fmt.Sprintf("items must not be more than %d", maxReactivateItems))
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	result := &reactivateResult{Results: make([]reactivateItemResult, 0, len(req.Items))}
+	seen := make(map[string]bool, len(req.Items))
+	for i := range req.Items {
+		item := h.reactivateItem(ctx, &req.Items[i], seen)
+		item.Index = i
+		if item.Reactivated {
+			result.Reactivated++
+		} else {
+			result.Failed++
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"reactivation": result}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// reactivateItem validates and reactivates a single fabric of the batch
+func (h *FabricReactivateHandler) reactivateItem(
+	ctx context.Context, req *reactivateFabricItemRequest, seen map[string]bool,
+) reactivateItemResult {
+	req.normalize()
+	result := reactivateItemResult{Code: req.Code}
+
+	v := validator.New()
+	v.Check(req.Code != "", "code", "code must be provided")
+	v.Check(req.Code == "" || !seen[req.Code], "code", "code must appear only once in a batch")
+	v.Check(len(req.Name) <= 250, "name", "name must not be more than 250 characters long")
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	seen[req.Code] = true
+	if !v.Valid() {
+		result.Errors = v.Errors
+		return result
+	}
+
+	fabric, err := h.service.ReactivateFabric(
+		ctx, req.Code, req.Name, req.MeasureUnit, req.OfferStatus, req.Specification.toDomainOrNil(), req.Version,
+	)
+	if err != nil {
+		result.Errors = reactivateItemError(ctx, req.Code, err)
+		return result
+	}
+
+	result.Reactivated = true
+	result.Version = fabric.Version
+	return result
+}
+
+func (req *reactivateFabricItemRequest) normalize() {
+	req.Code = validator.NormalizeCode(req.Code)
+	req.Name = validator.NormalizeText(req.Name)
+	req.MeasureUnit = validator.NormalizeCode(req.MeasureUnit)
+	req.OfferStatus = validator.NormalizeCode(req.OfferStatus)
+	req.Specification.normalize()
+}
+
+// reactivateItemError reports a failed reactivation in the shape of the import failures
+func reactivateItemError(ctx context.Context, code string, err error) any {
+	domainErr, ok := domain.AsDomainError(err)
+	switch {
+	case ok && domainErr.Kind == domain.KindValidation:
+		return fieldErrors(domainErr)
+	case ok:
+		return domainErr.Message
+	default:
+		httpx.GetLogger(ctx).Error("failed to reactivate fabric", "code", code, "error", err)
+		return "the server could not reactivate this fabric"
+	}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFabricReactivationService fails the reactivation of the configured codes
+type mockFabricReactivationService struct {
+	failures map[string]error
+	names    map[string]string
+}
+
+func (m *mockFabricReactivationService) ReactivateFabric(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification, version int,
+) (*domain.Fabric, error) {
+	if m.names == nil {
+		m.names = map[string]string{}
+	}
+	m.names[code] = name
+	if err := m.failures[code]; err != nil {
+		return nil, err
+	}
+	return &domain.Fabric{Code: code, Name: name, Status: domain.StatusActive, Version: 5}, nil
+}
+
+func serveReactivate(
+	t *testing.T, svc FabricReactivationService, body string,
+) (*httptest.ResponseRecorder, reactivateResult) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/reactivate-batch", strings.NewReader(body))
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	NewFabricReactivateHandler(svc).ServeHTTP(responseRecorder, req)
+
+	var response struct {
+		Reactivation reactivateResult `json:"reactivation"`
+	}
+	if responseRecorder.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	}
+	return responseRecorder, response.Reactivation
+}
+
+func TestFabricReactivateHandler_ReportsPerItem(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricReactivationService{failures: map[string]error{
+		"LIVE01": domain.ErrInvalidStatusTransition,
+		"GONE01": domain.ErrRecordNotFound,
+	}}
+	body := `{"items": [
+		{"code": "season01", "version": 4},
+		{"code": "SEASON02", "name": " Summer  Linen ", "version": 4},
+		{"code": "LIVE01", "version": 4},
+		{"code": "GONE01", "version": 4},
+		{"code": "SEASON01", "version": 4},
+		{"code": "", "version": 4},
+		{"code": "SEASON03"}
+	]}`
+
+	// --- Act ---
+	rr, result := serveReactivate(t, svc, body)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 2, result.Reactivated)
+	assert.Equal(t, 5, result.Failed)
+	require.Len(t, result.Results, 7)
+
+	assert.True(t, result.Results[0].Reactivated)
+	assert.Equal(t, "SEASON01", result.Results[0].Code, "codes are normalized")
+	assert.Equal(t, 5, result.Results[0].Version)
+	assert.Equal(t, "Summer Linen", svc.names["SEASON02"], "new attribute values are normalized")
+	assert.Equal(t, "", svc.names["SEASON01"], "an attribute left out keeps the value of the fabric")
+
+	_, called := svc.names["SEASON03"]
+	assert.False(t, called, "a version must be given, reactivation checks it against the stored one")
+
+	for i := 2; i < 7; i++ {
+		assert.False(t, result.Results[i].Reactivated, "item %d", i)
+		assert.NotEmpty(t, result.Results[i].Errors, "item %d", i)
+		assert.Equal(t, i, result.Results[i].Index)
+	}
+}
+
+func TestFabricReactivateHandler_RejectsBatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+	}{
+		{name: "no items", method: http.MethodPost, body: `{"items": []}`, expectedStatus: http.StatusUnprocessableEntity},
+		{
+			name: "too many items", method: http.MethodPost,
+			body:           `{"items": [` + strings.Repeat(`{"code": "A1", "version": 1},`, maxReactivateItems) + `{"code": "A1", "version": 1}]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{name: "bad JSON", method: http.MethodPost, body: `{"items": [`, expectedStatus: http.StatusBadRequest},
+		{name: "wrong method", method: http.MethodGet, body: ``, expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricReactivationService{}
+			req, err := http.NewRequest(tt.method, "/v1/fabrics/reactivate-batch", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+
+			// --- Act ---
+			NewFabricReactivateHandler(svc).ServeHTTP(rr, req)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Empty(t, svc.names, "service should not be called for a rejected batch")
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package handler
+
+import "github.com/salesworks/s-works/api/internal/platform/httpx"
+
+// Scopes a caller needs to see the commercially sensitive fields of fabrics.
+const (
+	ScopeFabricPricing       = "fabrics:pricing"
+	ScopeFabricSupplierTerms = "fabrics:supplier-terms"
+	ScopeFabricNotes         = "fabrics:notes"
+)
+
+// FabricRedactionRules withhold the price and the internal notes of fabrics, and the terms
+// of the suppliers embedded in a fabric, from callers without the scope to see them. The
+// fields are withheld wherever a fabric is returned: alone or listed, as a record of the
+// export, and as changed by the events of its history and of the change feed. The rules
+// are applied to every response by httpx.RedactionMiddleware.
+var FabricRedactionRules = []httpx.RedactionRule{
+	{Scope: ScopeFabricPricing, Paths: fabricFieldPaths("Price")},
+	{Scope: ScopeFabricNotes, Paths: fabricFieldPaths("Notes")},
+	{Scope: ScopeFabricSupplierTerms, Paths: []string{"suppliers.lead_time_days", "suppliers.article_number"}},
+}
+
+// fabricFieldPaths lists the paths a field of a fabric is returned under
+func fabricFieldPaths(field string) []string {
+	return []string{
+		"fabric." + field,
+		"fabrics." + field,
+		field,
+		"history.changes." + field,
+		"changes.data." + field,
+	}
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveRedacted(t *testing.T, handler http.Handler, target string, scopes ...string) string {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", "FAB01")
+	ctx := command.WithScopes(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), scopes)
+
+	responseRecorder := httptest.NewRecorder()
+	httpx.RedactionMiddleware(FabricRedactionRules...)(handler).ServeHTTP(responseRecorder, req.WithContext(ctx))
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	return responseRecorder.Body.String()
+}
+
+func TestFabricRedactionRules_Export(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockFabricExportRepository{fabrics: []*domain.Fabric{
+		{Code: "FAB01", Notes: "margin negotiated down", Price: &domain.Price{Amount: 2490, Currency: "PLN"}},
+		{Code: "FAB02"},
+	}}
+	handler := NewFabricExportHandler(repo, testPaginationConfig)
+
+	// --- Act ---
+	redacted := serveRedacted(t, handler, "/v1/fabrics/export.ndjson")
+	granted := serveRedacted(t, handler, "/v1/fabrics/export.ndjson", ScopeFabricPricing, ScopeFabricNotes)
+
+	// --- Assert ---
+	assert.Contains(t, redacted, `"Code":"FAB02"`)
+	assert.NotContains(t, redacted, `"Price"`)
+	assert.NotContains(t, redacted, `"Notes"`)
+	assert.Contains(t, granted, `"Amount":2490`)
+	assert.Contains(t, granted, `"Notes":"margin negotiated down"`)
+}
+
+func TestFabricRedactionRules_History(t *testing.T) {
+	// --- Arrange ---
+	reader := &mockFabricHistoryReader{events: []eventstore.RecordedEvent{
+		historyEvent(1, "app.fabric.created", "", `{"Code": "FAB01", "Name": "Linen", "Notes": "from the mill", "Version": 1}`),
+		historyEvent(2, "app.fabric.price_changed", "user_123", `{"Code": "FAB01", "Price": {"Amount": 2490}, "Version": 2}`),
+	}}
+
+	// --- Act ---
+	redacted := serveRedacted(t, NewFabricHistoryHandler(reader), "/v1/fabrics/FAB01/history")
+
+	// --- Assert ---
+	assert.Contains(t, redacted, `"Name"`)
+	assert.NotContains(t, redacted, `"Price"`)
+	assert.NotContains(t, redacted, `"Notes"`)
+}
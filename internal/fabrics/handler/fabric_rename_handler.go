@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricRenameService changes the code of a fabric.
+type FabricRenameService interface {
+	RenameFabric(ctx context.Context, code, newCode string, version int) (*domain.Fabric, error)
+}
+
+// FabricRenameHandler changes the code of a fabric. The previous code stays resolvable as
+// an alias, so nothing referencing it breaks.
+type FabricRenameHandler struct {
+	service FabricRenameService
+}
+
+type renameFabricRequest struct {
+	Code    string `json:"code"`
+	Version int    `json:"version"`
+}
+
+func NewFabricRenameHandler(service FabricRenameService) *FabricRenameHandler {
+	return &FabricRenameHandler{service: service}
+}
+
+func (h *FabricRenameHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	code := httpx.URLParam(r, "code")
+
+	var req renameFabricRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Code = validator.NormalizeCode(req.Code)
+	v := validator.New()
+	v.Check(req.Code != "", "code", "code must be provided")
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	fabric, err := h.service.RenameFabric(ctx, code, req.Code, req.Version)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabric": fabric}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
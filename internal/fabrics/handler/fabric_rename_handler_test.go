@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricRenameService struct {
+	code        string
+	newCode     string
+	version     int
+	errToReturn error
+}
+
+func (m *mockFabricRenameService) RenameFabric(ctx context.Context, code, newCode string, version int) (*domain.Fabric, error) {
+	m.code = code
+	m.newCode = newCode
+	m.version = version
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Fabric{Code: newCode, Version: version + 1}, nil
+}
+
+func serveRenameFabric(t *testing.T, handler *FabricRenameHandler, code, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/"+code+"/rename", strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", code)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricRenameHandler_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricRenameService{}
+	handler := NewFabricRenameHandler(svc)
+
+	// --- Act ---
+	responseRecorder := serveRenameFabric(t, handler, "OLD01", `{"code": " new01 ", "version": 2}`)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "OLD01", svc.code)
+	assert.Equal(t, "NEW01", svc.newCode, "the new code is normalized")
+	assert.Equal(t, 2, svc.version)
+	assert.Contains(t, responseRecorder.Body.String(), `"NEW01"`)
+}
+
+func TestFabricRenameHandler_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{name: "Missing code", body: `{"version": 1}`},
+		{name: "Missing version", body: `{"code": "NEW01"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricRenameService{}
+			handler := NewFabricRenameHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveRenameFabric(t, handler, "OLD01", tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+			assert.Empty(t, svc.code, "the service must not be called")
+		})
+	}
+}
+
+func TestFabricRenameHandler_ServiceErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "Unknown fabric", err: domain.ErrRecordNotFound, expectedStatus: http.StatusNotFound},
+		{name: "Code taken", err: domain.ErrDuplicateFabricCode, expectedStatus: http.StatusConflict},
+		{name: "Stale version", err: domain.ErrConcurrencyConflict, expectedStatus: http.StatusConflict},
+		{name: "Archived fabric", err: domain.ErrFabricArchived, expectedStatus: http.StatusConflict},
+		{name: "Same code", err: domain.ErrUnchangedFabricCode, expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewFabricRenameHandler(&mockFabricRenameService{errToReturn: tc.err})
+
+			// --- Act ---
+			responseRecorder := serveRenameFabric(t, handler, "OLD01", `{"code": "NEW01", "version": 1}`)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
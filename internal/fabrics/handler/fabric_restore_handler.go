@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricRestoreService brings deleted fabrics back.
+type FabricRestoreService interface {
+	RestoreFabric(ctx context.Context, code string, version int) (*domain.Fabric, error)
+}
+
+// FabricRestoreHandler undoes the deletion of a fabric. Unlike creating the fabric again,
+// the caller only confirms the version it saw; the fabric keeps the data it had.
+type FabricRestoreHandler struct {
+	service FabricRestoreService
+}
+
+type restoreFabricRequest struct {
+	Version int `json:"version"`
+}
+
+func NewFabricRestoreHandler(service FabricRestoreService) *FabricRestoreHandler {
+	return &FabricRestoreHandler{service: service}
+}
+
+func (h *FabricRestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req restoreFabricRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	fabric, err := h.service.RestoreFabric(ctx, httpx.URLParam(r, "code"), req.Version)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabric": fabric}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricRestoreService struct {
+	code        string
+	version     int
+	errToReturn error
+}
+
+func (m *mockFabricRestoreService) RestoreFabric(ctx context.Context, code string, version int) (*domain.Fabric, error) {
+	m.code = code
+	m.version = version
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Fabric{Code: code, Status: domain.StatusActive, Version: version + 1}, nil
+}
+
+func serveRestoreFabric(t *testing.T, handler *FabricRestoreHandler, code, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/"+code+"/restore", strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", code)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricRestoreHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{name: "restored", body: `{"version": 2}`, expectedStatus: http.StatusOK, expectedCall: true},
+		{name: "missing version", body: `{}`, expectedStatus: http.StatusUnprocessableEntity},
+		{
+			name: "not deleted", body: `{"version": 2}`, errToReturn: domain.ErrFabricNotDeleted,
+			expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "stale version", body: `{"version": 1}`, errToReturn: domain.ErrConcurrencyConflict,
+			expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "unknown fabric", body: `{"version": 2}`, errToReturn: domain.ErrRecordNotFound,
+			expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricRestoreService{errToReturn: tt.errToReturn}
+			handler := NewFabricRestoreHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveRestoreFabric(t, handler, "FAB01", tt.body)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, responseRecorder.Code)
+			if tt.expectedCall {
+				assert.Equal(t, "FAB01", svc.code)
+			} else {
+				assert.Empty(t, svc.code, "service should not be called with invalid input")
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// longest search accepted, in characters
+const fabricSearchMaxLength = 100
+
+// a search needs a word to look for
+var fabricSearchWordRX = regexp.MustCompile(`[\p{L}\p{N}]`)
+
+type FabricSearchRepository interface {
+	SearchFabrics(ctx context.Context, search domain.FabricSearch) ([]domain.FabricSearchHit, int, error)
+}
+
+// FabricSearchHandler finds active fabrics by partial or misspelled words of their code,
+// name and description, the best matches first:
+//
+//	GET /v1/fabrics/search?q=lin+natur&page=1&page_size=20
+//
+// Each hit carries its rank and the matched texts with the matching words highlighted.
+type FabricSearchHandler struct {
+	repo       FabricSearchRepository
+	pagination httpx.PaginationConfig
+	queryCost  httpx.QueryCostLimits
+}
+
+func NewFabricSearchHandler(
+	repo FabricSearchRepository, pagination httpx.PaginationConfig, queryCost httpx.QueryCostLimits,
+) *FabricSearchHandler {
+	return &FabricSearchHandler{
+		repo:       repo,
+		pagination: pagination,
+		queryCost:  queryCost,
+	}
+}
+
+func (h *FabricSearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	search := domain.FabricSearch{Query: validator.NormalizeText(r.URL.Query().Get("q"))}
+	v.Check(search.Query != "", "q", "q must be provided")
+	v.Check(
+		search.Query == "" || validator.Matches(search.Query, fabricSearchWordRX),
+		"q", "q must contain letters or numbers",
+	)
+	v.Check(len([]rune(search.Query)) <= fabricSearchMaxLength, "q", "q must not be more than 100 characters long")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+	search.Limit, search.Offset = page.Limit(), page.Offset()
+
+	// the search is backed by its own indexes, only its length is limited
+	costErr := httpx.CheckQueryCost(h.queryCost, httpx.QueryShape{
+		Search:      search.Query,
+		SortIndexed: true,
+		PageSize:    page.PageSize,
+	})
+	if costErr != nil {
+		httpx.QueryTooExpensive(w, r, costErr)
+		return
+	}
+
+	hits, totalRecords, err := h.repo.SearchFabrics(r.Context(), search)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabrics": hits, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricSearchRepository struct {
+	hitsToReturn  []domain.FabricSearchHit
+	totalToReturn int
+	search        domain.FabricSearch
+	called        bool
+}
+
+func (m *mockFabricSearchRepository) SearchFabrics(
+	ctx context.Context, search domain.FabricSearch,
+) ([]domain.FabricSearchHit, int, error) {
+	m.called = true
+	m.search = search
+	return m.hitsToReturn, m.totalToReturn, nil
+}
+
+func TestFabricSearchHandler_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := &mockFabricSearchRepository{
+		hitsToReturn: []domain.FabricSearchHit{{
+			Code: "LINNAT", Name: "Linen Natural", Rank: 0.9,
+			Highlights: domain.SearchHighlights{Name: "<mark>Linen</mark> Natural"},
+		}},
+		totalToReturn: 11,
+	}
+	handler := NewFabricSearchHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/fabrics/search?q=+lin++natur+&page=2&page_size=10", nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, req)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, domain.FabricSearch{Query: "lin natur", Limit: 10, Offset: 10}, mockRepo.search)
+
+	var response struct {
+		Fabrics  []domain.FabricSearchHit `json:"fabrics"`
+		Metadata httpx.PageMetadata       `json:"metadata"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	require.Len(t, response.Fabrics, 1)
+	assert.Equal(t, "<mark>Linen</mark> Natural", response.Fabrics[0].Highlights.Name)
+	assert.Equal(t, 11, response.Metadata.TotalRecords)
+}
+
+func TestFabricSearchHandler_RejectsInvalidQuery(t *testing.T) {
+	testCases := []struct {
+		name           string
+		target         string
+		expectedStatus int
+	}{
+		{name: "missing q", target: "/v1/fabrics/search", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "no words", target: "/v1/fabrics/search?q=%26%21%7C", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "too short", target: "/v1/fabrics/search?q=li", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			mockRepo := &mockFabricSearchRepository{}
+			handler := NewFabricSearchHandler(mockRepo, testPaginationConfig, httpx.DefaultQueryCostLimits)
+
+			req, err := http.NewRequest(http.MethodGet, tc.target, nil)
+			require.NoError(t, err)
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.False(t, mockRepo.called)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	stockActionAdjust  = "adjust"
+	stockActionReserve = "reserve"
+	stockActionRelease = "release"
+)
+
+// FabricStockService reads and moves the stock of fabrics.
+type FabricStockService interface {
+	GetStock(ctx context.Context, code string) (*domain.FabricStock, error)
+	AdjustStock(ctx context.Context, code, quantity, reason, warehouse string, version int) (*domain.FabricStock, error)
+	ReserveStock(
+		ctx context.Context, code, quantity, reference string, expiresAt *time.Time, version int,
+	) (*domain.FabricStock, error)
+	ReleaseStock(ctx context.Context, code, quantity, reference string, version int) (*domain.FabricStock, error)
+}
+
+// FabricStockHandler reports the stock of a fabric and adjusts, reserves and releases it.
+type FabricStockHandler struct {
+	service FabricStockService
+}
+
+// the quantity is a decimal string such as "12.5", negative only for adjustments. An
+// adjustment may name the warehouse it happened at, a reservation the time it expires at.
+// The version is required even though a fabric without stock is at version 0.
+type moveFabricStockRequest struct {
+	Quantity  string     `json:"quantity"`
+	Reason    string     `json:"reason"`
+	Reference string     `json:"reference"`
+	Warehouse string     `json:"warehouse"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Version   *int       `json:"version"`
+}
+
+func NewFabricStockHandler(service FabricStockService) *FabricStockHandler {
+	return &FabricStockHandler{service: service}
+}
+
+func (h *FabricStockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.getStock(w, r)
+	case http.MethodPost:
+		h.moveStock(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricStockHandler) getStock(w http.ResponseWriter, r *http.Request) {
+	stock, err := h.service.GetStock(r.Context(), httpx.URLParam(r, "code"))
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"stock": stock, "available": stock.Available()}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricStockHandler) moveStock(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	action := httpx.URLParam(r, "action")
+	if !validator.PermittedValue(action, stockActionAdjust, stockActionReserve, stockActionRelease) {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	var req moveFabricStockRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Quantity = validator.NormalizeText(req.Quantity)
+	req.Reason = validator.NormalizeText(req.Reason)
+	req.Reference = validator.NormalizeText(req.Reference)
+	req.Warehouse = validator.NormalizeCode(req.Warehouse)
+	v := validator.New()
+	v.Check(req.Quantity != "", "quantity", "quantity must be provided")
+	v.Check(req.Version != nil && *req.Version >= 0, "version", "version must be provided and not negative")
+	if action == stockActionAdjust {
+		v.Check(req.Reason != "", "reason", "reason must be provided")
+		v.Check(len(req.Warehouse) <= 30, "warehouse", "warehouse must not be more than 30 characters long")
+	} else {
+		v.Check(req.Reference != "", "reference", "reference must be provided")
+		v.Check(req.Warehouse == "", "warehouse", "warehouse can only be given for an adjustment")
+	}
+	if action != stockActionReserve {
+		v.Check(req.ExpiresAt == nil, "expires_at", "expires_at can only be given for a reservation")
+	}
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	code := httpx.URLParam(r, "code")
+	var (
+		stock *domain.FabricStock
+		err   error
+	)
+	switch action {
+	case stockActionAdjust:
+		stock, err = h.service.AdjustStock(ctx, code, req.Quantity, req.Reason, req.Warehouse, *req.Version)
+	case stockActionReserve:
+		stock, err = h.service.ReserveStock(ctx, code, req.Quantity, req.Reference, req.ExpiresAt, *req.Version)
+	case stockActionRelease:
+		stock, err = h.service.ReleaseStock(ctx, code, req.Quantity, req.Reference, *req.Version)
+	}
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"stock": stock, "available": stock.Available()}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricStockService struct {
+	called      string
+	quantity    string
+	note        string
+	warehouse   string
+	expiresAt   *time.Time
+	version     int
+	errToReturn error
+}
+
+func (m *mockFabricStockService) GetStock(ctx context.Context, code string) (*domain.FabricStock, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.FabricStock{Code: code, OnHand: 10000, Reserved: 2500, Version: 3}, nil
+}
+
+func (m *mockFabricStockService) AdjustStock(
+	ctx context.Context, code, quantity, reason, warehouse string, version int,
+) (*domain.FabricStock, error) {
+	m.warehouse = warehouse
+	return m.record("adjust", code, quantity, reason, version)
+}
+
+func (m *mockFabricStockService) ReserveStock(
+	ctx context.Context, code, quantity, reference string, expiresAt *time.Time, version int,
+) (*domain.FabricStock, error) {
+	m.expiresAt = expiresAt
+	return m.record("reserve", code, quantity, reference, version)
+}
+
+func (m *mockFabricStockService) ReleaseStock(
+	ctx context.Context, code, quantity, reference string, version int,
+) (*domain.FabricStock, error) {
+	return m.record("release", code, quantity, reference, version)
+}
+
+func (m *mockFabricStockService) record(
+	action, code, quantity, note string, version int,
+) (*domain.FabricStock, error) {
+	m.called, m.quantity, m.note, m.version = action, quantity, note, version
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.FabricStock{Code: code, Version: version + 1}, nil
+}
+
+func serveStock(t *testing.T, handler *FabricStockHandler, method, action, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	target := "/v1/fabrics/FAB01/stock"
+	if action != "" {
+		target += "/" + action
+	}
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", "FAB01")
+	rctx.URLParams.Add("action", action)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricStockHandler_GetStock(t *testing.T) {
+	// --- Arrange ---
+	handler := NewFabricStockHandler(&mockFabricStockService{})
+
+	// --- Act ---
+	responseRecorder := serveStock(t, handler, http.MethodGet, "", "")
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	var body struct {
+		Stock struct {
+			OnHand   string `json:"on_hand"`
+			Reserved string `json:"reserved"`
+			Version  int    `json:"version"`
+		} `json:"stock"`
+		Available string `json:"available"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	assert.Equal(t, "10", body.Stock.OnHand)
+	assert.Equal(t, "2.5", body.Stock.Reserved)
+	assert.Equal(t, "7.5", body.Available)
+	assert.Equal(t, 3, body.Stock.Version)
+}
+
+var testReservationExpiry = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFabricStockHandler_MoveStock(t *testing.T) {
+	testCases := []struct {
+		name              string
+		action            string
+		body              string
+		expectedNote      string
+		expectedWarehouse string
+		expectedExpiresAt *time.Time
+	}{
+		{name: "adjust", action: "adjust", body: `{"quantity": "-2.5", "reason": " stocktake ", "version": 0}`, expectedNote: "stocktake"},
+		{
+			name: "adjust at warehouse", action: "adjust",
+			body:         `{"quantity": "12", "reason": "goods receipt", "warehouse": " wh-ldz ", "version": 0}`,
+			expectedNote: "goods receipt", expectedWarehouse: "WH-LDZ",
+		},
+		{name: "reserve", action: "reserve", body: `{"quantity": "4", "reference": "ORDER-1", "version": 1}`, expectedNote: "ORDER-1"},
+		{
+			name: "reserve until", action: "reserve",
+			body:         `{"quantity": "4", "reference": "QUOTE-1", "expires_at": "2025-03-01T12:00:00Z", "version": 1}`,
+			expectedNote: "QUOTE-1", expectedExpiresAt: &testReservationExpiry,
+		},
+		{name: "release", action: "release", body: `{"quantity": "4", "reference": "ORDER-1", "version": 1}`, expectedNote: "ORDER-1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricStockService{}
+			handler := NewFabricStockHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveStock(t, handler, http.MethodPost, tc.action, tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Equal(t, tc.action, svc.called)
+			assert.Equal(t, tc.expectedNote, svc.note)
+			assert.Equal(t, tc.expectedWarehouse, svc.warehouse)
+			assert.Equal(t, tc.expectedExpiresAt, svc.expiresAt)
+		})
+	}
+}
+
+func TestFabricStockHandler_Rejected(t *testing.T) {
+	testCases := []struct {
+		name           string
+		action         string
+		body           string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{name: "unknown action", action: "steal", body: `{"quantity": "1", "version": 1}`, expectedStatus: http.StatusNotFound},
+		{name: "missing version", action: "adjust", body: `{"quantity": "1", "reason": "receipt"}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "missing reason", action: "adjust", body: `{"quantity": "1", "version": 0}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "missing reference", action: "reserve", body: `{"quantity": "1", "version": 0}`, expectedStatus: http.StatusUnprocessableEntity},
+		{
+			name: "reservation at warehouse", action: "reserve", body: `{"quantity": "1", "reference": "ORDER-1", "warehouse": "WH-LDZ", "version": 0}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "release with expiry", action: "release", body: `{"quantity": "1", "reference": "ORDER-1", "expires_at": "2025-03-01T12:00:00Z", "version": 0}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expiry passed", action: "reserve", body: `{"quantity": "1", "reference": "QUOTE-1", "expires_at": "2020-03-01T12:00:00Z", "version": 0}`,
+			errToReturn: domain.ErrReservationExpiryPassed, expectedStatus: http.StatusUnprocessableEntity, expectedCall: true,
+		},
+		{
+			name: "unknown warehouse", action: "adjust", body: `{"quantity": "1", "reason": "receipt", "warehouse": "WH-NOSUCH", "version": 0}`,
+			errToReturn: domain.ErrUnknownWarehouse, expectedStatus: http.StatusUnprocessableEntity, expectedCall: true,
+		},
+		{
+			name: "too little at warehouse", action: "adjust", body: `{"quantity": "-5", "reason": "write-off", "warehouse": "WH-LDZ", "version": 2}`,
+			errToReturn: domain.ErrInsufficientLocationStock, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "invalid quantity", action: "reserve", body: `{"quantity": "lots", "reference": "ORDER-1", "version": 1}`,
+			errToReturn: domain.ErrInvalidQuantity, expectedStatus: http.StatusUnprocessableEntity, expectedCall: true,
+		},
+		{
+			name: "insufficient stock", action: "reserve", body: `{"quantity": "100", "reference": "ORDER-1", "version": 1}`,
+			errToReturn: domain.ErrInsufficientStock, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "stale version", action: "adjust", body: `{"quantity": "1", "reason": "receipt", "version": 1}`,
+			errToReturn: domain.ErrConcurrencyConflict, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricStockService{errToReturn: tc.errToReturn}
+			handler := NewFabricStockHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveStock(t, handler, http.MethodPost, tc.action, tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called != "")
+		})
+	}
+}
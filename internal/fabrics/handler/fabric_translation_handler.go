@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FabricTranslationService maintains the names and descriptions of fabrics in other locales.
+type FabricTranslationService interface {
+	SetTranslation(
+		ctx context.Context, code, locale string, translation domain.Translation, version int,
+	) (*domain.Fabric, error)
+	RemoveTranslation(ctx context.Context, code, locale string, version int) (*domain.Fabric, error)
+}
+
+// FabricTranslationHandler sets and removes the translation of a fabric in the locale of
+// the path, e.g. /fabrics/VELVET01/translations/de.
+type FabricTranslationHandler struct {
+	service FabricTranslationService
+}
+
+type setTranslationRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Version     int    `json:"version"`
+}
+
+type removeTranslationRequest struct {
+	Version int `json:"version"`
+}
+
+func NewFabricTranslationHandler(service FabricTranslationService) *FabricTranslationHandler {
+	return &FabricTranslationHandler{service: service}
+}
+
+func (h *FabricTranslationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+	r = r.WithContext(ctx)
+
+	switch r.Method {
+	case http.MethodPut:
+		h.setTranslation(w, r)
+	case http.MethodDelete:
+		h.removeTranslation(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FabricTranslationHandler) setTranslation(w http.ResponseWriter, r *http.Request) {
+	var req setTranslationRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Name = validator.NormalizeText(req.Name)
+	v := validator.New()
+	v.Check(req.Name != "", "name", "name must be provided")
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	fabric, err := h.service.SetTranslation(
+		r.Context(), httpx.URLParam(r, "code"), httpx.URLParam(r, "locale"),
+		domain.Translation{Name: req.Name, Description: req.Description}, req.Version,
+	)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabric": fabric}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FabricTranslationHandler) removeTranslation(w http.ResponseWriter, r *http.Request) {
+	var req removeTranslationRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	fabric, err := h.service.RemoveTranslation(
+		r.Context(), httpx.URLParam(r, "code"), httpx.URLParam(r, "locale"), req.Version,
+	)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fabric": fabric}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
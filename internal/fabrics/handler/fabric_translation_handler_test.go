@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFabricTranslationService struct {
+	called      string
+	locale      string
+	translation domain.Translation
+	version     int
+	errToReturn error
+}
+
+func (m *mockFabricTranslationService) SetTranslation(
+	ctx context.Context, code, locale string, translation domain.Translation, version int,
+) (*domain.Fabric, error) {
+	m.called, m.locale, m.translation, m.version = "set", locale, translation, version
+	return m.result(code, version)
+}
+
+func (m *mockFabricTranslationService) RemoveTranslation(
+	ctx context.Context, code, locale string, version int,
+) (*domain.Fabric, error) {
+	m.called, m.locale, m.version = "remove", locale, version
+	return m.result(code, version)
+}
+
+func (m *mockFabricTranslationService) result(code string, version int) (*domain.Fabric, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Fabric{Code: code, Version: version + 1}, nil
+}
+
+func serveTranslation(
+	t *testing.T, handler *FabricTranslationHandler, method, locale, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "/v1/fabrics/FAB01/translations/"+locale, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", "FAB01")
+	rctx.URLParams.Add("locale", locale)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFabricTranslationHandler_SetTranslation(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricTranslationService{}
+	handler := NewFabricTranslationHandler(svc)
+
+	// --- Act ---
+	responseRecorder := serveTranslation(t, handler, http.MethodPut, "de",
+		`{"name": "  Samt ", "description": "Weicher *Samt*", "version": 2}`)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "set", svc.called)
+	assert.Equal(t, "de", svc.locale)
+	assert.Equal(t, domain.Translation{Name: "Samt", Description: "Weicher *Samt*"}, svc.translation)
+	assert.Equal(t, 2, svc.version)
+}
+
+func TestFabricTranslationHandler_RemoveTranslation(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricTranslationService{}
+	handler := NewFabricTranslationHandler(svc)
+
+	// --- Act ---
+	responseRecorder := serveTranslation(t, handler, http.MethodDelete, "de", `{"version": 3}`)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "remove", svc.called)
+	assert.Equal(t, 3, svc.version)
+}
+
+func TestFabricTranslationHandler_Rejected(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   string
+	}{
+		{name: "missing name", method: http.MethodPut, body: `{"version": 1}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "missing version", method: http.MethodPut, body: `{"name": "Samt"}`, expectedStatus: http.StatusUnprocessableEntity},
+		{
+			name: "invalid locale", method: http.MethodPut, body: `{"name": "Samt", "version": 1}`,
+			errToReturn: domain.ErrInvalidLocale, expectedStatus: http.StatusUnprocessableEntity, expectedCall: "set",
+		},
+		{
+			name: "no such translation", method: http.MethodDelete, body: `{"version": 1}`,
+			errToReturn: domain.ErrTranslationNotFound, expectedStatus: http.StatusNotFound, expectedCall: "remove",
+		},
+		{name: "wrong method", method: http.MethodPost, body: `{}`, expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricTranslationService{errToReturn: tc.errToReturn}
+			handler := NewFabricTranslationHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveTranslation(t, handler, tc.method, "de", tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called)
+		})
+	}
+}
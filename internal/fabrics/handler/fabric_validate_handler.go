@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	validateActionCreate = "create"
+	validateActionUpdate = "update"
+	validateActionDelete = "delete"
+
+	// upper bound for the items of a single batch, a nightly sync is split into several
+	maxValidateItems = 1000
+)
+
+// FabricValidationService runs the checks of a command without persisting anything.
+type FabricValidationService interface {
+	ValidateCreate(
+		ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification,
+	) error
+	ValidateUpdate(
+		ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification, version int,
+	) error
+	ValidateDelete(ctx context.Context, code string, version int) error
+}
+
+// FabricValidateHandler pre-flights a batch of fabric commands, so a sync can find out what
+// would be rejected before it changes anything. Each item is checked against the catalogue
+// as it is stored, not against the items before it in the batch.
+type FabricValidateHandler struct {
+	service FabricValidationService
+}
+
+type validateFabricsRequest struct {
+	Items []validateFabricItemRequest `json:"items"`
+}
+
+// a single command of the batch, the fields used depend on its action
+type validateFabricItemRequest struct {
+	Action        string                      `json:"action"`
+	Code          string                      `json:"code"`
+	Name          string                      `json:"name"`
+	MeasureUnit   string                      `json:"measure_unit"`
+	OfferStatus   string                      `json:"offer_status"`
+	Specification *fabricSpecificationRequest `json:"specification"`
+	Version       int                         `json:"version"`
+}
+
+// validateItemResult reports whether the command at the index of the batch would succeed
+type validateItemResult struct {
+	Index    int               `json:"index"`
+	Action   string            `json:"action"`
+	Code     string            `json:"code,omitempty"`
+	Valid    bool              `json:"valid"`
+	Errors   any               `json:"errors,omitempty"`
+	Warnings map[string]string `json:"warnings,omitempty"`
+}
+
+type validateResult struct {
+	Valid   int                  `json:"valid"`
+	Invalid int                  `json:"invalid"`
+	Results []validateItemResult `json:"results"`
+}
+
+func NewFabricValidateHandler(service FabricValidationService) *FabricValidateHandler {
+	return &FabricValidateHandler{service: service}
+}
+
+func (h *FabricValidateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	var req validateFabricsRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(len(req.Items) > 0, "items", "items must be provided")
+	v.Check(len(req.Items) <= maxValidateItems, "items", fmt.Sprintf("items must not be more than %d", maxValidateItems))
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	result := &validateResult{Results: make([]validateItemResult, 0, len(req.Items))}
+	seen := make(map[string]bool, len(req.Items))
+	for i := range req.Items {
+		item := h.validateItem(r.Context(), &req.Items[i], seen)
+		item.Index = i
+		if item.Valid {
+			result.Valid++
+		} else {
+			result.Invalid++
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"validation": result}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// validateItem runs the request and domain checks of a single command. A code repeated in
+// the batch is rejected, as its result would depend on the items before it.
+func (h *FabricValidateHandler) validateItem(
+	ctx context.Context, req *validateFabricItemRequest, seen map[string]bool,
+) validateItemResult {
+	req.Action = strings.ToLower(strings.TrimSpace(req.Action))
+	req.Code = validator.NormalizeCode(req.Code)
+	result := validateItemResult{Action: req.Action, Code: req.Code}
+
+	v := validator.New()
+	v.Check(
+		validator.PermittedValue(req.Action, validateActionCreate, validateActionUpdate, validateActionDelete),
+		"action", "action must be one of create, update or delete",
+	)
+	v.Check(req.Code != "", "code", "code must be provided")
+	v.Check(req.Code == "" || !seen[req.Code], "code", "code must appear only once in a batch")
+	seen[req.Code] = true
+	if !v.Valid() {
+		result.Errors = v.Errors
+		return result
+	}
+
+	var err error
+	switch req.Action {
+	case validateActionCreate:
+		create := createFabricRequest{
+			Code: req.Code, Name: req.Name, MeasureUnit: req.MeasureUnit,
+			OfferStatus: req.OfferStatus, Specification: req.Specification,
+		}
+		create.normalize()
+		validateCreateFabricRequest(v, &create)
+		if !v.Valid() {
+			break
+		}
+		normalizeFabricAttributes(v, &create.MeasureUnit, &create.OfferStatus)
+		err = h.service.ValidateCreate(
+			ctx, create.Code, create.Name, create.MeasureUnit, create.OfferStatus, create.Specification.toDomain(),
+		)
+	case validateActionUpdate:
+		update := updateFabricRequest{
+			Name: req.Name, MeasureUnit: req.MeasureUnit, OfferStatus: req.OfferStatus,
+			Specification: req.Specification, Version: req.Version,
+		}
+		update.normalize()
+		validateUpdateFabricRequest(v, &update)
+		if !v.Valid() {
+			break
+		}
+		normalizeFabricAttributes(v, &update.MeasureUnit, &update.OfferStatus)
+		err = h.service.ValidateUpdate(
+			ctx, req.Code, update.Name, update.MeasureUnit, update.OfferStatus,
+			update.Specification.toDomainOrNil(), update.Version,
+		)
+	case validateActionDelete:
+		v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+		if !v.Valid() {
+			break
+		}
+		err = h.service.ValidateDelete(ctx, req.Code, req.Version)
+	}
+
+	result.Warnings = v.Warnings
+	switch {
+	case !v.Valid():
+		result.Errors = v.Errors
+	case err != nil:
+		result.Errors = validateItemError(ctx, req.Code, err)
+	default:
+		result.Valid = true
+	}
+	return result
+}
+
+// validateItemError reports a failed check in the shape of the import failures
+func validateItemError(ctx context.Context, code string, err error) any {
+	domainErr, ok := domain.AsDomainError(err)
+	switch {
+	case ok && domainErr.Kind == domain.KindValidation:
+		return fieldErrors(domainErr)
+	case ok:
+		return domainErr.Message
+	default:
+		httpx.GetLogger(ctx).Error("failed to validate fabric", "code", code, "error", err)
+		return "the server could not validate this item"
+	}
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFabricValidationService fails the checks of the configured codes
+type mockFabricValidationService struct {
+	failures map[string]error
+	checked  []string
+}
+
+func (m *mockFabricValidationService) ValidateCreate(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec domain.Specification,
+) error {
+	return m.check("create " + code)
+}
+
+func (m *mockFabricValidationService) ValidateUpdate(
+	ctx context.Context, code, name, measureUnit, offerStatus string, spec *domain.Specification, version int,
+) error {
+	return m.check("update " + code)
+}
+
+func (m *mockFabricValidationService) ValidateDelete(ctx context.Context, code string, version int) error {
+	return m.check("delete " + code)
+}
+
+func (m *mockFabricValidationService) check(call string) error {
+	m.checked = append(m.checked, call)
+	return m.failures[call]
+}
+
+func serveValidate(t *testing.T, svc FabricValidationService, body string) (*httptest.ResponseRecorder, validateResult) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/fabrics/validate", strings.NewReader(body))
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+
+	NewFabricValidateHandler(svc).ServeHTTP(responseRecorder, req)
+
+	var response struct {
+		Validation validateResult `json:"validation"`
+	}
+	if responseRecorder.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	}
+	return responseRecorder, response.Validation
+}
+
+func TestFabricValidateHandler_ReportsPerItem(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricValidationService{failures: map[string]error{
+		"create DUP01": domain.ErrDuplicateFabricCode,
+		"update OLD01": domain.ErrConcurrencyConflict,
+		"delete GONE1": domain.ErrRecordNotFound,
+	}}
+	body := `{"items": [
+		{"action": "create", "code": "new01", "name": "Linen", "measure_unit": "m"},
+		{"action": "create", "code": "DUP01", "name": "Linen", "measure_unit": "m", "offer_status": "new"},
+		{"action": "update", "code": "OLD01", "name": "Wool", "version": 2},
+		{"action": "delete", "code": "GONE1", "version": 1},
+		{"action": "create", "code": "bad-1", "name": "Hyphenated"},
+		{"action": "rename", "code": "REN01"},
+		{"action": "create", "code": "NEW01", "name": "Linen again"}
+	]}`
+
+	// --- Act ---
+	rr, result := serveValidate(t, svc, body)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 1, result.Valid)
+	assert.Equal(t, 6, result.Invalid)
+	require.Len(t, result.Results, 7)
+
+	assert.True(t, result.Results[0].Valid)
+	assert.Equal(t, "NEW01", result.Results[0].Code, "codes are normalized as for a create")
+	assert.Contains(t, result.Results[0].Warnings, "offer_status", "corrections are reported as warnings")
+	assert.Equal(t, domain.ErrDuplicateFabricCode.Message, result.Results[1].Errors)
+	assert.Equal(t, domain.ErrConcurrencyConflict.Message, result.Results[2].Errors)
+	assert.Equal(t, domain.ErrRecordNotFound.Message, result.Results[3].Errors)
+	assert.Contains(t, result.Results[4].Errors, "code")
+	assert.Contains(t, result.Results[5].Errors, "action")
+	assert.Equal(t, map[string]any{"code": "code must appear only once in a batch"}, result.Results[6].Errors)
+	for i, item := range result.Results {
+		assert.Equal(t, i, item.Index)
+	}
+	assert.Equal(t, []string{"create NEW01", "create DUP01", "update OLD01", "delete GONE1"}, svc.checked,
+		"items failing the request checks never reach the service")
+}
+
+func TestFabricValidateHandler_DomainValidationError(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockFabricValidationService{failures: map[string]error{
+		"update FAB01": domain.ErrInvalidMeasureUnit,
+		"create FAB02": errors.New("connection reset"),
+	}}
+	body := `{"items": [
+		{"action": "update", "code": "FAB01", "name": "Linen", "measure_unit": "yard", "offer_status": "new", "version": 3},
+		{"action": "create", "code": "FAB02", "name": "Wool", "measure_unit": "m", "offer_status": "new"}
+	]}`
+
+	// --- Act ---
+	rr, result := serveValidate(t, svc, body)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Len(t, result.Results, 2)
+	assert.Contains(t, result.Results[0].Errors, "measure_unit", "domain validation errors are keyed by field")
+	assert.Equal(t, "the server could not validate this item", result.Results[1].Errors,
+		"unexpected failures are not leaked")
+}
+
+func TestFabricValidateHandler_RejectsBatch(t *testing.T) {
+	tooMany := make([]string, maxValidateItems+1)
+	for i := range tooMany {
+		tooMany[i] = `{"action": "delete", "code": "FAB01", "version": 1}`
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "no items", body: `{"items": []}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "too many items", body: `{"items": [` + strings.Join(tooMany, ",") + `]}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "badly-formed JSON", body: `{"items": [`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockFabricValidationService{}
+
+			// --- Act ---
+			rr, _ := serveValidate(t, svc, tt.body)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Empty(t, svc.checked)
+		})
+	}
+}
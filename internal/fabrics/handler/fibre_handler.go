@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// FibreHandler lists the reference list of fibres compositions may name and lets
+// administrators add, rename and remove fibres, e.g. /admin/fibres/cotton.
+type FibreHandler struct {
+	fibres domain.FibreRepository
+	clock  clock.Clock
+}
+
+type saveFibreRequest struct {
+	Name string `json:"name"`
+}
+
+func NewFibreHandler(fibres domain.FibreRepository, clock clock.Clock) *FibreHandler {
+	return &FibreHandler{
+		fibres: fibres,
+		clock:  clock,
+	}
+}
+
+func (h *FibreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listFibres(w, r)
+	case http.MethodPut:
+		h.saveFibre(w, r)
+	case http.MethodDelete:
+		h.deleteFibre(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *FibreHandler) listFibres(w http.ResponseWriter, r *http.Request) {
+	fibres, err := h.fibres.ListFibres(r.Context())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fibres": fibres}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FibreHandler) saveFibre(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+
+	var req saveFibreRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Name = validator.NormalizeText(req.Name)
+	v := validator.New()
+	v.Check(req.Name != "", "name", "name must be provided")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	fibre, err := domain.NewFibre(
+		httpx.URLParam(r, "code"), req.Name, domain.Stamp{By: command.Actor(ctx), At: h.clock.Now()},
+	)
+	if err == nil {
+		err = h.fibres.SaveFibre(ctx, fibre)
+	}
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"fibre": fibre}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *FibreHandler) deleteFibre(w http.ResponseWriter, r *http.Request) {
+	if err := h.fibres.DeleteFibre(r.Context(), httpx.URLParam(r, "code")); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
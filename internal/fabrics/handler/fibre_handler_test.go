@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFibreRepository keeps the reference list in memory, the codes in inUse stand for
+// fibres compositions name
+type mockFibreRepository struct {
+	fibres []*domain.Fibre
+	inUse  map[string]bool
+}
+
+func (m *mockFibreRepository) SaveFibre(ctx context.Context, fibre *domain.Fibre) error {
+	for i, existing := range m.fibres {
+		if existing.Code == fibre.Code {
+			m.fibres[i].Name = fibre.Name
+			return nil
+		}
+	}
+	m.fibres = append(m.fibres, fibre)
+	return nil
+}
+
+func (m *mockFibreRepository) ListFibres(ctx context.Context) ([]*domain.Fibre, error) {
+	return m.fibres, nil
+}
+
+func (m *mockFibreRepository) DeleteFibre(ctx context.Context, code string) error {
+	if !slices.ContainsFunc(m.fibres, func(f *domain.Fibre) bool { return f.Code == code }) {
+		return domain.ErrFibreNotFound
+	}
+	if m.inUse[code] {
+		return domain.ErrFibreInUse
+	}
+	m.fibres = slices.DeleteFunc(m.fibres, func(f *domain.Fibre) bool { return f.Code == code })
+	return nil
+}
+
+func serveFibre(t *testing.T, handler *FibreHandler, method, code, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "/v1/admin/fibres/"+code, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", code)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestFibreHandler_SaveFibre(t *testing.T) {
+	testCases := []struct {
+		name           string
+		code           string
+		body           string
+		expectedStatus int
+		expectedName   string
+	}{
+		{name: "Add", code: "lyocell", body: `{"name": "Lyocell"}`, expectedStatus: http.StatusOK, expectedName: "Lyocell"},
+		{name: "Rename", code: "cotton", body: `{"name": "Organic cotton"}`, expectedStatus: http.StatusOK, expectedName: "Organic cotton"},
+		{name: "Invalid code", code: "Lyocell", body: `{"name": "Lyocell"}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "No name", code: "lyocell", body: `{}`, expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fibres := &mockFibreRepository{fibres: []*domain.Fibre{{Code: "cotton", Name: "Cotton"}}}
+			handler := NewFibreHandler(fibres, testClock)
+
+			// --- Act ---
+			responseRecorder := serveFibre(t, handler, http.MethodPut, tc.code, tc.body)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedName == "" {
+				assert.Len(t, fibres.fibres, 1, "nothing is added to the list")
+				return
+			}
+			var response struct {
+				Fibre domain.Fibre `json:"fibre"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+			assert.Equal(t, tc.code, response.Fibre.Code)
+			assert.Equal(t, tc.expectedName, response.Fibre.Name)
+		})
+	}
+}
+
+func TestFibreHandler_DeleteFibre(t *testing.T) {
+	testCases := []struct {
+		name           string
+		code           string
+		expectedStatus int
+	}{
+		{name: "Unused fibre", code: "silk", expectedStatus: http.StatusNoContent},
+		{name: "Fibre in use", code: "cotton", expectedStatus: http.StatusConflict},
+		{name: "Unknown fibre", code: "kevlar", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			fibres := &mockFibreRepository{
+				fibres: []*domain.Fibre{{Code: "cotton", Name: "Cotton"}, {Code: "silk", Name: "Silk"}},
+				inUse:  map[string]bool{"cotton": true},
+			}
+			handler := NewFibreHandler(fibres, testClock)
+
+			// --- Act ---
+			responseRecorder := serveFibre(t, handler, http.MethodDelete, tc.code, "")
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+)
+
+// MessageRoutes lists the subject patterns consumed messages are routed by.
+type MessageRoutes interface {
+	Patterns() []string
+}
+
+// MessageRouteHandler lets an operator check which subjects the service handles.
+type MessageRouteHandler struct {
+	routes MessageRoutes
+}
+
+func NewMessageRouteHandler(routes MessageRoutes) *MessageRouteHandler {
+	return &MessageRouteHandler{routes: routes}
+}
+
+func (h *MessageRouteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"patterns": h.routes.Patterns()}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
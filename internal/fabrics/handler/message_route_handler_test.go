@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMessageRoutes struct {
+	patterns []string
+}
+
+func (m *mockMessageRoutes) Patterns() []string {
+	return m.patterns
+}
+
+func TestMessageRouteHandler(t *testing.T) {
+	t.Run("lists the registered patterns", func(t *testing.T) {
+		// --- Arrange ---
+		handler := NewMessageRouteHandler(&mockMessageRoutes{patterns: []string{"erp.fabric"}})
+		responseRecorder := httptest.NewRecorder()
+
+		// --- Act ---
+		handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/v1/admin/messaging/routes", nil))
+
+		// --- Assert ---
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		var body struct {
+			Patterns []string `json:"patterns"`
+		}
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+		assert.Equal(t, []string{"erp.fabric"}, body.Patterns)
+	})
+
+	t.Run("only reads", func(t *testing.T) {
+		// --- Arrange ---
+		handler := NewMessageRouteHandler(&mockMessageRoutes{})
+		responseRecorder := httptest.NewRecorder()
+
+		// --- Act ---
+		handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodPost, "/v1/admin/messaging/routes", nil))
+
+		// --- Assert ---
+		assert.Equal(t, http.StatusMethodNotAllowed, responseRecorder.Code)
+	})
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+)
+
+type TenantUsageService interface {
+	Usage(ctx context.Context) (domain.TenantQuota, domain.TenantUsage, error)
+}
+
+// TenantUsageHandler reports what the tenant holds against its quota, so users can tell
+// how much room is left before their commands are refused.
+type TenantUsageHandler struct {
+	service TenantUsageService
+}
+
+func NewTenantUsageHandler(service TenantUsageService) *TenantUsageHandler {
+	return &TenantUsageHandler{service: service}
+}
+
+// resourceUsage is the use of a limited resource, the limit omitted when there is none
+type resourceUsage struct {
+	Used  int64 `json:"used"`
+	Limit int64 `json:"limit,omitempty"`
+}
+
+func (h *TenantUsageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	quota, usage, err := h.service.Usage(r.Context())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{
+		"tenant":           quota.Tenant,
+		"fabrics":          resourceUsage{Used: int64(usage.Fabrics), Limit: int64(quota.MaxFabrics)},
+		"attachment_bytes": resourceUsage{Used: usage.AttachmentBytes, Limit: quota.MaxAttachmentBytes},
+	}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
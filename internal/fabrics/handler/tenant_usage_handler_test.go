@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTenantUsageService struct {
+	quota       domain.TenantQuota
+	usage       domain.TenantUsage
+	errToReturn error
+}
+
+func (m *mockTenantUsageService) Usage(ctx context.Context) (domain.TenantQuota, domain.TenantUsage, error) {
+	return m.quota, m.usage, m.errToReturn
+}
+
+func TestTenantUsageHandler(t *testing.T) {
+	// --- Arrange ---
+	handler := NewTenantUsageHandler(&mockTenantUsageService{
+		quota: domain.TenantQuota{Tenant: "acme", MaxFabrics: 100},
+		usage: domain.TenantUsage{Fabrics: 42, AttachmentBytes: 2048},
+	})
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/v1/tenant/usage", nil))
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Equal(t, "acme", response["tenant"])
+	assert.Equal(t, map[string]any{"used": float64(42), "limit": float64(100)}, response["fabrics"])
+	assert.Equal(t, map[string]any{"used": float64(2048)}, response["attachment_bytes"], "an unlimited resource has no limit")
+}
+
+func TestTenantUsageHandler_Failure(t *testing.T) {
+	// --- Arrange ---
+	handler := NewTenantUsageHandler(&mockTenantUsageService{errToReturn: errors.New("database is down")})
+	responseRecorder := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/v1/tenant/usage", nil))
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+}
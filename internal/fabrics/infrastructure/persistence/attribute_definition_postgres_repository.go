@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type AttributeDefinitionPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewAttributeDefinitionPostgresRepository(db *database.PostgresDB) *AttributeDefinitionPostgresRepository {
+	return &AttributeDefinitionPostgresRepository{
+		db: db,
+	}
+}
+
+const attributeDefinitionColumns = `key, type, required, description, created_at, created_by, updated_at, updated_by`
+
+// attributeInUseSQL is true when a fabric holds a value of the attribute given as $1
+const attributeInUseSQL = `EXISTS (SELECT 1 FROM fabrics WHERE custom_attributes ? $1)`
+
+// SaveDefinition registers an attribute, or updates the registration keeping who created
+// it. The type only changes while no fabric holds a value of the attribute.
+func (r *AttributeDefinitionPostgresRepository) SaveDefinition(
+	ctx context.Context, definition *domain.AttributeDefinition,
+) error {
+	query := `
+		INSERT INTO fabric_attribute_definitions (` + attributeDefinitionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (key) DO UPDATE
+		SET type = EXCLUDED.type, required = EXCLUDED.required, description = EXCLUDED.description,
+			updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by
+		WHERE fabric_attribute_definitions.type = EXCLUDED.type OR NOT ` + attributeInUseSQL + `
+		RETURNING created_at, created_by
+	`
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query,
+		definition.Key, definition.Type, definition.Required, definition.Description,
+		definition.CreatedAt, definition.CreatedBy, definition.UpdatedAt, definition.UpdatedBy,
+	).Scan(&definition.CreatedAt, &definition.CreatedBy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrAttributeDefinitionInUse.WithParam("key", definition.Key)
+		}
+		return fmt.Errorf("failed to save attribute definition: %w", err)
+	}
+	return nil
+}
+
+func (r *AttributeDefinitionPostgresRepository) GetDefinition(
+	ctx context.Context, key string,
+) (*domain.AttributeDefinition, error) {
+	query := `SELECT ` + attributeDefinitionColumns + ` FROM fabric_attribute_definitions WHERE key = $1`
+	definition, err := scanAttributeDefinition(r.db.Conn(ctx).QueryRowContext(ctx, query, key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAttributeDefinitionNotFound
+		}
+		return nil, fmt.Errorf("failed to get attribute definition: %w", err)
+	}
+	return definition, nil
+}
+
+func (r *AttributeDefinitionPostgresRepository) ListDefinitions(ctx context.Context) ([]*domain.AttributeDefinition, error) {
+	query := `SELECT ` + attributeDefinitionColumns + ` FROM fabric_attribute_definitions ORDER BY key`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attribute definitions: %w", err)
+	}
+	defer rows.Close()
+
+	definitions := []*domain.AttributeDefinition{}
+	for rows.Next() {
+		definition, err := scanAttributeDefinition(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attribute definition: %w", err)
+		}
+		definitions = append(definitions, definition)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate attribute definitions: %w", err)
+	}
+	return definitions, nil
+}
+
+// DeleteDefinition removes the registration of an attribute unless a fabric, deleted ones
+// included, still holds a value of it.
+func (r *AttributeDefinitionPostgresRepository) DeleteDefinition(ctx context.Context, key string) error {
+	query := `
+		DELETE FROM fabric_attribute_definitions
+		WHERE key = $1 AND NOT ` + attributeInUseSQL + `
+	`
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete attribute definition: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		if _, err := r.GetDefinition(ctx, key); err != nil {
+			return err
+		}
+		return domain.ErrAttributeDefinitionInUse.WithParam("key", key)
+	}
+	return nil
+}
+
+func scanAttributeDefinition(row interface{ Scan(dest ...any) error }) (*domain.AttributeDefinition, error) {
+	definition := &domain.AttributeDefinition{}
+	err := row.Scan(
+		&definition.Key, &definition.Type, &definition.Required, &definition.Description,
+		&definition.CreatedAt, &definition.CreatedBy, &definition.UpdatedAt, &definition.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type FabricAliasPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricAliasPostgresRepository(db *database.PostgresDB) *FabricAliasPostgresRepository {
+	return &FabricAliasPostgresRepository{
+		db: db,
+	}
+}
+
+// AddAlias points an alternate code at a fabric that is neither deleted nor merged. The
+// alias must not clash with the code of any fabric, deleted ones included, nor with
+// another alias.
+func (r *FabricAliasPostgresRepository) AddAlias(ctx context.Context, alias *domain.FabricAlias) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM fabrics WHERE code = $1 FOR UPDATE`, alias.CanonicalCode).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrRecordNotFound
+		}
+		return fmt.Errorf("failed to lock canonical fabric: %w", err)
+	}
+	if status == domain.StatusDeleted || status == domain.StatusMerged {
+		return domain.ErrRecordNotFound
+	}
+
+	var taken bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM fabrics WHERE code = $1)`, alias.AliasCode).Scan(&taken)
+	if err != nil {
+		return fmt.Errorf("failed to check alias code: %w", err)
+	}
+	if taken {
+		return domain.ErrDuplicateFabricCode
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO fabric_aliases (alias_code, canonical_code, created_at, created_by)
+		VALUES ($1, $2, $3, $4)
+	`, alias.AliasCode, alias.CanonicalCode, alias.CreatedAt, alias.CreatedBy)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrDuplicateFabricCode
+		}
+		return fmt.Errorf("failed to insert fabric alias: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *FabricAliasPostgresRepository) RemoveAlias(ctx context.Context, canonicalCode, aliasCode string) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx,
+		`DELETE FROM fabric_aliases WHERE alias_code = $1 AND canonical_code = $2`,
+		aliasCode, canonicalCode,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete fabric alias: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected post-delete: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *FabricAliasPostgresRepository) ListAliases(ctx context.Context, canonicalCode string) ([]*domain.FabricAlias, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT alias_code, canonical_code, created_at, created_by
+		FROM fabric_aliases
+		WHERE canonical_code = $1
+		ORDER BY alias_code
+	`, canonicalCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fabric aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []*domain.FabricAlias{}
+	for rows.Next() {
+		alias := &domain.FabricAlias{}
+		if err := rows.Scan(&alias.AliasCode, &alias.CanonicalCode, &alias.CreatedAt, &alias.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan fabric alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate fabric aliases: %w", err)
+	}
+	return aliases, nil
+}
@@ -0,0 +1,129 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type FabricAttachmentPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricAttachmentPostgresRepository(db *database.PostgresDB) *FabricAttachmentPostgresRepository {
+	return &FabricAttachmentPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *FabricAttachmentPostgresRepository) GetFabricCode(ctx context.Context, code string) (string, error) {
+	var fabricCode string
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		`SELECT code FROM fabrics WHERE code = `+canonicalCodeSQL+` AND `+liveStatusSQL, code,
+	).Scan(&fabricCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("fabric with code %s not found: %w", code, domain.ErrRecordNotFound)
+		}
+		return "", fmt.Errorf("failed to find fabric: %w", err)
+	}
+	return fabricCode, nil
+}
+
+func (r *FabricAttachmentPostgresRepository) SaveAttachment(ctx context.Context, attachment *domain.FabricAttachment) error {
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		INSERT INTO fabric_attachments
+			(id, fabric_code, kind, file_name, content_type, size, storage_key, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, attachment.ID, attachment.FabricCode, attachment.Kind, attachment.FileName, attachment.ContentType,
+		attachment.Size, attachment.StorageKey, attachment.CreatedAt, attachment.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save fabric attachment: %w", err)
+	}
+	return nil
+}
+
+// GetAttachment returns the attachment of the fabric, given by its code or one of its aliases.
+func (r *FabricAttachmentPostgresRepository) GetAttachment(
+	ctx context.Context, fabricCode, id string,
+) (*domain.FabricAttachment, error) {
+	row := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT id, fabric_code, kind, file_name, content_type, size, storage_key, created_at, created_by
+		FROM fabric_attachments
+		WHERE fabric_code = `+canonicalCodeSQL+` AND id = $2
+	`, fabricCode, id)
+
+	attachment, err := scanAttachment(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("fabric attachment %s not found: %w", id, domain.ErrRecordNotFound)
+		}
+		return nil, fmt.Errorf("failed to get fabric attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+func (r *FabricAttachmentPostgresRepository) ListAttachments(
+	ctx context.Context, fabricCode string,
+) ([]*domain.FabricAttachment, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT id, fabric_code, kind, file_name, content_type, size, storage_key, created_at, created_by
+		FROM fabric_attachments
+		WHERE fabric_code = `+canonicalCodeSQL+`
+		ORDER BY created_at, id
+	`, fabricCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fabric attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []*domain.FabricAttachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fabric attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate fabric attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *FabricAttachmentPostgresRepository) DeleteAttachment(ctx context.Context, attachment *domain.FabricAttachment) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx,
+		`DELETE FROM fabric_attachments WHERE id = $1`, attachment.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete fabric attachment: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected post-delete: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrRecordNotFound
+	}
+	return nil
+}
+
+type attachmentScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAttachment(row attachmentScanner) (*domain.FabricAttachment, error) {
+	attachment := &domain.FabricAttachment{}
+	err := row.Scan(
+		&attachment.ID, &attachment.FabricCode, &attachment.Kind, &attachment.FileName, &attachment.ContentType,
+		&attachment.Size, &attachment.StorageKey, &attachment.CreatedAt, &attachment.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
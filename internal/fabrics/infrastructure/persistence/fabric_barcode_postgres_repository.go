@@ -0,0 +1,118 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type FabricBarcodePostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricBarcodePostgresRepository(db *database.PostgresDB) *FabricBarcodePostgresRepository {
+	return &FabricBarcodePostgresRepository{
+		db: db,
+	}
+}
+
+// AddBarcode assigns a barcode to a fabric that is neither deleted nor merged. The primary
+// key on the barcode keeps it unique across all fabrics, deleted ones included.
+func (r *FabricBarcodePostgresRepository) AddBarcode(ctx context.Context, barcode *domain.FabricBarcode) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM fabrics WHERE code = $1 FOR UPDATE`, barcode.FabricCode).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrRecordNotFound
+		}
+		return fmt.Errorf("failed to lock fabric: %w", err)
+	}
+	if status == domain.StatusDeleted || status == domain.StatusMerged {
+		return domain.ErrRecordNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO fabric_barcodes (barcode, fabric_code, created_at, created_by)
+		VALUES ($1, $2, $3, $4)
+	`, barcode.Barcode, barcode.FabricCode, barcode.CreatedAt, barcode.CreatedBy)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrBarcodeTaken.WithParam("barcode", barcode.Barcode)
+		}
+		return fmt.Errorf("failed to insert fabric barcode: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *FabricBarcodePostgresRepository) RemoveBarcode(ctx context.Context, fabricCode, barcode string) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx,
+		`DELETE FROM fabric_barcodes WHERE barcode = $1 AND fabric_code = $2`,
+		barcode, fabricCode,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete fabric barcode: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected post-delete: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *FabricBarcodePostgresRepository) ListBarcodes(ctx context.Context, fabricCode string) ([]*domain.FabricBarcode, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT barcode, fabric_code, created_at, created_by
+		FROM fabric_barcodes
+		WHERE fabric_code = $1
+		ORDER BY barcode
+	`, fabricCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fabric barcodes: %w", err)
+	}
+	defer rows.Close()
+
+	barcodes := []*domain.FabricBarcode{}
+	for rows.Next() {
+		barcode := &domain.FabricBarcode{}
+		if err := rows.Scan(&barcode.Barcode, &barcode.FabricCode, &barcode.CreatedAt, &barcode.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan fabric barcode: %w", err)
+		}
+		barcodes = append(barcodes, barcode)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate fabric barcodes: %w", err)
+	}
+	return barcodes, nil
+}
+
+func (r *FabricBarcodePostgresRepository) GetBarcode(ctx context.Context, barcode string) (*domain.FabricBarcode, error) {
+	found := &domain.FabricBarcode{}
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT barcode, fabric_code, created_at, created_by
+		FROM fabric_barcodes
+		WHERE barcode = $1
+	`, barcode).Scan(&found.Barcode, &found.FabricCode, &found.CreatedAt, &found.CreatedBy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get fabric barcode: %w", err)
+	}
+	return found, nil
+}
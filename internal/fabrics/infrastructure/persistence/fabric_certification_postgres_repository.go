@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type FabricCertificationPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricCertificationPostgresRepository(db *database.PostgresDB) *FabricCertificationPostgresRepository {
+	return &FabricCertificationPostgresRepository{
+		db: db,
+	}
+}
+
+const certificationColumns = `id, fabric_code, scheme, certificate_number, valid_from, valid_until, expired_at,
+	created_at, created_by`
+
+func (r *FabricCertificationPostgresRepository) GetFabricCode(ctx context.Context, code string) (string, error) {
+	var fabricCode string
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		`SELECT code FROM fabrics WHERE code = `+canonicalCodeSQL+` AND `+liveStatusSQL, code,
+	).Scan(&fabricCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("fabric with code %s not found: %w", code, domain.ErrRecordNotFound)
+		}
+		return "", fmt.Errorf("failed to find fabric: %w", err)
+	}
+	return fabricCode, nil
+}
+
+func (r *FabricCertificationPostgresRepository) SaveCertification(
+	ctx context.Context, certification *domain.FabricCertification,
+) error {
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		INSERT INTO fabric_certifications (`+certificationColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, certification.ID, certification.FabricCode, certification.Scheme, certification.CertificateNumber,
+		certification.ValidFrom, certification.ValidUntil, certification.ExpiredAt,
+		certification.CreatedAt, certification.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save fabric certification: %w", err)
+	}
+	return nil
+}
+
+func (r *FabricCertificationPostgresRepository) ListCertifications(
+	ctx context.Context, fabricCode string,
+) ([]*domain.FabricCertification, error) {
+	return r.queryCertifications(ctx, `
+		SELECT `+certificationColumns+`
+		FROM fabric_certifications
+		WHERE fabric_code = `+canonicalCodeSQL+`
+		ORDER BY valid_until DESC, id
+	`, fabricCode)
+}
+
+func (r *FabricCertificationPostgresRepository) DueForExpiry(
+	ctx context.Context, at time.Time, limit int,
+) ([]*domain.FabricCertification, error) {
+	return r.queryCertifications(ctx, `
+		SELECT `+certificationColumns+`
+		FROM fabric_certifications
+		WHERE expired_at IS NULL AND valid_until <= $1
+		ORDER BY valid_until, id
+		LIMIT $2
+	`, at, limit)
+}
+
+// MarkExpired sets the expiry of the certification unless it was already set, so
+// concurrent sweeps record each expiry once.
+func (r *FabricCertificationPostgresRepository) MarkExpired(
+	ctx context.Context, certification *domain.FabricCertification,
+) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx,
+		`UPDATE fabric_certifications SET expired_at = $2 WHERE id = $1 AND expired_at IS NULL`,
+		certification.ID, certification.ExpiredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark fabric certification expired: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected post-update: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrCertificationAlreadyExpired
+	}
+	return nil
+}
+
+func (r *FabricCertificationPostgresRepository) queryCertifications(
+	ctx context.Context, query string, args ...any,
+) ([]*domain.FabricCertification, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fabric certifications: %w", err)
+	}
+	defer rows.Close()
+
+	certifications := []*domain.FabricCertification{}
+	for rows.Next() {
+		certification := &domain.FabricCertification{}
+		err := rows.Scan(
+			&certification.ID, &certification.FabricCode, &certification.Scheme, &certification.CertificateNumber,
+			&certification.ValidFrom, &certification.ValidUntil, &certification.ExpiredAt,
+			&certification.CreatedAt, &certification.CreatedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fabric certification: %w", err)
+		}
+		certifications = append(certifications, certification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate fabric certifications: %w", err)
+	}
+	return certifications, nil
+}
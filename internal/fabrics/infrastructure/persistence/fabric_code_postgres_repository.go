@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+// upper bound for the taken codes skipped by a single reservation, a run longer than this
+// means the ERP assigns codes in the range of the sequence
+const maxCodeProbes = 1000
+
+type FabricCodePostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricCodePostgresRepository(db *database.PostgresDB) *FabricCodePostgresRepository {
+	return &FabricCodePostgresRepository{
+		db: db,
+	}
+}
+
+// ReserveCode allocates the next free code of the sequence. The sequence row is locked for
+// the whole allocation, so concurrent reservations of a prefix are handed distinct codes.
+// Codes already used by a fabric, deleted ones included, or by an alias are skipped.
+func (r *FabricCodePostgresRepository) ReserveCode(
+	ctx context.Context, sequence domain.CodeSequence, reservation *domain.CodeReservation,
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO fabric_code_sequences (prefix) VALUES ($1) ON CONFLICT (prefix) DO NOTHING`, sequence.Prefix,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create code sequence: %w", err)
+	}
+
+	var next int64
+	err = tx.QueryRowContext(ctx,
+		`SELECT next_value FROM fabric_code_sequences WHERE prefix = $1 FOR UPDATE`, sequence.Prefix,
+	).Scan(&next)
+	if err != nil {
+		return fmt.Errorf("failed to lock code sequence: %w", err)
+	}
+
+	for probe := 0; probe < maxCodeProbes; probe, next = probe+1, next+1 {
+		code, err := sequence.Format(next)
+		if err != nil {
+			return err
+		}
+
+		var taken bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM fabrics WHERE code = $1)
+				OR EXISTS (SELECT 1 FROM fabric_aliases WHERE alias_code = $1)
+				OR EXISTS (SELECT 1 FROM fabric_code_reservations WHERE code = $1)
+				OR EXISTS (SELECT 1 FROM fabric_purges WHERE code = $1)
+		`, code).Scan(&taken)
+		if err != nil {
+			return fmt.Errorf("failed to check code %s: %w", code, err)
+		}
+		if taken {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO fabric_code_reservations (code, prefix, reserved_by, reserved_at)
+			VALUES ($1, $2, $3, $4)
+		`, code, reservation.Prefix, reservation.ReservedBy, reservation.ReservedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert code reservation: %w", err)
+		}
+		_, err = tx.ExecContext(ctx,
+			`UPDATE fabric_code_sequences SET next_value = $2 WHERE prefix = $1`, sequence.Prefix, next+1,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to advance code sequence: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		reservation.Code = code
+		return nil
+	}
+
+	return fmt.Errorf("no free code of sequence %s within %d numbers of %d", sequence.Prefix, maxCodeProbes, next)
+}
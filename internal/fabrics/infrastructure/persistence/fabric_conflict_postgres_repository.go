@@ -0,0 +1,146 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type FabricConflictPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricConflictPostgresRepository(db *database.PostgresDB) *FabricConflictPostgresRepository {
+	return &FabricConflictPostgresRepository{
+		db: db,
+	}
+}
+
+const conflictColumns = `id, event_id, event_type, code, name, measure_unit, offer_status,
+	expected_version, current_version, status, created_at, resolved_at, resolved_by`
+
+// SaveConflict queues a conflict for review. A redelivered event is queued only once.
+func (r *FabricConflictPostgresRepository) SaveConflict(ctx context.Context, conflict *domain.FabricConflict) error {
+	query := `
+		INSERT INTO fabric_conflicts (
+			event_id, event_type, code, name, measure_unit, offer_status,
+			expected_version, current_version, status, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (event_id) DO NOTHING
+		RETURNING id
+	`
+	args := []any{
+		conflict.EventID, conflict.EventType, conflict.Code, conflict.Name, conflict.MeasureUnit,
+		conflict.OfferStatus, conflict.ExpectedVersion, conflict.CurrentVersion, conflict.Status,
+		conflict.CreatedAt,
+	}
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query, args...).Scan(&conflict.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to save fabric conflict: %w", err)
+	}
+	return nil
+}
+
+func (r *FabricConflictPostgresRepository) GetConflict(ctx context.Context, id int64) (*domain.FabricConflict, error) {
+	query := `SELECT ` + conflictColumns + ` FROM fabric_conflicts WHERE id = $1`
+	conflict, err := scanConflict(r.db.Conn(ctx).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get fabric conflict: %w", err)
+	}
+	return conflict, nil
+}
+
+// ListPendingConflicts returns a page of unresolved conflicts, oldest first, together
+// with the total number of unresolved conflicts.
+func (r *FabricConflictPostgresRepository) ListPendingConflicts(
+	ctx context.Context, limit, offset int,
+) ([]*domain.FabricConflict, int, error) {
+	query := `
+		SELECT count(*) OVER(), ` + conflictColumns + `
+		FROM fabric_conflicts
+		WHERE status = $1
+		ORDER BY id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, domain.ConflictStatusPending, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list fabric conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	conflicts := []*domain.FabricConflict{}
+	for rows.Next() {
+		conflict := &domain.FabricConflict{}
+		var resolvedAt sql.NullTime
+		err := rows.Scan(
+			&totalRecords,
+			&conflict.ID, &conflict.EventID, &conflict.EventType, &conflict.Code,
+			&conflict.Name, &conflict.MeasureUnit, &conflict.OfferStatus,
+			&conflict.ExpectedVersion, &conflict.CurrentVersion, &conflict.Status,
+			&conflict.CreatedAt, &resolvedAt, &conflict.ResolvedBy,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan fabric conflict: %w", err)
+		}
+		if resolvedAt.Valid {
+			conflict.ResolvedAt = &resolvedAt.Time
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate fabric conflicts: %w", err)
+	}
+
+	return conflicts, totalRecords, nil
+}
+
+// ResolveConflict stores the resolution of a conflict that is still pending.
+func (r *FabricConflictPostgresRepository) ResolveConflict(ctx context.Context, conflict *domain.FabricConflict) error {
+	query := `
+		UPDATE fabric_conflicts
+		SET status = $1, resolved_at = $2, resolved_by = $3
+		WHERE id = $4 AND status = $5
+	`
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		conflict.Status, conflict.ResolvedAt, conflict.ResolvedBy, conflict.ID, domain.ConflictStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to resolve fabric conflict: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConflictAlreadyResolved
+	}
+	return nil
+}
+
+func scanConflict(row *sql.Row) (*domain.FabricConflict, error) {
+	conflict := &domain.FabricConflict{}
+	var resolvedAt sql.NullTime
+	err := row.Scan(
+		&conflict.ID, &conflict.EventID, &conflict.EventType, &conflict.Code,
+		&conflict.Name, &conflict.MeasureUnit, &conflict.OfferStatus,
+		&conflict.ExpectedVersion, &conflict.CurrentVersion, &conflict.Status,
+		&conflict.CreatedAt, &resolvedAt, &conflict.ResolvedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedAt.Valid {
+		conflict.ResolvedAt = &resolvedAt.Time
+	}
+	return conflict, nil
+}
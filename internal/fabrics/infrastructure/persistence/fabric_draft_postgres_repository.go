@@ -0,0 +1,118 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type FabricDraftPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricDraftPostgresRepository(db *database.PostgresDB) *FabricDraftPostgresRepository {
+	return &FabricDraftPostgresRepository{
+		db: db,
+	}
+}
+
+const draftColumns = `id, code, name, measure_unit, offer_status, base_version, status,
+	created_at, created_by, resolved_at, resolved_by`
+
+func (r *FabricDraftPostgresRepository) SaveDraft(ctx context.Context, draft *domain.FabricDraft) error {
+	query := `
+		INSERT INTO fabric_drafts (
+			code, name, measure_unit, offer_status, base_version, status, created_at, created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query,
+		draft.Code, draft.Name, draft.MeasureUnit, draft.OfferStatus, draft.BaseVersion,
+		draft.Status, draft.CreatedAt, draft.CreatedBy,
+	).Scan(&draft.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save fabric draft: %w", err)
+	}
+	return nil
+}
+
+func (r *FabricDraftPostgresRepository) GetDraft(ctx context.Context, id int64) (*domain.FabricDraft, error) {
+	query := `SELECT ` + draftColumns + ` FROM fabric_drafts WHERE id = $1`
+	draft, err := scanDraft(r.db.Conn(ctx).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get fabric draft: %w", err)
+	}
+	return draft, nil
+}
+
+// ListPendingDrafts returns the drafts of a fabric awaiting review, oldest first.
+func (r *FabricDraftPostgresRepository) ListPendingDrafts(ctx context.Context, code string) ([]*domain.FabricDraft, error) {
+	query := `SELECT ` + draftColumns + ` FROM fabric_drafts WHERE code = $1 AND status = $2 ORDER BY id`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, code, domain.DraftStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fabric drafts: %w", err)
+	}
+	defer rows.Close()
+
+	drafts := []*domain.FabricDraft{}
+	for rows.Next() {
+		draft, err := scanDraft(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fabric draft: %w", err)
+		}
+		drafts = append(drafts, draft)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate fabric drafts: %w", err)
+	}
+	return drafts, nil
+}
+
+// ResolveDraft stores the resolution of a draft that is still pending.
+func (r *FabricDraftPostgresRepository) ResolveDraft(ctx context.Context, draft *domain.FabricDraft) error {
+	query := `
+		UPDATE fabric_drafts
+		SET status = $1, resolved_at = $2, resolved_by = $3
+		WHERE id = $4 AND status = $5
+	`
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		draft.Status, draft.ResolvedAt, draft.ResolvedBy, draft.ID, domain.DraftStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to resolve fabric draft: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrDraftAlreadyResolved
+	}
+	return nil
+}
+
+func scanDraft(row interface{ Scan(dest ...any) error }) (*domain.FabricDraft, error) {
+	draft := &domain.FabricDraft{}
+	var resolvedAt sql.NullTime
+	err := row.Scan(
+		&draft.ID, &draft.Code, &draft.Name, &draft.MeasureUnit, &draft.OfferStatus,
+		&draft.BaseVersion, &draft.Status, &draft.CreatedAt, &draft.CreatedBy,
+		&resolvedAt, &draft.ResolvedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedAt.Valid {
+		draft.ResolvedAt = &resolvedAt.Time
+	}
+	return draft, nil
+}
@@ -0,0 +1,142 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type FabricDuplicatePostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricDuplicatePostgresRepository(db *database.PostgresDB) *FabricDuplicatePostgresRepository {
+	return &FabricDuplicatePostgresRepository{
+		db: db,
+	}
+}
+
+const duplicateColumns = `id, code, canonical_code, reason, name_score, status, detected_at, resolved_at, resolved_by`
+
+// SaveDuplicate queues a duplicate for review and sets its ID. A pair flagged before, in
+// either direction, is left as it is and the ID stays zero, so a dismissed finding does
+// not come back with the next scan.
+func (r *FabricDuplicatePostgresRepository) SaveDuplicate(ctx context.Context, duplicate *domain.FabricDuplicate) error {
+	query := `
+		INSERT INTO fabric_duplicates (code, canonical_code, reason, name_score, status, detected_at)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE NOT EXISTS (
+			SELECT 1 FROM fabric_duplicates WHERE code = $2 AND canonical_code = $1
+		)
+		ON CONFLICT (code, canonical_code) DO NOTHING
+		RETURNING id
+	`
+	args := []any{
+		duplicate.Code, duplicate.CanonicalCode, duplicate.Reason, duplicate.NameScore,
+		duplicate.Status, duplicate.DetectedAt,
+	}
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query, args...).Scan(&duplicate.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to save fabric duplicate: %w", err)
+	}
+	return nil
+}
+
+func (r *FabricDuplicatePostgresRepository) GetDuplicate(ctx context.Context, id int64) (*domain.FabricDuplicate, error) {
+	query := `SELECT ` + duplicateColumns + ` FROM fabric_duplicates WHERE id = $1`
+	duplicate, err := scanDuplicate(r.db.Conn(ctx).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get fabric duplicate: %w", err)
+	}
+	return duplicate, nil
+}
+
+// ListPendingDuplicates returns a page of duplicates awaiting review, most similar names
+// first, together with the total number awaiting review.
+func (r *FabricDuplicatePostgresRepository) ListPendingDuplicates(
+	ctx context.Context, limit, offset int,
+) ([]*domain.FabricDuplicate, int, error) {
+	query := `
+		SELECT count(*) OVER(), ` + duplicateColumns + `
+		FROM fabric_duplicates
+		WHERE status = $1
+		ORDER BY name_score DESC, id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, domain.DuplicateStatusPending, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list fabric duplicates: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	duplicates := []*domain.FabricDuplicate{}
+	for rows.Next() {
+		duplicate := &domain.FabricDuplicate{}
+		var resolvedAt sql.NullTime
+		err := rows.Scan(
+			&totalRecords,
+			&duplicate.ID, &duplicate.Code, &duplicate.CanonicalCode, &duplicate.Reason,
+			&duplicate.NameScore, &duplicate.Status, &duplicate.DetectedAt, &resolvedAt, &duplicate.ResolvedBy,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan fabric duplicate: %w", err)
+		}
+		if resolvedAt.Valid {
+			duplicate.ResolvedAt = &resolvedAt.Time
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate fabric duplicates: %w", err)
+	}
+
+	return duplicates, totalRecords, nil
+}
+
+// ResolveDuplicate stores the outcome of the review of a duplicate that is still pending.
+func (r *FabricDuplicatePostgresRepository) ResolveDuplicate(ctx context.Context, duplicate *domain.FabricDuplicate) error {
+	query := `
+		UPDATE fabric_duplicates
+		SET status = $1, resolved_at = $2, resolved_by = $3
+		WHERE id = $4 AND status = $5
+	`
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		duplicate.Status, duplicate.ResolvedAt, duplicate.ResolvedBy, duplicate.ID, domain.DuplicateStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to resolve fabric duplicate: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrDuplicateAlreadyResolved
+	}
+	return nil
+}
+
+func scanDuplicate(row *sql.Row) (*domain.FabricDuplicate, error) {
+	duplicate := &domain.FabricDuplicate{}
+	var resolvedAt sql.NullTime
+	err := row.Scan(
+		&duplicate.ID, &duplicate.Code, &duplicate.CanonicalCode, &duplicate.Reason,
+		&duplicate.NameScore, &duplicate.Status, &duplicate.DetectedAt, &resolvedAt, &duplicate.ResolvedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedAt.Valid {
+		duplicate.ResolvedAt = &resolvedAt.Time
+	}
+	return duplicate, nil
+}
@@ -0,0 +1,97 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+// resolves a fabric code, or one of its aliases, to the canonical code locks are kept under
+const canonicalCodeSQL = `COALESCE((SELECT canonical_code FROM fabric_aliases WHERE alias_code = $1), $1)`
+
+type FabricLockPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricLockPostgresRepository(db *database.PostgresDB) *FabricLockPostgresRepository {
+	return &FabricLockPostgresRepository{
+		db: db,
+	}
+}
+
+// AcquireLock locks a fabric that is neither deleted nor merged for the lock holder. A
+// lock held by someone else is only taken over once it has expired, one held by the same
+// holder is renewed.
+func (r *FabricLockPostgresRepository) AcquireLock(ctx context.Context, lock *domain.FabricLock) (*domain.FabricLock, error) {
+	var code string
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		`SELECT code FROM fabrics WHERE code = `+canonicalCodeSQL+` AND `+liveStatusSQL, lock.Code,
+	).Scan(&code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to find fabric to lock: %w", err)
+	}
+	lock.Code = code
+
+	err = r.db.Conn(ctx).QueryRowContext(ctx, `
+		INSERT INTO fabric_edit_locks (code, holder, acquired_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (code) DO UPDATE
+		SET holder = EXCLUDED.holder, acquired_at = EXCLUDED.acquired_at, expires_at = EXCLUDED.expires_at
+		WHERE fabric_edit_locks.holder = EXCLUDED.holder OR fabric_edit_locks.expires_at <= EXCLUDED.acquired_at
+		RETURNING code
+	`, lock.Code, lock.Holder, lock.AcquiredAt, lock.ExpiresAt).Scan(&code)
+	if err == nil {
+		return lock, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to acquire fabric lock: %w", err)
+	}
+
+	current, err := r.GetLock(ctx, lock.Code, lock.AcquiredAt)
+	if err != nil {
+		return nil, err
+	}
+	return current, domain.ErrFabricLocked
+}
+
+func (r *FabricLockPostgresRepository) ReleaseLock(ctx context.Context, code, holder string) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx,
+		`DELETE FROM fabric_edit_locks WHERE code = `+canonicalCodeSQL+` AND holder = $2`, code, holder,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release fabric lock: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected post-delete: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetLock returns the lock in force on the fabric at the given time.
+func (r *FabricLockPostgresRepository) GetLock(ctx context.Context, code string, now time.Time) (*domain.FabricLock, error) {
+	lock := &domain.FabricLock{}
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT code, holder, acquired_at, expires_at
+		FROM fabric_edit_locks
+		WHERE code = `+canonicalCodeSQL+` AND expires_at > $2
+	`, code, now).Scan(&lock.Code, &lock.Holder, &lock.AcquiredAt, &lock.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get fabric lock: %w", err)
+	}
+	return lock, nil
+}
@@ -0,0 +1,153 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+const pendingEventColumns = `id, event_id, event_type, code, version, envelope, status,
+	received_at, attempts, next_attempt_at`
+
+type FabricPendingEventPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricPendingEventPostgresRepository(db *database.PostgresDB) *FabricPendingEventPostgresRepository {
+	return &FabricPendingEventPostgresRepository{
+		db: db,
+	}
+}
+
+// ParkEvent stores an event until its preceding versions arrive. A redelivered event is
+// parked only once.
+func (r *FabricPendingEventPostgresRepository) ParkEvent(ctx context.Context, event *domain.PendingFabricEvent) error {
+	query := `
+		INSERT INTO erp_pending_events (
+			event_id, event_type, code, version, envelope, status, received_at, attempts, next_attempt_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (event_id) DO NOTHING
+		RETURNING id
+	`
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query,
+		event.EventID, event.EventType, event.Code, event.Version, event.Envelope, event.Status, event.ReceivedAt,
+		event.Attempts, event.NextAttemptAt,
+	).Scan(&event.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to park pending event: %w", err)
+	}
+	return nil
+}
+
+// NextPendingEvent returns the oldest waiting event for the fabric at the given version.
+func (r *FabricPendingEventPostgresRepository) NextPendingEvent(
+	ctx context.Context, code string, version int,
+) (*domain.PendingFabricEvent, error) {
+	query := `
+		SELECT ` + pendingEventColumns + `
+		FROM erp_pending_events
+		WHERE code = $1 AND version = $2 AND status = $3
+		ORDER BY id
+		LIMIT 1
+	`
+	event, err := scanPendingEvent(r.db.Conn(ctx).QueryRowContext(ctx, query, code, version, domain.PendingStatusWaiting))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get pending event: %w", err)
+	}
+	return event, nil
+}
+
+// DueForRetry returns up to limit waiting events whose next attempt is due, oldest first.
+func (r *FabricPendingEventPostgresRepository) DueForRetry(
+	ctx context.Context, now time.Time, limit int,
+) ([]*domain.PendingFabricEvent, error) {
+	query := `
+		SELECT ` + pendingEventColumns + `
+		FROM erp_pending_events
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at, id
+		LIMIT $3
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, domain.PendingStatusWaiting, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending events due for retry: %w", err)
+	}
+	return collectPendingEvents(rows)
+}
+
+func (r *FabricPendingEventPostgresRepository) ScheduleRetry(ctx context.Context, event *domain.PendingFabricEvent) error {
+	query := `UPDATE erp_pending_events SET attempts = $1, next_attempt_at = $2 WHERE id = $3 AND status = $4`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, event.Attempts, event.NextAttemptAt, event.ID, domain.PendingStatusWaiting)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pending event retry: %w", err)
+	}
+	return nil
+}
+
+func (r *FabricPendingEventPostgresRepository) MarkResolved(ctx context.Context, id int64, status string) error {
+	query := `UPDATE erp_pending_events SET status = $1 WHERE id = $2`
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, status, id); err != nil {
+		return fmt.Errorf("failed to resolve pending event: %w", err)
+	}
+	return nil
+}
+
+// ExpirePendingEvents marks every event still waiting since before the cutoff as expired
+// and returns them, so the caller can dead-letter them.
+func (r *FabricPendingEventPostgresRepository) ExpirePendingEvents(
+	ctx context.Context, receivedBefore time.Time,
+) ([]*domain.PendingFabricEvent, error) {
+	query := `
+		UPDATE erp_pending_events
+		SET status = $1
+		WHERE status = $2 AND received_at < $3
+		RETURNING ` + pendingEventColumns + `
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, domain.PendingStatusExpired, domain.PendingStatusWaiting, receivedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire pending events: %w", err)
+	}
+	return collectPendingEvents(rows)
+}
+
+type pendingEventScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPendingEvent(row pendingEventScanner) (*domain.PendingFabricEvent, error) {
+	event := &domain.PendingFabricEvent{}
+	err := row.Scan(
+		&event.ID, &event.EventID, &event.EventType, &event.Code, &event.Version,
+		&event.Envelope, &event.Status, &event.ReceivedAt, &event.Attempts, &event.NextAttemptAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func collectPendingEvents(rows *sql.Rows) ([]*domain.PendingFabricEvent, error) {
+	defer rows.Close()
+
+	events := []*domain.PendingFabricEvent{}
+	for rows.Next() {
+		event, err := scanPendingEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate pending events: %w", err)
+	}
+	return events, nil
+}
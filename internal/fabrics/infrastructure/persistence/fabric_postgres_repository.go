@@ -3,14 +3,22 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/salesworks/s-works/api/internal/fabrics/domain"
 	"github.com/salesworks/s-works/api/internal/platform/database"
 )
 
+// selects the fabrics still in the catalogue, whatever their lifecycle status
+const liveStatusSQL = `status NOT IN ('DELETED', 'MERGED')`
+
 type FabricPostgresRepository struct {
 	db *database.PostgresDB
 }
@@ -22,35 +30,81 @@ func NewFabricPostgresRepository(db *database.PostgresDB) *FabricPostgresReposit
 }
 
 func (r *FabricPostgresRepository) Save(ctx context.Context, fabric *domain.Fabric) (*domain.Fabric, error) {
-	tx, err := r.db.Pool.BeginTx(ctx, nil)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("could not begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	findQuery := `SELECT version, code, name, measure_unit, offer_status, status FROM fabrics WHERE code = $1 FOR UPDATE`
+	findQuery := `
+		SELECT version, code, name, measure_unit, offer_status, ` + specificationColumns + `, ` + textColumns + `, ` + priceColumns + `, status,
+			created_at, created_by, updated_at, updated_by
+		FROM fabrics WHERE code = $1 FOR UPDATE
+	`
 	existingFabric := &domain.Fabric{}
 	err = tx.QueryRowContext(ctx, findQuery, fabric.Code).Scan(
 		&existingFabric.Version, &existingFabric.Code, &existingFabric.Name,
-		&existingFabric.MeasureUnit, &existingFabric.OfferStatus, &existingFabric.Status,
+		&existingFabric.MeasureUnit, &existingFabric.OfferStatus,
+		composition(&existingFabric.Specification.Composition), &existingFabric.Specification.WidthCM,
+		&existingFabric.Specification.WeightGSM, &existingFabric.Specification.Color,
+		&existingFabric.Specification.MinOrderQuantity, &existingFabric.Specification.LeadTimeDays,
+		&existingFabric.Description, &existingFabric.Notes, translations(&existingFabric.Translations),
+		customAttributes(&existingFabric.CustomAttributes),
+		price(&existingFabric.Price),
+		&existingFabric.Status,
+		&existingFabric.CreatedAt, &existingFabric.CreatedBy,
+		&existingFabric.UpdatedAt, &existingFabric.UpdatedBy,
 	)
 
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, fmt.Errorf("failed during select for update: %w", err)
 	}
 
-	if err == nil && existingFabric.Status == domain.StatusActive {
+	if errors.Is(err, sql.ErrNoRows) {
+		var aliased bool
+		aliasQuery := `SELECT EXISTS (SELECT 1 FROM fabric_aliases WHERE alias_code = $1)`
+		if err := tx.QueryRowContext(ctx, aliasQuery, fabric.Code).Scan(&aliased); err != nil {
+			return nil, fmt.Errorf("failed to check fabric aliases: %w", err)
+		}
+		if aliased {
+			return nil, domain.ErrDuplicateFabricCode
+		}
+		if err := checkNotPurged(ctx, tx, fabric.Code); err != nil {
+			return nil, err
+		}
+	}
+
+	if err == nil && existingFabric.Status != domain.StatusDeleted {
 		return nil, domain.ErrDuplicateFabricCode
 	}
 
-	if err == nil && existingFabric.Status == domain.StatusDeleted {
-		err = existingFabric.Reactivate(fabric.Name, fabric.MeasureUnit, fabric.OfferStatus, existingFabric.Version)
+	if err == nil {
+		stamp := domain.Stamp{By: fabric.CreatedBy, At: fabric.CreatedAt}
+		texts := domain.FabricTexts{Description: &fabric.Description, Notes: &fabric.Notes}
+		err = existingFabric.Reactivate(
+			fabric.Status, fabric.Name, string(fabric.MeasureUnit), string(fabric.OfferStatus), fabric.Specification,
+			texts, existingFabric.Version, stamp,
+		)
 		if err != nil {
 			return nil, err
 		}
 
-		updateQuery := `UPDATE fabrics SET name = $1, measure_unit = $2, offer_status = $3, status = $4, version = $5 WHERE code = $6`
-		_, err = tx.ExecContext(ctx, updateQuery, existingFabric.Name, existingFabric.MeasureUnit, existingFabric.OfferStatus, existingFabric.Status, existingFabric.Version, existingFabric.Code)
+		updateQuery := `
+			UPDATE fabrics
+			SET name = $1, measure_unit = $2, offer_status = $3, status = $4, version = $5, updated_at = $6, updated_by = $7,
+				composition = $9, width_cm = NULLIF($10, 0), weight_gsm = NULLIF($11, 0), color = $12,
+				description = $13, notes = $14, min_order_quantity = $15, lead_time_days = $16
+			WHERE code = $8
+		`
+		args := []any{
+			existingFabric.Name, existingFabric.MeasureUnit, existingFabric.OfferStatus, existingFabric.Status,
+			existingFabric.Version, existingFabric.UpdatedAt, existingFabric.UpdatedBy, existingFabric.Code,
+			composition(&existingFabric.Specification.Composition), existingFabric.Specification.WidthCM,
+			existingFabric.Specification.WeightGSM, existingFabric.Specification.Color,
+			existingFabric.Description, existingFabric.Notes,
+			existingFabric.Specification.MinOrderQuantity, existingFabric.Specification.LeadTimeDays,
+		}
+		_, err = tx.ExecContext(ctx, updateQuery, args...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to reactivate fabric: %w", err)
 		}
@@ -61,8 +115,26 @@ func (r *FabricPostgresRepository) Save(ctx context.Context, fabric *domain.Fabr
 		return existingFabric, nil
 	}
 
-	insertQuery := `INSERT INTO fabrics (version, code, name, measure_unit, offer_status, status) VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err = tx.ExecContext(ctx, insertQuery, fabric.Version, fabric.Code, fabric.Name, fabric.MeasureUnit, fabric.OfferStatus, fabric.Status)
+	insertQuery := `
+		INSERT INTO fabrics (
+			version, code, name, measure_unit, offer_status, status, created_at, created_by, updated_at, updated_by,
+			composition, width_cm, weight_gsm, color, description, notes, translations, custom_attributes,
+			min_order_quantity, lead_time_days
+		)
+		VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, 0), NULLIF($13, 0), $14, $15, $16, $17, $18,
+			$19, $20
+		)
+	`
+	args := []any{
+		fabric.Version, fabric.Code, fabric.Name, fabric.MeasureUnit, fabric.OfferStatus, fabric.Status,
+		fabric.CreatedAt, fabric.CreatedBy, fabric.UpdatedAt, fabric.UpdatedBy,
+		composition(&fabric.Specification.Composition), fabric.Specification.WidthCM,
+		fabric.Specification.WeightGSM, fabric.Specification.Color, fabric.Description, fabric.Notes,
+		translations(&fabric.Translations), customAttributes(&fabric.CustomAttributes),
+		fabric.Specification.MinOrderQuantity, fabric.Specification.LeadTimeDays,
+	}
+	_, err = tx.ExecContext(ctx, insertQuery, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -77,21 +149,41 @@ func (r *FabricPostgresRepository) Save(ctx context.Context, fabric *domain.Fabr
 	return fabric, nil
 }
 
+// GetByCode loads a fabric that is neither deleted nor merged by its code or by one of its
+// aliases, in which case the returned fabric carries the canonical code.
 func (r *FabricPostgresRepository) GetByCode(ctx context.Context, code string) (*domain.Fabric, error) {
 	query := `
-		SELECT version, code, name, measure_unit, offer_status, status
+		SELECT version, code, name, measure_unit, offer_status, ` + specificationColumns + `, ` + textColumns + `, ` + priceColumns + `, status,
+			created_at, created_by, updated_at, updated_by
 		FROM fabrics
-		WHERE code = $1 AND status = 'ACTIVE'
+		WHERE code = COALESCE(
+			(SELECT canonical_code FROM fabric_aliases WHERE alias_code = $1), $1
+		) AND ` + liveStatusSQL + `
 	`
 
 	fabric := &domain.Fabric{}
-	err := r.db.Pool.QueryRowContext(ctx, query, code).Scan(
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query, code).Scan(
 		&fabric.Version,
 		&fabric.Code,
 		&fabric.Name,
 		&fabric.MeasureUnit,
 		&fabric.OfferStatus,
+		composition(&fabric.Specification.Composition),
+		&fabric.Specification.WidthCM,
+		&fabric.Specification.WeightGSM,
+		&fabric.Specification.Color,
+		&fabric.Specification.MinOrderQuantity,
+		&fabric.Specification.LeadTimeDays,
+		&fabric.Description,
+		&fabric.Notes,
+		translations(&fabric.Translations),
+		customAttributes(&fabric.CustomAttributes),
+		price(&fabric.Price),
 		&fabric.Status, // The 6th variable
+		&fabric.CreatedAt,
+		&fabric.CreatedBy,
+		&fabric.UpdatedAt,
+		&fabric.UpdatedBy,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -106,12 +198,26 @@ func (r *FabricPostgresRepository) GetByCode(ctx context.Context, code string) (
 func (r *FabricPostgresRepository) Update(ctx context.Context, fabric *domain.Fabric) error {
 	query := `
 		UPDATE fabrics
-		SET name = $1, measure_unit = $2, offer_status = $3, version = $4
-		WHERE code = $5 AND version = $6 AND status = 'ACTIVE'
+		SET name = $1, measure_unit = $2, offer_status = $3, version = $4, updated_at = $5, updated_by = $6,
+			composition = $9, width_cm = NULLIF($10, 0), weight_gsm = NULLIF($11, 0), color = $12,
+			list_price = $13, currency = $14, price_valid_from = $15, description = $16, notes = $17,
+			translations = $18, custom_attributes = $19, min_order_quantity = $20, lead_time_days = $21
+		WHERE code = $7 AND version = $8 AND ` + liveStatusSQL + `
 	`
-	args := []any{fabric.Name, fabric.MeasureUnit, fabric.OfferStatus, fabric.Version, fabric.Code, fabric.Version - 1}
+	args := []any{
+		fabric.Name, fabric.MeasureUnit, fabric.OfferStatus, fabric.Version,
+		fabric.UpdatedAt, fabric.UpdatedBy, fabric.Code, fabric.Version - 1,
+		composition(&fabric.Specification.Composition), fabric.Specification.WidthCM,
+		fabric.Specification.WeightGSM, fabric.Specification.Color,
+	}
+	args = append(args, priceArgs(fabric.Price)...)
+	args = append(
+		args, fabric.Description, fabric.Notes, translations(&fabric.Translations),
+		customAttributes(&fabric.CustomAttributes), fabric.Specification.MinOrderQuantity,
+		fabric.Specification.LeadTimeDays,
+	)
 
-	result, err := r.db.Pool.ExecContext(ctx, query, args...)
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update fabric: %w", err)
 	}
@@ -131,12 +237,15 @@ func (r *FabricPostgresRepository) Update(ctx context.Context, fabric *domain.Fa
 func (r *FabricPostgresRepository) Delete(ctx context.Context, fabric *domain.Fabric) error {
 	query := `
 		UPDATE fabrics
-		SET status = $1
-		WHERE code = $2 AND version = $3
+		SET status = $1, version = $2, updated_at = $3, updated_by = $4, status_before_deletion = $7
+		WHERE code = $5 AND version = $6
 	`
-	args := []any{domain.StatusDeleted, fabric.Code, fabric.Version}
+	args := []any{
+		domain.StatusDeleted, fabric.Version, fabric.UpdatedAt, fabric.UpdatedBy,
+		fabric.Code, fabric.Version - 1, fabric.StatusBeforeDeletion,
+	}
 
-	result, err := r.db.Pool.ExecContext(ctx, query, args...)
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete fabric: %w", err)
 	}
@@ -153,21 +262,513 @@ func (r *FabricPostgresRepository) Delete(ctx context.Context, fabric *domain.Fa
 	return nil
 }
 
+// ChangeStatus moves the fabric to its new lifecycle status.
+func (r *FabricPostgresRepository) ChangeStatus(ctx context.Context, fabric *domain.Fabric) error {
+	query := `
+		UPDATE fabrics
+		SET status = $1, version = $2, updated_at = $3, updated_by = $4
+		WHERE code = $5 AND version = $6 AND ` + liveStatusSQL + `
+	`
+	args := []any{
+		fabric.Status, fabric.Version, fabric.UpdatedAt, fabric.UpdatedBy,
+		fabric.Code, fabric.Version - 1,
+	}
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to change fabric status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected post-status change: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Restore brings a deleted fabric back in the status it had, keeping the data it had when
+// it was deleted.
+func (r *FabricPostgresRepository) Restore(ctx context.Context, fabric *domain.Fabric) error {
+	query := `
+		UPDATE fabrics
+		SET status = $1, version = $2, updated_at = $3, updated_by = $4, status_before_deletion = NULL
+		WHERE code = $5 AND version = $6 AND status = 'DELETED'
+	`
+	args := []any{
+		fabric.Status, fabric.Version, fabric.UpdatedAt, fabric.UpdatedBy,
+		fabric.Code, fabric.Version - 1,
+	}
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to restore fabric: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected post-restore: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Reactivate puts a discontinued or deleted fabric back on offer with the data it returns with.
+func (r *FabricPostgresRepository) Reactivate(ctx context.Context, fabric *domain.Fabric) error {
+	query := `
+		UPDATE fabrics
+		SET name = $1, measure_unit = $2, offer_status = $3, status = $4, version = $5, updated_at = $6, updated_by = $7,
+			composition = $10, width_cm = NULLIF($11, 0), weight_gsm = NULLIF($12, 0), color = $13,
+			min_order_quantity = $14, lead_time_days = $15
+		WHERE code = $8 AND version = $9 AND status IN ('DISCONTINUED', 'DELETED')
+	`
+	args := []any{
+		fabric.Name, fabric.MeasureUnit, fabric.OfferStatus, fabric.Status, fabric.Version,
+		fabric.UpdatedAt, fabric.UpdatedBy, fabric.Code, fabric.Version - 1,
+		composition(&fabric.Specification.Composition), fabric.Specification.WidthCM,
+		fabric.Specification.WeightGSM, fabric.Specification.Color,
+		fabric.Specification.MinOrderQuantity, fabric.Specification.LeadTimeDays,
+	}
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to reactivate fabric: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected post-reactivation: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Merge retires the duplicate fabric and makes its code, and every alias that pointed to
+// it, resolve to the canonical fabric, which takes over the rows kept under the duplicate
+// code. The canonical fabric must still be active.
+func (r *FabricPostgresRepository) Merge(ctx context.Context, duplicate *domain.Fabric, canonicalCode string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var canonicalStatus string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM fabrics WHERE code = $1 FOR UPDATE`, canonicalCode).Scan(&canonicalStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrRecordNotFound
+		}
+		return fmt.Errorf("failed to lock canonical fabric: %w", err)
+	}
+	if canonicalStatus != domain.StatusActive {
+		return domain.ErrInvalidMergeTarget
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE fabrics
+		SET status = $1, version = $2, updated_at = $3, updated_by = $4
+		WHERE code = $5 AND version = $6
+	`, domain.StatusMerged, duplicate.Version, duplicate.UpdatedAt, duplicate.UpdatedBy,
+		duplicate.Code, duplicate.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to merge fabric: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected post-merge: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE fabric_aliases SET canonical_code = $1 WHERE canonical_code = $2`,
+		canonicalCode, duplicate.Code,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to move aliases to canonical fabric: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO fabric_aliases (alias_code, canonical_code, created_at, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (alias_code) DO UPDATE SET canonical_code = EXCLUDED.canonical_code
+	`, duplicate.Code, canonicalCode, duplicate.UpdatedAt, duplicate.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to add alias for merged fabric: %w", err)
+	}
+
+	if err := moveFabricRows(ctx, tx, duplicate, canonicalCode); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ChangeCode renames the fabric from the previous code, which becomes an alias of it along
+// with the aliases it already had. Rows referencing the fabric follow the code through
+// cascading foreign keys; edit locks and pending drafts are moved along, and so are the
+// events of the fabric and its stock, so their history and sequence carry on under the new
+// code. A code taken by another fabric or alias is refused, the fabric's own alias is
+// given up for it.
+func (r *FabricPostgresRepository) ChangeCode(ctx context.Context, fabric *domain.Fabric, previousCode string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`DELETE FROM fabric_aliases WHERE alias_code = $1 AND canonical_code = $2`, fabric.Code, previousCode,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release alias for renamed fabric: %w", err)
+	}
+	var aliased bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM fabric_aliases WHERE alias_code = $1)`, fabric.Code,
+	).Scan(&aliased)
+	if err != nil {
+		return fmt.Errorf("failed to check fabric aliases: %w", err)
+	}
+	if aliased {
+		return domain.ErrDuplicateFabricCode
+	}
+	if err := checkNotPurged(ctx, tx, fabric.Code); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE fabrics
+		SET code = $1, version = $2, updated_at = $3, updated_by = $4
+		WHERE code = $5 AND version = $6
+	`, fabric.Code, fabric.Version, fabric.UpdatedAt, fabric.UpdatedBy, previousCode, fabric.Version-1)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrDuplicateFabricCode
+		}
+		return fmt.Errorf("failed to change fabric code: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected post-rename: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE fabric_aliases SET canonical_code = $1 WHERE canonical_code = $2`, fabric.Code, previousCode,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to move aliases to renamed fabric: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO fabric_aliases (alias_code, canonical_code, created_at, created_by)
+		VALUES ($1, $2, $3, $4)
+	`, previousCode, fabric.Code, fabric.UpdatedAt, fabric.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to add alias for renamed fabric: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE fabric_edit_locks SET code = $1 WHERE code = $2`, fabric.Code, previousCode)
+	if err != nil {
+		return fmt.Errorf("failed to move edit lock to renamed fabric: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`UPDATE fabric_drafts SET code = $1 WHERE code = $2 AND status = $3`,
+		fabric.Code, previousCode, domain.DraftStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to move drafts to renamed fabric: %w", err)
+	}
+	if err := moveFabricEvents(ctx, tx, previousCode, fabric.Code); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// the aggregates keyed by the fabric code, whose events follow a rename
+var fabricCodeAggregateTypes = []string{domain.AggregateType, "FabricStock"}
+
+// moveFabricEvents re-keys the events of the fabric and its stock to the new code. It first
+// takes the append locks of the event store on the previous code, so appends under it
+// still in flight are committed and moved too.
+func moveFabricEvents(ctx context.Context, tx database.Querier, previousCode, code string) error {
+	for _, aggregateType := range fabricCodeAggregateTypes {
+		_, err := tx.ExecContext(ctx,
+			"SELECT pg_advisory_xact_lock(hashtext($1), hashtext($2))", aggregateType, previousCode,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to lock %s events of renamed fabric: %w", aggregateType, err)
+		}
+		_, err = tx.ExecContext(ctx,
+			`UPDATE events SET aggregate_id = $1 WHERE aggregate_type = $2 AND aggregate_id = $3`,
+			code, aggregateType, previousCode,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to move %s events to renamed fabric: %w", aggregateType, err)
+		}
+	}
+	return nil
+}
+
+// Purge removes the deleted fabric together with its stock, attachments, certifications,
+// barcodes, supplier links, category assignments, aliases, edit lock and drafts. The code
+// and the aliases are recorded as purged, so they are never used again. With purgeEvents
+// the events of the fabric and its stock are removed, recorded under the code or one of
+// the aliases, as are the events of the attachments and certifications it still has; the
+// files of its attachments are left in blob storage.
+func (r *FabricPostgresRepository) Purge(ctx context.Context, fabric *domain.Fabric, purgeEvents bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx,
+		`SELECT status FROM fabrics WHERE code = $1 AND version = $2 FOR UPDATE`, fabric.Code, fabric.Version-1,
+	).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrConcurrencyConflict
+		}
+		return fmt.Errorf("failed to lock purged fabric: %w", err)
+	}
+	if status != domain.StatusDeleted {
+		return domain.ErrFabricNotPurgeable
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO fabric_purges (code, fabric_code, version, events_purged, purged_at, purged_by)
+		SELECT $1, $1, $2, $3, $4, $5
+		UNION ALL
+		SELECT alias_code, $1, $2, $3, $4, $5 FROM fabric_aliases WHERE canonical_code = $1
+		ON CONFLICT (code) DO NOTHING
+	`, fabric.Code, fabric.Version, purgeEvents, fabric.UpdatedAt, fabric.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to retire codes of purged fabric: %w", err)
+	}
+
+	if purgeEvents {
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM events
+			WHERE (aggregate_type IN ('Fabric', 'FabricStock') AND aggregate_id IN (
+					SELECT code FROM fabric_purges WHERE fabric_code = $1
+				))
+				OR (aggregate_type = 'FabricAttachment' AND aggregate_id IN (
+					SELECT id::text FROM fabric_attachments WHERE fabric_code = $1
+				))
+				OR (aggregate_type = 'FabricCertification' AND aggregate_id IN (
+					SELECT id::text FROM fabric_certifications WHERE fabric_code = $1
+				))
+		`, fabric.Code)
+		if err != nil {
+			return fmt.Errorf("failed to remove events of purged fabric: %w", err)
+		}
+	}
+
+	for _, statement := range []struct{ query, rows string }{
+		{`DELETE FROM fabric_stock WHERE code = $1`, "stock"},
+		{`DELETE FROM fabric_attachments WHERE fabric_code = $1`, "attachments"},
+		{`DELETE FROM fabric_certifications WHERE fabric_code = $1`, "certifications"},
+		{`DELETE FROM fabric_barcodes WHERE fabric_code = $1`, "barcodes"},
+		{`DELETE FROM supplier_fabrics WHERE fabric_code = $1`, "supplier links"},
+		{`DELETE FROM category_fabrics WHERE fabric_code = $1`, "category assignments"},
+		{`DELETE FROM fabric_aliases WHERE canonical_code = $1`, "aliases"},
+		{`DELETE FROM fabric_edit_locks WHERE code = $1`, "edit lock"},
+		{`DELETE FROM fabric_drafts WHERE code = $1`, "drafts"},
+		{`DELETE FROM fabrics WHERE code = $1`, "fabric"},
+	} {
+		if _, err := tx.ExecContext(ctx, statement.query, fabric.Code); err != nil {
+			// order lines, product components and list prices keep referencing the fabric,
+			// none is purged with it
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				return domain.ErrFabricReferenced
+			}
+			return fmt.Errorf("failed to remove %s of purged fabric: %w", statement.rows, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CheckColor looks the color up among those of the palette that are not deleted.
+func (r *FabricPostgresRepository) CheckColor(ctx context.Context, code string) error {
+	var exists bool
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM colors WHERE code = $1 AND deleted_at IS NULL)
+	`, code).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check color: %w", err)
+	}
+	if !exists {
+		return domain.ErrUnknownColor
+	}
+	return nil
+}
+
+// checkNotPurged refuses the code of a purged fabric or of one of its aliases.
+func checkNotPurged(ctx context.Context, tx database.Querier, code string) error {
+	var purged bool
+	err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM fabric_purges WHERE code = $1)`, code).Scan(&purged)
+	if err != nil {
+		return fmt.Errorf("failed to check purged fabric codes: %w", err)
+	}
+	if purged {
+		return domain.ErrFabricCodePurged
+	}
+	return nil
+}
+
+// moveFabricRows hands the stock, attachments, certifications, supplier links and category
+// assignments of the merged duplicate over to the canonical fabric. Stock is added to the
+// canonical stock, warehouse by warehouse, whose version moves on so a stock change loaded
+// before the merge conflicts. Where both fabrics are linked to the same supplier or category, the canonical
+// link is kept.
+func moveFabricRows(ctx context.Context, tx database.Querier, duplicate *domain.Fabric, canonicalCode string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO fabric_stock (code, on_hand, reserved, version, updated_at, updated_by)
+		SELECT $1, on_hand, reserved, 1, $3, $4 FROM fabric_stock WHERE code = $2
+		ON CONFLICT (code) DO UPDATE
+		SET on_hand = fabric_stock.on_hand + EXCLUDED.on_hand, reserved = fabric_stock.reserved + EXCLUDED.reserved,
+			version = fabric_stock.version + 1, updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by
+	`, canonicalCode, duplicate.Code, duplicate.UpdatedAt, duplicate.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to add stock to canonical fabric: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO fabric_stock_locations (fabric_code, warehouse_code, on_hand)
+		SELECT $1, warehouse_code, on_hand FROM fabric_stock_locations WHERE fabric_code = $2
+		ON CONFLICT (fabric_code, warehouse_code) DO UPDATE
+		SET on_hand = fabric_stock_locations.on_hand + EXCLUDED.on_hand
+	`, canonicalCode, duplicate.Code)
+	if err != nil {
+		return fmt.Errorf("failed to add stock locations to canonical fabric: %w", err)
+	}
+	// reservations of both under the same reference are held until the later expiry, or
+	// for good when either does not expire
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO fabric_stock_reservations (fabric_code, reference, quantity, expires_at, reserved_at, reserved_by)
+		SELECT $1, reference, quantity, expires_at, reserved_at, reserved_by
+		FROM fabric_stock_reservations WHERE fabric_code = $2
+		ON CONFLICT (fabric_code, reference) DO UPDATE
+		SET quantity = fabric_stock_reservations.quantity + EXCLUDED.quantity,
+			expires_at = CASE
+				WHEN fabric_stock_reservations.expires_at IS NULL OR EXCLUDED.expires_at IS NULL THEN NULL
+				ELSE GREATEST(fabric_stock_reservations.expires_at, EXCLUDED.expires_at)
+			END,
+			reserved_at = LEAST(fabric_stock_reservations.reserved_at, EXCLUDED.reserved_at)
+	`, canonicalCode, duplicate.Code)
+	if err != nil {
+		return fmt.Errorf("failed to add stock reservations to canonical fabric: %w", err)
+	}
+	// the locations and reservations of the merged stock go with it
+	_, err = tx.ExecContext(ctx, `DELETE FROM fabric_stock WHERE code = $1`, duplicate.Code)
+	if err != nil {
+		return fmt.Errorf("failed to remove stock of merged fabric: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE fabric_attachments SET fabric_code = $1 WHERE fabric_code = $2`,
+		canonicalCode, duplicate.Code,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to move attachments to canonical fabric: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`UPDATE fabric_certifications SET fabric_code = $1 WHERE fabric_code = $2`,
+		canonicalCode, duplicate.Code,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to move certifications to canonical fabric: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`UPDATE fabric_barcodes SET fabric_code = $1 WHERE fabric_code = $2`,
+		canonicalCode, duplicate.Code,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to move barcodes to canonical fabric: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE supplier_fabrics SET fabric_code = $1
+		WHERE fabric_code = $2
+			AND supplier_code NOT IN (SELECT supplier_code FROM supplier_fabrics WHERE fabric_code = $1)
+	`, canonicalCode, duplicate.Code)
+	if err != nil {
+		return fmt.Errorf("failed to move supplier links to canonical fabric: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM supplier_fabrics WHERE fabric_code = $1`, duplicate.Code)
+	if err != nil {
+		return fmt.Errorf("failed to remove supplier links of merged fabric: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE category_fabrics SET fabric_code = $1
+		WHERE fabric_code = $2
+			AND category_code NOT IN (SELECT category_code FROM category_fabrics WHERE fabric_code = $1)
+	`, canonicalCode, duplicate.Code)
+	if err != nil {
+		return fmt.Errorf("failed to move category assignments to canonical fabric: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM category_fabrics WHERE fabric_code = $1`, duplicate.Code)
+	if err != nil {
+		return fmt.Errorf("failed to remove category assignments of merged fabric: %w", err)
+	}
+
+	return nil
+}
+
 func (r *FabricPostgresRepository) GetByCodeIncludingDeleted(ctx context.Context, code string) (*domain.Fabric, error) {
 	query := `
-		SELECT version, code, name, measure_unit, offer_status, status
+		SELECT version, code, name, measure_unit, offer_status, ` + specificationColumns + `, ` + textColumns + `, ` + priceColumns + `, status,
+			COALESCE(status_before_deletion, ''), created_at, created_by, updated_at, updated_by
 		FROM fabrics
 		WHERE code = $1
 	`
 
 	fabric := &domain.Fabric{}
-	err := r.db.Pool.QueryRowContext(ctx, query, code).Scan(
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query, code).Scan(
 		&fabric.Version,
 		&fabric.Code,
 		&fabric.Name,
 		&fabric.MeasureUnit,
 		&fabric.OfferStatus,
+		composition(&fabric.Specification.Composition),
+		&fabric.Specification.WidthCM,
+		&fabric.Specification.WeightGSM,
+		&fabric.Specification.Color,
+		&fabric.Specification.MinOrderQuantity,
+		&fabric.Specification.LeadTimeDays,
+		&fabric.Description,
+		&fabric.Notes,
+		translations(&fabric.Translations),
+		customAttributes(&fabric.CustomAttributes),
+		price(&fabric.Price),
 		&fabric.Status,
+		&fabric.StatusBeforeDeletion,
+		&fabric.CreatedAt,
+		&fabric.CreatedBy,
+		&fabric.UpdatedAt,
+		&fabric.UpdatedBy,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -178,3 +779,546 @@ func (r *FabricPostgresRepository) GetByCodeIncludingDeleted(ctx context.Context
 
 	return fabric, nil
 }
+
+// maps the sort columns accepted by the list endpoint onto table columns
+var fabricSortColumns = map[string]string{
+	"code":         "code",
+	"name":         "name",
+	"version":      "version",
+	"offer_status": "offer_status",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+}
+
+// fabricOrderBy translates the sort keys of a filter into an ORDER BY list, by code when
+// there are none. Code closes every list unless already in it, so pages never overlap.
+// Only the columns of fabricSortColumns are accepted.
+func fabricOrderBy(filter domain.FabricFilter) (string, error) {
+	var (
+		terms  []string
+		byCode bool
+	)
+	for _, key := range filter.SortKeys() {
+		column, ok := fabricSortColumns[key.Column]
+		if !ok {
+			return "", domain.ErrUnsortableColumn.WithParam("column", key.Column)
+		}
+		direction := "ASC"
+		if key.Descending {
+			direction = "DESC"
+		}
+		terms = append(terms, column+" "+direction)
+		byCode = byCode || column == "code"
+	}
+	if !byCode {
+		terms = append(terms, "code ASC")
+	}
+	return strings.Join(terms, ", "), nil
+}
+
+// sqlPredicates accumulates the conditions of a WHERE clause with their positional arguments
+type sqlPredicates struct {
+	clauses []string
+	args    []any
+}
+
+// add appends a condition, format refers to its argument with %[1]d, as often as needed
+func (p *sqlPredicates) add(format string, arg any) {
+	p.args = append(p.args, arg)
+	p.clauses = append(p.clauses, fmt.Sprintf(format, len(p.args)))
+}
+
+func (p *sqlPredicates) where() string {
+	return "WHERE " + strings.Join(p.clauses, " AND ")
+}
+
+// fabricPredicates translates a filter into SQL conditions, one per criterion set
+func fabricPredicates(filter domain.FabricFilter) *sqlPredicates {
+	p := &sqlPredicates{}
+	p.add("status = $%[1]d", filter.StatusOrDefault())
+	if filter.OfferStatus != "" {
+		p.add("offer_status = $%[1]d", filter.OfferStatus)
+	}
+	if filter.MeasureUnit != "" {
+		p.add("measure_unit = $%[1]d", filter.MeasureUnit)
+	}
+	// codes hold no LIKE wildcards, the prefix is matched as it is
+	if filter.CodePrefix != "" {
+		p.add("code LIKE $%[1]d || '%%'", filter.CodePrefix)
+	}
+	if filter.Search != "" {
+		p.add("(code ILIKE '%%' || $%[1]d || '%%' OR name ILIKE '%%' || $%[1]d || '%%')", filter.Search)
+	}
+	if filter.UpdatedSince != nil {
+		p.add("updated_at >= $%[1]d", *filter.UpdatedSince)
+	}
+	if filter.Certification != "" {
+		p.add("EXISTS (SELECT 1 FROM fabric_certifications c WHERE c.fabric_code = fabrics.code AND "+
+			"c.scheme = $%[1]d AND c.valid_from <= now() AND c.valid_until > now())", filter.Certification)
+	}
+	return p
+}
+
+// ListFabrics returns a page of fabrics matching the filter, together with the total
+// number of matching fabrics. Search matches code or name case-insensitively.
+func (r *FabricPostgresRepository) ListFabrics(ctx context.Context, filter domain.FabricFilter) ([]*domain.Fabric, int, error) {
+	orderBy, err := fabricOrderBy(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	predicates := fabricPredicates(filter)
+	args := append(predicates.args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT count(*) OVER(), version, code, name, measure_unit, offer_status, %s, status,
+			created_at, created_by, updated_at, updated_by
+		FROM fabrics
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, specificationColumns+", "+textColumns+", "+priceColumns, predicates.where(), orderBy, len(args)-1, len(args))
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list fabrics: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	fabrics := []*domain.Fabric{}
+	for rows.Next() {
+		fabric := &domain.Fabric{}
+		err := rows.Scan(
+			&totalRecords,
+			&fabric.Version,
+			&fabric.Code,
+			&fabric.Name,
+			&fabric.MeasureUnit,
+			&fabric.OfferStatus,
+			composition(&fabric.Specification.Composition),
+			&fabric.Specification.WidthCM,
+			&fabric.Specification.WeightGSM,
+			&fabric.Specification.Color,
+			&fabric.Specification.MinOrderQuantity,
+			&fabric.Specification.LeadTimeDays,
+			&fabric.Description,
+			&fabric.Notes,
+			translations(&fabric.Translations),
+			customAttributes(&fabric.CustomAttributes),
+			price(&fabric.Price),
+			&fabric.Status,
+			&fabric.CreatedAt,
+			&fabric.CreatedBy,
+			&fabric.UpdatedAt,
+			&fabric.UpdatedBy,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan fabric: %w", err)
+		}
+		fabrics = append(fabrics, fabric)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate fabrics: %w", err)
+	}
+
+	return fabrics, totalRecords, nil
+}
+
+// SearchFabrics finds the active fabrics matching a search, the best ranked first. Fabrics
+// match on the words of their search_vector or, to forgive typos, on a name similar
+// enough to the query by trigrams. Both contribute to the rank.
+func (r *FabricPostgresRepository) SearchFabrics(
+	ctx context.Context, search domain.FabricSearch,
+) ([]domain.FabricSearchHit, int, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT count(*) OVER(), code, name, measure_unit, offer_status,
+			ts_rank(search_vector, query) + word_similarity($2, name) AS rank,
+			ts_headline('simple', name, query, 'HighlightAll=true, StartSel=<mark>, StopSel=</mark>'),
+			CASE WHEN to_tsvector('simple', description) @@ query
+				THEN ts_headline('simple', description, query, 'MaxFragments=2, StartSel=<mark>, StopSel=</mark>')
+				ELSE ''
+			END
+		FROM fabrics, to_tsquery('simple', $1) AS query
+		WHERE status = 'ACTIVE' AND (search_vector @@ query OR $2 <% name)
+		ORDER BY rank DESC, code
+		LIMIT $3 OFFSET $4
+	`, fabricSearchQuery(search.Query), search.Query, search.Limit, search.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search fabrics: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	hits := []domain.FabricSearchHit{}
+	for rows.Next() {
+		var hit domain.FabricSearchHit
+		err := rows.Scan(
+			&totalRecords,
+			&hit.Code,
+			&hit.Name,
+			&hit.MeasureUnit,
+			&hit.OfferStatus,
+			&hit.Rank,
+			&hit.Highlights.Name,
+			&hit.Highlights.Description,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan fabric search hit: %w", err)
+		}
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate fabric search hits: %w", err)
+	}
+
+	return hits, totalRecords, nil
+}
+
+// fabricSearchQuery turns the words of a search into a tsquery matching every one of them
+// as a prefix, "flax lin" into "flax:* & lin:*". Anything but letters and digits separates
+// words, so the query never carries tsquery operators of its own.
+func fabricSearchQuery(raw string) string {
+	words := strings.FieldsFunc(strings.ToLower(raw), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// rows fetched from the export cursor per round trip
+const exportFetchSize = 500
+
+// ExportFabrics streams up to limit active fabrics ordered by code to fn, reading them
+// through a server-side cursor so the result set is never held in memory at once.
+func (r *FabricPostgresRepository) ExportFabrics(ctx context.Context, limit int, fn func(*domain.Fabric) error) error {
+	return r.export(ctx, "WHERE status = 'ACTIVE'", []any{limit}, fn)
+}
+
+// ExportChangedFabrics streams up to limit fabrics with an event recorded after since,
+// whatever their status, ordered by code to fn.
+func (r *FabricPostgresRepository) ExportChangedFabrics(
+	ctx context.Context, since domain.ChangedSince, limit int, fn func(*domain.Fabric) error,
+) error {
+	where := `
+		WHERE code IN (
+			SELECT aggregate_id FROM events
+			WHERE aggregate_type = $2 AND position > $3 AND ($4::timestamptz IS NULL OR "timestamp" > $4)
+		)`
+	return r.export(ctx, where, []any{limit, domain.AggregateType, since.Position, since.Time}, fn)
+}
+
+// LastEventPosition returns the position of the last fabric event, the one to export the
+// changes after on the next run. Positions become visible in order, so no change recorded
+// up to it can still appear.
+func (r *FabricPostgresRepository) LastEventPosition(ctx context.Context) (int64, error) {
+	var position int64
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		"SELECT COALESCE(MAX(position), 0) FROM events WHERE aggregate_type = $1", domain.AggregateType,
+	).Scan(&position)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read last fabric event position: %w", err)
+	}
+	return position, nil
+}
+
+// export streams the fabrics matching where through a server-side cursor, the limit is
+// always the first argument.
+func (r *FabricPostgresRepository) export(ctx context.Context, where string, args []any, fn func(*domain.Fabric) error) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin export transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	declare := `
+		DECLARE fabric_export NO SCROLL CURSOR FOR
+		SELECT version, code, name, measure_unit, offer_status, ` + specificationColumns + `, ` + textColumns + `, ` + priceColumns + `, status,
+			created_at, created_by, updated_at, updated_by
+		FROM fabrics
+		` + where + `
+		ORDER BY code
+		LIMIT $1
+	`
+	if _, err := tx.ExecContext(ctx, declare, args...); err != nil {
+		return fmt.Errorf("failed to declare export cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH %d FROM fabric_export", exportFetchSize)
+	for {
+		fetched, err := r.fetchExportBatch(ctx, tx, fetch, fn)
+		if err != nil {
+			return err
+		}
+		if fetched < exportFetchSize {
+			break
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *FabricPostgresRepository) fetchExportBatch(
+	ctx context.Context, tx database.Querier, fetch string, fn func(*domain.Fabric) error,
+) (int, error) {
+	rows, err := tx.QueryContext(ctx, fetch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch from export cursor: %w", err)
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		fabric := &domain.Fabric{}
+		err := rows.Scan(
+			&fabric.Version,
+			&fabric.Code,
+			&fabric.Name,
+			&fabric.MeasureUnit,
+			&fabric.OfferStatus,
+			composition(&fabric.Specification.Composition),
+			&fabric.Specification.WidthCM,
+			&fabric.Specification.WeightGSM,
+			&fabric.Specification.Color,
+			&fabric.Specification.MinOrderQuantity,
+			&fabric.Specification.LeadTimeDays,
+			&fabric.Description,
+			&fabric.Notes,
+			translations(&fabric.Translations),
+			customAttributes(&fabric.CustomAttributes),
+			price(&fabric.Price),
+			&fabric.Status,
+			&fabric.CreatedAt,
+			&fabric.CreatedBy,
+			&fabric.UpdatedAt,
+			&fabric.UpdatedBy,
+		)
+		if err != nil {
+			return fetched, fmt.Errorf("failed to scan fabric: %w", err)
+		}
+		fetched++
+		if err := fn(fabric); err != nil {
+			return fetched, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fetched, fmt.Errorf("failed to iterate export cursor: %w", err)
+	}
+
+	return fetched, nil
+}
+
+// specificationColumns selects the specification of a fabric, unknown width and weight
+// are stored as NULL and read as zero
+const specificationColumns = `composition, COALESCE(width_cm, 0), COALESCE(weight_gsm, 0), color, ` +
+	`min_order_quantity, lead_time_days`
+
+// textColumns selects the long-form texts of a fabric, its translations included, and its
+// custom attributes
+const textColumns = `description, notes, translations, custom_attributes`
+
+// compositionColumn stores the composition of a fabric as a JSON array
+type compositionColumn struct {
+	parts *[]domain.CompositionPart
+}
+
+func composition(parts *[]domain.CompositionPart) compositionColumn {
+	return compositionColumn{parts: parts}
+}
+
+type compositionPartRecord struct {
+	Fibre   string `json:"fibre"`
+	Percent int    `json:"percent"`
+}
+
+func (c compositionColumn) Value() (driver.Value, error) {
+	records := make([]compositionPartRecord, 0, len(*c.parts))
+	for _, part := range *c.parts {
+		records = append(records, compositionPartRecord{Fibre: part.Fibre, Percent: part.Percent})
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode composition: %w", err)
+	}
+	return string(data), nil
+}
+
+func (c compositionColumn) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*c.parts = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into composition", src)
+	}
+
+	var records []compositionPartRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("failed to decode composition: %w", err)
+	}
+	*c.parts = nil
+	for _, record := range records {
+		*c.parts = append(*c.parts, domain.CompositionPart{Fibre: record.Fibre, Percent: record.Percent})
+	}
+	return nil
+}
+
+// translationsColumn reads and writes the translations of a fabric as a JSON object keyed
+// by locale
+type translationsColumn struct {
+	translations *map[string]domain.Translation
+}
+
+func translations(t *map[string]domain.Translation) translationsColumn {
+	return translationsColumn{translations: t}
+}
+
+type translationRecord struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+func (c translationsColumn) Value() (driver.Value, error) {
+	records := make(map[string]translationRecord, len(*c.translations))
+	for locale, translation := range *c.translations {
+		records[locale] = translationRecord{Name: translation.Name, Description: translation.Description}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode translations: %w", err)
+	}
+	return string(data), nil
+}
+
+func (c translationsColumn) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*c.translations = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into translations", src)
+	}
+
+	var records map[string]translationRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("failed to decode translations: %w", err)
+	}
+	*c.translations = nil
+	for locale, record := range records {
+		if *c.translations == nil {
+			*c.translations = map[string]domain.Translation{}
+		}
+		(*c.translations)[locale] = domain.Translation{Name: record.Name, Description: record.Description}
+	}
+	return nil
+}
+
+// customAttributesColumn reads and writes the custom attributes of a fabric as a JSON
+// object keyed by attribute
+type customAttributesColumn struct {
+	values *map[string]any
+}
+
+func customAttributes(v *map[string]any) customAttributesColumn {
+	return customAttributesColumn{values: v}
+}
+
+func (c customAttributesColumn) Value() (driver.Value, error) {
+	values := *c.values
+	if values == nil {
+		values = map[string]any{}
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode custom attributes: %w", err)
+	}
+	return string(data), nil
+}
+
+func (c customAttributesColumn) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*c.values = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into custom attributes", src)
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to decode custom attributes: %w", err)
+	}
+	*c.values = nil
+	if len(values) > 0 {
+		*c.values = values
+	}
+	return nil
+}
+
+// priceColumns selects the list price of a fabric as a single JSON object, NULL for a
+// fabric that has not been priced yet
+const priceColumns = `CASE WHEN list_price IS NULL THEN NULL ELSE json_build_object(
+	'amount', list_price, 'currency', currency, 'valid_from', price_valid_from
+) END`
+
+// priceColumn reads the list price selected by priceColumns
+type priceColumn struct {
+	price **domain.Price
+}
+
+func price(p **domain.Price) priceColumn {
+	return priceColumn{price: p}
+}
+
+type priceRecord struct {
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	ValidFrom time.Time `json:"valid_from"`
+}
+
+func (c priceColumn) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*c.price = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into price", src)
+	}
+
+	var record priceRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return fmt.Errorf("failed to decode price: %w", err)
+	}
+	*c.price = &domain.Price{Amount: record.Amount, Currency: record.Currency, ValidFrom: record.ValidFrom}
+	return nil
+}
+
+// priceArgs returns the list price, currency and validity start to store, all NULL when
+// the fabric has no price
+func priceArgs(p *domain.Price) []any {
+	if p == nil {
+		return []any{nil, nil, nil}
+	}
+	return []any{p.Amount, p.Currency, p.ValidFrom}
+}
@@ -9,10 +9,14 @@ import (
 
 	"github.com/salesworks/s-works/api/internal/fabrics/domain"
 	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+var testStamp = domain.Stamp{By: "tester", At: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+
 type postgresTestFixture struct {
 	db   *database.PostgresDB
 	repo *FabricPostgresRepository
@@ -26,7 +30,13 @@ func setup(t *testing.T) *postgresTestFixture {
 	repo := NewFabricPostgresRepository(db)
 
 	t.Cleanup(func() {
-		_, err := db.Pool.Exec("DELETE FROM fabrics")
+		_, err := db.Pool.Exec(`
+			DELETE FROM category_fabrics; DELETE FROM categories; DELETE FROM supplier_fabrics; DELETE FROM suppliers;
+			DELETE FROM fabric_attachments; DELETE FROM fabric_certifications; DELETE FROM fabric_barcodes;
+			DELETE FROM fabric_stock;
+			DELETE FROM fabric_drafts; DELETE FROM fabric_edit_locks; DELETE FROM fabric_aliases; DELETE FROM fabrics;
+			DELETE FROM fabric_code_reservations; DELETE FROM fabric_code_sequences; DELETE FROM fabric_purges
+		`)
 		if err != nil {
 			t.Fatalf("Failed to clean up test data: %v", err)
 		}
@@ -65,7 +75,7 @@ func setupTestPostgresDB(t *testing.T) *database.PostgresDB {
 func TestFabricPostgresRepository_Save(t *testing.T) {
 	// --- Arrange ---
 	fixture := setup(t)
-	fabricToSave, err := domain.NewFabric("PGTEST01", "Postgres Test Fabric", "m", "available")
+	fabricToSave, err := domain.NewFabric("PGTEST01", "Postgres Test Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 
 	// --- Act ---
@@ -82,7 +92,7 @@ func TestFabricPostgresRepository_Save(t *testing.T) {
 func TestFabricPostgresRepository_Save_ConflictOnActiveFabric(t *testing.T) {
 	// --- Arrange ---
 	fixture := setup(t)
-	fabricToSave, err := domain.NewFabric("DUPLICATE", "Duplicate Test Fabric", "m", "available")
+	fabricToSave, err := domain.NewFabric("DUPLICATE", "Duplicate Test Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 
 	// --- Act & Assert
@@ -96,7 +106,7 @@ func TestFabricPostgresRepository_Save_ConflictOnActiveFabric(t *testing.T) {
 func TestFabricPostgresRepository_GetByCode(t *testing.T) {
 	// --- Arrange ---
 	fixture := setup(t)
-	fabricToSave, err := domain.NewFabric("GETCODE", "GetByCode Fabric", "m", "available")
+	fabricToSave, err := domain.NewFabric("GETCODE", "GetByCode Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 
 	// --- Act ---
@@ -109,16 +119,124 @@ func TestFabricPostgresRepository_GetByCode(t *testing.T) {
 	assert.NotNil(t, retrivedFabric)
 	assert.Equal(t, fabricToSave.Code, retrivedFabric.Code)
 	assert.Equal(t, fabricToSave.Name, retrivedFabric.Name)
+	assert.Equal(t, testStamp.By, retrivedFabric.CreatedBy)
+	assert.Equal(t, testStamp.By, retrivedFabric.UpdatedBy)
+	assert.True(t, testStamp.At.Equal(retrivedFabric.CreatedAt), "created_at should round-trip")
 
 	_, err = fixture.repo.GetByCode(context.Background(), "NOEXISTENT")
 	assert.ErrorIs(t, err, domain.ErrRecordNotFound, "GetByCode should return ErrRecordNotFound for nonexistent code")
 }
 
+func TestFabricPostgresRepository_Specification_RoundTrip(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	spec := domain.Specification{
+		Composition:      []domain.CompositionPart{{Fibre: "cotton", Percent: 70}, {Fibre: "polyester", Percent: 30}},
+		WidthCM:          150,
+		Color:            "graphite",
+		MinOrderQuantity: 30,
+		LeadTimeDays:     10,
+	}
+	fabric, err := domain.NewFabric("PGSPEC01", "Specified Fabric", "m", "available", spec, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	_, err = fixture.repo.Save(context.Background(), fabric)
+	require.NoError(t, err)
+	saved, err := fixture.repo.GetByCode(context.Background(), fabric.Code)
+	require.NoError(t, err)
+
+	// --- Assert ---
+	assert.Equal(t, spec, saved.Specification, "the specification should round-trip, unknown weight included")
+
+	// --- Act ---
+	replacement := domain.Specification{WeightGSM: 240, LeadTimeDays: 45}
+	require.NoError(t, saved.UpdateFabric(saved.Name, "m", "available", &replacement, domain.FabricTexts{}, saved.Version, testStamp))
+	require.NoError(t, fixture.repo.Update(context.Background(), saved))
+	updated, err := fixture.repo.GetByCode(context.Background(), fabric.Code)
+	require.NoError(t, err)
+
+	// --- Assert ---
+	assert.Equal(t, replacement, updated.Specification)
+}
+
+func TestFabricPostgresRepository_Texts_RoundTrip(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	description, notes := "Soft *linen*\n\nfor summer", "reorder in May"
+	texts := domain.FabricTexts{Description: &description, Notes: &notes}
+	fabric, err := domain.NewFabric("PGTEXT01", "Described Fabric", "m", "available", domain.Specification{}, texts, testStamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	_, err = fixture.repo.Save(context.Background(), fabric)
+	require.NoError(t, err)
+	saved, err := fixture.repo.GetByCode(context.Background(), fabric.Code)
+	require.NoError(t, err)
+	cleared := ""
+	require.NoError(t, saved.UpdateFabric(
+		saved.Name, "m", "available", nil, domain.FabricTexts{Notes: &cleared}, saved.Version, testStamp,
+	))
+	require.NoError(t, fixture.repo.Update(context.Background(), saved))
+	updated, err := fixture.repo.GetByCode(context.Background(), fabric.Code)
+	require.NoError(t, err)
+
+	// --- Assert ---
+	assert.Equal(t, description, updated.Description)
+	assert.Equal(t, "", updated.Notes)
+}
+
+func TestFabricPostgresRepository_Translations_RoundTrip(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	fabric, err := domain.NewFabric("PGTRANS01", "Velvet", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(context.Background(), fabric)
+	require.NoError(t, err)
+
+	// --- Act ---
+	saved, err := fixture.repo.GetByCode(context.Background(), fabric.Code)
+	require.NoError(t, err)
+	translation := domain.Translation{Name: "Samt", Description: "Weicher *Samt*"}
+	require.NoError(t, saved.SetTranslation("de", translation, saved.Version, testStamp))
+	require.NoError(t, fixture.repo.Update(context.Background(), saved))
+	updated, err := fixture.repo.GetByCode(context.Background(), fabric.Code)
+	require.NoError(t, err)
+
+	// --- Assert ---
+	assert.Equal(t, map[string]domain.Translation{"de": translation}, updated.Translations)
+}
+
+func TestFabricPostgresRepository_CustomAttributes_RoundTrip(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	fabric, err := domain.NewFabric("PGATTR01", "Velvet", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(context.Background(), fabric)
+	require.NoError(t, err)
+	definitions := []*domain.AttributeDefinition{
+		{Key: "gots_certified", Type: domain.AttributeTypeBoolean},
+		{Key: "shrinkage_pct", Type: domain.AttributeTypeNumber},
+	}
+	values := map[string]any{"gots_certified": true, "shrinkage_pct": 2.5}
+
+	// --- Act ---
+	saved, err := fixture.repo.GetByCode(context.Background(), fabric.Code)
+	require.NoError(t, err)
+	require.NoError(t, saved.SetCustomAttributes(values, definitions, saved.Version, testStamp))
+	require.NoError(t, fixture.repo.Update(context.Background(), saved))
+	updated, err := fixture.repo.GetByCode(context.Background(), fabric.Code)
+	require.NoError(t, err)
+
+	// --- Assert ---
+	assert.Equal(t, values, updated.CustomAttributes)
+}
+
 func TestFabricPostgresRepository_Update_HappyPath(t *testing.T) {
 	// --- Arrange ---
 	fixture := setup(t)
 	code := "UPDATETEST01"
-	fabricToSave, err := domain.NewFabric(code, "Initial Name", "m", "available")
+	fabricToSave, err := domain.NewFabric(code, "Initial Name", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 
 	_, err = fixture.repo.Save(context.Background(), fabricToSave)
@@ -146,7 +264,7 @@ func TestFabricPostgresRepository_Update_ConcurrencyConflict(t *testing.T) {
 	// --- Arrange ---
 	fixture := setup(t)
 	code := "UPDATETEST02"
-	fabricToSave, err := domain.NewFabric(code, "Initial Name", "m", "available")
+	fabricToSave, err := domain.NewFabric(code, "Initial Name", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 
 	_, err = fixture.repo.Save(context.Background(), fabricToSave)
@@ -167,13 +285,13 @@ func TestFabricPostgresRepository_Delete(t *testing.T) {
 	// --- Arrange ---
 	fixture := setup(t)
 	code := "DELETETEST"
-	fabric, err := domain.NewFabric(code, "To Be Deleted", "m", "available")
+	fabric, err := domain.NewFabric(code, "To Be Deleted", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 	persistedFabric, err := fixture.repo.Save(context.Background(), fabric)
 	require.NoError(t, err)
 
 	// --- Act ---
-	err = persistedFabric.Delete(1)
+	err = persistedFabric.Delete(1, testStamp)
 	require.NoError(t, err)
 
 	err = fixture.repo.Delete(context.Background(), persistedFabric)
@@ -196,20 +314,20 @@ func TestFabricPostgresRepository_Save_ReactivationIncrementsVersion(t *testing.
 	code := "REACTIVATE"
 
 	// 1. Create a fabric (version 1)
-	fabricToCreate, err := domain.NewFabric(code, "Original", "m", "available")
+	fabricToCreate, err := domain.NewFabric(code, "Original", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 	persistedFabric, err := fixture.repo.Save(context.Background(), fabricToCreate)
 	require.NoError(t, err)
 	require.Equal(t, 1, persistedFabric.Version)
 
 	// 2. Delete the fabric (results in version 2)
-	err = persistedFabric.Delete(1)
+	err = persistedFabric.Delete(1, testStamp)
 	require.NoError(t, err)
 	err = fixture.repo.Delete(context.Background(), persistedFabric)
 	require.NoError(t, err)
 
 	// 3. Prepare a "new" fabric object to simulate the reactivation request.
-	reactivationRequest, err := domain.NewFabric(code, "Reactivated", "cm", "new")
+	reactivationRequest, err := domain.NewFabric(code, "Reactivated", "cm", "new", domain.Specification{}, domain.FabricTexts{}, testStamp)
 	require.NoError(t, err)
 
 	// --- Act ---
@@ -224,3 +342,837 @@ func TestFabricPostgresRepository_Save_ReactivationIncrementsVersion(t *testing.
 	_, ok := finalFabric.Events()[0].(domain.FabricReactivated)
 	assert.True(t, ok, "The event should be FabricReactivated")
 }
+
+func TestFabricPostgresRepository_ChangeStatus(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	draft, err := domain.NewDraftFabric("LIFECYCLE", "Lifecycle", "m", "new", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, draft)
+	require.NoError(t, err)
+
+	// --- Act ---
+	require.NoError(t, draft.Activate(1, testStamp))
+	require.NoError(t, fixture.repo.ChangeStatus(ctx, draft))
+	require.NoError(t, draft.Discontinue(2, testStamp))
+	require.NoError(t, fixture.repo.ChangeStatus(ctx, draft))
+
+	// --- Assert ---
+	stored, err := fixture.repo.GetByCode(ctx, "LIFECYCLE")
+	require.NoError(t, err, "a discontinued fabric is still in the catalogue")
+	assert.Equal(t, domain.StatusDiscontinued, stored.Status)
+	assert.Equal(t, 3, stored.Version)
+
+	duplicate, err := domain.NewFabric("LIFECYCLE", "Lifecycle", "m", "new", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, duplicate)
+	assert.ErrorIs(t, err, domain.ErrDuplicateFabricCode, "the code stays taken whatever the status")
+
+	stale := *stored
+	stale.Version = 3
+	assert.ErrorIs(t, fixture.repo.ChangeStatus(ctx, &stale), domain.ErrRecordNotFound)
+}
+
+func TestFabricPostgresRepository_ListFabrics_SearchAndSort(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	for _, f := range []struct{ code, name string }{
+		{"LISTA", "Velvet Blue"},
+		{"LISTB", "Cotton White"},
+		{"LISTC", "Velvet Red"},
+	} {
+		fabric, err := domain.NewFabric(f.code, f.name, "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+		require.NoError(t, err)
+		_, err = fixture.repo.Save(context.Background(), fabric)
+		require.NoError(t, err)
+	}
+	filter := domain.FabricFilter{Search: "velvet", Sort: "-name", Limit: 10, Offset: 0}
+
+	// --- Act ---
+	fabrics, total, err := fixture.repo.ListFabrics(context.Background(), filter)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, fabrics, 2)
+	assert.Equal(t, "LISTC", fabrics[0].Code, "descending name sort should put Velvet Red first")
+	assert.Equal(t, "LISTA", fabrics[1].Code)
+}
+
+func TestFabricPredicates(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := []struct {
+		name          string
+		filter        domain.FabricFilter
+		expectedWhere string
+		expectedArgs  []any
+	}{
+		{
+			name:          "Active fabrics by default",
+			filter:        domain.FabricFilter{},
+			expectedWhere: "WHERE status = $1",
+			expectedArgs:  []any{domain.StatusActive},
+		},
+		{
+			name: "Every criterion",
+			filter: domain.FabricFilter{
+				Status: domain.StatusDeleted, OfferStatus: domain.OfferStatusNew, Search: "velvet", UpdatedSince: &since,
+			},
+			expectedWhere: "WHERE status = $1 AND offer_status = $2 AND " +
+				"(code ILIKE '%' || $3 || '%' OR name ILIKE '%' || $3 || '%') AND updated_at >= $4",
+			expectedArgs: []any{domain.StatusDeleted, domain.OfferStatusNew, "velvet", since},
+		},
+		{
+			name:          "Measure unit and code prefix",
+			filter:        domain.FabricFilter{MeasureUnit: domain.MeasureUnitKilogram, CodePrefix: "VEL"},
+			expectedWhere: "WHERE status = $1 AND measure_unit = $2 AND code LIKE $3 || '%'",
+			expectedArgs:  []any{domain.StatusActive, domain.MeasureUnitKilogram, "VEL"},
+		},
+		{
+			name:   "Active certification",
+			filter: domain.FabricFilter{Certification: domain.CertificationGOTS},
+			expectedWhere: "WHERE status = $1 AND EXISTS (SELECT 1 FROM fabric_certifications c WHERE " +
+				"c.fabric_code = fabrics.code AND c.scheme = $2 AND c.valid_from <= now() AND c.valid_until > now())",
+			expectedArgs: []any{domain.StatusActive, domain.CertificationGOTS},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			predicates := fabricPredicates(tc.filter)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedWhere, predicates.where())
+			assert.Equal(t, tc.expectedArgs, predicates.args)
+		})
+	}
+}
+
+func TestFabricOrderBy(t *testing.T) {
+	testCases := []struct {
+		name            string
+		sort            string
+		expectedOrderBy string
+		expectedErr     error
+	}{
+		{name: "By code when unsorted", sort: "", expectedOrderBy: "code ASC"},
+		{name: "Code closes the list", sort: "name,-version", expectedOrderBy: "name ASC, version DESC, code ASC"},
+		{name: "Code already in the list", sort: "-code,name", expectedOrderBy: "code DESC, name ASC"},
+		{name: "Column not offered", sort: "name,notes", expectedErr: domain.ErrUnsortableColumn},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			orderBy, err := fabricOrderBy(domain.FabricFilter{Sort: tc.sort})
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedOrderBy, orderBy)
+		})
+	}
+}
+
+func TestFabricPostgresRepository_ListFabrics_Filters(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	for _, f := range []struct{ code, offerStatus string }{
+		{"FILTA", "new"},
+		{"FILTB", "available"},
+		{"FILTC", "new"},
+	} {
+		fabric, err := domain.NewFabric(f.code, "Filtered", "m", f.offerStatus, domain.Specification{}, domain.FabricTexts{}, testStamp)
+		require.NoError(t, err)
+		_, err = fixture.repo.Save(ctx, fabric)
+		require.NoError(t, err)
+	}
+	deleted, err := fixture.repo.GetByCode(ctx, "FILTC")
+	require.NoError(t, err)
+	require.NoError(t, deleted.Delete(deleted.Version, testStamp))
+	require.NoError(t, fixture.repo.Delete(ctx, deleted))
+	since := testStamp.At
+
+	// --- Act ---
+	active, activeTotal, err := fixture.repo.ListFabrics(ctx, domain.FabricFilter{
+		OfferStatus: domain.OfferStatusNew, UpdatedSince: &since, Limit: 10,
+	})
+	require.NoError(t, err)
+	removed, removedTotal, err := fixture.repo.ListFabrics(ctx, domain.FabricFilter{Status: domain.StatusDeleted, Limit: 10})
+	require.NoError(t, err)
+	prefixed, prefixedTotal, err := fixture.repo.ListFabrics(ctx, domain.FabricFilter{
+		MeasureUnit: domain.MeasureUnitMetre, CodePrefix: "FILTB", Limit: 10,
+	})
+	require.NoError(t, err)
+
+	// --- Assert ---
+	assert.Equal(t, 1, activeTotal)
+	require.Len(t, active, 1)
+	assert.Equal(t, "FILTA", active[0].Code)
+	assert.Equal(t, 1, removedTotal)
+	require.Len(t, removed, 1)
+	assert.Equal(t, "FILTC", removed[0].Code)
+	assert.Equal(t, 1, prefixedTotal)
+	require.Len(t, prefixed, 1)
+	assert.Equal(t, "FILTB", prefixed[0].Code)
+}
+
+func TestFabricSearchQuery(t *testing.T) {
+	testCases := []struct {
+		name          string
+		raw           string
+		expectedQuery string
+	}{
+		{name: "Words become prefixes", raw: "Flax lin", expectedQuery: "flax:* & lin:*"},
+		{name: "Operators are dropped", raw: "cotton & !silk | (wool)", expectedQuery: "cotton:* & silk:* & wool:*"},
+		{name: "Letters beyond ASCII are kept", raw: "Len-Żakard 300", expectedQuery: "len:* & żakard:* & 300:*"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			query := fabricSearchQuery(tc.raw)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedQuery, query)
+		})
+	}
+}
+
+func TestFabricPostgresRepository_SearchFabrics(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	description := "A heavy linen for upholstery"
+	for _, f := range []struct {
+		code, name string
+		texts      domain.FabricTexts
+	}{
+		{"SRCHA", "Linen Natural", domain.FabricTexts{}},
+		{"SRCHB", "Velvet Royal", domain.FabricTexts{Description: &description}},
+		{"SRCHC", "Cotton Plain", domain.FabricTexts{}},
+	} {
+		fabric, err := domain.NewFabric(f.code, f.name, "m", "available", domain.Specification{}, f.texts, testStamp)
+		require.NoError(t, err)
+		_, err = fixture.repo.Save(ctx, fabric)
+		require.NoError(t, err)
+	}
+
+	// --- Act ---
+	prefixed, prefixedTotal, err := fixture.repo.SearchFabrics(ctx, domain.FabricSearch{Query: "line", Limit: 10})
+	require.NoError(t, err)
+	misspelled, misspelledTotal, err := fixture.repo.SearchFabrics(ctx, domain.FabricSearch{Query: "Velvett", Limit: 10})
+	require.NoError(t, err)
+
+	// --- Assert ---
+	assert.Equal(t, 2, prefixedTotal)
+	require.Len(t, prefixed, 2)
+	assert.Equal(t, "SRCHA", prefixed[0].Code, "a match in the name outranks one in the description")
+	assert.Equal(t, "<mark>Linen</mark> Natural", prefixed[0].Highlights.Name)
+	assert.Empty(t, prefixed[0].Highlights.Description)
+	assert.Equal(t, "SRCHB", prefixed[1].Code)
+	assert.Contains(t, prefixed[1].Highlights.Description, "<mark>linen</mark>")
+	assert.Equal(t, 1, misspelledTotal)
+	require.Len(t, misspelled, 1)
+	assert.Equal(t, "SRCHB", misspelled[0].Code)
+}
+
+func TestFabricPostgresRepository_ExportFabrics(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	for _, code := range []string{"EXPB", "EXPA", "EXPC"} {
+		fabric, err := domain.NewFabric(code, "Export Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+		require.NoError(t, err)
+		_, err = fixture.repo.Save(context.Background(), fabric)
+		require.NoError(t, err)
+	}
+
+	// --- Act ---
+	var codes []string
+	err := fixture.repo.ExportFabrics(context.Background(), 2, func(f *domain.Fabric) error {
+		codes = append(codes, f.Code)
+		return nil
+	})
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, []string{"EXPA", "EXPB"}, codes, "export should be ordered by code and limited")
+}
+
+func TestFabricPostgresRepository_Merge(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	duplicate, err := domain.NewFabric("PGDUPE01", "Duplicate", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	canonical, err := domain.NewFabric("PGCANON01", "Canonical", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, duplicate)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, canonical)
+	require.NoError(t, err)
+	_, err = fixture.db.Pool.Exec(
+		"INSERT INTO fabric_aliases (alias_code, canonical_code) VALUES ('PGLEGACY01', 'PGDUPE01')",
+	)
+	require.NoError(t, err)
+	require.NoError(t, duplicate.MergeInto(canonical, 1, testStamp))
+
+	// --- Act ---
+	err = fixture.repo.Merge(ctx, duplicate, canonical.Code)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	var status string
+	var version int
+	err = fixture.db.Pool.QueryRow("SELECT status, version FROM fabrics WHERE code = $1", duplicate.Code).Scan(&status, &version)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusMerged, status)
+	assert.Equal(t, 2, version)
+
+	rows, err := fixture.db.Pool.Query("SELECT alias_code FROM fabric_aliases WHERE canonical_code = $1 ORDER BY alias_code", canonical.Code)
+	require.NoError(t, err)
+	defer rows.Close()
+	var aliases []string
+	for rows.Next() {
+		var alias string
+		require.NoError(t, rows.Scan(&alias))
+		aliases = append(aliases, alias)
+	}
+	assert.Equal(t, []string{"PGDUPE01", "PGLEGACY01"}, aliases, "the duplicate and its own aliases should resolve to the canonical fabric")
+}
+
+func TestFabricPostgresRepository_Merge_MovesFabricRows(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	duplicate, err := domain.NewFabric("PGDUPE02", "Duplicate", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	canonical, err := domain.NewFabric("PGCANON02", "Canonical", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, duplicate)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, canonical)
+	require.NoError(t, err)
+	_, err = fixture.db.Pool.Exec(`
+		INSERT INTO fabric_stock (code, on_hand, reserved, version, updated_at) VALUES
+			('PGDUPE02', 5000, 1000, 3, now()), ('PGCANON02', 2000, 500, 7, now());
+		INSERT INTO fabric_stock_reservations (fabric_code, reference, quantity, expires_at, reserved_at) VALUES
+			('PGDUPE02', 'ORDER-1', 600, now() + interval '1 day', now()), ('PGDUPE02', 'QUOTE-1', 400, NULL, now()),
+			('PGCANON02', 'ORDER-1', 500, now() + interval '2 days', now());
+		INSERT INTO fabric_attachments (id, fabric_code, kind, file_name, content_type, size, storage_key, created_at)
+		VALUES ('0190b0a0-0000-7000-8000-000000000001', 'PGDUPE02', 'image', 'a.png', 'image/png', 1, 'k1', now());
+		INSERT INTO suppliers (code, name, version, created_at, updated_at) VALUES
+			('PGSUP01', 'Both', 1, now(), now()), ('PGSUP02', 'Duplicate only', 1, now(), now());
+		INSERT INTO supplier_fabrics (supplier_code, fabric_code, lead_time_days, article_number, linked_at) VALUES
+			('PGSUP01', 'PGDUPE02', 10, 'OLD-1', now()), ('PGSUP01', 'PGCANON02', 5, 'NEW-1', now()),
+			('PGSUP02', 'PGDUPE02', 20, 'ONLY-1', now());
+		INSERT INTO categories (code, name, version, created_at, updated_at) VALUES
+			('PGCAT01', 'Both', 1, now(), now()), ('PGCAT02', 'Duplicate only', 1, now(), now());
+		INSERT INTO category_fabrics (category_code, fabric_code, assigned_at) VALUES
+			('PGCAT01', 'PGDUPE02', now()), ('PGCAT01', 'PGCANON02', now()), ('PGCAT02', 'PGDUPE02', now());
+	`)
+	require.NoError(t, err)
+	require.NoError(t, duplicate.MergeInto(canonical, 1, testStamp))
+
+	// --- Act ---
+	err = fixture.repo.Merge(ctx, duplicate, canonical.Code)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	count := func(query string) int {
+		t.Helper()
+		var n int
+		require.NoError(t, fixture.db.Pool.QueryRow(query).Scan(&n))
+		return n
+	}
+
+	var onHand, reserved, stockVersion int64
+	err = fixture.db.Pool.QueryRow(
+		"SELECT on_hand, reserved, version FROM fabric_stock WHERE code = 'PGCANON02'",
+	).Scan(&onHand, &reserved, &stockVersion)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7000), onHand, "the stock on hand should be added up")
+	assert.Equal(t, int64(1500), reserved, "the reservations should be added up")
+	assert.Equal(t, int64(8), stockVersion, "the canonical stock should move to a new version")
+	assert.Zero(t, count("SELECT count(*) FROM fabric_stock WHERE code = 'PGDUPE02'"))
+	assert.Equal(t, 1100, count("SELECT quantity FROM fabric_stock_reservations WHERE fabric_code = 'PGCANON02' AND reference = 'ORDER-1'"))
+	assert.Equal(t, 400, count("SELECT quantity FROM fabric_stock_reservations WHERE fabric_code = 'PGCANON02' AND reference = 'QUOTE-1'"))
+
+	assert.Equal(t, 1, count("SELECT count(*) FROM fabric_attachments WHERE fabric_code = 'PGCANON02'"))
+	assert.Zero(t, count("SELECT count(*) FROM fabric_attachments WHERE fabric_code = 'PGDUPE02'"))
+
+	var leadTime int
+	var articleNumber string
+	err = fixture.db.Pool.QueryRow(
+		"SELECT lead_time_days, article_number FROM supplier_fabrics WHERE supplier_code = 'PGSUP01' AND fabric_code = 'PGCANON02'",
+	).Scan(&leadTime, &articleNumber)
+	require.NoError(t, err)
+	assert.Equal(t, 5, leadTime, "the canonical terms should win over the duplicate ones")
+	assert.Equal(t, "NEW-1", articleNumber)
+	assert.Equal(t, 1, count("SELECT count(*) FROM supplier_fabrics WHERE supplier_code = 'PGSUP02' AND fabric_code = 'PGCANON02'"))
+	assert.Zero(t, count("SELECT count(*) FROM supplier_fabrics WHERE fabric_code = 'PGDUPE02'"))
+
+	assert.Equal(t, 2, count("SELECT count(*) FROM category_fabrics WHERE fabric_code = 'PGCANON02'"))
+	assert.Zero(t, count("SELECT count(*) FROM category_fabrics WHERE fabric_code = 'PGDUPE02'"))
+}
+
+func TestFabricPostgresRepository_ChangeCode(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	fabric, err := domain.NewFabric("PGOLD01", "Renamed", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, fabric)
+	require.NoError(t, err)
+	_, err = fixture.db.Pool.Exec(`
+		INSERT INTO fabric_aliases (alias_code, canonical_code) VALUES ('PGLEGACY02', 'PGOLD01');
+		INSERT INTO fabric_stock (code, on_hand, reserved, version, updated_at) VALUES ('PGOLD01', 5000, 0, 1, now());
+		INSERT INTO suppliers (code, name, version, created_at, updated_at) VALUES ('PGSUP03', 'Supplier', 1, now(), now());
+		INSERT INTO supplier_fabrics (supplier_code, fabric_code, lead_time_days, article_number, linked_at)
+		VALUES ('PGSUP03', 'PGOLD01', 10, 'ART-1', now());
+	`)
+	require.NoError(t, err)
+	require.NoError(t, fabric.ChangeCode("PGNEW01", 1, testStamp))
+
+	// --- Act ---
+	err = fixture.repo.ChangeCode(ctx, fabric, "PGOLD01")
+
+	// --- Assert ---
+	require.NoError(t, err)
+	byOldCode, err := fixture.repo.GetByCode(ctx, "PGOLD01")
+	require.NoError(t, err)
+	assert.Equal(t, "PGNEW01", byOldCode.Code, "the previous code should resolve to the renamed fabric")
+	assert.Equal(t, 2, byOldCode.Version)
+	byLegacyCode, err := fixture.repo.GetByCode(ctx, "PGLEGACY02")
+	require.NoError(t, err)
+	assert.Equal(t, "PGNEW01", byLegacyCode.Code, "existing aliases should follow the rename")
+
+	var stockCode, supplierFabricCode string
+	require.NoError(t, fixture.db.Pool.QueryRow("SELECT code FROM fabric_stock").Scan(&stockCode))
+	assert.Equal(t, "PGNEW01", stockCode)
+	require.NoError(t, fixture.db.Pool.QueryRow("SELECT fabric_code FROM supplier_fabrics").Scan(&supplierFabricCode))
+	assert.Equal(t, "PGNEW01", supplierFabricCode)
+}
+
+func TestFabricPostgresRepository_ChangeCode_KeepsHistory(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	t.Cleanup(func() {
+		_, _ = fixture.db.Pool.Exec(`DELETE FROM events WHERE aggregate_id IN ('PGOLD03', 'PGNEW03')`)
+	})
+	store := eventstore.NewPostgresStore(fixture.db.Pool)
+	fabric, err := domain.NewFabric("PGOLD03", "Renamed", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, fabric)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx,
+		messaging.NewEventEnvelope("app.fabric.created", "PGOLD03", domain.AggregateType, 1, fabric.Events()[0]),
+		messaging.NewEventEnvelope("app.fabric.stock_adjusted", "PGOLD03", "FabricStock", 1, map[string]any{"Code": "PGOLD03"}),
+	))
+	require.NoError(t, fabric.ChangeCode("PGNEW03", 1, testStamp))
+	events := fabric.Events()
+
+	// --- Act ---
+	err = fixture.repo.ChangeCode(ctx, fabric, "PGOLD03")
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx,
+		messaging.NewEventEnvelope("app.fabric.code_changed", "PGNEW03", domain.AggregateType, 2, events[len(events)-1]),
+	))
+	history, err := store.ReadAggregate(ctx, domain.AggregateType, "PGNEW03")
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.Len(t, history, 2, "the events before the rename should be read under the new code")
+	assert.Equal(t, "app.fabric.created", history[0].Envelope.EventType)
+	assert.Equal(t, "app.fabric.code_changed", history[1].Envelope.EventType)
+	assert.Equal(t, []int64{1, 2}, []int64{history[0].Envelope.Sequence, history[1].Envelope.Sequence})
+	stock, err := store.ReadAggregate(ctx, "FabricStock", "PGNEW03")
+	require.NoError(t, err)
+	assert.Len(t, stock, 1)
+	left, err := store.ReadAggregate(ctx, domain.AggregateType, "PGOLD03")
+	require.NoError(t, err)
+	assert.Empty(t, left)
+}
+
+func TestFabricPostgresRepository_ChangeCode_RefusesTakenCode(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	for _, code := range []string{"PGOLD02", "PGTAKEN02"} {
+		fabric, err := domain.NewFabric(code, "Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+		require.NoError(t, err)
+		_, err = fixture.repo.Save(ctx, fabric)
+		require.NoError(t, err)
+	}
+	_, err := fixture.db.Pool.Exec("INSERT INTO fabric_aliases (alias_code, canonical_code) VALUES ('PGALIAS02', 'PGTAKEN02')")
+	require.NoError(t, err)
+
+	for _, taken := range []string{"PGTAKEN02", "PGALIAS02"} {
+		fabric, err := fixture.repo.GetByCode(ctx, "PGOLD02")
+		require.NoError(t, err)
+		require.NoError(t, fabric.ChangeCode(taken, fabric.Version, testStamp))
+
+		// --- Act ---
+		err = fixture.repo.ChangeCode(ctx, fabric, "PGOLD02")
+
+		// --- Assert ---
+		assert.ErrorIs(t, err, domain.ErrDuplicateFabricCode, taken)
+	}
+}
+
+func TestFabricPostgresRepository_Purge(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	t.Cleanup(func() {
+		_, _ = fixture.db.Pool.Exec(`DELETE FROM events WHERE aggregate_id IN ('PGPURGE01', 'PGPURGED02', 'PGKEPT01')`)
+	})
+	fabric, err := domain.NewFabric("PGPURGE01", "Purged", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, fabric)
+	require.NoError(t, err)
+	_, err = fixture.db.Pool.Exec(`
+		INSERT INTO fabric_aliases (alias_code, canonical_code) VALUES ('PGPURGED02', 'PGPURGE01');
+		INSERT INTO fabric_stock (code, on_hand, reserved, version, updated_at) VALUES ('PGPURGE01', 5000, 0, 1, now());
+		INSERT INTO fabric_barcodes (barcode, fabric_code, created_at) VALUES ('4006381333931', 'PGPURGE01', now());
+		INSERT INTO events (
+			event_id, aggregate_id, aggregate_type, event_type, aggregate_version, payload, "timestamp", sequence
+		)
+		VALUES
+			('0190b0a0-0000-7000-8000-000000000011', 'PGPURGED02', 'Fabric', 'app.fabric.created', 1, '{}', now(), 1),
+			('0190b0a0-0000-7000-8000-000000000012', 'PGPURGE01', 'Fabric', 'app.fabric.code_changed', 2, '{}', now(), 1),
+			('0190b0a0-0000-7000-8000-000000000013', 'PGPURGE01', 'FabricStock', 'app.fabric.stock_adjusted', 1, '{}', now(), 1),
+			('0190b0a0-0000-7000-8000-000000000014', 'PGKEPT01', 'Fabric', 'app.fabric.created', 1, '{}', now(), 1);
+	`)
+	require.NoError(t, err)
+	require.NoError(t, fabric.Delete(1, testStamp))
+	require.NoError(t, fixture.repo.Delete(ctx, fabric))
+	require.NoError(t, fabric.Purge(2, 0, true, testStamp))
+
+	// --- Act ---
+	err = fixture.repo.Purge(ctx, fabric, true)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	count := func(query string) int {
+		t.Helper()
+		var n int
+		require.NoError(t, fixture.db.Pool.QueryRow(query).Scan(&n))
+		return n
+	}
+	assert.Zero(t, count("SELECT count(*) FROM fabrics WHERE code = 'PGPURGE01'"))
+	assert.Zero(t, count("SELECT count(*) FROM fabric_stock WHERE code = 'PGPURGE01'"))
+	assert.Zero(t, count("SELECT count(*) FROM fabric_barcodes WHERE fabric_code = 'PGPURGE01'"))
+	assert.Zero(t, count("SELECT count(*) FROM fabric_aliases WHERE canonical_code = 'PGPURGE01'"))
+	assert.Zero(t, count("SELECT count(*) FROM events WHERE aggregate_id IN ('PGPURGE01', 'PGPURGED02')"))
+	assert.Equal(t, 1, count("SELECT count(*) FROM events WHERE aggregate_id = 'PGKEPT01'"), "other fabrics keep their events")
+	assert.Equal(t, 2, count("SELECT count(*) FROM fabric_purges WHERE fabric_code = 'PGPURGE01'"))
+
+	for _, code := range []string{"PGPURGE01", "PGPURGED02"} {
+		again, err := domain.NewFabric(code, "Again", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+		require.NoError(t, err)
+		_, err = fixture.repo.Save(ctx, again)
+		assert.ErrorIs(t, err, domain.ErrFabricCodePurged, "the code of a purged fabric is retired: %s", code)
+	}
+}
+
+func TestFabricPostgresRepository_Purge_RefusesLiveFabric(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	fabric, err := domain.NewFabric("PGLIVE01", "Live", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, fabric)
+	require.NoError(t, err)
+	fabric.Version++
+
+	// --- Act ---
+	err = fixture.repo.Purge(ctx, fabric, false)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, domain.ErrFabricNotPurgeable)
+	_, err = fixture.repo.GetByCode(ctx, "PGLIVE01")
+	assert.NoError(t, err)
+}
+
+func TestFabricPostgresRepository_GetByCode_ResolvesAlias(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	canonical, err := domain.NewFabric("PGCANON01", "Canonical", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, canonical)
+	require.NoError(t, err)
+	aliases := NewFabricAliasPostgresRepository(fixture.db)
+	alias, err := domain.NewFabricAlias("PGLEGACY01", canonical.Code, testStamp)
+	require.NoError(t, err)
+	require.NoError(t, aliases.AddAlias(ctx, alias))
+
+	// --- Act ---
+	found, err := fixture.repo.GetByCode(ctx, "PGLEGACY01")
+	shadow, _ := domain.NewFabric("PGLEGACY01", "Shadow", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	_, saveErr := fixture.repo.Save(ctx, shadow)
+	aliasErr := aliases.AddAlias(ctx, alias)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, canonical.Code, found.Code)
+	assert.ErrorIs(t, saveErr, domain.ErrDuplicateFabricCode, "a fabric must not shadow an alias")
+	assert.ErrorIs(t, aliasErr, domain.ErrDuplicateFabricCode)
+}
+
+func TestFabricLockPostgresRepository_AcquireLock(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	locks := NewFabricLockPostgresRepository(fixture.db)
+	fabric, err := domain.NewFabric("PGLOCK01", "Locked Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, fabric)
+	require.NoError(t, err)
+
+	first, err := domain.NewFabricLock("PGLOCK01", time.Minute, domain.Stamp{By: "alice", At: testStamp.At})
+	require.NoError(t, err)
+	contender, err := domain.NewFabricLock("PGLOCK01", time.Minute, domain.Stamp{By: "bob", At: testStamp.At.Add(30 * time.Second)})
+	require.NoError(t, err)
+	afterExpiry, err := domain.NewFabricLock("PGLOCK01", time.Minute, domain.Stamp{By: "bob", At: testStamp.At.Add(2 * time.Minute)})
+	require.NoError(t, err)
+
+	// --- Act ---
+	_, firstErr := locks.AcquireLock(ctx, first)
+	held, contenderErr := locks.AcquireLock(ctx, contender)
+	_, takeOverErr := locks.AcquireLock(ctx, afterExpiry)
+	current, getErr := locks.GetLock(ctx, "PGLOCK01", testStamp.At.Add(2*time.Minute))
+	releaseErr := locks.ReleaseLock(ctx, "PGLOCK01", "alice")
+
+	// --- Assert ---
+	require.NoError(t, firstErr)
+	assert.ErrorIs(t, contenderErr, domain.ErrFabricLocked)
+	require.NotNil(t, held)
+	assert.Equal(t, "alice", held.Holder, "the lock in force is reported to the contender")
+	require.NoError(t, takeOverErr, "an expired lock can be taken over")
+	require.NoError(t, getErr)
+	assert.Equal(t, "bob", current.Holder)
+	assert.ErrorIs(t, releaseErr, domain.ErrRecordNotFound, "only the holder releases a lock")
+}
+
+func TestFabricCodePostgresRepository_ReserveCode(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	codes := NewFabricCodePostgresRepository(fixture.db)
+	sequence := domain.CodeSequence{Prefix: "PGZY", Digits: 4}
+	// the ERP already assigned the second code of the sequence
+	erpFabric, err := domain.NewFabric("PGZY0002", "ERP Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, erpFabric)
+	require.NoError(t, err)
+
+	// --- Act ---
+	var reserved []string
+	for range 3 {
+		reservation := domain.NewCodeReservation(sequence, testStamp)
+		require.NoError(t, codes.ReserveCode(ctx, sequence, reservation))
+		reserved = append(reserved, reservation.Code)
+	}
+
+	// --- Assert ---
+	assert.Equal(t, []string{"PGZY0001", "PGZY0003", "PGZY0004"}, reserved, "codes in use are skipped")
+}
+
+func TestFabricDraftPostgresRepository_Lifecycle(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	drafts := NewFabricDraftPostgresRepository(fixture.db)
+	fabric, err := domain.NewFabric("PGDRAFT01", "Drafted Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, fabric)
+	require.NoError(t, err)
+
+	draft, err := domain.NewFabricDraft(fabric, "Renamed Fabric", "m", "new", testStamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	saveErr := drafts.SaveDraft(ctx, draft)
+	pending, listErr := drafts.ListPendingDrafts(ctx, "PGDRAFT01")
+	require.NoError(t, draft.Resolve(domain.DraftStatusDiscarded, testStamp))
+	resolveErr := drafts.ResolveDraft(ctx, draft)
+	againErr := drafts.ResolveDraft(ctx, draft)
+	stored, getErr := drafts.GetDraft(ctx, draft.ID)
+	pendingAfter, _ := drafts.ListPendingDrafts(ctx, "PGDRAFT01")
+
+	// --- Assert ---
+	require.NoError(t, saveErr)
+	require.NoError(t, listErr)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "Renamed Fabric", pending[0].Name)
+	require.NoError(t, resolveErr)
+	assert.ErrorIs(t, againErr, domain.ErrDraftAlreadyResolved)
+	require.NoError(t, getErr)
+	assert.Equal(t, domain.DraftStatusDiscarded, stored.Status)
+	assert.Empty(t, pendingAfter)
+}
+
+func TestFibrePostgresRepository_Lifecycle(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	fibres := NewFibrePostgresRepository(fixture.db)
+	t.Cleanup(func() {
+		_, _ = fixture.db.Pool.Exec(`DELETE FROM fibres WHERE code IN ('pg_aramid', 'pg_basalt')`)
+	})
+	spec := domain.Specification{Composition: []domain.CompositionPart{{Fibre: "PG_Aramid", Percent: 100}}}
+	fabric, err := domain.NewFabric("PGFIBRE01", "Aramid Weave", "m", "available", spec, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, fabric)
+	require.NoError(t, err)
+
+	// --- Act ---
+	aramidErr := fibres.SaveFibre(ctx, &domain.Fibre{Code: "pg_aramid", Name: "Aramid", CreatedAt: testStamp.At})
+	basaltErr := fibres.SaveFibre(ctx, &domain.Fibre{Code: "pg_basalt", Name: "Basalt", CreatedAt: testStamp.At})
+	listed, listErr := fibres.ListFibres(ctx)
+	inUseErr := fibres.DeleteFibre(ctx, "pg_aramid")
+	unusedErr := fibres.DeleteFibre(ctx, "pg_basalt")
+	missingErr := fibres.DeleteFibre(ctx, "pg_basalt")
+
+	// --- Assert ---
+	require.NoError(t, aramidErr)
+	require.NoError(t, basaltErr)
+	require.NoError(t, listErr)
+	codes := make([]string, 0, len(listed))
+	for _, fibre := range listed {
+		codes = append(codes, fibre.Code)
+	}
+	assert.Contains(t, codes, "pg_aramid")
+	assert.ErrorIs(t, inUseErr, domain.ErrFibreInUse, "the composition names the fibre regardless of case")
+	assert.NoError(t, unusedErr)
+	assert.ErrorIs(t, missingErr, domain.ErrFibreNotFound)
+}
+
+func TestFabricCertificationPostgresRepository_Lifecycle(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	certifications := NewFabricCertificationPostgresRepository(fixture.db)
+	fabric, err := domain.NewFabric("PGCERT01", "Certified Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	_, err = fixture.repo.Save(ctx, fabric)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	valid, err := domain.NewFabricCertification(
+		"6f1c2b4e-3a5d-4c7e-9b8a-1d2e3f4a5b6c", "PGCERT01", domain.CertificationGOTS, "GOTS-1",
+		now.AddDate(-1, 0, 0), now.AddDate(1, 0, 0), testStamp,
+	)
+	require.NoError(t, err)
+	ended, err := domain.NewFabricCertification(
+		"7a2d3c5f-4b6e-4d8f-8c9b-2e3f4a5b6c7d", "PGCERT01", domain.CertificationOekoTex, "OEKO-1",
+		now.AddDate(-2, 0, 0), now.AddDate(0, 0, -1), testStamp,
+	)
+	require.NoError(t, err)
+
+	// --- Act ---
+	validErr := certifications.SaveCertification(ctx, valid)
+	endedErr := certifications.SaveCertification(ctx, ended)
+	listed, listErr := certifications.ListCertifications(ctx, "PGCERT01")
+	due, dueErr := certifications.DueForExpiry(ctx, now, 10)
+	require.NoError(t, ended.Expire(now))
+	expireErr := certifications.MarkExpired(ctx, ended)
+	againErr := certifications.MarkExpired(ctx, ended)
+	dueAfter, _ := certifications.DueForExpiry(ctx, now, 10)
+	gots, _, gotsErr := fixture.repo.ListFabrics(ctx, domain.FabricFilter{Certification: domain.CertificationGOTS, Limit: 10})
+	oekoTex, _, oekoTexErr := fixture.repo.ListFabrics(ctx, domain.FabricFilter{Certification: domain.CertificationOekoTex, Limit: 10})
+
+	// --- Assert ---
+	require.NoError(t, validErr)
+	require.NoError(t, endedErr)
+	require.NoError(t, listErr)
+	require.Len(t, listed, 2)
+	assert.Equal(t, valid.ID, listed[0].ID, "the longest valid certification comes first")
+	require.NoError(t, dueErr)
+	require.Len(t, due, 1)
+	assert.Equal(t, ended.ID, due[0].ID)
+	require.NoError(t, expireErr)
+	assert.ErrorIs(t, againErr, domain.ErrCertificationAlreadyExpired)
+	assert.Empty(t, dueAfter)
+	require.NoError(t, gotsErr)
+	require.Len(t, gots, 1)
+	assert.Equal(t, "PGCERT01", gots[0].Code)
+	require.NoError(t, oekoTexErr)
+	assert.Empty(t, oekoTex, "an ended certification does not count")
+}
+
+func TestFabricBarcodePostgresRepository_Lifecycle(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	barcodes := NewFabricBarcodePostgresRepository(fixture.db)
+	for _, code := range []string{"PGEAN01", "PGEAN02"} {
+		fabric, err := domain.NewFabric(code, "Scanned Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+		require.NoError(t, err)
+		_, err = fixture.repo.Save(ctx, fabric)
+		require.NoError(t, err)
+	}
+	barcode, err := domain.NewFabricBarcode("4006381333931", "PGEAN01", testStamp)
+	require.NoError(t, err)
+	taken, err := domain.NewFabricBarcode("4006381333931", "PGEAN02", testStamp)
+	require.NoError(t, err)
+	orphan, err := domain.NewFabricBarcode("96385074", "PGMISSING", testStamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	addErr := barcodes.AddBarcode(ctx, barcode)
+	takenErr := barcodes.AddBarcode(ctx, taken)
+	orphanErr := barcodes.AddBarcode(ctx, orphan)
+	found, getErr := barcodes.GetBarcode(ctx, "4006381333931")
+	listed, listErr := barcodes.ListBarcodes(ctx, "PGEAN01")
+	removeErr := barcodes.RemoveBarcode(ctx, "PGEAN01", "4006381333931")
+	_, goneErr := barcodes.GetBarcode(ctx, "4006381333931")
+
+	// --- Assert ---
+	require.NoError(t, addErr)
+	assert.ErrorIs(t, takenErr, domain.ErrBarcodeTaken)
+	assert.ErrorIs(t, orphanErr, domain.ErrRecordNotFound)
+	require.NoError(t, getErr)
+	assert.Equal(t, "PGEAN01", found.FabricCode)
+	require.NoError(t, listErr)
+	require.Len(t, listed, 1)
+	require.NoError(t, removeErr)
+	assert.ErrorIs(t, goneErr, domain.ErrRecordNotFound)
+}
+
+func TestFabricPostgresRepository_RequestTransaction(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	aliases := NewFabricAliasPostgresRepository(fixture.db)
+	fabric, err := domain.NewFabric("PGTX01", "Transactional Fabric", "m", "available", domain.Specification{}, domain.FabricTexts{}, testStamp)
+	require.NoError(t, err)
+	alias, err := domain.NewFabricAlias("PGTXALIAS", "PGTX01", testStamp)
+	require.NoError(t, err)
+
+	tx, err := fixture.db.Pool.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	txCtx := database.WithTx(ctx, tx)
+
+	// --- Act ---
+	_, saveErr := fixture.repo.Save(txCtx, fabric)
+	firstAliasErr := aliases.AddAlias(txCtx, alias)
+	aliasErr := aliases.AddAlias(txCtx, alias)
+	_, getInTxErr := fixture.repo.GetByCode(txCtx, "PGTX01")
+	_, getOutsideErr := fixture.repo.GetByCode(ctx, "PGTX01")
+	require.NoError(t, tx.Rollback())
+	_, getAfterRollbackErr := fixture.repo.GetByCode(ctx, "PGTX01")
+
+	// --- Assert ---
+	require.NoError(t, saveErr)
+	require.NoError(t, firstAliasErr)
+	assert.ErrorIs(t, aliasErr, domain.ErrDuplicateFabricCode)
+	assert.NoError(t, getInTxErr, "a failed unit of work leaves the request transaction usable")
+	assert.ErrorIs(t, getOutsideErr, domain.ErrRecordNotFound, "uncommitted changes are not visible outside")
+	assert.ErrorIs(t, getAfterRollbackErr, domain.ErrRecordNotFound, "rolling back the request discards every unit")
+}
@@ -0,0 +1,166 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type FabricProposalPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricProposalPostgresRepository(db *database.PostgresDB) *FabricProposalPostgresRepository {
+	return &FabricProposalPostgresRepository{
+		db: db,
+	}
+}
+
+const proposalColumns = `id, code, source, COALESCE(event_id, ''), name, measure_unit, offer_status,
+	changes, base_version, status, reason, created_at, created_by, resolved_at, resolved_by`
+
+// SaveProposal queues a proposal for review. Proposals of the same ERP event are queued
+// only once, supplier proposals carry no event.
+func (r *FabricProposalPostgresRepository) SaveProposal(ctx context.Context, proposal *domain.FabricProposal) error {
+	changes, err := json.Marshal(proposal.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode proposal changes: %w", err)
+	}
+
+	query := `
+		INSERT INTO fabric_proposals (
+			code, source, event_id, name, measure_unit, offer_status, changes,
+			base_version, status, created_at, created_by
+		)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (event_id) WHERE event_id IS NOT NULL DO NOTHING
+		RETURNING id
+	`
+	args := []any{
+		proposal.Code, proposal.Source, proposal.EventID, proposal.Name, proposal.MeasureUnit,
+		proposal.OfferStatus, string(changes), proposal.BaseVersion, proposal.Status,
+		proposal.CreatedAt, proposal.CreatedBy,
+	}
+	err = r.db.Conn(ctx).QueryRowContext(ctx, query, args...).Scan(&proposal.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to save fabric proposal: %w", err)
+	}
+	return nil
+}
+
+func (r *FabricProposalPostgresRepository) GetProposal(ctx context.Context, id int64) (*domain.FabricProposal, error) {
+	query := `SELECT ` + proposalColumns + ` FROM fabric_proposals WHERE id = $1`
+	proposal, err := scanProposal(r.db.Conn(ctx).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get fabric proposal: %w", err)
+	}
+	return proposal, nil
+}
+
+// ListPendingProposals returns a page of proposals awaiting review, oldest first, together
+// with the total number of proposals matching the filter.
+func (r *FabricProposalPostgresRepository) ListPendingProposals(
+	ctx context.Context, filter domain.ProposalFilter, limit, offset int,
+) ([]*domain.FabricProposal, int, error) {
+	query := `
+		SELECT count(*) OVER(), ` + proposalColumns + `
+		FROM fabric_proposals
+		WHERE status = $1
+			AND ($2 = '' OR code = $2)
+			AND ($3 = '' OR created_by = $3)
+		ORDER BY id
+		LIMIT $4 OFFSET $5
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query,
+		domain.ProposalStatusPending, filter.Code, filter.CreatedBy, limit, offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list fabric proposals: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	proposals := []*domain.FabricProposal{}
+	for rows.Next() {
+		proposal := &domain.FabricProposal{}
+		var changes []byte
+		var resolvedAt sql.NullTime
+		err := rows.Scan(
+			&totalRecords,
+			&proposal.ID, &proposal.Code, &proposal.Source, &proposal.EventID, &proposal.Name,
+			&proposal.MeasureUnit, &proposal.OfferStatus, &changes, &proposal.BaseVersion,
+			&proposal.Status, &proposal.Reason, &proposal.CreatedAt, &proposal.CreatedBy,
+			&resolvedAt, &proposal.ResolvedBy,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan fabric proposal: %w", err)
+		}
+		if err := json.Unmarshal(changes, &proposal.Changes); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode proposal changes: %w", err)
+		}
+		if resolvedAt.Valid {
+			proposal.ResolvedAt = &resolvedAt.Time
+		}
+		proposals = append(proposals, proposal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate fabric proposals: %w", err)
+	}
+
+	return proposals, totalRecords, nil
+}
+
+// ResolveProposal stores the resolution of a proposal that is still pending.
+func (r *FabricProposalPostgresRepository) ResolveProposal(ctx context.Context, proposal *domain.FabricProposal) error {
+	query := `
+		UPDATE fabric_proposals
+		SET status = $1, reason = $2, resolved_at = $3, resolved_by = $4
+		WHERE id = $5 AND status = $6
+	`
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		proposal.Status, proposal.Reason, proposal.ResolvedAt, proposal.ResolvedBy, proposal.ID,
+		domain.ProposalStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to resolve fabric proposal: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrProposalAlreadyResolved
+	}
+	return nil
+}
+
+func scanProposal(row *sql.Row) (*domain.FabricProposal, error) {
+	proposal := &domain.FabricProposal{}
+	var changes []byte
+	var resolvedAt sql.NullTime
+	err := row.Scan(
+		&proposal.ID, &proposal.Code, &proposal.Source, &proposal.EventID, &proposal.Name,
+		&proposal.MeasureUnit, &proposal.OfferStatus, &changes, &proposal.BaseVersion,
+		&proposal.Status, &proposal.Reason, &proposal.CreatedAt, &proposal.CreatedBy,
+		&resolvedAt, &proposal.ResolvedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(changes, &proposal.Changes); err != nil {
+		return nil, fmt.Errorf("failed to decode proposal changes: %w", err)
+	}
+	if resolvedAt.Valid {
+		proposal.ResolvedAt = &resolvedAt.Time
+	}
+	return proposal, nil
+}
@@ -0,0 +1,236 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type FabricStockPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFabricStockPostgresRepository(db *database.PostgresDB) *FabricStockPostgresRepository {
+	return &FabricStockPostgresRepository{
+		db: db,
+	}
+}
+
+// GetStock returns the stock of a fabric that is neither deleted nor merged under its
+// canonical code. A fabric without a stock row yet has an empty stock at version 0.
+func (r *FabricStockPostgresRepository) GetStock(ctx context.Context, code string) (*domain.FabricStock, error) {
+	var (
+		stock     = &domain.FabricStock{}
+		onHand    sql.NullInt64
+		reserved  sql.NullInt64
+		version   sql.NullInt64
+		updatedAt sql.NullTime
+		updatedBy sql.NullString
+	)
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT f.code, s.on_hand, s.reserved, s.version, s.updated_at, s.updated_by
+		FROM fabrics f
+		LEFT JOIN fabric_stock s ON s.code = f.code
+		WHERE f.code = `+canonicalCodeSQL+` AND f.status NOT IN ('DELETED', 'MERGED')
+	`, code).Scan(&stock.Code, &onHand, &reserved, &version, &updatedAt, &updatedBy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("fabric with code %s not found: %w", code, domain.ErrRecordNotFound)
+		}
+		return nil, fmt.Errorf("failed to get fabric stock: %w", err)
+	}
+
+	stock.OnHand = domain.Quantity(onHand.Int64)
+	stock.Reserved = domain.Quantity(reserved.Int64)
+	stock.Version = int(version.Int64)
+	stock.UpdatedAt = updatedAt.Time
+	stock.UpdatedBy = updatedBy.String
+
+	if stock.Locations, err = r.getLocations(ctx, stock.Code); err != nil {
+		return nil, err
+	}
+	if stock.Reservations, err = r.getReservations(ctx, stock.Code); err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+// getLocations returns the stock on hand of a fabric at each warehouse holding some.
+func (r *FabricStockPostgresRepository) getLocations(ctx context.Context, code string) (map[string]domain.Quantity, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT warehouse_code, on_hand FROM fabric_stock_locations WHERE fabric_code = $1
+	`, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fabric stock locations: %w", err)
+	}
+	defer rows.Close()
+
+	var locations map[string]domain.Quantity
+	for rows.Next() {
+		var (
+			warehouse string
+			onHand    domain.Quantity
+		)
+		if err := rows.Scan(&warehouse, &onHand); err != nil {
+			return nil, fmt.Errorf("failed to scan fabric stock location: %w", err)
+		}
+		if locations == nil {
+			locations = make(map[string]domain.Quantity)
+		}
+		locations[warehouse] = onHand
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate fabric stock locations: %w", err)
+	}
+	return locations, nil
+}
+
+// getReservations returns the reservations of a fabric by reference.
+func (r *FabricStockPostgresRepository) getReservations(
+	ctx context.Context, code string,
+) (map[string]domain.StockReservation, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT reference, quantity, expires_at, reserved_at, reserved_by
+		FROM fabric_stock_reservations WHERE fabric_code = $1
+	`, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fabric stock reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations map[string]domain.StockReservation
+	for rows.Next() {
+		var (
+			reservation domain.StockReservation
+			expiresAt   sql.NullTime
+		)
+		err := rows.Scan(
+			&reservation.Reference, &reservation.Quantity, &expiresAt, &reservation.ReservedAt, &reservation.ReservedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fabric stock reservation: %w", err)
+		}
+		if expiresAt.Valid {
+			reservation.ExpiresAt = &expiresAt.Time
+		}
+		if reservations == nil {
+			reservations = make(map[string]domain.StockReservation)
+		}
+		reservations[reservation.Reference] = reservation
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate fabric stock reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+// SaveStock inserts the first stock of a fabric or updates the stock still at the version
+// it was loaded with, and replaces its locations and reservations.
+func (r *FabricStockPostgresRepository) SaveStock(ctx context.Context, stock *domain.FabricStock) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO fabric_stock (code, on_hand, reserved, version, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (code) DO UPDATE
+		SET on_hand = EXCLUDED.on_hand, reserved = EXCLUDED.reserved, version = EXCLUDED.version,
+			updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by
+		WHERE fabric_stock.version = EXCLUDED.version - 1
+	`, stock.Code, stock.OnHand, stock.Reserved, stock.Version, stock.UpdatedAt, stock.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save fabric stock: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM fabric_stock_locations WHERE fabric_code = $1`, stock.Code); err != nil {
+		return fmt.Errorf("failed to clear fabric stock locations: %w", err)
+	}
+	for warehouse, onHand := range stock.Locations {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO fabric_stock_locations (fabric_code, warehouse_code, on_hand) VALUES ($1, $2, $3)
+		`, stock.Code, warehouse, onHand)
+		if err != nil {
+			return fmt.Errorf("failed to save fabric stock location: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM fabric_stock_reservations WHERE fabric_code = $1`, stock.Code); err != nil {
+		return fmt.Errorf("failed to clear fabric stock reservations: %w", err)
+	}
+	for _, reservation := range stock.Reservations {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO fabric_stock_reservations (fabric_code, reference, quantity, expires_at, reserved_at, reserved_by)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, stock.Code, reservation.Reference, reservation.Quantity, reservation.ExpiresAt,
+			reservation.ReservedAt, reservation.ReservedBy)
+		if err != nil {
+			return fmt.Errorf("failed to save fabric stock reservation: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CheckWarehouse looks the warehouse up among those that are not deleted.
+func (r *FabricStockPostgresRepository) CheckWarehouse(ctx context.Context, code string) error {
+	var exists bool
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM warehouses WHERE code = $1 AND deleted_at IS NULL)
+	`, code).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check warehouse: %w", err)
+	}
+	if !exists {
+		return domain.ErrUnknownWarehouse
+	}
+	return nil
+}
+
+// DueForReservationExpiry returns the codes of the fabrics, neither deleted nor merged,
+// holding a reservation expired at the time, by their earliest expired reservation.
+func (r *FabricStockPostgresRepository) DueForReservationExpiry(
+	ctx context.Context, at time.Time, limit int,
+) ([]string, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT sr.fabric_code
+		FROM fabric_stock_reservations sr
+		JOIN fabrics f ON f.code = sr.fabric_code
+		WHERE sr.expires_at <= $1 AND f.status NOT IN ('DELETED', 'MERGED')
+		GROUP BY sr.fabric_code
+		ORDER BY MIN(sr.expires_at), sr.fabric_code
+		LIMIT $2
+	`, at, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find fabric stock reservations due for expiry: %w", err)
+	}
+	defer rows.Close()
+
+	var codes []string
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, fmt.Errorf("failed to scan fabric code: %w", err)
+		}
+		codes = append(codes, code)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate fabric stock reservations due for expiry: %w", err)
+	}
+	return codes, nil
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type FibrePostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewFibrePostgresRepository(db *database.PostgresDB) *FibrePostgresRepository {
+	return &FibrePostgresRepository{
+		db: db,
+	}
+}
+
+// fibreInUseSQL is true when the composition of a fabric names the fibre given as $1
+const fibreInUseSQL = `EXISTS (
+	SELECT 1 FROM fabrics, jsonb_array_elements(fabrics.composition) part
+	WHERE lower(part->>'fibre') = $1
+)`
+
+// SaveFibre adds a fibre to the reference list, or renames it keeping who added it.
+func (r *FibrePostgresRepository) SaveFibre(ctx context.Context, fibre *domain.Fibre) error {
+	query := `
+		INSERT INTO fibres (code, name, created_at, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (code) DO UPDATE SET name = EXCLUDED.name
+		RETURNING created_at, created_by
+	`
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query,
+		fibre.Code, fibre.Name, fibre.CreatedAt, fibre.CreatedBy,
+	).Scan(&fibre.CreatedAt, &fibre.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save fibre: %w", err)
+	}
+	return nil
+}
+
+func (r *FibrePostgresRepository) ListFibres(ctx context.Context) ([]*domain.Fibre, error) {
+	query := `SELECT code, name, created_at, created_by FROM fibres ORDER BY code`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fibres: %w", err)
+	}
+	defer rows.Close()
+
+	fibres := []*domain.Fibre{}
+	for rows.Next() {
+		fibre := &domain.Fibre{}
+		if err := rows.Scan(&fibre.Code, &fibre.Name, &fibre.CreatedAt, &fibre.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan fibre: %w", err)
+		}
+		fibres = append(fibres, fibre)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate fibres: %w", err)
+	}
+	return fibres, nil
+}
+
+// DeleteFibre removes a fibre from the reference list unless the composition of a fabric,
+// deleted ones included, still names it.
+func (r *FibrePostgresRepository) DeleteFibre(ctx context.Context, code string) error {
+	query := `DELETE FROM fibres WHERE code = $1 AND NOT ` + fibreInUseSQL
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, code)
+	if err != nil {
+		return fmt.Errorf("failed to delete fibre: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		var exists bool
+		err := r.db.Conn(ctx).QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM fibres WHERE code = $1)`, code).
+			Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to check fibre: %w", err)
+		}
+		if !exists {
+			return domain.ErrFibreNotFound
+		}
+		return domain.ErrFibreInUse.WithParam("fibre", code)
+	}
+	return nil
+}
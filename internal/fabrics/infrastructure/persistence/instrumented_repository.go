@@ -0,0 +1,705 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+)
+
+// FabricRepository is the full set of fabric reads and writes served by the postgres repository.
+type FabricRepository interface {
+	domain.FabricCommandRepository
+	ListFabrics(ctx context.Context, filter domain.FabricFilter) ([]*domain.Fabric, int, error)
+	SearchFabrics(ctx context.Context, search domain.FabricSearch) ([]domain.FabricSearchHit, int, error)
+	ExportFabrics(ctx context.Context, limit int, fn func(*domain.Fabric) error) error
+	ExportChangedFabrics(ctx context.Context, since domain.ChangedSince, limit int, fn func(*domain.Fabric) error) error
+	LastEventPosition(ctx context.Context) (int64, error)
+}
+
+// InstrumentedFabricRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedFabricRepository struct {
+	next FabricRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricRepository(next FabricRepository, rec *instrument.Recorder) *InstrumentedFabricRepository {
+	return &InstrumentedFabricRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricRepository) Save(ctx context.Context, fabric *domain.Fabric) (*domain.Fabric, error) {
+	return instrument.Call(ctx, r.rec, "Save", func(ctx context.Context) (*domain.Fabric, error) {
+		return r.next.Save(ctx, fabric)
+	})
+}
+
+func (r *InstrumentedFabricRepository) GetByCode(ctx context.Context, code string) (*domain.Fabric, error) {
+	return instrument.Call(ctx, r.rec, "GetByCode", func(ctx context.Context) (*domain.Fabric, error) {
+		return r.next.GetByCode(ctx, code)
+	})
+}
+
+func (r *InstrumentedFabricRepository) GetByCodeIncludingDeleted(ctx context.Context, code string) (*domain.Fabric, error) {
+	return instrument.Call(ctx, r.rec, "GetByCodeIncludingDeleted", func(ctx context.Context) (*domain.Fabric, error) {
+		return r.next.GetByCodeIncludingDeleted(ctx, code)
+	})
+}
+
+func (r *InstrumentedFabricRepository) Update(ctx context.Context, fabric *domain.Fabric) error {
+	return instrument.Exec(ctx, r.rec, "Update", func(ctx context.Context) error {
+		return r.next.Update(ctx, fabric)
+	})
+}
+
+func (r *InstrumentedFabricRepository) Delete(ctx context.Context, fabric *domain.Fabric) error {
+	return instrument.Exec(ctx, r.rec, "Delete", func(ctx context.Context) error {
+		return r.next.Delete(ctx, fabric)
+	})
+}
+
+func (r *InstrumentedFabricRepository) ChangeStatus(ctx context.Context, fabric *domain.Fabric) error {
+	return instrument.Exec(ctx, r.rec, "ChangeStatus", func(ctx context.Context) error {
+		return r.next.ChangeStatus(ctx, fabric)
+	})
+}
+
+func (r *InstrumentedFabricRepository) Restore(ctx context.Context, fabric *domain.Fabric) error {
+	return instrument.Exec(ctx, r.rec, "Restore", func(ctx context.Context) error {
+		return r.next.Restore(ctx, fabric)
+	})
+}
+
+func (r *InstrumentedFabricRepository) Reactivate(ctx context.Context, fabric *domain.Fabric) error {
+	return instrument.Exec(ctx, r.rec, "Reactivate", func(ctx context.Context) error {
+		return r.next.Reactivate(ctx, fabric)
+	})
+}
+
+func (r *InstrumentedFabricRepository) Merge(ctx context.Context, duplicate *domain.Fabric, canonicalCode string) error {
+	return instrument.Exec(ctx, r.rec, "Merge", func(ctx context.Context) error {
+		return r.next.Merge(ctx, duplicate, canonicalCode)
+	})
+}
+
+func (r *InstrumentedFabricRepository) ChangeCode(ctx context.Context, fabric *domain.Fabric, previousCode string) error {
+	return instrument.Exec(ctx, r.rec, "ChangeCode", func(ctx context.Context) error {
+		return r.next.ChangeCode(ctx, fabric, previousCode)
+	})
+}
+
+func (r *InstrumentedFabricRepository) Purge(ctx context.Context, fabric *domain.Fabric, purgeEvents bool) error {
+	return instrument.Exec(ctx, r.rec, "Purge", func(ctx context.Context) error {
+		return r.next.Purge(ctx, fabric, purgeEvents)
+	})
+}
+
+func (r *InstrumentedFabricRepository) CheckColor(ctx context.Context, code string) error {
+	return instrument.Exec(ctx, r.rec, "CheckColor", func(ctx context.Context) error {
+		return r.next.CheckColor(ctx, code)
+	})
+}
+
+func (r *InstrumentedFabricRepository) ListFabrics(
+	ctx context.Context, filter domain.FabricFilter,
+) ([]*domain.Fabric, int, error) {
+	var total int
+	fabrics, err := instrument.Call(ctx, r.rec, "ListFabrics", func(ctx context.Context) ([]*domain.Fabric, error) {
+		fabrics, count, err := r.next.ListFabrics(ctx, filter)
+		total = count
+		return fabrics, err
+	})
+	return fabrics, total, err
+}
+
+func (r *InstrumentedFabricRepository) SearchFabrics(
+	ctx context.Context, search domain.FabricSearch,
+) ([]domain.FabricSearchHit, int, error) {
+	var total int
+	hits, err := instrument.Call(ctx, r.rec, "SearchFabrics", func(ctx context.Context) ([]domain.FabricSearchHit, error) {
+		hits, count, err := r.next.SearchFabrics(ctx, search)
+		total = count
+		return hits, err
+	})
+	return hits, total, err
+}
+
+func (r *InstrumentedFabricRepository) ExportFabrics(ctx context.Context, limit int, fn func(*domain.Fabric) error) error {
+	return instrument.Exec(ctx, r.rec, "ExportFabrics", func(ctx context.Context) error {
+		return r.next.ExportFabrics(ctx, limit, fn)
+	})
+}
+
+func (r *InstrumentedFabricRepository) ExportChangedFabrics(
+	ctx context.Context, since domain.ChangedSince, limit int, fn func(*domain.Fabric) error,
+) error {
+	return instrument.Exec(ctx, r.rec, "ExportChangedFabrics", func(ctx context.Context) error {
+		return r.next.ExportChangedFabrics(ctx, since, limit, fn)
+	})
+}
+
+func (r *InstrumentedFabricRepository) LastEventPosition(ctx context.Context) (int64, error) {
+	return instrument.Call(ctx, r.rec, "LastEventPosition", func(ctx context.Context) (int64, error) {
+		return r.next.LastEventPosition(ctx)
+	})
+}
+
+type InstrumentedFabricAliasRepository struct {
+	next domain.FabricAliasRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricAliasRepository(
+	next domain.FabricAliasRepository, rec *instrument.Recorder,
+) *InstrumentedFabricAliasRepository {
+	return &InstrumentedFabricAliasRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricAliasRepository) AddAlias(ctx context.Context, alias *domain.FabricAlias) error {
+	return instrument.Exec(ctx, r.rec, "AddAlias", func(ctx context.Context) error {
+		return r.next.AddAlias(ctx, alias)
+	})
+}
+
+func (r *InstrumentedFabricAliasRepository) RemoveAlias(ctx context.Context, canonicalCode, aliasCode string) error {
+	return instrument.Exec(ctx, r.rec, "RemoveAlias", func(ctx context.Context) error {
+		return r.next.RemoveAlias(ctx, canonicalCode, aliasCode)
+	})
+}
+
+func (r *InstrumentedFabricAliasRepository) ListAliases(
+	ctx context.Context, canonicalCode string,
+) ([]*domain.FabricAlias, error) {
+	return instrument.Call(ctx, r.rec, "ListAliases", func(ctx context.Context) ([]*domain.FabricAlias, error) {
+		return r.next.ListAliases(ctx, canonicalCode)
+	})
+}
+
+type InstrumentedFabricBarcodeRepository struct {
+	next domain.FabricBarcodeRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricBarcodeRepository(
+	next domain.FabricBarcodeRepository, rec *instrument.Recorder,
+) *InstrumentedFabricBarcodeRepository {
+	return &InstrumentedFabricBarcodeRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricBarcodeRepository) AddBarcode(ctx context.Context, barcode *domain.FabricBarcode) error {
+	return instrument.Exec(ctx, r.rec, "AddBarcode", func(ctx context.Context) error {
+		return r.next.AddBarcode(ctx, barcode)
+	})
+}
+
+func (r *InstrumentedFabricBarcodeRepository) RemoveBarcode(ctx context.Context, fabricCode, barcode string) error {
+	return instrument.Exec(ctx, r.rec, "RemoveBarcode", func(ctx context.Context) error {
+		return r.next.RemoveBarcode(ctx, fabricCode, barcode)
+	})
+}
+
+func (r *InstrumentedFabricBarcodeRepository) ListBarcodes(
+	ctx context.Context, fabricCode string,
+) ([]*domain.FabricBarcode, error) {
+	return instrument.Call(ctx, r.rec, "ListBarcodes", func(ctx context.Context) ([]*domain.FabricBarcode, error) {
+		return r.next.ListBarcodes(ctx, fabricCode)
+	})
+}
+
+func (r *InstrumentedFabricBarcodeRepository) GetBarcode(
+	ctx context.Context, barcode string,
+) (*domain.FabricBarcode, error) {
+	return instrument.Call(ctx, r.rec, "GetBarcode", func(ctx context.Context) (*domain.FabricBarcode, error) {
+		return r.next.GetBarcode(ctx, barcode)
+	})
+}
+
+type InstrumentedFabricConflictRepository struct {
+	next domain.FabricConflictRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricConflictRepository(
+	next domain.FabricConflictRepository, rec *instrument.Recorder,
+) *InstrumentedFabricConflictRepository {
+	return &InstrumentedFabricConflictRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricConflictRepository) SaveConflict(ctx context.Context, conflict *domain.FabricConflict) error {
+	return instrument.Exec(ctx, r.rec, "SaveConflict", func(ctx context.Context) error {
+		return r.next.SaveConflict(ctx, conflict)
+	})
+}
+
+func (r *InstrumentedFabricConflictRepository) GetConflict(ctx context.Context, id int64) (*domain.FabricConflict, error) {
+	return instrument.Call(ctx, r.rec, "GetConflict", func(ctx context.Context) (*domain.FabricConflict, error) {
+		return r.next.GetConflict(ctx, id)
+	})
+}
+
+func (r *InstrumentedFabricConflictRepository) ListPendingConflicts(
+	ctx context.Context, limit, offset int,
+) ([]*domain.FabricConflict, int, error) {
+	var total int
+	conflicts, err := instrument.Call(ctx, r.rec, "ListPendingConflicts",
+		func(ctx context.Context) ([]*domain.FabricConflict, error) {
+			conflicts, count, err := r.next.ListPendingConflicts(ctx, limit, offset)
+			total = count
+			return conflicts, err
+		})
+	return conflicts, total, err
+}
+
+func (r *InstrumentedFabricConflictRepository) ResolveConflict(ctx context.Context, conflict *domain.FabricConflict) error {
+	return instrument.Exec(ctx, r.rec, "ResolveConflict", func(ctx context.Context) error {
+		return r.next.ResolveConflict(ctx, conflict)
+	})
+}
+
+type InstrumentedFabricDuplicateRepository struct {
+	next domain.FabricDuplicateRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricDuplicateRepository(
+	next domain.FabricDuplicateRepository, rec *instrument.Recorder,
+) *InstrumentedFabricDuplicateRepository {
+	return &InstrumentedFabricDuplicateRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricDuplicateRepository) SaveDuplicate(ctx context.Context, duplicate *domain.FabricDuplicate) error {
+	return instrument.Exec(ctx, r.rec, "SaveDuplicate", func(ctx context.Context) error {
+		return r.next.SaveDuplicate(ctx, duplicate)
+	})
+}
+
+func (r *InstrumentedFabricDuplicateRepository) GetDuplicate(ctx context.Context, id int64) (*domain.FabricDuplicate, error) {
+	return instrument.Call(ctx, r.rec, "GetDuplicate", func(ctx context.Context) (*domain.FabricDuplicate, error) {
+		return r.next.GetDuplicate(ctx, id)
+	})
+}
+
+func (r *InstrumentedFabricDuplicateRepository) ListPendingDuplicates(
+	ctx context.Context, limit, offset int,
+) ([]*domain.FabricDuplicate, int, error) {
+	var total int
+	duplicates, err := instrument.Call(ctx, r.rec, "ListPendingDuplicates",
+		func(ctx context.Context) ([]*domain.FabricDuplicate, error) {
+			duplicates, count, err := r.next.ListPendingDuplicates(ctx, limit, offset)
+			total = count
+			return duplicates, err
+		})
+	return duplicates, total, err
+}
+
+func (r *InstrumentedFabricDuplicateRepository) ResolveDuplicate(ctx context.Context, duplicate *domain.FabricDuplicate) error {
+	return instrument.Exec(ctx, r.rec, "ResolveDuplicate", func(ctx context.Context) error {
+		return r.next.ResolveDuplicate(ctx, duplicate)
+	})
+}
+
+type InstrumentedFabricPendingEventRepository struct {
+	next domain.FabricPendingEventRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricPendingEventRepository(
+	next domain.FabricPendingEventRepository, rec *instrument.Recorder,
+) *InstrumentedFabricPendingEventRepository {
+	return &InstrumentedFabricPendingEventRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricPendingEventRepository) ParkEvent(ctx context.Context, event *domain.PendingFabricEvent) error {
+	return instrument.Exec(ctx, r.rec, "ParkEvent", func(ctx context.Context) error {
+		return r.next.ParkEvent(ctx, event)
+	})
+}
+
+func (r *InstrumentedFabricPendingEventRepository) NextPendingEvent(
+	ctx context.Context, code string, version int,
+) (*domain.PendingFabricEvent, error) {
+	return instrument.Call(ctx, r.rec, "NextPendingEvent", func(ctx context.Context) (*domain.PendingFabricEvent, error) {
+		return r.next.NextPendingEvent(ctx, code, version)
+	})
+}
+
+func (r *InstrumentedFabricPendingEventRepository) DueForRetry(
+	ctx context.Context, now time.Time, limit int,
+) ([]*domain.PendingFabricEvent, error) {
+	return instrument.Call(ctx, r.rec, "DueForRetry", func(ctx context.Context) ([]*domain.PendingFabricEvent, error) {
+		return r.next.DueForRetry(ctx, now, limit)
+	})
+}
+
+func (r *InstrumentedFabricPendingEventRepository) ScheduleRetry(ctx context.Context, event *domain.PendingFabricEvent) error {
+	return instrument.Exec(ctx, r.rec, "ScheduleRetry", func(ctx context.Context) error {
+		return r.next.ScheduleRetry(ctx, event)
+	})
+}
+
+func (r *InstrumentedFabricPendingEventRepository) MarkResolved(ctx context.Context, id int64, status string) error {
+	return instrument.Exec(ctx, r.rec, "MarkResolved", func(ctx context.Context) error {
+		return r.next.MarkResolved(ctx, id, status)
+	})
+}
+
+func (r *InstrumentedFabricPendingEventRepository) ExpirePendingEvents(
+	ctx context.Context, receivedBefore time.Time,
+) ([]*domain.PendingFabricEvent, error) {
+	return instrument.Call(ctx, r.rec, "ExpirePendingEvents", func(ctx context.Context) ([]*domain.PendingFabricEvent, error) {
+		return r.next.ExpirePendingEvents(ctx, receivedBefore)
+	})
+}
+
+type InstrumentedFabricLockRepository struct {
+	next domain.FabricLockRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricLockRepository(
+	next domain.FabricLockRepository, rec *instrument.Recorder,
+) *InstrumentedFabricLockRepository {
+	return &InstrumentedFabricLockRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricLockRepository) AcquireLock(
+	ctx context.Context, lock *domain.FabricLock,
+) (*domain.FabricLock, error) {
+	return instrument.Call(ctx, r.rec, "AcquireLock", func(ctx context.Context) (*domain.FabricLock, error) {
+		return r.next.AcquireLock(ctx, lock)
+	})
+}
+
+func (r *InstrumentedFabricLockRepository) ReleaseLock(ctx context.Context, code, holder string) error {
+	return instrument.Exec(ctx, r.rec, "ReleaseLock", func(ctx context.Context) error {
+		return r.next.ReleaseLock(ctx, code, holder)
+	})
+}
+
+func (r *InstrumentedFabricLockRepository) GetLock(
+	ctx context.Context, code string, now time.Time,
+) (*domain.FabricLock, error) {
+	return instrument.Call(ctx, r.rec, "GetLock", func(ctx context.Context) (*domain.FabricLock, error) {
+		return r.next.GetLock(ctx, code, now)
+	})
+}
+
+type InstrumentedFabricCodeRepository struct {
+	next domain.FabricCodeRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricCodeRepository(
+	next domain.FabricCodeRepository, rec *instrument.Recorder,
+) *InstrumentedFabricCodeRepository {
+	return &InstrumentedFabricCodeRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricCodeRepository) ReserveCode(
+	ctx context.Context, sequence domain.CodeSequence, reservation *domain.CodeReservation,
+) error {
+	return instrument.Exec(ctx, r.rec, "ReserveCode", func(ctx context.Context) error {
+		return r.next.ReserveCode(ctx, sequence, reservation)
+	})
+}
+
+type InstrumentedFabricDraftRepository struct {
+	next domain.FabricDraftRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricDraftRepository(
+	next domain.FabricDraftRepository, rec *instrument.Recorder,
+) *InstrumentedFabricDraftRepository {
+	return &InstrumentedFabricDraftRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricDraftRepository) SaveDraft(ctx context.Context, draft *domain.FabricDraft) error {
+	return instrument.Exec(ctx, r.rec, "SaveDraft", func(ctx context.Context) error {
+		return r.next.SaveDraft(ctx, draft)
+	})
+}
+
+func (r *InstrumentedFabricDraftRepository) GetDraft(ctx context.Context, id int64) (*domain.FabricDraft, error) {
+	return instrument.Call(ctx, r.rec, "GetDraft", func(ctx context.Context) (*domain.FabricDraft, error) {
+		return r.next.GetDraft(ctx, id)
+	})
+}
+
+func (r *InstrumentedFabricDraftRepository) ListPendingDrafts(
+	ctx context.Context, code string,
+) ([]*domain.FabricDraft, error) {
+	return instrument.Call(ctx, r.rec, "ListPendingDrafts", func(ctx context.Context) ([]*domain.FabricDraft, error) {
+		return r.next.ListPendingDrafts(ctx, code)
+	})
+}
+
+func (r *InstrumentedFabricDraftRepository) ResolveDraft(ctx context.Context, draft *domain.FabricDraft) error {
+	return instrument.Exec(ctx, r.rec, "ResolveDraft", func(ctx context.Context) error {
+		return r.next.ResolveDraft(ctx, draft)
+	})
+}
+
+type InstrumentedFabricProposalRepository struct {
+	next domain.FabricProposalRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricProposalRepository(
+	next domain.FabricProposalRepository, rec *instrument.Recorder,
+) *InstrumentedFabricProposalRepository {
+	return &InstrumentedFabricProposalRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricProposalRepository) SaveProposal(ctx context.Context, proposal *domain.FabricProposal) error {
+	return instrument.Exec(ctx, r.rec, "SaveProposal", func(ctx context.Context) error {
+		return r.next.SaveProposal(ctx, proposal)
+	})
+}
+
+func (r *InstrumentedFabricProposalRepository) GetProposal(ctx context.Context, id int64) (*domain.FabricProposal, error) {
+	return instrument.Call(ctx, r.rec, "GetProposal", func(ctx context.Context) (*domain.FabricProposal, error) {
+		return r.next.GetProposal(ctx, id)
+	})
+}
+
+func (r *InstrumentedFabricProposalRepository) ListPendingProposals(
+	ctx context.Context, filter domain.ProposalFilter, limit, offset int,
+) ([]*domain.FabricProposal, int, error) {
+	var total int
+	proposals, err := instrument.Call(ctx, r.rec, "ListPendingProposals",
+		func(ctx context.Context) ([]*domain.FabricProposal, error) {
+			proposals, count, err := r.next.ListPendingProposals(ctx, filter, limit, offset)
+			total = count
+			return proposals, err
+		})
+	return proposals, total, err
+}
+
+func (r *InstrumentedFabricProposalRepository) ResolveProposal(
+	ctx context.Context, proposal *domain.FabricProposal,
+) error {
+	return instrument.Exec(ctx, r.rec, "ResolveProposal", func(ctx context.Context) error {
+		return r.next.ResolveProposal(ctx, proposal)
+	})
+}
+
+type InstrumentedAttributeDefinitionRepository struct {
+	next domain.AttributeDefinitionRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedAttributeDefinitionRepository(
+	next domain.AttributeDefinitionRepository, rec *instrument.Recorder,
+) *InstrumentedAttributeDefinitionRepository {
+	return &InstrumentedAttributeDefinitionRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedAttributeDefinitionRepository) SaveDefinition(
+	ctx context.Context, definition *domain.AttributeDefinition,
+) error {
+	return instrument.Exec(ctx, r.rec, "SaveDefinition", func(ctx context.Context) error {
+		return r.next.SaveDefinition(ctx, definition)
+	})
+}
+
+func (r *InstrumentedAttributeDefinitionRepository) GetDefinition(
+	ctx context.Context, key string,
+) (*domain.AttributeDefinition, error) {
+	return instrument.Call(ctx, r.rec, "GetDefinition", func(ctx context.Context) (*domain.AttributeDefinition, error) {
+		return r.next.GetDefinition(ctx, key)
+	})
+}
+
+func (r *InstrumentedAttributeDefinitionRepository) ListDefinitions(
+	ctx context.Context,
+) ([]*domain.AttributeDefinition, error) {
+	return instrument.Call(ctx, r.rec, "ListDefinitions", func(ctx context.Context) ([]*domain.AttributeDefinition, error) {
+		return r.next.ListDefinitions(ctx)
+	})
+}
+
+func (r *InstrumentedAttributeDefinitionRepository) DeleteDefinition(ctx context.Context, key string) error {
+	return instrument.Exec(ctx, r.rec, "DeleteDefinition", func(ctx context.Context) error {
+		return r.next.DeleteDefinition(ctx, key)
+	})
+}
+
+type InstrumentedFibreRepository struct {
+	next domain.FibreRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFibreRepository(next domain.FibreRepository, rec *instrument.Recorder) *InstrumentedFibreRepository {
+	return &InstrumentedFibreRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFibreRepository) SaveFibre(ctx context.Context, fibre *domain.Fibre) error {
+	return instrument.Exec(ctx, r.rec, "SaveFibre", func(ctx context.Context) error {
+		return r.next.SaveFibre(ctx, fibre)
+	})
+}
+
+func (r *InstrumentedFibreRepository) ListFibres(ctx context.Context) ([]*domain.Fibre, error) {
+	return instrument.Call(ctx, r.rec, "ListFibres", func(ctx context.Context) ([]*domain.Fibre, error) {
+		return r.next.ListFibres(ctx)
+	})
+}
+
+func (r *InstrumentedFibreRepository) DeleteFibre(ctx context.Context, code string) error {
+	return instrument.Exec(ctx, r.rec, "DeleteFibre", func(ctx context.Context) error {
+		return r.next.DeleteFibre(ctx, code)
+	})
+}
+
+type InstrumentedFabricStockRepository struct {
+	next domain.FabricStockRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricStockRepository(
+	next domain.FabricStockRepository, rec *instrument.Recorder,
+) *InstrumentedFabricStockRepository {
+	return &InstrumentedFabricStockRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricStockRepository) GetStock(ctx context.Context, code string) (*domain.FabricStock, error) {
+	return instrument.Call(ctx, r.rec, "GetStock", func(ctx context.Context) (*domain.FabricStock, error) {
+		return r.next.GetStock(ctx, code)
+	})
+}
+
+func (r *InstrumentedFabricStockRepository) SaveStock(ctx context.Context, stock *domain.FabricStock) error {
+	return instrument.Exec(ctx, r.rec, "SaveStock", func(ctx context.Context) error {
+		return r.next.SaveStock(ctx, stock)
+	})
+}
+
+func (r *InstrumentedFabricStockRepository) CheckWarehouse(ctx context.Context, code string) error {
+	return instrument.Exec(ctx, r.rec, "CheckWarehouse", func(ctx context.Context) error {
+		return r.next.CheckWarehouse(ctx, code)
+	})
+}
+
+func (r *InstrumentedFabricStockRepository) DueForReservationExpiry(
+	ctx context.Context, at time.Time, limit int,
+) ([]string, error) {
+	return instrument.Call(ctx, r.rec, "DueForReservationExpiry", func(ctx context.Context) ([]string, error) {
+		return r.next.DueForReservationExpiry(ctx, at, limit)
+	})
+}
+
+type InstrumentedFabricAttachmentRepository struct {
+	next domain.FabricAttachmentRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricAttachmentRepository(
+	next domain.FabricAttachmentRepository, rec *instrument.Recorder,
+) *InstrumentedFabricAttachmentRepository {
+	return &InstrumentedFabricAttachmentRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricAttachmentRepository) GetFabricCode(ctx context.Context, code string) (string, error) {
+	return instrument.Call(ctx, r.rec, "GetFabricCode", func(ctx context.Context) (string, error) {
+		return r.next.GetFabricCode(ctx, code)
+	})
+}
+
+func (r *InstrumentedFabricAttachmentRepository) SaveAttachment(
+	ctx context.Context, attachment *domain.FabricAttachment,
+) error {
+	return instrument.Exec(ctx, r.rec, "SaveAttachment", func(ctx context.Context) error {
+		return r.next.SaveAttachment(ctx, attachment)
+	})
+}
+
+func (r *InstrumentedFabricAttachmentRepository) GetAttachment(
+	ctx context.Context, fabricCode, id string,
+) (*domain.FabricAttachment, error) {
+	return instrument.Call(ctx, r.rec, "GetAttachment", func(ctx context.Context) (*domain.FabricAttachment, error) {
+		return r.next.GetAttachment(ctx, fabricCode, id)
+	})
+}
+
+func (r *InstrumentedFabricAttachmentRepository) ListAttachments(
+	ctx context.Context, fabricCode string,
+) ([]*domain.FabricAttachment, error) {
+	return instrument.Call(ctx, r.rec, "ListAttachments", func(ctx context.Context) ([]*domain.FabricAttachment, error) {
+		return r.next.ListAttachments(ctx, fabricCode)
+	})
+}
+
+func (r *InstrumentedFabricAttachmentRepository) DeleteAttachment(
+	ctx context.Context, attachment *domain.FabricAttachment,
+) error {
+	return instrument.Exec(ctx, r.rec, "DeleteAttachment", func(ctx context.Context) error {
+		return r.next.DeleteAttachment(ctx, attachment)
+	})
+}
+
+type InstrumentedFabricCertificationRepository struct {
+	next domain.FabricCertificationRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedFabricCertificationRepository(
+	next domain.FabricCertificationRepository, rec *instrument.Recorder,
+) *InstrumentedFabricCertificationRepository {
+	return &InstrumentedFabricCertificationRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedFabricCertificationRepository) GetFabricCode(ctx context.Context, code string) (string, error) {
+	return instrument.Call(ctx, r.rec, "GetFabricCode", func(ctx context.Context) (string, error) {
+		return r.next.GetFabricCode(ctx, code)
+	})
+}
+
+func (r *InstrumentedFabricCertificationRepository) SaveCertification(
+	ctx context.Context, certification *domain.FabricCertification,
+) error {
+	return instrument.Exec(ctx, r.rec, "SaveCertification", func(ctx context.Context) error {
+		return r.next.SaveCertification(ctx, certification)
+	})
+}
+
+func (r *InstrumentedFabricCertificationRepository) ListCertifications(
+	ctx context.Context, fabricCode string,
+) ([]*domain.FabricCertification, error) {
+	return instrument.Call(ctx, r.rec, "ListCertifications", func(ctx context.Context) ([]*domain.FabricCertification, error) {
+		return r.next.ListCertifications(ctx, fabricCode)
+	})
+}
+
+func (r *InstrumentedFabricCertificationRepository) DueForExpiry(
+	ctx context.Context, at time.Time, limit int,
+) ([]*domain.FabricCertification, error) {
+	return instrument.Call(ctx, r.rec, "DueForExpiry", func(ctx context.Context) ([]*domain.FabricCertification, error) {
+		return r.next.DueForExpiry(ctx, at, limit)
+	})
+}
+
+func (r *InstrumentedFabricCertificationRepository) MarkExpired(
+	ctx context.Context, certification *domain.FabricCertification,
+) error {
+	return instrument.Exec(ctx, r.rec, "MarkExpired", func(ctx context.Context) error {
+		return r.next.MarkExpired(ctx, certification)
+	})
+}
+
+type InstrumentedTenantUsageRepository struct {
+	next domain.TenantUsageRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedTenantUsageRepository(
+	next domain.TenantUsageRepository, rec *instrument.Recorder,
+) *InstrumentedTenantUsageRepository {
+	return &InstrumentedTenantUsageRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedTenantUsageRepository) GetTenantUsage(ctx context.Context) (domain.TenantUsage, error) {
+	return instrument.Call(ctx, r.rec, "GetTenantUsage", func(ctx context.Context) (domain.TenantUsage, error) {
+		return r.next.GetTenantUsage(ctx)
+	})
+}
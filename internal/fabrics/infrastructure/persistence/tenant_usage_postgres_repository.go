@@ -0,0 +1,34 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/fabrics/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type TenantUsagePostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewTenantUsagePostgresRepository(db *database.PostgresDB) *TenantUsagePostgresRepository {
+	return &TenantUsagePostgresRepository{
+		db: db,
+	}
+}
+
+// GetTenantUsage counts what the database holds, as it holds the data of a single tenant.
+// The files of deleted fabrics are kept, so they count against the storage until purged.
+func (r *TenantUsagePostgresRepository) GetTenantUsage(ctx context.Context) (domain.TenantUsage, error) {
+	var usage domain.TenantUsage
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM fabrics WHERE `+liveStatusSQL+`),
+			(SELECT COALESCE(SUM(size), 0) FROM fabric_attachments)
+	`).Scan(&usage.Fabrics, &usage.AttachmentBytes)
+	if err != nil {
+		return domain.TenantUsage{}, fmt.Errorf("failed to get tenant usage: %w", err)
+	}
+	return usage, nil
+}
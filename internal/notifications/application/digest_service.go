@@ -0,0 +1,201 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/mail"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+)
+
+// number of events read from the store per query while building a digest
+const digestReadBatchSize = 500
+
+// offer status after which a fabric is reported as discontinued
+const offerStatusDiscontinued = "DISCONTINUED"
+
+// lifecycle status of fabrics prepared but not yet offered, reported once activated
+const statusDraft = "DRAFT"
+
+// EventFeed reads recorded events in global position order.
+type EventFeed interface {
+	ReadAfter(ctx context.Context, aggregateType string, after int64, limit int) ([]eventstore.RecordedEvent, error)
+}
+
+// DigestService emails every subscriber the events of their topics recorded since their
+// previous digest.
+type DigestService struct {
+	subscriptions domain.SubscriptionRepository
+	feed          EventFeed
+	mailer        mail.Mailer
+	clock         clock.Clock
+	logger        *slog.Logger
+}
+
+func NewDigestService(
+	subscriptions domain.SubscriptionRepository,
+	feed EventFeed,
+	mailer mail.Mailer,
+	clock clock.Clock,
+	logger *slog.Logger,
+) *DigestService {
+	return &DigestService{
+		subscriptions: subscriptions,
+		feed:          feed,
+		mailer:        mailer,
+		clock:         clock,
+		logger:        logger.With("component", "notification.digest"),
+	}
+}
+
+// Run sends the digests every interval until ctx is done.
+func (s *DigestService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.SendDigests(ctx); err != nil {
+				s.logger.Error("failed to send digests", "error", err)
+			}
+		}
+	}
+}
+
+// SendDigests sends each subscriber a digest of the items recorded since their last one.
+// Subscribers with nothing new are skipped. A subscriber whose digest fails keeps its
+// position and receives the items with the next run; the others are not held back.
+func (s *DigestService) SendDigests(ctx context.Context) error {
+	subscriptions, err := s.subscriptions.ListSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		if err := s.sendDigest(ctx, subscription); err != nil {
+			s.logger.Warn("failed to send digest",
+				"error", err,
+				"subscriptionID", subscription.ID,
+				"subscriber", subscription.Subscriber,
+			)
+			errs = append(errs, fmt.Errorf("subscription %d: %w", subscription.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *DigestService) sendDigest(ctx context.Context, subscription *domain.Subscription) error {
+	digest := &domain.Digest{Subscription: subscription}
+	position := subscription.LastPosition
+	for {
+		events, err := s.feed.ReadAfter(ctx, "Fabric", position, digestReadBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			position = event.Position
+			if item, ok := digestItem(event.Envelope); ok && subscription.Wants(item.Topic) {
+				digest.Items = append(digest.Items, item)
+			}
+		}
+		if len(events) < digestReadBatchSize {
+			break
+		}
+	}
+
+	if position == subscription.LastPosition {
+		return nil
+	}
+	if len(digest.Items) > 0 {
+		msg := mail.Message{
+			To:      []string{subscription.Email},
+			Subject: digest.Subject(),
+			Body:    digest.Body(),
+		}
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return s.subscriptions.MarkDigested(ctx, subscription.ID, position, s.clock.Now())
+}
+
+// fabricPayload holds the payload fields digest items are built from
+type fabricPayload struct {
+	Name           string
+	OfferStatus    string
+	CanonicalCode  string
+	Status         string
+	PreviousStatus string
+}
+
+// digestItem maps an event to the digest item it is reported as, if any.
+func digestItem(envelope *messaging.EventEnvelope) (domain.DigestItem, bool) {
+	// the changes of the synthetic probe are not catalogue news
+	if envelope.UserID == command.ActorSyntheticProbe {
+		return domain.DigestItem{}, false
+	}
+
+	var payload fabricPayload
+	if err := decodePayload(envelope, &payload); err != nil {
+		return domain.DigestItem{}, false
+	}
+
+	item := domain.DigestItem{Code: envelope.AggregateID, OccurredAt: envelope.Timestamp}
+	switch envelope.EventType {
+	case "app.fabric.created", "app.fabric.reactivated":
+		if payload.Status == statusDraft {
+			return domain.DigestItem{}, false
+		}
+		item.Topic = domain.TopicFabricCreated
+		item.Summary = fmt.Sprintf("%s added to the catalogue", payload.Name)
+	case "app.fabric.activated":
+		if payload.PreviousStatus != statusDraft {
+			return domain.DigestItem{}, false
+		}
+		item.Topic = domain.TopicFabricCreated
+		item.Summary = fmt.Sprintf("%s added to the catalogue", payload.Name)
+	case "app.fabric.updated":
+		if payload.OfferStatus != offerStatusDiscontinued {
+			return domain.DigestItem{}, false
+		}
+		item.Topic = domain.TopicFabricDiscontinued
+		item.Summary = fmt.Sprintf("%s discontinued", payload.Name)
+	case "app.fabric.discontinued":
+		item.Topic = domain.TopicFabricDiscontinued
+		item.Summary = fmt.Sprintf("%s discontinued", payload.Name)
+	case "app.fabric.deleted":
+		item.Topic = domain.TopicFabricDeleted
+		item.Summary = "removed from the catalogue"
+	case "app.fabric.merged":
+		item.Topic = domain.TopicFabricMerged
+		item.Summary = fmt.Sprintf("merged into %s", payload.CanonicalCode)
+	default:
+		return domain.DigestItem{}, false
+	}
+	return item, true
+}
+
+// decodePayload reads the envelope payload into dst, whether it was read back from the
+// store as raw JSON or still holds the typed event.
+func decodePayload(envelope *messaging.EventEnvelope, dst any) error {
+	raw, ok := envelope.Payload.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(envelope.Payload); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(raw, dst)
+}
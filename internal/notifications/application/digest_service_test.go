@@ -0,0 +1,194 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/mail"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type mockSubscriptionRepository struct {
+	subscriptions []*domain.Subscription
+	digested      map[int64]int64
+}
+
+func (m *mockSubscriptionRepository) SaveSubscription(ctx context.Context, subscription *domain.Subscription) error {
+	return nil
+}
+
+func (m *mockSubscriptionRepository) ListSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	return m.subscriptions, nil
+}
+
+func (m *mockSubscriptionRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (m *mockSubscriptionRepository) MarkDigested(ctx context.Context, id int64, position int64, sentAt time.Time) error {
+	if m.digested == nil {
+		m.digested = map[int64]int64{}
+	}
+	m.digested[id] = position
+	return nil
+}
+
+type mockEventFeed struct {
+	events []eventstore.RecordedEvent
+}
+
+func (m *mockEventFeed) ReadAfter(
+	ctx context.Context, aggregateType string, after int64, limit int,
+) ([]eventstore.RecordedEvent, error) {
+	result := []eventstore.RecordedEvent{}
+	for _, event := range m.events {
+		if event.Position > after && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+type mockMailer struct {
+	sent    []mail.Message
+	failFor string
+}
+
+func (m *mockMailer) Send(ctx context.Context, msg mail.Message) error {
+	if msg.To[0] == m.failFor {
+		return errors.New("mailbox unavailable")
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func recorded(position int64, eventType, code string, payload any) eventstore.RecordedEvent {
+	envelope := messaging.NewEventEnvelope(eventType, code, "Fabric", int(position), payload,
+		messaging.WithClock(clock.NewFixed(testNow)))
+	return eventstore.RecordedEvent{Position: position, Envelope: envelope}
+}
+
+// probeRecorded records an event of the synthetic probe, which digests leave out
+func probeRecorded(position int64, eventType string, payload any) eventstore.RecordedEvent {
+	event := recorded(position, eventType, "ZZPROBE", payload)
+	event.Envelope.UserID = command.ActorSyntheticProbe
+	return event
+}
+
+func testFeed() *mockEventFeed {
+	return &mockEventFeed{events: []eventstore.RecordedEvent{
+		recorded(1, "app.fabric.created", "FAB01", map[string]any{"Name": "Linen"}),
+		recorded(2, "app.fabric.updated", "FAB01", map[string]any{"Name": "Linen", "OfferStatus": "ACTIVE"}),
+		recorded(3, "app.fabric.updated", "FAB02", map[string]any{"Name": "Wool", "OfferStatus": "DISCONTINUED"}),
+		recorded(4, "app.fabric.deleted", "FAB03", map[string]any{"Code": "FAB03"}),
+	}}
+}
+
+func TestDigestService_SendDigests(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockSubscriptionRepository{subscriptions: []*domain.Subscription{
+		{ID: 1, Email: "buyer@example.com", Topics: []string{domain.TopicFabricDiscontinued}},
+		{ID: 2, Email: "sales@example.com", Topics: []string{domain.TopicFabricCreated, domain.TopicFabricDeleted}, LastPosition: 1},
+		{ID: 3, Email: "merges@example.com", Topics: []string{domain.TopicFabricMerged}},
+		{ID: 4, Email: "uptodate@example.com", Topics: []string{domain.TopicFabricDeleted}, LastPosition: 4},
+	}}
+	mailer := &mockMailer{}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewDigestService(repo, testFeed(), mailer, clock.NewFixed(testNow), logger)
+
+	// --- Act ---
+	err := service.SendDigests(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.Len(t, mailer.sent, 2, "only subscribers with new items of their topics receive a digest")
+	assert.Equal(t, []string{"buyer@example.com"}, mailer.sent[0].To)
+	assert.Equal(t, "Fabric digest: 1 update(s)", mailer.sent[0].Subject)
+	assert.Contains(t, mailer.sent[0].Body, "FAB02: Wool discontinued")
+	assert.NotContains(t, mailer.sent[0].Body, "FAB01")
+	assert.Equal(t, []string{"sales@example.com"}, mailer.sent[1].To)
+	assert.NotContains(t, mailer.sent[1].Body, "FAB01", "items before the last digest are not sent again")
+	assert.Contains(t, mailer.sent[1].Body, "FAB03: removed from the catalogue")
+	assert.Equal(t, map[int64]int64{1: 4, 2: 4, 3: 4}, repo.digested,
+		"every subscriber with new events moves to the latest position, even without items")
+}
+
+func TestDigestService_SendDigests_FailedDelivery(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockSubscriptionRepository{subscriptions: []*domain.Subscription{
+		{ID: 1, Email: "broken@example.com", Topics: []string{domain.TopicFabricDiscontinued}},
+		{ID: 2, Email: "buyer@example.com", Topics: []string{domain.TopicFabricDiscontinued}},
+	}}
+	mailer := &mockMailer{failFor: "broken@example.com"}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewDigestService(repo, testFeed(), mailer, clock.NewFixed(testNow), logger)
+
+	// --- Act ---
+	err := service.SendDigests(context.Background())
+
+	// --- Assert ---
+	require.Error(t, err)
+	assert.Len(t, mailer.sent, 1, "a failed delivery does not hold back other subscribers")
+	assert.Equal(t, map[int64]int64{2: 4}, repo.digested, "the failed subscriber keeps its position for the next run")
+}
+
+func TestDigestService_SendDigests_SkipsSyntheticProbe(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockSubscriptionRepository{subscriptions: []*domain.Subscription{
+		{ID: 1, Email: "sales@example.com", Topics: []string{domain.TopicFabricCreated, domain.TopicFabricDeleted}},
+	}}
+	feed := &mockEventFeed{events: []eventstore.RecordedEvent{
+		probeRecorded(1, "app.fabric.created", map[string]any{"Name": "Synthetic probe"}),
+		probeRecorded(2, "app.fabric.deleted", map[string]any{"Code": "ZZPROBE"}),
+	}}
+	mailer := &mockMailer{}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewDigestService(repo, feed, mailer, clock.NewFixed(testNow), logger)
+
+	// --- Act ---
+	err := service.SendDigests(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Empty(t, mailer.sent, "the fabric of the synthetic probe is not reported")
+	assert.Equal(t, map[int64]int64{1: 2}, repo.digested)
+}
+
+func TestDigestService_SendDigests_Lifecycle(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockSubscriptionRepository{subscriptions: []*domain.Subscription{
+		{ID: 1, Email: "sales@example.com", Topics: []string{domain.TopicFabricCreated, domain.TopicFabricDiscontinued}},
+	}}
+	feed := &mockEventFeed{events: []eventstore.RecordedEvent{
+		recorded(1, "app.fabric.created", "FAB01", map[string]any{"Name": "Linen", "Status": "DRAFT"}),
+		recorded(2, "app.fabric.activated", "FAB01", map[string]any{"Name": "Linen", "PreviousStatus": "DRAFT"}),
+		recorded(3, "app.fabric.discontinued", "FAB02", map[string]any{"Name": "Wool", "PreviousStatus": "ACTIVE"}),
+		recorded(4, "app.fabric.activated", "FAB02", map[string]any{"Name": "Wool", "PreviousStatus": "DISCONTINUED"}),
+	}}
+	mailer := &mockMailer{}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewDigestService(repo, feed, mailer, clock.NewFixed(testNow), logger)
+
+	// --- Act ---
+	err := service.SendDigests(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.Len(t, mailer.sent, 1)
+	assert.Equal(t, "Fabric digest: 2 update(s)", mailer.sent[0].Subject,
+		"a draft is announced once activated, a relaunch is not announced again")
+	assert.Contains(t, mailer.sent[0].Body, "FAB01: Linen added to the catalogue")
+	assert.Contains(t, mailer.sent[0].Body, "FAB02: Wool discontinued")
+}
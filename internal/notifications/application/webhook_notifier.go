@@ -0,0 +1,86 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+)
+
+// WebhookNotifier posts alerts to the chat webhooks configured for their type.
+type WebhookNotifier struct {
+	webhooks domain.WebhookRepository
+	client   *http.Client
+	logger   *slog.Logger
+}
+
+func NewWebhookNotifier(webhooks domain.WebhookRepository, client *http.Client, logger *slog.Logger) *WebhookNotifier {
+	return &WebhookNotifier{
+		webhooks: webhooks,
+		client:   client,
+		logger:   logger.With("component", "notification.webhook"),
+	}
+}
+
+// Notify posts the alert to every webhook that receives its type. A failing webhook does
+// not keep the alert from the others.
+func (n *WebhookNotifier) Notify(ctx context.Context, alert domain.Alert) error {
+	webhooks, err := n.webhooks.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		if !webhook.Wants(alert.Type) {
+			continue
+		}
+		if err := n.post(ctx, webhook, alert); err != nil {
+			n.logger.Warn("failed to post alert", "error", err, "webhook", webhook.Name, "alertType", alert.Type)
+			errs = append(errs, fmt.Errorf("webhook %s: %w", webhook.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *WebhookNotifier) post(ctx context.Context, webhook *domain.Webhook, alert domain.Alert) error {
+	body, err := json.Marshal(webhookMessage(webhook.Kind, alert))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %d", res.StatusCode)
+	}
+	return nil
+}
+
+// webhookMessage builds the message body understood by the webhook's chat service
+func webhookMessage(kind string, alert domain.Alert) map[string]any {
+	if kind == domain.WebhookTeams {
+		return map[string]any{
+			"@type":    "MessageCard",
+			"@context": "http://schema.org/extensions",
+			"summary":  alert.Type,
+			"text":     alert.Text,
+		}
+	}
+	return map[string]any{"text": alert.Text}
+}
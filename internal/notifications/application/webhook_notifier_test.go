@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockWebhookRepository struct {
+	webhooks []*domain.Webhook
+}
+
+func (m *mockWebhookRepository) SaveWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	return nil
+}
+
+func (m *mockWebhookRepository) ListWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+	return m.webhooks, nil
+}
+
+func (m *mockWebhookRepository) DeleteWebhook(ctx context.Context, id int64) error {
+	return nil
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	// --- Arrange ---
+	received := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received[r.URL.Path] = body
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	repo := &mockWebhookRepository{webhooks: []*domain.Webhook{
+		{Name: "slack", Kind: domain.WebhookSlack, URL: server.URL + "/slack", AlertTypes: []string{domain.AlertFabricDeleted}},
+		{Name: "teams", Kind: domain.WebhookTeams, URL: server.URL + "/teams", AlertTypes: []string{domain.AlertFabricDeleted}},
+		{Name: "dlq", Kind: domain.WebhookSlack, URL: server.URL + "/dlq", AlertTypes: []string{domain.AlertDeadLetterGrowth}},
+		{Name: "broken", Kind: domain.WebhookSlack, URL: server.URL + "/broken", AlertTypes: []string{domain.AlertFabricDeleted}},
+	}}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	notifier := NewWebhookNotifier(repo, server.Client(), logger)
+
+	// --- Act ---
+	err := notifier.Notify(context.Background(), domain.Alert{Type: domain.AlertFabricDeleted, Text: "Fabric FAB01 was deleted"})
+
+	// --- Assert ---
+	require.Error(t, err, "the failing webhook is reported")
+	assert.Contains(t, err.Error(), "webhook broken")
+	assert.Equal(t, map[string]any{"text": "Fabric FAB01 was deleted"}, received["/slack"])
+	assert.Equal(t, "MessageCard", received["/teams"]["@type"])
+	assert.Equal(t, "Fabric FAB01 was deleted", received["/teams"]["text"])
+	assert.NotContains(t, received, "/dlq", "webhooks only receive their alert types")
+}
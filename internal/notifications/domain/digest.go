@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DigestItem is a single notable event reported in a digest.
+type DigestItem struct {
+	Topic      string
+	Code       string
+	Summary    string
+	OccurredAt time.Time
+}
+
+// Digest collects the items a subscriber has not been sent yet.
+type Digest struct {
+	Subscription *Subscription
+	Items        []DigestItem
+}
+
+func (d *Digest) Subject() string {
+	return fmt.Sprintf("Fabric digest: %d update(s)", len(d.Items))
+}
+
+// Body lists the items grouped by topic, in the order of Topics.
+func (d *Digest) Body() string {
+	var b strings.Builder
+	for _, topic := range Topics {
+		first := true
+		for _, item := range d.Items {
+			if item.Topic != topic {
+				continue
+			}
+			if first {
+				fmt.Fprintf(&b, "%s\n", topic)
+				first = false
+			}
+			fmt.Fprintf(&b, "  %s  %s: %s\n", item.OccurredAt.Format(time.DateTime), item.Code, item.Summary)
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
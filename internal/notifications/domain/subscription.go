@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrSubscriptionNotFound is returned when no subscription matches the requested ID.
+var ErrSubscriptionNotFound = errors.New("notification subscription not found")
+
+// Subscribers a digest can be configured for: a single user or every user in a role.
+const (
+	SubscriberUser = "user"
+	SubscriberRole = "role"
+)
+
+// Topics subscribers can receive in their digests.
+const (
+	TopicFabricCreated      = "fabric.created"
+	TopicFabricDiscontinued = "fabric.discontinued"
+	TopicFabricDeleted      = "fabric.deleted"
+	TopicFabricMerged       = "fabric.merged"
+)
+
+var Topics = []string{TopicFabricCreated, TopicFabricDiscontinued, TopicFabricDeleted, TopicFabricMerged}
+
+// Subscription configures the digest emails a user or role receives. Roles subscribe with
+// their distribution list address.
+type Subscription struct {
+	ID             int64      `json:"id"`
+	SubscriberKind string     `json:"subscriber_kind"`
+	Subscriber     string     `json:"subscriber"`
+	Email          string     `json:"email"`
+	Topics         []string   `json:"topics"`
+	LastPosition   int64      `json:"-"`
+	LastSentAt     *time.Time `json:"last_sent_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CreatedBy      string     `json:"created_by"`
+}
+
+// Wants reports whether the subscriber receives items of the given topic.
+func (s *Subscription) Wants(topic string) bool {
+	for _, t := range s.Topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+type SubscriptionRepository interface {
+	// SaveSubscription creates the subscription of a user or role, or replaces its email and
+	// topics when it already exists. New subscriptions start from the latest event, so the
+	// first digest does not replay history.
+	SaveSubscription(ctx context.Context, subscription *Subscription) error
+	ListSubscriptions(ctx context.Context) ([]*Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	// MarkDigested records that the subscriber has been sent everything up to position.
+	MarkDigested(ctx context.Context, id int64, position int64, sentAt time.Time) error
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrWebhookNotFound is returned when no webhook matches the requested ID.
+var ErrWebhookNotFound = errors.New("notification webhook not found")
+
+// Chat services a webhook can post to.
+const (
+	WebhookSlack = "slack"
+	WebhookTeams = "teams"
+)
+
+// Alerts a webhook can be configured to receive.
+const (
+	AlertFabricDeleted    = "fabric.deleted"
+	AlertDeadLetterGrowth = "dlq.growth"
+)
+
+var AlertTypes = []string{AlertFabricDeleted, AlertDeadLetterGrowth}
+
+// Alert is a critical catalog event reported to chat channels.
+type Alert struct {
+	Type string
+	Text string
+}
+
+// Webhook posts the alerts of the configured types to a chat channel. The URL carries the
+// channel's credentials and is never returned by the API.
+type Webhook struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Kind       string    `json:"kind"`
+	URL        string    `json:"-"`
+	AlertTypes []string  `json:"alert_types"`
+	CreatedAt  time.Time `json:"created_at"`
+	CreatedBy  string    `json:"created_by"`
+}
+
+// Wants reports whether the webhook receives alerts of the given type.
+func (w *Webhook) Wants(alertType string) bool {
+	for _, t := range w.AlertTypes {
+		if t == alertType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookRepository interface {
+	// SaveWebhook creates the webhook, or replaces the kind, URL and alert types of the
+	// webhook with the same name.
+	SaveWebhook(ctx context.Context, webhook *Webhook) error
+	ListWebhooks(ctx context.Context) ([]*Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, alert domain.Alert) error
+}
+
+// AlertEventHandler turns the fabric events and dead letters it receives into alerts.
+// Fabric deletions are alerted one by one; dead letters are counted and reported as a
+// single growth alert per interval.
+type AlertEventHandler struct {
+	notifier          Notifier
+	deadLetterSubject string
+	deadLetters       atomic.Int64
+}
+
+func NewAlertEventHandler(notifier Notifier, deadLetterSubject string) *AlertEventHandler {
+	return &AlertEventHandler{
+		notifier:          notifier,
+		deadLetterSubject: deadLetterSubject,
+	}
+}
+
+func (h *AlertEventHandler) HandleMessage(ctx context.Context, subject string, payload []byte) error {
+	if subject == h.deadLetterSubject {
+		h.deadLetters.Add(1)
+		return nil
+	}
+
+	var envelope messaging.EventEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return fmt.Errorf("failed to unmarshal event envelope: %w", err)
+	}
+	// the synthetic probe deletes its own fabric on every run
+	if envelope.EventType != "app.fabric.deleted" || envelope.UserID == command.ActorSyntheticProbe {
+		return nil
+	}
+
+	return h.notifier.Notify(ctx, domain.Alert{
+		Type: domain.AlertFabricDeleted,
+		Text: fmt.Sprintf("Fabric %s was deleted from the catalog", envelope.AggregateID),
+	})
+}
+
+// ReportDeadLetters alerts on the dead letters received since the previous report, over
+// the given interval. Nothing is sent when there were none.
+func (h *AlertEventHandler) ReportDeadLetters(ctx context.Context, interval time.Duration) error {
+	count := h.deadLetters.Swap(0)
+	if count == 0 {
+		return nil
+	}
+
+	return h.notifier.Notify(ctx, domain.Alert{
+		Type: domain.AlertDeadLetterGrowth,
+		Text: fmt.Sprintf("%d ERP event(s) dead-lettered to %s in the last %s", count, h.deadLetterSubject, interval),
+	})
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockNotifier struct {
+	alerts []domain.Alert
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, alert domain.Alert) error {
+	m.alerts = append(m.alerts, alert)
+	return nil
+}
+
+func TestAlertEventHandler_HandleMessage(t *testing.T) {
+	// --- Arrange ---
+	notifier := &mockNotifier{}
+	handler := NewAlertEventHandler(notifier, "dlq.erp.fabric")
+	ctx := context.Background()
+	deleted, err := json.Marshal(messaging.NewEventEnvelope("app.fabric.deleted", "FAB01", "Fabric", 3, map[string]any{"Code": "FAB01"}))
+	require.NoError(t, err)
+	updated, err := json.Marshal(messaging.NewEventEnvelope("app.fabric.updated", "FAB02", "Fabric", 2, map[string]any{"Code": "FAB02"}))
+	require.NoError(t, err)
+	probed, err := json.Marshal(messaging.NewEventEnvelope("app.fabric.deleted", "ZZPROBE", "Fabric", 3,
+		map[string]any{"Code": "ZZPROBE"}, messaging.WithUserID(command.ActorSyntheticProbe)))
+	require.NoError(t, err)
+
+	// --- Act ---
+	require.NoError(t, handler.HandleMessage(ctx, "app.fabric", deleted))
+	require.NoError(t, handler.HandleMessage(ctx, "app.fabric", updated))
+	require.NoError(t, handler.HandleMessage(ctx, "app.fabric", probed))
+	require.NoError(t, handler.HandleMessage(ctx, "dlq.erp.fabric", []byte(`{}`)))
+	require.NoError(t, handler.HandleMessage(ctx, "dlq.erp.fabric", []byte(`{}`)))
+	require.NoError(t, handler.ReportDeadLetters(ctx, 5*time.Minute))
+	require.NoError(t, handler.ReportDeadLetters(ctx, 5*time.Minute))
+
+	// --- Assert ---
+	require.Len(t, notifier.alerts, 2, "one alert per deletion, except the probe's, and one per interval with dead letters")
+	assert.Equal(t, domain.AlertFabricDeleted, notifier.alerts[0].Type)
+	assert.Contains(t, notifier.alerts[0].Text, "FAB01")
+	assert.Equal(t, domain.AlertDeadLetterGrowth, notifier.alerts[1].Type)
+	assert.Equal(t, "2 ERP event(s) dead-lettered to dlq.erp.fabric in the last 5m0s", notifier.alerts[1].Text)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// SubscriptionHandler manages the digest email subscriptions of users and roles.
+type SubscriptionHandler struct {
+	subscriptions domain.SubscriptionRepository
+	clock         clock.Clock
+}
+
+type saveSubscriptionRequest struct {
+	SubscriberKind string   `json:"subscriber_kind"`
+	Subscriber     string   `json:"subscriber"`
+	Email          string   `json:"email"`
+	Topics         []string `json:"topics"`
+}
+
+func NewSubscriptionHandler(subscriptions domain.SubscriptionRepository, clock clock.Clock) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptions: subscriptions,
+		clock:         clock,
+	}
+}
+
+func (h *SubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listSubscriptions(w, r)
+	case http.MethodPut:
+		h.saveSubscription(w, r)
+	case http.MethodDelete:
+		h.deleteSubscription(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *SubscriptionHandler) listSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.subscriptions.ListSubscriptions(r.Context())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"subscriptions": subscriptions, "topics": domain.Topics}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// saveSubscription creates or replaces the subscription of the user or role.
+func (h *SubscriptionHandler) saveSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req saveSubscriptionRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(validator.PermittedValue(req.SubscriberKind, domain.SubscriberUser, domain.SubscriberRole),
+		"subscriber_kind", "must be user or role")
+	v.Check(req.Subscriber != "", "subscriber", "must be provided")
+	v.Check(len(req.Subscriber) <= 255, "subscriber", "must not be more than 255 characters long")
+	v.Check(validator.Matches(req.Email, validator.EmailRX), "email", "must be a valid email address")
+	v.Check(len(req.Topics) > 0, "topics", "must contain at least one topic")
+	v.Check(validator.Unique(req.Topics), "topics", "must not contain duplicate topics")
+	for _, topic := range req.Topics {
+		v.Check(validator.PermittedValue(topic, domain.Topics...), "topics", "must only contain known topics")
+	}
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	subscription := &domain.Subscription{
+		SubscriberKind: req.SubscriberKind,
+		Subscriber:     req.Subscriber,
+		Email:          req.Email,
+		Topics:         req.Topics,
+		CreatedAt:      h.clock.Now(),
+		CreatedBy:      command.Actor(ctx),
+	}
+	if err := h.subscriptions.SaveSubscription(ctx, subscription); err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"subscription": subscription}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *SubscriptionHandler) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(httpx.URLParam(r, "id"), 10, 64)
+	if err != nil || id < 1 {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	if err := h.subscriptions.DeleteSubscription(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrSubscriptionNotFound) {
+			httpx.NotFound(w, r)
+			return
+		}
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
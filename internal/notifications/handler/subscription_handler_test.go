@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testClock = clock.NewFixed(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+type mockSubscriptionRepository struct {
+	saved   []*domain.Subscription
+	deleted []int64
+}
+
+func (m *mockSubscriptionRepository) SaveSubscription(ctx context.Context, subscription *domain.Subscription) error {
+	subscription.ID = int64(len(m.saved) + 1)
+	m.saved = append(m.saved, subscription)
+	return nil
+}
+
+func (m *mockSubscriptionRepository) ListSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	return m.saved, nil
+}
+
+func (m *mockSubscriptionRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	if id != 1 {
+		return domain.ErrSubscriptionNotFound
+	}
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+func (m *mockSubscriptionRepository) MarkDigested(ctx context.Context, id int64, position int64, sentAt time.Time) error {
+	return nil
+}
+
+func TestSubscriptionHandler_SaveSubscription(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "user subscription",
+			body:           `{"subscriber_kind": "user", "subscriber": "user_42", "email": "jan@example.com", "topics": ["fabric.discontinued"]}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown subscriber kind",
+			body:           `{"subscriber_kind": "team", "subscriber": "sales", "email": "sales@example.com", "topics": ["fabric.deleted"]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "subscriber_kind",
+		},
+		{
+			name:           "invalid email",
+			body:           `{"subscriber_kind": "role", "subscriber": "sales", "email": "sales", "topics": ["fabric.deleted"]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "email",
+		},
+		{
+			name:           "unknown topic",
+			body:           `{"subscriber_kind": "role", "subscriber": "sales", "email": "sales@example.com", "topics": ["certificate.expiring"]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "topics",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := &mockSubscriptionRepository{}
+			handler := NewSubscriptionHandler(repo, testClock)
+			req := httptest.NewRequest(http.MethodPut, "/v1/admin/notifications/subscriptions", strings.NewReader(tc.body))
+			req = req.WithContext(command.WithUserID(req.Context(), "admin"))
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedError != "" {
+				assert.Contains(t, responseRecorder.Body.String(), tc.expectedError)
+				assert.Empty(t, repo.saved)
+				return
+			}
+			require.Len(t, repo.saved, 1)
+			assert.Equal(t, "admin", repo.saved[0].CreatedBy)
+			assert.Equal(t, testClock.Now(), repo.saved[0].CreatedAt)
+		})
+	}
+}
+
+func TestSubscriptionHandler_DeleteSubscription(t *testing.T) {
+	testCases := []struct {
+		name           string
+		id             string
+		expectedStatus int
+	}{
+		{name: "existing subscription", id: "1", expectedStatus: http.StatusNoContent},
+		{name: "unknown subscription", id: "7", expectedStatus: http.StatusNotFound},
+		{name: "invalid id", id: "abc", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewSubscriptionHandler(&mockSubscriptionRepository{}, testClock)
+			req := httptest.NewRequest(http.MethodDelete, "/v1/admin/notifications/subscriptions/"+tc.id, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// WebhookHandler manages the chat webhooks receiving critical catalog alerts.
+type WebhookHandler struct {
+	webhooks domain.WebhookRepository
+	clock    clock.Clock
+}
+
+type saveWebhookRequest struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind"`
+	URL        string   `json:"url"`
+	AlertTypes []string `json:"alert_types"`
+}
+
+func NewWebhookHandler(webhooks domain.WebhookRepository, clock clock.Clock) *WebhookHandler {
+	return &WebhookHandler{
+		webhooks: webhooks,
+		clock:    clock,
+	}
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listWebhooks(w, r)
+	case http.MethodPut:
+		h.saveWebhook(w, r)
+	case http.MethodDelete:
+		h.deleteWebhook(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *WebhookHandler) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhooks.ListWebhooks(r.Context())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"webhooks": webhooks, "alert_types": domain.AlertTypes}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// saveWebhook creates or replaces the webhook with the requested name.
+func (h *WebhookHandler) saveWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req saveWebhookRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Name != "", "name", "must be provided")
+	v.Check(len(req.Name) <= 100, "name", "must not be more than 100 characters long")
+	v.Check(validator.PermittedValue(req.Kind, domain.WebhookSlack, domain.WebhookTeams), "kind", "must be slack or teams")
+	target, err := url.Parse(req.URL)
+	v.Check(err == nil && target.Scheme == "https" && target.Host != "", "url", "must be an https URL")
+	v.Check(len(req.AlertTypes) > 0, "alert_types", "must contain at least one alert type")
+	v.Check(validator.Unique(req.AlertTypes), "alert_types", "must not contain duplicate alert types")
+	for _, alertType := range req.AlertTypes {
+		v.Check(validator.PermittedValue(alertType, domain.AlertTypes...), "alert_types", "must only contain known alert types")
+	}
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	webhook := &domain.Webhook{
+		Name:       req.Name,
+		Kind:       req.Kind,
+		URL:        req.URL,
+		AlertTypes: req.AlertTypes,
+		CreatedAt:  h.clock.Now(),
+		CreatedBy:  command.Actor(ctx),
+	}
+	if err := h.webhooks.SaveWebhook(ctx, webhook); err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"webhook": webhook}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *WebhookHandler) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(httpx.URLParam(r, "id"), 10, 64)
+	if err != nil || id < 1 {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	if err := h.webhooks.DeleteWebhook(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			httpx.NotFound(w, r)
+			return
+		}
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
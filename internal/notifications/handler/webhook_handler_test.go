@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockWebhookRepository struct {
+	saved []*domain.Webhook
+}
+
+func (m *mockWebhookRepository) SaveWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	webhook.ID = int64(len(m.saved) + 1)
+	m.saved = append(m.saved, webhook)
+	return nil
+}
+
+func (m *mockWebhookRepository) ListWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+	return m.saved, nil
+}
+
+func (m *mockWebhookRepository) DeleteWebhook(ctx context.Context, id int64) error {
+	return domain.ErrWebhookNotFound
+}
+
+func TestWebhookHandler_SaveWebhook(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "slack webhook",
+			body:           `{"name": "catalog", "kind": "slack", "url": "https://hooks.slack.com/services/T0/B0/secret", "alert_types": ["fabric.deleted"]}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "plain http url",
+			body:           `{"name": "catalog", "kind": "teams", "url": "http://example.com/hook", "alert_types": ["fabric.deleted"]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "url",
+		},
+		{
+			name:           "unknown alert type",
+			body:           `{"name": "catalog", "kind": "slack", "url": "https://example.com/hook", "alert_types": ["fabric.updated"]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "alert_types",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := &mockWebhookRepository{}
+			handler := NewWebhookHandler(repo, testClock)
+			req := httptest.NewRequest(http.MethodPut, "/v1/admin/notifications/webhooks", strings.NewReader(tc.body))
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedError != "" {
+				assert.Contains(t, responseRecorder.Body.String(), tc.expectedError)
+				assert.Empty(t, repo.saved)
+				return
+			}
+			require.Len(t, repo.saved, 1)
+			assert.NotContains(t, responseRecorder.Body.String(), "secret", "the webhook URL is not returned")
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+)
+
+// InstrumentedSubscriptionRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedSubscriptionRepository struct {
+	next domain.SubscriptionRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedSubscriptionRepository(
+	next domain.SubscriptionRepository, rec *instrument.Recorder,
+) *InstrumentedSubscriptionRepository {
+	return &InstrumentedSubscriptionRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedSubscriptionRepository) SaveSubscription(
+	ctx context.Context, subscription *domain.Subscription,
+) error {
+	return instrument.Exec(ctx, r.rec, "SaveSubscription", func(ctx context.Context) error {
+		return r.next.SaveSubscription(ctx, subscription)
+	})
+}
+
+func (r *InstrumentedSubscriptionRepository) ListSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	return instrument.Call(ctx, r.rec, "ListSubscriptions", func(ctx context.Context) ([]*domain.Subscription, error) {
+		return r.next.ListSubscriptions(ctx)
+	})
+}
+
+func (r *InstrumentedSubscriptionRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	return instrument.Exec(ctx, r.rec, "DeleteSubscription", func(ctx context.Context) error {
+		return r.next.DeleteSubscription(ctx, id)
+	})
+}
+
+func (r *InstrumentedSubscriptionRepository) MarkDigested(
+	ctx context.Context, id int64, position int64, sentAt time.Time,
+) error {
+	return instrument.Exec(ctx, r.rec, "MarkDigested", func(ctx context.Context) error {
+		return r.next.MarkDigested(ctx, id, position, sentAt)
+	})
+}
+
+type InstrumentedWebhookRepository struct {
+	next domain.WebhookRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedWebhookRepository(
+	next domain.WebhookRepository, rec *instrument.Recorder,
+) *InstrumentedWebhookRepository {
+	return &InstrumentedWebhookRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedWebhookRepository) SaveWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	return instrument.Exec(ctx, r.rec, "SaveWebhook", func(ctx context.Context) error {
+		return r.next.SaveWebhook(ctx, webhook)
+	})
+}
+
+func (r *InstrumentedWebhookRepository) ListWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+	return instrument.Call(ctx, r.rec, "ListWebhooks", func(ctx context.Context) ([]*domain.Webhook, error) {
+		return r.next.ListWebhooks(ctx)
+	})
+}
+
+func (r *InstrumentedWebhookRepository) DeleteWebhook(ctx context.Context, id int64) error {
+	return instrument.Exec(ctx, r.rec, "DeleteWebhook", func(ctx context.Context) error {
+		return r.next.DeleteWebhook(ctx, id)
+	})
+}
@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestPostgresDB(t *testing.T) *database.PostgresDB {
+	t.Helper()
+
+	uri := os.Getenv("POSTGRES_URI")
+	if uri == "" {
+		t.Skip("Skipping integration test: POSTGRES_URI env variable is not set")
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db, err := database.NewPostgresDB(ctx, uri, 5, 5, 5*time.Minute, logger)
+	require.NoError(t, err, "Failed to connect to postgres")
+
+	t.Cleanup(func() {
+		if _, err := db.Pool.Exec("DELETE FROM notification_webhooks; DELETE FROM notification_subscriptions"); err != nil {
+			t.Fatalf("Failed to clean up test data: %v", err)
+		}
+		db.Close()
+	})
+
+	return db
+}
+
+func TestSubscriptionPostgresRepository_Lifecycle(t *testing.T) {
+	// --- Arrange ---
+	db := setupTestPostgresDB(t)
+	repo := NewSubscriptionPostgresRepository(db)
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	subscription := &domain.Subscription{
+		SubscriberKind: domain.SubscriberRole,
+		Subscriber:     "purchasing",
+		Email:          "purchasing@example.com",
+		Topics:         []string{domain.TopicFabricDiscontinued},
+		CreatedAt:      createdAt,
+		CreatedBy:      "admin",
+	}
+	replacement := &domain.Subscription{
+		SubscriberKind: domain.SubscriberRole,
+		Subscriber:     "purchasing",
+		Email:          "buyers@example.com",
+		Topics:         []string{domain.TopicFabricDiscontinued, domain.TopicFabricDeleted},
+		CreatedAt:      createdAt.Add(time.Hour),
+		CreatedBy:      "other-admin",
+	}
+
+	// --- Act ---
+	saveErr := repo.SaveSubscription(ctx, subscription)
+	replaceErr := repo.SaveSubscription(ctx, replacement)
+	markErr := repo.MarkDigested(ctx, subscription.ID, subscription.LastPosition+1, createdAt.Add(2*time.Hour))
+	listed, listErr := repo.ListSubscriptions(ctx)
+	deleteErr := repo.DeleteSubscription(ctx, subscription.ID)
+	deleteAgainErr := repo.DeleteSubscription(ctx, subscription.ID)
+
+	// --- Assert ---
+	require.NoError(t, saveErr)
+	require.NoError(t, replaceErr)
+	assert.Equal(t, subscription.ID, replacement.ID, "a role has a single subscription")
+	assert.Equal(t, "admin", replacement.CreatedBy, "replacing keeps the original audit data")
+	require.NoError(t, markErr)
+	require.NoError(t, listErr)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "buyers@example.com", listed[0].Email)
+	assert.Equal(t, replacement.Topics, listed[0].Topics)
+	assert.Equal(t, subscription.LastPosition+1, listed[0].LastPosition)
+	require.NotNil(t, listed[0].LastSentAt)
+	require.NoError(t, deleteErr)
+	assert.ErrorIs(t, deleteAgainErr, domain.ErrSubscriptionNotFound)
+}
+
+func TestWebhookPostgresRepository_Lifecycle(t *testing.T) {
+	// --- Arrange ---
+	db := setupTestPostgresDB(t)
+	repo := NewWebhookPostgresRepository(db)
+	ctx := context.Background()
+	webhook := &domain.Webhook{
+		Name:       "catalog",
+		Kind:       domain.WebhookSlack,
+		URL:        "https://hooks.slack.com/services/T0/B0/first",
+		AlertTypes: []string{domain.AlertFabricDeleted},
+		CreatedAt:  time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		CreatedBy:  "admin",
+	}
+	replacement := &domain.Webhook{
+		Name:       "catalog",
+		Kind:       domain.WebhookTeams,
+		URL:        "https://example.webhook.office.com/second",
+		AlertTypes: []string{domain.AlertFabricDeleted, domain.AlertDeadLetterGrowth},
+		CreatedAt:  time.Date(2025, 1, 3, 3, 4, 5, 0, time.UTC),
+		CreatedBy:  "other-admin",
+	}
+
+	// --- Act ---
+	saveErr := repo.SaveWebhook(ctx, webhook)
+	replaceErr := repo.SaveWebhook(ctx, replacement)
+	listed, listErr := repo.ListWebhooks(ctx)
+	deleteErr := repo.DeleteWebhook(ctx, webhook.ID)
+	deleteAgainErr := repo.DeleteWebhook(ctx, webhook.ID)
+
+	// --- Assert ---
+	require.NoError(t, saveErr)
+	require.NoError(t, replaceErr)
+	assert.Equal(t, webhook.ID, replacement.ID, "webhooks are replaced by name")
+	require.NoError(t, listErr)
+	require.Len(t, listed, 1)
+	assert.Equal(t, replacement.URL, listed[0].URL)
+	assert.Equal(t, replacement.AlertTypes, listed[0].AlertTypes)
+	require.NoError(t, deleteErr)
+	assert.ErrorIs(t, deleteAgainErr, domain.ErrWebhookNotFound)
+}
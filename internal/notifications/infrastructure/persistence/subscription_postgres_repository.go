@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type SubscriptionPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewSubscriptionPostgresRepository(db *database.PostgresDB) *SubscriptionPostgresRepository {
+	return &SubscriptionPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *SubscriptionPostgresRepository) SaveSubscription(ctx context.Context, subscription *domain.Subscription) error {
+	topics, err := json.Marshal(subscription.Topics)
+	if err != nil {
+		return fmt.Errorf("failed to encode subscription topics: %w", err)
+	}
+
+	query := `
+		INSERT INTO notification_subscriptions (
+			subscriber_kind, subscriber, email, topics, last_position, created_at, created_by
+		)
+		VALUES ($1, $2, $3, $4, (SELECT COALESCE(MAX(position), 0) FROM events), $5, $6)
+		ON CONFLICT (subscriber_kind, subscriber)
+		DO UPDATE SET email = EXCLUDED.email, topics = EXCLUDED.topics
+		RETURNING id, last_position, last_sent_at, created_at, created_by
+	`
+	var lastSentAt sql.NullTime
+	err = r.db.Conn(ctx).QueryRowContext(ctx, query,
+		subscription.SubscriberKind, subscription.Subscriber, subscription.Email, topics,
+		subscription.CreatedAt, subscription.CreatedBy,
+	).Scan(
+		&subscription.ID, &subscription.LastPosition, &lastSentAt,
+		&subscription.CreatedAt, &subscription.CreatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save notification subscription: %w", err)
+	}
+	if lastSentAt.Valid {
+		subscription.LastSentAt = &lastSentAt.Time
+	}
+	return nil
+}
+
+func (r *SubscriptionPostgresRepository) ListSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	query := `
+		SELECT id, subscriber_kind, subscriber, email, topics, last_position, last_sent_at,
+			created_at, created_by
+		FROM notification_subscriptions
+		ORDER BY id
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []*domain.Subscription{}
+	for rows.Next() {
+		var (
+			subscription domain.Subscription
+			topics       []byte
+			lastSentAt   sql.NullTime
+		)
+		err := rows.Scan(
+			&subscription.ID, &subscription.SubscriberKind, &subscription.Subscriber,
+			&subscription.Email, &topics, &subscription.LastPosition, &lastSentAt,
+			&subscription.CreatedAt, &subscription.CreatedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification subscription: %w", err)
+		}
+		if err := json.Unmarshal(topics, &subscription.Topics); err != nil {
+			return nil, fmt.Errorf("failed to decode subscription topics: %w", err)
+		}
+		if lastSentAt.Valid {
+			subscription.LastSentAt = &lastSentAt.Time
+		}
+		subscriptions = append(subscriptions, &subscription)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notification subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *SubscriptionPostgresRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM notification_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (r *SubscriptionPostgresRepository) MarkDigested(
+	ctx context.Context, id int64, position int64, sentAt time.Time,
+) error {
+	query := `
+		UPDATE notification_subscriptions
+		SET last_position = $1, last_sent_at = $2
+		WHERE id = $3
+	`
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, position, sentAt, id); err != nil {
+		return fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+	return nil
+}
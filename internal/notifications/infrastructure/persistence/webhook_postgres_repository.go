@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/notifications/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type WebhookPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewWebhookPostgresRepository(db *database.PostgresDB) *WebhookPostgresRepository {
+	return &WebhookPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *WebhookPostgresRepository) SaveWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	alertTypes, err := json.Marshal(webhook.AlertTypes)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook alert types: %w", err)
+	}
+
+	query := `
+		INSERT INTO notification_webhooks (name, kind, url, alert_types, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (name)
+		DO UPDATE SET kind = EXCLUDED.kind, url = EXCLUDED.url, alert_types = EXCLUDED.alert_types
+		RETURNING id, created_at, created_by
+	`
+	err = r.db.Conn(ctx).QueryRowContext(ctx, query,
+		webhook.Name, webhook.Kind, webhook.URL, alertTypes, webhook.CreatedAt, webhook.CreatedBy,
+	).Scan(&webhook.ID, &webhook.CreatedAt, &webhook.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save notification webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookPostgresRepository) ListWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+	query := `
+		SELECT id, name, kind, url, alert_types, created_at, created_by
+		FROM notification_webhooks
+		ORDER BY name
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []*domain.Webhook{}
+	for rows.Next() {
+		var (
+			webhook    domain.Webhook
+			alertTypes []byte
+		)
+		err := rows.Scan(
+			&webhook.ID, &webhook.Name, &webhook.Kind, &webhook.URL, &alertTypes,
+			&webhook.CreatedAt, &webhook.CreatedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification webhook: %w", err)
+		}
+		if err := json.Unmarshal(alertTypes, &webhook.AlertTypes); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook alert types: %w", err)
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notification webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookPostgresRepository) DeleteWebhook(ctx context.Context, id int64) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM notification_webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification webhook: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OrderService takes the orders of customers through their statuses and publishes their
+// events.
+type OrderService struct {
+	repo         domain.OrderRepository
+	eventStore   eventstore.Store
+	clock        clock.Clock
+	eventChannel string
+	source       messaging.Source
+}
+
+func NewOrderCommandService(
+	repo domain.OrderRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+) *OrderService {
+	return &OrderService{
+		repo:         repo,
+		eventStore:   eventStore,
+		clock:        clock,
+		eventChannel: "app.order",
+		source:       source,
+	}
+}
+
+// CreateOrder opens a draft order of an existing customer. Fabrics may be ordered by an
+// alias, the lines keep their canonical codes.
+func (s *OrderService) CreateOrder(
+	ctx context.Context, customerCode, currency string, lines []domain.OrderLine,
+) (*domain.Order, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "order.service.create")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "order.service")
+
+	if err := s.repo.CheckCustomer(ctx, customerCode); err != nil {
+		return nil, err
+	}
+	lines, err := s.resolveLines(ctx, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := domain.NewOrder(uuid.Must(uuid.NewV7()).String(), customerCode, currency, lines, aggregate.StampNow(ctx, s.clock))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveOrder(ctx, order); err != nil {
+		return nil, s.failed(span, logger, "saving order failed", err)
+	}
+
+	if err := s.publish(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// ChangeLines replaces the lines of a draft order.
+func (s *OrderService) ChangeLines(
+	ctx context.Context, id string, lines []domain.OrderLine, version int,
+) (*domain.Order, error) {
+	lines, err := s.resolveLines(ctx, lines)
+	if err != nil {
+		return nil, err
+	}
+	return s.change(ctx, id, "order.service.change_lines", func(order *domain.Order, stamp domain.Stamp) error {
+		return order.ChangeLines(lines, version, stamp)
+	})
+}
+
+func (s *OrderService) ConfirmOrder(ctx context.Context, id string, version int) (*domain.Order, error) {
+	return s.change(ctx, id, "order.service.confirm", func(order *domain.Order, stamp domain.Stamp) error {
+		return order.Confirm(version, stamp)
+	})
+}
+
+func (s *OrderService) ShipOrder(ctx context.Context, id string, version int) (*domain.Order, error) {
+	return s.change(ctx, id, "order.service.ship", func(order *domain.Order, stamp domain.Stamp) error {
+		return order.Ship(version, stamp)
+	})
+}
+
+func (s *OrderService) CancelOrder(ctx context.Context, id, reason string, version int) (*domain.Order, error) {
+	return s.change(ctx, id, "order.service.cancel", func(order *domain.Order, stamp domain.Stamp) error {
+		return order.Cancel(reason, version, stamp)
+	})
+}
+
+// resolveLines puts the lines under the canonical codes of their fabrics, which have to
+// be active.
+func (s *OrderService) resolveLines(ctx context.Context, lines []domain.OrderLine) ([]domain.OrderLine, error) {
+	resolved := make([]domain.OrderLine, 0, len(lines))
+	for _, line := range lines {
+		code, err := s.repo.ResolveFabricCode(ctx, line.FabricCode)
+		if err != nil {
+			return nil, err
+		}
+		line.FabricCode = code
+		resolved = append(resolved, line)
+	}
+	return resolved, nil
+}
+
+// change loads the order, applies a command to it, stores it and publishes its events.
+func (s *OrderService) change(
+	ctx context.Context, id, spanName string, apply func(order *domain.Order, stamp domain.Stamp) error,
+) (*domain.Order, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, spanName)
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "order.service")
+
+	order, err := s.repo.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(order, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateOrder(ctx, order); err != nil {
+		return nil, s.failed(span, logger, "updating order failed", err)
+	}
+
+	if err := s.publish(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// failed reports a repository write that did not succeed. Order errors are passed
+// through as they are, anything else is wrapped and recorded as a database error.
+func (s *OrderService) failed(span trace.Span, logger *slog.Logger, msg string, err error) error {
+	var orderErr *domain.OrderError
+	if errors.As(err, &orderErr) {
+		return err
+	}
+	wrappedErr := fmt.Errorf("failed to write order in repo: %w", err)
+	logger.Error(msg, "error", wrappedErr)
+	span.RecordError(wrappedErr)
+	span.SetStatus(codes.Error, "database write error")
+	return wrappedErr
+}
+
+func (s *OrderService) publish(ctx context.Context, order *domain.Order) error {
+	logger := httpx.GetLogger(ctx).With("component", "order.service")
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range order.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.OrderCreated:
+			eventType = "app.order.created"
+		case domain.OrderLinesChanged:
+			eventType = "app.order.lines_changed"
+		case domain.OrderConfirmed:
+			eventType = "app.order.confirmed"
+		case domain.OrderShipped:
+			eventType = "app.order.shipped"
+		case domain.OrderCancelled:
+			eventType = "app.order.cancelled"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			order.ID,
+			"Order",
+			order.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelope.UserID = command.Actor(ctx)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopesToPublish...); err != nil {
+			wrappedErr := fmt.Errorf("failed to save order event to event store: %w", err)
+			logger.Error("saving order event failed", "error", wrappedErr)
+			return wrappedErr
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = domain.Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testSource = messaging.Source{Service: "s-works-api", Instance: "test-instance"}
+
+const testOrderID = "0190a6e2-7b1c-7000-8000-000000000001"
+
+type mockOrderRepository struct {
+	orders      map[string]*domain.Order
+	customers   map[string]bool
+	aliases     map[string]string
+	saved       *domain.Order
+	errToReturn error
+}
+
+func (m *mockOrderRepository) SaveOrder(ctx context.Context, order *domain.Order) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.saved = order
+	return nil
+}
+
+func (m *mockOrderRepository) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	order, ok := m.orders[id]
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+	orderCopy := *order
+	return &orderCopy, nil
+}
+
+func (m *mockOrderRepository) ListOrders(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockOrderRepository) UpdateOrder(ctx context.Context, order *domain.Order) error {
+	return m.SaveOrder(ctx, order)
+}
+
+func (m *mockOrderRepository) CheckCustomer(ctx context.Context, code string) error {
+	if !m.customers[code] {
+		return domain.ErrCustomerNotFound
+	}
+	return nil
+}
+
+func (m *mockOrderRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	if canonical, ok := m.aliases[code]; ok {
+		return canonical, nil
+	}
+	return "", domain.ErrFabricNotFound
+}
+
+type mockEventStore struct {
+	SavedCalled      bool
+	EnqueuedSubject  string
+	EnqueuedEnvelope *messaging.EventEnvelope
+}
+
+func (m *mockEventStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
+	m.SavedCalled = true
+	return nil
+}
+
+func (m *mockEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.SavedCalled = true
+	m.EnqueuedSubject = subject
+	m.EnqueuedEnvelope = envelopes[len(envelopes)-1]
+	return nil
+}
+
+func newTestRepository() *mockOrderRepository {
+	order := &domain.Order{
+		ID: testOrderID, CustomerCode: "CUST01", Currency: "PLN", Status: domain.StatusDraft, Version: 1,
+		Lines: []domain.OrderLine{{FabricCode: "VELVET01", Quantity: 12500, UnitPrice: 2490}},
+	}
+	return &mockOrderRepository{
+		orders:    map[string]*domain.Order{order.ID: order},
+		customers: map[string]bool{"CUST01": true},
+		aliases:   map[string]string{"VELVET01": "VELVET01", "OLDVELVET": "VELVET01"},
+	}
+}
+
+func TestOrderService_CreateOrder_ResolvesAlias(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewOrderCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+	lines := []domain.OrderLine{{FabricCode: "OLDVELVET", Quantity: 3000, UnitPrice: 2490}}
+
+	// --- Act ---
+	order, err := service.CreateOrder(context.Background(), "CUST01", "PLN", lines)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, repo.saved, "expected SaveOrder() to be called on the repository")
+	assert.NotEmpty(t, order.ID)
+	assert.Equal(t, domain.StatusDraft, order.Status)
+	assert.Equal(t, "VELVET01", order.Lines[0].FabricCode)
+	assert.Equal(t, "OLDVELVET", lines[0].FabricCode, "the lines given must not be changed")
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.order", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.order.created", publishedEnvelope.EventType)
+	assert.Equal(t, "Order", publishedEnvelope.AggregateType)
+	assert.Equal(t, order.ID, publishedEnvelope.AggregateID)
+}
+
+func TestOrderService_ConfirmOrder(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewOrderCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	order, err := service.ConfirmOrder(context.Background(), testOrderID, 1)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusConfirmed, order.Status)
+	assert.Equal(t, 2, order.Version)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.order.confirmed", publishedEnvelope.EventType)
+	payload, ok := publishedEnvelope.Payload.(domain.OrderConfirmed)
+	require.True(t, ok, "payload should be of type domain.OrderConfirmed")
+	assert.Equal(t, int64(31125), payload.Total)
+}
+
+func TestOrderService_RejectedIsNotPublished(t *testing.T) {
+	testCases := []struct {
+		name        string
+		run         func(s *OrderService) error
+		expectedErr error
+	}{
+		{
+			name: "Unknown customer",
+			run: func(s *OrderService) error {
+				_, err := s.CreateOrder(context.Background(), "NOSUCH", "PLN", nil)
+				return err
+			},
+			expectedErr: domain.ErrCustomerNotFound,
+		},
+		{
+			name: "Unknown fabric",
+			run: func(s *OrderService) error {
+				lines := []domain.OrderLine{{FabricCode: "NOSUCHFABRIC", Quantity: 1000}}
+				_, err := s.ChangeLines(context.Background(), testOrderID, lines, 1)
+				return err
+			},
+			expectedErr: domain.ErrFabricNotFound,
+		},
+		{
+			name: "Stale version",
+			run: func(s *OrderService) error {
+				_, err := s.CancelOrder(context.Background(), testOrderID, "", 2)
+				return err
+			},
+			expectedErr: domain.ErrConcurrencyConflict,
+		},
+		{
+			name: "Draft shipped",
+			run: func(s *OrderService) error {
+				_, err := s.ShipOrder(context.Background(), testOrderID, 1)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			eventStore := &mockEventStore{}
+			service := NewOrderCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err := tc.run(service)
+
+			// --- Assert ---
+			require.Error(t, err)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+			assert.Nil(t, repo.saved)
+			assert.False(t, eventStore.SavedCalled, "a rejected command must not be stored")
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+// Statuses an order moves through. A draft is confirmed and then shipped, and may be
+// cancelled until it is shipped.
+const (
+	StatusDraft     = "DRAFT"
+	StatusConfirmed = "CONFIRMED"
+	StatusShipped   = "SHIPPED"
+	StatusCancelled = "CANCELLED"
+)
+
+var (
+	ErrOrderNotFound       = notFoundError("order not found")
+	ErrCustomerNotFound    = notFoundError("customer not found")
+	ErrFabricNotFound      = notFoundError("fabric not found")
+	ErrConcurrencyConflict = conflictError("the order has been modified by another process, please refresh and try again")
+	ErrOrderNotDraft       = conflictError("only draft orders can be changed")
+	ErrEmptyOrder          = conflictError("an order needs at least one line to be confirmed")
+	ErrDuplicateLine       = invalidError("a fabric can only be on one line of an order")
+	ErrInvalidQuantity     = invalidError("the quantity must be a decimal number greater than 0 with at most 3 decimals")
+	ErrInvalidUnitPrice    = invalidError("the unit price must not be negative")
+)
+
+// OrderError is a rule violation reported by the order domain. Its kind tells the
+// handler which status to answer with and instrumentation how to class it.
+type OrderError struct {
+	Kind    string
+	Message string
+}
+
+func (e *OrderError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *OrderError) ErrorClass() string {
+	return e.Kind
+}
+
+func notFoundError(message string) *OrderError {
+	return &OrderError{Kind: "not_found", Message: message}
+}
+
+func conflictError(message string) *OrderError {
+	return &OrderError{Kind: "conflict", Message: message}
+}
+
+func invalidError(message string) *OrderError {
+	return &OrderError{Kind: "invalid", Message: message}
+}
+
+// transitionError refuses a status change the current status does not allow.
+func transitionError(from, to string) *OrderError {
+	return conflictError(fmt.Sprintf("an order cannot move from %s to %s", from, to))
+}
+
+type Event = aggregate.Event
+
+// Stamp identifies who performed a change on an order and when it happened.
+type Stamp = aggregate.Stamp
+
+// Order is a customer's order of fabrics. Its lines are agreed while it is a draft and are
+// fixed once it is confirmed.
+type Order struct {
+	ID           string      `json:"id"`
+	CustomerCode string      `json:"customer_code"`
+	Currency     string      `json:"currency"`
+	Status       string      `json:"status"`
+	Lines        []OrderLine `json:"lines"`
+	Version      int         `json:"version"`
+	aggregate.Audit
+	aggregate.Root
+}
+
+type OrderCreated struct {
+	ID           string
+	CustomerCode string
+	Currency     string
+	Lines        []OrderLine
+	Version      int
+}
+
+type OrderLinesChanged struct {
+	ID      string
+	Lines   []OrderLine
+	Version int
+}
+
+type OrderConfirmed struct {
+	ID      string
+	Total   int64
+	Version int
+}
+
+type OrderShipped struct {
+	ID      string
+	Version int
+}
+
+type OrderCancelled struct {
+	ID      string
+	Reason  string
+	Version int
+}
+
+// NewOrder opens a draft order of the customer, priced in the given currency.
+func NewOrder(id, customerCode, currency string, lines []OrderLine, stamp Stamp) (*Order, error) {
+	if err := checkLines(lines); err != nil {
+		return nil, err
+	}
+
+	order := &Order{
+		ID:           id,
+		CustomerCode: customerCode,
+		Currency:     currency,
+		Status:       StatusDraft,
+		Lines:        lines,
+		Version:      1,
+		Audit:        aggregate.NewAudit(stamp),
+	}
+
+	event := OrderCreated{
+		ID:           order.ID,
+		CustomerCode: order.CustomerCode,
+		Currency:     order.Currency,
+		Lines:        order.Lines,
+		Version:      order.Version,
+	}
+	order.Record(event)
+	return order, nil
+}
+
+// Total is the value of the order in the minor unit of its currency.
+func (o *Order) Total() int64 {
+	var total int64
+	for _, line := range o.Lines {
+		total += line.Total()
+	}
+	return total
+}
+
+// ChangeLines replaces the lines of a draft order.
+func (o *Order) ChangeLines(lines []OrderLine, version int, stamp Stamp) error {
+	if o.Status != StatusDraft {
+		return ErrOrderNotDraft
+	}
+	if err := aggregate.CheckVersion(o.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+	if err := checkLines(lines); err != nil {
+		return err
+	}
+
+	o.Lines = lines
+	o.Version++
+	o.Touch(stamp)
+
+	event := OrderLinesChanged{
+		ID:      o.ID,
+		Lines:   o.Lines,
+		Version: o.Version,
+	}
+	o.Record(event)
+	return nil
+}
+
+// Confirm fixes the lines of a draft order, which needs at least one.
+func (o *Order) Confirm(version int, stamp Stamp) error {
+	if err := o.transition(StatusConfirmed, version); err != nil {
+		return err
+	}
+	if len(o.Lines) == 0 {
+		return ErrEmptyOrder
+	}
+
+	o.Status = StatusConfirmed
+	o.Version++
+	o.Touch(stamp)
+
+	event := OrderConfirmed{
+		ID:      o.ID,
+		Total:   o.Total(),
+		Version: o.Version,
+	}
+	o.Record(event)
+	return nil
+}
+
+// Ship records that a confirmed order left the warehouse.
+func (o *Order) Ship(version int, stamp Stamp) error {
+	if err := o.transition(StatusShipped, version); err != nil {
+		return err
+	}
+
+	o.Status = StatusShipped
+	o.Version++
+	o.Touch(stamp)
+
+	event := OrderShipped{
+		ID:      o.ID,
+		Version: o.Version,
+	}
+	o.Record(event)
+	return nil
+}
+
+// Cancel calls off an order that has not been shipped yet.
+func (o *Order) Cancel(reason string, version int, stamp Stamp) error {
+	if err := o.transition(StatusCancelled, version); err != nil {
+		return err
+	}
+
+	o.Status = StatusCancelled
+	o.Version++
+	o.Touch(stamp)
+
+	event := OrderCancelled{
+		ID:      o.ID,
+		Reason:  reason,
+		Version: o.Version,
+	}
+	o.Record(event)
+	return nil
+}
+
+// transition checks that the order may move to the status and that the command was made
+// against its current version.
+func (o *Order) transition(to string, version int) error {
+	allowed := false
+	switch to {
+	case StatusConfirmed:
+		allowed = o.Status == StatusDraft
+	case StatusShipped:
+		allowed = o.Status == StatusConfirmed
+	case StatusCancelled:
+		allowed = o.Status == StatusDraft || o.Status == StatusConfirmed
+	}
+	if !allowed {
+		return transitionError(o.Status, to)
+	}
+	return aggregate.CheckVersion(o.Version, version, ErrConcurrencyConflict)
+}
+
+// OrderFilter narrows a listing of orders, empty fields match every order.
+type OrderFilter struct {
+	Status       string
+	CustomerCode string
+	Limit        int
+	Offset       int
+}
+
+type OrderRepository interface {
+	// SaveOrder stores a new order along with its lines.
+	SaveOrder(ctx context.Context, order *Order) error
+	// GetOrder loads an order with its lines, or fails with ErrOrderNotFound.
+	GetOrder(ctx context.Context, id string) (*Order, error)
+	// ListOrders returns a page of the orders matching the filter, the newest first,
+	// together with their total number.
+	ListOrders(ctx context.Context, filter OrderFilter) ([]*Order, int, error)
+	// UpdateOrder stores a change of an order still at the version it was loaded with,
+	// or fails with ErrConcurrencyConflict.
+	UpdateOrder(ctx context.Context, order *Order) error
+	// CheckCustomer fails with ErrCustomerNotFound unless the customer exists and is not
+	// deleted.
+	CheckCustomer(ctx context.Context, code string) error
+	// ResolveFabricCode resolves a fabric code, or one of its aliases, to the canonical
+	// code of an active fabric, or fails with ErrFabricNotFound.
+	ResolveFabricCode(ctx context.Context, code string) (string, error)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "github.com/salesworks/s-works/api/internal/platform/quantity"
+
+// Quantity is an amount of a fabric in its measure unit, in thousandths of the unit.
+type Quantity = quantity.Quantity
+
+// ParseQuantity reads a positive decimal quantity such as "12.5".
+func ParseQuantity(raw string) (Quantity, error) {
+	parsed, err := quantity.Parse(raw)
+	if err != nil || parsed <= 0 {
+		return 0, ErrInvalidQuantity
+	}
+	return parsed, nil
+}
+
+// OrderLine orders a quantity of a fabric, under its canonical code, at a unit price.
+type OrderLine struct {
+	FabricCode string   `json:"fabric_code"`
+	Quantity   Quantity `json:"quantity"`
+	// UnitPrice is the price per measure unit in the minor unit of the order currency,
+	// e.g. grosze for PLN.
+	UnitPrice int64 `json:"unit_price"`
+}
+
+// Total is the value of the line in the minor unit of the order currency, rounded half up
+// to the minor unit.
+func (l OrderLine) Total() int64 {
+	return (int64(l.Quantity)*l.UnitPrice + 500) / 1000
+}
+
+// checkLines holds the lines of an order to its rules: positive quantities, no negative
+// prices and each fabric on a single line.
+func checkLines(lines []OrderLine) error {
+	seen := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return ErrInvalidQuantity
+		}
+		if line.UnitPrice < 0 {
+			return ErrInvalidUnitPrice
+		}
+		if seen[line.FabricCode] {
+			return ErrDuplicateLine
+		}
+		seen[line.FabricCode] = true
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testLines = []OrderLine{
+	{FabricCode: "VELVET01", Quantity: 12500, UnitPrice: 2490},
+	{FabricCode: "LINEN02", Quantity: 3000, UnitPrice: 1599},
+}
+
+func newTestOrder(t *testing.T) *Order {
+	t.Helper()
+
+	order, err := NewOrder("0190a6e2-7b1c-7000-8000-000000000001", "CUST01", "PLN", testLines, testStamp)
+	require.NoError(t, err)
+	return order
+}
+
+func TestNewOrder(t *testing.T) {
+	// --- Act ---
+	order := newTestOrder(t)
+
+	// --- Assert ---
+	assert.Equal(t, StatusDraft, order.Status)
+	assert.Equal(t, 1, order.Version)
+	assert.Equal(t, int64(31125+4797), order.Total())
+	require.Len(t, order.Events(), 1)
+	assert.IsType(t, OrderCreated{}, order.Events()[0])
+}
+
+func TestNewOrder_InvalidLines(t *testing.T) {
+	testCases := []struct {
+		name        string
+		lines       []OrderLine
+		expectedErr error
+	}{
+		{name: "Zero quantity", lines: []OrderLine{{FabricCode: "VELVET01", UnitPrice: 2490}}, expectedErr: ErrInvalidQuantity},
+		{
+			name: "Negative price", lines: []OrderLine{{FabricCode: "VELVET01", Quantity: 1000, UnitPrice: -1}},
+			expectedErr: ErrInvalidUnitPrice,
+		},
+		{
+			name: "Fabric twice", lines: []OrderLine{
+				{FabricCode: "VELVET01", Quantity: 1000}, {FabricCode: "VELVET01", Quantity: 2000},
+			},
+			expectedErr: ErrDuplicateLine,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			order, err := NewOrder("0190a6e2-7b1c-7000-8000-000000000001", "CUST01", "PLN", tc.lines, testStamp)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, order)
+		})
+	}
+}
+
+func TestOrder_Transitions(t *testing.T) {
+	testCases := []struct {
+		name           string
+		status         string
+		apply          func(o *Order) error
+		expectedStatus string
+		expectedErr    bool
+	}{
+		{name: "Draft is confirmed", status: StatusDraft, apply: confirm, expectedStatus: StatusConfirmed},
+		{name: "Confirmed is shipped", status: StatusConfirmed, apply: ship, expectedStatus: StatusShipped},
+		{name: "Draft is cancelled", status: StatusDraft, apply: cancel, expectedStatus: StatusCancelled},
+		{name: "Confirmed is cancelled", status: StatusConfirmed, apply: cancel, expectedStatus: StatusCancelled},
+		{name: "Draft cannot be shipped", status: StatusDraft, apply: ship, expectedErr: true},
+		{name: "Shipped cannot be cancelled", status: StatusShipped, apply: cancel, expectedErr: true},
+		{name: "Cancelled cannot be confirmed", status: StatusCancelled, apply: confirm, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			order := newTestOrder(t)
+			order.Status = tc.status
+
+			// --- Act ---
+			err := tc.apply(order)
+
+			// --- Assert ---
+			if tc.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.status, order.Status)
+				assert.Len(t, order.Events(), 1, "a rejected transition must not record an event")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, order.Status)
+			assert.Equal(t, 2, order.Version)
+			assert.Len(t, order.Events(), 2)
+		})
+	}
+}
+
+func confirm(o *Order) error { return o.Confirm(1, testStamp) }
+
+func ship(o *Order) error { return o.Ship(1, testStamp) }
+
+func cancel(o *Order) error { return o.Cancel("customer withdrew", 1, testStamp) }
+
+func TestOrder_Confirm_Empty(t *testing.T) {
+	// --- Arrange ---
+	order, err := NewOrder("0190a6e2-7b1c-7000-8000-000000000001", "CUST01", "PLN", nil, testStamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	err = order.Confirm(1, testStamp)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, ErrEmptyOrder)
+	assert.Equal(t, StatusDraft, order.Status)
+}
+
+func TestOrder_ChangeLines(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      string
+		version     int
+		expectedErr error
+	}{
+		{name: "Draft at current version", status: StatusDraft, version: 1},
+		{name: "Stale version", status: StatusDraft, version: 2, expectedErr: ErrConcurrencyConflict},
+		{name: "Confirmed order", status: StatusConfirmed, version: 1, expectedErr: ErrOrderNotDraft},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			order := newTestOrder(t)
+			order.Status = tc.status
+			lines := []OrderLine{{FabricCode: "VELVET01", Quantity: 1000, UnitPrice: 2490}}
+
+			// --- Act ---
+			err := order.ChangeLines(lines, tc.version, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Len(t, order.Lines, 2)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, lines, order.Lines)
+			assert.Equal(t, OrderLinesChanged{ID: order.ID, Lines: lines, Version: 2}, order.Events()[1])
+		})
+	}
+}
+
+func TestParseQuantity(t *testing.T) {
+	testCases := []struct {
+		raw         string
+		expected    Quantity
+		expectedErr bool
+	}{
+		{raw: "12.5", expected: 12500},
+		{raw: "3", expected: 3000},
+		{raw: "0.001", expected: 1},
+		{raw: "0", expectedErr: true},
+		{raw: "-2", expectedErr: true},
+		{raw: "1.2345", expectedErr: true},
+		{raw: "", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.raw, func(t *testing.T) {
+			// --- Act ---
+			quantity, err := ParseQuantity(tc.raw)
+
+			// --- Assert ---
+			if tc.expectedErr {
+				assert.ErrorIs(t, err, ErrInvalidQuantity)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, quantity)
+			assert.Equal(t, tc.raw, quantity.String())
+		})
+	}
+}
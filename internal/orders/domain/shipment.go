@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Statuses of a shipment, as the ERP reports them.
+const (
+	ShipmentPending   = "PENDING"
+	ShipmentInTransit = "IN_TRANSIT"
+	ShipmentDelivered = "DELIVERED"
+	ShipmentReturned  = "RETURNED"
+)
+
+// Shipment is a delivery of fabrics of an order, as the ERP reports it. The ERP owns the
+// shipments, they are a read model kept up to date from its events and never changed
+// through the API. An order may be delivered in several shipments.
+type Shipment struct {
+	ID             string         `json:"id"`
+	OrderID        string         `json:"order_id"`
+	Status         string         `json:"status"`
+	Carrier        string         `json:"carrier,omitempty"`
+	TrackingNumber string         `json:"tracking_number,omitempty"`
+	ShippedAt      *time.Time     `json:"shipped_at,omitempty"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+	Lines          []ShipmentLine `json:"lines"`
+	// Version is the version of the shipment in the ERP, it orders its events.
+	Version int `json:"version"`
+	// UpdatedAt is when the ERP last changed the shipment.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ShipmentLine is a quantity of a fabric in a shipment, under the code the ERP sent.
+type ShipmentLine struct {
+	FabricCode string   `json:"fabric_code"`
+	Quantity   Quantity `json:"quantity"`
+}
+
+type ShipmentRepository interface {
+	// SaveShipment stores the shipment with its lines, unless the stored one is at the same
+	// or a later version, and reports whether it did. It fails with ErrOrderNotFound when
+	// the order of the shipment is not known.
+	SaveShipment(ctx context.Context, shipment *Shipment) (bool, error)
+	// DeleteShipment removes the shipment, unless it is at the same or a later version than
+	// the deletion, and reports whether it did. Deleted shipments are kept, so late events
+	// do not bring them back.
+	DeleteShipment(ctx context.Context, id string, version int, at time.Time) (bool, error)
+	// ListShipments returns the shipments of the order that are not deleted, the earliest
+	// shipped first.
+	ListShipments(ctx context.Context, orderID string) ([]*Shipment, error)
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+var currencyRX = regexp.MustCompile("^[A-Z]{3}$")
+
+const (
+	orderActionConfirm = "confirm"
+	orderActionShip    = "ship"
+	orderActionCancel  = "cancel"
+)
+
+// maxOrderLines caps the lines of a single order.
+const maxOrderLines = 200
+
+// OrderCommandService takes orders through their statuses.
+type OrderCommandService interface {
+	CreateOrder(ctx context.Context, customerCode, currency string, lines []domain.OrderLine) (*domain.Order, error)
+	ChangeLines(ctx context.Context, id string, lines []domain.OrderLine, version int) (*domain.Order, error)
+	ConfirmOrder(ctx context.Context, id string, version int) (*domain.Order, error)
+	ShipOrder(ctx context.Context, id string, version int) (*domain.Order, error)
+	CancelOrder(ctx context.Context, id, reason string, version int) (*domain.Order, error)
+}
+
+// OrderCommandHandler opens orders, changes the lines of drafts and confirms, ships and
+// cancels orders. A transition the current status does not allow is answered with a
+// conflict.
+type OrderCommandHandler struct {
+	service OrderCommandService
+}
+
+// the quantity is a decimal string, so no precision is lost on the way
+type orderLineRequest struct {
+	FabricCode string `json:"fabric_code"`
+	Quantity   string `json:"quantity"`
+	UnitPrice  *int64 `json:"unit_price"`
+}
+
+type createOrderRequest struct {
+	CustomerCode string             `json:"customer_code"`
+	Currency     string             `json:"currency"`
+	Lines        []orderLineRequest `json:"lines"`
+}
+
+type changeLinesRequest struct {
+	Lines   []orderLineRequest `json:"lines"`
+	Version int                `json:"version"`
+}
+
+type changeOrderStatusRequest struct {
+	Reason  string `json:"reason"`
+	Version int    `json:"version"`
+}
+
+func NewOrderCommandHandler(service OrderCommandService) *OrderCommandHandler {
+	return &OrderCommandHandler{service: service}
+}
+
+func (h *OrderCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+	r = r.WithContext(ctx)
+
+	switch {
+	case r.Method == http.MethodPost && httpx.URLParam(r, "id") == "":
+		h.createOrder(w, r)
+	case r.Method == http.MethodPut:
+		h.changeLines(w, r)
+	case r.Method == http.MethodPost:
+		h.changeStatus(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *OrderCommandHandler) createOrder(w http.ResponseWriter, r *http.Request) {
+	var req createOrderRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.CustomerCode = validator.NormalizeCode(req.CustomerCode)
+	req.Currency = validator.NormalizeCode(req.Currency)
+	v := validator.New()
+	v.Check(req.CustomerCode != "", "customer_code", "customer_code must be provided")
+	v.Check(validator.Matches(req.Currency, currencyRX), "currency", "currency must be a three-letter ISO 4217 code")
+	lines := readLines(v, req.Lines)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	order, err := h.service.CreateOrder(r.Context(), req.CustomerCode, req.Currency, lines)
+	if err != nil {
+		writeOrderError(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", "/v1/orders/"+order.ID)
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"order": order}, headers); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *OrderCommandHandler) changeLines(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.ReadIDParam(r)
+	if err != nil {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	var req changeLinesRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	lines := readLines(v, req.Lines)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	order, err := h.service.ChangeLines(r.Context(), id.String(), lines, req.Version)
+	if err != nil {
+		writeOrderError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"order": order}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *OrderCommandHandler) changeStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.ReadIDParam(r)
+	action := httpx.URLParam(r, "action")
+	if err != nil || !validator.PermittedValue(action, orderActionConfirm, orderActionShip, orderActionCancel) {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	var req changeOrderStatusRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Reason = validator.NormalizeText(req.Reason)
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	v.Check(len(req.Reason) <= 500, "reason", "reason must not be more than 500 characters long")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	var order *domain.Order
+	switch action {
+	case orderActionConfirm:
+		order, err = h.service.ConfirmOrder(r.Context(), id.String(), req.Version)
+	case orderActionShip:
+		order, err = h.service.ShipOrder(r.Context(), id.String(), req.Version)
+	case orderActionCancel:
+		order, err = h.service.CancelOrder(r.Context(), id.String(), req.Reason, req.Version)
+	}
+	if err != nil {
+		writeOrderError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"order": order}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// readLines checks the requested lines and turns them into order lines, reporting every
+// invalid field under its line.
+func readLines(v *validator.Validator, requested []orderLineRequest) []domain.OrderLine {
+	v.Check(len(requested) <= maxOrderLines, "lines", fmt.Sprintf("an order must not have more than %d lines", maxOrderLines))
+
+	lines := make([]domain.OrderLine, 0, len(requested))
+	for i, req := range requested {
+		field := fmt.Sprintf("lines[%d]", i)
+		line := domain.OrderLine{FabricCode: validator.NormalizeCode(req.FabricCode)}
+		v.Check(line.FabricCode != "", field+".fabric_code", "fabric_code must be provided")
+
+		quantity, err := domain.ParseQuantity(validator.NormalizeText(req.Quantity))
+		v.Check(err == nil, field+".quantity", "quantity must be a decimal number greater than 0 with at most 3 decimals")
+		line.Quantity = quantity
+
+		v.Check(req.UnitPrice != nil, field+".unit_price", "unit_price must be provided")
+		if req.UnitPrice != nil {
+			v.Check(*req.UnitPrice >= 0, field+".unit_price", "unit_price must not be negative")
+			line.UnitPrice = *req.UnitPrice
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// writeOrderError answers a failed command with the status matching the kind of order
+// error, anything that is not an order error is answered as an internal error.
+func writeOrderError(w http.ResponseWriter, r *http.Request, err error) {
+	var orderErr *domain.OrderError
+	if !errors.As(err, &orderErr) {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch orderErr.Kind {
+	case "not_found":
+		httpx.NotFound(w, r)
+	case "invalid":
+		httpx.ErrorJSON(w, http.StatusUnprocessableEntity, orderErr.Message)
+	default:
+		httpx.ErrorJSON(w, http.StatusConflict, orderErr.Message)
+	}
+}
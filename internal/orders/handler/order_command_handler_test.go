@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOrderID = "0190a6e2-7b1c-7000-8000-000000000001"
+
+type mockOrderCommandService struct {
+	called       string
+	id           string
+	customerCode string
+	currency     string
+	lines        []domain.OrderLine
+	reason       string
+	version      int
+	errToReturn  error
+}
+
+func (m *mockOrderCommandService) CreateOrder(
+	ctx context.Context, customerCode, currency string, lines []domain.OrderLine,
+) (*domain.Order, error) {
+	m.called, m.customerCode, m.currency, m.lines = "create", customerCode, currency, lines
+	return m.result(testOrderID, 1)
+}
+
+func (m *mockOrderCommandService) ChangeLines(
+	ctx context.Context, id string, lines []domain.OrderLine, version int,
+) (*domain.Order, error) {
+	m.called, m.id, m.lines, m.version = "lines", id, lines, version
+	return m.result(id, version+1)
+}
+
+func (m *mockOrderCommandService) ConfirmOrder(ctx context.Context, id string, version int) (*domain.Order, error) {
+	m.called, m.id, m.version = "confirm", id, version
+	return m.result(id, version+1)
+}
+
+func (m *mockOrderCommandService) ShipOrder(ctx context.Context, id string, version int) (*domain.Order, error) {
+	m.called, m.id, m.version = "ship", id, version
+	return m.result(id, version+1)
+}
+
+func (m *mockOrderCommandService) CancelOrder(ctx context.Context, id, reason string, version int) (*domain.Order, error) {
+	m.called, m.id, m.reason, m.version = "cancel", id, reason, version
+	return m.result(id, version+1)
+}
+
+func (m *mockOrderCommandService) result(id string, version int) (*domain.Order, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Order{ID: id, Version: version}, nil
+}
+
+func serveOrder(
+	t *testing.T, handler http.Handler, method, target, body string, params map[string]string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestOrderCommandHandler_CreateOrder(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockOrderCommandService{}
+	handler := NewOrderCommandHandler(svc)
+	body := `{"customer_code": " cust01 ", "currency": "pln", "lines": [
+		{"fabric_code": "velvet01", "quantity": "12.5", "unit_price": 2490}
+	]}`
+
+	// --- Act ---
+	responseRecorder := serveOrder(t, handler, http.MethodPost, "/v1/orders", body, nil)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, "/v1/orders/"+testOrderID, responseRecorder.Header().Get("Location"))
+	assert.Equal(t, "CUST01", svc.customerCode)
+	assert.Equal(t, "PLN", svc.currency)
+	assert.Equal(t, []domain.OrderLine{{FabricCode: "VELVET01", Quantity: 12500, UnitPrice: 2490}}, svc.lines)
+}
+
+func TestOrderCommandHandler_ChangeStatus(t *testing.T) {
+	testCases := []struct {
+		action       string
+		expectedCall string
+	}{
+		{action: "confirm", expectedCall: "confirm"},
+		{action: "ship", expectedCall: "ship"},
+		{action: "cancel", expectedCall: "cancel"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.action, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockOrderCommandService{}
+			handler := NewOrderCommandHandler(svc)
+			params := map[string]string{"id": testOrderID, "action": tc.action}
+
+			// --- Act ---
+			responseRecorder := serveOrder(
+				t, handler, http.MethodPost, "/v1/orders/"+testOrderID+"/"+tc.action, `{"version": 3}`, params,
+			)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called)
+			assert.Equal(t, testOrderID, svc.id)
+			assert.Equal(t, 3, svc.version)
+		})
+	}
+}
+
+func TestOrderCommandHandler_Rejected(t *testing.T) {
+	order := map[string]string{"id": testOrderID}
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		params         map[string]string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{
+			name: "invalid currency", method: http.MethodPost, body: `{"customer_code": "CUST01", "currency": "zloty"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "invalid quantity", method: http.MethodPost,
+			body:           `{"customer_code": "CUST01", "currency": "PLN", "lines": [{"fabric_code": "VELVET01", "quantity": "1.2345", "unit_price": 1}]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing unit price", method: http.MethodPut, body: `{"version": 1, "lines": [{"fabric_code": "VELVET01", "quantity": "1"}]}`,
+			params: order, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "unknown action", method: http.MethodPost, body: `{"version": 1}`,
+			params: map[string]string{"id": testOrderID, "action": "refund"}, expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "invalid id", method: http.MethodPost, body: `{"version": 1}`,
+			params: map[string]string{"id": "ORDER1", "action": "confirm"}, expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "unknown customer", method: http.MethodPost, body: `{"customer_code": "NOSUCH", "currency": "PLN"}`,
+			errToReturn: domain.ErrCustomerNotFound, expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+		{
+			name: "fabric on two lines", method: http.MethodPut,
+			body:        `{"version": 1, "lines": [{"fabric_code": "VELVET01", "quantity": "1", "unit_price": 1}]}`,
+			params:      order,
+			errToReturn: domain.ErrDuplicateLine, expectedStatus: http.StatusUnprocessableEntity, expectedCall: true,
+		},
+		{
+			name: "confirmed order changed", method: http.MethodPut, body: `{"version": 1, "lines": []}`,
+			params:      order,
+			errToReturn: domain.ErrOrderNotDraft, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockOrderCommandService{errToReturn: tc.errToReturn}
+			handler := NewOrderCommandHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveOrder(t, handler, tc.method, "/v1/orders", tc.body, tc.params)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called != "")
+		})
+	}
+}
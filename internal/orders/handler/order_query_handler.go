@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// OrderQueryRepository reads the orders.
+type OrderQueryRepository interface {
+	GetOrder(ctx context.Context, id string) (*domain.Order, error)
+	ListOrders(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, int, error)
+}
+
+// ShipmentQueryRepository reads the shipments of orders.
+type ShipmentQueryRepository interface {
+	ListShipments(ctx context.Context, orderID string) ([]*domain.Shipment, error)
+}
+
+// OrderQueryHandler serves the orders, the newest first, optionally of one status or
+// customer, and the shipments the ERP reported for an order.
+type OrderQueryHandler struct {
+	orders     OrderQueryRepository
+	shipments  ShipmentQueryRepository
+	pagination httpx.PaginationConfig
+}
+
+func NewOrderQueryHandler(
+	orders OrderQueryRepository, shipments ShipmentQueryRepository, pagination httpx.PaginationConfig,
+) *OrderQueryHandler {
+	return &OrderQueryHandler{
+		orders:     orders,
+		shipments:  shipments,
+		pagination: pagination,
+	}
+}
+
+func (h *OrderQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	if httpx.URLParam(r, "id") == "" {
+		h.listOrders(w, r)
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, "/shipments") {
+		h.listShipments(w, r)
+		return
+	}
+	h.getOrder(w, r)
+}
+
+func (h *OrderQueryHandler) listOrders(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.OrderFilter{
+		Status:       validator.NormalizeCode(query.Get("status")),
+		CustomerCode: validator.NormalizeCode(query.Get("customer_code")),
+	}
+
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if filter.Status != "" {
+		v.Check(validator.PermittedValue(filter.Status,
+			domain.StatusDraft, domain.StatusConfirmed, domain.StatusShipped, domain.StatusCancelled,
+		), "status", "status must be one of DRAFT, CONFIRMED, SHIPPED or CANCELLED")
+	}
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+	filter.Limit, filter.Offset = page.Limit(), page.Offset()
+
+	orders, totalRecords, err := h.orders.ListOrders(r.Context(), filter)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"orders": orders, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *OrderQueryHandler) getOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.ReadIDParam(r)
+	if err != nil {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	order, err := h.orders.GetOrder(r.Context(), id.String())
+	if err != nil {
+		writeOrderError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"order": order, "total": order.Total()}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// listShipments serves the shipments of an order, which has to exist.
+func (h *OrderQueryHandler) listShipments(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.ReadIDParam(r)
+	if err != nil {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	if _, err := h.orders.GetOrder(r.Context(), id.String()); err != nil {
+		writeOrderError(w, r, err)
+		return
+	}
+	shipments, err := h.shipments.ListShipments(r.Context(), id.String())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"shipments": shipments}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOrderQueryRepository struct {
+	filter domain.OrderFilter
+}
+
+func (m *mockOrderQueryRepository) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	if id != testOrderID {
+		return nil, domain.ErrOrderNotFound
+	}
+	return &domain.Order{
+		ID: id, CustomerCode: "CUST01", Currency: "PLN", Status: domain.StatusDraft, Version: 1,
+		Lines: []domain.OrderLine{{FabricCode: "VELVET01", Quantity: 12500, UnitPrice: 2490}},
+	}, nil
+}
+
+func (m *mockOrderQueryRepository) ListOrders(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, int, error) {
+	m.filter = filter
+	return []*domain.Order{{ID: testOrderID, Status: domain.StatusDraft, Version: 1}}, 21, nil
+}
+
+func TestOrderQueryHandler_GetOrder(t *testing.T) {
+	// --- Arrange ---
+	handler := NewOrderQueryHandler(&mockOrderQueryRepository{}, &mockShipmentRepository{}, httpx.PaginationConfig{})
+
+	// --- Act ---
+	responseRecorder := serveOrder(
+		t, handler, http.MethodGet, "/v1/orders/"+testOrderID, "", map[string]string{"id": testOrderID},
+	)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	var body struct {
+		Order struct {
+			ID    string `json:"id"`
+			Lines []struct {
+				FabricCode string `json:"fabric_code"`
+				Quantity   string `json:"quantity"`
+				UnitPrice  int64  `json:"unit_price"`
+			} `json:"lines"`
+		} `json:"order"`
+		Total int64 `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	assert.Equal(t, testOrderID, body.Order.ID)
+	require.Len(t, body.Order.Lines, 1)
+	assert.Equal(t, "12.5", body.Order.Lines[0].Quantity)
+	assert.Equal(t, int64(31125), body.Total)
+}
+
+func TestOrderQueryHandler_ListOrders(t *testing.T) {
+	testCases := []struct {
+		name           string
+		target         string
+		expectedStatus int
+		expectedFilter domain.OrderFilter
+	}{
+		{
+			name: "filtered", target: "/v1/orders?status=confirmed&customer_code=cust01&page=2", expectedStatus: http.StatusOK,
+			expectedFilter: domain.OrderFilter{Status: domain.StatusConfirmed, CustomerCode: "CUST01", Limit: 20, Offset: 20},
+		},
+		{name: "unknown status", target: "/v1/orders?status=lost", expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := &mockOrderQueryRepository{}
+			pagination := httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+			handler := NewOrderQueryHandler(repo, &mockShipmentRepository{}, pagination)
+
+			// --- Act ---
+			responseRecorder := serveOrder(t, handler, http.MethodGet, tc.target, "", nil)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedFilter, repo.filter)
+		})
+	}
+}
+
+func TestOrderQueryHandler_ListShipments(t *testing.T) {
+	testCases := []struct {
+		name           string
+		id             string
+		expectedStatus int
+	}{
+		{name: "known order", id: testOrderID, expectedStatus: http.StatusOK},
+		{name: "unknown order", id: "0190a6e2-7b1c-7000-8000-000000000099", expectedStatus: http.StatusNotFound},
+		{name: "malformed id", id: "not-a-uuid", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			shipments := &mockShipmentRepository{}
+			handler := NewOrderQueryHandler(&mockOrderQueryRepository{}, shipments, httpx.PaginationConfig{})
+
+			// --- Act ---
+			responseRecorder := serveOrder(
+				t, handler, http.MethodGet, "/v1/orders/"+tc.id+"/shipments", "", map[string]string{"id": tc.id},
+			)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Shipments []struct {
+					ID     string `json:"id"`
+					Status string `json:"status"`
+					Lines  []struct {
+						Quantity string `json:"quantity"`
+					} `json:"lines"`
+				} `json:"shipments"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+			assert.Equal(t, testOrderID, shipments.listedOrderID)
+			require.Len(t, body.Shipments, 1)
+			assert.Equal(t, "WZ/2025/0001", body.Shipments[0].ID)
+			assert.Equal(t, "5", body.Shipments[0].Lines[0].Quantity)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+const (
+	erpShipmentCreated = "erp.shipment.created"
+	erpShipmentUpdated = "erp.shipment.updated"
+	erpShipmentDeleted = "erp.shipment.deleted"
+)
+
+// ShipmentProjection keeps the shipments reported by the ERP.
+type ShipmentProjection interface {
+	SaveShipment(ctx context.Context, shipment *domain.Shipment) (bool, error)
+	DeleteShipment(ctx context.Context, id string, version int, at time.Time) (bool, error)
+}
+
+// ShipmentEventHandler keeps the shipments of orders from the events the ERP publishes.
+// Events are ordered by the version of the shipment in the ERP, re-sent and late ones
+// change nothing. It implements the subscriber.MessageHandler interface.
+type ShipmentEventHandler struct {
+	shipments ShipmentProjection
+	logger    *slog.Logger
+}
+
+type erpShipmentEvent struct {
+	ID             string     `json:"shipment_id"`
+	OrderID        string     `json:"order_id"`
+	Status         string     `json:"status"`
+	Carrier        string     `json:"carrier,omitempty"`
+	TrackingNumber string     `json:"tracking_number,omitempty"`
+	ShippedAt      *time.Time `json:"shipped_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	Lines          []struct {
+		FabricCode string          `json:"fabric_code"`
+		Quantity   domain.Quantity `json:"quantity"`
+	} `json:"lines"`
+}
+
+func NewShipmentEventHandler(shipments ShipmentProjection, logger *slog.Logger) *ShipmentEventHandler {
+	return &ShipmentEventHandler{
+		shipments: shipments,
+		logger:    logger.With("component", "erpShipmentEventHandler"),
+	}
+}
+
+// HandleMessage is the entry point called by the NatsSubscriber. Malformed and invalid
+// events are logged and dropped, infrastructure errors are returned to be retried.
+func (h *ShipmentEventHandler) HandleMessage(ctx context.Context, subject string, payload []byte) error {
+	var envelope messaging.EventEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		h.logger.Error("Failed to unmarshal event envelope", "error", err, "subject", subject)
+		return nil
+	}
+	if err := envelope.Validate(); err != nil {
+		h.logger.Error("Invalid event envelope", "error", err, "subject", subject)
+		return nil
+	}
+
+	event, err := decodeERPShipmentEvent(envelope)
+	if err != nil {
+		h.logger.Error("Failed to decode ERP event payload", "error", err, "event_id", envelope.EventID)
+		return nil
+	}
+
+	switch envelope.EventType {
+	case erpShipmentCreated, erpShipmentUpdated:
+		return h.handleSave(ctx, event, envelope)
+	case erpShipmentDeleted:
+		return h.handleDelete(ctx, event, envelope)
+	default:
+		h.logger.Warn("Received unknown ERP event, discarding", "type", envelope.EventType)
+		return nil
+	}
+}
+
+// extracts the ERP shipment payload from an envelope
+func decodeERPShipmentEvent(envelope messaging.EventEnvelope) (erpShipmentEvent, error) {
+	var event erpShipmentEvent
+
+	payloadBytes, err := json.Marshal(envelope.Payload)
+	if err != nil {
+		return event, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	if err := json.Unmarshal(payloadBytes, &event); err != nil {
+		return event, fmt.Errorf("failed to unmarshal payload to erpShipmentEvent: %w", err)
+	}
+	event.ID = strings.TrimSpace(event.ID)
+	event.Status = validator.NormalizeCode(event.Status)
+	return event, nil
+}
+
+func (h *ShipmentEventHandler) handleSave(
+	ctx context.Context, event erpShipmentEvent, envelope messaging.EventEnvelope,
+) error {
+	shipment := &domain.Shipment{
+		ID:             event.ID,
+		OrderID:        strings.TrimSpace(event.OrderID),
+		Status:         event.Status,
+		Carrier:        validator.NormalizeText(event.Carrier),
+		TrackingNumber: strings.TrimSpace(event.TrackingNumber),
+		ShippedAt:      event.ShippedAt,
+		DeliveredAt:    event.DeliveredAt,
+		Lines:          make([]domain.ShipmentLine, 0, len(event.Lines)),
+		Version:        envelope.AggregateVersion,
+		UpdatedAt:      envelope.Timestamp,
+	}
+	for _, line := range event.Lines {
+		shipment.Lines = append(shipment.Lines, domain.ShipmentLine{
+			FabricCode: validator.NormalizeCode(line.FabricCode), Quantity: line.Quantity,
+		})
+	}
+
+	v := validator.New()
+	validateShipment(v, shipment)
+	if !v.Valid() {
+		h.logger.Error("Invalid shipment data from ERP event", "errors", v.Errors, "shipment_id", event.ID, "event_id", envelope.EventID)
+		return nil // Don't retry validation errors
+	}
+
+	changed, err := h.shipments.SaveShipment(ctx, shipment)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			h.logger.Error("ERP shipment of an unknown order", "shipment_id", event.ID, "order_id", shipment.OrderID, "event_id", envelope.EventID)
+			return nil
+		}
+		h.logger.Error("Failed to save shipment", "error", err, "shipment_id", event.ID, "event_id", envelope.EventID)
+		return err // Retry infrastructure errors
+	}
+
+	h.logger.Info("Shipment synced from event", "shipment_id", event.ID, "changed", changed, "event_id", envelope.EventID)
+	return nil
+}
+
+func (h *ShipmentEventHandler) handleDelete(
+	ctx context.Context, event erpShipmentEvent, envelope messaging.EventEnvelope,
+) error {
+	if event.ID == "" {
+		h.logger.Error("ERP shipment deletion without an id", "event_id", envelope.EventID)
+		return nil
+	}
+
+	changed, err := h.shipments.DeleteShipment(ctx, event.ID, envelope.AggregateVersion, envelope.Timestamp)
+	if err != nil {
+		h.logger.Error("Failed to delete shipment", "error", err, "shipment_id", event.ID, "event_id", envelope.EventID)
+		return err // Retry infrastructure errors
+	}
+
+	h.logger.Info("Shipment deleted from event", "shipment_id", event.ID, "changed", changed, "event_id", envelope.EventID)
+	return nil
+}
+
+func validateShipment(v *validator.Validator, shipment *domain.Shipment) {
+	v.Check(shipment.ID != "", "shipment_id", "shipment_id must be provided")
+	v.Check(len(shipment.ID) <= 50, "shipment_id", "shipment_id must not be more than 50 characters long")
+	_, err := uuid.Parse(shipment.OrderID)
+	v.Check(err == nil, "order_id", "order_id must be a valid UUID")
+	v.Check(validator.PermittedValue(shipment.Status,
+		domain.ShipmentPending, domain.ShipmentInTransit, domain.ShipmentDelivered, domain.ShipmentReturned,
+	), "status", "status must be one of PENDING, IN_TRANSIT, DELIVERED or RETURNED")
+	v.Check(len(shipment.Carrier) <= 100, "carrier", "carrier must not be more than 100 characters long")
+	v.Check(len(shipment.TrackingNumber) <= 100, "tracking_number", "tracking_number must not be more than 100 characters long")
+	v.Check(shipment.Version > 0, "version", "the aggregate version must be greater than 0")
+	for _, line := range shipment.Lines {
+		v.Check(line.FabricCode != "", "lines", "every line must have a fabric_code")
+		v.Check(line.Quantity > 0, "lines", "every line must have a quantity greater than 0")
+	}
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockShipmentRepository struct {
+	called        string
+	saved         *domain.Shipment
+	deletedID     string
+	version       int
+	listedOrderID string
+	errToReturn   error
+}
+
+func (m *mockShipmentRepository) SaveShipment(ctx context.Context, shipment *domain.Shipment) (bool, error) {
+	m.called, m.saved = "save", shipment
+	return m.errToReturn == nil, m.errToReturn
+}
+
+func (m *mockShipmentRepository) DeleteShipment(ctx context.Context, id string, version int, at time.Time) (bool, error) {
+	m.called, m.deletedID, m.version = "delete", id, version
+	return m.errToReturn == nil, m.errToReturn
+}
+
+func (m *mockShipmentRepository) ListShipments(ctx context.Context, orderID string) ([]*domain.Shipment, error) {
+	m.listedOrderID = orderID
+	return []*domain.Shipment{{
+		ID: "WZ/2025/0001", OrderID: orderID, Status: domain.ShipmentInTransit, Version: 2,
+		Lines: []domain.ShipmentLine{{FabricCode: "VELVET01", Quantity: 5000}},
+	}}, nil
+}
+
+func erpShipmentMessage(t *testing.T, eventType string, version int, data map[string]any) []byte {
+	t.Helper()
+
+	envelope := messaging.NewEventEnvelope(eventType, "WZ/2025/0001", "Shipment", version, data)
+	payload, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestShipmentEventHandler_HandleMessage(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+	valid := map[string]any{
+		"shipment_id": " WZ/2025/0001 ", "order_id": testOrderID, "status": "in_transit", "carrier": "DHL",
+		"tracking_number": "JD0002", "shipped_at": "2025-01-02T08:00:00Z",
+		"lines": []map[string]string{{"fabric_code": " velvet01 ", "quantity": "5"}},
+	}
+	withStatus := func(status string) map[string]any {
+		data := map[string]any{}
+		for key, value := range valid {
+			data[key] = value
+		}
+		data["status"] = status
+		return data
+	}
+
+	testCases := []struct {
+		name         string
+		eventType    string
+		data         map[string]any
+		errToReturn  error
+		expectedCall string
+		expectedErr  error
+	}{
+		{name: "created", eventType: erpShipmentCreated, data: valid, expectedCall: "save"},
+		{name: "updated", eventType: erpShipmentUpdated, data: valid, expectedCall: "save"},
+		{name: "deleted", eventType: erpShipmentDeleted, data: map[string]any{"shipment_id": "WZ/2025/0001"}, expectedCall: "delete"},
+		{name: "unknown status is dropped", eventType: erpShipmentUpdated, data: withStatus("LOST")},
+		{name: "unknown type is dropped", eventType: "erp.shipment.split", data: valid},
+		{
+			name: "unknown order is dropped", eventType: erpShipmentCreated, data: valid,
+			errToReturn: domain.ErrOrderNotFound, expectedCall: "save",
+		},
+		{
+			name: "infrastructure error is retried", eventType: erpShipmentUpdated, data: valid,
+			errToReturn: errDatabase, expectedCall: "save", expectedErr: errDatabase,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := &mockShipmentRepository{errToReturn: tc.errToReturn}
+			handler := NewShipmentEventHandler(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			// --- Act ---
+			err := handler.HandleMessage(context.Background(), "erp.shipment", erpShipmentMessage(t, tc.eventType, 3, tc.data))
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedCall, repo.called)
+			switch tc.expectedCall {
+			case "save":
+				assert.Equal(t, "WZ/2025/0001", repo.saved.ID)
+				assert.Equal(t, domain.ShipmentInTransit, repo.saved.Status)
+				assert.Equal(t, 3, repo.saved.Version)
+				assert.Equal(t, []domain.ShipmentLine{{FabricCode: "VELVET01", Quantity: 5000}}, repo.saved.Lines)
+			case "delete":
+				assert.Equal(t, "WZ/2025/0001", repo.deletedID)
+				assert.Equal(t, 3, repo.version)
+			}
+		})
+	}
+}
+
+func TestShipmentEventHandler_MalformedQuantityIsDropped(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockShipmentRepository{}
+	handler := NewShipmentEventHandler(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	data := map[string]any{
+		"shipment_id": "WZ/2025/0001", "order_id": testOrderID, "status": "PENDING",
+		"lines": []map[string]any{{"fabric_code": "VELVET01", "quantity": -5}},
+	}
+
+	// --- Act ---
+	err := handler.HandleMessage(context.Background(), "erp.shipment", erpShipmentMessage(t, erpShipmentCreated, 1, data))
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Empty(t, repo.called)
+}
@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+)
+
+// InstrumentedOrderRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedOrderRepository struct {
+	next domain.OrderRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedOrderRepository(next domain.OrderRepository, rec *instrument.Recorder) *InstrumentedOrderRepository {
+	return &InstrumentedOrderRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedOrderRepository) SaveOrder(ctx context.Context, order *domain.Order) error {
+	return instrument.Exec(ctx, r.rec, "SaveOrder", func(ctx context.Context) error {
+		return r.next.SaveOrder(ctx, order)
+	})
+}
+
+func (r *InstrumentedOrderRepository) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	return instrument.Call(ctx, r.rec, "GetOrder", func(ctx context.Context) (*domain.Order, error) {
+		return r.next.GetOrder(ctx, id)
+	})
+}
+
+func (r *InstrumentedOrderRepository) ListOrders(
+	ctx context.Context, filter domain.OrderFilter,
+) ([]*domain.Order, int, error) {
+	var total int
+	orders, err := instrument.Call(ctx, r.rec, "ListOrders",
+		func(ctx context.Context) ([]*domain.Order, error) {
+			orders, count, err := r.next.ListOrders(ctx, filter)
+			total = count
+			return orders, err
+		})
+	return orders, total, err
+}
+
+func (r *InstrumentedOrderRepository) UpdateOrder(ctx context.Context, order *domain.Order) error {
+	return instrument.Exec(ctx, r.rec, "UpdateOrder", func(ctx context.Context) error {
+		return r.next.UpdateOrder(ctx, order)
+	})
+}
+
+func (r *InstrumentedOrderRepository) CheckCustomer(ctx context.Context, code string) error {
+	return instrument.Exec(ctx, r.rec, "CheckCustomer", func(ctx context.Context) error {
+		return r.next.CheckCustomer(ctx, code)
+	})
+}
+
+func (r *InstrumentedOrderRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	return instrument.Call(ctx, r.rec, "ResolveFabricCode", func(ctx context.Context) (string, error) {
+		return r.next.ResolveFabricCode(ctx, code)
+	})
+}
+
+// InstrumentedShipmentRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedShipmentRepository struct {
+	next domain.ShipmentRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedShipmentRepository(
+	next domain.ShipmentRepository, rec *instrument.Recorder,
+) *InstrumentedShipmentRepository {
+	return &InstrumentedShipmentRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedShipmentRepository) SaveShipment(ctx context.Context, shipment *domain.Shipment) (bool, error) {
+	return instrument.Call(ctx, r.rec, "SaveShipment", func(ctx context.Context) (bool, error) {
+		return r.next.SaveShipment(ctx, shipment)
+	})
+}
+
+func (r *InstrumentedShipmentRepository) DeleteShipment(
+	ctx context.Context, id string, version int, at time.Time,
+) (bool, error) {
+	return instrument.Call(ctx, r.rec, "DeleteShipment", func(ctx context.Context) (bool, error) {
+		return r.next.DeleteShipment(ctx, id, version, at)
+	})
+}
+
+func (r *InstrumentedShipmentRepository) ListShipments(ctx context.Context, orderID string) ([]*domain.Shipment, error) {
+	return instrument.Call(ctx, r.rec, "ListShipments", func(ctx context.Context) ([]*domain.Shipment, error) {
+		return r.next.ListShipments(ctx, orderID)
+	})
+}
@@ -0,0 +1,249 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+const orderColumns = `id, customer_code, currency, status, version, created_at, created_by, updated_at, updated_by`
+
+// resolves a fabric code, or one of its aliases, to the canonical code
+const canonicalFabricCodeSQL = `COALESCE((SELECT canonical_code FROM fabric_aliases WHERE alias_code = $1), $1)`
+
+type OrderPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewOrderPostgresRepository(db *database.PostgresDB) *OrderPostgresRepository {
+	return &OrderPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *OrderPostgresRepository) SaveOrder(ctx context.Context, order *domain.Order) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO orders (`+orderColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, order.ID, order.CustomerCode, order.Currency, order.Status, order.Version,
+		order.CreatedAt, order.CreatedBy, order.UpdatedAt, order.UpdatedBy)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			// the customer was deleted since it was checked
+			return domain.ErrCustomerNotFound
+		}
+		return fmt.Errorf("failed to insert order: %w", err)
+	}
+
+	if err := insertLines(ctx, tx, order); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *OrderPostgresRepository) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+	order, err := scanOrder(r.db.Conn(ctx).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if err := r.loadLines(ctx, []*domain.Order{order}); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *OrderPostgresRepository) ListOrders(
+	ctx context.Context, filter domain.OrderFilter,
+) ([]*domain.Order, int, error) {
+	query := `
+		SELECT count(*) OVER(), ` + orderColumns + `
+		FROM orders
+		WHERE ($1 = '' OR status = $1) AND ($2 = '' OR customer_code = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, filter.Status, filter.CustomerCode, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list orders: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	orders := []*domain.Order{}
+	for rows.Next() {
+		order := &domain.Order{}
+		if err := rows.Scan(append([]any{&totalRecords}, orderFields(order)...)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate orders: %w", err)
+	}
+
+	if err := r.loadLines(ctx, orders); err != nil {
+		return nil, 0, err
+	}
+	return orders, totalRecords, nil
+}
+
+// UpdateOrder stores the status and lines of the order, provided nobody changed it since
+// it was loaded.
+func (r *OrderPostgresRepository) UpdateOrder(ctx context.Context, order *domain.Order) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE orders SET status = $1, version = $2, updated_at = $3, updated_by = $4
+		WHERE id = $5 AND version = $6
+	`, order.Status, order.Version, order.UpdatedAt, order.UpdatedBy, order.ID, order.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM order_lines WHERE order_id = $1`, order.ID); err != nil {
+		return fmt.Errorf("failed to remove order lines: %w", err)
+	}
+	if err := insertLines(ctx, tx, order); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *OrderPostgresRepository) CheckCustomer(ctx context.Context, code string) error {
+	var exists bool
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM customers WHERE code = $1 AND deleted_at IS NULL)`, code,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check customer: %w", err)
+	}
+	if !exists {
+		return domain.ErrCustomerNotFound
+	}
+	return nil
+}
+
+func (r *OrderPostgresRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	var canonicalCode string
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		`SELECT code FROM fabrics WHERE code = `+canonicalFabricCodeSQL+` AND status = 'ACTIVE'`, code,
+	).Scan(&canonicalCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrFabricNotFound
+		}
+		return "", fmt.Errorf("failed to resolve fabric code: %w", err)
+	}
+	return canonicalCode, nil
+}
+
+// loadLines fills in the lines of the orders, in the order they were given.
+func (r *OrderPostgresRepository) loadLines(ctx context.Context, orders []*domain.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	byID := make(map[string]*domain.Order, len(orders))
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		order.Lines = []domain.OrderLine{}
+		byID[order.ID] = order
+		ids = append(ids, order.ID)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT order_id, fabric_code, quantity, unit_price
+		FROM order_lines
+		WHERE order_id::text = ANY($1)
+		ORDER BY order_id, position
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load order lines: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			orderID string
+			line    domain.OrderLine
+		)
+		if err := rows.Scan(&orderID, &line.FabricCode, &line.Quantity, &line.UnitPrice); err != nil {
+			return fmt.Errorf("failed to scan order line: %w", err)
+		}
+		if order, ok := byID[orderID]; ok {
+			order.Lines = append(order.Lines, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate order lines: %w", err)
+	}
+	return nil
+}
+
+func insertLines(ctx context.Context, tx *database.Tx, order *domain.Order) error {
+	for position, line := range order.Lines {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO order_lines (order_id, position, fabric_code, quantity, unit_price)
+			VALUES ($1, $2, $3, $4, $5)
+		`, order.ID, position+1, line.FabricCode, line.Quantity, line.UnitPrice)
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				// the fabric was purged since it was resolved
+				return domain.ErrFabricNotFound
+			}
+			return fmt.Errorf("failed to insert order line: %w", err)
+		}
+	}
+	return nil
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOrder(row rowScanner) (*domain.Order, error) {
+	order := &domain.Order{}
+	if err := row.Scan(orderFields(order)...); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// orderFields lists the destinations of orderColumns, in the same order.
+func orderFields(order *domain.Order) []any {
+	return []any{
+		&order.ID, &order.CustomerCode, &order.Currency, &order.Status, &order.Version,
+		&order.CreatedAt, &order.CreatedBy, &order.UpdatedAt, &order.UpdatedBy,
+	}
+}
@@ -0,0 +1,166 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/orders/domain"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+type ShipmentPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewShipmentPostgresRepository(db *database.PostgresDB) *ShipmentPostgresRepository {
+	return &ShipmentPostgresRepository{
+		db: db,
+	}
+}
+
+// SaveShipment upserts the shipment and replaces its lines. The upsert only touches a
+// stored shipment at an earlier version, so re-sent and late events change nothing.
+func (r *ShipmentPostgresRepository) SaveShipment(ctx context.Context, shipment *domain.Shipment) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO shipments (
+			id, order_id, status, carrier, tracking_number, shipped_at, delivered_at, version, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			order_id = EXCLUDED.order_id, status = EXCLUDED.status, carrier = EXCLUDED.carrier,
+			tracking_number = EXCLUDED.tracking_number, shipped_at = EXCLUDED.shipped_at,
+			delivered_at = EXCLUDED.delivered_at, version = EXCLUDED.version,
+			updated_at = EXCLUDED.updated_at, deleted_at = NULL
+		WHERE shipments.version < EXCLUDED.version
+	`, shipment.ID, shipment.OrderID, shipment.Status, shipment.Carrier, shipment.TrackingNumber,
+		shipment.ShippedAt, shipment.DeliveredAt, shipment.Version, shipment.UpdatedAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return false, domain.ErrOrderNotFound
+		}
+		return false, fmt.Errorf("failed to upsert shipment: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shipment_lines WHERE shipment_id = $1`, shipment.ID); err != nil {
+		return false, fmt.Errorf("failed to remove shipment lines: %w", err)
+	}
+	for position, line := range shipment.Lines {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO shipment_lines (shipment_id, position, fabric_code, quantity)
+			VALUES ($1, $2, $3, $4)
+		`, shipment.ID, position+1, line.FabricCode, line.Quantity)
+		if err != nil {
+			return false, fmt.Errorf("failed to insert shipment line: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit shipment: %w", err)
+	}
+	return true, nil
+}
+
+func (r *ShipmentPostgresRepository) DeleteShipment(
+	ctx context.Context, id string, version int, at time.Time,
+) (bool, error) {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE shipments SET version = $2, updated_at = $3, deleted_at = $3
+		WHERE id = $1 AND version < $2
+	`, id, version, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete shipment: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+func (r *ShipmentPostgresRepository) ListShipments(ctx context.Context, orderID string) ([]*domain.Shipment, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT id, order_id, status, carrier, tracking_number, shipped_at, delivered_at, version, updated_at
+		FROM shipments
+		WHERE order_id = $1 AND deleted_at IS NULL
+		ORDER BY shipped_at NULLS LAST, id
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shipments: %w", err)
+	}
+	defer rows.Close()
+
+	shipments := []*domain.Shipment{}
+	byID := make(map[string]*domain.Shipment)
+	for rows.Next() {
+		shipment := &domain.Shipment{Lines: []domain.ShipmentLine{}}
+		err := rows.Scan(
+			&shipment.ID, &shipment.OrderID, &shipment.Status, &shipment.Carrier, &shipment.TrackingNumber,
+			&shipment.ShippedAt, &shipment.DeliveredAt, &shipment.Version, &shipment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shipment: %w", err)
+		}
+		shipments = append(shipments, shipment)
+		byID[shipment.ID] = shipment
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate shipments: %w", err)
+	}
+
+	if err := r.loadLines(ctx, orderID, byID); err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+// loadLines fills in the lines of the shipments of the order, in the order they were sent.
+func (r *ShipmentPostgresRepository) loadLines(
+	ctx context.Context, orderID string, byID map[string]*domain.Shipment,
+) error {
+	if len(byID) == 0 {
+		return nil
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT l.shipment_id, l.fabric_code, l.quantity
+		FROM shipment_lines l
+		JOIN shipments s ON s.id = l.shipment_id
+		WHERE s.order_id = $1
+		ORDER BY l.shipment_id, l.position
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to load shipment lines: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			shipmentID string
+			line       domain.ShipmentLine
+		)
+		if err := rows.Scan(&shipmentID, &line.FabricCode, &line.Quantity); err != nil {
+			return fmt.Errorf("failed to scan shipment line: %w", err)
+		}
+		if shipment, ok := byID[shipmentID]; ok {
+			shipment.Lines = append(shipment.Lines, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate shipment lines: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Package aggregate holds the mechanics every aggregate of the domain modules shares:
+// recording the events of a change until they are stored, optimistic version checks, the
+// audit of who created and last changed an aggregate, and soft deletion. Aggregates embed
+// Root and Audit, and SoftDelete when they are deleted that way, and keep their own fields
+// and rules. cmd/scaffold generates a new module on top of them.
+package aggregate
+
+import (
+	"context"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+)
+
+// Event is a change recorded by an aggregate, published once the aggregate is stored.
+type Event = any
+
+// Stamp identifies who performed a change on an aggregate and when it happened.
+type Stamp struct {
+	By string
+	At time.Time
+}
+
+// StampNow captures the actor issuing the command and the current time.
+func StampNow(ctx context.Context, clock clock.Clock) Stamp {
+	return Stamp{By: command.Actor(ctx), At: clock.Now()}
+}
+
+// Audit records who created an aggregate and who changed it last, and when.
+type Audit struct {
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
+}
+
+// NewAudit audits an aggregate created with the stamp.
+func NewAudit(stamp Stamp) Audit {
+	return Audit{CreatedAt: stamp.At, CreatedBy: stamp.By, UpdatedAt: stamp.At, UpdatedBy: stamp.By}
+}
+
+// Touch records the author and time of the latest change.
+func (a *Audit) Touch(stamp Stamp) {
+	a.UpdatedAt = stamp.At
+	a.UpdatedBy = stamp.By
+}
+
+// Root records the events of an aggregate. Embedded in an aggregate it adds nothing to its
+// JSON, the events are never serialized with it.
+type Root struct {
+	events []Event
+}
+
+// Record appends an event to the events of the aggregate.
+func (r *Root) Record(event Event) {
+	r.events = append(r.events, event)
+}
+
+// Events returns the events recorded since the aggregate was created or loaded, oldest
+// first.
+func (r *Root) Events() []Event {
+	return r.events
+}
+
+// CheckVersion returns conflict when a command was made against another version of the
+// aggregate than the current one.
+func CheckVersion(current, expected int, conflict error) error {
+	if current != expected {
+		return conflict
+	}
+	return nil
+}
+
+// SoftDelete keeps a deleted aggregate around, so it can be restored and its code is not
+// taken by another one.
+type SoftDelete struct {
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Deleted reports whether the aggregate is deleted.
+func (d *SoftDelete) Deleted() bool {
+	return d.DeletedAt != nil
+}
+
+// MarkDeleted records the moment the aggregate was deleted.
+func (d *SoftDelete) MarkDeleted(at time.Time) {
+	d.DeletedAt = &at
+}
+
+// ClearDeleted brings a deleted aggregate back.
+func (d *SoftDelete) ClearDeleted() {
+	d.DeletedAt = nil
+}
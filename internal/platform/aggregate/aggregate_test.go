@@ -0,0 +1,59 @@
+package aggregate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCreated struct{ Code string }
+
+type testRenamed struct{ Name string }
+
+func TestRoot_Record(t *testing.T) {
+	// --- Arrange ---
+	var root Root
+
+	// --- Act ---
+	root.Record(testCreated{Code: "AGG01"})
+	root.Record(testRenamed{Name: "Renamed"})
+
+	// --- Assert ---
+	assert.Equal(t, []Event{testCreated{Code: "AGG01"}, testRenamed{Name: "Renamed"}}, root.Events())
+}
+
+func TestCheckVersion(t *testing.T) {
+	errConflict := errors.New("conflict")
+
+	assert.NoError(t, CheckVersion(3, 3, errConflict))
+	assert.ErrorIs(t, CheckVersion(4, 3, errConflict), errConflict)
+}
+
+func TestAudit(t *testing.T) {
+	// --- Arrange ---
+	created := Stamp{By: "user_1", At: time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)}
+	changed := Stamp{By: "user_2", At: time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC)}
+
+	// --- Act ---
+	audit := NewAudit(created)
+	audit.Touch(changed)
+
+	// --- Assert ---
+	assert.Equal(t, Audit{CreatedAt: created.At, CreatedBy: "user_1", UpdatedAt: changed.At, UpdatedBy: "user_2"}, audit)
+}
+
+func TestSoftDelete(t *testing.T) {
+	// --- Arrange ---
+	var deletion SoftDelete
+	at := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	// --- Act & Assert ---
+	assert.False(t, deletion.Deleted())
+	deletion.MarkDeleted(at)
+	assert.True(t, deletion.Deleted())
+	assert.Equal(t, at, *deletion.DeletedAt)
+	deletion.ClearDeleted()
+	assert.False(t, deletion.Deleted())
+}
@@ -0,0 +1,44 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore_PutOpenDelete(t *testing.T) {
+	// --- Arrange ---
+	store := NewFileStore(t.TempDir())
+	ctx := context.Background()
+
+	// --- Act ---
+	size, err := store.Put(ctx, "fabrics/VELVET01/spec.pdf", strings.NewReader("%PDF-1.7"))
+	require.NoError(t, err)
+	blob, err := store.Open(ctx, "fabrics/VELVET01/spec.pdf")
+	require.NoError(t, err)
+	content, err := io.ReadAll(blob)
+	require.NoError(t, blob.Close())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), size)
+	assert.Equal(t, "%PDF-1.7", string(content))
+
+	require.NoError(t, store.Delete(ctx, "fabrics/VELVET01/spec.pdf"))
+	_, err = store.Open(ctx, "fabrics/VELVET01/spec.pdf")
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+	assert.NoError(t, store.Delete(ctx, "fabrics/VELVET01/spec.pdf"), "deleting a missing blob is not an error")
+}
+
+func TestFileStore_RejectsKeysOutsideRoot(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "fabrics/../../outside"} {
+		_, err := store.Put(context.Background(), key, strings.NewReader("x"))
+		assert.Error(t, err, "key %q must be rejected", key)
+	}
+}
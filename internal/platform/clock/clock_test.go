@@ -0,0 +1,33 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemClock_ReturnsUTC(t *testing.T) {
+	// Act
+	now := New().Now()
+
+	// Assert
+	assert.Equal(t, time.UTC, now.Location())
+	assert.WithinDuration(t, time.Now(), now, time.Second)
+}
+
+func TestFixedClock_AdvanceAndSet(t *testing.T) {
+	// Arrange
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := NewFixed(start)
+
+	// Act & Assert
+	assert.Equal(t, start, c.Now())
+
+	c.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), c.Now())
+
+	later := start.Add(24 * time.Hour)
+	c.Set(later)
+	assert.Equal(t, later, c.Now())
+}
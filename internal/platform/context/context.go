@@ -10,10 +10,34 @@ const (
 	CommandSourceEvent CommandSource = "event" // From NATS event
 )
 
+const (
+	ActorERP            = "erp"             // Actor recorded for commands sourced from ERP events
+	ActorAnonymous      = "anonymous"       // Actor recorded when no principal is present
+	ActorSyntheticProbe = "synthetic-probe" // Actor recorded for commands of the synthetic probe
+	ActorClerk          = "clerk"           // Actor recorded for commands sourced from Clerk webhooks
+	ActorScheduler      = "scheduler"       // Actor recorded for changes made by background jobs
+)
+
 // Internal context key type to avoid collisions
 type contextKey string
 
-const commandSourceKey contextKey = "command_source"
+const (
+	commandSourceKey  contextKey = "command_source"
+	userIDKey         contextKey = "user_id"
+	syntheticProbeKey contextKey = "synthetic_probe"
+	supplierKey       contextKey = "supplier"
+	scopesKey         contextKey = "scopes"
+)
+
+// ActorSupplierPrefix prefixes the supplier code in the actor recorded for commands
+// issued through the supplier portal
+const ActorSupplierPrefix = "supplier:"
+
+// SupplierPrincipal is a supplier authenticated with a portal token, limited to its scopes
+type SupplierPrincipal struct {
+	Code   string
+	Scopes []string
+}
 
 // WithCommandSource adds the command source to context
 func WithCommandSource(ctx context.Context, source CommandSource) context.Context {
@@ -37,3 +61,68 @@ func IsFromREST(ctx context.Context) bool {
 func IsFromEvent(ctx context.Context) bool {
 	return GetCommandSource(ctx) == CommandSourceEvent
 }
+
+// WithUserID adds the authenticated user ID to context
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// GetUserID retrieves the authenticated user ID from context
+func GetUserID(ctx context.Context) string {
+	if userID, ok := ctx.Value(userIDKey).(string); ok {
+		return userID
+	}
+	return ""
+}
+
+// WithScopes adds the scopes granted to the authenticated user to context
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey, scopes)
+}
+
+// HasScope checks if the authenticated user was granted the scope
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, _ := ctx.Value(scopesKey).([]string)
+	for _, granted := range scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// WithSyntheticProbe marks the command as issued by the synthetic probe, acting as
+// ActorSyntheticProbe. Unlike the user ID, the mark cannot be carried by a request.
+func WithSyntheticProbe(ctx context.Context) context.Context {
+	return WithUserID(context.WithValue(ctx, syntheticProbeKey, true), ActorSyntheticProbe)
+}
+
+// IsSyntheticProbe checks if the command was issued by the synthetic probe itself
+func IsSyntheticProbe(ctx context.Context) bool {
+	probe, _ := ctx.Value(syntheticProbeKey).(bool)
+	return probe
+}
+
+// WithSupplier marks the command as issued by a supplier through the portal, acting as
+// "supplier:<code>". Like the synthetic probe mark, it cannot be carried by a request.
+func WithSupplier(ctx context.Context, supplier SupplierPrincipal) context.Context {
+	return WithUserID(context.WithValue(ctx, supplierKey, supplier), ActorSupplierPrefix+supplier.Code)
+}
+
+// GetSupplier retrieves the supplier issuing the command, if it came through the portal
+func GetSupplier(ctx context.Context) (SupplierPrincipal, bool) {
+	supplier, ok := ctx.Value(supplierKey).(SupplierPrincipal)
+	return supplier, ok
+}
+
+// Actor returns who is issuing the command: the authenticated user for REST
+// commands, "erp" for event-sourced commands and "anonymous" otherwise.
+func Actor(ctx context.Context) string {
+	if IsFromEvent(ctx) {
+		return ActorERP
+	}
+	if userID := GetUserID(ctx); userID != "" {
+		return userID
+	}
+	return ActorAnonymous
+}
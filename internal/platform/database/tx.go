@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// name of the savepoint a unit of work opens inside a request transaction. Savepoints are
+// stacked, so nested units of work can share the name.
+const unitOfWorkSavepoint = "unit_of_work"
+
+type txContextKey struct{}
+
+// Querier runs statements on either the connection pool or a transaction.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// WithTx returns a context carrying tx, so repositories and the event store called with it
+// take part in the transaction.
+func WithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any.
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*sql.Tx)
+	return tx, ok
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none.
+func Conn(ctx context.Context, db *sql.DB) Querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+// Tx is a unit of work: a transaction of its own, or a savepoint in the transaction carried
+// by the context. Committing a savepoint only releases it; the changes become durable when
+// the enclosing transaction commits.
+type Tx struct {
+	*sql.Tx
+	savepoint bool
+	done      bool
+}
+
+// BeginTx starts a unit of work on db. Inside a request transaction it opens a savepoint,
+// so a failed unit rolls back alone and the request transaction stays usable; opts are
+// ignored there, as the enclosing transaction already fixed them.
+func BeginTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions) (*Tx, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT "+unitOfWorkSavepoint); err != nil {
+			return nil, fmt.Errorf("could not open savepoint: %w", err)
+		}
+		return &Tx{Tx: tx, savepoint: true}, nil
+	}
+
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx}, nil
+}
+
+func (t *Tx) Commit() error {
+	if !t.savepoint {
+		return t.Tx.Commit()
+	}
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	_, err := t.Tx.Exec("RELEASE SAVEPOINT " + unitOfWorkSavepoint)
+	return err
+}
+
+// Rollback undoes the unit of work. Like sql.Tx it returns sql.ErrTxDone once the unit has
+// been committed or rolled back, so it can be deferred.
+func (t *Tx) Rollback() error {
+	if !t.savepoint {
+		return t.Tx.Rollback()
+	}
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	_, err := t.Tx.Exec("ROLLBACK TO SAVEPOINT " + unitOfWorkSavepoint + "; RELEASE SAVEPOINT " + unitOfWorkSavepoint)
+	return err
+}
+
+// Conn returns the request transaction carried by ctx, or the connection pool.
+func (db *PostgresDB) Conn(ctx context.Context) Querier {
+	return Conn(ctx, db.Pool)
+}
+
+// BeginTx starts a unit of work, joining the request transaction carried by ctx if any.
+func (db *PostgresDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	return BeginTx(ctx, db.Pool, opts)
+}
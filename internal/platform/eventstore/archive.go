@@ -0,0 +1,335 @@
+package eventstore
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+const (
+	// archiveFormat names the logical export of the events table.
+	archiveFormat = "s-works/events"
+
+	// ArchiveSchemaVersion is the version of the archive layout, raised whenever the
+	// events table gains or changes a column so an archive is never misread.
+	ArchiveSchemaVersion = 1
+
+	// longest archive line accepted on import, an event payload is far smaller
+	maxArchiveLine = 16 << 20
+)
+
+var (
+	// ErrStoreNotEmpty is returned when importing into a store that already holds events.
+	ErrStoreNotEmpty = errors.New("the event store already holds events")
+
+	// ErrInvalidArchive is returned for an archive that cannot be imported as a whole.
+	ErrInvalidArchive = errors.New("invalid event archive")
+)
+
+// archiveLine is one line of an archive: the header, an event, or the footer closing it.
+type archiveLine struct {
+	Header *archiveHeader `json:"header,omitempty"`
+	Event  *archivedEvent `json:"event,omitempty"`
+	Footer *archiveFooter `json:"footer,omitempty"`
+}
+
+type archiveHeader struct {
+	Format        string    `json:"format"`
+	SchemaVersion int       `json:"schema_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+}
+
+// archivedEvent is a row of the events table as stored, position and sequence included, so
+// an import reproduces the store's ordering exactly.
+type archivedEvent struct {
+	Position         int64           `json:"position"`
+	EventID          string          `json:"event_id"`
+	AggregateID      string          `json:"aggregate_id"`
+	AggregateType    string          `json:"aggregate_type"`
+	EventType        string          `json:"event_type"`
+	AggregateVersion int             `json:"aggregate_version"`
+	Sequence         int64           `json:"sequence"`
+	Payload          json.RawMessage `json:"payload"`
+	Timestamp        time.Time       `json:"timestamp"`
+	CorrelationID    *string         `json:"correlation_id"`
+	UserID           *string         `json:"user_id"`
+	TenantID         string          `json:"tenant_id"`
+	SourceService    string          `json:"source_service"`
+	SourceInstance   string          `json:"source_instance"`
+	SchemaURL        string          `json:"schema_url"`
+}
+
+// archiveFooter marks a complete archive, a stream cut short has none.
+type archiveFooter struct {
+	Events int64 `json:"events"`
+}
+
+// archiveWriter writes an archive one event at a time.
+type archiveWriter struct {
+	enc     *json.Encoder
+	written int64
+}
+
+func newArchiveWriter(w io.Writer, exportedAt time.Time) (*archiveWriter, error) {
+	aw := &archiveWriter{enc: json.NewEncoder(w)}
+	header := &archiveHeader{Format: archiveFormat, SchemaVersion: ArchiveSchemaVersion, ExportedAt: exportedAt.UTC()}
+	if err := aw.enc.Encode(archiveLine{Header: header}); err != nil {
+		return nil, fmt.Errorf("could not write archive header: %w", err)
+	}
+	return aw, nil
+}
+
+func (aw *archiveWriter) writeEvent(event *archivedEvent) error {
+	if err := aw.enc.Encode(archiveLine{Event: event}); err != nil {
+		return fmt.Errorf("could not write event %s: %w", event.EventID, err)
+	}
+	aw.written++
+	return nil
+}
+
+func (aw *archiveWriter) close() error {
+	if err := aw.enc.Encode(archiveLine{Footer: &archiveFooter{Events: aw.written}}); err != nil {
+		return fmt.Errorf("could not write archive footer: %w", err)
+	}
+	return nil
+}
+
+// archiveReader reads an archive back, checking its header, the order of its events and
+// that it is complete.
+type archiveReader struct {
+	scanner      *bufio.Scanner
+	line         int
+	read         int64
+	lastPosition int64
+}
+
+func newArchiveReader(r io.Reader) (*archiveReader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxArchiveLine)
+	ar := &archiveReader{scanner: scanner}
+
+	line, err := ar.next()
+	if err != nil {
+		return nil, err
+	}
+	header := line.Header
+	if header == nil {
+		return nil, fmt.Errorf("%w: the first line must be the header", ErrInvalidArchive)
+	}
+	if header.Format != archiveFormat {
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidArchive, header.Format)
+	}
+	if header.SchemaVersion != ArchiveSchemaVersion {
+		return nil, fmt.Errorf("%w: schema version %d is not supported, expected %d",
+			ErrInvalidArchive, header.SchemaVersion, ArchiveSchemaVersion)
+	}
+	return ar, nil
+}
+
+// readEvent returns the next event, or io.EOF once the footer confirmed every event was read.
+func (ar *archiveReader) readEvent() (*archivedEvent, error) {
+	line, err := ar.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case line.Event != nil:
+		if line.Event.Position <= ar.lastPosition {
+			return nil, fmt.Errorf("%w: event %s at position %d is out of order",
+				ErrInvalidArchive, line.Event.EventID, line.Event.Position)
+		}
+		ar.lastPosition = line.Event.Position
+		ar.read++
+		return line.Event, nil
+	case line.Footer != nil:
+		if line.Footer.Events != ar.read {
+			return nil, fmt.Errorf("%w: the footer counts %d events, the archive holds %d",
+				ErrInvalidArchive, line.Footer.Events, ar.read)
+		}
+		if ar.scanner.Scan() {
+			return nil, fmt.Errorf("%w: content after the footer", ErrInvalidArchive)
+		}
+		return nil, io.EOF
+	default:
+		return nil, fmt.Errorf("%w: line %d is neither an event nor the footer", ErrInvalidArchive, ar.line)
+	}
+}
+
+func (ar *archiveReader) next() (*archiveLine, error) {
+	if !ar.scanner.Scan() {
+		if err := ar.scanner.Err(); err != nil {
+			return nil, fmt.Errorf("could not read archive: %w", err)
+		}
+		return nil, fmt.Errorf("%w: the archive ends without a footer", ErrInvalidArchive)
+	}
+	ar.line++
+
+	var line archiveLine
+	if err := json.Unmarshal(ar.scanner.Bytes(), &line); err != nil {
+		return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidArchive, ar.line, err)
+	}
+	return &line, nil
+}
+
+// ExportEvents writes every stored event to w in position order and returns how many were
+// written. The events are read from a single snapshot, so appends made during a long
+// export do not end up half in it.
+func (s *PostgresStore) ExportEvents(ctx context.Context, w io.Writer, exportedAt time.Time) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return 0, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT position, event_id, aggregate_id, aggregate_type, event_type,
+			aggregate_version, sequence, payload, "timestamp", correlation_id, user_id,
+			tenant_id, source_service, source_instance, schema_url
+		FROM events
+		ORDER BY position
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("could not read events: %w", err)
+	}
+	defer rows.Close()
+
+	aw, err := newArchiveWriter(w, exportedAt)
+	if err != nil {
+		return 0, err
+	}
+	for rows.Next() {
+		var (
+			event   archivedEvent
+			payload []byte
+		)
+		err := rows.Scan(
+			&event.Position,
+			&event.EventID,
+			&event.AggregateID,
+			&event.AggregateType,
+			&event.EventType,
+			&event.AggregateVersion,
+			&event.Sequence,
+			&payload,
+			&event.Timestamp,
+			&event.CorrelationID,
+			&event.UserID,
+			&event.TenantID,
+			&event.SourceService,
+			&event.SourceInstance,
+			&event.SchemaURL,
+		)
+		if err != nil {
+			return aw.written, fmt.Errorf("could not scan event: %w", err)
+		}
+		event.Timestamp = event.Timestamp.UTC()
+		event.Payload = json.RawMessage(payload)
+		if err := aw.writeEvent(&event); err != nil {
+			return aw.written, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return aw.written, fmt.Errorf("could not iterate events: %w", err)
+	}
+
+	return aw.written, aw.close()
+}
+
+// ImportEvents loads an archive written by ExportEvents into an empty store, keeping event
+// IDs, positions and sequences, and returns how many events were imported. The import is
+// all or nothing: an archive that turns out invalid part way leaves the store empty.
+// Imported events are not queued in the outbox, a cloned environment does not publish
+// the history of the one it was cloned from.
+func (s *PostgresStore) ImportEvents(ctx context.Context, r io.Reader) (int64, error) {
+	ar, err := newArchiveReader(r)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := database.BeginTx(ctx, s.db, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", appendLockKey); err != nil {
+		return 0, fmt.Errorf("could not acquire append lock: %w", err)
+	}
+
+	var populated bool
+	if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM events)").Scan(&populated); err != nil {
+		return 0, fmt.Errorf("could not check the event store: %w", err)
+	}
+	if populated {
+		return 0, ErrStoreNotEmpty
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO events (
+			position, event_id, aggregate_id, aggregate_type, event_type,
+			aggregate_version, sequence, payload, "timestamp", correlation_id, user_id,
+			tenant_id, source_service, source_instance, schema_url
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("could not prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	var imported int64
+	for {
+		event, err := ar.readEvent()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = stmt.ExecContext(ctx,
+			event.Position,
+			event.EventID,
+			event.AggregateID,
+			event.AggregateType,
+			event.EventType,
+			event.AggregateVersion,
+			event.Sequence,
+			[]byte(event.Payload),
+			event.Timestamp.UTC(),
+			event.CorrelationID,
+			event.UserID,
+			event.TenantID,
+			event.SourceService,
+			event.SourceInstance,
+			event.SchemaURL,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("could not import event %s: %w", event.EventID, err)
+		}
+		imported++
+	}
+
+	// events appended after the import continue from the last imported position
+	if imported > 0 {
+		_, err := tx.ExecContext(ctx,
+			"SELECT setval(pg_get_serial_sequence('events', 'position'), (SELECT MAX(position) FROM events))",
+		)
+		if err != nil {
+			return 0, fmt.Errorf("could not advance the position sequence: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("could not commit import: %w", err)
+	}
+	return imported, nil
+}
@@ -0,0 +1,101 @@
+package eventstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestArchive(t *testing.T, events ...*archivedEvent) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	aw, err := newArchiveWriter(&buf, time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	for _, event := range events {
+		require.NoError(t, aw.writeEvent(event))
+	}
+	require.NoError(t, aw.close())
+	return buf.String()
+}
+
+func readTestArchive(archive string) ([]*archivedEvent, error) {
+	ar, err := newArchiveReader(strings.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	var events []*archivedEvent
+	for {
+		event, err := ar.readEvent()
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+}
+
+func TestArchive_RoundTrip(t *testing.T) {
+	// --- Arrange ---
+	correlationID := "corr-1"
+	events := []*archivedEvent{
+		{
+			Position: 3, EventID: "e1", AggregateID: "VELVET01", AggregateType: "Fabric",
+			EventType: "app.fabric.created", AggregateVersion: 1, Sequence: 1,
+			Payload: json.RawMessage(`{"name":"Velvet"}`), Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			CorrelationID: &correlationID,
+		},
+		{
+			Position: 7, EventID: "e2", AggregateID: "VELVET01", AggregateType: "Fabric",
+			EventType: "app.fabric.updated", AggregateVersion: 2, Sequence: 2,
+			Payload: json.RawMessage(`{"name":"Red velvet"}`), Timestamp: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	// --- Act ---
+	read, err := readTestArchive(writeTestArchive(t, events...))
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, events, read, "positions, IDs and nullable columns must survive the round trip")
+}
+
+func TestArchive_RejectsInvalidArchives(t *testing.T) {
+	complete := writeTestArchive(t,
+		&archivedEvent{Position: 1, EventID: "e1", Payload: json.RawMessage(`{}`)},
+		&archivedEvent{Position: 2, EventID: "e2", Payload: json.RawMessage(`{}`)},
+	)
+	lines := strings.SplitAfter(complete, "\n")
+
+	testCases := []struct {
+		name    string
+		archive string
+	}{
+		{name: "Empty", archive: ""},
+		{name: "Missing header", archive: strings.Join(lines[1:], "")},
+		{name: "Newer schema version", archive: strings.Replace(complete, `"schema_version":1`, `"schema_version":2`, 1)},
+		{name: "Cut short", archive: strings.Join(lines[:2], "")},
+		{name: "Footer miscounts", archive: lines[0] + lines[1] + lines[3]},
+		{name: "Out of order", archive: lines[0] + lines[2] + lines[1] + lines[3]},
+		{name: "Not JSON", archive: lines[0] + "garbage\n" + lines[3]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			_, err := readTestArchive(tc.archive)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, ErrInvalidArchive)
+		})
+	}
+}
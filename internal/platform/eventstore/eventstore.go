@@ -10,10 +10,23 @@ import (
 var (
 	// ErrConcurrencyConflict is returned when an event with the same aggregate version already exists.
 	ErrConcurrencyConflict = errors.New("concurrency conflict: event version already exists for this aggregate")
+
+	// ErrEventNotFound is returned when an event ID is not present in the store.
+	ErrEventNotFound = errors.New("event not found")
 )
 
+// RecordedEvent is an envelope read back from the store together with its global position.
+type RecordedEvent struct {
+	Position int64
+	Envelope *messaging.EventEnvelope
+}
+
 // Store is the interface for saving and retrieving events.
 type Store interface {
 	// Save saves one or more event envelopes to the store.
 	Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error
+
+	// SaveAndEnqueue saves the envelopes and, in the same transaction, queues them in the
+	// outbox for publishing to subject.
+	SaveAndEnqueue(ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope) error
 }
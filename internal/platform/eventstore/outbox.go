@@ -0,0 +1,194 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+)
+
+// key of the transaction-scoped advisory lock held by the dispatching relay, so a single
+// instance publishes at a time and events leave the outbox in position order
+const relayLockKey int64 = 0x6f7574626f78 // "outbox" in ASCII
+
+// OutboxEntry is an event queued for publishing.
+type OutboxEntry struct {
+	Subject  string
+	Attempts int
+	Envelope *messaging.EventEnvelope
+}
+
+// PublishFunc delivers one queued event.
+type PublishFunc func(ctx context.Context, entry OutboxEntry) error
+
+// Outbox is the delivery side of the event store, drained by the OutboxRelay.
+type Outbox interface {
+	// DispatchPending hands up to limit queued events to publish, oldest first, and records
+	// the outcome of every attempt. It returns the number of events published.
+	DispatchPending(ctx context.Context, limit, maxAttempts int, publish PublishFunc) (int, error)
+
+	// PendingCount returns the number of events not published yet.
+	PendingCount(ctx context.Context) (int64, error)
+}
+
+// DispatchPending publishes queued events in position order. Events that failed
+// maxAttempts times stay queued until re-dispatched and hold back the later events of
+// their aggregate, as do events that failed earlier in the same batch.
+func (s *PostgresStore) DispatchPending(
+	ctx context.Context, limit, maxAttempts int, publish PublishFunc,
+) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", relayLockKey).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("could not acquire relay lock: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	entries, err := pendingEntries(ctx, tx, limit, maxAttempts)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	failed := make(map[string]bool)
+	for _, entry := range entries {
+		aggregate := entry.Envelope.AggregateType + "/" + entry.Envelope.AggregateID
+		if failed[aggregate] {
+			continue
+		}
+
+		if err := publish(ctx, entry); err != nil {
+			failed[aggregate] = true
+			_, err := tx.ExecContext(ctx,
+				"UPDATE event_outbox SET attempts = attempts + 1, last_error = $1 WHERE event_id = $2",
+				err.Error(), entry.Envelope.EventID,
+			)
+			if err != nil {
+				return 0, fmt.Errorf("could not record failed delivery of event %s: %w", entry.Envelope.EventID, err)
+			}
+			continue
+		}
+
+		_, err := tx.ExecContext(ctx,
+			"UPDATE event_outbox SET attempts = attempts + 1, last_error = '', published_at = now() WHERE event_id = $1",
+			entry.Envelope.EventID,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("could not mark event %s published: %w", entry.Envelope.EventID, err)
+		}
+		published++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("could not commit dispatch: %w", err)
+	}
+	return published, nil
+}
+
+func pendingEntries(ctx context.Context, tx *sql.Tx, limit, maxAttempts int) ([]OutboxEntry, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT o.subject, o.attempts, e.event_id, e.aggregate_id, e.aggregate_type, e.event_type,
+			e.aggregate_version, e.payload, e."timestamp", e.sequence,
+			COALESCE(e.correlation_id, ''), COALESCE(e.user_id, ''), e.tenant_id,
+			e.source_service, e.source_instance, e.schema_url
+		FROM event_outbox o
+		JOIN events e ON e.event_id = o.event_id
+		WHERE o.published_at IS NULL AND o.attempts < $1
+			AND NOT EXISTS (
+				SELECT 1
+				FROM event_outbox so
+				JOIN events se ON se.event_id = so.event_id
+				WHERE so.published_at IS NULL AND so.attempts >= $1
+					AND se.aggregate_type = e.aggregate_type AND se.aggregate_id = e.aggregate_id
+					AND se.position < e.position
+			)
+		ORDER BY e.position
+		LIMIT $2
+	`, maxAttempts, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not read outbox: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []OutboxEntry{}
+	for rows.Next() {
+		var (
+			entry                         OutboxEntry
+			payload                       []byte
+			sourceService, sourceInstance string
+			envelope                      = messaging.EventEnvelope{EventVersion: 1}
+		)
+		err := rows.Scan(
+			&entry.Subject,
+			&entry.Attempts,
+			&envelope.EventID,
+			&envelope.AggregateID,
+			&envelope.AggregateType,
+			&envelope.EventType,
+			&envelope.AggregateVersion,
+			&payload,
+			&envelope.Timestamp,
+			&envelope.Sequence,
+			&envelope.CorrelationID,
+			&envelope.UserID,
+			&envelope.TenantID,
+			&sourceService,
+			&sourceInstance,
+			&envelope.SchemaURL,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan outbox entry: %w", err)
+		}
+		if sourceService != "" {
+			envelope.Source = &messaging.Source{Service: sourceService, Instance: sourceInstance}
+		}
+		envelope.Timestamp = envelope.Timestamp.UTC()
+		envelope.Payload = json.RawMessage(payload)
+		entry.Envelope = &envelope
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not iterate outbox: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (s *PostgresStore) PendingCount(ctx context.Context) (int64, error) {
+	var count int64
+	err := s.db.QueryRowContext(ctx, "SELECT count(*) FROM event_outbox WHERE published_at IS NULL").Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("could not count outbox: %w", err)
+	}
+	return count, nil
+}
+
+// Redispatch queues an event for publishing again, whether it was delivered already or
+// gave up after too many failed attempts.
+func (s *PostgresStore) Redispatch(ctx context.Context, eventID string) error {
+	result, err := database.Conn(ctx, s.db).ExecContext(ctx,
+		"UPDATE event_outbox SET attempts = 0, last_error = '', published_at = NULL WHERE event_id = $1",
+		eventID,
+	)
+	if err != nil {
+		return fmt.Errorf("could not re-dispatch event: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not re-dispatch event: %w", err)
+	}
+	if affected == 0 {
+		return ErrEventNotFound
+	}
+	return nil
+}
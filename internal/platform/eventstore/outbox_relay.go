@@ -0,0 +1,114 @@
+package eventstore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// number of queued events published per dispatch
+	relayBatchSize = 100
+
+	// failed attempts after which an event waits for a manual re-dispatch
+	relayMaxAttempts = 10
+)
+
+// connectionChecker is implemented by publishers that know whether their broker is reachable
+type connectionChecker interface {
+	Connected() bool
+}
+
+// OutboxRelay publishes the events queued in the outbox. Every event is marked published
+// after a successful delivery, so an event is only published again when the relay stops
+// between publishing it and recording that; consumers de-duplicate on the event ID.
+//
+// Only the event types on the allowlist reach the broker. The others are marked published
+// without being sent and stay internal to the event store.
+type OutboxRelay struct {
+	outbox    Outbox
+	publisher messaging.Publisher
+	allowlist *messaging.EventAllowlist
+	logger    *slog.Logger
+}
+
+func NewOutboxRelay(
+	outbox Outbox, publisher messaging.Publisher, allowlist *messaging.EventAllowlist, logger *slog.Logger,
+) *OutboxRelay {
+	return &OutboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		allowlist: allowlist,
+		logger:    logger.With("component", "outbox.relay"),
+	}
+}
+
+// Run dispatches the outbox every interval until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Dispatch(ctx); err != nil {
+				r.logger.Error("failed to dispatch outbox", "error", err)
+			}
+		}
+	}
+}
+
+// Dispatch publishes one batch of queued events and reports the remaining queue depth.
+// While the broker is unreachable nothing is attempted, so a long outage does not use up
+// the attempts of queued events; they are published once the connection is back.
+func (r *OutboxRelay) Dispatch(ctx context.Context) error {
+	if checker, ok := r.publisher.(connectionChecker); ok && !checker.Connected() {
+		r.logger.Debug("broker unreachable, keeping events queued")
+	} else {
+		published, err := r.outbox.DispatchPending(ctx, relayBatchSize, relayMaxAttempts, r.publish)
+		if err != nil {
+			return err
+		}
+		if published > 0 {
+			r.logger.Debug("published outbox events", "count", published)
+		}
+	}
+
+	depth, err := r.outbox.PendingCount(ctx)
+	if err != nil {
+		return err
+	}
+	httpx.OutboxDepthGauge.Record(ctx, depth)
+	return nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, entry OutboxEntry) error {
+	if !r.allowlist.Allows(entry.Envelope.EventType) {
+		httpx.OutboxWithheldCounter.Add(ctx, 1,
+			metric.WithAttributes(attribute.String("event_type", entry.Envelope.EventType)))
+		r.logger.Debug("withheld internal outbox event",
+			"eventID", entry.Envelope.EventID,
+			"eventType", entry.Envelope.EventType,
+		)
+		return nil
+	}
+	if err := r.publisher.Publish(ctx, entry.Subject, entry.Envelope); err != nil {
+		httpx.OutboxFailedCounter.Add(ctx, 1,
+			metric.WithAttributes(attribute.String("subject", entry.Subject)))
+		r.logger.Warn("failed to publish outbox event",
+			"error", err,
+			"eventID", entry.Envelope.EventID,
+			"attempt", entry.Attempts+1,
+			"maxAttempts", relayMaxAttempts,
+		)
+		return err
+	}
+	return nil
+}
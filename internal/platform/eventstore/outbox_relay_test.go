@@ -0,0 +1,152 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOutbox struct {
+	entries     []OutboxEntry
+	maxAttempts int
+	failures    map[string]error
+	pending     int64
+	errToReturn error
+}
+
+func (m *mockOutbox) DispatchPending(
+	ctx context.Context, limit, maxAttempts int, publish PublishFunc,
+) (int, error) {
+	if m.errToReturn != nil {
+		return 0, m.errToReturn
+	}
+	m.maxAttempts = maxAttempts
+	m.failures = make(map[string]error)
+	published := 0
+	for _, entry := range m.entries {
+		if err := publish(ctx, entry); err != nil {
+			m.failures[entry.Envelope.EventID] = err
+			continue
+		}
+		published++
+	}
+	return published, nil
+}
+
+func (m *mockOutbox) PendingCount(ctx context.Context) (int64, error) {
+	return m.pending, nil
+}
+
+type mockPublisher struct {
+	subjects []string
+	failing  map[string]bool
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, subject string, envelope *messaging.EventEnvelope) error {
+	if m.failing[envelope.EventID] {
+		return errors.New("nats unavailable")
+	}
+	m.subjects = append(m.subjects, subject)
+	return nil
+}
+
+func (m *mockPublisher) Close() error {
+	return nil
+}
+
+func testAllowlist(t *testing.T) *messaging.EventAllowlist {
+	t.Helper()
+
+	allowlist, err := messaging.NewEventAllowlist("app.fabric.*")
+	require.NoError(t, err)
+	return allowlist
+}
+
+func TestOutboxRelay_Dispatch_PublishesAndReportsFailures(t *testing.T) {
+	// --- Arrange ---
+	ok := messaging.NewEventEnvelope("app.fabric.created", "FABRIC001", "Fabric", 1, map[string]any{"v": 1})
+	broken := messaging.NewEventEnvelope("app.fabric.created", "FABRIC002", "Fabric", 1, map[string]any{"v": 1})
+	outbox := &mockOutbox{
+		entries: []OutboxEntry{
+			{Subject: "app.fabric", Envelope: ok},
+			{Subject: "app.fabric", Attempts: 2, Envelope: broken},
+		},
+		pending: 1,
+	}
+	publisher := &mockPublisher{failing: map[string]bool{broken.EventID: true}}
+	relay := NewOutboxRelay(outbox, publisher, testAllowlist(t), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// --- Act ---
+	err := relay.Dispatch(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.fabric"}, publisher.subjects)
+	assert.Equal(t, relayMaxAttempts, outbox.maxAttempts)
+	assert.Contains(t, outbox.failures, broken.EventID, "the failure is handed back to be recorded")
+	assert.NotContains(t, outbox.failures, ok.EventID)
+}
+
+func TestOutboxRelay_Dispatch_OutboxError(t *testing.T) {
+	// --- Arrange ---
+	outbox := &mockOutbox{errToReturn: errors.New("database is down")}
+	relay := NewOutboxRelay(outbox, &mockPublisher{}, testAllowlist(t), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// --- Act ---
+	err := relay.Dispatch(context.Background())
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, outbox.errToReturn)
+}
+
+type disconnectedPublisher struct {
+	mockPublisher
+}
+
+func (m *disconnectedPublisher) Connected() bool {
+	return false
+}
+
+func TestOutboxRelay_Dispatch_BrokerUnreachable(t *testing.T) {
+	// --- Arrange ---
+	envelope := messaging.NewEventEnvelope("app.fabric.created", "FABRIC001", "Fabric", 1, map[string]any{"v": 1})
+	outbox := &mockOutbox{entries: []OutboxEntry{{Subject: "app.fabric", Envelope: envelope}}, pending: 1}
+	publisher := &disconnectedPublisher{}
+	relay := NewOutboxRelay(outbox, publisher, testAllowlist(t), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// --- Act ---
+	err := relay.Dispatch(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Empty(t, publisher.subjects)
+	assert.Nil(t, outbox.failures, "no attempt is recorded against queued events")
+}
+
+func TestOutboxRelay_Dispatch_WithholdsInternalEvents(t *testing.T) {
+	// --- Arrange ---
+	public := messaging.NewEventEnvelope("app.fabric.created", "FABRIC001", "Fabric", 1, map[string]any{"v": 1})
+	internal := messaging.NewEventEnvelope("app.customer.created", "CUSTOMER001", "Customer", 1, map[string]any{"v": 1})
+	outbox := &mockOutbox{
+		entries: []OutboxEntry{
+			{Subject: "app.fabric", Envelope: public},
+			{Subject: "app.customer", Envelope: internal},
+		},
+	}
+	publisher := &mockPublisher{}
+	relay := NewOutboxRelay(outbox, publisher, testAllowlist(t), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// --- Act ---
+	err := relay.Dispatch(context.Background())
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.fabric"}, publisher.subjects, "events off the allowlist are not broadcast")
+	assert.Empty(t, outbox.failures, "a withheld event is not a failed one, it leaves the outbox")
+}
@@ -3,13 +3,23 @@ package eventstore
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/platform/database"
 	"github.com/salesworks/s-works/api/internal/platform/messaging"
 )
 
+// key of the transaction-scoped advisory lock serialising the assignment of global
+// positions, so they become visible in order and a change feed reader never skips an
+// uncommitted gap. Appends take it as they commit, in the trigger assigning positions
+// (see migration 000025); an import holds it throughout.
+const appendLockKey int64 = 0x6576656e7473 // "events" in ASCII
+
 type PostgresStore struct {
 	db *sql.DB
 }
@@ -21,18 +31,50 @@ func NewPostgresStore(db *sql.DB) *PostgresStore {
 }
 
 func (s *PostgresStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	return s.save(ctx, "", envelopes)
+}
+
+func (s *PostgresStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	return s.save(ctx, subject, envelopes)
+}
+
+// save appends the envelopes and, when a subject is given, queues them in the outbox.
+// Appends to the same aggregate are serialised, inside a request transaction until the
+// request commits; appends to different aggregates only wait for each other's commit.
+func (s *PostgresStore) save(ctx context.Context, subject string, envelopes []*messaging.EventEnvelope) error {
+	for _, envelope := range envelopes {
+		if err := envelope.Validate(); err != nil {
+			return fmt.Errorf("invalid event %s: %w", envelope.EventID, err)
+		}
+	}
+
+	tx, err := database.BeginTx(ctx, s.db, nil)
 	if err != nil {
 		return fmt.Errorf("could not begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	if err := lockAggregates(ctx, tx, envelopes); err != nil {
+		return err
+	}
+
+	// The per-aggregate sequence is assigned here rather than by the caller, so
+	// consumers get a gap-free ordering that does not depend on wall clocks.
 	stmt, err := tx.PrepareContext(ctx, `
 		INSERT INTO events (
 			event_id, aggregate_id, aggregate_type, event_type,
-			aggregate_version, payload, "timestamp", correlation_id, user_id
+			aggregate_version, payload, "timestamp", correlation_id, user_id,
+			tenant_id, source_service, source_instance, schema_url, sequence
+		)
+		VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
+			COALESCE(
+				(SELECT MAX(sequence) FROM events WHERE aggregate_type = $3 AND aggregate_id = $2), 0
+			) + 1
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING sequence
 	`)
 	if err != nil {
 		return fmt.Errorf("could not prepare statement: %w", err)
@@ -40,17 +82,26 @@ func (s *PostgresStore) Save(ctx context.Context, envelopes ...*messaging.EventE
 	defer stmt.Close()
 
 	for _, envelope := range envelopes {
-		_, err := stmt.ExecContext(ctx,
+		var sourceService, sourceInstance string
+		if envelope.Source != nil {
+			sourceService, sourceInstance = envelope.Source.Service, envelope.Source.Instance
+		}
+
+		err := stmt.QueryRowContext(ctx,
 			envelope.EventID,
 			envelope.AggregateID,
 			envelope.AggregateType,
 			envelope.EventType,
 			envelope.AggregateVersion,
 			envelope.Payload,
-			envelope.Timestamp,
+			envelope.Timestamp.UTC(),
 			envelope.CorrelationID,
 			envelope.UserID,
-		)
+			envelope.TenantID,
+			sourceService,
+			sourceInstance,
+			envelope.SchemaURL,
+		).Scan(&envelope.Sequence)
 
 		if err != nil {
 			var pgErr *pgconn.PgError
@@ -59,7 +110,167 @@ func (s *PostgresStore) Save(ctx context.Context, envelopes ...*messaging.EventE
 			}
 			return fmt.Errorf("could not execute statement for event %s: %w", envelope.EventID, err)
 		}
+
+		if subject != "" {
+			_, err := tx.ExecContext(ctx,
+				"INSERT INTO event_outbox (event_id, subject) VALUES ($1, $2)", envelope.EventID, subject,
+			)
+			if err != nil {
+				return fmt.Errorf("could not enqueue event %s: %w", envelope.EventID, err)
+			}
+		}
 	}
 
 	return tx.Commit()
 }
+
+// lockAggregates takes the transaction-scoped lock of every aggregate the envelopes belong
+// to, in a fixed order so two appends touching the same aggregates cannot deadlock.
+func lockAggregates(ctx context.Context, tx database.Querier, envelopes []*messaging.EventEnvelope) error {
+	type aggregate struct{ typ, id string }
+	seen := make(map[aggregate]bool, len(envelopes))
+	var aggregates []aggregate
+	for _, envelope := range envelopes {
+		key := aggregate{envelope.AggregateType, envelope.AggregateID}
+		if !seen[key] {
+			seen[key] = true
+			aggregates = append(aggregates, key)
+		}
+	}
+	sort.Slice(aggregates, func(i, j int) bool {
+		if aggregates[i].typ != aggregates[j].typ {
+			return aggregates[i].typ < aggregates[j].typ
+		}
+		return aggregates[i].id < aggregates[j].id
+	})
+
+	for _, a := range aggregates {
+		_, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1), hashtext($2))", a.typ, a.id)
+		if err != nil {
+			return fmt.Errorf("could not acquire the lock of aggregate %s %s: %w", a.typ, a.id, err)
+		}
+	}
+	return nil
+}
+
+// ReadAfter returns up to limit events of the given aggregate type whose global position
+// is greater than after, in position order.
+func (s *PostgresStore) ReadAfter(
+	ctx context.Context, aggregateType string, after int64, limit int,
+) ([]RecordedEvent, error) {
+	rows, err := database.Conn(ctx, s.db).QueryContext(ctx, `
+		SELECT position, event_id, aggregate_id, aggregate_type, event_type,
+			aggregate_version, payload, "timestamp", sequence,
+			COALESCE(correlation_id, ''), COALESCE(user_id, ''),
+			tenant_id, source_service, source_instance, schema_url
+		FROM events
+		WHERE aggregate_type = $1 AND position > $2
+		ORDER BY position
+		LIMIT $3
+	`, aggregateType, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not read events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+// ReadBetween returns up to limit events whose type starts with typePrefix, recorded at or
+// after from and before to, whose global position is greater than after, in position order.
+func (s *PostgresStore) ReadBetween(
+	ctx context.Context, typePrefix string, from, to time.Time, after int64, limit int,
+) ([]RecordedEvent, error) {
+	rows, err := database.Conn(ctx, s.db).QueryContext(ctx, `
+		SELECT position, event_id, aggregate_id, aggregate_type, event_type,
+			aggregate_version, payload, "timestamp", sequence,
+			COALESCE(correlation_id, ''), COALESCE(user_id, ''),
+			tenant_id, source_service, source_instance, schema_url
+		FROM events
+		WHERE starts_with(event_type, $1) AND "timestamp" >= $2 AND "timestamp" < $3 AND position > $4
+		ORDER BY position
+		LIMIT $5
+	`, typePrefix, from, to, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not read events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+// ReadAggregate returns every event of a single aggregate, in the order it was recorded.
+func (s *PostgresStore) ReadAggregate(ctx context.Context, aggregateType, aggregateID string) ([]RecordedEvent, error) {
+	rows, err := database.Conn(ctx, s.db).QueryContext(ctx, `
+		SELECT position, event_id, aggregate_id, aggregate_type, event_type,
+			aggregate_version, payload, "timestamp", sequence,
+			COALESCE(correlation_id, ''), COALESCE(user_id, ''),
+			tenant_id, source_service, source_instance, schema_url
+		FROM events
+		WHERE aggregate_type = $1 AND aggregate_id = $2
+		ORDER BY sequence
+	`, aggregateType, aggregateID)
+	if err != nil {
+		return nil, fmt.Errorf("could not read aggregate events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+func scanEvents(rows *sql.Rows) ([]RecordedEvent, error) {
+	events := []RecordedEvent{}
+	for rows.Next() {
+		var (
+			position                      int64
+			payload                       []byte
+			sourceService, sourceInstance string
+			envelope                      messaging.EventEnvelope
+		)
+		err := rows.Scan(
+			&position,
+			&envelope.EventID,
+			&envelope.AggregateID,
+			&envelope.AggregateType,
+			&envelope.EventType,
+			&envelope.AggregateVersion,
+			&payload,
+			&envelope.Timestamp,
+			&envelope.Sequence,
+			&envelope.CorrelationID,
+			&envelope.UserID,
+			&envelope.TenantID,
+			&sourceService,
+			&sourceInstance,
+			&envelope.SchemaURL,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan event: %w", err)
+		}
+		if sourceService != "" {
+			envelope.Source = &messaging.Source{Service: sourceService, Instance: sourceInstance}
+		}
+		envelope.Timestamp = envelope.Timestamp.UTC()
+		envelope.Payload = json.RawMessage(payload)
+		events = append(events, RecordedEvent{Position: position, Envelope: &envelope})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not iterate events: %w", err)
+	}
+
+	return events, nil
+}
+
+// PositionOf returns the current global position of an event. Event IDs survive a
+// replay of the store while positions may not, so readers resolve their checkpoint here.
+func (s *PostgresStore) PositionOf(ctx context.Context, eventID string) (int64, error) {
+	var position int64
+	err := database.Conn(ctx, s.db).QueryRowContext(ctx, "SELECT position FROM events WHERE event_id = $1", eventID).Scan(&position)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrEventNotFound
+		}
+		return 0, fmt.Errorf("could not read event position: %w", err)
+	}
+	return position, nil
+}
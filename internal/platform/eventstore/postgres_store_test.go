@@ -3,11 +3,13 @@ package eventstore
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log/slog"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/salesworks/s-works/api/internal/platform/clock"
 	"github.com/salesworks/s-works/api/internal/platform/database"
 	"github.com/salesworks/s-works/api/internal/platform/messaging"
 	"github.com/stretchr/testify/assert"
@@ -50,6 +52,22 @@ func setup(t *testing.T) *postgresTestFixture {
 	}
 }
 
+func TestPostgresStore_Save_RejectsInvalidEnvelope(t *testing.T) {
+	// --- Arrange ---
+	// an invalid envelope is refused before the database is touched
+	store := NewPostgresStore(nil)
+	envelope := messaging.NewEventEnvelope(
+		"app.fabric.created", "FABRIC01", "Fabric", 1, map[string]any{"code": "FABRIC01"},
+		messaging.WithSource("", "instance-1"),
+	)
+
+	// --- Act ---
+	err := store.Save(context.Background(), envelope)
+
+	// --- Assert ---
+	assert.ErrorContains(t, err, "source service is required")
+}
+
 func TestPostgresStore_Save(t *testing.T) {
 	// --- Arrange ---
 	fixture := setup(t)
@@ -75,3 +93,258 @@ func TestPostgresStore_Save(t *testing.T) {
 	require.NoError(t, dbErr, "Event should be found in the database")
 	assert.Equal(t, "fabric.created", eventType)
 }
+
+func TestPostgresStore_Save_AssignsPerAggregateSequence(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+
+	first := messaging.NewEventEnvelope("fabric.created", "FABRIC001", "Fabric", 1, map[string]interface{}{"v": 1})
+	second := messaging.NewEventEnvelope("fabric.updated", "FABRIC001", "Fabric", 2, map[string]interface{}{"v": 2})
+	other := messaging.NewEventEnvelope("fabric.created", "FABRIC002", "Fabric", 1, map[string]interface{}{"v": 1})
+
+	// --- Act ---
+	require.NoError(t, fixture.store.Save(ctx, first))
+	require.NoError(t, fixture.store.Save(ctx, second, other))
+
+	// --- Assert ---
+	assert.Equal(t, int64(1), first.Sequence)
+	assert.Equal(t, int64(2), second.Sequence)
+	assert.Equal(t, int64(1), other.Sequence, "sequences are independent per aggregate")
+}
+
+func TestPostgresStore_ReadAfter(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+
+	first := messaging.NewEventEnvelope("fabric.created", "FABRIC001", "Fabric", 1, map[string]interface{}{"v": 1})
+	second := messaging.NewEventEnvelope("fabric.created", "FABRIC002", "Fabric", 1, map[string]interface{}{"v": 1})
+	require.NoError(t, fixture.store.Save(ctx, first, second))
+
+	// --- Act ---
+	all, err := fixture.store.ReadAfter(ctx, "Fabric", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	rest, err := fixture.store.ReadAfter(ctx, "Fabric", all[0].Position, 10)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, first.EventID, all[0].Envelope.EventID)
+	assert.Less(t, all[0].Position, all[1].Position)
+	require.Len(t, rest, 1)
+	assert.Equal(t, second.EventID, rest[0].Envelope.EventID)
+}
+
+func TestPostgresStore_ReadBetween(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+
+	recorded := func(eventType, aggregateType, aggregateID string, version, day int) *messaging.EventEnvelope {
+		at := clock.NewFixed(time.Date(2025, 1, day, 12, 0, 0, 0, time.UTC))
+		return messaging.NewEventEnvelope(eventType, aggregateID, aggregateType, version,
+			map[string]interface{}{"v": version}, messaging.WithClock(at))
+	}
+	before := recorded("app.fabric.created", "Fabric", "FABRIC001", 1, 1)
+	first := recorded("app.fabric.created", "Fabric", "FABRIC002", 1, 2)
+	other := recorded("app.category.created", "Category", "CAT01", 1, 2)
+	second := recorded("app.fabric.updated", "Fabric", "FABRIC002", 2, 3)
+	after := recorded("app.fabric.updated", "Fabric", "FABRIC002", 3, 4)
+	require.NoError(t, fixture.store.Save(ctx, before, first, other, second, after))
+
+	from := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)
+
+	// --- Act ---
+	all, err := fixture.store.ReadBetween(ctx, "app.fabric.", from, to, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, all, 2, "events outside the range and of other types are left out")
+	rest, err := fixture.store.ReadBetween(ctx, "app.fabric.", from, to, all[0].Position, 10)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, first.EventID, all[0].Envelope.EventID)
+	assert.Equal(t, second.EventID, all[1].Envelope.EventID)
+	require.Len(t, rest, 1)
+	assert.Equal(t, second.EventID, rest[0].Envelope.EventID)
+}
+
+func TestPostgresStore_Save_DoesNotBlockOtherAggregates(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+
+	// a request transaction appends and stays open, as a slow command would
+	tx, err := fixture.db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	slow := messaging.NewEventEnvelope("fabric.created", "FABRIC001", "Fabric", 1, map[string]interface{}{"v": 1})
+	require.NoError(t, fixture.store.Save(database.WithTx(ctx, tx), slow))
+
+	// --- Act ---
+	fastCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	fast := messaging.NewEventEnvelope("fabric.created", "FABRIC002", "Fabric", 1, map[string]interface{}{"v": 1})
+	err = fixture.store.Save(fastCtx, fast)
+
+	// --- Assert ---
+	require.NoError(t, err, "an append to another aggregate should not wait for the open transaction")
+	require.NoError(t, tx.Commit())
+
+	events, err := fixture.store.ReadAfter(ctx, "Fabric", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, fast.EventID, events[0].Envelope.EventID, "positions follow the order of commits")
+	assert.Equal(t, slow.EventID, events[1].Envelope.EventID)
+}
+
+func TestPostgresStore_ReadAggregate(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+
+	first := messaging.NewEventEnvelope("fabric.created", "FABRIC001", "Fabric", 1, map[string]interface{}{"v": 1})
+	other := messaging.NewEventEnvelope("fabric.created", "FABRIC002", "Fabric", 1, map[string]interface{}{"v": 1})
+	second := messaging.NewEventEnvelope("fabric.updated", "FABRIC001", "Fabric", 2, map[string]interface{}{"v": 2},
+		messaging.WithUserID("user_123"))
+	require.NoError(t, fixture.store.Save(ctx, first, other, second))
+
+	// --- Act ---
+	events, err := fixture.store.ReadAggregate(ctx, "Fabric", "FABRIC001")
+	missing, missingErr := fixture.store.ReadAggregate(ctx, "Fabric", "FABRIC999")
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.Len(t, events, 2, "events of other aggregates are left out")
+	assert.Equal(t, first.EventID, events[0].Envelope.EventID)
+	assert.Equal(t, second.EventID, events[1].Envelope.EventID)
+	assert.Equal(t, "user_123", events[1].Envelope.UserID)
+	require.NoError(t, missingErr)
+	assert.Empty(t, missing)
+}
+
+func TestPostgresStore_Reads_KeepEnvelopeMetadata(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	tagged := messaging.NewEventEnvelope("fabric.created", "FABRIC001", "Fabric", 1, map[string]interface{}{"v": 1},
+		messaging.WithTenantID("tenant_a"),
+		messaging.WithSource("s-works-api", "replica-1"),
+		messaging.WithSchemaURL("https://schemas.example.com/fabric.created/v1.json"))
+	plain := messaging.NewEventEnvelope("fabric.updated", "FABRIC001", "Fabric", 2, map[string]interface{}{"v": 2})
+	require.NoError(t, fixture.store.Save(ctx, tagged, plain))
+
+	// --- Act ---
+	after, afterErr := fixture.store.ReadAfter(ctx, "Fabric", 0, 10)
+	between, betweenErr := fixture.store.ReadBetween(ctx, "fabric.", now.Add(-time.Minute), now.Add(time.Minute), 0, 10)
+	aggregate, aggregateErr := fixture.store.ReadAggregate(ctx, "Fabric", "FABRIC001")
+
+	// --- Assert ---
+	for name, events := range map[string][]RecordedEvent{"after": after, "between": between, "aggregate": aggregate} {
+		require.Len(t, events, 2, name)
+		read := events[0].Envelope
+		assert.Equal(t, "tenant_a", read.TenantID, name)
+		assert.Equal(t, &messaging.Source{Service: "s-works-api", Instance: "replica-1"}, read.Source, name)
+		assert.Equal(t, "https://schemas.example.com/fabric.created/v1.json", read.SchemaURL, name)
+		assert.Nil(t, events[1].Envelope.Source, "an event without a source is read without one: %s", name)
+	}
+	require.NoError(t, afterErr)
+	require.NoError(t, betweenErr)
+	require.NoError(t, aggregateErr)
+}
+
+func TestPostgresStore_PositionOf(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+
+	envelope := messaging.NewEventEnvelope("fabric.created", "FABRIC001", "Fabric", 1, map[string]interface{}{"v": 1})
+	require.NoError(t, fixture.store.Save(ctx, envelope))
+	events, err := fixture.store.ReadAfter(ctx, "Fabric", 0, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	// --- Act ---
+	position, err := fixture.store.PositionOf(ctx, envelope.EventID)
+	_, missingErr := fixture.store.PositionOf(ctx, "00000000-0000-7000-8000-000000000000")
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, events[0].Position, position)
+	assert.ErrorIs(t, missingErr, ErrEventNotFound)
+}
+
+func TestPostgresStore_SaveAndEnqueue_DispatchesInOrder(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+
+	first := messaging.NewEventEnvelope("fabric.created", "FABRIC001", "Fabric", 1, map[string]interface{}{"v": 1})
+	second := messaging.NewEventEnvelope("fabric.updated", "FABRIC001", "Fabric", 2, map[string]interface{}{"v": 2})
+	unpublished := messaging.NewEventEnvelope("fabric.created", "FABRIC002", "Fabric", 1, map[string]interface{}{"v": 1})
+	require.NoError(t, fixture.store.SaveAndEnqueue(ctx, "app.fabric", first, second))
+	require.NoError(t, fixture.store.Save(ctx, unpublished))
+
+	var dispatched []string
+	publish := func(_ context.Context, entry OutboxEntry) error {
+		dispatched = append(dispatched, entry.Envelope.EventID)
+		assert.Equal(t, "app.fabric", entry.Subject)
+		return nil
+	}
+
+	// --- Act ---
+	published, err := fixture.store.DispatchPending(ctx, 10, 3, publish)
+	require.NoError(t, err)
+	depth, depthErr := fixture.store.PendingCount(ctx)
+	again, againErr := fixture.store.DispatchPending(ctx, 10, 3, publish)
+
+	// --- Assert ---
+	require.NoError(t, depthErr)
+	require.NoError(t, againErr)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, []string{first.EventID, second.EventID}, dispatched, "only enqueued events are published, in order")
+	assert.Equal(t, int64(0), depth)
+	assert.Equal(t, 0, again, "published events are not dispatched twice")
+}
+
+func TestPostgresStore_DispatchPending_RecordsFailuresAndHoldsBackAggregate(t *testing.T) {
+	// --- Arrange ---
+	fixture := setup(t)
+	ctx := context.Background()
+
+	first := messaging.NewEventEnvelope("fabric.created", "FABRIC001", "Fabric", 1, map[string]interface{}{"v": 1})
+	second := messaging.NewEventEnvelope("fabric.updated", "FABRIC001", "Fabric", 2, map[string]interface{}{"v": 2})
+	require.NoError(t, fixture.store.SaveAndEnqueue(ctx, "app.fabric", first, second))
+
+	failing := func(_ context.Context, entry OutboxEntry) error {
+		if entry.Envelope.EventID == first.EventID {
+			return errors.New("nats unavailable")
+		}
+		return nil
+	}
+
+	// --- Act ---
+	published, err := fixture.store.DispatchPending(ctx, 10, 1, failing)
+	require.NoError(t, err)
+	afterGivingUp, err := fixture.store.DispatchPending(ctx, 10, 1, failing)
+	require.NoError(t, err)
+	redispatchErr := fixture.store.Redispatch(ctx, first.EventID)
+	missingErr := fixture.store.Redispatch(ctx, "00000000-0000-7000-8000-000000000000")
+
+	// --- Assert ---
+	assert.Equal(t, 0, published, "later events of a failed aggregate wait for it")
+	assert.Equal(t, 0, afterGivingUp, "an event out of attempts holds back its aggregate")
+
+	var attempts int
+	var lastError string
+	require.NoError(t, fixture.db.QueryRowContext(ctx,
+		"SELECT attempts, last_error FROM event_outbox WHERE event_id = $1", first.EventID,
+	).Scan(&attempts, &lastError))
+	assert.Equal(t, 0, attempts, "re-dispatch resets the attempts")
+	assert.Empty(t, lastError)
+	require.NoError(t, redispatchErr)
+	assert.ErrorIs(t, missingErr, ErrEventNotFound)
+}
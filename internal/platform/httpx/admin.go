@@ -0,0 +1,39 @@
+package httpx
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+)
+
+// AdminMiddleware lets through only requests presenting the admin token as a bearer token.
+// Admin routes switch the whole instance or reach into the event store, so the principal
+// passed on by the upstream authentication does not grant them. With no token configured
+// every admin request is refused.
+func AdminMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				ErrorJSON(w, http.StatusForbidden, "the admin API is disabled on this instance")
+				return
+			}
+
+			presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || presented == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				ErrorJSON(w, http.StatusUnauthorized, "admin credentials must be provided")
+				return
+			}
+			if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+				GetLogger(r.Context()).Warn("admin request refused",
+					"principal", command.GetUserID(r.Context()), "path", r.URL.Path)
+				ErrorJSON(w, http.StatusForbidden, "the credentials do not grant admin access")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
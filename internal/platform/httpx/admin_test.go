@@ -0,0 +1,44 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminMiddleware(t *testing.T) {
+	testCases := []struct {
+		name           string
+		token          string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "Matching token", token: "s3cret", authorization: "Bearer s3cret", expectedStatus: http.StatusOK},
+		{name: "No credentials", token: "s3cret", expectedStatus: http.StatusUnauthorized},
+		{name: "Other scheme", token: "s3cret", authorization: "Basic s3cret", expectedStatus: http.StatusUnauthorized},
+		{name: "Wrong token", token: "s3cret", authorization: "Bearer guess", expectedStatus: http.StatusForbidden},
+		{name: "Admin API disabled", authorization: "Bearer ", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+			handler := AdminMiddleware(tc.token)(next)
+			req := httptest.NewRequest(http.MethodPut, "/v1/admin/read-only", nil)
+			req.Header.Set(UserIDHeader, "user_123")
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+
+			// --- Act ---
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+		})
+	}
+}
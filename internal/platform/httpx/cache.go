@@ -0,0 +1,132 @@
+package httpx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheControl lets clients and shared caches such as a CDN keep successful reads of the
+// handler for maxAge. Errors are never marked cacheable, so a failed read is retried
+// rather than served from the cache. A maxAge below one second leaves the handler as it is.
+func CacheControl(maxAge time.Duration, next http.Handler) http.Handler {
+	seconds := int(maxAge / time.Second)
+	if seconds < 1 {
+		return next
+	}
+	return cacheControl("public, max-age="+strconv.Itoa(seconds), next)
+}
+
+// Revalidate lets only the client's own cache keep successful reads of the handler, and
+// serve them again only once the API confirmed them unchanged. It suits representations
+// that vary by the caller or carry short-lived state, which shared caches must not keep;
+// the handler answers the revalidation with WriteCacheableJSON.
+func Revalidate(next http.Handler) http.Handler {
+	return cacheControl("private, no-cache", next)
+}
+
+func cacheControl(value string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(&cacheControlWriter{ResponseWriter: w, value: value}, r)
+	})
+}
+
+// SetLastModified sets the Last-Modified header, a zero time is left out.
+func SetLastModified(headers http.Header, modified time.Time) {
+	if modified.IsZero() {
+		return
+	}
+	headers.Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+}
+
+// WriteCacheableJSON writes data like WriteJSON, tagged with a weak ETag of the body. A GET
+// or HEAD whose If-None-Match, or lacking one whose If-Modified-Since against the
+// Last-Modified date in headers, shows the client holds this representation already is
+// answered 304 Not Modified without a body.
+func WriteCacheableJSON(w http.ResponseWriter, r *http.Request, data Envelope, headers http.Header) error {
+	js, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return err
+	}
+	js = append(js, '\n')
+
+	sum := sha256.Sum256(js)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	headers.Set("ETag", etag)
+
+	for key, value := range headers {
+		w.Header()[key] = value
+	}
+	if (r.Method == http.MethodGet || r.Method == http.MethodHead) && notModified(r, etag, headers) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(js)
+	return nil
+}
+
+// notModified evaluates the conditional headers of the request the way RFC 9110 orders
+// them: If-None-Match decides when present, If-Modified-Since only otherwise.
+func notModified(r *http.Request, etag string, headers http.Header) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(headers.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// cacheControlWriter adds the Cache-Control header once the status is known to be a success
+// or a confirmation that the client's copy is still current
+type cacheControlWriter struct {
+	http.ResponseWriter
+	value       string
+	wroteHeader bool
+}
+
+func (cw *cacheControlWriter) WriteHeader(status int) {
+	cacheable := status >= 200 && status < 300 || status == http.StatusNotModified
+	if !cw.wroteHeader && cacheable && cw.Header().Get("Cache-Control") == "" {
+		cw.Header().Set("Cache-Control", cw.value)
+	}
+	cw.wroteHeader = true
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+func (cw *cacheControlWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+func (cw *cacheControlWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
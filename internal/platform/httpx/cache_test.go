@@ -0,0 +1,153 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheControl(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxAge        time.Duration
+		method        string
+		status        int
+		expectedValue string
+	}{
+		{name: "successful read", maxAge: time.Minute, method: http.MethodGet, status: http.StatusOK, expectedValue: "public, max-age=60"},
+		{name: "head request", maxAge: 90 * time.Second, method: http.MethodHead, status: http.StatusOK, expectedValue: "public, max-age=90"},
+		{name: "failed read", maxAge: time.Minute, method: http.MethodGet, status: http.StatusNotFound},
+		{name: "command", maxAge: time.Minute, method: http.MethodPost, status: http.StatusOK},
+		{name: "disabled", maxAge: 0, method: http.MethodGet, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := CacheControl(tt.maxAge, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			rr := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(rr, httptest.NewRequest(tt.method, "/fabrics/FAB01", nil))
+
+			// --- Assert ---
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Equal(t, tt.expectedValue, rr.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func TestCacheControl_ImplicitStatusAndHandlerOverride(t *testing.T) {
+	// --- Arrange ---
+	implicit := CacheControl(time.Minute, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	override := CacheControl(time.Minute, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+	}))
+	implicitRR, overrideRR := httptest.NewRecorder(), httptest.NewRecorder()
+
+	// --- Act ---
+	implicit.ServeHTTP(implicitRR, httptest.NewRequest(http.MethodGet, "/fabrics", nil))
+	override.ServeHTTP(overrideRR, httptest.NewRequest(http.MethodGet, "/fabrics", nil))
+
+	// --- Assert ---
+	assert.Equal(t, "public, max-age=60", implicitRR.Header().Get("Cache-Control"),
+		"a body written without a status is a successful read")
+	assert.Equal(t, "no-store", overrideRR.Header().Get("Cache-Control"), "a handler may opt out")
+}
+
+func TestRevalidate(t *testing.T) {
+	// --- Arrange ---
+	handler := Revalidate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	rr := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/fabrics/FAB01", nil))
+
+	// --- Assert ---
+	assert.Equal(t, "private, no-cache", rr.Header().Get("Cache-Control"),
+		"a confirmation that the copy is current carries the caching rules too")
+}
+
+func TestWriteCacheableJSON(t *testing.T) {
+	modified := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	data := Envelope{"fabric": map[string]string{"code": "FAB01"}}
+	etag := func() string {
+		rr := httptest.NewRecorder()
+		headers := http.Header{}
+		SetLastModified(headers, modified)
+		require.NoError(t, WriteCacheableJSON(rr, httptest.NewRequest(http.MethodGet, "/fabrics/FAB01", nil), data, headers))
+		return rr.Header().Get("ETag")
+	}()
+
+	tests := []struct {
+		name           string
+		method         string
+		conditions     map[string]string
+		expectedStatus int
+	}{
+		{name: "unconditional", method: http.MethodGet, expectedStatus: http.StatusOK},
+		{name: "matching etag", method: http.MethodGet, conditions: map[string]string{"If-None-Match": `"x", ` + etag}, expectedStatus: http.StatusNotModified},
+		{name: "other etag", method: http.MethodGet, conditions: map[string]string{"If-None-Match": `W/"x"`}, expectedStatus: http.StatusOK},
+		{name: "not modified since", method: http.MethodGet, conditions: map[string]string{"If-Modified-Since": "Tue, 04 Mar 2025 05:06:07 GMT"}, expectedStatus: http.StatusNotModified},
+		{name: "modified since", method: http.MethodGet, conditions: map[string]string{"If-Modified-Since": "Tue, 04 Mar 2025 05:06:06 GMT"}, expectedStatus: http.StatusOK},
+		{
+			name:           "etag decides over the date",
+			method:         http.MethodGet,
+			conditions:     map[string]string{"If-None-Match": `W/"x"`, "If-Modified-Since": "Tue, 04 Mar 2025 05:06:07 GMT"},
+			expectedStatus: http.StatusOK,
+		},
+		{name: "command", method: http.MethodPost, conditions: map[string]string{"If-None-Match": etag}, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			req := httptest.NewRequest(tt.method, "/fabrics/FAB01", nil)
+			for name, value := range tt.conditions {
+				req.Header.Set(name, value)
+			}
+			headers := http.Header{}
+			SetLastModified(headers, modified)
+			rr := httptest.NewRecorder()
+
+			// --- Act ---
+			err := WriteCacheableJSON(rr, req, data, headers)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, etag, rr.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, rr.Body.Bytes())
+			} else {
+				assert.JSONEq(t, `{"fabric": {"code": "FAB01"}}`, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestSetLastModified(t *testing.T) {
+	// --- Arrange ---
+	headers := http.Header{}
+	empty := http.Header{}
+	modified := time.Date(2025, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600))
+
+	// --- Act ---
+	SetLastModified(headers, modified)
+	SetLastModified(empty, time.Time{})
+
+	// --- Assert ---
+	assert.Equal(t, "Tue, 04 Mar 2025 04:06:07 GMT", headers.Get("Last-Modified"))
+	assert.Empty(t, empty.Get("Last-Modified"))
+}
@@ -0,0 +1,65 @@
+package httpx
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ConcurrencyLimiter bounds how many requests of one kind are in flight at once, so a
+// burst of imports or reads during an ERP sync cannot exhaust the Postgres pool. A
+// request over the limit is shed right away with 503 and a Retry-After hint instead of
+// queueing behind the others.
+type ConcurrencyLimiter struct {
+	name       string
+	slots      chan struct{}
+	retryAfter time.Duration
+}
+
+// NewConcurrencyLimiter returns a limiter allowing max requests at once. The name labels
+// shed requests in metrics and logs. A max below 1 disables the limit.
+func NewConcurrencyLimiter(name string, max int, retryAfter time.Duration) *ConcurrencyLimiter {
+	l := &ConcurrencyLimiter{name: name, retryAfter: retryAfter}
+	if max > 0 {
+		l.slots = make(chan struct{}, max)
+	}
+	return l
+}
+
+// Limit wraps a handler, every handler wrapped by the same limiter shares its slots.
+func (l *ConcurrencyLimiter) Limit(next http.Handler) http.Handler {
+	if l.slots == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case l.slots <- struct{}{}:
+			defer func() { <-l.slots }()
+			next.ServeHTTP(w, r)
+		default:
+			l.shed(w, r)
+		}
+	})
+}
+
+// responds with 503 and counts the shed request
+func (l *ConcurrencyLimiter) shed(w http.ResponseWriter, r *http.Request) {
+	ShedRequestCounter.Add(r.Context(), 1, metric.WithAttributes(
+		attribute.String("limiter", l.name),
+	))
+	GetLogger(r.Context()).Warn("request shed by concurrency limit",
+		"limiter", l.name, "max_in_flight", cap(l.slots))
+
+	retryAfter := int((l.retryAfter + time.Second - 1) / time.Second)
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	headers := http.Header{"Retry-After": []string{strconv.Itoa(retryAfter)}}
+	_ = WriteJSON(w, http.StatusServiceUnavailable, Envelope{
+		"error": "too many concurrent requests, retry later",
+	}, headers)
+}
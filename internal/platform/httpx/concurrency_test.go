@@ -0,0 +1,91 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingHandler holds every request until released
+type blockingHandler struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{entered: make(chan struct{}, 10), release: make(chan struct{})}
+}
+
+func (h *blockingHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	h.entered <- struct{}{}
+	<-h.release
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestConcurrencyLimiter_ShedsRequestsOverTheLimit(t *testing.T) {
+	// --- Arrange ---
+	limiter := NewConcurrencyLimiter("imports", 2, 1500*time.Millisecond)
+	inner := newBlockingHandler()
+	handler := limiter.Limit(inner)
+
+	var wg sync.WaitGroup
+	statuses := make([]int, 2)
+	for i := range statuses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/fabrics/import", nil))
+			statuses[i] = rr.Code
+		}()
+		<-inner.entered
+	}
+
+	// --- Act ---
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/fabrics/import", nil))
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("Retry-After"), "retry hint should round up to whole seconds")
+
+	close(inner.release)
+	wg.Wait()
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK}, statuses)
+}
+
+func TestConcurrencyLimiter_ReleasesSlots(t *testing.T) {
+	// --- Arrange ---
+	limiter := NewConcurrencyLimiter("reads", 1, time.Second)
+	handler := limiter.Limit(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for range 3 {
+		// --- Act ---
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/fabrics", nil))
+
+		// --- Assert ---
+		require.Equal(t, http.StatusOK, rr.Code, "sequential requests must never be shed")
+	}
+}
+
+func TestConcurrencyLimiter_ZeroDisablesTheLimit(t *testing.T) {
+	// --- Arrange ---
+	inner := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {})
+
+	// --- Act ---
+	limiter := NewConcurrencyLimiter("reads", 0, time.Second)
+
+	// --- Assert ---
+	handler := limiter.Limit(inner)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/fabrics", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
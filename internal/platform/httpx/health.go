@@ -0,0 +1,62 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const healthProbeTimeout = 2 * time.Second
+
+// HealthCheck probes one dependency. A failing critical check makes the service unavailable,
+// a failing non-critical one only degrades it
+type HealthCheck struct {
+	Name     string
+	Critical bool
+	Probe    func(ctx context.Context) error
+}
+
+// HealthHandler reports the state of the service dependencies
+type HealthHandler struct {
+	checks []HealthCheck
+}
+
+func NewHealthHandler(checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthProbeTimeout)
+	defer cancel()
+
+	status := "available"
+	degraded := false
+	code := http.StatusOK
+	checks := make(map[string]string, len(h.checks))
+
+	for _, check := range h.checks {
+		if err := check.Probe(ctx); err != nil {
+			checks[check.Name] = "down"
+			GetLogger(r.Context()).Warn("health check failed", "check", check.Name, "error", err)
+			if check.Critical {
+				status = "unavailable"
+				code = http.StatusServiceUnavailable
+			} else if code == http.StatusOK {
+				status = "degraded"
+				degraded = true
+			}
+			continue
+		}
+		checks[check.Name] = "up"
+	}
+
+	_ = WriteJSON(w, code, Envelope{
+		"status":   status,
+		"degraded": degraded,
+		"system_info": map[string]string{
+			"environment": SystemEnv(r.Context()),
+			"version":     SystemVersion(r.Context()),
+		},
+		"checks": checks,
+	}, nil)
+}
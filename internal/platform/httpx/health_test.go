@@ -0,0 +1,69 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func probe(err error) func(context.Context) error {
+	return func(context.Context) error { return err }
+}
+
+func TestHealthHandler(t *testing.T) {
+	down := errors.New("connection refused")
+
+	tests := []struct {
+		name             string
+		postgres         error
+		nats             error
+		expectedStatus   int
+		expectedState    string
+		expectedDegraded bool
+		expectedChecks   map[string]any
+	}{
+		{
+			name:           "all dependencies up",
+			expectedStatus: http.StatusOK, expectedState: "available",
+			expectedChecks: map[string]any{"postgres": "up", "nats": "up"},
+		},
+		{
+			name: "broker down degrades", nats: down,
+			expectedStatus: http.StatusOK, expectedState: "degraded", expectedDegraded: true,
+			expectedChecks: map[string]any{"postgres": "up", "nats": "down"},
+		},
+		{
+			name: "database down is unavailable", postgres: down, nats: down,
+			expectedStatus: http.StatusServiceUnavailable, expectedState: "unavailable",
+			expectedChecks: map[string]any{"postgres": "down", "nats": "down"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewHealthHandler(
+				HealthCheck{Name: "postgres", Critical: true, Probe: probe(tt.postgres)},
+				HealthCheck{Name: "nats", Probe: probe(tt.nats)},
+			)
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, responseRecorder.Code)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedState, body["status"])
+			assert.Equal(t, tt.expectedDegraded, body["degraded"])
+			assert.Equal(t, tt.expectedChecks, body["checks"])
+		})
+	}
+}
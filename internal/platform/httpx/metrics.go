@@ -5,22 +5,42 @@ import (
 	"strconv"
 	"time"
 
-	"go.opentelemetry.io/otel"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
 
 var (
-	meter                  = otel.Meter("s-works/api")
+	meter                  = telemetry.Meter()
 	httpRequestDuration    metric.Float64Histogram
 	httpRequestCounter     metric.Int64Counter
 	FabricGetByCodeCounter metric.Int64Counter
+	RejectedQueryCounter   metric.Int64Counter
+	ShedRequestCounter     metric.Int64Counter
+	ERPConflictCounter     metric.Int64Counter
+	ERPDeadLetterCounter   metric.Int64Counter
+	RepositoryCallDuration metric.Float64Histogram
+	OutboxDepthGauge       metric.Int64Gauge
+	OutboxFailedCounter    metric.Int64Counter
+	OutboxWithheldCounter  metric.Int64Counter
+	ProbeRunCounter        metric.Int64Counter
+	ProbeDuration          metric.Float64Histogram
 )
 
 func init() {
 	httpRequestDuration, _ = meter.Float64Histogram("http.server.duration")
 	httpRequestCounter, _ = meter.Int64Counter("http.server.requests")
 	FabricGetByCodeCounter, _ = meter.Int64Counter("fabric.get_by_code.total")
+	RejectedQueryCounter, _ = meter.Int64Counter("http.server.rejected_queries")
+	ShedRequestCounter, _ = meter.Int64Counter("http.server.shed_requests")
+	ERPConflictCounter, _ = meter.Int64Counter("erp.fabric.conflicts")
+	ERPDeadLetterCounter, _ = meter.Int64Counter("erp.fabric.dead_lettered")
+	RepositoryCallDuration, _ = meter.Float64Histogram("repository.call.duration")
+	OutboxDepthGauge, _ = meter.Int64Gauge("outbox.pending")
+	OutboxFailedCounter, _ = meter.Int64Counter("outbox.publish.failed")
+	OutboxWithheldCounter, _ = meter.Int64Counter("outbox.publish.withheld")
+	ProbeRunCounter, _ = meter.Int64Counter("synthetic_probe.runs")
+	ProbeDuration, _ = meter.Float64Histogram("synthetic_probe.duration")
 }
 
 func MetricsMiddleware(next http.Handler) http.Handler {
@@ -53,3 +73,7 @@ func (rr *responseRecorder) WriteHeader(code int) {
 	rr.status = code
 	rr.ResponseWriter.WriteHeader(code)
 }
+
+func (rr *responseRecorder) Unwrap() http.ResponseWriter {
+	return rr.ResponseWriter
+}
@@ -7,7 +7,9 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
-	"go.opentelemetry.io/otel"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -20,6 +22,9 @@ const (
 	ctxKeyVersion ctxKey = "version"
 )
 
+// UserIDHeader carries the user ID of the principal authenticated upstream (clerk).
+const UserIDHeader = "X-User-ID"
+
 func RecoverPanic(logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -53,6 +58,11 @@ func (rw *statusResponseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// exposes the wrapped writer to http.ResponseController, so streaming handlers can flush
+func (rw *statusResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // injects a per-request logger into the context, includes request_id, method, and path in the logger fields
 func RequestLoggerMiddleware(baseLogger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -61,7 +71,8 @@ func RequestLoggerMiddleware(baseLogger *slog.Logger) func(http.Handler) http.Ha
 
 			// a global propagator will automatically be used to check for incoming headers
 			// (like x-cloud-trace-context) and link this new span to the parent trace if one exists.
-			ctx, span := otel.Tracer("s-works/api").Start(r.Context(), r.URL.Path)
+			// the span is renamed after the route pattern once the router matched the request
+			ctx, span := telemetry.Tracer().Start(r.Context(), r.Method)
 			defer span.End()
 
 			spanID := span.SpanContext().SpanID().String()
@@ -83,6 +94,13 @@ func RequestLoggerMiddleware(baseLogger *slog.Logger) func(http.Handler) http.Ha
 			rw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
 			next.ServeHTTP(rw, r)
 
+			span.SetName(routeSpanName(r))
+			span.SetAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", routePattern(r)),
+				attribute.Int("http.response.status_code", rw.status),
+			)
+
 			logger.Info("request finished", "status", rw.status)
 		})
 	}
@@ -107,6 +125,37 @@ func SystemContextMiddleware(env, version string) func(http.Handler) http.Handle
 	}
 }
 
+// injects the authenticated principal's user ID and the scopes granted to it into the
+// context, so commands can record who performed them and responses can leave out what the
+// principal may not see. Scopes come from the grants only, never from the request.
+func PrincipalMiddleware(grants ScopeGrants) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if userID := r.Header.Get(UserIDHeader); userID != "" {
+				ctx := command.WithUserID(r.Context(), userID)
+				r = r.WithContext(command.WithScopes(ctx, grants[userID]))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// stands in for PrincipalMiddleware when authentication is disabled in development: requests
+// without a principal act as the given development user
+func DevPrincipalMiddleware(userID string, grants ScopeGrants) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := r.Header.Get(UserIDHeader)
+			if principal == "" {
+				principal = userID
+			}
+			ctx := command.WithUserID(r.Context(), principal)
+			ctx = command.WithScopes(ctx, grants[principal])
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 func SystemEnv(ctx context.Context) string {
 	if v, ok := ctx.Value(ctxKeyEnv).(string); ok {
 		return v
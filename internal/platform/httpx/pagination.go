@@ -0,0 +1,105 @@
+package httpx
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+)
+
+// PaginationConfig holds the server-side paging limits, loaded from configuration.
+type PaginationConfig struct {
+	DefaultPageSize int
+	MaxPageSize     int
+	MaxExportRows   int
+}
+
+// Pagination is a validated page request.
+type Pagination struct {
+	Page     int
+	PageSize int
+}
+
+// PageMetadata describes the returned page of a collection.
+type PageMetadata struct {
+	CurrentPage  int `json:"current_page,omitempty"`
+	PageSize     int `json:"page_size,omitempty"`
+	FirstPage    int `json:"first_page,omitempty"`
+	LastPage     int `json:"last_page,omitempty"`
+	TotalRecords int `json:"total_records"`
+}
+
+// reads page and page_size from the query string, falling back to the configured
+// default page size and recording validation errors when the limits are exceeded
+func ReadPagination(r *http.Request, cfg PaginationConfig, v *validator.Validator) Pagination {
+	qs := r.URL.Query()
+	p := Pagination{
+		Page:     readInt(qs.Get("page"), 1, "page", v),
+		PageSize: readInt(qs.Get("page_size"), cfg.DefaultPageSize, "page_size", v),
+	}
+
+	v.Check(p.Page > 0, "page", "must be greater than zero")
+	v.Check(p.Page <= 10_000_000, "page", "must be a maximum of 10 million")
+	v.Check(p.PageSize > 0, "page_size", "must be greater than zero")
+	v.Check(p.PageSize <= cfg.MaxPageSize, "page_size", fmt.Sprintf("must be a maximum of %d", cfg.MaxPageSize))
+
+	return p
+}
+
+// reads the sort query parameter, a comma-separated list of keys such as "name,-version",
+// recording a validation error unless every key is in the safelist and no column repeats
+func ReadSort(r *http.Request, defaultSort string, safelist []string, v *validator.Validator) string {
+	sort := r.URL.Query().Get("sort")
+	if sort == "" {
+		return defaultSort
+	}
+
+	keys := strings.Split(sort, ",")
+	columns := make([]string, 0, len(keys))
+	for _, key := range keys {
+		v.Check(validator.PermittedValue(key, safelist...), "sort", "invalid sort value")
+		columns = append(columns, strings.TrimPrefix(key, "-"))
+	}
+	v.Check(validator.Unique(columns), "sort", "sort must not name a column twice")
+	return sort
+}
+
+func (p Pagination) Limit() int {
+	return p.PageSize
+}
+
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// builds the page metadata for a response, an empty result yields only the total
+func CalculateMetadata(totalRecords, page, pageSize int) PageMetadata {
+	if totalRecords == 0 {
+		return PageMetadata{}
+	}
+
+	return PageMetadata{
+		CurrentPage:  page,
+		PageSize:     pageSize,
+		FirstPage:    1,
+		LastPage:     int(math.Ceil(float64(totalRecords) / float64(pageSize))),
+		TotalRecords: totalRecords,
+	}
+}
+
+func readInt(value string, defaultValue int, key string, v *validator.Validator) int {
+	if value == "" {
+		return defaultValue
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		v.AddError(key, "must be an integer value")
+		return defaultValue
+	}
+
+	return i
+}
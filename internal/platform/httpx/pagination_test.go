@@ -0,0 +1,121 @@
+package httpx
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPaginationConfig = PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100, MaxExportRows: 1000}
+
+func TestReadPagination_Defaults(t *testing.T) {
+	// --- Arrange ---
+	r, err := http.NewRequest(http.MethodGet, "/v1/fabrics", nil)
+	require.NoError(t, err)
+	v := validator.New()
+
+	// --- Act ---
+	p := ReadPagination(r, testPaginationConfig, v)
+
+	// --- Assert ---
+	assert.True(t, v.Valid())
+	assert.Equal(t, 1, p.Page)
+	assert.Equal(t, 20, p.PageSize)
+	assert.Equal(t, 0, p.Offset())
+	assert.Equal(t, 20, p.Limit())
+}
+
+func TestReadPagination_Limits(t *testing.T) {
+	testCases := []struct {
+		name        string
+		query       string
+		expectedKey string
+	}{
+		{name: "Page size above maximum", query: "page_size=1000000", expectedKey: "page_size"},
+		{name: "Zero page size", query: "page_size=0", expectedKey: "page_size"},
+		{name: "Non numeric page", query: "page=abc", expectedKey: "page"},
+		{name: "Negative page", query: "page=-1", expectedKey: "page"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			r, err := http.NewRequest(http.MethodGet, "/v1/fabrics?"+tc.query, nil)
+			require.NoError(t, err)
+			v := validator.New()
+
+			// --- Act ---
+			ReadPagination(r, testPaginationConfig, v)
+
+			// --- Assert ---
+			assert.False(t, v.Valid())
+			assert.Contains(t, v.Errors, tc.expectedKey)
+		})
+	}
+}
+
+func TestReadPagination_Offset(t *testing.T) {
+	// --- Arrange ---
+	r, err := http.NewRequest(http.MethodGet, "/v1/fabrics?page=3&page_size=25", nil)
+	require.NoError(t, err)
+	v := validator.New()
+
+	// --- Act ---
+	p := ReadPagination(r, testPaginationConfig, v)
+
+	// --- Assert ---
+	assert.True(t, v.Valid())
+	assert.Equal(t, 50, p.Offset())
+	assert.Equal(t, 25, p.Limit())
+}
+
+func TestReadSort(t *testing.T) {
+	safelist := []string{"code", "-code", "name", "-name", "version", "-version"}
+	testCases := []struct {
+		name         string
+		query        string
+		expectedSort string
+		expectValid  bool
+	}{
+		{name: "Default", query: "", expectedSort: "code", expectValid: true},
+		{name: "Single key", query: "sort=-name", expectedSort: "-name", expectValid: true},
+		{name: "Several keys", query: "sort=name,-version", expectedSort: "name,-version", expectValid: true},
+		{name: "Unknown key", query: "sort=name,colour"},
+		{name: "Empty key", query: "sort=name,"},
+		{name: "Repeated column", query: "sort=name,-name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			r, err := http.NewRequest(http.MethodGet, "/v1/fabrics?"+tc.query, nil)
+			require.NoError(t, err)
+			v := validator.New()
+
+			// --- Act ---
+			sort := ReadSort(r, "code", safelist, v)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectValid, v.Valid())
+			if tc.expectValid {
+				assert.Equal(t, tc.expectedSort, sort)
+			} else {
+				assert.Contains(t, v.Errors, "sort")
+			}
+		})
+	}
+}
+
+func TestCalculateMetadata(t *testing.T) {
+	assert.Equal(t, PageMetadata{}, CalculateMetadata(0, 1, 20))
+	assert.Equal(t, PageMetadata{
+		CurrentPage:  2,
+		PageSize:     20,
+		FirstPage:    1,
+		LastPage:     3,
+		TotalRecords: 41,
+	}, CalculateMetadata(41, 2, 20))
+}
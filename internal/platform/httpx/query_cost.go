@@ -0,0 +1,85 @@
+package httpx
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// QueryCostLimits bound the combinations of search, sort and page size a list
+// endpoint accepts, protecting the shared database from expensive scans.
+type QueryCostLimits struct {
+	MinSearchLength           int
+	MaxUnindexedSortPageSize  int
+	MaxSearchWithSortPageSize int
+}
+
+// DefaultQueryCostLimits are applied when no limits are configured.
+var DefaultQueryCostLimits = QueryCostLimits{
+	MinSearchLength:           3,
+	MaxUnindexedSortPageSize:  50,
+	MaxSearchWithSortPageSize: 25,
+}
+
+// QueryShape describes the cost-relevant parts of a list request.
+type QueryShape struct {
+	Search      string
+	SortIndexed bool
+	PageSize    int
+}
+
+// QueryCostError explains why a query was rejected and how to make it cheaper.
+type QueryCostError struct {
+	Reason string
+	Advice string
+}
+
+func (e *QueryCostError) Error() string {
+	return fmt.Sprintf("query rejected as too expensive (%s)", e.Reason)
+}
+
+// returns a QueryCostError when the query shape exceeds the limits, nil otherwise
+func CheckQueryCost(limits QueryCostLimits, shape QueryShape) *QueryCostError {
+	searching := shape.Search != ""
+
+	switch {
+	case searching && len([]rune(shape.Search)) < limits.MinSearchLength:
+		return &QueryCostError{
+			Reason: "search_too_short",
+			Advice: fmt.Sprintf("use at least %d characters in q", limits.MinSearchLength),
+		}
+	case searching && !shape.SortIndexed && shape.PageSize > limits.MaxSearchWithSortPageSize:
+		return &QueryCostError{
+			Reason: "unindexed_sort_with_search",
+			Advice: fmt.Sprintf(
+				"sort by an indexed field or request at most %d records per page when searching",
+				limits.MaxSearchWithSortPageSize,
+			),
+		}
+	case !shape.SortIndexed && shape.PageSize > limits.MaxUnindexedSortPageSize:
+		return &QueryCostError{
+			Reason: "unindexed_sort_large_page",
+			Advice: fmt.Sprintf(
+				"sort by an indexed field or request at most %d records per page",
+				limits.MaxUnindexedSortPageSize,
+			),
+		}
+	}
+
+	return nil
+}
+
+// responds with 400 and advice for a rejected query, and counts the rejection
+func QueryTooExpensive(w http.ResponseWriter, r *http.Request, err *QueryCostError) {
+	RejectedQueryCounter.Add(r.Context(), 1, metric.WithAttributes(
+		attribute.String("path", r.URL.Path),
+		attribute.String("reason", err.Reason),
+	))
+
+	_ = WriteJSON(w, http.StatusBadRequest, Envelope{
+		"error":  err.Error(),
+		"advice": err.Advice,
+	}, nil)
+}
@@ -0,0 +1,61 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckQueryCost(t *testing.T) {
+	testCases := []struct {
+		name           string
+		shape          QueryShape
+		expectedReason string
+	}{
+		{name: "Indexed sort with large page", shape: QueryShape{SortIndexed: true, PageSize: 100}},
+		{name: "Unindexed sort with small page", shape: QueryShape{PageSize: 50}},
+		{name: "Search with indexed sort", shape: QueryShape{Search: "velvet", SortIndexed: true, PageSize: 100}},
+		{name: "Search with unindexed sort and small page", shape: QueryShape{Search: "velvet", PageSize: 25}},
+		{name: "Short search", shape: QueryShape{Search: "ve", SortIndexed: true, PageSize: 10}, expectedReason: "search_too_short"},
+		{name: "Search with unindexed sort and large page", shape: QueryShape{Search: "velvet", PageSize: 26}, expectedReason: "unindexed_sort_with_search"},
+		{name: "Unindexed sort with large page", shape: QueryShape{PageSize: 51}, expectedReason: "unindexed_sort_large_page"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			err := CheckQueryCost(DefaultQueryCostLimits, tc.shape)
+
+			// --- Assert ---
+			if tc.expectedReason == "" {
+				assert.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			assert.Equal(t, tc.expectedReason, err.Reason)
+			assert.NotEmpty(t, err.Advice)
+		})
+	}
+}
+
+func TestQueryTooExpensive(t *testing.T) {
+	// --- Arrange ---
+	r, err := http.NewRequest(http.MethodGet, "/v1/fabrics?sort=name&page_size=100", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	costErr := &QueryCostError{Reason: "unindexed_sort_large_page", Advice: "sort by an indexed field"}
+
+	// --- Act ---
+	QueryTooExpensive(w, r, costErr)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "sort by an indexed field", body["advice"])
+	assert.Contains(t, body["error"], "unindexed_sort_large_page")
+}
@@ -0,0 +1,89 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/readonly"
+)
+
+// how long a client refused by read-only mode is asked to wait before retrying, a restore
+// takes minutes rather than seconds
+const readOnlyRetryAfter = 60 * time.Second
+
+// ReadOnlyMiddleware refuses every request that may change state with 503 while the mode
+// is on. Safe methods pass through, so queries keep being served during a restore.
+func ReadOnlyMiddleware(mode *readonly.Mode) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !mode.Enabled() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			headers := http.Header{"Retry-After": []string{strconv.Itoa(int(readOnlyRetryAfter / time.Second))}}
+			_ = WriteJSON(w, http.StatusServiceUnavailable, Envelope{
+				"error": readonly.ErrReadOnly.Error() + ", retry later",
+			}, headers)
+		})
+	}
+}
+
+// ReadOnlyHandler reports and switches read-only mode. It must be routed outside
+// ReadOnlyMiddleware, or the mode could not be switched off again.
+type ReadOnlyHandler struct {
+	mode  *readonly.Mode
+	clock clock.Clock
+}
+
+func NewReadOnlyHandler(mode *readonly.Mode, clock clock.Clock) *ReadOnlyHandler {
+	return &ReadOnlyHandler{mode: mode, clock: clock}
+}
+
+func (h *ReadOnlyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeStatus(w, r)
+	case http.MethodPut:
+		h.setMode(w, r)
+	default:
+		MethodNotAllowed(w, r)
+	}
+}
+
+func (h *ReadOnlyHandler) setMode(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Enabled *bool  `json:"enabled"`
+		Reason  string `json:"reason"`
+	}
+	if err := ReadJSON(w, r, &input); err != nil {
+		BadRequest(w, r, err)
+		return
+	}
+	if input.Enabled == nil {
+		BadRequest(w, r, errors.New("body must contain enabled"))
+		return
+	}
+
+	actor := command.Actor(r.Context())
+	if h.mode.Set(*input.Enabled, input.Reason, actor, h.clock.Now()) {
+		GetLogger(r.Context()).Warn("read-only mode switched",
+			"enabled", *input.Enabled, "reason", input.Reason, "by", actor)
+	}
+	h.writeStatus(w, r)
+}
+
+func (h *ReadOnlyHandler) writeStatus(w http.ResponseWriter, r *http.Request) {
+	if err := WriteJSON(w, http.StatusOK, Envelope{"read_only": h.mode.Status()}, nil); err != nil {
+		InternalError(w, r, err)
+	}
+}
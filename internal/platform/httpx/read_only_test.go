@@ -0,0 +1,79 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/readonly"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	testCases := []struct {
+		name           string
+		enabled        bool
+		method         string
+		expectedStatus int
+	}{
+		{name: "Query while read-only", enabled: true, method: http.MethodGet, expectedStatus: http.StatusOK},
+		{name: "Command while read-only", enabled: true, method: http.MethodPost, expectedStatus: http.StatusServiceUnavailable},
+		{name: "Delete while read-only", enabled: true, method: http.MethodDelete, expectedStatus: http.StatusServiceUnavailable},
+		{name: "Command while writable", enabled: false, method: http.MethodPut, expectedStatus: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+			handler := ReadOnlyMiddleware(readonly.New(tc.enabled))(next)
+
+			// --- Act ---
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(tc.method, "/v1/fabrics", nil))
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusServiceUnavailable {
+				assert.Equal(t, "60", rr.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestReadOnlyHandler_SetMode(t *testing.T) {
+	// --- Arrange ---
+	at := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	mode := readonly.New(false)
+	handler := NewReadOnlyHandler(mode, clock.NewFixed(at))
+	req := httptest.NewRequest(http.MethodPut, "/v1/admin/read-only",
+		strings.NewReader(`{"enabled": true, "reason": "restoring primary"}`))
+
+	// --- Act ---
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, mode.Enabled())
+	assert.Contains(t, rr.Body.String(), `"reason": "restoring primary"`)
+}
+
+func TestReadOnlyHandler_SetMode_RequiresEnabled(t *testing.T) {
+	// --- Arrange ---
+	mode := readonly.New(true)
+	handler := NewReadOnlyHandler(mode, clock.New())
+	req := httptest.NewRequest(http.MethodPut, "/v1/admin/read-only", strings.NewReader(`{"reason": "oops"}`))
+
+	// --- Act ---
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.True(t, mode.Enabled(), "a request without enabled must not switch the mode")
+}
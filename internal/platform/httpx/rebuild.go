@@ -0,0 +1,58 @@
+package httpx
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/platform/rebuild"
+)
+
+// DegradedHeader names the rebuilds a response was served during, its data incomplete
+// until they are done.
+const DegradedHeader = "X-Degraded"
+
+// RebuildMiddleware marks the responses of the endpoint as degraded while the data it reads
+// from the sources is rebuilt, and keeps them out of caches, so clients can tell an
+// incomplete result from a complete one and ask again later. The endpoint is listed on the
+// readiness report of the rebuild meanwhile.
+func RebuildMiddleware(tracker *rebuild.Tracker, endpoint string, sources ...string) func(http.Handler) http.Handler {
+	tracker.Reads(endpoint, sources...)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if names := tracker.Rebuilding(sources...); len(names) > 0 {
+				w.Header().Set(DegradedHeader, strings.Join(names, ", "))
+				w.Header().Set("Cache-Control", "no-store")
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ReadinessHandler reports whether the data the query endpoints read is complete, listing
+// the rebuilds in progress with the endpoints each degrades. The instance keeps serving
+// meanwhile, so the report is always answered 200.
+type ReadinessHandler struct {
+	tracker *rebuild.Tracker
+}
+
+func NewReadinessHandler(tracker *rebuild.Tracker) *ReadinessHandler {
+	return &ReadinessHandler{tracker: tracker}
+}
+
+func (h *ReadinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	runs := h.tracker.Running()
+	status := "ready"
+	if len(runs) > 0 {
+		status = "degraded"
+	}
+
+	err := WriteJSON(w, http.StatusOK, Envelope{
+		"status":   status,
+		"degraded": len(runs) > 0,
+		"rebuilds": runs,
+	}, nil)
+	if err != nil {
+		InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,80 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/rebuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildMiddleware(t *testing.T) {
+	// --- Arrange ---
+	tracker := rebuild.New()
+	handler := RebuildMiddleware(tracker, "GET /v1/fabrics/changes", rebuild.EventStore)(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	serve := func() *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/fabrics/changes", nil))
+		return rr
+	}
+
+	// --- Act ---
+	before := serve()
+	finish := tracker.Start("event-import", []string{rebuild.EventStore}, "ops", time.Now())
+	during := serve()
+	finish()
+	after := serve()
+
+	// --- Assert ---
+	assert.Empty(t, before.Header().Get(DegradedHeader))
+	assert.Equal(t, "event-import", during.Header().Get(DegradedHeader))
+	assert.Equal(t, "no-store", during.Header().Get("Cache-Control"), "an incomplete result must not be cached")
+	assert.Empty(t, after.Header().Get(DegradedHeader))
+	assert.Empty(t, after.Header().Get("Cache-Control"))
+}
+
+func TestReadinessHandler(t *testing.T) {
+	// --- Arrange ---
+	tracker := rebuild.New()
+	tracker.Reads("GET /v1/fabrics/changes", rebuild.EventStore)
+	handler := NewReadinessHandler(tracker)
+	type report struct {
+		Status   string        `json:"status"`
+		Degraded bool          `json:"degraded"`
+		Rebuilds []rebuild.Run `json:"rebuilds"`
+	}
+	serve := func() (int, report) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var body report
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		return rr.Code, body
+	}
+
+	// --- Act ---
+	readyStatus, ready := serve()
+	finish := tracker.Start("event-import", []string{rebuild.EventStore}, "ops", time.Now())
+	degradedStatus, degraded := serve()
+	finish()
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, readyStatus)
+	assert.Equal(t, "ready", ready.Status)
+	assert.False(t, ready.Degraded)
+	assert.Empty(t, ready.Rebuilds)
+
+	assert.Equal(t, http.StatusOK, degradedStatus, "the instance keeps serving during a rebuild")
+	assert.Equal(t, "degraded", degraded.Status)
+	assert.True(t, degraded.Degraded)
+	require.Len(t, degraded.Rebuilds, 1)
+	assert.Equal(t, "event-import", degraded.Rebuilds[0].Name)
+	assert.Equal(t, []string{"GET /v1/fabrics/changes"}, degraded.Rebuilds[0].DegradedEndpoints)
+}
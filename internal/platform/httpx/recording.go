@@ -0,0 +1,221 @@
+package httpx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/recording"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// how long recording stays on when switched on without a duration
+const defaultRecordingDuration = 15 * time.Minute
+
+// routes of the recorder itself, never recorded so reading the buffer does not fill it
+const recordingAdminPrefix = "/v1/admin/recording"
+
+// RecordingMiddleware captures the request/response pairs matching the settings of the
+// recorder. Nothing is captured while recording is off; once on, bodies are copied as they
+// are read and written, and the route is matched after the router resolved it.
+func RecordingMiddleware(recorder *recording.Recorder, clock clock.Clock) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := clock.Now()
+			if !recorder.Active(start) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			requestBody := &capturedBody{ReadCloser: r.Body}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = requestBody
+			}
+			rw := &recordingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r)
+
+			route := routePattern(r)
+			if strings.HasPrefix(route, recordingAdminPrefix) {
+				return
+			}
+			fabricCode := URLParam(r, "code")
+			if fabricCode == "" {
+				fabricCode = URLParam(r, "fabricCode")
+			}
+			now := clock.Now()
+			if !recorder.Matches(route, fabricCode, now) {
+				return
+			}
+
+			recorder.Record(recording.Exchange{
+				RecordedAt:      now,
+				TraceID:         trace.SpanContextFromContext(r.Context()).TraceID().String(),
+				Method:          r.Method,
+				Route:           route,
+				Path:            r.URL.Path,
+				Query:           r.URL.RawQuery,
+				RequestHeaders:  recording.SanitizeHeaders(r.Header),
+				RequestBody:     recording.SanitizeBody(requestBody.buf.Bytes(), r.Header.Get("Content-Type")),
+				Status:          rw.status,
+				ResponseHeaders: recording.SanitizeHeaders(rw.Header()),
+				ResponseBody:    recording.SanitizeBody(rw.buf.Bytes(), rw.Header().Get("Content-Type")),
+				DurationMS:      now.Sub(start).Milliseconds(),
+				Truncated:       requestBody.truncated || rw.truncated,
+			})
+		})
+	}
+}
+
+// capturedBody copies the first MaxBodyBytes of the request body as the handler reads it
+type capturedBody struct {
+	io.ReadCloser
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (b *capturedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.truncated = captureBytes(&b.buf, p[:n]) || b.truncated
+	return n, err
+}
+
+// recordingResponseWriter copies the status and the first MaxBodyBytes of the response
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	buf         bytes.Buffer
+	truncated   bool
+	wroteHeader bool
+}
+
+func (rw *recordingResponseWriter) WriteHeader(status int) {
+	if !rw.wroteHeader {
+		rw.status = status
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *recordingResponseWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	rw.truncated = captureBytes(&rw.buf, b) || rw.truncated
+	return rw.ResponseWriter.Write(b)
+}
+
+// exposes the wrapped writer to http.ResponseController, so streaming handlers can flush
+func (rw *recordingResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// captureBytes appends what fits under MaxBodyBytes, reporting whether anything was cut off
+func captureBytes(buf *bytes.Buffer, p []byte) bool {
+	room := recording.MaxBodyBytes - buf.Len()
+	if len(p) <= room {
+		buf.Write(p)
+		return false
+	}
+	if room > 0 {
+		buf.Write(p[:room])
+	}
+	return true
+}
+
+// RecordingHandler switches the debug recorder and serves what it recorded. It reports
+// and changes the settings on the recording route, and lists or clears the recorded
+// exchanges on its exchanges route.
+type RecordingHandler struct {
+	recorder *recording.Recorder
+	clock    clock.Clock
+}
+
+func NewRecordingHandler(recorder *recording.Recorder, clock clock.Clock) *RecordingHandler {
+	return &RecordingHandler{recorder: recorder, clock: clock}
+}
+
+func (h *RecordingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	exchanges := path.Base(r.URL.Path) == "exchanges"
+	switch {
+	case r.Method == http.MethodGet && exchanges:
+		h.writeExchanges(w, r)
+	case r.Method == http.MethodDelete && exchanges:
+		h.recorder.Clear()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet:
+		h.writeSettings(w, r, h.recorder.Settings(h.clock.Now()))
+	case r.Method == http.MethodPut:
+		h.setRecording(w, r)
+	default:
+		MethodNotAllowed(w, r)
+	}
+}
+
+func (h *RecordingHandler) setRecording(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Enabled    *bool    `json:"enabled"`
+		Route      string   `json:"route"`
+		FabricCode string   `json:"fabric_code"`
+		SampleRate *float64 `json:"sample_rate"`
+		Duration   string   `json:"duration"`
+	}
+	if err := ReadJSON(w, r, &input); err != nil {
+		BadRequest(w, r, err)
+		return
+	}
+	if input.Enabled == nil {
+		BadRequest(w, r, errors.New("body must contain enabled"))
+		return
+	}
+
+	actor := command.Actor(r.Context())
+	now := h.clock.Now()
+	if !*input.Enabled {
+		settings := h.recorder.Disable(actor, now)
+		GetLogger(r.Context()).Info("request recording switched off", "by", actor)
+		h.writeSettings(w, r, settings)
+		return
+	}
+
+	sampleRate := 1.0
+	if input.SampleRate != nil {
+		sampleRate = *input.SampleRate
+	}
+	duration := defaultRecordingDuration
+	if input.Duration != "" {
+		parsed, err := time.ParseDuration(input.Duration)
+		if err != nil {
+			ValidationError(w, r, map[string]string{"duration": "must be a duration such as 15m"})
+			return
+		}
+		duration = parsed
+	}
+
+	settings, err := h.recorder.Enable(
+		input.Route, strings.ToUpper(strings.TrimSpace(input.FabricCode)), sampleRate, duration, actor, now,
+	)
+	if err != nil {
+		ValidationError(w, r, map[string]string{"recording": err.Error()})
+		return
+	}
+	GetLogger(r.Context()).Warn("request recording switched on",
+		"route", settings.Route, "fabric_code", settings.FabricCode,
+		"sample_rate", settings.SampleRate, "expires_at", settings.ExpiresAt, "by", actor)
+	h.writeSettings(w, r, settings)
+}
+
+func (h *RecordingHandler) writeSettings(w http.ResponseWriter, r *http.Request, settings recording.Settings) {
+	if err := WriteJSON(w, http.StatusOK, Envelope{"recording": settings}, nil); err != nil {
+		InternalError(w, r, err)
+	}
+}
+
+func (h *RecordingHandler) writeExchanges(w http.ResponseWriter, r *http.Request) {
+	if err := WriteJSON(w, http.StatusOK, Envelope{"exchanges": h.recorder.Exchanges()}, nil); err != nil {
+		InternalError(w, r, err)
+	}
+}
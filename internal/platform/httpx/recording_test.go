@@ -0,0 +1,210 @@
+package httpx
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/recording"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var recordingNow = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+// recordingRouter serves an echo of the request body on a fabric route behind the middleware
+func recordingRouter(recorder *recording.Recorder) http.Handler {
+	router := chi.NewRouter()
+	router.Route("/v1", func(r chi.Router) {
+		r.Use(RecordingMiddleware(recorder, clock.NewFixed(recordingNow)))
+		r.Put("/fabrics/{code}", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write(body)
+		})
+		r.Get("/fabrics", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		r.Method(http.MethodGet, "/admin/recording/exchanges", NewRecordingHandler(recorder, clock.NewFixed(recordingNow)))
+	})
+	return router
+}
+
+func TestRecordingMiddleware_RecordsMatchingRequests(t *testing.T) {
+	// --- Arrange ---
+	recorder := recording.New(10)
+	_, err := recorder.Enable("", "FAB01", 1, time.Hour, "ops", recordingNow)
+	require.NoError(t, err)
+	router := recordingRouter(recorder)
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/fabrics/FAB01", strings.NewReader(`{"name": "Linen", "token": "t0k3n"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+
+	// --- Act ---
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/v1/fabrics/FAB02", strings.NewReader(`{}`)))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/admin/recording/exchanges", nil))
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.JSONEq(t, `{"name": "Linen", "token": "t0k3n"}`, rr.Body.String(), "the handler still reads the whole body")
+
+	exchanges := recorder.Exchanges()
+	require.Len(t, exchanges, 1, "other fabrics and the recorder itself are not recorded")
+	exchange := exchanges[0]
+	assert.Equal(t, "/v1/fabrics/{code}", exchange.Route)
+	assert.Equal(t, "/v1/fabrics/FAB01", exchange.Path)
+	assert.Equal(t, http.StatusUnprocessableEntity, exchange.Status)
+	assert.Equal(t, "[REDACTED]", exchange.RequestHeaders["Authorization"])
+	assert.JSONEq(t, `{"name": "Linen", "token": "[REDACTED]"}`, exchange.RequestBody)
+	assert.JSONEq(t, `{"name": "Linen", "token": "[REDACTED]"}`, exchange.ResponseBody)
+	assert.Equal(t, recordingNow, exchange.RecordedAt)
+}
+
+func TestRecordingMiddleware_RedactsCredentials(t *testing.T) {
+	// --- Arrange ---
+	recorder := recording.New(10)
+	_, err := recorder.Enable("", "FAB01", 1, time.Hour, "ops", recordingNow)
+	require.NoError(t, err)
+	router := recordingRouter(recorder)
+
+	body := `{"name": "Linen", "password": "hunter2", "webhook": {"client_secret": "s3cret", "api_key": "k3y"}}`
+	req := httptest.NewRequest(http.MethodPut, "/v1/fabrics/FAB01", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin-token")
+	req.Header.Set("Proxy-Authorization", "Basic cHJveHk6cGFzcw==")
+	req.Header.Set("Cookie", "session=abc")
+	req.Header.Set("X-Api-Key", "k3y")
+	req.Header.Set("X-Auth-Token", "t0k3n")
+	req.Header.Set("X-User-ID", "user_123")
+
+	// --- Act ---
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	// --- Assert ---
+	exchanges := recorder.Exchanges()
+	require.Len(t, exchanges, 1)
+	exchange := exchanges[0]
+	for _, header := range []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Api-Key", "X-Auth-Token"} {
+		assert.Equal(t, "[REDACTED]", exchange.RequestHeaders[header], header)
+	}
+	assert.Equal(t, "user_123", exchange.RequestHeaders["X-User-Id"], "the principal is kept to tell who made the request")
+	redactedBody := `{"name": "Linen", "password": "[REDACTED]", "webhook": {"client_secret": "[REDACTED]", "api_key": "[REDACTED]"}}`
+	assert.JSONEq(t, redactedBody, exchange.RequestBody)
+	assert.JSONEq(t, redactedBody, exchange.ResponseBody)
+	recorded, err := json.Marshal(exchange)
+	require.NoError(t, err)
+	for _, secret := range []string{"admin-token", "cHJveHk6cGFzcw==", "session=abc", "k3y", "t0k3n", "hunter2", "s3cret"} {
+		assert.NotContains(t, string(recorded), secret)
+	}
+}
+
+func TestRecordingMiddleware_OffByDefault(t *testing.T) {
+	// --- Arrange ---
+	recorder := recording.New(10)
+	router := recordingRouter(recorder)
+
+	// --- Act ---
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/fabrics", nil))
+
+	// --- Assert ---
+	assert.Empty(t, recorder.Exchanges())
+}
+
+func TestRecordingMiddleware_TruncatesLargeBodies(t *testing.T) {
+	// --- Arrange ---
+	recorder := recording.New(10)
+	_, err := recorder.Enable("/v1/fabrics/{code}", "", 1, time.Hour, "ops", recordingNow)
+	require.NoError(t, err)
+	router := recordingRouter(recorder)
+	large := `{"name": "` + strings.Repeat("x", recording.MaxBodyBytes) + `"}`
+
+	// --- Act ---
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/v1/fabrics/FAB01", strings.NewReader(large)))
+
+	// --- Assert ---
+	assert.Equal(t, len(large), rr.Body.Len(), "the response is not cut off, only its recording")
+	exchanges := recorder.Exchanges()
+	require.Len(t, exchanges, 1)
+	assert.True(t, exchanges[0].Truncated)
+}
+
+func TestRecordingHandler_SetRecording(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedActive bool
+	}{
+		{
+			name: "switch on", body: `{"enabled": true, "route": "/v1/fabrics/{code}", "sample_rate": 0.5, "duration": "30m"}`,
+			expectedStatus: http.StatusOK, expectedActive: true,
+		},
+		{
+			name: "switch on for a fabric", body: `{"enabled": true, "fabric_code": " fab01 "}`,
+			expectedStatus: http.StatusOK, expectedActive: true,
+		},
+		{name: "switch off", body: `{"enabled": false}`, expectedStatus: http.StatusOK},
+		{name: "no filter", body: `{"enabled": true}`, expectedStatus: http.StatusUnprocessableEntity},
+		{
+			name: "too long", body: `{"enabled": true, "route": "/v1/fabrics", "duration": "48h"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "bad duration", body: `{"enabled": true, "route": "/v1/fabrics", "duration": "soon"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{name: "missing enabled", body: `{"route": "/v1/fabrics"}`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			recorder := recording.New(10)
+			handler := NewRecordingHandler(recorder, clock.NewFixed(recordingNow))
+			req := httptest.NewRequest(http.MethodPut, "/v1/admin/recording", strings.NewReader(tt.body))
+
+			// --- Act ---
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectedActive, recorder.Active(recordingNow))
+		})
+	}
+}
+
+func TestRecordingHandler_Exchanges(t *testing.T) {
+	// --- Arrange ---
+	recorder := recording.New(10)
+	recorder.Record(recording.Exchange{Method: http.MethodGet, Path: "/v1/fabrics/FAB01", Status: http.StatusOK})
+	handler := NewRecordingHandler(recorder, clock.NewFixed(recordingNow))
+
+	// --- Act ---
+	listed := httptest.NewRecorder()
+	handler.ServeHTTP(listed, httptest.NewRequest(http.MethodGet, "/v1/admin/recording/exchanges", nil))
+	cleared := httptest.NewRecorder()
+	handler.ServeHTTP(cleared, httptest.NewRequest(http.MethodDelete, "/v1/admin/recording/exchanges", nil))
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, listed.Code)
+	var response struct {
+		Exchanges []recording.Exchange `json:"exchanges"`
+	}
+	require.NoError(t, json.Unmarshal(listed.Body.Bytes(), &response))
+	require.Len(t, response.Exchanges, 1)
+	assert.Equal(t, "/v1/fabrics/FAB01", response.Exchanges[0].Path)
+	assert.Equal(t, http.StatusNoContent, cleared.Code)
+	assert.Empty(t, recorder.Exchanges())
+}
@@ -0,0 +1,203 @@
+package httpx
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+)
+
+// RedactionRule names the response fields only callers granted Scope may see. A path is a
+// dot-separated list of JSON keys from the top of the body, or from the top of each record
+// of an NDJSON stream; arrays on the way are walked into, so "fabrics.Price" stands for the
+// price of every fabric of a listing.
+type RedactionRule struct {
+	Scope string
+	Paths []string
+}
+
+// RedactionMiddleware leaves the fields of the rules whose scope the caller lacks out of
+// JSON responses and NDJSON streams, so sensitive fields are withheld in one place whichever
+// handler returns them. JSON bodies are held back and rewritten once written, streams are
+// rewritten record by record as they go. The responses vary by the caller, which shared
+// caches are told with a Vary header.
+func RedactionMiddleware(rules ...RedactionRule) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var paths [][]string
+			for _, rule := range rules {
+				if command.HasScope(r.Context(), rule.Scope) {
+					continue
+				}
+				for _, path := range rule.Paths {
+					paths = append(paths, strings.Split(path, "."))
+				}
+			}
+
+			rw := &redactingResponseWriter{ResponseWriter: w, paths: paths}
+			next.ServeHTTP(rw, r)
+			rw.flush()
+		})
+	}
+}
+
+// redactingResponseWriter holds back a JSON body with fields to redact until the handler
+// is done writing it, and an NDJSON record until its line is complete
+type redactingResponseWriter struct {
+	http.ResponseWriter
+	paths       [][]string
+	status      int
+	body        *bytes.Buffer
+	records     *bytes.Buffer
+	wroteHeader bool
+}
+
+func (rw *redactingResponseWriter) WriteHeader(status int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.wroteHeader = true
+	rw.Header().Add("Vary", UserIDHeader)
+
+	if len(rw.paths) > 0 {
+		contentType := rw.Header().Get("Content-Type")
+		switch {
+		case strings.HasPrefix(contentType, "application/json"):
+			rw.status = status
+			rw.body = &bytes.Buffer{}
+			return
+		case strings.HasPrefix(contentType, "application/x-ndjson"):
+			rw.Header().Del("Content-Length")
+			rw.records = &bytes.Buffer{}
+		}
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *redactingResponseWriter) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	switch {
+	case rw.body != nil:
+		return rw.body.Write(b)
+	case rw.records != nil:
+		rw.records.Write(b)
+		return len(b), rw.writeRecords()
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
+func (rw *redactingResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// writeRecords writes the complete records held back without the redacted fields, keeping
+// the start of the next one until its line is complete
+func (rw *redactingResponseWriter) writeRecords() error {
+	for {
+		i := bytes.IndexByte(rw.records.Bytes(), '\n')
+		if i < 0 {
+			return nil
+		}
+		record := rw.records.Next(i + 1)
+		if redacted, ok := redactRecord(record, rw.paths); ok {
+			record = redacted
+		}
+		if _, err := rw.ResponseWriter.Write(record); err != nil {
+			return err
+		}
+	}
+}
+
+// flush writes the held back body without the redacted fields. A body that cannot be read
+// as JSON, or holds none of the fields, is written as the handler wrote it. A stream left
+// without its final newline has its last record written as it is too.
+func (rw *redactingResponseWriter) flush() {
+	if rw.records != nil && rw.records.Len() > 0 {
+		_, _ = rw.ResponseWriter.Write(rw.records.Bytes())
+		return
+	}
+	if rw.body == nil {
+		return
+	}
+
+	body := rw.body.Bytes()
+	if redacted, ok := redactJSON(body, rw.paths); ok {
+		body = redacted
+	}
+	rw.Header().Del("Content-Length")
+	rw.ResponseWriter.WriteHeader(rw.status)
+	_, _ = rw.ResponseWriter.Write(body)
+}
+
+// redactJSON removes the paths from the body, reporting false when nothing was removed.
+// The body is written back in the layout of WriteJSON.
+func redactJSON(body []byte, paths [][]string) ([]byte, bool) {
+	value, removed := removePaths(body, paths)
+	if !removed {
+		return nil, false
+	}
+
+	redacted, err := json.MarshalIndent(value, "", "\t")
+	if err != nil {
+		return nil, false
+	}
+	return append(redacted, '\n'), true
+}
+
+// redactRecord removes the paths from a record of an NDJSON stream, reporting false when
+// nothing was removed. The record is written back on a single line.
+func redactRecord(record []byte, paths [][]string) ([]byte, bool) {
+	value, removed := removePaths(record, paths)
+	if !removed {
+		return nil, false
+	}
+
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	return append(redacted, '\n'), true
+}
+
+// removePaths decodes the JSON and removes the paths from it, reporting whether any was
+// there. Numbers are kept as written.
+func removePaths(data []byte, paths [][]string) (any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+
+	removed := false
+	for _, path := range paths {
+		removed = removePath(value, path) || removed
+	}
+	return value, removed
+}
+
+func removePath(value any, path []string) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		child, ok := v[path[0]]
+		if !ok {
+			return false
+		}
+		if len(path) == 1 {
+			delete(v, path[0])
+			return true
+		}
+		return removePath(child, path[1:])
+	case []any:
+		removed := false
+		for _, item := range v {
+			removed = removePath(item, path) || removed
+		}
+		return removed
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactionMiddleware(t *testing.T) {
+	rules := []RedactionRule{
+		{Scope: "fabrics:pricing", Paths: []string{"fabric.Price", "fabrics.Price"}},
+		{Scope: "fabrics:supplier-terms", Paths: []string{"suppliers.lead_time_days"}},
+	}
+
+	tests := []struct {
+		name         string
+		scopes       []string
+		contentType  string
+		body         Envelope
+		expectedBody string
+	}{
+		{
+			name:         "fabric without the scope",
+			contentType:  "application/json",
+			body:         Envelope{"fabric": map[string]any{"Code": "FAB01", "Price": map[string]any{"Amount": 2490}}},
+			expectedBody: `{"fabric": {"Code": "FAB01"}}`,
+		},
+		{
+			name:         "fabric with the scope",
+			scopes:       []string{"fabrics:pricing"},
+			contentType:  "application/json",
+			body:         Envelope{"fabric": map[string]any{"Code": "FAB01", "Price": map[string]any{"Amount": 2490}}},
+			expectedBody: `{"fabric": {"Code": "FAB01", "Price": {"Amount": 2490}}}`,
+		},
+		{
+			name:        "listing and embedded suppliers",
+			scopes:      []string{"fabrics:pricing"},
+			contentType: "application/json",
+			body: Envelope{
+				"fabrics":   []any{map[string]any{"Code": "FAB01", "Price": nil}, map[string]any{"Code": "FAB02"}},
+				"suppliers": []any{map[string]any{"code": "TEXTILIA", "lead_time_days": 14}},
+			},
+			expectedBody: `{"fabrics": [{"Code": "FAB01", "Price": null}, {"Code": "FAB02"}], "suppliers": [{"code": "TEXTILIA"}]}`,
+		},
+		{
+			name:         "body without the fields",
+			contentType:  "application/json",
+			body:         Envelope{"fabric": map[string]any{"Code": "FAB01"}},
+			expectedBody: `{"fabric": {"Code": "FAB01"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := RedactionMiddleware(rules...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = WriteJSON(w, http.StatusOK, tt.body, nil)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/v1/fabrics/FAB01", nil)
+			if tt.scopes != nil {
+				req = req.WithContext(command.WithScopes(req.Context(), tt.scopes))
+			}
+			rr := httptest.NewRecorder()
+
+			// --- Act ---
+			handler.ServeHTTP(rr, req)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+			assert.Equal(t, UserIDHeader, rr.Header().Get("Vary"), "caches must key the response on the caller")
+		})
+	}
+}
+
+func TestRedactionMiddleware_RedactsStreamedRecords(t *testing.T) {
+	// --- Arrange ---
+	rules := []RedactionRule{{Scope: "fabrics:pricing", Paths: []string{"Price"}}}
+	handler := RedactionMiddleware(rules...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Add("Vary", "Accept-Language")
+		_, _ = w.Write([]byte("{\"Code\":\"FAB01\",\"Price\":2490}\n{\"Code\":"))
+		_, _ = w.Write([]byte("\"FAB02\"}\n"))
+	}))
+	rr := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/fabrics/export.ndjson", nil))
+
+	// --- Assert ---
+	assert.Equal(t, "{\"Code\":\"FAB01\"}\n{\"Code\":\"FAB02\"}\n", rr.Body.String(),
+		"records are redacted one by one, also when written in pieces")
+	assert.Equal(t, []string{"Accept-Language", UserIDHeader}, rr.Header().Values("Vary"))
+}
+
+func TestRedactionMiddleware_PassesOtherBodiesThrough(t *testing.T) {
+	// --- Arrange ---
+	rules := []RedactionRule{{Scope: "fabrics:pricing", Paths: []string{"Price"}}}
+	const body = "code,price\nFAB01,2490\n"
+	handler := RedactionMiddleware(rules...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte(body))
+	}))
+	rr := httptest.NewRecorder()
+
+	// --- Act ---
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/fabrics/export.csv", nil))
+
+	// --- Assert ---
+	assert.Equal(t, body, rr.Body.String(), "bodies other than JSON are not rewritten")
+}
@@ -1,6 +1,7 @@
 package httpx
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +20,18 @@ func URLParam(r *http.Request, key string) string {
 	return chi.URLParam(r, key)
 }
 
+// WithURLParam sets a URL parameter of the request, so a handler resolving a resource can
+// hand the request over to the handler serving it.
+func WithURLParam(r *http.Request, key, value string) *http.Request {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		rctx = chi.NewRouteContext()
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	}
+	rctx.URLParams.Add(key, value)
+	return r
+}
+
 func ReadIDParam(r *http.Request) (uuid.UUID, error) {
 	idStr := chi.URLParam(r, "id")
 
@@ -0,0 +1,33 @@
+package httpx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScopeGrants are the scopes granted to principals, by user ID. They are configured on
+// the instance rather than read from the request, so a caller cannot grant itself more.
+type ScopeGrants map[string][]string
+
+// ParseScopeGrants reads a semicolon-separated list of USER_ID=SCOPES entries, the scopes
+// of an entry separated by spaces, such as "user_2a=fabrics:pricing fabrics:supplier-terms".
+// An empty list grants no scope.
+func ParseScopeGrants(raw string) (ScopeGrants, error) {
+	grants := ScopeGrants{}
+	for _, item := range strings.Split(raw, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		userID, scopes, ok := strings.Cut(item, "=")
+		userID = strings.TrimSpace(userID)
+		if !ok || userID == "" || len(strings.Fields(scopes)) == 0 {
+			return nil, fmt.Errorf("scope grant %q must be given as USER_ID=SCOPES", item)
+		}
+		if _, dup := grants[userID]; dup {
+			return nil, fmt.Errorf("scopes of user %q are granted twice", userID)
+		}
+		grants[userID] = strings.Fields(scopes)
+	}
+	return grants, nil
+}
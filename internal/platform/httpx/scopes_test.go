@@ -0,0 +1,65 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScopeGrants(t *testing.T) {
+	testCases := []struct {
+		name           string
+		raw            string
+		expectedGrants ScopeGrants
+		expectedErr    bool
+	}{
+		{name: "empty", raw: "", expectedGrants: ScopeGrants{}},
+		{
+			name:           "several users",
+			raw:            " user_2a=fabrics:pricing  fabrics:supplier-terms ; user_3b=fabrics:pricing;",
+			expectedGrants: ScopeGrants{"user_2a": {"fabrics:pricing", "fabrics:supplier-terms"}, "user_3b": {"fabrics:pricing"}},
+		},
+		{name: "no scopes", raw: "user_2a=", expectedErr: true},
+		{name: "no user", raw: "=fabrics:pricing", expectedErr: true},
+		{name: "user twice", raw: "user_2a=fabrics:pricing;user_2a=fabrics:supplier-terms", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			grants, err := ParseScopeGrants(tc.raw)
+
+			// --- Assert ---
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGrants, grants)
+		})
+	}
+}
+
+func TestPrincipalMiddleware_GrantsConfiguredScopesOnly(t *testing.T) {
+	// --- Arrange ---
+	grants := ScopeGrants{"user_2a": {"fabrics:pricing"}}
+	var granted, forged bool
+	handler := PrincipalMiddleware(grants)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		granted = command.HasScope(r.Context(), "fabrics:pricing")
+		forged = command.HasScope(r.Context(), "fabrics:supplier-terms")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/v1/fabrics/FAB01", nil)
+	req.Header.Set(UserIDHeader, "user_2a")
+	req.Header.Set("X-User-Scopes", "fabrics:supplier-terms")
+
+	// --- Act ---
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// --- Assert ---
+	assert.True(t, granted)
+	assert.False(t, forged, "scopes named by the request are not granted")
+}
@@ -0,0 +1,57 @@
+package httpx
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceHandler records which handler served a request on the request span, together with
+// the module that owns it, so traces can be grouped by handler in the APM UI.
+func TraceHandler(h http.Handler) http.Handler {
+	name, service := handlerIdentity(h)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trace.SpanFromContext(r.Context()).SetAttributes(
+			attribute.String("handler.name", name),
+			attribute.String("handler.service", service),
+		)
+		h.ServeHTTP(w, r)
+	})
+}
+
+// handlerIdentity names a handler after its type and the module it lives in, handlers being
+// kept in internal/<module>/handler
+func handlerIdentity(h http.Handler) (name, service string) {
+	t := reflect.TypeOf(h)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	packages := strings.Split(t.PkgPath(), "/")
+	service = packages[len(packages)-1]
+	if service == "handler" && len(packages) > 1 {
+		service = packages[len(packages)-2]
+	}
+	return t.Name(), service
+}
+
+// routeSpanName names a routed request after its route pattern, e.g. "PUT /v1/fabrics/{code}",
+// so requests to different fabrics share one span name. Unrouted requests keep the method only.
+func routeSpanName(r *http.Request) string {
+	if pattern := routePattern(r); pattern != "" {
+		return r.Method + " " + pattern
+	}
+	return r.Method
+}
+
+// routePattern is the pattern of the route that matched the request, empty when none did
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		return rctx.RoutePattern()
+	}
+	return ""
+}
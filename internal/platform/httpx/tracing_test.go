@@ -0,0 +1,58 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubHandler struct{}
+
+func (stubHandler) ServeHTTP(http.ResponseWriter, *http.Request) {}
+
+func TestHandlerIdentity(t *testing.T) {
+	// --- Act ---
+	name, service := handlerIdentity(&stubHandler{})
+
+	// --- Assert ---
+	assert.Equal(t, "stubHandler", name)
+	assert.Equal(t, "httpx", service)
+}
+
+func TestRouteSpanName(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		expectedName string
+	}{
+		{name: "routed request", method: http.MethodPut, path: "/v1/fabrics/FAB-1", expectedName: "PUT /v1/fabrics/{code}"},
+		{name: "unrouted request", method: http.MethodGet, path: "/unknown", expectedName: "GET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			var spanName string
+			router := chi.NewRouter()
+			router.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					next.ServeHTTP(w, r)
+					spanName = routeSpanName(r)
+				})
+			})
+			router.Route("/v1", func(r chi.Router) {
+				r.Method(http.MethodPut, "/fabrics/{code}", stubHandler{})
+			})
+
+			// --- Act ---
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedName, spanName)
+		})
+	}
+}
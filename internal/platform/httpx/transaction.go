@@ -0,0 +1,83 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/platform/database"
+)
+
+// TxBeginner opens database transactions, it is implemented by *sql.DB.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// runs every command request (any method but GET, HEAD and OPTIONS) in a database
+// transaction carried by the request context, so all repositories and the event store
+// called by the command commit or roll back together. The transaction commits when the
+// handler answers with a status below 400. The response is held back until then, so a
+// client is never told a command succeeded when its commit failed.
+func TransactionMiddleware(db TxBeginner) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tx, err := db.BeginTx(r.Context(), nil)
+			if err != nil {
+				InternalError(w, r, err)
+				return
+			}
+			defer tx.Rollback()
+
+			rw := &bufferedResponseWriter{header: http.Header{}, status: http.StatusOK}
+			next.ServeHTTP(rw, r.WithContext(database.WithTx(r.Context(), tx)))
+
+			if rw.status < http.StatusBadRequest {
+				if err := tx.Commit(); err != nil {
+					InternalError(w, r, err)
+					return
+				}
+			}
+			rw.flushTo(w)
+		})
+	}
+}
+
+// bufferedResponseWriter keeps the response in memory until the transaction is resolved
+type bufferedResponseWriter struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rw *bufferedResponseWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *bufferedResponseWriter) WriteHeader(code int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.status = code
+	rw.wroteHeader = true
+}
+
+func (rw *bufferedResponseWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	return rw.body.Write(b)
+}
+
+func (rw *bufferedResponseWriter) flushTo(w http.ResponseWriter) {
+	for key, values := range rw.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(rw.status)
+	_, _ = w.Write(rw.body.Bytes())
+}
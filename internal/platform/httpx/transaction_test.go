@@ -0,0 +1,107 @@
+package httpx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDriver is a database/sql driver whose transactions only record how they ended
+type recordingDriver struct {
+	outcomes  []string
+	commitErr error
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{driver: d}, nil }
+
+type recordingConn struct{ driver *recordingDriver }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *recordingConn) Close() error                        { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error)           { return &recordingTx{driver: c.driver}, nil }
+
+type recordingTx struct{ driver *recordingDriver }
+
+func (t *recordingTx) Commit() error {
+	if t.driver.commitErr != nil {
+		return t.driver.commitErr
+	}
+	t.driver.outcomes = append(t.driver.outcomes, "commit")
+	return nil
+}
+
+func (t *recordingTx) Rollback() error {
+	t.driver.outcomes = append(t.driver.outcomes, "rollback")
+	return nil
+}
+
+func TestTransactionMiddleware(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		status           int
+		commitErr        error
+		expectedOutcomes []string
+		expectedStatus   int
+		expectedTx       bool
+	}{
+		{
+			name: "successful command commits", method: http.MethodPost, status: http.StatusCreated,
+			expectedOutcomes: []string{"commit"}, expectedStatus: http.StatusCreated, expectedTx: true,
+		},
+		{
+			name: "failed command rolls back", method: http.MethodPut, status: http.StatusConflict,
+			expectedOutcomes: []string{"rollback"}, expectedStatus: http.StatusConflict, expectedTx: true,
+		},
+		{
+			name: "failed commit is not reported as success", method: http.MethodDelete, status: http.StatusNoContent,
+			commitErr:      errors.New("serialization failure"),
+			expectedStatus: http.StatusInternalServerError, expectedTx: true,
+		},
+		{
+			name: "reads run without a transaction", method: http.MethodGet, status: http.StatusOK,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			drv := &recordingDriver{commitErr: tt.commitErr}
+			db := sql.OpenDB(connector{drv})
+			defer db.Close()
+
+			var sawTx bool
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, sawTx = database.TxFromContext(r.Context())
+				w.Header().Set("X-Handled", "yes")
+				w.WriteHeader(tt.status)
+			})
+			responseRecorder := httptest.NewRecorder()
+
+			// --- Act ---
+			TransactionMiddleware(db)(handler).ServeHTTP(responseRecorder, httptest.NewRequest(tt.method, "/", nil))
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedTx, sawTx)
+			assert.Equal(t, tt.expectedOutcomes, drv.outcomes)
+			require.Equal(t, tt.expectedStatus, responseRecorder.Code)
+			if tt.commitErr == nil {
+				assert.Equal(t, "yes", responseRecorder.Header().Get("X-Handled"), "the buffered response is passed on")
+			}
+		})
+	}
+}
+
+type connector struct{ driver *recordingDriver }
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return c.driver.Open("") }
+func (c connector) Driver() driver.Driver                        { return c.driver }
@@ -0,0 +1,90 @@
+// Package instrument observes calls made through decorated components, typically
+// repositories, with a trace span, a duration metric and a log line per call.
+package instrument
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ErrorClassNone     = "none"
+	ErrorClassCanceled = "canceled"
+	ErrorClassInternal = "internal"
+)
+
+// Recorder observes the calls of one component, its name prefixes spans and labels metrics.
+type Recorder struct {
+	component string
+	logger    *slog.Logger
+}
+
+func NewRecorder(component string, logger *slog.Logger) *Recorder {
+	return &Recorder{
+		component: component,
+		logger:    logger.With("component", component),
+	}
+}
+
+// Call runs fn as the named method of the recorded component and returns its result.
+func Call[T any](ctx context.Context, rec *Recorder, method string, fn func(context.Context) (T, error)) (T, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, rec.component+"."+method)
+	defer span.End()
+
+	start := time.Now()
+	result, err := fn(ctx)
+	rec.observe(ctx, span, method, time.Since(start), err)
+	return result, err
+}
+
+// Exec is Call for methods that only return an error.
+func Exec(ctx context.Context, rec *Recorder, method string, fn func(context.Context) error) error {
+	_, err := Call(ctx, rec, method, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// ErrorClass groups errors for metrics and logs. Errors may report their own class through
+// an ErrorClass method, as domain errors do with their kind.
+func ErrorClass(err error) string {
+	if err == nil {
+		return ErrorClassNone
+	}
+	var classified interface{ ErrorClass() string }
+	if errors.As(err, &classified) {
+		return classified.ErrorClass()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassCanceled
+	}
+	return ErrorClassInternal
+}
+
+func (rec *Recorder) observe(ctx context.Context, span trace.Span, method string, duration time.Duration, err error) {
+	class := ErrorClass(err)
+	attrs := []attribute.KeyValue{
+		attribute.String("component", rec.component),
+		attribute.String("method", method),
+		attribute.String("error_class", class),
+	}
+	httpx.RepositoryCallDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(attrs...))
+	span.SetAttributes(attrs...)
+
+	if class == ErrorClassInternal {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		rec.logger.Error("call failed", "method", method, "duration", duration, "error", err)
+		return
+	}
+	rec.logger.Debug("call finished", "method", method, "duration", duration, "error_class", class)
+}
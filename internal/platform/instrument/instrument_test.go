@@ -0,0 +1,60 @@
+package instrument
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type classifiedError struct{}
+
+func (classifiedError) Error() string      { return "classified" }
+func (classifiedError) ErrorClass() string { return "validation" }
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "no error", err: nil, want: ErrorClassNone},
+		{name: "self classified", err: fmt.Errorf("wrapped: %w", classifiedError{}), want: "validation"},
+		{name: "canceled", err: context.Canceled, want: ErrorClassCanceled},
+		{name: "deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: ErrorClassCanceled},
+		{name: "internal", err: errors.New("connection refused"), want: ErrorClassInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Act ---
+			got := ErrorClass(tt.err)
+
+			// --- Assert ---
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCall_ReturnsResultAndError(t *testing.T) {
+	// --- Arrange ---
+	rec := NewRecorder("test.repository", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	wantErr := errors.New("boom")
+
+	// --- Act ---
+	result, err := Call(context.Background(), rec, "Get", func(context.Context) (int, error) {
+		return 42, nil
+	})
+	execErr := Exec(context.Background(), rec, "Delete", func(context.Context) error {
+		return wantErr
+	})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, 42, result)
+	assert.ErrorIs(t, execErr, wantErr)
+}
@@ -0,0 +1,25 @@
+package mail
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_Bytes(t *testing.T) {
+	// --- Arrange ---
+	msg := Message{
+		To:      []string{"ops@example.com", "sales@example.com"},
+		Subject: "Fabric digest",
+		Body:    "line one\nline two",
+	}
+
+	// --- Act ---
+	raw := string(msg.bytes("noreply@example.com"))
+
+	// --- Assert ---
+	assert.Contains(t, raw, "From: noreply@example.com\r\n")
+	assert.Contains(t, raw, "To: ops@example.com, sales@example.com\r\n")
+	assert.Contains(t, raw, "Subject: Fabric digest\r\n")
+	assert.Contains(t, raw, "\r\n\r\nline one\r\nline two", "the body follows the headers with CRLF line endings")
+}
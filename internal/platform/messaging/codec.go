@@ -0,0 +1,329 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Content types identifying the envelope encoding in the Content-Type message header
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// ErrUnknownContentType is returned for messages encoded in a format no codec is registered for.
+var ErrUnknownContentType = errors.New("unknown content type")
+
+// Codec encodes event envelopes for the wire.
+type Codec interface {
+	ContentType() string
+	Encode(envelope *EventEnvelope) ([]byte, error)
+	Decode(data []byte, envelope *EventEnvelope) error
+}
+
+// CodecRegistry finds the codec of a message by its content type.
+type CodecRegistry struct {
+	codecs map[string]Codec
+}
+
+// NewCodecRegistry registers the given codecs, JSON always being one of them.
+func NewCodecRegistry(codecs ...Codec) *CodecRegistry {
+	r := &CodecRegistry{codecs: make(map[string]Codec)}
+	r.Register(JSONCodec{})
+	for _, codec := range codecs {
+		r.Register(codec)
+	}
+	return r
+}
+
+// DefaultCodecRegistry knows every encoding the service can publish.
+func DefaultCodecRegistry() *CodecRegistry {
+	return NewCodecRegistry(ProtobufCodec{})
+}
+
+// Register adds a codec, replacing any registered for the same content type.
+func (r *CodecRegistry) Register(codec Codec) {
+	r.codecs[codec.ContentType()] = codec
+}
+
+// Lookup returns the codec of a content type. Messages without one, such as those sent by
+// the ERP, are JSON.
+func (r *CodecRegistry) Lookup(contentType string) (Codec, error) {
+	if contentType == "" {
+		contentType = ContentTypeJSON
+	}
+	codec, ok := r.codecs[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownContentType, contentType)
+	}
+	return codec, nil
+}
+
+// SubjectCodecs picks the envelope encoding of each published subject; subjects without
+// one are published as JSON.
+type SubjectCodecs map[string]Codec
+
+// For returns the codec envelopes published on subject are encoded with.
+func (c SubjectCodecs) For(subject string) Codec {
+	if codec, ok := c[subject]; ok {
+		return codec
+	}
+	return JSONCodec{}
+}
+
+// JSONCodec encodes envelopes as JSON, the format every consumer understands.
+type JSONCodec struct{}
+
+func (JSONCodec) ContentType() string { return ContentTypeJSON }
+
+func (JSONCodec) Encode(envelope *EventEnvelope) ([]byte, error) {
+	return json.Marshal(envelope)
+}
+
+func (JSONCodec) Decode(data []byte, envelope *EventEnvelope) error {
+	return json.Unmarshal(data, envelope)
+}
+
+// ProtobufCodec encodes envelopes following event_envelope.proto. The metadata is binary,
+// which is where most of the size of small events goes; the payload stays JSON encoded.
+type ProtobufCodec struct{}
+
+// field numbers of event_envelope.proto
+const (
+	pbEventID          protowire.Number = 1
+	pbEventType        protowire.Number = 2
+	pbAggregateID      protowire.Number = 3
+	pbAggregateType    protowire.Number = 4
+	pbAggregateVersion protowire.Number = 5
+	pbEventVersion     protowire.Number = 6
+	pbTimestamp        protowire.Number = 7
+	pbSequence         protowire.Number = 8
+	pbCorrelationID    protowire.Number = 9
+	pbCausationID      protowire.Number = 10
+	pbUserID           protowire.Number = 11
+	pbTenantID         protowire.Number = 12
+	pbSource           protowire.Number = 13
+	pbSchemaURL        protowire.Number = 14
+	pbPayload          protowire.Number = 15
+
+	pbSourceService  protowire.Number = 1
+	pbSourceInstance protowire.Number = 2
+
+	pbTimestampSeconds protowire.Number = 1
+	pbTimestampNanos   protowire.Number = 2
+)
+
+func (ProtobufCodec) ContentType() string { return ContentTypeProtobuf }
+
+func (ProtobufCodec) Encode(envelope *EventEnvelope) ([]byte, error) {
+	payload, err := json.Marshal(envelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	var b []byte
+	b = appendString(b, pbEventID, envelope.EventID)
+	b = appendString(b, pbEventType, envelope.EventType)
+	b = appendString(b, pbAggregateID, envelope.AggregateID)
+	b = appendString(b, pbAggregateType, envelope.AggregateType)
+	b = appendVarint(b, pbAggregateVersion, int64(envelope.AggregateVersion))
+	b = appendVarint(b, pbEventVersion, int64(envelope.EventVersion))
+	if !envelope.Timestamp.IsZero() {
+		var ts []byte
+		ts = appendVarint(ts, pbTimestampSeconds, envelope.Timestamp.Unix())
+		ts = appendVarint(ts, pbTimestampNanos, int64(envelope.Timestamp.Nanosecond()))
+		b = appendBytes(b, pbTimestamp, ts)
+	}
+	b = appendVarint(b, pbSequence, envelope.Sequence)
+	b = appendString(b, pbCorrelationID, envelope.CorrelationID)
+	b = appendString(b, pbCausationID, envelope.CausationID)
+	b = appendString(b, pbUserID, envelope.UserID)
+	b = appendString(b, pbTenantID, envelope.TenantID)
+	if envelope.Source != nil {
+		var source []byte
+		source = appendString(source, pbSourceService, envelope.Source.Service)
+		source = appendString(source, pbSourceInstance, envelope.Source.Instance)
+		b = appendBytes(b, pbSource, source)
+	}
+	b = appendString(b, pbSchemaURL, envelope.SchemaURL)
+	b = appendBytes(b, pbPayload, payload)
+	return b, nil
+}
+
+func (ProtobufCodec) Decode(data []byte, envelope *EventEnvelope) error {
+	return consumeFields(data, func(num protowire.Number, typ protowire.Type, value []byte) (int, error) {
+		switch num {
+		case pbEventID:
+			return consumeString(value, typ, &envelope.EventID)
+		case pbEventType:
+			return consumeString(value, typ, &envelope.EventType)
+		case pbAggregateID:
+			return consumeString(value, typ, &envelope.AggregateID)
+		case pbAggregateType:
+			return consumeString(value, typ, &envelope.AggregateType)
+		case pbAggregateVersion:
+			var v int64
+			n, err := consumeVarint(value, typ, &v)
+			envelope.AggregateVersion = int(v)
+			return n, err
+		case pbEventVersion:
+			var v int64
+			n, err := consumeVarint(value, typ, &v)
+			envelope.EventVersion = int(v)
+			return n, err
+		case pbTimestamp:
+			var ts []byte
+			n, err := consumeBytes(value, typ, &ts)
+			if err != nil {
+				return n, err
+			}
+			envelope.Timestamp, err = decodeTimestamp(ts)
+			return n, err
+		case pbSequence:
+			return consumeVarint(value, typ, &envelope.Sequence)
+		case pbCorrelationID:
+			return consumeString(value, typ, &envelope.CorrelationID)
+		case pbCausationID:
+			return consumeString(value, typ, &envelope.CausationID)
+		case pbUserID:
+			return consumeString(value, typ, &envelope.UserID)
+		case pbTenantID:
+			return consumeString(value, typ, &envelope.TenantID)
+		case pbSource:
+			var source []byte
+			n, err := consumeBytes(value, typ, &source)
+			if err != nil {
+				return n, err
+			}
+			envelope.Source, err = decodeSource(source)
+			return n, err
+		case pbSchemaURL:
+			return consumeString(value, typ, &envelope.SchemaURL)
+		case pbPayload:
+			var payload []byte
+			n, err := consumeBytes(value, typ, &payload)
+			envelope.Payload = json.RawMessage(payload)
+			return n, err
+		}
+		return skipField(num, typ, value)
+	})
+}
+
+func decodeTimestamp(data []byte) (time.Time, error) {
+	var seconds, nanos int64
+	err := consumeFields(data, func(num protowire.Number, typ protowire.Type, value []byte) (int, error) {
+		switch num {
+		case pbTimestampSeconds:
+			return consumeVarint(value, typ, &seconds)
+		case pbTimestampNanos:
+			return consumeVarint(value, typ, &nanos)
+		}
+		return skipField(num, typ, value)
+	})
+	return time.Unix(seconds, nanos).UTC(), err
+}
+
+func decodeSource(data []byte) (*Source, error) {
+	source := &Source{}
+	err := consumeFields(data, func(num protowire.Number, typ protowire.Type, value []byte) (int, error) {
+		switch num {
+		case pbSourceService:
+			return consumeString(value, typ, &source.Service)
+		case pbSourceInstance:
+			return consumeString(value, typ, &source.Instance)
+		}
+		return skipField(num, typ, value)
+	})
+	return source, err
+}
+
+// appenders leave out zero values, as proto3 does
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v))
+}
+
+// consumeFields walks the fields of a message, handing each value to consume, which returns
+// how many bytes it read
+func consumeFields(
+	data []byte, consume func(num protowire.Number, typ protowire.Type, value []byte) (int, error),
+) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("invalid protobuf tag: %w", protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		n, err := consume(num, typ, data)
+		if err != nil {
+			return fmt.Errorf("invalid protobuf field %d: %w", num, err)
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+func consumeString(data []byte, typ protowire.Type, dst *string) (int, error) {
+	var v []byte
+	n, err := consumeBytes(data, typ, &v)
+	*dst = string(v)
+	return n, err
+}
+
+func consumeBytes(data []byte, typ protowire.Type, dst *[]byte) (int, error) {
+	if typ != protowire.BytesType {
+		return 0, errors.New("expected a length-delimited value")
+	}
+	v, n := protowire.ConsumeBytes(data)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	*dst = append([]byte(nil), v...)
+	return n, nil
+}
+
+func consumeVarint(data []byte, typ protowire.Type, dst *int64) (int, error) {
+	if typ != protowire.VarintType {
+		return 0, errors.New("expected a varint value")
+	}
+	v, n := protowire.ConsumeVarint(data)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	*dst = int64(v)
+	return n, nil
+}
+
+// skipField passes over fields added to the schema after this service was built
+func skipField(num protowire.Number, typ protowire.Type, data []byte) (int, error) {
+	n := protowire.ConsumeFieldValue(num, typ, data)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	return n, nil
+}
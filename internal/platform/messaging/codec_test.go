@@ -0,0 +1,98 @@
+package messaging
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtobufCodec_RoundTrip(t *testing.T) {
+	// --- Arrange ---
+	envelope := NewEventEnvelope(
+		"app.fabric.created", "FABRIC001", "Fabric", 3,
+		map[string]any{"code": "FABRIC001", "name": "Zoya"},
+		WithCorrelationID("corr-1"),
+		WithUserID("user-1"),
+		WithTenantID("tenant-1"),
+		WithSource("s-works-api", "pod-1"),
+	)
+	envelope.Sequence = 42
+	codec := ProtobufCodec{}
+
+	// --- Act ---
+	data, err := codec.Encode(envelope)
+	require.NoError(t, err)
+	var decoded EventEnvelope
+	err = codec.Decode(data, &decoded)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, envelope.EventID, decoded.EventID)
+	assert.Equal(t, envelope.EventType, decoded.EventType)
+	assert.Equal(t, envelope.AggregateID, decoded.AggregateID)
+	assert.Equal(t, envelope.AggregateVersion, decoded.AggregateVersion)
+	assert.Equal(t, envelope.EventVersion, decoded.EventVersion)
+	assert.True(t, envelope.Timestamp.Equal(decoded.Timestamp))
+	assert.Equal(t, time.UTC, decoded.Timestamp.Location())
+	assert.Equal(t, envelope.Sequence, decoded.Sequence)
+	assert.Equal(t, envelope.CorrelationID, decoded.CorrelationID)
+	assert.Equal(t, envelope.UserID, decoded.UserID)
+	assert.Equal(t, envelope.TenantID, decoded.TenantID)
+	assert.Equal(t, envelope.Source, decoded.Source)
+	assert.JSONEq(t, `{"code": "FABRIC001", "name": "Zoya"}`, string(decoded.Payload.(json.RawMessage)))
+
+	jsonData, err := JSONCodec{}.Encode(envelope)
+	require.NoError(t, err)
+	assert.Less(t, len(data), len(jsonData))
+}
+
+func TestProtobufCodec_Decode_Invalid(t *testing.T) {
+	// --- Arrange ---
+	var decoded EventEnvelope
+
+	// --- Act ---
+	err := ProtobufCodec{}.Decode([]byte{0x0a, 0x05, 'a'}, &decoded)
+
+	// --- Assert ---
+	assert.Error(t, err)
+}
+
+func TestCodecRegistry_Lookup(t *testing.T) {
+	tests := []struct {
+		name          string
+		contentType   string
+		expectedCodec Codec
+		expectedErr   error
+	}{
+		{name: "no content type is JSON", contentType: "", expectedCodec: JSONCodec{}},
+		{name: "protobuf", contentType: ContentTypeProtobuf, expectedCodec: ProtobufCodec{}},
+		{name: "unknown", contentType: "avro/binary", expectedErr: ErrUnknownContentType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			registry := DefaultCodecRegistry()
+
+			// --- Act ---
+			codec, err := registry.Lookup(tt.contentType)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedCodec, codec)
+		})
+	}
+}
+
+func TestSubjectCodecs_For(t *testing.T) {
+	// --- Arrange ---
+	codecs := SubjectCodecs{"app.fabric": ProtobufCodec{}}
+
+	// --- Act & Assert ---
+	assert.Equal(t, ProtobufCodec{}, codecs.For("app.fabric"))
+	assert.Equal(t, JSONCodec{}, codecs.For("erp.fabric"))
+	assert.Equal(t, JSONCodec{}, SubjectCodecs(nil).For("app.fabric"))
+}
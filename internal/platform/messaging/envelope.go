@@ -2,12 +2,16 @@ package messaging
 
 import (
 	"errors"
+	"net/url"
+	"regexp"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
 )
 
-// EventEnvelope wraps domain events with metadata
+// EventEnvelope wraps domain events with metadata.
+// Timestamp is always UTC; Sequence is the per-aggregate position assigned by the
+// event store and is the field consumers should order by.
 type EventEnvelope struct {
 	EventID          string      `json:"event_id"`
 	EventType        string      `json:"event_type"`
@@ -16,12 +20,24 @@ type EventEnvelope struct {
 	AggregateVersion int         `json:"aggregate_version"`
 	EventVersion     int         `json:"event_version"`
 	Timestamp        time.Time   `json:"timestamp"`
+	Sequence         int64       `json:"sequence,omitempty"`
 	CorrelationID    string      `json:"correlation_id,omitempty"`
 	CausationID      string      `json:"causation_id,omitempty"`
 	UserID           string      `json:"user_id,omitempty"`
+	TenantID         string      `json:"tenant_id,omitempty"`
+	Source           *Source     `json:"source,omitempty"`
+	SchemaURL        string      `json:"schema_url,omitempty"`
 	Payload          interface{} `json:"payload"`
 }
 
+// Source identifies the service (and instance of it) that produced an event
+type Source struct {
+	Service  string `json:"service"`
+	Instance string `json:"instance,omitempty"`
+}
+
+var tenantIDRX = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
 // EnvelopeOption is a functional option for configuring EventEnvelope
 type EnvelopeOption func(*EventEnvelope)
 
@@ -46,6 +62,41 @@ func WithUserID(userID string) EnvelopeOption {
 	}
 }
 
+// WithTenantID sets the tenant owning the event
+func WithTenantID(tenantID string) EnvelopeOption {
+	return func(e *EventEnvelope) {
+		e.TenantID = tenantID
+	}
+}
+
+// WithSource sets the producing service name and instance
+func WithSource(service, instance string) EnvelopeOption {
+	return func(e *EventEnvelope) {
+		e.Source = &Source{Service: service, Instance: instance}
+	}
+}
+
+// WithSchemaURL sets the reference to the payload schema
+func WithSchemaURL(schemaURL string) EnvelopeOption {
+	return func(e *EventEnvelope) {
+		e.SchemaURL = schemaURL
+	}
+}
+
+// WithClock sets the envelope timestamp from the given clock
+func WithClock(c clock.Clock) EnvelopeOption {
+	return func(e *EventEnvelope) {
+		e.Timestamp = c.Now()
+	}
+}
+
+// WithIDGenerator sets the event ID using the given generator
+func WithIDGenerator(g IDGenerator) EnvelopeOption {
+	return func(e *EventEnvelope) {
+		e.EventID = g.NewID()
+	}
+}
+
 func NewEventEnvelope(
 	eventType, aggregateID, aggregateType string,
 	aggregateVersion int,
@@ -53,7 +104,7 @@ func NewEventEnvelope(
 	options ...EnvelopeOption,
 ) *EventEnvelope {
 	envelope := &EventEnvelope{
-		EventID:          uuid.New().String(),
+		EventID:          defaultIDGenerator.NewID(),
 		EventType:        eventType,
 		EventVersion:     1,
 		AggregateID:      aggregateID,
@@ -67,6 +118,7 @@ func NewEventEnvelope(
 	for _, option := range options {
 		option(envelope)
 	}
+	envelope.Timestamp = envelope.Timestamp.UTC()
 
 	return envelope
 }
@@ -85,5 +137,17 @@ func (e *EventEnvelope) Validate() error {
 	if e.Payload == nil {
 		return errors.New("payload is required")
 	}
+	if e.TenantID != "" && !tenantIDRX.MatchString(e.TenantID) {
+		return errors.New("tenant ID must be 1-64 characters of A-Z, a-z, 0-9, _ or -")
+	}
+	if e.Source != nil && e.Source.Service == "" {
+		return errors.New("source service is required when source is set")
+	}
+	if e.SchemaURL != "" {
+		u, err := url.Parse(e.SchemaURL)
+		if err != nil || !u.IsAbs() || u.Host == "" {
+			return errors.New("schema URL must be an absolute URL")
+		}
+	}
 	return nil
 }
@@ -1,8 +1,11 @@
 package messaging
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/salesworks/s-works/api/internal/platform/clock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,11 +48,85 @@ func TestEventEnvelope_WithOptionalMetadata(t *testing.T) {
 		WithCorrelationID("correlation-123"),
 		WithCausationID("causation-456"),
 		WithUserID("user-789"),
+		WithTenantID("tenant-1"),
+		WithSource("goworks-api", "api-7d9f"),
+		WithSchemaURL("https://schemas.example.com/fabric/created/v1.json"),
 	)
 
 	assert.Equal(t, "correlation-123", envelope.CorrelationID)
 	assert.Equal(t, "causation-456", envelope.CausationID)
 	assert.Equal(t, "user-789", envelope.UserID)
+	assert.Equal(t, "tenant-1", envelope.TenantID)
+	assert.Equal(t, &Source{Service: "goworks-api", Instance: "api-7d9f"}, envelope.Source)
+	assert.Equal(t, "https://schemas.example.com/fabric/created/v1.json", envelope.SchemaURL)
+	assert.NoError(t, envelope.Validate())
+}
+
+func TestEventEnvelope_JSONIsBackwardCompatible(t *testing.T) {
+	// Arrange: an envelope produced before tenant/source/schema metadata existed
+	legacy := `{
+		"event_id": "test-create-002",
+		"event_type": "erp.fabric.created",
+		"aggregate_id": "TEST02",
+		"aggregate_type": "fabric",
+		"aggregate_version": 1,
+		"event_version": 1,
+		"timestamp": "2025-01-02T03:04:05Z",
+		"payload": {"kod": "TEST02"}
+	}`
+
+	// Act
+	var envelope EventEnvelope
+	err := json.Unmarshal([]byte(legacy), &envelope)
+	assert.NoError(t, err)
+	encoded, err := json.Marshal(NewEventEnvelope("fabric.created", "FABRIC01", "fabric", 1, map[string]interface{}{"test": "data"}))
+	assert.NoError(t, err)
+
+	// Assert
+	assert.NoError(t, envelope.Validate())
+	assert.Empty(t, envelope.TenantID)
+	assert.Nil(t, envelope.Source)
+	assert.NotContains(t, string(encoded), "tenant_id", "unset metadata should be omitted")
+	assert.NotContains(t, string(encoded), "source")
+	assert.NotContains(t, string(encoded), "schema_url")
+}
+
+func TestEventEnvelope_WithClock(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Act
+	envelope := NewEventEnvelope(
+		"fabric.created",
+		"FABRIC01",
+		"fabric",
+		1,
+		map[string]interface{}{"test": "data"},
+		WithClock(clock.NewFixed(now)),
+	)
+
+	// Assert
+	assert.Equal(t, now, envelope.Timestamp)
+}
+
+func TestEventEnvelope_NormalizesTimestampToUTC(t *testing.T) {
+	// Arrange
+	warsaw := time.FixedZone("CET", 60*60)
+	local := time.Date(2025, 1, 2, 4, 4, 5, 0, warsaw)
+
+	// Act
+	envelope := NewEventEnvelope(
+		"fabric.created",
+		"FABRIC01",
+		"fabric",
+		1,
+		map[string]interface{}{"test": "data"},
+		WithClock(clock.NewFixed(local)),
+	)
+
+	// Assert
+	assert.Equal(t, time.UTC, envelope.Timestamp.Location())
+	assert.True(t, local.Equal(envelope.Timestamp), "normalization must not change the instant")
 }
 
 func TestEventEnvelope_Validation(t *testing.T) {
@@ -111,6 +188,31 @@ func TestEventEnvelope_Validation(t *testing.T) {
 		},
 	}
 
+	invalidMetadata := []struct {
+		name     string
+		option   EnvelopeOption
+		errorMsg string
+	}{
+		{name: "invalid tenant ID", option: WithTenantID("tenant with spaces"), errorMsg: "tenant ID"},
+		{name: "source without service", option: WithSource("", "api-1"), errorMsg: "source service is required"},
+		{name: "relative schema URL", option: WithSchemaURL("schemas/fabric.json"), errorMsg: "schema URL"},
+	}
+	for _, im := range invalidMetadata {
+		tests = append(tests, struct {
+			name        string
+			envelope    *EventEnvelope
+			expectError bool
+			errorMsg    string
+		}{
+			name: im.name,
+			envelope: NewEventEnvelope(
+				"fabric.created", "FABRIC001", "fabric", 1, map[string]interface{}{"test": "data"}, im.option,
+			),
+			expectError: true,
+			errorMsg:    im.errorMsg,
+		})
+	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := tt.envelope.Validate()
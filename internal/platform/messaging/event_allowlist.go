@@ -0,0 +1,49 @@
+package messaging
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventAllowlist selects the event types published to the broker; every other event is
+// kept internal to the service. An entry is either an event type or a prefix ending in
+// ".*", which allows every type below it: "app.fabric.*" allows "app.fabric.created".
+// Aggregates added later stay internal until they are listed.
+type EventAllowlist struct {
+	types    map[string]bool
+	prefixes []string
+}
+
+// NewEventAllowlist builds the allowlist from its entries, failing on a malformed one.
+func NewEventAllowlist(entries ...string) (*EventAllowlist, error) {
+	allowlist := &EventAllowlist{types: make(map[string]bool)}
+	for _, entry := range entries {
+		prefix, wildcard := strings.CutSuffix(entry, ".*")
+		if prefix == "" || strings.Contains(prefix, "*") {
+			return nil, fmt.Errorf("invalid event allowlist entry %q", entry)
+		}
+		if wildcard {
+			allowlist.prefixes = append(allowlist.prefixes, prefix+".")
+		} else {
+			allowlist.types[entry] = true
+		}
+	}
+	return allowlist, nil
+}
+
+// Allows reports whether events of the type may be published. A nil allowlist allows
+// nothing.
+func (a *EventAllowlist) Allows(eventType string) bool {
+	if a == nil {
+		return false
+	}
+	if a.types[eventType] {
+		return true
+	}
+	for _, prefix := range a.prefixes {
+		if strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventAllowlist_Allows(t *testing.T) {
+	allowlist, err := NewEventAllowlist("app.fabric.*", "app.category.created")
+	require.NoError(t, err)
+
+	tests := []struct {
+		eventType string
+		expected  bool
+	}{
+		{eventType: "app.fabric.created", expected: true},
+		{eventType: "app.fabric.price_changed", expected: true},
+		{eventType: "app.category.created", expected: true},
+		{eventType: "app.category.deleted", expected: false},
+		{eventType: "app.fabricator.created", expected: false},
+		{eventType: "app.customer.created", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			// --- Act & Assert ---
+			assert.Equal(t, tt.expected, allowlist.Allows(tt.eventType))
+		})
+	}
+}
+
+func TestEventAllowlist_NilAllowsNothing(t *testing.T) {
+	var allowlist *EventAllowlist
+
+	assert.False(t, allowlist.Allows("app.fabric.created"))
+}
+
+func TestNewEventAllowlist_InvalidEntry(t *testing.T) {
+	for _, entry := range []string{"", ".*", "app.*.created", "app.fabric*"} {
+		t.Run(entry, func(t *testing.T) {
+			// --- Act ---
+			_, err := NewEventAllowlist(entry)
+
+			// --- Assert ---
+			assert.Error(t, err)
+		})
+	}
+}
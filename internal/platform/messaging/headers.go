@@ -0,0 +1,55 @@
+package messaging
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// NATS header names mirroring the key envelope metadata, so intermediaries and
+// JetStream consumers can filter and route without deserializing the payload.
+const (
+	HeaderMsgID            = "Nats-Msg-Id" // JetStream de-duplication
+	HeaderEventID          = "Event-Id"
+	HeaderEventType        = "Event-Type"
+	HeaderEventVersion     = "Event-Version"
+	HeaderAggregateID      = "Aggregate-Id"
+	HeaderAggregateType    = "Aggregate-Type"
+	HeaderAggregateVersion = "Aggregate-Version"
+	HeaderCorrelationID    = "Correlation-Id"
+	HeaderTenantID         = "Tenant-Id"
+	HeaderContentType      = "Content-Type" // envelope encoding, JSON when absent
+)
+
+// envelopeHeaders builds the NATS headers for an envelope, including the trace
+// context of ctx.
+func envelopeHeaders(ctx context.Context, envelope *EventEnvelope) nats.Header {
+	header := nats.Header{}
+	header.Set(HeaderMsgID, envelope.EventID)
+	header.Set(HeaderEventID, envelope.EventID)
+	header.Set(HeaderEventType, envelope.EventType)
+	header.Set(HeaderEventVersion, strconv.Itoa(envelope.EventVersion))
+	header.Set(HeaderAggregateID, envelope.AggregateID)
+	header.Set(HeaderAggregateType, envelope.AggregateType)
+	header.Set(HeaderAggregateVersion, strconv.Itoa(envelope.AggregateVersion))
+	if envelope.CorrelationID != "" {
+		header.Set(HeaderCorrelationID, envelope.CorrelationID)
+	}
+	if envelope.TenantID != "" {
+		header.Set(HeaderTenantID, envelope.TenantID)
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+	return header
+}
+
+// contextFromHeaders continues the trace carried in the message headers, if any.
+func contextFromHeaders(ctx context.Context, header nats.Header) context.Context {
+	if len(header) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
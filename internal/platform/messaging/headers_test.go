@@ -0,0 +1,47 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelopeHeaders_CarryKeyMetadata(t *testing.T) {
+	// Arrange
+	envelope := NewEventEnvelope(
+		"app.fabric.updated",
+		"FABRIC01",
+		"Fabric",
+		3,
+		map[string]interface{}{"test": "data"},
+		WithCorrelationID("correlation-123"),
+		WithTenantID("tenant-1"),
+	)
+
+	// Act
+	header := envelopeHeaders(context.Background(), envelope)
+
+	// Assert
+	assert.Equal(t, envelope.EventID, header.Get(HeaderEventID))
+	assert.Equal(t, envelope.EventID, header.Get(HeaderMsgID), "event ID doubles as the JetStream de-duplication ID")
+	assert.Equal(t, "app.fabric.updated", header.Get(HeaderEventType))
+	assert.Equal(t, "1", header.Get(HeaderEventVersion))
+	assert.Equal(t, "FABRIC01", header.Get(HeaderAggregateID))
+	assert.Equal(t, "Fabric", header.Get(HeaderAggregateType))
+	assert.Equal(t, "3", header.Get(HeaderAggregateVersion))
+	assert.Equal(t, "correlation-123", header.Get(HeaderCorrelationID))
+	assert.Equal(t, "tenant-1", header.Get(HeaderTenantID))
+}
+
+func TestEnvelopeHeaders_OmitsEmptyOptionalMetadata(t *testing.T) {
+	// Arrange
+	envelope := NewEventEnvelope("app.fabric.created", "FABRIC01", "Fabric", 1, map[string]interface{}{"test": "data"})
+
+	// Act
+	header := envelopeHeaders(context.Background(), envelope)
+
+	// Assert
+	assert.NotContains(t, header, HeaderCorrelationID)
+	assert.NotContains(t, header, HeaderTenantID)
+}
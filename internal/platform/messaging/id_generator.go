@@ -0,0 +1,22 @@
+package messaging
+
+import "github.com/google/uuid"
+
+// IDGenerator produces unique event IDs
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc adapts a function to the IDGenerator interface
+type IDGeneratorFunc func() string
+
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+// defaultIDGenerator is used by NewEventEnvelope unless WithIDGenerator is given. UUIDv7
+// IDs (RFC 9562) sort chronologically while still fitting the UUID column of the events
+// table, IDs generated within the same millisecond stay monotonic.
+var defaultIDGenerator IDGenerator = IDGeneratorFunc(func() string {
+	return uuid.Must(uuid.NewV7()).String()
+})
@@ -0,0 +1,52 @@
+package messaging
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultIDGenerator_ProducesValidVersion7UUIDs(t *testing.T) {
+	// Act
+	id := defaultIDGenerator.NewID()
+
+	// Assert
+	parsed, err := uuid.Parse(id)
+	require.NoError(t, err, "ID should be a valid UUID")
+	assert.Equal(t, uuid.Version(7), parsed.Version())
+	assert.Equal(t, uuid.RFC4122, parsed.Variant())
+}
+
+func TestDefaultIDGenerator_SortsChronologically(t *testing.T) {
+	// Act: many IDs, most of them within the same millisecond
+	var ids []string
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, defaultIDGenerator.NewID())
+	}
+
+	// Assert
+	assert.True(t, sort.StringsAreSorted(ids), "IDs should sort in generation order")
+	unique := make(map[string]bool)
+	for _, id := range ids {
+		unique[id] = true
+	}
+	assert.Len(t, unique, len(ids), "IDs should be unique")
+}
+
+func TestEventEnvelope_WithIDGenerator(t *testing.T) {
+	// Act
+	envelope := NewEventEnvelope(
+		"fabric.created",
+		"FABRIC01",
+		"fabric",
+		1,
+		map[string]interface{}{"test": "data"},
+		WithIDGenerator(IDGeneratorFunc(func() string { return "fixed-id" })),
+	)
+
+	// Assert
+	assert.Equal(t, "fixed-id", envelope.EventID)
+}
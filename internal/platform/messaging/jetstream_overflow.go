@@ -0,0 +1,88 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// how long a fetch waits for parked messages before reporting there are none
+const overflowFetchWait = time.Second
+
+// JetStreamOverflow parks messages in a JetStream work queue stream, under the subject they
+// were received on prefixed with the prefix of the stream. Core NATS does not redeliver,
+// the stream keeps parked messages across restarts and shares them between the instances
+// of the service through a durable consumer.
+type JetStreamOverflow struct {
+	js           nats.JetStreamContext
+	prefix       string
+	subscription *nats.Subscription
+}
+
+// NewJetStreamOverflow creates or updates the stream holding the messages of subject parked
+// under prefix, erp.* under overflow.erp.* for instance, and binds the durable consumer
+// replaying them.
+func NewJetStreamOverflow(conn *nats.Conn, stream, prefix, subject, durable string) (*JetStreamOverflow, error) {
+	js, err := conn.JetStream()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JetStream: %w", err)
+	}
+
+	config := &nats.StreamConfig{
+		Name:      stream,
+		Subjects:  []string{prefix + "." + subject},
+		Retention: nats.WorkQueuePolicy,
+		Storage:   nats.FileStorage,
+	}
+	if _, err := js.AddStream(config); errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		_, err = js.UpdateStream(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update overflow stream '%s': %w", stream, err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create overflow stream '%s': %w", stream, err)
+	}
+
+	subscription, err := js.PullSubscribe(prefix+"."+subject, durable, nats.BindStream(stream))
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind overflow consumer '%s': %w", durable, err)
+	}
+	return &JetStreamOverflow{js: js, prefix: prefix, subscription: subscription}, nil
+}
+
+// Park implements the Overflow interface. The event ID in the headers doubles as the
+// de-duplication ID, so a message parked twice is kept once.
+func (o *JetStreamOverflow) Park(msg *nats.Msg) error {
+	parked := &nats.Msg{Subject: o.prefix + "." + msg.Subject, Header: msg.Header, Data: msg.Data}
+	if _, err := o.js.PublishMsg(parked); err != nil {
+		return fmt.Errorf("failed to park message of '%s': %w", msg.Subject, err)
+	}
+	return nil
+}
+
+// Fetch implements the Overflow interface.
+func (o *JetStreamOverflow) Fetch(ctx context.Context, batch int) ([]*nats.Msg, error) {
+	ctx, cancel := context.WithTimeout(ctx, overflowFetchWait)
+	defer cancel()
+
+	msgs, err := o.subscription.Fetch(batch, nats.Context(ctx))
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, nats.ErrTimeout) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch parked messages: %w", err)
+	}
+	for _, msg := range msgs {
+		msg.Subject = strings.TrimPrefix(msg.Subject, o.prefix+".")
+	}
+	return msgs, nil
+}
+
+// Release implements the Overflow interface.
+func (o *JetStreamOverflow) Release(msg *nats.Msg) error {
+	return msg.Ack()
+}
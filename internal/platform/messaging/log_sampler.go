@@ -0,0 +1,29 @@
+package messaging
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// LogSampler thins out routine per-message logs so bulk syncs do not flood production logs.
+// Failures are always logged by the callers; only the logs of messages that went through
+// are sampled. A nil sampler keeps every log.
+type LogSampler struct {
+	rate  float64
+	count atomic.Uint64
+}
+
+// NewLogSampler keeps the given fraction of sampled logs, spread evenly: a rate of 0.01
+// keeps every hundredth, 1 keeps them all and 0 none.
+func NewLogSampler(rate float64) *LogSampler {
+	return &LogSampler{rate: math.Min(math.Max(rate, 0), 1)}
+}
+
+// Sample reports whether the next log should be written.
+func (s *LogSampler) Sample() bool {
+	if s == nil {
+		return true
+	}
+	n := s.count.Add(1)
+	return math.Floor(float64(n)*s.rate) > math.Floor(float64(n-1)*s.rate)
+}
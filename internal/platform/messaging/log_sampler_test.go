@@ -0,0 +1,38 @@
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogSampler_Sample(t *testing.T) {
+	tests := []struct {
+		name         string
+		sampler      *LogSampler
+		expectedKept int
+	}{
+		{name: "nil sampler keeps everything", sampler: nil, expectedKept: 1000},
+		{name: "full rate", sampler: NewLogSampler(1), expectedKept: 1000},
+		{name: "one percent", sampler: NewLogSampler(0.01), expectedKept: 10},
+		{name: "zero rate", sampler: NewLogSampler(0), expectedKept: 0},
+		{name: "rate above one is clamped", sampler: NewLogSampler(5), expectedKept: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			kept := 0
+
+			// --- Act ---
+			for range 1000 {
+				if tt.sampler.Sample() {
+					kept++
+				}
+			}
+
+			// --- Assert ---
+			assert.Equal(t, tt.expectedKept, kept)
+		})
+	}
+}
@@ -2,12 +2,21 @@ package messaging
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
+	"sync"
 )
 
+// ErrHandlerRegistered is returned when a subject pattern already has a handler.
+var ErrHandlerRegistered = errors.New("handler already registered")
+
 // MessageRouter routes incoming messages to appropriate handlers based on subject patterns.
+// Handlers can be registered and unregistered while messages are being routed.
 type MessageRouter struct {
+	mu       sync.RWMutex
 	handlers map[string]MessageHandler
 	logger   *slog.Logger
 }
@@ -20,12 +29,58 @@ func NewMessageRouter(logger *slog.Logger) *MessageRouter {
 	}
 }
 
-// RegisterHandler registers a handler for a specific subject pattern.
-func (r *MessageRouter) RegisterHandler(subjectPattern string, handler MessageHandler) {
+// RegisterHandler registers a handler for a specific subject pattern. It refuses to replace a
+// handler already registered for the pattern; use ReplaceHandler to override one on purpose.
+func (r *MessageRouter) RegisterHandler(subjectPattern string, handler MessageHandler) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.handlers[subjectPattern]; exists {
+		return fmt.Errorf("subject pattern '%s': %w", subjectPattern, ErrHandlerRegistered)
+	}
+	r.handlers[subjectPattern] = handler
+	r.logger.Info("Registered message handler", "pattern", subjectPattern)
+	return nil
+}
+
+// ReplaceHandler registers a handler for a subject pattern, overriding any registered before.
+func (r *MessageRouter) ReplaceHandler(subjectPattern string, handler MessageHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.handlers[subjectPattern]; exists {
+		r.logger.Warn("Replacing message handler", "pattern", subjectPattern)
+	}
 	r.handlers[subjectPattern] = handler
 	r.logger.Info("Registered message handler", "pattern", subjectPattern)
 }
 
+// UnregisterHandler removes the handler of a subject pattern and reports whether there was one.
+func (r *MessageRouter) UnregisterHandler(subjectPattern string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.handlers[subjectPattern]; !exists {
+		return false
+	}
+	delete(r.handlers, subjectPattern)
+	r.logger.Info("Unregistered message handler", "pattern", subjectPattern)
+	return true
+}
+
+// Patterns lists the registered subject patterns in alphabetical order.
+func (r *MessageRouter) Patterns() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	patterns := make([]string, 0, len(r.handlers))
+	for pattern := range r.handlers {
+		patterns = append(patterns, pattern)
+	}
+	slices.Sort(patterns)
+	return patterns
+}
+
 // HandleMessage implements the MessageHandler interface and routes messages to appropriate handlers.
 func (r *MessageRouter) HandleMessage(ctx context.Context, subject string, payload []byte) error {
 	handler, found := r.findHandler(subject)
@@ -40,6 +95,9 @@ func (r *MessageRouter) HandleMessage(ctx context.Context, subject string, paylo
 
 // findHandler finds the appropriate handler for a given subject.
 func (r *MessageRouter) findHandler(subject string) (MessageHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	// First try exact match
 	if handler, exists := r.handlers[subject]; exists {
 		return handler, true
@@ -0,0 +1,87 @@
+package messaging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHandler struct {
+	subjects []string
+}
+
+func (h *recordingHandler) HandleMessage(_ context.Context, subject string, _ []byte) error {
+	h.subjects = append(h.subjects, subject)
+	return nil
+}
+
+func newTestRouter() *MessageRouter {
+	return NewMessageRouter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestMessageRouter_RegisterHandler(t *testing.T) {
+	t.Run("refuses a second handler for a pattern", func(t *testing.T) {
+		// --- Arrange ---
+		router := newTestRouter()
+		first, second := &recordingHandler{}, &recordingHandler{}
+		require.NoError(t, router.RegisterHandler("erp.fabric", first))
+
+		// --- Act ---
+		err := router.RegisterHandler("erp.fabric", second)
+
+		// --- Assert ---
+		assert.ErrorIs(t, err, ErrHandlerRegistered)
+		require.NoError(t, router.HandleMessage(context.Background(), "erp.fabric", nil))
+		assert.Equal(t, []string{"erp.fabric"}, first.subjects)
+		assert.Empty(t, second.subjects)
+	})
+
+	t.Run("replace overrides the registered handler", func(t *testing.T) {
+		// --- Arrange ---
+		router := newTestRouter()
+		first, second := &recordingHandler{}, &recordingHandler{}
+		require.NoError(t, router.RegisterHandler("erp.fabric", first))
+
+		// --- Act ---
+		router.ReplaceHandler("erp.fabric", second)
+
+		// --- Assert ---
+		require.NoError(t, router.HandleMessage(context.Background(), "erp.fabric", nil))
+		assert.Empty(t, first.subjects)
+		assert.Equal(t, []string{"erp.fabric"}, second.subjects)
+	})
+}
+
+func TestMessageRouter_UnregisterHandler(t *testing.T) {
+	// --- Arrange ---
+	router := newTestRouter()
+	handler := &recordingHandler{}
+	require.NoError(t, router.RegisterHandler("erp.fabric", handler))
+
+	// --- Act ---
+	removed := router.UnregisterHandler("erp.fabric")
+	removedAgain := router.UnregisterHandler("erp.fabric")
+
+	// --- Assert ---
+	assert.True(t, removed)
+	assert.False(t, removedAgain)
+	require.NoError(t, router.HandleMessage(context.Background(), "erp.fabric", nil))
+	assert.Empty(t, handler.subjects, "messages of an unregistered pattern are not routed")
+}
+
+func TestMessageRouter_Patterns(t *testing.T) {
+	// --- Arrange ---
+	router := newTestRouter()
+	require.NoError(t, router.RegisterHandler("erp.fabric", &recordingHandler{}))
+	require.NoError(t, router.RegisterHandler("app.fabric", &recordingHandler{}))
+
+	// --- Act ---
+	patterns := router.Patterns()
+
+	// --- Assert ---
+	assert.Equal(t, []string{"app.fabric", "erp.fabric"}, patterns)
+}
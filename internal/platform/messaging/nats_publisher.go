@@ -2,7 +2,6 @@ package messaging
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 
@@ -17,15 +16,21 @@ type Publisher interface {
 
 // EventPublisher is a generic publisher for all domain events
 type NatsPublisher struct {
-	conn   *nats.Conn
-	logger *slog.Logger
+	conn    *nats.Conn
+	codecs  SubjectCodecs
+	sampler *LogSampler
+	logger  *slog.Logger
 }
 
 // NewEventPublisher creates a new generic event publisher
-func NewNatsPublisher(conn *nats.Conn, logger *slog.Logger) *NatsPublisher {
+func NewNatsPublisher(
+	conn *nats.Conn, codecs SubjectCodecs, sampler *LogSampler, logger *slog.Logger,
+) *NatsPublisher {
 	return &NatsPublisher{
-		conn:   conn,
-		logger: logger.With("component", "NatsPublisher"),
+		conn:    conn,
+		codecs:  codecs,
+		sampler: sampler,
+		logger:  logger.With("component", "NatsPublisher"),
 	}
 }
 
@@ -36,25 +41,41 @@ func (p *NatsPublisher) Publish(ctx context.Context, subject string, envelope *E
 		return fmt.Errorf("invalid event envelope: %w", err)
 	}
 
-	// Serialize the envelope to JSON
-	event, err := json.Marshal(envelope)
+	// Serialize the envelope in the encoding of the subject
+	codec := p.codecs.For(subject)
+	event, err := codec.Encode(envelope)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event envelope: %w", err)
 	}
 
-	if err := p.conn.Publish(subject, event); err != nil {
+	msg := &nats.Msg{
+		Subject: subject,
+		Header:  envelopeHeaders(ctx, envelope),
+		Data:    event,
+	}
+	msg.Header.Set(HeaderContentType, codec.ContentType())
+
+	if err := p.conn.PublishMsg(msg); err != nil {
 		return fmt.Errorf("failed to publish message to subject '%s': %w", subject, err)
 	}
 
-	p.logger.Debug(
-		"Message published to NATS",
-		"subject", subject,
-		"aggregate_type", envelope.AggregateType,
-	)
+	if p.sampler.Sample() {
+		p.logger.Debug(
+			"Message published to NATS",
+			"subject", subject,
+			"aggregate_type", envelope.AggregateType,
+		)
+	}
 
 	return nil
 }
 
+// Connected reports whether the broker is currently reachable. While it is not, the
+// connection keeps reconnecting in the background.
+func (p *NatsPublisher) Connected() bool {
+	return p.conn.IsConnected()
+}
+
 func (p *NatsPublisher) Close() error {
 	if p.conn != nil && !p.conn.IsClosed() {
 		p.logger.Info("Draining and closing NATS connection.")
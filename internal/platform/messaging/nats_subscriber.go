@@ -2,7 +2,11 @@ package messaging
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/nats-io/nats.go"
 )
@@ -15,41 +19,328 @@ type MessageHandler interface {
 
 // NatsSubscriber manages a NATS subscription and delegates message processing.
 type NatsSubscriber struct {
-	conn       *nats.Conn
-	handler    MessageHandler
-	subject    string
-	queueGroup string
-	logger     *slog.Logger
+	conn         *nats.Conn
+	handler      MessageHandler
+	subject      string
+	queueGroup   string
+	timeout      time.Duration
+	sampler      *LogSampler
+	codecs       *CodecRegistry
+	logger       *slog.Logger
+	subscription *nats.Subscription
+
+	// processing counters reported by Status
+	mu            sync.Mutex
+	processed     int64
+	failed        int64
+	decodeErrors  int64
+	overflowed    int64
+	lastProcessed *ProcessedMessage
+	lastError     *FailedMessage
+
+	// optional throttling, messages beyond its in-flight bound are parked in the overflow
+	throttle *Throttle
+	overflow Overflow
+	// held while a message is handled, so received and replayed messages take turns
+	handling sync.Mutex
+
+	// parent of every handler context, cancelled when stopping runs out of time
+	baseCtx context.Context
+	cancel  context.CancelFunc
 }
 
-// NewNatsSubscriber creates and initializes a new NatsSubscriber.
+// how many parked messages are replayed per fetch, and how long replaying waits for the
+// subscription to catch up
+const (
+	overflowReplayBatch = 10
+	overflowPollWait    = 100 * time.Millisecond
+)
+
+// NewNatsSubscriber creates and initializes a new NatsSubscriber. Each message is handled
+// with a context that expires after timeout; a zero timeout leaves handling unbounded.
+// Successfully processed messages are logged as the sampler allows, failures always.
 func NewNatsSubscriber(
 	conn *nats.Conn,
 	handler MessageHandler,
 	subject string,
 	queueGroup string,
+	timeout time.Duration,
+	sampler *LogSampler,
 	logger *slog.Logger,
 ) *NatsSubscriber {
+	baseCtx, cancel := context.WithCancel(context.Background())
 	return &NatsSubscriber{
 		conn:       conn,
 		handler:    handler,
 		subject:    subject,
 		queueGroup: queueGroup,
+		timeout:    timeout,
+		sampler:    sampler,
+		codecs:     DefaultCodecRegistry(),
 		logger:     logger.With("component", "natsSubscriber"),
+		baseCtx:    baseCtx,
+		cancel:     cancel,
 	}
 }
 
+// SetThrottle bounds the consumption of the subscription. Without an overflow, messages
+// beyond the in-flight bound wait in the client like any other.
+func (s *NatsSubscriber) SetThrottle(throttle *Throttle, overflow Overflow) {
+	s.throttle = throttle
+	s.overflow = overflow
+}
+
 // StartListening creates a subscription and processes messages in the background.
-func (s *NatsSubscriber) StartListening() {
-	s.conn.QueueSubscribe(s.subject, s.queueGroup, func(msg *nats.Msg) {
-		s.logger.Debug("Received message", "subject", msg.Subject)
+func (s *NatsSubscriber) StartListening() error {
+	subscription, err := s.conn.QueueSubscribe(s.subject, s.queueGroup, s.handle)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to subject '%s': %w", s.subject, err)
+	}
+	s.mu.Lock()
+	s.subscription = subscription
+	s.mu.Unlock()
+	return nil
+}
 
-		// Delegate all logic to the injected handler.
-		if err := s.handler.HandleMessage(context.Background(), msg.Subject, msg.Data); err != nil {
-			s.logger.Error("Failed to handle message", "error", err)
+// handle parks a received message when more than the in-flight bound wait, and handles it
+// otherwise.
+func (s *NatsSubscriber) handle(msg *nats.Msg) {
+	if s.overflow != nil && s.throttle.Full(s.inFlight()) {
+		err := s.overflow.Park(msg)
+		if err == nil {
+			s.mu.Lock()
+			s.overflowed++
+			s.mu.Unlock()
 			return
 		}
+		s.logger.Warn("Failed to park message, handling it now", "subject", msg.Subject, "error", err)
+	}
+	s.process(msg)
+}
+
+// process decodes a message and delegates it to the handler, in its turn when throttled.
+func (s *NatsSubscriber) process(msg *nats.Msg) {
+	s.handling.Lock()
+	defer s.handling.Unlock()
+	if s.throttle != nil {
+		if err := s.throttle.Wait(s.baseCtx); err != nil {
+			s.recordFailure(msg.Subject, err, false)
+			return
+		}
+	}
+
+	sampled := s.sampler.Sample()
+	if sampled {
+		s.logger.Debug("Received message", "subject", msg.Subject)
+	}
 
+	ctx, cancel := s.messageContext(msg.Header)
+	defer cancel()
+
+	payload, err := s.jsonPayload(msg)
+	if err != nil {
+		s.logger.Error("Failed to decode message", "subject", msg.Subject, "error", err)
+		s.recordFailure(msg.Subject, err, true)
+		return
+	}
+
+	// Delegate all logic to the injected handler.
+	if err := s.handler.HandleMessage(ctx, msg.Subject, payload); err != nil {
+		s.logger.Error("Failed to handle message", "subject", msg.Subject, "error", err)
+		s.recordFailure(msg.Subject, err, false)
+		return
+	}
+	s.recordSuccess(msg.Subject, payload)
+
+	if sampled {
 		s.logger.Info("Successfully processed message", "subject", msg.Subject)
-	})
+	}
+}
+
+// ReplayOverflow hands parked messages back to the handler whenever the subscription has
+// room for them, until ctx is done.
+func (s *NatsSubscriber) ReplayOverflow(ctx context.Context) {
+	if s.overflow == nil {
+		return
+	}
+
+	for ctx.Err() == nil {
+		if s.throttle.Full(s.inFlight()) {
+			sleep(ctx, overflowPollWait)
+			continue
+		}
+		msgs, err := s.overflow.Fetch(ctx, overflowReplayBatch)
+		if err != nil {
+			s.logger.Error("Failed to fetch parked messages", "subject", s.subject, "error", err)
+			sleep(ctx, overflowPollWait)
+			continue
+		}
+		for _, msg := range msgs {
+			s.process(msg)
+			if err := s.overflow.Release(msg); err != nil {
+				s.logger.Error("Failed to release parked message", "subject", msg.Subject, "error", err)
+			}
+		}
+	}
+}
+
+// inFlight counts the messages received and not yet handled, the one at hand included.
+func (s *NatsSubscriber) inFlight() int {
+	s.mu.Lock()
+	subscription := s.subscription
+	s.mu.Unlock()
+	if subscription == nil {
+		return 1
+	}
+	pending, _, err := subscription.Pending()
+	if err != nil {
+		return 1
+	}
+	return pending + 1
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// StopListening drains the subscription, letting messages already received finish. Handlers
+// still running when ctx is done are cancelled.
+func (s *NatsSubscriber) StopListening(ctx context.Context) error {
+	defer s.cancel()
+	s.mu.Lock()
+	subscription := s.subscription
+	s.mu.Unlock()
+	if subscription == nil {
+		return nil
+	}
+
+	closed := subscription.StatusChanged(nats.SubscriptionClosed)
+	if err := subscription.Drain(); err != nil {
+		return fmt.Errorf("failed to drain subject '%s': %w", s.subject, err)
+	}
+	select {
+	case <-closed:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain subject '%s': %w", s.subject, ctx.Err())
+	}
+}
+
+// jsonPayload hands handlers the message as JSON whatever encoding it was published in, so
+// they decode envelopes one way only.
+func (s *NatsSubscriber) jsonPayload(msg *nats.Msg) ([]byte, error) {
+	codec, err := s.codecs.Lookup(msg.Header.Get(HeaderContentType))
+	if err != nil {
+		return nil, err
+	}
+	if codec.ContentType() == ContentTypeJSON {
+		return msg.Data, nil
+	}
+
+	var envelope EventEnvelope
+	if err := codec.Decode(msg.Data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode %s envelope: %w", codec.ContentType(), err)
+	}
+	return json.Marshal(&envelope)
+}
+
+// messageContext continues the trace of a message and bounds its processing time.
+func (s *NatsSubscriber) messageContext(header nats.Header) (context.Context, context.CancelFunc) {
+	ctx := contextFromHeaders(s.baseCtx, header)
+	if s.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.timeout)
+}
+
+// ConsumerStatus is the state of a subscription, so operators can tell an ingestion stall
+// without access to the NATS server.
+type ConsumerStatus struct {
+	Subject    string `json:"subject"`
+	QueueGroup string `json:"queue_group,omitempty"`
+	Listening  bool   `json:"listening"`
+	// messages and bytes received from the server and not yet handled
+	PendingMessages int   `json:"pending_messages"`
+	PendingBytes    int   `json:"pending_bytes"`
+	Dropped         int   `json:"dropped"`
+	Processed       int64 `json:"processed"`
+	Failed          int64 `json:"failed"`
+	// messages that could not be decoded, counted among the failed ones
+	DecodeErrors int64 `json:"decode_errors"`
+	// messages parked in the overflow of a throttled subscription
+	Overflowed    int64             `json:"overflowed"`
+	LastProcessed *ProcessedMessage `json:"last_processed,omitempty"`
+	LastError     *FailedMessage    `json:"last_error,omitempty"`
+}
+
+// ProcessedMessage is the last message a subscription handled successfully.
+type ProcessedMessage struct {
+	EventID string    `json:"event_id,omitempty"`
+	Subject string    `json:"subject"`
+	At      time.Time `json:"at"`
+}
+
+// FailedMessage is the last message a subscription failed to handle.
+type FailedMessage struct {
+	Subject string    `json:"subject"`
+	Error   string    `json:"error"`
+	At      time.Time `json:"at"`
+}
+
+// Status reports the state of the subscription and the messages handled since it started.
+func (s *NatsSubscriber) Status() ConsumerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := ConsumerStatus{
+		Subject:       s.subject,
+		QueueGroup:    s.queueGroup,
+		Processed:     s.processed,
+		Failed:        s.failed,
+		DecodeErrors:  s.decodeErrors,
+		Overflowed:    s.overflowed,
+		LastProcessed: s.lastProcessed,
+		LastError:     s.lastError,
+	}
+	if s.subscription == nil {
+		return status
+	}
+
+	status.Listening = s.subscription.IsValid()
+	// the counts are unavailable once the subscription is closed, they are left at zero
+	if msgs, bytes, err := s.subscription.Pending(); err == nil {
+		status.PendingMessages, status.PendingBytes = msgs, bytes
+	}
+	if dropped, err := s.subscription.Dropped(); err == nil {
+		status.Dropped = dropped
+	}
+	return status
+}
+
+func (s *NatsSubscriber) recordSuccess(subject string, payload []byte) {
+	// the event id is only informative, a payload without one is still counted
+	var envelope struct {
+		EventID string `json:"event_id"`
+	}
+	_ = json.Unmarshal(payload, &envelope)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.processed++
+	s.lastProcessed = &ProcessedMessage{EventID: envelope.EventID, Subject: subject, At: time.Now().UTC()}
+}
+
+func (s *NatsSubscriber) recordFailure(subject string, err error, decoding bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed++
+	if decoding {
+		s.decodeErrors++
+	}
+	s.lastError = &FailedMessage{Subject: subject, Error: err.Error(), At: time.Now().UTC()}
 }
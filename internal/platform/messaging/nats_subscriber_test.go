@@ -0,0 +1,215 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSubscriber(timeout time.Duration) *NatsSubscriber {
+	return NewNatsSubscriber(nil, nil, "erp.*", "group", timeout, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestNatsSubscriber_MessageContext(t *testing.T) {
+	t.Run("expires after the processing timeout", func(t *testing.T) {
+		// --- Arrange ---
+		subscriber := newTestSubscriber(time.Minute)
+
+		// --- Act ---
+		ctx, cancel := subscriber.messageContext(nil)
+		defer cancel()
+
+		// --- Assert ---
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+	})
+
+	t.Run("zero timeout leaves processing unbounded", func(t *testing.T) {
+		// --- Arrange ---
+		subscriber := newTestSubscriber(0)
+
+		// --- Act ---
+		ctx, cancel := subscriber.messageContext(nil)
+		defer cancel()
+
+		// --- Assert ---
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+	})
+
+	t.Run("stopping cancels handlers in flight", func(t *testing.T) {
+		// --- Arrange ---
+		subscriber := newTestSubscriber(time.Minute)
+		ctx, cancel := subscriber.messageContext(nil)
+		defer cancel()
+
+		// --- Act ---
+		err := subscriber.StopListening(context.Background())
+
+		// --- Assert ---
+		require.NoError(t, err)
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
+
+func TestNatsSubscriber_JSONPayload(t *testing.T) {
+	envelope := NewEventEnvelope("app.fabric.created", "FABRIC001", "Fabric", 1, map[string]any{"code": "FABRIC001"})
+	protobufData, err := ProtobufCodec{}.Encode(envelope)
+	require.NoError(t, err)
+	jsonData, err := JSONCodec{}.Encode(envelope)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		contentType string
+		data        []byte
+		expectErr   bool
+	}{
+		{name: "JSON without content type", data: jsonData},
+		{name: "JSON", contentType: ContentTypeJSON, data: jsonData},
+		{name: "protobuf is transcoded", contentType: ContentTypeProtobuf, data: protobufData},
+		{name: "unknown encoding", contentType: "avro/binary", data: []byte("?"), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			subscriber := newTestSubscriber(0)
+			msg := &nats.Msg{Subject: "app.fabric", Header: nats.Header{}, Data: tt.data}
+			if tt.contentType != "" {
+				msg.Header.Set(HeaderContentType, tt.contentType)
+			}
+
+			// --- Act ---
+			payload, err := subscriber.jsonPayload(msg)
+
+			// --- Assert ---
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrUnknownContentType)
+				return
+			}
+			require.NoError(t, err)
+			var decoded EventEnvelope
+			require.NoError(t, json.Unmarshal(payload, &decoded))
+			assert.Equal(t, envelope.EventID, decoded.EventID)
+			assert.Equal(t, map[string]any{"code": "FABRIC001"}, decoded.Payload)
+		})
+	}
+}
+
+// failingHandler fails every message it is given
+type failingHandler struct{}
+
+func (failingHandler) HandleMessage(context.Context, string, []byte) error {
+	return errors.New("fabric repository unavailable")
+}
+
+func TestNatsSubscriber_Status(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	envelope := NewEventEnvelope("erp.fabric.updated", "FABRIC001", "Fabric", 1, map[string]any{"code": "FABRIC001"})
+	data, err := JSONCodec{}.Encode(envelope)
+	require.NoError(t, err)
+
+	t.Run("counts processed messages and keeps the last one", func(t *testing.T) {
+		// --- Arrange ---
+		subscriber := NewNatsSubscriber(nil, &recordingHandler{}, "erp.*", "erp-service-group", 0, nil, logger)
+
+		// --- Act ---
+		subscriber.handle(&nats.Msg{Subject: "erp.fabric", Data: data})
+		subscriber.handle(&nats.Msg{Subject: "erp.fabric", Data: data})
+		status := subscriber.Status()
+
+		// --- Assert ---
+		assert.Equal(t, "erp.*", status.Subject)
+		assert.Equal(t, "erp-service-group", status.QueueGroup)
+		assert.False(t, status.Listening, "not subscribed yet")
+		assert.Equal(t, int64(2), status.Processed)
+		assert.Zero(t, status.Failed)
+		require.NotNil(t, status.LastProcessed)
+		assert.Equal(t, envelope.EventID, status.LastProcessed.EventID)
+		assert.Equal(t, "erp.fabric", status.LastProcessed.Subject)
+		assert.Nil(t, status.LastError)
+	})
+
+	t.Run("counts failures and decode errors", func(t *testing.T) {
+		// --- Arrange ---
+		subscriber := NewNatsSubscriber(nil, failingHandler{}, "app.fabric", "", 0, nil, logger)
+		undecodable := &nats.Msg{Subject: "app.fabric", Header: nats.Header{}, Data: []byte("?")}
+		undecodable.Header.Set(HeaderContentType, "avro/binary")
+
+		// --- Act ---
+		subscriber.handle(&nats.Msg{Subject: "app.fabric", Data: data})
+		subscriber.handle(undecodable)
+		status := subscriber.Status()
+
+		// --- Assert ---
+		assert.Zero(t, status.Processed)
+		assert.Nil(t, status.LastProcessed)
+		assert.Equal(t, int64(2), status.Failed)
+		assert.Equal(t, int64(1), status.DecodeErrors)
+		require.NotNil(t, status.LastError)
+		assert.Contains(t, status.LastError.Error, "avro/binary")
+	})
+}
+
+// memoryOverflow keeps parked messages in memory and cancels replaying once it is empty
+type memoryOverflow struct {
+	parked   []*nats.Msg
+	released []*nats.Msg
+	drained  context.CancelFunc
+}
+
+func (o *memoryOverflow) Park(msg *nats.Msg) error {
+	o.parked = append(o.parked, msg)
+	return nil
+}
+
+func (o *memoryOverflow) Fetch(_ context.Context, batch int) ([]*nats.Msg, error) {
+	if len(o.parked) == 0 {
+		o.drained()
+		return nil, nil
+	}
+	msgs := o.parked[:min(batch, len(o.parked))]
+	o.parked = o.parked[len(msgs):]
+	return msgs, nil
+}
+
+func (o *memoryOverflow) Release(msg *nats.Msg) error {
+	o.released = append(o.released, msg)
+	return nil
+}
+
+func TestNatsSubscriber_ReplayOverflow(t *testing.T) {
+	// --- Arrange ---
+	envelope := NewEventEnvelope("erp.fabric.updated", "FABRIC001", "Fabric", 1, map[string]any{"code": "FABRIC001"})
+	data, err := JSONCodec{}.Encode(envelope)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	handler := &recordingHandler{}
+	subscriber := NewNatsSubscriber(nil, handler, "erp.*", "group", 0, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	overflow := &memoryOverflow{drained: cancel}
+	for _, subject := range []string{"erp.fabric", "erp.stock", "erp.price"} {
+		require.NoError(t, overflow.Park(&nats.Msg{Subject: subject, Data: data}))
+	}
+	subscriber.SetThrottle(NewThrottle(ThrottleConfig{MaxPerSecond: 1000, MaxInFlight: 1}), overflow)
+
+	// --- Act ---
+	subscriber.ReplayOverflow(ctx)
+
+	// --- Assert ---
+	assert.Equal(t, []string{"erp.fabric", "erp.stock", "erp.price"}, handler.subjects)
+	assert.Len(t, overflow.released, 3)
+	assert.Equal(t, int64(3), subscriber.Status().Processed)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled, "replaying stopped once drained, not on the timeout")
+}
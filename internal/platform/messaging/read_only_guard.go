@@ -0,0 +1,114 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/readonly"
+)
+
+type parkedMessage struct {
+	subject string
+	payload []byte
+}
+
+// ReadOnlyGuard holds back messages that would change state while the service is
+// read-only. Core NATS does not redeliver, so held messages are parked in memory and
+// replayed in arrival order once writes are accepted again. A message arriving when the
+// parking is full is refused with readonly.ErrReadOnly.
+type ReadOnlyGuard struct {
+	next     MessageHandler
+	mode     *readonly.Mode
+	capacity int
+	logger   *slog.Logger
+
+	// held while handling, so parked messages are replayed before newer ones
+	mu     sync.Mutex
+	parked []parkedMessage
+}
+
+// NewReadOnlyGuard wraps next, parking at most capacity messages while read-only.
+func NewReadOnlyGuard(next MessageHandler, mode *readonly.Mode, capacity int, logger *slog.Logger) *ReadOnlyGuard {
+	return &ReadOnlyGuard{
+		next:     next,
+		mode:     mode,
+		capacity: capacity,
+		logger:   logger.With("component", "readOnlyGuard"),
+	}
+}
+
+// HandleMessage implements the MessageHandler interface.
+func (g *ReadOnlyGuard) HandleMessage(ctx context.Context, subject string, payload []byte) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.mode.Enabled() {
+		if len(g.parked) >= g.capacity {
+			return fmt.Errorf("parking full with %d messages: %w", len(g.parked), readonly.ErrReadOnly)
+		}
+		g.parked = append(g.parked, parkedMessage{subject: subject, payload: bytes.Clone(payload)})
+		if len(g.parked) == 1 {
+			g.logger.Warn("Parking messages while read-only", "subject", subject)
+		}
+		return nil
+	}
+
+	g.replay(ctx)
+	return g.next.HandleMessage(ctx, subject, payload)
+}
+
+// Parked returns how many messages wait to be replayed.
+func (g *ReadOnlyGuard) Parked() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.parked)
+}
+
+// Run replays parked messages every interval once the service is writable again, so they
+// do not wait for the next message to arrive.
+func (g *ReadOnlyGuard) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if g.mode.Enabled() {
+				continue
+			}
+			g.mu.Lock()
+			g.replay(ctx)
+			g.mu.Unlock()
+		}
+	}
+}
+
+// replay hands the parked messages to next, stopping if the service turns read-only again.
+// A message failing on replay is logged and dropped, as it would have been on arrival.
+func (g *ReadOnlyGuard) replay(ctx context.Context) {
+	if len(g.parked) == 0 {
+		return
+	}
+
+	replayed := 0
+	for _, message := range g.parked {
+		if g.mode.Enabled() || ctx.Err() != nil {
+			break
+		}
+		if err := g.next.HandleMessage(ctx, message.subject, message.payload); err != nil {
+			g.logger.Error("Failed to handle parked message", "subject", message.subject, "error", err)
+		}
+		replayed++
+	}
+	g.parked = g.parked[replayed:]
+	if len(g.parked) == 0 {
+		g.parked = nil
+	}
+	g.logger.Info("Replayed parked messages", "replayed", replayed, "remaining", len(g.parked))
+}
@@ -0,0 +1,74 @@
+package messaging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/readonly"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGuard(next MessageHandler, mode *readonly.Mode, capacity int) *ReadOnlyGuard {
+	return NewReadOnlyGuard(next, mode, capacity, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestReadOnlyGuard_ParksWhileReadOnly(t *testing.T) {
+	// --- Arrange ---
+	mode := readonly.New(true)
+	next := &recordingHandler{}
+	guard := newTestGuard(next, mode, 10)
+	ctx := context.Background()
+
+	// --- Act ---
+	require.NoError(t, guard.HandleMessage(ctx, "erp.fabric.1", nil))
+	require.NoError(t, guard.HandleMessage(ctx, "erp.fabric.2", nil))
+
+	// --- Assert ---
+	assert.Empty(t, next.subjects, "no message may be handled while read-only")
+	assert.Equal(t, 2, guard.Parked())
+
+	mode.Set(false, "", "ops", time.Now())
+	require.NoError(t, guard.HandleMessage(ctx, "erp.fabric.3", nil))
+	assert.Equal(t, []string{"erp.fabric.1", "erp.fabric.2", "erp.fabric.3"}, next.subjects,
+		"parked messages must be replayed before newer ones")
+	assert.Zero(t, guard.Parked())
+}
+
+func TestReadOnlyGuard_RefusesWhenParkingIsFull(t *testing.T) {
+	// --- Arrange ---
+	guard := newTestGuard(&recordingHandler{}, readonly.New(true), 1)
+	require.NoError(t, guard.HandleMessage(context.Background(), "erp.fabric.1", nil))
+
+	// --- Act ---
+	err := guard.HandleMessage(context.Background(), "erp.fabric.2", nil)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, readonly.ErrReadOnly)
+	assert.Equal(t, 1, guard.Parked())
+}
+
+func TestReadOnlyGuard_RunReplaysOnceWritable(t *testing.T) {
+	// --- Arrange ---
+	mode := readonly.New(true)
+	next := &recordingHandler{}
+	guard := newTestGuard(next, mode, 10)
+	require.NoError(t, guard.HandleMessage(context.Background(), "erp.fabric.1", nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go guard.Run(ctx, 10*time.Millisecond)
+
+	// --- Act ---
+	mode.Set(false, "", "ops", time.Now())
+
+	// --- Assert ---
+	assert.Eventually(t, func() bool { return guard.Parked() == 0 }, time.Second, 10*time.Millisecond)
+	cancel()
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+	assert.Equal(t, []string{"erp.fabric.1"}, next.subjects)
+}
@@ -0,0 +1,84 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// ThrottleConfig bounds the consumption of a subscription, so a burst of messages such as
+// a full ERP re-sync cannot take every database connection from interactive traffic. Zero
+// values leave the bound off.
+type ThrottleConfig struct {
+	// MaxPerSecond is the most messages handled per second.
+	MaxPerSecond float64
+	// MaxInFlight is the most messages received and not yet handled. Messages beyond it are
+	// parked in the overflow and handled once the subscription catches up.
+	MaxInFlight int
+}
+
+// Throttle spaces the messages of a subscription evenly to at most MaxPerSecond and tells
+// when more messages than MaxInFlight wait to be handled.
+type Throttle struct {
+	config   ThrottleConfig
+	interval time.Duration
+
+	mu sync.Mutex
+	// the earliest moment the next message may be handled
+	next time.Time
+}
+
+func NewThrottle(config ThrottleConfig) *Throttle {
+	throttle := &Throttle{config: config}
+	if config.MaxPerSecond > 0 {
+		throttle.interval = time.Duration(float64(time.Second) / config.MaxPerSecond)
+	}
+	return throttle
+}
+
+// Wait blocks until the next message may be handled, or until ctx is done.
+func (t *Throttle) Wait(ctx context.Context) error {
+	if t.interval == 0 {
+		return ctx.Err()
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	slot := t.next
+	if slot.Before(now) {
+		slot = now
+	}
+	t.next = slot.Add(t.interval)
+	t.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Full reports whether inFlight messages received and not yet handled exceed MaxInFlight.
+func (t *Throttle) Full(inFlight int) bool {
+	return t.config.MaxInFlight > 0 && inFlight > t.config.MaxInFlight
+}
+
+// Overflow keeps the messages a throttled subscription has no room for, until it has.
+type Overflow interface {
+	// Park keeps the message, headers included.
+	Park(msg *nats.Msg) error
+	// Fetch returns up to batch parked messages, oldest first, on the subject they were
+	// received on. It returns no messages when none are parked.
+	Fetch(ctx context.Context, batch int) ([]*nats.Msg, error)
+	// Release drops a fetched message from the overflow once it has been handled.
+	Release(msg *nats.Msg) error
+}
@@ -0,0 +1,79 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottle_Wait(t *testing.T) {
+	t.Run("spaces messages evenly", func(t *testing.T) {
+		// --- Arrange ---
+		throttle := NewThrottle(ThrottleConfig{MaxPerSecond: 50})
+		start := time.Now()
+
+		// --- Act ---
+		for range 4 {
+			assert.NoError(t, throttle.Wait(context.Background()))
+		}
+
+		// --- Assert ---
+		assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond, "the first message is not delayed")
+	})
+
+	t.Run("zero rate leaves messages undelayed", func(t *testing.T) {
+		// --- Arrange ---
+		throttle := NewThrottle(ThrottleConfig{})
+		start := time.Now()
+
+		// --- Act ---
+		for range 100 {
+			assert.NoError(t, throttle.Wait(context.Background()))
+		}
+
+		// --- Assert ---
+		assert.Less(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		// --- Arrange ---
+		throttle := NewThrottle(ThrottleConfig{MaxPerSecond: 0.1})
+		assert.NoError(t, throttle.Wait(context.Background()))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// --- Act ---
+		err := throttle.Wait(ctx)
+
+		// --- Assert ---
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestThrottle_Full(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   ThrottleConfig
+		inFlight int
+		expected bool
+	}{
+		{name: "Below the bound", config: ThrottleConfig{MaxInFlight: 5}, inFlight: 5, expected: false},
+		{name: "Beyond the bound", config: ThrottleConfig{MaxInFlight: 5}, inFlight: 6, expected: true},
+		{name: "No bound", config: ThrottleConfig{}, inFlight: 10000, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			throttle := NewThrottle(tc.config)
+
+			// --- Act ---
+			full := throttle.Full(tc.inFlight)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expected, full)
+		})
+	}
+}
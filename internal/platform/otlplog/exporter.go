@@ -0,0 +1,264 @@
+package otlplog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	// records waiting for export, further records are dropped until the queue drains
+	queueSize = 4096
+	// records sent in one request
+	batchSize = 512
+	// longest a record waits in a partial batch
+	flushInterval = 5 * time.Second
+	// bound on a single export request
+	exportTimeout = 10 * time.Second
+)
+
+// Exporter batches log records and ships them to an OTLP/HTTP collector encoded as JSON.
+// Exporting never blocks logging: when the collector falls behind records are dropped
+// and counted, the local log output is unaffected.
+type Exporter struct {
+	endpoint string
+	client   *http.Client
+	resource []keyValue
+
+	queue    chan logRecord
+	done     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+	dropped  atomic.Int64
+}
+
+// NewExporter returns an exporter posting to endpoint, the full URL of the collector's
+// logs receiver (e.g. http://collector:4318/v1/logs). Every batch carries the resource
+// attributes, so logs line up with the metrics and traces of the same process.
+func NewExporter(endpoint string, res *resource.Resource) *Exporter {
+	attrs := make([]keyValue, 0, len(res.Attributes()))
+	for _, kv := range res.Attributes() {
+		attrs = append(attrs, resourceAttr(kv))
+	}
+
+	return &Exporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: exportTimeout},
+		resource: attrs,
+		queue:    make(chan logRecord, queueSize),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+}
+
+// Start exports queued records in the background until Shutdown.
+func (e *Exporter) Start() {
+	go e.run()
+}
+
+// Shutdown exports the records still queued and stops the exporter. It returns early
+// when ctx ends first.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.stopOnce.Do(func() { close(e.done) })
+
+	select {
+	case <-e.stopped:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("log export did not finish: %w", ctx.Err())
+	}
+}
+
+// Dropped reports how many records were discarded because the queue was full.
+func (e *Exporter) Dropped() int64 {
+	return e.dropped.Load()
+}
+
+func (e *Exporter) enqueue(rec logRecord) {
+	select {
+	case e.queue <- rec:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+func (e *Exporter) run() {
+	defer close(e.stopped)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]logRecord, 0, batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+		// the logger cannot report its own failures, they go straight to stderr
+		if err := e.export(ctx, batch); err != nil {
+			fmt.Fprintf(os.Stderr, "otlp log export failed: %v (%d records lost)\n", err, len(batch))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case rec := <-e.queue:
+			batch = append(batch, rec)
+			if len(batch) == batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			for {
+				select {
+				case rec := <-e.queue:
+					batch = append(batch, rec)
+					if len(batch) == batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *Exporter) export(ctx context.Context, batch []logRecord) error {
+	records := make([]otlpLogRecord, len(batch))
+	for i, rec := range batch {
+		records[i] = rec.toOTLP()
+	}
+
+	body, err := json.Marshal(exportRequest{ResourceLogs: []resourceLogs{{
+		Resource: otlpResource{Attributes: e.resource},
+		ScopeLogs: []scopeLogs{{
+			Scope:      scope{Name: telemetry.ScopeName},
+			LogRecords: records,
+		}},
+	}}})
+	if err != nil {
+		return fmt.Errorf("failed to encode logs: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach collector: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("collector responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// logRecord is a log entry captured by Handler, waiting for export
+type logRecord struct {
+	time    time.Time
+	level   slog.Level
+	message string
+	attrs   []keyValue
+	traceID string
+	spanID  string
+}
+
+func (r logRecord) toOTLP() otlpLogRecord {
+	msg := r.message
+	return otlpLogRecord{
+		TimeUnixNano:   strconv.FormatInt(r.time.UnixNano(), 10),
+		SeverityNumber: severityNumber(r.level),
+		SeverityText:   r.level.String(),
+		Body:           anyValue{StringValue: &msg},
+		Attributes:     r.attrs,
+		TraceID:        r.traceID,
+		SpanID:         r.spanID,
+	}
+}
+
+// maps slog levels onto the OTLP severity range, INFO is 9 and each slog step of 4 is
+// one OTLP band of 4
+func severityNumber(level slog.Level) int {
+	n := int(level) + 9
+	switch {
+	case n < 1:
+		return 1
+	case n > 24:
+		return 24
+	}
+	return n
+}
+
+func resourceAttr(kv attribute.KeyValue) keyValue {
+	value := kv.Value.Emit()
+	return keyValue{Key: string(kv.Key), Value: anyValue{StringValue: &value}}
+}
+
+// --- OTLP/JSON wire format (opentelemetry-proto ExportLogsServiceRequest) ---
+
+type exportRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  otlpResource `json:"resource"`
+	ScopeLogs []scopeLogs  `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeLogs struct {
+	Scope      scope           `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string     `json:"timeUnixNano"`
+	SeverityNumber int        `json:"severityNumber"`
+	SeverityText   string     `json:"severityText"`
+	Body           anyValue   `json:"body"`
+	Attributes     []keyValue `json:"attributes,omitempty"`
+	TraceID        string     `json:"traceId,omitempty"`
+	SpanID         string     `json:"spanId,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+// anyValue holds exactly one of its fields, 64 bit integers are strings in OTLP/JSON
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
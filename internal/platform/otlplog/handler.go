@@ -0,0 +1,134 @@
+package otlplog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Handler passes every record to the wrapped handler and queues it on the exporter.
+// Records logged with a context carrying a span are stamped with its trace_id and
+// span_id, so a log line can be followed to its trace from either backend. Without an
+// exporter the handler only adds the correlation.
+type Handler struct {
+	next     slog.Handler
+	exporter *Exporter
+	attrs    []keyValue
+	prefix   string
+}
+
+func NewHandler(next slog.Handler, exporter *Exporter) *Handler {
+	return &Handler{
+		next:     next,
+		exporter: exporter,
+	}
+}
+
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	sc := trace.SpanContextFromContext(ctx)
+
+	if h.exporter != nil {
+		rec := logRecord{
+			time:    r.Time,
+			level:   r.Level,
+			message: r.Message,
+			attrs:   make([]keyValue, len(h.attrs), len(h.attrs)+r.NumAttrs()),
+		}
+		copy(rec.attrs, h.attrs)
+		r.Attrs(func(a slog.Attr) bool {
+			rec.attrs = appendAttr(rec.attrs, h.prefix, a)
+			return true
+		})
+		if sc.IsValid() {
+			rec.traceID = sc.TraceID().String()
+			rec.spanID = sc.SpanID().String()
+		}
+		h.exporter.enqueue(rec)
+	}
+
+	if sc.IsValid() {
+		r = r.Clone()
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.next = h.next.WithAttrs(attrs)
+	next.attrs = make([]keyValue, len(h.attrs), len(h.attrs)+len(attrs))
+	copy(next.attrs, h.attrs)
+	for _, a := range attrs {
+		next.attrs = appendAttr(next.attrs, h.prefix, a)
+	}
+	return &next
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	next := *h
+	next.next = h.next.WithGroup(name)
+	next.prefix = h.prefix + name + "."
+	return &next
+}
+
+// appendAttr converts a slog attribute to its OTLP form, groups are flattened into
+// dotted keys
+func appendAttr(dst []keyValue, prefix string, a slog.Attr) []keyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return dst
+	}
+
+	key := prefix + a.Key
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix = key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			dst = appendAttr(dst, groupPrefix, ga)
+		}
+		return dst
+	case slog.KindBool:
+		b := a.Value.Bool()
+		return append(dst, keyValue{Key: key, Value: anyValue{BoolValue: &b}})
+	case slog.KindInt64:
+		i := strconv.FormatInt(a.Value.Int64(), 10)
+		return append(dst, keyValue{Key: key, Value: anyValue{IntValue: &i}})
+	case slog.KindUint64:
+		i := strconv.FormatUint(a.Value.Uint64(), 10)
+		return append(dst, keyValue{Key: key, Value: anyValue{IntValue: &i}})
+	case slog.KindFloat64:
+		f := a.Value.Float64()
+		return append(dst, keyValue{Key: key, Value: anyValue{DoubleValue: &f}})
+	case slog.KindTime:
+		s := a.Value.Time().Format(time.RFC3339Nano)
+		return append(dst, keyValue{Key: key, Value: anyValue{StringValue: &s}})
+	case slog.KindAny:
+		var s string
+		if err, ok := a.Value.Any().(error); ok {
+			s = err.Error()
+		} else {
+			s = fmt.Sprint(a.Value.Any())
+		}
+		return append(dst, keyValue{Key: key, Value: anyValue{StringValue: &s}})
+	default:
+		s := a.Value.String()
+		return append(dst, keyValue{Key: key, Value: anyValue{StringValue: &s}})
+	}
+}
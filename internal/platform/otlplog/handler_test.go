@@ -0,0 +1,133 @@
+package otlplog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// collector records the export requests it receives
+type collector struct {
+	mu       sync.Mutex
+	requests []exportRequest
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req exportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	c.requests = append(c.requests, req)
+	c.mu.Unlock()
+}
+
+func spanContext() trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanID:  trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+	})
+}
+
+func TestHandler_ExportsRecordsWithResourceAndTrace(t *testing.T) {
+	// --- Arrange ---
+	col := &collector{}
+	server := httptest.NewServer(col)
+	defer server.Close()
+
+	res := resource.NewSchemaless(attribute.String("service.name", "s-works-api"))
+	exporter := NewExporter(server.URL+"/v1/logs", res)
+	exporter.Start()
+
+	logger := slog.New(NewHandler(slog.NewTextHandler(io.Discard, nil), exporter)).
+		With("component", "api").
+		WithGroup("fabric")
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext())
+
+	// --- Act ---
+	logger.WarnContext(ctx, "fabric locked", "code", "TESTCODE", "attempts", 3, "error", errors.New("busy"))
+	ctxShutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, exporter.Shutdown(ctxShutdown))
+
+	// --- Assert ---
+	require.Len(t, col.requests, 1, "shutdown should flush the queued record")
+	resourceLogs := col.requests[0].ResourceLogs[0]
+	require.Len(t, resourceLogs.Resource.Attributes, 1)
+	assert.Equal(t, "service.name", resourceLogs.Resource.Attributes[0].Key)
+	assert.Equal(t, "s-works-api", *resourceLogs.Resource.Attributes[0].Value.StringValue)
+
+	require.Len(t, resourceLogs.ScopeLogs[0].LogRecords, 1)
+	record := resourceLogs.ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, "fabric locked", *record.Body.StringValue)
+	assert.Equal(t, 13, record.SeverityNumber, "WARN maps to the OTLP WARN severity")
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", record.TraceID)
+	assert.Equal(t, "0102030405060708", record.SpanID)
+
+	attrs := map[string]anyValue{}
+	for _, kv := range record.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	assert.Equal(t, "api", *attrs["component"].StringValue)
+	assert.Equal(t, "TESTCODE", *attrs["fabric.code"].StringValue, "group names should prefix the keys")
+	assert.Equal(t, "3", *attrs["fabric.attempts"].IntValue)
+	assert.Equal(t, "busy", *attrs["fabric.error"].StringValue)
+}
+
+func TestHandler_CorrelatesLocalOutputWithTrace(t *testing.T) {
+	// --- Arrange ---
+	var out bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&out, nil), nil))
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext())
+
+	// --- Act ---
+	logger.InfoContext(ctx, "in a span")
+	logger.Info("outside a span")
+
+	// --- Assert ---
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var inSpan, outside map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &inSpan))
+	require.NoError(t, json.Unmarshal(lines[1], &outside))
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", inSpan["trace_id"])
+	assert.Equal(t, "0102030405060708", inSpan["span_id"])
+	assert.NotContains(t, outside, "trace_id")
+}
+
+func TestExporter_DropsRecordsWhenQueueIsFull(t *testing.T) {
+	// --- Arrange ---
+	exporter := NewExporter("http://127.0.0.1:0/v1/logs", resource.NewSchemaless())
+
+	// --- Act ---
+	for range queueSize + 3 {
+		exporter.enqueue(logRecord{message: "burst"})
+	}
+
+	// --- Assert ---
+	assert.Equal(t, int64(3), exporter.Dropped())
+}
+
+func TestSeverityNumber(t *testing.T) {
+	assert.Equal(t, 5, severityNumber(slog.LevelDebug))
+	assert.Equal(t, 9, severityNumber(slog.LevelInfo))
+	assert.Equal(t, 13, severityNumber(slog.LevelWarn))
+	assert.Equal(t, 17, severityNumber(slog.LevelError))
+	assert.Equal(t, 24, severityNumber(slog.Level(100)))
+}
@@ -0,0 +1,79 @@
+// Package quantity holds the amounts of material the domain modules count: the stock of a
+// fabric, the lines of an order, the components of a product. An amount is kept in
+// thousandths of the measure unit of its material, so it is exact for centimetres of a
+// metre and grams of a kilogram. Each module decides which amounts it accepts, such as
+// positive ones only.
+package quantity
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Decimals is the number of decimals a quantity is kept with.
+const Decimals = 3
+
+// ErrInvalid is returned for text that is not a decimal number with at most Decimals
+// decimals.
+var ErrInvalid = errors.New("the quantity must be a decimal number with at most 3 decimals")
+
+// Quantity is an amount of material in its measure unit, in thousandths of the unit.
+type Quantity int64
+
+// Parse reads a decimal quantity such as "12.5" or "-3".
+func Parse(raw string) (Quantity, error) {
+	sign := int64(1)
+	digits := raw
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = -1, digits[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if whole == "" || len(fraction) > Decimals || strings.ContainsAny(whole, "+-") {
+		return 0, ErrInvalid
+	}
+	fraction += strings.Repeat("0", Decimals-len(fraction))
+	thousandths, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, ErrInvalid
+	}
+
+	return Quantity(sign * thousandths), nil
+}
+
+// String formats the quantity without trailing zeros, e.g. "12.5".
+func (q Quantity) String() string {
+	sign := ""
+	if q < 0 {
+		sign, q = "-", -q
+	}
+	digits := strconv.FormatInt(int64(q), 10)
+	if len(digits) <= Decimals {
+		digits = strings.Repeat("0", Decimals-len(digits)+1) + digits
+	}
+	whole, fraction := digits[:len(digits)-Decimals], strings.TrimRight(digits[len(digits)-Decimals:], "0")
+	if fraction == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + fraction
+}
+
+// MarshalJSON writes the quantity as a decimal string, so no precision is lost on the way.
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(q.String())), nil
+}
+
+// UnmarshalJSON reads the decimal string MarshalJSON writes, for stored events.
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	raw, err := strconv.Unquote(string(data))
+	if err != nil {
+		return ErrInvalid
+	}
+	parsed, err := Parse(raw)
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
@@ -0,0 +1,59 @@
+package quantity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		raw              string
+		expectedQuantity Quantity
+		expectedErr      error
+	}{
+		{raw: "12.5", expectedQuantity: 12500},
+		{raw: "0.001", expectedQuantity: 1},
+		{raw: "-3", expectedQuantity: -3000},
+		{raw: "7", expectedQuantity: 7000},
+		{raw: "1.2345", expectedErr: ErrInvalid},
+		{raw: "+1", expectedErr: ErrInvalid},
+		{raw: "--1", expectedErr: ErrInvalid},
+		{raw: ".5", expectedErr: ErrInvalid},
+		{raw: "1,5", expectedErr: ErrInvalid},
+		{raw: "", expectedErr: ErrInvalid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.raw, func(t *testing.T) {
+			// --- Act ---
+			quantity, err := Parse(tc.raw)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedQuantity, quantity)
+		})
+	}
+}
+
+func TestQuantity_String(t *testing.T) {
+	assert.Equal(t, "12.5", Quantity(12500).String())
+	assert.Equal(t, "0.001", Quantity(1).String())
+	assert.Equal(t, "-3", Quantity(-3000).String())
+	assert.Equal(t, "0", Quantity(0).String())
+}
+
+func TestQuantity_JSON(t *testing.T) {
+	// --- Act ---
+	data, err := json.Marshal(Quantity(-2250))
+	require.NoError(t, err)
+	var decoded Quantity
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	// --- Assert ---
+	assert.JSONEq(t, `"-2.25"`, string(data))
+	assert.Equal(t, Quantity(-2250), decoded)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`2.25`), &decoded), ErrInvalid, "quantities are written as strings")
+}
@@ -0,0 +1,58 @@
+// Package readonly holds the switch putting the service in read-only mode for disaster
+// recovery. While it is on, commands are refused and queries keep being served, so the
+// primary database can be restored without writes racing the restore.
+package readonly
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrReadOnly is returned for a mutation attempted while the service is read-only.
+var ErrReadOnly = errors.New("the service is in read-only mode")
+
+// Status describes the current mode and the last time it was switched.
+type Status struct {
+	Enabled   bool       `json:"enabled"`
+	Reason    string     `json:"reason,omitempty"`
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
+	ChangedBy string     `json:"changed_by,omitempty"`
+}
+
+// Mode is the read-only switch shared by the HTTP and messaging entry points. It is kept
+// in memory, so switching it affects only the instance it was switched on.
+type Mode struct {
+	mu     sync.RWMutex
+	status Status
+}
+
+// New returns a switch starting in the given mode, as configured at startup.
+func New(enabled bool) *Mode {
+	return &Mode{status: Status{Enabled: enabled}}
+}
+
+func (m *Mode) Enabled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status.Enabled
+}
+
+func (m *Mode) Status() Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+// Set switches the mode and reports whether it changed. Setting the current mode again
+// keeps the record of who switched it last.
+func (m *Mode) Set(enabled bool, reason, by string, at time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.status.Enabled == enabled {
+		return false
+	}
+	m.status = Status{Enabled: enabled, Reason: reason, ChangedAt: &at, ChangedBy: by}
+	return true
+}
@@ -0,0 +1,30 @@
+package readonly
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMode_Set(t *testing.T) {
+	// --- Arrange ---
+	mode := New(false)
+	at := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	// --- Act ---
+	enabled := mode.Set(true, "restoring primary", "ops", at)
+	repeated := mode.Set(true, "again", "someone else", at.Add(time.Hour))
+
+	// --- Assert ---
+	assert.True(t, enabled)
+	assert.False(t, repeated, "setting the current mode again must not change it")
+	assert.True(t, mode.Enabled())
+
+	status := mode.Status()
+	assert.Equal(t, "restoring primary", status.Reason)
+	assert.Equal(t, "ops", status.ChangedBy)
+	require.NotNil(t, status.ChangedAt)
+	assert.Equal(t, at, *status.ChangedAt)
+}
@@ -0,0 +1,113 @@
+// Package rebuild tracks the rebuilds of the data query endpoints read, such as an import
+// restoring the event store. While one runs, the endpoints reading that data serve
+// incomplete results, which they report as degraded rather than serve silently.
+package rebuild
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventStore is the event store, read by the change feed and the history of fabrics.
+const EventStore = "event_store"
+
+// Run is a rebuild in progress.
+type Run struct {
+	Name      string    `json:"name"`
+	Sources   []string  `json:"sources"`
+	StartedAt time.Time `json:"started_at"`
+	StartedBy string    `json:"started_by,omitempty"`
+	// DegradedEndpoints are the query endpoints reading the sources, served incomplete
+	// until the rebuild is done.
+	DegradedEndpoints []string `json:"degraded_endpoints"`
+}
+
+// Tracker knows the rebuilds in progress and which endpoints read the data they rewrite.
+// It is kept in memory, so a rebuild is only reported by the instance running it.
+type Tracker struct {
+	mu      sync.RWMutex
+	readers map[string][]string
+	runs    map[int]Run
+	nextID  int
+}
+
+func New() *Tracker {
+	return &Tracker{readers: map[string][]string{}, runs: map[int]Run{}}
+}
+
+// Reads records that the endpoint serves the sources, so readiness lists it while they are
+// rebuilt.
+func (t *Tracker) Reads(endpoint string, sources ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, source := range sources {
+		if !slices.Contains(t.readers[source], endpoint) {
+			t.readers[source] = append(t.readers[source], endpoint)
+		}
+	}
+}
+
+// Start records a rebuild of the sources as running, until the returned function is
+// called.
+func (t *Tracker) Start(name string, sources []string, by string, at time.Time) (finish func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := t.nextID
+	t.nextID++
+	t.runs[id] = Run{Name: name, Sources: sources, StartedAt: at, StartedBy: by}
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.runs, id)
+	}
+}
+
+// Running returns the rebuilds in progress, the oldest first, each with the endpoints it
+// degrades.
+func (t *Tracker) Running() []Run {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	runs := make([]Run, 0, len(t.runs))
+	for _, run := range t.runs {
+		run.DegradedEndpoints = []string{}
+		for _, source := range run.Sources {
+			for _, endpoint := range t.readers[source] {
+				if !slices.Contains(run.DegradedEndpoints, endpoint) {
+					run.DegradedEndpoints = append(run.DegradedEndpoints, endpoint)
+				}
+			}
+		}
+		slices.Sort(run.DegradedEndpoints)
+		runs = append(runs, run)
+	}
+	slices.SortFunc(runs, func(a, b Run) int {
+		if c := a.StartedAt.Compare(b.StartedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return runs
+}
+
+// Rebuilding returns the names of the rebuilds in progress rewriting any of the sources.
+func (t *Tracker) Rebuilding(sources ...string) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var names []string
+	for _, run := range t.runs {
+		for _, source := range sources {
+			if slices.Contains(run.Sources, source) && !slices.Contains(names, run.Name) {
+				names = append(names, run.Name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,54 @@
+package rebuild
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker_StartAndFinish(t *testing.T) {
+	// --- Arrange ---
+	tracker := New()
+	tracker.Reads("GET /v1/fabrics/changes", EventStore)
+	tracker.Reads("GET /v1/fabrics/{code}/history", EventStore)
+	tracker.Reads("GET /v1/fabrics/changes", EventStore)
+	at := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	// --- Act ---
+	finish := tracker.Start("event-import", []string{EventStore}, "ops", at)
+	running := tracker.Running()
+	rebuilding := tracker.Rebuilding(EventStore)
+	unrelated := tracker.Rebuilding("search_index")
+	finish()
+
+	// --- Assert ---
+	require.Len(t, running, 1)
+	assert.Equal(t, "event-import", running[0].Name)
+	assert.Equal(t, "ops", running[0].StartedBy)
+	assert.Equal(t, at, running[0].StartedAt)
+	assert.Equal(t, []string{"GET /v1/fabrics/changes", "GET /v1/fabrics/{code}/history"}, running[0].DegradedEndpoints)
+	assert.Equal(t, []string{"event-import"}, rebuilding)
+	assert.Empty(t, unrelated, "a rebuild degrades only the endpoints reading its sources")
+	assert.Empty(t, tracker.Running(), "a finished rebuild is no longer reported")
+	assert.Empty(t, tracker.Rebuilding(EventStore))
+}
+
+func TestTracker_ConcurrentRebuilds(t *testing.T) {
+	// --- Arrange ---
+	tracker := New()
+	at := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	finishLater := tracker.Start("event-import", []string{EventStore}, "ops", at.Add(time.Minute))
+	finishFirst := tracker.Start("event-import", []string{EventStore}, "ops", at)
+
+	// --- Act ---
+	finishFirst()
+	running := tracker.Running()
+	finishLater()
+
+	// --- Assert ---
+	require.Len(t, running, 1, "each rebuild ends on its own")
+	assert.Equal(t, at.Add(time.Minute), running[0].StartedAt)
+	assert.Empty(t, tracker.Running())
+}
@@ -0,0 +1,171 @@
+// Package recording holds the opt-in debug recorder capturing request/response pairs of
+// the HTTP API. It is switched on for a route or a fabric code for a limited time, so a
+// client issue that cannot be reproduced can be looked at as the client saw it. Exchanges
+// are sanitized before they are kept, and only the most recent ones are kept.
+package recording
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// MaxDuration bounds how long recording stays on, so a forgotten switch turns itself off.
+const MaxDuration = 24 * time.Hour
+
+var (
+	ErrInvalidSampleRate = errors.New("sample_rate must be greater than 0 and at most 1")
+	ErrInvalidDuration   = errors.New("duration must be greater than 0 and at most 24h")
+	ErrNoFilter          = errors.New("route or fabric_code must be provided")
+)
+
+// Settings tell which requests are recorded and until when.
+type Settings struct {
+	Enabled bool `json:"enabled"`
+	// Route is the pattern of the route to record, such as /v1/fabrics/{code}
+	Route string `json:"route,omitempty"`
+	// FabricCode records the requests addressing this fabric on any route
+	FabricCode string     `json:"fabric_code,omitempty"`
+	SampleRate float64    `json:"sample_rate,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	ChangedAt  *time.Time `json:"changed_at,omitempty"`
+	ChangedBy  string     `json:"changed_by,omitempty"`
+}
+
+// Exchange is a recorded request together with the response it got.
+type Exchange struct {
+	RecordedAt      time.Time         `json:"recorded_at"`
+	TraceID         string            `json:"trace_id,omitempty"`
+	Method          string            `json:"method"`
+	Route           string            `json:"route"`
+	Path            string            `json:"path"`
+	Query           string            `json:"query,omitempty"`
+	RequestHeaders  map[string]string `json:"request_headers"`
+	RequestBody     string            `json:"request_body,omitempty"`
+	Status          int               `json:"status"`
+	ResponseHeaders map[string]string `json:"response_headers"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+	DurationMS      int64             `json:"duration_ms"`
+	// Truncated tells that a body was longer than MaxBodyBytes and was cut off
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Recorder keeps the settings and a ring buffer of the latest exchanges. It is kept in
+// memory, so it records only the requests served by the instance it was switched on.
+type Recorder struct {
+	mu        sync.RWMutex
+	settings  Settings
+	exchanges []Exchange
+	next      int
+	full      bool
+	sample    func() float64
+}
+
+// New returns a recorder, switched off, keeping up to capacity exchanges.
+func New(capacity int) *Recorder {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Recorder{exchanges: make([]Exchange, capacity), sample: rand.Float64}
+}
+
+// Settings returns the current settings, reported as off once they expired.
+func (r *Recorder) Settings(now time.Time) Settings {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings := r.settings
+	if settings.Enabled && settings.ExpiresAt != nil && !now.Before(*settings.ExpiresAt) {
+		settings.Enabled = false
+	}
+	return settings
+}
+
+// Enable starts recording the requests matching the route or the fabric code, sampled at
+// the rate, for the duration.
+func (r *Recorder) Enable(
+	route, fabricCode string, sampleRate float64, duration time.Duration, by string, at time.Time,
+) (Settings, error) {
+	if route == "" && fabricCode == "" {
+		return Settings{}, ErrNoFilter
+	}
+	if sampleRate <= 0 || sampleRate > 1 {
+		return Settings{}, ErrInvalidSampleRate
+	}
+	if duration <= 0 || duration > MaxDuration {
+		return Settings{}, ErrInvalidDuration
+	}
+
+	expiresAt := at.Add(duration)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.settings = Settings{
+		Enabled: true, Route: route, FabricCode: fabricCode, SampleRate: sampleRate,
+		ExpiresAt: &expiresAt, ChangedAt: &at, ChangedBy: by,
+	}
+	return r.settings, nil
+}
+
+// Disable stops recording, the exchanges recorded so far are kept.
+func (r *Recorder) Disable(by string, at time.Time) Settings {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.settings = Settings{ChangedAt: &at, ChangedBy: by}
+	return r.settings
+}
+
+// Active reports whether recording is on, a cheap check made before anything is captured.
+func (r *Recorder) Active(now time.Time) bool {
+	return r.Settings(now).Enabled
+}
+
+// Matches decides whether the request served on the route for the fabric code is
+// recorded, sampling the requests that match the settings.
+func (r *Recorder) Matches(route, fabricCode string, now time.Time) bool {
+	settings := r.Settings(now)
+	if !settings.Enabled {
+		return false
+	}
+	if settings.Route != "" && settings.Route != route {
+		return false
+	}
+	if settings.FabricCode != "" && settings.FabricCode != fabricCode {
+		return false
+	}
+	return settings.SampleRate >= 1 || r.sample() < settings.SampleRate
+}
+
+// Record keeps the exchange, replacing the oldest one once the buffer is full.
+func (r *Recorder) Record(exchange Exchange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.exchanges[r.next] = exchange
+	r.next = (r.next + 1) % len(r.exchanges)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Exchanges returns the recorded exchanges, oldest first.
+func (r *Recorder) Exchanges() []Exchange {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.full {
+		return append([]Exchange{}, r.exchanges[:r.next]...)
+	}
+	exchanges := make([]Exchange, 0, len(r.exchanges))
+	exchanges = append(exchanges, r.exchanges[r.next:]...)
+	return append(exchanges, r.exchanges[:r.next]...)
+}
+
+// Clear drops the recorded exchanges.
+func (r *Recorder) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.exchanges)
+	r.next, r.full = 0, false
+}
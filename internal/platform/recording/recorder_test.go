@@ -0,0 +1,153 @@
+package recording
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+func TestRecorder_Enable(t *testing.T) {
+	tests := []struct {
+		name        string
+		route       string
+		fabricCode  string
+		sampleRate  float64
+		duration    time.Duration
+		expectedErr error
+	}{
+		{name: "route", route: "/v1/fabrics/{code}", sampleRate: 0.5, duration: time.Hour},
+		{name: "fabric code", fabricCode: "FAB01", sampleRate: 1, duration: time.Hour},
+		{name: "no filter", sampleRate: 1, duration: time.Hour, expectedErr: ErrNoFilter},
+		{name: "no sample rate", route: "/v1/fabrics", duration: time.Hour, expectedErr: ErrInvalidSampleRate},
+		{name: "sample rate over 1", route: "/v1/fabrics", sampleRate: 1.5, duration: time.Hour, expectedErr: ErrInvalidSampleRate},
+		{name: "too long", route: "/v1/fabrics", sampleRate: 1, duration: 25 * time.Hour, expectedErr: ErrInvalidDuration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Arrange ---
+			recorder := New(10)
+
+			// --- Act ---
+			settings, err := recorder.Enable(tt.route, tt.fabricCode, tt.sampleRate, tt.duration, "ops", testNow)
+
+			// --- Assert ---
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.False(t, recorder.Active(testNow))
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, settings.Enabled)
+			assert.Equal(t, "ops", settings.ChangedBy)
+			require.NotNil(t, settings.ExpiresAt)
+			assert.Equal(t, testNow.Add(tt.duration), *settings.ExpiresAt)
+		})
+	}
+}
+
+func TestRecorder_Matches(t *testing.T) {
+	// --- Arrange ---
+	byRoute := New(10)
+	_, err := byRoute.Enable("/v1/fabrics/{code}", "", 1, time.Hour, "ops", testNow)
+	require.NoError(t, err)
+	byCode := New(10)
+	_, err = byCode.Enable("", "FAB01", 1, time.Hour, "ops", testNow)
+	require.NoError(t, err)
+	sampled := New(10)
+	_, err = sampled.Enable("/v1/fabrics", "", 0.25, time.Hour, "ops", testNow)
+	require.NoError(t, err)
+	draws := []float64{0.1, 0.9}
+	sampled.sample = func() float64 {
+		draw := draws[0]
+		draws = draws[1:]
+		return draw
+	}
+
+	// --- Act & Assert ---
+	assert.True(t, byRoute.Matches("/v1/fabrics/{code}", "FAB02", testNow))
+	assert.False(t, byRoute.Matches("/v1/fabrics", "", testNow))
+	assert.False(t, byRoute.Matches("/v1/fabrics/{code}", "FAB02", testNow.Add(time.Hour)),
+		"recording switches itself off once it expires")
+	assert.True(t, byCode.Matches("/v1/fabrics/{code}/stock", "FAB01", testNow))
+	assert.False(t, byCode.Matches("/v1/fabrics/{code}", "FAB02", testNow))
+	assert.True(t, sampled.Matches("/v1/fabrics", "", testNow))
+	assert.False(t, sampled.Matches("/v1/fabrics", "", testNow), "requests outside the sample are not recorded")
+	assert.False(t, New(10).Matches("/v1/fabrics", "", testNow), "a new recorder is off")
+}
+
+func TestRecorder_RingBuffer(t *testing.T) {
+	// --- Arrange ---
+	recorder := New(3)
+
+	// --- Act ---
+	for _, path := range []string{"/1", "/2", "/3", "/4", "/5"} {
+		recorder.Record(Exchange{Path: path})
+	}
+	exchanges := recorder.Exchanges()
+	recorder.Clear()
+
+	// --- Assert ---
+	paths := []string{}
+	for _, exchange := range exchanges {
+		paths = append(paths, exchange.Path)
+	}
+	assert.Equal(t, []string{"/3", "/4", "/5"}, paths, "the oldest exchanges make room for new ones")
+	assert.Empty(t, recorder.Exchanges())
+}
+
+func TestSanitizeHeaders(t *testing.T) {
+	// --- Arrange ---
+	headers := http.Header{
+		"Authorization": {"Bearer secret"},
+		"Cookie":        {"session=abc"},
+		"Accept":        {"application/json", "text/plain"},
+	}
+
+	// --- Act ---
+	sanitized := SanitizeHeaders(headers)
+
+	// --- Assert ---
+	assert.Equal(t, map[string]string{
+		"Authorization": redacted,
+		"Cookie":        redacted,
+		"Accept":        "application/json, text/plain",
+	}, sanitized)
+}
+
+func TestSanitizeBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		expected    string
+	}{
+		{name: "empty", body: "", contentType: "application/json", expected: ""},
+		{
+			name: "nested secrets", contentType: "application/json",
+			body:     `{"code": "FAB01", "webhook": {"url": "https://x", "signing_secret": "s3cr3t"}, "items": [{"token": "t"}]}`,
+			expected: `{"code":"FAB01","items":[{"token":"[REDACTED]"}],"webhook":{"signing_secret":"[REDACTED]","url":"https://x"}}`,
+		},
+		{
+			name: "NDJSON", contentType: "application/x-ndjson",
+			body:     "{\"code\": \"FAB01\"}\n{\"password\": \"p\"}",
+			expected: "{\"code\":\"FAB01\"}\n{\"password\":\"[REDACTED]\"}",
+		},
+		{name: "binary upload", body: "\x89PNG", contentType: "image/png", expected: "[image/png body not recorded]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Act ---
+			sanitized := SanitizeBody([]byte(tt.body), tt.contentType)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expected, sanitized)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// MaxBodyBytes bounds the part of a request or response body that is kept.
+const MaxBodyBytes = 16 * 1024
+
+const redacted = "[REDACTED]"
+
+// headers never kept, they carry credentials or session state
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+}
+
+// parts of JSON field names whose values are never kept
+var sensitiveFields = []string{"password", "secret", "token", "api_key", "authorization"}
+
+// SanitizeHeaders flattens the headers, redacting those carrying credentials, known ones
+// and custom ones named like a sensitive field such as X-Auth-Token.
+func SanitizeHeaders(headers http.Header) map[string]string {
+	sanitized := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] || isSensitiveField(strings.ReplaceAll(name, "-", "_")) {
+			sanitized[name] = redacted
+			continue
+		}
+		sanitized[name] = strings.Join(values, ", ")
+	}
+	return sanitized
+}
+
+// SanitizeBody returns the body as text, redacting the values of sensitive fields of a
+// JSON body. A body that is not JSON, such as an uploaded file, is left out entirely.
+func SanitizeBody(body []byte, contentType string) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+	if !strings.Contains(contentType, "json") && !json.Valid(body) {
+		return "[" + contentType + " body not recorded]"
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		// a truncated or NDJSON body is kept line by line
+		lines := bytes.Split(body, []byte("\n"))
+		sanitized := make([]string, 0, len(lines))
+		for _, line := range lines {
+			sanitized = append(sanitized, sanitizeJSON(line))
+		}
+		return strings.Join(sanitized, "\n")
+	}
+	return sanitizeJSON(body)
+}
+
+func sanitizeJSON(raw []byte) string {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "[unparsable JSON not recorded]"
+	}
+	sanitized, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return "[unparsable JSON not recorded]"
+	}
+	return string(sanitized)
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if isSensitiveField(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return value
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range sensitiveFields {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
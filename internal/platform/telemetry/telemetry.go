@@ -0,0 +1,71 @@
+// Package telemetry describes the running process to OpenTelemetry. The resource built
+// here is attached to every metric, span and exported log record, so the signals of one
+// replica can be told apart from another and grouped together in the backend.
+package telemetry
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of every tracer, meter and log record of the API.
+const ScopeName = "s-works/api"
+
+// Config identifies the service and the replica reporting telemetry.
+type Config struct {
+	ServiceName    string
+	ServiceVersion string
+	Environment    string
+	// InstanceID tells replicas of the same service and version apart
+	InstanceID string
+}
+
+// NewResource returns the resource shared by all signals of the process.
+func NewResource(cfg Config) *resource.Resource {
+	return resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+		attribute.String("service.version", cfg.ServiceVersion),
+		attribute.String("deployment.environment", cfg.Environment),
+		attribute.String("service.instance.id", cfg.InstanceID),
+	)
+}
+
+// SetupMetrics registers the global meter provider, read by the Prometheus exporter, which
+// reports the resource as the target_info metric.
+func SetupMetrics(res *resource.Resource) error {
+	exporter, err := prometheus.New()
+	if err != nil {
+		return fmt.Errorf("create prometheus exporter: %w", err)
+	}
+
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter), sdkmetric.WithResource(res)))
+	return nil
+}
+
+// SetupTracing registers the global tracer provider. Spans are not exported yet, but they
+// carry the resource and give every request a trace id the logs are correlated with. The
+// caller shuts the provider down on exit.
+func SetupTracing(res *resource.Resource) *sdktrace.TracerProvider {
+	provider := sdktrace.NewTracerProvider(sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider
+}
+
+// Tracer returns the tracer of the API from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(ScopeName)
+}
+
+// Meter returns the meter of the API from the global provider. Instruments created before
+// SetupMetrics are forwarded to the provider once it is registered.
+func Meter() metric.Meter {
+	return otel.Meter(ScopeName)
+}
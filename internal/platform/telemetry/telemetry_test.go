@@ -0,0 +1,49 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestNewResource(t *testing.T) {
+	// --- Arrange ---
+	cfg := Config{
+		ServiceName:    "s-works-api",
+		ServiceVersion: "1.0.0",
+		Environment:    "production",
+		InstanceID:     "api-7f9c",
+	}
+
+	// --- Act ---
+	res := NewResource(cfg)
+
+	// --- Assert ---
+	attrs := attribute.NewSet(res.Attributes()...)
+	for key, expected := range map[attribute.Key]string{
+		"service.name":           "s-works-api",
+		"service.version":        "1.0.0",
+		"deployment.environment": "production",
+		"service.instance.id":    "api-7f9c",
+	} {
+		value, ok := attrs.Value(key)
+		require.True(t, ok, "expected the %s attribute", key)
+		assert.Equal(t, expected, value.AsString())
+	}
+}
+
+func TestSetupTracing_SpansCarryTraceIDs(t *testing.T) {
+	// --- Arrange ---
+	provider := SetupTracing(NewResource(Config{ServiceName: "s-works-api", InstanceID: "api-7f9c"}))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	// --- Act ---
+	_, span := Tracer().Start(context.Background(), "test")
+	span.End()
+
+	// --- Assert ---
+	assert.True(t, span.SpanContext().HasTraceID(), "spans must carry a trace id to correlate logs with")
+}
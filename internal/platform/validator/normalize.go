@@ -0,0 +1,18 @@
+package validator
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeText prepares free text for validation and storage: composed unicode (NFC),
+// no surrounding whitespace and single spaces inside, so "Zoya " and "Zoya" are the same name
+func NormalizeText(s string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(s)), " ")
+}
+
+// NormalizeCode prepares an identifier for validation: no surrounding whitespace, uppercase
+func NormalizeCode(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
@@ -0,0 +1,39 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "trailing space", input: "Zoya ", expected: "Zoya"},
+		{name: "internal spaces collapsed", input: "  Velvet \t  Royal\n", expected: "Velvet Royal"},
+		{name: "decomposed unicode composed", input: "Cre\u0301me", expected: "Cr\u00e9me"},
+		{name: "already normal", input: "Zoya", expected: "Zoya"},
+		{name: "blank", input: "   ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// --- Act ---
+			normalized := NormalizeText(tt.input)
+
+			// --- Assert ---
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestNormalizeCode(t *testing.T) {
+	// --- Act ---
+	normalized := NormalizeCode(" fab01 ")
+
+	// --- Assert ---
+	assert.Equal(t, "FAB01", normalized)
+}
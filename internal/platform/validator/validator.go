@@ -10,12 +10,15 @@ var (
 	EmailRX = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
 )
 
+// Validator collects blocking errors and non-blocking warnings, the latter report data
+// that was accepted but corrected or looks suspicious
 type Validator struct {
-	Errors map[string]string
+	Errors   map[string]string
+	Warnings map[string]string
 }
 
 func New() *Validator {
-	return &Validator{Errors: make(map[string]string)}
+	return &Validator{Errors: make(map[string]string), Warnings: make(map[string]string)}
 }
 
 // valid returns true if the errors map doesn't contain any entries
@@ -37,6 +40,26 @@ func (v *Validator) Check(ok bool, key, message string) {
 	}
 }
 
+// adds a warning message to the map (so long as no entry already exists for the given key),
+// warnings never make the validator invalid
+func (v *Validator) AddWarning(key, message string) {
+	if _, exists := v.Warnings[key]; !exists {
+		v.Warnings[key] = message
+	}
+}
+
+// adds a warning message to the map only if a check is not 'ok'
+func (v *Validator) Warn(ok bool, key, message string) {
+	if !ok {
+		v.AddWarning(key, message)
+	}
+}
+
+// returns true if the warnings map contains any entries
+func (v *Validator) HasWarnings() bool {
+	return len(v.Warnings) > 0
+}
+
 // generic function which returns true if a specific value is in a list of permitted values
 func PermittedValue[T comparable](value T, permittedValues ...T) bool {
 	return slices.Contains(permittedValues, value)
@@ -0,0 +1,191 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"github.com/salesworks/s-works/api/internal/pricelists/domain"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PriceListService prepares and publishes price lists and publishes their events.
+type PriceListService struct {
+	repo         domain.PriceListRepository
+	eventStore   eventstore.Store
+	clock        clock.Clock
+	eventChannel string
+	source       messaging.Source
+}
+
+func NewPriceListCommandService(
+	repo domain.PriceListRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+) *PriceListService {
+	return &PriceListService{
+		repo:         repo,
+		eventStore:   eventStore,
+		clock:        clock,
+		eventChannel: "app.price_list",
+		source:       source,
+	}
+}
+
+// CreatePriceList opens a draft price list without prices.
+func (s *PriceListService) CreatePriceList(
+	ctx context.Context, code string, details domain.PriceListDetails,
+) (*domain.PriceList, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "price_list.service.create")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "price_list.service")
+
+	priceList, err := domain.NewPriceList(code, details, aggregate.StampNow(ctx, s.clock))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SavePriceList(ctx, priceList); err != nil {
+		return nil, s.failed(span, logger, "saving price list failed", err)
+	}
+
+	if err := s.publish(ctx, priceList); err != nil {
+		return nil, err
+	}
+	return priceList, nil
+}
+
+func (s *PriceListService) UpdatePriceList(
+	ctx context.Context, code string, details domain.PriceListDetails, version int,
+) (*domain.PriceList, error) {
+	return s.change(ctx, code, "price_list.service.update", func(priceList *domain.PriceList, stamp domain.Stamp) error {
+		return priceList.Update(details, version, stamp)
+	})
+}
+
+// ChangePrices replaces the prices of a draft. Fabrics may be priced by an alias, the list
+// keeps their canonical codes.
+func (s *PriceListService) ChangePrices(
+	ctx context.Context, code string, prices []domain.Price, version int,
+) (*domain.PriceList, error) {
+	prices, err := s.resolvePrices(ctx, prices)
+	if err != nil {
+		return nil, err
+	}
+	return s.change(ctx, code, "price_list.service.change_prices", func(priceList *domain.PriceList, stamp domain.Stamp) error {
+		return priceList.ChangePrices(prices, version, stamp)
+	})
+}
+
+func (s *PriceListService) PublishPriceList(ctx context.Context, code string, version int) (*domain.PriceList, error) {
+	return s.change(ctx, code, "price_list.service.publish", func(priceList *domain.PriceList, stamp domain.Stamp) error {
+		return priceList.Publish(version, stamp)
+	})
+}
+
+// resolvePrices puts the prices under the canonical codes of their fabrics, which have to
+// be active.
+func (s *PriceListService) resolvePrices(ctx context.Context, prices []domain.Price) ([]domain.Price, error) {
+	resolved := make([]domain.Price, 0, len(prices))
+	for _, price := range prices {
+		code, err := s.repo.ResolveFabricCode(ctx, price.FabricCode)
+		if err != nil {
+			return nil, err
+		}
+		price.FabricCode = code
+		resolved = append(resolved, price)
+	}
+	return resolved, nil
+}
+
+// change loads the price list, applies a command to it, stores it and publishes its events.
+func (s *PriceListService) change(
+	ctx context.Context, code, spanName string, apply func(priceList *domain.PriceList, stamp domain.Stamp) error,
+) (*domain.PriceList, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, spanName)
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "price_list.service")
+
+	priceList, err := s.repo.GetPriceList(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(priceList, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdatePriceList(ctx, priceList); err != nil {
+		return nil, s.failed(span, logger, "updating price list failed", err)
+	}
+
+	if err := s.publish(ctx, priceList); err != nil {
+		return nil, err
+	}
+	return priceList, nil
+}
+
+// failed reports a repository write that did not succeed. Price list errors are passed
+// through as they are, anything else is wrapped and recorded as a database error.
+func (s *PriceListService) failed(span trace.Span, logger *slog.Logger, msg string, err error) error {
+	var priceListErr *domain.PriceListError
+	if errors.As(err, &priceListErr) {
+		return err
+	}
+	wrappedErr := fmt.Errorf("failed to write price list in repo: %w", err)
+	logger.Error(msg, "error", wrappedErr)
+	span.RecordError(wrappedErr)
+	span.SetStatus(codes.Error, "database write error")
+	return wrappedErr
+}
+
+func (s *PriceListService) publish(ctx context.Context, priceList *domain.PriceList) error {
+	logger := httpx.GetLogger(ctx).With("component", "price_list.service")
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range priceList.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.PriceListCreated:
+			eventType = "app.price_list.created"
+		case domain.PriceListUpdated:
+			eventType = "app.price_list.updated"
+		case domain.PriceListPricesChanged:
+			eventType = "app.price_list.prices_changed"
+		case domain.PriceListPublished:
+			eventType = "app.price_list.published"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			priceList.Code,
+			"PriceList",
+			priceList.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelope.UserID = command.Actor(ctx)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopesToPublish...); err != nil {
+			wrappedErr := fmt.Errorf("failed to save price list event to event store: %w", err)
+			logger.Error("saving price list event failed", "error", wrappedErr)
+			return wrappedErr
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/pricelists/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = domain.Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testSource = messaging.Source{Service: "s-works-api", Instance: "test-instance"}
+
+var testDetails = domain.PriceListDetails{
+	Name:      "Poland retail 2025",
+	Market:    "PL",
+	Currency:  "PLN",
+	ValidFrom: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+}
+
+type mockPriceListRepository struct {
+	priceLists  map[string]*domain.PriceList
+	aliases     map[string]string
+	saved       *domain.PriceList
+	errToReturn error
+}
+
+func (m *mockPriceListRepository) SavePriceList(ctx context.Context, priceList *domain.PriceList) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.saved = priceList
+	return nil
+}
+
+func (m *mockPriceListRepository) GetPriceList(ctx context.Context, code string) (*domain.PriceList, error) {
+	priceList, ok := m.priceLists[code]
+	if !ok {
+		return nil, domain.ErrPriceListNotFound
+	}
+	priceListCopy := *priceList
+	return &priceListCopy, nil
+}
+
+func (m *mockPriceListRepository) ListPriceLists(
+	ctx context.Context, filter domain.PriceListFilter,
+) ([]*domain.PriceList, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockPriceListRepository) UpdatePriceList(ctx context.Context, priceList *domain.PriceList) error {
+	return m.SavePriceList(ctx, priceList)
+}
+
+func (m *mockPriceListRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	if canonical, ok := m.aliases[code]; ok {
+		return canonical, nil
+	}
+	return "", domain.ErrFabricNotFound
+}
+
+func (m *mockPriceListRepository) ResolvePrice(
+	ctx context.Context, query domain.PriceQuery,
+) (*domain.ResolvedPrice, error) {
+	return nil, domain.ErrPriceNotFound
+}
+
+type mockEventStore struct {
+	SavedCalled      bool
+	EnqueuedSubject  string
+	EnqueuedEnvelope *messaging.EventEnvelope
+}
+
+func (m *mockEventStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
+	m.SavedCalled = true
+	return nil
+}
+
+func (m *mockEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.SavedCalled = true
+	m.EnqueuedSubject = subject
+	m.EnqueuedEnvelope = envelopes[len(envelopes)-1]
+	return nil
+}
+
+func newTestRepository() *mockPriceListRepository {
+	draft := &domain.PriceList{
+		Code: "PL-RETAIL-2025", Name: testDetails.Name, Market: "PL", Currency: "PLN",
+		ValidFrom: testDetails.ValidFrom, Status: domain.StatusDraft, Version: 1,
+		Prices: []domain.Price{{FabricCode: "VELVET01", UnitPrice: 2490}},
+	}
+	return &mockPriceListRepository{
+		priceLists: map[string]*domain.PriceList{draft.Code: draft},
+		aliases:    map[string]string{"VELVET01": "VELVET01", "OLDVELVET": "VELVET01"},
+	}
+}
+
+func TestPriceListService_CreatePriceList(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewPriceListCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	priceList, err := service.CreatePriceList(context.Background(), "PL-RETAIL-2026", testDetails)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, repo.saved, "expected SavePriceList() to be called on the repository")
+	assert.Equal(t, domain.StatusDraft, priceList.Status)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.price_list", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.price_list.created", publishedEnvelope.EventType)
+	assert.Equal(t, "PriceList", publishedEnvelope.AggregateType)
+	assert.Equal(t, "PL-RETAIL-2026", publishedEnvelope.AggregateID)
+}
+
+func TestPriceListService_ChangePrices_ResolvesAlias(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewPriceListCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+	prices := []domain.Price{{FabricCode: "OLDVELVET", UnitPrice: 2590}}
+
+	// --- Act ---
+	priceList, err := service.ChangePrices(context.Background(), "PL-RETAIL-2025", prices, 1)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Price{{FabricCode: "VELVET01", UnitPrice: 2590}}, priceList.Prices)
+	require.NotNil(t, eventStore.EnqueuedEnvelope)
+	assert.Equal(t, "app.price_list.prices_changed", eventStore.EnqueuedEnvelope.EventType)
+}
+
+func TestPriceListService_PublishPriceList(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewPriceListCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	priceList, err := service.PublishPriceList(context.Background(), "PL-RETAIL-2025", 1)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPublished, priceList.Status)
+	require.NotNil(t, eventStore.EnqueuedEnvelope)
+	assert.Equal(t, "app.price_list.published", eventStore.EnqueuedEnvelope.EventType)
+	assert.Equal(t, 2, eventStore.EnqueuedEnvelope.AggregateVersion)
+}
+
+func TestPriceListService_RejectedIsNotPublished(t *testing.T) {
+	testCases := []struct {
+		name        string
+		errToReturn error
+		run         func(s *PriceListService) error
+		expectedErr error
+	}{
+		{
+			name: "Unknown fabric",
+			run: func(s *PriceListService) error {
+				prices := []domain.Price{{FabricCode: "NOSUCHFABRIC", UnitPrice: 1000}}
+				_, err := s.ChangePrices(context.Background(), "PL-RETAIL-2025", prices, 1)
+				return err
+			},
+			expectedErr: domain.ErrFabricNotFound,
+		},
+		{
+			name:        "Code taken",
+			errToReturn: domain.ErrDuplicatePriceListCode,
+			run: func(s *PriceListService) error {
+				_, err := s.CreatePriceList(context.Background(), "PL-RETAIL-2025", testDetails)
+				return err
+			},
+			expectedErr: domain.ErrDuplicatePriceListCode,
+		},
+		{
+			name: "Stale version",
+			run: func(s *PriceListService) error {
+				_, err := s.PublishPriceList(context.Background(), "PL-RETAIL-2025", 2)
+				return err
+			},
+			expectedErr: domain.ErrConcurrencyConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			repo.errToReturn = tc.errToReturn
+			eventStore := &mockEventStore{}
+			service := NewPriceListCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err := tc.run(service)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, repo.saved)
+			assert.False(t, eventStore.SavedCalled, "a rejected command must not be stored")
+		})
+	}
+}
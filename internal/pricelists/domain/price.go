@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// maxPrices caps the prices of a single price list.
+const maxPrices = 5000
+
+// Price is the unit price of a fabric, under its canonical code, per its measure unit.
+type Price struct {
+	FabricCode string `json:"fabric_code"`
+	// UnitPrice is expressed in the minor unit of the currency of the list, e.g. grosze
+	// for PLN.
+	UnitPrice int64 `json:"unit_price"`
+}
+
+// checkPrices holds the prices of a list to its rules: a bounded number of them,
+// no negative amounts and each fabric once.
+func checkPrices(prices []Price) error {
+	if len(prices) > maxPrices {
+		return ErrInvalidPriceCount
+	}
+	seen := make(map[string]bool, len(prices))
+	for _, price := range prices {
+		if price.UnitPrice < 0 {
+			return ErrInvalidUnitPrice
+		}
+		if seen[price.FabricCode] {
+			return ErrDuplicatePrice
+		}
+		seen[price.FabricCode] = true
+	}
+	return nil
+}
+
+// PriceQuery asks for the price of a fabric, given by its code or one of its aliases, for
+// a customer group of a market at a time. An empty customer group only matches the lists
+// of the whole market.
+type PriceQuery struct {
+	FabricCode    string
+	Market        string
+	CustomerGroup string
+	At            time.Time
+}
+
+// ResolvedPrice is the price that applies to a query and the published list it comes
+// from. A list of the customer group takes precedence over one of the whole market; among
+// lists equally specific, the one effective the latest wins, so a new list supersedes an
+// open-ended one from the day it takes effect.
+type ResolvedPrice struct {
+	FabricCode    string     `json:"fabric_code"`
+	UnitPrice     int64      `json:"unit_price"`
+	Currency      string     `json:"currency"`
+	PriceListCode string     `json:"price_list_code"`
+	CustomerGroup string     `json:"customer_group,omitempty"`
+	ValidFrom     time.Time  `json:"valid_from"`
+	ValidUntil    *time.Time `json:"valid_until,omitempty"`
+}
@@ -0,0 +1,297 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+const (
+	StatusDraft     = "DRAFT"
+	StatusPublished = "PUBLISHED"
+)
+
+var (
+	ErrPriceListNotFound      = notFoundError("price list not found")
+	ErrFabricNotFound         = notFoundError("fabric not found")
+	ErrPriceNotFound          = notFoundError("no published price list prices the fabric at this time")
+	ErrDuplicatePriceListCode = conflictError("a price list with this code already exists")
+	ErrConcurrencyConflict    = conflictError("the price list has been modified by another process, please refresh and try again")
+	ErrPriceListPublished     = conflictError("a published price list cannot be changed")
+	ErrEmptyPriceList         = conflictError("a price list without prices cannot be published")
+	ErrInvalidValidity        = invalidError("valid_until must be after valid_from")
+	ErrDuplicatePrice         = invalidError("a fabric can only be priced once in a price list")
+	ErrInvalidUnitPrice       = invalidError("the unit price must not be negative")
+	ErrInvalidPriceCount      = invalidError("a price list must not have more than 5000 prices")
+)
+
+// PriceListError is a rule violation reported by the price list domain. Its kind tells the
+// handler which status to answer with and instrumentation how to class it.
+type PriceListError struct {
+	Kind    string
+	Message string
+}
+
+func (e *PriceListError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *PriceListError) ErrorClass() string {
+	return e.Kind
+}
+
+func notFoundError(message string) *PriceListError {
+	return &PriceListError{Kind: "not_found", Message: message}
+}
+
+func conflictError(message string) *PriceListError {
+	return &PriceListError{Kind: "conflict", Message: message}
+}
+
+func invalidError(message string) *PriceListError {
+	return &PriceListError{Kind: "invalid", Message: message}
+}
+
+type Event = aggregate.Event
+
+// Stamp identifies who performed a change on a price list and when it happened.
+type Stamp = aggregate.Stamp
+
+// PriceList prices fabrics for a market, and optionally a single customer group of it,
+// from ValidFrom until, but not including, ValidUntil. An open-ended list has no
+// ValidUntil. A list is prepared as a draft and only applies once published, after which
+// it cannot be changed anymore.
+type PriceList struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Market string `json:"market"`
+	// CustomerGroup narrows the list to one group of customers; a list without one
+	// applies to every customer of the market.
+	CustomerGroup string     `json:"customer_group,omitempty"`
+	Currency      string     `json:"currency"`
+	ValidFrom     time.Time  `json:"valid_from"`
+	ValidUntil    *time.Time `json:"valid_until,omitempty"`
+	Status        string     `json:"status"`
+	Prices        []Price    `json:"prices"`
+	PublishedAt   *time.Time `json:"published_at,omitempty"`
+	Version       int        `json:"version"`
+	aggregate.Audit
+	aggregate.Root
+}
+
+// PriceListDetails is what a price list applies to, everything but its code and prices.
+type PriceListDetails struct {
+	Name          string
+	Market        string
+	CustomerGroup string
+	Currency      string
+	ValidFrom     time.Time
+	ValidUntil    *time.Time
+}
+
+type PriceListCreated struct {
+	Code          string
+	Name          string
+	Market        string
+	CustomerGroup string
+	Currency      string
+	ValidFrom     time.Time
+	ValidUntil    *time.Time
+	Version       int
+}
+
+type PriceListUpdated struct {
+	Code          string
+	Name          string
+	Market        string
+	CustomerGroup string
+	Currency      string
+	ValidFrom     time.Time
+	ValidUntil    *time.Time
+	Version       int
+}
+
+// PriceListPricesChanged is recorded when the prices of a draft are replaced.
+type PriceListPricesChanged struct {
+	Code    string
+	Prices  []Price
+	Version int
+}
+
+// PriceListPublished carries the whole list, so consumers can apply it without reading
+// the earlier events.
+type PriceListPublished struct {
+	Code          string
+	Market        string
+	CustomerGroup string
+	Currency      string
+	ValidFrom     time.Time
+	ValidUntil    *time.Time
+	Prices        []Price
+	Version       int
+}
+
+func NewPriceList(code string, details PriceListDetails, stamp Stamp) (*PriceList, error) {
+	if err := checkValidity(details); err != nil {
+		return nil, err
+	}
+
+	priceList := &PriceList{
+		Code:    code,
+		Status:  StatusDraft,
+		Prices:  []Price{},
+		Version: 1,
+		Audit:   aggregate.NewAudit(stamp),
+	}
+	priceList.setDetails(details)
+
+	event := PriceListCreated{
+		Code:          priceList.Code,
+		Name:          priceList.Name,
+		Market:        priceList.Market,
+		CustomerGroup: priceList.CustomerGroup,
+		Currency:      priceList.Currency,
+		ValidFrom:     priceList.ValidFrom,
+		ValidUntil:    priceList.ValidUntil,
+		Version:       priceList.Version,
+	}
+	priceList.Record(event)
+	return priceList, nil
+}
+
+// Update replaces what a draft applies to.
+func (p *PriceList) Update(details PriceListDetails, version int, stamp Stamp) error {
+	if err := p.checkDraft(version); err != nil {
+		return err
+	}
+	if err := checkValidity(details); err != nil {
+		return err
+	}
+
+	p.setDetails(details)
+	p.Version++
+	p.Touch(stamp)
+
+	event := PriceListUpdated{
+		Code:          p.Code,
+		Name:          p.Name,
+		Market:        p.Market,
+		CustomerGroup: p.CustomerGroup,
+		Currency:      p.Currency,
+		ValidFrom:     p.ValidFrom,
+		ValidUntil:    p.ValidUntil,
+		Version:       p.Version,
+	}
+	p.Record(event)
+	return nil
+}
+
+// ChangePrices replaces the prices of a draft.
+func (p *PriceList) ChangePrices(prices []Price, version int, stamp Stamp) error {
+	if err := p.checkDraft(version); err != nil {
+		return err
+	}
+	if err := checkPrices(prices); err != nil {
+		return err
+	}
+
+	p.Prices = prices
+	p.Version++
+	p.Touch(stamp)
+
+	event := PriceListPricesChanged{
+		Code:    p.Code,
+		Prices:  p.Prices,
+		Version: p.Version,
+	}
+	p.Record(event)
+	return nil
+}
+
+// Publish makes the draft apply over its validity. It needs at least one price.
+func (p *PriceList) Publish(version int, stamp Stamp) error {
+	if err := p.checkDraft(version); err != nil {
+		return err
+	}
+	if len(p.Prices) == 0 {
+		return ErrEmptyPriceList
+	}
+
+	publishedAt := stamp.At
+	p.Status = StatusPublished
+	p.PublishedAt = &publishedAt
+	p.Version++
+	p.Touch(stamp)
+
+	event := PriceListPublished{
+		Code:          p.Code,
+		Market:        p.Market,
+		CustomerGroup: p.CustomerGroup,
+		Currency:      p.Currency,
+		ValidFrom:     p.ValidFrom,
+		ValidUntil:    p.ValidUntil,
+		Prices:        p.Prices,
+		Version:       p.Version,
+	}
+	p.Record(event)
+	return nil
+}
+
+// checkDraft refuses changes of a published list and commands made against another
+// version than the current one.
+func (p *PriceList) checkDraft(version int) error {
+	if p.Status != StatusDraft {
+		return ErrPriceListPublished
+	}
+	return aggregate.CheckVersion(p.Version, version, ErrConcurrencyConflict)
+}
+
+func (p *PriceList) setDetails(details PriceListDetails) {
+	p.Name = details.Name
+	p.Market = details.Market
+	p.CustomerGroup = details.CustomerGroup
+	p.Currency = details.Currency
+	p.ValidFrom = details.ValidFrom.UTC()
+	p.ValidUntil = nil
+	if details.ValidUntil != nil {
+		validUntil := details.ValidUntil.UTC()
+		p.ValidUntil = &validUntil
+	}
+}
+
+func checkValidity(details PriceListDetails) error {
+	if details.ValidUntil != nil && !details.ValidUntil.After(details.ValidFrom) {
+		return ErrInvalidValidity
+	}
+	return nil
+}
+
+// PriceListFilter narrows a listing of price lists, empty fields match every list.
+type PriceListFilter struct {
+	Market string
+	Status string
+	Limit  int
+	Offset int
+}
+
+type PriceListRepository interface {
+	// SavePriceList stores a new price list with its prices, failing with
+	// ErrDuplicatePriceListCode when the code is taken.
+	SavePriceList(ctx context.Context, priceList *PriceList) error
+	// GetPriceList loads a price list with its prices, or fails with ErrPriceListNotFound.
+	GetPriceList(ctx context.Context, code string) (*PriceList, error)
+	// ListPriceLists returns a page of the price lists matching the filter, the latest
+	// effective first, together with their total number. Prices are left out.
+	ListPriceLists(ctx context.Context, filter PriceListFilter) ([]*PriceList, int, error)
+	// UpdatePriceList stores a change of a price list still at the version it was loaded
+	// with, or fails with ErrConcurrencyConflict.
+	UpdatePriceList(ctx context.Context, priceList *PriceList) error
+	// ResolveFabricCode resolves a fabric code, or one of its aliases, to the canonical
+	// code of an active fabric, or fails with ErrFabricNotFound.
+	ResolveFabricCode(ctx context.Context, code string) (string, error)
+	// ResolvePrice finds the price that applies to the query, or fails with
+	// ErrPriceNotFound.
+	ResolvePrice(ctx context.Context, query PriceQuery) (*ResolvedPrice, error)
+}
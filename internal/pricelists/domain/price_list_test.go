@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testDetails = PriceListDetails{
+	Name:      "Poland retail 2025",
+	Market:    "PL",
+	Currency:  "PLN",
+	ValidFrom: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+}
+
+var testPrices = []Price{
+	{FabricCode: "VELVET01", UnitPrice: 2490},
+	{FabricCode: "LINEN02", UnitPrice: 1599},
+}
+
+func newTestPriceList(t *testing.T) *PriceList {
+	t.Helper()
+
+	priceList, err := NewPriceList("PL-RETAIL-2025", testDetails, testStamp)
+	require.NoError(t, err)
+	return priceList
+}
+
+func TestNewPriceList_InvalidValidity(t *testing.T) {
+	// --- Arrange ---
+	details := testDetails
+	validUntil := details.ValidFrom
+	details.ValidUntil = &validUntil
+
+	// --- Act ---
+	priceList, err := NewPriceList("PL-RETAIL-2025", details, testStamp)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, ErrInvalidValidity)
+	assert.Nil(t, priceList)
+}
+
+func TestPriceList_ChangePrices(t *testing.T) {
+	testCases := []struct {
+		name        string
+		prices      []Price
+		published   bool
+		version     int
+		expectedErr error
+	}{
+		{name: "Draft at current version", prices: testPrices, version: 1},
+		{name: "Stale version", prices: testPrices, version: 2, expectedErr: ErrConcurrencyConflict},
+		{name: "Published list", prices: testPrices, published: true, version: 1, expectedErr: ErrPriceListPublished},
+		{
+			name: "Negative price", prices: []Price{{FabricCode: "VELVET01", UnitPrice: -1}}, version: 1,
+			expectedErr: ErrInvalidUnitPrice,
+		},
+		{
+			name: "Fabric twice", prices: []Price{{FabricCode: "VELVET01", UnitPrice: 2490}, {FabricCode: "VELVET01"}},
+			version: 1, expectedErr: ErrDuplicatePrice,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			priceList := newTestPriceList(t)
+			if tc.published {
+				priceList.Status = StatusPublished
+			}
+
+			// --- Act ---
+			err := priceList.ChangePrices(tc.prices, tc.version, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, priceList.Prices)
+				assert.Len(t, priceList.Events(), 1, "a rejected change must not record an event")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 2, priceList.Version)
+			assert.Equal(t, PriceListPricesChanged{Code: "PL-RETAIL-2025", Prices: tc.prices, Version: 2}, priceList.Events()[1])
+		})
+	}
+}
+
+func TestPriceList_Publish(t *testing.T) {
+	// --- Arrange ---
+	priceList := newTestPriceList(t)
+	require.NoError(t, priceList.ChangePrices(testPrices, 1, testStamp))
+
+	// --- Act ---
+	err := priceList.Publish(2, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, StatusPublished, priceList.Status)
+	require.NotNil(t, priceList.PublishedAt)
+	assert.Equal(t, testStamp.At, *priceList.PublishedAt)
+	assert.Equal(t, PriceListPublished{
+		Code: "PL-RETAIL-2025", Market: "PL", Currency: "PLN", ValidFrom: testDetails.ValidFrom,
+		Prices: testPrices, Version: 3,
+	}, priceList.Events()[2])
+	assert.ErrorIs(t, priceList.Update(testDetails, 3, testStamp), ErrPriceListPublished)
+}
+
+func TestPriceList_Publish_Empty(t *testing.T) {
+	// --- Arrange ---
+	priceList := newTestPriceList(t)
+
+	// --- Act ---
+	err := priceList.Publish(1, testStamp)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, ErrEmptyPriceList)
+	assert.Equal(t, StatusDraft, priceList.Status)
+}
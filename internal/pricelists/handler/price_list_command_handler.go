@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/pricelists/domain"
+)
+
+var (
+	priceListCodeRX = regexp.MustCompile("^[A-Z0-9-]+$")
+	currencyRX      = regexp.MustCompile("^[A-Z]{3}$")
+)
+
+// PriceListCommandService prepares and publishes price lists.
+type PriceListCommandService interface {
+	CreatePriceList(ctx context.Context, code string, details domain.PriceListDetails) (*domain.PriceList, error)
+	UpdatePriceList(
+		ctx context.Context, code string, details domain.PriceListDetails, version int,
+	) (*domain.PriceList, error)
+	ChangePrices(ctx context.Context, code string, prices []domain.Price, version int) (*domain.PriceList, error)
+	PublishPriceList(ctx context.Context, code string, version int) (*domain.PriceList, error)
+}
+
+// PriceListCommandHandler creates and updates draft price lists, replaces their prices and
+// publishes them. A published list cannot be changed, a change is answered with a conflict.
+type PriceListCommandHandler struct {
+	service PriceListCommandService
+}
+
+type priceListDetailsRequest struct {
+	Name          string     `json:"name"`
+	Market        string     `json:"market"`
+	CustomerGroup string     `json:"customer_group"`
+	Currency      string     `json:"currency"`
+	ValidFrom     *time.Time `json:"valid_from"`
+	ValidUntil    *time.Time `json:"valid_until"`
+}
+
+type createPriceListRequest struct {
+	Code string `json:"code"`
+	priceListDetailsRequest
+}
+
+type updatePriceListRequest struct {
+	priceListDetailsRequest
+	Version int `json:"version"`
+}
+
+// the unit price is a pointer so that a missing one is told apart from a free fabric
+type priceRequest struct {
+	FabricCode string `json:"fabric_code"`
+	UnitPrice  *int64 `json:"unit_price"`
+}
+
+type changePricesRequest struct {
+	Prices  []priceRequest `json:"prices"`
+	Version int            `json:"version"`
+}
+
+type publishPriceListRequest struct {
+	Version int `json:"version"`
+}
+
+func NewPriceListCommandHandler(service PriceListCommandService) *PriceListCommandHandler {
+	return &PriceListCommandHandler{service: service}
+}
+
+func (h *PriceListCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+	r = r.WithContext(ctx)
+
+	switch {
+	case r.Method == http.MethodPost && httpx.URLParam(r, "code") == "":
+		h.createPriceList(w, r)
+	case r.Method == http.MethodPost:
+		h.publishPriceList(w, r)
+	case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/prices"):
+		h.changePrices(w, r)
+	case r.Method == http.MethodPut:
+		h.updatePriceList(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *PriceListCommandHandler) createPriceList(w http.ResponseWriter, r *http.Request) {
+	var req createPriceListRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Code = validator.NormalizeCode(req.Code)
+	v := validator.New()
+	v.Check(req.Code != "", "code", "code must be provided")
+	v.Check(len(req.Code) >= 2 && len(req.Code) <= 30, "code", "code must be between 2 and 30 characters long")
+	v.Check(validator.Matches(req.Code, priceListCodeRX), "code", "code must only contain uppercase letters, numbers and dashes")
+	details := readDetails(v, req.priceListDetailsRequest)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	priceList, err := h.service.CreatePriceList(r.Context(), req.Code, details)
+	if err != nil {
+		writePriceListError(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", "/v1/price-lists/"+priceList.Code)
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"price_list": priceList}, headers); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *PriceListCommandHandler) updatePriceList(w http.ResponseWriter, r *http.Request) {
+	var req updatePriceListRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	details := readDetails(v, req.priceListDetailsRequest)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	priceList, err := h.service.UpdatePriceList(r.Context(), httpx.URLParam(r, "code"), details, req.Version)
+	if err != nil {
+		writePriceListError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"price_list": priceList}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *PriceListCommandHandler) changePrices(w http.ResponseWriter, r *http.Request) {
+	var req changePricesRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	prices := readPrices(v, req.Prices)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	priceList, err := h.service.ChangePrices(r.Context(), httpx.URLParam(r, "code"), prices, req.Version)
+	if err != nil {
+		writePriceListError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"price_list": priceList}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *PriceListCommandHandler) publishPriceList(w http.ResponseWriter, r *http.Request) {
+	var req publishPriceListRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	priceList, err := h.service.PublishPriceList(r.Context(), httpx.URLParam(r, "code"), req.Version)
+	if err != nil {
+		writePriceListError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"price_list": priceList}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// readDetails checks what a price list applies to. Whether the validity ends after it
+// starts is left to the domain.
+func readDetails(v *validator.Validator, req priceListDetailsRequest) domain.PriceListDetails {
+	details := domain.PriceListDetails{
+		Name:          validator.NormalizeText(req.Name),
+		Market:        validator.NormalizeCode(req.Market),
+		CustomerGroup: validator.NormalizeCode(req.CustomerGroup),
+		Currency:      validator.NormalizeCode(req.Currency),
+		ValidUntil:    req.ValidUntil,
+	}
+	v.Check(details.Name != "", "name", "name must be provided")
+	v.Check(len(details.Name) <= 250, "name", "name must not be more than 250 characters long")
+	v.Check(details.Market != "", "market", "market must be provided")
+	v.Check(len(details.Market) <= 30, "market", "market must not be more than 30 characters long")
+	v.Check(len(details.CustomerGroup) <= 30, "customer_group", "customer_group must not be more than 30 characters long")
+	v.Check(validator.Matches(details.Currency, currencyRX), "currency", "currency must be a three-letter ISO 4217 code")
+	v.Check(req.ValidFrom != nil, "valid_from", "valid_from must be provided")
+	if req.ValidFrom != nil {
+		details.ValidFrom = *req.ValidFrom
+	}
+	return details
+}
+
+// readPrices checks the requested prices and turns them into the prices of a list,
+// reporting every invalid field under its price.
+func readPrices(v *validator.Validator, requested []priceRequest) []domain.Price {
+	prices := make([]domain.Price, 0, len(requested))
+	for i, req := range requested {
+		field := fmt.Sprintf("prices[%d]", i)
+		price := domain.Price{FabricCode: validator.NormalizeCode(req.FabricCode)}
+		v.Check(price.FabricCode != "", field+".fabric_code", "fabric_code must be provided")
+
+		v.Check(req.UnitPrice != nil, field+".unit_price", "unit_price must be provided")
+		if req.UnitPrice != nil {
+			v.Check(*req.UnitPrice >= 0, field+".unit_price", "unit_price must not be negative")
+			price.UnitPrice = *req.UnitPrice
+		}
+		prices = append(prices, price)
+	}
+	return prices
+}
+
+// writePriceListError answers a failed request with the status matching the kind of price
+// list error, anything that is not a price list error is answered as an internal error.
+func writePriceListError(w http.ResponseWriter, r *http.Request, err error) {
+	var priceListErr *domain.PriceListError
+	if !errors.As(err, &priceListErr) {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch priceListErr.Kind {
+	case "not_found":
+		httpx.NotFound(w, r)
+	case "invalid":
+		httpx.ErrorJSON(w, http.StatusUnprocessableEntity, priceListErr.Message)
+	default:
+		httpx.ErrorJSON(w, http.StatusConflict, priceListErr.Message)
+	}
+}
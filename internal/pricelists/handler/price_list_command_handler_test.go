@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/pricelists/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPriceListCommandService struct {
+	called      string
+	code        string
+	details     domain.PriceListDetails
+	prices      []domain.Price
+	version     int
+	errToReturn error
+}
+
+func (m *mockPriceListCommandService) CreatePriceList(
+	ctx context.Context, code string, details domain.PriceListDetails,
+) (*domain.PriceList, error) {
+	m.called, m.code, m.details = "create", code, details
+	return m.result(code, 1)
+}
+
+func (m *mockPriceListCommandService) UpdatePriceList(
+	ctx context.Context, code string, details domain.PriceListDetails, version int,
+) (*domain.PriceList, error) {
+	m.called, m.code, m.details, m.version = "update", code, details, version
+	return m.result(code, version+1)
+}
+
+func (m *mockPriceListCommandService) ChangePrices(
+	ctx context.Context, code string, prices []domain.Price, version int,
+) (*domain.PriceList, error) {
+	m.called, m.code, m.prices, m.version = "change_prices", code, prices, version
+	return m.result(code, version+1)
+}
+
+func (m *mockPriceListCommandService) PublishPriceList(
+	ctx context.Context, code string, version int,
+) (*domain.PriceList, error) {
+	m.called, m.code, m.version = "publish", code, version
+	return m.result(code, version+1)
+}
+
+func (m *mockPriceListCommandService) result(code string, version int) (*domain.PriceList, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.PriceList{Code: code, Version: version}, nil
+}
+
+func servePriceList(
+	t *testing.T, handler http.Handler, method, target, body string, params map[string]string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestPriceListCommandHandler_CreatePriceList(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockPriceListCommandService{}
+	handler := NewPriceListCommandHandler(svc)
+	body := `{"code": " pl-retail-2025 ", "name": "Poland retail 2025", "market": "pl", "customer_group": "retail",
+		"currency": "pln", "valid_from": "2025-02-01T00:00:00Z", "valid_until": "2026-01-01T00:00:00Z"}`
+
+	// --- Act ---
+	responseRecorder := servePriceList(t, handler, http.MethodPost, "/v1/price-lists", body, nil)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, "/v1/price-lists/PL-RETAIL-2025", responseRecorder.Header().Get("Location"))
+	assert.Equal(t, "PL-RETAIL-2025", svc.code)
+	validUntil := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, domain.PriceListDetails{
+		Name: "Poland retail 2025", Market: "PL", CustomerGroup: "RETAIL", Currency: "PLN",
+		ValidFrom: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), ValidUntil: &validUntil,
+	}, svc.details)
+}
+
+func TestPriceListCommandHandler_Routes(t *testing.T) {
+	priceList := map[string]string{"code": "PL-RETAIL-2025"}
+	testCases := []struct {
+		name         string
+		method       string
+		target       string
+		body         string
+		expectedCall string
+	}{
+		{
+			name: "update", method: http.MethodPut, target: "/v1/price-lists/PL-RETAIL-2025",
+			body:         `{"name": "Poland retail", "market": "PL", "currency": "PLN", "valid_from": "2025-02-01T00:00:00Z", "version": 1}`,
+			expectedCall: "update",
+		},
+		{
+			name: "change prices", method: http.MethodPut, target: "/v1/price-lists/PL-RETAIL-2025/prices",
+			body:         `{"prices": [{"fabric_code": "velvet01", "unit_price": 2490}], "version": 1}`,
+			expectedCall: "change_prices",
+		},
+		{
+			name: "publish", method: http.MethodPost, target: "/v1/price-lists/PL-RETAIL-2025/publish",
+			body: `{"version": 2}`, expectedCall: "publish",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockPriceListCommandService{}
+			handler := NewPriceListCommandHandler(svc)
+
+			// --- Act ---
+			responseRecorder := servePriceList(t, handler, tc.method, tc.target, tc.body, priceList)
+
+			// --- Assert ---
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called)
+			assert.Equal(t, "PL-RETAIL-2025", svc.code)
+		})
+	}
+}
+
+func TestPriceListCommandHandler_Rejected(t *testing.T) {
+	priceList := map[string]string{"code": "PL-RETAIL-2025"}
+	testCases := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		params         map[string]string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{
+			name: "missing valid_from", method: http.MethodPost, target: "/v1/price-lists",
+			body:           `{"code": "PL-RETAIL-2025", "name": "Poland retail", "market": "PL", "currency": "PLN"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "invalid currency", method: http.MethodPost, target: "/v1/price-lists",
+			body:           `{"code": "PL-RETAIL-2025", "name": "Poland retail", "market": "PL", "currency": "ZLOTY", "valid_from": "2025-02-01T00:00:00Z"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing unit price", method: http.MethodPut, target: "/v1/price-lists/PL-RETAIL-2025/prices",
+			body: `{"prices": [{"fabric_code": "VELVET01"}], "version": 1}`, params: priceList,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "validity ends before it starts", method: http.MethodPost, target: "/v1/price-lists",
+			body:        `{"code": "PL-RETAIL-2025", "name": "Poland retail", "market": "PL", "currency": "PLN", "valid_from": "2025-02-01T00:00:00Z", "valid_until": "2025-01-01T00:00:00Z"}`,
+			errToReturn: domain.ErrInvalidValidity, expectedStatus: http.StatusUnprocessableEntity, expectedCall: true,
+		},
+		{
+			name: "published list", method: http.MethodPut, target: "/v1/price-lists/PL-RETAIL-2025/prices",
+			body: `{"prices": [], "version": 3}`, params: priceList,
+			errToReturn: domain.ErrPriceListPublished, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "unknown list", method: http.MethodPost, target: "/v1/price-lists/NOSUCH/publish",
+			body: `{"version": 1}`, params: map[string]string{"code": "NOSUCH"},
+			errToReturn: domain.ErrPriceListNotFound, expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockPriceListCommandService{errToReturn: tc.errToReturn}
+			handler := NewPriceListCommandHandler(svc)
+
+			// --- Act ---
+			responseRecorder := servePriceList(t, handler, tc.method, tc.target, tc.body, tc.params)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called != "")
+		})
+	}
+}
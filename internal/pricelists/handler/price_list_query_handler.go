@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/pricelists/domain"
+)
+
+// PriceListQueryRepository reads the price lists.
+type PriceListQueryRepository interface {
+	GetPriceList(ctx context.Context, code string) (*domain.PriceList, error)
+	ListPriceLists(ctx context.Context, filter domain.PriceListFilter) ([]*domain.PriceList, int, error)
+}
+
+// PriceListQueryHandler serves the price lists, the latest effective first, optionally of
+// one market or status. Listed price lists leave out their prices, which are served with
+// a single list.
+type PriceListQueryHandler struct {
+	priceLists PriceListQueryRepository
+	pagination httpx.PaginationConfig
+}
+
+func NewPriceListQueryHandler(priceLists PriceListQueryRepository, pagination httpx.PaginationConfig) *PriceListQueryHandler {
+	return &PriceListQueryHandler{
+		priceLists: priceLists,
+		pagination: pagination,
+	}
+}
+
+func (h *PriceListQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	if httpx.URLParam(r, "code") == "" {
+		h.listPriceLists(w, r)
+		return
+	}
+	h.getPriceList(w, r)
+}
+
+func (h *PriceListQueryHandler) listPriceLists(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.PriceListFilter{
+		Market: validator.NormalizeCode(query.Get("market")),
+		Status: validator.NormalizeCode(query.Get("status")),
+	}
+
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if filter.Status != "" {
+		v.Check(validator.PermittedValue(filter.Status, domain.StatusDraft, domain.StatusPublished),
+			"status", "status must be one of DRAFT or PUBLISHED")
+	}
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+	filter.Limit, filter.Offset = page.Limit(), page.Offset()
+
+	priceLists, totalRecords, err := h.priceLists.ListPriceLists(r.Context(), filter)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"price_lists": priceLists, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *PriceListQueryHandler) getPriceList(w http.ResponseWriter, r *http.Request) {
+	priceList, err := h.priceLists.GetPriceList(r.Context(), validator.NormalizeCode(httpx.URLParam(r, "code")))
+	if err != nil {
+		writePriceListError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"price_list": priceList}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
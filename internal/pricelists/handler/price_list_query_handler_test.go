@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/pricelists/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPriceListQueryRepository struct {
+	filter domain.PriceListFilter
+}
+
+func (m *mockPriceListQueryRepository) GetPriceList(ctx context.Context, code string) (*domain.PriceList, error) {
+	if code != "PL-RETAIL-2025" {
+		return nil, domain.ErrPriceListNotFound
+	}
+	return &domain.PriceList{Code: code, Market: "PL", Status: domain.StatusDraft, Version: 1}, nil
+}
+
+func (m *mockPriceListQueryRepository) ListPriceLists(
+	ctx context.Context, filter domain.PriceListFilter,
+) ([]*domain.PriceList, int, error) {
+	m.filter = filter
+	return []*domain.PriceList{{Code: "PL-RETAIL-2025", Market: "PL", Version: 1}}, 21, nil
+}
+
+func TestPriceListQueryHandler_GetPriceList(t *testing.T) {
+	testCases := []struct {
+		name           string
+		code           string
+		expectedStatus int
+	}{
+		{name: "known list", code: "pl-retail-2025", expectedStatus: http.StatusOK},
+		{name: "unknown list", code: "NOSUCH", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewPriceListQueryHandler(&mockPriceListQueryRepository{}, httpx.PaginationConfig{})
+
+			// --- Act ---
+			responseRecorder := servePriceList(
+				t, handler, http.MethodGet, "/v1/price-lists/"+tc.code, "", map[string]string{"code": tc.code},
+			)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
+
+func TestPriceListQueryHandler_ListPriceLists(t *testing.T) {
+	testCases := []struct {
+		name           string
+		target         string
+		expectedStatus int
+		expectedFilter domain.PriceListFilter
+	}{
+		{
+			name: "filtered page", target: "/v1/price-lists?market=pl&status=published&page=2",
+			expectedStatus: http.StatusOK,
+			expectedFilter: domain.PriceListFilter{Market: "PL", Status: domain.StatusPublished, Limit: 20, Offset: 20},
+		},
+		{name: "unknown status", target: "/v1/price-lists?status=archived", expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := &mockPriceListQueryRepository{}
+			pagination := httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+			handler := NewPriceListQueryHandler(repo, pagination)
+
+			// --- Act ---
+			responseRecorder := servePriceList(t, handler, http.MethodGet, tc.target, "", nil)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedFilter, repo.filter)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	currencyDomain "github.com/salesworks/s-works/api/internal/currencies/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/pricelists/domain"
+)
+
+// PriceResolver finds the price that applies to a fabric.
+type PriceResolver interface {
+	ResolvePrice(ctx context.Context, query domain.PriceQuery) (*domain.ResolvedPrice, error)
+}
+
+// CurrencyConverter converts an amount between currencies at the exchange rates in force
+// on a date.
+type CurrencyConverter interface {
+	Convert(ctx context.Context, amount int64, from, to string, at time.Time) (*currencyDomain.Conversion, error)
+}
+
+// PriceResolutionHandler answers which price applies to a fabric for a customer group of
+// a market on a date, now when none is given:
+//
+//	GET /v1/price-lists/resolve?fabric_code=VELVET01&market=PL&customer_group=RETAIL&at=2025-03-01&currency=EUR
+//
+// The date is either a day, which is resolved at its start in UTC, or an RFC 3339 time.
+// When a currency other than the one of the price list is asked for, the unit price is
+// also converted at the exchange rates in force on that date.
+type PriceResolutionHandler struct {
+	resolver  PriceResolver
+	converter CurrencyConverter
+	clock     clock.Clock
+}
+
+func NewPriceResolutionHandler(
+	resolver PriceResolver, converter CurrencyConverter, clock clock.Clock,
+) *PriceResolutionHandler {
+	return &PriceResolutionHandler{
+		resolver:  resolver,
+		converter: converter,
+		clock:     clock,
+	}
+}
+
+func (h *PriceResolutionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	priceQuery := domain.PriceQuery{
+		FabricCode:    validator.NormalizeCode(query.Get("fabric_code")),
+		Market:        validator.NormalizeCode(query.Get("market")),
+		CustomerGroup: validator.NormalizeCode(query.Get("customer_group")),
+		At:            h.clock.Now(),
+	}
+	currency := validator.NormalizeCode(query.Get("currency"))
+
+	v := validator.New()
+	v.Check(priceQuery.FabricCode != "", "fabric_code", "fabric_code must be provided")
+	v.Check(priceQuery.Market != "", "market", "market must be provided")
+	if raw := query.Get("at"); raw != "" {
+		at, err := parseAt(raw)
+		v.Check(err == nil, "at", "at must be a date such as 2025-03-01 or an RFC 3339 time")
+		priceQuery.At = at
+	}
+	if currency != "" {
+		v.Check(validator.Matches(currency, currencyRX), "currency", "currency must be a three-letter ISO 4217 code")
+	}
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	price, err := h.resolver.ResolvePrice(r.Context(), priceQuery)
+	if err != nil {
+		if errors.Is(err, domain.ErrPriceNotFound) {
+			httpx.ErrorJSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writePriceListError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"price": price}
+	if currency != "" && currency != price.Currency {
+		conversion, err := h.converter.Convert(r.Context(), price.UnitPrice, price.Currency, currency, priceQuery.At)
+		if err != nil {
+			if errors.Is(err, currencyDomain.ErrRateNotFound) {
+				httpx.ErrorJSON(w, http.StatusNotFound, err.Error())
+				return
+			}
+			httpx.InternalError(w, r, err)
+			return
+		}
+		env["conversion"] = conversion
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// parseAt reads a day, taken at its start in UTC, or an RFC 3339 time.
+func parseAt(raw string) (time.Time, error) {
+	if day, err := time.Parse(time.DateOnly, raw); err == nil {
+		return day, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
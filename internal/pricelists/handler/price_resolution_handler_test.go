@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	currencyDomain "github.com/salesworks/s-works/api/internal/currencies/domain"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/pricelists/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 6, 15, 9, 30, 0, 0, time.UTC)
+
+type mockPriceResolver struct {
+	query *domain.PriceQuery
+}
+
+func (m *mockPriceResolver) ResolvePrice(ctx context.Context, query domain.PriceQuery) (*domain.ResolvedPrice, error) {
+	m.query = &query
+	if query.FabricCode != "VELVET01" {
+		return nil, domain.ErrPriceNotFound
+	}
+	return &domain.ResolvedPrice{
+		FabricCode: "VELVET01", UnitPrice: 2490, Currency: "PLN", PriceListCode: "PL-RETAIL-2025",
+		CustomerGroup: query.CustomerGroup, ValidFrom: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}, nil
+}
+
+type mockCurrencyConverter struct {
+	to string
+	at time.Time
+}
+
+func (m *mockCurrencyConverter) Convert(
+	ctx context.Context, amount int64, from, to string, at time.Time,
+) (*currencyDomain.Conversion, error) {
+	m.to, m.at = to, at
+	if to != "EUR" {
+		return nil, currencyDomain.ErrRateNotFound
+	}
+	rate := currencyDomain.ExchangeRate{Currency: "EUR", Day: currencyDomain.Day(at), Rate: 418000000}
+	conversion := currencyDomain.Convert(amount, from, nil, to, &rate)
+	return &conversion, nil
+}
+
+func TestPriceResolutionHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		target         string
+		expectedStatus int
+		expectedQuery  *domain.PriceQuery
+		expectedTo     string
+		expectedAmount int64
+	}{
+		{
+			name: "on a day", target: "/v1/price-lists/resolve?fabric_code=velvet01&market=pl&customer_group=retail&at=2025-03-01",
+			expectedStatus: http.StatusOK,
+			expectedQuery: &domain.PriceQuery{
+				FabricCode: "VELVET01", Market: "PL", CustomerGroup: "RETAIL", At: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "now", target: "/v1/price-lists/resolve?fabric_code=VELVET01&market=PL",
+			expectedStatus: http.StatusOK,
+			expectedQuery:  &domain.PriceQuery{FabricCode: "VELVET01", Market: "PL", At: testNow},
+		},
+		{
+			name: "no price", target: "/v1/price-lists/resolve?fabric_code=LINEN02&market=PL",
+			expectedStatus: http.StatusNotFound,
+			expectedQuery:  &domain.PriceQuery{FabricCode: "LINEN02", Market: "PL", At: testNow},
+		},
+		{
+			name: "in another currency", target: "/v1/price-lists/resolve?fabric_code=VELVET01&market=PL&currency=eur",
+			expectedStatus: http.StatusOK,
+			expectedQuery:  &domain.PriceQuery{FabricCode: "VELVET01", Market: "PL", At: testNow},
+			expectedTo:     "EUR", expectedAmount: 596,
+		},
+		{
+			name: "in the currency of the price", target: "/v1/price-lists/resolve?fabric_code=VELVET01&market=PL&currency=PLN",
+			expectedStatus: http.StatusOK,
+			expectedQuery:  &domain.PriceQuery{FabricCode: "VELVET01", Market: "PL", At: testNow},
+		},
+		{
+			name: "no exchange rate", target: "/v1/price-lists/resolve?fabric_code=VELVET01&market=PL&currency=CHF",
+			expectedStatus: http.StatusNotFound,
+			expectedQuery:  &domain.PriceQuery{FabricCode: "VELVET01", Market: "PL", At: testNow},
+			expectedTo:     "CHF",
+		},
+		{name: "invalid currency", target: "/v1/price-lists/resolve?fabric_code=VELVET01&market=PL&currency=EURO", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "missing market", target: "/v1/price-lists/resolve?fabric_code=VELVET01", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "invalid date", target: "/v1/price-lists/resolve?fabric_code=VELVET01&market=PL&at=01.03.2025", expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			resolver := &mockPriceResolver{}
+			converter := &mockCurrencyConverter{}
+			handler := NewPriceResolutionHandler(resolver, converter, clock.NewFixed(testNow))
+
+			// --- Act ---
+			responseRecorder := servePriceList(t, handler, http.MethodGet, tc.target, "", nil)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedQuery, resolver.query)
+			assert.Equal(t, tc.expectedTo, converter.to)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Price      domain.ResolvedPrice       `json:"price"`
+				Conversion *currencyDomain.Conversion `json:"conversion"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+			assert.Equal(t, int64(2490), body.Price.UnitPrice)
+			assert.Equal(t, "PL-RETAIL-2025", body.Price.PriceListCode)
+			if tc.expectedTo == "" {
+				assert.Nil(t, body.Conversion)
+				return
+			}
+			require.NotNil(t, body.Conversion)
+			assert.Equal(t, tc.expectedAmount, body.Conversion.Converted)
+			assert.Equal(t, testNow, converter.at)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+	"github.com/salesworks/s-works/api/internal/pricelists/domain"
+)
+
+// InstrumentedPriceListRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedPriceListRepository struct {
+	next domain.PriceListRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedPriceListRepository(
+	next domain.PriceListRepository, rec *instrument.Recorder,
+) *InstrumentedPriceListRepository {
+	return &InstrumentedPriceListRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedPriceListRepository) SavePriceList(ctx context.Context, priceList *domain.PriceList) error {
+	return instrument.Exec(ctx, r.rec, "SavePriceList", func(ctx context.Context) error {
+		return r.next.SavePriceList(ctx, priceList)
+	})
+}
+
+func (r *InstrumentedPriceListRepository) GetPriceList(ctx context.Context, code string) (*domain.PriceList, error) {
+	return instrument.Call(ctx, r.rec, "GetPriceList", func(ctx context.Context) (*domain.PriceList, error) {
+		return r.next.GetPriceList(ctx, code)
+	})
+}
+
+func (r *InstrumentedPriceListRepository) ListPriceLists(
+	ctx context.Context, filter domain.PriceListFilter,
+) ([]*domain.PriceList, int, error) {
+	var total int
+	priceLists, err := instrument.Call(ctx, r.rec, "ListPriceLists",
+		func(ctx context.Context) ([]*domain.PriceList, error) {
+			priceLists, count, err := r.next.ListPriceLists(ctx, filter)
+			total = count
+			return priceLists, err
+		})
+	return priceLists, total, err
+}
+
+func (r *InstrumentedPriceListRepository) UpdatePriceList(ctx context.Context, priceList *domain.PriceList) error {
+	return instrument.Exec(ctx, r.rec, "UpdatePriceList", func(ctx context.Context) error {
+		return r.next.UpdatePriceList(ctx, priceList)
+	})
+}
+
+func (r *InstrumentedPriceListRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	return instrument.Call(ctx, r.rec, "ResolveFabricCode", func(ctx context.Context) (string, error) {
+		return r.next.ResolveFabricCode(ctx, code)
+	})
+}
+
+func (r *InstrumentedPriceListRepository) ResolvePrice(
+	ctx context.Context, query domain.PriceQuery,
+) (*domain.ResolvedPrice, error) {
+	return instrument.Call(ctx, r.rec, "ResolvePrice", func(ctx context.Context) (*domain.ResolvedPrice, error) {
+		return r.next.ResolvePrice(ctx, query)
+	})
+}
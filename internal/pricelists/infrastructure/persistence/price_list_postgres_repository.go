@@ -0,0 +1,249 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/salesworks/s-works/api/internal/pricelists/domain"
+)
+
+const priceListColumns = `code, name, market, customer_group, currency, valid_from, valid_until, status,
+	published_at, version, created_at, created_by, updated_at, updated_by`
+
+// resolves a fabric code, or one of its aliases, to the canonical code
+const canonicalFabricCodeSQL = `COALESCE((SELECT canonical_code FROM fabric_aliases WHERE alias_code = $1), $1)`
+
+type PriceListPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewPriceListPostgresRepository(db *database.PostgresDB) *PriceListPostgresRepository {
+	return &PriceListPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *PriceListPostgresRepository) SavePriceList(ctx context.Context, priceList *domain.PriceList) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO price_lists (`+priceListColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`, priceList.Code, priceList.Name, priceList.Market, priceList.CustomerGroup, priceList.Currency,
+		priceList.ValidFrom, priceList.ValidUntil, priceList.Status, priceList.PublishedAt, priceList.Version,
+		priceList.CreatedAt, priceList.CreatedBy, priceList.UpdatedAt, priceList.UpdatedBy)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrDuplicatePriceListCode
+		}
+		return fmt.Errorf("failed to insert price list: %w", err)
+	}
+
+	if err := insertPrices(ctx, tx, priceList); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PriceListPostgresRepository) GetPriceList(ctx context.Context, code string) (*domain.PriceList, error) {
+	query := `SELECT ` + priceListColumns + ` FROM price_lists WHERE code = $1`
+	priceList, err := scanPriceList(r.db.Conn(ctx).QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrPriceListNotFound
+		}
+		return nil, fmt.Errorf("failed to get price list: %w", err)
+	}
+
+	if err := r.loadPrices(ctx, priceList); err != nil {
+		return nil, err
+	}
+	return priceList, nil
+}
+
+func (r *PriceListPostgresRepository) ListPriceLists(
+	ctx context.Context, filter domain.PriceListFilter,
+) ([]*domain.PriceList, int, error) {
+	query := `
+		SELECT count(*) OVER(), ` + priceListColumns + `
+		FROM price_lists
+		WHERE ($1 = '' OR market = $1) AND ($2 = '' OR status = $2)
+		ORDER BY valid_from DESC, code
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, filter.Market, filter.Status, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list price lists: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	priceLists := []*domain.PriceList{}
+	for rows.Next() {
+		priceList := &domain.PriceList{}
+		if err := rows.Scan(append([]any{&totalRecords}, priceListFields(priceList)...)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan price list: %w", err)
+		}
+		priceLists = append(priceLists, priceList)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate price lists: %w", err)
+	}
+
+	return priceLists, totalRecords, nil
+}
+
+// UpdatePriceList stores the price list with its prices, provided nobody changed it since
+// it was loaded.
+func (r *PriceListPostgresRepository) UpdatePriceList(ctx context.Context, priceList *domain.PriceList) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE price_lists
+		SET name = $1, market = $2, customer_group = $3, currency = $4, valid_from = $5, valid_until = $6,
+			status = $7, published_at = $8, version = $9, updated_at = $10, updated_by = $11
+		WHERE code = $12 AND version = $13
+	`, priceList.Name, priceList.Market, priceList.CustomerGroup, priceList.Currency, priceList.ValidFrom,
+		priceList.ValidUntil, priceList.Status, priceList.PublishedAt, priceList.Version,
+		priceList.UpdatedAt, priceList.UpdatedBy, priceList.Code, priceList.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update price list: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM price_list_prices WHERE price_list_code = $1`, priceList.Code); err != nil {
+		return fmt.Errorf("failed to remove price list prices: %w", err)
+	}
+	if err := insertPrices(ctx, tx, priceList); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PriceListPostgresRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	var canonicalCode string
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		`SELECT code FROM fabrics WHERE code = `+canonicalFabricCodeSQL+` AND status = 'ACTIVE'`, code,
+	).Scan(&canonicalCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrFabricNotFound
+		}
+		return "", fmt.Errorf("failed to resolve fabric code: %w", err)
+	}
+	return canonicalCode, nil
+}
+
+// ResolvePrice picks the price from the published lists of the market effective at the
+// time, those of the customer group first and the latest effective next, as
+// domain.ResolvedPrice describes.
+func (r *PriceListPostgresRepository) ResolvePrice(
+	ctx context.Context, query domain.PriceQuery,
+) (*domain.ResolvedPrice, error) {
+	var price domain.ResolvedPrice
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT p.fabric_code, p.unit_price, l.currency, l.code, l.customer_group, l.valid_from, l.valid_until
+		FROM price_lists l
+		JOIN price_list_prices p ON p.price_list_code = l.code
+		WHERE p.fabric_code = `+canonicalFabricCodeSQL+`
+			AND l.status = 'PUBLISHED' AND l.market = $2 AND l.customer_group IN ($3, '')
+			AND l.valid_from <= $4 AND (l.valid_until IS NULL OR l.valid_until > $4)
+		ORDER BY l.customer_group = '', l.valid_from DESC, l.published_at DESC
+		LIMIT 1
+	`, query.FabricCode, query.Market, query.CustomerGroup, query.At).Scan(
+		&price.FabricCode, &price.UnitPrice, &price.Currency, &price.PriceListCode, &price.CustomerGroup,
+		&price.ValidFrom, &price.ValidUntil,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrPriceNotFound
+		}
+		return nil, fmt.Errorf("failed to resolve price: %w", err)
+	}
+	return &price, nil
+}
+
+// loadPrices fills in the prices of the price list, in the order they were given.
+func (r *PriceListPostgresRepository) loadPrices(ctx context.Context, priceList *domain.PriceList) error {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT fabric_code, unit_price
+		FROM price_list_prices
+		WHERE price_list_code = $1
+		ORDER BY position
+	`, priceList.Code)
+	if err != nil {
+		return fmt.Errorf("failed to load price list prices: %w", err)
+	}
+	defer rows.Close()
+
+	priceList.Prices = []domain.Price{}
+	for rows.Next() {
+		var price domain.Price
+		if err := rows.Scan(&price.FabricCode, &price.UnitPrice); err != nil {
+			return fmt.Errorf("failed to scan price list price: %w", err)
+		}
+		priceList.Prices = append(priceList.Prices, price)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate price list prices: %w", err)
+	}
+	return nil
+}
+
+func insertPrices(ctx context.Context, tx *database.Tx, priceList *domain.PriceList) error {
+	for position, price := range priceList.Prices {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO price_list_prices (price_list_code, position, fabric_code, unit_price)
+			VALUES ($1, $2, $3, $4)
+		`, priceList.Code, position+1, price.FabricCode, price.UnitPrice)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				// the fabric was purged since it was resolved
+				return domain.ErrFabricNotFound
+			}
+			return fmt.Errorf("failed to insert price list price: %w", err)
+		}
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPriceList(row rowScanner) (*domain.PriceList, error) {
+	priceList := &domain.PriceList{}
+	if err := row.Scan(priceListFields(priceList)...); err != nil {
+		return nil, err
+	}
+	return priceList, nil
+}
+
+// priceListFields lists the destinations of priceListColumns, in the same order.
+func priceListFields(priceList *domain.PriceList) []any {
+	return []any{
+		&priceList.Code, &priceList.Name, &priceList.Market, &priceList.CustomerGroup, &priceList.Currency,
+		&priceList.ValidFrom, &priceList.ValidUntil, &priceList.Status, &priceList.PublishedAt, &priceList.Version,
+		&priceList.CreatedAt, &priceList.CreatedBy, &priceList.UpdatedAt, &priceList.UpdatedBy,
+	}
+}
@@ -0,0 +1,205 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"github.com/salesworks/s-works/api/internal/products/domain"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ProductService maintains the products and publishes their events.
+type ProductService struct {
+	repo         domain.ProductRepository
+	eventStore   eventstore.Store
+	clock        clock.Clock
+	eventChannel string
+	source       messaging.Source
+}
+
+func NewProductCommandService(
+	repo domain.ProductRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+) *ProductService {
+	return &ProductService{
+		repo:         repo,
+		eventStore:   eventStore,
+		clock:        clock,
+		eventChannel: "app.product",
+		source:       source,
+	}
+}
+
+// CreateProduct adds a product made of the given components. Fabrics may be given by an
+// alias, the bill of materials keeps their canonical codes.
+func (s *ProductService) CreateProduct(
+	ctx context.Context, code, name, description string, components []domain.Component,
+) (*domain.Product, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "product.service.create")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "product.service")
+
+	components, err := s.resolveComponents(ctx, components)
+	if err != nil {
+		return nil, err
+	}
+	product, err := domain.NewProduct(code, name, description, components, aggregate.StampNow(ctx, s.clock))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveProduct(ctx, product); err != nil {
+		return nil, s.failed(span, logger, "saving product failed", err)
+	}
+
+	if err := s.publish(ctx, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func (s *ProductService) UpdateProduct(
+	ctx context.Context, code, name, description string, version int,
+) (*domain.Product, error) {
+	return s.change(ctx, code, "product.service.update", func(product *domain.Product, stamp domain.Stamp) error {
+		return product.Update(name, description, version, stamp)
+	})
+}
+
+// ChangeComponents replaces the bill of materials of the product.
+func (s *ProductService) ChangeComponents(
+	ctx context.Context, code string, components []domain.Component, version int,
+) (*domain.Product, error) {
+	components, err := s.resolveComponents(ctx, components)
+	if err != nil {
+		return nil, err
+	}
+	return s.change(ctx, code, "product.service.change_components", func(product *domain.Product, stamp domain.Stamp) error {
+		return product.ChangeComponents(components, version, stamp)
+	})
+}
+
+func (s *ProductService) DeleteProduct(ctx context.Context, code string, version int) (*domain.Product, error) {
+	return s.change(ctx, code, "product.service.delete", func(product *domain.Product, stamp domain.Stamp) error {
+		return product.Delete(version, stamp)
+	})
+}
+
+func (s *ProductService) RestoreProduct(ctx context.Context, code string, version int) (*domain.Product, error) {
+	return s.change(ctx, code, "product.service.restore", func(product *domain.Product, stamp domain.Stamp) error {
+		return product.Restore(version, stamp)
+	})
+}
+
+// resolveComponents puts the components under the canonical codes of their fabrics, which
+// have to be active.
+func (s *ProductService) resolveComponents(
+	ctx context.Context, components []domain.Component,
+) ([]domain.Component, error) {
+	resolved := make([]domain.Component, 0, len(components))
+	for _, component := range components {
+		code, err := s.repo.ResolveFabricCode(ctx, component.FabricCode)
+		if err != nil {
+			return nil, err
+		}
+		component.FabricCode = code
+		resolved = append(resolved, component)
+	}
+	return resolved, nil
+}
+
+// change loads the product, applies a command to it, stores it and publishes its events.
+func (s *ProductService) change(
+	ctx context.Context, code, spanName string, apply func(product *domain.Product, stamp domain.Stamp) error,
+) (*domain.Product, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, spanName)
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "product.service")
+
+	product, err := s.repo.GetProduct(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(product, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateProduct(ctx, product); err != nil {
+		return nil, s.failed(span, logger, "updating product failed", err)
+	}
+
+	if err := s.publish(ctx, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// failed reports a repository write that did not succeed. Product errors are passed
+// through as they are, anything else is wrapped and recorded as a database error.
+func (s *ProductService) failed(span trace.Span, logger *slog.Logger, msg string, err error) error {
+	var productErr *domain.ProductError
+	if errors.As(err, &productErr) {
+		return err
+	}
+	wrappedErr := fmt.Errorf("failed to write product in repo: %w", err)
+	logger.Error(msg, "error", wrappedErr)
+	span.RecordError(wrappedErr)
+	span.SetStatus(codes.Error, "database write error")
+	return wrappedErr
+}
+
+func (s *ProductService) publish(ctx context.Context, product *domain.Product) error {
+	logger := httpx.GetLogger(ctx).With("component", "product.service")
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range product.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.ProductCreated:
+			eventType = "app.product.created"
+		case domain.ProductUpdated:
+			eventType = "app.product.updated"
+		case domain.ProductComponentsChanged:
+			eventType = "app.product.components_changed"
+		case domain.ProductDeleted:
+			eventType = "app.product.deleted"
+		case domain.ProductRestored:
+			eventType = "app.product.restored"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			product.Code,
+			"Product",
+			product.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelope.UserID = command.Actor(ctx)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopesToPublish...); err != nil {
+			wrappedErr := fmt.Errorf("failed to save product event to event store: %w", err)
+			logger.Error("saving product event failed", "error", wrappedErr)
+			return wrappedErr
+		}
+	}
+
+	return nil
+}
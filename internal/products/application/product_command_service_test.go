@@ -0,0 +1,187 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/products/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = domain.Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testSource = messaging.Source{Service: "s-works-api", Instance: "test-instance"}
+
+type mockProductRepository struct {
+	products    map[string]*domain.Product
+	aliases     map[string]string
+	saved       *domain.Product
+	errToReturn error
+}
+
+func (m *mockProductRepository) SaveProduct(ctx context.Context, product *domain.Product) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.saved = product
+	return nil
+}
+
+func (m *mockProductRepository) GetProduct(ctx context.Context, code string) (*domain.Product, error) {
+	product, ok := m.products[code]
+	if !ok {
+		return nil, domain.ErrProductNotFound
+	}
+	productCopy := *product
+	return &productCopy, nil
+}
+
+func (m *mockProductRepository) ListProducts(ctx context.Context, limit, offset int) ([]*domain.Product, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockProductRepository) UpdateProduct(ctx context.Context, product *domain.Product) error {
+	return m.SaveProduct(ctx, product)
+}
+
+func (m *mockProductRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	if canonical, ok := m.aliases[code]; ok {
+		return canonical, nil
+	}
+	return "", domain.ErrFabricNotFound
+}
+
+type mockEventStore struct {
+	SavedCalled      bool
+	EnqueuedSubject  string
+	EnqueuedEnvelope *messaging.EventEnvelope
+}
+
+func (m *mockEventStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
+	m.SavedCalled = true
+	return nil
+}
+
+func (m *mockEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.SavedCalled = true
+	m.EnqueuedSubject = subject
+	m.EnqueuedEnvelope = envelopes[len(envelopes)-1]
+	return nil
+}
+
+func newTestRepository() *mockProductRepository {
+	product := &domain.Product{
+		Code: "CUSHION-01", Name: "Velvet cushion", Version: 1,
+		Components: []domain.Component{{FabricCode: "VELVET01", Quantity: 1750}},
+	}
+	return &mockProductRepository{
+		products: map[string]*domain.Product{product.Code: product},
+		aliases:  map[string]string{"VELVET01": "VELVET01", "OLDVELVET": "VELVET01", "LINEN02": "LINEN02"},
+	}
+}
+
+func TestProductService_CreateProduct_ResolvesAlias(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewProductCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+	components := []domain.Component{{FabricCode: "OLDVELVET", Quantity: 1750}}
+
+	// --- Act ---
+	product, err := service.CreateProduct(context.Background(), "CUSHION-02", "Velvet cushion XL", "", components)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, repo.saved, "expected SaveProduct() to be called on the repository")
+	assert.Equal(t, "VELVET01", product.Components[0].FabricCode)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.product", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.product.created", publishedEnvelope.EventType)
+	assert.Equal(t, "Product", publishedEnvelope.AggregateType)
+	assert.Equal(t, "CUSHION-02", publishedEnvelope.AggregateID)
+}
+
+func TestProductService_ChangeComponents(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewProductCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+	components := []domain.Component{{FabricCode: "VELVET01", Quantity: 1500}, {FabricCode: "LINEN02", Quantity: 250}}
+
+	// --- Act ---
+	product, err := service.ChangeComponents(context.Background(), "CUSHION-01", components, 1)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, 2, product.Version)
+	assert.Equal(t, components, product.Components)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.product.components_changed", publishedEnvelope.EventType)
+}
+
+func TestProductService_RejectedIsNotPublished(t *testing.T) {
+	testCases := []struct {
+		name        string
+		errToReturn error
+		run         func(s *ProductService) error
+		expectedErr error
+	}{
+		{
+			name: "Unknown fabric",
+			run: func(s *ProductService) error {
+				components := []domain.Component{{FabricCode: "NOSUCHFABRIC", Quantity: 1000}}
+				_, err := s.CreateProduct(context.Background(), "CUSHION-02", "Cushion", "", components)
+				return err
+			},
+			expectedErr: domain.ErrFabricNotFound,
+		},
+		{
+			name:        "Code taken",
+			errToReturn: domain.ErrDuplicateProductCode,
+			run: func(s *ProductService) error {
+				_, err := s.CreateProduct(context.Background(), "CUSHION-01", "Cushion", "", nil)
+				return err
+			},
+			expectedErr: domain.ErrDuplicateProductCode,
+		},
+		{
+			name: "Stale version",
+			run: func(s *ProductService) error {
+				_, err := s.UpdateProduct(context.Background(), "CUSHION-01", "Cushion", "", 2)
+				return err
+			},
+			expectedErr: domain.ErrConcurrencyConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			repo.errToReturn = tc.errToReturn
+			eventStore := &mockEventStore{}
+			service := NewProductCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err := tc.run(service)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, repo.saved)
+			assert.False(t, eventStore.SavedCalled, "a rejected command must not be stored")
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+var (
+	ErrProductNotFound       = notFoundError("product not found")
+	ErrFabricNotFound        = notFoundError("fabric not found")
+	ErrDuplicateProductCode  = conflictError("a product with this code already exists")
+	ErrConcurrencyConflict   = conflictError("the product has been modified by another process, please refresh and try again")
+	ErrProductDeleted        = conflictError("cannot perform on a deleted product")
+	ErrProductNotDeleted     = conflictError("the product is not deleted")
+	ErrDuplicateComponent    = invalidError("a fabric can only be once in the bill of materials")
+	ErrInvalidComponentCount = invalidError("the bill of materials must not have more than 50 components")
+	ErrInvalidQuantity       = invalidError("the quantity must be a decimal number greater than 0 with at most 3 decimals")
+)
+
+// ProductError is a rule violation reported by the product domain. Its kind tells the
+// handler which status to answer with and instrumentation how to class it.
+type ProductError struct {
+	Kind    string
+	Message string
+}
+
+func (e *ProductError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *ProductError) ErrorClass() string {
+	return e.Kind
+}
+
+func notFoundError(message string) *ProductError {
+	return &ProductError{Kind: "not_found", Message: message}
+}
+
+func conflictError(message string) *ProductError {
+	return &ProductError{Kind: "conflict", Message: message}
+}
+
+func invalidError(message string) *ProductError {
+	return &ProductError{Kind: "invalid", Message: message}
+}
+
+type Event = aggregate.Event
+
+// Stamp identifies who performed a change on a product and when it happened.
+type Stamp = aggregate.Stamp
+
+// Product is a finished good sold to customers, made of the fabrics of its bill of
+// materials. A deleted product is kept, so it can be restored and its code is not given
+// to another one.
+type Product struct {
+	Code        string      `json:"code"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Components  []Component `json:"components"`
+	Version     int         `json:"version"`
+	aggregate.Audit
+	aggregate.SoftDelete
+	aggregate.Root
+}
+
+type ProductCreated struct {
+	Code        string
+	Name        string
+	Description string
+	Components  []Component
+	Version     int
+}
+
+type ProductUpdated struct {
+	Code        string
+	Name        string
+	Description string
+	Version     int
+}
+
+// ProductComponentsChanged is recorded when the bill of materials is replaced.
+type ProductComponentsChanged struct {
+	Code       string
+	Components []Component
+	Version    int
+}
+
+type ProductDeleted struct {
+	Code    string
+	Version int
+}
+
+type ProductRestored struct {
+	Code    string
+	Version int
+}
+
+func NewProduct(code, name, description string, components []Component, stamp Stamp) (*Product, error) {
+	if err := checkComponents(components); err != nil {
+		return nil, err
+	}
+
+	product := &Product{
+		Code:        code,
+		Name:        name,
+		Description: description,
+		Components:  components,
+		Version:     1,
+		Audit:       aggregate.NewAudit(stamp),
+	}
+
+	event := ProductCreated{
+		Code:        product.Code,
+		Name:        product.Name,
+		Description: product.Description,
+		Components:  product.Components,
+		Version:     product.Version,
+	}
+	product.Record(event)
+	return product, nil
+}
+
+// Update renames the product and replaces its description.
+func (p *Product) Update(name, description string, version int, stamp Stamp) error {
+	if err := p.checkLive(version); err != nil {
+		return err
+	}
+
+	p.Name = name
+	p.Description = description
+	p.Version++
+	p.Touch(stamp)
+
+	event := ProductUpdated{
+		Code:        p.Code,
+		Name:        p.Name,
+		Description: p.Description,
+		Version:     p.Version,
+	}
+	p.Record(event)
+	return nil
+}
+
+// ChangeComponents replaces the bill of materials of the product.
+func (p *Product) ChangeComponents(components []Component, version int, stamp Stamp) error {
+	if err := p.checkLive(version); err != nil {
+		return err
+	}
+	if err := checkComponents(components); err != nil {
+		return err
+	}
+
+	p.Components = components
+	p.Version++
+	p.Touch(stamp)
+
+	event := ProductComponentsChanged{
+		Code:       p.Code,
+		Components: p.Components,
+		Version:    p.Version,
+	}
+	p.Record(event)
+	return nil
+}
+
+// Delete marks the product deleted, it keeps its code until it is restored.
+func (p *Product) Delete(version int, stamp Stamp) error {
+	if err := p.checkLive(version); err != nil {
+		return err
+	}
+
+	p.MarkDeleted(stamp.At)
+	p.Version++
+	p.Touch(stamp)
+
+	event := ProductDeleted{
+		Code:    p.Code,
+		Version: p.Version,
+	}
+	p.Record(event)
+	return nil
+}
+
+// Restore brings a deleted product back with the bill of materials it had.
+func (p *Product) Restore(version int, stamp Stamp) error {
+	if !p.Deleted() {
+		return ErrProductNotDeleted
+	}
+	if err := aggregate.CheckVersion(p.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	p.ClearDeleted()
+	p.Version++
+	p.Touch(stamp)
+
+	event := ProductRestored{
+		Code:    p.Code,
+		Version: p.Version,
+	}
+	p.Record(event)
+	return nil
+}
+
+// checkLive refuses changes of a deleted product and commands made against another
+// version than the current one.
+func (p *Product) checkLive(version int) error {
+	if p.Deleted() {
+		return ErrProductDeleted
+	}
+	return aggregate.CheckVersion(p.Version, version, ErrConcurrencyConflict)
+}
+
+type ProductRepository interface {
+	// SaveProduct stores a new product with its bill of materials, failing with
+	// ErrDuplicateProductCode when the code is taken, deleted products included.
+	SaveProduct(ctx context.Context, product *Product) error
+	// GetProduct loads a product with its bill of materials, deleted ones included, or
+	// fails with ErrProductNotFound.
+	GetProduct(ctx context.Context, code string) (*Product, error)
+	// ListProducts returns a page of the products that are not deleted, by code, together
+	// with their total number.
+	ListProducts(ctx context.Context, limit, offset int) ([]*Product, int, error)
+	// UpdateProduct stores a change of a product still at the version it was loaded
+	// with, or fails with ErrConcurrencyConflict.
+	UpdateProduct(ctx context.Context, product *Product) error
+	// ResolveFabricCode resolves a fabric code, or one of its aliases, to the canonical
+	// code of an active fabric, or fails with ErrFabricNotFound.
+	ResolveFabricCode(ctx context.Context, code string) (string, error)
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "github.com/salesworks/s-works/api/internal/platform/quantity"
+
+// maxComponents caps the bill of materials of a single product.
+const maxComponents = 50
+
+// Quantity is an amount of a fabric in its measure unit, in thousandths of the unit.
+type Quantity = quantity.Quantity
+
+// ParseQuantity reads a positive decimal quantity such as "1.75".
+func ParseQuantity(raw string) (Quantity, error) {
+	parsed, err := quantity.Parse(raw)
+	if err != nil || parsed <= 0 {
+		return 0, ErrInvalidQuantity
+	}
+	return parsed, nil
+}
+
+// Component is a fabric, under its canonical code, that goes into one unit of a product.
+type Component struct {
+	FabricCode string   `json:"fabric_code"`
+	Quantity   Quantity `json:"quantity"`
+}
+
+// checkComponents holds a bill of materials to its rules: a bounded number of components,
+// positive quantities and each fabric once.
+func checkComponents(components []Component) error {
+	if len(components) > maxComponents {
+		return ErrInvalidComponentCount
+	}
+	seen := make(map[string]bool, len(components))
+	for _, component := range components {
+		if component.Quantity <= 0 {
+			return ErrInvalidQuantity
+		}
+		if seen[component.FabricCode] {
+			return ErrDuplicateComponent
+		}
+		seen[component.FabricCode] = true
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testComponents = []Component{
+	{FabricCode: "VELVET01", Quantity: 1750},
+	{FabricCode: "LINEN02", Quantity: 500},
+}
+
+func newTestProduct(t *testing.T) *Product {
+	t.Helper()
+
+	product, err := NewProduct("CUSHION-01", "Velvet cushion", "", testComponents, testStamp)
+	require.NoError(t, err)
+	return product
+}
+
+func TestNewProduct_InvalidComponents(t *testing.T) {
+	tooMany := make([]Component, maxComponents+1)
+	for i := range tooMany {
+		tooMany[i] = Component{FabricCode: fmt.Sprintf("FAB%02d", i), Quantity: 1000}
+	}
+
+	testCases := []struct {
+		name        string
+		components  []Component
+		expectedErr error
+	}{
+		{name: "Zero quantity", components: []Component{{FabricCode: "VELVET01"}}, expectedErr: ErrInvalidQuantity},
+		{
+			name: "Fabric twice", components: []Component{
+				{FabricCode: "VELVET01", Quantity: 1000}, {FabricCode: "VELVET01", Quantity: 500},
+			},
+			expectedErr: ErrDuplicateComponent,
+		},
+		{name: "Too many components", components: tooMany, expectedErr: ErrInvalidComponentCount},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Act ---
+			product, err := NewProduct("CUSHION-01", "Velvet cushion", "", tc.components, testStamp)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, product)
+		})
+	}
+}
+
+func TestProduct_ChangeComponents(t *testing.T) {
+	testCases := []struct {
+		name        string
+		deleted     bool
+		version     int
+		expectedErr error
+	}{
+		{name: "Current version", version: 1},
+		{name: "Stale version", version: 2, expectedErr: ErrConcurrencyConflict},
+		{name: "Deleted product", deleted: true, version: 1, expectedErr: ErrProductDeleted},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			product := newTestProduct(t)
+			if tc.deleted {
+				product.MarkDeleted(testStamp.At)
+			}
+			components := []Component{{FabricCode: "VELVET01", Quantity: 2000}}
+
+			// --- Act ---
+			err := product.ChangeComponents(components, tc.version, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Len(t, product.Components, 2)
+				assert.Len(t, product.Events(), 1, "a rejected change must not record an event")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 2, product.Version)
+			assert.Equal(t, ProductComponentsChanged{Code: "CUSHION-01", Components: components, Version: 2}, product.Events()[1])
+		})
+	}
+}
+
+func TestProduct_DeleteAndRestore(t *testing.T) {
+	// --- Arrange ---
+	product := newTestProduct(t)
+
+	// --- Act ---
+	deleteErr := product.Delete(1, testStamp)
+	updateErr := product.Update("Renamed", "", 2, testStamp)
+	restoreErr := product.Restore(2, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, deleteErr)
+	assert.ErrorIs(t, updateErr, ErrProductDeleted)
+	require.NoError(t, restoreErr)
+	assert.False(t, product.Deleted())
+	assert.Equal(t, 3, product.Version)
+	assert.Equal(t, testComponents, product.Components)
+	assert.IsType(t, ProductRestored{}, product.Events()[2])
+}
+
+func TestParseQuantity(t *testing.T) {
+	testCases := []struct {
+		raw         string
+		expected    Quantity
+		expectedErr bool
+	}{
+		{raw: "1.75", expected: 1750},
+		{raw: "2", expected: 2000},
+		{raw: "0", expectedErr: true},
+		{raw: "-1", expectedErr: true},
+		{raw: "0.0005", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.raw, func(t *testing.T) {
+			// --- Act ---
+			quantity, err := ParseQuantity(tc.raw)
+
+			// --- Assert ---
+			if tc.expectedErr {
+				assert.ErrorIs(t, err, ErrInvalidQuantity)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, quantity)
+			assert.Equal(t, tc.raw, quantity.String())
+		})
+	}
+}
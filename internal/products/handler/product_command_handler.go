@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/products/domain"
+)
+
+var productCodeRX = regexp.MustCompile("^[A-Z0-9-]+$")
+
+// ProductCommandService maintains the products and their bills of materials.
+type ProductCommandService interface {
+	CreateProduct(
+		ctx context.Context, code, name, description string, components []domain.Component,
+	) (*domain.Product, error)
+	UpdateProduct(ctx context.Context, code, name, description string, version int) (*domain.Product, error)
+	ChangeComponents(
+		ctx context.Context, code string, components []domain.Component, version int,
+	) (*domain.Product, error)
+	DeleteProduct(ctx context.Context, code string, version int) (*domain.Product, error)
+	RestoreProduct(ctx context.Context, code string, version int) (*domain.Product, error)
+}
+
+// ProductCommandHandler creates, updates, deletes and restores products and replaces their
+// bills of materials.
+type ProductCommandHandler struct {
+	service ProductCommandService
+}
+
+// the quantity is a decimal string, so no precision is lost on the way
+type componentRequest struct {
+	FabricCode string `json:"fabric_code"`
+	Quantity   string `json:"quantity"`
+}
+
+type createProductRequest struct {
+	Code        string             `json:"code"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Components  []componentRequest `json:"components"`
+}
+
+type updateProductRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Version     int    `json:"version"`
+}
+
+type changeComponentsRequest struct {
+	Components []componentRequest `json:"components"`
+	Version    int                `json:"version"`
+}
+
+// versionRequest carries the version a delete or restore is made against.
+type versionRequest struct {
+	Version int `json:"version"`
+}
+
+func NewProductCommandHandler(service ProductCommandService) *ProductCommandHandler {
+	return &ProductCommandHandler{service: service}
+}
+
+func (h *ProductCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+	r = r.WithContext(ctx)
+
+	switch r.Method {
+	case http.MethodPost:
+		// a product is created on the collection and restored on its own resource
+		if httpx.URLParam(r, "code") != "" {
+			h.restoreProduct(w, r)
+			return
+		}
+		h.createProduct(w, r)
+	case http.MethodPut:
+		if strings.HasSuffix(r.URL.Path, "/components") {
+			h.changeComponents(w, r)
+			return
+		}
+		h.updateProduct(w, r)
+	case http.MethodDelete:
+		h.deleteProduct(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *ProductCommandHandler) createProduct(w http.ResponseWriter, r *http.Request) {
+	var req createProductRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Code = validator.NormalizeCode(req.Code)
+	req.Name = validator.NormalizeText(req.Name)
+	req.Description = strings.TrimSpace(req.Description)
+	v := validator.New()
+	v.Check(req.Code != "", "code", "code must be provided")
+	v.Check(len(req.Code) >= 2 && len(req.Code) <= 30, "code", "code must be between 2 and 30 characters long")
+	v.Check(validator.Matches(req.Code, productCodeRX), "code", "code must only contain uppercase letters, numbers and dashes")
+	validateProduct(v, req.Name, req.Description)
+	components := readComponents(v, req.Components)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	product, err := h.service.CreateProduct(r.Context(), req.Code, req.Name, req.Description, components)
+	if err != nil {
+		writeProductError(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", "/v1/products/"+product.Code)
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"product": product}, headers); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *ProductCommandHandler) updateProduct(w http.ResponseWriter, r *http.Request) {
+	var req updateProductRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Name = validator.NormalizeText(req.Name)
+	req.Description = strings.TrimSpace(req.Description)
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	validateProduct(v, req.Name, req.Description)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	product, err := h.service.UpdateProduct(
+		r.Context(), httpx.URLParam(r, "code"), req.Name, req.Description, req.Version,
+	)
+	if err != nil {
+		writeProductError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"product": product}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *ProductCommandHandler) changeComponents(w http.ResponseWriter, r *http.Request) {
+	var req changeComponentsRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	components := readComponents(v, req.Components)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	product, err := h.service.ChangeComponents(r.Context(), httpx.URLParam(r, "code"), components, req.Version)
+	if err != nil {
+		writeProductError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"product": product}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *ProductCommandHandler) deleteProduct(w http.ResponseWriter, r *http.Request) {
+	version, ok := readVersion(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.service.DeleteProduct(r.Context(), httpx.URLParam(r, "code"), version); err != nil {
+		writeProductError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ProductCommandHandler) restoreProduct(w http.ResponseWriter, r *http.Request) {
+	version, ok := readVersion(w, r)
+	if !ok {
+		return
+	}
+
+	product, err := h.service.RestoreProduct(r.Context(), httpx.URLParam(r, "code"), version)
+	if err != nil {
+		writeProductError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"product": product}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// readVersion reads the version of a delete or restore, answering the request itself when
+// it is missing or invalid.
+func readVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	var req versionRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return 0, false
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return 0, false
+	}
+	return req.Version, true
+}
+
+func validateProduct(v *validator.Validator, name, description string) {
+	v.Check(name != "", "name", "name must be provided")
+	v.Check(len(name) <= 250, "name", "name must not be more than 250 characters long")
+	v.Check(len(description) <= 2000, "description", "description must not be more than 2000 characters long")
+}
+
+// readComponents checks the requested components and turns them into a bill of materials,
+// reporting every invalid field under its component. Rules spanning the whole bill, such
+// as a fabric given twice, are left to the domain.
+func readComponents(v *validator.Validator, requested []componentRequest) []domain.Component {
+	components := make([]domain.Component, 0, len(requested))
+	for i, req := range requested {
+		field := fmt.Sprintf("components[%d]", i)
+		component := domain.Component{FabricCode: validator.NormalizeCode(req.FabricCode)}
+		v.Check(component.FabricCode != "", field+".fabric_code", "fabric_code must be provided")
+
+		quantity, err := domain.ParseQuantity(validator.NormalizeText(req.Quantity))
+		v.Check(err == nil, field+".quantity", "quantity must be a decimal number greater than 0 with at most 3 decimals")
+		component.Quantity = quantity
+		components = append(components, component)
+	}
+	return components
+}
+
+// writeProductError answers a failed command with the status matching the kind of product
+// error, anything that is not a product error is answered as an internal error.
+func writeProductError(w http.ResponseWriter, r *http.Request, err error) {
+	var productErr *domain.ProductError
+	if !errors.As(err, &productErr) {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch productErr.Kind {
+	case "not_found":
+		httpx.NotFound(w, r)
+	case "invalid":
+		httpx.ErrorJSON(w, http.StatusUnprocessableEntity, productErr.Message)
+	default:
+		httpx.ErrorJSON(w, http.StatusConflict, productErr.Message)
+	}
+}
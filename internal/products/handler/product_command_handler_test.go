@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/products/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProductCommandService struct {
+	called      string
+	code        string
+	name        string
+	components  []domain.Component
+	version     int
+	errToReturn error
+}
+
+func (m *mockProductCommandService) CreateProduct(
+	ctx context.Context, code, name, description string, components []domain.Component,
+) (*domain.Product, error) {
+	m.called, m.code, m.name, m.components = "create", code, name, components
+	return m.result(code, 1)
+}
+
+func (m *mockProductCommandService) UpdateProduct(
+	ctx context.Context, code, name, description string, version int,
+) (*domain.Product, error) {
+	m.called, m.code, m.name, m.version = "update", code, name, version
+	return m.result(code, version+1)
+}
+
+func (m *mockProductCommandService) ChangeComponents(
+	ctx context.Context, code string, components []domain.Component, version int,
+) (*domain.Product, error) {
+	m.called, m.code, m.components, m.version = "change_components", code, components, version
+	return m.result(code, version+1)
+}
+
+func (m *mockProductCommandService) DeleteProduct(ctx context.Context, code string, version int) (*domain.Product, error) {
+	m.called, m.code, m.version = "delete", code, version
+	return m.result(code, version+1)
+}
+
+func (m *mockProductCommandService) RestoreProduct(ctx context.Context, code string, version int) (*domain.Product, error) {
+	m.called, m.code, m.version = "restore", code, version
+	return m.result(code, version+1)
+}
+
+func (m *mockProductCommandService) result(code string, version int) (*domain.Product, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Product{Code: code, Version: version}, nil
+}
+
+func serveProduct(
+	t *testing.T, handler http.Handler, method, target, body string, params map[string]string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestProductCommandHandler_CreateProduct(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockProductCommandService{}
+	handler := NewProductCommandHandler(svc)
+	body := `{"code": " cushion-01 ", "name": " Velvet  cushion ", "components": [
+		{"fabric_code": "velvet01", "quantity": "1.75"}, {"fabric_code": "LINEN02", "quantity": "0.5"}
+	]}`
+
+	// --- Act ---
+	responseRecorder := serveProduct(t, handler, http.MethodPost, "/v1/products", body, nil)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, "/v1/products/CUSHION-01", responseRecorder.Header().Get("Location"))
+	assert.Equal(t, "CUSHION-01", svc.code)
+	assert.Equal(t, "Velvet cushion", svc.name)
+	assert.Equal(t, []domain.Component{
+		{FabricCode: "VELVET01", Quantity: 1750}, {FabricCode: "LINEN02", Quantity: 500},
+	}, svc.components)
+}
+
+func TestProductCommandHandler_Routes(t *testing.T) {
+	product := map[string]string{"code": "CUSHION-01"}
+	testCases := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		expectedStatus int
+		expectedCall   string
+	}{
+		{
+			name: "update", method: http.MethodPut, target: "/v1/products/CUSHION-01",
+			body: `{"name": "Velvet cushion", "version": 1}`, expectedStatus: http.StatusOK, expectedCall: "update",
+		},
+		{
+			name: "change components", method: http.MethodPut, target: "/v1/products/CUSHION-01/components",
+			body:           `{"components": [{"fabric_code": "VELVET01", "quantity": "2"}], "version": 1}`,
+			expectedStatus: http.StatusOK, expectedCall: "change_components",
+		},
+		{
+			name: "delete", method: http.MethodDelete, target: "/v1/products/CUSHION-01",
+			body: `{"version": 1}`, expectedStatus: http.StatusNoContent, expectedCall: "delete",
+		},
+		{
+			name: "restore", method: http.MethodPost, target: "/v1/products/CUSHION-01/restore",
+			body: `{"version": 2}`, expectedStatus: http.StatusOK, expectedCall: "restore",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockProductCommandService{}
+			handler := NewProductCommandHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveProduct(t, handler, tc.method, tc.target, tc.body, product)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called)
+			assert.Equal(t, "CUSHION-01", svc.code)
+		})
+	}
+}
+
+func TestProductCommandHandler_Rejected(t *testing.T) {
+	product := map[string]string{"code": "CUSHION-01"}
+	testCases := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		params         map[string]string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{
+			name: "invalid code", method: http.MethodPost, target: "/v1/products",
+			body:           `{"code": "cushion 01", "name": "Velvet cushion"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "invalid quantity", method: http.MethodPost, target: "/v1/products",
+			body:           `{"code": "CUSHION-01", "name": "Velvet cushion", "components": [{"fabric_code": "VELVET01", "quantity": "1.2345"}]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing version", method: http.MethodPut, target: "/v1/products/CUSHION-01/components",
+			body: `{"components": []}`, params: product, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fabric twice", method: http.MethodPut, target: "/v1/products/CUSHION-01/components",
+			body: `{"components": [], "version": 1}`, params: product,
+			errToReturn: domain.ErrDuplicateComponent, expectedStatus: http.StatusUnprocessableEntity, expectedCall: true,
+		},
+		{
+			name: "unknown fabric", method: http.MethodPost, target: "/v1/products",
+			body:        `{"code": "CUSHION-01", "name": "Velvet cushion", "components": [{"fabric_code": "NOSUCH", "quantity": "1"}]}`,
+			errToReturn: domain.ErrFabricNotFound, expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+		{
+			name: "deleted product", method: http.MethodPut, target: "/v1/products/CUSHION-01",
+			body: `{"name": "Velvet cushion", "version": 2}`, params: product,
+			errToReturn: domain.ErrProductDeleted, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockProductCommandService{errToReturn: tc.errToReturn}
+			handler := NewProductCommandHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveProduct(t, handler, tc.method, tc.target, tc.body, tc.params)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called != "")
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/products/domain"
+)
+
+// ProductQueryRepository reads the products.
+type ProductQueryRepository interface {
+	GetProduct(ctx context.Context, code string) (*domain.Product, error)
+	ListProducts(ctx context.Context, limit, offset int) ([]*domain.Product, int, error)
+}
+
+// ProductQueryHandler serves the products. Deleted products are left out of the list but
+// can still be read by code, so they can be restored.
+type ProductQueryHandler struct {
+	products   ProductQueryRepository
+	pagination httpx.PaginationConfig
+}
+
+func NewProductQueryHandler(products ProductQueryRepository, pagination httpx.PaginationConfig) *ProductQueryHandler {
+	return &ProductQueryHandler{
+		products:   products,
+		pagination: pagination,
+	}
+}
+
+func (h *ProductQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	if httpx.URLParam(r, "code") == "" {
+		h.listProducts(w, r)
+		return
+	}
+	h.getProduct(w, r)
+}
+
+func (h *ProductQueryHandler) listProducts(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	products, totalRecords, err := h.products.ListProducts(r.Context(), page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"products": products, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *ProductQueryHandler) getProduct(w http.ResponseWriter, r *http.Request) {
+	product, err := h.products.GetProduct(r.Context(), validator.NormalizeCode(httpx.URLParam(r, "code")))
+	if err != nil {
+		writeProductError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"product": product}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
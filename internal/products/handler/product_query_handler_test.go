@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/products/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProductQueryRepository struct {
+	listedLimit  int
+	listedOffset int
+}
+
+func (m *mockProductQueryRepository) GetProduct(ctx context.Context, code string) (*domain.Product, error) {
+	if code != "CUSHION-01" {
+		return nil, domain.ErrProductNotFound
+	}
+	return &domain.Product{
+		Code: code, Name: "Velvet cushion", Version: 1,
+		Components: []domain.Component{{FabricCode: "VELVET01", Quantity: 1750}},
+	}, nil
+}
+
+func (m *mockProductQueryRepository) ListProducts(ctx context.Context, limit, offset int) ([]*domain.Product, int, error) {
+	m.listedLimit, m.listedOffset = limit, offset
+	return []*domain.Product{{Code: "CUSHION-01", Name: "Velvet cushion", Version: 1}}, 21, nil
+}
+
+func TestProductQueryHandler_GetProduct(t *testing.T) {
+	testCases := []struct {
+		name           string
+		code           string
+		expectedStatus int
+	}{
+		{name: "known product", code: "cushion-01", expectedStatus: http.StatusOK},
+		{name: "unknown product", code: "NOSUCH", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewProductQueryHandler(&mockProductQueryRepository{}, httpx.PaginationConfig{})
+
+			// --- Act ---
+			responseRecorder := serveProduct(
+				t, handler, http.MethodGet, "/v1/products/"+tc.code, "", map[string]string{"code": tc.code},
+			)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Product struct {
+					Code       string `json:"code"`
+					Components []struct {
+						FabricCode string `json:"fabric_code"`
+						Quantity   string `json:"quantity"`
+					} `json:"components"`
+				} `json:"product"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+			assert.Equal(t, "CUSHION-01", body.Product.Code)
+			require.Len(t, body.Product.Components, 1)
+			assert.Equal(t, "1.75", body.Product.Components[0].Quantity)
+		})
+	}
+}
+
+func TestProductQueryHandler_ListProducts(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockProductQueryRepository{}
+	pagination := httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+	handler := NewProductQueryHandler(repo, pagination)
+
+	// --- Act ---
+	responseRecorder := serveProduct(t, handler, http.MethodGet, "/v1/products?page=2", "", nil)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, 20, repo.listedLimit)
+	assert.Equal(t, 20, repo.listedOffset)
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+	"github.com/salesworks/s-works/api/internal/products/domain"
+)
+
+// InstrumentedProductRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedProductRepository struct {
+	next domain.ProductRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedProductRepository(
+	next domain.ProductRepository, rec *instrument.Recorder,
+) *InstrumentedProductRepository {
+	return &InstrumentedProductRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedProductRepository) SaveProduct(ctx context.Context, product *domain.Product) error {
+	return instrument.Exec(ctx, r.rec, "SaveProduct", func(ctx context.Context) error {
+		return r.next.SaveProduct(ctx, product)
+	})
+}
+
+func (r *InstrumentedProductRepository) GetProduct(ctx context.Context, code string) (*domain.Product, error) {
+	return instrument.Call(ctx, r.rec, "GetProduct", func(ctx context.Context) (*domain.Product, error) {
+		return r.next.GetProduct(ctx, code)
+	})
+}
+
+func (r *InstrumentedProductRepository) ListProducts(
+	ctx context.Context, limit, offset int,
+) ([]*domain.Product, int, error) {
+	var total int
+	products, err := instrument.Call(ctx, r.rec, "ListProducts",
+		func(ctx context.Context) ([]*domain.Product, error) {
+			products, count, err := r.next.ListProducts(ctx, limit, offset)
+			total = count
+			return products, err
+		})
+	return products, total, err
+}
+
+func (r *InstrumentedProductRepository) UpdateProduct(ctx context.Context, product *domain.Product) error {
+	return instrument.Exec(ctx, r.rec, "UpdateProduct", func(ctx context.Context) error {
+		return r.next.UpdateProduct(ctx, product)
+	})
+}
+
+func (r *InstrumentedProductRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	return instrument.Call(ctx, r.rec, "ResolveFabricCode", func(ctx context.Context) (string, error) {
+		return r.next.ResolveFabricCode(ctx, code)
+	})
+}
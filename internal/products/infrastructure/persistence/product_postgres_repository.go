@@ -0,0 +1,234 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/salesworks/s-works/api/internal/products/domain"
+)
+
+const productColumns = `code, name, description, version, created_at, created_by, updated_at, updated_by, deleted_at`
+
+// resolves a fabric code, or one of its aliases, to the canonical code
+const canonicalFabricCodeSQL = `COALESCE((SELECT canonical_code FROM fabric_aliases WHERE alias_code = $1), $1)`
+
+type ProductPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewProductPostgresRepository(db *database.PostgresDB) *ProductPostgresRepository {
+	return &ProductPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *ProductPostgresRepository) SaveProduct(ctx context.Context, product *domain.Product) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO products (`+productColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, product.Code, product.Name, product.Description, product.Version,
+		product.CreatedAt, product.CreatedBy, product.UpdatedAt, product.UpdatedBy, product.DeletedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrDuplicateProductCode
+		}
+		return fmt.Errorf("failed to insert product: %w", err)
+	}
+
+	if err := insertComponents(ctx, tx, product); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *ProductPostgresRepository) GetProduct(ctx context.Context, code string) (*domain.Product, error) {
+	query := `SELECT ` + productColumns + ` FROM products WHERE code = $1`
+	product, err := scanProduct(r.db.Conn(ctx).QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	if err := r.loadComponents(ctx, []*domain.Product{product}); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func (r *ProductPostgresRepository) ListProducts(
+	ctx context.Context, limit, offset int,
+) ([]*domain.Product, int, error) {
+	query := `
+		SELECT count(*) OVER(), ` + productColumns + `
+		FROM products
+		WHERE deleted_at IS NULL
+		ORDER BY code
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list products: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	products := []*domain.Product{}
+	for rows.Next() {
+		product := &domain.Product{}
+		if err := rows.Scan(append([]any{&totalRecords}, productFields(product)...)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products = append(products, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate products: %w", err)
+	}
+
+	if err := r.loadComponents(ctx, products); err != nil {
+		return nil, 0, err
+	}
+	return products, totalRecords, nil
+}
+
+// UpdateProduct stores the product with its bill of materials and deletion, provided
+// nobody changed it since it was loaded.
+func (r *ProductPostgresRepository) UpdateProduct(ctx context.Context, product *domain.Product) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE products
+		SET name = $1, description = $2, version = $3, updated_at = $4, updated_by = $5, deleted_at = $6
+		WHERE code = $7 AND version = $8
+	`, product.Name, product.Description, product.Version, product.UpdatedAt, product.UpdatedBy,
+		product.DeletedAt, product.Code, product.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update product: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_components WHERE product_code = $1`, product.Code); err != nil {
+		return fmt.Errorf("failed to remove product components: %w", err)
+	}
+	if err := insertComponents(ctx, tx, product); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *ProductPostgresRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	var canonicalCode string
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		`SELECT code FROM fabrics WHERE code = `+canonicalFabricCodeSQL+` AND status = 'ACTIVE'`, code,
+	).Scan(&canonicalCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrFabricNotFound
+		}
+		return "", fmt.Errorf("failed to resolve fabric code: %w", err)
+	}
+	return canonicalCode, nil
+}
+
+// loadComponents fills in the bills of materials of the products, in the order they were
+// given.
+func (r *ProductPostgresRepository) loadComponents(ctx context.Context, products []*domain.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	byCode := make(map[string]*domain.Product, len(products))
+	codes := make([]string, 0, len(products))
+	for _, product := range products {
+		product.Components = []domain.Component{}
+		byCode[product.Code] = product
+		codes = append(codes, product.Code)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT product_code, fabric_code, quantity
+		FROM product_components
+		WHERE product_code = ANY($1)
+		ORDER BY product_code, position
+	`, codes)
+	if err != nil {
+		return fmt.Errorf("failed to load product components: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			productCode string
+			component   domain.Component
+		)
+		if err := rows.Scan(&productCode, &component.FabricCode, &component.Quantity); err != nil {
+			return fmt.Errorf("failed to scan product component: %w", err)
+		}
+		if product, ok := byCode[productCode]; ok {
+			product.Components = append(product.Components, component)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate product components: %w", err)
+	}
+	return nil
+}
+
+func insertComponents(ctx context.Context, tx *database.Tx, product *domain.Product) error {
+	for position, component := range product.Components {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO product_components (product_code, position, fabric_code, quantity)
+			VALUES ($1, $2, $3, $4)
+		`, product.Code, position+1, component.FabricCode, component.Quantity)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				// the fabric was purged since it was resolved
+				return domain.ErrFabricNotFound
+			}
+			return fmt.Errorf("failed to insert product component: %w", err)
+		}
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProduct(row rowScanner) (*domain.Product, error) {
+	product := &domain.Product{}
+	if err := row.Scan(productFields(product)...); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// productFields lists the destinations of productColumns, in the same order.
+func productFields(product *domain.Product) []any {
+	return []any{
+		&product.Code, &product.Name, &product.Description, &product.Version,
+		&product.CreatedAt, &product.CreatedBy, &product.UpdatedAt, &product.UpdatedBy, &product.DeletedAt,
+	}
+}
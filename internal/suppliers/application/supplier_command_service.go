@@ -0,0 +1,263 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SupplierService maintains the suppliers and the fabrics they deliver.
+type SupplierService struct {
+	repo         domain.SupplierRepository
+	eventStore   eventstore.Store
+	clock        clock.Clock
+	eventChannel string
+	source       messaging.Source
+}
+
+func NewSupplierCommandService(
+	repo domain.SupplierRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+) *SupplierService {
+	return &SupplierService{
+		repo:         repo,
+		eventStore:   eventStore,
+		clock:        clock,
+		eventChannel: "app.supplier",
+		source:       source,
+	}
+}
+
+func (s *SupplierService) CreateSupplier(
+	ctx context.Context, code, name, contactEmail string,
+) (*domain.Supplier, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "supplier.service.create")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "supplier.service")
+
+	supplier := domain.NewSupplier(code, name, contactEmail, aggregate.StampNow(ctx, s.clock))
+	if err := s.repo.SaveSupplier(ctx, supplier); err != nil {
+		return nil, s.failed(span, logger, "saving supplier failed", err)
+	}
+
+	if err := s.publish(ctx, supplier); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+func (s *SupplierService) UpdateSupplier(
+	ctx context.Context, code, name, contactEmail string, version int,
+) (*domain.Supplier, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "supplier.service.update")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "supplier.service")
+
+	supplier, err := s.repo.GetSupplier(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := supplier.Update(name, contactEmail, version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateSupplier(ctx, supplier); err != nil {
+		return nil, s.failed(span, logger, "updating supplier failed", err)
+	}
+
+	if err := s.publish(ctx, supplier); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+func (s *SupplierService) DeleteSupplier(ctx context.Context, code string, version int) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "supplier.service.delete")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "supplier.service")
+
+	supplier, err := s.repo.GetSupplier(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if err := supplier.Delete(version, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteSupplier(ctx, supplier); err != nil {
+		return s.failed(span, logger, "deleting supplier failed", err)
+	}
+
+	return s.publish(ctx, supplier)
+}
+
+// SyncSupplier brings the supplier to the given name and contact at whatever version it is,
+// creating it when the ERP sends a supplier not known yet. A supplier already holding them
+// is left untouched and reported unchanged, so re-sent ERP events are harmless.
+func (s *SupplierService) SyncSupplier(
+	ctx context.Context, code, name, contactEmail string,
+) (*domain.Supplier, bool, error) {
+	current, err := s.repo.GetSupplier(ctx, code)
+	if errors.Is(err, domain.ErrSupplierNotFound) {
+		supplier, err := s.CreateSupplier(ctx, code, name, contactEmail)
+		return supplier, err == nil, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if current.Name == name && current.ContactEmail == contactEmail {
+		return current, false, nil
+	}
+
+	supplier, err := s.UpdateSupplier(ctx, code, name, contactEmail, current.Version)
+	return supplier, err == nil, err
+}
+
+// SyncSupplierDeletion deletes the supplier at whatever version it is, for deletions sent
+// by the ERP. A supplier that is unknown, as when it was deleted already, is reported
+// unchanged.
+func (s *SupplierService) SyncSupplierDeletion(ctx context.Context, code string) (bool, error) {
+	current, err := s.repo.GetSupplier(ctx, code)
+	if errors.Is(err, domain.ErrSupplierNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = s.DeleteSupplier(ctx, code, current.Version)
+	return err == nil, err
+}
+
+// LinkFabric links the fabric, given by its code or one of its aliases, to the supplier on
+// the given terms. Linking a fabric already linked replaces its terms.
+func (s *SupplierService) LinkFabric(
+	ctx context.Context, code, fabricCode string, leadTimeDays int, articleNumber string,
+) (*domain.Supplier, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "supplier.service.link_fabric")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "supplier.service")
+
+	supplier, fabricCode, err := s.getLink(ctx, code, fabricCode)
+	if err != nil {
+		return nil, err
+	}
+
+	link := domain.FabricLink{FabricCode: fabricCode, LeadTimeDays: leadTimeDays, ArticleNumber: articleNumber}
+	supplier.LinkFabric(link, aggregate.StampNow(ctx, s.clock))
+	if err := s.repo.LinkFabric(ctx, supplier, link); err != nil {
+		return nil, s.failed(span, logger, "linking fabric to supplier failed", err)
+	}
+
+	if err := s.publish(ctx, supplier); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+func (s *SupplierService) UnlinkFabric(ctx context.Context, code, fabricCode string) (*domain.Supplier, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "supplier.service.unlink_fabric")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "supplier.service")
+
+	supplier, fabricCode, err := s.getLink(ctx, code, fabricCode)
+	if err != nil {
+		return nil, err
+	}
+
+	supplier.UnlinkFabric(fabricCode, aggregate.StampNow(ctx, s.clock))
+	if err := s.repo.UnlinkFabric(ctx, supplier, fabricCode); err != nil {
+		return nil, s.failed(span, logger, "unlinking fabric from supplier failed", err)
+	}
+
+	if err := s.publish(ctx, supplier); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// getLink loads the supplier and resolves the fabric to its canonical code.
+func (s *SupplierService) getLink(
+	ctx context.Context, code, fabricCode string,
+) (*domain.Supplier, string, error) {
+	supplier, err := s.repo.GetSupplier(ctx, code)
+	if err != nil {
+		return nil, "", err
+	}
+	fabricCode, err = s.repo.ResolveFabricCode(ctx, fabricCode)
+	if err != nil {
+		return nil, "", err
+	}
+	return supplier, fabricCode, nil
+}
+
+// failed reports a repository write that did not succeed. Supplier errors are passed
+// through as they are, anything else is wrapped and recorded as a database error.
+func (s *SupplierService) failed(span trace.Span, logger *slog.Logger, msg string, err error) error {
+	var supplierErr *domain.SupplierError
+	if errors.As(err, &supplierErr) {
+		return err
+	}
+	wrappedErr := fmt.Errorf("failed to write supplier in repo: %w", err)
+	logger.Error(msg, "error", wrappedErr)
+	span.RecordError(wrappedErr)
+	span.SetStatus(codes.Error, "database write error")
+	return wrappedErr
+}
+
+func (s *SupplierService) publish(ctx context.Context, supplier *domain.Supplier) error {
+	logger := httpx.GetLogger(ctx).With("component", "supplier.service")
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range supplier.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.SupplierCreated:
+			eventType = "app.supplier.created"
+		case domain.SupplierUpdated:
+			eventType = "app.supplier.updated"
+		case domain.SupplierDeleted:
+			eventType = "app.supplier.deleted"
+		case domain.SupplierFabricLinked:
+			eventType = "app.supplier.fabric_linked"
+		case domain.SupplierFabricUnlinked:
+			eventType = "app.supplier.fabric_unlinked"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			supplier.Code,
+			"Supplier",
+			supplier.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopesToPublish...); err != nil {
+			wrappedErr := fmt.Errorf("failed to save supplier event to event store: %w", err)
+			logger.Error("saving supplier event failed", "error", wrappedErr)
+			return wrappedErr
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = domain.Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testSource = messaging.Source{Service: "s-works-api", Instance: "test-instance"}
+
+type mockSupplierRepository struct {
+	suppliers   map[string]*domain.Supplier
+	aliases     map[string]string
+	saved       *domain.Supplier
+	linked      *domain.FabricLink
+	errToReturn error
+}
+
+func (m *mockSupplierRepository) SaveSupplier(ctx context.Context, supplier *domain.Supplier) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.saved = supplier
+	return nil
+}
+
+func (m *mockSupplierRepository) GetSupplier(ctx context.Context, code string) (*domain.Supplier, error) {
+	supplier, ok := m.suppliers[code]
+	if !ok {
+		return nil, domain.ErrSupplierNotFound
+	}
+	supplierCopy := *supplier
+	return &supplierCopy, nil
+}
+
+func (m *mockSupplierRepository) ListSuppliers(ctx context.Context, limit, offset int) ([]*domain.Supplier, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockSupplierRepository) UpdateSupplier(ctx context.Context, supplier *domain.Supplier) error {
+	return m.SaveSupplier(ctx, supplier)
+}
+
+func (m *mockSupplierRepository) DeleteSupplier(ctx context.Context, supplier *domain.Supplier) error {
+	return m.SaveSupplier(ctx, supplier)
+}
+
+func (m *mockSupplierRepository) LinkFabric(ctx context.Context, supplier *domain.Supplier, link domain.FabricLink) error {
+	if m.errToReturn == nil {
+		m.linked = &link
+	}
+	return m.SaveSupplier(ctx, supplier)
+}
+
+func (m *mockSupplierRepository) UnlinkFabric(ctx context.Context, supplier *domain.Supplier, fabricCode string) error {
+	return m.SaveSupplier(ctx, supplier)
+}
+
+func (m *mockSupplierRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	if canonical, ok := m.aliases[code]; ok {
+		return canonical, nil
+	}
+	return "", domain.ErrFabricNotFound
+}
+
+func (m *mockSupplierRepository) ListSupplierFabrics(ctx context.Context, code string) ([]*domain.SupplierFabric, error) {
+	return nil, nil
+}
+
+func (m *mockSupplierRepository) ListFabricSuppliers(ctx context.Context, fabricCode string) ([]*domain.FabricSupplier, error) {
+	return nil, nil
+}
+
+type mockEventStore struct {
+	SavedCalled      bool
+	EnqueuedSubject  string
+	EnqueuedEnvelope *messaging.EventEnvelope
+}
+
+func (m *mockEventStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
+	m.SavedCalled = true
+	return nil
+}
+
+func (m *mockEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.SavedCalled = true
+	m.EnqueuedSubject = subject
+	m.EnqueuedEnvelope = envelopes[len(envelopes)-1]
+	return nil
+}
+
+func newTestRepository() *mockSupplierRepository {
+	supplier := &domain.Supplier{Code: "TEXTILIA", Name: "Textilia", Version: 1}
+	return &mockSupplierRepository{
+		suppliers: map[string]*domain.Supplier{supplier.Code: supplier},
+		aliases:   map[string]string{"VELVET01": "VELVET01", "OLDVELVET": "VELVET01"},
+	}
+}
+
+func TestSupplierService_CreateSupplier_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewSupplierCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	supplier, err := service.CreateSupplier(context.Background(), "WEAVERS", "Weavers Ltd", "sales@weavers.example")
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, repo.saved, "expected SaveSupplier() to be called on the repository")
+	assert.Equal(t, "sales@weavers.example", supplier.ContactEmail)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.supplier", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.supplier.created", publishedEnvelope.EventType)
+	assert.Equal(t, "Supplier", publishedEnvelope.AggregateType)
+	assert.Equal(t, "WEAVERS", publishedEnvelope.AggregateID)
+	assert.Equal(t, 1, publishedEnvelope.AggregateVersion)
+}
+
+func TestSupplierService_LinkFabric_ResolvesAlias(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewSupplierCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	supplier, err := service.LinkFabric(context.Background(), "TEXTILIA", "OLDVELVET", 21, "TX-4411")
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, repo.linked)
+	assert.Equal(t, domain.FabricLink{FabricCode: "VELVET01", LeadTimeDays: 21, ArticleNumber: "TX-4411"}, *repo.linked)
+	assert.Equal(t, 2, supplier.Version)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.supplier.fabric_linked", publishedEnvelope.EventType)
+	payload, ok := publishedEnvelope.Payload.(domain.SupplierFabricLinked)
+	require.True(t, ok, "payload should be of type domain.SupplierFabricLinked")
+	assert.Equal(t, "VELVET01", payload.FabricCode)
+	assert.Equal(t, 21, payload.LeadTimeDays)
+}
+
+func TestSupplierService_RejectedIsNotPublished(t *testing.T) {
+	testCases := []struct {
+		name        string
+		errToReturn error
+		run         func(s *SupplierService) error
+		expectedErr error
+	}{
+		{
+			name: "Stale version",
+			run: func(s *SupplierService) error {
+				_, err := s.UpdateSupplier(context.Background(), "TEXTILIA", "Textilia", "", 2)
+				return err
+			},
+			expectedErr: domain.ErrConcurrencyConflict,
+		},
+		{
+			name: "Unknown fabric",
+			run: func(s *SupplierService) error {
+				_, err := s.LinkFabric(context.Background(), "TEXTILIA", "NOSUCHFABRIC", 10, "")
+				return err
+			},
+			expectedErr: domain.ErrFabricNotFound,
+		},
+		{
+			name:        "Article number taken",
+			errToReturn: domain.ErrDuplicateArticleNumber,
+			run: func(s *SupplierService) error {
+				_, err := s.LinkFabric(context.Background(), "TEXTILIA", "VELVET01", 10, "TX-4411")
+				return err
+			},
+			expectedErr: domain.ErrDuplicateArticleNumber,
+		},
+		{
+			name:        "Fabric not linked",
+			errToReturn: domain.ErrFabricNotLinked,
+			run: func(s *SupplierService) error {
+				_, err := s.UnlinkFabric(context.Background(), "TEXTILIA", "VELVET01")
+				return err
+			},
+			expectedErr: domain.ErrFabricNotLinked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			repo.errToReturn = tc.errToReturn
+			eventStore := &mockEventStore{}
+			service := NewSupplierCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err := tc.run(service)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, repo.saved)
+			assert.False(t, eventStore.SavedCalled, "a rejected command must not be stored")
+		})
+	}
+}
+
+func TestSupplierService_SyncSupplier(t *testing.T) {
+	testCases := []struct {
+		name            string
+		code            string
+		supplierName    string
+		expectedChanged bool
+		expectedVersion int
+	}{
+		{name: "Unknown supplier is created", code: "WEAVERS", supplierName: "Weavers Ltd", expectedChanged: true, expectedVersion: 1},
+		{name: "Changed name is applied", code: "TEXTILIA", supplierName: "Textilia S.A.", expectedChanged: true, expectedVersion: 2},
+		{name: "Same data is left untouched", code: "TEXTILIA", supplierName: "Textilia", expectedVersion: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			eventStore := &mockEventStore{}
+			service := NewSupplierCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			supplier, changed, err := service.SyncSupplier(context.Background(), tc.code, tc.supplierName, "")
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChanged, changed)
+			assert.Equal(t, tc.expectedVersion, supplier.Version)
+			assert.Equal(t, tc.expectedChanged, eventStore.SavedCalled)
+		})
+	}
+}
+
+func TestSupplierService_SyncSupplierDeletion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		code            string
+		expectedChanged bool
+	}{
+		{name: "Known supplier is deleted", code: "TEXTILIA", expectedChanged: true},
+		{name: "Unknown supplier is ignored", code: "NOSUCH"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			eventStore := &mockEventStore{}
+			service := NewSupplierCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			changed, err := service.SyncSupplierDeletion(context.Background(), tc.code)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChanged, changed)
+			assert.Equal(t, tc.expectedChanged, eventStore.SavedCalled)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+var (
+	ErrSupplierNotFound       = notFoundError("supplier not found")
+	ErrFabricNotFound         = notFoundError("fabric not found")
+	ErrDuplicateSupplierCode  = conflictError("a supplier with this code already exists")
+	ErrDuplicateArticleNumber = conflictError("the supplier already offers another fabric under this article number")
+	ErrConcurrencyConflict    = conflictError("the supplier has been modified by another process, please refresh and try again")
+	ErrFabricNotLinked        = conflictError("the fabric is not linked to this supplier")
+)
+
+// SupplierError is a rule violation reported by the supplier domain. Its kind tells the
+// handler which status to answer with and instrumentation how to class it.
+type SupplierError struct {
+	Kind    string
+	Message string
+}
+
+func (e *SupplierError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *SupplierError) ErrorClass() string {
+	return e.Kind
+}
+
+func notFoundError(message string) *SupplierError {
+	return &SupplierError{Kind: "not_found", Message: message}
+}
+
+func conflictError(message string) *SupplierError {
+	return &SupplierError{Kind: "conflict", Message: message}
+}
+
+type Event = aggregate.Event
+
+// Stamp identifies who performed a change on a supplier and when it happened.
+type Stamp = aggregate.Stamp
+
+// Supplier delivers fabrics. A fabric can be linked to any number of suppliers, each
+// with its own lead time and article number.
+type Supplier struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	ContactEmail string `json:"contact_email,omitempty"`
+	Version      int    `json:"version"`
+	aggregate.Audit
+	aggregate.Root
+}
+
+// FabricLink holds the terms under which a supplier delivers a fabric.
+type FabricLink struct {
+	// FabricCode is the canonical code of the fabric.
+	FabricCode    string
+	LeadTimeDays  int
+	ArticleNumber string
+}
+
+// SupplierFabric is a fabric linked to a supplier, as listed for the supplier.
+type SupplierFabric struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	LeadTimeDays  int    `json:"lead_time_days"`
+	ArticleNumber string `json:"article_number,omitempty"`
+}
+
+// FabricSupplier summarises a supplier of a fabric, as embedded in the fabric.
+type FabricSupplier struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	LeadTimeDays  int    `json:"lead_time_days"`
+	ArticleNumber string `json:"article_number,omitempty"`
+	// ModifiedAt is when the link or the supplier last changed, telling readers of the
+	// fabric when its list of suppliers did.
+	ModifiedAt time.Time `json:"-"`
+}
+
+type SupplierCreated struct {
+	Code         string
+	Name         string
+	ContactEmail string
+	Version      int
+}
+
+type SupplierUpdated struct {
+	Code         string
+	Name         string
+	ContactEmail string
+	Version      int
+}
+
+type SupplierDeleted struct {
+	Code    string
+	Version int
+}
+
+// SupplierFabricLinked is recorded when a fabric is linked to a supplier, and again when
+// the terms of an existing link change.
+type SupplierFabricLinked struct {
+	Code          string
+	FabricCode    string
+	LeadTimeDays  int
+	ArticleNumber string
+	Version       int
+}
+
+type SupplierFabricUnlinked struct {
+	Code       string
+	FabricCode string
+	Version    int
+}
+
+func NewSupplier(code, name, contactEmail string, stamp Stamp) *Supplier {
+	supplier := &Supplier{
+		Code:         code,
+		Name:         name,
+		ContactEmail: contactEmail,
+		Version:      1,
+		Audit:        aggregate.NewAudit(stamp),
+	}
+
+	event := SupplierCreated{
+		Code:         supplier.Code,
+		Name:         supplier.Name,
+		ContactEmail: supplier.ContactEmail,
+		Version:      supplier.Version,
+	}
+	supplier.Record(event)
+	return supplier
+}
+
+func (s *Supplier) Update(name, contactEmail string, version int, stamp Stamp) error {
+	if err := aggregate.CheckVersion(s.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	s.Name = name
+	s.ContactEmail = contactEmail
+	s.Version++
+	s.Touch(stamp)
+
+	event := SupplierUpdated{
+		Code:         s.Code,
+		Name:         s.Name,
+		ContactEmail: s.ContactEmail,
+		Version:      s.Version,
+	}
+	s.Record(event)
+	return nil
+}
+
+// Delete removes the supplier together with its fabric links.
+func (s *Supplier) Delete(version int, stamp Stamp) error {
+	if err := aggregate.CheckVersion(s.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	s.Version++
+	s.Touch(stamp)
+
+	event := SupplierDeleted{
+		Code:    s.Code,
+		Version: s.Version,
+	}
+	s.Record(event)
+	return nil
+}
+
+// LinkFabric records that the supplier delivers the fabric on the given terms, replacing
+// the terms of an existing link.
+func (s *Supplier) LinkFabric(link FabricLink, stamp Stamp) {
+	s.Version++
+	s.Touch(stamp)
+
+	event := SupplierFabricLinked{
+		Code:          s.Code,
+		FabricCode:    link.FabricCode,
+		LeadTimeDays:  link.LeadTimeDays,
+		ArticleNumber: link.ArticleNumber,
+		Version:       s.Version,
+	}
+	s.Record(event)
+}
+
+func (s *Supplier) UnlinkFabric(fabricCode string, stamp Stamp) {
+	s.Version++
+	s.Touch(stamp)
+
+	event := SupplierFabricUnlinked{
+		Code:       s.Code,
+		FabricCode: fabricCode,
+		Version:    s.Version,
+	}
+	s.Record(event)
+}
+
+type SupplierRepository interface {
+	// SaveSupplier stores a new supplier, failing with ErrDuplicateSupplierCode when the
+	// code is taken.
+	SaveSupplier(ctx context.Context, supplier *Supplier) error
+	GetSupplier(ctx context.Context, code string) (*Supplier, error)
+	// ListSuppliers returns a page of the suppliers by code, together with their total number.
+	ListSuppliers(ctx context.Context, limit, offset int) ([]*Supplier, int, error)
+	// UpdateSupplier, DeleteSupplier, LinkFabric and UnlinkFabric store a change of a
+	// supplier still at the version it was loaded with, or fail with ErrConcurrencyConflict.
+	UpdateSupplier(ctx context.Context, supplier *Supplier) error
+	DeleteSupplier(ctx context.Context, supplier *Supplier) error
+	LinkFabric(ctx context.Context, supplier *Supplier, link FabricLink) error
+	UnlinkFabric(ctx context.Context, supplier *Supplier, fabricCode string) error
+	// ResolveFabricCode returns the canonical code of an active fabric given by its code or
+	// one of its aliases.
+	ResolveFabricCode(ctx context.Context, code string) (string, error)
+	ListSupplierFabrics(ctx context.Context, code string) ([]*SupplierFabric, error)
+	ListFabricSuppliers(ctx context.Context, fabricCode string) ([]*FabricSupplier, error)
+}
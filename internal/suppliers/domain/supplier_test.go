@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func TestSupplier_Update(t *testing.T) {
+	testCases := []struct {
+		name        string
+		version     int
+		expectedErr error
+	}{
+		{name: "Current version", version: 1},
+		{name: "Stale version", version: 2, expectedErr: ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			supplier := NewSupplier("TEXTILIA", "Textilia", "", testStamp)
+
+			// --- Act ---
+			err := supplier.Update("Textilia Mills", "orders@textilia.example", tc.version, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Len(t, supplier.Events(), 1, "a rejected update must not record an event")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 2, supplier.Version)
+			updated, ok := supplier.Events()[1].(SupplierUpdated)
+			require.True(t, ok, "expected a SupplierUpdated event")
+			assert.Equal(t, "orders@textilia.example", updated.ContactEmail)
+		})
+	}
+}
+
+func TestSupplier_LinkFabric(t *testing.T) {
+	// --- Arrange ---
+	supplier := NewSupplier("TEXTILIA", "Textilia", "", testStamp)
+	link := FabricLink{FabricCode: "VELVET01", LeadTimeDays: 21, ArticleNumber: "TX-4411"}
+
+	// --- Act ---
+	supplier.LinkFabric(link, testStamp)
+	supplier.UnlinkFabric("VELVET01", testStamp)
+
+	// --- Assert ---
+	assert.Equal(t, 3, supplier.Version)
+	require.Len(t, supplier.Events(), 3)
+	linked, ok := supplier.Events()[1].(SupplierFabricLinked)
+	require.True(t, ok, "expected a SupplierFabricLinked event")
+	assert.Equal(t, SupplierFabricLinked{
+		Code: "TEXTILIA", FabricCode: "VELVET01", LeadTimeDays: 21, ArticleNumber: "TX-4411", Version: 2,
+	}, linked)
+	unlinked, ok := supplier.Events()[2].(SupplierFabricUnlinked)
+	require.True(t, ok, "expected a SupplierFabricUnlinked event")
+	assert.Equal(t, 3, unlinked.Version)
+}
+
+func TestNewSupplierToken(t *testing.T) {
+	// --- Act ---
+	token, secret, err := NewSupplierToken("TEXTILIA", []string{ScopeFabricsRead}, 24*time.Hour, testStamp)
+	_, otherSecret, otherErr := NewSupplierToken("TEXTILIA", []string{ScopeFabricsRead}, 24*time.Hour, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NoError(t, otherErr)
+	assert.True(t, strings.HasPrefix(secret, supplierTokenPrefix))
+	assert.NotEqual(t, secret, otherSecret, "every token gets its own secret")
+	assert.Equal(t, HashSupplierToken(secret), token.Hash)
+	assert.NotContains(t, token.Hash, secret, "only a hash of the secret is kept")
+	assert.Equal(t, testStamp.At.Add(24*time.Hour), token.ExpiresAt)
+	assert.Equal(t, "user_test", token.CreatedBy)
+}
+
+func TestSupplierToken_Active(t *testing.T) {
+	// --- Arrange ---
+	token, _, err := NewSupplierToken("TEXTILIA", []string{ScopeFabricsRead}, time.Hour, testStamp)
+	require.NoError(t, err)
+	revoked, _, err := NewSupplierToken("TEXTILIA", []string{ScopeFabricsRead}, time.Hour, testStamp)
+	require.NoError(t, err)
+
+	// --- Act ---
+	revokeErr := revoked.Revoke(testStamp)
+	secondRevokeErr := revoked.Revoke(testStamp)
+
+	// --- Assert ---
+	assert.True(t, token.Active(testStamp.At.Add(59*time.Minute)))
+	assert.False(t, token.Active(testStamp.At.Add(time.Hour)), "a token expires at the end of its term")
+	require.NoError(t, revokeErr)
+	assert.False(t, revoked.Active(testStamp.At))
+	assert.Equal(t, "user_test", revoked.RevokedBy)
+	assert.ErrorIs(t, secondRevokeErr, ErrSupplierTokenRevoked)
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// The scopes a supplier portal token can be issued with.
+const (
+	ScopeFabricsRead    = "fabrics:read"
+	ScopeFabricsPropose = "fabrics:propose"
+)
+
+// supplierTokenPrefix marks the secret of a supplier portal token, so that a leaked one is
+// recognized for what it is
+const supplierTokenPrefix = "spt_"
+
+var (
+	ErrSupplierTokenNotFound = notFoundError("supplier token not found")
+	ErrSupplierTokenRevoked  = conflictError("the supplier token has already been revoked")
+)
+
+// SupplierScopes lists the scopes a supplier portal token can be issued with.
+func SupplierScopes() []string {
+	return []string{ScopeFabricsRead, ScopeFabricsPropose}
+}
+
+// SupplierToken lets a supplier read and propose changes to the fabrics linked to it
+// through the portal, until it expires or is revoked. Only a hash of its secret is kept,
+// the secret itself is shown once when the token is issued.
+type SupplierToken struct {
+	ID           int64      `json:"id"`
+	SupplierCode string     `json:"supplier_code"`
+	Scopes       []string   `json:"scopes"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CreatedBy    string     `json:"created_by"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy    string     `json:"revoked_by,omitempty"`
+	Hash         string     `json:"-"`
+}
+
+// NewSupplierToken issues a token for the supplier, valid for the given time. It returns
+// the token together with its secret.
+func NewSupplierToken(
+	supplierCode string, scopes []string, validFor time.Duration, stamp Stamp,
+) (*SupplierToken, string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", fmt.Errorf("failed to generate supplier token: %w", err)
+	}
+	secret := supplierTokenPrefix + hex.EncodeToString(random)
+
+	token := &SupplierToken{
+		SupplierCode: supplierCode,
+		Scopes:       scopes,
+		ExpiresAt:    stamp.At.Add(validFor),
+		CreatedAt:    stamp.At,
+		CreatedBy:    stamp.By,
+		Hash:         HashSupplierToken(secret),
+	}
+	return token, secret, nil
+}
+
+// HashSupplierToken returns the hash a token is looked up by from its secret.
+func HashSupplierToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Active reports whether the token still grants access at the given time.
+func (t *SupplierToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// Revoke withdraws the token before it expires.
+func (t *SupplierToken) Revoke(stamp Stamp) error {
+	if t.RevokedAt != nil {
+		return ErrSupplierTokenRevoked
+	}
+	at := stamp.At
+	t.RevokedAt = &at
+	t.RevokedBy = stamp.By
+	return nil
+}
+
+type SupplierTokenRepository interface {
+	SaveToken(ctx context.Context, token *SupplierToken) error
+	// GetToken returns a token of the supplier, failing with ErrSupplierTokenNotFound when
+	// the supplier has no token with the ID.
+	GetToken(ctx context.Context, supplierCode string, id int64) (*SupplierToken, error)
+	// FindTokenByHash returns the token with the hash, revoked and expired ones included.
+	FindTokenByHash(ctx context.Context, hash string) (*SupplierToken, error)
+	// ListTokens returns the tokens of the supplier, the latest issued first.
+	ListTokens(ctx context.Context, supplierCode string) ([]*SupplierToken, error)
+	RevokeToken(ctx context.Context, token *SupplierToken) error
+}
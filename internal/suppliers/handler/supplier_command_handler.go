@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+)
+
+var supplierCodeRX = regexp.MustCompile("^[A-Z0-9-]+$")
+
+// maxLeadTimeDays caps the lead time a supplier can be linked with.
+const maxLeadTimeDays = 365
+
+// SupplierCommandService maintains the suppliers and the fabrics they deliver.
+type SupplierCommandService interface {
+	CreateSupplier(ctx context.Context, code, name, contactEmail string) (*domain.Supplier, error)
+	UpdateSupplier(ctx context.Context, code, name, contactEmail string, version int) (*domain.Supplier, error)
+	DeleteSupplier(ctx context.Context, code string, version int) error
+	LinkFabric(
+		ctx context.Context, code, fabricCode string, leadTimeDays int, articleNumber string,
+	) (*domain.Supplier, error)
+	UnlinkFabric(ctx context.Context, code, fabricCode string) (*domain.Supplier, error)
+}
+
+// SupplierCommandHandler creates, updates and deletes suppliers and links fabrics to them.
+type SupplierCommandHandler struct {
+	service SupplierCommandService
+}
+
+type createSupplierRequest struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	ContactEmail string `json:"contact_email"`
+}
+
+type updateSupplierRequest struct {
+	Name         string `json:"name"`
+	ContactEmail string `json:"contact_email"`
+	Version      int    `json:"version"`
+}
+
+type deleteSupplierRequest struct {
+	Version int `json:"version"`
+}
+
+// the lead time is a pointer so that a missing one is told apart from same-day delivery
+type linkFabricRequest struct {
+	LeadTimeDays  *int   `json:"lead_time_days"`
+	ArticleNumber string `json:"article_number"`
+}
+
+func NewSupplierCommandHandler(service SupplierCommandService) *SupplierCommandHandler {
+	return &SupplierCommandHandler{service: service}
+}
+
+func (h *SupplierCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+	r = r.WithContext(ctx)
+
+	if httpx.URLParam(r, "fabricCode") != "" {
+		switch r.Method {
+		case http.MethodPut:
+			h.linkFabric(w, r)
+		case http.MethodDelete:
+			h.unlinkFabric(w, r)
+		default:
+			httpx.MethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		h.createSupplier(w, r)
+	case http.MethodPut:
+		h.updateSupplier(w, r)
+	case http.MethodDelete:
+		h.deleteSupplier(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *SupplierCommandHandler) createSupplier(w http.ResponseWriter, r *http.Request) {
+	var req createSupplierRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Code = validator.NormalizeCode(req.Code)
+	req.Name = validator.NormalizeText(req.Name)
+	req.ContactEmail = strings.TrimSpace(req.ContactEmail)
+	v := validator.New()
+	v.Check(req.Code != "", "code", "code must be provided")
+	v.Check(len(req.Code) >= 2 && len(req.Code) <= 30, "code", "code must be between 2 and 30 characters long")
+	v.Check(validator.Matches(req.Code, supplierCodeRX), "code", "code must only contain uppercase letters, numbers and dashes")
+	validateSupplier(v, req.Name, req.ContactEmail)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	supplier, err := h.service.CreateSupplier(r.Context(), req.Code, req.Name, req.ContactEmail)
+	if err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", "/v1/suppliers/"+supplier.Code)
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"supplier": supplier}, headers); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *SupplierCommandHandler) updateSupplier(w http.ResponseWriter, r *http.Request) {
+	var req updateSupplierRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Name = validator.NormalizeText(req.Name)
+	req.ContactEmail = strings.TrimSpace(req.ContactEmail)
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	validateSupplier(v, req.Name, req.ContactEmail)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	supplier, err := h.service.UpdateSupplier(
+		r.Context(), httpx.URLParam(r, "code"), req.Name, req.ContactEmail, req.Version,
+	)
+	if err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"supplier": supplier}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *SupplierCommandHandler) deleteSupplier(w http.ResponseWriter, r *http.Request) {
+	var req deleteSupplierRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	if err := h.service.DeleteSupplier(r.Context(), httpx.URLParam(r, "code"), req.Version); err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SupplierCommandHandler) linkFabric(w http.ResponseWriter, r *http.Request) {
+	var req linkFabricRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.ArticleNumber = strings.TrimSpace(req.ArticleNumber)
+	v := validator.New()
+	v.Check(req.LeadTimeDays != nil, "lead_time_days", "lead_time_days must be provided")
+	if req.LeadTimeDays != nil {
+		v.Check(*req.LeadTimeDays >= 0 && *req.LeadTimeDays <= maxLeadTimeDays,
+			"lead_time_days", "lead_time_days must be between 0 and 365")
+	}
+	v.Check(len(req.ArticleNumber) <= 64, "article_number", "article_number must not be more than 64 characters long")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	supplier, err := h.service.LinkFabric(
+		r.Context(),
+		httpx.URLParam(r, "code"),
+		validator.NormalizeCode(httpx.URLParam(r, "fabricCode")),
+		*req.LeadTimeDays,
+		req.ArticleNumber,
+	)
+	if err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"supplier": supplier}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *SupplierCommandHandler) unlinkFabric(w http.ResponseWriter, r *http.Request) {
+	supplier, err := h.service.UnlinkFabric(
+		r.Context(), httpx.URLParam(r, "code"), validator.NormalizeCode(httpx.URLParam(r, "fabricCode")),
+	)
+	if err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"supplier": supplier}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func validateSupplier(v *validator.Validator, name, contactEmail string) {
+	v.Check(name != "", "name", "name must be provided")
+	v.Check(len(name) <= 250, "name", "name must not be more than 250 characters long")
+	if contactEmail != "" {
+		v.Check(validator.Matches(contactEmail, validator.EmailRX), "contact_email", "contact_email must be a valid email address")
+	}
+}
+
+// writeSupplierError answers a failed command with the status matching the kind of
+// supplier error, anything that is not a supplier error is answered as an internal error.
+func writeSupplierError(w http.ResponseWriter, r *http.Request, err error) {
+	var supplierErr *domain.SupplierError
+	if !errors.As(err, &supplierErr) {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch supplierErr.Kind {
+	case "not_found":
+		httpx.NotFound(w, r)
+	default:
+		httpx.ErrorJSON(w, http.StatusConflict, supplierErr.Message)
+	}
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSupplierCommandService struct {
+	called        string
+	code          string
+	contactEmail  string
+	fabricCode    string
+	leadTimeDays  int
+	articleNumber string
+	version       int
+	errToReturn   error
+}
+
+func (m *mockSupplierCommandService) CreateSupplier(
+	ctx context.Context, code, name, contactEmail string,
+) (*domain.Supplier, error) {
+	m.called, m.code, m.contactEmail = "create", code, contactEmail
+	return m.result(code, 1)
+}
+
+func (m *mockSupplierCommandService) UpdateSupplier(
+	ctx context.Context, code, name, contactEmail string, version int,
+) (*domain.Supplier, error) {
+	m.called, m.code, m.contactEmail, m.version = "update", code, contactEmail, version
+	return m.result(code, version+1)
+}
+
+func (m *mockSupplierCommandService) DeleteSupplier(ctx context.Context, code string, version int) error {
+	m.called, m.code, m.version = "delete", code, version
+	return m.errToReturn
+}
+
+func (m *mockSupplierCommandService) LinkFabric(
+	ctx context.Context, code, fabricCode string, leadTimeDays int, articleNumber string,
+) (*domain.Supplier, error) {
+	m.called, m.code, m.fabricCode = "link", code, fabricCode
+	m.leadTimeDays, m.articleNumber = leadTimeDays, articleNumber
+	return m.result(code, 2)
+}
+
+func (m *mockSupplierCommandService) UnlinkFabric(ctx context.Context, code, fabricCode string) (*domain.Supplier, error) {
+	m.called, m.code, m.fabricCode = "unlink", code, fabricCode
+	return m.result(code, 2)
+}
+
+func (m *mockSupplierCommandService) result(code string, version int) (*domain.Supplier, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Supplier{Code: code, Version: version}, nil
+}
+
+func serveSupplier(
+	t *testing.T, handler http.Handler, method, target, body string, params map[string]string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestSupplierCommandHandler_CreateSupplier(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockSupplierCommandService{}
+	handler := NewSupplierCommandHandler(svc)
+	body := `{"code": " textilia ", "name": "Textilia", "contact_email": " orders@textilia.example "}`
+
+	// --- Act ---
+	responseRecorder := serveSupplier(t, handler, http.MethodPost, "/v1/suppliers", body, nil)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, "/v1/suppliers/TEXTILIA", responseRecorder.Header().Get("Location"))
+	assert.Equal(t, "TEXTILIA", svc.code)
+	assert.Equal(t, "orders@textilia.example", svc.contactEmail)
+}
+
+func TestSupplierCommandHandler_LinkFabric(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockSupplierCommandService{}
+	handler := NewSupplierCommandHandler(svc)
+	params := map[string]string{"code": "TEXTILIA", "fabricCode": "velvet01"}
+	body := `{"lead_time_days": 0, "article_number": " TX-4411 "}`
+
+	// --- Act ---
+	responseRecorder := serveSupplier(
+		t, handler, http.MethodPut, "/v1/suppliers/TEXTILIA/fabrics/velvet01", body, params,
+	)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "link", svc.called)
+	assert.Equal(t, "VELVET01", svc.fabricCode)
+	assert.Equal(t, 0, svc.leadTimeDays)
+	assert.Equal(t, "TX-4411", svc.articleNumber)
+}
+
+func TestSupplierCommandHandler_Rejected(t *testing.T) {
+	link := map[string]string{"code": "TEXTILIA", "fabricCode": "VELVET01"}
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		params         map[string]string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{
+			name: "invalid code", method: http.MethodPost, body: `{"code": "textilia!", "name": "Textilia"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "invalid email", method: http.MethodPost, body: `{"code": "TEXTILIA", "name": "Textilia", "contact_email": "orders"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing version", method: http.MethodPut, body: `{"name": "Textilia"}`,
+			params: map[string]string{"code": "TEXTILIA"}, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing lead time", method: http.MethodPut, body: `{"article_number": "TX-4411"}`,
+			params: link, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "lead time out of range", method: http.MethodPut, body: `{"lead_time_days": 400}`,
+			params: link, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "duplicate code", method: http.MethodPost, body: `{"code": "TEXTILIA", "name": "Textilia"}`,
+			errToReturn: domain.ErrDuplicateSupplierCode, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "unknown fabric", method: http.MethodPut, body: `{"lead_time_days": 14}`,
+			params:      link,
+			errToReturn: domain.ErrFabricNotFound, expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+		{
+			name: "article number taken", method: http.MethodPut, body: `{"lead_time_days": 14, "article_number": "TX-4411"}`,
+			params:      link,
+			errToReturn: domain.ErrDuplicateArticleNumber, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "fabric not linked", method: http.MethodDelete,
+			params:      link,
+			errToReturn: domain.ErrFabricNotLinked, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockSupplierCommandService{errToReturn: tc.errToReturn}
+			handler := NewSupplierCommandHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveSupplier(t, handler, tc.method, "/v1/suppliers", tc.body, tc.params)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called != "")
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+)
+
+const (
+	erpSupplierCreated = "erp.supplier.created"
+	erpSupplierUpdated = "erp.supplier.updated"
+	erpSupplierDeleted = "erp.supplier.deleted"
+)
+
+// SupplierSyncService mirrors the suppliers of the ERP.
+type SupplierSyncService interface {
+	SyncSupplier(ctx context.Context, code, name, contactEmail string) (*domain.Supplier, bool, error)
+	SyncSupplierDeletion(ctx context.Context, code string) (bool, error)
+}
+
+// SupplierEventHandler mirrors the suppliers the ERP publishes. The ERP owns the supplier
+// master data, so its events are applied at whatever version the supplier is at; the
+// fabric links are maintained through the API only.
+// It implements the subscriber.MessageHandler interface.
+type SupplierEventHandler struct {
+	service SupplierSyncService
+	logger  *slog.Logger
+}
+
+type erpSupplierEvent struct {
+	Code         string `json:"supplier_code"`
+	Name         string `json:"supplier_name"`
+	ContactEmail string `json:"contact_email,omitempty"`
+}
+
+func NewSupplierEventHandler(service SupplierSyncService, logger *slog.Logger) *SupplierEventHandler {
+	return &SupplierEventHandler{
+		service: service,
+		logger:  logger.With("component", "erpSupplierEventHandler"),
+	}
+}
+
+// HandleMessage is the entry point called by the NatsSubscriber. Malformed and invalid
+// events are logged and dropped, infrastructure errors are returned to be retried.
+func (h *SupplierEventHandler) HandleMessage(ctx context.Context, subject string, payload []byte) error {
+	var envelope messaging.EventEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		h.logger.Error("Failed to unmarshal event envelope", "error", err, "subject", subject)
+		return nil
+	}
+	if err := envelope.Validate(); err != nil {
+		h.logger.Error("Invalid event envelope", "error", err, "subject", subject)
+		return nil
+	}
+
+	event, err := decodeERPSupplierEvent(envelope)
+	if err != nil {
+		h.logger.Error("Failed to decode ERP event payload", "error", err, "event_id", envelope.EventID)
+		return nil
+	}
+
+	ctx = command.WithCommandSource(ctx, command.CommandSourceEvent)
+	switch envelope.EventType {
+	case erpSupplierCreated, erpSupplierUpdated:
+		return h.handleSync(ctx, event, envelope.EventID)
+	case erpSupplierDeleted:
+		return h.handleDelete(ctx, event, envelope.EventID)
+	default:
+		h.logger.Warn("Received unknown ERP event, discarding", "type", envelope.EventType)
+		return nil
+	}
+}
+
+// extracts the ERP supplier payload from an envelope
+func decodeERPSupplierEvent(envelope messaging.EventEnvelope) (erpSupplierEvent, error) {
+	var event erpSupplierEvent
+
+	payloadBytes, err := json.Marshal(envelope.Payload)
+	if err != nil {
+		return event, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	if err := json.Unmarshal(payloadBytes, &event); err != nil {
+		return event, fmt.Errorf("failed to unmarshal payload to erpSupplierEvent: %w", err)
+	}
+	event.Code = validator.NormalizeCode(event.Code)
+	event.Name = validator.NormalizeText(event.Name)
+	event.ContactEmail = strings.TrimSpace(event.ContactEmail)
+	return event, nil
+}
+
+func (h *SupplierEventHandler) handleSync(ctx context.Context, event erpSupplierEvent, eventID string) error {
+	v := validator.New()
+	v.Check(event.Code != "", "supplier_code", "supplier_code must be provided")
+	v.Check(len(event.Code) <= 30, "supplier_code", "supplier_code must not be more than 30 characters long")
+	v.Check(validator.Matches(event.Code, supplierCodeRX), "supplier_code", "supplier_code must only contain uppercase letters, numbers and dashes")
+	validateSupplier(v, event.Name, event.ContactEmail)
+	if !v.Valid() {
+		h.logger.Error("Invalid supplier data from ERP event", "errors", v.Errors, "code", event.Code, "event_id", eventID)
+		return nil // Don't retry validation errors
+	}
+
+	_, changed, err := h.service.SyncSupplier(ctx, event.Code, event.Name, event.ContactEmail)
+	if err != nil {
+		if isRejection(err) {
+			h.logger.Error("ERP supplier change rejected", "error", err, "code", event.Code, "event_id", eventID)
+			return nil
+		}
+		h.logger.Error("Failed to sync supplier", "error", err, "code", event.Code, "event_id", eventID)
+		return err // Retry infrastructure errors and lost races
+	}
+
+	h.logger.Info("Supplier synced from event", "code", event.Code, "changed", changed, "event_id", eventID)
+	return nil
+}
+
+func (h *SupplierEventHandler) handleDelete(ctx context.Context, event erpSupplierEvent, eventID string) error {
+	if event.Code == "" {
+		h.logger.Error("ERP supplier deletion without a code", "event_id", eventID)
+		return nil
+	}
+
+	changed, err := h.service.SyncSupplierDeletion(ctx, event.Code)
+	if err != nil {
+		if isRejection(err) {
+			h.logger.Error("ERP supplier deletion rejected", "error", err, "code", event.Code, "event_id", eventID)
+			return nil
+		}
+		h.logger.Error("Failed to delete supplier", "error", err, "code", event.Code, "event_id", eventID)
+		return err // Retry infrastructure errors and lost races
+	}
+
+	h.logger.Info("Supplier deleted from event", "code", event.Code, "changed", changed, "event_id", eventID)
+	return nil
+}
+
+// isRejection reports whether the supplier refused the change for good. A concurrency
+// conflict is not, the event is retried against the version that won the race.
+func isRejection(err error) bool {
+	var supplierErr *domain.SupplierError
+	return errors.As(err, &supplierErr) && !errors.Is(err, domain.ErrConcurrencyConflict)
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSupplierSyncService struct {
+	called       string
+	code         string
+	name         string
+	contactEmail string
+	errToReturn  error
+}
+
+func (m *mockSupplierSyncService) SyncSupplier(
+	ctx context.Context, code, name, contactEmail string,
+) (*domain.Supplier, bool, error) {
+	m.called, m.code, m.name, m.contactEmail = "sync", code, name, contactEmail
+	if m.errToReturn != nil {
+		return nil, false, m.errToReturn
+	}
+	return &domain.Supplier{Code: code, Version: 1}, true, nil
+}
+
+func (m *mockSupplierSyncService) SyncSupplierDeletion(ctx context.Context, code string) (bool, error) {
+	m.called, m.code = "delete", code
+	return m.errToReturn == nil, m.errToReturn
+}
+
+func erpSupplierMessage(t *testing.T, eventType string, data map[string]string) []byte {
+	t.Helper()
+
+	envelope := messaging.NewEventEnvelope(eventType, "TEXTILIA", "Supplier", 1, data)
+	payload, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestSupplierEventHandler_HandleMessage(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+	valid := map[string]string{
+		"supplier_code": " textilia ", "supplier_name": " Textilia  S.A. ", "contact_email": " sales@textilia.example ",
+	}
+
+	testCases := []struct {
+		name         string
+		eventType    string
+		data         map[string]string
+		errToReturn  error
+		expectedCall string
+		expectedErr  error
+	}{
+		{name: "created", eventType: erpSupplierCreated, data: valid, expectedCall: "sync"},
+		{name: "updated", eventType: erpSupplierUpdated, data: valid, expectedCall: "sync"},
+		{name: "deleted", eventType: erpSupplierDeleted, data: map[string]string{"supplier_code": "TEXTILIA"}, expectedCall: "delete"},
+		{name: "invalid data is dropped", eventType: erpSupplierCreated, data: map[string]string{"supplier_code": "TEXTILIA"}},
+		{
+			name: "invalid code is dropped", eventType: erpSupplierCreated,
+			data: map[string]string{"supplier_code": "TEXTILIA/PL", "supplier_name": "Textilia"},
+		},
+		{name: "unknown type is dropped", eventType: "erp.supplier.merged", data: valid},
+		{
+			name: "lost race is retried", eventType: erpSupplierUpdated, data: valid,
+			errToReturn: domain.ErrConcurrencyConflict, expectedCall: "sync", expectedErr: domain.ErrConcurrencyConflict,
+		},
+		{
+			name: "infrastructure error is retried", eventType: erpSupplierDeleted, data: valid,
+			errToReturn: errDatabase, expectedCall: "delete", expectedErr: errDatabase,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockSupplierSyncService{errToReturn: tc.errToReturn}
+			handler := NewSupplierEventHandler(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			// --- Act ---
+			err := handler.HandleMessage(context.Background(), "erp.supplier", erpSupplierMessage(t, tc.eventType, tc.data))
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedCall, svc.called)
+			if tc.expectedCall != "" {
+				assert.Equal(t, "TEXTILIA", svc.code)
+			}
+			if tc.expectedCall == "sync" {
+				assert.Equal(t, "Textilia S.A.", svc.name)
+				assert.Equal(t, "sales@textilia.example", svc.contactEmail)
+			}
+		})
+	}
+}
+
+func TestSupplierEventHandler_MalformedMessageIsDropped(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockSupplierSyncService{}
+	handler := NewSupplierEventHandler(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// --- Act ---
+	err := handler.HandleMessage(context.Background(), "erp.supplier", []byte("{not json"))
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Empty(t, svc.called)
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+)
+
+// SupplierTokenMiddleware authenticates the portal requests of suppliers by the token they
+// were issued. The request then acts as the supplier of the token, limited to its scopes;
+// whatever principal the request carried before is replaced, together with the scopes
+// granted to it.
+func SupplierTokenMiddleware(tokens domain.SupplierTokenRepository, clock clock.Clock) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || presented == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="supplier-portal"`)
+				httpx.ErrorJSON(w, http.StatusUnauthorized, "a supplier token must be provided")
+				return
+			}
+
+			token, err := tokens.FindTokenByHash(r.Context(), domain.HashSupplierToken(presented))
+			if err != nil && !errors.Is(err, domain.ErrSupplierTokenNotFound) {
+				httpx.InternalError(w, r, err)
+				return
+			}
+			if err != nil || !token.Active(clock.Now()) {
+				// unknown, expired and revoked tokens are not told apart
+				w.Header().Set("WWW-Authenticate", `Bearer realm="supplier-portal", error="invalid_token"`)
+				httpx.ErrorJSON(w, http.StatusUnauthorized, "the supplier token is invalid, expired or revoked")
+				return
+			}
+
+			ctx := command.WithSupplier(command.WithScopes(r.Context(), nil), command.SupplierPrincipal{
+				Code:   token.SupplierCode,
+				Scopes: token.Scopes,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireSupplierScope refuses portal requests whose token was not issued with the scope.
+func RequireSupplierScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			supplier, ok := command.GetSupplier(r.Context())
+			if !ok || !slices.Contains(supplier.Scopes, scope) {
+				httpx.ErrorJSON(w, http.StatusForbidden, "the supplier token does not grant "+scope)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+)
+
+// SupplierQueryRepository reads the suppliers and the fabrics linked to them.
+type SupplierQueryRepository interface {
+	GetSupplier(ctx context.Context, code string) (*domain.Supplier, error)
+	ListSuppliers(ctx context.Context, limit, offset int) ([]*domain.Supplier, int, error)
+	ListSupplierFabrics(ctx context.Context, code string) ([]*domain.SupplierFabric, error)
+}
+
+// SupplierQueryHandler serves the suppliers and the fabrics each of them delivers.
+type SupplierQueryHandler struct {
+	suppliers  SupplierQueryRepository
+	pagination httpx.PaginationConfig
+}
+
+func NewSupplierQueryHandler(suppliers SupplierQueryRepository, pagination httpx.PaginationConfig) *SupplierQueryHandler {
+	return &SupplierQueryHandler{
+		suppliers:  suppliers,
+		pagination: pagination,
+	}
+}
+
+func (h *SupplierQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	if httpx.URLParam(r, "code") == "" {
+		h.listSuppliers(w, r)
+		return
+	}
+	h.getSupplier(w, r)
+}
+
+func (h *SupplierQueryHandler) listSuppliers(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	suppliers, totalRecords, err := h.suppliers.ListSuppliers(r.Context(), page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"suppliers": suppliers, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *SupplierQueryHandler) getSupplier(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	supplier, err := h.suppliers.GetSupplier(ctx, httpx.URLParam(r, "code"))
+	if err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+	fabrics, err := h.suppliers.ListSupplierFabrics(ctx, supplier.Code)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	env := httpx.Envelope{"supplier": supplier, "fabrics": fabrics}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
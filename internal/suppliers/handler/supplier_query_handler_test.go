@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSupplierQueryRepository struct {
+	listedLimit  int
+	listedOffset int
+}
+
+func (m *mockSupplierQueryRepository) GetSupplier(ctx context.Context, code string) (*domain.Supplier, error) {
+	if code != "TEXTILIA" {
+		return nil, domain.ErrSupplierNotFound
+	}
+	return &domain.Supplier{Code: code, Name: "Textilia", Version: 1}, nil
+}
+
+func (m *mockSupplierQueryRepository) ListSuppliers(ctx context.Context, limit, offset int) ([]*domain.Supplier, int, error) {
+	m.listedLimit, m.listedOffset = limit, offset
+	return []*domain.Supplier{{Code: "TEXTILIA", Name: "Textilia", Version: 1}}, 21, nil
+}
+
+func (m *mockSupplierQueryRepository) ListSupplierFabrics(ctx context.Context, code string) ([]*domain.SupplierFabric, error) {
+	return []*domain.SupplierFabric{{Code: "VELVET01", Name: "Velvet", LeadTimeDays: 21, ArticleNumber: "TX-4411"}}, nil
+}
+
+func TestSupplierQueryHandler_GetSupplier(t *testing.T) {
+	// --- Arrange ---
+	handler := NewSupplierQueryHandler(&mockSupplierQueryRepository{}, httpx.PaginationConfig{})
+
+	// --- Act ---
+	responseRecorder := serveSupplier(
+		t, handler, http.MethodGet, "/v1/suppliers/TEXTILIA", "", map[string]string{"code": "TEXTILIA"},
+	)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	var body struct {
+		Supplier domain.Supplier         `json:"supplier"`
+		Fabrics  []domain.SupplierFabric `json:"fabrics"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+	assert.Equal(t, "TEXTILIA", body.Supplier.Code)
+	require.Len(t, body.Fabrics, 1)
+	assert.Equal(t, 21, body.Fabrics[0].LeadTimeDays)
+}
+
+func TestSupplierQueryHandler_ListSuppliers(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockSupplierQueryRepository{}
+	pagination := httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+	handler := NewSupplierQueryHandler(repo, pagination)
+
+	// --- Act ---
+	responseRecorder := serveSupplier(t, handler, http.MethodGet, "/v1/suppliers?page=2", "", nil)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, 20, repo.listedLimit)
+	assert.Equal(t, 20, repo.listedOffset)
+}
+
+func TestSupplierQueryHandler_NotFound(t *testing.T) {
+	// --- Arrange ---
+	handler := NewSupplierQueryHandler(&mockSupplierQueryRepository{}, httpx.PaginationConfig{})
+
+	// --- Act ---
+	responseRecorder := serveSupplier(
+		t, handler, http.MethodGet, "/v1/suppliers/NONE", "", map[string]string{"code": "NONE"},
+	)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+)
+
+const (
+	// defaultTokenValidDays is how long a supplier token is valid when no term is given.
+	defaultTokenValidDays = 30
+	// maxTokenValidDays caps the term of a supplier token, so that none is forgotten for good.
+	maxTokenValidDays = 365
+)
+
+// SupplierTokenHandler issues, lists and revokes the tokens suppliers use to access the portal.
+type SupplierTokenHandler struct {
+	tokens    domain.SupplierTokenRepository
+	suppliers SupplierQueryRepository
+	clock     clock.Clock
+}
+
+// the term is a pointer so that a missing one is told apart from an invalid zero
+type issueSupplierTokenRequest struct {
+	Scopes       []string `json:"scopes"`
+	ValidForDays *int     `json:"valid_for_days"`
+}
+
+func NewSupplierTokenHandler(
+	tokens domain.SupplierTokenRepository, suppliers SupplierQueryRepository, clock clock.Clock,
+) *SupplierTokenHandler {
+	return &SupplierTokenHandler{
+		tokens:    tokens,
+		suppliers: suppliers,
+		clock:     clock,
+	}
+}
+
+func (h *SupplierTokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+	r = r.WithContext(ctx)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.listTokens(w, r)
+	case http.MethodPost:
+		h.issueToken(w, r)
+	case http.MethodDelete:
+		h.revokeToken(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *SupplierTokenHandler) listTokens(w http.ResponseWriter, r *http.Request) {
+	supplier, err := h.suppliers.GetSupplier(r.Context(), httpx.URLParam(r, "code"))
+	if err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+
+	tokens, err := h.tokens.ListTokens(r.Context(), supplier.Code)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"tokens": tokens}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *SupplierTokenHandler) issueToken(w http.ResponseWriter, r *http.Request) {
+	var req issueSupplierTokenRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	validForDays := defaultTokenValidDays
+	if req.ValidForDays != nil {
+		validForDays = *req.ValidForDays
+	}
+	v := validator.New()
+	v.Check(len(req.Scopes) > 0, "scopes", "scopes must be provided")
+	v.Check(validator.Unique(req.Scopes), "scopes", "scopes must not contain duplicate values")
+	for _, scope := range req.Scopes {
+		v.Check(validator.PermittedValue(scope, domain.SupplierScopes()...), "scopes",
+			"scopes must only contain fabrics:read and fabrics:propose")
+	}
+	v.Check(validForDays >= 1 && validForDays <= maxTokenValidDays,
+		"valid_for_days", "valid_for_days must be between 1 and 365")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	supplier, err := h.suppliers.GetSupplier(r.Context(), httpx.URLParam(r, "code"))
+	if err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+
+	token, secret, err := domain.NewSupplierToken(
+		supplier.Code, req.Scopes, time.Duration(validForDays)*24*time.Hour, aggregate.StampNow(r.Context(), h.clock),
+	)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+	if err := h.tokens.SaveToken(r.Context(), token); err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+
+	// the secret is shown this once, only its hash is kept
+	env := httpx.Envelope{"token": token, "secret": secret}
+	if err := httpx.WriteJSON(w, http.StatusCreated, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *SupplierTokenHandler) revokeToken(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(httpx.URLParam(r, "id"), 10, 64)
+	if err != nil || id < 1 {
+		httpx.NotFound(w, r)
+		return
+	}
+
+	token, err := h.tokens.GetToken(r.Context(), httpx.URLParam(r, "code"), id)
+	if err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+	if err := token.Revoke(aggregate.StampNow(r.Context(), h.clock)); err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+	if err := h.tokens.RevokeToken(r.Context(), token); err != nil {
+		writeSupplierError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"token": token}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
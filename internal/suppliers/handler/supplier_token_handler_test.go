@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testClock = clock.NewFixed(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+// mockSupplierTokenRepository keeps the tokens in memory, numbered as they are saved
+type mockSupplierTokenRepository struct {
+	tokens []*domain.SupplierToken
+}
+
+func (m *mockSupplierTokenRepository) SaveToken(ctx context.Context, token *domain.SupplierToken) error {
+	token.ID = int64(len(m.tokens) + 1)
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func (m *mockSupplierTokenRepository) GetToken(
+	ctx context.Context, supplierCode string, id int64,
+) (*domain.SupplierToken, error) {
+	for _, token := range m.tokens {
+		if token.SupplierCode == supplierCode && token.ID == id {
+			return token, nil
+		}
+	}
+	return nil, domain.ErrSupplierTokenNotFound
+}
+
+func (m *mockSupplierTokenRepository) FindTokenByHash(ctx context.Context, hash string) (*domain.SupplierToken, error) {
+	for _, token := range m.tokens {
+		if token.Hash == hash {
+			return token, nil
+		}
+	}
+	return nil, domain.ErrSupplierTokenNotFound
+}
+
+func (m *mockSupplierTokenRepository) ListTokens(
+	ctx context.Context, supplierCode string,
+) ([]*domain.SupplierToken, error) {
+	return m.tokens, nil
+}
+
+func (m *mockSupplierTokenRepository) RevokeToken(ctx context.Context, token *domain.SupplierToken) error {
+	return nil
+}
+
+func TestSupplierTokenHandler_IssueToken(t *testing.T) {
+	// --- Arrange ---
+	tokens := &mockSupplierTokenRepository{}
+	handler := NewSupplierTokenHandler(tokens, &mockSupplierQueryRepository{}, testClock)
+	body := `{"scopes": ["fabrics:read", "fabrics:propose"], "valid_for_days": 7}`
+
+	// --- Act ---
+	responseRecorder := serveSupplier(
+		t, handler, http.MethodPost, "/v1/admin/suppliers/TEXTILIA/tokens", body, map[string]string{"code": "TEXTILIA"},
+	)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusCreated, responseRecorder.Code)
+	var response struct {
+		Token  map[string]any `json:"token"`
+		Secret string         `json:"secret"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.NotEmpty(t, response.Secret)
+	assert.NotContains(t, response.Token, "hash", "the hash of the secret must not be shown")
+	require.Len(t, tokens.tokens, 1)
+	assert.Equal(t, domain.HashSupplierToken(response.Secret), tokens.tokens[0].Hash)
+	assert.Equal(t, testClock.Now().Add(7*24*time.Hour), tokens.tokens[0].ExpiresAt)
+}
+
+func TestSupplierTokenHandler_Rejected(t *testing.T) {
+	testCases := []struct {
+		name           string
+		code           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "No scopes", code: "TEXTILIA", body: `{}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Unknown scope", code: "TEXTILIA", body: `{"scopes": ["fabrics:write"]}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Duplicate scope", code: "TEXTILIA", body: `{"scopes": ["fabrics:read", "fabrics:read"]}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Term too long", code: "TEXTILIA", body: `{"scopes": ["fabrics:read"], "valid_for_days": 366}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "No term", code: "TEXTILIA", body: `{"scopes": ["fabrics:read"], "valid_for_days": 0}`, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Unknown supplier", code: "NOBODY", body: `{"scopes": ["fabrics:read"]}`, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			tokens := &mockSupplierTokenRepository{}
+			handler := NewSupplierTokenHandler(tokens, &mockSupplierQueryRepository{}, testClock)
+
+			// --- Act ---
+			responseRecorder := serveSupplier(
+				t, handler, http.MethodPost, "/v1/admin/suppliers/"+tc.code+"/tokens", tc.body,
+				map[string]string{"code": tc.code},
+			)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Empty(t, tokens.tokens, "no token should be issued for a rejected request")
+		})
+	}
+}
+
+func TestSupplierTokenHandler_RevokeToken(t *testing.T) {
+	// --- Arrange ---
+	tokens := &mockSupplierTokenRepository{}
+	token, _, err := domain.NewSupplierToken("TEXTILIA", []string{domain.ScopeFabricsRead}, time.Hour, domain.Stamp{})
+	require.NoError(t, err)
+	require.NoError(t, tokens.SaveToken(context.Background(), token))
+	handler := NewSupplierTokenHandler(tokens, &mockSupplierQueryRepository{}, testClock)
+	params := map[string]string{"code": "TEXTILIA", "id": "1"}
+
+	// --- Act ---
+	revoked := serveSupplier(t, handler, http.MethodDelete, "/v1/admin/suppliers/TEXTILIA/tokens/1", "", params)
+	again := serveSupplier(t, handler, http.MethodDelete, "/v1/admin/suppliers/TEXTILIA/tokens/1", "", params)
+	otherSupplier := serveSupplier(
+		t, handler, http.MethodDelete, "/v1/admin/suppliers/WEAVERS/tokens/1", "",
+		map[string]string{"code": "WEAVERS", "id": "1"},
+	)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, revoked.Code)
+	require.NotNil(t, token.RevokedAt)
+	assert.Equal(t, testClock.Now(), *token.RevokedAt)
+	assert.Equal(t, http.StatusConflict, again.Code)
+	assert.Equal(t, http.StatusNotFound, otherSupplier.Code, "a token is only revoked through its own supplier")
+}
+
+func TestSupplierTokenMiddleware(t *testing.T) {
+	// --- Arrange ---
+	tokens := &mockSupplierTokenRepository{}
+	issue := func(validFor time.Duration, revoke bool) string {
+		token, secret, err := domain.NewSupplierToken(
+			"TEXTILIA", []string{domain.ScopeFabricsRead}, validFor, domain.Stamp{At: testClock.Now()},
+		)
+		require.NoError(t, err)
+		if revoke {
+			require.NoError(t, token.Revoke(domain.Stamp{At: testClock.Now()}))
+		}
+		require.NoError(t, tokens.SaveToken(context.Background(), token))
+		return secret
+	}
+	valid := issue(time.Hour, false)
+	expired := issue(0, false)
+	revoked := issue(time.Hour, true)
+
+	testCases := []struct {
+		name           string
+		authorization  string
+		scope          string
+		expectedStatus int
+	}{
+		{name: "Valid token", authorization: "Bearer " + valid, scope: domain.ScopeFabricsRead, expectedStatus: http.StatusOK},
+		{name: "Scope not granted", authorization: "Bearer " + valid, scope: domain.ScopeFabricsPropose, expectedStatus: http.StatusForbidden},
+		{name: "No token", authorization: "", scope: domain.ScopeFabricsRead, expectedStatus: http.StatusUnauthorized},
+		{name: "Unknown token", authorization: "Bearer spt_unknown", scope: domain.ScopeFabricsRead, expectedStatus: http.StatusUnauthorized},
+		{name: "Expired token", authorization: "Bearer " + expired, scope: domain.ScopeFabricsRead, expectedStatus: http.StatusUnauthorized},
+		{name: "Revoked token", authorization: "Bearer " + revoked, scope: domain.ScopeFabricsRead, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			var actor string
+			var scoped bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor = command.Actor(r.Context())
+				scoped = command.HasScope(r.Context(), "fabrics:pricing")
+			})
+			handler := SupplierTokenMiddleware(tokens, testClock)(RequireSupplierScope(tc.scope)(next))
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/portal/fabrics", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", tc.authorization)
+			ctx := command.WithScopes(command.WithUserID(req.Context(), "user_42"), []string{"fabrics:pricing"})
+			req = req.WithContext(ctx)
+
+			// --- Act ---
+			responseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "supplier:TEXTILIA", actor, "the supplier replaces the principal of the request")
+				assert.False(t, scoped, "the scopes of the replaced principal are not kept")
+			}
+		})
+	}
+}
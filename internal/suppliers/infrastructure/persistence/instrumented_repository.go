@@ -0,0 +1,143 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+)
+
+// InstrumentedSupplierRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedSupplierRepository struct {
+	next domain.SupplierRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedSupplierRepository(
+	next domain.SupplierRepository, rec *instrument.Recorder,
+) *InstrumentedSupplierRepository {
+	return &InstrumentedSupplierRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedSupplierRepository) SaveSupplier(ctx context.Context, supplier *domain.Supplier) error {
+	return instrument.Exec(ctx, r.rec, "SaveSupplier", func(ctx context.Context) error {
+		return r.next.SaveSupplier(ctx, supplier)
+	})
+}
+
+func (r *InstrumentedSupplierRepository) GetSupplier(ctx context.Context, code string) (*domain.Supplier, error) {
+	return instrument.Call(ctx, r.rec, "GetSupplier", func(ctx context.Context) (*domain.Supplier, error) {
+		return r.next.GetSupplier(ctx, code)
+	})
+}
+
+func (r *InstrumentedSupplierRepository) ListSuppliers(
+	ctx context.Context, limit, offset int,
+) ([]*domain.Supplier, int, error) {
+	var total int
+	suppliers, err := instrument.Call(ctx, r.rec, "ListSuppliers",
+		func(ctx context.Context) ([]*domain.Supplier, error) {
+			suppliers, count, err := r.next.ListSuppliers(ctx, limit, offset)
+			total = count
+			return suppliers, err
+		})
+	return suppliers, total, err
+}
+
+func (r *InstrumentedSupplierRepository) UpdateSupplier(ctx context.Context, supplier *domain.Supplier) error {
+	return instrument.Exec(ctx, r.rec, "UpdateSupplier", func(ctx context.Context) error {
+		return r.next.UpdateSupplier(ctx, supplier)
+	})
+}
+
+func (r *InstrumentedSupplierRepository) DeleteSupplier(ctx context.Context, supplier *domain.Supplier) error {
+	return instrument.Exec(ctx, r.rec, "DeleteSupplier", func(ctx context.Context) error {
+		return r.next.DeleteSupplier(ctx, supplier)
+	})
+}
+
+func (r *InstrumentedSupplierRepository) LinkFabric(
+	ctx context.Context, supplier *domain.Supplier, link domain.FabricLink,
+) error {
+	return instrument.Exec(ctx, r.rec, "LinkFabric", func(ctx context.Context) error {
+		return r.next.LinkFabric(ctx, supplier, link)
+	})
+}
+
+func (r *InstrumentedSupplierRepository) UnlinkFabric(
+	ctx context.Context, supplier *domain.Supplier, fabricCode string,
+) error {
+	return instrument.Exec(ctx, r.rec, "UnlinkFabric", func(ctx context.Context) error {
+		return r.next.UnlinkFabric(ctx, supplier, fabricCode)
+	})
+}
+
+func (r *InstrumentedSupplierRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	return instrument.Call(ctx, r.rec, "ResolveFabricCode", func(ctx context.Context) (string, error) {
+		return r.next.ResolveFabricCode(ctx, code)
+	})
+}
+
+func (r *InstrumentedSupplierRepository) ListSupplierFabrics(
+	ctx context.Context, code string,
+) ([]*domain.SupplierFabric, error) {
+	return instrument.Call(ctx, r.rec, "ListSupplierFabrics", func(ctx context.Context) ([]*domain.SupplierFabric, error) {
+		return r.next.ListSupplierFabrics(ctx, code)
+	})
+}
+
+func (r *InstrumentedSupplierRepository) ListFabricSuppliers(
+	ctx context.Context, fabricCode string,
+) ([]*domain.FabricSupplier, error) {
+	return instrument.Call(ctx, r.rec, "ListFabricSuppliers", func(ctx context.Context) ([]*domain.FabricSupplier, error) {
+		return r.next.ListFabricSuppliers(ctx, fabricCode)
+	})
+}
+
+// InstrumentedSupplierTokenRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedSupplierTokenRepository struct {
+	next domain.SupplierTokenRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedSupplierTokenRepository(
+	next domain.SupplierTokenRepository, rec *instrument.Recorder,
+) *InstrumentedSupplierTokenRepository {
+	return &InstrumentedSupplierTokenRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedSupplierTokenRepository) SaveToken(ctx context.Context, token *domain.SupplierToken) error {
+	return instrument.Exec(ctx, r.rec, "SaveToken", func(ctx context.Context) error {
+		return r.next.SaveToken(ctx, token)
+	})
+}
+
+func (r *InstrumentedSupplierTokenRepository) GetToken(
+	ctx context.Context, supplierCode string, id int64,
+) (*domain.SupplierToken, error) {
+	return instrument.Call(ctx, r.rec, "GetToken", func(ctx context.Context) (*domain.SupplierToken, error) {
+		return r.next.GetToken(ctx, supplierCode, id)
+	})
+}
+
+func (r *InstrumentedSupplierTokenRepository) FindTokenByHash(
+	ctx context.Context, hash string,
+) (*domain.SupplierToken, error) {
+	return instrument.Call(ctx, r.rec, "FindTokenByHash", func(ctx context.Context) (*domain.SupplierToken, error) {
+		return r.next.FindTokenByHash(ctx, hash)
+	})
+}
+
+func (r *InstrumentedSupplierTokenRepository) ListTokens(
+	ctx context.Context, supplierCode string,
+) ([]*domain.SupplierToken, error) {
+	return instrument.Call(ctx, r.rec, "ListTokens", func(ctx context.Context) ([]*domain.SupplierToken, error) {
+		return r.next.ListTokens(ctx, supplierCode)
+	})
+}
+
+func (r *InstrumentedSupplierTokenRepository) RevokeToken(ctx context.Context, token *domain.SupplierToken) error {
+	return instrument.Exec(ctx, r.rec, "RevokeToken", func(ctx context.Context) error {
+		return r.next.RevokeToken(ctx, token)
+	})
+}
@@ -0,0 +1,303 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+)
+
+const supplierColumns = `code, name, contact_email, version, created_at, created_by, updated_at, updated_by`
+
+// resolves a fabric code, or one of its aliases, to the canonical code
+const canonicalFabricCodeSQL = `COALESCE((SELECT canonical_code FROM fabric_aliases WHERE alias_code = $1), $1)`
+
+type SupplierPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewSupplierPostgresRepository(db *database.PostgresDB) *SupplierPostgresRepository {
+	return &SupplierPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *SupplierPostgresRepository) SaveSupplier(ctx context.Context, supplier *domain.Supplier) error {
+	query := `
+		INSERT INTO suppliers (` + supplierColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		supplier.Code, supplier.Name, supplier.ContactEmail, supplier.Version,
+		supplier.CreatedAt, supplier.CreatedBy, supplier.UpdatedAt, supplier.UpdatedBy,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrDuplicateSupplierCode
+		}
+		return fmt.Errorf("failed to insert supplier: %w", err)
+	}
+	return nil
+}
+
+func (r *SupplierPostgresRepository) GetSupplier(ctx context.Context, code string) (*domain.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers WHERE code = $1`
+	supplier, err := scanSupplier(r.db.Conn(ctx).QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSupplierNotFound
+		}
+		return nil, fmt.Errorf("failed to get supplier: %w", err)
+	}
+	return supplier, nil
+}
+
+func (r *SupplierPostgresRepository) ListSuppliers(
+	ctx context.Context, limit, offset int,
+) ([]*domain.Supplier, int, error) {
+	query := `
+		SELECT count(*) OVER(), ` + supplierColumns + `
+		FROM suppliers
+		ORDER BY code
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	suppliers := []*domain.Supplier{}
+	for rows.Next() {
+		supplier := &domain.Supplier{}
+		err := rows.Scan(
+			&totalRecords, &supplier.Code, &supplier.Name, &supplier.ContactEmail, &supplier.Version,
+			&supplier.CreatedAt, &supplier.CreatedBy, &supplier.UpdatedAt, &supplier.UpdatedBy,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan supplier: %w", err)
+		}
+		suppliers = append(suppliers, supplier)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate suppliers: %w", err)
+	}
+
+	return suppliers, totalRecords, nil
+}
+
+func (r *SupplierPostgresRepository) UpdateSupplier(ctx context.Context, supplier *domain.Supplier) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE suppliers
+		SET name = $1, contact_email = $2, version = $3, updated_at = $4, updated_by = $5
+		WHERE code = $6 AND version = $7
+	`, supplier.Name, supplier.ContactEmail, supplier.Version, supplier.UpdatedAt, supplier.UpdatedBy,
+		supplier.Code, supplier.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update supplier: %w", err)
+	}
+	return expectOneRow(result)
+}
+
+// DeleteSupplier removes the supplier along with its fabric links.
+func (r *SupplierPostgresRepository) DeleteSupplier(ctx context.Context, supplier *domain.Supplier) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx,
+		`DELETE FROM suppliers WHERE code = $1 AND version = $2`, supplier.Code, supplier.Version-1,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete supplier: %w", err)
+	}
+	return expectOneRow(result)
+}
+
+// LinkFabric links the fabric to the supplier, or replaces the terms of an existing link.
+func (r *SupplierPostgresRepository) LinkFabric(
+	ctx context.Context, supplier *domain.Supplier, link domain.FabricLink,
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO supplier_fabrics (supplier_code, fabric_code, lead_time_days, article_number, linked_at, linked_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (supplier_code, fabric_code) DO UPDATE
+		SET lead_time_days = EXCLUDED.lead_time_days, article_number = EXCLUDED.article_number,
+			linked_at = EXCLUDED.linked_at, linked_by = EXCLUDED.linked_by
+	`, supplier.Code, link.FabricCode, link.LeadTimeDays, link.ArticleNumber, supplier.UpdatedAt, supplier.UpdatedBy)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return domain.ErrDuplicateArticleNumber
+			case "23503":
+				// the fabric was deleted since it was resolved
+				return domain.ErrFabricNotFound
+			}
+		}
+		return fmt.Errorf("failed to link fabric to supplier: %w", err)
+	}
+
+	if err := bumpVersion(ctx, tx, supplier); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *SupplierPostgresRepository) UnlinkFabric(
+	ctx context.Context, supplier *domain.Supplier, fabricCode string,
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM supplier_fabrics WHERE supplier_code = $1 AND fabric_code = $2`,
+		supplier.Code, fabricCode,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unlink fabric from supplier: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrFabricNotLinked
+	}
+
+	if err := bumpVersion(ctx, tx, supplier); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *SupplierPostgresRepository) ResolveFabricCode(ctx context.Context, code string) (string, error) {
+	var canonicalCode string
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		`SELECT code FROM fabrics WHERE code = `+canonicalFabricCodeSQL+` AND status = 'ACTIVE'`, code,
+	).Scan(&canonicalCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrFabricNotFound
+		}
+		return "", fmt.Errorf("failed to resolve fabric code: %w", err)
+	}
+	return canonicalCode, nil
+}
+
+// ListSupplierFabrics returns the active fabrics linked to the supplier, by fabric code.
+func (r *SupplierPostgresRepository) ListSupplierFabrics(
+	ctx context.Context, code string,
+) ([]*domain.SupplierFabric, error) {
+	query := `
+		SELECT f.code, f.name, sf.lead_time_days, sf.article_number
+		FROM supplier_fabrics sf
+		JOIN fabrics f ON f.code = sf.fabric_code AND f.status = 'ACTIVE'
+		WHERE sf.supplier_code = $1
+		ORDER BY f.code
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list supplier fabrics: %w", err)
+	}
+	defer rows.Close()
+
+	fabrics := []*domain.SupplierFabric{}
+	for rows.Next() {
+		fabric := &domain.SupplierFabric{}
+		if err := rows.Scan(&fabric.Code, &fabric.Name, &fabric.LeadTimeDays, &fabric.ArticleNumber); err != nil {
+			return nil, fmt.Errorf("failed to scan supplier fabric: %w", err)
+		}
+		fabrics = append(fabrics, fabric)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate supplier fabrics: %w", err)
+	}
+	return fabrics, nil
+}
+
+// ListFabricSuppliers returns the suppliers of the fabric, given by its code or an alias,
+// the quickest to deliver first.
+func (r *SupplierPostgresRepository) ListFabricSuppliers(
+	ctx context.Context, fabricCode string,
+) ([]*domain.FabricSupplier, error) {
+	query := `
+		SELECT s.code, s.name, sf.lead_time_days, sf.article_number, GREATEST(sf.linked_at, s.updated_at)
+		FROM supplier_fabrics sf
+		JOIN suppliers s ON s.code = sf.supplier_code
+		WHERE sf.fabric_code = ` + canonicalFabricCodeSQL + `
+		ORDER BY sf.lead_time_days, s.code
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, fabricCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fabric suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	suppliers := []*domain.FabricSupplier{}
+	for rows.Next() {
+		supplier := &domain.FabricSupplier{}
+		err := rows.Scan(
+			&supplier.Code, &supplier.Name, &supplier.LeadTimeDays, &supplier.ArticleNumber, &supplier.ModifiedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fabric supplier: %w", err)
+		}
+		suppliers = append(suppliers, supplier)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate fabric suppliers: %w", err)
+	}
+	return suppliers, nil
+}
+
+// bumpVersion moves the supplier to its new version, provided nobody else did first.
+func bumpVersion(ctx context.Context, tx *database.Tx, supplier *domain.Supplier) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE suppliers SET version = $1, updated_at = $2, updated_by = $3
+		WHERE code = $4 AND version = $5
+	`, supplier.Version, supplier.UpdatedAt, supplier.UpdatedBy, supplier.Code, supplier.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update supplier version: %w", err)
+	}
+	return expectOneRow(result)
+}
+
+func expectOneRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSupplier(row rowScanner) (*domain.Supplier, error) {
+	supplier := &domain.Supplier{}
+	err := row.Scan(
+		&supplier.Code, &supplier.Name, &supplier.ContactEmail, &supplier.Version,
+		&supplier.CreatedAt, &supplier.CreatedBy, &supplier.UpdatedAt, &supplier.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
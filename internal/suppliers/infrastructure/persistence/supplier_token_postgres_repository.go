@@ -0,0 +1,134 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/salesworks/s-works/api/internal/suppliers/domain"
+)
+
+const supplierTokenColumns = `id, supplier_code, token_hash, scopes, expires_at, created_at, created_by, revoked_at, revoked_by`
+
+type SupplierTokenPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewSupplierTokenPostgresRepository(db *database.PostgresDB) *SupplierTokenPostgresRepository {
+	return &SupplierTokenPostgresRepository{
+		db: db,
+	}
+}
+
+// SaveToken stores a newly issued token and sets its ID.
+func (r *SupplierTokenPostgresRepository) SaveToken(ctx context.Context, token *domain.SupplierToken) error {
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		INSERT INTO supplier_tokens (supplier_code, token_hash, scopes, expires_at, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, token.SupplierCode, token.Hash, strings.Join(token.Scopes, " "), token.ExpiresAt, token.CreatedAt, token.CreatedBy,
+	).Scan(&token.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			// the supplier was deleted since it was loaded
+			return domain.ErrSupplierNotFound
+		}
+		return fmt.Errorf("failed to insert supplier token: %w", err)
+	}
+	return nil
+}
+
+func (r *SupplierTokenPostgresRepository) GetToken(
+	ctx context.Context, supplierCode string, id int64,
+) (*domain.SupplierToken, error) {
+	query := `SELECT ` + supplierTokenColumns + ` FROM supplier_tokens WHERE supplier_code = $1 AND id = $2`
+	token, err := scanSupplierToken(r.db.Conn(ctx).QueryRowContext(ctx, query, supplierCode, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSupplierTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to get supplier token: %w", err)
+	}
+	return token, nil
+}
+
+func (r *SupplierTokenPostgresRepository) FindTokenByHash(
+	ctx context.Context, hash string,
+) (*domain.SupplierToken, error) {
+	query := `SELECT ` + supplierTokenColumns + ` FROM supplier_tokens WHERE token_hash = $1`
+	token, err := scanSupplierToken(r.db.Conn(ctx).QueryRowContext(ctx, query, hash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSupplierTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to find supplier token: %w", err)
+	}
+	return token, nil
+}
+
+func (r *SupplierTokenPostgresRepository) ListTokens(
+	ctx context.Context, supplierCode string,
+) ([]*domain.SupplierToken, error) {
+	query := `SELECT ` + supplierTokenColumns + ` FROM supplier_tokens WHERE supplier_code = $1 ORDER BY id DESC`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, supplierCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list supplier tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []*domain.SupplierToken{}
+	for rows.Next() {
+		token, err := scanSupplierToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan supplier token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate supplier tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// RevokeToken stores the revocation of a token, failing with ErrSupplierTokenRevoked when
+// it was revoked by somebody else in the meantime.
+func (r *SupplierTokenPostgresRepository) RevokeToken(ctx context.Context, token *domain.SupplierToken) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE supplier_tokens SET revoked_at = $1, revoked_by = $2
+		WHERE id = $3 AND revoked_at IS NULL
+	`, token.RevokedAt, token.RevokedBy, token.ID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke supplier token: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrSupplierTokenRevoked
+	}
+	return nil
+}
+
+func scanSupplierToken(row rowScanner) (*domain.SupplierToken, error) {
+	token := &domain.SupplierToken{}
+	var scopes string
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&token.ID, &token.SupplierCode, &token.Hash, &scopes, &token.ExpiresAt,
+		&token.CreatedAt, &token.CreatedBy, &revokedAt, &token.RevokedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	token.Scopes = strings.Fields(scopes)
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	return token, nil
+}
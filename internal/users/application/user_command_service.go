@@ -0,0 +1,184 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"github.com/salesworks/s-works/api/internal/users/domain"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UserService mirrors the users of Clerk and stores their events.
+type UserService struct {
+	repo       domain.UserRepository
+	eventStore eventstore.Store
+	clock      clock.Clock
+	source     messaging.Source
+}
+
+func NewUserCommandService(
+	repo domain.UserRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+) *UserService {
+	return &UserService{
+		repo:       repo,
+		eventStore: eventStore,
+		clock:      clock,
+		source:     source,
+	}
+}
+
+// SyncUser brings the user to the profile Clerk sent, creating it when the user is not
+// known yet. Profiles no newer than the one the user has, and changes of deleted users,
+// are ignored and reported unchanged, so re-sent and late webhooks are harmless.
+func (s *UserService) SyncUser(
+	ctx context.Context, id string, profile domain.UserProfile,
+) (*domain.User, bool, error) {
+	current, err := s.repo.GetUser(ctx, id)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		user, err := s.createUser(ctx, id, profile)
+		return user, err == nil, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if current.Deleted() || !current.Supersedes(profile) {
+		return current, false, nil
+	}
+
+	user, err := s.change(ctx, id, "user.service.update", func(user *domain.User, stamp domain.Stamp) error {
+		return user.Update(profile, current.Version, stamp)
+	})
+	return user, err == nil, err
+}
+
+// SyncUserDeletion deletes the user at whatever version it is. A user that is unknown or
+// already deleted is reported unchanged.
+func (s *UserService) SyncUserDeletion(ctx context.Context, id string) (bool, error) {
+	current, err := s.repo.GetUser(ctx, id)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if current.Deleted() {
+		return false, nil
+	}
+
+	_, err = s.change(ctx, id, "user.service.delete", func(user *domain.User, stamp domain.Stamp) error {
+		return user.Delete(current.Version, stamp)
+	})
+	return err == nil, err
+}
+
+func (s *UserService) createUser(ctx context.Context, id string, profile domain.UserProfile) (*domain.User, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "user.service.create")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "user.service")
+
+	user := domain.NewUser(id, profile, aggregate.StampNow(ctx, s.clock))
+	if err := s.repo.SaveUser(ctx, user); err != nil {
+		return nil, s.failed(span, logger, "saving user failed", err)
+	}
+
+	if err := s.publish(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// change loads the user, applies a command to it, stores it and stores its events.
+func (s *UserService) change(
+	ctx context.Context, id, spanName string, apply func(user *domain.User, stamp domain.Stamp) error,
+) (*domain.User, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, spanName)
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "user.service")
+
+	user, err := s.repo.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(user, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
+		return nil, s.failed(span, logger, "updating user failed", err)
+	}
+
+	if err := s.publish(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// failed reports a repository write that did not succeed. User errors are passed through
+// as they are, anything else is wrapped and recorded as a database error.
+func (s *UserService) failed(span trace.Span, logger *slog.Logger, msg string, err error) error {
+	var userErr *domain.UserError
+	if errors.As(err, &userErr) {
+		return err
+	}
+	wrappedErr := fmt.Errorf("failed to write user in repo: %w", err)
+	logger.Error(msg, "error", wrappedErr)
+	span.RecordError(wrappedErr)
+	span.SetStatus(codes.Error, "database write error")
+	return wrappedErr
+}
+
+// publish appends the events of the user to the event store. They hold personal data and
+// are not queued in the outbox, nothing outside the API needs them.
+func (s *UserService) publish(ctx context.Context, user *domain.User) error {
+	logger := httpx.GetLogger(ctx).With("component", "user.service")
+
+	var envelopesToSave []*messaging.EventEnvelope
+	for _, event := range user.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.UserCreated:
+			eventType = "app.user.created"
+		case domain.UserUpdated:
+			eventType = "app.user.updated"
+		case domain.UserDeleted:
+			eventType = "app.user.deleted"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			user.ID,
+			"User",
+			user.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelope.UserID = command.Actor(ctx)
+		envelopesToSave = append(envelopesToSave, envelope)
+	}
+
+	if len(envelopesToSave) > 0 {
+		if err := s.eventStore.Save(ctx, envelopesToSave...); err != nil {
+			wrappedErr := fmt.Errorf("failed to save user event to event store: %w", err)
+			logger.Error("saving user event failed", "error", wrappedErr)
+			return wrappedErr
+		}
+	}
+
+	return nil
+}
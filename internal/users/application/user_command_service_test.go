@@ -0,0 +1,182 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/users/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+var testSource = messaging.Source{Service: "s-works-api", Instance: "test-instance"}
+
+var testProfile = domain.UserProfile{
+	Email:     "anna@atelier-nord.example",
+	FirstName: "Anna",
+	LastName:  "Nowak",
+	ChangedAt: time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC),
+}
+
+type mockUserRepository struct {
+	users map[string]*domain.User
+	saved *domain.User
+}
+
+func (m *mockUserRepository) SaveUser(ctx context.Context, user *domain.User) error {
+	m.saved = user
+	return nil
+}
+
+func (m *mockUserRepository) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	userCopy := *user
+	return &userCopy, nil
+}
+
+func (m *mockUserRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	return m.SaveUser(ctx, user)
+}
+
+type mockEventStore struct {
+	Saved    []*messaging.EventEnvelope
+	Enqueued bool
+}
+
+func (m *mockEventStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
+	m.Saved = append(m.Saved, envelopes...)
+	return nil
+}
+
+func (m *mockEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.Enqueued = true
+	return m.Save(ctx, envelopes...)
+}
+
+func newTestRepository() *mockUserRepository {
+	user := &domain.User{
+		ID: "user_2abc", Email: testProfile.Email, FirstName: "Anna", LastName: "Nowak",
+		ProfileChangedAt: testProfile.ChangedAt, Version: 1,
+	}
+	return &mockUserRepository{users: map[string]*domain.User{user.ID: user}}
+}
+
+func clerkContext() context.Context {
+	return command.WithUserID(context.Background(), command.ActorClerk)
+}
+
+func TestUserService_SyncUser(t *testing.T) {
+	newer := testProfile
+	newer.LastName = "Kowalska"
+	newer.ChangedAt = testProfile.ChangedAt.Add(time.Hour)
+	older := newer
+	older.ChangedAt = testProfile.ChangedAt.Add(-time.Hour)
+
+	testCases := []struct {
+		name            string
+		id              string
+		profile         domain.UserProfile
+		expectedChanged bool
+		expectedVersion int
+		expectedEvent   string
+	}{
+		{
+			name: "Unknown user is created", id: "user_2def", profile: testProfile,
+			expectedChanged: true, expectedVersion: 1, expectedEvent: "app.user.created",
+		},
+		{
+			name: "Newer profile is applied", id: "user_2abc", profile: newer,
+			expectedChanged: true, expectedVersion: 2, expectedEvent: "app.user.updated",
+		},
+		{name: "Re-sent profile is ignored", id: "user_2abc", profile: testProfile, expectedVersion: 1},
+		{name: "Older profile is ignored", id: "user_2abc", profile: older, expectedVersion: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			eventStore := &mockEventStore{}
+			service := NewUserCommandService(repo, eventStore, clock.NewFixed(testNow), testSource)
+
+			// --- Act ---
+			user, changed, err := service.SyncUser(clerkContext(), tc.id, tc.profile)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChanged, changed)
+			assert.Equal(t, tc.expectedVersion, user.Version)
+			assert.False(t, eventStore.Enqueued, "user events hold personal data and are not published")
+			if !tc.expectedChanged {
+				assert.Nil(t, repo.saved)
+				assert.Empty(t, eventStore.Saved)
+				return
+			}
+			require.Len(t, eventStore.Saved, 1)
+			assert.Equal(t, tc.expectedEvent, eventStore.Saved[0].EventType)
+			assert.Equal(t, command.ActorClerk, eventStore.Saved[0].UserID)
+			assert.Equal(t, command.ActorClerk, repo.saved.UpdatedBy)
+		})
+	}
+}
+
+func TestUserService_SyncUser_DeletedUserIsIgnored(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	repo.users["user_2abc"].MarkDeleted(testNow)
+	eventStore := &mockEventStore{}
+	service := NewUserCommandService(repo, eventStore, clock.NewFixed(testNow), testSource)
+	profile := testProfile
+	profile.ChangedAt = testProfile.ChangedAt.Add(time.Hour)
+
+	// --- Act ---
+	_, changed, err := service.SyncUser(clerkContext(), "user_2abc", profile)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Nil(t, repo.saved)
+}
+
+func TestUserService_SyncUserDeletion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		id              string
+		expectedChanged bool
+	}{
+		{name: "Live user is deleted", id: "user_2abc", expectedChanged: true},
+		{name: "Unknown user is ignored", id: "user_2def"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			eventStore := &mockEventStore{}
+			service := NewUserCommandService(repo, eventStore, clock.NewFixed(testNow), testSource)
+
+			// --- Act ---
+			changed, err := service.SyncUserDeletion(clerkContext(), tc.id)
+
+			// --- Assert ---
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChanged, changed)
+			if tc.expectedChanged {
+				require.NotNil(t, repo.saved)
+				assert.True(t, repo.saved.Deleted())
+				assert.Equal(t, 2, repo.saved.Version)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+var (
+	ErrUserNotFound        = notFoundError("user not found")
+	ErrDuplicateUser       = conflictError("a user with this id already exists")
+	ErrConcurrencyConflict = conflictError("the user has been modified by another process, please retry")
+	ErrUserDeleted         = conflictError("cannot perform on a deleted user")
+)
+
+// UserError is a rule violation reported by the user domain. Its kind tells the handler
+// which status to answer with and instrumentation how to class it.
+type UserError struct {
+	Kind    string
+	Message string
+}
+
+func (e *UserError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *UserError) ErrorClass() string {
+	return e.Kind
+}
+
+func notFoundError(message string) *UserError {
+	return &UserError{Kind: "not_found", Message: message}
+}
+
+func conflictError(message string) *UserError {
+	return &UserError{Kind: "conflict", Message: message}
+}
+
+type Event = aggregate.Event
+
+// Stamp identifies who performed a change on a user and when it happened.
+type Stamp = aggregate.Stamp
+
+// User is the local profile of a user signed up in Clerk, under the id Clerk gave it, which
+// is the actor recorded on every change the user makes. Clerk owns the profile, it is only
+// ever changed by its webhooks. A user deleted in Clerk is kept, so the changes it made
+// can still be attributed.
+type User struct {
+	ID        string `json:"id"`
+	Email     string `json:"email,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	ImageURL  string `json:"image_url,omitempty"`
+	// ProfileChangedAt is when Clerk last changed the profile, it orders its webhooks.
+	ProfileChangedAt time.Time `json:"profile_changed_at"`
+	Version          int       `json:"version"`
+	aggregate.Audit
+	aggregate.SoftDelete
+	aggregate.Root
+}
+
+// UserProfile is the profile of a user as Clerk sends it, everything but its id.
+type UserProfile struct {
+	Email     string
+	FirstName string
+	LastName  string
+	ImageURL  string
+	ChangedAt time.Time
+}
+
+type UserCreated struct {
+	ID        string
+	Email     string
+	FirstName string
+	LastName  string
+	ImageURL  string
+	Version   int
+}
+
+type UserUpdated struct {
+	ID        string
+	Email     string
+	FirstName string
+	LastName  string
+	ImageURL  string
+	Version   int
+}
+
+type UserDeleted struct {
+	ID      string
+	Version int
+}
+
+func NewUser(id string, profile UserProfile, stamp Stamp) *User {
+	user := &User{
+		ID:      id,
+		Version: 1,
+		Audit:   aggregate.NewAudit(stamp),
+	}
+	user.setProfile(profile)
+
+	event := UserCreated{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		ImageURL:  user.ImageURL,
+		Version:   user.Version,
+	}
+	user.Record(event)
+	return user
+}
+
+// Supersedes reports whether the profile is newer than the one the user has. Clerk may
+// deliver its webhooks more than once and out of order, older profiles are ignored.
+func (u *User) Supersedes(profile UserProfile) bool {
+	return profile.ChangedAt.After(u.ProfileChangedAt)
+}
+
+// Update replaces the profile of the user.
+func (u *User) Update(profile UserProfile, version int, stamp Stamp) error {
+	if u.Deleted() {
+		return ErrUserDeleted
+	}
+	if err := aggregate.CheckVersion(u.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	u.setProfile(profile)
+	u.Version++
+	u.Touch(stamp)
+
+	event := UserUpdated{
+		ID:        u.ID,
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		ImageURL:  u.ImageURL,
+		Version:   u.Version,
+	}
+	u.Record(event)
+	return nil
+}
+
+// Delete marks the user deleted. Clerk does not give its ids out again, so a deleted user
+// is never restored.
+func (u *User) Delete(version int, stamp Stamp) error {
+	if u.Deleted() {
+		return ErrUserDeleted
+	}
+	if err := aggregate.CheckVersion(u.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	u.MarkDeleted(stamp.At)
+	u.Version++
+	u.Touch(stamp)
+
+	event := UserDeleted{
+		ID:      u.ID,
+		Version: u.Version,
+	}
+	u.Record(event)
+	return nil
+}
+
+func (u *User) setProfile(profile UserProfile) {
+	u.Email = profile.Email
+	u.FirstName = profile.FirstName
+	u.LastName = profile.LastName
+	u.ImageURL = profile.ImageURL
+	u.ProfileChangedAt = profile.ChangedAt
+}
+
+type UserRepository interface {
+	// SaveUser stores a new user, failing with ErrDuplicateUser when the id is taken,
+	// deleted users included.
+	SaveUser(ctx context.Context, user *User) error
+	// GetUser loads a user, deleted ones included, or fails with ErrUserNotFound.
+	GetUser(ctx context.Context, id string) (*User, error)
+	// UpdateUser stores a change of a user still at the version it was loaded with, or
+	// fails with ErrConcurrencyConflict.
+	UpdateUser(ctx context.Context, user *User) error
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = Stamp{
+	By: "clerk",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testProfile = UserProfile{
+	Email:     "anna@atelier-nord.example",
+	FirstName: "Anna",
+	LastName:  "Nowak",
+	ChangedAt: time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC),
+}
+
+func TestUser_Supersedes(t *testing.T) {
+	testCases := []struct {
+		name      string
+		changedAt time.Time
+		expected  bool
+	}{
+		{name: "Newer profile", changedAt: testProfile.ChangedAt.Add(time.Second), expected: true},
+		{name: "Same profile sent again", changedAt: testProfile.ChangedAt},
+		{name: "Older profile", changedAt: testProfile.ChangedAt.Add(-time.Minute)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			user := NewUser("user_2abc", testProfile, testStamp)
+			profile := testProfile
+			profile.ChangedAt = tc.changedAt
+
+			// --- Act ---
+			supersedes := user.Supersedes(profile)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expected, supersedes)
+		})
+	}
+}
+
+func TestUser_Update(t *testing.T) {
+	testCases := []struct {
+		name        string
+		deleted     bool
+		version     int
+		expectedErr error
+	}{
+		{name: "Current version", version: 1},
+		{name: "Stale version", version: 2, expectedErr: ErrConcurrencyConflict},
+		{name: "Deleted user", deleted: true, version: 1, expectedErr: ErrUserDeleted},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			user := NewUser("user_2abc", testProfile, testStamp)
+			if tc.deleted {
+				user.MarkDeleted(testStamp.At)
+			}
+			profile := testProfile
+			profile.LastName = "Kowalska"
+			profile.ChangedAt = profile.ChangedAt.Add(time.Hour)
+
+			// --- Act ---
+			err := user.Update(profile, tc.version, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Equal(t, "Nowak", user.LastName)
+				assert.Len(t, user.Events(), 1, "a rejected change must not record an event")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, profile.ChangedAt, user.ProfileChangedAt)
+			assert.Equal(t, UserUpdated{
+				ID: "user_2abc", Email: profile.Email, FirstName: "Anna", LastName: "Kowalska", Version: 2,
+			}, user.Events()[1])
+		})
+	}
+}
+
+func TestUser_Delete(t *testing.T) {
+	// --- Arrange ---
+	user := NewUser("user_2abc", testProfile, testStamp)
+
+	// --- Act ---
+	deleteErr := user.Delete(1, testStamp)
+	againErr := user.Delete(2, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, deleteErr)
+	assert.ErrorIs(t, againErr, ErrUserDeleted)
+	assert.True(t, user.Deleted())
+	assert.Equal(t, "Anna", user.FirstName, "a deleted user keeps its profile for attribution")
+	assert.Equal(t, UserDeleted{ID: "user_2abc", Version: 2}, user.Events()[1])
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/users/domain"
+)
+
+const (
+	clerkUserCreated = "user.created"
+	clerkUserUpdated = "user.updated"
+	clerkUserDeleted = "user.deleted"
+)
+
+// how far the timestamp of a webhook may be from now, older deliveries are taken as replays
+const webhookTolerance = 5 * time.Minute
+
+// the largest webhook body read, Clerk user payloads are a few kilobytes
+const maxWebhookBytes = 1_048_576
+
+var errInvalidSignature = errors.New("the webhook signature is invalid")
+
+// UserSyncService mirrors the users of Clerk.
+type UserSyncService interface {
+	SyncUser(ctx context.Context, id string, profile domain.UserProfile) (*domain.User, bool, error)
+	SyncUserDeletion(ctx context.Context, id string) (bool, error)
+}
+
+// ClerkWebhookHandler mirrors the users Clerk reports through its webhooks. Clerk signs them
+// the Svix way, with the signing secret of the endpoint; deliveries that are not signed with
+// it, or are too old, are refused. Other event types are acknowledged and ignored.
+type ClerkWebhookHandler struct {
+	service UserSyncService
+	secret  []byte
+	clock   clock.Clock
+}
+
+type clerkWebhook struct {
+	Type string    `json:"type"`
+	Data clerkUser `json:"data"`
+}
+
+type clerkUser struct {
+	ID             string `json:"id"`
+	EmailAddresses []struct {
+		ID           string `json:"id"`
+		EmailAddress string `json:"email_address"`
+	} `json:"email_addresses"`
+	PrimaryEmailAddressID string `json:"primary_email_address_id"`
+	FirstName             string `json:"first_name"`
+	LastName              string `json:"last_name"`
+	ImageURL              string `json:"image_url"`
+	// UpdatedAt is in milliseconds since the epoch.
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// ParseWebhookSecret decodes the signing secret of a Clerk webhook endpoint, given as
+// "whsec_" followed by the base64 key. An empty secret leaves the webhooks disabled.
+func ParseWebhookSecret(raw string) ([]byte, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(raw, "whsec_"))
+	if err != nil || len(key) == 0 {
+		return nil, errors.New("expected whsec_ followed by a base64 key")
+	}
+	return key, nil
+}
+
+func NewClerkWebhookHandler(service UserSyncService, secret []byte, clock clock.Clock) *ClerkWebhookHandler {
+	return &ClerkWebhookHandler{
+		service: service,
+		secret:  secret,
+		clock:   clock,
+	}
+}
+
+func (h *ClerkWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+	if len(h.secret) == 0 {
+		httpx.ErrorJSON(w, http.StatusForbidden, "the Clerk webhooks are disabled on this instance")
+		return
+	}
+	logger := httpx.GetLogger(r.Context()).With("component", "clerkWebhookHandler")
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBytes))
+	if err != nil {
+		httpx.BadRequest(w, r, fmt.Errorf("body must not be larger than %d bytes", maxWebhookBytes))
+		return
+	}
+	if err := h.verify(r.Header, body); err != nil {
+		logger.Warn("Clerk webhook refused", "error", err, "svix_id", r.Header.Get("svix-id"))
+		httpx.ErrorJSON(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var webhook clerkWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		httpx.BadRequest(w, r, errors.New("body contains badly-formed JSON"))
+		return
+	}
+	if webhook.Data.ID == "" {
+		httpx.ValidationError(w, r, map[string]string{"data.id": "data.id must be provided"})
+		return
+	}
+
+	ctx := command.WithUserID(r.Context(), command.ActorClerk)
+	var changed bool
+	switch webhook.Type {
+	case clerkUserCreated, clerkUserUpdated:
+		_, changed, err = h.service.SyncUser(ctx, webhook.Data.ID, webhook.Data.profile())
+	case clerkUserDeleted:
+		changed, err = h.service.SyncUserDeletion(ctx, webhook.Data.ID)
+	default:
+		logger.Info("Ignoring Clerk webhook", "type", webhook.Type, "svix_id", r.Header.Get("svix-id"))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		writeUserError(w, r, err)
+		return
+	}
+
+	logger.Info("User synced from Clerk", "type", webhook.Type, "user_id", webhook.Data.ID, "changed", changed)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verify checks the Svix signature of the webhook: an HMAC-SHA256 of its id, timestamp and
+// body, one of the space separated "v1,<base64>" signatures it carries, sent recently.
+func (h *ClerkWebhookHandler) verify(header http.Header, body []byte) error {
+	id, timestamp, signatures := header.Get("svix-id"), header.Get("svix-timestamp"), header.Get("svix-signature")
+	if id == "" || timestamp == "" || signatures == "" {
+		return errors.New("the webhook signature headers must be provided")
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errInvalidSignature
+	}
+	age := h.clock.Now().Sub(time.Unix(seconds, 0))
+	if age > webhookTolerance || age < -webhookTolerance {
+		return errors.New("the webhook timestamp is too far from now")
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	for _, signature := range strings.Fields(signatures) {
+		version, encoded, ok := strings.Cut(signature, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		presented, err := base64.StdEncoding.DecodeString(encoded)
+		if err == nil && hmac.Equal(presented, expected) {
+			return nil
+		}
+	}
+	return errInvalidSignature
+}
+
+// profile maps the Clerk user to its local profile, with its primary email address.
+func (u clerkUser) profile() domain.UserProfile {
+	profile := domain.UserProfile{
+		FirstName: strings.TrimSpace(u.FirstName),
+		LastName:  strings.TrimSpace(u.LastName),
+		ImageURL:  u.ImageURL,
+		ChangedAt: time.UnixMilli(u.UpdatedAt).UTC(),
+	}
+	for _, address := range u.EmailAddresses {
+		if address.ID == u.PrimaryEmailAddressID {
+			profile.Email = strings.ToLower(strings.TrimSpace(address.EmailAddress))
+		}
+	}
+	return profile
+}
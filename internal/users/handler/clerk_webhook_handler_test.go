@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/users/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+var testSecret = []byte("clerk-webhook-test-secret")
+
+const userCreatedBody = `{
+	"type": "user.created",
+	"data": {
+		"id": "user_2abc",
+		"email_addresses": [
+			{"id": "idn_1", "email_address": "old@atelier-nord.example"},
+			{"id": "idn_2", "email_address": " Anna@Atelier-Nord.example "}
+		],
+		"primary_email_address_id": "idn_2",
+		"first_name": "Anna",
+		"last_name": "Nowak",
+		"image_url": "https://img.clerk.com/anna.png",
+		"updated_at": 1735729200000,
+		"object": "user"
+	}
+}`
+
+type mockUserSyncService struct {
+	syncedID    string
+	syncedActor string
+	profile     domain.UserProfile
+	deletedID   string
+}
+
+func (m *mockUserSyncService) SyncUser(
+	ctx context.Context, id string, profile domain.UserProfile,
+) (*domain.User, bool, error) {
+	m.syncedID, m.syncedActor, m.profile = id, command.Actor(ctx), profile
+	return &domain.User{ID: id}, true, nil
+}
+
+func (m *mockUserSyncService) SyncUserDeletion(ctx context.Context, id string) (bool, error) {
+	m.deletedID = id
+	return true, nil
+}
+
+// sign sets the Svix headers of a webhook sent at the given time, signed with the secret.
+func sign(req *http.Request, secret []byte, body string, sentAt time.Time) {
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("msg_1." + timestamp + "." + body))
+	req.Header.Set("svix-id", "msg_1")
+	req.Header.Set("svix-timestamp", timestamp)
+	req.Header.Set("svix-signature", "v1,bm90IHRoaXMgb25l v1,"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+func serveWebhook(t *testing.T, handler http.Handler, body string, prepare func(req *http.Request)) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/webhooks/clerk", strings.NewReader(body))
+	require.NoError(t, err)
+	prepare(req)
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestClerkWebhookHandler_UserCreated(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockUserSyncService{}
+	handler := NewClerkWebhookHandler(svc, testSecret, clock.NewFixed(testNow))
+
+	// --- Act ---
+	responseRecorder := serveWebhook(t, handler, userCreatedBody, func(req *http.Request) {
+		sign(req, testSecret, userCreatedBody, testNow.Add(-time.Minute))
+	})
+
+	// --- Assert ---
+	require.Equal(t, http.StatusNoContent, responseRecorder.Code, responseRecorder.Body.String())
+	assert.Equal(t, "user_2abc", svc.syncedID)
+	assert.Equal(t, command.ActorClerk, svc.syncedActor)
+	assert.Equal(t, domain.UserProfile{
+		Email:     "anna@atelier-nord.example",
+		FirstName: "Anna",
+		LastName:  "Nowak",
+		ImageURL:  "https://img.clerk.com/anna.png",
+		ChangedAt: time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC),
+	}, svc.profile)
+}
+
+func TestClerkWebhookHandler_UserDeleted(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockUserSyncService{}
+	handler := NewClerkWebhookHandler(svc, testSecret, clock.NewFixed(testNow))
+	body := `{"type": "user.deleted", "data": {"id": "user_2abc", "deleted": true, "object": "user"}}`
+
+	// --- Act ---
+	responseRecorder := serveWebhook(t, handler, body, func(req *http.Request) {
+		sign(req, testSecret, body, testNow)
+	})
+
+	// --- Assert ---
+	require.Equal(t, http.StatusNoContent, responseRecorder.Code)
+	assert.Equal(t, "user_2abc", svc.deletedID)
+}
+
+func TestClerkWebhookHandler_Refused(t *testing.T) {
+	testCases := []struct {
+		name           string
+		secret         []byte
+		prepare        func(req *http.Request)
+		expectedStatus int
+	}{
+		{
+			name:           "Webhooks disabled",
+			prepare:        func(req *http.Request) { sign(req, testSecret, userCreatedBody, testNow) },
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Not signed",
+			secret:         testSecret,
+			prepare:        func(req *http.Request) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Signed with another secret",
+			secret:         testSecret,
+			prepare:        func(req *http.Request) { sign(req, []byte("another-secret"), userCreatedBody, testNow) },
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "Body tampered with",
+			secret: testSecret,
+			prepare: func(req *http.Request) {
+				sign(req, testSecret, strings.Replace(userCreatedBody, "Anna", "Eve", 1), testNow)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Replayed",
+			secret:         testSecret,
+			prepare:        func(req *http.Request) { sign(req, testSecret, userCreatedBody, testNow.Add(-time.Hour)) },
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockUserSyncService{}
+			handler := NewClerkWebhookHandler(svc, tc.secret, clock.NewFixed(testNow))
+
+			// --- Act ---
+			responseRecorder := serveWebhook(t, handler, userCreatedBody, tc.prepare)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Empty(t, svc.syncedID, "a refused webhook must not be applied")
+		})
+	}
+}
+
+func TestParseWebhookSecret(t *testing.T) {
+	// --- Act ---
+	key, err := ParseWebhookSecret("whsec_" + base64.StdEncoding.EncodeToString(testSecret))
+	_, invalidErr := ParseWebhookSecret("whsec_not base64")
+	disabled, disabledErr := ParseWebhookSecret("")
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, testSecret, key)
+	assert.Error(t, invalidErr)
+	require.NoError(t, disabledErr)
+	assert.Nil(t, disabled)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/users/domain"
+)
+
+// UserQueryRepository reads the users.
+type UserQueryRepository interface {
+	GetUser(ctx context.Context, id string) (*domain.User, error)
+}
+
+// UserQueryHandler serves the profile of the authenticated user. Users are only known once
+// Clerk has reported them, until then the profile is not found.
+type UserQueryHandler struct {
+	users UserQueryRepository
+}
+
+func NewUserQueryHandler(users UserQueryRepository) *UserQueryHandler {
+	return &UserQueryHandler{
+		users: users,
+	}
+}
+
+func (h *UserQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	id := command.GetUserID(r.Context())
+	if id == "" {
+		httpx.ErrorJSON(w, http.StatusUnauthorized, "an authenticated user is required")
+		return
+	}
+
+	user, err := h.users.GetUser(r.Context(), id)
+	if err != nil {
+		writeUserError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"user": user}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func writeUserError(w http.ResponseWriter, r *http.Request, err error) {
+	var userErr *domain.UserError
+	if !errors.As(err, &userErr) {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch userErr.Kind {
+	case "not_found":
+		httpx.NotFound(w, r)
+	default:
+		httpx.ErrorJSON(w, http.StatusConflict, userErr.Message)
+	}
+}
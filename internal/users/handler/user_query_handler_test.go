@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/users/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockUserQueryRepository struct{}
+
+func (m *mockUserQueryRepository) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	if id != "user_2abc" {
+		return nil, domain.ErrUserNotFound
+	}
+	return &domain.User{ID: id, Email: "anna@atelier-nord.example", FirstName: "Anna", Version: 1}, nil
+}
+
+func TestUserQueryHandler_GetMe(t *testing.T) {
+	testCases := []struct {
+		name           string
+		userID         string
+		expectedStatus int
+	}{
+		{name: "Known user", userID: "user_2abc", expectedStatus: http.StatusOK},
+		{name: "User not reported by Clerk yet", userID: "user_2def", expectedStatus: http.StatusNotFound},
+		{name: "No principal", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewUserQueryHandler(&mockUserQueryRepository{})
+			req, err := http.NewRequest(http.MethodGet, "/v1/users/me", nil)
+			require.NoError(t, err)
+			if tc.userID != "" {
+				req = req.WithContext(command.WithUserID(req.Context(), tc.userID))
+			}
+
+			// --- Act ---
+			responseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(responseRecorder, req)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				User domain.User `json:"user"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+			assert.Equal(t, "user_2abc", body.User.ID)
+			assert.Equal(t, "anna@atelier-nord.example", body.User.Email)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+	"github.com/salesworks/s-works/api/internal/users/domain"
+)
+
+// InstrumentedUserRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedUserRepository struct {
+	next domain.UserRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedUserRepository(next domain.UserRepository, rec *instrument.Recorder) *InstrumentedUserRepository {
+	return &InstrumentedUserRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedUserRepository) SaveUser(ctx context.Context, user *domain.User) error {
+	return instrument.Exec(ctx, r.rec, "SaveUser", func(ctx context.Context) error {
+		return r.next.SaveUser(ctx, user)
+	})
+}
+
+func (r *InstrumentedUserRepository) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	return instrument.Call(ctx, r.rec, "GetUser", func(ctx context.Context) (*domain.User, error) {
+		return r.next.GetUser(ctx, id)
+	})
+}
+
+func (r *InstrumentedUserRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	return instrument.Exec(ctx, r.rec, "UpdateUser", func(ctx context.Context) error {
+		return r.next.UpdateUser(ctx, user)
+	})
+}
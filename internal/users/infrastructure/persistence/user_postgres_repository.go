@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/salesworks/s-works/api/internal/users/domain"
+)
+
+const userColumns = `id, email, first_name, last_name, image_url, profile_changed_at, version,
+	created_at, created_by, updated_at, updated_by, deleted_at`
+
+type UserPostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewUserPostgresRepository(db *database.PostgresDB) *UserPostgresRepository {
+	return &UserPostgresRepository{
+		db: db,
+	}
+}
+
+func (r *UserPostgresRepository) SaveUser(ctx context.Context, user *domain.User) error {
+	query := `
+		INSERT INTO users (` + userColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		user.ID, user.Email, user.FirstName, user.LastName, user.ImageURL, user.ProfileChangedAt,
+		user.Version, user.CreatedAt, user.CreatedBy, user.UpdatedAt, user.UpdatedBy, user.DeletedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrDuplicateUser
+		}
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+	return nil
+}
+
+func (r *UserPostgresRepository) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+	user := &domain.User{}
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query, id).Scan(
+		&user.ID, &user.Email, &user.FirstName, &user.LastName, &user.ImageURL, &user.ProfileChangedAt,
+		&user.Version, &user.CreatedAt, &user.CreatedBy, &user.UpdatedAt, &user.UpdatedBy, &user.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// UpdateUser stores the profile and deletion of the user, provided nobody changed it
+// since it was loaded.
+func (r *UserPostgresRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE users
+		SET email = $1, first_name = $2, last_name = $3, image_url = $4, profile_changed_at = $5,
+			version = $6, updated_at = $7, updated_by = $8, deleted_at = $9
+		WHERE id = $10 AND version = $11
+	`, user.Email, user.FirstName, user.LastName, user.ImageURL, user.ProfileChangedAt,
+		user.Version, user.UpdatedAt, user.UpdatedBy, user.DeletedAt,
+		user.ID, user.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+	return nil
+}
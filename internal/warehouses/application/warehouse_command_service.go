@@ -0,0 +1,173 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/eventstore"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/platform/telemetry"
+	"github.com/salesworks/s-works/api/internal/warehouses/domain"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WarehouseService maintains the warehouses and publishes their events.
+type WarehouseService struct {
+	repo         domain.WarehouseRepository
+	eventStore   eventstore.Store
+	clock        clock.Clock
+	eventChannel string
+	source       messaging.Source
+}
+
+func NewWarehouseCommandService(
+	repo domain.WarehouseRepository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+	source messaging.Source,
+) *WarehouseService {
+	return &WarehouseService{
+		repo:         repo,
+		eventStore:   eventStore,
+		clock:        clock,
+		eventChannel: "app.warehouse",
+		source:       source,
+	}
+}
+
+func (s *WarehouseService) CreateWarehouse(
+	ctx context.Context, code string, details domain.WarehouseDetails,
+) (*domain.Warehouse, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "warehouse.service.create")
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "warehouse.service")
+
+	warehouse := domain.NewWarehouse(code, details, aggregate.StampNow(ctx, s.clock))
+	if err := s.repo.SaveWarehouse(ctx, warehouse); err != nil {
+		return nil, s.failed(span, logger, "saving warehouse failed", err)
+	}
+
+	if err := s.publish(ctx, warehouse); err != nil {
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+func (s *WarehouseService) UpdateWarehouse(
+	ctx context.Context, code string, details domain.WarehouseDetails, version int,
+) (*domain.Warehouse, error) {
+	return s.change(ctx, code, "warehouse.service.update", func(warehouse *domain.Warehouse, stamp domain.Stamp) error {
+		return warehouse.Update(details, version, stamp)
+	})
+}
+
+// DeleteWarehouse deletes a warehouse no fabric is on hand at any more, so no stock is left
+// attributed to a site that is gone.
+func (s *WarehouseService) DeleteWarehouse(ctx context.Context, code string, version int) (*domain.Warehouse, error) {
+	holdsStock, err := s.repo.HoldsStock(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if holdsStock {
+		return nil, domain.ErrWarehouseHoldsStock
+	}
+	return s.change(ctx, code, "warehouse.service.delete", func(warehouse *domain.Warehouse, stamp domain.Stamp) error {
+		return warehouse.Delete(version, stamp)
+	})
+}
+
+func (s *WarehouseService) RestoreWarehouse(ctx context.Context, code string, version int) (*domain.Warehouse, error) {
+	return s.change(ctx, code, "warehouse.service.restore", func(warehouse *domain.Warehouse, stamp domain.Stamp) error {
+		return warehouse.Restore(version, stamp)
+	})
+}
+
+// change loads the warehouse, applies a command to it, stores it and publishes its events.
+func (s *WarehouseService) change(
+	ctx context.Context, code, spanName string, apply func(warehouse *domain.Warehouse, stamp domain.Stamp) error,
+) (*domain.Warehouse, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, spanName)
+	defer span.End()
+	logger := httpx.GetLogger(ctx).With("component", "warehouse.service")
+
+	warehouse, err := s.repo.GetWarehouse(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(warehouse, aggregate.StampNow(ctx, s.clock)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateWarehouse(ctx, warehouse); err != nil {
+		return nil, s.failed(span, logger, "updating warehouse failed", err)
+	}
+
+	if err := s.publish(ctx, warehouse); err != nil {
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+// failed reports a repository write that did not succeed. Warehouse errors are passed
+// through as they are, anything else is wrapped and recorded as a database error.
+func (s *WarehouseService) failed(span trace.Span, logger *slog.Logger, msg string, err error) error {
+	var warehouseErr *domain.WarehouseError
+	if errors.As(err, &warehouseErr) {
+		return err
+	}
+	wrappedErr := fmt.Errorf("failed to write warehouse in repo: %w", err)
+	logger.Error(msg, "error", wrappedErr)
+	span.RecordError(wrappedErr)
+	span.SetStatus(codes.Error, "database write error")
+	return wrappedErr
+}
+
+func (s *WarehouseService) publish(ctx context.Context, warehouse *domain.Warehouse) error {
+	logger := httpx.GetLogger(ctx).With("component", "warehouse.service")
+
+	var envelopesToPublish []*messaging.EventEnvelope
+	for _, event := range warehouse.Events() {
+		var eventType string
+		switch event.(type) {
+		case domain.WarehouseCreated:
+			eventType = "app.warehouse.created"
+		case domain.WarehouseUpdated:
+			eventType = "app.warehouse.updated"
+		case domain.WarehouseDeleted:
+			eventType = "app.warehouse.deleted"
+		case domain.WarehouseRestored:
+			eventType = "app.warehouse.restored"
+		default:
+			continue
+		}
+
+		envelope := messaging.NewEventEnvelope(
+			eventType,
+			warehouse.Code,
+			"Warehouse",
+			warehouse.Version,
+			event,
+			messaging.WithClock(s.clock),
+			messaging.WithSource(s.source.Service, s.source.Instance),
+		)
+		envelope.UserID = command.Actor(ctx)
+		envelopesToPublish = append(envelopesToPublish, envelope)
+	}
+
+	if len(envelopesToPublish) > 0 {
+		if err := s.eventStore.SaveAndEnqueue(ctx, s.eventChannel, envelopesToPublish...); err != nil {
+			wrappedErr := fmt.Errorf("failed to save warehouse event to event store: %w", err)
+			logger.Error("saving warehouse event failed", "error", wrappedErr)
+			return wrappedErr
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salesworks/s-works/api/internal/platform/clock"
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/messaging"
+	"github.com/salesworks/s-works/api/internal/warehouses/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = domain.Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testSource = messaging.Source{Service: "s-works-api", Instance: "test-instance"}
+
+var testDetails = domain.WarehouseDetails{Name: "Łódź central", Address: "ul. Tkacka 4, 90-001 Łódź", Country: "PL"}
+
+type mockWarehouseRepository struct {
+	warehouses  map[string]*domain.Warehouse
+	stocked     map[string]bool
+	saved       *domain.Warehouse
+	errToReturn error
+}
+
+func (m *mockWarehouseRepository) SaveWarehouse(ctx context.Context, warehouse *domain.Warehouse) error {
+	if m.errToReturn != nil {
+		return m.errToReturn
+	}
+	m.saved = warehouse
+	return nil
+}
+
+func (m *mockWarehouseRepository) GetWarehouse(ctx context.Context, code string) (*domain.Warehouse, error) {
+	warehouse, ok := m.warehouses[code]
+	if !ok {
+		return nil, domain.ErrWarehouseNotFound
+	}
+	warehouseCopy := *warehouse
+	return &warehouseCopy, nil
+}
+
+func (m *mockWarehouseRepository) ListWarehouses(ctx context.Context, limit, offset int) ([]*domain.Warehouse, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockWarehouseRepository) UpdateWarehouse(ctx context.Context, warehouse *domain.Warehouse) error {
+	return m.SaveWarehouse(ctx, warehouse)
+}
+
+func (m *mockWarehouseRepository) HoldsStock(ctx context.Context, code string) (bool, error) {
+	return m.stocked[code], nil
+}
+
+func (m *mockWarehouseRepository) ListStock(
+	ctx context.Context, code string, limit, offset int,
+) ([]domain.LocationStock, int, error) {
+	return nil, 0, nil
+}
+
+type mockEventStore struct {
+	SavedCalled      bool
+	EnqueuedSubject  string
+	EnqueuedEnvelope *messaging.EventEnvelope
+}
+
+func (m *mockEventStore) Save(ctx context.Context, envelopes ...*messaging.EventEnvelope) error {
+	m.SavedCalled = true
+	return nil
+}
+
+func (m *mockEventStore) SaveAndEnqueue(
+	ctx context.Context, subject string, envelopes ...*messaging.EventEnvelope,
+) error {
+	m.SavedCalled = true
+	m.EnqueuedSubject = subject
+	m.EnqueuedEnvelope = envelopes[len(envelopes)-1]
+	return nil
+}
+
+func newTestRepository() *mockWarehouseRepository {
+	warehouse := &domain.Warehouse{Code: "WH-LDZ", Name: "Łódź central", Country: "PL", Version: 1}
+	stocked := &domain.Warehouse{Code: "WH-WAW", Name: "Warsaw", Country: "PL", Version: 1}
+	return &mockWarehouseRepository{
+		warehouses: map[string]*domain.Warehouse{warehouse.Code: warehouse, stocked.Code: stocked},
+		stocked:    map[string]bool{stocked.Code: true},
+	}
+}
+
+func TestWarehouseService_CreateWarehouse_HappyPath(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewWarehouseCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+	ctx := command.WithUserID(context.Background(), "user_test")
+
+	// --- Act ---
+	warehouse, err := service.CreateWarehouse(ctx, "WH-KRK", testDetails)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	require.NotNil(t, repo.saved, "expected SaveWarehouse() to be called on the repository")
+	assert.Equal(t, testDetails.Address, warehouse.Address)
+
+	publishedEnvelope := eventStore.EnqueuedEnvelope
+	require.NotNil(t, publishedEnvelope)
+	assert.Equal(t, "app.warehouse", eventStore.EnqueuedSubject)
+	assert.Equal(t, "app.warehouse.created", publishedEnvelope.EventType)
+	assert.Equal(t, "Warehouse", publishedEnvelope.AggregateType)
+	assert.Equal(t, "WH-KRK", publishedEnvelope.AggregateID)
+	assert.Equal(t, "user_test", publishedEnvelope.UserID)
+}
+
+func TestWarehouseService_DeleteWarehouse(t *testing.T) {
+	// --- Arrange ---
+	repo := newTestRepository()
+	eventStore := &mockEventStore{}
+	service := NewWarehouseCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+	// --- Act ---
+	warehouse, err := service.DeleteWarehouse(context.Background(), "WH-LDZ", 1)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.True(t, warehouse.Deleted())
+	assert.Equal(t, "app.warehouse.deleted", eventStore.EnqueuedEnvelope.EventType)
+}
+
+func TestWarehouseService_RejectedIsNotPublished(t *testing.T) {
+	testCases := []struct {
+		name        string
+		errToReturn error
+		run         func(s *WarehouseService) error
+		expectedErr error
+	}{
+		{
+			name: "Stale version",
+			run: func(s *WarehouseService) error {
+				_, err := s.UpdateWarehouse(context.Background(), "WH-LDZ", testDetails, 2)
+				return err
+			},
+			expectedErr: domain.ErrConcurrencyConflict,
+		},
+		{
+			name: "Code taken",
+			run: func(s *WarehouseService) error {
+				_, err := s.CreateWarehouse(context.Background(), "WH-LDZ", testDetails)
+				return err
+			},
+			errToReturn: domain.ErrDuplicateWarehouseCode,
+			expectedErr: domain.ErrDuplicateWarehouseCode,
+		},
+		{
+			name: "Warehouse holding stock",
+			run: func(s *WarehouseService) error {
+				_, err := s.DeleteWarehouse(context.Background(), "WH-WAW", 1)
+				return err
+			},
+			expectedErr: domain.ErrWarehouseHoldsStock,
+		},
+		{
+			name: "Restore of a live warehouse",
+			run: func(s *WarehouseService) error {
+				_, err := s.RestoreWarehouse(context.Background(), "WH-LDZ", 1)
+				return err
+			},
+			expectedErr: domain.ErrWarehouseNotDeleted,
+		},
+		{
+			name: "Unknown warehouse",
+			run: func(s *WarehouseService) error {
+				_, err := s.DeleteWarehouse(context.Background(), "NOSUCH", 1)
+				return err
+			},
+			expectedErr: domain.ErrWarehouseNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			repo := newTestRepository()
+			repo.errToReturn = tc.errToReturn
+			eventStore := &mockEventStore{}
+			service := NewWarehouseCommandService(repo, eventStore, clock.NewFixed(testStamp.At), testSource)
+
+			// --- Act ---
+			err := tc.run(service)
+
+			// --- Assert ---
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, repo.saved)
+			assert.False(t, eventStore.SavedCalled, "a rejected command must not be stored")
+		})
+	}
+}
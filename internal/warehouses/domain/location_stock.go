@@ -0,0 +1,13 @@
+package domain
+
+import "github.com/salesworks/s-works/api/internal/platform/quantity"
+
+// Quantity is an amount of a fabric in its measure unit, in thousandths of the unit.
+type Quantity = quantity.Quantity
+
+// LocationStock is the quantity of a fabric on hand at a warehouse. It is kept by the
+// stock of the fabric, which adjustments attributed to the warehouse change.
+type LocationStock struct {
+	FabricCode string   `json:"fabric_code"`
+	OnHand     Quantity `json:"on_hand"`
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/platform/aggregate"
+)
+
+var (
+	ErrWarehouseNotFound      = notFoundError("warehouse not found")
+	ErrDuplicateWarehouseCode = conflictError("a warehouse with this code already exists")
+	ErrConcurrencyConflict    = conflictError("the warehouse has been modified by another process, please refresh and try again")
+	ErrWarehouseDeleted       = conflictError("cannot perform on a deleted warehouse")
+	ErrWarehouseNotDeleted    = conflictError("the warehouse is not deleted")
+	ErrWarehouseHoldsStock    = conflictError("a warehouse holding stock cannot be deleted, adjust its stock away first")
+)
+
+// WarehouseError is a rule violation reported by the warehouse domain. Its kind tells the
+// handler which status to answer with and instrumentation how to class it.
+type WarehouseError struct {
+	Kind    string
+	Message string
+}
+
+func (e *WarehouseError) Error() string {
+	return e.Message
+}
+
+// ErrorClass reports the kind of the error to instrumentation.
+func (e *WarehouseError) ErrorClass() string {
+	return e.Kind
+}
+
+func notFoundError(message string) *WarehouseError {
+	return &WarehouseError{Kind: "not_found", Message: message}
+}
+
+func conflictError(message string) *WarehouseError {
+	return &WarehouseError{Kind: "conflict", Message: message}
+}
+
+type Event = aggregate.Event
+
+// Stamp identifies who performed a change on a warehouse and when it happened.
+type Stamp = aggregate.Stamp
+
+// Warehouse is a site fabrics are stocked at, stock adjustments of fabrics are attributed
+// to it. A deleted warehouse is kept, so it can be restored and its code is not given to
+// another one.
+type Warehouse struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Address string `json:"address,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 code of the country the warehouse is in.
+	Country string `json:"country,omitempty"`
+	Version int    `json:"version"`
+	aggregate.Audit
+	aggregate.SoftDelete
+	aggregate.Root
+}
+
+// WarehouseDetails is the master data of a warehouse, everything but its code.
+type WarehouseDetails struct {
+	Name    string
+	Address string
+	Country string
+}
+
+type WarehouseCreated struct {
+	Code    string
+	Name    string
+	Address string
+	Country string
+	Version int
+}
+
+type WarehouseUpdated struct {
+	Code    string
+	Name    string
+	Address string
+	Country string
+	Version int
+}
+
+type WarehouseDeleted struct {
+	Code    string
+	Version int
+}
+
+type WarehouseRestored struct {
+	Code    string
+	Version int
+}
+
+func NewWarehouse(code string, details WarehouseDetails, stamp Stamp) *Warehouse {
+	warehouse := &Warehouse{
+		Code:    code,
+		Version: 1,
+		Audit:   aggregate.NewAudit(stamp),
+	}
+	warehouse.setDetails(details)
+
+	event := WarehouseCreated{
+		Code:    warehouse.Code,
+		Name:    warehouse.Name,
+		Address: warehouse.Address,
+		Country: warehouse.Country,
+		Version: warehouse.Version,
+	}
+	warehouse.Record(event)
+	return warehouse
+}
+
+// Update replaces the master data of the warehouse.
+func (w *Warehouse) Update(details WarehouseDetails, version int, stamp Stamp) error {
+	if w.Deleted() {
+		return ErrWarehouseDeleted
+	}
+	if err := aggregate.CheckVersion(w.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	w.setDetails(details)
+	w.Version++
+	w.Touch(stamp)
+
+	event := WarehouseUpdated{
+		Code:    w.Code,
+		Name:    w.Name,
+		Address: w.Address,
+		Country: w.Country,
+		Version: w.Version,
+	}
+	w.Record(event)
+	return nil
+}
+
+// Delete marks the warehouse deleted, it keeps its code until it is restored. Whether it
+// still holds stock is up to the caller to check, the stock is not part of the aggregate.
+func (w *Warehouse) Delete(version int, stamp Stamp) error {
+	if w.Deleted() {
+		return ErrWarehouseDeleted
+	}
+	if err := aggregate.CheckVersion(w.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	w.MarkDeleted(stamp.At)
+	w.Version++
+	w.Touch(stamp)
+
+	event := WarehouseDeleted{
+		Code:    w.Code,
+		Version: w.Version,
+	}
+	w.Record(event)
+	return nil
+}
+
+// Restore brings a deleted warehouse back with the master data it had.
+func (w *Warehouse) Restore(version int, stamp Stamp) error {
+	if !w.Deleted() {
+		return ErrWarehouseNotDeleted
+	}
+	if err := aggregate.CheckVersion(w.Version, version, ErrConcurrencyConflict); err != nil {
+		return err
+	}
+
+	w.ClearDeleted()
+	w.Version++
+	w.Touch(stamp)
+
+	event := WarehouseRestored{
+		Code:    w.Code,
+		Version: w.Version,
+	}
+	w.Record(event)
+	return nil
+}
+
+func (w *Warehouse) setDetails(details WarehouseDetails) {
+	w.Name = details.Name
+	w.Address = details.Address
+	w.Country = details.Country
+}
+
+type WarehouseRepository interface {
+	// SaveWarehouse stores a new warehouse, failing with ErrDuplicateWarehouseCode when the
+	// code is taken, deleted warehouses included.
+	SaveWarehouse(ctx context.Context, warehouse *Warehouse) error
+	// GetWarehouse loads a warehouse, deleted ones included, or fails with ErrWarehouseNotFound.
+	GetWarehouse(ctx context.Context, code string) (*Warehouse, error)
+	// ListWarehouses returns a page of the warehouses that are not deleted, by code,
+	// together with their total number.
+	ListWarehouses(ctx context.Context, limit, offset int) ([]*Warehouse, int, error)
+	// UpdateWarehouse stores a change of a warehouse still at the version it was loaded
+	// with, or fails with ErrConcurrencyConflict.
+	UpdateWarehouse(ctx context.Context, warehouse *Warehouse) error
+	// HoldsStock reports whether any fabric is on hand at the warehouse.
+	HoldsStock(ctx context.Context, code string) (bool, error)
+	// ListStock returns a page of the fabrics on hand at the warehouse, by fabric code,
+	// together with their total number.
+	ListStock(ctx context.Context, code string, limit, offset int) ([]LocationStock, int, error)
+}
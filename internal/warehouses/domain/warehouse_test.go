@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStamp = Stamp{
+	By: "user_test",
+	At: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var testDetails = WarehouseDetails{Name: "Łódź central", Address: "ul. Tkacka 4, 90-001 Łódź", Country: "PL"}
+
+func TestWarehouse_Update(t *testing.T) {
+	testCases := []struct {
+		name        string
+		version     int
+		expectedErr error
+	}{
+		{name: "Current version", version: 1},
+		{name: "Stale version", version: 2, expectedErr: ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			warehouse := NewWarehouse("WH-LDZ", testDetails, testStamp)
+
+			// --- Act ---
+			err := warehouse.Update(WarehouseDetails{Name: "Łódź north"}, tc.version, testStamp)
+
+			// --- Assert ---
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Len(t, warehouse.Events(), 1, "a rejected update must not record an event")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 2, warehouse.Version)
+			assert.Equal(t, WarehouseUpdated{Code: "WH-LDZ", Name: "Łódź north", Version: 2}, warehouse.Events()[1])
+		})
+	}
+}
+
+func TestWarehouse_DeleteAndRestore(t *testing.T) {
+	// --- Arrange ---
+	warehouse := NewWarehouse("WH-LDZ", testDetails, testStamp)
+
+	// --- Act ---
+	deleteErr := warehouse.Delete(1, testStamp)
+	updateErr := warehouse.Update(WarehouseDetails{Name: "Łódź north"}, 2, testStamp)
+	restoreErr := warehouse.Restore(2, testStamp)
+
+	// --- Assert ---
+	require.NoError(t, deleteErr)
+	assert.ErrorIs(t, updateErr, ErrWarehouseDeleted)
+	require.NoError(t, restoreErr)
+	assert.False(t, warehouse.Deleted())
+	assert.Equal(t, 3, warehouse.Version)
+	assert.Equal(t, testDetails.Address, warehouse.Address)
+	require.Len(t, warehouse.Events(), 3)
+	assert.IsType(t, WarehouseRestored{}, warehouse.Events()[2])
+}
+
+func TestQuantity_String(t *testing.T) {
+	testCases := []struct {
+		quantity Quantity
+		expected string
+	}{
+		{quantity: 12500, expected: "12.5"},
+		{quantity: 3000, expected: "3"},
+		{quantity: 1, expected: "0.001"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			// --- Act ---
+			formatted := tc.quantity.String()
+
+			// --- Assert ---
+			assert.Equal(t, tc.expected, formatted)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+
+	command "github.com/salesworks/s-works/api/internal/platform/context"
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/warehouses/domain"
+)
+
+var (
+	warehouseCodeRX = regexp.MustCompile("^[A-Z0-9-]+$")
+	countryRX       = regexp.MustCompile("^[A-Z]{2}$")
+)
+
+// WarehouseCommandService maintains the warehouses.
+type WarehouseCommandService interface {
+	CreateWarehouse(ctx context.Context, code string, details domain.WarehouseDetails) (*domain.Warehouse, error)
+	UpdateWarehouse(
+		ctx context.Context, code string, details domain.WarehouseDetails, version int,
+	) (*domain.Warehouse, error)
+	DeleteWarehouse(ctx context.Context, code string, version int) (*domain.Warehouse, error)
+	RestoreWarehouse(ctx context.Context, code string, version int) (*domain.Warehouse, error)
+}
+
+// WarehouseCommandHandler creates, updates, deletes and restores warehouses.
+type WarehouseCommandHandler struct {
+	service WarehouseCommandService
+}
+
+type createWarehouseRequest struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Country string `json:"country"`
+}
+
+type updateWarehouseRequest struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Country string `json:"country"`
+	Version int    `json:"version"`
+}
+
+// versionRequest carries the version a delete or restore is made against.
+type versionRequest struct {
+	Version int `json:"version"`
+}
+
+func NewWarehouseCommandHandler(service WarehouseCommandService) *WarehouseCommandHandler {
+	return &WarehouseCommandHandler{service: service}
+}
+
+func (h *WarehouseCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := command.WithCommandSource(r.Context(), command.CommandSourceREST)
+	r = r.WithContext(ctx)
+
+	switch r.Method {
+	case http.MethodPost:
+		// a warehouse is created on the collection and restored on its own resource
+		if httpx.URLParam(r, "code") != "" {
+			h.restoreWarehouse(w, r)
+			return
+		}
+		h.createWarehouse(w, r)
+	case http.MethodPut:
+		h.updateWarehouse(w, r)
+	case http.MethodDelete:
+		h.deleteWarehouse(w, r)
+	default:
+		httpx.MethodNotAllowed(w, r)
+	}
+}
+
+func (h *WarehouseCommandHandler) createWarehouse(w http.ResponseWriter, r *http.Request) {
+	var req createWarehouseRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	req.Code = validator.NormalizeCode(req.Code)
+	details := normalizeDetails(req.Name, req.Address, req.Country)
+	v := validator.New()
+	v.Check(req.Code != "", "code", "code must be provided")
+	v.Check(len(req.Code) >= 2 && len(req.Code) <= 30, "code", "code must be between 2 and 30 characters long")
+	v.Check(validator.Matches(req.Code, warehouseCodeRX), "code", "code must only contain uppercase letters, numbers and dashes")
+	validateWarehouse(v, details)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	warehouse, err := h.service.CreateWarehouse(r.Context(), req.Code, details)
+	if err != nil {
+		writeWarehouseError(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", "/v1/warehouses/"+warehouse.Code)
+	if err := httpx.WriteJSON(w, http.StatusCreated, httpx.Envelope{"warehouse": warehouse}, headers); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *WarehouseCommandHandler) updateWarehouse(w http.ResponseWriter, r *http.Request) {
+	var req updateWarehouseRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return
+	}
+
+	details := normalizeDetails(req.Name, req.Address, req.Country)
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	validateWarehouse(v, details)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	warehouse, err := h.service.UpdateWarehouse(r.Context(), httpx.URLParam(r, "code"), details, req.Version)
+	if err != nil {
+		writeWarehouseError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"warehouse": warehouse}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *WarehouseCommandHandler) deleteWarehouse(w http.ResponseWriter, r *http.Request) {
+	version, ok := readVersion(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.service.DeleteWarehouse(r.Context(), httpx.URLParam(r, "code"), version); err != nil {
+		writeWarehouseError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WarehouseCommandHandler) restoreWarehouse(w http.ResponseWriter, r *http.Request) {
+	version, ok := readVersion(w, r)
+	if !ok {
+		return
+	}
+
+	warehouse, err := h.service.RestoreWarehouse(r.Context(), httpx.URLParam(r, "code"), version)
+	if err != nil {
+		writeWarehouseError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"warehouse": warehouse}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// readVersion reads the version of a delete or restore, answering the request itself when
+// it is missing or invalid.
+func readVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	var req versionRequest
+	if err := httpx.ReadJSON(w, r, &req); err != nil {
+		httpx.BadRequest(w, r, err)
+		return 0, false
+	}
+
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "version must be provided and greater than 0")
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return 0, false
+	}
+	return req.Version, true
+}
+
+func normalizeDetails(name, address, country string) domain.WarehouseDetails {
+	return domain.WarehouseDetails{
+		Name:    validator.NormalizeText(name),
+		Address: validator.NormalizeText(address),
+		Country: validator.NormalizeCode(country),
+	}
+}
+
+func validateWarehouse(v *validator.Validator, details domain.WarehouseDetails) {
+	v.Check(details.Name != "", "name", "name must be provided")
+	v.Check(len(details.Name) <= 250, "name", "name must not be more than 250 characters long")
+	v.Check(len(details.Address) <= 500, "address", "address must not be more than 500 characters long")
+	if details.Country != "" {
+		v.Check(validator.Matches(details.Country, countryRX), "country", "country must be a two-letter ISO 3166-1 code")
+	}
+}
+
+// writeWarehouseError answers a failed command with the status matching the kind of
+// warehouse error, anything that is not a warehouse error is answered as an internal error.
+func writeWarehouseError(w http.ResponseWriter, r *http.Request, err error) {
+	var warehouseErr *domain.WarehouseError
+	if !errors.As(err, &warehouseErr) {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	switch warehouseErr.Kind {
+	case "not_found":
+		httpx.NotFound(w, r)
+	default:
+		httpx.ErrorJSON(w, http.StatusConflict, warehouseErr.Message)
+	}
+}
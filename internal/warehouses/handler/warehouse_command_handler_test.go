@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/salesworks/s-works/api/internal/warehouses/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockWarehouseCommandService struct {
+	called      string
+	code        string
+	details     domain.WarehouseDetails
+	version     int
+	errToReturn error
+}
+
+func (m *mockWarehouseCommandService) CreateWarehouse(
+	ctx context.Context, code string, details domain.WarehouseDetails,
+) (*domain.Warehouse, error) {
+	m.called, m.code, m.details = "create", code, details
+	return m.result(code, 1)
+}
+
+func (m *mockWarehouseCommandService) UpdateWarehouse(
+	ctx context.Context, code string, details domain.WarehouseDetails, version int,
+) (*domain.Warehouse, error) {
+	m.called, m.code, m.details, m.version = "update", code, details, version
+	return m.result(code, version+1)
+}
+
+func (m *mockWarehouseCommandService) DeleteWarehouse(ctx context.Context, code string, version int) (*domain.Warehouse, error) {
+	m.called, m.code, m.version = "delete", code, version
+	return m.result(code, version+1)
+}
+
+func (m *mockWarehouseCommandService) RestoreWarehouse(ctx context.Context, code string, version int) (*domain.Warehouse, error) {
+	m.called, m.code, m.version = "restore", code, version
+	return m.result(code, version+1)
+}
+
+func (m *mockWarehouseCommandService) result(code string, version int) (*domain.Warehouse, error) {
+	if m.errToReturn != nil {
+		return nil, m.errToReturn
+	}
+	return &domain.Warehouse{Code: code, Version: version}, nil
+}
+
+func serveWarehouse(
+	t *testing.T, handler http.Handler, method, target, body string, params map[string]string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	require.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	return responseRecorder
+}
+
+func TestWarehouseCommandHandler_CreateWarehouse(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockWarehouseCommandService{}
+	handler := NewWarehouseCommandHandler(svc)
+	body := `{"code": " wh-ldz ", "name": " Łódź  central ", "address": "ul. Tkacka 4, 90-001 Łódź", "country": "pl"}`
+
+	// --- Act ---
+	responseRecorder := serveWarehouse(t, handler, http.MethodPost, "/v1/warehouses", body, nil)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, "/v1/warehouses/WH-LDZ", responseRecorder.Header().Get("Location"))
+	assert.Equal(t, "WH-LDZ", svc.code)
+	assert.Equal(t, domain.WarehouseDetails{
+		Name: "Łódź central", Address: "ul. Tkacka 4, 90-001 Łódź", Country: "PL",
+	}, svc.details)
+}
+
+func TestWarehouseCommandHandler_RestoreWarehouse(t *testing.T) {
+	// --- Arrange ---
+	svc := &mockWarehouseCommandService{}
+	handler := NewWarehouseCommandHandler(svc)
+
+	// --- Act ---
+	responseRecorder := serveWarehouse(
+		t, handler, http.MethodPost, "/v1/warehouses/WH-LDZ/restore", `{"version": 2}`, map[string]string{"code": "WH-LDZ"},
+	)
+
+	// --- Assert ---
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "restore", svc.called)
+	assert.Equal(t, 2, svc.version)
+}
+
+func TestWarehouseCommandHandler_Rejected(t *testing.T) {
+	warehouse := map[string]string{"code": "WH-LDZ"}
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		params         map[string]string
+		errToReturn    error
+		expectedStatus int
+		expectedCall   bool
+	}{
+		{
+			name: "invalid code", method: http.MethodPost, body: `{"code": "wh ldz", "name": "Łódź central"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "invalid country", method: http.MethodPost, body: `{"code": "WH-LDZ", "name": "Łódź central", "country": "POL"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing version", method: http.MethodPut, body: `{"name": "Łódź central"}`,
+			params: warehouse, expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "duplicate code", method: http.MethodPost, body: `{"code": "WH-LDZ", "name": "Łódź central"}`,
+			errToReturn: domain.ErrDuplicateWarehouseCode, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "warehouse holding stock", method: http.MethodDelete, body: `{"version": 1}`,
+			params:      warehouse,
+			errToReturn: domain.ErrWarehouseHoldsStock, expectedStatus: http.StatusConflict, expectedCall: true,
+		},
+		{
+			name: "unknown warehouse", method: http.MethodPut, body: `{"name": "Łódź central", "version": 1}`,
+			params:      warehouse,
+			errToReturn: domain.ErrWarehouseNotFound, expectedStatus: http.StatusNotFound, expectedCall: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			svc := &mockWarehouseCommandService{errToReturn: tc.errToReturn}
+			handler := NewWarehouseCommandHandler(svc)
+
+			// --- Act ---
+			responseRecorder := serveWarehouse(t, handler, tc.method, "/v1/warehouses", tc.body, tc.params)
+
+			// --- Assert ---
+			assert.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCall, svc.called != "")
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/platform/validator"
+	"github.com/salesworks/s-works/api/internal/warehouses/domain"
+)
+
+// WarehouseQueryRepository reads the warehouses and the stock on hand at them.
+type WarehouseQueryRepository interface {
+	GetWarehouse(ctx context.Context, code string) (*domain.Warehouse, error)
+	ListWarehouses(ctx context.Context, limit, offset int) ([]*domain.Warehouse, int, error)
+	ListStock(ctx context.Context, code string, limit, offset int) ([]domain.LocationStock, int, error)
+}
+
+// WarehouseQueryHandler serves the warehouses and the fabrics on hand at each of them.
+// Deleted warehouses are left out of the list but can still be read by code, so they can
+// be restored.
+type WarehouseQueryHandler struct {
+	warehouses WarehouseQueryRepository
+	pagination httpx.PaginationConfig
+}
+
+func NewWarehouseQueryHandler(warehouses WarehouseQueryRepository, pagination httpx.PaginationConfig) *WarehouseQueryHandler {
+	return &WarehouseQueryHandler{
+		warehouses: warehouses,
+		pagination: pagination,
+	}
+}
+
+func (h *WarehouseQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w, r)
+		return
+	}
+
+	switch {
+	case httpx.URLParam(r, "code") == "":
+		h.listWarehouses(w, r)
+	case strings.HasSuffix(r.URL.Path, "/stock"):
+		h.listStock(w, r)
+	default:
+		h.getWarehouse(w, r)
+	}
+}
+
+func (h *WarehouseQueryHandler) listWarehouses(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	warehouses, totalRecords, err := h.warehouses.ListWarehouses(r.Context(), page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	err = httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"warehouses": warehouses, "metadata": metadata}, nil)
+	if err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+func (h *WarehouseQueryHandler) getWarehouse(w http.ResponseWriter, r *http.Request) {
+	warehouse, err := h.warehouses.GetWarehouse(r.Context(), validator.NormalizeCode(httpx.URLParam(r, "code")))
+	if err != nil {
+		writeWarehouseError(w, r, err)
+		return
+	}
+
+	if err := httpx.WriteJSON(w, http.StatusOK, httpx.Envelope{"warehouse": warehouse}, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
+
+// listStock serves a page of the fabrics on hand at a warehouse, which may be deleted once
+// nothing is left there.
+func (h *WarehouseQueryHandler) listStock(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	page := httpx.ReadPagination(r, h.pagination, v)
+	if !v.Valid() {
+		httpx.ValidationError(w, r, v.Errors)
+		return
+	}
+
+	warehouse, err := h.warehouses.GetWarehouse(r.Context(), validator.NormalizeCode(httpx.URLParam(r, "code")))
+	if err != nil {
+		writeWarehouseError(w, r, err)
+		return
+	}
+	stock, totalRecords, err := h.warehouses.ListStock(r.Context(), warehouse.Code, page.Limit(), page.Offset())
+	if err != nil {
+		httpx.InternalError(w, r, err)
+		return
+	}
+
+	metadata := httpx.CalculateMetadata(totalRecords, page.Page, page.PageSize)
+	env := httpx.Envelope{"warehouse": warehouse.Code, "stock": stock, "metadata": metadata}
+	if err := httpx.WriteJSON(w, http.StatusOK, env, nil); err != nil {
+		httpx.InternalError(w, r, err)
+	}
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/salesworks/s-works/api/internal/platform/httpx"
+	"github.com/salesworks/s-works/api/internal/warehouses/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockWarehouseQueryRepository struct {
+	listedLimit  int
+	listedOffset int
+}
+
+func (m *mockWarehouseQueryRepository) GetWarehouse(ctx context.Context, code string) (*domain.Warehouse, error) {
+	if code != "WH-LDZ" {
+		return nil, domain.ErrWarehouseNotFound
+	}
+	return &domain.Warehouse{Code: code, Name: "Łódź central", Country: "PL", Version: 1}, nil
+}
+
+func (m *mockWarehouseQueryRepository) ListWarehouses(ctx context.Context, limit, offset int) ([]*domain.Warehouse, int, error) {
+	m.listedLimit, m.listedOffset = limit, offset
+	return []*domain.Warehouse{{Code: "WH-LDZ", Name: "Łódź central", Version: 1}}, 21, nil
+}
+
+func (m *mockWarehouseQueryRepository) ListStock(
+	ctx context.Context, code string, limit, offset int,
+) ([]domain.LocationStock, int, error) {
+	m.listedLimit, m.listedOffset = limit, offset
+	return []domain.LocationStock{{FabricCode: "VELVET01", OnHand: 12500}}, 1, nil
+}
+
+func TestWarehouseQueryHandler_GetWarehouse(t *testing.T) {
+	testCases := []struct {
+		name           string
+		code           string
+		expectedStatus int
+	}{
+		{name: "known warehouse", code: "wh-ldz", expectedStatus: http.StatusOK},
+		{name: "unknown warehouse", code: "NOSUCH", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			handler := NewWarehouseQueryHandler(&mockWarehouseQueryRepository{}, httpx.PaginationConfig{})
+
+			// --- Act ---
+			responseRecorder := serveWarehouse(
+				t, handler, http.MethodGet, "/v1/warehouses/"+tc.code, "", map[string]string{"code": tc.code},
+			)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Warehouse domain.Warehouse `json:"warehouse"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+			assert.Equal(t, "WH-LDZ", body.Warehouse.Code)
+		})
+	}
+}
+
+func TestWarehouseQueryHandler_ListWarehouses(t *testing.T) {
+	// --- Arrange ---
+	repo := &mockWarehouseQueryRepository{}
+	pagination := httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+	handler := NewWarehouseQueryHandler(repo, pagination)
+
+	// --- Act ---
+	responseRecorder := serveWarehouse(t, handler, http.MethodGet, "/v1/warehouses?page=2", "", nil)
+
+	// --- Assert ---
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, 20, repo.listedLimit)
+	assert.Equal(t, 20, repo.listedOffset)
+}
+
+func TestWarehouseQueryHandler_ListStock(t *testing.T) {
+	testCases := []struct {
+		name           string
+		code           string
+		expectedStatus int
+	}{
+		{name: "known warehouse", code: "wh-ldz", expectedStatus: http.StatusOK},
+		{name: "unknown warehouse", code: "NOSUCH", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// --- Arrange ---
+			pagination := httpx.PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+			handler := NewWarehouseQueryHandler(&mockWarehouseQueryRepository{}, pagination)
+
+			// --- Act ---
+			responseRecorder := serveWarehouse(
+				t, handler, http.MethodGet, "/v1/warehouses/"+tc.code+"/stock", "", map[string]string{"code": tc.code},
+			)
+
+			// --- Assert ---
+			require.Equal(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Warehouse string `json:"warehouse"`
+				Stock     []struct {
+					FabricCode string `json:"fabric_code"`
+					OnHand     string `json:"on_hand"`
+				} `json:"stock"`
+			}
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &body))
+			assert.Equal(t, "WH-LDZ", body.Warehouse)
+			require.Len(t, body.Stock, 1)
+			assert.Equal(t, "12.5", body.Stock[0].OnHand)
+		})
+	}
+}
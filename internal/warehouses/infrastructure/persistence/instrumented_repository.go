@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/salesworks/s-works/api/internal/platform/instrument"
+	"github.com/salesworks/s-works/api/internal/warehouses/domain"
+)
+
+// InstrumentedWarehouseRepository traces, times and logs every call to the wrapped repository.
+type InstrumentedWarehouseRepository struct {
+	next domain.WarehouseRepository
+	rec  *instrument.Recorder
+}
+
+func NewInstrumentedWarehouseRepository(
+	next domain.WarehouseRepository, rec *instrument.Recorder,
+) *InstrumentedWarehouseRepository {
+	return &InstrumentedWarehouseRepository{next: next, rec: rec}
+}
+
+func (r *InstrumentedWarehouseRepository) SaveWarehouse(ctx context.Context, warehouse *domain.Warehouse) error {
+	return instrument.Exec(ctx, r.rec, "SaveWarehouse", func(ctx context.Context) error {
+		return r.next.SaveWarehouse(ctx, warehouse)
+	})
+}
+
+func (r *InstrumentedWarehouseRepository) GetWarehouse(ctx context.Context, code string) (*domain.Warehouse, error) {
+	return instrument.Call(ctx, r.rec, "GetWarehouse", func(ctx context.Context) (*domain.Warehouse, error) {
+		return r.next.GetWarehouse(ctx, code)
+	})
+}
+
+func (r *InstrumentedWarehouseRepository) ListWarehouses(
+	ctx context.Context, limit, offset int,
+) ([]*domain.Warehouse, int, error) {
+	var total int
+	warehouses, err := instrument.Call(ctx, r.rec, "ListWarehouses",
+		func(ctx context.Context) ([]*domain.Warehouse, error) {
+			warehouses, count, err := r.next.ListWarehouses(ctx, limit, offset)
+			total = count
+			return warehouses, err
+		})
+	return warehouses, total, err
+}
+
+func (r *InstrumentedWarehouseRepository) UpdateWarehouse(ctx context.Context, warehouse *domain.Warehouse) error {
+	return instrument.Exec(ctx, r.rec, "UpdateWarehouse", func(ctx context.Context) error {
+		return r.next.UpdateWarehouse(ctx, warehouse)
+	})
+}
+
+func (r *InstrumentedWarehouseRepository) HoldsStock(ctx context.Context, code string) (bool, error) {
+	return instrument.Call(ctx, r.rec, "HoldsStock", func(ctx context.Context) (bool, error) {
+		return r.next.HoldsStock(ctx, code)
+	})
+}
+
+func (r *InstrumentedWarehouseRepository) ListStock(
+	ctx context.Context, code string, limit, offset int,
+) ([]domain.LocationStock, int, error) {
+	var total int
+	stock, err := instrument.Call(ctx, r.rec, "ListStock",
+		func(ctx context.Context) ([]domain.LocationStock, error) {
+			stock, count, err := r.next.ListStock(ctx, code, limit, offset)
+			total = count
+			return stock, err
+		})
+	return stock, total, err
+}
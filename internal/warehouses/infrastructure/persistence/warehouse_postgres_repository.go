@@ -0,0 +1,175 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salesworks/s-works/api/internal/platform/database"
+	"github.com/salesworks/s-works/api/internal/warehouses/domain"
+)
+
+const warehouseColumns = `code, name, address, country, version,
+	created_at, created_by, updated_at, updated_by, deleted_at`
+
+type WarehousePostgresRepository struct {
+	db *database.PostgresDB
+}
+
+func NewWarehousePostgresRepository(db *database.PostgresDB) *WarehousePostgresRepository {
+	return &WarehousePostgresRepository{
+		db: db,
+	}
+}
+
+func (r *WarehousePostgresRepository) SaveWarehouse(ctx context.Context, warehouse *domain.Warehouse) error {
+	query := `
+		INSERT INTO warehouses (` + warehouseColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		warehouse.Code, warehouse.Name, warehouse.Address, warehouse.Country, warehouse.Version,
+		warehouse.CreatedAt, warehouse.CreatedBy, warehouse.UpdatedAt, warehouse.UpdatedBy, warehouse.DeletedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrDuplicateWarehouseCode
+		}
+		return fmt.Errorf("failed to insert warehouse: %w", err)
+	}
+	return nil
+}
+
+func (r *WarehousePostgresRepository) GetWarehouse(ctx context.Context, code string) (*domain.Warehouse, error) {
+	query := `SELECT ` + warehouseColumns + ` FROM warehouses WHERE code = $1`
+	warehouse, err := scanWarehouse(r.db.Conn(ctx).QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWarehouseNotFound
+		}
+		return nil, fmt.Errorf("failed to get warehouse: %w", err)
+	}
+	return warehouse, nil
+}
+
+func (r *WarehousePostgresRepository) ListWarehouses(
+	ctx context.Context, limit, offset int,
+) ([]*domain.Warehouse, int, error) {
+	query := `
+		SELECT count(*) OVER(), ` + warehouseColumns + `
+		FROM warehouses
+		WHERE deleted_at IS NULL
+		ORDER BY code
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list warehouses: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	warehouses := []*domain.Warehouse{}
+	for rows.Next() {
+		warehouse := &domain.Warehouse{}
+		err := rows.Scan(append([]any{&totalRecords}, warehouseFields(warehouse)...)...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan warehouse: %w", err)
+		}
+		warehouses = append(warehouses, warehouse)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate warehouses: %w", err)
+	}
+
+	return warehouses, totalRecords, nil
+}
+
+// UpdateWarehouse stores the master data and deletion of the warehouse, provided nobody
+// changed it since it was loaded.
+func (r *WarehousePostgresRepository) UpdateWarehouse(ctx context.Context, warehouse *domain.Warehouse) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE warehouses
+		SET name = $1, address = $2, country = $3,
+			version = $4, updated_at = $5, updated_by = $6, deleted_at = $7
+		WHERE code = $8 AND version = $9
+	`, warehouse.Name, warehouse.Address, warehouse.Country,
+		warehouse.Version, warehouse.UpdatedAt, warehouse.UpdatedBy, warehouse.DeletedAt,
+		warehouse.Code, warehouse.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update warehouse: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConcurrencyConflict
+	}
+	return nil
+}
+
+func (r *WarehousePostgresRepository) HoldsStock(ctx context.Context, code string) (bool, error) {
+	var holdsStock bool
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM fabric_stock_locations WHERE warehouse_code = $1)
+	`, code).Scan(&holdsStock)
+	if err != nil {
+		return false, fmt.Errorf("failed to check stock of warehouse: %w", err)
+	}
+	return holdsStock, nil
+}
+
+func (r *WarehousePostgresRepository) ListStock(
+	ctx context.Context, code string, limit, offset int,
+) ([]domain.LocationStock, int, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT count(*) OVER(), fabric_code, on_hand
+		FROM fabric_stock_locations
+		WHERE warehouse_code = $1
+		ORDER BY fabric_code
+		LIMIT $2 OFFSET $3
+	`, code, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list stock of warehouse: %w", err)
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	stock := []domain.LocationStock{}
+	for rows.Next() {
+		var location domain.LocationStock
+		if err := rows.Scan(&totalRecords, &location.FabricCode, &location.OnHand); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan stock of warehouse: %w", err)
+		}
+		stock = append(stock, location)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate stock of warehouse: %w", err)
+	}
+
+	return stock, totalRecords, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWarehouse(row rowScanner) (*domain.Warehouse, error) {
+	warehouse := &domain.Warehouse{}
+	if err := row.Scan(warehouseFields(warehouse)...); err != nil {
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+// warehouseFields lists the destinations of warehouseColumns, in the same order.
+func warehouseFields(warehouse *domain.Warehouse) []any {
+	return []any{
+		&warehouse.Code, &warehouse.Name, &warehouse.Address, &warehouse.Country, &warehouse.Version,
+		&warehouse.CreatedAt, &warehouse.CreatedBy, &warehouse.UpdatedAt, &warehouse.UpdatedBy, &warehouse.DeletedAt,
+	}
+}
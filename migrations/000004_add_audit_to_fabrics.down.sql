@@ -0,0 +1,4 @@
+ALTER TABLE fabrics DROP COLUMN updated_by;
+ALTER TABLE fabrics DROP COLUMN updated_at;
+ALTER TABLE fabrics DROP COLUMN created_by;
+ALTER TABLE fabrics DROP COLUMN created_at;
@@ -0,0 +1,5 @@
+-- Track when and by whom each fabric was created and last modified.
+ALTER TABLE fabrics ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE fabrics ADD COLUMN created_by VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE fabrics ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE fabrics ADD COLUMN updated_by VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE events DROP CONSTRAINT IF EXISTS unique_aggregate_sequence;
+
+ALTER TABLE events DROP COLUMN sequence;
@@ -0,0 +1,25 @@
+-- Per-aggregate sequence, assigned on insert, used to order events deterministically.
+ALTER TABLE events ADD COLUMN sequence BIGINT;
+
+-- Creation events used to be stored as 'fabric' and the others as 'Fabric'. Bring them under
+-- one aggregate type first, otherwise a fabric would get two histories each starting at 1,
+-- and reads by aggregate type would miss its creation.
+UPDATE events SET aggregate_type = 'Fabric' WHERE aggregate_type = 'fabric';
+
+-- Backfill existing events in their stored order.
+UPDATE events e
+SET sequence = ordered.seq
+FROM (
+    SELECT
+        event_id,
+        ROW_NUMBER() OVER (
+            PARTITION BY aggregate_type, aggregate_id
+            ORDER BY aggregate_version, "timestamp", event_id
+        ) AS seq
+    FROM events
+) ordered
+WHERE e.event_id = ordered.event_id;
+
+ALTER TABLE events ALTER COLUMN sequence SET NOT NULL;
+
+ALTER TABLE events ADD CONSTRAINT unique_aggregate_sequence UNIQUE (aggregate_type, aggregate_id, sequence);
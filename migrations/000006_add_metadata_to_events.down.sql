@@ -0,0 +1,4 @@
+ALTER TABLE events DROP COLUMN schema_url;
+ALTER TABLE events DROP COLUMN source_instance;
+ALTER TABLE events DROP COLUMN source_service;
+ALTER TABLE events DROP COLUMN tenant_id;
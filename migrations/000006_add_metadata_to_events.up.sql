@@ -0,0 +1,5 @@
+-- Optional governance metadata carried by event envelopes.
+ALTER TABLE events ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN source_service VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN source_instance VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN schema_url TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_events_aggregate_type_position;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS unique_event_position;
+ALTER TABLE events DROP COLUMN IF EXISTS position;
@@ -0,0 +1,7 @@
+-- Global, store-wide position used by the change feed. Existing events are numbered in
+-- insertion order; new events get the next value on insert.
+ALTER TABLE events ADD COLUMN position BIGINT GENERATED ALWAYS AS IDENTITY;
+
+ALTER TABLE events ADD CONSTRAINT unique_event_position UNIQUE (position);
+
+CREATE INDEX IF NOT EXISTS idx_events_aggregate_type_position ON events (aggregate_type, position);
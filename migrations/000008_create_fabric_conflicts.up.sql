@@ -0,0 +1,19 @@
+-- ERP changes that hit a version conflict and were queued for manual review.
+CREATE TABLE IF NOT EXISTS fabric_conflicts (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(255) NOT NULL,
+    code VARCHAR(30) NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    measure_unit TEXT NOT NULL DEFAULT '',
+    offer_status TEXT NOT NULL DEFAULT '',
+    expected_version INT NOT NULL,
+    current_version INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMPTZ,
+    resolved_by VARCHAR(255) NOT NULL DEFAULT '',
+    CONSTRAINT unique_conflict_event UNIQUE (event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fabric_conflicts_status ON fabric_conflicts (status, id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS erp_pending_events;
@@ -0,0 +1,15 @@
+-- ERP events received ahead of a missing aggregate version, applied once the gap closes.
+CREATE TABLE IF NOT EXISTS erp_pending_events (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(255) NOT NULL,
+    code VARCHAR(30) NOT NULL,
+    version INT NOT NULL,
+    envelope JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'WAITING',
+    received_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT unique_pending_event UNIQUE (event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_erp_pending_events_code_version ON erp_pending_events (code, version) WHERE status = 'WAITING';
+CREATE INDEX IF NOT EXISTS idx_erp_pending_events_received_at ON erp_pending_events (received_at) WHERE status = 'WAITING';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_erp_pending_events_next_attempt_at;
+ALTER TABLE erp_pending_events DROP COLUMN IF EXISTS next_attempt_at;
+ALTER TABLE erp_pending_events DROP COLUMN IF EXISTS attempts;
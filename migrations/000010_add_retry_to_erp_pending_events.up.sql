@@ -0,0 +1,5 @@
+-- Parked ERP events are retried with backoff until applied or expired.
+ALTER TABLE erp_pending_events ADD COLUMN attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE erp_pending_events ADD COLUMN next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS idx_erp_pending_events_next_attempt_at ON erp_pending_events (next_attempt_at) WHERE status = 'WAITING';
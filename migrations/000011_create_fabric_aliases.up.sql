@@ -0,0 +1,9 @@
+-- Alternate codes that resolve to a canonical fabric, e.g. duplicates merged into it.
+CREATE TABLE IF NOT EXISTS fabric_aliases (
+    alias_code VARCHAR(30) PRIMARY KEY,
+    canonical_code VARCHAR(30) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_fabric_aliases_canonical_code ON fabric_aliases (canonical_code);
@@ -0,0 +1,12 @@
+-- Events waiting to be published to NATS. Rows are written in the same transaction as the
+-- event itself and marked published by the outbox relay.
+CREATE TABLE IF NOT EXISTS event_outbox (
+    event_id UUID PRIMARY KEY REFERENCES events (event_id) ON DELETE CASCADE,
+    subject VARCHAR(255) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_pending ON event_outbox (created_at) WHERE published_at IS NULL;
@@ -0,0 +1,7 @@
+-- Advisory edit locks telling users a fabric is being edited. They expire on their own.
+CREATE TABLE IF NOT EXISTS fabric_edit_locks (
+    code VARCHAR(30) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    acquired_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
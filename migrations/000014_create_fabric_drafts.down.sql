@@ -0,0 +1 @@
+DROP TABLE IF EXISTS fabric_drafts;
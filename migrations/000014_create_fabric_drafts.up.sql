@@ -0,0 +1,16 @@
+-- Prepared changes of fabrics, kept aside until applied to the live fabric or discarded.
+CREATE TABLE IF NOT EXISTS fabric_drafts (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    code VARCHAR(30) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    measure_unit TEXT NOT NULL,
+    offer_status TEXT NOT NULL,
+    base_version INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    resolved_at TIMESTAMPTZ,
+    resolved_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_fabric_drafts_code_status ON fabric_drafts (code, status, id);
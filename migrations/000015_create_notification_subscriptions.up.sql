@@ -0,0 +1,14 @@
+-- Digest email subscriptions of users and roles. last_position is the global event
+-- position up to which the subscriber has been sent a digest.
+CREATE TABLE IF NOT EXISTS notification_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    subscriber_kind VARCHAR(10) NOT NULL,
+    subscriber VARCHAR(255) NOT NULL,
+    email VARCHAR(320) NOT NULL,
+    topics JSONB NOT NULL,
+    last_position BIGINT NOT NULL DEFAULT 0,
+    last_sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    UNIQUE (subscriber_kind, subscriber)
+);
@@ -0,0 +1,10 @@
+-- Chat webhooks (Slack, Teams) receiving alerts on critical catalog events.
+CREATE TABLE IF NOT EXISTS notification_webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    kind VARCHAR(10) NOT NULL,
+    url TEXT NOT NULL,
+    alert_types JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL
+);
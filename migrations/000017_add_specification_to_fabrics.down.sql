@@ -0,0 +1,4 @@
+ALTER TABLE fabrics DROP COLUMN color;
+ALTER TABLE fabrics DROP COLUMN weight_gsm;
+ALTER TABLE fabrics DROP COLUMN width_cm;
+ALTER TABLE fabrics DROP COLUMN composition;
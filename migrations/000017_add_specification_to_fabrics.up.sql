@@ -0,0 +1,5 @@
+-- Structured attributes describing what a fabric is made of, all optional.
+ALTER TABLE fabrics ADD COLUMN composition JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE fabrics ADD COLUMN width_cm INT CHECK (width_cm > 0);
+ALTER TABLE fabrics ADD COLUMN weight_gsm INT CHECK (weight_gsm > 0);
+ALTER TABLE fabrics ADD COLUMN color VARCHAR(50) NOT NULL DEFAULT '';
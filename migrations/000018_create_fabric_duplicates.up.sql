@@ -0,0 +1,15 @@
+-- Pairs of fabrics flagged as probable duplicates, queued until merged or dismissed.
+CREATE TABLE IF NOT EXISTS fabric_duplicates (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    code VARCHAR(30) NOT NULL,
+    canonical_code VARCHAR(30) NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    name_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMPTZ,
+    resolved_by VARCHAR(255) NOT NULL DEFAULT '',
+    CONSTRAINT unique_duplicate_pair UNIQUE (code, canonical_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fabric_duplicates_status ON fabric_duplicates (status, id);
@@ -0,0 +1,3 @@
+ALTER TABLE fabrics DROP COLUMN price_valid_from;
+ALTER TABLE fabrics DROP COLUMN currency;
+ALTER TABLE fabrics DROP COLUMN list_price;
@@ -0,0 +1,4 @@
+-- List price of a fabric in the minor unit of its currency, unset until first priced.
+ALTER TABLE fabrics ADD COLUMN list_price BIGINT CHECK (list_price >= 0);
+ALTER TABLE fabrics ADD COLUMN currency CHAR(3);
+ALTER TABLE fabrics ADD COLUMN price_valid_from TIMESTAMPTZ;
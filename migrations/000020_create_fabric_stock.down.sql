@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS fabric_stock;
+
+ALTER TABLE fabrics DROP CONSTRAINT IF EXISTS fabrics_code_key;
@@ -0,0 +1,14 @@
+-- A fabric keeps its row once deleted or merged and is reactivated in place, so its code is
+-- unique across all rows and can be referenced by the tables keyed by the fabric code.
+ALTER TABLE fabrics ADD CONSTRAINT fabrics_code_key UNIQUE (code);
+
+-- Stock on hand of a fabric and the part of it reserved, versioned apart from the fabric.
+CREATE TABLE IF NOT EXISTS fabric_stock (
+    code VARCHAR(30) PRIMARY KEY REFERENCES fabrics (code),
+    on_hand BIGINT NOT NULL DEFAULT 0,
+    reserved BIGINT NOT NULL DEFAULT 0,
+    version INT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    CONSTRAINT reserved_within_on_hand CHECK (reserved >= 0 AND reserved <= on_hand)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS category_fabrics;
+DROP TABLE IF EXISTS categories;
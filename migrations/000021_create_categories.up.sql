@@ -0,0 +1,24 @@
+-- Hierarchical fabric taxonomy, a category without a parent is a root.
+CREATE TABLE IF NOT EXISTS categories (
+    code VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(250) NOT NULL,
+    parent_code VARCHAR(30) REFERENCES categories (code),
+    version INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_code ON categories (parent_code);
+
+-- Fabrics assigned to categories, under the canonical fabric code.
+CREATE TABLE IF NOT EXISTS category_fabrics (
+    category_code VARCHAR(30) NOT NULL REFERENCES categories (code) ON DELETE CASCADE,
+    fabric_code VARCHAR(30) NOT NULL REFERENCES fabrics (code),
+    assigned_at TIMESTAMPTZ NOT NULL,
+    assigned_by VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (category_code, fabric_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_fabrics_fabric_code ON category_fabrics (fabric_code);
@@ -0,0 +1,14 @@
+-- Images and spec sheets attached to a fabric, the files themselves live in blob storage.
+CREATE TABLE IF NOT EXISTS fabric_attachments (
+    id UUID PRIMARY KEY,
+    fabric_code VARCHAR(30) NOT NULL REFERENCES fabrics (code),
+    kind VARCHAR(20) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(300) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_fabric_attachments_fabric_code ON fabric_attachments (fabric_code, created_at);
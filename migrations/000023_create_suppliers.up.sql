@@ -0,0 +1,28 @@
+-- Suppliers delivering fabrics.
+CREATE TABLE IF NOT EXISTS suppliers (
+    code VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(250) NOT NULL,
+    contact_email VARCHAR(255) NOT NULL DEFAULT '',
+    version INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+-- Fabrics delivered by suppliers, under the canonical fabric code, with the terms of each supplier.
+CREATE TABLE IF NOT EXISTS supplier_fabrics (
+    supplier_code VARCHAR(30) NOT NULL REFERENCES suppliers (code) ON DELETE CASCADE,
+    fabric_code VARCHAR(30) NOT NULL REFERENCES fabrics (code),
+    lead_time_days INT NOT NULL CHECK (lead_time_days >= 0),
+    article_number VARCHAR(64) NOT NULL DEFAULT '',
+    linked_at TIMESTAMPTZ NOT NULL,
+    linked_by VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (supplier_code, fabric_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_supplier_fabrics_fabric_code ON supplier_fabrics (fabric_code);
+
+-- A supplier article number identifies a single fabric of that supplier.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_supplier_fabrics_article_number
+    ON supplier_fabrics (supplier_code, article_number) WHERE article_number <> '';
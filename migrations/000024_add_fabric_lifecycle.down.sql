@@ -0,0 +1,7 @@
+ALTER TABLE fabrics DROP CONSTRAINT IF EXISTS fabrics_status_check;
+
+-- Fabrics in a lifecycle status unknown before are brought back as active.
+UPDATE fabrics SET status = 'ACTIVE' WHERE status IN ('DRAFT', 'DISCONTINUED', 'ARCHIVED');
+
+DROP INDEX IF EXISTS fabrics_live_code_idx;
+CREATE UNIQUE INDEX fabrics_active_code_idx ON fabrics (code) WHERE (status = 'ACTIVE');
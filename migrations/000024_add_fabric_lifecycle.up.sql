@@ -0,0 +1,6 @@
+-- Drafts, discontinued and archived fabrics keep their code taken like active ones do.
+DROP INDEX IF EXISTS fabrics_active_code_idx;
+CREATE UNIQUE INDEX fabrics_live_code_idx ON fabrics (code) WHERE (status NOT IN ('DELETED', 'MERGED'));
+
+ALTER TABLE fabrics ADD CONSTRAINT fabrics_status_check
+    CHECK (status IN ('DRAFT', 'ACTIVE', 'DISCONTINUED', 'ARCHIVED', 'DELETED', 'MERGED'));
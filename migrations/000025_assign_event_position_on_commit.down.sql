@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS events_assign_position ON events;
+DROP FUNCTION IF EXISTS assign_event_position();
+
+DROP SEQUENCE IF EXISTS events_position_seq;
+
+ALTER TABLE events ALTER COLUMN position SET NOT NULL;
+ALTER TABLE events ALTER COLUMN position ADD GENERATED ALWAYS AS IDENTITY;
+SELECT setval(pg_get_serial_sequence('events', 'position'), MAX(position)) FROM events HAVING COUNT(*) > 0;
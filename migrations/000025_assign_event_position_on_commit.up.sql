@@ -0,0 +1,25 @@
+-- Positions were taken from an identity column under a global lock held by the appending
+-- transaction until it committed, so every command waited for every other one. They are
+-- now assigned by a deferred trigger as the transaction commits, under the same lock, so
+-- positions still become visible in order while the lock is held for the commit only.
+ALTER TABLE events ALTER COLUMN position DROP IDENTITY;
+ALTER TABLE events ALTER COLUMN position DROP NOT NULL;
+
+CREATE SEQUENCE events_position_seq OWNED BY events.position;
+SELECT setval('events_position_seq', COALESCE((SELECT MAX(position) FROM events), 0) + 1, false);
+
+-- 111559182283891 is "events" in ASCII, the append lock key of the event store
+CREATE FUNCTION assign_event_position() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(111559182283891);
+    UPDATE events SET position = nextval('events_position_seq')
+    WHERE event_id = NEW.event_id AND position IS NULL;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER events_assign_position
+    AFTER INSERT ON events
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW WHEN (NEW.position IS NULL)
+    EXECUTE FUNCTION assign_event_position();
@@ -0,0 +1,6 @@
+-- Stock events share the fabric code as aggregate ID, so their versions overlap those of
+-- the fabric and the uniqueness across types cannot come back while they are kept. It is
+-- restored for every other type, and the stock events are left as they are.
+ALTER TABLE events DROP CONSTRAINT unique_aggregate_version;
+CREATE UNIQUE INDEX unique_aggregate_version ON events (aggregate_id, aggregate_version)
+    WHERE aggregate_type <> 'FabricStock';
@@ -0,0 +1,5 @@
+-- Stock events share the fabric code as aggregate ID, so versions are unique per type.
+-- A rollback leaves the uniqueness as an index rather than a constraint.
+ALTER TABLE events DROP CONSTRAINT IF EXISTS unique_aggregate_version;
+DROP INDEX IF EXISTS unique_aggregate_version;
+ALTER TABLE events ADD CONSTRAINT unique_aggregate_version UNIQUE (aggregate_type, aggregate_id, aggregate_version);
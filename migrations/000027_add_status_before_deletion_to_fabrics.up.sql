@@ -0,0 +1,3 @@
+-- Lifecycle status a deleted fabric had, so a restore brings it back in it. Fabrics deleted
+-- before it was kept are restored as active.
+ALTER TABLE fabrics ADD COLUMN status_before_deletion VARCHAR(20);
@@ -0,0 +1,15 @@
+ALTER TABLE supplier_fabrics DROP CONSTRAINT supplier_fabrics_fabric_code_fkey;
+ALTER TABLE supplier_fabrics ADD CONSTRAINT supplier_fabrics_fabric_code_fkey
+    FOREIGN KEY (fabric_code) REFERENCES fabrics (code);
+
+ALTER TABLE fabric_attachments DROP CONSTRAINT fabric_attachments_fabric_code_fkey;
+ALTER TABLE fabric_attachments ADD CONSTRAINT fabric_attachments_fabric_code_fkey
+    FOREIGN KEY (fabric_code) REFERENCES fabrics (code);
+
+ALTER TABLE category_fabrics DROP CONSTRAINT category_fabrics_fabric_code_fkey;
+ALTER TABLE category_fabrics ADD CONSTRAINT category_fabrics_fabric_code_fkey
+    FOREIGN KEY (fabric_code) REFERENCES fabrics (code);
+
+ALTER TABLE fabric_stock DROP CONSTRAINT fabric_stock_code_fkey;
+ALTER TABLE fabric_stock ADD CONSTRAINT fabric_stock_code_fkey
+    FOREIGN KEY (code) REFERENCES fabrics (code);
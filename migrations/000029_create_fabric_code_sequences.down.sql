@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS fabric_code_reservations;
+DROP TABLE IF EXISTS fabric_code_sequences;
@@ -0,0 +1,13 @@
+-- Next number of each prefix fabric codes are allocated from for articles created in the UI.
+CREATE TABLE IF NOT EXISTS fabric_code_sequences (
+    prefix VARCHAR(30) PRIMARY KEY,
+    next_value BIGINT NOT NULL DEFAULT 1
+);
+
+-- Codes handed out by a sequence, kept so that none is handed out twice.
+CREATE TABLE IF NOT EXISTS fabric_code_reservations (
+    code VARCHAR(30) PRIMARY KEY,
+    prefix VARCHAR(30) NOT NULL,
+    reserved_by VARCHAR(255) NOT NULL DEFAULT '',
+    reserved_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
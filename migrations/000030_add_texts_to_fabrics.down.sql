@@ -0,0 +1,2 @@
+ALTER TABLE fabrics DROP COLUMN notes;
+ALTER TABLE fabrics DROP COLUMN description;
@@ -0,0 +1,3 @@
+-- Long-form texts of a fabric: a markdown description shown to customers and internal notes.
+ALTER TABLE fabrics ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE fabrics ADD COLUMN notes TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,15 @@
+-- Tokens suppliers use to read and propose changes to their fabrics through the portal.
+-- Only a hash of the secret is kept; scopes are separated by spaces.
+CREATE TABLE IF NOT EXISTS supplier_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    supplier_code VARCHAR(30) NOT NULL REFERENCES suppliers (code) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    revoked_at TIMESTAMPTZ,
+    revoked_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_supplier_tokens_supplier_code ON supplier_tokens (supplier_code);
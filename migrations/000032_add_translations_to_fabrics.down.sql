@@ -0,0 +1 @@
+ALTER TABLE fabrics DROP COLUMN translations;
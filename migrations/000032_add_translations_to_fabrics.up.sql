@@ -0,0 +1,2 @@
+-- Names and descriptions of fabrics in other locales, keyed by language tag.
+ALTER TABLE fabrics ADD COLUMN translations JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,23 @@
+-- Changes of fabrics proposed by suppliers or held back from the ERP, queued until a
+-- reviewer approves or rejects them. Changes holds the diff against the base version.
+CREATE TABLE IF NOT EXISTS fabric_proposals (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    code VARCHAR(30) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    event_id VARCHAR(255),
+    name VARCHAR(255) NOT NULL,
+    measure_unit TEXT NOT NULL,
+    offer_status TEXT NOT NULL,
+    changes JSONB NOT NULL DEFAULT '[]',
+    base_version INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    resolved_at TIMESTAMPTZ,
+    resolved_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fabric_proposals_event_id ON fabric_proposals (event_id)
+    WHERE event_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_fabric_proposals_status ON fabric_proposals (status, id);
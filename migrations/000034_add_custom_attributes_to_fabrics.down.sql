@@ -0,0 +1,2 @@
+ALTER TABLE fabrics DROP COLUMN custom_attributes;
+DROP TABLE IF EXISTS fabric_attribute_definitions;
@@ -0,0 +1,14 @@
+-- Custom attributes integrators attach to fabrics, with the type and presence each
+-- registered attribute requires. The values of a fabric are keyed by attribute.
+CREATE TABLE IF NOT EXISTS fabric_attribute_definitions (
+    key VARCHAR(50) PRIMARY KEY,
+    type VARCHAR(20) NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+ALTER TABLE fabrics ADD COLUMN custom_attributes JSONB NOT NULL DEFAULT '{}';
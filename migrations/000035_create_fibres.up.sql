@@ -0,0 +1,32 @@
+-- The reference list of fibres the composition of a fabric may name. Fibres are compared
+-- regardless of case, so codes are kept in lowercase.
+CREATE TABLE IF NOT EXISTS fibres (
+    code VARCHAR(100) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+INSERT INTO fibres (code, name) VALUES
+    ('acrylic', 'Acrylic'),
+    ('cashmere', 'Cashmere'),
+    ('cotton', 'Cotton'),
+    ('elastane', 'Elastane'),
+    ('hemp', 'Hemp'),
+    ('linen', 'Linen'),
+    ('lyocell', 'Lyocell'),
+    ('modal', 'Modal'),
+    ('nylon', 'Nylon'),
+    ('polyamide', 'Polyamide'),
+    ('polyester', 'Polyester'),
+    ('silk', 'Silk'),
+    ('viscose', 'Viscose'),
+    ('wool', 'Wool')
+ON CONFLICT (code) DO NOTHING;
+
+-- keep the compositions already stored valid, whatever fibres they name
+INSERT INTO fibres (code, name)
+SELECT DISTINCT lower(part->>'fibre'), part->>'fibre'
+FROM fabrics, jsonb_array_elements(fabrics.composition) part
+WHERE part->>'fibre' <> ''
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,19 @@
+-- Certificates a fabric holds under a certification scheme such as OEKO-TEX or GOTS, valid
+-- from valid_from until valid_until. expired_at is set once the expiry has been recorded.
+CREATE TABLE IF NOT EXISTS fabric_certifications (
+    id UUID PRIMARY KEY,
+    fabric_code VARCHAR(30) NOT NULL REFERENCES fabrics (code) ON UPDATE CASCADE,
+    scheme VARCHAR(20) NOT NULL,
+    certificate_number VARCHAR(100) NOT NULL,
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_until TIMESTAMPTZ NOT NULL CHECK (valid_until > valid_from),
+    expired_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_fabric_certifications_fabric_code
+    ON fabric_certifications (fabric_code, scheme, valid_until);
+-- certifications whose expiry is still to be recorded
+CREATE INDEX IF NOT EXISTS idx_fabric_certifications_unexpired
+    ON fabric_certifications (valid_until) WHERE expired_at IS NULL;
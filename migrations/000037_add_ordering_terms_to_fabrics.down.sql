@@ -0,0 +1,2 @@
+ALTER TABLE fabrics DROP COLUMN lead_time_days;
+ALTER TABLE fabrics DROP COLUMN min_order_quantity;
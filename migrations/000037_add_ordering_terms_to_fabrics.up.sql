@@ -0,0 +1,5 @@
+-- The terms a fabric is ordered on, zero when the fabric has no minimum or lead time.
+ALTER TABLE fabrics ADD COLUMN min_order_quantity INT NOT NULL DEFAULT 0
+    CHECK (min_order_quantity BETWEEN 0 AND 100000);
+ALTER TABLE fabrics ADD COLUMN lead_time_days INT NOT NULL DEFAULT 0
+    CHECK (lead_time_days BETWEEN 0 AND 365);
@@ -0,0 +1,10 @@
+-- Barcodes printed on the bolts of a fabric, EAN-8, EAN-13 or GTIN-14. A barcode resolves
+-- to a single fabric, whichever fabric holds it.
+CREATE TABLE IF NOT EXISTS fabric_barcodes (
+    barcode VARCHAR(14) PRIMARY KEY,
+    fabric_code VARCHAR(30) NOT NULL REFERENCES fabrics (code) ON UPDATE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_fabric_barcodes_fabric_code ON fabric_barcodes (fabric_code);
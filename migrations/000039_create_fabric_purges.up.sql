@@ -0,0 +1,10 @@
+-- Codes of fabrics purged for good, together with the aliases they had. A purged code is
+-- never used again, downstream systems dropped the fabric on its purge.
+CREATE TABLE IF NOT EXISTS fabric_purges (
+    code VARCHAR(30) PRIMARY KEY,
+    fabric_code VARCHAR(30) NOT NULL,
+    version INT NOT NULL,
+    events_purged BOOLEAN NOT NULL DEFAULT FALSE,
+    purged_at TIMESTAMPTZ NOT NULL,
+    purged_by VARCHAR(255) NOT NULL DEFAULT ''
+);
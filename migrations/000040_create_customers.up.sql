@@ -0,0 +1,18 @@
+-- Customers buying fabrics, mirrored from the ERP and maintained through the API.
+CREATE TABLE IF NOT EXISTS customers (
+    code VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(250) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    tax_id VARCHAR(50) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL DEFAULT '',
+    version INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    deleted_at TIMESTAMPTZ
+);
+
+-- Deleted customers are kept to be restored, listings only show the others.
+CREATE INDEX IF NOT EXISTS idx_customers_active ON customers (code) WHERE deleted_at IS NULL;
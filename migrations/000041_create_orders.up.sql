@@ -0,0 +1,29 @@
+-- Orders of fabrics placed by customers.
+CREATE TABLE IF NOT EXISTS orders (
+    id UUID PRIMARY KEY,
+    customer_code VARCHAR(30) NOT NULL REFERENCES customers (code),
+    currency CHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('DRAFT', 'CONFIRMED', 'SHIPPED', 'CANCELLED')),
+    version INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_customer_code ON orders (customer_code);
+CREATE INDEX IF NOT EXISTS idx_orders_status ON orders (status);
+
+-- Lines of an order, the quantity in thousandths of the fabric measure unit and the unit
+-- price in the minor unit of the order currency.
+CREATE TABLE IF NOT EXISTS order_lines (
+    order_id UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    fabric_code VARCHAR(30) NOT NULL REFERENCES fabrics (code),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    unit_price BIGINT NOT NULL CHECK (unit_price >= 0),
+    PRIMARY KEY (order_id, position),
+    UNIQUE (order_id, fabric_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_lines_fabric_code ON order_lines (fabric_code);
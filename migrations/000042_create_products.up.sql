@@ -0,0 +1,28 @@
+-- Finished goods sold to customers.
+CREATE TABLE IF NOT EXISTS products (
+    code VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(250) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    version INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    deleted_at TIMESTAMPTZ
+);
+
+-- Deleted products are kept to be restored, listings only show the others.
+CREATE INDEX IF NOT EXISTS idx_products_active ON products (code) WHERE deleted_at IS NULL;
+
+-- Bill of materials of a product, the quantity in thousandths of the fabric measure unit
+-- going into one unit of the product.
+CREATE TABLE IF NOT EXISTS product_components (
+    product_code VARCHAR(30) NOT NULL REFERENCES products (code) ON DELETE CASCADE,
+    position INT NOT NULL,
+    fabric_code VARCHAR(30) NOT NULL REFERENCES fabrics (code),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (product_code, position),
+    UNIQUE (product_code, fabric_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_components_fabric_code ON product_components (fabric_code);
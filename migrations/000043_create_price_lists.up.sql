@@ -0,0 +1,34 @@
+-- Price lists of fabrics per market and, optionally, customer group. A list applies from
+-- valid_from until, but not including, valid_until, or indefinitely without one, once it
+-- is published.
+CREATE TABLE IF NOT EXISTS price_lists (
+    code VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(250) NOT NULL,
+    market VARCHAR(30) NOT NULL,
+    customer_group VARCHAR(30) NOT NULL DEFAULT '',
+    currency CHAR(3) NOT NULL,
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_until TIMESTAMPTZ CHECK (valid_until > valid_from),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('DRAFT', 'PUBLISHED')),
+    published_at TIMESTAMPTZ,
+    version INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+-- Prices are resolved against the published lists of a market only.
+CREATE INDEX IF NOT EXISTS idx_price_lists_published
+    ON price_lists (market, customer_group, valid_from) WHERE status = 'PUBLISHED';
+
+-- Prices of a list, the unit price in the minor unit of the list currency.
+CREATE TABLE IF NOT EXISTS price_list_prices (
+    price_list_code VARCHAR(30) NOT NULL REFERENCES price_lists (code) ON DELETE CASCADE,
+    position INT NOT NULL,
+    fabric_code VARCHAR(30) NOT NULL REFERENCES fabrics (code),
+    unit_price BIGINT NOT NULL CHECK (unit_price >= 0),
+    PRIMARY KEY (price_list_code, fabric_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_list_prices_fabric_code ON price_list_prices (fabric_code);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS fabric_stock_locations;
+DROP TABLE IF EXISTS warehouses;
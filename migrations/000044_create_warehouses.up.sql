@@ -0,0 +1,29 @@
+-- Sites fabrics are stocked at.
+CREATE TABLE IF NOT EXISTS warehouses (
+    code VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(250) NOT NULL,
+    address VARCHAR(500) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL DEFAULT '',
+    version INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    deleted_at TIMESTAMPTZ
+);
+
+-- Deleted warehouses are kept to be restored, listings only show the others.
+CREATE INDEX IF NOT EXISTS idx_warehouses_active ON warehouses (code) WHERE deleted_at IS NULL;
+
+-- Part of the stock on hand of a fabric attributed to a warehouse. The rows belong to the
+-- stock of the fabric: they follow it when the fabric is renamed and go when it is purged.
+-- Stock adjusted without a warehouse is on hand at no site in particular.
+CREATE TABLE IF NOT EXISTS fabric_stock_locations (
+    fabric_code VARCHAR(30) NOT NULL REFERENCES fabric_stock (code) ON UPDATE CASCADE ON DELETE CASCADE,
+    warehouse_code VARCHAR(30) NOT NULL REFERENCES warehouses (code),
+    on_hand BIGINT NOT NULL CHECK (on_hand > 0),
+    PRIMARY KEY (fabric_code, warehouse_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fabric_stock_locations_warehouse_code
+    ON fabric_stock_locations (warehouse_code, fabric_code);
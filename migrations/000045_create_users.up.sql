@@ -0,0 +1,16 @@
+-- Users signed up in Clerk, mirrored from its webhooks under the ids Clerk gave them.
+-- Deleted users are kept, so the changes they made can still be attributed.
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(255) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    first_name VARCHAR(255) NOT NULL DEFAULT '',
+    last_name VARCHAR(255) NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    profile_changed_at TIMESTAMPTZ NOT NULL,
+    version INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    deleted_at TIMESTAMPTZ
+);
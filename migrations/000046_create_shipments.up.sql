@@ -0,0 +1,27 @@
+-- Shipments of orders as the ERP reports them, a read model kept from its events. The
+-- version is the one of the shipment in the ERP, deleted shipments are kept so late events
+-- do not bring them back.
+CREATE TABLE IF NOT EXISTS shipments (
+    id VARCHAR(50) PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders (id),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('PENDING', 'IN_TRANSIT', 'DELIVERED', 'RETURNED')),
+    carrier VARCHAR(100) NOT NULL DEFAULT '',
+    tracking_number VARCHAR(100) NOT NULL DEFAULT '',
+    shipped_at TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ,
+    version INT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipments_order_id ON shipments (order_id) WHERE deleted_at IS NULL;
+
+-- Lines of a shipment, the quantity in thousandths of the fabric measure unit. The fabric
+-- code is the one the ERP sent, it is not checked against the catalog.
+CREATE TABLE IF NOT EXISTS shipment_lines (
+    shipment_id VARCHAR(50) NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    fabric_code VARCHAR(30) NOT NULL,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (shipment_id, position)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_fabrics_color;
+DROP TABLE IF EXISTS colors;
@@ -0,0 +1,37 @@
+-- Palette of colors fabrics are made in, fabrics reference a color by its code.
+CREATE TABLE IF NOT EXISTS colors (
+    code VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    hex CHAR(7) NOT NULL DEFAULT '',
+    version INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    deleted_at TIMESTAMPTZ
+);
+
+-- Deleted colors are kept to be restored, listings only show the others.
+CREATE INDEX IF NOT EXISTS idx_colors_active ON colors (code) WHERE deleted_at IS NULL;
+
+-- The free-text colors fabrics were given so far become colors of the palette, coded from
+-- their text, and the fabrics take their code. Their hex is left to be set.
+CREATE TEMPORARY TABLE color_codes AS
+SELECT color AS text,
+       left(btrim(upper(regexp_replace(color, '[^A-Za-z0-9]+', '-', 'g')), '-'), 30) AS code
+FROM (SELECT DISTINCT color FROM fabrics WHERE btrim(color) <> '') AS colors_in_use;
+
+INSERT INTO colors (code, name, version, created_at, created_by, updated_at, updated_by)
+SELECT DISTINCT ON (code) code, left(btrim(text), 100), 1, now(), 'migration', now(), 'migration'
+FROM color_codes
+WHERE code <> ''
+ORDER BY code, text;
+
+UPDATE fabrics f SET color = c.code
+FROM color_codes c
+WHERE f.color = c.text;
+
+DROP TABLE color_codes;
+
+-- Fabrics look up whether a color is in use before it is deleted.
+CREATE INDEX IF NOT EXISTS idx_fabrics_color ON fabrics (color) WHERE color <> '';
@@ -0,0 +1,11 @@
+-- Daily exchange rates: the price of one unit of a currency in PLN, in hundred-millionths.
+-- Rates come from the ERP or are fetched from the NBP, the last received for a currency
+-- and day replaces the earlier one.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    currency CHAR(3) NOT NULL,
+    day DATE NOT NULL,
+    rate BIGINT NOT NULL CHECK (rate > 0),
+    source VARCHAR(20) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (currency, day)
+);
@@ -0,0 +1,17 @@
+-- Quantities of a fabric set aside for a quote or an order, by reference. Their sum is
+-- part of fabric_stock.reserved; the rest of it was reserved before reservations were
+-- tracked. The rows belong to the stock of the fabric: they follow it when the fabric is
+-- renamed and go when it is purged.
+CREATE TABLE IF NOT EXISTS fabric_stock_reservations (
+    fabric_code VARCHAR(30) NOT NULL REFERENCES fabric_stock (code) ON UPDATE CASCADE ON DELETE CASCADE,
+    reference VARCHAR(100) NOT NULL,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    expires_at TIMESTAMPTZ,
+    reserved_at TIMESTAMPTZ NOT NULL,
+    reserved_by VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (fabric_code, reference)
+);
+
+-- The expiry sweep looks for the reservations whose expiry has passed.
+CREATE INDEX IF NOT EXISTS idx_fabric_stock_reservations_expires_at
+    ON fabric_stock_reservations (expires_at) WHERE expires_at IS NOT NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_fabrics_name_trgm;
+DROP INDEX IF EXISTS idx_fabrics_search_vector;
+ALTER TABLE fabrics DROP COLUMN IF EXISTS search_vector;
+DROP EXTENSION IF EXISTS pg_trgm;
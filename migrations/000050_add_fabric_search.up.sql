@@ -0,0 +1,12 @@
+-- Full-text search over fabrics: the words of the code and name weigh more than those of
+-- the description. Trigrams of the name find fabrics whose name is misspelled in a search.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE fabrics ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(code, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', description), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_fabrics_search_vector ON fabrics USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_fabrics_name_trgm ON fabrics USING GIN (name gin_trgm_ops);